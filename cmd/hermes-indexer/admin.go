@@ -0,0 +1,269 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/pkg/indexer/consumer"
+	"github.com/hashicorp-forge/hermes/pkg/indexer/ruleset"
+	"github.com/hashicorp-forge/hermes/pkg/kafka"
+	"github.com/hashicorp-forge/hermes/pkg/kafka/producer"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+// adminCommands are the hermes-indexer subcommands used to debug rulesets and
+// pipelines. Running hermes-indexer without a subcommand starts the consumer.
+var adminCommands = map[string]func(args []string) int{
+	"test-ruleset": runTestRuleset,
+	"emit":         runEmit,
+}
+
+// runTestRuleset implements "hermes-indexer test-ruleset", which reports the
+// rulesets and pipelines a document revision event would trigger without
+// executing them.
+func runTestRuleset(args []string) int {
+	flags := flag.NewFlagSet("test-ruleset", flag.ContinueOnError)
+	configPath := flags.String("config", "config.hcl", "Path to configuration file")
+	eventPath := flags.String("event", "",
+		`(Required) Path to a document revision event JSON file, or "-" for stdin`)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: hermes-indexer test-ruleset -event event.json [options]\n\n"+
+			"  Evaluates the configured rulesets against a document revision event and\n"+
+			"  reports which rulesets match and which pipeline steps would run. No\n"+
+			"  pipeline steps are executed.\n\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *eventPath == "" {
+		fmt.Fprintln(os.Stderr, "error: -event is required")
+		return 2
+	}
+
+	cfg, err := loadConfig(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		return 1
+	}
+	if cfg.Indexer == nil {
+		fmt.Fprintln(os.Stderr, "error: config file has no indexer block")
+		return 1
+	}
+
+	data, err := readEventFile(*eventPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		return 1
+	}
+	event, revision, metadata, err := consumer.ParseEvent(data)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		return 1
+	}
+
+	rulesets := ruleset.Rulesets(convertRulesets(cfg.Indexer.Rulesets))
+	if err := rulesets.ValidateAll(); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: invalid rulesets: %v\n", err)
+	}
+
+	fmt.Printf("Event %s for document %s (revision %d)\n\n",
+		event.EventType, event.DocumentUUID, revision.ID)
+
+	var matched int
+	var steps []string
+	seen := make(map[string]bool)
+	for _, rs := range rulesets {
+		if rs.Matches(revision, metadata) {
+			matched++
+			fmt.Printf("MATCH  %s\n", rs.Name)
+			fmt.Printf("       pipeline: %s\n", strings.Join(rs.Pipeline, ", "))
+			for _, step := range rs.Pipeline {
+				if !seen[step] {
+					seen[step] = true
+					steps = append(steps, step)
+				}
+			}
+		} else {
+			fmt.Printf("SKIP   %s\n", rs.Name)
+		}
+		for _, c := range rs.Evaluate(revision, metadata) {
+			result := "ok"
+			if !c.Matched {
+				result = "failed"
+			}
+			fmt.Printf("       %s = %q (actual: %v): %s\n",
+				c.Key, c.Expected, c.Actual, result)
+		}
+	}
+
+	fmt.Printf("\n%d of %d rulesets matched", matched, len(rulesets))
+	if len(steps) > 0 {
+		fmt.Printf("; steps: %s", strings.Join(steps, ", "))
+	}
+	fmt.Println()
+
+	return 0
+}
+
+// runEmit implements "hermes-indexer emit", which publishes a synthetic
+// document revision event to the indexer topic.
+func runEmit(args []string) int {
+	flags := flag.NewFlagSet("emit", flag.ContinueOnError)
+	configPath := flags.String("config", "config.hcl", "Path to configuration file")
+	eventPath := flags.String("event", "",
+		`Path to a document revision event JSON file to publish, or "-" for stdin. `+
+			"Overrides the document flags.")
+	docUUID := flags.String("doc-uuid", "", "UUID of the document")
+	docID := flags.String("doc-id", "", "Provider ID of the document")
+	providerType := flags.String("provider-type", "google", "Provider type of the document")
+	eventType := flags.String("event-type", models.RevisionEventUpdated, "Event type")
+	title := flags.String("title", "", "Title of the revision")
+	status := flags.String("status", "active", "Status of the revision")
+	contentHash := flags.String("content-hash", "", "Content hash of the revision")
+	revisionID := flags.Uint("revision-id", 0, "ID of the revision")
+	dryRun := flags.Bool("dry-run", false, "Print the event instead of publishing it")
+	laneName := flags.String("lane", "",
+		"Name of the indexer lane to publish the event to (default: the indexer topic)")
+	metadata := make(map[string]interface{})
+	flags.Func("metadata", "Metadata `key=value` pair (may be repeated)",
+		func(s string) error {
+			k, v, ok := strings.Cut(s, "=")
+			if !ok || k == "" {
+				return fmt.Errorf("metadata must be in key=value format")
+			}
+			metadata[k] = v
+			return nil
+		})
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: hermes-indexer emit -doc-uuid UUID [options]\n\n"+
+			"  Publishes a synthetic document revision event to the indexer topic, for\n"+
+			"  debugging rulesets and pipelines without touching production producers.\n"+
+			"  Synthetic events have no outbox ID and carry a \"synthetic\" header.\n\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	var event *consumer.DocumentRevisionEvent
+	if *eventPath != "" {
+		data, err := readEventFile(*eventPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			return 1
+		}
+		event, _, _, err = consumer.ParseEvent(data)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			return 1
+		}
+	} else {
+		if *docUUID == "" {
+			fmt.Fprintln(os.Stderr, "error: -doc-uuid or -event is required")
+			return 2
+		}
+		id, err := uuid.Parse(*docUUID)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: invalid document UUID: %v\n", err)
+			return 2
+		}
+		if len(metadata) == 0 {
+			metadata = nil
+		}
+		event = consumer.NewEvent(&models.DocumentRevision{
+			ID:           *revisionID,
+			DocumentUUID: id,
+			DocumentID:   *docID,
+			ProviderType: *providerType,
+			Title:        *title,
+			Status:       *status,
+			ContentHash:  *contentHash,
+		}, *eventType, metadata)
+	}
+
+	value, err := json.Marshal(event)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error encoding event: %v\n", err)
+		return 1
+	}
+	if *dryRun {
+		fmt.Println(string(value))
+		return 0
+	}
+
+	cfg, err := loadConfig(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		return 1
+	}
+	topic := kafka.GetDocumentRevisionTopic(cfg)
+	if *laneName != "" {
+		topic = ""
+		if cfg.Indexer != nil {
+			for _, l := range cfg.Indexer.Lanes {
+				if l.Name == *laneName {
+					topic = l.Topic
+				}
+			}
+		}
+		if topic == "" {
+			fmt.Fprintf(os.Stderr, "error: no indexer lane named %q\n", *laneName)
+			return 1
+		}
+	}
+
+	var producerCfg *config.KafkaProducer
+	if cfg.Indexer != nil {
+		producerCfg = cfg.Indexer.Producer
+	}
+	p, err := producer.New(producerCfg.ToProducerConfig(
+		kafka.GetBrokers(cfg), "indexer-admin"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error creating kafka producer: %v\n", err)
+		return 1
+	}
+	defer p.Close()
+
+	// Key by document UUID, like the outbox relay, so the event is ordered with
+	// the document's other events.
+	record := producer.DocumentRecord(topic, event.DocumentUUID, value,
+		kgo.RecordHeader{Key: "event_type", Value: []byte(event.EventType)},
+		kgo.RecordHeader{Key: "provider_type", Value: []byte(event.ProviderType)},
+		kgo.RecordHeader{Key: "synthetic", Value: []byte("true")},
+	)
+	res, err := p.Produce(context.Background(), record)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error publishing event: %v\n", err)
+		return 1
+	}
+
+	fmt.Printf("Published %s event for document %s to %s (partition %d, offset %d)\n",
+		event.EventType, event.DocumentUUID, topic, res.Partition, res.Offset)
+	return 0
+}
+
+// readEventFile reads an event from path, or from stdin if path is "-".
+func readEventFile(path string) ([]byte, error) {
+	if path == "-" {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, fmt.Errorf("error reading event from stdin: %w", err)
+		}
+		return data, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading event file: %w", err)
+	}
+	return data, nil
+}
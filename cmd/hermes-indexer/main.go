@@ -4,9 +4,11 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/hashicorp-forge/hermes/internal/config"
 	"github.com/hashicorp-forge/hermes/pkg/indexer/consumer"
@@ -14,15 +16,25 @@ import (
 	"github.com/hashicorp-forge/hermes/pkg/indexer/pipeline/steps"
 	"github.com/hashicorp-forge/hermes/pkg/indexer/ruleset"
 	"github.com/hashicorp-forge/hermes/pkg/kafka"
+	"github.com/hashicorp-forge/hermes/pkg/metrics"
+	"github.com/hashicorp-forge/hermes/pkg/models"
 	"github.com/hashicorp-forge/hermes/pkg/search"
 	algoliaadapter "github.com/hashicorp-forge/hermes/pkg/search/adapters/algolia"
 	bleveadapter "github.com/hashicorp-forge/hermes/pkg/search/adapters/bleve"
 	meilisearchadapter "github.com/hashicorp-forge/hermes/pkg/search/adapters/meilisearch"
+	"github.com/hashicorp-forge/hermes/pkg/tracing"
+	"github.com/hashicorp-forge/hermes/pkg/workerstatus"
 	"github.com/hashicorp/go-hclog"
-	"github.com/hashicorp/hcl/v2/hclsimple"
 )
 
 func main() {
+	// Run an admin subcommand, if one was given.
+	if len(os.Args) > 1 {
+		if run, ok := adminCommands[os.Args[1]]; ok {
+			os.Exit(run(os.Args[2:]))
+		}
+	}
+
 	// Parse command-line flags
 	configPath := flag.String("config", "config.hcl", "Path to configuration file")
 	flag.Parse()
@@ -42,6 +54,22 @@ func main() {
 		os.Exit(1)
 	}
 
+	if cfg.Tracing != nil && cfg.Tracing.Enabled {
+		shutdownTracing, err := tracing.Setup(
+			context.Background(), cfg.Tracing.ToTracingConfig("hermes-indexer"))
+		if err != nil {
+			logger.Error("failed to initialize tracing", "error", err)
+			os.Exit(1)
+		}
+		defer func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := shutdownTracing(ctx); err != nil {
+				logger.Error("failed to flush traces", "error", err)
+			}
+		}()
+	}
+
 	// Create context with cancellation
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -57,7 +85,7 @@ func main() {
 	}()
 
 	// Run consumer mode
-	if err := runConsumer(ctx, cfg, logger); err != nil {
+	if err := runConsumer(ctx, cfg, *configPath, logger); err != nil {
 		logger.Error("consumer failed", "error", err)
 		cancel() // Ensure context is canceled before exit
 		os.Exit(1)
@@ -66,8 +94,9 @@ func main() {
 	logger.Info("hermes-indexer stopped gracefully")
 }
 
-// runConsumer runs the indexer consumer (database-independent).
-func runConsumer(ctx context.Context, cfg *config.Config, logger hclog.Logger) error {
+// runConsumer runs the indexer consumer (database-independent). Rulesets are
+// reloaded from configPath on SIGHUP.
+func runConsumer(ctx context.Context, cfg *config.Config, configPath string, logger hclog.Logger) error {
 	logger.Info("starting indexer consumer")
 
 	// Initialize search provider
@@ -75,6 +104,12 @@ func runConsumer(ctx context.Context, cfg *config.Config, logger hclog.Logger) e
 	if err != nil {
 		return fmt.Errorf("failed to initialize search provider: %w", err)
 	}
+	searchProvider = metrics.InstrumentSearchProvider(
+		tracing.InstrumentSearchProvider(searchProvider))
+
+	if cfg.Indexer.MetricsAddress != "" {
+		go serveMetrics(cfg.Indexer.MetricsAddress, logger)
+	}
 
 	// Create pipeline steps
 	pipelineSteps := []pipeline.Step{
@@ -82,6 +117,9 @@ func runConsumer(ctx context.Context, cfg *config.Config, logger hclog.Logger) e
 		// Add more steps as they're implemented:
 		// steps.NewLLMSummaryStep(hermesAPIClient, llmClient, logger),
 		// steps.NewEmbeddingsStep(hermesAPIClient, embeddingClient, logger),
+		// steps.NewQualityLintStep(db, workspaceProvider, baseURLs, logger),
+		// steps.NewSensitiveDataStep(db, workspaceProvider, dlpClient, notifier, logger),
+		// steps.NewDuplicateDetectionStep(db, workspaceProvider, logger),
 	}
 
 	// Create pipeline executor (no database - stateless)
@@ -102,24 +140,88 @@ func runConsumer(ctx context.Context, cfg *config.Config, logger hclog.Logger) e
 	// Convert config rulesets to indexer rulesets
 	rulesets := convertRulesets(cfg.Indexer.Rulesets)
 
+	// Report the indexer's progress to the Hermes API, if configured.
+	var status *workerstatus.Reporter
+	if s := cfg.Indexer.Status; s != nil {
+		status, err = workerstatus.New(workerstatus.Config{
+			Client:   workerstatus.NewClient(s.URL, s.Token),
+			Name:     s.Name,
+			Kind:     models.WorkerKindIndexer,
+			Interval: s.Interval,
+			Logger:   logger.Named("worker-status"),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create worker status reporter: %w", err)
+		}
+		go status.Run(ctx)
+	}
+
 	// Create consumer (no database - gets all data from event payload)
 	indexerConsumer, err := consumer.New(consumer.Config{
 		DB:            nil, // No database - indexer is stateless
 		Brokers:       brokers,
 		Topic:         topic,
 		ConsumerGroup: consumerGroup,
+		Lanes:         convertLanes(cfg.Indexer.Lanes, consumerGroup),
 		Rulesets:      rulesets,
 		Executor:      executor,
+		Status:        status,
 		Logger:        logger,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create consumer: %w", err)
 	}
 
+	// Reload rulesets on SIGHUP.
+	go reloadOnSIGHUP(ctx, logger, func() error {
+		cfg, err := loadConfig(configPath)
+		if err != nil {
+			return err
+		}
+		if cfg.Indexer == nil {
+			return fmt.Errorf("config file has no indexer block")
+		}
+		return indexerConsumer.UpdateRulesets(convertRulesets(cfg.Indexer.Rulesets))
+	})
+
 	// Start consumer
 	return indexerConsumer.Start(ctx)
 }
 
+// serveMetrics serves Prometheus metrics on /metrics at address addr.
+func serveMetrics(addr string, logger hclog.Logger) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+	logger.Info("serving metrics", "address", addr)
+	if err := http.ListenAndServe(addr, mux); err != nil {
+		logger.Error("error serving metrics", "error", err)
+	}
+}
+
+// reloadOnSIGHUP calls reload each time the process receives SIGHUP, until ctx
+// is done. If reload fails, the error is logged and the running configuration
+// is kept.
+func reloadOnSIGHUP(ctx context.Context, logger hclog.Logger, reload func() error) {
+	hupCh := make(chan os.Signal, 1)
+	signal.Notify(hupCh, syscall.SIGHUP)
+	defer signal.Stop(hupCh)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hupCh:
+			logger.Info("received SIGHUP, reloading configuration")
+			if err := reload(); err != nil {
+				logger.Error("failed to reload configuration, keeping current configuration",
+					"error", err)
+				continue
+			}
+			logger.Info("reloaded configuration")
+		}
+	}
+}
+
 // convertRulesets converts config rulesets to indexer rulesets.
 func convertRulesets(cfgRulesets []config.IndexerRuleset) []ruleset.Ruleset {
 	rulesets := make([]ruleset.Ruleset, len(cfgRulesets))
@@ -134,15 +236,33 @@ func convertRulesets(cfgRulesets []config.IndexerRuleset) []ruleset.Ruleset {
 	return rulesets
 }
 
+// convertLanes converts config lanes to consumer lanes. Lanes without a
+// consumer group get consumerGroup followed by "-" and the lane name.
+func convertLanes(cfgLanes []*config.IndexerLane, consumerGroup string) []consumer.Lane {
+	var lanes []consumer.Lane
+	for _, l := range cfgLanes {
+		group := l.ConsumerGroup
+		if group == "" {
+			group = consumerGroup + "-" + l.Name
+		}
+		lanes = append(lanes, consumer.Lane{
+			Name:          l.Name,
+			Topic:         l.Topic,
+			ConsumerGroup: group,
+			Priority:      l.Priority,
+		})
+	}
+	return lanes
+}
+
 // loadConfig loads the configuration from an HCL file.
 func loadConfig(path string) (*config.Config, error) {
-	var cfg config.Config
-	err := hclsimple.DecodeFile(path, nil, &cfg)
+	cfg, err := config.NewConfig(path, "")
 	if err != nil {
 		return nil, fmt.Errorf("failed to load config: %w", err)
 	}
 
-	return &cfg, nil
+	return cfg, nil
 }
 
 // initializeSearchProvider creates the search provider based on config.
@@ -180,6 +300,7 @@ func initializeSearchProvider(cfg *config.Config, logger hclog.Logger) (search.P
 		}
 
 		meilisearchCfg := cfg.Meilisearch.ToMeilisearchAdapterConfig()
+		meilisearchCfg.Ranking = cfg.Search.Rankings()
 		provider, err := meilisearchadapter.NewAdapter(meilisearchCfg)
 		if err != nil {
 			return nil, fmt.Errorf("failed to initialize meilisearch adapter: %w", err)
@@ -195,6 +316,7 @@ func initializeSearchProvider(cfg *config.Config, logger hclog.Logger) (search.P
 
 		bleveCfg := &bleveadapter.Config{
 			IndexPath: cfg.Bleve.IndexPath,
+			Ranking:   cfg.Search.Rankings(),
 		}
 
 		provider, err := bleveadapter.NewAdapter(bleveCfg)
@@ -19,6 +19,10 @@ func main() {
 	// Command-line flags
 	driver := flag.String("driver", "postgres", "Database driver (postgres|sqlite)")
 	dsn := flag.String("dsn", "", "Database connection string")
+	to := flag.Int("to", -1, "Migrate up or down to the given version (0 rolls back all migrations)")
+	down := flag.Int("down", 0, "Roll back the given number of applied migrations")
+	status := flag.Bool("status", false, "List applied and pending migrations")
+	baseline := flag.Int("baseline", -1, "Record the given version as applied without running migrations (0 for none)")
 	help := flag.Bool("help", false, "Show help message")
 
 	flag.Usage = func() {
@@ -33,6 +37,14 @@ func main() {
 		fmt.Fprintf(os.Stderr, "    %s -driver=postgres -dsn=\"host=localhost user=postgres password=postgres dbname=hermes port=5432 sslmode=disable\"\n\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  SQLite:\n")
 		fmt.Fprintf(os.Stderr, "    %s -driver=sqlite -dsn=\".hermes/hermes.db\"\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  Show applied and pending migrations:\n")
+		fmt.Fprintf(os.Stderr, "    %s -driver=sqlite -dsn=\".hermes/hermes.db\" -status\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  Migrate to version 10 (up or down):\n")
+		fmt.Fprintf(os.Stderr, "    %s -driver=sqlite -dsn=\".hermes/hermes.db\" -to=10\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  Roll back the last two migrations:\n")
+		fmt.Fprintf(os.Stderr, "    %s -driver=sqlite -dsn=\".hermes/hermes.db\" -down=2\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  Adopt an existing database at version 23:\n")
+		fmt.Fprintf(os.Stderr, "    %s -driver=sqlite -dsn=\".hermes/hermes.db\" -baseline=23\n\n", os.Args[0])
 	}
 
 	flag.Parse()
@@ -51,6 +63,19 @@ func main() {
 		log.Fatalf("Error: unsupported driver '%s' (must be 'postgres' or 'sqlite')\n", *driver)
 	}
 
+	modes := 0
+	for _, set := range []bool{*to >= 0, *down != 0, *status, *baseline >= 0} {
+		if set {
+			modes++
+		}
+	}
+	if modes > 1 {
+		log.Fatal("Error: only one of -to, -down, -status, and -baseline may be used\n")
+	}
+	if *down < 0 {
+		log.Fatal("Error: -down must be a positive number of migrations\n")
+	}
+
 	// Connect to database
 	log.Printf("Connecting to %s database...\n", *driver)
 	sqlDB, err := sql.Open(*driver, *dsn)
@@ -66,11 +91,99 @@ func main() {
 	}
 	log.Printf("✓ Connected to database\n")
 
-	// Run migrations
-	log.Printf("Running migrations...\n")
-	if err := migrate.RunMigrations(sqlDB, *driver); err != nil {
-		log.Fatalf("Migration failed: %v\n", err)
+	switch {
+	case *status:
+		if err := printStatus(sqlDB, *driver); err != nil {
+			log.Fatalf("Failed to get migration status: %v\n", err)
+		}
+
+	case *baseline >= 0:
+		log.Printf("Setting migration version to %d without running migrations...\n", *baseline)
+		if err := migrate.Baseline(sqlDB, *driver, uint(*baseline)); err != nil {
+			log.Fatalf("Baseline failed: %v\n", err)
+		}
+		log.Printf("✅ Database is now at version %d\n", *baseline)
+
+	case *to >= 0:
+		log.Printf("Migrating to version %d...\n", *to)
+		if err := migrate.MigrateTo(sqlDB, *driver, uint(*to)); err != nil {
+			fatalMigrationError(err)
+		}
+		log.Printf("✅ Database is now at version %d\n", *to)
+
+	case *down > 0:
+		log.Printf("Rolling back %d migration(s)...\n", *down)
+		if err := migrate.MigrateDown(sqlDB, *driver, *down); err != nil {
+			fatalMigrationError(err)
+		}
+		log.Printf("✅ Rolled back %d migration(s)\n", *down)
+
+	default:
+		log.Printf("Running migrations...\n")
+		if err := migrate.RunMigrations(sqlDB, *driver); err != nil {
+			fatalMigrationError(err)
+		}
+		log.Printf("✅ All migrations completed successfully!\n")
+	}
+}
+
+// printStatus prints the current version and each applied or pending migration.
+func printStatus(db *sql.DB, driver string) error {
+	status, err := migrate.GetStatus(db, driver)
+	if err != nil {
+		return err
+	}
+
+	if status.Version == 0 {
+		fmt.Println("Current version: none")
+	} else if status.Dirty {
+		fmt.Printf("Current version: %d (dirty)\n", status.Version)
+	} else {
+		fmt.Printf("Current version: %d\n", status.Version)
 	}
+	fmt.Println()
+
+	pending := 0
+	for _, m := range status.Migrations {
+		state := "applied"
+		switch {
+		case !m.Applied:
+			state = "pending"
+			pending++
+		case status.Dirty && m.Version == status.Version:
+			state = "dirty"
+		}
+		fmt.Printf("  %06d  %-8s %s\n", m.Version, state, m.Name)
+	}
+	fmt.Printf("\n%d applied, %d pending\n", len(status.Migrations)-pending, pending)
+
+	if status.Dirty {
+		fmt.Println()
+		printDirtyGuidance(int(status.Version))
+	}
+	return nil
+}
+
+// fatalMigrationError logs a migration error, with recovery steps if the
+// database is dirty, and exits.
+func fatalMigrationError(err error) {
+	log.Printf("Migration failed: %v\n", err)
+	if version, ok := migrate.DirtyVersion(err); ok {
+		printDirtyGuidance(version)
+	}
+	os.Exit(1)
+}
 
-	log.Printf("✅ All migrations completed successfully!\n")
+// printDirtyGuidance prints the steps to recover from a migration that failed
+// partway through.
+func printDirtyGuidance(version int) {
+	fmt.Fprintf(os.Stderr, "The database is dirty: migration %d failed partway through.\n", version)
+	fmt.Fprintf(os.Stderr, "No further migrations will run until this is resolved. To recover:\n\n")
+	fmt.Fprintf(os.Stderr, "  1. Review migration %06d in internal/migrate/migrations and check which of\n", version)
+	fmt.Fprintf(os.Stderr, "     its statements were applied.\n")
+	fmt.Fprintf(os.Stderr, "  2. Either complete the migration by hand and mark it applied:\n")
+	fmt.Fprintf(os.Stderr, "       %s -baseline=%d ...\n", os.Args[0], version)
+	fmt.Fprintf(os.Stderr, "     or undo its partial changes and mark the previous version applied:\n")
+	fmt.Fprintf(os.Stderr, "       %s -baseline=%d ...\n", os.Args[0], version-1)
+	fmt.Fprintf(os.Stderr, "  3. Run the migration tool again.\n")
 }
@@ -6,29 +6,25 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/pkg/metrics"
+	"github.com/hashicorp-forge/hermes/pkg/models"
 	"github.com/hashicorp-forge/hermes/pkg/notifications"
 	"github.com/hashicorp-forge/hermes/pkg/notifications/backends"
-	"github.com/hashicorp/hcl/v2/hclsimple"
+	"github.com/hashicorp-forge/hermes/pkg/tracing"
+	"github.com/hashicorp-forge/hermes/pkg/workerstatus"
+	"github.com/hashicorp/go-hclog"
 	"github.com/twmb/franz-go/pkg/kgo"
 )
 
-// NotifierConfig holds the notifier configuration from HCL
-type NotifierConfig struct {
-	// Backends configuration (pointer - 8 bytes on 64-bit)
-	Backends *backends.Config `hcl:"backends,block"`
-
-	// Strings (16 bytes each on 64-bit due to struct layout)
-	Brokers       string `hcl:"brokers,optional"`
-	Topic         string `hcl:"topic,optional"`
-	ConsumerGroup string `hcl:"consumer_group,optional"`
-}
-
 func main() {
 	// Parse command-line flags
 	configFile := flag.String("config", "", "Path to HCL configuration file")
@@ -38,33 +34,34 @@ func main() {
 		log.Fatal("Missing required -config flag")
 	}
 
-	// Load configuration from HCL file
-	var cfg NotifierConfig
-	err := hclsimple.DecodeFile(*configFile, nil, &cfg)
+	// Load configuration and initialize backends
+	cfg, backendList, err := loadConfig(*configFile)
 	if err != nil {
-		log.Fatalf("Failed to load configuration from %s: %v", *configFile, err)
+		log.Fatal(err)
 	}
 
-	// Apply defaults
-	if cfg.Brokers == "" {
-		cfg.Brokers = "localhost:9092"
-	}
-	if cfg.Topic == "" {
-		cfg.Topic = "hermes.notifications"
-	}
-	if cfg.ConsumerGroup == "" {
-		cfg.ConsumerGroup = "hermes-notifiers"
-	}
+	// Backends are replaced when the configuration is reloaded on SIGHUP.
+	// Messages being processed keep the backends they started with.
+	var currentBackends atomic.Pointer[[]backends.Backend]
+	currentBackends.Store(&backendList)
 
-	// Initialize backend registry from configuration
-	registry, err := backends.NewRegistry(cfg.Backends)
-	if err != nil {
-		log.Fatalf("Failed to initialize backend registry: %v", err)
-	}
+	// Identical notifications published within the window (e.g., by both the
+	// API server and an indexer step) are only delivered once.
+	dedup := notifications.NewDeduplicator(cfg.DedupWindow)
 
-	backendList := registry.GetAll()
-	if len(backendList) == 0 {
-		log.Fatal("No backends initialized")
+	if cfg.Tracing != nil && cfg.Tracing.Enabled {
+		shutdownTracing, err := tracing.Setup(
+			context.Background(), cfg.Tracing.ToTracingConfig("hermes-notify"))
+		if err != nil {
+			log.Fatalf("Failed to initialize tracing: %v", err)
+		}
+		defer func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := shutdownTracing(ctx); err != nil {
+				log.Printf("Failed to flush traces: %v", err)
+			}
+		}()
 	}
 
 	// Create Kafka consumer
@@ -82,8 +79,30 @@ func main() {
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer cancel()
 
-	backendNames := registry.GetBackendNames()
-	log.Printf("Starting notification worker (backends=%v, group=%s)\n", backendNames, cfg.ConsumerGroup)
+	go reloadOnSIGHUP(ctx, *configFile, cfg, &currentBackends, dedup)
+
+	if cfg.MetricsAddress != "" {
+		go serveMetrics(cfg.MetricsAddress)
+	}
+
+	// Report the notifier's progress to the Hermes API, if configured.
+	var status *workerstatus.Reporter
+	if s := cfg.Status; s != nil {
+		status, err = workerstatus.New(workerstatus.Config{
+			Client:   workerstatus.NewClient(s.URL, s.Token),
+			Name:     s.Name,
+			Kind:     models.WorkerKindNotifier,
+			Interval: s.Interval,
+			Logger:   hclog.Default().Named("worker-status"),
+		})
+		if err != nil {
+			log.Fatalf("Failed to create worker status reporter: %v", err)
+		}
+		go status.Run(ctx)
+	}
+
+	log.Printf("Starting notification worker (backends=%v, group=%s, dedup_window=%s)\n",
+		backendNames(backendList), cfg.ConsumerGroup, cfg.DedupWindow)
 
 	// RFC-087-ADDENDUM Section 7: Graceful Shutdown
 	// Track in-flight messages for graceful shutdown
@@ -123,13 +142,21 @@ func main() {
 			}
 
 			fetches.EachPartition(func(p kgo.FetchTopicPartition) {
+				if n := len(p.Records); n > 0 {
+					last := p.Records[n-1].Offset
+					lag := p.HighWatermark - last - 1
+					metrics.SetKafkaConsumerLag(cfg.ConsumerGroup, p.Topic, p.Partition, lag)
+					status.ObservePartition(cfg.ConsumerGroup, p.Topic, p.Partition, last, lag)
+				}
 				for _, record := range p.Records {
 					// Track message processing
 					inFlight.Add(1)
-					go func(rec *kgo.Record) {
+					go func(rec *kgo.Record, backendList []backends.Backend) {
 						defer inFlight.Done()
 
-						if err := processMessage(ctx, backendList, rec); err != nil {
+						err := processMessage(ctx, backendList, dedup, rec)
+						status.ObserveRecord(err)
+						if err != nil {
 							log.Printf("Failed to process message: %v\n", err)
 							// Don't commit offset on failure (RFC-087-ADDENDUM Section 9)
 						} else {
@@ -138,14 +165,97 @@ func main() {
 								log.Printf("Failed to commit record offset: %v\n", err)
 							}
 						}
-					}(record)
+					}(record, *currentBackends.Load())
 				}
 			})
 		}
 	}
 }
 
-func processMessage(ctx context.Context, backends []backends.Backend, record *kgo.Record) error {
+// loadConfig loads the notifier configuration from path and initializes the
+// configured backends.
+func loadConfig(path string) (*config.NotifierConfig, []backends.Backend, error) {
+	cfg, warnings, err := config.LoadNotifierConfig(path)
+	for _, w := range warnings {
+		log.Printf("Ignoring config setting: %s", w)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf(
+			"failed to load configuration from %s: %w", path, err)
+	}
+
+	// Initialize backend registry from configuration
+	registry, err := backends.NewRegistry(cfg.Backends)
+	if err != nil {
+		return nil, nil, fmt.Errorf(
+			"failed to initialize backend registry: %w", err)
+	}
+
+	backendList := registry.GetAll()
+	if len(backendList) == 0 {
+		return nil, nil, fmt.Errorf("no backends initialized")
+	}
+
+	return cfg, backendList, nil
+}
+
+// reloadOnSIGHUP reloads the configuration from path each time the process
+// receives SIGHUP, until ctx is done, and replaces the current backends with
+// the reloaded ones and applies the reloaded deduplication window. If the
+// configuration is invalid, the current backends are kept. Changes to the
+// Kafka settings are only applied on restart.
+func reloadOnSIGHUP(ctx context.Context, path string, cfg *config.NotifierConfig, current *atomic.Pointer[[]backends.Backend], dedup *notifications.Deduplicator) {
+	hupCh := make(chan os.Signal, 1)
+	signal.Notify(hupCh, syscall.SIGHUP)
+	defer signal.Stop(hupCh)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hupCh:
+			log.Println("Received SIGHUP, reloading configuration")
+			newCfg, backendList, err := loadConfig(path)
+			if err != nil {
+				log.Printf("Failed to reload configuration, keeping current backends: %v", err)
+				continue
+			}
+			if newCfg.Brokers != cfg.Brokers || newCfg.Topic != cfg.Topic ||
+				newCfg.ConsumerGroup != cfg.ConsumerGroup {
+				log.Println("Kafka settings changed; restart the notifier to apply them")
+			}
+
+			current.Store(&backendList)
+			dedup.SetWindow(newCfg.DedupWindow)
+			log.Printf("Reloaded configuration (backends=%v, dedup_window=%s)",
+				backendNames(backendList), newCfg.DedupWindow)
+		}
+	}
+}
+
+// serveMetrics serves Prometheus metrics on /metrics at address addr.
+func serveMetrics(addr string) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+	log.Printf("Serving metrics on %s/metrics", addr)
+	if err := http.ListenAndServe(addr, mux); err != nil {
+		log.Printf("Failed to serve metrics: %v", err)
+	}
+}
+
+// backendNames returns the names of backends.
+func backendNames(backendList []backends.Backend) []string {
+	names := make([]string, 0, len(backendList))
+	for _, b := range backendList {
+		names = append(names, b.Name())
+	}
+	return names
+}
+
+func processMessage(ctx context.Context, backends []backends.Backend, dedup *notifications.Deduplicator, record *kgo.Record) (err error) {
+	ctx, span := tracing.StartConsume(ctx, record)
+	defer func() { tracing.End(span, err) }()
+
 	// Parse notification message
 	var msg notifications.NotificationMessage
 	if err := json.Unmarshal(record.Value, &msg); err != nil {
@@ -172,13 +282,26 @@ func processMessage(ctx context.Context, backends []backends.Backend, record *kg
 		return nil
 	}
 
+	// Skip recipients that were sent an identical notification recently.
+	if n := dedup.Filter(&msg); n > 0 {
+		metrics.ObserveNotificationDuplicates(n)
+		if len(msg.Recipients) == 0 {
+			log.Printf("Skipping message %s (type=%s document=%s, duplicate of a recent notification)",
+				msg.ID, msg.Type, msg.DocumentUUID)
+			return nil
+		}
+		log.Printf("Skipped %d duplicate recipients of message %s", n, msg.ID)
+	}
+
 	log.Printf("Processing message: id=%s template=%s backends=%v", msg.ID, msg.Template, msg.Backends)
 
 	// Route to appropriate backends based on message.Backends field
 	for _, backend := range backends {
 		for _, targetBackend := range msg.Backends {
 			if backend.SupportsBackend(targetBackend) {
-				if err := backend.Handle(ctx, &msg); err != nil {
+				err := backend.Handle(ctx, &msg)
+				metrics.ObserveNotificationDelivery(backend.Name(), err)
+				if err != nil {
 					log.Printf("backend %s failed: %v", backend.Name(), err)
 					// Continue with other backends
 				} else {
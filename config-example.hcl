@@ -148,6 +148,14 @@ document_types {
     // Example: "templates/rfc.md"
     // markdown_template = "templates/rfc.md"
 
+    // template_variant: Other templates drafts of this type can be created
+    // from (optional). The template above is the "default" template.
+    // template_variant "short" {
+    //   long_name   = "Short RFC"
+    //   description = "A shorter RFC for small, low-risk changes."
+    //   template    = "1mQW2m_short_rfc_template_id"
+    // }
+
     // more_info_link: Optional link to documentation about this doc type
     more_info_link {
       text = "More info on the RFC template"
@@ -398,6 +406,34 @@ email {
   // For Google Workspace: Must be a valid email in your domain
   // For Local Workspace: Can be any address (SMTP dependent)
   from_address = "hermes@yourorganization.com"
+
+  // smtp: Send emails through an SMTP server instead of the workspace
+  // provider (optional). Connections are pooled and reused.
+  // smtp {
+  //   host            = "smtp.yourorganization.com"
+  //   port            = 587
+  //   username        = "hermes"
+  //   password        = "..."
+  //   tls             = "starttls"  // "starttls", "tls", or "none"
+  //   max_connections = 2
+  //
+  //   // dkim: Sign emails with DKIM (optional). Publish the public key in
+  //   // the DNS TXT record "<selector>._domainkey.<domain>".
+  //   dkim {
+  //     domain           = "yourorganization.com"
+  //     selector         = "hermes"
+  //     private_key_file = "/etc/hermes/dkim.pem"
+  //   }
+  // }
+
+  // approval_links: Add single-use links to review request emails that
+  // approve the document or request changes without opening Hermes
+  // (optional). Links confirm the action before it's recorded, so email
+  // scanners that follow links don't approve documents.
+  // approval_links {
+  //   enabled = true
+  //   ttl     = "168h"
+  // }
 }
 
 //------------------------------------------------------------------------------
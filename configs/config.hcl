@@ -1,7 +1,23 @@
+// Values can reference secrets instead of storing them in this file, using the
+// env("NAME"), env("NAME", "default"), file("path"), and vault("path", "key")
+// functions. For example:
+//
+//   password = env("HERMES_SERVER_POSTGRES_PASSWORD")
+//
+// Run "hermes config schema" to print every block and setting with its
+// documentation. Unknown settings are ignored with a warning.
+
 // base_url is the base URL used for building links. This should be the public
 // URL of the application.
 base_url = "http://localhost:8000"
 
+// deployment_size sets defaults for connection pool sizes, batch sizes,
+// polling and refresh intervals, cache TTLs, worker concurrency, and rate
+// limits based on the size of the deployment. Supported values are "small",
+// "medium", or "large". Settings configured explicitly in their own blocks
+// take precedence.
+// deployment_size = "medium"
+
 // log_format configures the logging format. Supported values are "standard" or
 // "json".
 log_format = "standard"
@@ -19,6 +35,26 @@ algolia {
   write_api_key             = ""
 }
 
+// auth configures browser sessions for deployments that are not behind an
+// authenticating proxy.
+auth {
+  // csrf requires mutating requests authenticated with a session cookie to
+  // include a CSRF token. Requires session_secret.
+  csrf = false
+
+  // same_site is the SameSite attribute of session cookies ("lax", "strict",
+  // or "none").
+  same_site = "lax"
+
+  // secure_cookies always sets the Secure flag on session cookies (set this
+  // when TLS is terminated by a load balancer).
+  secure_cookies = false
+
+  // session_secret enables signed cookie sessions after Dex or OIDC login. It
+  // must be at least 32 characters.
+  session_secret = ""
+}
+
 // datadog configures Hermes to send metrics to Datadog.
 datadog {
   enabled = false
@@ -54,6 +90,14 @@ document_types {
       name = "Target Version"
       type = "string"
     }
+
+    // custom_field types are "string", "person", "people", "enum", "date",
+    // "number", and "boolean". Enum fields require options, e.g.:
+    // custom_field {
+    //   name    = "Priority"
+    //   type    = "enum"
+    //   options = ["Low", "Medium", "High"]
+    // }
   }
 
   document_type "PRD" {
@@ -196,6 +240,30 @@ jira {
   user = ""
 }
 
+// oidc configures Hermes to authenticate users with any OpenID Connect
+// provider (e.g., Okta, Azure AD, Keycloak) using the authorization code flow
+// with PKCE.
+oidc {
+  // client_id is the OIDC client ID for Hermes.
+  client_id = ""
+
+  // client_secret is the OIDC client secret (optional for public clients).
+  client_secret = ""
+
+  // disabled disables OIDC authentication.
+  disabled = true
+
+  // email_claim is the ID token claim containing the user's email address
+  // (default: "email").
+  // email_claim = "email"
+
+  // issuer_url is the URL of the OIDC issuer used for discovery.
+  issuer_url = ""
+
+  // redirect_url is the OIDC callback URL.
+  redirect_url = "http://localhost:8000/auth/callback"
+}
+
 // okta configures Hermes to authenticate users using an AWS Application Load
 // Balancer and Okta instead of using Google OAuth.
 okta {
@@ -222,6 +290,19 @@ postgres {
   password = "postgres"
   port     = 5432
   user     = "postgres"
+
+  // max_open_conns and max_idle_conns size the connection pool (defaults: 25
+  // and 10, or based on deployment_size).
+  // max_open_conns = 25
+  // max_idle_conns = 10
+
+  // conn_max_lifetime and conn_max_idle_time recycle pooled connections
+  // (defaults: 5m and 10m).
+  // conn_max_lifetime = "5m"
+  // conn_max_idle_time = "10m"
+
+  // read_replica_dsn serves read-heavy requests from a read replica.
+  // read_replica_dsn = "host=replica.internal port=5432 user=postgres password=postgres dbname=db"
 }
 
 // products should be modified to reflect the products/areas in your
@@ -242,4 +323,37 @@ products {
 server {
   // addr is the address to bind to for listening.
   addr = "127.0.0.1:8000"
+
+  // document_location_cache_ttl is how long the storage provider that serves
+  // a document is cached (default: 1m, or based on deployment_size).
+  // document_location_cache_ttl = "1m"
+
+  // shutdown_drain_delay is how long the server reports that it isn't ready
+  // (via /readyz) before it stops accepting connections on shutdown, so load
+  // balancers stop sending it new requests (default: 0s).
+  // shutdown_drain_delay = "10s"
+
+  // shutdown_timeout is the time allowed for in-flight requests and background
+  // work to finish on shutdown (default: 30s).
+  // shutdown_timeout = "30s"
+}
+
+// tenant configures a tenant: an isolated document space served on its own
+// host names, with its own documents, document numbers, search results, and
+// site admins. Requests to other hosts are served for the default tenant.
+// Documents indexed before tenants are configured must be reindexed.
+// tenant "acme" {
+//   display_name    = "Acme"
+//   hostnames       = ["docs.acme.example"]
+//   allowed_domains = ["acme.example"]
+//   site_admins     = ["admin@acme.example"]
+// }
+
+// tracing configures exporting OpenTelemetry traces of API requests, workspace
+// and search provider calls, and Kafka records to an OTLP/HTTP collector.
+tracing {
+  enabled      = false
+  endpoint     = "localhost:4318"
+  insecure     = true
+  sample_ratio = 1.0
 }
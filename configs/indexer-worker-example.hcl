@@ -48,10 +48,41 @@ indexer {
   topic            = "hermes.document-revisions"
   consumer_group   = "hermes-indexer-workers"
 
+  # Priority lanes: consume several topics, each with its own consumer group,
+  # so a large backfill doesn't starve real-time indexing of fresh edits.
+  # Records of higher priority lanes are processed first. When lanes are
+  # configured, the topic above is only consumed if it has a lane.
+  # lane "revisions" {
+  #   topic    = "hermes.document-revisions"
+  #   priority = 10
+  # }
+  # lane "bulk-reindex" {
+  #   topic          = "hermes.documents.bulk-reindex"
+  #   consumer_group = "hermes-indexer-bulk"  # Default: "<consumer_group>-<lane name>"
+  # }
+
   # Outbox relay settings
   poll_interval = "1s"   # How often to poll the outbox table
   batch_size    = 100    # How many outbox entries to process per batch
 
+  # Producer settings of the outbox relay. Events are keyed by document UUID,
+  # so the events of a document are consumed in order.
+  # producer {
+  #   acks        = "all"  # "all" (default), "leader", or "none"
+  #   compression = "gzip" # "gzip" (default), "snappy", "lz4", "zstd", or "none"
+  #   idempotent  = true   # Default; requires acks = "all"
+  # }
+
+  # Report consumer groups, offsets, lag, and errors to the Hermes API, where
+  # site admins see them at /api/v2/admin/workers. The token is an "api"
+  # service token with the "workers" scope.
+  # status {
+  #   url      = "http://localhost:8000"
+  #   token    = env("HERMES_WORKER_TOKEN")
+  #   name     = "indexer-1" # Default: the hostname
+  #   interval = "30s"       # Default
+  # }
+
   # Pipeline rulesets
   # Each ruleset defines conditions for matching documents and the pipeline steps to execute
 
@@ -17,6 +17,11 @@ notifications {
   # Available: audit, mail, slack, telegram, discord
   backends = "audit,mail"
 
+  # Ops channel for operational events such as migration progress
+  # (job started, 50% complete, item failed, job complete). Defaults to "audit".
+  ops_backends   = "audit,mail"
+  ops_recipients = "hermes-ops@example.com"
+
   # Optional: Override embedded templates with custom templates
   # If not specified, uses embedded templates from internal/notifications/templates
   # templates_path = "/path/to/custom/templates"
@@ -7,25 +7,27 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
+	"github.com/hashicorp-forge/hermes/internal/auth"
 	"github.com/hashicorp-forge/hermes/internal/config"
 	"github.com/hashicorp-forge/hermes/pkg/auth/adapters/dex"
 	"github.com/hashicorp/go-hclog"
 )
 
 const (
-	// Session cookie name for storing user email
-	sessionCookieName = "hermes_session"
 	// State cookie name for CSRF protection
 	stateCookieName = "hermes_oauth_state"
 	// Cookie max age (7 days)
 	cookieMaxAge = 7 * 24 * time.Hour
+	// How long a user has to complete the login flow
+	loginFlowMaxAge = 5 * time.Minute
 )
 
 // LoginHandler redirects the user to the Dex OIDC authorization endpoint.
 // It generates a random state parameter for CSRF protection and stores it in a cookie.
-func LoginHandler(cfg config.Config, log hclog.Logger) http.Handler {
+func LoginHandler(cfg config.Config, sessions *auth.Sessions, log hclog.Logger) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Only support Dex authentication
 		if cfg.Dex == nil || cfg.Dex.Disabled {
@@ -50,15 +52,7 @@ func LoginHandler(cfg config.Config, log hclog.Logger) http.Handler {
 		}
 
 		// Store state in cookie
-		http.SetCookie(w, &http.Cookie{
-			Name:     stateCookieName,
-			Value:    state,
-			Path:     "/",
-			MaxAge:   int(5 * time.Minute / time.Second), // State expires in 5 minutes
-			HttpOnly: true,
-			Secure:   r.TLS != nil,
-			SameSite: http.SameSiteLaxMode,
-		})
+		http.SetCookie(w, loginFlowCookie(sessions, r, stateCookieName, state))
 
 		// Redirect to Dex authorization URL
 		authURL := adapter.GetAuthCodeURL(state)
@@ -69,8 +63,9 @@ func LoginHandler(cfg config.Config, log hclog.Logger) http.Handler {
 
 // CallbackHandler handles the OAuth2 callback from Dex.
 // It exchanges the authorization code for an ID token, validates it,
-// and establishes a session for the authenticated user.
-func CallbackHandler(cfg config.Config, log hclog.Logger) http.Handler {
+// and establishes a session for the authenticated user. The session is signed
+// if signed cookie sessions are enabled.
+func CallbackHandler(cfg config.Config, sessions *auth.Sessions, log hclog.Logger) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Only support Dex authentication
 		if cfg.Dex == nil || cfg.Dex.Disabled {
@@ -103,13 +98,7 @@ func CallbackHandler(cfg config.Config, log hclog.Logger) http.Handler {
 		}
 
 		// Clear state cookie
-		http.SetCookie(w, &http.Cookie{
-			Name:     stateCookieName,
-			Value:    "",
-			Path:     "/",
-			MaxAge:   -1,
-			HttpOnly: true,
-		})
+		http.SetCookie(w, sessions.Cookie(r, stateCookieName, "", -1))
 
 		// Get authorization code
 		code := r.URL.Query().Get("code")
@@ -136,61 +125,57 @@ func CallbackHandler(cfg config.Config, log hclog.Logger) http.Handler {
 		log.Info("user authenticated successfully", "email", email)
 
 		// Set session cookie with user email
-		http.SetCookie(w, &http.Cookie{
-			Name:     sessionCookieName,
-			Value:    email,
-			Path:     "/",
-			MaxAge:   int(cookieMaxAge / time.Second),
-			HttpOnly: true,
-			Secure:   r.TLS != nil,
-			SameSite: http.SameSiteLaxMode,
-		})
-
-		// Build redirect URL - use BaseURL from config if available
-		// This ensures we redirect to the frontend URL (e.g., http://localhost:4201)
-		// instead of the backend URL (e.g., http://localhost:8001)
-		redirectPath := "/dashboard"
-
-		// Check if there's a redirect path in the query params
-		if redirect := r.URL.Query().Get("redirect"); redirect != "" {
-			// Validate redirect URL to prevent open redirects
-			if u, err := url.Parse(redirect); err == nil && u.Host == "" {
-				redirectPath = redirect
-			}
-		}
-
-		// Construct absolute URL if BaseURL is configured
-		var redirectURL string
-		if cfg.BaseURL != "" {
-			baseURL, err := url.Parse(cfg.BaseURL)
-			if err != nil {
-				log.Error("invalid base_url in configuration", "base_url", cfg.BaseURL, "error", err)
-				redirectURL = redirectPath // Fallback to relative path
-			} else {
-				baseURL.Path = redirectPath
-				redirectURL = baseURL.String()
+		if sessions.Enabled() {
+			if err := sessions.Issue(w, r, email); err != nil {
+				log.Error("failed to start session", "error", err)
+				http.Error(w, "Failed to complete authentication", http.StatusInternalServerError)
+				return
 			}
 		} else {
-			// Fallback to relative redirect if BaseURL not configured
-			redirectURL = redirectPath
+			http.SetCookie(w, sessions.Cookie(r, auth.SessionCookieName, email, cookieMaxAge))
 		}
 
+		redirectURL := postLoginRedirectURL(cfg, r.URL.Query().Get("redirect"), log)
+
 		log.Info("redirecting after authentication", "url", redirectURL, "base_url", cfg.BaseURL, "email", email)
 		http.Redirect(w, r, redirectURL, http.StatusFound)
 	})
 }
 
+// postLoginRedirectURL returns the URL to redirect to after a user logs in.
+// The redirect path must be relative to prevent open redirects; it defaults to
+// the dashboard.
+func postLoginRedirectURL(cfg config.Config, redirect string, log hclog.Logger) string {
+	target := &url.URL{Path: "/dashboard"}
+	if redirect != "" {
+		// Validate redirect URL to prevent open redirects
+		if u, err := url.Parse(redirect); err == nil && u.Host == "" &&
+			u.Scheme == "" && strings.HasPrefix(u.Path, "/") &&
+			!strings.HasPrefix(u.Path, "/\\") {
+			target = u
+		}
+	}
+
+	// Use BaseURL from config if available. This ensures we redirect to the
+	// frontend URL (e.g., http://localhost:4201) instead of the backend URL
+	// (e.g., http://localhost:8001).
+	if cfg.BaseURL == "" {
+		// Fallback to relative redirect if BaseURL not configured
+		return target.String()
+	}
+	baseURL, err := url.Parse(cfg.BaseURL)
+	if err != nil {
+		log.Error("invalid base_url in configuration", "base_url", cfg.BaseURL, "error", err)
+		return target.String() // Fallback to relative path
+	}
+	return baseURL.ResolveReference(target).String()
+}
+
 // LogoutHandler clears the session cookie and redirects to the home page.
-func LogoutHandler(log hclog.Logger) http.Handler {
+func LogoutHandler(sessions *auth.Sessions, log hclog.Logger) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Clear session cookie
-		http.SetCookie(w, &http.Cookie{
-			Name:     sessionCookieName,
-			Value:    "",
-			Path:     "/",
-			MaxAge:   -1,
-			HttpOnly: true,
-		})
+		// Clear session cookies
+		sessions.Clear(w, r)
 
 		log.Debug("user logged out")
 
@@ -199,6 +184,17 @@ func LogoutHandler(log hclog.Logger) http.Handler {
 	})
 }
 
+// loginFlowCookie returns a short-lived cookie used during the login flow. It
+// always uses SameSite=Lax because the identity provider redirects back to
+// Hermes cross-site.
+func loginFlowCookie(
+	sessions *auth.Sessions, r *http.Request, name, value string,
+) *http.Cookie {
+	c := sessions.Cookie(r, name, value, loginFlowMaxAge)
+	c.SameSite = http.SameSiteLaxMode
+	return c
+}
+
 // generateRandomState generates a cryptographically secure random state string.
 func generateRandomState() (string, error) {
 	b := make([]byte, 32)
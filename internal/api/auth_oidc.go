@@ -0,0 +1,160 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/auth"
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/pkg/auth/adapters/oidc"
+	"github.com/hashicorp/go-hclog"
+	"golang.org/x/oauth2"
+)
+
+const (
+	// PKCE code verifier cookie name for the OIDC login flow
+	oidcVerifierCookieName = "hermes_oidc_verifier"
+	// Redirect path cookie name for the OIDC login flow
+	oidcRedirectCookieName = "hermes_oidc_redirect"
+)
+
+// OIDCLoginHandler starts the OIDC authorization code flow with PKCE. It
+// stores the state, PKCE code verifier, and post-login redirect path in
+// short-lived cookies and redirects the user to the provider's authorization
+// endpoint.
+func OIDCLoginHandler(
+	adapter *oidc.Adapter, sessions *auth.Sessions, log hclog.Logger,
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Generate random state for CSRF protection
+		state, err := generateRandomState()
+		if err != nil {
+			log.Error("failed to generate state", "error", err)
+			http.Error(w, "Failed to generate state", http.StatusInternalServerError)
+			return
+		}
+		verifier := oauth2.GenerateVerifier()
+
+		for name, value := range map[string]string{
+			stateCookieName:        state,
+			oidcVerifierCookieName: verifier,
+			oidcRedirectCookieName: r.URL.Query().Get("redirect"),
+		} {
+			http.SetCookie(w, loginFlowCookie(sessions, r, name, value))
+		}
+
+		authURL := adapter.AuthCodeURL(state, verifier)
+		log.Debug("redirecting to OIDC authorization URL", "url", authURL)
+		http.Redirect(w, r, authURL, http.StatusFound)
+	})
+}
+
+// OIDCCallbackHandler handles the redirect back from the OIDC provider. It
+// exchanges the authorization code and PKCE code verifier for an ID token and
+// starts a signed cookie session if they are enabled. Otherwise, it stores the
+// verified ID token in the session cookie, which is verified again on every
+// authenticated request.
+func OIDCCallbackHandler(
+	cfg config.Config, adapter *oidc.Adapter, sessions *auth.Sessions,
+	log hclog.Logger,
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		stateCookie, err := r.Cookie(stateCookieName)
+		if err != nil {
+			log.Error("state cookie not found", "error", err)
+			http.Error(w, "Invalid authentication state", http.StatusBadRequest)
+			return
+		}
+		verifierCookie, err := r.Cookie(oidcVerifierCookieName)
+		if err != nil {
+			log.Error("PKCE verifier cookie not found", "error", err)
+			http.Error(w, "Invalid authentication state", http.StatusBadRequest)
+			return
+		}
+		var redirect string
+		if c, err := r.Cookie(oidcRedirectCookieName); err == nil {
+			redirect = c.Value
+		}
+
+		// Validate state parameter
+		state := r.URL.Query().Get("state")
+		if state == "" || state != stateCookie.Value {
+			log.Error("state mismatch")
+			http.Error(w, "Invalid state parameter", http.StatusBadRequest)
+			return
+		}
+
+		// Clear login flow cookies
+		for _, name := range []string{
+			stateCookieName, oidcVerifierCookieName, oidcRedirectCookieName,
+		} {
+			http.SetCookie(w, sessions.Cookie(r, name, "", -1))
+		}
+
+		// Get authorization code
+		code := r.URL.Query().Get("code")
+		if code == "" {
+			errorCode := r.URL.Query().Get("error")
+			errorDesc := r.URL.Query().Get("error_description")
+			log.Error("authorization failed", "error", errorCode, "description", errorDesc)
+			http.Error(w, fmt.Sprintf("Authorization failed: %s", errorDesc), http.StatusBadRequest)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+		defer cancel()
+
+		rawIDToken, claims, expiry, err := adapter.Exchange(ctx, code, verifierCookie.Value)
+		if err != nil {
+			log.Error("failed to complete OIDC authentication", "error", err)
+			http.Error(w, "Failed to complete authentication", http.StatusUnauthorized)
+			return
+		}
+
+		log.Info("user authenticated successfully", "email", claims.Email)
+
+		if sessions.Enabled() {
+			if err := sessions.Issue(w, r, claims.Email); err != nil {
+				log.Error("failed to start session", "error", err)
+				http.Error(w, "Failed to complete authentication", http.StatusInternalServerError)
+				return
+			}
+		} else {
+			// The session lasts as long as the ID token.
+			maxAge := time.Until(expiry)
+			if maxAge <= 0 {
+				maxAge = -1
+			}
+			http.SetCookie(w, sessions.Cookie(r, oidc.SessionCookieName, rawIDToken, maxAge))
+		}
+
+		redirectURL := postLoginRedirectURL(cfg, redirect, log)
+		log.Debug("redirecting after authentication", "url", redirectURL)
+		http.Redirect(w, r, redirectURL, http.StatusFound)
+	})
+}
+
+// OIDCLogoutHandler clears the session cookie and ends the user's session with
+// the OIDC provider if it supports RP-initiated logout.
+func OIDCLogoutHandler(
+	cfg config.Config, adapter *oidc.Adapter, sessions *auth.Sessions,
+	log hclog.Logger,
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var idToken string
+		if c, err := r.Cookie(oidc.SessionCookieName); err == nil {
+			idToken = c.Value
+		}
+		sessions.Clear(w, r)
+
+		log.Debug("user logged out")
+
+		if logoutURL := adapter.LogoutURL(idToken, cfg.BaseURL); logoutURL != "" {
+			http.Redirect(w, r, logoutURL, http.StatusFound)
+			return
+		}
+		http.Redirect(w, r, "/", http.StatusFound)
+	})
+}
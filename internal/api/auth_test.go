@@ -0,0 +1,37 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostLoginRedirectURL(t *testing.T) {
+	log := hclog.NewNullLogger()
+	cfg := config.Config{BaseURL: "https://hermes.example.com"}
+
+	cases := map[string]struct {
+		redirect string
+		want     string
+	}{
+		"default":             {"", "https://hermes.example.com/dashboard"},
+		"relative path":       {"/documents/1", "https://hermes.example.com/documents/1"},
+		"path with query":     {"/results?q=rfc", "https://hermes.example.com/results?q=rfc"},
+		"absolute URL":        {"https://evil.example.com/", "https://hermes.example.com/dashboard"},
+		"protocol-relative":   {"//evil.example.com/", "https://hermes.example.com/dashboard"},
+		"backslash authority": {"/\\evil.example.com/", "https://hermes.example.com/dashboard"},
+		"not a path":          {"documents", "https://hermes.example.com/dashboard"},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, c.want, postLoginRedirectURL(cfg, c.redirect, log))
+		})
+	}
+
+	t.Run("no base URL", func(t *testing.T) {
+		assert.Equal(t, "/documents/1",
+			postLoginRedirectURL(config.Config{}, "/documents/1", log))
+	})
+}
@@ -0,0 +1,233 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/pkg/activity"
+	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+	"github.com/hashicorp-forge/hermes/pkg/database"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	// defaultActivityLimit is the default number of activities returned.
+	defaultActivityLimit = 50
+
+	// maxActivityLimit is the maximum number of activities returned.
+	maxActivityLimit = 200
+)
+
+// ActivityGetResponse is the response for GET /api/v2/me/activity and
+// GET /api/v2/documents/:id/activity.
+type ActivityGetResponse struct {
+	Activity []ActivityItem `json:"activity"`
+
+	// NextCursor is passed as the "cursor" query parameter to get the next page
+	// of activity, or empty if there is no more activity.
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// ActivityItem is a view, edit, or review of a document by a user.
+type ActivityItem struct {
+	// Action is "view", "edit", or "review".
+	Action string `json:"action"`
+
+	// User is the email address of the user.
+	User string `json:"user"`
+
+	DocumentID string `json:"documentId"`
+	Title      string `json:"title"`
+	DocType    string `json:"docType"`
+	IsDraft    bool   `json:"isDraft"`
+
+	// CreatedTime is when the activity happened, in seconds since the Unix
+	// epoch.
+	CreatedTime int64 `json:"createdTime"`
+}
+
+// MeActivityHandler returns the authenticated user's activity on documents for
+// the dashboard (GET /api/v2/me/activity). Results are newest first and can be
+// filtered with the action query parameter.
+func MeActivityHandler(srv server.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
+		if r.Method != "GET" {
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
+			return
+		}
+
+		userEmail, ok := pkgauth.GetUserEmail(r.Context())
+		if !ok || userEmail == "" {
+			writeProblem(w, r, http.StatusUnauthorized, ErrCodeUnauthorized,
+				"No authorization information for request")
+			return
+		}
+
+		filter, err := parseActivityFilter(r.URL.Query())
+		if err != nil {
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				fmt.Sprintf("Bad request: %v", err))
+			return
+		}
+
+		// Get one extra activity to determine if there is another page. The
+		// activity feed tolerates replication lag, so it is served from the
+		// read replica if one is configured.
+		limit := filter.Limit
+		filter.Limit++
+		as, err := activity.ForUser(
+			database.Replica(srv.DB.WithContext(r.Context())), userEmail, filter)
+		if err != nil {
+			respondError(w, r, srv.Logger, http.StatusInternalServerError,
+				"Error getting activity", "error finding user activity", err)
+			return
+		}
+
+		writeActivityResponse(w, r, srv.Logger, as, limit)
+	})
+}
+
+// documentActivityHandler returns the activity on a document or draft for the
+// document side panel (GET /api/v2/documents/:id/activity and
+// GET /api/v2/drafts/:id/activity).
+func documentActivityHandler(
+	w http.ResponseWriter,
+	r *http.Request,
+	srv server.Server,
+	model models.Document,
+) {
+	if r.Method != "GET" {
+		writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+			"Method not allowed")
+		return
+	}
+
+	filter, err := parseActivityFilter(r.URL.Query())
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			fmt.Sprintf("Bad request: %v", err))
+		return
+	}
+
+	limit := filter.Limit
+	filter.Limit++
+	as, err := activity.ForDocument(
+		database.Replica(srv.DB.WithContext(r.Context())), model, filter)
+	if err != nil {
+		respondError(w, r, srv.Logger, http.StatusInternalServerError,
+			"Error getting activity", "error finding document activity", err,
+			"doc_id", model.GoogleFileID)
+		return
+	}
+
+	writeActivityResponse(w, r, srv.Logger, as, limit)
+}
+
+// parseActivityFilter parses activity query parameters.
+func parseActivityFilter(q url.Values) (models.UserActivityFilter, error) {
+	f := models.UserActivityFilter{
+		Action: models.UserActivityAction(q.Get("action")),
+		Limit:  defaultActivityLimit,
+	}
+
+	switch f.Action {
+	case "", models.ViewUserActivityAction, models.EditUserActivityAction,
+		models.ReviewUserActivityAction:
+	default:
+		return f, fmt.Errorf("invalid action: %q", f.Action)
+	}
+
+	if v := q.Get("cursor"); v != "" {
+		id, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return f, fmt.Errorf("invalid cursor: %w", err)
+		}
+		f.BeforeID = id
+	}
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return f, fmt.Errorf("invalid limit: %q", v)
+		}
+		f.Limit = min(n, maxActivityLimit)
+	}
+
+	return f, nil
+}
+
+// writeActivityResponse writes up to limit activities as the response.
+func writeActivityResponse(
+	w http.ResponseWriter,
+	r *http.Request,
+	l hclog.Logger,
+	as models.UserActivities,
+	limit int,
+) {
+	resp := ActivityGetResponse{
+		Activity: []ActivityItem{},
+	}
+	if len(as) > limit {
+		as = as[:limit]
+		resp.NextCursor = strconv.FormatUint(as[limit-1].ID, 10)
+	}
+	for _, a := range as {
+		resp.Activity = append(resp.Activity, newActivityItem(a))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		l.Error("error encoding activity response",
+			"error", err,
+			"method", r.Method,
+			"path", r.URL.Path,
+		)
+	}
+}
+
+func newActivityItem(a models.UserActivity) ActivityItem {
+	return ActivityItem{
+		Action:     string(a.Action),
+		User:       a.User.EmailAddress,
+		DocumentID: a.Document.GoogleFileID,
+		Title:      a.Document.Title,
+		DocType:    a.Document.DocumentType.Name,
+		// The document is a draft if it's in WIP status and wasn't imported.
+		IsDraft: a.Document.Status == models.WIPDocumentStatus &&
+			!a.Document.Imported,
+		CreatedTime: a.CreatedAt.Unix(),
+	}
+}
+
+// recordActivity records user activity on a document in the background. Errors
+// are logged because activity must not fail the request that caused it.
+func recordActivity(
+	srv server.Server,
+	r *http.Request,
+	email string,
+	model models.Document,
+	action models.UserActivityAction,
+) {
+	now := time.Now()
+	go func() {
+		if err := activity.Record(
+			srv.DB, email, model, action, now,
+		); err != nil {
+			srv.Logger.Error("error recording user activity",
+				"error", err,
+				"action", action,
+				"doc_id", model.GoogleFileID,
+				"method", r.Method,
+				"path", r.URL.Path,
+			)
+		}
+	}()
+}
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"net/http"
+	"runtime"
+
+	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/internal/version"
+	"github.com/hashicorp-forge/hermes/pkg/authz"
+)
+
+// AdminConfigResponse is the effective configuration of the running server.
+type AdminConfigResponse struct {
+	Version   string `json:"version"`
+	Revision  string `json:"revision,omitempty"`
+	GoVersion string `json:"goVersion"`
+
+	// Providers are the names of the providers in use.
+	Providers AdminConfigProviders `json:"providers"`
+
+	// Settings are the effective settings keyed by their dotted HCL path, with
+	// secrets redacted.
+	Settings map[string]any `json:"settings"`
+}
+
+// AdminConfigProviders are the providers used by the running server.
+type AdminConfigProviders struct {
+	Search    string `json:"search,omitempty"`
+	Workspace string `json:"workspace,omitempty"`
+}
+
+// AdminConfigHandler returns the effective configuration of the running server
+// (GET /api/v2/admin/config) so operators can compare it with a config file.
+// Only site admins are allowed.
+func AdminConfigHandler(srv server.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
+		if r.Method != "GET" {
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
+			return
+		}
+
+		if !authorizeRequest(w, r, srv.Config, srv.DB, srv.Logger,
+			authz.ActionAdmin, authz.Resource{},
+			"Only site admins can view the server configuration",
+		) {
+			return
+		}
+
+		resp := AdminConfigResponse{
+			Version:   version.Version,
+			Revision:  version.GetShortRevision(),
+			GoVersion: runtime.Version(),
+			Settings:  map[string]any{},
+		}
+		if srv.Config != nil {
+			resp.Settings = srv.Config.EffectiveSettings()
+			if srv.Config.Providers != nil {
+				resp.Providers.Workspace = srv.Config.Providers.Workspace
+				resp.Providers.Search = srv.Config.Providers.Search
+			}
+		}
+		if srv.SearchProvider != nil {
+			resp.Providers.Search = srv.SearchProvider.Name()
+		}
+
+		writeAdminResponse(srv, w, r, http.StatusOK, resp)
+	})
+}
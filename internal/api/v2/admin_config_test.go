@@ -0,0 +1,71 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/internal/server"
+	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminConfigHandler(t *testing.T) {
+	srv := server.Server{
+		Config: &config.Config{
+			Authorization: &config.Authorization{
+				SiteAdmins: []string{"admin@example.com"},
+			},
+			BaseURL: "https://hermes.example.com",
+			Postgres: &config.Postgres{
+				Host:     "db.example.com",
+				Password: "postgres-secret",
+			},
+			Providers: &config.Providers{
+				Workspace: "local",
+				Search:    "meilisearch",
+			},
+		},
+		Logger: hclog.NewNullLogger(),
+	}
+
+	newRequest := func(method, userEmail string) *http.Request {
+		req := httptest.NewRequest(method, "/api/v2/admin/config", nil)
+		return req.WithContext(context.WithValue(
+			req.Context(), pkgauth.UserEmailKey, userEmail))
+	}
+
+	t.Run("site admin gets the effective configuration", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		AdminConfigHandler(srv).ServeHTTP(w, newRequest("GET", "admin@example.com"))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var resp AdminConfigResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.NotEmpty(t, resp.Version)
+		assert.NotEmpty(t, resp.GoVersion)
+		assert.Equal(t, "local", resp.Providers.Workspace)
+		assert.Equal(t, "meilisearch", resp.Providers.Search)
+		assert.Equal(t, "https://hermes.example.com", resp.Settings["base_url"])
+		assert.Equal(t, "db.example.com", resp.Settings["postgres.host"])
+		assert.Equal(t, config.RedactedValue, resp.Settings["postgres.password"])
+		assert.NotContains(t, w.Body.String(), "postgres-secret")
+	})
+
+	t.Run("other users are forbidden", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		AdminConfigHandler(srv).ServeHTTP(w, newRequest("GET", "user@example.com"))
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("only GET is allowed", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		AdminConfigHandler(srv).ServeHTTP(w, newRequest("POST", "admin@example.com"))
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
@@ -0,0 +1,352 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/internal/server"
+	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+	"github.com/hashicorp-forge/hermes/pkg/authz"
+	"github.com/hashicorp-forge/hermes/pkg/document"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"gorm.io/gorm"
+)
+
+// AdminCustomField is the schema of a custom field of a document type.
+type AdminCustomField struct {
+	// Name is the key of the custom field in documents.
+	Name string `json:"name"`
+
+	// DisplayName identifies the custom field in the admin API.
+	DisplayName string `json:"displayName"`
+
+	// Type is the type of the custom field (e.g., "STRING" or "ENUM").
+	Type string `json:"type"`
+
+	// Options are the allowed values of enum custom fields.
+	Options []string `json:"options,omitempty"`
+
+	ReadOnly bool `json:"readOnly"`
+
+	// Configured is true if the custom field is defined in the configuration.
+	// Configured custom fields are reset to the configuration on startup, so
+	// they can't be changed with the API.
+	Configured bool `json:"configured"`
+
+	// Values is the number of documents with a value for the custom field.
+	Values int64 `json:"values"`
+}
+
+// AdminDocumentTypeCustomFields are the custom fields of a document type.
+type AdminDocumentTypeCustomFields struct {
+	DocumentType string             `json:"documentType"`
+	CustomFields []AdminCustomField `json:"customFields"`
+}
+
+// AdminCustomFieldPutRequest contains the schema of a custom field to create
+// or update.
+type AdminCustomFieldPutRequest struct {
+	// Type is the type of the custom field: "boolean", "date", "enum",
+	// "number", "people", "person", or "string".
+	Type string `json:"type"`
+
+	// Options are the allowed values of enum custom fields.
+	Options []string `json:"options,omitempty"`
+
+	ReadOnly bool `json:"readOnly"`
+}
+
+var adminCustomFieldURLPathRE = regexp.MustCompile(
+	`^/api/v2/admin/custom-fields(?:/([^/]+)/([^/]+))?/?$`)
+
+// AdminCustomFieldsHandler manages the custom field schemas of document types.
+//
+// GET    /api/v2/admin/custom-fields                   - List custom fields
+// PUT    /api/v2/admin/custom-fields/:docType/:name    - Create or update one
+// DELETE /api/v2/admin/custom-fields/:docType/:name    - Delete one
+//
+// Schemas evolve without breaking documents: the type of a custom field can
+// only change while no document has a value, enum options in use can't be
+// removed, and custom fields with values can't be deleted. Only site admins
+// are allowed.
+func AdminCustomFieldsHandler(srv server.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
+		if !authorizeRequest(w, r, srv.Config, srv.DB, srv.Logger,
+			authz.ActionAdmin, authz.Resource{},
+			"Only site admins can manage custom fields",
+		) {
+			return
+		}
+		userEmail := pkgauth.MustGetUserEmail(r.Context())
+
+		errResp := func(httpCode int, userErrMsg, logErrMsg string, err error) {
+			respondError(w, r, srv.Logger, httpCode, userErrMsg, logErrMsg, err)
+		}
+
+		matches := adminCustomFieldURLPathRE.FindStringSubmatch(r.URL.Path)
+		if matches == nil {
+			writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound, "Not found")
+			return
+		}
+		docTypeName, name := matches[1], matches[2]
+
+		if docTypeName == "" {
+			if r.Method != "GET" {
+				writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+					"Method not allowed")
+				return
+			}
+
+			var docTypes models.DocumentTypes
+			if err := srv.DB.
+				Preload("CustomFields").
+				Order("name").
+				Find(&docTypes).
+				Error; err != nil {
+				errResp(http.StatusInternalServerError,
+					"Error getting custom fields",
+					"error finding document types", err)
+				return
+			}
+
+			resp := []AdminDocumentTypeCustomFields{}
+			for _, dt := range docTypes {
+				dtResp := AdminDocumentTypeCustomFields{
+					DocumentType: dt.Name,
+					CustomFields: []AdminCustomField{},
+				}
+				for _, cf := range dt.CustomFields {
+					f, err := adminCustomFieldResponse(srv, dt.Name, cf)
+					if err != nil {
+						errResp(http.StatusInternalServerError,
+							"Error getting custom fields",
+							"error building custom field response", err)
+						return
+					}
+					dtResp.CustomFields = append(dtResp.CustomFields, f)
+				}
+				resp = append(resp, dtResp)
+			}
+			writeAdminResponse(srv, w, r, http.StatusOK, resp)
+			return
+		}
+
+		if configuredCustomField(srv.Config, docTypeName, name) != nil {
+			writeProblem(w, r, http.StatusConflict, ErrCodeConflict,
+				"Custom field is defined in the configuration and can only be "+
+					"changed there")
+			return
+		}
+
+		// Get the existing custom field, if any.
+		cf := models.DocumentTypeCustomField{
+			Name: name,
+			DocumentType: models.DocumentType{
+				Name: docTypeName,
+			},
+		}
+		exists := true
+		if err := cf.Get(srv.DB); err != nil {
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				errResp(http.StatusInternalServerError,
+					"Error getting custom field",
+					"error getting document type custom field", err)
+				return
+			}
+			exists = false
+		}
+		if cf.DocumentType.ID == 0 {
+			writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound,
+				"Document type not found")
+			return
+		}
+
+		switch r.Method {
+		case "PUT":
+			var req AdminCustomFieldPutRequest
+			if err := decodeRequest(r, &req); err != nil {
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					fmt.Sprintf("Bad request: %q", err))
+				return
+			}
+			// Custom fields changed with the API follow the same rules as
+			// configured ones.
+			if err := (&config.DocumentTypeCustomField{
+				Name:    name,
+				Type:    req.Type,
+				Options: req.Options,
+			}).Validate(); err != nil {
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					fmt.Sprintf("Bad request: %v", err))
+				return
+			}
+			typ, _ := models.ParseDocumentTypeCustomFieldType(req.Type)
+
+			if exists {
+				if msg, err := customFieldChangeConflict(
+					srv.DB, cf, typ, req.Options); err != nil {
+					errResp(http.StatusInternalServerError,
+						"Error updating custom field",
+						"error checking custom field values", err)
+					return
+				} else if msg != "" {
+					writeProblem(w, r, http.StatusConflict, ErrCodeConflict, msg)
+					return
+				}
+			}
+
+			cf.Type = typ
+			cf.ReadOnly = req.ReadOnly
+			if err := cf.SetOptions(req.Options); err != nil {
+				errResp(http.StatusInternalServerError,
+					"Error updating custom field",
+					"error setting custom field options", err)
+				return
+			}
+			if err := cf.Upsert(srv.DB); err != nil {
+				errResp(http.StatusInternalServerError,
+					"Error updating custom field",
+					"error upserting document type custom field", err)
+				return
+			}
+
+			srv.Logger.Info("updated custom field",
+				"document_type", docTypeName,
+				"custom_field", name,
+				"type", req.Type,
+				"updated_by", userEmail,
+			)
+			resp, err := adminCustomFieldResponse(srv, docTypeName, cf)
+			if err != nil {
+				errResp(http.StatusInternalServerError,
+					"Error updating custom field",
+					"error building custom field response", err)
+				return
+			}
+			status := http.StatusOK
+			if !exists {
+				status = http.StatusCreated
+			}
+			writeAdminResponse(srv, w, r, status, resp)
+
+		case "DELETE":
+			if !exists {
+				writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound,
+					"Custom field not found")
+				return
+			}
+			n, err := cf.CountValues(srv.DB)
+			if err != nil {
+				errResp(http.StatusInternalServerError,
+					"Error deleting custom field",
+					"error counting custom field values", err)
+				return
+			}
+			if n > 0 {
+				writeProblem(w, r, http.StatusConflict, ErrCodeConflict,
+					fmt.Sprintf("Custom field has values in %d document(s)", n))
+				return
+			}
+			if err := cf.Delete(srv.DB); err != nil {
+				errResp(http.StatusInternalServerError,
+					"Error deleting custom field",
+					"error deleting document type custom field", err)
+				return
+			}
+
+			srv.Logger.Info("deleted custom field",
+				"document_type", docTypeName,
+				"custom_field", name,
+				"deleted_by", userEmail,
+			)
+			w.WriteHeader(http.StatusNoContent)
+
+		default:
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
+		}
+	})
+}
+
+// adminCustomFieldResponse returns the admin API response for a custom field
+// of a document type.
+func adminCustomFieldResponse(
+	srv server.Server, docType string, cf models.DocumentTypeCustomField,
+) (AdminCustomField, error) {
+	s, err := document.NewCustomFieldSchema(cf)
+	if err != nil {
+		return AdminCustomField{}, err
+	}
+	n, err := cf.CountValues(srv.DB)
+	if err != nil {
+		return AdminCustomField{}, err
+	}
+	return AdminCustomField{
+		Name:        s.Name,
+		DisplayName: s.DisplayName,
+		Type:        s.Type,
+		Options:     s.Options,
+		ReadOnly:    s.ReadOnly,
+		Configured:  configuredCustomField(srv.Config, docType, cf.Name) != nil,
+		Values:      n,
+	}, nil
+}
+
+// configuredCustomField returns the custom field of a document type defined in
+// the configuration, or nil.
+func configuredCustomField(
+	cfg *config.Config, docType, name string,
+) *config.DocumentTypeCustomField {
+	dt := configuredDocumentType(cfg, docType)
+	if dt == nil {
+		return nil
+	}
+	for _, cf := range dt.CustomFields {
+		if cf.Name == name {
+			return cf
+		}
+	}
+	return nil
+}
+
+// customFieldChangeConflict returns why changing the type or options of a
+// custom field would break documents with values for it, or an empty string
+// if it wouldn't.
+func customFieldChangeConflict(
+	db *gorm.DB,
+	cf models.DocumentTypeCustomField,
+	typ models.DocumentTypeCustomFieldType,
+	options []string,
+) (string, error) {
+	if typ != cf.Type {
+		n, err := cf.CountValues(db)
+		if err != nil {
+			return "", err
+		}
+		if n > 0 {
+			return fmt.Sprintf(
+				"The type of a custom field with values in %d document(s) "+
+					"can't be changed", n), nil
+		}
+		return "", nil
+	}
+
+	if typ == models.EnumDocumentTypeCustomFieldType {
+		vals, err := cf.Values(db)
+		if err != nil {
+			return "", err
+		}
+		for _, v := range vals {
+			if !slices.Contains(options, v) {
+				return fmt.Sprintf(
+					"Option %q is in use and can't be removed", v), nil
+			}
+		}
+	}
+	return "", nil
+}
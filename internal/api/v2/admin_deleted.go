@@ -0,0 +1,297 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/internal/server"
+	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+	"github.com/hashicorp-forge/hermes/pkg/authz"
+	"github.com/hashicorp-forge/hermes/pkg/document"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp-forge/hermes/pkg/purge"
+	"github.com/hashicorp-forge/hermes/pkg/tenant"
+	"gorm.io/gorm"
+)
+
+// DeletedDocument is a deleted document that can be restored.
+type DeletedDocument struct {
+	ID        string    `json:"id"`
+	Title     string    `json:"title"`
+	DocType   string    `json:"docType"`
+	Product   string    `json:"product"`
+	Owner     string    `json:"owner,omitempty"`
+	Draft     bool      `json:"draft"`
+	DeletedAt time.Time `json:"deletedAt"`
+
+	// PurgeAt is when the document is purged and can no longer be restored.
+	PurgeAt time.Time `json:"purgeAt"`
+}
+
+// DeletedProject is a deleted project that can be restored.
+type DeletedProject struct {
+	ID        uint      `json:"id"`
+	Title     string    `json:"title"`
+	Creator   string    `json:"creator,omitempty"`
+	DeletedAt time.Time `json:"deletedAt"`
+
+	// PurgeAt is when the project is purged and can no longer be restored.
+	PurgeAt time.Time `json:"purgeAt"`
+}
+
+var adminDeletedURLPathRE = regexp.MustCompile(
+	`^/api/v2/admin/deleted/(documents|projects)(?:/([0-9A-Za-z_\-]+)/restore)?/?$`)
+
+// AdminDeletedHandler lists and restores deleted documents and projects
+// within the recovery window, after which they are purged.
+//
+// GET  /api/v2/admin/deleted/documents              - List deleted documents
+// POST /api/v2/admin/deleted/documents/:id/restore  - Restore a document
+// GET  /api/v2/admin/deleted/projects               - List deleted projects
+// POST /api/v2/admin/deleted/projects/:id/restore   - Restore a project
+//
+// Only site admins are allowed. Tenant admins are allowed to list and restore
+// the deleted documents of their tenant.
+func AdminDeletedHandler(srv server.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
+		tenantID := tenant.IDFromContext(r.Context())
+		if !authorizeRequest(w, r, srv.Config, srv.DB, srv.Logger,
+			authz.ActionTenantAdmin, authz.Resource{TenantID: tenantID},
+			"Only site admins can restore deleted documents and projects",
+		) {
+			return
+		}
+		userEmail := pkgauth.MustGetUserEmail(r.Context())
+
+		matches := adminDeletedURLPathRE.FindStringSubmatch(r.URL.Path)
+		if matches == nil {
+			writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound, "Not found")
+			return
+		}
+		kind, id := matches[1], matches[2]
+
+		// Projects aren't scoped to tenants.
+		if kind == "projects" &&
+			!authorizeRequest(w, r, srv.Config, srv.DB, srv.Logger,
+				authz.ActionAdmin, authz.Resource{},
+				"Only site admins can restore deleted projects",
+			) {
+			return
+		}
+
+		wantMethod := "GET"
+		if id != "" {
+			wantMethod = "POST"
+		}
+		if r.Method != wantMethod {
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
+			return
+		}
+
+		window := recoveryWindow(srv.Config)
+		cutoff := time.Now().Add(-window)
+
+		switch {
+		case kind == "documents" && id == "":
+			var docs models.Documents
+			if err := docs.FindDeleted(srv.DB,
+				"documents.tenant_id = ? AND documents.deleted_at >= ?",
+				tenantID, cutoff); err != nil {
+				respondError(w, r, srv.Logger, http.StatusInternalServerError,
+					"Error getting deleted documents",
+					"error finding deleted documents", err)
+				return
+			}
+
+			resp := []DeletedDocument{}
+			for _, d := range docs {
+				dd := DeletedDocument{
+					ID:        d.GoogleFileID,
+					Title:     d.Title,
+					DocType:   d.DocumentType.Name,
+					Product:   d.Product.Name,
+					Draft:     d.Status == models.WIPDocumentStatus,
+					DeletedAt: d.DeletedAt.Time,
+					PurgeAt:   d.DeletedAt.Time.Add(window),
+				}
+				if d.Owner != nil {
+					dd.Owner = d.Owner.EmailAddress
+				}
+				resp = append(resp, dd)
+			}
+			writeAdminResponse(srv, w, r, http.StatusOK, resp)
+
+		case kind == "documents":
+			var docs models.Documents
+			if err := docs.FindDeleted(srv.DB,
+				"documents.google_file_id = ? AND documents.tenant_id = ? AND "+
+					"documents.deleted_at >= ?",
+				id, tenantID, cutoff); err != nil {
+				respondError(w, r, srv.Logger, http.StatusInternalServerError,
+					"Error restoring document",
+					"error finding deleted document", err)
+				return
+			}
+			if len(docs) == 0 {
+				writeProblem(w, r, http.StatusNotFound, ErrCodeDocumentNotFound,
+					"Deleted document not found")
+				return
+			}
+
+			doc := models.Document{GoogleFileID: id}
+			if err := doc.Restore(srv.DB); err != nil {
+				respondError(w, r, srv.Logger, http.StatusInternalServerError,
+					"Error restoring document",
+					"error restoring document", err, "doc_id", id)
+				return
+			}
+			if err := indexRestoredDocument(r.Context(), srv, doc); err != nil {
+				srv.Logger.Error("error indexing restored document",
+					"error", err,
+					"method", r.Method,
+					"path", r.URL.Path,
+					"doc_id", id,
+				)
+			}
+
+			srv.Logger.Info("restored document",
+				"doc_id", id,
+				"restored_by", userEmail,
+			)
+			w.WriteHeader(http.StatusNoContent)
+
+		case id == "":
+			var projs models.Projects
+			if err := projs.FindDeleted(
+				srv.DB, "projects.deleted_at >= ?", cutoff); err != nil {
+				respondError(w, r, srv.Logger, http.StatusInternalServerError,
+					"Error getting deleted projects",
+					"error finding deleted projects", err)
+				return
+			}
+
+			resp := []DeletedProject{}
+			for _, p := range projs {
+				resp = append(resp, DeletedProject{
+					ID:        p.ID,
+					Title:     p.Title,
+					Creator:   p.Creator.EmailAddress,
+					DeletedAt: p.DeletedAt.Time,
+					PurgeAt:   p.DeletedAt.Time.Add(window),
+				})
+			}
+			writeAdminResponse(srv, w, r, http.StatusOK, resp)
+
+		default:
+			projectID, err := strconv.ParseUint(id, 10, 64)
+			if err != nil {
+				writeProblem(w, r, http.StatusNotFound, ErrCodeProjectNotFound,
+					"Deleted project not found")
+				return
+			}
+			var projs models.Projects
+			if err := projs.FindDeleted(srv.DB,
+				"projects.id = ? AND projects.deleted_at >= ?",
+				projectID, cutoff); err != nil {
+				respondError(w, r, srv.Logger, http.StatusInternalServerError,
+					"Error restoring project",
+					"error finding deleted project", err)
+				return
+			}
+			if len(projs) == 0 {
+				writeProblem(w, r, http.StatusNotFound, ErrCodeProjectNotFound,
+					"Deleted project not found")
+				return
+			}
+
+			var proj models.Project
+			if err := proj.Restore(srv.DB, uint(projectID)); err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					writeProblem(w, r, http.StatusNotFound, ErrCodeProjectNotFound,
+						"Deleted project not found")
+					return
+				}
+				respondError(w, r, srv.Logger, http.StatusInternalServerError,
+					"Error restoring project",
+					"error restoring project", err, "project_id", projectID)
+				return
+			}
+			if err := saveProjectInAlgolia(proj, srv.SearchProvider); err != nil {
+				srv.Logger.Error("error indexing restored project",
+					"error", err,
+					"method", r.Method,
+					"path", r.URL.Path,
+					"project_id", projectID,
+				)
+			}
+
+			srv.Logger.Info("restored project",
+				"project_id", projectID,
+				"restored_by", userEmail,
+			)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+}
+
+// recoveryWindow returns how long deleted documents and projects can be
+// restored.
+func recoveryWindow(cfg *config.Config) time.Duration {
+	if cfg != nil && cfg.SoftDelete != nil && cfg.SoftDelete.RecoveryWindow > 0 {
+		return cfg.SoftDelete.RecoveryWindow
+	}
+	return purge.DefaultRecoveryWindow
+}
+
+// indexRestoredDocument saves a restored document in the draft or document
+// search index, depending on its status.
+func indexRestoredDocument(
+	ctx context.Context, srv server.Server, model models.Document,
+) error {
+	var reviews models.DocumentReviews
+	if err := reviews.Find(srv.DB, models.DocumentReview{
+		Document: models.Document{
+			Model: gorm.Model{
+				ID: model.ID,
+			},
+		},
+	}); err != nil {
+		return fmt.Errorf("error getting reviews: %w", err)
+	}
+	var groupReviews models.DocumentGroupReviews
+	if err := groupReviews.Find(srv.DB, models.DocumentGroupReview{
+		Document: models.Document{
+			Model: gorm.Model{
+				ID: model.ID,
+			},
+		},
+	}); err != nil {
+		return fmt.Errorf("error getting group reviews: %w", err)
+	}
+
+	doc, err := document.NewFromDatabaseModel(model, reviews, groupReviews)
+	if err != nil {
+		return fmt.Errorf("error converting database model: %w", err)
+	}
+	docObjMap, err := doc.ToAlgoliaObject(true)
+	if err != nil {
+		return fmt.Errorf("error converting document to search object: %w", err)
+	}
+	docObj, err := mapToSearchDocument(docObjMap)
+	if err != nil {
+		return fmt.Errorf("error converting document to search document: %w", err)
+	}
+
+	if model.Status == models.WIPDocumentStatus {
+		return srv.SearchProvider.DraftIndex().Index(ctx, docObj)
+	}
+	return srv.SearchProvider.DocumentIndex().Index(ctx, docObj)
+}
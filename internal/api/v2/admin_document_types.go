@@ -0,0 +1,834 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/internal/config/decode"
+	"github.com/hashicorp-forge/hermes/internal/server"
+	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+	"github.com/hashicorp-forge/hermes/pkg/authz"
+	"github.com/hashicorp-forge/hermes/pkg/document"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+	"gorm.io/gorm"
+)
+
+// AdminDocumentType is a document type and its review workflow.
+type AdminDocumentType struct {
+	// Name is the name of the document type, generally an abbreviation (e.g.,
+	// "RFC").
+	Name string `json:"name"`
+
+	AdminDocumentTypePutRequest
+
+	// Configured is true if the document type is defined in the
+	// configuration. Configured document types are reset to the configuration
+	// on startup, so they can't be changed with the API.
+	Configured bool `json:"configured"`
+
+	// Documents is the number of documents of the document type.
+	Documents int64 `json:"documents"`
+}
+
+// AdminDocumentTypePutRequest contains a document type to create or update.
+type AdminDocumentTypePutRequest struct {
+	LongName    string `json:"longName"`
+	Description string `json:"description"`
+
+	// FlightIcon is the name of the Helios flight icon of the document type.
+	FlightIcon string `json:"flightIcon"`
+
+	// Template is the file ID of the default template of the document type.
+	Template string `json:"template"`
+
+	// TemplateVariants are other templates drafts can be created from.
+	TemplateVariants []AdminDocumentTypeTemplate `json:"templateVariants"`
+
+	MoreInfoLink *AdminDocumentTypeLink `json:"moreInfoLink,omitempty"`
+
+	// Checks must be acknowledged to publish documents of the document type.
+	Checks []AdminDocumentTypeCheck `json:"checks"`
+
+	// CustomFields is the custom field schema of the document type.
+	CustomFields []AdminDocumentTypeCustomField `json:"customFields"`
+
+	Workflow AdminDocumentTypeWorkflow `json:"workflow"`
+}
+
+// AdminDocumentTypeTemplate is a named template of a document type.
+type AdminDocumentTypeTemplate struct {
+	Name        string `json:"name"`
+	LongName    string `json:"longName"`
+	Description string `json:"description"`
+
+	// Template is the file ID of the template.
+	Template string `json:"template"`
+}
+
+// AdminDocumentTypeLink is a link of a document type.
+type AdminDocumentTypeLink struct {
+	Text string `json:"text"`
+	URL  string `json:"url"`
+}
+
+// AdminDocumentTypeCheck is a check box that must be acknowledged to publish
+// documents of a document type.
+type AdminDocumentTypeCheck struct {
+	Label      string                  `json:"label"`
+	HelperText string                  `json:"helperText"`
+	Links      []AdminDocumentTypeLink `json:"links"`
+}
+
+// AdminDocumentTypeCustomField is a custom field of a document type.
+type AdminDocumentTypeCustomField struct {
+	Name string `json:"name"`
+
+	// Type is the type of the custom field: "boolean", "date", "enum",
+	// "number", "people", "person", or "string".
+	Type string `json:"type"`
+
+	// Options are the allowed values of enum custom fields.
+	Options []string `json:"options,omitempty"`
+
+	ReadOnly bool `json:"readOnly"`
+}
+
+// AdminDocumentTypeWorkflow is the review workflow of documents of a document
+// type.
+type AdminDocumentTypeWorkflow struct {
+	// ReviewSLA is how long documents are expected to be in review before
+	// they're approved (e.g., "120h"). Reviews aren't tracked if it's empty.
+	ReviewSLA string `json:"reviewSLA,omitempty"`
+
+	// RequiredApprovals is the number of approvals documents need before they
+	// can be marked approved.
+	RequiredApprovals int `json:"requiredApprovals"`
+
+	// HeaderCustomFields are the names of the custom fields shown in document
+	// headers, in order. All custom fields are shown if it's empty.
+	HeaderCustomFields []string `json:"headerCustomFields"`
+
+	// ReviewChecklist are the items of the checklist completed on documents
+	// during review.
+	ReviewChecklist []AdminDocumentTypeChecklistItem `json:"reviewChecklist"`
+}
+
+// AdminDocumentTypeChecklistItem is an item of the review checklist of a
+// document type.
+type AdminDocumentTypeChecklistItem struct {
+	Name       string `json:"name"`
+	Label      string `json:"label"`
+	HelperText string `json:"helperText"`
+
+	// RequiredFor is the document status ("In-Review" or "Approved") that the
+	// item must be checked before documents can move to, or empty if the item
+	// is optional.
+	RequiredFor string `json:"requiredFor"`
+}
+
+// AdminDocumentTypesImportRequest contains document types to import.
+type AdminDocumentTypesImportRequest struct {
+	// Config is a document_types configuration block, like the export.
+	Config string `json:"config" validate:"required"`
+}
+
+// AdminDocumentTypesImportResponse is the result of importing document types.
+type AdminDocumentTypesImportResponse struct {
+	// Imported are the names of the document types created or updated.
+	Imported []string `json:"imported"`
+
+	// Skipped are the names of the document types that are defined in the
+	// configuration, which can't be changed with the API.
+	Skipped []string `json:"skipped"`
+
+	// Warnings are the unknown settings in the imported configuration, which
+	// were ignored.
+	Warnings []string `json:"warnings"`
+}
+
+var adminDocumentTypeURLPathRE = regexp.MustCompile(
+	`^/api/v2/admin/document-types(?:/([^/]+))?/?$`)
+
+// documentTypeNameRE matches the names of document types managed with the
+// admin API, which are used in URL paths.
+var documentTypeNameRE = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]{0,99}$`)
+
+// reservedDocumentTypeNames are path segments of the admin API that can't be
+// document type names.
+var reservedDocumentTypeNames = map[string]bool{
+	"export": true,
+	"import": true,
+}
+
+// AdminDocumentTypesHandler manages document types without restarting the
+// server.
+//
+// GET    /api/v2/admin/document-types         - List document types
+// GET    /api/v2/admin/document-types/export  - Export them as HCL
+// POST   /api/v2/admin/document-types/import  - Import HCL document types
+// GET    /api/v2/admin/document-types/:name   - Get a document type
+// PUT    /api/v2/admin/document-types/:name   - Create or update one
+// DELETE /api/v2/admin/document-types/:name   - Delete one
+//
+// Document types created with the API are stored in the database. Document
+// types defined in the configuration can only be changed there. The export is
+// a document_types configuration block, so document types can be moved between
+// the configuration and the database. Only site admins are allowed.
+func AdminDocumentTypesHandler(srv server.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
+		if !authorizeRequest(w, r, srv.Config, srv.DB, srv.Logger,
+			authz.ActionAdmin, authz.Resource{},
+			"Only site admins can manage document types",
+		) {
+			return
+		}
+		userEmail := pkgauth.MustGetUserEmail(r.Context())
+
+		errResp := func(httpCode int, userErrMsg, logErrMsg string, err error) {
+			respondError(w, r, srv.Logger, httpCode, userErrMsg, logErrMsg, err)
+		}
+		methodNotAllowed := func() {
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
+		}
+
+		matches := adminDocumentTypeURLPathRE.FindStringSubmatch(r.URL.Path)
+		if matches == nil {
+			writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound, "Not found")
+			return
+		}
+		name := matches[1]
+
+		switch name {
+		case "":
+			if r.Method != "GET" {
+				methodNotAllowed()
+				return
+			}
+			resp := []AdminDocumentType{}
+			for _, d := range documentTypes(r.Context(), srv) {
+				dt, err := adminDocumentTypeResponse(srv, d)
+				if err != nil {
+					errResp(http.StatusInternalServerError,
+						"Error getting document types",
+						"error building document type response", err)
+					return
+				}
+				resp = append(resp, dt)
+			}
+			writeAdminResponse(srv, w, r, http.StatusOK, resp)
+			return
+
+		case "export":
+			if r.Method != "GET" {
+				methodNotAllowed()
+				return
+			}
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			w.Header().Set("Content-Disposition",
+				`attachment; filename="document_types.hcl"`)
+			_, _ = w.Write(encodeDocumentTypes(documentTypes(r.Context(), srv)))
+			return
+
+		case "import":
+			if r.Method != "POST" {
+				methodNotAllowed()
+				return
+			}
+			var req AdminDocumentTypesImportRequest
+			if !decodeAndValidateRequest(w, r, srv.Logger, &req) {
+				return
+			}
+			var cfg struct {
+				DocumentTypes *config.DocumentTypes `hcl:"document_types,block"`
+			}
+			warnings, err := decode.Untrusted(
+				[]byte(req.Config), "document_types.hcl", &cfg)
+			if err != nil {
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					fmt.Sprintf("Bad request: %v", err))
+				return
+			}
+
+			resp := AdminDocumentTypesImportResponse{
+				Imported: []string{},
+				Skipped:  []string{},
+				Warnings: warnings,
+			}
+			if resp.Warnings == nil {
+				resp.Warnings = []string{}
+			}
+			var docTypes []*config.DocumentType
+			if cfg.DocumentTypes != nil {
+				for _, d := range cfg.DocumentTypes.DocumentType {
+					if configuredDocumentType(srv.Config, d.Name) != nil {
+						resp.Skipped = append(resp.Skipped, d.Name)
+						continue
+					}
+					if err := validateManagedDocumentType(d); err != nil {
+						writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+							fmt.Sprintf("Bad request: %v", err))
+						return
+					}
+					docTypes = append(docTypes, d)
+				}
+			}
+
+			// Check every document type before changing any, so an import is
+			// applied completely or not at all.
+			for _, d := range docTypes {
+				if msg, err := documentTypeChangeConflict(srv.DB, d); err != nil {
+					errResp(http.StatusInternalServerError,
+						"Error importing document types",
+						"error checking document type changes", err)
+					return
+				} else if msg != "" {
+					writeProblem(w, r, http.StatusConflict, ErrCodeConflict,
+						fmt.Sprintf("Document type %q: %s", d.Name, msg))
+					return
+				}
+			}
+			if err := srv.DB.Transaction(func(tx *gorm.DB) error {
+				for _, d := range docTypes {
+					if err := saveManagedDocumentType(tx, d); err != nil {
+						return fmt.Errorf("document type %q: %w", d.Name, err)
+					}
+				}
+				return nil
+			}); err != nil {
+				errResp(http.StatusInternalServerError,
+					"Error importing document types",
+					"error saving document types", err)
+				return
+			}
+			for _, d := range docTypes {
+				resp.Imported = append(resp.Imported, d.Name)
+			}
+
+			srv.Logger.Info("imported document types",
+				"imported", resp.Imported,
+				"skipped", resp.Skipped,
+				"imported_by", userEmail,
+			)
+			writeAdminResponse(srv, w, r, http.StatusOK, resp)
+			return
+		}
+
+		if configured := configuredDocumentType(srv.Config, name); configured != nil {
+			if r.Method == "GET" {
+				resp, err := adminDocumentTypeResponse(srv, configured)
+				if err != nil {
+					errResp(http.StatusInternalServerError,
+						"Error getting document type",
+						"error building document type response", err)
+					return
+				}
+				writeAdminResponse(srv, w, r, http.StatusOK, resp)
+				return
+			}
+			writeProblem(w, r, http.StatusConflict, ErrCodeConflict,
+				"Document type is defined in the configuration and can only be "+
+					"changed there")
+			return
+		}
+
+		// Get the existing document type, if any.
+		dt := models.DocumentType{
+			Name: name,
+		}
+		exists := true
+		if err := dt.Get(srv.DB); err != nil {
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				errResp(http.StatusInternalServerError,
+					"Error getting document type",
+					"error getting document type", err)
+				return
+			}
+			exists = false
+		}
+
+		switch r.Method {
+		case "GET":
+			if !exists {
+				writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound,
+					"Document type not found")
+				return
+			}
+			var ts models.DocumentTypeTemplates
+			if err := ts.Find(srv.DB, name); err != nil {
+				errResp(http.StatusInternalServerError,
+					"Error getting document type",
+					"error finding document type templates", err)
+				return
+			}
+			d, err := document.NewDocumentTypeFromModel(dt, ts)
+			if err != nil {
+				errResp(http.StatusInternalServerError,
+					"Error getting document type",
+					"error converting document type", err)
+				return
+			}
+			resp, err := adminDocumentTypeResponse(srv, d)
+			if err != nil {
+				errResp(http.StatusInternalServerError,
+					"Error getting document type",
+					"error building document type response", err)
+				return
+			}
+			writeAdminResponse(srv, w, r, http.StatusOK, resp)
+
+		case "PUT":
+			var req AdminDocumentTypePutRequest
+			if err := decodeRequest(r, &req); err != nil {
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					fmt.Sprintf("Bad request: %q", err))
+				return
+			}
+			d, err := newConfigDocumentType(name, req)
+			if err == nil {
+				err = validateManagedDocumentType(d)
+			}
+			if err != nil {
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					fmt.Sprintf("Bad request: %v", err))
+				return
+			}
+
+			if msg, err := documentTypeChangeConflict(srv.DB, d); err != nil {
+				errResp(http.StatusInternalServerError,
+					"Error updating document type",
+					"error checking document type changes", err)
+				return
+			} else if msg != "" {
+				writeProblem(w, r, http.StatusConflict, ErrCodeConflict, msg)
+				return
+			}
+			if err := srv.DB.Transaction(func(tx *gorm.DB) error {
+				return saveManagedDocumentType(tx, d)
+			}); err != nil {
+				errResp(http.StatusInternalServerError,
+					"Error updating document type",
+					"error saving document type", err)
+				return
+			}
+
+			srv.Logger.Info("updated document type",
+				"document_type", name,
+				"updated_by", userEmail,
+			)
+			resp, err := adminDocumentTypeResponse(srv, d)
+			if err != nil {
+				errResp(http.StatusInternalServerError,
+					"Error updating document type",
+					"error building document type response", err)
+				return
+			}
+			status := http.StatusOK
+			if !exists {
+				status = http.StatusCreated
+			}
+			writeAdminResponse(srv, w, r, status, resp)
+
+		case "DELETE":
+			if !exists {
+				writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound,
+					"Document type not found")
+				return
+			}
+			n, err := dt.CountDocuments(srv.DB)
+			if err != nil {
+				errResp(http.StatusInternalServerError,
+					"Error deleting document type",
+					"error counting documents", err)
+				return
+			}
+			if n > 0 {
+				writeProblem(w, r, http.StatusConflict, ErrCodeConflict,
+					fmt.Sprintf("Document type has %d document(s)", n))
+				return
+			}
+			if err := dt.Delete(srv.DB); err != nil {
+				errResp(http.StatusInternalServerError,
+					"Error deleting document type",
+					"error deleting document type", err)
+				return
+			}
+
+			srv.Logger.Info("deleted document type",
+				"document_type", name,
+				"deleted_by", userEmail,
+			)
+			w.WriteHeader(http.StatusNoContent)
+
+		default:
+			methodNotAllowed()
+		}
+	})
+}
+
+// adminDocumentTypeResponse returns the admin API response for document type
+// d.
+func adminDocumentTypeResponse(
+	srv server.Server, d *config.DocumentType,
+) (AdminDocumentType, error) {
+	resp := AdminDocumentType{
+		Name: d.Name,
+		AdminDocumentTypePutRequest: AdminDocumentTypePutRequest{
+			LongName:         d.LongName,
+			Description:      d.Description,
+			FlightIcon:       d.FlightIcon,
+			Template:         d.Template,
+			TemplateVariants: []AdminDocumentTypeTemplate{},
+			Checks:           []AdminDocumentTypeCheck{},
+			CustomFields:     []AdminDocumentTypeCustomField{},
+			Workflow: AdminDocumentTypeWorkflow{
+				RequiredApprovals:  d.RequiredApprovals,
+				HeaderCustomFields: []string{},
+				ReviewChecklist:    []AdminDocumentTypeChecklistItem{},
+			},
+		},
+		Configured: configuredDocumentType(srv.Config, d.Name) != nil,
+	}
+	for _, t := range d.TemplateVariants {
+		resp.TemplateVariants = append(resp.TemplateVariants,
+			AdminDocumentTypeTemplate{
+				Name:        t.Name,
+				LongName:    t.LongName,
+				Description: t.Description,
+				Template:    t.Template,
+			})
+	}
+	if d.MoreInfoLink != nil {
+		resp.MoreInfoLink = &AdminDocumentTypeLink{
+			Text: d.MoreInfoLink.Text,
+			URL:  d.MoreInfoLink.URL,
+		}
+	}
+	for _, c := range d.Checks {
+		check := AdminDocumentTypeCheck{
+			Label:      c.Label,
+			HelperText: c.HelperText,
+			Links:      []AdminDocumentTypeLink{},
+		}
+		for _, l := range c.Links {
+			check.Links = append(check.Links, AdminDocumentTypeLink{
+				Text: l.Text,
+				URL:  l.URL,
+			})
+		}
+		resp.Checks = append(resp.Checks, check)
+	}
+	for _, cf := range d.CustomFields {
+		resp.CustomFields = append(resp.CustomFields,
+			AdminDocumentTypeCustomField{
+				Name:     cf.Name,
+				Type:     cf.Type,
+				Options:  cf.Options,
+				ReadOnly: cf.ReadOnly,
+			})
+	}
+	if d.ReviewSLA > 0 {
+		resp.Workflow.ReviewSLA = d.ReviewSLA.String()
+	}
+	resp.Workflow.HeaderCustomFields = append(
+		resp.Workflow.HeaderCustomFields, d.HeaderCustomFields...)
+	for _, item := range d.ReviewChecklist {
+		resp.Workflow.ReviewChecklist = append(resp.Workflow.ReviewChecklist,
+			AdminDocumentTypeChecklistItem{
+				Name:        item.Name,
+				Label:       item.Label,
+				HelperText:  item.HelperText,
+				RequiredFor: item.RequiredFor,
+			})
+	}
+
+	if srv.DB != nil {
+		dt := models.DocumentType{Name: d.Name}
+		if err := srv.DB.
+			Where(dt).
+			Select("id").
+			Take(&dt).
+			Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return AdminDocumentType{}, err
+		}
+		if dt.ID != 0 {
+			n, err := dt.CountDocuments(srv.DB)
+			if err != nil {
+				return AdminDocumentType{}, err
+			}
+			resp.Documents = n
+		}
+	}
+	return resp, nil
+}
+
+// newConfigDocumentType returns the document type named name in request req
+// like in the configuration.
+func newConfigDocumentType(
+	name string, req AdminDocumentTypePutRequest,
+) (*config.DocumentType, error) {
+	d := &config.DocumentType{
+		Name:               name,
+		LongName:           req.LongName,
+		Description:        req.Description,
+		FlightIcon:         req.FlightIcon,
+		Template:           req.Template,
+		RequiredApprovals:  req.Workflow.RequiredApprovals,
+		HeaderCustomFields: req.Workflow.HeaderCustomFields,
+	}
+	if req.Workflow.ReviewSLA != "" {
+		sla, err := time.ParseDuration(req.Workflow.ReviewSLA)
+		if err != nil {
+			return nil, fmt.Errorf("invalid review SLA: %w", err)
+		}
+		d.ReviewSLA = sla
+	}
+	for _, t := range req.TemplateVariants {
+		d.TemplateVariants = append(d.TemplateVariants,
+			&config.DocumentTypeTemplateVariant{
+				Name:        t.Name,
+				LongName:    t.LongName,
+				Description: t.Description,
+				Template:    t.Template,
+			})
+	}
+	if req.MoreInfoLink != nil {
+		d.MoreInfoLink = &config.DocumentTypeLink{
+			Text: req.MoreInfoLink.Text,
+			URL:  req.MoreInfoLink.URL,
+		}
+	}
+	for _, c := range req.Checks {
+		check := &config.DocumentTypeCheck{
+			Label:      c.Label,
+			HelperText: c.HelperText,
+		}
+		for _, l := range c.Links {
+			check.Links = append(check.Links, &config.DocumentTypeLink{
+				Text: l.Text,
+				URL:  l.URL,
+			})
+		}
+		d.Checks = append(d.Checks, check)
+	}
+	for _, cf := range req.CustomFields {
+		d.CustomFields = append(d.CustomFields, &config.DocumentTypeCustomField{
+			Name:     cf.Name,
+			Type:     cf.Type,
+			Options:  cf.Options,
+			ReadOnly: cf.ReadOnly,
+		})
+	}
+	for _, item := range req.Workflow.ReviewChecklist {
+		d.ReviewChecklist = append(d.ReviewChecklist,
+			&config.DocumentTypeChecklistItem{
+				Name:        item.Name,
+				Label:       item.Label,
+				HelperText:  item.HelperText,
+				RequiredFor: item.RequiredFor,
+			})
+	}
+	return d, nil
+}
+
+// validateManagedDocumentType validates document type d to be managed with
+// the admin API. Document types managed with the API follow the same rules as
+// configured ones.
+func validateManagedDocumentType(d *config.DocumentType) error {
+	if !documentTypeNameRE.MatchString(d.Name) ||
+		reservedDocumentTypeNames[d.Name] {
+		return fmt.Errorf("invalid document type name %q", d.Name)
+	}
+	if d.Template == "" {
+		return fmt.Errorf("document type %q: template is required", d.Name)
+	}
+	for _, cf := range d.CustomFields {
+		if cf.Name == "" {
+			return fmt.Errorf("document type %q: custom field name is required",
+				d.Name)
+		}
+	}
+	return decode.Validate(d)
+}
+
+// documentTypeChangeConflict returns why saving document type d in database db
+// would break documents with values for its custom fields, or an empty string
+// if it wouldn't.
+func documentTypeChangeConflict(
+	db *gorm.DB, d *config.DocumentType,
+) (string, error) {
+	existing := models.DocumentType{Name: d.Name}
+	if err := existing.Get(db); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	for _, cf := range existing.CustomFields {
+		var updated *config.DocumentTypeCustomField
+		for _, f := range d.CustomFields {
+			if f.Name == cf.Name {
+				updated = f
+				break
+			}
+		}
+
+		if updated == nil {
+			n, err := cf.CountValues(db)
+			if err != nil {
+				return "", err
+			}
+			if n > 0 {
+				return fmt.Sprintf("Custom field %q has values in %d document(s) "+
+					"and can't be removed", cf.Name, n), nil
+			}
+			continue
+		}
+
+		typ, _ := models.ParseDocumentTypeCustomFieldType(updated.Type)
+		msg, err := customFieldChangeConflict(db, cf, typ, updated.Options)
+		if err != nil || msg != "" {
+			return msg, err
+		}
+	}
+	return "", nil
+}
+
+// saveManagedDocumentType creates or updates document type d, managed with the
+// admin API, in database db. Custom fields that d doesn't have are deleted.
+func saveManagedDocumentType(db *gorm.DB, d *config.DocumentType) error {
+	dt, templates, err := document.NewDocumentTypeModel(d)
+	if err != nil {
+		return err
+	}
+	dt.Managed = true
+
+	existing := models.DocumentType{Name: d.Name}
+	if err := existing.Get(db); err != nil &&
+		!errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("error getting document type: %w", err)
+	}
+	for _, cf := range existing.CustomFields {
+		if documentTypeCustomField(d, cf.Name) == nil {
+			if err := cf.Delete(db); err != nil {
+				return fmt.Errorf("error deleting custom field %q: %w", cf.Name, err)
+			}
+		}
+	}
+
+	if err := dt.Upsert(db); err != nil {
+		return fmt.Errorf("error upserting document type: %w", err)
+	}
+	if err := models.SetDocumentTypeTemplates(db, dt.ID, templates); err != nil {
+		return fmt.Errorf("error setting templates: %w", err)
+	}
+	return nil
+}
+
+// documentTypeCustomField returns the custom field named name of document type
+// d, or nil.
+func documentTypeCustomField(
+	d *config.DocumentType, name string,
+) *config.DocumentTypeCustomField {
+	for _, cf := range d.CustomFields {
+		if cf.Name == name {
+			return cf
+		}
+	}
+	return nil
+}
+
+// encodeDocumentTypes returns docTypes as a document_types configuration
+// block. Settings that aren't set are omitted.
+func encodeDocumentTypes(docTypes []*config.DocumentType) []byte {
+	f := hclwrite.NewEmptyFile()
+	body := f.Body().AppendNewBlock("document_types", nil).Body()
+
+	setString := func(b *hclwrite.Body, name, val string) {
+		if val != "" {
+			b.SetAttributeValue(name, cty.StringVal(val))
+		}
+	}
+	setStrings := func(b *hclwrite.Body, name string, vals []string) {
+		if len(vals) == 0 {
+			return
+		}
+		list := make([]cty.Value, 0, len(vals))
+		for _, v := range vals {
+			list = append(list, cty.StringVal(v))
+		}
+		b.SetAttributeValue(name, cty.ListVal(list))
+	}
+	appendLink := func(b *hclwrite.Body, name string, l *config.DocumentTypeLink) {
+		lb := b.AppendNewBlock(name, nil).Body()
+		lb.SetAttributeValue("text", cty.StringVal(l.Text))
+		lb.SetAttributeValue("url", cty.StringVal(l.URL))
+	}
+
+	for i, d := range docTypes {
+		if i > 0 {
+			body.AppendNewline()
+		}
+		b := body.AppendNewBlock("document_type", []string{d.Name}).Body()
+		setString(b, "long_name", d.LongName)
+		setString(b, "description", d.Description)
+		setString(b, "flight_icon", d.FlightIcon)
+		b.SetAttributeValue("template", cty.StringVal(d.Template))
+		if d.ReviewSLA > 0 {
+			b.SetAttributeValue("review_sla", cty.StringVal(d.ReviewSLA.String()))
+		}
+		if d.RequiredApprovals > 0 {
+			b.SetAttributeValue("required_approvals",
+				cty.NumberIntVal(int64(d.RequiredApprovals)))
+		}
+		setStrings(b, "header_custom_fields", d.HeaderCustomFields)
+
+		for _, t := range d.TemplateVariants {
+			b.AppendNewline()
+			tb := b.AppendNewBlock("template_variant", []string{t.Name}).Body()
+			setString(tb, "long_name", t.LongName)
+			setString(tb, "description", t.Description)
+			tb.SetAttributeValue("template", cty.StringVal(t.Template))
+		}
+		if d.MoreInfoLink != nil {
+			b.AppendNewline()
+			appendLink(b, "more_info_link", d.MoreInfoLink)
+		}
+		for _, c := range d.Checks {
+			b.AppendNewline()
+			cb := b.AppendNewBlock("check", nil).Body()
+			cb.SetAttributeValue("label", cty.StringVal(c.Label))
+			setString(cb, "helper_text", c.HelperText)
+			for _, l := range c.Links {
+				appendLink(cb, "link", l)
+			}
+		}
+		for _, cf := range d.CustomFields {
+			b.AppendNewline()
+			cb := b.AppendNewBlock("custom_field", nil).Body()
+			cb.SetAttributeValue("name", cty.StringVal(cf.Name))
+			cb.SetAttributeValue("type", cty.StringVal(cf.Type))
+			if cf.ReadOnly {
+				cb.SetAttributeValue("read_only", cty.True)
+			}
+			setStrings(cb, "options", cf.Options)
+		}
+		for _, item := range d.ReviewChecklist {
+			b.AppendNewline()
+			ib := b.AppendNewBlock("review_checklist_item", nil).Body()
+			ib.SetAttributeValue("name", cty.StringVal(item.Name))
+			ib.SetAttributeValue("label", cty.StringVal(item.Label))
+			setString(ib, "helper_text", item.HelperText)
+			setString(ib, "required_for", item.RequiredFor)
+		}
+	}
+	return f.Bytes()
+}
@@ -0,0 +1,218 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/internal/config/decode"
+	"github.com/hashicorp-forge/hermes/internal/server"
+	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminDocumentTypesHandler(t *testing.T) {
+	rfc := &config.DocumentType{
+		Name:              "RFC",
+		LongName:          "Request for Comments",
+		Template:          "rfc-template",
+		ReviewSLA:         120 * time.Hour,
+		RequiredApprovals: 2,
+	}
+	srv := server.Server{
+		Config: &config.Config{
+			Authorization: &config.Authorization{
+				SiteAdmins: []string{"admin@example.com"},
+			},
+			DocumentTypes: &config.DocumentTypes{
+				DocumentType: []*config.DocumentType{rfc},
+			},
+		},
+		Logger: hclog.NewNullLogger(),
+	}
+
+	newRequest := func(method, target, userEmail string, body any) *http.Request {
+		var b bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&b).Encode(body))
+		}
+		req := httptest.NewRequest(method, target, &b)
+		return req.WithContext(context.WithValue(
+			req.Context(), pkgauth.UserEmailKey, userEmail))
+	}
+
+	t.Run("other users are forbidden", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		AdminDocumentTypesHandler(srv).ServeHTTP(w, newRequest(
+			"GET", "/api/v2/admin/document-types", "user@example.com", nil))
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("list includes configured document types", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		AdminDocumentTypesHandler(srv).ServeHTTP(w, newRequest(
+			"GET", "/api/v2/admin/document-types", "admin@example.com", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var resp []AdminDocumentType
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		require.Len(t, resp, 1)
+		assert.Equal(t, "RFC", resp[0].Name)
+		assert.True(t, resp[0].Configured)
+		assert.Equal(t, "120h0m0s", resp[0].Workflow.ReviewSLA)
+		assert.Equal(t, 2, resp[0].Workflow.RequiredApprovals)
+	})
+
+	t.Run("configured document types can't be changed", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		AdminDocumentTypesHandler(srv).ServeHTTP(w, newRequest(
+			"PUT", "/api/v2/admin/document-types/RFC", "admin@example.com",
+			AdminDocumentTypePutRequest{Template: "other-template"}))
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("export", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		AdminDocumentTypesHandler(srv).ServeHTTP(w, newRequest(
+			"GET", "/api/v2/admin/document-types/export", "admin@example.com", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `document_type "RFC" {`)
+		assert.Contains(t, w.Body.String(), `review_sla`)
+	})
+}
+
+func TestEncodeDocumentTypes(t *testing.T) {
+	docTypes := []*config.DocumentType{
+		{
+			Name:        "RFC",
+			LongName:    "Request for Comments",
+			Description: "Propose a change.",
+			FlightIcon:  "discussion-circle",
+			Template:    "rfc-template",
+			TemplateVariants: []*config.DocumentTypeTemplateVariant{
+				{
+					Name:     "short",
+					LongName: "Short RFC",
+					Template: "short-rfc-template",
+				},
+			},
+			MoreInfoLink: &config.DocumentTypeLink{
+				Text: "When should I create an RFC?",
+				URL:  "https://example.com/rfc",
+			},
+			Checks: []*config.DocumentTypeCheck{
+				{
+					Label: "I have read the guidelines",
+					Links: []*config.DocumentTypeLink{
+						{Text: "Guidelines", URL: "https://example.com/guidelines"},
+					},
+				},
+			},
+			CustomFields: []*config.DocumentTypeCustomField{
+				{Name: "Current Version", Type: "string"},
+				{Name: "Stage", Type: "enum", Options: []string{"Draft", "Final"}},
+				{Name: "Stakeholders", Type: "people", ReadOnly: true},
+			},
+			ReviewSLA:          36 * time.Hour,
+			RequiredApprovals:  2,
+			HeaderCustomFields: []string{"Stakeholders", "Stage"},
+			ReviewChecklist: []*config.DocumentTypeChecklistItem{
+				{
+					Name:        "security-review",
+					Label:       "Security review done",
+					HelperText:  "Link the security review in the document.",
+					RequiredFor: "In-Review",
+				},
+				{Name: "test-plan", Label: "Test plan linked"},
+			},
+		},
+		{
+			Name:     "PRD",
+			Template: "prd-template",
+		},
+	}
+
+	var cfg struct {
+		DocumentTypes *config.DocumentTypes `hcl:"document_types,block"`
+	}
+	warnings, err := decode.Untrusted(
+		encodeDocumentTypes(docTypes), "document_types.hcl", &cfg)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	require.NotNil(t, cfg.DocumentTypes)
+	assert.Equal(t, docTypes, cfg.DocumentTypes.DocumentType)
+}
+
+func TestValidateManagedDocumentType(t *testing.T) {
+	req := AdminDocumentTypePutRequest{
+		Template: "template",
+		CustomFields: []AdminDocumentTypeCustomField{
+			{Name: "Stage", Type: "enum", Options: []string{"Draft"}},
+		},
+		Workflow: AdminDocumentTypeWorkflow{
+			ReviewSLA:          "48h",
+			HeaderCustomFields: []string{"Stage"},
+			ReviewChecklist: []AdminDocumentTypeChecklistItem{
+				{Name: "legal", Label: "Legal sign-off", RequiredFor: "Approved"},
+			},
+		},
+	}
+	d, err := newConfigDocumentType("ADR", req)
+	require.NoError(t, err)
+	assert.Equal(t, 48*time.Hour, d.ReviewSLA)
+	assert.NoError(t, validateManagedDocumentType(d))
+
+	for name, change := range map[string]func(*AdminDocumentTypePutRequest){
+		"missing template": func(r *AdminDocumentTypePutRequest) {
+			r.Template = ""
+		},
+		"invalid custom field type": func(r *AdminDocumentTypePutRequest) {
+			r.CustomFields[0].Type = "color"
+		},
+		"unknown header custom field": func(r *AdminDocumentTypePutRequest) {
+			r.Workflow.HeaderCustomFields = []string{"Owner"}
+		},
+		"negative required approvals": func(r *AdminDocumentTypePutRequest) {
+			r.Workflow.RequiredApprovals = -1
+		},
+		"invalid checklist item status": func(r *AdminDocumentTypePutRequest) {
+			r.Workflow.ReviewChecklist = []AdminDocumentTypeChecklistItem{
+				{Name: "legal", Label: "Legal sign-off", RequiredFor: "Obsolete"},
+			}
+		},
+		"duplicate checklist item": func(r *AdminDocumentTypePutRequest) {
+			r.Workflow.ReviewChecklist = []AdminDocumentTypeChecklistItem{
+				{Name: "legal", Label: "Legal sign-off"},
+				{Name: "legal", Label: "Legal review"},
+			}
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			r := req
+			r.CustomFields = append(
+				[]AdminDocumentTypeCustomField{}, req.CustomFields...)
+			change(&r)
+			d, err := newConfigDocumentType("ADR", r)
+			require.NoError(t, err)
+			assert.Error(t, validateManagedDocumentType(d))
+		})
+	}
+
+	for _, name := range []string{"import", "a/b", ""} {
+		d, err := newConfigDocumentType(name, req)
+		require.NoError(t, err)
+		assert.Error(t, validateManagedDocumentType(d), name)
+	}
+
+	_, err = newConfigDocumentType("ADR", AdminDocumentTypePutRequest{
+		Workflow: AdminDocumentTypeWorkflow{ReviewSLA: "soon"},
+	})
+	assert.Error(t, err)
+}
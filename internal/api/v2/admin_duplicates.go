@@ -0,0 +1,131 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/pkg/authz"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+)
+
+// defaultDuplicatesLimit is the default maximum number of duplicates listed.
+const defaultDuplicatesLimit = 100
+
+// AdminDuplicate is a pair of documents that are likely duplicates.
+type AdminDuplicate struct {
+	Document  AdminDuplicateDocument `json:"document"`
+	Duplicate AdminDuplicateDocument `json:"duplicate"`
+
+	// Similarity is the estimated similarity of the content of the documents,
+	// from 0 to 1.
+	Similarity float64 `json:"similarity"`
+
+	// CrossProduct is true if the documents belong to different products.
+	CrossProduct bool      `json:"crossProduct"`
+	DetectedAt   time.Time `json:"detectedAt"`
+}
+
+// AdminDuplicateDocument is a document of a pair of likely duplicates.
+type AdminDuplicateDocument struct {
+	ID      string `json:"id"`
+	Title   string `json:"title"`
+	DocType string `json:"docType"`
+	Product string `json:"product"`
+	Status  string `json:"status"`
+	Owner   string `json:"owner,omitempty"`
+}
+
+// AdminDuplicatesHandler lists documents that are likely duplicates of each
+// other, as flagged by the duplicate_detection indexer pipeline step, most
+// similar first (GET /api/v2/admin/duplicates). The "minSimilarity" (0 to 1),
+// "crossProduct", and "limit" (default 100) query parameters filter the list.
+// Only site admins are allowed.
+func AdminDuplicatesHandler(srv server.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
+		if r.Method != "GET" {
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
+			return
+		}
+
+		if !authorizeRequest(w, r, srv.Config, srv.DB, srv.Logger,
+			authz.ActionAdmin, authz.Resource{},
+			"Only site admins can list duplicate documents",
+		) {
+			return
+		}
+
+		filter := models.DocumentDuplicateFilter{Limit: defaultDuplicatesLimit}
+		q := r.URL.Query()
+		if s := q.Get("minSimilarity"); s != "" {
+			v, err := strconv.ParseFloat(s, 64)
+			if err != nil || v < 0 || v > 1 {
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					"minSimilarity must be a number from 0 to 1")
+				return
+			}
+			filter.MinSimilarity = v
+		}
+		if s := q.Get("crossProduct"); s != "" {
+			v, err := strconv.ParseBool(s)
+			if err != nil {
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					"crossProduct must be a boolean")
+				return
+			}
+			filter.CrossProduct = v
+		}
+		if s := q.Get("limit"); s != "" {
+			v, err := strconv.Atoi(s)
+			if err != nil || v <= 0 {
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					"limit must be a positive integer")
+				return
+			}
+			filter.Limit = v
+		}
+
+		var dups models.DocumentDuplicates
+		if err := dups.Find(srv.DB.WithContext(r.Context()), filter); err != nil {
+			respondError(w, r, srv.Logger, http.StatusInternalServerError,
+				"Error getting duplicate documents",
+				"error finding document duplicates", err)
+			return
+		}
+
+		resp := []AdminDuplicate{}
+		for _, d := range dups {
+			resp = append(resp, adminDuplicateResponse(d))
+		}
+		writeAdminResponse(srv, w, r, http.StatusOK, resp)
+	})
+}
+
+// adminDuplicateResponse returns the API representation of a pair of likely
+// duplicates.
+func adminDuplicateResponse(d models.DocumentDuplicate) AdminDuplicate {
+	return AdminDuplicate{
+		Document:     adminDuplicateDocument(d.Document),
+		Duplicate:    adminDuplicateDocument(d.Duplicate),
+		Similarity:   d.Similarity,
+		CrossProduct: d.Document.ProductID != d.Duplicate.ProductID,
+		DetectedAt:   d.DetectedAt,
+	}
+}
+
+func adminDuplicateDocument(doc models.Document) AdminDuplicateDocument {
+	resp := AdminDuplicateDocument{
+		ID:      doc.GoogleFileID,
+		Title:   doc.Title,
+		DocType: doc.DocumentType.Name,
+		Product: doc.Product.Name,
+		Status:  documentStatusString(doc.Status),
+	}
+	if doc.Owner != nil {
+		resp.Owner = doc.Owner.EmailAddress
+	}
+	return resp
+}
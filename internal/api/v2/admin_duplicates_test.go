@@ -0,0 +1,96 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/internal/server"
+	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminDuplicatesHandler(t *testing.T) {
+	srv := server.Server{
+		Config: &config.Config{
+			Authorization: &config.Authorization{
+				SiteAdmins: []string{"admin@example.com"},
+			},
+		},
+		Logger: hclog.NewNullLogger(),
+	}
+
+	newRequest := func(method, target, userEmail string) *http.Request {
+		req := httptest.NewRequest(method, target, nil)
+		return req.WithContext(context.WithValue(
+			req.Context(), pkgauth.UserEmailKey, userEmail))
+	}
+
+	t.Run("other users are forbidden", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		AdminDuplicatesHandler(srv).ServeHTTP(w,
+			newRequest("GET", "/api/v2/admin/duplicates", "user@example.com"))
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("query parameters are validated", func(t *testing.T) {
+		for _, query := range []string{
+			"minSimilarity=high", "minSimilarity=1.5", "crossProduct=maybe",
+			"limit=0",
+		} {
+			w := httptest.NewRecorder()
+			AdminDuplicatesHandler(srv).ServeHTTP(w, newRequest("GET",
+				"/api/v2/admin/duplicates?"+query, "admin@example.com"))
+			assert.Equal(t, http.StatusBadRequest, w.Code, query)
+		}
+	})
+
+	t.Run("only GET is allowed", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		AdminDuplicatesHandler(srv).ServeHTTP(w,
+			newRequest("POST", "/api/v2/admin/duplicates", "admin@example.com"))
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
+
+func TestAdminDuplicateResponse(t *testing.T) {
+	detectedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	assert.Equal(t, AdminDuplicate{
+		Document: AdminDuplicateDocument{
+			ID: "doc1", Title: "Rollout", DocType: "RFC", Product: "Hermes",
+			Status: "Approved", Owner: "alice@example.com",
+		},
+		Duplicate: AdminDuplicateDocument{
+			ID: "doc2", Title: "Rollout (copy)", DocType: "RFC", Product: "Vault",
+			Status: "WIP",
+		},
+		Similarity:   0.92,
+		CrossProduct: true,
+		DetectedAt:   detectedAt,
+	}, adminDuplicateResponse(models.DocumentDuplicate{
+		Document: models.Document{
+			GoogleFileID: "doc1",
+			Title:        "Rollout",
+			DocumentType: models.DocumentType{Name: "RFC"},
+			Product:      models.Product{Name: "Hermes"},
+			ProductID:    1,
+			Status:       models.ApprovedDocumentStatus,
+			Owner:        &models.User{EmailAddress: "alice@example.com"},
+		},
+		Duplicate: models.Document{
+			GoogleFileID: "doc2",
+			Title:        "Rollout (copy)",
+			DocumentType: models.DocumentType{Name: "RFC"},
+			Product:      models.Product{Name: "Vault"},
+			ProductID:    2,
+			Status:       models.WIPDocumentStatus,
+		},
+		Similarity: 0.92,
+		DetectedAt: detectedAt,
+	}))
+}
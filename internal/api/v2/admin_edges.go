@@ -0,0 +1,90 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/pkg/authz"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+)
+
+// defaultEdgeStaleAfter is how long an edge instance can go without a
+// heartbeat or sync before it is flagged as stale.
+const defaultEdgeStaleAfter = 15 * time.Minute
+
+// AdminEdge is an edge instance in the edge registry.
+type AdminEdge struct {
+	Name            string     `json:"name"`
+	Version         string     `json:"version,omitempty"`
+	Hostname        string     `json:"hostname,omitempty"`
+	RemoteAddr      string     `json:"remoteAddr,omitempty"`
+	DocumentCount   int        `json:"documentCount"`
+	ConflictCount   int        `json:"conflictCount"`
+	FirstSeenAt     time.Time  `json:"firstSeenAt"`
+	LastHeartbeatAt *time.Time `json:"lastHeartbeatAt,omitempty"`
+	LastSyncAt      *time.Time `json:"lastSyncAt,omitempty"`
+
+	// Stale is true if the edge instance hasn't sent a heartbeat or synced
+	// within the stale threshold.
+	Stale bool `json:"stale"`
+}
+
+// AdminEdgesHandler lists the edge instances that sync with this server
+// (GET /api/v2/admin/edges). Edge instances that haven't sent a heartbeat or
+// synced within the "staleAfter" duration query parameter (default 15m) are
+// flagged as stale. Only site admins are allowed.
+func AdminEdgesHandler(srv server.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
+		if r.Method != "GET" {
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
+			return
+		}
+
+		if !authorizeRequest(w, r, srv.Config, srv.DB, srv.Logger,
+			authz.ActionAdmin, authz.Resource{},
+			"Only site admins can list edge instances",
+		) {
+			return
+		}
+
+		staleAfter := defaultEdgeStaleAfter
+		if s := r.URL.Query().Get("staleAfter"); s != "" {
+			d, err := time.ParseDuration(s)
+			if err != nil || d <= 0 {
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					"staleAfter must be a positive duration")
+				return
+			}
+			staleAfter = d
+		}
+
+		var edges models.EdgeInstances
+		if err := edges.FindAll(srv.DB); err != nil {
+			respondError(w, r, srv.Logger, http.StatusInternalServerError,
+				"Error getting edge instances",
+				"error finding edge instances", err)
+			return
+		}
+
+		now := time.Now()
+		resp := []AdminEdge{}
+		for _, e := range edges {
+			resp = append(resp, AdminEdge{
+				Name:            e.Name,
+				Version:         e.Version,
+				Hostname:        e.Hostname,
+				RemoteAddr:      e.RemoteAddr,
+				DocumentCount:   e.DocumentCount,
+				ConflictCount:   e.ConflictCount,
+				FirstSeenAt:     e.CreatedAt,
+				LastHeartbeatAt: e.LastHeartbeatAt,
+				LastSyncAt:      e.LastSyncAt,
+				Stale:           e.IsStale(now, staleAfter),
+			})
+		}
+		writeAdminResponse(srv, w, r, http.StatusOK, resp)
+	})
+}
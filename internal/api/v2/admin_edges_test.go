@@ -0,0 +1,54 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/internal/server"
+	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminEdgesHandler(t *testing.T) {
+	srv := server.Server{
+		Config: &config.Config{
+			Authorization: &config.Authorization{
+				SiteAdmins: []string{"admin@example.com"},
+			},
+		},
+		Logger: hclog.NewNullLogger(),
+	}
+
+	newRequest := func(method, target, userEmail string) *http.Request {
+		req := httptest.NewRequest(method, target, nil)
+		return req.WithContext(context.WithValue(
+			req.Context(), pkgauth.UserEmailKey, userEmail))
+	}
+
+	t.Run("other users are forbidden", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		AdminEdgesHandler(srv).ServeHTTP(w,
+			newRequest("GET", "/api/v2/admin/edges", "user@example.com"))
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("staleAfter must be a positive duration", func(t *testing.T) {
+		for _, staleAfter := range []string{"soon", "-5m", "0s"} {
+			w := httptest.NewRecorder()
+			AdminEdgesHandler(srv).ServeHTTP(w, newRequest("GET",
+				"/api/v2/admin/edges?staleAfter="+staleAfter, "admin@example.com"))
+			assert.Equal(t, http.StatusBadRequest, w.Code, staleAfter)
+		}
+	})
+
+	t.Run("only GET is allowed", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		AdminEdgesHandler(srv).ServeHTTP(w,
+			newRequest("POST", "/api/v2/admin/edges", "admin@example.com"))
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
@@ -0,0 +1,298 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp-forge/hermes/internal/email"
+	"github.com/hashicorp-forge/hermes/internal/server"
+	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+	"github.com/hashicorp-forge/hermes/pkg/authz"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+)
+
+// Email templates that can be previewed.
+const (
+	emailTemplateDocumentApproved            = "document-approved"
+	emailTemplateNewOwner                    = "new-owner"
+	emailTemplateReviewRequested             = "review-requested"
+	emailTemplateSubscriberDocumentPublished = "subscriber-document-published"
+)
+
+const (
+	adminEmailPreviewPath  = "/api/v2/admin/email/preview"
+	adminEmailTestSendPath = "/api/v2/admin/email/test-send"
+
+	// adminEmailTestSubjectPrefix prefixes the subject of test emails.
+	adminEmailTestSubjectPrefix = "[Test] "
+)
+
+// AdminEmailPreviewRequest contains the email template to render, and the
+// data to render it with.
+type AdminEmailPreviewRequest struct {
+	// Template is the name of the email template: "document-approved",
+	// "new-owner", "review-requested", or "subscriber-document-published".
+	Template string `json:"template"`
+
+	// Data replaces fields of the sample data the template is rendered with
+	// (optional). Its keys are the names of the fields of the template data,
+	// such as "DocumentTitle".
+	Data map[string]any `json:"data,omitempty"`
+}
+
+// AdminEmailPreview is a rendered email template.
+type AdminEmailPreview struct {
+	Template string `json:"template"`
+	Subject  string `json:"subject"`
+
+	// Body is the HTML body of the email.
+	Body string `json:"body"`
+
+	// SentTo is the address a test email was sent to.
+	SentTo string `json:"sentTo,omitempty"`
+}
+
+// emailTemplateRenderer renders an email template with sample data, replacing
+// the fields that are set in data.
+type emailTemplateRenderer func(
+	srv server.Server, userEmail string, data []byte) (email.Message, error)
+
+// emailTemplateRenderers are the renderers of the email templates that can be
+// previewed, keyed by template name.
+var emailTemplateRenderers = map[string]emailTemplateRenderer{
+	emailTemplateDocumentApproved: func(
+		srv server.Server, userEmail string, data []byte,
+	) (email.Message, error) {
+		d := email.DocumentApprovedEmailData{
+			BaseURL: srv.Config.BaseURL,
+			DocumentApprover: email.User{
+				EmailAddress: userEmail,
+				Name:         "Sample Approver",
+			},
+			DocumentOwner:            userEmail,
+			DocumentNonApproverCount: 1,
+			DocumentShortName:        "SAMPLE-001",
+			DocumentTitle:            "Sample Document",
+			DocumentStatus:           "In-Review",
+			DocumentType:             "RFC",
+			DocumentURL:              sampleDocumentURL(srv),
+			Product:                  "Sample Product",
+		}
+		if err := mergeEmailData(data, &d); err != nil {
+			return email.Message{}, err
+		}
+		return email.RenderDocumentApprovedEmail(d)
+	},
+	emailTemplateNewOwner: func(
+		srv server.Server, userEmail string, data []byte,
+	) (email.Message, error) {
+		d := email.NewOwnerEmailData{
+			BaseURL:           srv.Config.BaseURL,
+			DocumentShortName: "SAMPLE-001",
+			DocumentStatus:    "WIP",
+			DocumentTitle:     "Sample Document",
+			DocumentType:      "RFC",
+			DocumentURL:       sampleDocumentURL(srv),
+			NewDocumentOwner: email.User{
+				EmailAddress: userEmail,
+			},
+			OldDocumentOwner: email.User{
+				EmailAddress: "previous.owner@example.com",
+				Name:         "Previous Owner",
+			},
+			Product: "Sample Product",
+		}
+		if err := mergeEmailData(data, &d); err != nil {
+			return email.Message{}, err
+		}
+		return email.RenderNewOwnerEmail(d)
+	},
+	emailTemplateReviewRequested: func(
+		srv server.Server, userEmail string, data []byte,
+	) (email.Message, error) {
+		d := email.ReviewRequestedEmailData{
+			BaseURL:           srv.Config.BaseURL,
+			DocumentOwner:     userEmail,
+			DocumentShortName: "SAMPLE-001",
+			DocumentTitle:     "Sample Document",
+			DocumentType:      "RFC",
+			DocumentStatus:    "In-Review",
+			DocumentURL:       sampleDocumentURL(srv),
+			Product:           "Sample Product",
+		}
+		if approvalLinksEnabled(srv) {
+			base := strings.TrimSuffix(srv.Config.BaseURL, "/") +
+				approvalLinksPathPrefix + "sample?action="
+			d.ApproveURL = base + models.ApprovalLinkActionApprove
+			d.RequestChangesURL = base + models.ApprovalLinkActionRequestChanges
+		}
+		if err := mergeEmailData(data, &d); err != nil {
+			return email.Message{}, err
+		}
+		return email.RenderReviewRequestedEmail(d)
+	},
+	emailTemplateSubscriberDocumentPublished: func(
+		srv server.Server, userEmail string, data []byte,
+	) (email.Message, error) {
+		d := email.SubscriberDocumentPublishedEmailData{
+			BaseURL:           srv.Config.BaseURL,
+			DocumentOwner:     userEmail,
+			DocumentShortName: "SAMPLE-001",
+			DocumentTitle:     "Sample Document",
+			DocumentType:      "RFC",
+			DocumentURL:       sampleDocumentURL(srv),
+			Product:           "Sample Product",
+		}
+		if err := mergeEmailData(data, &d); err != nil {
+			return email.Message{}, err
+		}
+		return email.RenderSubscriberDocumentPublishedEmail(d)
+	},
+}
+
+// mergeEmailData replaces the fields of the template data v that are set in
+// the JSON object data.
+func mergeEmailData(data []byte, v any) error {
+	if len(data) == 0 {
+		return nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("invalid template data: %w", err)
+	}
+	return nil
+}
+
+// sampleDocumentURL returns the URL of a sample document.
+func sampleDocumentURL(srv server.Server) string {
+	return strings.TrimSuffix(srv.Config.BaseURL, "/") + "/document/sample"
+}
+
+// AdminEmailHandler renders email templates with sample or supplied data, so
+// site admins can verify changes to the templates without triggering real
+// workflows.
+//
+// POST /api/v2/admin/email/preview    - Render an email template
+// POST /api/v2/admin/email/test-send  - Render and send it to the admin
+//
+// Test emails are sent to the requesting admin only, with "[Test]" prepended
+// to their subject. Only site admins are allowed.
+func AdminEmailHandler(srv server.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
+
+		path := strings.TrimSuffix(r.URL.Path, "/")
+		if path != adminEmailPreviewPath && path != adminEmailTestSendPath {
+			writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound, "Not found")
+			return
+		}
+		if r.Method != "POST" {
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
+			return
+		}
+
+		if !authorizeRequest(w, r, srv.Config, srv.DB, srv.Logger,
+			authz.ActionAdmin, authz.Resource{},
+			"Only site admins can preview emails",
+		) {
+			return
+		}
+		userEmail := pkgauth.MustGetUserEmail(r.Context())
+
+		var req AdminEmailPreviewRequest
+		if err := decodeRequest(r, &req); err != nil {
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				fmt.Sprintf("Bad request: %q", err))
+			return
+		}
+		render, ok := emailTemplateRenderers[req.Template]
+		if !ok {
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				fmt.Sprintf("Bad request: template must be one of: %s",
+					strings.Join(emailTemplateNames(), ", ")))
+			return
+		}
+		var data []byte
+		if req.Data != nil {
+			b, err := json.Marshal(req.Data)
+			if err != nil {
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					fmt.Sprintf("Bad request: %v", err))
+				return
+			}
+			data = b
+		}
+
+		msg, err := render(srv, userEmail, data)
+		if err != nil {
+			// Rendering only fails for invalid data, such as required fields
+			// that were cleared.
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				fmt.Sprintf("Bad request: %v", err))
+			return
+		}
+		resp := AdminEmailPreview{
+			Template: req.Template,
+			Subject:  msg.Subject,
+			Body:     msg.Body,
+		}
+
+		if path == adminEmailTestSendPath {
+			if srv.Config.Email == nil || srv.Config.Email.FromAddress == "" {
+				writeProblem(w, r, http.StatusUnprocessableEntity,
+					ErrCodeUnprocessable,
+					"Email isn't configured: from_address is required")
+				return
+			}
+			msg.Subject = adminEmailTestSubjectPrefix + msg.Subject
+			if err := email.Send(
+				msg,
+				[]string{userEmail},
+				srv.Config.Email.FromAddress,
+				emailSender(srv),
+			); err != nil {
+				if errors.Is(err, email.ErrNoSender) {
+					writeProblem(w, r, http.StatusNotImplemented,
+						ErrCodeNotImplemented,
+						"No email sender is configured")
+					return
+				}
+				respondError(w, r, srv.Logger, http.StatusBadGateway,
+					"Error sending test email",
+					"error sending test email", err,
+					"template", req.Template,
+				)
+				return
+			}
+			resp.Subject = msg.Subject
+			resp.SentTo = userEmail
+
+			srv.Logger.Info("sent test email",
+				"template", req.Template,
+				"to", userEmail,
+				"method", r.Method,
+				"path", r.URL.Path,
+			)
+		}
+
+		writeAdminResponse(srv, w, r, http.StatusOK, resp)
+	})
+}
+
+// emailTemplateNames returns the sorted names of the email templates that can
+// be previewed.
+func emailTemplateNames() []string {
+	names := make([]string, 0, len(emailTemplateRenderers))
+	for name := range emailTemplateRenderers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
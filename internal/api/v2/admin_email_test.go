@@ -0,0 +1,127 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/internal/server"
+	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeEmailSender records the emails it sends.
+type fakeEmailSender struct {
+	to      []string
+	from    string
+	subject string
+}
+
+func (s *fakeEmailSender) SendEmail(to []string, from, subject, body string) error {
+	s.to, s.from, s.subject = to, from, subject
+	return nil
+}
+
+func TestAdminEmailHandler(t *testing.T) {
+	sender := &fakeEmailSender{}
+	srv := server.Server{
+		Config: &config.Config{
+			BaseURL: "https://hermes.example.com",
+			Authorization: &config.Authorization{
+				SiteAdmins: []string{"admin@example.com"},
+			},
+			Email: &config.Email{
+				Enabled:     true,
+				FromAddress: "hermes@example.com",
+			},
+		},
+		EmailSender: sender,
+		Logger:      hclog.NewNullLogger(),
+	}
+
+	newRequest := func(target, userEmail, body string) *http.Request {
+		req := httptest.NewRequest("POST", target, strings.NewReader(body))
+		return req.WithContext(context.WithValue(
+			req.Context(), pkgauth.UserEmailKey, userEmail))
+	}
+
+	t.Run("other users are forbidden", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		AdminEmailHandler(srv).ServeHTTP(w, newRequest(
+			"/api/v2/admin/email/preview", "user@example.com",
+			`{"template":"new-owner"}`))
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("all templates render with sample data", func(t *testing.T) {
+		for _, name := range emailTemplateNames() {
+			w := httptest.NewRecorder()
+			AdminEmailHandler(srv).ServeHTTP(w, newRequest(
+				"/api/v2/admin/email/preview", "admin@example.com",
+				`{"template":"`+name+`"}`))
+			require.Equal(t, http.StatusOK, w.Code, name)
+
+			var resp AdminEmailPreview
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+			assert.Equal(t, name, resp.Template)
+			assert.NotEmpty(t, resp.Subject, name)
+			assert.Contains(t, resp.Body, "Sample Document", name)
+			assert.Empty(t, resp.SentTo, name)
+		}
+	})
+
+	t.Run("supplied data replaces sample data", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		AdminEmailHandler(srv).ServeHTTP(w, newRequest(
+			"/api/v2/admin/email/preview", "admin@example.com",
+			`{"template":"review-requested","data":{"DocumentShortName":"HER-042"}}`))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var resp AdminEmailPreview
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.Equal(t, "Document review requested for HER-042", resp.Subject)
+	})
+
+	t.Run("invalid requests are rejected", func(t *testing.T) {
+		for _, body := range []string{
+			`{"template":"unknown"}`,
+			`{"template":"new-owner","data":{"NoSuchField":"x"}}`,
+			`{"template":"new-owner","data":{"DocumentTitle":""}}`,
+		} {
+			w := httptest.NewRecorder()
+			AdminEmailHandler(srv).ServeHTTP(w, newRequest(
+				"/api/v2/admin/email/preview", "admin@example.com", body))
+			assert.Equal(t, http.StatusBadRequest, w.Code, body)
+		}
+	})
+
+	t.Run("test emails are sent to the admin", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		AdminEmailHandler(srv).ServeHTTP(w, newRequest(
+			"/api/v2/admin/email/test-send", "admin@example.com",
+			`{"template":"document-approved"}`))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var resp AdminEmailPreview
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.Equal(t, "admin@example.com", resp.SentTo)
+		assert.True(t, strings.HasPrefix(resp.Subject, "[Test] "))
+		assert.Equal(t, []string{"admin@example.com"}, sender.to)
+		assert.Equal(t, "hermes@example.com", sender.from)
+		assert.Equal(t, resp.Subject, sender.subject)
+	})
+
+	t.Run("only POST is allowed", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := newRequest("/api/v2/admin/email/preview", "admin@example.com", "")
+		req.Method = "GET"
+		AdminEmailHandler(srv).ServeHTTP(w, req)
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
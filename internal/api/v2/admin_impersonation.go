@@ -0,0 +1,426 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp-forge/hermes/internal/auth"
+	"github.com/hashicorp-forge/hermes/internal/server"
+	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+	"github.com/hashicorp-forge/hermes/pkg/authz"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"gorm.io/gorm"
+)
+
+const (
+	// impersonationCookieName is the name of the cookie that identifies a site
+	// admin's active impersonation session.
+	impersonationCookieName = "hermes_impersonation"
+
+	// defaultImpersonationMaxDuration is the default longest impersonation
+	// session.
+	defaultImpersonationMaxDuration = time.Hour
+
+	// impersonationPath is the path of the impersonation admin API. Requests
+	// to it are always made as the admin.
+	impersonationPath = "/api/v2/admin/impersonation"
+)
+
+// Impersonation audit actions.
+const (
+	auditActionImpersonationStart = "impersonation.start"
+	auditActionImpersonationEnd   = "impersonation.end"
+)
+
+// ImpersonationSession is an impersonation session in API responses.
+type ImpersonationSession struct {
+	ID        uuid.UUID  `json:"id"`
+	Admin     string     `json:"admin"`
+	User      string     `json:"user"`
+	Reason    string     `json:"reason"`
+	Active    bool       `json:"active"`
+	CreatedAt time.Time  `json:"createdAt"`
+	ExpiresAt time.Time  `json:"expiresAt"`
+	EndedAt   *time.Time `json:"endedAt,omitempty"`
+}
+
+// AdminImpersonationPostRequest contains the fields to start impersonating a
+// user.
+type AdminImpersonationPostRequest struct {
+	User string `json:"user"`
+
+	// Reason is why the user is impersonated (e.g., a support ticket).
+	Reason string `json:"reason"`
+
+	// Duration is how long the session lasts as a duration string (e.g.,
+	// "30m"). Defaults to, and may not exceed, the configured maximum.
+	Duration string `json:"duration,omitempty"`
+}
+
+var adminImpersonationURLPathRE = regexp.MustCompile(
+	`^/api/v2/admin/impersonation(?:/([0-9a-fA-F-]{36}))?/?$`)
+
+// AdminImpersonationHandler lets site admins act as another user to debug
+// issues the user reports without asking for their credentials.
+//
+// GET    /api/v2/admin/impersonation      - List active sessions
+// POST   /api/v2/admin/impersonation      - Start impersonating a user
+// DELETE /api/v2/admin/impersonation      - End the admin's current session
+// DELETE /api/v2/admin/impersonation/:id  - End any session
+//
+// Starting a session sets a cookie that ImpersonationHandler uses to make the
+// admin's requests as the user. Starting and ending sessions are recorded in
+// the audit log. Only site admins are allowed.
+func AdminImpersonationHandler(srv server.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
+		if !authorizeRequest(w, r, srv.Config, srv.DB, srv.Logger,
+			authz.ActionAdmin, authz.Resource{},
+			"Only site admins can impersonate users",
+		) {
+			return
+		}
+		userEmail := pkgauth.MustGetUserEmail(r.Context())
+
+		errResp := func(httpCode int, userErrMsg, logErrMsg string, err error) {
+			respondError(w, r, srv.Logger, httpCode, userErrMsg, logErrMsg, err)
+		}
+
+		matches := adminImpersonationURLPathRE.FindStringSubmatch(r.URL.Path)
+		if matches == nil {
+			writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound,
+				"Impersonation session not found")
+			return
+		}
+		idStr := matches[1]
+
+		switch {
+		case idStr == "" && r.Method == "GET":
+			var sessions models.ImpersonationSessions
+			if err := sessions.Find(
+				srv.DB, r.URL.Query().Get("includeInactive") == "true",
+			); err != nil {
+				errResp(http.StatusInternalServerError,
+					"Error getting impersonation sessions",
+					"error finding impersonation sessions", err)
+				return
+			}
+
+			resp := []ImpersonationSession{}
+			for _, s := range sessions {
+				resp = append(resp, impersonationSessionResponse(s))
+			}
+			writeAdminResponse(srv, w, r, http.StatusOK, resp)
+
+		case idStr == "" && r.Method == "POST":
+			var req AdminImpersonationPostRequest
+			if err := decodeRequest(r, &req); err != nil {
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					fmt.Sprintf("Bad request: %q", err))
+				return
+			}
+			req.User = strings.TrimSpace(req.User)
+			req.Reason = strings.TrimSpace(req.Reason)
+			d, err := impersonationDuration(srv, req.Duration)
+			if err != nil {
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					fmt.Sprintf("Bad request: %v", err))
+				return
+			}
+			if req.User == "" || req.Reason == "" {
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					"Bad request: user and reason are required")
+				return
+			}
+			if strings.EqualFold(req.User, userEmail) {
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					"Bad request: you cannot impersonate yourself")
+				return
+			}
+
+			// Admins cannot impersonate other admins, which would let them act
+			// with the other admin's identity in the audit log.
+			target, err := loadPrincipal(r.Context(), srv.Config, srv.DB, req.User)
+			if err != nil {
+				errResp(http.StatusInternalServerError,
+					"Error starting impersonation",
+					"error loading impersonated user", err)
+				return
+			}
+			if target.IsSiteAdmin() {
+				writeProblem(w, r, http.StatusForbidden, ErrCodeForbidden,
+					"Site admins cannot be impersonated")
+				return
+			}
+
+			s := models.ImpersonationSession{
+				AdminEmail: userEmail,
+				UserEmail:  req.User,
+				Reason:     req.Reason,
+				ExpiresAt:  time.Now().Add(d),
+			}
+			if err := s.Create(srv.DB); err != nil {
+				errResp(http.StatusInternalServerError,
+					"Error starting impersonation",
+					"error creating impersonation session", err)
+				return
+			}
+			recordImpersonationEvent(srv, r, s, auditActionImpersonationStart,
+				http.StatusCreated)
+
+			srv.Logger.Warn("started impersonation session",
+				"session_id", s.ID,
+				"admin", userEmail,
+				"user", s.UserEmail,
+				"reason", s.Reason,
+				"expires_at", s.ExpiresAt,
+			)
+			http.SetCookie(w, impersonationCookie(srv, r, s.ID.String(),
+				time.Until(s.ExpiresAt)))
+			writeAdminResponse(srv, w, r, http.StatusCreated,
+				impersonationSessionResponse(s))
+
+		case r.Method == "DELETE":
+			// Without an ID, end the admin's current session.
+			if idStr == "" {
+				c, err := r.Cookie(impersonationCookieName)
+				if err != nil {
+					w.WriteHeader(http.StatusNoContent)
+					return
+				}
+				idStr = c.Value
+				clearImpersonationCookie(srv, w, r)
+			}
+
+			id, err := uuid.Parse(idStr)
+			if err != nil {
+				writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound,
+					"Impersonation session not found")
+				return
+			}
+			s := models.ImpersonationSession{ID: id}
+			if err := s.Get(srv.DB); err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound,
+						"Impersonation session not found")
+					return
+				}
+				errResp(http.StatusInternalServerError,
+					"Error ending impersonation",
+					"error getting impersonation session", err)
+				return
+			}
+
+			if s.IsActive(time.Now()) {
+				if err := s.End(srv.DB); err != nil {
+					errResp(http.StatusInternalServerError,
+						"Error ending impersonation",
+						"error ending impersonation session", err)
+					return
+				}
+				recordImpersonationEvent(srv, r, s, auditActionImpersonationEnd,
+					http.StatusNoContent)
+
+				srv.Logger.Warn("ended impersonation session",
+					"session_id", s.ID,
+					"admin", s.AdminEmail,
+					"user", s.UserEmail,
+					"ended_by", userEmail,
+				)
+			}
+			w.WriteHeader(http.StatusNoContent)
+
+		default:
+			writeProblem(w, r, http.StatusMethodNotAllowed,
+				ErrCodeMethodNotAllowed, "Method not allowed")
+		}
+	})
+}
+
+// ImpersonationHandler wraps a handler so requests from a site admin with an
+// active impersonation session are made as the impersonated user. The admin
+// is available with pkgauth.GetImpersonator. It must run after authentication
+// and before AuditHandler so audit events record both.
+//
+// Changes made while impersonating are only allowed if audit logging is
+// enabled, so that they are always attributed to the admin. Sessions that
+// have ended, expired, or belong to another user, or whose admin is no longer
+// a site admin, are ignored and their cookie is cleared.
+func ImpersonationHandler(srv server.Server, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
+		c, err := r.Cookie(impersonationCookieName)
+		if err != nil || strings.HasPrefix(r.URL.Path, impersonationPath) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		adminEmail, ok := pkgauth.GetUserEmail(r.Context())
+		if !ok || adminEmail == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		s, err := activeImpersonationSession(srv, r, c.Value, adminEmail)
+		if err != nil {
+			srv.Logger.Warn("ignoring impersonation session",
+				"error", err,
+				"admin", adminEmail,
+				"method", r.Method,
+				"path", r.URL.Path,
+			)
+			clearImpersonationCookie(srv, w, r)
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if isAuditedRequest(r) &&
+			(srv.Config == nil || srv.Config.Audit == nil ||
+				!srv.Config.Audit.Enabled) {
+			writeProblem(w, r, http.StatusForbidden, ErrCodeForbidden,
+				"Changes are not allowed while impersonating a user unless audit logging is enabled")
+			return
+		}
+
+		srv.Logger.Debug("impersonating user",
+			"session_id", s.ID,
+			"admin", adminEmail,
+			"user", s.UserEmail,
+			"method", r.Method,
+			"path", r.URL.Path,
+		)
+		next.ServeHTTP(w, r.WithContext(
+			pkgauth.WithImpersonation(r.Context(), adminEmail, s.UserEmail)))
+	})
+}
+
+// activeImpersonationSession returns the impersonation session with ID id if
+// it is active and was started by adminEmail, who must still be a site admin.
+func activeImpersonationSession(
+	srv server.Server, r *http.Request, id, adminEmail string,
+) (models.ImpersonationSession, error) {
+	sessionID, err := uuid.Parse(id)
+	if err != nil {
+		return models.ImpersonationSession{}, fmt.Errorf("invalid session ID")
+	}
+	s := models.ImpersonationSession{ID: sessionID}
+	if err := s.Get(srv.DB); err != nil {
+		return s, fmt.Errorf("error getting session: %w", err)
+	}
+	if !s.IsActive(time.Now()) {
+		return s, fmt.Errorf("session is not active")
+	}
+	if s.AdminEmail != adminEmail {
+		return s, fmt.Errorf("session belongs to another admin")
+	}
+	if err := authorize(r, srv.Config, srv.DB,
+		authz.ActionAdmin, authz.Resource{}); err != nil {
+		return s, fmt.Errorf("admin is no longer allowed: %w", err)
+	}
+	return s, nil
+}
+
+// impersonationDuration parses the requested session duration, defaulting to
+// and limited by the configured maximum.
+func impersonationDuration(srv server.Server, duration string) (time.Duration, error) {
+	max := defaultImpersonationMaxDuration
+	if srv.Config != nil && srv.Config.Authorization != nil &&
+		srv.Config.Authorization.ImpersonationMaxDuration > 0 {
+		max = srv.Config.Authorization.ImpersonationMaxDuration
+	}
+	if duration == "" {
+		return max, nil
+	}
+
+	d, err := time.ParseDuration(duration)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid duration %q", duration)
+	}
+	if d > max {
+		return 0, fmt.Errorf("duration may not exceed %s", max)
+	}
+	return d, nil
+}
+
+// recordImpersonationEvent records starting or ending an impersonation
+// session in the audit log. Sessions are always recorded, even if audit
+// logging of other requests is disabled.
+func recordImpersonationEvent(
+	srv server.Server, r *http.Request, s models.ImpersonationSession,
+	action string, status int,
+) {
+	actor, _ := pkgauth.GetUserEmail(r.Context())
+	requestID := auditRequestID(r)
+	e := models.AuditEvent{
+		Actor:      actor,
+		RequestID:  requestID,
+		Action:     action,
+		Method:     r.Method,
+		Path:       r.URL.Path,
+		StatusCode: status,
+		TargetType: auditTargetUser,
+		TargetID:   s.UserEmail,
+		After:      impersonationAuditSnapshot(srv, s),
+	}
+	if err := e.Create(srv.DB); err != nil {
+		srv.Logger.Error("error recording impersonation audit event",
+			"error", err,
+			"session_id", s.ID,
+		)
+	}
+}
+
+// impersonationAuditSnapshot returns the key fields of an impersonation
+// session for the audit log.
+func impersonationAuditSnapshot(
+	srv server.Server, s models.ImpersonationSession,
+) models.JSON {
+	b, err := json.Marshal(impersonationSessionResponse(s))
+	if err != nil {
+		srv.Logger.Error("error marshaling impersonation audit snapshot",
+			"error", err,
+			"session_id", s.ID,
+		)
+		return nil
+	}
+	return models.JSON(b)
+}
+
+// impersonationCookie returns the impersonation cookie with the configured
+// session cookie flags. The cookie is deleted if maxAge is negative.
+func impersonationCookie(
+	srv server.Server, r *http.Request, value string, maxAge time.Duration,
+) *http.Cookie {
+	sessions := srv.Sessions
+	if sessions == nil {
+		sessions, _ = auth.NewSessions(nil)
+	}
+	return sessions.Cookie(r, impersonationCookieName, value, maxAge)
+}
+
+// clearImpersonationCookie deletes the impersonation cookie.
+func clearImpersonationCookie(
+	srv server.Server, w http.ResponseWriter, r *http.Request,
+) {
+	http.SetCookie(w, impersonationCookie(srv, r, "", -1))
+}
+
+// impersonationSessionResponse converts an impersonation session to its API
+// response.
+func impersonationSessionResponse(s models.ImpersonationSession) ImpersonationSession {
+	return ImpersonationSession{
+		ID:        s.ID,
+		Admin:     s.AdminEmail,
+		User:      s.UserEmail,
+		Reason:    s.Reason,
+		Active:    s.IsActive(time.Now()),
+		CreatedAt: s.CreatedAt,
+		ExpiresAt: s.ExpiresAt,
+		EndedAt:   s.EndedAt,
+	}
+}
@@ -0,0 +1,163 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/internal/server"
+	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminImpersonationHandler(t *testing.T) {
+	srv := server.Server{
+		Config: &config.Config{
+			Authorization: &config.Authorization{
+				SiteAdmins: []string{"admin@example.com", "admin2@example.com"},
+			},
+		},
+		Logger: hclog.NewNullLogger(),
+	}
+
+	newRequest := func(method, userEmail, body string) *http.Request {
+		req := httptest.NewRequest(method, "/api/v2/admin/impersonation",
+			strings.NewReader(body))
+		return req.WithContext(context.WithValue(
+			req.Context(), pkgauth.UserEmailKey, userEmail))
+	}
+
+	cases := map[string]struct {
+		userEmail string
+		body      string
+		want      int
+	}{
+		"other users are forbidden": {
+			userEmail: "user@example.com",
+			body:      `{"user": "user2@example.com", "reason": "ticket"}`,
+			want:      http.StatusForbidden,
+		},
+		"reason is required": {
+			userEmail: "admin@example.com",
+			body:      `{"user": "user@example.com"}`,
+			want:      http.StatusBadRequest,
+		},
+		"cannot impersonate yourself": {
+			userEmail: "admin@example.com",
+			body:      `{"user": "Admin@example.com", "reason": "ticket"}`,
+			want:      http.StatusBadRequest,
+		},
+		"cannot impersonate site admins": {
+			userEmail: "admin@example.com",
+			body:      `{"user": "admin2@example.com", "reason": "ticket"}`,
+			want:      http.StatusForbidden,
+		},
+		"duration may not exceed the maximum": {
+			userEmail: "admin@example.com",
+			body:      `{"user": "user@example.com", "reason": "ticket", "duration": "2h"}`,
+			want:      http.StatusBadRequest,
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			AdminImpersonationHandler(srv).ServeHTTP(w,
+				newRequest("POST", c.userEmail, c.body))
+			assert.Equal(t, c.want, w.Code)
+			assert.Empty(t, w.Result().Cookies())
+		})
+	}
+
+	t.Run("PUT is not allowed", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		AdminImpersonationHandler(srv).ServeHTTP(w,
+			newRequest("PUT", "admin@example.com", ""))
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
+
+func TestImpersonationHandler(t *testing.T) {
+	srv := server.Server{
+		Config: &config.Config{},
+		Logger: hclog.NewNullLogger(),
+	}
+
+	var gotEmail string
+	var gotImpersonated bool
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotEmail, _ = pkgauth.GetUserEmail(r.Context())
+		_, gotImpersonated = pkgauth.GetImpersonator(r.Context())
+	})
+
+	newRequest := func(path string, cookie *http.Cookie) *http.Request {
+		req := httptest.NewRequest("GET", path, nil)
+		if cookie != nil {
+			req.AddCookie(cookie)
+		}
+		return req.WithContext(context.WithValue(
+			req.Context(), pkgauth.UserEmailKey, "admin@example.com"))
+	}
+
+	t.Run("requests without a session are unchanged", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ImpersonationHandler(srv, next).ServeHTTP(w,
+			newRequest("/api/v2/me", nil))
+		assert.Equal(t, "admin@example.com", gotEmail)
+		assert.False(t, gotImpersonated)
+		assert.Empty(t, w.Result().Cookies())
+	})
+
+	t.Run("invalid sessions are ignored and cleared", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ImpersonationHandler(srv, next).ServeHTTP(w,
+			newRequest("/api/v2/me", &http.Cookie{
+				Name:  impersonationCookieName,
+				Value: "not-a-session",
+			}))
+		assert.Equal(t, "admin@example.com", gotEmail)
+		assert.False(t, gotImpersonated)
+		if assert.Len(t, w.Result().Cookies(), 1) {
+			assert.Equal(t, impersonationCookieName, w.Result().Cookies()[0].Name)
+			assert.Equal(t, -1, w.Result().Cookies()[0].MaxAge)
+		}
+	})
+
+	t.Run("impersonation API requests are made as the admin", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ImpersonationHandler(srv, next).ServeHTTP(w,
+			newRequest(impersonationPath, &http.Cookie{
+				Name:  impersonationCookieName,
+				Value: "1b4e28ba-2fa1-11d2-883f-0016d3cca427",
+			}))
+		assert.Equal(t, "admin@example.com", gotEmail)
+		assert.False(t, gotImpersonated)
+		assert.Empty(t, w.Result().Cookies())
+	})
+}
+
+func TestImpersonationDuration(t *testing.T) {
+	srv := server.Server{Config: &config.Config{}}
+
+	d, err := impersonationDuration(srv, "")
+	assert.NoError(t, err)
+	assert.Equal(t, defaultImpersonationMaxDuration, d)
+
+	d, err = impersonationDuration(srv, "15m")
+	assert.NoError(t, err)
+	assert.Equal(t, 15*time.Minute, d)
+
+	_, err = impersonationDuration(srv, "0s")
+	assert.Error(t, err)
+
+	srv.Config.Authorization = &config.Authorization{
+		ImpersonationMaxDuration: 4 * time.Hour,
+	}
+	d, err = impersonationDuration(srv, "")
+	assert.NoError(t, err)
+	assert.Equal(t, 4*time.Hour, d)
+}
@@ -0,0 +1,221 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/pkg/authz"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"gorm.io/gorm"
+)
+
+const (
+	// defaultJobsLimit is the default number of jobs returned.
+	defaultJobsLimit = 50
+
+	// maxJobsLimit is the maximum number of jobs returned.
+	maxJobsLimit = 200
+)
+
+// AdminJob is a background job queued by an API request.
+type AdminJob struct {
+	ID      uint            `json:"id"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload"`
+
+	// Status is "pending", "running", "succeeded", or "failed".
+	Status      string `json:"status"`
+	Attempts    int    `json:"attempts"`
+	MaxAttempts int    `json:"maxAttempts"`
+
+	// LastError is the error of the last failed attempt.
+	LastError string `json:"lastError,omitempty"`
+
+	// RequestID is the ID of the request that queued the job.
+	RequestID string `json:"requestId,omitempty"`
+
+	// RunAt is when the job may next be started.
+	RunAt      time.Time  `json:"runAt"`
+	StartedAt  *time.Time `json:"startedAt,omitempty"`
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+// AdminJobsGetResponse is the response for GET /api/v2/admin/jobs.
+type AdminJobsGetResponse struct {
+	Jobs []AdminJob `json:"jobs"`
+
+	// NextCursor is passed as the "cursor" query parameter to get the next page
+	// of jobs, or empty if there are no more jobs.
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+var adminJobsURLPathRE = regexp.MustCompile(
+	`^/api/v2/admin/jobs(?:/([0-9]+)(/retry)?)?/?$`)
+
+// AdminJobsHandler lists the background jobs queued by API requests, such as
+// indexing documents and sending emails, and retries failed jobs.
+//
+// GET  /api/v2/admin/jobs            - List jobs, newest first
+// GET  /api/v2/admin/jobs/:id        - Get a job
+// POST /api/v2/admin/jobs/:id/retry  - Retry a failed job
+//
+// Only site admins are allowed.
+func AdminJobsHandler(srv server.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
+		if !authorizeRequest(w, r, srv.Config, srv.DB, srv.Logger,
+			authz.ActionAdmin, authz.Resource{},
+			"Only site admins can manage background jobs",
+		) {
+			return
+		}
+
+		matches := adminJobsURLPathRE.FindStringSubmatch(r.URL.Path)
+		if matches == nil {
+			writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound, "Not found")
+			return
+		}
+		retry := matches[2] != ""
+
+		wantMethod := "GET"
+		if retry {
+			wantMethod = "POST"
+		}
+		if r.Method != wantMethod {
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
+			return
+		}
+
+		if matches[1] == "" {
+			filter, err := parseJobFilter(r.URL.Query())
+			if err != nil {
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					fmt.Sprintf("Bad request: %v", err))
+				return
+			}
+
+			// Get one extra job to determine if there is another page.
+			limit := filter.Limit
+			filter.Limit++
+			var js models.Jobs
+			if err := js.Find(srv.DB.WithContext(r.Context()), filter); err != nil {
+				respondError(w, r, srv.Logger, http.StatusInternalServerError,
+					"Error getting jobs", "error finding jobs", err)
+				return
+			}
+
+			resp := AdminJobsGetResponse{
+				Jobs: []AdminJob{},
+			}
+			if len(js) > limit {
+				js = js[:limit]
+				resp.NextCursor = strconv.FormatUint(uint64(js[limit-1].ID), 10)
+			}
+			for _, j := range js {
+				resp.Jobs = append(resp.Jobs, newAdminJob(j))
+			}
+			writeAdminResponse(srv, w, r, http.StatusOK, resp)
+			return
+		}
+
+		id, err := strconv.ParseUint(matches[1], 10, 64)
+		if err != nil {
+			writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound, "Job not found")
+			return
+		}
+		db := srv.DB.WithContext(r.Context())
+		j := models.Job{ID: uint(id)}
+		if err := j.Get(db); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound,
+					"Job not found")
+				return
+			}
+			respondError(w, r, srv.Logger, http.StatusInternalServerError,
+				"Error getting job", "error getting job", err, "job_id", id)
+			return
+		}
+
+		if retry {
+			if j.Status != models.FailedJobStatus {
+				writeProblem(w, r, http.StatusConflict, ErrCodeConflict,
+					"Only failed jobs can be retried")
+				return
+			}
+			if err := j.Retry(db, time.Now()); err != nil {
+				respondError(w, r, srv.Logger, http.StatusInternalServerError,
+					"Error retrying job", "error retrying job", err, "job_id", id)
+				return
+			}
+			srv.Logger.Info("retried job",
+				"job_id", id,
+				"job_type", j.Type,
+				"method", r.Method,
+				"path", r.URL.Path,
+			)
+		}
+
+		writeAdminResponse(srv, w, r, http.StatusOK, newAdminJob(j))
+	})
+}
+
+// parseJobFilter parses the status, type, cursor, and limit query parameters
+// of a jobs request.
+func parseJobFilter(q url.Values) (models.JobFilter, error) {
+	f := models.JobFilter{
+		Status: models.JobStatus(q.Get("status")),
+		Type:   q.Get("type"),
+		Limit:  defaultJobsLimit,
+	}
+
+	switch f.Status {
+	case "", models.PendingJobStatus, models.RunningJobStatus,
+		models.SucceededJobStatus, models.FailedJobStatus:
+	default:
+		return f, fmt.Errorf("invalid status: %q", f.Status)
+	}
+
+	if v := q.Get("cursor"); v != "" {
+		id, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return f, fmt.Errorf("invalid cursor: %w", err)
+		}
+		f.BeforeID = uint(id)
+	}
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return f, fmt.Errorf("invalid limit: %q", v)
+		}
+		f.Limit = min(n, maxJobsLimit)
+	}
+
+	return f, nil
+}
+
+// newAdminJob converts a job database model to its API representation.
+func newAdminJob(j models.Job) AdminJob {
+	return AdminJob{
+		ID:          j.ID,
+		Type:        j.Type,
+		Payload:     json.RawMessage(j.Payload),
+		Status:      string(j.Status),
+		Attempts:    j.Attempts,
+		MaxAttempts: j.MaxAttempts,
+		LastError:   j.LastError,
+		RequestID:   j.RequestID,
+		RunAt:       j.RunAt,
+		StartedAt:   j.StartedAt,
+		FinishedAt:  j.FinishedAt,
+		CreatedAt:   j.CreatedAt,
+	}
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/internal/server"
+	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminJobsHandler(t *testing.T) {
+	srv := server.Server{
+		Config: &config.Config{
+			Authorization: &config.Authorization{
+				SiteAdmins: []string{"admin@example.com"},
+			},
+		},
+		Logger: hclog.NewNullLogger(),
+	}
+
+	newRequest := func(method, target, userEmail string) *http.Request {
+		req := httptest.NewRequest(method, target, nil)
+		return req.WithContext(context.WithValue(
+			req.Context(), pkgauth.UserEmailKey, userEmail))
+	}
+
+	t.Run("other users are forbidden", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		AdminJobsHandler(srv).ServeHTTP(w,
+			newRequest("GET", "/api/v2/admin/jobs", "user@example.com"))
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("invalid filters are bad requests", func(t *testing.T) {
+		for _, q := range []string{"status=done", "cursor=abc", "limit=0"} {
+			w := httptest.NewRecorder()
+			AdminJobsHandler(srv).ServeHTTP(w, newRequest("GET",
+				"/api/v2/admin/jobs?"+q, "admin@example.com"))
+			assert.Equal(t, http.StatusBadRequest, w.Code, q)
+		}
+	})
+
+	t.Run("methods", func(t *testing.T) {
+		for _, tc := range []struct{ method, path string }{
+			{"POST", "/api/v2/admin/jobs"},
+			{"DELETE", "/api/v2/admin/jobs/1"},
+			{"GET", "/api/v2/admin/jobs/1/retry"},
+		} {
+			w := httptest.NewRecorder()
+			AdminJobsHandler(srv).ServeHTTP(w,
+				newRequest(tc.method, tc.path, "admin@example.com"))
+			assert.Equal(t, http.StatusMethodNotAllowed, w.Code, tc)
+		}
+	})
+
+	t.Run("unknown paths are not found", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		AdminJobsHandler(srv).ServeHTTP(w,
+			newRequest("GET", "/api/v2/admin/jobs/abc", "admin@example.com"))
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestParseJobFilter(t *testing.T) {
+	f, err := parseJobFilter(url.Values{})
+	require.NoError(t, err)
+	assert.Equal(t, models.JobFilter{Limit: defaultJobsLimit}, f)
+
+	f, err = parseJobFilter(url.Values{
+		"status": {"failed"},
+		"type":   {"search.index_document"},
+		"cursor": {"42"},
+		"limit":  {"1000"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, models.JobFilter{
+		Status:   models.FailedJobStatus,
+		Type:     "search.index_document",
+		BeforeID: 42,
+		Limit:    maxJobsLimit,
+	}, f)
+}
@@ -0,0 +1,177 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/internal/server"
+	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+	"github.com/hashicorp-forge/hermes/pkg/authz"
+	"github.com/hashicorp-forge/hermes/pkg/indexer/replay"
+	"github.com/hashicorp-forge/hermes/pkg/kafka"
+	"github.com/hashicorp-forge/hermes/pkg/kafka/producer"
+)
+
+// AdminReplayRevisionEventsRequest selects the document revisions to replay.
+// At least one of DocumentUUID, Product, Since, or Until is required.
+type AdminReplayRevisionEventsRequest struct {
+	DocumentUUID string     `json:"documentUuid,omitempty"`
+	Product      string     `json:"product,omitempty"`
+	Since        *time.Time `json:"since,omitempty"`
+	Until        *time.Time `json:"until,omitempty"`
+
+	// Limit is the maximum number of revisions to replay (default 1000, at
+	// most 10000).
+	Limit int `json:"limit,omitempty"`
+
+	// DryRun lists the revisions that would be replayed without producing
+	// their events.
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// AdminReplayRevisionEventsResponse is the result of a replay.
+type AdminReplayRevisionEventsResponse struct {
+	Topic     string                  `json:"topic"`
+	DryRun    bool                    `json:"dryRun"`
+	Matched   int                     `json:"matched"`
+	Published int                     `json:"published"`
+	Revisions []AdminReplayedRevision `json:"revisions"`
+
+	// Truncated is true if more revisions matched than the limit.
+	Truncated bool `json:"truncated"`
+}
+
+// AdminReplayedRevision is a document revision whose event was replayed.
+type AdminReplayedRevision struct {
+	ID           uint      `json:"id"`
+	DocumentUUID string    `json:"documentUuid"`
+	DocumentID   string    `json:"documentId"`
+	ProviderType string    `json:"providerType"`
+	Title        string    `json:"title"`
+	DocumentType string    `json:"documentType,omitempty"`
+	Product      string    `json:"product,omitempty"`
+	ModifiedTime time.Time `json:"modifiedTime"`
+}
+
+// AdminRevisionEventsHandler re-emits document revision events for revisions
+// in the database onto the document revision topic, so fixes to the indexer
+// and notifier can be re-applied without a full backfill.
+//
+// POST /api/v2/admin/revision-events/replay - Replay revision events
+//
+// Only site admins are allowed.
+func AdminRevisionEventsHandler(srv server.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
+		if r.URL.Path != "/api/v2/admin/revision-events/replay" {
+			writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound, "Not found")
+			return
+		}
+		if r.Method != http.MethodPost {
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
+			return
+		}
+
+		if !authorizeRequest(w, r, srv.Config, srv.DB, srv.Logger,
+			authz.ActionAdmin, authz.Resource{},
+			"Only site admins can replay revision events",
+		) {
+			return
+		}
+
+		var req AdminReplayRevisionEventsRequest
+		if err := decodeRequest(r, &req); err != nil {
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				fmt.Sprintf("Bad request: %q", err))
+			return
+		}
+		filter := replay.Filter{
+			Product: req.Product,
+			Since:   req.Since,
+			Until:   req.Until,
+			Limit:   req.Limit,
+		}
+		if req.DocumentUUID != "" {
+			id, err := uuid.Parse(req.DocumentUUID)
+			if err != nil {
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					"Bad request: invalid document UUID")
+				return
+			}
+			filter.DocumentUUID = &id
+		}
+		if err := filter.Validate(); err != nil {
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				fmt.Sprintf("Bad request: %v", err))
+			return
+		}
+
+		revisions, truncated, err := replay.FindRevisions(srv.DB, filter)
+		if err != nil {
+			respondError(w, r, srv.Logger, http.StatusInternalServerError,
+				"Error finding revisions",
+				"error finding revisions to replay", err)
+			return
+		}
+
+		topic := kafka.GetDocumentRevisionTopic(srv.Config)
+		resp := AdminReplayRevisionEventsResponse{
+			Topic:     topic,
+			DryRun:    req.DryRun,
+			Matched:   len(revisions),
+			Revisions: []AdminReplayedRevision{},
+			Truncated: truncated,
+		}
+		for _, rev := range revisions {
+			resp.Revisions = append(resp.Revisions, AdminReplayedRevision{
+				ID:           rev.ID,
+				DocumentUUID: rev.DocumentUUID.String(),
+				DocumentID:   rev.DocumentID,
+				ProviderType: rev.ProviderType,
+				Title:        rev.Title,
+				DocumentType: rev.DocumentType,
+				Product:      rev.Product,
+				ModifiedTime: rev.ModifiedTime,
+			})
+		}
+		if req.DryRun || len(revisions) == 0 {
+			writeAdminResponse(srv, w, r, http.StatusOK, resp)
+			return
+		}
+
+		var producerCfg *config.KafkaProducer
+		if srv.Config.Indexer != nil {
+			producerCfg = srv.Config.Indexer.Producer
+		}
+		p, err := producer.New(producerCfg.ToProducerConfig(
+			kafka.GetBrokers(srv.Config), "revision-replay"))
+		if err != nil {
+			respondError(w, r, srv.Logger, http.StatusInternalServerError,
+				"Error replaying revision events",
+				"error creating kafka producer", err)
+			return
+		}
+		defer p.Close()
+
+		resp.Published, err = replay.Publish(r.Context(), p, topic, revisions)
+		srv.Logger.Info("replayed revision events",
+			"topic", topic,
+			"matched", resp.Matched,
+			"published", resp.Published,
+			"replayed_by", pkgauth.MustGetUserEmail(r.Context()),
+		)
+		if err != nil {
+			respondError(w, r, srv.Logger, http.StatusBadGateway,
+				fmt.Sprintf("Error replaying revision events: published %d of %d",
+					resp.Published, resp.Matched),
+				"error publishing replayed revision events", err)
+			return
+		}
+
+		writeAdminResponse(srv, w, r, http.StatusOK, resp)
+	})
+}
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/internal/server"
+	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminRevisionEventsHandler(t *testing.T) {
+	srv := server.Server{
+		Config: &config.Config{
+			Authorization: &config.Authorization{
+				SiteAdmins: []string{"admin@example.com"},
+			},
+		},
+		Logger: hclog.NewNullLogger(),
+	}
+
+	newRequest := func(method, body, userEmail string) *http.Request {
+		req := httptest.NewRequest(method,
+			"/api/v2/admin/revision-events/replay", strings.NewReader(body))
+		return req.WithContext(context.WithValue(
+			req.Context(), pkgauth.UserEmailKey, userEmail))
+	}
+
+	t.Run("other users are forbidden", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		AdminRevisionEventsHandler(srv).ServeHTTP(w,
+			newRequest("POST", `{"product":"Hermes"}`, "user@example.com"))
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("method not allowed", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		AdminRevisionEventsHandler(srv).ServeHTTP(w,
+			newRequest("GET", "", "admin@example.com"))
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+
+	t.Run("bad requests", func(t *testing.T) {
+		for _, body := range []string{
+			`{}`,
+			`{"dryRun":true}`,
+			`{"documentUuid":"not-a-uuid"}`,
+			`{"since":"2025-02-01T00:00:00Z","until":"2025-01-01T00:00:00Z"}`,
+			`{"product":"Hermes","limit":100000}`,
+			`{"product":"Hermes","unknown":true}`,
+		} {
+			w := httptest.NewRecorder()
+			AdminRevisionEventsHandler(srv).ServeHTTP(w,
+				newRequest("POST", body, "admin@example.com"))
+			assert.Equal(t, http.StatusBadRequest, w.Code, body)
+		}
+	})
+}
@@ -0,0 +1,223 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/hashicorp-forge/hermes/internal/server"
+	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+	"github.com/hashicorp-forge/hermes/pkg/authz"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"gorm.io/gorm"
+)
+
+// UserRole is a role granted to a user in API responses.
+type UserRole struct {
+	ID           uint      `json:"id"`
+	User         string    `json:"user"`
+	Role         string    `json:"role"`
+	DocumentType string    `json:"documentType,omitempty"`
+	Product      string    `json:"product,omitempty"`
+	GrantedBy    string    `json:"grantedBy,omitempty"`
+	GrantedAt    time.Time `json:"grantedAt"`
+}
+
+// AdminRolesPostRequest contains the fields to grant a role to a user.
+type AdminRolesPostRequest struct {
+	User string `json:"user"`
+	Role string `json:"role"`
+
+	// DocumentType is required for the document_type_admin role.
+	DocumentType string `json:"documentType,omitempty"`
+
+	// Product is required for the product_lead role.
+	Product string `json:"product,omitempty"`
+}
+
+// AdminRolesHandler manages user roles. GET /api/v2/admin/roles lists granted
+// roles, POST grants a role, and DELETE /api/v2/admin/roles/:id revokes one.
+// Only site admins are allowed.
+func AdminRolesHandler(srv server.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
+		if !authorizeRequest(w, r, srv.Config, srv.DB, srv.Logger,
+			authz.ActionAdmin, authz.Resource{},
+			"Only site admins can manage roles",
+		) {
+			return
+		}
+		userEmail := pkgauth.MustGetUserEmail(r.Context())
+
+		errResp := func(httpCode int, userErrMsg, logErrMsg string, err error) {
+			respondError(w, r, srv.Logger, httpCode, userErrMsg, logErrMsg, err)
+		}
+
+		idStr := strings.TrimPrefix(
+			strings.TrimPrefix(r.URL.Path, "/api/v2/admin/roles"), "/")
+
+		if idStr != "" {
+			if r.Method != "DELETE" {
+				writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+					"Method not allowed")
+				return
+			}
+
+			id, err := strconv.ParseUint(idStr, 10, 64)
+			if err != nil || id == 0 {
+				writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound,
+					"Role not found")
+				return
+			}
+
+			role := models.UserRole{}
+			role.ID = uint(id)
+			if err := role.Get(srv.DB); err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound,
+						"Role not found")
+					return
+				}
+				errResp(http.StatusInternalServerError, "Error accessing role",
+					"error getting user role", err)
+				return
+			}
+
+			if err := role.Delete(srv.DB); err != nil {
+				errResp(http.StatusInternalServerError, "Error revoking role",
+					"error deleting user role", err)
+				return
+			}
+
+			srv.Logger.Info("revoked user role",
+				"user", role.User.EmailAddress,
+				"role", role.Role,
+				"revoked_by", userEmail,
+			)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		switch r.Method {
+		case "GET":
+			var roles models.UserRoles
+			if err := roles.Find(srv.DB); err != nil {
+				errResp(http.StatusInternalServerError, "Error getting roles",
+					"error finding user roles", err)
+				return
+			}
+
+			resp := []UserRole{}
+			for _, role := range roles {
+				resp = append(resp, userRoleResponse(role))
+			}
+			writeAdminResponse(srv, w, r, http.StatusOK, resp)
+
+		case "POST":
+			var req AdminRolesPostRequest
+			if err := decodeRequest(r, &req); err != nil {
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					fmt.Sprintf("Bad request: %q", err))
+				return
+			}
+
+			roleType, ok := models.ParseRoleTypeString(req.Role)
+			if !ok {
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					fmt.Sprintf("Invalid role %q", req.Role))
+				return
+			}
+			if req.User == "" {
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					"user is required")
+				return
+			}
+
+			role := models.UserRole{
+				User: models.User{
+					EmailAddress: req.User,
+				},
+				Role:      roleType,
+				GrantedBy: userEmail,
+			}
+			if req.DocumentType != "" {
+				role.DocumentType = &models.DocumentType{
+					Name: req.DocumentType,
+				}
+			}
+			if req.Product != "" {
+				role.Product = &models.Product{
+					Name: req.Product,
+				}
+			}
+
+			if err := role.Create(srv.DB); err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+						"Document type or product not found")
+					return
+				}
+				var verr validation.Errors
+				if errors.As(err, &verr) {
+					writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+						fmt.Sprintf("Bad request: %v", err))
+					return
+				}
+				errResp(http.StatusInternalServerError, "Error granting role",
+					"error creating user role", err)
+				return
+			}
+
+			srv.Logger.Info("granted user role",
+				"user", req.User,
+				"role", roleType,
+				"granted_by", userEmail,
+			)
+			writeAdminResponse(
+				srv, w, r, http.StatusCreated, userRoleResponse(role))
+
+		default:
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
+			return
+		}
+	})
+}
+
+// userRoleResponse converts a user role to its API response.
+func userRoleResponse(role models.UserRole) UserRole {
+	resp := UserRole{
+		ID:        role.ID,
+		User:      role.User.EmailAddress,
+		Role:      role.Role.String(),
+		GrantedBy: role.GrantedBy,
+		GrantedAt: role.CreatedAt,
+	}
+	if role.DocumentType != nil {
+		resp.DocumentType = role.DocumentType.Name
+	}
+	if role.Product != nil {
+		resp.Product = role.Product.Name
+	}
+	return resp
+}
+
+func writeAdminResponse(
+	srv server.Server, w http.ResponseWriter, r *http.Request,
+	status int, resp any,
+) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		srv.Logger.Error("error encoding admin response",
+			"error", err,
+			"method", r.Method,
+			"path", r.URL.Path,
+		)
+	}
+}
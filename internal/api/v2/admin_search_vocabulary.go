@@ -0,0 +1,265 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/hashicorp-forge/hermes/internal/server"
+	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+	"github.com/hashicorp-forge/hermes/pkg/authz"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp-forge/hermes/pkg/search"
+	"gorm.io/gorm"
+)
+
+var adminSearchVocabularyURLPathRE = regexp.MustCompile(
+	`^/api/v2/admin/search-vocabulary(?:/(stop-words)|/synonyms/([^/]+))?$`)
+
+// AdminSearchVocabulary contains the synonyms and stop words of searches.
+type AdminSearchVocabulary struct {
+	// Provider is the name of the search provider.
+	Provider string `json:"provider"`
+
+	// Supported is false if the search provider doesn't support managing its
+	// vocabulary, in which case it can't be changed.
+	Supported bool `json:"supported"`
+
+	Synonyms  []AdminSearchSynonymSet `json:"synonyms"`
+	StopWords []string                `json:"stopWords"`
+
+	// ReindexRequired is true if the search indexes must be rebuilt for a
+	// change to fully apply (e.g., stop words with Bleve).
+	ReindexRequired bool `json:"reindexRequired,omitempty"`
+}
+
+// AdminSearchSynonymSet is a named set of equivalent search terms.
+type AdminSearchSynonymSet struct {
+	Name      string    `json:"name"`
+	Terms     []string  `json:"terms"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// AdminSearchSynonymSetPutRequest contains the terms of a synonym set to
+// create or update.
+type AdminSearchSynonymSetPutRequest struct {
+	Terms []string `json:"terms"`
+}
+
+// AdminSearchStopWordsPutRequest contains the stop words to replace the
+// current ones with.
+type AdminSearchStopWordsPutRequest struct {
+	StopWords []string `json:"stopWords"`
+}
+
+// AdminSearchVocabularyHandler manages the synonyms and stop words of searches.
+//
+// GET    /api/v2/admin/search-vocabulary                 - Get the vocabulary
+// PUT    /api/v2/admin/search-vocabulary/synonyms/:name  - Create or update a synonym set
+// DELETE /api/v2/admin/search-vocabulary/synonyms/:name  - Delete a synonym set
+// PUT    /api/v2/admin/search-vocabulary/stop-words      - Replace the stop words
+//
+// The vocabulary is stored in the database and pushed to the search provider
+// on every change and on startup. Changes the provider rejects aren't saved.
+// Every response contains the whole vocabulary. Only site admins are allowed.
+func AdminSearchVocabularyHandler(srv server.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
+		if !authorizeRequest(w, r, srv.Config, srv.DB, srv.Logger,
+			authz.ActionAdmin, authz.Resource{},
+			"Only site admins can manage the search vocabulary",
+		) {
+			return
+		}
+		userEmail := pkgauth.MustGetUserEmail(r.Context())
+
+		errResp := func(httpCode int, userErrMsg, logErrMsg string, err error) {
+			respondError(w, r, srv.Logger, httpCode, userErrMsg, logErrMsg, err)
+		}
+
+		matches := adminSearchVocabularyURLPathRE.FindStringSubmatch(r.URL.Path)
+		if matches == nil {
+			writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound, "Not found")
+			return
+		}
+		stopWords, synonymSet := matches[1] != "", matches[2]
+
+		if r.Method == "GET" && !stopWords && synonymSet == "" {
+			resp, err := adminSearchVocabularyResponse(srv, srv.DB)
+			if err != nil {
+				errResp(http.StatusInternalServerError,
+					"Error getting search vocabulary",
+					"error getting search vocabulary", err)
+				return
+			}
+			writeAdminResponse(srv, w, r, http.StatusOK, resp)
+			return
+		}
+		if (r.Method != "PUT" && r.Method != "DELETE") ||
+			(r.Method == "DELETE" && synonymSet == "") ||
+			(!stopWords && synonymSet == "") {
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
+			return
+		}
+		if srv.SearchVocabulary == nil {
+			writeProblem(w, r, http.StatusNotImplemented, ErrCodeUnsupportedProvider,
+				"The search provider doesn't support managing its vocabulary")
+			return
+		}
+
+		// change changes the vocabulary in the database.
+		var change func(tx *gorm.DB) error
+		switch {
+		case stopWords:
+			var req AdminSearchStopWordsPutRequest
+			if err := decodeRequest(r, &req); err != nil {
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					fmt.Sprintf("Bad request: %q", err))
+				return
+			}
+			change = func(tx *gorm.DB) error {
+				return models.ReplaceSearchStopWords(tx, req.StopWords)
+			}
+
+		case r.Method == "PUT":
+			var req AdminSearchSynonymSetPutRequest
+			if err := decodeRequest(r, &req); err != nil {
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					fmt.Sprintf("Bad request: %q", err))
+				return
+			}
+			change = func(tx *gorm.DB) error {
+				s := models.SearchSynonymSet{
+					Name:  synonymSet,
+					Terms: req.Terms,
+				}
+				return s.Upsert(tx)
+			}
+
+		default:
+			change = func(tx *gorm.DB) error {
+				s := models.SearchSynonymSet{
+					Name: synonymSet,
+				}
+				return s.Delete(tx)
+			}
+		}
+
+		// Change the vocabulary and push it to the search provider in a
+		// transaction, so changes the provider rejects are rolled back.
+		var (
+			resp    AdminSearchVocabulary
+			pushErr error
+		)
+		if err := srv.DB.Transaction(func(tx *gorm.DB) error {
+			if err := change(tx); err != nil {
+				return err
+			}
+			reindex, err := pushSearchVocabulary(r.Context(), srv, tx)
+			if err != nil {
+				pushErr = err
+				return err
+			}
+			resp, err = adminSearchVocabularyResponse(srv, tx)
+			resp.ReindexRequired = reindex
+			return err
+		}); err != nil {
+			var verr validation.Errors
+			switch {
+			case errors.Is(err, gorm.ErrRecordNotFound):
+				writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound,
+					"Synonym set not found")
+			case errors.As(err, &verr):
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					fmt.Sprintf("Bad request: %v", err))
+			case pushErr != nil:
+				errResp(http.StatusBadGateway,
+					"Error updating the search provider",
+					"error pushing search vocabulary", err)
+			default:
+				errResp(http.StatusInternalServerError,
+					"Error updating search vocabulary",
+					"error updating search vocabulary", err)
+			}
+			return
+		}
+
+		srv.Logger.Info("updated search vocabulary",
+			"method", r.Method,
+			"path", r.URL.Path,
+			"reindex_required", resp.ReindexRequired,
+			"updated_by", userEmail,
+		)
+		writeAdminResponse(srv, w, r, http.StatusOK, resp)
+	})
+}
+
+// PushSearchVocabulary pushes the search vocabulary in the database to the
+// search provider, if it supports managing its vocabulary. It returns true if
+// the search indexes must be rebuilt for the vocabulary to fully apply.
+func PushSearchVocabulary(ctx context.Context, srv server.Server) (bool, error) {
+	if srv.SearchVocabulary == nil {
+		return false, nil
+	}
+	return pushSearchVocabulary(ctx, srv, srv.DB)
+}
+
+// pushSearchVocabulary pushes the search vocabulary in database db to the
+// search provider.
+func pushSearchVocabulary(
+	ctx context.Context, srv server.Server, db *gorm.DB,
+) (bool, error) {
+	var sets models.SearchSynonymSets
+	if err := sets.FindAll(db); err != nil {
+		return false, fmt.Errorf("error finding synonym sets: %w", err)
+	}
+	stopWords, err := models.GetSearchStopWords(db)
+	if err != nil {
+		return false, fmt.Errorf("error getting stop words: %w", err)
+	}
+
+	v := search.Vocabulary{
+		StopWords: stopWords,
+	}
+	for _, s := range sets {
+		v.Synonyms = append(v.Synonyms, s.Terms)
+	}
+	return srv.SearchVocabulary.UpdateVocabulary(ctx, v)
+}
+
+// adminSearchVocabularyResponse returns the search vocabulary in database db.
+func adminSearchVocabularyResponse(
+	srv server.Server, db *gorm.DB,
+) (AdminSearchVocabulary, error) {
+	resp := AdminSearchVocabulary{
+		Supported: srv.SearchVocabulary != nil,
+		Synonyms:  []AdminSearchSynonymSet{},
+	}
+	if srv.SearchProvider != nil {
+		resp.Provider = srv.SearchProvider.Name()
+	}
+
+	var sets models.SearchSynonymSets
+	if err := sets.FindAll(db); err != nil {
+		return resp, fmt.Errorf("error finding synonym sets: %w", err)
+	}
+	for _, s := range sets {
+		resp.Synonyms = append(resp.Synonyms, AdminSearchSynonymSet{
+			Name:      s.Name,
+			Terms:     s.Terms,
+			UpdatedAt: s.UpdatedAt,
+		})
+	}
+
+	stopWords, err := models.GetSearchStopWords(db)
+	if err != nil {
+		return resp, fmt.Errorf("error getting stop words: %w", err)
+	}
+	resp.StopWords = stopWords
+	return resp, nil
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/internal/server"
+	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminSearchVocabularyHandler(t *testing.T) {
+	srv := server.Server{
+		Config: &config.Config{
+			Authorization: &config.Authorization{
+				SiteAdmins: []string{"admin@example.com"},
+			},
+		},
+		Logger: hclog.NewNullLogger(),
+	}
+
+	serve := func(method, target, userEmail, body string) int {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req = req.WithContext(context.WithValue(
+			req.Context(), pkgauth.UserEmailKey, userEmail))
+		w := httptest.NewRecorder()
+		AdminSearchVocabularyHandler(srv).ServeHTTP(w, req)
+		return w.Code
+	}
+
+	t.Run("other users are forbidden", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, serve("GET",
+			"/api/v2/admin/search-vocabulary", "user@example.com", ""))
+	})
+
+	t.Run("unknown paths are not found", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, serve("GET",
+			"/api/v2/admin/search-vocabulary/synonyms", "admin@example.com", ""))
+		assert.Equal(t, http.StatusNotFound, serve("PUT",
+			"/api/v2/admin/search-vocabulary/synonyms/vm/terms",
+			"admin@example.com", ""))
+	})
+
+	t.Run("methods", func(t *testing.T) {
+		assert.Equal(t, http.StatusMethodNotAllowed, serve("POST",
+			"/api/v2/admin/search-vocabulary", "admin@example.com", ""))
+		assert.Equal(t, http.StatusMethodNotAllowed, serve("DELETE",
+			"/api/v2/admin/search-vocabulary/stop-words", "admin@example.com", ""))
+		assert.Equal(t, http.StatusMethodNotAllowed, serve("GET",
+			"/api/v2/admin/search-vocabulary/synonyms/vm", "admin@example.com", ""))
+	})
+
+	t.Run("unsupported search providers can't be changed", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotImplemented, serve("PUT",
+			"/api/v2/admin/search-vocabulary/synonyms/vm", "admin@example.com",
+			`{"terms": ["VM", "virtual machine"]}`))
+		assert.Equal(t, http.StatusNotImplemented, serve("PUT",
+			"/api/v2/admin/search-vocabulary/stop-words", "admin@example.com",
+			`{"stopWords": ["hashicorp"]}`))
+	})
+}
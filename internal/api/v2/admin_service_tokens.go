@@ -0,0 +1,316 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp-forge/hermes/internal/server"
+	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+	"github.com/hashicorp-forge/hermes/pkg/authz"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"gorm.io/gorm"
+)
+
+// ServiceToken is a service token in API responses. The plaintext token is
+// only included when a token is created or rotated.
+type ServiceToken struct {
+	ID            uuid.UUID  `json:"id"`
+	Type          string     `json:"type"`
+	Name          string     `json:"name,omitempty"`
+	Scopes        []string   `json:"scopes"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	ExpiresAt     *time.Time `json:"expiresAt,omitempty"`
+	LastUsedAt    *time.Time `json:"lastUsedAt,omitempty"`
+	Revoked       bool       `json:"revoked"`
+	RevokedAt     *time.Time `json:"revokedAt,omitempty"`
+	RevokedReason string     `json:"revokedReason,omitempty"`
+	IndexerID     *uuid.UUID `json:"indexerID,omitempty"`
+	Token         string     `json:"token,omitempty"`
+}
+
+// AdminServiceTokensPostRequest contains the fields to mint a service token.
+type AdminServiceTokensPostRequest struct {
+	// Type is the token type ("api", "edge", or "registration").
+	Type string `json:"type"`
+
+	Name string `json:"name"`
+
+	// Scopes are the scopes the token is allowed ("read", "sync",
+	// "notifications", or "workers"). At least one scope is required.
+	Scopes []string `json:"scopes"`
+
+	// ExpiresIn is the lifetime of the token as a duration string (e.g.,
+	// "720h"). The token never expires if empty.
+	ExpiresIn string `json:"expiresIn,omitempty"`
+}
+
+// AdminServiceTokensRotateRequest contains the fields to rotate a service
+// token.
+type AdminServiceTokensRotateRequest struct {
+	// GracePeriod is how long the rotated token remains valid as a duration
+	// string (e.g., "1h"). The rotated token is revoked immediately if empty.
+	GracePeriod string `json:"gracePeriod,omitempty"`
+}
+
+// mintableServiceTokenTypes are the token types that can be minted with the
+// admin API.
+var mintableServiceTokenTypes = []string{"api", "edge", "registration"}
+
+var adminServiceTokensURLPathRE = regexp.MustCompile(
+	`^/api/v2/admin/service-tokens(?:/([0-9a-fA-F-]{36})(/rotate)?)?/?$`)
+
+// AdminServiceTokensHandler manages service tokens used by edge instances,
+// indexers, and other services.
+//
+// GET    /api/v2/admin/service-tokens             - List tokens
+// POST   /api/v2/admin/service-tokens             - Mint a token
+// GET    /api/v2/admin/service-tokens/:id         - Get a token
+// DELETE /api/v2/admin/service-tokens/:id         - Revoke a token
+// POST   /api/v2/admin/service-tokens/:id/rotate  - Rotate a token
+//
+// Only site admins are allowed.
+func AdminServiceTokensHandler(srv server.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
+		if !authorizeRequest(w, r, srv.Config, srv.DB, srv.Logger,
+			authz.ActionAdmin, authz.Resource{},
+			"Only site admins can manage service tokens",
+		) {
+			return
+		}
+		userEmail := pkgauth.MustGetUserEmail(r.Context())
+
+		errResp := func(httpCode int, userErrMsg, logErrMsg string, err error) {
+			respondError(w, r, srv.Logger, httpCode, userErrMsg, logErrMsg, err)
+		}
+
+		matches := adminServiceTokensURLPathRE.FindStringSubmatch(r.URL.Path)
+		if matches == nil {
+			writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound,
+				"Service token not found")
+			return
+		}
+		idStr, rotate := matches[1], matches[2] != ""
+
+		if idStr == "" {
+			switch r.Method {
+			case "GET":
+				var tokens models.IndexerTokens
+				if err := tokens.FindByType(
+					srv.DB,
+					r.URL.Query().Get("type"),
+					r.URL.Query().Get("includeRevoked") == "true",
+				); err != nil {
+					errResp(http.StatusInternalServerError,
+						"Error getting service tokens",
+						"error finding service tokens", err)
+					return
+				}
+
+				resp := []ServiceToken{}
+				for _, t := range tokens {
+					resp = append(resp, serviceTokenResponse(t))
+				}
+				writeAdminResponse(srv, w, r, http.StatusOK, resp)
+
+			case "POST":
+				var req AdminServiceTokensPostRequest
+				if err := decodeRequest(r, &req); err != nil {
+					writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+						fmt.Sprintf("Bad request: %q", err))
+					return
+				}
+				if err := validateServiceTokenRequest(req); err != nil {
+					writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+						fmt.Sprintf("Bad request: %v", err))
+					return
+				}
+
+				token := models.IndexerToken{
+					TokenType: req.Type,
+					Name:      req.Name,
+					Scopes:    models.StringArray(req.Scopes),
+				}
+				if req.ExpiresIn != "" {
+					// Already validated.
+					d, _ := time.ParseDuration(req.ExpiresIn)
+					exp := time.Now().Add(d)
+					token.ExpiresAt = &exp
+				}
+
+				plaintext, err := models.GenerateToken(req.Type)
+				if err != nil {
+					errResp(http.StatusInternalServerError,
+						"Error creating service token",
+						"error generating service token", err)
+					return
+				}
+				if err := token.Create(srv.DB, plaintext); err != nil {
+					errResp(http.StatusInternalServerError,
+						"Error creating service token",
+						"error creating service token", err)
+					return
+				}
+
+				srv.Logger.Info("created service token",
+					"token_id", token.ID,
+					"token_type", token.TokenType,
+					"scopes", req.Scopes,
+					"created_by", userEmail,
+				)
+				resp := serviceTokenResponse(token)
+				resp.Token = plaintext
+				writeAdminResponse(srv, w, r, http.StatusCreated, resp)
+
+			default:
+				writeProblem(w, r, http.StatusMethodNotAllowed,
+					ErrCodeMethodNotAllowed, "Method not allowed")
+			}
+			return
+		}
+
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound,
+				"Service token not found")
+			return
+		}
+		token := models.IndexerToken{ID: id}
+		if err := token.Get(srv.DB); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound,
+					"Service token not found")
+				return
+			}
+			errResp(http.StatusInternalServerError,
+				"Error accessing service token",
+				"error getting service token", err)
+			return
+		}
+
+		if rotate {
+			if r.Method != "POST" {
+				writeProblem(w, r, http.StatusMethodNotAllowed,
+					ErrCodeMethodNotAllowed, "Method not allowed")
+				return
+			}
+
+			var req AdminServiceTokensRotateRequest
+			if err := decodeRequest(r, &req); err != nil {
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					fmt.Sprintf("Bad request: %q", err))
+				return
+			}
+			var gracePeriod time.Duration
+			if req.GracePeriod != "" {
+				gracePeriod, err = time.ParseDuration(req.GracePeriod)
+				if err != nil || gracePeriod < 0 {
+					writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+						fmt.Sprintf("Invalid gracePeriod %q", req.GracePeriod))
+					return
+				}
+			}
+
+			if !token.IsValid() {
+				writeProblem(w, r, http.StatusConflict, ErrCodeConflict,
+					"Service token has expired or been revoked")
+				return
+			}
+
+			newToken, plaintext, err := token.Rotate(srv.DB, gracePeriod)
+			if err != nil {
+				errResp(http.StatusInternalServerError,
+					"Error rotating service token",
+					"error rotating service token", err)
+				return
+			}
+
+			srv.Logger.Info("rotated service token",
+				"token_id", token.ID,
+				"new_token_id", newToken.ID,
+				"grace_period", gracePeriod,
+				"rotated_by", userEmail,
+			)
+			resp := serviceTokenResponse(newToken)
+			resp.Token = plaintext
+			writeAdminResponse(srv, w, r, http.StatusCreated, resp)
+			return
+		}
+
+		switch r.Method {
+		case "GET":
+			writeAdminResponse(
+				srv, w, r, http.StatusOK, serviceTokenResponse(token))
+
+		case "DELETE":
+			if !token.Revoked {
+				reason := r.URL.Query().Get("reason")
+				if reason == "" {
+					reason = fmt.Sprintf("revoked by %s", userEmail)
+				}
+				if err := token.Revoke(srv.DB, reason); err != nil {
+					errResp(http.StatusInternalServerError,
+						"Error revoking service token",
+						"error revoking service token", err)
+					return
+				}
+				srv.Logger.Info("revoked service token",
+					"token_id", token.ID,
+					"revoked_by", userEmail,
+				)
+			}
+			w.WriteHeader(http.StatusNoContent)
+
+		default:
+			writeProblem(w, r, http.StatusMethodNotAllowed,
+				ErrCodeMethodNotAllowed, "Method not allowed")
+		}
+	})
+}
+
+// validateServiceTokenRequest validates a request to mint a service token.
+func validateServiceTokenRequest(req AdminServiceTokensPostRequest) error {
+	if !contains(mintableServiceTokenTypes, req.Type) {
+		return fmt.Errorf("invalid token type %q", req.Type)
+	}
+	if len(req.Scopes) == 0 {
+		return fmt.Errorf("at least one scope is required")
+	}
+	for _, s := range req.Scopes {
+		if !models.IsValidTokenScope(s) {
+			return fmt.Errorf("invalid scope %q", s)
+		}
+	}
+	if req.ExpiresIn != "" {
+		d, err := time.ParseDuration(req.ExpiresIn)
+		if err != nil || d <= 0 {
+			return fmt.Errorf("invalid expiresIn %q", req.ExpiresIn)
+		}
+	}
+	return nil
+}
+
+// serviceTokenResponse converts a service token to its API response.
+func serviceTokenResponse(t models.IndexerToken) ServiceToken {
+	scopes := []string(t.Scopes)
+	if scopes == nil {
+		scopes = []string{}
+	}
+	return ServiceToken{
+		ID:            t.ID,
+		Type:          t.TokenType,
+		Name:          t.Name,
+		Scopes:        scopes,
+		CreatedAt:     t.CreatedAt,
+		ExpiresAt:     t.ExpiresAt,
+		LastUsedAt:    t.LastUsedAt,
+		Revoked:       t.Revoked,
+		RevokedAt:     t.RevokedAt,
+		RevokedReason: t.RevokedReason,
+		IndexerID:     t.IndexerID,
+	}
+}
@@ -0,0 +1,106 @@
+package api
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateServiceTokenRequest(t *testing.T) {
+	cases := map[string]struct {
+		req     AdminServiceTokensPostRequest
+		wantErr bool
+	}{
+		"valid edge token": {
+			req: AdminServiceTokensPostRequest{
+				Type:   "edge",
+				Name:   "edge-us-east",
+				Scopes: []string{"sync"},
+			},
+		},
+		"valid expiring read-only token": {
+			req: AdminServiceTokensPostRequest{
+				Type:      "api",
+				Scopes:    []string{"read"},
+				ExpiresIn: "720h",
+			},
+		},
+		"invalid type": {
+			req: AdminServiceTokensPostRequest{
+				Type:   "admin",
+				Scopes: []string{"read"},
+			},
+			wantErr: true,
+		},
+		"no scopes": {
+			req: AdminServiceTokensPostRequest{
+				Type: "edge",
+			},
+			wantErr: true,
+		},
+		"invalid scope": {
+			req: AdminServiceTokensPostRequest{
+				Type:   "edge",
+				Scopes: []string{"sync", "write"},
+			},
+			wantErr: true,
+		},
+		"invalid expiration": {
+			req: AdminServiceTokensPostRequest{
+				Type:      "edge",
+				Scopes:    []string{"sync"},
+				ExpiresIn: "-1h",
+			},
+			wantErr: true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := validateServiceTokenRequest(c.req)
+			if c.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAdminServiceTokensURLPathRE(t *testing.T) {
+	id := "1b4e28ba-2fa1-11d2-883f-0016d3cca427"
+
+	m := adminServiceTokensURLPathRE.FindStringSubmatch(
+		"/api/v2/admin/service-tokens")
+	if assert.NotNil(t, m) {
+		assert.Equal(t, "", m[1])
+	}
+
+	m = adminServiceTokensURLPathRE.FindStringSubmatch(
+		"/api/v2/admin/service-tokens/" + id)
+	if assert.NotNil(t, m) {
+		assert.Equal(t, id, m[1])
+		assert.Equal(t, "", m[2])
+	}
+
+	m = adminServiceTokensURLPathRE.FindStringSubmatch(
+		"/api/v2/admin/service-tokens/" + id + "/rotate")
+	if assert.NotNil(t, m) {
+		assert.Equal(t, id, m[1])
+		assert.Equal(t, "/rotate", m[2])
+	}
+
+	assert.Nil(t, adminServiceTokensURLPathRE.FindStringSubmatch(
+		"/api/v2/admin/service-tokens/"+id+"/other"))
+}
+
+func TestEdgeSyncRequiredScope(t *testing.T) {
+	assert.Equal(t, models.TokenScopeRead, edgeSyncRequiredScope(
+		httptest.NewRequest("GET", "/api/v2/edge/documents/sync-status", nil)))
+	assert.Equal(t, models.TokenScopeSync, edgeSyncRequiredScope(
+		httptest.NewRequest("POST", "/api/v2/edge/documents/register", nil)))
+	assert.Equal(t, models.TokenScopeSync, edgeSyncRequiredScope(
+		httptest.NewRequest("DELETE", "/api/v2/edge/documents/abc", nil)))
+}
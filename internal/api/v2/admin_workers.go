@@ -0,0 +1,168 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/server"
+	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+	"github.com/hashicorp-forge/hermes/pkg/authz"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"gorm.io/gorm"
+)
+
+// defaultWorkerStaleAfter is how long a worker can go without a heartbeat
+// before it is flagged as stale. Workers send heartbeats every 30s by default.
+const defaultWorkerStaleAfter = 2 * time.Minute
+
+var adminWorkersURLPathRE = regexp.MustCompile(
+	`^/api/v2/admin/workers(?:/([^/]+))?$`)
+
+// AdminWorker is a pipeline worker (an indexer or notifier) in the worker
+// registry.
+type AdminWorker struct {
+	Name       string                 `json:"name"`
+	Kind       string                 `json:"kind"`
+	Version    string                 `json:"version,omitempty"`
+	Hostname   string                 `json:"hostname,omitempty"`
+	RemoteAddr string                 `json:"remoteAddr,omitempty"`
+	Partitions []AdminWorkerPartition `json:"partitions"`
+
+	// Lag is the total lag of the worker on its partitions.
+	Lag int64 `json:"lag"`
+
+	ProcessedCount  int64      `json:"processedCount"`
+	ErrorCount      int64      `json:"errorCount"`
+	LastError       string     `json:"lastError,omitempty"`
+	LastErrorAt     *time.Time `json:"lastErrorAt,omitempty"`
+	StartedAt       *time.Time `json:"startedAt,omitempty"`
+	FirstSeenAt     time.Time  `json:"firstSeenAt"`
+	LastHeartbeatAt *time.Time `json:"lastHeartbeatAt,omitempty"`
+
+	// Stale is true if the worker hasn't sent a heartbeat within the stale
+	// threshold.
+	Stale bool `json:"stale"`
+}
+
+// AdminWorkerPartition is the progress of a worker on a topic partition.
+type AdminWorkerPartition struct {
+	ConsumerGroup string `json:"consumerGroup"`
+	Topic         string `json:"topic"`
+	Partition     int32  `json:"partition"`
+	Offset        int64  `json:"offset"`
+	Lag           int64  `json:"lag"`
+}
+
+// AdminWorkersHandler lists the pipeline workers that report their status
+// with WorkersHandler, so operators can see the health of the pipeline.
+//
+// GET    /api/v2/admin/workers        - List workers
+// DELETE /api/v2/admin/workers/:name  - Remove a decommissioned worker
+//
+// Workers that haven't sent a heartbeat within the "staleAfter" duration query
+// parameter (default 2m) are flagged as stale. Only site admins are allowed.
+func AdminWorkersHandler(srv server.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
+		matches := adminWorkersURLPathRE.FindStringSubmatch(r.URL.Path)
+		if matches == nil {
+			writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound, "Not found")
+			return
+		}
+		name, err := url.PathUnescape(matches[1])
+		if err != nil {
+			writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound, "Not found")
+			return
+		}
+		if !(r.Method == "GET" && name == "") &&
+			!(r.Method == "DELETE" && name != "") {
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
+			return
+		}
+
+		if !authorizeRequest(w, r, srv.Config, srv.DB, srv.Logger,
+			authz.ActionAdmin, authz.Resource{},
+			"Only site admins can manage workers",
+		) {
+			return
+		}
+
+		if r.Method == "DELETE" {
+			if err := models.DeleteWorkerInstance(srv.DB, name); err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound,
+						"Worker not found")
+					return
+				}
+				respondError(w, r, srv.Logger, http.StatusInternalServerError,
+					"Error removing worker",
+					"error deleting worker instance", err,
+					"worker", name,
+				)
+				return
+			}
+			srv.Logger.Info("removed worker",
+				"worker", name,
+				"removed_by", pkgauth.MustGetUserEmail(r.Context()),
+			)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		staleAfter := defaultWorkerStaleAfter
+		if s := r.URL.Query().Get("staleAfter"); s != "" {
+			d, err := time.ParseDuration(s)
+			if err != nil || d <= 0 {
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					"staleAfter must be a positive duration")
+				return
+			}
+			staleAfter = d
+		}
+
+		var workers models.WorkerInstances
+		if err := workers.FindAll(srv.DB); err != nil {
+			respondError(w, r, srv.Logger, http.StatusInternalServerError,
+				"Error getting workers",
+				"error finding worker instances", err)
+			return
+		}
+
+		now := time.Now()
+		resp := []AdminWorker{}
+		for _, wi := range workers {
+			partitions := []AdminWorkerPartition{}
+			for _, p := range wi.Partitions {
+				partitions = append(partitions, AdminWorkerPartition{
+					ConsumerGroup: p.ConsumerGroup,
+					Topic:         p.Topic,
+					Partition:     p.Partition,
+					Offset:        p.Offset,
+					Lag:           p.Lag,
+				})
+			}
+			resp = append(resp, AdminWorker{
+				Name:            wi.Name,
+				Kind:            wi.Kind,
+				Version:         wi.Version,
+				Hostname:        wi.Hostname,
+				RemoteAddr:      wi.RemoteAddr,
+				Partitions:      partitions,
+				Lag:             wi.Lag(),
+				ProcessedCount:  wi.ProcessedCount,
+				ErrorCount:      wi.ErrorCount,
+				LastError:       wi.LastError,
+				LastErrorAt:     wi.LastErrorAt,
+				StartedAt:       wi.StartedAt,
+				FirstSeenAt:     wi.CreatedAt,
+				LastHeartbeatAt: wi.LastHeartbeatAt,
+				Stale:           wi.IsStale(now, staleAfter),
+			})
+		}
+		writeAdminResponse(srv, w, r, http.StatusOK, resp)
+	})
+}
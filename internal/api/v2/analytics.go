@@ -19,9 +19,11 @@ type AnalyticsResponse struct {
 // Analytics handles user events for analytics
 func AnalyticsHandler(srv server.Server) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
 		// Only allow POST requests.
 		if r.Method != http.MethodPost {
-			w.WriteHeader(http.StatusMethodNotAllowed)
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
 			return
 		}
 
@@ -29,8 +31,8 @@ func AnalyticsHandler(srv server.Server) http.Handler {
 		var req AnalyticsRequest
 		if err := decoder.Decode(&req); err != nil {
 			srv.Logger.Error("error decoding analytics request", "error", err)
-			http.Error(w, "Error decoding analytics request",
-				http.StatusBadRequest)
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				"Error decoding analytics request")
 			return
 		}
 
@@ -57,8 +59,8 @@ func AnalyticsHandler(srv server.Server) http.Handler {
 		err := enc.Encode(response)
 		if err != nil {
 			srv.Logger.Error("error encoding analytics response", "error", err)
-			http.Error(w, "Error encoding analytics response",
-				http.StatusInternalServerError)
+			writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Error encoding analytics response")
 			return
 		}
 	})
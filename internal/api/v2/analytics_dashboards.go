@@ -0,0 +1,202 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/pkg/analytics"
+	"github.com/hashicorp-forge/hermes/pkg/tenant"
+)
+
+const (
+	// defaultAnalyticsMonths is the default number of months of analytics,
+	// including the current month.
+	defaultAnalyticsMonths = 12
+
+	// defaultAnalyticsContributorsLimit is the default number of top
+	// contributors returned.
+	defaultAnalyticsContributorsLimit = 10
+
+	// maxAnalyticsContributorsLimit is the maximum number of top contributors
+	// returned.
+	maxAnalyticsContributorsLimit = 100
+)
+
+// AnalyticsCachePolicy caches analytics, which are aggregated over all
+// published documents and are the same for all users.
+var AnalyticsCachePolicy = CachePolicy{Endpoint: "analytics"}
+
+// AnalyticsDocumentsGetResponse is the response for
+// GET /api/v2/analytics/documents.
+type AnalyticsDocumentsGetResponse struct {
+	// From and To are the first and last months of the analytics, in "YYYY-MM"
+	// format.
+	From string `json:"from"`
+	To   string `json:"to"`
+
+	// Months are the documents published and approved per product per month,
+	// by month and then product. Months and products without documents are
+	// omitted.
+	Months []analytics.ProductMonth `json:"months"`
+}
+
+// AnalyticsReviewTimeGetResponse is the response for
+// GET /api/v2/analytics/review-time.
+type AnalyticsReviewTimeGetResponse struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+
+	// Overall is the time in review of documents of all products.
+	Overall analytics.ReviewTime `json:"overall"`
+
+	// Products is the time in review of documents per product, by product.
+	Products []analytics.ReviewTime `json:"products"`
+}
+
+// AnalyticsContributorsGetResponse is the response for
+// GET /api/v2/analytics/contributors.
+type AnalyticsContributorsGetResponse struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+
+	// Contributors are the users who own or contribute to the most documents,
+	// most documents first.
+	Contributors []analytics.Contributor `json:"contributors"`
+}
+
+// AnalyticsDashboardsHandler serves aggregate document analytics for
+// leadership dashboards.
+// GET /api/v2/analytics/documents    - documents published and approved per
+// product per month
+// GET /api/v2/analytics/review-time  - median time in review
+// GET /api/v2/analytics/contributors - top contributors
+//
+// Analytics are computed for the months in [from, to] (query parameters in
+// "YYYY-MM" format, defaulting to the last 12 months), optionally for one
+// product. Drafts aren't counted, so analytics are available to all users.
+func AnalyticsDashboardsHandler(srv server.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
+		if r.Method != "GET" {
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
+			return
+		}
+
+		filter, err := parseAnalyticsFilter(r.URL.Query(), time.Now())
+		if err != nil {
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				fmt.Sprintf("Bad request: %v", err))
+			return
+		}
+		filter.TenantID = tenant.IDFromContext(r.Context())
+		from := filter.Since.Format("2006-01")
+		to := filter.Until.AddDate(0, -1, 0).Format("2006-01")
+		db := srv.DB.WithContext(r.Context())
+
+		var resp any
+		switch r.URL.Path {
+		case "/api/v2/analytics/documents":
+			months, err := analytics.DocumentsByProductMonth(db, filter)
+			if err != nil {
+				respondError(w, r, srv.Logger, http.StatusInternalServerError,
+					"Error getting analytics",
+					"error computing document analytics", err)
+				return
+			}
+			resp = AnalyticsDocumentsGetResponse{
+				From:   from,
+				To:     to,
+				Months: months,
+			}
+
+		case "/api/v2/analytics/review-time":
+			overall, products, err := analytics.ReviewTimes(db, filter)
+			if err != nil {
+				respondError(w, r, srv.Logger, http.StatusInternalServerError,
+					"Error getting analytics",
+					"error computing review time analytics", err)
+				return
+			}
+			resp = AnalyticsReviewTimeGetResponse{
+				From:     from,
+				To:       to,
+				Overall:  overall,
+				Products: products,
+			}
+
+		case "/api/v2/analytics/contributors":
+			limit := defaultAnalyticsContributorsLimit
+			if v := r.URL.Query().Get("limit"); v != "" {
+				n, err := strconv.Atoi(v)
+				if err != nil || n < 1 {
+					writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+						"Bad request: limit must be a positive integer")
+					return
+				}
+				limit = min(n, maxAnalyticsContributorsLimit)
+			}
+			contributors, err := analytics.TopContributors(db, filter, limit)
+			if err != nil {
+				respondError(w, r, srv.Logger, http.StatusInternalServerError,
+					"Error getting analytics",
+					"error computing contributor analytics", err)
+				return
+			}
+			resp = AnalyticsContributorsGetResponse{
+				From:         from,
+				To:           to,
+				Contributors: contributors,
+			}
+
+		default:
+			writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound, "Not found")
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			srv.Logger.Error("error encoding analytics response",
+				"error", err,
+				"method", r.Method,
+				"path", r.URL.Path,
+			)
+		}
+	})
+}
+
+// parseAnalyticsFilter parses analytics query parameters. Months default to
+// the last 12 months before and including the month of now.
+func parseAnalyticsFilter(q url.Values, now time.Time) (analytics.Filter, error) {
+	f := analytics.Filter{Product: q.Get("product")}
+
+	to := analytics.MonthStart(now)
+	if v := q.Get("to"); v != "" {
+		t, err := analytics.ParseMonth(v)
+		if err != nil {
+			return f, fmt.Errorf("invalid to month %q, must be YYYY-MM", v)
+		}
+		to = t
+	}
+	from := to.AddDate(0, 1-defaultAnalyticsMonths, 0)
+	if v := q.Get("from"); v != "" {
+		t, err := analytics.ParseMonth(v)
+		if err != nil {
+			return f, fmt.Errorf("invalid from month %q, must be YYYY-MM", v)
+		}
+		from = t
+	}
+	if from.After(to) {
+		return f, fmt.Errorf("from must not be after to")
+	}
+
+	f.Since = from
+	f.Until = to.AddDate(0, 1, 0)
+	return f, nil
+}
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/analytics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAnalyticsFilter(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	f, err := parseAnalyticsFilter(url.Values{}, now)
+	require.NoError(t, err)
+	assert.Equal(t, analytics.Filter{
+		Since: time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC),
+		Until: time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC),
+	}, f)
+
+	f, err = parseAnalyticsFilter(url.Values{
+		"product": {"Hermes"},
+		"from":    {"2026-01"},
+		"to":      {"2026-03"},
+	}, now)
+	require.NoError(t, err)
+	assert.Equal(t, analytics.Filter{
+		Product: "Hermes",
+		Since:   time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		Until:   time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+	}, f)
+
+	for _, q := range []url.Values{
+		{"from": {"2026-1-1"}},
+		{"to": {"March"}},
+		{"from": {"2026-04"}, "to": {"2026-03"}},
+	} {
+		_, err := parseAnalyticsFilter(q, now)
+		assert.Error(t, err, q)
+	}
+}
@@ -0,0 +1,324 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/server"
+	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"gorm.io/gorm"
+)
+
+const (
+	approvalLinksPathPrefix = "/approval-links/"
+
+	// defaultApprovalLinkTTL is how long approval links are valid if the
+	// configuration doesn't set it.
+	defaultApprovalLinkTTL = 7 * 24 * time.Hour
+)
+
+// approvalLinkPageTemplate renders the pages of approval links. They are
+// standalone because approvers using the links may not have a session.
+var approvalLinkPageTemplate = template.Must(template.New("page").Parse(`<!DOCTYPE html>
+<html lang="en">
+  <head>
+    <meta charset="utf-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1">
+    <meta name="robots" content="noindex">
+    <title>{{.Heading}} | Hermes</title>
+  </head>
+  <body style="font-family: sans-serif; max-width: 40rem; margin: 3rem auto; padding: 0 1rem;">
+    <h1>{{.Heading}}</h1>
+    <p>{{.Message}}</p>
+    {{- if .ConfirmLabel}}
+    <form method="POST">
+      <button type="submit">{{.ConfirmLabel}}</button>
+    </form>
+    {{- end}}
+    {{- if .DocumentURL}}
+    <p><a href="{{.DocumentURL}}">View the document in Hermes</a></p>
+    {{- end}}
+  </body>
+</html>
+`))
+
+// approvalLinkPage is the data of an approval link page.
+type approvalLinkPage struct {
+	Heading      string
+	Message      string
+	ConfirmLabel string
+	DocumentURL  string
+}
+
+// approvalLinksEnabled returns true if review request emails include approval
+// links.
+func approvalLinksEnabled(srv server.Server) bool {
+	return srv.Config.Email != nil && srv.Config.Email.ApprovalLinks != nil &&
+		srv.Config.Email.ApprovalLinks.Enabled
+}
+
+// approvalLinkURLs creates an approval link of the document for the approver,
+// and returns the URLs that approve the document and request changes of it.
+// The URLs are empty if approval links aren't enabled or the link can't be
+// created, in which case the review request email is sent without them.
+func approvalLinkURLs(
+	ctx context.Context, srv server.Server, docID, approverEmail string,
+) (approveURL, requestChangesURL string) {
+	if !approvalLinksEnabled(srv) || srv.DB == nil {
+		return "", ""
+	}
+	logger := srv.Logger.With("doc_id", docID, "approver", approverEmail)
+
+	doc := models.Document{GoogleFileID: docID}
+	if err := doc.Get(srv.DB.WithContext(ctx)); err != nil {
+		logger.Warn("error getting document for approval link", "error", err)
+		return "", ""
+	}
+	ttl := srv.Config.Email.ApprovalLinks.TTL
+	if ttl == 0 {
+		ttl = defaultApprovalLinkTTL
+	}
+	link := models.ApprovalLink{
+		DocumentID:    doc.ID,
+		ApproverEmail: approverEmail,
+		ExpiresAt:     time.Now().Add(ttl),
+	}
+	token, err := link.Create(srv.DB.WithContext(ctx))
+	if err != nil {
+		logger.Warn("error creating approval link", "error", err)
+		return "", ""
+	}
+
+	base := strings.TrimSuffix(srv.Config.BaseURL, "/") +
+		approvalLinksPathPrefix + token + "?action="
+	return base + models.ApprovalLinkActionApprove,
+		base + models.ApprovalLinkActionRequestChanges
+}
+
+// ApprovalLinksHandler serves the single-use approval links of review request
+// emails, which approve a document or request changes of it on behalf of the
+// approver the email was sent to. The token of the link authenticates the
+// request, so the handler is registered without authentication.
+//
+// GET  /approval-links/{token}?action={approve|request-changes}
+// - Show a page to confirm the action
+// POST /approval-links/{token}?action={approve|request-changes}
+// - Perform the action
+//
+// The action is only performed on POST because email security scanners follow
+// the links in emails. It is performed by the approvals API as the approver,
+// so it is authorized, recorded, and audited like approving in Hermes.
+func ApprovalLinksHandler(srv server.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
+
+		token := strings.TrimPrefix(r.URL.Path, approvalLinksPathPrefix)
+		if token == "" || strings.Contains(token, "/") ||
+			!approvalLinksEnabled(srv) {
+			writeApprovalLinkPage(w, http.StatusNotFound, approvalLinkPage{
+				Heading: "Link not found",
+				Message: "This link isn't valid.",
+			})
+			return
+		}
+		if r.Method != "GET" && r.Method != "POST" {
+			w.Header().Set("Allow", "GET, POST")
+			writeApprovalLinkPage(w, http.StatusMethodNotAllowed, approvalLinkPage{
+				Heading: "Method not allowed",
+			})
+			return
+		}
+
+		action := r.URL.Query().Get("action")
+		var heading, confirmLabel, method string
+		switch action {
+		case models.ApprovalLinkActionApprove:
+			heading, confirmLabel, method = "Approve document", "Approve", "POST"
+		case models.ApprovalLinkActionRequestChanges:
+			heading, confirmLabel, method =
+				"Request changes", "Request changes", "DELETE"
+		default:
+			writeApprovalLinkPage(w, http.StatusBadRequest, approvalLinkPage{
+				Heading: "Invalid link",
+				Message: "This link doesn't have a valid action.",
+			})
+			return
+		}
+
+		var link models.ApprovalLink
+		if err := link.GetByToken(srv.DB, token); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				writeApprovalLinkPage(w, http.StatusNotFound, approvalLinkPage{
+					Heading: "Link not found",
+					Message: "This link isn't valid.",
+				})
+				return
+			}
+			srv.Logger.Error("error getting approval link",
+				"error", err,
+				"method", r.Method,
+				"path", approvalLinksPathPrefix,
+			)
+			writeApprovalLinkPage(w, http.StatusInternalServerError,
+				approvalLinkPage{
+					Heading: "Something went wrong",
+					Message: "Please try again later.",
+				})
+			return
+		}
+
+		docID := link.Document.GoogleFileID
+		// Pages omit the link to the document if its URL can't be built.
+		docURL, _ := getDocumentURL(srv.Config.BaseURL, docID)
+		if !link.IsUsable(time.Now()) {
+			writeApprovalLinkPage(w, http.StatusGone, approvalLinkPage{
+				Heading:     "Link expired",
+				Message:     "This link was already used or has expired.",
+				DocumentURL: docURL,
+			})
+			return
+		}
+
+		if r.Method == "GET" {
+			writeApprovalLinkPage(w, http.StatusOK, approvalLinkPage{
+				Heading: heading,
+				Message: fmt.Sprintf("%s %q as %s?",
+					confirmLabel, link.Document.Title, link.ApproverEmail),
+				ConfirmLabel: confirmLabel,
+				DocumentURL:  docURL,
+			})
+			return
+		}
+
+		// Claim the link before performing the action so concurrent requests
+		// can't use it twice.
+		if err := link.Claim(srv.DB, action); err != nil {
+			if errors.Is(err, models.ErrApprovalLinkUnavailable) {
+				writeApprovalLinkPage(w, http.StatusGone, approvalLinkPage{
+					Heading:     "Link expired",
+					Message:     "This link was already used or has expired.",
+					DocumentURL: docURL,
+				})
+				return
+			}
+			srv.Logger.Error("error claiming approval link",
+				"error", err,
+				"doc_id", docID,
+				"method", r.Method,
+				"path", approvalLinksPathPrefix,
+			)
+			writeApprovalLinkPage(w, http.StatusInternalServerError,
+				approvalLinkPage{
+					Heading: "Something went wrong",
+					Message: "Please try again later.",
+				})
+			return
+		}
+
+		resp := performApprovalLinkAction(srv, r, method, docID, link.ApproverEmail)
+		if resp.status >= 300 {
+			// Release the link so the approver can retry, e.g., after the
+			// document is unlocked.
+			if err := link.Release(srv.DB); err != nil {
+				srv.Logger.Error("error releasing approval link",
+					"error", err,
+					"doc_id", docID,
+				)
+			}
+			message := "The action couldn't be performed."
+			var p ProblemDetails
+			if json.Unmarshal(resp.body.Bytes(), &p) == nil && p.Detail != "" {
+				message = p.Detail + "."
+			}
+			writeApprovalLinkPage(w, resp.status, approvalLinkPage{
+				Heading:     heading,
+				Message:     message,
+				DocumentURL: docURL,
+			})
+			return
+		}
+
+		srv.Logger.Info("performed approval link action",
+			"action", action,
+			"doc_id", docID,
+			"approver", link.ApproverEmail,
+		)
+		message := fmt.Sprintf("You approved %q.", link.Document.Title)
+		if action == models.ApprovalLinkActionRequestChanges {
+			message = fmt.Sprintf("You requested changes of %q.",
+				link.Document.Title)
+		}
+		writeApprovalLinkPage(w, http.StatusOK, approvalLinkPage{
+			Heading:     "Done",
+			Message:     message,
+			DocumentURL: docURL,
+		})
+	})
+}
+
+// performApprovalLinkAction performs an approval link action by serving a
+// request of the approvals API with the method as the approver, and returns
+// the response.
+func performApprovalLinkAction(
+	srv server.Server, r *http.Request, method, docID, approverEmail string,
+) *capturedResponse {
+	ctx := context.WithValue(r.Context(), pkgauth.UserEmailKey, approverEmail)
+	req := r.Clone(ctx)
+	req.Method = method
+	req.URL.Path = "/api/v2/approvals/" + docID
+	req.URL.RawQuery = ""
+	req.Body = http.NoBody
+	req.ContentLength = 0
+
+	var handler http.Handler = ApprovalsHandler(srv)
+	if srv.Config.Audit != nil && srv.Config.Audit.Enabled {
+		handler = AuditHandler(srv, handler)
+	}
+	handler = TenantHandler(srv, handler)
+
+	resp := &capturedResponse{header: http.Header{}}
+	handler.ServeHTTP(resp, req)
+	return resp
+}
+
+// capturedResponse is a response writer that keeps the response instead of
+// sending it to a client.
+type capturedResponse struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (c *capturedResponse) Header() http.Header { return c.header }
+
+func (c *capturedResponse) WriteHeader(status int) {
+	if c.status == 0 {
+		c.status = status
+	}
+}
+
+func (c *capturedResponse) Write(b []byte) (int, error) {
+	if c.status == 0 {
+		c.status = http.StatusOK
+	}
+	return c.body.Write(b)
+}
+
+// writeApprovalLinkPage writes an approval link page with the status code.
+func writeApprovalLinkPage(w http.ResponseWriter, status int, p approvalLinkPage) {
+	h := w.Header()
+	h.Set("Content-Type", "text/html; charset=utf-8")
+	h.Set("Cache-Control", "no-store")
+	h.Set("Referrer-Policy", "no-referrer")
+	h.Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	_ = approvalLinkPageTemplate.Execute(w, p)
+}
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApprovalLinksHandler(t *testing.T) {
+	newServer := func(enabled bool) server.Server {
+		return server.Server{
+			Config: &config.Config{
+				BaseURL: "https://hermes.example.com",
+				Email: &config.Email{
+					Enabled:       true,
+					FromAddress:   "hermes@example.com",
+					ApprovalLinks: &config.EmailApprovalLinks{Enabled: enabled},
+				},
+			},
+			Logger: hclog.NewNullLogger(),
+		}
+	}
+
+	tests := map[string]struct {
+		enabled  bool
+		method   string
+		target   string
+		wantCode int
+	}{
+		"disabled": {
+			method:   "GET",
+			target:   "/approval-links/token?action=approve",
+			wantCode: http.StatusNotFound,
+		},
+		"missing token": {
+			enabled:  true,
+			method:   "GET",
+			target:   "/approval-links/?action=approve",
+			wantCode: http.StatusNotFound,
+		},
+		"nested path": {
+			enabled:  true,
+			method:   "GET",
+			target:   "/approval-links/token/extra?action=approve",
+			wantCode: http.StatusNotFound,
+		},
+		"invalid action": {
+			enabled:  true,
+			method:   "GET",
+			target:   "/approval-links/token?action=reject",
+			wantCode: http.StatusBadRequest,
+		},
+		"method not allowed": {
+			enabled:  true,
+			method:   "DELETE",
+			target:   "/approval-links/token?action=approve",
+			wantCode: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for name, c := range tests {
+		t.Run(name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			ApprovalLinksHandler(newServer(c.enabled)).ServeHTTP(w,
+				httptest.NewRequest(c.method, c.target, nil))
+			assert.Equal(t, c.wantCode, w.Code)
+			assert.Equal(t, "text/html; charset=utf-8",
+				w.Header().Get("Content-Type"))
+			assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+		})
+	}
+
+	t.Run("links aren't created if disabled", func(t *testing.T) {
+		approveURL, requestChangesURL := approvalLinkURLs(
+			context.Background(), newServer(false), "doc1", "approver@example.com")
+		assert.Empty(t, approveURL)
+		assert.Empty(t, requestChangesURL)
+	})
+}
+
+func TestWriteApprovalLinkPage(t *testing.T) {
+	w := httptest.NewRecorder()
+	writeApprovalLinkPage(w, http.StatusOK, approvalLinkPage{
+		Heading:      "Approve document",
+		Message:      `Approve "<script>" as approver@example.com?`,
+		ConfirmLabel: "Approve",
+		DocumentURL:  "https://hermes.example.com/document/doc1",
+	})
+
+	body := w.Body.String()
+	assert.Contains(t, body, `<form method="POST">`)
+	assert.Contains(t, body, "&lt;script&gt;")
+	assert.NotContains(t, body, "<script>")
+	assert.Contains(t, body, `href="https://hermes.example.com/document/doc1"`)
+}
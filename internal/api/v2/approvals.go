@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+	"github.com/hashicorp-forge/hermes/pkg/authz"
 
 	"github.com/hashicorp-forge/hermes/internal/email"
 	"github.com/hashicorp-forge/hermes/internal/helpers"
@@ -17,6 +18,7 @@ import (
 
 func ApprovalsHandler(srv server.Server) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
 		// Validate request.
 		docID, err := parseResourceIDFromURL(r.URL.Path, "approvals")
 		if err != nil {
@@ -25,7 +27,8 @@ func ApprovalsHandler(srv server.Server) http.Handler {
 				"method", r.Method,
 				"path", r.URL.Path,
 			)
-			http.Error(w, "Document ID not found", http.StatusNotFound)
+			writeProblem(w, r, http.StatusNotFound, ErrCodeDocumentNotFound,
+				"Document ID not found")
 			return
 		}
 
@@ -40,8 +43,8 @@ func ApprovalsHandler(srv server.Server) http.Handler {
 				"method", r.Method,
 				"doc_id", docID,
 			)
-			http.Error(w, "Error accessing document",
-				http.StatusInternalServerError)
+			writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Error accessing document")
 			return
 		}
 
@@ -87,8 +90,8 @@ func ApprovalsHandler(srv server.Server) http.Handler {
 				"path", r.URL.Path,
 				"doc_id", docID,
 			)
-			http.Error(w, "Error accessing document",
-				http.StatusInternalServerError)
+			writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Error accessing document")
 			return
 		}
 
@@ -98,19 +101,19 @@ func ApprovalsHandler(srv server.Server) http.Handler {
 		case "DELETE":
 			// Authorize request.
 			if doc.Status != "In-Review" {
-				http.Error(w,
-					"Can only request changes of documents in the \"In-Review\" status",
-					http.StatusBadRequest)
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					"Can only request changes of documents in the \"In-Review\" status")
 				return
 			}
-			if !contains(doc.Approvers, userEmail) {
-				http.Error(w, "Not authorized as a document approver",
-					http.StatusUnauthorized)
+			if !authorizeRequest(w, r, srv.Config, srv.DB, srv.Logger,
+				authz.ActionDocumentReview, documentAuthzResource(*doc),
+				"Not authorized as a document approver",
+			) {
 				return
 			}
 			if contains(doc.ChangesRequestedBy, userEmail) {
-				http.Error(w, "Document already has changes requested by user",
-					http.StatusBadRequest)
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					"Document already has changes requested by user")
 				return
 			}
 
@@ -126,12 +129,14 @@ func ApprovalsHandler(srv server.Server) http.Handler {
 						"method", r.Method,
 						"doc_id", docID,
 					)
-					http.Error(w, "Error getting document status", http.StatusNotFound)
+					writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound,
+						"Error getting document status")
 					return
 				}
 				// Don't continue if document is locked.
 				if locked {
-					http.Error(w, "Document is locked", http.StatusLocked)
+					writeProblem(w, r, http.StatusLocked, ErrCodeDocumentLocked,
+						"Document is locked")
 					return
 				}
 			}
@@ -159,8 +164,8 @@ func ApprovalsHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"path", r.URL.Path,
 					"doc_id", docID)
-				http.Error(w, "Error requesting changes of document",
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error requesting changes of document")
 				return
 			}
 
@@ -173,8 +178,8 @@ func ApprovalsHandler(srv server.Server) http.Handler {
 					"path", r.URL.Path,
 					"doc_id", docID,
 					"rev_id", latestRev.RevisionID)
-				http.Error(w, "Error updating document status",
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error updating document status")
 				return
 			}
 
@@ -197,8 +202,8 @@ func ApprovalsHandler(srv server.Server) http.Handler {
 					"path", r.URL.Path,
 					"doc_id", docID,
 					"rev_id", latestRev.RevisionID)
-				http.Error(w, "Error updating document status",
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error updating document status")
 				return
 			}
 
@@ -210,31 +215,37 @@ func ApprovalsHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"path", r.URL.Path,
 				)
-				http.Error(w, "Error updating document status",
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error updating document status")
 				return
 			}
 
-			// Replace the doc header (Google Docs specific).
-			// Extract Google provider for Google-specific operations
-			googleUpdater := getGoogleDocsUpdater(srv.WorkspaceProvider)
-			if googleUpdater == nil {
-				srv.Logger.Warn("ReplaceHeader skipped - not using Google Workspace",
-					"doc_id", docID)
-			} else {
-				if err := doc.ReplaceHeader(
-					srv.Config.BaseURL, false, googleUpdater,
-				); err != nil {
-					srv.Logger.Error("error replacing doc header",
-						"error", err,
-						"doc_id", docID,
-						"method", r.Method,
-						"path", r.URL.Path,
-					)
-					http.Error(w, "Error updating document status",
-						http.StatusInternalServerError)
-					return
-				}
+			// Withdraw the user's approvals toward group reviews.
+			if err := models.DeleteDocumentGroupReviewApprovalsByApprover(
+				srv.DB, docID, userEmail,
+			); err != nil {
+				srv.Logger.Error("error deleting group review approvals",
+					"error", err,
+					"doc_id", docID,
+					"method", r.Method,
+					"path", r.URL.Path,
+				)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error updating document status")
+				return
+			}
+
+			// Replace the doc header.
+			if err := replaceDocumentHeader(r.Context(), srv, doc, false); err != nil {
+				srv.Logger.Error("error replacing doc header",
+					"error", err,
+					"doc_id", docID,
+					"method", r.Method,
+					"path", r.URL.Path,
+				)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error updating document status")
+				return
 			}
 
 			// Write response.
@@ -246,94 +257,26 @@ func ApprovalsHandler(srv server.Server) http.Handler {
 				"method", r.Method,
 				"path", r.URL.Path,
 			)
+			recordActivity(srv, r, userEmail, model, models.ReviewUserActivityAction)
 
 			// Request post-processing.
-			go func() {
-				// Convert document to search index object.
-				docObjMap, err := doc.ToAlgoliaObject(true)
+			// Save new modified doc object in search index.
+			if srv.SearchProvider != nil {
+				docObj, err := toSearchDocument(doc)
 				if err != nil {
-					srv.Logger.Error("error converting document to search object",
+					srv.Logger.Error("error converting document to search document",
 						"error", err,
 						"method", r.Method,
 						"path", r.URL.Path,
 						"doc_id", docID,
 					)
-					http.Error(w, "Error updating document status",
-						http.StatusInternalServerError)
 					return
 				}
-
-				// Save new modified doc object in search index.
-				if srv.SearchProvider != nil {
-					// Convert map to search.Document via JSON round-trip
-					docObj, err := mapToSearchDocument(docObjMap)
-					if err != nil {
-						srv.Logger.Error("error converting document to search document",
-							"error", err,
-							"method", r.Method,
-							"path", r.URL.Path,
-							"doc_id", docID,
-						)
-						return
-					}
-
-					ctx := r.Context()
-					err = srv.SearchProvider.DocumentIndex().Index(ctx, docObj)
-					if err != nil {
-						srv.Logger.Error("error saving approved document in search index",
-							"error", err,
-							"method", r.Method,
-							"path", r.URL.Path,
-							"doc_id", docID)
-						http.Error(w, "Error updating document status",
-							http.StatusInternalServerError)
-						return
-					}
-
-					// Compare search index and database documents to find data inconsistencies.
-					// Get document from database.
-					dbDoc := models.Document{
-						GoogleFileID: docID,
-					}
-					if err := dbDoc.Get(srv.DB); err != nil {
-						srv.Logger.Error(
-							"error getting document from database for data comparison",
-							"error", err,
-							"path", r.URL.Path,
-							"method", r.Method,
-							"doc_id", docID,
-						)
-						return
-					}
-					// Get all reviews for the document.
-					var reviews models.DocumentReviews
-					if err := reviews.Find(srv.DB, models.DocumentReview{
-						Document: models.Document{
-							GoogleFileID: docID,
-						},
-					}); err != nil {
-						srv.Logger.Error(
-							"error getting all reviews for document for data comparison",
-							"error", err,
-							"method", r.Method,
-							"path", r.URL.Path,
-							"doc_id", docID,
-						)
-						return
-					}
-					if err := CompareAlgoliaAndDatabaseDocument(
-						docObjMap, dbDoc, reviews, srv.Config.DocumentTypes.DocumentType,
-					); err != nil {
-						srv.Logger.Warn(
-							"inconsistencies detected between search index and database docs",
-							"error", err,
-							"method", r.Method,
-							"path", r.URL.Path,
-							"doc_id", docID,
-						)
-					}
-				}
-			}()
+				enqueueJob(srv, r, indexDocumentJobType, indexDocumentJob{
+					Document: docObj,
+					Compare:  true,
+				})
+			}
 
 		case "OPTIONS":
 			// Document is not in review or approved status.
@@ -348,9 +291,10 @@ func ApprovalsHandler(srv server.Server) http.Handler {
 				return
 			}
 
-			// User is not an approver or in an approver group.
-			inApproverGroup, err := isUserInGroups(
-				r.Context(), userEmail, doc.ApproverGroups, srv.WorkspaceProvider)
+			// User is not an approver or in an approver group, either directly or
+			// as a delegate of a group member.
+			groupApprovals, err := resolveGroupApprovals(
+				r.Context(), srv, userEmail, doc.ApproverGroups)
 			if err != nil {
 				srv.Logger.Error("error calculating if user is in an approver group",
 					"error", err,
@@ -358,11 +302,13 @@ func ApprovalsHandler(srv server.Server) http.Handler {
 					"path", r.URL.Path,
 					"doc_id", docID,
 				)
-				http.Error(w, "Error accessing document",
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error accessing document")
 				return
 			}
-			if !contains(doc.Approvers, userEmail) && !inApproverGroup {
+			if err := authorize(r, srv.Config, srv.DB, authz.ActionDocumentReview,
+				approvalAuthzResource(*doc, userEmail, groupApprovals),
+			); err != nil {
 				w.Header().Set("Allowed", "")
 				return
 			}
@@ -374,19 +320,17 @@ func ApprovalsHandler(srv server.Server) http.Handler {
 		case "POST":
 			// Authorize request.
 			if doc.Status != "In-Review" && doc.Status != "Approved" {
-				http.Error(w,
-					`Document status must be "In-Review" or "Approved" to approve`,
-					http.StatusBadRequest)
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					`Document status must be "In-Review" or "Approved" to approve`)
 				return
 			}
 			if contains(doc.ApprovedBy, userEmail) {
-				http.Error(w,
-					"Document already approved by user",
-					http.StatusBadRequest)
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					"Document already approved by user")
 				return
 			}
-			inApproverGroup, err := isUserInGroups(
-				r.Context(), userEmail, doc.ApproverGroups, srv.WorkspaceProvider)
+			groupApprovals, err := resolveGroupApprovals(
+				r.Context(), srv, userEmail, doc.ApproverGroups)
 			if err != nil {
 				srv.Logger.Error("error calculating if user is in an approver group",
 					"error", err,
@@ -394,14 +338,15 @@ func ApprovalsHandler(srv server.Server) http.Handler {
 					"path", r.URL.Path,
 					"doc_id", docID,
 				)
-				http.Error(w, "Error accessing document",
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error accessing document")
 				return
 			}
-			if !contains(doc.Approvers, userEmail) && !inApproverGroup {
-				http.Error(w,
-					"Not authorized as a document approver",
-					http.StatusUnauthorized)
+			if !authorizeRequest(w, r, srv.Config, srv.DB, srv.Logger,
+				authz.ActionDocumentReview,
+				approvalAuthzResource(*doc, userEmail, groupApprovals),
+				"Not authorized as a document approver",
+			) {
 				return
 			}
 
@@ -417,12 +362,14 @@ func ApprovalsHandler(srv server.Server) http.Handler {
 						"method", r.Method,
 						"doc_id", docID,
 					)
-					http.Error(w, "Error getting document status", http.StatusNotFound)
+					writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound,
+						"Error getting document status")
 					return
 				}
 				// Don't continue if document is locked.
 				if locked {
-					http.Error(w, "Document is locked", http.StatusLocked)
+					writeProblem(w, r, http.StatusLocked, ErrCodeDocumentLocked,
+						"Document is locked")
 					return
 				}
 			}
@@ -443,8 +390,7 @@ func ApprovalsHandler(srv server.Server) http.Handler {
 						"path", r.URL.Path,
 						"doc_id", docID,
 					)
-					http.Error(w, "Error approving document",
-						http.StatusInternalServerError)
+					writeUpsertProblem(w, r, err, "Error approving document")
 					return
 				}
 			}
@@ -472,8 +418,8 @@ func ApprovalsHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"path", r.URL.Path,
 					"doc_id", docID)
-				http.Error(w, "Error creating review",
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error creating review")
 				return
 			}
 
@@ -486,8 +432,8 @@ func ApprovalsHandler(srv server.Server) http.Handler {
 					"path", r.URL.Path,
 					"doc_id", docID,
 					"rev_id", latestRev.RevisionID)
-				http.Error(w, "Error approving document",
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error approving document")
 				return
 			}
 
@@ -510,8 +456,8 @@ func ApprovalsHandler(srv server.Server) http.Handler {
 					"path", r.URL.Path,
 					"doc_id", docID,
 					"rev_id", latestRev.RevisionID)
-				http.Error(w, "Error updating document status",
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error updating document status")
 				return
 			}
 
@@ -523,30 +469,37 @@ func ApprovalsHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"path", r.URL.Path,
 				)
-				http.Error(w, "Error approving document",
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error approving document")
 				return
 			}
 
-			// Replace the doc header (Google Docs specific).
-			// Extract Google provider for Google-specific operations
-			googleUpdater := getGoogleDocsUpdater(srv.WorkspaceProvider)
-			if googleUpdater == nil {
-				srv.Logger.Warn("ReplaceHeader skipped - not using Google Workspace",
-					"doc_id", docID)
-			} else {
-				err = doc.ReplaceHeader(srv.Config.BaseURL, false, googleUpdater)
-				if err != nil {
-					srv.Logger.Error("error replacing doc header",
-						"error", err,
-						"doc_id", docID,
-						"method", r.Method,
-						"path", r.URL.Path,
-					)
-					http.Error(w, "Error approving document",
-						http.StatusInternalServerError)
-					return
-				}
+			// Record the approval toward group review quorums.
+			if err := recordGroupApprovals(
+				srv.DB, docID, userEmail, groupApprovals,
+			); err != nil {
+				srv.Logger.Error("error recording group review approvals",
+					"error", err,
+					"doc_id", docID,
+					"method", r.Method,
+					"path", r.URL.Path,
+				)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error approving document")
+				return
+			}
+
+			// Replace the doc header.
+			if err := replaceDocumentHeader(r.Context(), srv, doc, false); err != nil {
+				srv.Logger.Error("error replacing doc header",
+					"error", err,
+					"doc_id", docID,
+					"method", r.Method,
+					"path", r.URL.Path,
+				)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error approving document")
+				return
 			}
 
 			// Write response.
@@ -558,159 +511,65 @@ func ApprovalsHandler(srv server.Server) http.Handler {
 				"method", r.Method,
 				"path", r.URL.Path,
 			)
+			recordActivity(srv, r, userEmail, model, models.ReviewUserActivityAction)
 
 			// Request post-processing.
-			go func() {
-				// Send email to document owner, if enabled.
-				if srv.Config.Email != nil && srv.Config.Email.Enabled &&
-					len(doc.Owners) > 0 {
-					// Get name of document approver.
-					approver := email.User{
-						EmailAddress: userEmail,
-					}
-					ppl, err := srv.WorkspaceProvider.SearchPeople(
-						r.Context(), userEmail)
-					if err != nil {
-						srv.Logger.Warn("error searching directory for approver",
-							"error", err,
-							"method", r.Method,
-							"path", r.URL.Path,
-							"doc_id", docID,
-							"person", doc.Owners[0],
-						)
-					}
-					if len(ppl) == 1 {
-						approver.Name = ppl[0].DisplayName
-					}
-
-					// Get document URL.
-					docURL, err := getDocumentURL(srv.Config.BaseURL, docID)
-					if err != nil {
-						srv.Logger.Error("error getting document URL",
-							"error", err,
-							"doc_id", docID,
-							"method", r.Method,
-							"path", r.URL.Path,
-						)
-						return
-					}
-
-					// Send email.
-					if err := email.SendDocumentApprovedEmail(
-						email.DocumentApprovedEmailData{
-							BaseURL:          srv.Config.BaseURL,
-							DocumentOwner:    doc.Owners[0],
-							DocumentApprover: approver,
-							DocumentNonApproverCount: len(doc.Approvers) -
-								len(doc.ApprovedBy),
-							DocumentShortName: doc.DocNumber,
-							DocumentTitle:     doc.Title,
-							DocumentType:      doc.DocType,
-							DocumentStatus:    doc.Status,
-							DocumentURL:       docURL,
-							Product:           doc.Product,
-						},
-						[]string{doc.Owners[0]},
-						srv.Config.Email.FromAddress,
-						getCompatProvider(srv.WorkspaceProvider),
-					); err != nil {
-						srv.Logger.Error("error sending document approved email",
-							"error", err,
-							"method", r.Method,
-							"path", r.URL.Path,
-							"doc_id", docID,
-						)
-					}
-				}
-
-				// Convert document to search index object.
-				docObjMap, err := doc.ToAlgoliaObject(true)
+			// Send email to document owner, if enabled.
+			if srv.Config.Email != nil && srv.Config.Email.Enabled &&
+				len(doc.Owners) > 0 {
+				// Get document URL.
+				docURL, err := getDocumentURL(srv.Config.BaseURL, docID)
 				if err != nil {
-					srv.Logger.Error("error converting document to search object",
+					srv.Logger.Error("error getting document URL",
 						"error", err,
+						"doc_id", docID,
 						"method", r.Method,
 						"path", r.URL.Path,
-						"doc_id", docID,
 					)
-					http.Error(w, "Error updating document status",
-						http.StatusInternalServerError)
 					return
 				}
 
-				// Save new modified doc object in search index.
-				if srv.SearchProvider != nil {
-					// Convert map to search.Document via JSON round-trip
-					docObj, err := mapToSearchDocument(docObjMap)
-					if err != nil {
-						srv.Logger.Error("error converting document to search document",
-							"error", err,
-							"method", r.Method,
-							"path", r.URL.Path,
-							"doc_id", docID,
-						)
-						return
-					}
-
-					ctx := r.Context()
-					err = srv.SearchProvider.DocumentIndex().Index(ctx, docObj)
-					if err != nil {
-						srv.Logger.Error("error saving approved document in search index",
-							"error", err,
-							"method", r.Method,
-							"path", r.URL.Path,
-							"doc_id", docID)
-						http.Error(w, "Error updating document status",
-							http.StatusInternalServerError)
-						return
-					}
-
-					// Compare search index and database documents to find data inconsistencies.
-					// Get document from database.
-					dbDoc := models.Document{
-						GoogleFileID: docID,
-					}
-					if err := dbDoc.Get(srv.DB); err != nil {
-						srv.Logger.Error(
-							"error getting document from database for data comparison",
-							"error", err,
-							"path", r.URL.Path,
-							"method", r.Method,
-							"doc_id", docID,
-						)
-						return
-					}
-					// Get all reviews for the document.
-					var reviews models.DocumentReviews
-					if err := reviews.Find(srv.DB, models.DocumentReview{
-						Document: models.Document{
-							GoogleFileID: docID,
+				enqueueJob(srv, r, documentApprovedEmailJobType, documentApprovedEmailJob{
+					Data: email.DocumentApprovedEmailData{
+						BaseURL:       srv.Config.BaseURL,
+						DocumentOwner: doc.Owners[0],
+						DocumentApprover: email.User{
+							EmailAddress: userEmail,
 						},
-					}); err != nil {
-						srv.Logger.Error(
-							"error getting all reviews for document for data comparison",
-							"error", err,
-							"method", r.Method,
-							"path", r.URL.Path,
-							"doc_id", docID,
-						)
-						return
-					}
-					if err := CompareAlgoliaAndDatabaseDocument(
-						docObjMap, dbDoc, reviews, srv.Config.DocumentTypes.DocumentType,
-					); err != nil {
-						srv.Logger.Warn(
-							"inconsistencies detected between search index and database docs",
-							"error", err,
-							"method", r.Method,
-							"path", r.URL.Path,
-							"doc_id", docID,
-						)
-					}
+						DocumentNonApproverCount: len(doc.Approvers) -
+							len(doc.ApprovedBy),
+						DocumentShortName: doc.DocNumber,
+						DocumentTitle:     doc.Title,
+						DocumentType:      doc.DocType,
+						DocumentStatus:    doc.Status,
+						DocumentURL:       docURL,
+						Product:           doc.Product,
+					},
+					To: doc.Owners[0],
+				})
+			}
+
+			// Save new modified doc object in search index.
+			if srv.SearchProvider != nil {
+				docObj, err := toSearchDocument(doc)
+				if err != nil {
+					srv.Logger.Error("error converting document to search document",
+						"error", err,
+						"method", r.Method,
+						"path", r.URL.Path,
+						"doc_id", docID,
+					)
+					return
 				}
-			}()
+				enqueueJob(srv, r, indexDocumentJobType, indexDocumentJob{
+					Document: docObj,
+					Compare:  true,
+				})
+			}
 
 		default:
-			w.WriteHeader(http.StatusMethodNotAllowed)
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
 			return
 		}
 	})
@@ -752,3 +611,16 @@ func updateDocumentReviewsInDatabase(doc document.Document, db *gorm.DB) error {
 
 	return nil
 }
+
+// approvalAuthzResource returns the authorization resource for approving a
+// document. A user who can approve for an approver group, as a member or as a
+// delegate of a member, is an approver.
+func approvalAuthzResource(
+	doc document.Document, userEmail string, groupApprovals []groupApproval,
+) authz.Resource {
+	res := documentAuthzResource(doc)
+	if len(groupApprovals) > 0 {
+		res.Approvers = append(append([]string{}, res.Approvers...), userEmail)
+	}
+	return res
+}
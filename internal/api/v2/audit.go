@@ -0,0 +1,356 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp-forge/hermes/internal/server"
+	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp-forge/hermes/pkg/requestid"
+)
+
+// Audit target types.
+const (
+	auditTargetDocument = "document"
+	auditTargetProject  = "project"
+	auditTargetUser     = "user"
+)
+
+// auditResourceTargets maps the first path segment of endpoints that operate
+// on a resource identified by the second path segment to the target type.
+var auditResourceTargets = map[string]string{
+	"approvals":          auditTargetDocument,
+	"documents":          auditTargetDocument,
+	"drafts":             auditTargetDocument,
+	"group-reviews":      auditTargetDocument,
+	"projects":           auditTargetProject,
+	"reviews":            auditTargetDocument,
+	"workspace-projects": "workspace-project",
+}
+
+// unauditedPathPrefixes are endpoints that use POST for queries rather than
+// mutations or that record their own audit events.
+var unauditedPathPrefixes = []string{
+	// Impersonation sessions are recorded by the handler itself.
+	impersonationPath,
+	"/api/v2/people",
+	"/api/v2/search/",
+	"/api/v2/web/analytics",
+}
+
+// AuditHandler wraps a v2 API handler so mutating requests (POST, PUT, PATCH,
+// and DELETE) are recorded in the audit log with the actor (and the admin
+// impersonating them, if any), action, target, response status, request ID,
+// and snapshots of the target's key fields before and after the request. It
+// must run after user authentication so the actor is known; requests
+// authenticated by a service token are attributed to the token. Other requests
+// are passed through unchanged.
+func AuditHandler(srv server.Server, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
+		if !isAuditedRequest(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		requestID := auditRequestID(r)
+		w.Header().Set(requestid.Header, requestID)
+
+		actor, _ := pkgauth.GetUserEmail(r.Context())
+		if actor == "" {
+			actor = auditServiceTokenActor(srv, r)
+		}
+		impersonator, _ := pkgauth.GetImpersonator(r.Context())
+		target := parseAuditTarget(r.URL.Path, r.Method)
+		if target.Type == auditTargetUser && target.ID == "" {
+			target.ID = actor
+		}
+
+		before := auditSnapshot(srv, target)
+
+		rw := &recordingResponseWriter{ResponseWriter: w}
+		next.ServeHTTP(rw, r)
+
+		// Resources created by the request are identified by the "id" field of
+		// the response.
+		if target.ID == "" && rw.Status() < 300 {
+			var resp struct {
+				ID json.RawMessage `json:"id"`
+			}
+			if err := json.Unmarshal(rw.body.Bytes(), &resp); err == nil &&
+				len(resp.ID) > 0 && string(resp.ID) != "null" {
+				target.ID = strings.Trim(string(resp.ID), `"`)
+			}
+		}
+
+		e := models.AuditEvent{
+			Actor:        actor,
+			Impersonator: impersonator,
+			RequestID:    requestID,
+			Action:       target.Action,
+			Method:       r.Method,
+			Path:         r.URL.Path,
+			StatusCode:   rw.Status(),
+			TargetType:   target.Type,
+			TargetID:     target.ID,
+			Before:       before,
+			After:        auditSnapshot(srv, target),
+		}
+		if e.Actor == "" {
+			e.Actor = "unknown"
+		}
+		if err := e.Create(srv.DB); err != nil {
+			srv.Logger.Error("error recording audit event",
+				"error", err,
+				"method", r.Method,
+				"path", r.URL.Path,
+			)
+		}
+		publishEdgeEvents(r.Context(), srv, e)
+	})
+}
+
+// auditServiceTokenActor returns the actor for a request authenticated by a
+// service token (e.g., "service-token:<id>"), or an empty string if the
+// request has no valid service token.
+func auditServiceTokenActor(srv server.Server, r *http.Request) string {
+	token, tErr := bearerToken(r)
+	if tErr != nil {
+		return ""
+	}
+	var t models.IndexerToken
+	if err := t.GetByToken(srv.DB, token); err != nil {
+		return ""
+	}
+	return "service-token:" + t.ID.String()
+}
+
+// isAuditedRequest returns true if the request may change server state.
+func isAuditedRequest(r *http.Request) bool {
+	switch r.Method {
+	case "POST", "PUT", "PATCH", "DELETE":
+	default:
+		return false
+	}
+
+	for _, p := range unauditedPathPrefixes {
+		if strings.HasPrefix(r.URL.Path, p) {
+			return false
+		}
+	}
+	// Similar document lookups (POST /api/v2/documents/:id/similar).
+	return !strings.HasSuffix(r.URL.Path, "/similar")
+}
+
+// auditRequestID returns the request ID of r. Requests served without the
+// request ID middleware use the client-supplied request ID if it is usable, or
+// a new one otherwise.
+func auditRequestID(r *http.Request) string {
+	if id := requestid.FromContext(r.Context()); id != "" {
+		return id
+	}
+	return requestid.Parse(r.Header.Get(requestid.Header))
+}
+
+// auditTarget is the resource targeted by a request and the action performed
+// on it.
+type auditTarget struct {
+	Type   string
+	ID     string
+	Action string
+}
+
+// parseAuditTarget determines the target and action of a v2 API request from
+// its path. The action is the path with the target ID removed, joined with
+// dots, and suffixed with the operation (e.g., PUT
+// /api/v2/documents/:id/content is "documents.content.update").
+func parseAuditTarget(path, method string) auditTarget {
+	var segs []string
+	for _, s := range strings.Split(strings.TrimPrefix(path, "/api/v2/"), "/") {
+		if s != "" {
+			segs = append(segs, s)
+		}
+	}
+	if len(segs) == 0 {
+		return auditTarget{Type: "unknown", Action: auditOperation(method)}
+	}
+
+	t := auditTarget{Type: segs[0]}
+	idIdx := -1
+	if typ, ok := auditResourceTargets[segs[0]]; ok {
+		// The second segment is the target ID.
+		t.Type = typ
+		if len(segs) > 1 && segs[1] != "import" {
+			idIdx = 1
+		}
+	} else {
+		if segs[0] == "me" {
+			t.Type = auditTargetUser
+		}
+		// Other resources are identified by a numeric or UUID ID following
+		// the collection name (e.g., /api/v2/me/review-delegations/:id).
+		for i := 1; i < len(segs); i++ {
+			if _, err := uuid.Parse(segs[i]); isNumeric(segs[i]) || err == nil {
+				t.Type = strings.TrimSuffix(segs[i-1], "s")
+				idIdx = i
+				break
+			}
+		}
+	}
+
+	var actionSegs []string
+	for i, s := range segs {
+		if i == idIdx {
+			t.ID = s
+			continue
+		}
+		actionSegs = append(actionSegs, s)
+	}
+	t.Action = strings.Join(
+		append(actionSegs, auditOperation(method)), ".")
+
+	return t
+}
+
+// auditOperation returns the audit action suffix for an HTTP method.
+func auditOperation(method string) string {
+	switch method {
+	case "POST":
+		return "create"
+	case "PUT", "PATCH":
+		return "update"
+	case "DELETE":
+		return "delete"
+	default:
+		return strings.ToLower(method)
+	}
+}
+
+// isNumeric returns true if s is a non-empty string of digits.
+func isNumeric(s string) bool {
+	_, err := strconv.ParseUint(s, 10, 64)
+	return err == nil
+}
+
+// auditSnapshot returns the key fields of the target as JSON, or nil if the
+// target does not exist or is not a type that is snapshotted.
+func auditSnapshot(srv server.Server, t auditTarget) models.JSON {
+	if t.ID == "" {
+		return nil
+	}
+
+	var snapshot any
+	switch t.Type {
+	case auditTargetDocument:
+		doc := models.Document{
+			GoogleFileID: t.ID,
+		}
+		if err := doc.Get(srv.DB); err != nil {
+			return nil
+		}
+		snapshot = documentAuditSnapshot(doc)
+
+	case auditTargetProject:
+		id, err := strconv.ParseUint(t.ID, 10, 64)
+		if err != nil {
+			return nil
+		}
+		var proj models.Project
+		if err := proj.Get(srv.DB, uint(id)); err != nil {
+			return nil
+		}
+		snapshot = projectAuditSnapshot(proj)
+
+	default:
+		return nil
+	}
+
+	b, err := json.Marshal(snapshot)
+	if err != nil {
+		srv.Logger.Error("error marshaling audit snapshot",
+			"error", err,
+			"target_type", t.Type,
+			"target_id", t.ID,
+		)
+		return nil
+	}
+	return models.JSON(b)
+}
+
+// documentAuditSnapshot returns the audited fields of a document.
+func documentAuditSnapshot(doc models.Document) map[string]any {
+	approvers := []string{}
+	for _, a := range doc.Approvers {
+		if a != nil {
+			approvers = append(approvers, a.EmailAddress)
+		}
+	}
+	approverGroups := []string{}
+	for _, g := range doc.ApproverGroups {
+		if g != nil {
+			approverGroups = append(approverGroups, g.EmailAddress)
+		}
+	}
+
+	var owner string
+	if doc.Owner != nil {
+		owner = doc.Owner.EmailAddress
+	}
+	var summary string
+	if doc.Summary != nil {
+		summary = *doc.Summary
+	}
+
+	return map[string]any{
+		"title":            doc.Title,
+		"status":           documentStatusString(doc.Status),
+		"owner":            owner,
+		"summary":          summary,
+		"product":          doc.Product.Name,
+		"docType":          doc.DocumentType.Name,
+		"documentNumber":   doc.DocumentNumber,
+		"approvers":        approvers,
+		"approverGroups":   approverGroups,
+		"locked":           doc.Locked,
+		"shareableAsDraft": doc.ShareableAsDraft,
+	}
+}
+
+// projectAuditSnapshot returns the audited fields of a project.
+func projectAuditSnapshot(proj models.Project) map[string]any {
+	var description, jiraIssueID string
+	if proj.Description != nil {
+		description = *proj.Description
+	}
+	if proj.JiraIssueID != nil {
+		jiraIssueID = *proj.JiraIssueID
+	}
+
+	return map[string]any{
+		"title":       proj.Title,
+		"status":      proj.Status.String(),
+		"description": description,
+		"jiraIssueId": jiraIssueID,
+		"creator":     proj.Creator.EmailAddress,
+	}
+}
+
+// documentStatusString returns the API representation of a document status.
+func documentStatusString(s models.DocumentStatus) string {
+	switch s {
+	case models.WIPDocumentStatus:
+		return "WIP"
+	case models.InReviewDocumentStatus:
+		return "In-Review"
+	case models.ApprovedDocumentStatus:
+		return "Approved"
+	case models.ObsoleteDocumentStatus:
+		return "Obsolete"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,171 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/server"
+	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+	"github.com/hashicorp-forge/hermes/pkg/authz"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+)
+
+const (
+	// defaultAuditEventsLimit is the default number of audit events returned.
+	defaultAuditEventsLimit = 100
+
+	// maxAuditEventsLimit is the maximum number of audit events returned.
+	maxAuditEventsLimit = 1000
+)
+
+// AuditEventsGetResponse is the response for GET /api/v2/audit-events.
+type AuditEventsGetResponse struct {
+	Events models.AuditEvents `json:"events"`
+
+	// NextCursor is passed as the "cursor" query parameter to get the next page
+	// of events, or empty if there are no more events.
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// AuditEventsHandler queries the audit log (GET /api/v2/audit-events). Results
+// are newest first and can be filtered with the actor, action, targetType,
+// targetId, requestId, since, and until (RFC 3339) query parameters. Only
+// site admins, and users and members of groups configured as audit readers,
+// are allowed.
+func AuditEventsHandler(srv server.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
+		if r.Method != "GET" {
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
+			return
+		}
+
+		userEmail, ok := pkgauth.GetUserEmail(r.Context())
+		if !ok || userEmail == "" {
+			writeProblem(w, r, http.StatusUnauthorized, ErrCodeUnauthorized,
+				"No authorization information for request")
+			return
+		}
+
+		// Authorize request (site admins and configured readers are allowed).
+		var readers []string
+		if srv.Config.Audit != nil {
+			readers = srv.Config.Audit.Readers
+		}
+		allowed := contains(readers, userEmail)
+		if !allowed {
+			err := authorize(r, srv.Config, srv.DB, authz.ActionAdmin, authz.Resource{})
+			if err != nil && !errors.Is(err, authz.ErrForbidden) {
+				respondError(w, r, srv.Logger, http.StatusInternalServerError,
+					"Error authorizing request",
+					"error calculating if user is a site admin", err)
+				return
+			}
+			allowed = err == nil
+		}
+		if !allowed && len(readers) > 0 {
+			inGroup, err := isUserInGroups(
+				r.Context(), userEmail, readers, srv.WorkspaceProvider)
+			if err != nil {
+				respondError(w, r, srv.Logger, http.StatusInternalServerError,
+					"Error authorizing request",
+					"error calculating if user is an audit reader", err)
+				return
+			}
+			allowed = inGroup
+		}
+		if !allowed {
+			writeProblem(w, r, http.StatusForbidden, ErrCodeForbidden,
+				"Not authorized to read the audit log")
+			return
+		}
+
+		filter, err := parseAuditEventFilter(r.URL.Query())
+		if err != nil {
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				fmt.Sprintf("Bad request: %v", err))
+			return
+		}
+
+		// Get one extra event to determine if there is another page.
+		limit := filter.Limit
+		filter.Limit++
+		var events models.AuditEvents
+		if err := events.Find(srv.DB.WithContext(r.Context()), filter); err != nil {
+			respondError(w, r, srv.Logger, http.StatusInternalServerError,
+				"Error querying audit events", "error finding audit events", err)
+			return
+		}
+
+		resp := AuditEventsGetResponse{
+			Events: models.AuditEvents{},
+		}
+		if len(events) > limit {
+			events = events[:limit]
+			resp.NextCursor = strconv.FormatUint(events[limit-1].ID, 10)
+		}
+		resp.Events = append(resp.Events, events...)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			srv.Logger.Error("error encoding audit events response",
+				"error", err,
+				"method", r.Method,
+				"path", r.URL.Path,
+			)
+		}
+	})
+}
+
+// parseAuditEventFilter parses audit event query parameters.
+func parseAuditEventFilter(q url.Values) (models.AuditEventFilter, error) {
+	f := models.AuditEventFilter{
+		Actor:      q.Get("actor"),
+		Action:     q.Get("action"),
+		TargetType: q.Get("targetType"),
+		TargetID:   q.Get("targetId"),
+		RequestID:  q.Get("requestId"),
+		Limit:      defaultAuditEventsLimit,
+	}
+
+	if v := q.Get("since"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return f, fmt.Errorf("invalid since: %w", err)
+		}
+		f.Since = t
+	}
+	if v := q.Get("until"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return f, fmt.Errorf("invalid until: %w", err)
+		}
+		f.Until = t
+	}
+	if v := q.Get("cursor"); v != "" {
+		id, err := strconv.ParseUint(v, 10, 64)
+		if err != nil || id == 0 {
+			return f, fmt.Errorf("invalid cursor")
+		}
+		f.BeforeID = id
+	}
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return f, fmt.Errorf("limit must be a positive integer")
+		}
+		if n > maxAuditEventsLimit {
+			n = maxAuditEventsLimit
+		}
+		f.Limit = n
+	}
+
+	return f, nil
+}
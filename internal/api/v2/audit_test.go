@@ -0,0 +1,114 @@
+package api
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/pkg/requestid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAuditTarget(t *testing.T) {
+	cases := []struct {
+		method string
+		path   string
+		want   auditTarget
+	}{
+		{"POST", "/api/v2/drafts",
+			auditTarget{Type: "document", Action: "drafts.create"}},
+		{"PATCH", "/api/v2/documents/abc",
+			auditTarget{Type: "document", ID: "abc", Action: "documents.update"}},
+		{"PUT", "/api/v2/documents/abc/content",
+			auditTarget{Type: "document", ID: "abc",
+				Action: "documents.content.update"}},
+		{"POST", "/api/v2/documents/import",
+			auditTarget{Type: "document", Action: "documents.import.create"}},
+		{"DELETE", "/api/v2/approvals/abc",
+			auditTarget{Type: "document", ID: "abc", Action: "approvals.delete"}},
+		{"PATCH", "/api/v2/projects/12",
+			auditTarget{Type: "project", ID: "12", Action: "projects.update"}},
+		{"POST", "/api/v2/me/subscriptions",
+			auditTarget{Type: "user", Action: "me.subscriptions.create"}},
+		{"DELETE", "/api/v2/me/review-delegations/7",
+			auditTarget{Type: "review-delegation", ID: "7",
+				Action: "me.review-delegations.delete"}},
+		{"POST", "/api/v2/migrations/jobs/3/start",
+			auditTarget{Type: "job", ID: "3",
+				Action: "migrations.jobs.start.create"}},
+		{"POST", "/api/v2/admin/service-tokens/" +
+			"1b4e28ba-2fa1-11d2-883f-0016d3cca427/rotate",
+			auditTarget{Type: "service-token",
+				ID:     "1b4e28ba-2fa1-11d2-883f-0016d3cca427",
+				Action: "admin.service-tokens.rotate.create"}},
+		{"POST", "/api/v2/migrations/jobs",
+			auditTarget{Type: "migrations", Action: "migrations.jobs.create"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.method+" "+c.path, func(t *testing.T) {
+			assert.Equal(t, c.want, parseAuditTarget(c.path, c.method))
+		})
+	}
+}
+
+func TestIsAuditedRequest(t *testing.T) {
+	assert.True(t, isAuditedRequest(
+		httptest.NewRequest("PATCH", "/api/v2/documents/abc", nil)))
+	assert.True(t, isAuditedRequest(
+		httptest.NewRequest("POST", "/api/v2/drafts", nil)))
+	assert.False(t, isAuditedRequest(
+		httptest.NewRequest("GET", "/api/v2/documents/abc", nil)))
+	assert.False(t, isAuditedRequest(
+		httptest.NewRequest("POST", "/api/v2/search/docs", nil)))
+	assert.False(t, isAuditedRequest(
+		httptest.NewRequest("POST", "/api/v2/people", nil)))
+	assert.False(t, isAuditedRequest(
+		httptest.NewRequest("POST", "/api/v2/documents/abc/similar", nil)))
+}
+
+func TestAuditRequestID(t *testing.T) {
+	r := httptest.NewRequest("POST", "/api/v2/drafts", nil)
+	r.Header.Set(requestid.Header, " req-1 ")
+	assert.Equal(t, "req-1", auditRequestID(r))
+
+	// The request ID of the middleware takes precedence.
+	r = r.WithContext(requestid.NewContext(r.Context(), "req-2"))
+	assert.Equal(t, "req-2", auditRequestID(r))
+
+	r = httptest.NewRequest("POST", "/api/v2/drafts", nil)
+	assert.NotEmpty(t, auditRequestID(r))
+	assert.NotEqual(t, auditRequestID(r), auditRequestID(r))
+}
+
+func TestParseAuditEventFilter(t *testing.T) {
+	f, err := parseAuditEventFilter(url.Values{
+		"actor":    {"alice@example.com"},
+		"targetId": {"abc"},
+		"since":    {"2025-01-01T00:00:00Z"},
+		"cursor":   {"42"},
+		"limit":    {"5000"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", f.Actor)
+	assert.Equal(t, "abc", f.TargetID)
+	assert.Equal(t, 2025, f.Since.Year())
+	assert.True(t, f.Until.IsZero())
+	assert.Equal(t, uint64(42), f.BeforeID)
+	assert.Equal(t, maxAuditEventsLimit, f.Limit)
+
+	f, err = parseAuditEventFilter(url.Values{})
+	require.NoError(t, err)
+	assert.Equal(t, defaultAuditEventsLimit, f.Limit)
+
+	for _, q := range []url.Values{
+		{"since": {"yesterday"}},
+		{"until": {"2025-01-01"}},
+		{"cursor": {"abc"}},
+		{"limit": {"0"}},
+	} {
+		_, err := parseAuditEventFilter(q)
+		assert.Error(t, err, q.Encode())
+	}
+}
@@ -0,0 +1,152 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp-forge/hermes/internal/config"
+	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+	"github.com/hashicorp-forge/hermes/pkg/authz"
+	"github.com/hashicorp-forge/hermes/pkg/document"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp-forge/hermes/pkg/tenant"
+	"github.com/hashicorp/go-hclog"
+	"gorm.io/gorm"
+)
+
+// authorize returns nil if the authenticated user is allowed to perform action
+// on resource res. The user's roles are loaded from database db, and site
+// admins configured in cfg always have the site admin role. An error wrapping
+// authz.ErrForbidden is returned if the user is not allowed.
+func authorize(
+	r *http.Request, cfg *config.Config, db *gorm.DB,
+	action authz.Action, res authz.Resource,
+) error {
+	userEmail, ok := pkgauth.GetUserEmail(r.Context())
+	if !ok || userEmail == "" {
+		return fmt.Errorf("%w: no authorization information for request",
+			authz.ErrForbidden)
+	}
+
+	p, err := loadPrincipal(r.Context(), cfg, db, userEmail)
+	if err != nil {
+		return err
+	}
+	return authz.Authorize(p, action, res)
+}
+
+// authorizeRequest authorizes a request like authorize and writes a problem
+// response if it is not allowed. It returns false if a response was written.
+func authorizeRequest(
+	w http.ResponseWriter, r *http.Request,
+	cfg *config.Config, db *gorm.DB, l hclog.Logger,
+	action authz.Action, res authz.Resource, userErrMsg string,
+) bool {
+	if err := authorize(r, cfg, db, action, res); err != nil {
+		if errors.Is(err, authz.ErrForbidden) {
+			l.Warn("request not authorized",
+				"error", err,
+				"method", r.Method,
+				"path", r.URL.Path,
+			)
+			writeProblem(w, r, http.StatusForbidden, ErrCodeForbidden, userErrMsg)
+			return false
+		}
+		respondError(w, r, l, http.StatusInternalServerError,
+			"Error authorizing request", "error authorizing request", err)
+		return false
+	}
+	return true
+}
+
+// loadPrincipal returns the user with the provided email address and the roles
+// granted to them. Site admins of the tenant in ctx are tenant admins of the
+// tenant, which doesn't let them manage the deployment.
+func loadPrincipal(
+	ctx context.Context, cfg *config.Config, db *gorm.DB, userEmail string,
+) (authz.Principal, error) {
+	p := authz.Principal{
+		Email: userEmail,
+	}
+	if cfg != nil && cfg.Authorization != nil &&
+		contains(cfg.Authorization.SiteAdmins, userEmail) {
+		p.Grants = append(p.Grants, authz.Grant{Role: authz.RoleSiteAdmin})
+	}
+	if isTenantSiteAdmin(ctx, cfg, userEmail) {
+		p.Grants = append(p.Grants, authz.Grant{
+			Role:     authz.RoleTenantAdmin,
+			TenantID: tenant.IDFromContext(ctx),
+		})
+	}
+
+	if db == nil {
+		return p, nil
+	}
+	var roles models.UserRoles
+	if err := roles.FindByUser(db, userEmail); err != nil {
+		return authz.Principal{}, fmt.Errorf("error getting user roles: %w", err)
+	}
+	p.Grants = append(p.Grants, roleGrants(roles)...)
+
+	return p, nil
+}
+
+// roleGrants converts user role database models to authorization grants.
+func roleGrants(roles models.UserRoles) []authz.Grant {
+	var grants []authz.Grant
+	for _, r := range roles {
+		g := authz.Grant{
+			Role: authz.Role(r.Role.String()),
+		}
+		if r.DocumentType != nil {
+			g.DocumentType = r.DocumentType.Name
+		}
+		if r.Product != nil {
+			g.Product = r.Product.Name
+		}
+		grants = append(grants, g)
+	}
+	return grants
+}
+
+// documentAuthzResource returns the authorization resource for a document.
+func documentAuthzResource(doc document.Document) authz.Resource {
+	res := authz.Resource{
+		Contributors: doc.Contributors,
+		Approvers:    doc.Approvers,
+		DocumentType: doc.DocType,
+		Product:      doc.Product,
+		TenantID:     doc.TenantID,
+	}
+	if len(doc.Owners) > 0 {
+		res.Owner = doc.Owners[0]
+	}
+	return res
+}
+
+// documentModelAuthzResource returns the authorization resource for a
+// document database model.
+func documentModelAuthzResource(doc models.Document) authz.Resource {
+	res := authz.Resource{
+		DocumentType: doc.DocumentType.Name,
+		Product:      doc.Product.Name,
+		TenantID:     doc.TenantID,
+		Shared:       doc.ShareableAsDraft,
+	}
+	if doc.Owner != nil {
+		res.Owner = doc.Owner.EmailAddress
+	}
+	for _, c := range doc.Contributors {
+		if c != nil {
+			res.Contributors = append(res.Contributors, c.EmailAddress)
+		}
+	}
+	for _, a := range doc.Approvers {
+		if a != nil {
+			res.Approvers = append(res.Approvers, a.EmailAddress)
+		}
+	}
+	return res
+}
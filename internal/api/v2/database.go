@@ -0,0 +1,22 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/hashicorp-forge/hermes/internal/server"
+)
+
+// PostgresRequiredHandler wraps the handlers of endpoints that use
+// PostgreSQL-specific tables or SQL, like the central edge sync API and
+// storage migrations. When the app database is SQLite, requests are answered
+// with 501 Not Implemented instead of failing with database errors.
+func PostgresRequiredHandler(srv server.Server, next http.Handler) http.Handler {
+	if srv.DB == nil || srv.DB.Dialector == nil ||
+		srv.DB.Dialector.Name() != "sqlite" {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeProblem(w, r, http.StatusNotImplemented, ErrCodeNotImplemented,
+			"This endpoint requires a PostgreSQL database")
+	})
+}
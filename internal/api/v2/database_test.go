@@ -0,0 +1,29 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestPostgresRequiredHandler(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	serve := func(dialector gorm.Dialector) int {
+		srv := server.Server{DB: &gorm.DB{Config: &gorm.Config{Dialector: dialector}}}
+		rr := httptest.NewRecorder()
+		PostgresRequiredHandler(srv, next).ServeHTTP(rr,
+			httptest.NewRequest("POST", "/api/v2/edge/sync", nil))
+		return rr.Code
+	}
+
+	assert.Equal(t, http.StatusNoContent, serve(postgres.Dialector{}))
+	assert.Equal(t, http.StatusNotImplemented, serve(sqlite.Dialector{}))
+}
@@ -0,0 +1,575 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/pkg/attachments"
+	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+	"github.com/hashicorp-forge/hermes/pkg/authz"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp-forge/hermes/pkg/search"
+	"github.com/hashicorp-forge/hermes/pkg/workspace"
+	"gorm.io/gorm"
+)
+
+// documentAttachmentsURLPathRE matches document attachment URL paths.
+var documentAttachmentsURLPathRE = regexp.MustCompile(
+	`^/api/v2/documents/([0-9A-Za-z_\-]+)/attachments(?:/([^/]+))?$`)
+
+// DocumentAttachment is a file attached to a document.
+type DocumentAttachment struct {
+	Name        string `json:"name"`
+	ContentType string `json:"contentType,omitempty"`
+	Size        int64  `json:"size"`
+
+	// Indexed is true if the text of the file is included in search.
+	Indexed bool `json:"indexed"`
+
+	// TextLength is the length of the text extracted from the file, in bytes.
+	TextLength int `json:"textLength"`
+
+	// ExtractionError is why no text was extracted from the file, if any.
+	ExtractionError string `json:"extractionError,omitempty"`
+
+	UploadedBy string    `json:"uploadedBy,omitempty"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+// DocumentAttachmentsGetResponse is the list of files attached to a document.
+type DocumentAttachmentsGetResponse struct {
+	Attachments []DocumentAttachment `json:"attachments"`
+}
+
+// DocumentAttachmentPatchRequest toggles whether the text of an attached file
+// is included in search.
+type DocumentAttachmentPatchRequest struct {
+	Indexed *bool `json:"indexed,omitempty"`
+}
+
+// DocumentAttachmentsHandler handles the files attached to a document:
+//
+//   - GET /api/v2/documents/:id/attachments lists the attached files.
+//   - POST /api/v2/documents/:id/attachments attaches the "file" of a
+//     multipart form, replacing an attached file with the same name.
+//   - GET /api/v2/documents/:id/attachments/:name downloads an attached file.
+//   - PATCH /api/v2/documents/:id/attachments/:name toggles whether the text of
+//     the file is included in search.
+//   - DELETE /api/v2/documents/:id/attachments/:name removes an attached file.
+//
+// The text of attached files is extracted when they are attached and included
+// in the search document content of the document unless it's toggled off.
+// Files can only be attached to documents of workspace providers that store
+// attachments.
+func DocumentAttachmentsHandler(srv server.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
+
+		if srv.Config.Attachments == nil {
+			writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound,
+				"Attachments are not enabled")
+			return
+		}
+
+		matches := documentAttachmentsURLPathRE.FindStringSubmatch(r.URL.Path)
+		if len(matches) != 3 {
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				"Bad request")
+			return
+		}
+		docID, name := matches[1], matches[2]
+
+		switch {
+		case name == "" && (r.Method == "GET" || r.Method == "POST"):
+		case name != "" &&
+			(r.Method == "GET" || r.Method == "PATCH" || r.Method == "DELETE"):
+		default:
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
+			return
+		}
+
+		// Get document from database.
+		model := models.Document{}
+		if err := model.GetByGoogleFileIDOrUUID(srv.DB, docID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				writeProblem(w, r, http.StatusNotFound, ErrCodeDocumentNotFound,
+					"Document not found")
+				return
+			}
+			respondError(w, r, srv.Logger, http.StatusInternalServerError,
+				"Error requesting document",
+				"error getting document from database", err,
+				"doc_id", docID,
+			)
+			return
+		}
+
+		// Anyone who can view a document can view its attachments, and users
+		// who can edit its content can change them.
+		if r.Method == "GET" {
+			if model.Status == models.WIPDocumentStatus &&
+				!authorizeRequest(w, r, srv.Config, srv.DB, srv.Logger,
+					authz.ActionDraftView, documentModelAuthzResource(model),
+					"Only owners or contributors can access a non-shared draft document",
+				) {
+				return
+			}
+		} else {
+			action := authz.ActionDocumentEditContent
+			if model.Status == models.WIPDocumentStatus {
+				action = authz.ActionDraftEdit
+			}
+			if !authorizeRequest(w, r, srv.Config, srv.DB, srv.Logger,
+				action, documentModelAuthzResource(model),
+				"Only owners or contributors can change the attachments of a document",
+			) {
+				return
+			}
+		}
+
+		switch {
+		case r.Method == "GET" && name == "":
+			var atts models.DocumentAttachments
+			if err := atts.Find(srv.DB.WithContext(r.Context()), model.ID); err != nil {
+				respondError(w, r, srv.Logger, http.StatusInternalServerError,
+					"Error getting attachments",
+					"error getting document attachments", err,
+					"doc_id", docID,
+				)
+				return
+			}
+			resp := DocumentAttachmentsGetResponse{
+				Attachments: []DocumentAttachment{},
+			}
+			for _, a := range atts {
+				resp.Attachments = append(resp.Attachments, documentAttachmentResponse(a))
+			}
+			writeDocumentAttachmentsJSON(w, srv, http.StatusOK, resp, docID)
+
+		case r.Method == "GET":
+			handleGetDocumentAttachment(w, r, srv, docID, name, &model)
+
+		case r.Method == "POST":
+			handlePostDocumentAttachment(w, r, srv, docID, &model)
+
+		case r.Method == "PATCH":
+			var req DocumentAttachmentPatchRequest
+			if err := decodeRequest(r, &req); err != nil {
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					"Bad request")
+				return
+			}
+			if req.Indexed == nil {
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					"indexed is required")
+				return
+			}
+
+			att := models.DocumentAttachment{DocumentID: model.ID, Name: name}
+			if !getDocumentAttachment(w, r, srv, docID, &att) {
+				return
+			}
+			if err := srv.DB.Model(&att).
+				// Select is needed because Indexed is a boolean.
+				Select("Indexed").
+				Updates(models.DocumentAttachment{Indexed: *req.Indexed}).
+				Error; err != nil {
+				respondError(w, r, srv.Logger, http.StatusInternalServerError,
+					"Error updating attachment",
+					"error updating document attachment", err,
+					"doc_id", docID,
+					"name", name,
+				)
+				return
+			}
+			att.Indexed = *req.Indexed
+
+			updateDocumentAttachmentsSearchContent(r.Context(), srv, &model)
+			writeDocumentAttachmentsJSON(w, srv, http.StatusOK,
+				documentAttachmentResponse(att), docID)
+
+		case r.Method == "DELETE":
+			att := models.DocumentAttachment{DocumentID: model.ID, Name: name}
+			if !getDocumentAttachment(w, r, srv, docID, &att) {
+				return
+			}
+			if err := att.Delete(srv.DB); err != nil {
+				respondError(w, r, srv.Logger, http.StatusInternalServerError,
+					"Error deleting attachment",
+					"error deleting document attachment", err,
+					"doc_id", docID,
+					"name", name,
+				)
+				return
+			}
+
+			// The attachment is removed from the database first, so the file
+			// isn't listed if deleting it fails.
+			if p, providerID, err := attachmentProvider(r.Context(), srv, &model, docID); err == nil {
+				if err := p.DeleteAttachment(r.Context(), providerID, name); err != nil &&
+					!errors.Is(err, workspace.ErrNotFound) {
+					srv.Logger.Warn("error deleting attached file",
+						"error", err,
+						"doc_id", docID,
+						"name", name,
+					)
+				}
+			}
+
+			updateDocumentAttachmentsSearchContent(r.Context(), srv, &model)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+}
+
+// handleGetDocumentAttachment downloads an attached file.
+func handleGetDocumentAttachment(
+	w http.ResponseWriter,
+	r *http.Request,
+	srv server.Server,
+	docID, name string,
+	model *models.Document,
+) {
+	att := models.DocumentAttachment{DocumentID: model.ID, Name: name}
+	if !getDocumentAttachment(w, r, srv, docID, &att) {
+		return
+	}
+
+	p, providerID, ok := requireAttachmentProvider(w, r, srv, model, docID)
+	if !ok {
+		return
+	}
+	rc, err := p.GetAttachment(r.Context(), providerID, name)
+	if err != nil {
+		if errors.Is(err, workspace.ErrNotFound) {
+			writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound,
+				"Attachment not found")
+			return
+		}
+		respondError(w, r, srv.Logger, http.StatusInternalServerError,
+			"Error getting attachment",
+			"error getting attached file", err,
+			"doc_id", docID,
+			"name", name,
+		)
+		return
+	}
+	defer rc.Close()
+
+	contentType := att.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition",
+		fmt.Sprintf("attachment; filename=%q", name))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, rc); err != nil {
+		srv.Logger.Error("error writing attached file",
+			"error", err,
+			"doc_id", docID,
+			"name", name,
+		)
+	}
+}
+
+// handlePostDocumentAttachment attaches a file to a document and extracts its
+// text.
+func handlePostDocumentAttachment(
+	w http.ResponseWriter,
+	r *http.Request,
+	srv server.Server,
+	docID string,
+	model *models.Document,
+) {
+	extractor, err := attachmentExtractor(srv.Config.Attachments)
+	if err != nil {
+		respondError(w, r, srv.Logger, http.StatusInternalServerError,
+			"Error attaching file",
+			"error creating attachment text extractor", err,
+			"doc_id", docID,
+		)
+		return
+	}
+	maxSize := extractor.MaxSize()
+
+	p, providerID, ok := requireAttachmentProvider(w, r, srv, model, docID)
+	if !ok {
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxSize+1<<20)
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			"Error parsing multipart form")
+		return
+	}
+	f, fh, err := r.FormFile("file")
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			"file is required")
+		return
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, maxSize+1))
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			"Error reading file")
+		return
+	}
+	if int64(len(data)) > maxSize {
+		writeProblem(w, r, http.StatusRequestEntityTooLarge, ErrCodeBadRequest,
+			fmt.Sprintf("File is larger than %d bytes", maxSize))
+		return
+	}
+	name := fh.Filename
+	if name == "" || len(name) > 255 || name == "." || name == ".." ||
+		strings.ContainsAny(name, `/\`) {
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			"Invalid file name")
+		return
+	}
+	contentType := fh.Header.Get("Content-Type")
+
+	if err := p.PutAttachment(r.Context(), providerID, name, bytes.NewReader(data)); err != nil {
+		if errors.Is(err, workspace.ErrInvalidInput) {
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				"Invalid file name")
+			return
+		}
+		respondError(w, r, srv.Logger, http.StatusInternalServerError,
+			"Error attaching file",
+			"error storing attached file", err,
+			"doc_id", docID,
+			"name", name,
+		)
+		return
+	}
+
+	sum := sha256.Sum256(data)
+	att := models.DocumentAttachment{
+		DocumentID:  model.ID,
+		Name:        name,
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		ContentHash: hex.EncodeToString(sum[:]),
+		Indexed:     true,
+	}
+	// Extraction errors don't prevent attaching the file, but are recorded so
+	// users know why its text isn't searchable.
+	text, err := extractor.Extract(r.Context(), name, contentType, data)
+	if err != nil {
+		att.Indexed = false
+		att.ExtractionError = err.Error()
+		if !errors.Is(err, attachments.ErrUnsupported) &&
+			!errors.Is(err, attachments.ErrDisabled) &&
+			!errors.Is(err, attachments.ErrTooLarge) {
+			srv.Logger.Warn("error extracting text of attached file",
+				"error", err,
+				"doc_id", docID,
+				"name", name,
+			)
+		}
+	}
+	att.Text = text
+
+	user := models.User{EmailAddress: pkgauth.MustGetUserEmail(r.Context())}
+	if err := user.FirstOrCreate(srv.DB); err == nil {
+		att.UploadedByID = &user.ID
+		att.UploadedBy = &user
+	}
+
+	if err := att.Upsert(srv.DB); err != nil {
+		respondError(w, r, srv.Logger, http.StatusInternalServerError,
+			"Error attaching file",
+			"error saving document attachment", err,
+			"doc_id", docID,
+			"name", name,
+		)
+		return
+	}
+
+	updateDocumentAttachmentsSearchContent(r.Context(), srv, model)
+	writeDocumentAttachmentsJSON(w, srv, http.StatusCreated,
+		documentAttachmentResponse(att), docID)
+}
+
+// getDocumentAttachment gets an attachment from the database, and writes an
+// error response and returns false if it can't.
+func getDocumentAttachment(
+	w http.ResponseWriter,
+	r *http.Request,
+	srv server.Server,
+	docID string,
+	att *models.DocumentAttachment,
+) bool {
+	if err := att.Get(srv.DB.WithContext(r.Context())); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound,
+				"Attachment not found")
+			return false
+		}
+		respondError(w, r, srv.Logger, http.StatusInternalServerError,
+			"Error getting attachment",
+			"error getting document attachment", err,
+			"doc_id", docID,
+			"name", att.Name,
+		)
+		return false
+	}
+	return true
+}
+
+// attachmentProvider returns the workspace provider that stores the
+// attachments of a document and the provider ID of the document.
+func attachmentProvider(
+	ctx context.Context, srv server.Server, model *models.Document, docID string,
+) (workspace.AttachmentProvider, string, error) {
+	provider, providerID, err := documentContentProvider(ctx, srv, model, docID)
+	if err != nil {
+		return nil, "", err
+	}
+	p, ok := workspace.Unwrap(provider).(workspace.AttachmentProvider)
+	if !ok {
+		return nil, "", errAttachmentsUnsupported
+	}
+	return p, providerID, nil
+}
+
+// errAttachmentsUnsupported is returned by attachmentProvider if the workspace
+// provider of a document doesn't store attachments.
+var errAttachmentsUnsupported = errors.New(
+	"workspace provider doesn't support attachments")
+
+// requireAttachmentProvider returns the workspace provider that stores the
+// attachments of a document, and writes an error response and returns false if
+// there isn't one.
+func requireAttachmentProvider(
+	w http.ResponseWriter,
+	r *http.Request,
+	srv server.Server,
+	model *models.Document,
+	docID string,
+) (workspace.AttachmentProvider, string, bool) {
+	p, providerID, err := attachmentProvider(r.Context(), srv, model, docID)
+	if errors.Is(err, errAttachmentsUnsupported) {
+		writeProblem(w, r, http.StatusNotImplemented, ErrCodeUnsupportedProvider,
+			"The workspace provider of this document doesn't support attachments")
+		return nil, "", false
+	}
+	if err != nil {
+		respondError(w, r, srv.Logger, http.StatusInternalServerError,
+			"Error resolving document provider",
+			"error resolving document provider for attachments", err,
+			"doc_id", docID,
+		)
+		return nil, "", false
+	}
+	return p, providerID, true
+}
+
+// attachmentExtractor returns the extractor of the text of attached files.
+func attachmentExtractor(cfg *config.Attachments) (*attachments.Extractor, error) {
+	var ocr attachments.OCR
+	if cfg.OCR != nil {
+		c, err := attachments.NewOCRClient(attachments.OCRConfig{
+			URL:     cfg.OCR.URL,
+			Token:   cfg.OCR.Token,
+			Timeout: cfg.OCR.Timeout,
+		})
+		if err != nil {
+			return nil, err
+		}
+		ocr = c
+	}
+	return attachments.New(attachments.Config{
+		Types:       cfg.Types,
+		MaxSize:     cfg.MaxSize,
+		MaxTextSize: cfg.MaxTextSize,
+	}, ocr)
+}
+
+// updateDocumentAttachmentsSearchContent replaces the text of attached files in
+// the search document content of a document with the text of its indexed
+// attachments. Errors are logged, because the indexer also includes the text
+// of attachments the next time it indexes the document.
+func updateDocumentAttachmentsSearchContent(
+	ctx context.Context, srv server.Server, model *models.Document,
+) {
+	var idx search.DocumentIndex = srv.SearchProvider.DocumentIndex()
+	if model.Status == models.WIPDocumentStatus {
+		idx = srv.SearchProvider.DraftIndex()
+	}
+	u, ok := idx.(search.DocumentUpdater)
+	if !ok {
+		return
+	}
+
+	docID := model.GoogleFileID
+	l := srv.Logger.With("doc_id", docID)
+	doc, err := idx.GetObject(ctx, docID)
+	if err != nil {
+		if !errors.Is(err, search.ErrNotFound) {
+			l.Warn("error getting search object to update attachment text",
+				"error", err)
+		}
+		return
+	}
+
+	var atts models.DocumentAttachments
+	if err := atts.Find(srv.DB.WithContext(ctx), model.ID); err != nil {
+		l.Warn("error getting attachment text", "error", err)
+		return
+	}
+	if err := u.UpdateFields(ctx, docID, map[string]any{
+		"content": attachments.WithText(doc.Content, attachments.IndexedText(atts)),
+	}); err != nil && !errors.Is(err, search.ErrNotFound) {
+		l.Warn("error updating attachment text in search index", "error", err)
+	}
+}
+
+// documentAttachmentResponse returns the API representation of an attachment.
+func documentAttachmentResponse(a models.DocumentAttachment) DocumentAttachment {
+	resp := DocumentAttachment{
+		Name:            a.Name,
+		ContentType:     a.ContentType,
+		Size:            a.Size,
+		Indexed:         a.Indexed,
+		TextLength:      len(a.Text),
+		ExtractionError: a.ExtractionError,
+		UpdatedAt:       a.UpdatedAt,
+	}
+	if a.UploadedBy != nil {
+		resp.UploadedBy = a.UploadedBy.EmailAddress
+	}
+	return resp
+}
+
+// writeDocumentAttachmentsJSON writes a JSON response.
+func writeDocumentAttachmentsJSON(
+	w http.ResponseWriter, srv server.Server, status int, resp any, docID string,
+) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		srv.Logger.Error("error encoding document attachments response",
+			"error", err,
+			"doc_id", docID,
+		)
+	}
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocumentAttachmentsURLPathRE(t *testing.T) {
+	assert.Equal(t, []string{"/api/v2/documents/doc1/attachments", "doc1", ""},
+		documentAttachmentsURLPathRE.FindStringSubmatch(
+			"/api/v2/documents/doc1/attachments"))
+	assert.Equal(t,
+		[]string{"/api/v2/documents/doc1/attachments/plan v2.pdf", "doc1", "plan v2.pdf"},
+		documentAttachmentsURLPathRE.FindStringSubmatch(
+			"/api/v2/documents/doc1/attachments/plan v2.pdf"))
+
+	for _, path := range []string{
+		"/api/v2/documents/doc1",
+		"/api/v2/documents/doc1/attachments/",
+		"/api/v2/documents/doc1/attachments/a/b",
+		"/api/v2/documents//attachments",
+	} {
+		assert.False(t, documentAttachmentsURLPathRE.MatchString(path), path)
+	}
+}
+
+func TestDocumentAttachmentResponse(t *testing.T) {
+	updatedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	assert.Equal(t, DocumentAttachment{
+		Name:            "deck.pptx",
+		ContentType:     "application/octet-stream",
+		Size:            1024,
+		TextLength:      5,
+		ExtractionError: "text extraction disabled for attachment type",
+		UploadedBy:      "alice@example.com",
+		UpdatedAt:       updatedAt,
+	}, documentAttachmentResponse(models.DocumentAttachment{
+		Name:            "deck.pptx",
+		ContentType:     "application/octet-stream",
+		Size:            1024,
+		Text:            "Slide",
+		ExtractionError: "text extraction disabled for attachment type",
+		UploadedBy:      &models.User{EmailAddress: "alice@example.com"},
+		UpdatedAt:       updatedAt,
+	}))
+}
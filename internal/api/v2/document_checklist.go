@@ -0,0 +1,293 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/internal/server"
+	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+	"github.com/hashicorp-forge/hermes/pkg/authz"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"gorm.io/gorm"
+)
+
+// documentChecklistURLPathRE matches document review checklist URL paths.
+var documentChecklistURLPathRE = regexp.MustCompile(
+	`^/api/v2/documents/([0-9A-Za-z_\-]+)/checklist$`)
+
+// DocumentChecklistItem is an item of the review checklist of a document.
+type DocumentChecklistItem struct {
+	Name       string `json:"name"`
+	Label      string `json:"label"`
+	HelperText string `json:"helperText,omitempty"`
+
+	// RequiredFor is the document status ("In-Review" or "Approved") that the
+	// item must be checked before the document can move to, or empty if the
+	// item is optional.
+	RequiredFor string `json:"requiredFor,omitempty"`
+
+	Checked   bool       `json:"checked"`
+	CheckedBy string     `json:"checkedBy,omitempty"`
+	CheckedAt *time.Time `json:"checkedAt,omitempty"`
+}
+
+// DocumentChecklistGetResponse is the review checklist of a document.
+type DocumentChecklistGetResponse struct {
+	Items []DocumentChecklistItem `json:"items"`
+}
+
+// DocumentChecklistPatchRequest is the request to check or uncheck items of
+// the review checklist of a document.
+type DocumentChecklistPatchRequest struct {
+	Items []DocumentChecklistItemPatch `json:"items"`
+}
+
+// DocumentChecklistItemPatch checks or unchecks an item of the review
+// checklist of a document.
+type DocumentChecklistItemPatch struct {
+	Name    string `json:"name"`
+	Checked bool   `json:"checked"`
+}
+
+// DocumentChecklistHandler handles the review checklist of a document, which
+// is defined by the workflow of its document type.
+// GET   /api/v2/documents/:id/checklist - gets the checklist
+// PATCH /api/v2/documents/:id/checklist - checks or unchecks items
+//
+// Documents can't move to In-Review or Approved until the items required for
+// that status are checked; requests that would move them get 409 Conflict.
+func DocumentChecklistHandler(srv server.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
+		matches := documentChecklistURLPathRE.FindStringSubmatch(r.URL.Path)
+		if len(matches) != 2 {
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				"Bad request")
+			return
+		}
+		docID := matches[1]
+
+		if r.Method != "GET" && r.Method != "PATCH" {
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
+			return
+		}
+
+		// Get document from database.
+		model := models.Document{}
+		if err := model.GetByGoogleFileIDOrUUID(srv.DB, docID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				writeProblem(w, r, http.StatusNotFound, ErrCodeDocumentNotFound,
+					"Document not found")
+				return
+			}
+			respondError(w, r, srv.Logger, http.StatusInternalServerError,
+				"Error requesting document",
+				"error getting document from database", err,
+				"doc_id", docID,
+			)
+			return
+		}
+
+		isDraft := model.Status == models.WIPDocumentStatus
+		if isDraft &&
+			!authorizeRequest(w, r, srv.Config, srv.DB, srv.Logger,
+				authz.ActionDraftView, documentModelAuthzResource(model),
+				"Only owners or contributors can access a non-shared draft document",
+			) {
+			return
+		}
+
+		var checklist []*config.DocumentTypeChecklistItem
+		if dt := findDocumentType(
+			documentTypes(r.Context(), srv), model.DocumentType.Name,
+		); dt != nil {
+			checklist = dt.ReviewChecklist
+		}
+
+		if r.Method == "PATCH" {
+			action := authz.ActionDocumentEdit
+			if isDraft {
+				action = authz.ActionDraftEdit
+			}
+			if !authorizeRequest(w, r, srv.Config, srv.DB, srv.Logger,
+				action, documentModelAuthzResource(model),
+				"Only users who can edit the document can update its checklist",
+			) {
+				return
+			}
+
+			var req DocumentChecklistPatchRequest
+			if err := decodeRequest(r, &req); err != nil {
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					fmt.Sprintf("Bad request: %q", err))
+				return
+			}
+			if err := validateDocumentChecklistPatch(checklist, req); err != nil {
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					fmt.Sprintf("Bad request: %v", err))
+				return
+			}
+
+			userEmail := pkgauth.MustGetUserEmail(r.Context())
+			if err := srv.DB.WithContext(r.Context()).Transaction(
+				func(tx *gorm.DB) error {
+					for _, p := range req.Items {
+						item := models.DocumentChecklistItem{
+							DocumentID: model.ID,
+							Name:       p.Name,
+							Checked:    p.Checked,
+						}
+						if p.Checked {
+							item.CheckedByEmail = userEmail
+						}
+						if err := item.Upsert(tx); err != nil {
+							return fmt.Errorf("error upserting checklist item %q: %w",
+								p.Name, err)
+						}
+					}
+					return nil
+				},
+			); err != nil {
+				respondError(w, r, srv.Logger, http.StatusInternalServerError,
+					"Error updating checklist",
+					"error updating document checklist", err,
+					"doc_id", docID,
+				)
+				return
+			}
+
+			srv.Logger.Info("updated document checklist",
+				"doc_id", docID,
+				"user", userEmail,
+				"items", len(req.Items),
+			)
+		}
+
+		var items models.DocumentChecklistItems
+		if err := items.FindByDocument(
+			srv.DB.WithContext(r.Context()), model.ID,
+		); err != nil {
+			respondError(w, r, srv.Logger, http.StatusInternalServerError,
+				"Error getting checklist",
+				"error getting document checklist items", err,
+				"doc_id", docID,
+			)
+			return
+		}
+
+		resp := documentChecklistResponse(checklist, items)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			srv.Logger.Error("error encoding document checklist response",
+				"error", err,
+				"doc_id", docID,
+			)
+		}
+	})
+}
+
+// validateDocumentChecklistPatch returns an error if req doesn't check or
+// uncheck items of checklist, or changes an item more than once.
+func validateDocumentChecklistPatch(
+	checklist []*config.DocumentTypeChecklistItem,
+	req DocumentChecklistPatchRequest,
+) error {
+	if len(req.Items) == 0 {
+		return fmt.Errorf("items are required")
+	}
+	names := map[string]bool{}
+	for _, item := range checklist {
+		names[item.Name] = true
+	}
+	seen := map[string]bool{}
+	for _, p := range req.Items {
+		if !names[p.Name] {
+			return fmt.Errorf("%q isn't a checklist item of the document type",
+				p.Name)
+		}
+		if seen[p.Name] {
+			return fmt.Errorf("duplicate checklist item %q", p.Name)
+		}
+		seen[p.Name] = true
+	}
+	return nil
+}
+
+// documentChecklistResponse returns the review checklist response for the
+// checklist of a document type and the items recorded for a document. Items
+// recorded for checklist items that were removed from the document type are
+// omitted.
+func documentChecklistResponse(
+	checklist []*config.DocumentTypeChecklistItem,
+	items models.DocumentChecklistItems,
+) DocumentChecklistGetResponse {
+	byName := map[string]models.DocumentChecklistItem{}
+	for _, i := range items {
+		byName[i.Name] = i
+	}
+
+	resp := DocumentChecklistGetResponse{
+		Items: make([]DocumentChecklistItem, 0, len(checklist)),
+	}
+	for _, c := range checklist {
+		item := DocumentChecklistItem{
+			Name:        c.Name,
+			Label:       c.Label,
+			HelperText:  c.HelperText,
+			RequiredFor: c.RequiredFor,
+		}
+		if i, ok := byName[c.Name]; ok && i.Checked {
+			item.Checked = true
+			item.CheckedBy = i.CheckedByEmail
+			item.CheckedAt = i.CheckedAt
+		}
+		resp.Items = append(resp.Items, item)
+	}
+	return resp
+}
+
+// uncheckedRequiredChecklistItems returns the labels of the items of the
+// review checklist of document type dt that must be checked before the
+// document with ID documentID can move to status status, but aren't.
+func uncheckedRequiredChecklistItems(
+	db *gorm.DB, dt *config.DocumentType, documentID uint, status string,
+) ([]string, error) {
+	if dt == nil {
+		return nil, nil
+	}
+	required := dt.RequiredChecklistItems(status)
+	if len(required) == 0 {
+		return nil, nil
+	}
+
+	var items models.DocumentChecklistItems
+	if err := items.FindByDocument(db, documentID); err != nil {
+		return nil, err
+	}
+	checked := items.Checked()
+
+	var unchecked []string
+	for _, item := range required {
+		if !checked[item.Name] {
+			unchecked = append(unchecked, item.Label)
+		}
+	}
+	return unchecked, nil
+}
+
+// uncheckedChecklistProblemDetail returns the problem detail for a document
+// that can't move to status status because the required checklist items with
+// labels unchecked aren't checked.
+func uncheckedChecklistProblemDetail(status string, unchecked []string) string {
+	return fmt.Sprintf(
+		"Documents need the following checklist items checked to move to %s: %s",
+		status, strings.Join(unchecked, ", "))
+}
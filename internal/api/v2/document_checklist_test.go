@@ -0,0 +1,79 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+var testReviewChecklist = []*config.DocumentTypeChecklistItem{
+	{Name: "security-review", Label: "Security review done", RequiredFor: "In-Review"},
+	{Name: "legal", Label: "Legal sign-off", RequiredFor: "Approved"},
+	{Name: "test-plan", Label: "Test plan linked"},
+}
+
+func TestDocumentChecklistURLPathRE(t *testing.T) {
+	assert.Equal(t, []string{"/api/v2/documents/doc1/checklist", "doc1"},
+		documentChecklistURLPathRE.FindStringSubmatch(
+			"/api/v2/documents/doc1/checklist"))
+
+	for _, path := range []string{
+		"/api/v2/documents/doc1",
+		"/api/v2/documents/doc1/checklist/legal",
+		"/api/v2/documents//checklist",
+	} {
+		assert.False(t, documentChecklistURLPathRE.MatchString(path), path)
+	}
+}
+
+func TestValidateDocumentChecklistPatch(t *testing.T) {
+	assert.NoError(t, validateDocumentChecklistPatch(testReviewChecklist,
+		DocumentChecklistPatchRequest{Items: []DocumentChecklistItemPatch{
+			{Name: "legal", Checked: true},
+			{Name: "test-plan"},
+		}}))
+
+	for name, req := range map[string]DocumentChecklistPatchRequest{
+		"no items": {},
+		"unknown item": {Items: []DocumentChecklistItemPatch{
+			{Name: "design-review", Checked: true},
+		}},
+		"duplicate item": {Items: []DocumentChecklistItemPatch{
+			{Name: "legal", Checked: true},
+			{Name: "legal"},
+		}},
+	} {
+		assert.Error(t, validateDocumentChecklistPatch(testReviewChecklist, req),
+			name)
+	}
+}
+
+func TestDocumentChecklistResponse(t *testing.T) {
+	assert.Equal(t, DocumentChecklistGetResponse{
+		Items: []DocumentChecklistItem{},
+	}, documentChecklistResponse(nil, nil))
+
+	t0 := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	resp := documentChecklistResponse(testReviewChecklist,
+		models.DocumentChecklistItems{
+			{Name: "removed", Checked: true, CheckedByEmail: "a@example.com"},
+			{Name: "security-review", Checked: true,
+				CheckedByEmail: "a@example.com", CheckedAt: &t0},
+			{Name: "test-plan"},
+		})
+	assert.Equal(t, []DocumentChecklistItem{
+		{
+			Name:        "security-review",
+			Label:       "Security review done",
+			RequiredFor: "In-Review",
+			Checked:     true,
+			CheckedBy:   "a@example.com",
+			CheckedAt:   &t0,
+		},
+		{Name: "legal", Label: "Legal sign-off", RequiredFor: "Approved"},
+		{Name: "test-plan", Label: "Test plan linked"},
+	}, resp.Items)
+}
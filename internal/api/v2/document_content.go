@@ -9,6 +9,7 @@ import (
 
 	"github.com/hashicorp-forge/hermes/internal/server"
 	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+	"github.com/hashicorp-forge/hermes/pkg/authz"
 	hcd "github.com/hashicorp-forge/hermes/pkg/hashicorpdocs"
 	"github.com/hashicorp-forge/hermes/pkg/models"
 	"github.com/hashicorp-forge/hermes/pkg/workspace"
@@ -25,25 +26,35 @@ type DocumentContentRequest struct {
 // DocumentContentResponse contains the document content.
 type DocumentContentResponse struct {
 	Content string `json:"content"`
+
+	// Revision is set when content was requested as of a point in time.
+	Revision *DocumentContentRevision `json:"revision,omitempty"`
 }
 
 // DocumentContentHandler handles GET and PUT requests for document content.
 // GET /api/v2/documents/:id/content - retrieves document content
+// GET /api/v2/documents/:id/content?asOf=<timestamp> - retrieves archived content
 // PUT /api/v2/documents/:id/content - updates document content
 //
 // This endpoint is only available for workspace providers that support content editing.
 // Providers must implement the ProviderCapabilities interface and return true from
-// SupportsContentEditing() to enable this functionality.
+// SupportsContentEditing() to enable this functionality. Archived content
+// (asOf) is read from revisions, which every provider tracks.
 func DocumentContentHandler(srv server.Server) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
+		asOf := r.URL.Query().Get("asOf")
+		timeTravel := r.Method == "GET" && asOf != ""
+
 		// Check if workspace provider supports content editing
-		if caps, ok := srv.WorkspaceProvider.(workspace.ProviderCapabilities); !ok || !caps.SupportsContentEditing() {
+		if caps, ok := workspace.Unwrap(srv.WorkspaceProvider).(workspace.ProviderCapabilities); !timeTravel &&
+			(!ok || !caps.SupportsContentEditing()) {
 			srv.Logger.Warn("document content API not supported by workspace provider",
 				"path", r.URL.Path,
 				"method", r.Method,
 			)
-			http.Error(w, "Document content editing not supported for this workspace provider",
-				http.StatusNotImplemented)
+			writeProblem(w, r, http.StatusNotImplemented, ErrCodeUnsupportedProvider,
+				"Document content editing not supported for this workspace provider")
 			return
 		}
 
@@ -55,7 +66,8 @@ func DocumentContentHandler(srv server.Server) http.Handler {
 				"path", r.URL.Path,
 				"method", r.Method,
 			)
-			http.Error(w, "Bad request", http.StatusBadRequest)
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				"Bad request")
 			return
 		}
 
@@ -70,7 +82,8 @@ func DocumentContentHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"doc_id", docID,
 				)
-				http.Error(w, "Document not found", http.StatusNotFound)
+				writeProblem(w, r, http.StatusNotFound, ErrCodeDocumentNotFound,
+					"Document not found")
 				return
 			}
 			srv.Logger.Error("error getting document from database",
@@ -79,8 +92,8 @@ func DocumentContentHandler(srv server.Server) http.Handler {
 				"method", r.Method,
 				"doc_id", docID,
 			)
-			http.Error(w, "Error requesting document",
-				http.StatusInternalServerError)
+			writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Error requesting document")
 			return
 		}
 
@@ -89,11 +102,16 @@ func DocumentContentHandler(srv server.Server) http.Handler {
 
 		switch r.Method {
 		case "GET":
+			if timeTravel {
+				handleGetDocumentContentAsOf(w, r, srv, docID, &model, asOf)
+				return
+			}
 			handleGetDocumentContent(w, r, srv, docID, userEmail, &model)
 		case "PUT":
 			handlePutDocumentContent(w, r, srv, docID, userEmail, &model)
 		default:
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
 		}
 	})
 }
@@ -108,28 +126,35 @@ func handleGetDocumentContent(
 	model *models.Document,
 ) {
 	// Use RFC-084 GetContent method
-	providerID := fmt.Sprintf("google:%s", docID) // Assume Google for now, adjust as needed
-
-	// Check if this is a local workspace provider
-	if _, ok := srv.WorkspaceProvider.(*local.WorkspaceAdapter); ok {
-		providerID = fmt.Sprintf("local:%s", docID)
-	} else if _, ok := srv.WorkspaceProvider.(*local.ProviderAdapter); ok {
-		providerID = fmt.Sprintf("local:%s", docID)
+	provider, providerID, err := documentContentProvider(r.Context(), srv, model, docID)
+	if err != nil {
+		srv.Logger.Error("error resolving document provider",
+			"error", err,
+			"doc_id", docID,
+		)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"Error retrieving document content")
+		return
 	}
 
-	docContent, err := srv.WorkspaceProvider.GetContent(r.Context(), providerID)
+	docContent, err := provider.GetContent(r.Context(), providerID)
 	if err != nil {
 		srv.Logger.Error("error getting document content",
 			"error", err,
 			"doc_id", docID,
 		)
-		http.Error(w, "Error retrieving document content",
-			http.StatusInternalServerError)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"Error retrieving document content")
 		return
 	}
 
 	content := docContent.Body
 
+	// Respond with 304 Not Modified if the client already has this content.
+	if checkIfNoneMatch(w, r, contentETag(docContent)) {
+		return
+	}
+
 	resp := DocumentContentResponse{
 		Content: content,
 	}
@@ -152,13 +177,20 @@ func handlePutDocumentContent(
 	userEmail string,
 	model *models.Document,
 ) {
-	// Authorize: only owner and contributors can edit content
-	if !isOwnerOrContributor(userEmail, model) {
+	// Authorize: only owners, contributors, and admins can edit content.
+	if !authorizeRequest(w, r, srv.Config, srv.DB, srv.Logger,
+		authz.ActionDocumentEditContent, documentModelAuthzResource(*model),
+		"Unauthorized",
+	) {
 		srv.Logger.Warn("unauthorized document content update attempt",
 			"user", userEmail,
 			"doc_id", docID,
 		)
-		http.Error(w, "Unauthorized", http.StatusForbidden)
+		return
+	}
+
+	// Don't continue if the document is locked by another user.
+	if !checkDocumentLock(w, r, srv, model.ID, userEmail) {
 		return
 	}
 
@@ -171,11 +203,13 @@ func handlePutDocumentContent(
 				"error", err,
 				"doc_id", docID,
 			)
-			http.Error(w, "Error getting document status", http.StatusInternalServerError)
+			writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Error getting document status")
 			return
 		}
 		if locked {
-			http.Error(w, "Document is locked", http.StatusLocked)
+			writeProblem(w, r, http.StatusLocked, ErrCodeDocumentLocked,
+				"Document is locked")
 			return
 		}
 	}
@@ -187,28 +221,52 @@ func handlePutDocumentContent(
 			"error", err,
 			"doc_id", docID,
 		)
-		http.Error(w, "Bad request", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest, "Bad request")
 		return
 	}
 
 	// Use RFC-084 UpdateContent method
-	providerID := fmt.Sprintf("google:%s", docID)
+	provider, providerID, err := documentContentProvider(r.Context(), srv, model, docID)
+	if err != nil {
+		srv.Logger.Error("error resolving document provider",
+			"error", err,
+			"doc_id", docID,
+		)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"Error updating document content")
+		return
+	}
 
-	// Check if this is a local workspace provider
-	if _, ok := srv.WorkspaceProvider.(*local.WorkspaceAdapter); ok {
-		providerID = fmt.Sprintf("local:%s", docID)
-	} else if _, ok := srv.WorkspaceProvider.(*local.ProviderAdapter); ok {
-		providerID = fmt.Sprintf("local:%s", docID)
+	// Evaluate If-Match precondition against the current content to prevent
+	// lost updates from concurrent editors.
+	if r.Header.Get("If-Match") != "" {
+		current, err := provider.GetContent(r.Context(), providerID)
+		if err != nil {
+			srv.Logger.Error("error getting document content for precondition",
+				"error", err,
+				"doc_id", docID,
+			)
+			writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Error updating document content")
+			return
+		}
+		if checkIfMatch(w, r, contentETag(current)) {
+			srv.Logger.Warn("document content update precondition failed",
+				"doc_id", docID,
+				"user", userEmail,
+			)
+			return
+		}
 	}
 
-	_, err := srv.WorkspaceProvider.UpdateContent(r.Context(), providerID, req.Content)
+	updated, err := provider.UpdateContent(r.Context(), providerID, req.Content)
 	if err != nil {
 		srv.Logger.Error("error updating document content",
 			"error", err,
 			"doc_id", docID,
 		)
-		http.Error(w, "Error updating document content",
-			http.StatusInternalServerError)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"Error updating document content")
 		return
 	}
 
@@ -220,6 +278,9 @@ func handlePutDocumentContent(
 	// Note: Re-indexing for search happens via the background indexer service
 
 	// Return success
+	if updated != nil {
+		w.Header().Set("ETag", contentETag(updated))
+	}
 	w.WriteHeader(http.StatusOK)
 	resp := map[string]string{"status": "success"}
 	if err := json.NewEncoder(w).Encode(resp); err != nil {
@@ -230,6 +291,11 @@ func handlePutDocumentContent(
 	}
 }
 
+// contentETag returns the entity tag for document content.
+func contentETag(c *workspace.DocumentContent) string {
+	return computeETag([]byte(c.Body), c.LastModified)
+}
+
 // parseDocumentContentURLPath extracts the document ID from /api/v2/documents/:id/content
 func parseDocumentContentURLPath(path string) (string, error) {
 	re := regexp.MustCompile(`^/api/v2/documents/([0-9A-Za-z_\-]+)/content$`)
@@ -240,21 +306,19 @@ func parseDocumentContentURLPath(path string) (string, error) {
 	return matches[1], nil
 }
 
-// isOwnerOrContributor checks if the user is the owner or a contributor
-func isOwnerOrContributor(userEmail string, doc *models.Document) bool {
-	// Check owner
-	if doc.Owner != nil && doc.Owner.EmailAddress == userEmail {
-		return true
-	}
-
-	// Check contributors
-	for _, contributor := range doc.Contributors {
-		if contributor.EmailAddress == userEmail {
-			return true
-		}
+// contentProviderID returns the RFC-084 provider ID used to read and write a
+// document's content with the configured workspace provider.
+func contentProviderID(srv server.Server, docID string) string {
+	// Check if this is a local workspace provider
+	provider := workspace.Unwrap(srv.WorkspaceProvider)
+	if _, ok := provider.(*local.WorkspaceAdapter); ok {
+		return fmt.Sprintf("local:%s", docID)
+	} else if _, ok := provider.(*local.ProviderAdapter); ok {
+		return fmt.Sprintf("local:%s", docID)
 	}
 
-	return false
+	// Assume Google for now, adjust as needed
+	return fmt.Sprintf("google:%s", docID)
 }
 
 // extractTextFromGoogleDoc extracts plain text content from a Google Docs document
@@ -0,0 +1,194 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/pkg/migration"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp-forge/hermes/pkg/workspace"
+)
+
+// asOfRevisionLimit is the maximum number of revisions considered when
+// resolving content at a point in time.
+const asOfRevisionLimit = 1000
+
+// DocumentContentRevision identifies the archived revision content was read
+// from for a point-in-time (asOf) request.
+type DocumentContentRevision struct {
+	AsOf         time.Time `json:"asOf"`
+	Provider     string    `json:"provider,omitempty"`
+	ProviderType string    `json:"providerType"`
+	RevisionID   string    `json:"revisionId"`
+	ModifiedTime time.Time `json:"modifiedTime"`
+	KeepForever  bool      `json:"keepForever"`
+}
+
+// handleGetDocumentContentAsOf handles GET requests for document content as it
+// was at a point in time (GET /api/v2/documents/:id/content?asOf=<timestamp>).
+// The document's migration history determines which provider held it at that
+// time, and the newest revision in that provider at or before the timestamp
+// (Drive revisions, S3 versions, local snapshots) is returned.
+func handleGetDocumentContentAsOf(
+	w http.ResponseWriter,
+	r *http.Request,
+	srv server.Server,
+	docID string,
+	model *models.Document,
+	asOfParam string,
+) {
+	asOf, err := parseAsOf(asOfParam)
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			"asOf must be an RFC 3339 timestamp or Unix seconds")
+		return
+	}
+
+	providerName := ""
+	provider, providerID, err := documentContentProvider(r.Context(), srv, model, docID)
+	if err != nil {
+		srv.Logger.Error("error resolving document provider",
+			"error", err,
+			"doc_id", docID,
+		)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"Error retrieving document content")
+		return
+	}
+
+	// Find where the document was stored at that time if it has been
+	// migrated between providers since.
+	if model.DocumentUUID != nil {
+		loc, err := documentLocationAt(r, srv, model, asOf)
+		if err != nil {
+			srv.Logger.Error("error getting document migration history",
+				"error", err,
+				"doc_id", docID,
+			)
+			writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Error retrieving document content")
+			return
+		}
+		if loc != nil {
+			p, ok := srv.StorageProviders[loc.Provider]
+			if !ok {
+				writeProblem(w, r, http.StatusUnprocessableEntity,
+					ErrCodeUnprocessable,
+					fmt.Sprintf("Document was stored in provider %q at the requested "+
+						"time, which is not configured on this server", loc.Provider))
+				return
+			}
+			providerName, provider, providerID = loc.Provider, p, loc.ProviderID
+		}
+	}
+
+	revs, err := provider.GetRevisionHistory(r.Context(), providerID,
+		asOfRevisionLimit)
+	if err != nil {
+		srv.Logger.Error("error getting document revision history",
+			"error", err,
+			"doc_id", docID,
+			"provider_id", providerID,
+		)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"Error retrieving document revisions")
+		return
+	}
+
+	rev := revisionAsOf(revs, asOf)
+	if rev == nil {
+		writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound,
+			"No revision of the document exists at the requested time")
+		return
+	}
+
+	docContent, err := provider.GetRevisionContent(r.Context(), providerID,
+		rev.RevisionID)
+	if err != nil {
+		srv.Logger.Error("error getting document revision content",
+			"error", err,
+			"doc_id", docID,
+			"provider_id", providerID,
+			"revision_id", rev.RevisionID,
+		)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"Error retrieving document content")
+		return
+	}
+
+	// Archived content never changes, so its ETag is stable.
+	if checkIfNoneMatch(w, r, contentETag(docContent)) {
+		return
+	}
+
+	resp := DocumentContentResponse{
+		Content: docContent.Body,
+		Revision: &DocumentContentRevision{
+			AsOf:         asOf,
+			Provider:     providerName,
+			ProviderType: rev.ProviderType,
+			RevisionID:   rev.RevisionID,
+			ModifiedTime: rev.ModifiedTime,
+			KeepForever:  rev.KeepForever,
+		},
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		srv.Logger.Error("error encoding document content response",
+			"error", err,
+			"doc_id", docID,
+		)
+	}
+}
+
+// documentLocationAt returns where a document was stored at time t, or nil if
+// it has always been stored with the configured workspace provider.
+func documentLocationAt(
+	r *http.Request, srv server.Server, model *models.Document, t time.Time,
+) (*migration.Location, error) {
+	sqlDB, err := getSQLDB(srv)
+	if err != nil {
+		return nil, err
+	}
+	history, err := newMigrationManager(srv, sqlDB).
+		GetDocumentHistory(r.Context(), *model.DocumentUUID)
+	if err != nil {
+		return nil, err
+	}
+	return migration.LocationAt(history, t), nil
+}
+
+// parseAsOf parses an asOf query parameter as an RFC 3339 timestamp or Unix
+// seconds.
+func parseAsOf(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t, nil
+	}
+	secs, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timestamp %q", s)
+	}
+	return time.Unix(secs, 0).UTC(), nil
+}
+
+// revisionAsOf returns the newest revision created at or before t, or nil if
+// the document had no revisions yet. Revisions may be in any order.
+func revisionAsOf(
+	revs []*workspace.BackendRevision, t time.Time,
+) *workspace.BackendRevision {
+	var best *workspace.BackendRevision
+	for _, rev := range revs {
+		if rev == nil || rev.ModifiedTime.After(t) {
+			continue
+		}
+		if best == nil || rev.ModifiedTime.After(best.ModifiedTime) {
+			best = rev
+		}
+	}
+	return best
+}
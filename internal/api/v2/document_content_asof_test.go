@@ -0,0 +1,49 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAsOf(t *testing.T) {
+	want := time.Date(2025, 3, 14, 9, 26, 53, 0, time.UTC)
+
+	got, err := parseAsOf("2025-03-14T09:26:53Z")
+	require.NoError(t, err)
+	assert.True(t, want.Equal(got))
+
+	got, err = parseAsOf("2025-03-14T10:26:53+01:00")
+	require.NoError(t, err)
+	assert.True(t, want.Equal(got))
+
+	got, err = parseAsOf("1741944413")
+	require.NoError(t, err)
+	assert.True(t, want.Equal(got))
+
+	_, err = parseAsOf("yesterday")
+	assert.Error(t, err)
+}
+
+func TestRevisionAsOf(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	rev := func(id string, offset time.Duration) *workspace.BackendRevision {
+		return &workspace.BackendRevision{RevisionID: id, ModifiedTime: t0.Add(offset)}
+	}
+	// Providers list revisions oldest or newest first.
+	revs := []*workspace.BackendRevision{
+		rev("3", 3*time.Hour),
+		rev("1", time.Hour),
+		nil,
+		rev("2", 2*time.Hour),
+	}
+
+	assert.Nil(t, revisionAsOf(revs, t0), "before first revision")
+	assert.Equal(t, "1", revisionAsOf(revs, t0.Add(time.Hour)).RevisionID)
+	assert.Equal(t, "2", revisionAsOf(revs, t0.Add(150*time.Minute)).RevisionID)
+	assert.Equal(t, "3", revisionAsOf(revs, t0.Add(48*time.Hour)).RevisionID)
+	assert.Nil(t, revisionAsOf(nil, t0))
+}
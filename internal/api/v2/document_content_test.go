@@ -9,6 +9,7 @@ import (
 
 	"github.com/hashicorp-forge/hermes/internal/config"
 	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/pkg/authz"
 	"github.com/hashicorp-forge/hermes/pkg/models"
 	"github.com/hashicorp-forge/hermes/pkg/workspace"
 	"github.com/hashicorp-forge/hermes/pkg/workspace/adapters/mock"
@@ -164,7 +165,7 @@ func TestParseDocumentContentURLPath(t *testing.T) {
 	}
 }
 
-func TestIsOwnerOrContributor(t *testing.T) {
+func TestDocumentContentEditAuthorization(t *testing.T) {
 	tests := []struct {
 		name     string
 		email    string
@@ -213,7 +214,11 @@ func TestIsOwnerOrContributor(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := isOwnerOrContributor(tt.email, tt.doc)
+			result := authz.Authorize(
+				authz.Principal{Email: tt.email},
+				authz.ActionDocumentEditContent,
+				documentModelAuthzResource(*tt.doc),
+			) == nil
 			if result != tt.expected {
 				t.Errorf("Expected %v, got %v", tt.expected, result)
 			}
@@ -0,0 +1,142 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/pkg/export"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"gorm.io/gorm"
+)
+
+// exportCache caches rendered exports keyed by content hash and format.
+var exportCache = export.NewCache(256, 64<<20)
+
+// DocumentExportHandler handles requests to export a document.
+// GET /api/v2/documents/:id/export?format=md|html|pdf|docx
+//
+// Provider content (Markdown or Google Doc text) is converted to the
+// requested format server-side. Rendered artifacts are cached by content hash,
+// which is also used as the ETag so clients can revalidate cheaply.
+func DocumentExportHandler(srv server.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
+		if r.Method != "GET" {
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
+			return
+		}
+
+		docID, err := parseDocumentExportURLPath(r.URL.Path)
+		if err != nil {
+			srv.Logger.Error("error parsing document export URL path",
+				"error", err,
+				"path", r.URL.Path,
+				"method", r.Method,
+			)
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				"Bad request")
+			return
+		}
+
+		format, err := export.ParseFormat(r.URL.Query().Get("format"))
+		if err != nil {
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				fmt.Sprintf("Bad request: %v", err))
+			return
+		}
+
+		// Get document from database to verify it exists.
+		model := models.Document{}
+		if err := model.GetByGoogleFileIDOrUUID(srv.DB, docID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				writeProblem(w, r, http.StatusNotFound, ErrCodeDocumentNotFound,
+					"Document not found")
+				return
+			}
+			respondError(w, r, srv.Logger, http.StatusInternalServerError,
+				"Error requesting document",
+				"error getting document from database", err,
+				"doc_id", docID,
+			)
+			return
+		}
+
+		provider, providerID, err := documentContentProvider(
+			r.Context(), srv, &model, model.GoogleFileID)
+		if err != nil {
+			respondError(w, r, srv.Logger, http.StatusInternalServerError,
+				"Error retrieving document content",
+				"error resolving document provider for export", err,
+				"doc_id", docID,
+			)
+			return
+		}
+		content, err := provider.GetContent(r.Context(), providerID)
+		if err != nil {
+			respondError(w, r, srv.Logger, http.StatusInternalServerError,
+				"Error retrieving document content",
+				"error getting document content for export", err,
+				"doc_id", docID,
+			)
+			return
+		}
+		if content.Title == "" {
+			content.Title = model.Title
+		}
+
+		key := export.CacheKey(model.GoogleFileID, content, format)
+		if checkIfNoneMatch(w, r, strconv.Quote(key)) {
+			return
+		}
+
+		data, ok := exportCache.Get(key)
+		if !ok {
+			data, err = export.Render(content, format)
+			if err != nil {
+				respondError(w, r, srv.Logger, http.StatusInternalServerError,
+					"Error exporting document",
+					"error rendering document export", err,
+					"doc_id", docID,
+					"format", format,
+				)
+				return
+			}
+			exportCache.Put(key, data)
+		}
+
+		w.Header().Set("Content-Type", format.ContentType())
+		w.Header().Set("Content-Disposition", fmt.Sprintf(
+			"attachment; filename=%q", export.Filename(model.Title, format)))
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write(data); err != nil {
+			srv.Logger.Error("error writing document export response",
+				"error", err,
+				"doc_id", docID,
+			)
+			return
+		}
+
+		srv.Logger.Info("exported document",
+			"doc_id", docID,
+			"format", format,
+			"cache_hit", ok,
+		)
+	})
+}
+
+// parseDocumentExportURLPath parses the document ID from a document export
+// API URL path.
+func parseDocumentExportURLPath(path string) (string, error) {
+	re := regexp.MustCompile(`^/api/v2/documents/([0-9A-Za-z_\-]+)/export$`)
+	matches := re.FindStringSubmatch(path)
+	if len(matches) != 2 {
+		return "", fmt.Errorf("invalid document export URL path")
+	}
+	return matches[1], nil
+}
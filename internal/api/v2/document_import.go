@@ -0,0 +1,569 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp-forge/hermes/internal/server"
+	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+	"github.com/hashicorp-forge/hermes/pkg/docid"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp-forge/hermes/pkg/search"
+	"github.com/hashicorp-forge/hermes/pkg/tenant"
+	"github.com/hashicorp-forge/hermes/pkg/workspace"
+	"gorm.io/gorm"
+)
+
+// maxImportFileSize is the maximum size of an uploaded import file.
+const maxImportFileSize = 10 << 20 // 10 MiB
+
+// googleDocURLRE matches the file ID in Google Docs and Drive URLs
+// (e.g., https://docs.google.com/document/d/<id>/edit).
+var googleDocURLRE = regexp.MustCompile(`/(?:document/)?d/([a-zA-Z0-9_-]{10,})`)
+
+// DocumentImportRequest is the request to import an existing document.
+// Exactly one of GoogleDocURL or Markdown must be set. For multipart uploads,
+// the request fields are sent as JSON in the "metadata" form field and the
+// uploaded "file" part is used as the Markdown content.
+type DocumentImportRequest struct {
+	GoogleDocURL string   `json:"googleDocUrl,omitempty"`
+	Markdown     string   `json:"markdown,omitempty"`
+	Approvers    []string `json:"approvers,omitempty"`
+	Contributors []string `json:"contributors,omitempty"`
+	DocType      string   `json:"docType"`
+	Product      string   `json:"product"`
+	Summary      string   `json:"summary,omitempty"`
+	Title        string   `json:"title,omitempty"`
+
+	// RequestReview publishes the imported document for review, which assigns
+	// its document number.
+	RequestReview bool `json:"requestReview,omitempty"`
+}
+
+// DocumentImportResponse is the response to a document import request.
+type DocumentImportResponse struct {
+	ID        string `json:"id"`
+	UUID      string `json:"uuid"`
+	DocNumber string `json:"docNumber"`
+	Status    string `json:"status"`
+}
+
+// DocumentImportHandler handles requests to import existing documents.
+// POST /api/v2/documents/import
+//
+// A Google Doc is registered in place and moved to the drafts folder. Markdown
+// (sent inline or uploaded as a file) is written to a new draft created from
+// the document type's template. Either way the document is assigned a UUID,
+// recorded in the database as an imported WIP document, and indexed as a
+// draft. If RequestReview is set, the document is then published for review.
+func DocumentImportHandler(srv server.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
+		if r.Method != "POST" {
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
+			return
+		}
+
+		userEmail := pkgauth.MustGetUserEmail(r.Context())
+		if userEmail == "" {
+			writeProblem(w, r, http.StatusUnauthorized, ErrCodeUnauthorized,
+				"No authorization information for request")
+			return
+		}
+
+		req, err := decodeDocumentImportRequest(r)
+		if err != nil {
+			srv.Logger.Error("error decoding document import request", "error", err)
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				fmt.Sprintf("Bad request: %v", err))
+			return
+		}
+		if err := validateDocumentImportRequest(req); err != nil {
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				fmt.Sprintf("Bad request: %v", err))
+			return
+		}
+		if !validateDocType(documentTypes(r.Context(), srv), req.DocType) {
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				"Bad request: invalid document type")
+			return
+		}
+
+		product := models.Product{Name: req.Product}
+		if err := product.Get(srv.DB); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					"Bad request: invalid product")
+				return
+			}
+			respondError(w, r, srv.Logger, http.StatusInternalServerError,
+				"Error importing document",
+				"error getting product", err,
+				"product", req.Product,
+			)
+			return
+		}
+
+		providerName := primaryProviderType(srv)
+		destFolderID, err := folderLayout(srv).DraftsFolder(r.Context())
+		if err != nil {
+			respondError(w, r, srv.Logger, http.StatusInternalServerError,
+				"Error importing document",
+				"error getting drafts folder", err,
+			)
+			return
+		}
+
+		// Undo completed steps if a later step fails.
+		cleanup := newSaga("import document", srv.Logger)
+		defer cleanup.Compensate(r.Context())
+
+		var docMeta *workspace.DocumentMetadata
+		if req.GoogleDocURL != "" {
+			docMeta, err = importGoogleDoc(r.Context(), srv, req, providerName, destFolderID, cleanup)
+		} else {
+			docMeta, err = importMarkdown(r.Context(), srv, req, providerName, destFolderID, cleanup)
+		}
+		if err != nil {
+			var ie *importError
+			if errors.As(err, &ie) {
+				writeProblem(w, r, ie.status, errorCodeForStatus(ie.status), ie.msg)
+				return
+			}
+			respondError(w, r, srv.Logger, http.StatusInternalServerError,
+				"Error importing document",
+				"error importing document into workspace provider", err,
+			)
+			return
+		}
+
+		fileID := docMeta.ProviderID
+		if idx := strings.Index(fileID, ":"); idx != -1 {
+			fileID = fileID[idx+1:]
+		}
+		if req.Title == "" {
+			req.Title = docMeta.Name
+		}
+
+		// Assign a UUID, keeping one the provider already tracks.
+		docUUID := docMeta.UUID
+		if docUUID.IsZero() {
+			docUUID = docid.NewUUID()
+			docMeta.UUID = docUUID
+			if _, err := srv.WorkspaceProvider.RegisterDocument(r.Context(), docMeta); err != nil {
+				// The UUID is still recorded in the database.
+				srv.Logger.Warn("error registering document UUID with workspace provider",
+					"error", err,
+					"doc_id", fileID,
+				)
+			}
+		}
+
+		// Create document in the database.
+		var approvers, contributors []*models.User
+		for _, a := range req.Approvers {
+			approvers = append(approvers, &models.User{EmailAddress: a})
+		}
+		for _, c := range req.Contributors {
+			contributors = append(contributors, &models.User{EmailAddress: c})
+		}
+		providerType := providerName
+		model := models.Document{
+			GoogleFileID:       fileID,
+			DocumentUUID:       &docUUID,
+			ProviderType:       &providerType,
+			ProviderDocumentID: &docMeta.ProviderID,
+			Approvers:          approvers,
+			Contributors:       contributors,
+			DocumentCreatedAt:  docMeta.CreatedTime,
+			DocumentModifiedAt: docMeta.ModifiedTime,
+			DocumentType: models.DocumentType{
+				Name: req.DocType,
+			},
+			Imported: true,
+			Owner: &models.User{
+				EmailAddress: userEmail,
+			},
+			Product: models.Product{
+				Name: req.Product,
+			},
+			Status:   models.WIPDocumentStatus,
+			Summary:  &req.Summary,
+			TenantID: tenant.IDFromContext(r.Context()),
+			Title:    req.Title,
+		}
+		if err := model.Create(srv.DB); err != nil {
+			respondError(w, r, srv.Logger, http.StatusInternalServerError,
+				"Error importing document",
+				"error creating imported document in database", err,
+				"doc_id", fileID,
+			)
+			return
+		}
+		cleanup.AddCompensation("delete imported document database record", func(ctx context.Context) error {
+			return model.Purge(srv.DB.WithContext(ctx))
+		})
+
+		// Share document with the owner and contributors. Sharing isn't supported
+		// by the local workspace provider.
+		if providerName != "local" {
+			for _, email := range append([]string{userEmail}, req.Contributors...) {
+				if err := srv.WorkspaceProvider.ShareDocument(
+					r.Context(), docMeta.ProviderID, email, "writer",
+				); err != nil {
+					respondError(w, r, srv.Logger, http.StatusInternalServerError,
+						"Error importing document",
+						"error sharing imported document", err,
+						"doc_id", fileID,
+						"email", email,
+					)
+					return
+				}
+			}
+		}
+
+		// Index the document as a draft.
+		docNumber := fmt.Sprintf("%s-???", product.Abbreviation)
+		searchDoc := &search.Document{
+			ObjectID:     fileID,
+			DocID:        fileID,
+			Title:        req.Title,
+			DocNumber:    docNumber,
+			DocType:      req.DocType,
+			Product:      req.Product,
+			Status:       "WIP",
+			Owners:       []string{userEmail},
+			Contributors: req.Contributors,
+			Approvers:    req.Approvers,
+			Summary:      req.Summary,
+			Content:      req.Markdown,
+			CreatedTime:  docMeta.CreatedTime.Unix(),
+			ModifiedTime: docMeta.ModifiedTime.Unix(),
+		}
+		if err := srv.SearchProvider.DraftIndex().Index(r.Context(), searchDoc); err != nil {
+			respondError(w, r, srv.Logger, http.StatusInternalServerError,
+				"Error importing document",
+				"error indexing imported document", err,
+				"doc_id", fileID,
+			)
+			return
+		}
+		cleanup.AddCompensation("delete imported document search object", func(ctx context.Context) error {
+			err := srv.SearchProvider.DraftIndex().Delete(ctx, fileID)
+			if errors.Is(err, search.ErrNotFound) {
+				return nil
+			}
+			return err
+		})
+
+		// The document is imported; a failed review request leaves it as a draft.
+		cleanup.Complete()
+
+		resp := DocumentImportResponse{
+			ID:        fileID,
+			UUID:      docUUID.String(),
+			DocNumber: docNumber,
+			Status:    "WIP",
+		}
+		if req.RequestReview {
+			if err := requestImportReview(r, srv, fileID); err != nil {
+				srv.Logger.Error("error requesting review for imported document",
+					"error", err,
+					"doc_id", fileID,
+				)
+				writeProblem(w, r, http.StatusUnprocessableEntity, ErrCodeUnprocessable,
+					fmt.Sprintf("Document %s was imported as a draft, but review could not be requested", fileID))
+				return
+			}
+
+			// Get the document number assigned by the review.
+			reviewed := models.Document{GoogleFileID: fileID}
+			if err := reviewed.Get(srv.DB); err != nil {
+				srv.Logger.Error("error getting reviewed document from database",
+					"error", err,
+					"doc_id", fileID,
+				)
+			} else {
+				resp.DocNumber = fmt.Sprintf("%s-%03d",
+					product.Abbreviation, reviewed.DocumentNumber)
+				resp.Status = "In-Review"
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			srv.Logger.Error("error encoding document import response",
+				"error", err,
+				"doc_id", fileID,
+			)
+			return
+		}
+
+		srv.Logger.Info("imported document",
+			"doc_id", fileID,
+			"document_uuid", docUUID.String(),
+			"source", importSourceName(req),
+			"review_requested", req.RequestReview,
+		)
+	})
+}
+
+// importError is an import failure caused by the request, with the HTTP
+// status and message to return to the client.
+type importError struct {
+	status int
+	msg    string
+}
+
+func (e *importError) Error() string { return e.msg }
+
+// importGoogleDoc registers an existing Google Doc in place and moves it to
+// the drafts folder.
+func importGoogleDoc(
+	ctx context.Context,
+	srv server.Server,
+	req *DocumentImportRequest,
+	providerName, destFolderID string,
+	cleanup *saga,
+) (*workspace.DocumentMetadata, error) {
+	if providerName != "google" {
+		return nil, &importError{http.StatusBadRequest,
+			"Bad request: Google Docs can only be imported with the Google workspace provider"}
+	}
+
+	fileID, err := parseGoogleDocURL(req.GoogleDocURL)
+	if err != nil {
+		return nil, &importError{http.StatusBadRequest, fmt.Sprintf("Bad request: %v", err)}
+	}
+
+	existing := models.Document{GoogleFileID: fileID}
+	if err := existing.Get(srv.DB); err == nil {
+		return nil, &importError{http.StatusConflict, "Document has already been imported"}
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("error checking for existing document: %w", err)
+	}
+
+	providerID := "google:" + fileID
+	docMeta, err := srv.WorkspaceProvider.GetDocument(ctx, providerID)
+	if err != nil {
+		return nil, &importError{http.StatusNotFound,
+			"Google Doc not found or not shared with Hermes"}
+	}
+
+	moved, err := srv.WorkspaceProvider.MoveDocument(ctx, providerID, destFolderID)
+	if err != nil {
+		return nil, fmt.Errorf("error moving document to drafts folder: %w", err)
+	}
+	if len(docMeta.Parents) > 0 {
+		origFolderID := docMeta.Parents[0]
+		cleanup.AddCompensation("move imported document back", func(ctx context.Context) error {
+			_, err := srv.WorkspaceProvider.MoveDocument(ctx, providerID, origFolderID)
+			return err
+		})
+	}
+	if moved != nil {
+		docMeta = moved
+	}
+
+	return docMeta, nil
+}
+
+// importMarkdown creates a draft from the document type's template and
+// replaces its content with the imported Markdown.
+func importMarkdown(
+	ctx context.Context,
+	srv server.Server,
+	req *DocumentImportRequest,
+	providerName, destFolderID string,
+	cleanup *saga,
+) (*workspace.DocumentMetadata, error) {
+	if req.Title == "" {
+		req.Title = markdownTitle(req.Markdown)
+	}
+	if req.Title == "" {
+		return nil, &importError{http.StatusBadRequest, "Bad request: title is required"}
+	}
+
+	template := getDocTypeTemplate(documentTypes(ctx, srv), req.DocType)
+	if template == "" {
+		return nil, &importError{http.StatusBadRequest,
+			"Bad request: no template configured for doc type"}
+	}
+
+	docMeta, err := srv.WorkspaceProvider.CopyDocument(ctx,
+		fmt.Sprintf("%s:%s", providerName, template), destFolderID, req.Title)
+	if err != nil {
+		return nil, fmt.Errorf("error creating document from template: %w", err)
+	}
+	cleanup.AddCompensation("delete imported document file", func(ctx context.Context) error {
+		return srv.WorkspaceProvider.DeleteDocument(ctx, docMeta.ProviderID)
+	})
+
+	if _, err := srv.WorkspaceProvider.UpdateContent(ctx, docMeta.ProviderID, req.Markdown); err != nil {
+		return nil, fmt.Errorf("error writing imported content: %w", err)
+	}
+
+	return docMeta, nil
+}
+
+// requestImportReview publishes an imported document for review by invoking
+// the reviews handler on behalf of the current user.
+func requestImportReview(r *http.Request, srv server.Server, fileID string) error {
+	reviewReq, err := http.NewRequestWithContext(r.Context(),
+		http.MethodPost, "/api/v2/reviews/"+fileID, nil)
+	if err != nil {
+		return err
+	}
+
+	rw := newBufferedResponseWriter()
+	ReviewsHandler(srv).ServeHTTP(rw, reviewReq)
+	if rw.status >= 300 {
+		return fmt.Errorf("review request failed with status %d: %s",
+			rw.status, strings.TrimSpace(rw.body.String()))
+	}
+	return nil
+}
+
+// decodeDocumentImportRequest decodes a JSON or multipart/form-data import
+// request.
+func decodeDocumentImportRequest(r *http.Request) (*DocumentImportRequest, error) {
+	var req DocumentImportRequest
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "multipart/form-data" {
+		if err := decodeRequest(r, &req); err != nil {
+			return nil, err
+		}
+		return &req, nil
+	}
+
+	r.Body = http.MaxBytesReader(nil, r.Body, maxImportFileSize+1<<20)
+	if err := r.ParseMultipartForm(maxImportFileSize); err != nil {
+		return nil, fmt.Errorf("error parsing multipart form: %w", err)
+	}
+
+	if md := r.FormValue("metadata"); md != "" {
+		dec := json.NewDecoder(strings.NewReader(md))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&req); err != nil {
+			return nil, fmt.Errorf("error decoding metadata: %w", err)
+		}
+	}
+
+	f, _, err := r.FormFile("file")
+	if err != nil {
+		if errors.Is(err, http.ErrMissingFile) {
+			return &req, nil
+		}
+		return nil, fmt.Errorf("error reading file: %w", err)
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, maxImportFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("error reading file: %w", err)
+	}
+	if len(data) > maxImportFileSize {
+		return nil, fmt.Errorf("file is larger than %d bytes", maxImportFileSize)
+	}
+	if req.Markdown != "" {
+		return nil, fmt.Errorf("markdown and file cannot both be provided")
+	}
+	req.Markdown = string(data)
+
+	return &req, nil
+}
+
+// validateDocumentImportRequest validates fields that don't require the
+// server configuration.
+func validateDocumentImportRequest(req *DocumentImportRequest) error {
+	switch {
+	case req.GoogleDocURL == "" && strings.TrimSpace(req.Markdown) == "":
+		return fmt.Errorf("one of googleDocUrl, markdown, or file is required")
+	case req.GoogleDocURL != "" && req.Markdown != "":
+		return fmt.Errorf("only one of googleDocUrl, markdown, or file may be provided")
+	case req.DocType == "":
+		return fmt.Errorf("docType is required")
+	case req.Product == "":
+		return fmt.Errorf("product is required")
+	}
+	return nil
+}
+
+// importSourceName returns the kind of source being imported, for logging.
+func importSourceName(req *DocumentImportRequest) string {
+	if req.GoogleDocURL != "" {
+		return "google"
+	}
+	return "markdown"
+}
+
+// parseGoogleDocURL returns the file ID from a Google Docs or Drive URL.
+func parseGoogleDocURL(s string) (string, error) {
+	u, err := url.Parse(s)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") {
+		return "", fmt.Errorf("invalid Google Doc URL")
+	}
+	if u.Host != "docs.google.com" && u.Host != "drive.google.com" {
+		return "", fmt.Errorf("URL is not a Google Docs or Drive URL")
+	}
+
+	if m := googleDocURLRE.FindStringSubmatch(u.Path); m != nil {
+		return m[1], nil
+	}
+	// Drive "open" links: https://drive.google.com/open?id=<id>
+	if id := u.Query().Get("id"); id != "" {
+		return id, nil
+	}
+
+	return "", fmt.Errorf("URL does not contain a Google Doc ID")
+}
+
+// markdownTitle returns the text of the first level 1 heading in Markdown
+// content, if any.
+func markdownTitle(md string) string {
+	for _, line := range strings.Split(md, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "# ") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "# "))
+		}
+	}
+	return ""
+}
+
+// bufferedResponseWriter captures a response in memory.
+type bufferedResponseWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func newBufferedResponseWriter() *bufferedResponseWriter {
+	return &bufferedResponseWriter{header: make(http.Header)}
+}
+
+func (rw *bufferedResponseWriter) Header() http.Header { return rw.header }
+
+func (rw *bufferedResponseWriter) WriteHeader(status int) {
+	if rw.status == 0 {
+		rw.status = status
+	}
+}
+
+func (rw *bufferedResponseWriter) Write(b []byte) (int, error) {
+	if rw.status == 0 {
+		rw.status = http.StatusOK
+	}
+	return rw.body.Write(b)
+}
@@ -0,0 +1,152 @@
+package api
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGoogleDocURL(t *testing.T) {
+	cases := []struct {
+		name    string
+		url     string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "docs edit URL",
+			url:  "https://docs.google.com/document/d/1AbCdEfGhIjKlMnOp_-qr/edit",
+			want: "1AbCdEfGhIjKlMnOp_-qr",
+		},
+		{
+			name: "docs URL with account index",
+			url:  "https://docs.google.com/document/u/1/d/1AbCdEfGhIjKlMnOp_-qr/edit#heading=h.1",
+			want: "1AbCdEfGhIjKlMnOp_-qr",
+		},
+		{
+			name: "drive file URL",
+			url:  "https://drive.google.com/file/d/1AbCdEfGhIjKlMnOp_-qr/view",
+			want: "1AbCdEfGhIjKlMnOp_-qr",
+		},
+		{
+			name: "drive open URL",
+			url:  "https://drive.google.com/open?id=1AbCdEfGhIjKlMnOp_-qr",
+			want: "1AbCdEfGhIjKlMnOp_-qr",
+		},
+		{
+			name:    "other host",
+			url:     "https://example.com/document/d/1AbCdEfGhIjKlMnOp_-qr/edit",
+			wantErr: true,
+		},
+		{
+			name:    "missing ID",
+			url:     "https://docs.google.com/document/",
+			wantErr: true,
+		},
+		{
+			name:    "not a URL",
+			url:     "1AbCdEfGhIjKlMnOp_-qr",
+			wantErr: true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := parseGoogleDocURL(c.url)
+			if c.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, c.want, got)
+		})
+	}
+}
+
+func TestValidateDocumentImportRequest(t *testing.T) {
+	valid := DocumentImportRequest{
+		Markdown: "# Title",
+		DocType:  "RFC",
+		Product:  "Terraform",
+	}
+	assert.NoError(t, validateDocumentImportRequest(&valid))
+
+	noSource := valid
+	noSource.Markdown = "  "
+	assert.Error(t, validateDocumentImportRequest(&noSource))
+
+	twoSources := valid
+	twoSources.GoogleDocURL = "https://docs.google.com/document/d/1AbCdEfGhIjKlMnOp/edit"
+	assert.Error(t, validateDocumentImportRequest(&twoSources))
+
+	noDocType := valid
+	noDocType.DocType = ""
+	assert.Error(t, validateDocumentImportRequest(&noDocType))
+
+	noProduct := valid
+	noProduct.Product = ""
+	assert.Error(t, validateDocumentImportRequest(&noProduct))
+}
+
+func TestDecodeDocumentImportRequest(t *testing.T) {
+	t.Run("json", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/api/v2/documents/import", strings.NewReader(
+			`{"markdown":"# Hello","docType":"RFC","product":"Terraform","requestReview":true}`))
+		r.Header.Set("Content-Type", "application/json")
+
+		req, err := decodeDocumentImportRequest(r)
+		require.NoError(t, err)
+		assert.Equal(t, "# Hello", req.Markdown)
+		assert.Equal(t, "RFC", req.DocType)
+		assert.True(t, req.RequestReview)
+	})
+
+	t.Run("json with unknown field", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/api/v2/documents/import", strings.NewReader(
+			`{"markdown":"# Hello","bogus":1}`))
+		r.Header.Set("Content-Type", "application/json")
+
+		_, err := decodeDocumentImportRequest(r)
+		assert.Error(t, err)
+	})
+
+	t.Run("multipart upload", func(t *testing.T) {
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		require.NoError(t, mw.WriteField("metadata",
+			`{"docType":"PRD","product":"Vault","title":"Uploaded"}`))
+		fw, err := mw.CreateFormFile("file", "doc.md")
+		require.NoError(t, err)
+		fw.Write([]byte("# Uploaded\n\nBody"))
+		require.NoError(t, mw.Close())
+
+		r := httptest.NewRequest("POST", "/api/v2/documents/import", &body)
+		r.Header.Set("Content-Type", mw.FormDataContentType())
+
+		req, err := decodeDocumentImportRequest(r)
+		require.NoError(t, err)
+		assert.Equal(t, "PRD", req.DocType)
+		assert.Equal(t, "Uploaded", req.Title)
+		assert.Equal(t, "# Uploaded\n\nBody", req.Markdown)
+	})
+}
+
+func TestMarkdownTitle(t *testing.T) {
+	assert.Equal(t, "My RFC", markdownTitle("---\nfoo: bar\n---\n\n# My RFC\n\n## Background"))
+	assert.Equal(t, "", markdownTitle("## Only a subheading"))
+}
+
+func TestBufferedResponseWriter(t *testing.T) {
+	rw := newBufferedResponseWriter()
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(422)
+	rw.Write([]byte("nope"))
+
+	assert.Equal(t, 422, rw.status)
+	assert.Equal(t, "nope", rw.body.String())
+	assert.Equal(t, "application/json", rw.Header().Get("Content-Type"))
+}
@@ -0,0 +1,255 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/server"
+	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+	"github.com/hashicorp-forge/hermes/pkg/authz"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"gorm.io/gorm"
+)
+
+// Document lock TTLs.
+const (
+	defaultDocumentLockTTL = 15 * time.Minute
+	maxDocumentLockTTL     = 8 * time.Hour
+)
+
+// documentLockURLPathRE matches document lock URL paths.
+var documentLockURLPathRE = regexp.MustCompile(
+	`^/api/v2/documents/([0-9A-Za-z_\-]+)/lock$`)
+
+// DocumentLockPostRequest is the request to acquire or renew a document lock.
+type DocumentLockPostRequest struct {
+	// TTLSeconds is how long the lock is held unless it is released or renewed
+	// earlier. The default is 15 minutes and the maximum is 8 hours.
+	TTLSeconds int `json:"ttlSeconds,omitempty"`
+
+	// Reason is why the document is locked, shown to other users.
+	Reason string `json:"reason,omitempty"`
+}
+
+// DocumentLock is the lock on editing a document.
+type DocumentLock struct {
+	Locked     bool       `json:"locked"`
+	Owner      string     `json:"owner,omitempty"`
+	Reason     string     `json:"reason,omitempty"`
+	AcquiredAt *time.Time `json:"acquiredAt,omitempty"`
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty"`
+}
+
+// DocumentLockHandler handles the lock on editing a document.
+// GET    /api/v2/documents/:id/lock - gets the lock
+// POST   /api/v2/documents/:id/lock - acquires or renews the lock
+// DELETE /api/v2/documents/:id/lock - releases the lock
+//
+// Locks are advisory locks held by a user until they're released or expire.
+// While a document is locked, requests of other users to patch the document or
+// update its content are rejected for all workspace providers. Users who can
+// edit the document's metadata (e.g., owners and admins) can release locks
+// held by other users.
+func DocumentLockHandler(srv server.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
+		matches := documentLockURLPathRE.FindStringSubmatch(r.URL.Path)
+		if len(matches) != 2 {
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				"Bad request")
+			return
+		}
+		docID := matches[1]
+
+		// Get document from database.
+		model := models.Document{}
+		if err := model.GetByGoogleFileIDOrUUID(srv.DB, docID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				writeProblem(w, r, http.StatusNotFound, ErrCodeDocumentNotFound,
+					"Document not found")
+				return
+			}
+			respondError(w, r, srv.Logger, http.StatusInternalServerError,
+				"Error requesting document",
+				"error getting document from database", err,
+				"doc_id", docID,
+			)
+			return
+		}
+
+		userEmail := pkgauth.MustGetUserEmail(r.Context())
+
+		lock := models.DocumentLock{DocumentID: model.ID}
+		if err := lock.GetActive(srv.DB); err != nil &&
+			!errors.Is(err, gorm.ErrRecordNotFound) {
+			respondError(w, r, srv.Logger, http.StatusInternalServerError,
+				"Error getting document lock",
+				"error getting document lock", err,
+				"doc_id", docID,
+			)
+			return
+		}
+
+		switch r.Method {
+		case "GET":
+			writeDocumentLockResponse(w, srv, docID, documentLockResponse(lock))
+
+		case "POST":
+			if !authorizeRequest(w, r, srv.Config, srv.DB, srv.Logger,
+				authz.ActionDocumentEditContent, documentModelAuthzResource(model),
+				"Only users who can edit the document can lock it",
+			) {
+				return
+			}
+
+			var req DocumentLockPostRequest
+			if r.ContentLength != 0 {
+				if err := decodeRequest(r, &req); err != nil {
+					writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+						fmt.Sprintf("Bad request: %q", err))
+					return
+				}
+			}
+			ttl := defaultDocumentLockTTL
+			if req.TTLSeconds != 0 {
+				ttl = time.Duration(req.TTLSeconds) * time.Second
+			}
+			if ttl <= 0 || ttl > maxDocumentLockTTL {
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					fmt.Sprintf("Bad request: ttlSeconds must be between 1 and %d",
+						int(maxDocumentLockTTL.Seconds())))
+				return
+			}
+
+			lock = models.DocumentLock{
+				DocumentID: model.ID,
+				OwnerEmail: userEmail,
+				Reason:     req.Reason,
+			}
+			if err := lock.Acquire(srv.DB, ttl); err != nil {
+				if errors.Is(err, models.ErrDocumentLocked) {
+					writeDocumentLockedProblem(w, r, lock)
+					return
+				}
+				respondError(w, r, srv.Logger, http.StatusInternalServerError,
+					"Error locking document",
+					"error acquiring document lock", err,
+					"doc_id", docID,
+				)
+				return
+			}
+
+			writeDocumentLockResponse(w, srv, docID, documentLockResponse(lock))
+
+			srv.Logger.Info("locked document",
+				"doc_id", docID,
+				"user", userEmail,
+				"expires_at", lock.ExpiresAt,
+			)
+
+		case "DELETE":
+			if lock.OwnerEmail == "" {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			if lock.OwnerEmail != userEmail &&
+				!authorizeRequest(w, r, srv.Config, srv.DB, srv.Logger,
+					authz.ActionDocumentEdit, documentModelAuthzResource(model),
+					"Only the lock owner can unlock the document",
+				) {
+				return
+			}
+
+			if err := lock.Release(srv.DB); err != nil {
+				respondError(w, r, srv.Logger, http.StatusInternalServerError,
+					"Error unlocking document",
+					"error releasing document lock", err,
+					"doc_id", docID,
+				)
+				return
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+
+			srv.Logger.Info("unlocked document",
+				"doc_id", docID,
+				"user", userEmail,
+				"lock_owner", lock.OwnerEmail,
+			)
+
+		default:
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
+		}
+	})
+}
+
+// checkDocumentLock returns true if the user can edit the document because it
+// isn't locked by another user. Otherwise, it writes an error response and
+// returns false.
+func checkDocumentLock(
+	w http.ResponseWriter, r *http.Request, srv server.Server,
+	documentID uint, userEmail string,
+) bool {
+	lock := models.DocumentLock{DocumentID: documentID}
+	if err := lock.GetActive(srv.DB); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return true
+		}
+		respondError(w, r, srv.Logger, http.StatusInternalServerError,
+			"Error getting document status",
+			"error getting document lock", err,
+			"document_id", documentID,
+		)
+		return false
+	}
+	if lock.OwnerEmail == userEmail {
+		return true
+	}
+
+	writeDocumentLockedProblem(w, r, lock)
+	return false
+}
+
+// writeDocumentLockedProblem writes the error response for a document locked
+// by another user.
+func writeDocumentLockedProblem(
+	w http.ResponseWriter, r *http.Request, lock models.DocumentLock,
+) {
+	writeProblem(w, r, http.StatusLocked, ErrCodeDocumentLocked,
+		fmt.Sprintf("Document is locked by %s until %s",
+			lock.OwnerEmail, lock.ExpiresAt.UTC().Format(time.RFC3339)))
+}
+
+// documentLockResponse converts a document lock database model to its API
+// response. Locks without an owner are unlocked.
+func documentLockResponse(lock models.DocumentLock) DocumentLock {
+	if lock.OwnerEmail == "" {
+		return DocumentLock{}
+	}
+	return DocumentLock{
+		Locked:     true,
+		Owner:      lock.OwnerEmail,
+		Reason:     lock.Reason,
+		AcquiredAt: &lock.CreatedAt,
+		ExpiresAt:  &lock.ExpiresAt,
+	}
+}
+
+// writeDocumentLockResponse writes a JSON document lock API response.
+func writeDocumentLockResponse(
+	w http.ResponseWriter, srv server.Server, docID string, resp DocumentLock,
+) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		srv.Logger.Error("error encoding document lock response",
+			"error", err,
+			"doc_id", docID,
+		)
+	}
+}
@@ -0,0 +1,44 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocumentLockURLPathRE(t *testing.T) {
+	assert.Equal(t, []string{"/api/v2/documents/doc1/lock", "doc1"},
+		documentLockURLPathRE.FindStringSubmatch("/api/v2/documents/doc1/lock"))
+
+	for _, path := range []string{
+		"/api/v2/documents/doc1",
+		"/api/v2/documents/doc1/lock/1",
+		"/api/v2/documents//lock",
+	} {
+		assert.False(t, documentLockURLPathRE.MatchString(path), path)
+	}
+}
+
+func TestDocumentLockResponse(t *testing.T) {
+	assert.Equal(t, DocumentLock{}, documentLockResponse(models.DocumentLock{
+		DocumentID: 1,
+	}))
+
+	acquiredAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	expiresAt := acquiredAt.Add(15 * time.Minute)
+	assert.Equal(t, DocumentLock{
+		Locked:     true,
+		Owner:      "alice@example.com",
+		Reason:     "Editing the rollout plan",
+		AcquiredAt: &acquiredAt,
+		ExpiresAt:  &expiresAt,
+	}, documentLockResponse(models.DocumentLock{
+		DocumentID: 1,
+		OwnerEmail: "alice@example.com",
+		Reason:     "Editing the rollout plan",
+		CreatedAt:  acquiredAt,
+		ExpiresAt:  expiresAt,
+	}))
+}
@@ -0,0 +1,196 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"regexp"
+
+	"github.com/hashicorp-forge/hermes/internal/server"
+	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+	"github.com/hashicorp-forge/hermes/pkg/authz"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp-forge/hermes/pkg/workspace"
+	"gorm.io/gorm"
+)
+
+// maxDocumentShares is the maximum number of shares of a batch permissions
+// request.
+const maxDocumentShares = 100
+
+// documentPermissionsBatchURLPathRE matches batch document permissions URL
+// paths.
+var documentPermissionsBatchURLPathRE = regexp.MustCompile(
+	`^/api/v2/documents/([0-9A-Za-z_\-]+)/permissions/batch$`)
+
+// documentShareRoles are the roles that can be granted on a document.
+var documentShareRoles = map[string]bool{
+	"reader":    true,
+	"commenter": true,
+	"writer":    true,
+}
+
+// DocumentPermissionsBatchRequest is the request to share a document with
+// several users at once.
+type DocumentPermissionsBatchRequest struct {
+	Shares []DocumentShare `json:"shares"`
+}
+
+// DocumentShare is a role on a document granted to a user or group.
+type DocumentShare struct {
+	Email string `json:"email"`
+
+	// Role is "reader", "commenter", or "writer".
+	Role string `json:"role"`
+}
+
+// DocumentPermissionsBatchResponse is the result of each share of a batch
+// permissions request.
+type DocumentPermissionsBatchResponse struct {
+	Results []DocumentShareResult `json:"results"`
+}
+
+// DocumentShareResult is the result of granting a share.
+type DocumentShareResult struct {
+	Email string `json:"email"`
+	Role  string `json:"role"`
+
+	// Error is why the share wasn't granted, or empty if it was.
+	Error string `json:"error,omitempty"`
+}
+
+// DocumentPermissionsBatchHandler shares a document with several users at
+// once, which is much faster than sharing with them one by one.
+//
+// POST /api/v2/documents/:id/permissions/batch - Share a document
+//
+// Workspace providers with a native batch API grant the shares in one call,
+// and other providers grant them with concurrent calls. Each share succeeds
+// or fails on its own, so the response has the result of each share. Only
+// users who can edit a document (or its sharing settings, for drafts) can
+// share it.
+func DocumentPermissionsBatchHandler(srv server.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
+		matches := documentPermissionsBatchURLPathRE.FindStringSubmatch(r.URL.Path)
+		if len(matches) != 2 {
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				"Bad request")
+			return
+		}
+		docID := matches[1]
+
+		if r.Method != http.MethodPost {
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
+			return
+		}
+
+		var req DocumentPermissionsBatchRequest
+		if err := decodeRequest(r, &req); err != nil {
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				fmt.Sprintf("Bad request: %q", err))
+			return
+		}
+		if len(req.Shares) == 0 || len(req.Shares) > maxDocumentShares {
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				fmt.Sprintf("Bad request: between 1 and %d shares are required",
+					maxDocumentShares))
+			return
+		}
+		shares := make([]workspace.Share, 0, len(req.Shares))
+		for _, s := range req.Shares {
+			if _, err := mail.ParseAddress(s.Email); err != nil {
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					fmt.Sprintf("Bad request: invalid email %q", s.Email))
+				return
+			}
+			if !documentShareRoles[s.Role] {
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					fmt.Sprintf(
+						`Bad request: invalid role %q: must be "reader", "commenter", or "writer"`,
+						s.Role))
+				return
+			}
+			shares = append(shares, workspace.Share{Email: s.Email, Role: s.Role})
+		}
+
+		// Get document from database.
+		model := models.Document{}
+		if err := model.GetByGoogleFileIDOrUUID(srv.DB, docID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				writeProblem(w, r, http.StatusNotFound, ErrCodeDocumentNotFound,
+					"Document not found")
+				return
+			}
+			respondError(w, r, srv.Logger, http.StatusInternalServerError,
+				"Error requesting document",
+				"error getting document from database", err,
+				"doc_id", docID,
+			)
+			return
+		}
+
+		action := authz.ActionDocumentEdit
+		if model.Status == models.WIPDocumentStatus {
+			action = authz.ActionDraftEdit
+		}
+		if !authorizeRequest(w, r, srv.Config, srv.DB, srv.Logger,
+			action, documentModelAuthzResource(model),
+			"Only owners or contributors can share a document",
+		) {
+			return
+		}
+
+		err := workspace.ShareDocumentBatch(r.Context(), srv.WorkspaceProvider,
+			getWorkspaceProviderID(srv.Config, model.GoogleFileID), shares, 0)
+		failed := map[workspace.Share]bool{}
+		if err != nil {
+			var batchErr *workspace.ShareBatchError
+			if !errors.As(err, &batchErr) {
+				respondError(w, r, srv.Logger, http.StatusBadGateway,
+					"Error sharing document",
+					"error sharing document", err,
+					"doc_id", docID,
+				)
+				return
+			}
+			for _, e := range batchErr.Errors {
+				failed[e.Share] = true
+				srv.Logger.Warn("error sharing document",
+					"error", e.Err,
+					"doc_id", docID,
+					"email", e.Share.Email,
+					"role", e.Share.Role,
+				)
+			}
+		}
+
+		resp := DocumentPermissionsBatchResponse{
+			Results: []DocumentShareResult{},
+		}
+		for _, s := range shares {
+			res := DocumentShareResult{Email: s.Email, Role: s.Role}
+			if failed[s] {
+				res.Error = "Error sharing document"
+			}
+			resp.Results = append(resp.Results, res)
+		}
+		srv.Logger.Info("shared document",
+			"doc_id", docID,
+			"shares", len(shares),
+			"failed", len(failed),
+			"shared_by", pkgauth.MustGetUserEmail(r.Context()),
+		)
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			srv.Logger.Error("error encoding document permissions response",
+				"error", err,
+				"doc_id", docID,
+			)
+		}
+	})
+}
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocumentPermissionsBatchURLPathRE(t *testing.T) {
+	assert.Equal(t,
+		[]string{"/api/v2/documents/doc1/permissions/batch", "doc1"},
+		documentPermissionsBatchURLPathRE.FindStringSubmatch(
+			"/api/v2/documents/doc1/permissions/batch"))
+
+	for _, path := range []string{
+		"/api/v2/documents/doc1",
+		"/api/v2/documents/doc1/permissions",
+		"/api/v2/documents/doc1/permissions/batch/1",
+		"/api/v2/documents//permissions/batch",
+	} {
+		assert.False(t, documentPermissionsBatchURLPathRE.MatchString(path), path)
+	}
+}
+
+func TestDocumentPermissionsBatchHandler(t *testing.T) {
+	srv := server.Server{
+		Config: &config.Config{},
+		Logger: hclog.NewNullLogger(),
+	}
+	const path = "/api/v2/documents/doc1/permissions/batch"
+
+	t.Run("method not allowed", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		DocumentPermissionsBatchHandler(srv).ServeHTTP(w,
+			httptest.NewRequest("GET", path, nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+
+	t.Run("bad requests", func(t *testing.T) {
+		tooMany := `{"shares":[` + strings.Repeat(
+			`{"email":"a@example.com","role":"writer"},`, maxDocumentShares) +
+			`{"email":"b@example.com","role":"writer"}]}`
+		for _, body := range []string{
+			`{}`,
+			`{"shares":[]}`,
+			`{"shares":[{"email":"not an email","role":"writer"}]}`,
+			`{"shares":[{"email":"a@example.com","role":"owner"}]}`,
+			`{"shares":[{"email":"a@example.com"}]}`,
+			tooMany,
+		} {
+			w := httptest.NewRecorder()
+			DocumentPermissionsBatchHandler(srv).ServeHTTP(w,
+				httptest.NewRequest("POST", path, strings.NewReader(body)))
+			assert.Equal(t, http.StatusBadRequest, w.Code, body)
+		}
+	})
+}
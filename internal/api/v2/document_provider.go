@@ -0,0 +1,152 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/pkg/migration"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp-forge/hermes/pkg/workspace"
+)
+
+// documentLocation is where a document's content is served from.
+type documentLocation struct {
+	// provider is the provider_storage name of the provider, or empty for the
+	// primary workspace provider.
+	provider   string
+	providerID string
+	expires    time.Time
+}
+
+// documentLocations caches resolved document locations keyed by document ID,
+// so serving a repointed document doesn't look up its storage provider on
+// every request. Entries are removed when a document is repointed (see
+// DocumentLocationRepointHook).
+var documentLocations sync.Map
+
+// documentContentProvider returns the workspace provider and RFC-084 provider
+// ID that serve a document's content.
+//
+// Documents are served from the provider in their canonical provider reference
+// (documents.provider_type and documents.provider_document_id), which is
+// updated when a document is repointed after a migration (RFC-089). Documents
+// without one are served from the primary workspace provider.
+func documentContentProvider(
+	ctx context.Context, srv server.Server, model *models.Document, docID string,
+) (workspace.WorkspaceProvider, string, error) {
+	if v, ok := documentLocations.Load(docID); ok {
+		if loc := v.(documentLocation); time.Now().Before(loc.expires) {
+			return locationProvider(srv, loc)
+		}
+	}
+
+	loc, err := resolveDocumentLocation(ctx, srv, model, docID)
+	if err != nil {
+		return nil, "", err
+	}
+	loc.expires = time.Now().Add(documentLocationTTL(srv))
+	documentLocations.Store(docID, loc)
+	return locationProvider(srv, loc)
+}
+
+// resolveDocumentLocation resolves the location of a document from its
+// canonical provider reference.
+func resolveDocumentLocation(
+	ctx context.Context, srv server.Server, model *models.Document, docID string,
+) (documentLocation, error) {
+	loc := documentLocation{providerID: contentProviderID(srv, docID)}
+	if model == nil || model.ProviderType == nil || *model.ProviderType == "" ||
+		model.ProviderDocumentID == nil || *model.ProviderDocumentID == "" {
+		return loc, nil
+	}
+	providerType := *model.ProviderType
+	providerID := *model.ProviderDocumentID
+	if !strings.Contains(providerID, ":") {
+		providerID = providerType + ":" + providerID
+	}
+
+	if providerType == primaryProviderType(srv) {
+		loc.providerID = providerID
+		return loc, nil
+	}
+
+	// The document was repointed to another provider type, so find a
+	// configured storage provider of that type.
+	var names []string
+	if err := srv.DB.WithContext(ctx).Raw(`
+		SELECT provider_name FROM provider_storage
+		WHERE provider_type = ?
+		ORDER BY id
+	`, providerType).Scan(&names).Error; err != nil {
+		return loc, fmt.Errorf("error getting storage providers: %w", err)
+	}
+	for _, name := range names {
+		if _, ok := srv.StorageProviders[name]; ok {
+			return documentLocation{provider: name, providerID: providerID}, nil
+		}
+	}
+	return loc, fmt.Errorf(
+		"document is stored in a %q provider, which is not configured on this server",
+		providerType)
+}
+
+// documentLocationTTL returns how long a resolved document location is cached.
+// Repoints in this server invalidate the cache right away; the TTL bounds how
+// long other servers keep serving a repointed document from its old provider.
+func documentLocationTTL(srv server.Server) time.Duration {
+	if srv.Config != nil && srv.Config.Server != nil &&
+		srv.Config.Server.DocumentLocationCacheTTL > 0 {
+		return srv.Config.Server.DocumentLocationCacheTTL
+	}
+	return config.DefaultDocumentLocationCacheTTL
+}
+
+// primaryProviderType returns the provider type of the primary workspace
+// provider.
+func primaryProviderType(srv server.Server) string {
+	if srv.Config.Providers != nil && srv.Config.Providers.Workspace != "" {
+		return srv.Config.Providers.Workspace
+	}
+	return "google" // default for backwards compatibility
+}
+
+// folderLayout returns the folder layout of the primary workspace provider.
+func folderLayout(srv server.Server) workspace.FolderLayout {
+	if srv.Folders != nil {
+		return srv.Folders
+	}
+	return workspace.NewFolderLayout(srv.WorkspaceProvider,
+		srv.Config.WorkspaceFolders(primaryProviderType(srv)))
+}
+
+// locationProvider returns the workspace provider and provider ID for loc.
+func locationProvider(
+	srv server.Server, loc documentLocation,
+) (workspace.WorkspaceProvider, string, error) {
+	if loc.provider == "" {
+		return srv.WorkspaceProvider, loc.providerID, nil
+	}
+	p, ok := srv.StorageProviders[loc.provider]
+	if !ok {
+		return nil, "", fmt.Errorf(
+			"storage provider %q is not configured on this server", loc.provider)
+	}
+	return p, loc.providerID, nil
+}
+
+// DocumentLocationRepointHook returns a migration.RepointHook that invalidates
+// the cached location of a repointed document, so it's served from its new
+// provider.
+func DocumentLocationRepointHook() migration.RepointHook {
+	return func(ctx context.Context, ev *migration.RepointEvent) error {
+		if ev.DocumentID != "" {
+			documentLocations.Delete(ev.DocumentID)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/pkg/migration"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp-forge/hermes/pkg/workspace/adapters/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveDocumentLocation(t *testing.T) {
+	srv := server.Server{
+		Config:            &config.Config{},
+		WorkspaceProvider: mock.NewFakeAdapter(),
+	}
+	strPtr := func(s string) *string { return &s }
+
+	cases := []struct {
+		name  string
+		model *models.Document
+		want  documentLocation
+	}{
+		{
+			name: "no model",
+			want: documentLocation{providerID: "google:doc1"},
+		},
+		{
+			name:  "no canonical provider reference",
+			model: &models.Document{},
+			want:  documentLocation{providerID: "google:doc1"},
+		},
+		{
+			name: "primary provider",
+			model: &models.Document{
+				ProviderType:       strPtr("google"),
+				ProviderDocumentID: strPtr("google:doc2"),
+			},
+			want: documentLocation{providerID: "google:doc2"},
+		},
+		{
+			name: "unqualified provider document ID",
+			model: &models.Document{
+				ProviderType:       strPtr("google"),
+				ProviderDocumentID: strPtr("doc2"),
+			},
+			want: documentLocation{providerID: "google:doc2"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			loc, err := resolveDocumentLocation(
+				context.Background(), srv, c.model, "doc1")
+			require.NoError(t, err)
+			assert.Equal(t, c.want, loc)
+		})
+	}
+}
+
+func TestDocumentLocationRepointHook(t *testing.T) {
+	documentLocations.Store("doc1", documentLocation{providerID: "google:doc1"})
+
+	err := DocumentLocationRepointHook()(context.Background(),
+		&migration.RepointEvent{DocumentID: "doc1"})
+	require.NoError(t, err)
+
+	_, ok := documentLocations.Load("doc1")
+	assert.False(t, ok)
+}
@@ -0,0 +1,121 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/pkg/authz"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"gorm.io/gorm"
+)
+
+// documentQualityURLPathRE matches document quality checklist URL paths.
+var documentQualityURLPathRE = regexp.MustCompile(
+	`^/api/v2/documents/([0-9A-Za-z_\-]+)/quality$`)
+
+// DocumentQualityGetResponse is the quality checklist of a document.
+type DocumentQualityGetResponse struct {
+	// Passed is true if the document's content was linted and all checks
+	// passed.
+	Passed bool `json:"passed"`
+
+	// CheckedAt is when the document's content was last linted, or nil if it
+	// hasn't been linted yet.
+	CheckedAt *time.Time `json:"checkedAt,omitempty"`
+
+	Checks []models.DocumentQualityCheck `json:"checks"`
+}
+
+// DocumentQualityHandler returns the quality checklist of a document
+// (GET /api/v2/documents/:id/quality), which is the result of running the lint
+// rules of the quality_lint indexer pipeline step over its latest content.
+// Drafts can be checked before review is requested by users who can view them.
+func DocumentQualityHandler(srv server.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
+		if r.Method != "GET" {
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
+			return
+		}
+
+		matches := documentQualityURLPathRE.FindStringSubmatch(r.URL.Path)
+		if len(matches) != 2 {
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				"Bad request")
+			return
+		}
+		docID := matches[1]
+
+		// Get document from database.
+		model := models.Document{}
+		if err := model.GetByGoogleFileIDOrUUID(srv.DB, docID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				writeProblem(w, r, http.StatusNotFound, ErrCodeDocumentNotFound,
+					"Document not found")
+				return
+			}
+			respondError(w, r, srv.Logger, http.StatusInternalServerError,
+				"Error requesting document",
+				"error getting document from database", err,
+				"doc_id", docID,
+			)
+			return
+		}
+
+		if model.Status == models.WIPDocumentStatus &&
+			!authorizeRequest(w, r, srv.Config, srv.DB, srv.Logger,
+				authz.ActionDraftView, documentModelAuthzResource(model),
+				"Only owners or contributors can access a non-shared draft document",
+			) {
+			return
+		}
+
+		var checks models.DocumentQualityChecks
+		if err := checks.Find(srv.DB.WithContext(r.Context()), model.ID); err != nil {
+			respondError(w, r, srv.Logger, http.StatusInternalServerError,
+				"Error getting quality checks",
+				"error getting document quality checks", err,
+				"doc_id", docID,
+			)
+			return
+		}
+
+		resp := documentQualityResponse(checks)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			srv.Logger.Error("error encoding document quality response",
+				"error", err,
+				"doc_id", docID,
+			)
+		}
+	})
+}
+
+// documentQualityResponse returns the quality checklist response for checks.
+func documentQualityResponse(
+	checks models.DocumentQualityChecks,
+) DocumentQualityGetResponse {
+	resp := DocumentQualityGetResponse{
+		Passed: len(checks) > 0,
+		Checks: checks,
+	}
+	if resp.Checks == nil {
+		resp.Checks = models.DocumentQualityChecks{}
+	}
+	for _, c := range checks {
+		if !c.Passed {
+			resp.Passed = false
+		}
+		if resp.CheckedAt == nil || c.CheckedAt.After(*resp.CheckedAt) {
+			checkedAt := c.CheckedAt
+			resp.CheckedAt = &checkedAt
+		}
+	}
+	return resp
+}
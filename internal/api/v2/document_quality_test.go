@@ -0,0 +1,46 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocumentQualityURLPathRE(t *testing.T) {
+	assert.Equal(t, []string{"/api/v2/documents/doc1/quality", "doc1"},
+		documentQualityURLPathRE.FindStringSubmatch("/api/v2/documents/doc1/quality"))
+
+	for _, path := range []string{
+		"/api/v2/documents/doc1",
+		"/api/v2/documents/doc1/quality/1",
+		"/api/v2/documents//quality",
+	} {
+		assert.False(t, documentQualityURLPathRE.MatchString(path), path)
+	}
+}
+
+func TestDocumentQualityResponse(t *testing.T) {
+	assert.Equal(t, DocumentQualityGetResponse{
+		Checks: []models.DocumentQualityCheck{},
+	}, documentQualityResponse(nil))
+
+	t0 := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	t1 := t0.Add(time.Minute)
+	checks := models.DocumentQualityChecks{
+		{Rule: "summary_section", Passed: true, CheckedAt: t1},
+		{Rule: "todo_markers", Passed: true, CheckedAt: t0},
+	}
+	assert.Equal(t, DocumentQualityGetResponse{
+		Passed:    true,
+		CheckedAt: &t1,
+		Checks:    checks,
+	}, documentQualityResponse(checks))
+
+	checks[1].Passed = false
+	checks[1].Findings = []models.DocumentQualityFinding{
+		{Line: 3, Message: "TODO: write this"},
+	}
+	assert.False(t, documentQualityResponse(checks).Passed)
+}
@@ -0,0 +1,383 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/server"
+	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp-forge/hermes/pkg/runbook"
+	"gorm.io/gorm"
+)
+
+// documentRunsURLPathRE matches document runbook run URL paths, with an
+// optional run ID.
+var documentRunsURLPathRE = regexp.MustCompile(
+	`^/api/v2/documents/([0-9A-Za-z_\-]+)/runs(?:/([0-9]+))?$`)
+
+// DocumentRunPostRequest is the request to start a run of a document's
+// checklist.
+type DocumentRunPostRequest struct {
+	Name string `json:"name,omitempty"`
+}
+
+// DocumentRunItemPatch checks or unchecks a run item.
+type DocumentRunItemPatch struct {
+	Position int    `json:"position"`
+	Checked  bool   `json:"checked"`
+	Note     string `json:"note,omitempty"`
+}
+
+// DocumentRunPatchRequest contains the fields that are allowed to be patched
+// for a run.
+type DocumentRunPatchRequest struct {
+	Items  []DocumentRunItemPatch `json:"items,omitempty"`
+	Status *string                `json:"status,omitempty"`
+}
+
+// DocumentRunItem is a checklist item in a run.
+type DocumentRunItem struct {
+	Position  int        `json:"position"`
+	Section   string     `json:"section,omitempty"`
+	Text      string     `json:"text"`
+	Checked   bool       `json:"checked"`
+	CheckedBy string     `json:"checkedBy,omitempty"`
+	CheckedAt *time.Time `json:"checkedAt,omitempty"`
+	Note      string     `json:"note,omitempty"`
+}
+
+// DocumentRun is an execution of a document's checklist.
+type DocumentRun struct {
+	ID              uint              `json:"id"`
+	Name            string            `json:"name,omitempty"`
+	Status          string            `json:"status"`
+	StartedBy       string            `json:"startedBy"`
+	StartedAt       time.Time         `json:"startedAt"`
+	CompletedAt     *time.Time        `json:"completedAt,omitempty"`
+	ContentRevision string            `json:"contentRevision,omitempty"`
+	Items           []DocumentRunItem `json:"items"`
+}
+
+// DocumentRunsGetResponse is the response for GET
+// /api/v2/documents/:id/runs.
+type DocumentRunsGetResponse struct {
+	Runs []DocumentRun `json:"runs"`
+}
+
+// DocumentRunsHandler handles runbook runs of a document's checklist.
+// GET  /api/v2/documents/:id/runs         - lists runs, newest first
+// POST /api/v2/documents/:id/runs         - starts a run
+// GET  /api/v2/documents/:id/runs/:run_id - gets a run
+// PATCH /api/v2/documents/:id/runs/:run_id - checks items or sets the status
+//
+// Starting a run copies the checklist items from the document's current
+// content, so who checked what, and when, is recorded on the run and never
+// written back to the document.
+func DocumentRunsHandler(srv server.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
+		docID, runID, err := parseDocumentRunsURLPath(r.URL.Path)
+		if err != nil {
+			srv.Logger.Error("error parsing document runs URL path",
+				"error", err,
+				"path", r.URL.Path,
+				"method", r.Method,
+			)
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				"Bad request")
+			return
+		}
+
+		// Get document from database to verify it exists.
+		model := models.Document{}
+		if err := model.GetByGoogleFileIDOrUUID(srv.DB, docID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				writeProblem(w, r, http.StatusNotFound, ErrCodeDocumentNotFound,
+					"Document not found")
+				return
+			}
+			respondError(w, r, srv.Logger, http.StatusInternalServerError,
+				"Error requesting document",
+				"error getting document from database", err,
+				"doc_id", docID,
+			)
+			return
+		}
+
+		userEmail := pkgauth.MustGetUserEmail(r.Context())
+
+		if runID == 0 {
+			switch r.Method {
+			case "GET":
+				var runs models.DocumentRuns
+				if err := runs.FindByDocument(srv.DB, model.ID); err != nil {
+					respondError(w, r, srv.Logger, http.StatusInternalServerError,
+						"Error getting runs",
+						"error finding document runs", err,
+						"doc_id", docID,
+					)
+					return
+				}
+
+				resp := DocumentRunsGetResponse{
+					Runs: []DocumentRun{},
+				}
+				for _, run := range runs {
+					resp.Runs = append(resp.Runs, documentRunResponse(run))
+				}
+				writeDocumentRunsResponse(w, srv, docID, http.StatusOK, resp)
+
+			case "POST":
+				var req DocumentRunPostRequest
+				if r.ContentLength != 0 {
+					if err := decodeRequest(r, &req); err != nil {
+						writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+							fmt.Sprintf("Bad request: %q", err))
+						return
+					}
+				}
+
+				provider, providerID, err := documentContentProvider(
+					r.Context(), srv, &model, model.GoogleFileID)
+				if err != nil {
+					respondError(w, r, srv.Logger, http.StatusInternalServerError,
+						"Error retrieving document content",
+						"error resolving document provider for run", err,
+						"doc_id", docID,
+					)
+					return
+				}
+				content, err := provider.GetContent(r.Context(), providerID)
+				if err != nil {
+					respondError(w, r, srv.Logger, http.StatusInternalServerError,
+						"Error retrieving document content",
+						"error getting document content for run", err,
+						"doc_id", docID,
+					)
+					return
+				}
+
+				checklist := runbook.ParseChecklist(content.Body)
+				if len(checklist) == 0 {
+					writeProblem(w, r, http.StatusUnprocessableEntity,
+						ErrCodeUnprocessable, "Document has no checklist items")
+					return
+				}
+
+				run := models.DocumentRun{
+					Document: models.Document{
+						GoogleFileID: model.GoogleFileID,
+					},
+					StartedBy: models.User{
+						EmailAddress: userEmail,
+					},
+					Name: req.Name,
+				}
+				if content.BackendRevision != nil {
+					run.ContentRevision = content.BackendRevision.RevisionID
+				}
+				for i, item := range checklist {
+					run.Items = append(run.Items, models.DocumentRunItem{
+						Position: i + 1,
+						Section:  item.Section,
+						Text:     item.Text,
+					})
+				}
+				if err := run.Create(srv.DB); err != nil {
+					respondError(w, r, srv.Logger, http.StatusInternalServerError,
+						"Error starting run",
+						"error creating document run", err,
+						"doc_id", docID,
+					)
+					return
+				}
+				if err := run.Get(srv.DB); err != nil {
+					respondError(w, r, srv.Logger, http.StatusInternalServerError,
+						"Error starting run",
+						"error getting created document run", err,
+						"doc_id", docID,
+					)
+					return
+				}
+
+				writeDocumentRunsResponse(w, srv, docID, http.StatusCreated,
+					documentRunResponse(run))
+
+				srv.Logger.Info("started document run",
+					"doc_id", docID,
+					"run_id", run.ID,
+					"items", len(run.Items),
+				)
+
+			default:
+				writeProblem(w, r, http.StatusMethodNotAllowed,
+					ErrCodeMethodNotAllowed, "Method not allowed")
+			}
+			return
+		}
+
+		// Get the run and verify it belongs to the document.
+		run := models.DocumentRun{
+			Model: gorm.Model{
+				ID: runID,
+			},
+		}
+		if err := run.Get(srv.DB); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound,
+					"Run not found")
+				return
+			}
+			respondError(w, r, srv.Logger, http.StatusInternalServerError,
+				"Error getting run",
+				"error getting document run", err,
+				"doc_id", docID,
+				"run_id", runID,
+			)
+			return
+		}
+		if run.DocumentID != model.ID {
+			writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound,
+				"Run not found")
+			return
+		}
+
+		switch r.Method {
+		case "GET":
+			writeDocumentRunsResponse(w, srv, docID, http.StatusOK,
+				documentRunResponse(run))
+
+		case "PATCH":
+			var req DocumentRunPatchRequest
+			if err := decodeRequest(r, &req); err != nil {
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					fmt.Sprintf("Bad request: %q", err))
+				return
+			}
+
+			var status models.DocumentRunStatus
+			if req.Status != nil {
+				var ok bool
+				status, ok = models.ParseDocumentRunStatusString(*req.Status)
+				if !ok {
+					writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+						fmt.Sprintf("Invalid status %q", *req.Status))
+					return
+				}
+			}
+
+			if len(req.Items) > 0 &&
+				run.Status != models.InProgressDocumentRunStatus {
+				writeProblem(w, r, http.StatusConflict, ErrCodeConflict,
+					"Items can only be checked while the run is in progress")
+				return
+			}
+			for _, item := range req.Items {
+				if item.Position < 1 || item.Position > len(run.Items) {
+					writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+						fmt.Sprintf("Invalid item position %d", item.Position))
+					return
+				}
+			}
+
+			if err := srv.DB.Transaction(func(tx *gorm.DB) error {
+				for _, item := range req.Items {
+					if err := run.SetItemChecked(
+						tx, item.Position, userEmail, item.Checked, item.Note,
+					); err != nil {
+						return fmt.Errorf("error updating item %d: %w",
+							item.Position, err)
+					}
+				}
+				if req.Status != nil && status != run.Status {
+					if err := run.SetStatus(tx, status); err != nil {
+						return fmt.Errorf("error setting status: %w", err)
+					}
+				}
+				return nil
+			}); err != nil {
+				respondError(w, r, srv.Logger, http.StatusInternalServerError,
+					"Error updating run",
+					"error updating document run", err,
+					"doc_id", docID,
+					"run_id", runID,
+				)
+				return
+			}
+
+			writeDocumentRunsResponse(w, srv, docID, http.StatusOK,
+				documentRunResponse(run))
+
+		default:
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
+		}
+	})
+}
+
+// documentRunResponse converts a run database model to its API response.
+func documentRunResponse(run models.DocumentRun) DocumentRun {
+	res := DocumentRun{
+		ID:              run.ID,
+		Name:            run.Name,
+		Status:          run.Status.String(),
+		StartedBy:       run.StartedBy.EmailAddress,
+		StartedAt:       run.CreatedAt,
+		CompletedAt:     run.CompletedAt,
+		ContentRevision: run.ContentRevision,
+		Items:           []DocumentRunItem{},
+	}
+	for _, item := range run.Items {
+		i := DocumentRunItem{
+			Position:  item.Position,
+			Section:   item.Section,
+			Text:      item.Text,
+			Checked:   item.CheckedAt != nil,
+			CheckedAt: item.CheckedAt,
+			Note:      item.Note,
+		}
+		if item.CheckedBy != nil {
+			i.CheckedBy = item.CheckedBy.EmailAddress
+		}
+		res.Items = append(res.Items, i)
+	}
+	return res
+}
+
+// writeDocumentRunsResponse writes a JSON document runs API response.
+func writeDocumentRunsResponse(
+	w http.ResponseWriter, srv server.Server, docID string, httpCode int,
+	resp any,
+) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpCode)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		srv.Logger.Error("error encoding document runs response",
+			"error", err,
+			"doc_id", docID,
+		)
+	}
+}
+
+// parseDocumentRunsURLPath parses the document ID and, if present, the run ID
+// from a document runs API URL path. The run ID is 0 for the runs collection.
+func parseDocumentRunsURLPath(path string) (string, uint, error) {
+	matches := documentRunsURLPathRE.FindStringSubmatch(path)
+	if len(matches) != 3 {
+		return "", 0, fmt.Errorf("invalid document runs URL path")
+	}
+	if matches[2] == "" {
+		return matches[1], 0, nil
+	}
+	runID, err := strconv.ParseUint(matches[2], 10, 64)
+	if err != nil || runID == 0 {
+		return "", 0, fmt.Errorf("invalid run ID: %q", matches[2])
+	}
+	return matches[1], uint(runID), nil
+}
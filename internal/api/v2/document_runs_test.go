@@ -0,0 +1,76 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDocumentRunsURLPath(t *testing.T) {
+	docID, runID, err := parseDocumentRunsURLPath("/api/v2/documents/doc1/runs")
+	require.NoError(t, err)
+	assert.Equal(t, "doc1", docID)
+	assert.Equal(t, uint(0), runID)
+
+	docID, runID, err = parseDocumentRunsURLPath("/api/v2/documents/doc1/runs/42")
+	require.NoError(t, err)
+	assert.Equal(t, "doc1", docID)
+	assert.Equal(t, uint(42), runID)
+
+	for _, path := range []string{
+		"/api/v2/documents/doc1/runs/0",
+		"/api/v2/documents/doc1/runs/abc",
+		"/api/v2/documents/doc1/runs/1/items",
+		"/api/v2/documents/doc1",
+	} {
+		_, _, err := parseDocumentRunsURLPath(path)
+		assert.Error(t, err, path)
+	}
+}
+
+func TestDocumentRunResponse(t *testing.T) {
+	checkedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	run := models.DocumentRun{
+		Name:      "INC-123",
+		Status:    models.InProgressDocumentRunStatus,
+		StartedBy: models.User{EmailAddress: "alice@example.com"},
+		Items: []models.DocumentRunItem{
+			{
+				Position:  1,
+				Section:   "Prepare",
+				Text:      "Announce in #ops",
+				CheckedBy: &models.User{EmailAddress: "bob@example.com"},
+				CheckedAt: &checkedAt,
+				Note:      "done",
+			},
+			{
+				Position: 2,
+				Section:  "Prepare",
+				Text:     "Drain traffic",
+			},
+		},
+	}
+
+	got := documentRunResponse(run)
+	assert.Equal(t, "in_progress", got.Status)
+	assert.Equal(t, "alice@example.com", got.StartedBy)
+	assert.Equal(t, []DocumentRunItem{
+		{
+			Position:  1,
+			Section:   "Prepare",
+			Text:      "Announce in #ops",
+			Checked:   true,
+			CheckedBy: "bob@example.com",
+			CheckedAt: &checkedAt,
+			Note:      "done",
+		},
+		{
+			Position: 2,
+			Section:  "Prepare",
+			Text:     "Drain traffic",
+		},
+	}, got.Items)
+}
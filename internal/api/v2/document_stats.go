@@ -0,0 +1,171 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/pkg/activity"
+	"github.com/hashicorp-forge/hermes/pkg/authz"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"gorm.io/gorm"
+)
+
+const (
+	// defaultDocumentStatsDays is the number of days of view stats returned
+	// by default.
+	defaultDocumentStatsDays = 30
+
+	// maxDocumentStatsDays is the maximum number of days of view stats.
+	maxDocumentStatsDays = 365
+)
+
+// documentStatsURLPathRE matches document stats URL paths.
+var documentStatsURLPathRE = regexp.MustCompile(
+	`^/api/v2/documents/([0-9A-Za-z_\-]+)/stats$`)
+
+// DocumentStatsGetResponse are the view stats of a document.
+type DocumentStatsGetResponse struct {
+	// Since is the first day of the stats.
+	Since string `json:"since"`
+
+	// Views is the number of views. Repeated views by a user within a few
+	// minutes are counted once.
+	Views int `json:"views"`
+
+	// UniqueReaders is the number of distinct users who viewed the document.
+	// It is omitted if fewer than minReaders users viewed the document.
+	UniqueReaders *int `json:"uniqueReaders,omitempty"`
+
+	// MinReaders is the minimum number of readers that readers and periods
+	// are reported for.
+	MinReaders int `json:"minReaders"`
+
+	// Series are the views by period, oldest first. Days with fewer than
+	// minReaders readers are merged with their neighbors, and days without
+	// views are omitted.
+	Series []DocumentStatsPeriod `json:"series"`
+}
+
+// DocumentStatsPeriod are the views of a document during a period of days.
+type DocumentStatsPeriod struct {
+	// Start is the first day of the period (YYYY-MM-DD).
+	Start string `json:"start"`
+
+	// End is the last day of the period (YYYY-MM-DD).
+	End string `json:"end"`
+
+	Views         int `json:"views"`
+	UniqueReaders int `json:"uniqueReaders"`
+}
+
+// DocumentStatsHandler returns the view stats of a document
+// (GET /api/v2/documents/:id/stats?days=30): its view count, unique readers,
+// and views over time. Only owners (and admins) can get the stats, which never
+// identify readers.
+func DocumentStatsHandler(srv server.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
+		if r.Method != "GET" {
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
+			return
+		}
+
+		matches := documentStatsURLPathRE.FindStringSubmatch(r.URL.Path)
+		if len(matches) != 2 {
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				"Bad request")
+			return
+		}
+		docID := matches[1]
+
+		days := defaultDocumentStatsDays
+		if v := r.URL.Query().Get("days"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 1 || n > maxDocumentStatsDays {
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					"Bad request: days must be an integer from 1 to "+
+						strconv.Itoa(maxDocumentStatsDays))
+				return
+			}
+			days = n
+		}
+
+		// Get document from database.
+		model := models.Document{}
+		if err := model.GetByGoogleFileIDOrUUID(srv.DB, docID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				writeProblem(w, r, http.StatusNotFound, ErrCodeDocumentNotFound,
+					"Document not found")
+				return
+			}
+			respondError(w, r, srv.Logger, http.StatusInternalServerError,
+				"Error requesting document",
+				"error getting document from database", err,
+				"doc_id", docID,
+			)
+			return
+		}
+
+		if !authorizeRequest(w, r, srv.Config, srv.DB, srv.Logger,
+			authz.ActionDocumentEdit, documentModelAuthzResource(model),
+			"Only owners can view the stats of a document",
+		) {
+			return
+		}
+
+		minReaders := activity.DefaultStatsMinReaders
+		if srv.Config.Activity != nil && srv.Config.Activity.StatsMinReaders > 0 {
+			minReaders = srv.Config.Activity.StatsMinReaders
+		}
+		since := models.ViewDay(time.Now()).AddDate(0, 0, 1-days)
+		st, err := activity.DocumentStats(
+			srv.DB.WithContext(r.Context()), model, since, minReaders)
+		if err != nil {
+			respondError(w, r, srv.Logger, http.StatusInternalServerError,
+				"Error getting document stats",
+				"error getting document stats", err,
+				"doc_id", docID,
+			)
+			return
+		}
+
+		resp := documentStatsResponse(st, since, minReaders)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			srv.Logger.Error("error encoding document stats response",
+				"error", err,
+				"doc_id", docID,
+			)
+		}
+	})
+}
+
+// documentStatsResponse returns the stats response for stats st since day
+// since.
+func documentStatsResponse(
+	st activity.Stats, since time.Time, minReaders int,
+) DocumentStatsGetResponse {
+	resp := DocumentStatsGetResponse{
+		Since:         since.Format(time.DateOnly),
+		Views:         st.Views,
+		UniqueReaders: st.UniqueReaders,
+		MinReaders:    minReaders,
+		Series:        make([]DocumentStatsPeriod, len(st.Series)),
+	}
+	for i, p := range st.Series {
+		resp.Series[i] = DocumentStatsPeriod{
+			Start:         p.Start.Format(time.DateOnly),
+			End:           p.End.AddDate(0, 0, -1).Format(time.DateOnly),
+			Views:         p.Views,
+			UniqueReaders: p.UniqueReaders,
+		}
+	}
+	return resp
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/activity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocumentStatsURLPathRE(t *testing.T) {
+	assert.Equal(t, []string{"/api/v2/documents/doc1/stats", "doc1"},
+		documentStatsURLPathRE.FindStringSubmatch("/api/v2/documents/doc1/stats"))
+
+	for _, path := range []string{
+		"/api/v2/documents/doc1",
+		"/api/v2/documents/doc1/stats/1",
+		"/api/v2/documents//stats",
+	} {
+		assert.False(t, documentStatsURLPathRE.MatchString(path), path)
+	}
+}
+
+func TestDocumentStatsResponse(t *testing.T) {
+	since := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, DocumentStatsGetResponse{
+		Since:      "2026-03-01",
+		Views:      2,
+		MinReaders: 3,
+		Series:     []DocumentStatsPeriod{},
+	}, documentStatsResponse(activity.Stats{Views: 2}, since, 3))
+
+	readers := 3
+	assert.Equal(t, DocumentStatsGetResponse{
+		Since:         "2026-03-01",
+		Views:         5,
+		UniqueReaders: &readers,
+		MinReaders:    3,
+		Series: []DocumentStatsPeriod{
+			{Start: "2026-03-02", End: "2026-03-04", Views: 5, UniqueReaders: 3},
+		},
+	}, documentStatsResponse(activity.Stats{
+		Views:         5,
+		UniqueReaders: &readers,
+		Series: []activity.StatsPeriod{{
+			Start:         since.AddDate(0, 0, 1),
+			End:           since.AddDate(0, 0, 4),
+			Views:         5,
+			UniqueReaders: 3,
+		}},
+	}, since, 3))
+}
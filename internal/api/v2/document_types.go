@@ -1,33 +1,219 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"regexp"
 
+	"github.com/hashicorp-forge/hermes/internal/config"
 	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/pkg/document"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"gorm.io/gorm"
 )
 
+// documentTypeTemplatesURLPathRE matches document type templates URL paths.
+var documentTypeTemplatesURLPathRE = regexp.MustCompile(
+	`^/api/v2/document-types/([^/]+)/templates$`)
+
+// DocumentTypeTemplate is a template that drafts of a document type can be
+// created from.
+type DocumentTypeTemplate struct {
+	// Name identifies the template in requests to create drafts.
+	Name string `json:"name"`
+
+	LongName    string `json:"longName"`
+	Description string `json:"description"`
+
+	// Default is true for the template drafts are created from if no template
+	// is selected.
+	Default bool `json:"default"`
+}
+
 func DocumentTypesHandler(srv server.Server) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
 		switch r.Method {
 		case "GET":
 			w.Header().Set("Content-Type", "application/json")
 
 			enc := json.NewEncoder(w)
-			err := enc.Encode(srv.Config.DocumentTypes.DocumentType)
+			err := enc.Encode(documentTypes(r.Context(), srv))
 			if err != nil {
 				srv.Logger.Error("error encoding document types",
 					"error", err,
 					"method", r.Method,
 					"path", r.URL.Path)
-				http.Error(w, "{\"error\": \"Error getting document types\"}",
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error getting document types")
 				return
 			}
 
 		default:
-			w.WriteHeader(http.StatusMethodNotAllowed)
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
+			return
+		}
+	})
+}
+
+// DocumentTypeTemplatesHandler lists the templates of a document type
+// (GET /api/v2/document-types/:name/templates), so users creating a draft can
+// choose one (e.g., a short or a full RFC). The default template is first.
+func DocumentTypeTemplatesHandler(srv server.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
+		if r.Method != "GET" {
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
 			return
 		}
+
+		matches := documentTypeTemplatesURLPathRE.FindStringSubmatch(r.URL.Path)
+		if len(matches) != 2 {
+			writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound, "Not found")
+			return
+		}
+		docType := matches[1]
+
+		var ts models.DocumentTypeTemplates
+		if err := ts.Find(srv.DB.WithContext(r.Context()), docType); err != nil {
+			respondError(w, r, srv.Logger, http.StatusInternalServerError,
+				"Error getting document type templates",
+				"error finding document type templates", err,
+				"doc_type", docType,
+			)
+			return
+		}
+
+		resp := make([]DocumentTypeTemplate, 0, len(ts))
+		for _, t := range ts {
+			resp = append(resp, DocumentTypeTemplate{
+				Name:        t.Name,
+				LongName:    t.LongName,
+				Description: t.Description,
+				Default:     t.Default,
+			})
+		}
+		if len(ts) == 0 {
+			// Fall back to the configuration if the templates weren't
+			// synchronized to the database.
+			cts := configDocTypeTemplates(
+				documentTypes(r.Context(), srv), docType)
+			if cts == nil {
+				writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound,
+					"Document type not found")
+				return
+			}
+			resp = configDocumentTypeTemplates(cts)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			srv.Logger.Error("error encoding document type templates",
+				"error", err,
+				"method", r.Method,
+				"path", r.URL.Path,
+			)
+		}
 	})
 }
+
+// configDocumentTypeTemplates returns the response for the configured
+// templates ts of a document type.
+func configDocumentTypeTemplates(
+	ts []*config.DocumentTypeTemplateVariant,
+) []DocumentTypeTemplate {
+	resp := make([]DocumentTypeTemplate, 0, len(ts))
+	for _, t := range ts {
+		if t.Template == "" {
+			continue
+		}
+		resp = append(resp, DocumentTypeTemplate{
+			Name:        t.Name,
+			LongName:    t.LongName,
+			Description: t.Description,
+			Default:     t.Name == config.DefaultTemplateVariant,
+		})
+	}
+	return resp
+}
+
+// documentTypes returns the document types defined in the configuration
+// followed by the ones managed with the admin API. Only the configured
+// document types are returned if the managed ones can't be read.
+func documentTypes(ctx context.Context, srv server.Server) []*config.DocumentType {
+	var docTypes []*config.DocumentType
+	if srv.Config != nil && srv.Config.DocumentTypes != nil {
+		docTypes = append(docTypes, srv.Config.DocumentTypes.DocumentType...)
+	}
+	if srv.DB == nil {
+		return docTypes
+	}
+
+	managed, err := managedDocumentTypes(srv.DB.WithContext(ctx))
+	if err != nil {
+		srv.Logger.Warn("error getting managed document types",
+			"error", err,
+		)
+		return docTypes
+	}
+	for _, d := range managed {
+		if configuredDocumentType(srv.Config, d.Name) == nil {
+			docTypes = append(docTypes, d)
+		}
+	}
+	return docTypes
+}
+
+// managedDocumentTypes returns the document types managed with the admin API
+// in database db, ordered by name.
+func managedDocumentTypes(db *gorm.DB) ([]*config.DocumentType, error) {
+	var dts models.DocumentTypes
+	if err := dts.FindManaged(db); err != nil {
+		return nil, fmt.Errorf("error finding document types: %w", err)
+	}
+
+	docTypes := make([]*config.DocumentType, 0, len(dts))
+	for _, dt := range dts {
+		var ts models.DocumentTypeTemplates
+		if err := ts.Find(db, dt.Name); err != nil {
+			return nil, fmt.Errorf(
+				"error finding templates of document type %q: %w", dt.Name, err)
+		}
+		d, err := document.NewDocumentTypeFromModel(dt, ts)
+		if err != nil {
+			return nil, fmt.Errorf("document type %q: %w", dt.Name, err)
+		}
+		docTypes = append(docTypes, d)
+	}
+	return docTypes, nil
+}
+
+// configuredDocumentType returns the document type named name defined in the
+// configuration, or nil.
+func configuredDocumentType(cfg *config.Config, name string) *config.DocumentType {
+	if cfg == nil || cfg.DocumentTypes == nil {
+		return nil
+	}
+	for _, dt := range cfg.DocumentTypes.DocumentType {
+		if dt.Name == name {
+			return dt
+		}
+	}
+	return nil
+}
+
+// findDocumentType returns the document type named name from docTypes, or nil.
+func findDocumentType(
+	docTypes []*config.DocumentType, name string,
+) *config.DocumentType {
+	for _, dt := range docTypes {
+		if dt.Name == name {
+			return dt
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocumentTypeTemplatesURLPathRE(t *testing.T) {
+	assert.Equal(t, []string{"/api/v2/document-types/RFC/templates", "RFC"},
+		documentTypeTemplatesURLPathRE.FindStringSubmatch(
+			"/api/v2/document-types/RFC/templates"))
+
+	for _, path := range []string{
+		"/api/v2/document-types",
+		"/api/v2/document-types/RFC",
+		"/api/v2/document-types//templates",
+		"/api/v2/document-types/RFC/templates/short",
+	} {
+		assert.False(t, documentTypeTemplatesURLPathRE.MatchString(path), path)
+	}
+}
+
+func TestConfigDocumentTypeTemplates(t *testing.T) {
+	docTypes := []*config.DocumentType{
+		{Name: "PRD", Template: "prd"},
+		{
+			Name:        "RFC",
+			LongName:    "Request for Comments",
+			Description: "A proposal",
+			Template:    "rfc",
+			TemplateVariants: []*config.DocumentTypeTemplateVariant{
+				{Name: "short", LongName: "Short RFC", Template: "rfc-short"},
+			},
+		},
+		// Local workspaces allow document types without templates.
+		{Name: "ADR"},
+	}
+
+	assert.Nil(t, configDocTypeTemplates(docTypes, "FRD"))
+	assert.Equal(t, []DocumentTypeTemplate{
+		{
+			Name:        "default",
+			LongName:    "Request for Comments",
+			Description: "A proposal",
+			Default:     true,
+		},
+		{Name: "short", LongName: "Short RFC"},
+	}, configDocumentTypeTemplates(configDocTypeTemplates(docTypes, "RFC")))
+	assert.Empty(t,
+		configDocumentTypeTemplates(configDocTypeTemplates(docTypes, "ADR")))
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -13,22 +14,25 @@ import (
 	"github.com/hashicorp-forge/hermes/internal/email"
 	"github.com/hashicorp-forge/hermes/internal/helpers"
 	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/pkg/activity"
 	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+	"github.com/hashicorp-forge/hermes/pkg/authz"
 	"github.com/hashicorp-forge/hermes/pkg/document"
 	hcd "github.com/hashicorp-forge/hermes/pkg/hashicorpdocs"
 	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp-forge/hermes/pkg/workspace"
 	"gorm.io/gorm"
 )
 
 // DocumentPatchRequest contains a subset of documents fields that are allowed
 // to be updated with a PATCH request.
 type DocumentPatchRequest struct {
-	Approvers      *[]string               `json:"approvers,omitempty"`
-	ApproverGroups *[]string               `json:"approverGroups,omitempty"`
-	Contributors   *[]string               `json:"contributors,omitempty"`
+	Approvers      *[]string               `json:"approvers,omitempty" validate:"email"`
+	ApproverGroups *[]string               `json:"approverGroups,omitempty" validate:"email"`
+	Contributors   *[]string               `json:"contributors,omitempty" validate:"email"`
 	CustomFields   *[]document.CustomField `json:"customFields,omitempty"`
-	Owners         *[]string               `json:"owners,omitempty"`
-	Status         *string                 `json:"status,omitempty"`
+	Owners         *[]string               `json:"owners,omitempty" validate:"len=1,email"`
+	Status         *string                 `json:"status,omitempty" validate:"oneof=Approved In-Review Obsolete"`
 	Summary        *string                 `json:"summary,omitempty"`
 	// Tags                []string `json:"tags,omitempty"`
 	Title *string `json:"title,omitempty"`
@@ -41,10 +45,12 @@ const (
 	noSubcollectionRequestType
 	relatedResourcesDocumentSubcollectionRequestType
 	shareableDocumentSubcollectionRequestType
+	activityDocumentSubcollectionRequestType
 )
 
 func DocumentHandler(srv server.Server) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
 		// Check if this is a document content request (/content suffix)
 		// and delegate to DocumentContentHandler
 		if strings.HasSuffix(r.URL.Path, "/content") {
@@ -52,6 +58,61 @@ func DocumentHandler(srv server.Server) http.Handler {
 			return
 		}
 
+		// Delegate document export requests (/export suffix).
+		if strings.HasSuffix(r.URL.Path, "/export") {
+			DocumentExportHandler(srv).ServeHTTP(w, r)
+			return
+		}
+
+		// Delegate similar document requests (/similar suffix).
+		if strings.HasSuffix(r.URL.Path, "/similar") {
+			SimilarDocumentsHandler(srv).ServeHTTP(w, r)
+			return
+		}
+
+		// Delegate view stats requests (/stats suffix).
+		if documentStatsURLPathRE.MatchString(r.URL.Path) {
+			DocumentStatsHandler(srv).ServeHTTP(w, r)
+			return
+		}
+
+		// Delegate quality checklist requests (/quality suffix).
+		if documentQualityURLPathRE.MatchString(r.URL.Path) {
+			DocumentQualityHandler(srv).ServeHTTP(w, r)
+			return
+		}
+
+		// Delegate review checklist requests (/checklist suffix).
+		if documentChecklistURLPathRE.MatchString(r.URL.Path) {
+			DocumentChecklistHandler(srv).ServeHTTP(w, r)
+			return
+		}
+
+		// Delegate runbook run requests (/runs and /runs/:run_id suffixes).
+		if documentRunsURLPathRE.MatchString(r.URL.Path) {
+			DocumentRunsHandler(srv).ServeHTTP(w, r)
+			return
+		}
+
+		// Delegate attachment requests (/attachments and /attachments/:name
+		// suffixes).
+		if documentAttachmentsURLPathRE.MatchString(r.URL.Path) {
+			DocumentAttachmentsHandler(srv).ServeHTTP(w, r)
+			return
+		}
+
+		// Delegate batch permission requests (/permissions/batch suffix).
+		if documentPermissionsBatchURLPathRE.MatchString(r.URL.Path) {
+			DocumentPermissionsBatchHandler(srv).ServeHTTP(w, r)
+			return
+		}
+
+		// Delegate document lock requests (/lock suffix).
+		if documentLockURLPathRE.MatchString(r.URL.Path) {
+			DocumentLockHandler(srv).ServeHTTP(w, r)
+			return
+		}
+
 		// Parse document ID and request type from the URL path.
 		docID, reqType, err := parseDocumentsURLPath(
 			r.URL.Path, "documents")
@@ -61,7 +122,8 @@ func DocumentHandler(srv server.Server) http.Handler {
 				"path", r.URL.Path,
 				"method", r.Method,
 			)
-			http.Error(w, "Bad request", http.StatusBadRequest)
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				"Bad request")
 			return
 		}
 
@@ -76,7 +138,8 @@ func DocumentHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"doc_id", docID,
 				)
-				http.Error(w, "Document not found", http.StatusNotFound)
+				writeProblem(w, r, http.StatusNotFound, ErrCodeDocumentNotFound,
+					"Document not found")
 				return
 			} else {
 				srv.Logger.Error("error getting document from database",
@@ -85,8 +148,8 @@ func DocumentHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"doc_id", docID,
 				)
-				http.Error(w, "Error requesting document",
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error requesting document")
 				return
 			}
 		}
@@ -108,7 +171,8 @@ func DocumentHandler(srv server.Server) http.Handler {
 				"path", r.URL.Path,
 				"doc_id", docID,
 			)
-			http.Error(w, "Error processing request", http.StatusInternalServerError)
+			writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Error processing request")
 			return
 		}
 
@@ -140,7 +204,8 @@ func DocumentHandler(srv server.Server) http.Handler {
 				"path", r.URL.Path,
 				"doc_id", docID,
 			)
-			http.Error(w, "Error processing request", http.StatusInternalServerError)
+			writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Error processing request")
 			return
 		}
 
@@ -154,7 +219,8 @@ func DocumentHandler(srv server.Server) http.Handler {
 				"path", r.URL.Path,
 				"doc_id", docID,
 			)
-			http.Error(w, "Document not found", http.StatusNotFound)
+			writeProblem(w, r, http.StatusNotFound, ErrCodeDocumentNotFound,
+				"Document not found")
 			return
 		}
 
@@ -171,7 +237,11 @@ func DocumentHandler(srv server.Server) http.Handler {
 				"path", r.URL.Path,
 				"method", r.Method,
 			)
-			http.Error(w, "Bad request", http.StatusBadRequest)
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				"Bad request")
+			return
+		case activityDocumentSubcollectionRequestType:
+			documentActivityHandler(w, r, srv, model)
 			return
 		}
 
@@ -179,95 +249,73 @@ func DocumentHandler(srv server.Server) http.Handler {
 		case "GET":
 			now := time.Now()
 
-			// Get document metadata from workspace provider so we can return the latest modified time.
-			providerID := fmt.Sprintf("google:%s", docID)
-			docMeta, err := srv.WorkspaceProvider.GetDocument(r.Context(), providerID)
+			docObj, modifiedTime, err := buildDocumentResponse(
+				r.Context(), srv, docID, doc, &model)
 			if err != nil {
-				srv.Logger.Error("error getting document metadata from workspace",
+				srv.Logger.Error("error building document response",
 					"error", err,
-					"path", r.URL.Path,
 					"method", r.Method,
+					"path", r.URL.Path,
 					"doc_id", docID,
 				)
-				http.Error(w,
-					"Error requesting document", http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error requesting document")
 				return
 			}
 
-			// Use modified time from metadata
-			modifiedTime := docMeta.ModifiedTime
-			doc.ModifiedTime = modifiedTime.Unix()
-
-			// Convert document to Algolia object because this is how it is expected
-			// by the frontend.
-			docObj, err := doc.ToAlgoliaObject(false)
+			body, err := json.Marshal(docObj)
 			if err != nil {
-				srv.Logger.Error("error converting document to Algolia object",
+				srv.Logger.Error("error encoding document",
 					"error", err,
-					"method", r.Method,
-					"path", r.URL.Path,
 					"doc_id", docID,
 				)
-				http.Error(w, "Error processing request",
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error processing request")
 				return
 			}
 
-			// Get projects associated with the document.
-			projs, err := model.GetProjects(srv.DB)
-			if err != nil {
-				srv.Logger.Error("error getting projects associated with document",
-					"error", err,
+			// Write response, or 304 Not Modified if the client already has the
+			// current representation.
+			if checkIfNoneMatch(w, r, computeETag(body, modifiedTime)) {
+				srv.Logger.Debug("document not modified",
+					"doc_id", docID,
 					"method", r.Method,
 					"path", r.URL.Path,
-					"doc_id", docID,
 				)
-				http.Error(w, "Error processing request",
-					http.StatusInternalServerError)
-				return
-			}
-			projIDs := make([]int, len(projs))
-			for i, p := range projs {
-				projIDs[i] = int(p.ID)
-			}
-			docObj["projects"] = projIDs
-
-			// Write response.
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusOK)
+			} else {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusOK)
+				if _, err := w.Write(append(body, '\n')); err != nil {
+					srv.Logger.Error("error writing document response",
+						"error", err,
+						"doc_id", docID,
+					)
+					return
+				}
 
-			enc := json.NewEncoder(w)
-			err = enc.Encode(docObj)
-			if err != nil {
-				srv.Logger.Error("error encoding document",
-					"error", err,
+				srv.Logger.Info("retrieved document",
 					"doc_id", docID,
+					"method", r.Method,
+					"path", r.URL.Path,
 				)
-				http.Error(w, "Error processing request",
-					http.StatusInternalServerError)
-				return
 			}
 
-			srv.Logger.Info("retrieved document",
-				"doc_id", docID,
-				"method", r.Method,
-				"path", r.URL.Path,
-			)
-
 			// Request post-processing.
 			go func() {
+				ctx := postProcessingContext(r)
+
 				// Update recently viewed documents if this is a document view event. The
 				// Add-To-Recently-Viewed header is set in the request from the frontend
 				// to differentiate between document views and requests to only retrieve
 				// document metadata.
 				if r.Header.Get("Add-To-Recently-Viewed") != "" {
 					// Get authenticated user's email address.
-					email := pkgauth.MustGetUserEmail(r.Context())
+					email := pkgauth.MustGetUserEmail(ctx)
 
-					if err := updateRecentlyViewedDocs(
-						email, docID, srv.DB, now,
+					if err := activity.RecordView(
+						srv.DB, email, model, now,
 					); err != nil {
-						srv.Logger.Error("error updating recently viewed docs",
+						srv.Logger.Error("error recording document view",
 							"error", err,
 							"doc_id", docID,
 							"method", r.Method,
@@ -278,20 +326,25 @@ func DocumentHandler(srv server.Server) http.Handler {
 			}()
 
 		case "PATCH":
-			// Decode request. The request struct validates that the request only
-			// contains fields that are allowed to be patched.
+			// Decode and validate request. The request struct validates that the
+			// request only contains fields that are allowed to be patched.
 			var req DocumentPatchRequest
-			if err := decodeRequest(r, &req); err != nil {
-				srv.Logger.Error("error decoding document patch request", "error", err)
-				http.Error(w, fmt.Sprintf("Bad request: %q", err),
-					http.StatusBadRequest)
+			if !decodeAndValidateRequest(w, r, srv.Logger, &req) {
 				return
 			}
 
 			// Authorize request.
 			userEmail := pkgauth.MustGetUserEmail(r.Context())
+			principal, err := loadPrincipal(r.Context(), srv.Config, srv.DB, userEmail)
+			if err != nil {
+				respondError(w, r, srv.Logger, http.StatusInternalServerError,
+					"Error authorizing request", "error loading user roles", err,
+					"doc_id", docID,
+				)
+				return
+			}
 			if err := authorizeDocumentPatchRequest(
-				userEmail, *doc, req,
+				principal, *doc, req,
 			); err != nil {
 				srv.Logger.Warn("error authorizing request",
 					"error", err,
@@ -300,83 +353,107 @@ func DocumentHandler(srv server.Server) http.Handler {
 					"doc_id", docID,
 					"user", userEmail,
 				)
-				http.Error(w,
-					fmt.Sprintf("Unauthorized: %v", err), http.StatusForbidden)
+				writeProblem(w, r, http.StatusForbidden, ErrCodeForbidden,
+					fmt.Sprintf("Unauthorized: %v", err))
 				return
 			}
 
-			// Validate owners.
-			if req.Owners != nil {
-				if len(*req.Owners) != 1 {
-					srv.Logger.Warn("invalid number of owners in patch request",
+			// Evaluate If-Match precondition to prevent lost updates. This is only
+			// computed when the header is present because it requires a round trip
+			// to the workspace provider.
+			if r.Header.Get("If-Match") != "" {
+				docObj, modifiedTime, err := buildDocumentResponse(
+					r.Context(), srv, docID, doc, &model)
+				if err != nil {
+					srv.Logger.Error("error building document response for precondition",
+						"error", err,
 						"method", r.Method,
 						"path", r.URL.Path,
-						"doc_id", docID)
-					http.Error(w,
-						"Bad request: invalid number of owners (only 1 allowed)",
-						http.StatusBadRequest)
+						"doc_id", docID,
+					)
+					writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error patching document")
 					return
 				}
-			}
-
-			// Validate custom fields.
-			if req.CustomFields != nil {
-				for _, cf := range *req.CustomFields {
-					cef, ok := doc.CustomEditableFields[cf.Name]
-					if !ok {
-						srv.Logger.Error("custom field not found",
-							"error", err,
-							"method", r.Method,
-							"path", r.URL.Path,
-							"custom_field", cf.Name,
-							"doc_id", docID)
-						http.Error(w, "Bad request: invalid custom field",
-							http.StatusBadRequest)
-						return
-					}
-					if cf.DisplayName != cef.DisplayName {
-						srv.Logger.Error("invalid custom field display name",
-							"error", err,
-							"method", r.Method,
-							"path", r.URL.Path,
-							"custom_field", cf.Name,
-							"custom_field_display_name", cf.DisplayName,
-							"doc_id", docID)
-						http.Error(w, "Bad request: invalid custom field display name",
-							http.StatusBadRequest)
-						return
-					}
-					if cf.Type != cef.Type {
-						srv.Logger.Error("invalid custom field type",
-							"error", err,
-							"method", r.Method,
-							"path", r.URL.Path,
-							"custom_field", cf.Name,
-							"custom_field_type", cf.Type,
-							"doc_id", docID)
-						http.Error(w, "Bad request: invalid custom field type",
-							http.StatusBadRequest)
-						return
-					}
+				body, err := json.Marshal(docObj)
+				if err != nil {
+					srv.Logger.Error("error encoding document for precondition",
+						"error", err,
+						"doc_id", docID,
+					)
+					writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error patching document")
+					return
+				}
+				if checkIfMatch(w, r, computeETag(body, modifiedTime)) {
+					srv.Logger.Warn("document patch precondition failed",
+						"method", r.Method,
+						"path", r.URL.Path,
+						"doc_id", docID,
+						"user", userEmail,
+					)
+					return
 				}
 			}
 
-			// Validate document Status.
-			if req.Status != nil {
-				switch *req.Status {
-				case "Approved":
-				case "In-Review":
-				case "Obsolete":
-				default:
-					srv.Logger.Warn("invalid status",
+			// Validate custom fields against the custom field schemas of the
+			// document type, and normalize their values.
+			if req.CustomFields != nil {
+				cfs, err := doc.ValidateCustomFields(*req.CustomFields)
+				if err != nil {
+					srv.Logger.Error("invalid custom field",
+						"error", err,
 						"method", r.Method,
 						"path", r.URL.Path,
 						"doc_id", docID)
-					http.Error(w, "Bad request: invalid status", http.StatusBadRequest)
+					writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+						fmt.Sprintf("Bad request: %v", err))
+					return
+				}
+				req.CustomFields = &cfs
+			}
+
+			// Documents need the approvals required by the workflow of their
+			// document type to be approved.
+			if req.Status != nil && *req.Status == "Approved" &&
+				doc.Status != "Approved" {
+				dt := findDocumentType(documentTypes(r.Context(), srv), doc.DocType)
+				if dt != nil && len(doc.ApprovedBy) < dt.RequiredApprovals {
+					writeProblem(w, r, http.StatusConflict, ErrCodeConflict,
+						fmt.Sprintf("%s documents need %d approval(s) to be "+
+							"approved, this document has %d",
+							dt.Name, dt.RequiredApprovals, len(doc.ApprovedBy)))
 					return
 				}
 			}
 
+			// Documents also need the review checklist items required by the
+			// workflow of their document type to be checked.
+			if req.Status != nil && *req.Status != doc.Status {
+				unchecked, err := uncheckedRequiredChecklistItems(
+					srv.DB.WithContext(r.Context()),
+					findDocumentType(documentTypes(r.Context(), srv), doc.DocType),
+					model.ID, *req.Status)
+				if err != nil {
+					respondError(w, r, srv.Logger, http.StatusInternalServerError,
+						"Error patching document",
+						"error getting document checklist items", err,
+						"doc_id", docID,
+					)
+					return
+				}
+				if len(unchecked) > 0 {
+					writeProblem(w, r, http.StatusConflict, ErrCodeConflict,
+						uncheckedChecklistProblemDetail(*req.Status, unchecked))
+					return
+				}
+			}
+
+			// Don't continue if the document is locked by another user.
+			if !checkDocumentLock(w, r, srv, model.ID, userEmail) {
+				return
+			}
+
 			// Check if document is locked (Google Docs specific).
 			googleProvider := getGoogleDocsProvider(srv.WorkspaceProvider)
 			if googleProvider != nil {
@@ -388,12 +465,14 @@ func DocumentHandler(srv server.Server) http.Handler {
 						"method", r.Method,
 						"doc_id", docID,
 					)
-					http.Error(w, "Error getting document status", http.StatusNotFound)
+					writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound,
+						"Error getting document status")
 					return
 				}
 				// Don't continue if document is locked.
 				if locked {
-					http.Error(w, "Document is locked", http.StatusLocked)
+					writeProblem(w, r, http.StatusLocked, ErrCodeDocumentLocked,
+						"Document is locked")
 					return
 				}
 			}
@@ -441,83 +520,16 @@ func DocumentHandler(srv server.Server) http.Handler {
 			// Custom fields.
 			if req.CustomFields != nil {
 				for _, cf := range *req.CustomFields {
-					switch cf.Type {
-					case "STRING":
-						if _, ok := cf.Value.(string); ok {
-							if err := doc.UpsertCustomField(cf); err != nil {
-								srv.Logger.Error("error upserting custom string field",
-									"error", err,
-									"method", r.Method,
-									"path", r.URL.Path,
-									"custom_field", cf.Name,
-									"doc_id", docID,
-								)
-								http.Error(w,
-									"Error patching document",
-									http.StatusInternalServerError)
-								return
-							}
-						}
-					case "PEOPLE":
-						if reflect.TypeOf(cf.Value).Kind() != reflect.Slice {
-							srv.Logger.Error("invalid value type for people custom field",
-								"error", err,
-								"method", r.Method,
-								"path", r.URL.Path,
-								"custom_field", cf.Name,
-								"doc_id", docID)
-							http.Error(w,
-								fmt.Sprintf(
-									"Bad request: invalid value type for custom field %q",
-									cf.Name,
-								),
-								http.StatusBadRequest)
-							return
-						}
-						for _, v := range cf.Value.([]any) {
-							if _, ok := v.(string); !ok {
-								srv.Logger.Error("invalid value type for people custom field",
-									"error", err,
-									"method", r.Method,
-									"path", r.URL.Path,
-									"custom_field", cf.Name,
-									"doc_id", docID)
-								http.Error(w,
-									fmt.Sprintf(
-										"Bad request: invalid value type for custom field %q",
-										cf.Name,
-									),
-									http.StatusBadRequest)
-								return
-							}
-						}
-						if err := doc.UpsertCustomField(cf); err != nil {
-							srv.Logger.Error("error upserting custom people field",
-								"error", err,
-								"method", r.Method,
-								"path", r.URL.Path,
-								"custom_field", cf.Name,
-								"doc_id", docID,
-							)
-							http.Error(w,
-								"Error patching document",
-								http.StatusInternalServerError)
-							return
-						}
-					default:
-						srv.Logger.Error("invalid custom field type",
+					if err := doc.UpsertCustomField(cf); err != nil {
+						srv.Logger.Error("error upserting custom field",
 							"error", err,
 							"method", r.Method,
 							"path", r.URL.Path,
 							"custom_field", cf.Name,
-							"custom_field_type", cf.Type,
-							"doc_id", docID)
-						http.Error(w,
-							fmt.Sprintf(
-								"Bad request: invalid type for custom field %q",
-								cf.Name,
-							),
-							http.StatusBadRequest)
+							"doc_id", docID,
+						)
+						writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+							"Error patching document")
 						return
 					}
 				}
@@ -536,8 +548,8 @@ func DocumentHandler(srv server.Server) http.Handler {
 						"path", r.URL.Path,
 						"doc_id", docID,
 						"new_owner", doc.Owners[0])
-					http.Error(w, "Error patching document",
-						http.StatusInternalServerError)
+					writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error patching document")
 					return
 				}
 			}
@@ -556,36 +568,33 @@ func DocumentHandler(srv server.Server) http.Handler {
 
 			// Give new document approvers edit access to the document.
 			providerID := fmt.Sprintf("google:%s", docID)
+			shares := make([]workspace.Share, 0, len(approversToEmail))
 			for _, a := range approversToEmail {
-				if err := srv.WorkspaceProvider.ShareDocument(r.Context(), providerID, a, "writer"); err != nil {
-					srv.Logger.Error("error sharing document with approver",
-						"error", err,
-						"doc_id", docID,
-						"method", r.Method,
-						"path", r.URL.Path,
-						"approver", a)
-					http.Error(w, "Error patching document",
-						http.StatusInternalServerError)
-					return
-				}
+				shares = append(shares, workspace.Share{Email: a, Role: "writer"})
+			}
+			if err := workspace.ShareDocumentBatch(
+				r.Context(), srv.WorkspaceProvider, providerID, shares, 0); err != nil {
+				srv.Logger.Error("error sharing document with approvers",
+					"error", err,
+					"doc_id", docID,
+					"method", r.Method,
+					"path", r.URL.Path)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error patching document")
+				return
 			}
 
-			// Replace the doc header (Google Docs specific).
-			googleUpdater := getGoogleDocsUpdater(srv.WorkspaceProvider)
-			if googleUpdater == nil {
-				srv.Logger.Warn("ReplaceHeader skipped - not using Google Workspace", "doc_id", docID)
-			} else if err := doc.ReplaceHeader(
-				srv.Config.BaseURL, false, googleUpdater,
-			); err != nil {
+			// Replace the doc header.
+			if err := replaceDocumentHeader(r.Context(), srv, doc, false); err != nil {
 				srv.Logger.Error("error replacing document header",
 					"error", err, "doc_id", docID)
-				http.Error(w, "Error patching document",
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error patching document")
 				return
 			}
 
 			// Rename document with new title (Google Docs specific).
-			if googleUpdater != nil {
+			if getGoogleDocsUpdater(srv.WorkspaceProvider) != nil {
 				providerID := fmt.Sprintf("google:%s", docID)
 				srv.WorkspaceProvider.RenameDocument(r.Context(), providerID,
 					fmt.Sprintf("[%s] %s", doc.DocNumber, doc.Title))
@@ -602,8 +611,8 @@ func DocumentHandler(srv server.Server) http.Handler {
 					"path", r.URL.Path,
 					"doc_id", docID,
 				)
-				http.Error(w, "Error patching document",
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error patching document")
 				return
 			} else {
 				// Approvers.
@@ -644,90 +653,17 @@ func DocumentHandler(srv server.Server) http.Handler {
 
 				// Custom fields.
 				if req.CustomFields != nil {
-					for _, cf := range *req.CustomFields {
-						switch cf.Type {
-						case "STRING":
-							if v, ok := cf.Value.(string); ok {
-								model.CustomFields = models.UpsertStringDocumentCustomField(
-									model.CustomFields,
-									doc.DocType,
-									cf.DisplayName,
-									v,
-								)
-							} else {
-								srv.Logger.Error("invalid value type for string custom field",
-									"error", err,
-									"method", r.Method,
-									"path", r.URL.Path,
-									"custom_field", cf.Name,
-									"doc_id", docID)
-								http.Error(w, "Error patching document",
-									http.StatusInternalServerError)
-								return
-							}
-						case "PEOPLE":
-							if reflect.TypeOf(cf.Value).Kind() != reflect.Slice {
-								srv.Logger.Error("invalid value type for people custom field",
-									"error", err,
-									"method", r.Method,
-									"path", r.URL.Path,
-									"custom_field", cf.Name,
-									"doc_id", docID)
-								http.Error(w, "Error patching document",
-									http.StatusInternalServerError)
-								return
-							}
-							cfVal := []string{}
-							for _, v := range cf.Value.([]any) {
-								if v, ok := v.(string); ok {
-									cfVal = append(cfVal, v)
-								} else {
-									srv.Logger.Error("invalid value type for people custom field",
-										"error", err,
-										"method", r.Method,
-										"path", r.URL.Path,
-										"custom_field", cf.Name,
-										"doc_id", docID)
-									http.Error(w, "Error patching document",
-										http.StatusInternalServerError)
-									return
-								}
-							}
-
-							model.CustomFields, err = models.
-								UpsertStringSliceDocumentCustomField(
-									model.CustomFields,
-									doc.DocType,
-									cf.DisplayName,
-									cfVal,
-								)
-							if err != nil {
-								srv.Logger.Error("invalid value type for people custom field",
-									"error", err,
-									"method", r.Method,
-									"path", r.URL.Path,
-									"custom_field", cf.Name,
-									"doc_id", docID)
-								http.Error(w, "Error patching document",
-									http.StatusInternalServerError)
-								return
-							}
-						default:
-							srv.Logger.Error("invalid custom field type",
-								"error", err,
-								"method", r.Method,
-								"path", r.URL.Path,
-								"custom_field", cf.Name,
-								"custom_field_type", cf.Type,
-								"doc_id", docID)
-							http.Error(w,
-								fmt.Sprintf(
-									"Bad request: invalid type for custom field %q",
-									cf.Name,
-								),
-								http.StatusBadRequest)
-							return
-						}
+					model.CustomFields, err = document.UpsertCustomFieldModels(
+						model.CustomFields, doc.DocType, *req.CustomFields)
+					if err != nil {
+						srv.Logger.Error("error upserting custom fields",
+							"error", err,
+							"method", r.Method,
+							"path", r.URL.Path,
+							"doc_id", docID)
+						writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+							"Error patching document")
+						return
 					}
 				}
 				// Make sure all custom fields have the document ID.
@@ -749,6 +685,10 @@ func DocumentHandler(srv server.Server) http.Handler {
 				if req.Status != nil {
 					switch *req.Status {
 					case "Approved":
+						if model.Status != models.ApprovedDocumentStatus {
+							approvedAt := time.Now()
+							model.ApprovedAt = &approvedAt
+						}
 						model.Status = models.ApprovedDocumentStatus
 					case "In-Review":
 						model.Status = models.InReviewDocumentStatus
@@ -779,8 +719,8 @@ func DocumentHandler(srv server.Server) http.Handler {
 							"method", r.Method,
 							"path", r.URL.Path,
 						)
-						http.Error(w, "Error patching document",
-							http.StatusInternalServerError)
+						writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+							"Error patching document")
 						return
 					}
 
@@ -833,10 +773,12 @@ func DocumentHandler(srv server.Server) http.Handler {
 							NewDocumentOwner:  newOwner,
 							OldDocumentOwner:  oldOwner,
 							Product:           doc.Product,
+							Locale: userLocale(
+								r.Context(), srv, doc.Owners[0]),
 						},
 						[]string{doc.Owners[0]},
 						srv.Config.Email.FromAddress,
-						getCompatProvider(srv.WorkspaceProvider),
+						emailSender(srv),
 					); err != nil {
 						srv.Logger.Error("error sending new owner email",
 							"error", err,
@@ -844,8 +786,8 @@ func DocumentHandler(srv server.Server) http.Handler {
 							"path", r.URL.Path,
 							"doc_id", docID,
 						)
-						http.Error(w, "Error patching document",
-							http.StatusInternalServerError)
+						writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+							"Error patching document")
 						return
 					}
 				}
@@ -862,14 +804,16 @@ func DocumentHandler(srv server.Server) http.Handler {
 								"method", r.Method,
 								"path", r.URL.Path,
 							)
-							http.Error(w, "Error patching document",
-								http.StatusInternalServerError)
+							writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+								"Error patching document")
 							return
 						}
 
 						// TODO: use an asynchronous method for sending emails because we
 						// can't currently recover gracefully on a failure here.
 						for _, approverEmail := range approversToEmail {
+							approveURL, requestChangesURL := approvalLinkURLs(
+								r.Context(), srv, docID, approverEmail)
 							err := email.SendReviewRequestedEmail(
 								email.ReviewRequestedEmailData{
 									BaseURL:           srv.Config.BaseURL,
@@ -880,10 +824,14 @@ func DocumentHandler(srv server.Server) http.Handler {
 									Product:           doc.Product,
 									DocumentType:      doc.DocType,
 									DocumentStatus:    doc.Status,
+									ApproveURL:        approveURL,
+									RequestChangesURL: requestChangesURL,
+									Locale: userLocale(
+										r.Context(), srv, approverEmail),
 								},
 								[]string{approverEmail},
 								srv.Config.Email.FromAddress,
-								getCompatProvider(srv.WorkspaceProvider),
+								emailSender(srv),
 							)
 							if err != nil {
 								srv.Logger.Error("error sending approver email",
@@ -892,8 +840,8 @@ func DocumentHandler(srv server.Server) http.Handler {
 									"method", r.Method,
 									"path", r.URL.Path,
 								)
-								http.Error(w, "Error patching document",
-									http.StatusInternalServerError)
+								writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+									"Error patching document")
 								return
 							}
 						}
@@ -913,8 +861,7 @@ func DocumentHandler(srv server.Server) http.Handler {
 						"path", r.URL.Path,
 						"doc_id", docID,
 					)
-					http.Error(w, "Error patching document",
-						http.StatusInternalServerError)
+					writeUpsertProblem(w, r, err, "Error patching document")
 					return
 				}
 			}
@@ -925,124 +872,31 @@ func DocumentHandler(srv server.Server) http.Handler {
 				"method", r.Method,
 				"path", r.URL.Path,
 			)
+			recordActivity(srv, r, userEmail, model, models.EditUserActivityAction)
 
 			// Request post-processing.
-			go func() {
-				// Convert document to search object.
-				docObjMap, err := doc.ToAlgoliaObject(true)
-				if err != nil {
-					srv.Logger.Error("error converting document to search object",
-						"error", err,
-						"method", r.Method,
-						"path", r.URL.Path,
-						"doc_id", docID,
-					)
-					return
-				}
-
-				// Convert map to search.Document via JSON round-trip
-				docObj, err := mapToSearchDocument(docObjMap)
-				if err != nil {
-					srv.Logger.Error("error converting document to search document",
-						"error", err,
-						"method", r.Method,
-						"path", r.URL.Path,
-						"doc_id", docID,
-					)
-					return
-				}
-
-				// Save new modified doc object in search index.
-				ctx := r.Context()
-				if err := srv.SearchProvider.DocumentIndex().Index(ctx, docObj); err != nil {
-					srv.Logger.Error("error saving patched document in search index",
-						"error", err,
-						"method", r.Method,
-						"path", r.URL.Path,
-						"doc_id", docID)
-					return
-				}
-			}()
+			docObj, err := toSearchDocument(doc)
+			if err != nil {
+				srv.Logger.Error("error converting document to search document",
+					"error", err,
+					"method", r.Method,
+					"path", r.URL.Path,
+					"doc_id", docID,
+				)
+				return
+			}
+			enqueueJob(srv, r, indexDocumentJobType, indexDocumentJob{
+				Document: docObj,
+			})
 
 		default:
-			w.WriteHeader(http.StatusMethodNotAllowed)
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
 			return
 		}
 	})
 }
 
-// updateRecentlyViewedDocs updates the recently viewed docs for a user with the
-// provided email address, using the document file ID and viewed at time for a
-// document view event.
-func updateRecentlyViewedDocs(
-	email, docID string, db *gorm.DB, viewedAt time.Time) error {
-	// Get user (if exists).
-	u := models.User{
-		EmailAddress: email,
-	}
-	if err := u.Get(db); err != nil && !errors.Is(
-		err, gorm.ErrRecordNotFound) {
-		return fmt.Errorf("error getting user in database: %w", err)
-	}
-
-	// Get viewed document in database.
-	doc := models.Document{
-		GoogleFileID: docID,
-	}
-	if err := doc.Get(db); err != nil {
-		return fmt.Errorf("error getting viewed document: %w", err)
-	}
-
-	// Find recently viewed documents (excluding the current viewed document).
-	var rvd []models.RecentlyViewedDoc
-	if err := db.Where(&models.RecentlyViewedDoc{UserID: int(u.ID)}).
-		Not("document_id = ?", doc.ID).
-		Limit(9).
-		Order("viewed_at desc").
-		Find(&rvd).Error; err != nil {
-		return fmt.Errorf("error finding recently viewed docs for user: %w", err)
-	}
-
-	// Prepend viewed document to recently viewed documents.
-	rvd = append(
-		[]models.RecentlyViewedDoc{{
-			DocumentID: int(doc.ID),
-			UserID:     int(u.ID),
-		}},
-		rvd...)
-
-	// Make slice of recently viewed document IDs.
-	docIDs := make([]int, len(rvd))
-	for i, d := range rvd {
-		docIDs[i] = d.DocumentID
-	}
-
-	// Get document records for recently viewed documents.
-	var docs []models.Document
-	if err := db.Where("id IN ?", docIDs).Find(&docs).Error; err != nil {
-		return fmt.Errorf("error getting documents: %w", err)
-	}
-
-	// Update user.
-	u.RecentlyViewedDocs = docs
-	if err := u.Upsert(db); err != nil {
-		return fmt.Errorf("error upserting user: %w", err)
-	}
-
-	// Update ViewedAt time for the viewed document.
-	viewedDoc := models.RecentlyViewedDoc{
-		UserID:     int(u.ID),
-		DocumentID: int(doc.ID),
-		ViewedAt:   viewedAt,
-	}
-	if err := db.Updates(&viewedDoc).Error; err != nil {
-		return fmt.Errorf(
-			"error updating recently viewed document in database: %w", err)
-	}
-
-	return nil
-}
-
 // parseDocumentsURLPath parses the document ID and subcollection request type
 // from a documents/drafts API URL path.
 func parseDocumentsURLPath(path, collection string) (
@@ -1070,6 +924,11 @@ func parseDocumentsURLPath(path, collection string) (
 			`^\/api\/v2\/%s\/((?:uuid\/)?[0-9A-Za-z_\-]+)\/shareable$`,
 			collection))
 
+	activityRE := regexp.MustCompile(
+		fmt.Sprintf(
+			`^\/api\/v2\/%s\/((?:uuid\/)?[0-9A-Za-z_\-]+)\/activity$`,
+			collection))
+
 	switch {
 	case noSubcollectionRE.MatchString(path):
 		matches := noSubcollectionRE.FindStringSubmatch(path)
@@ -1101,6 +960,16 @@ func parseDocumentsURLPath(path, collection string) (
 		}
 		return matches[1], shareableDocumentSubcollectionRequestType, nil
 
+	case activityRE.MatchString(path):
+		matches := activityRE.FindStringSubmatch(path)
+		if len(matches) != 2 {
+			return "",
+				activityDocumentSubcollectionRequestType,
+				fmt.Errorf(
+					"wrong number of string submatches for activity subcollection URL path")
+		}
+		return matches[1], activityDocumentSubcollectionRequestType, nil
+
 	default:
 		return "",
 			unspecifiedDocumentSubcollectionRequestType,
@@ -1108,16 +977,81 @@ func parseDocumentsURLPath(path, collection string) (
 	}
 }
 
+// buildDocumentResponse builds the document object returned by GET requests.
+// The latest modified time is fetched from the workspace provider and returned
+// alongside the object so callers can derive an ETag.
+func buildDocumentResponse(
+	ctx context.Context,
+	srv server.Server,
+	docID string,
+	doc *document.Document,
+	model *models.Document,
+) (map[string]any, time.Time, error) {
+	// Get document metadata from workspace provider so we can return the latest
+	// modified time.
+	provider, providerID, err := documentContentProvider(ctx, srv, model, docID)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	docMeta, err := provider.GetDocument(ctx, providerID)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf(
+			"error getting document metadata from workspace: %w", err)
+	}
+
+	// Work on a copy so the caller's document is not modified.
+	d := *doc
+	modifiedTime := docMeta.ModifiedTime
+	d.ModifiedTime = modifiedTime.Unix()
+
+	// Convert document to Algolia object because this is how it is expected by
+	// the frontend.
+	docObj, err := d.ToAlgoliaObject(false)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf(
+			"error converting document to Algolia object: %w", err)
+	}
+
+	// Get projects associated with the document.
+	projs, err := model.GetProjects(srv.DB)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf(
+			"error getting projects associated with document: %w", err)
+	}
+	projIDs := make([]int, len(projs))
+	for i, p := range projs {
+		projIDs[i] = int(p.ID)
+	}
+	docObj["projects"] = projIDs
+
+	// Add the document's migration history so support can see which provider
+	// the document is stored in now. Failures are logged but do not fail the
+	// request.
+	if model.DocumentUUID != nil && !model.DocumentUUID.IsZero() {
+		if err := addMigrationHistory(ctx, srv, *model.DocumentUUID, docObj); err != nil {
+			srv.Logger.Warn("error getting document migration history",
+				"error", err,
+				"doc_id", docID,
+			)
+		}
+	}
+
+	return docObj, modifiedTime, nil
+}
+
 // authorizeDocumentPatchRequest authorizes a PATCH request to a document.
 func authorizeDocumentPatchRequest(
-	userEmail string,
+	principal authz.Principal,
 	doc document.Document,
 	req DocumentPatchRequest,
 ) error {
-	// The document owner can patch any field.
-	if doc.Owners[0] == userEmail {
+	// Document owners and admins can patch any field.
+	if authz.Authorize(
+		principal, authz.ActionDocumentEdit, documentAuthzResource(doc),
+	) == nil {
 		return nil
 	}
+	userEmail := principal.Email
 
 	// Approvers can only patch the Approvers field to remove themselves as an
 	// approver.
@@ -1158,5 +1092,5 @@ func authorizeDocumentPatchRequest(
 		return nil
 	}
 
-	return errors.New("only owners or approvers can patch a document")
+	return errors.New("only owners, admins, or approvers can patch a document")
 }
@@ -5,7 +5,7 @@ import (
 	"net/http"
 
 	"github.com/hashicorp-forge/hermes/internal/config"
-	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+	"github.com/hashicorp-forge/hermes/pkg/authz"
 	"github.com/hashicorp-forge/hermes/pkg/document"
 	"github.com/hashicorp-forge/hermes/pkg/models"
 	"github.com/hashicorp-forge/hermes/pkg/search"
@@ -70,8 +70,8 @@ func documentsResourceRelatedResourcesHandler(
 				"method", r.Method,
 				"doc_id", docID,
 			)
-			http.Error(w, "Error accessing document resources",
-				http.StatusInternalServerError)
+			writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Error accessing document resources")
 			return
 		}
 
@@ -84,8 +84,8 @@ func documentsResourceRelatedResourcesHandler(
 				"method", r.Method,
 				"doc_id", docID,
 			)
-			http.Error(w, "Error accessing document",
-				http.StatusInternalServerError)
+			writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Error accessing document")
 			return
 		}
 
@@ -103,8 +103,8 @@ func documentsResourceRelatedResourcesHandler(
 					"method", r.Method,
 					"doc_id", docID,
 				)
-				http.Error(w, "Error accessing document",
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error accessing document")
 				return
 			}
 
@@ -127,8 +127,8 @@ func documentsResourceRelatedResourcesHandler(
 					"doc_id", docID,
 					"target_doc_id", hdrr.Document.GoogleFileID,
 				)
-				http.Error(w, "Error accessing document",
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error accessing document")
 				return
 			}
 
@@ -153,18 +153,23 @@ func documentsResourceRelatedResourcesHandler(
 				"error", err,
 				"doc_id", docID,
 			)
-			http.Error(w, "Error accessing document", http.StatusInternalServerError)
+			writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Error accessing document")
 			return
 		}
 
 	case "POST":
 		fallthrough
 	case "PUT":
-		// Authorize request (only the document owner can replace related
-		// resources).
-		userEmail := pkgauth.MustGetUserEmail(r.Context())
-		if doc.Owners[0] != userEmail {
-			http.Error(w, "Not a document owner", http.StatusUnauthorized)
+		// Authorize request (only the document owner or an admin can replace
+		// related resources).
+		action := authz.ActionDocumentEdit
+		if doc.Status == "WIP" {
+			action = authz.ActionDraftEdit
+		}
+		if !authorizeRequest(w, r, cfg, db, l,
+			action, documentAuthzResource(doc), "Not a document owner",
+		) {
 			return
 		}
 
@@ -177,7 +182,8 @@ func documentsResourceRelatedResourcesHandler(
 				"method", r.Method,
 				"doc_id", docID,
 			)
-			http.Error(w, "Bad request", http.StatusBadRequest)
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				"Bad request")
 			return
 		}
 
@@ -223,7 +229,8 @@ func documentsResourceRelatedResourcesHandler(
 				"method", r.Method,
 				"doc_id", docID,
 			)
-			http.Error(w, "Error accessing document", http.StatusInternalServerError)
+			writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Error accessing document")
 			return
 		}
 
@@ -234,7 +241,8 @@ func documentsResourceRelatedResourcesHandler(
 		)
 
 	default:
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+			"Method not allowed")
 		return
 	}
 }
@@ -3,6 +3,7 @@ package api
 import (
 	"testing"
 
+	"github.com/hashicorp-forge/hermes/pkg/authz"
 	"github.com/hashicorp-forge/hermes/pkg/document"
 	"github.com/stretchr/testify/assert"
 )
@@ -33,6 +34,12 @@ func TestParseDocumentIDFromURLPath(t *testing.T) {
 			wantReqType: shareableDocumentSubcollectionRequestType,
 			wantDocID:   "doc123",
 		},
+		"good documents collection URL with activity": {
+			path:        "/api/v2/documents/doc123/activity",
+			collection:  "documents",
+			wantReqType: activityDocumentSubcollectionRequestType,
+			wantDocID:   "doc123",
+		},
 		"extra frontslash after related-resources": {
 			path:        "/api/v2/documents/doc123/related-resources/",
 			collection:  "documents",
@@ -65,6 +72,7 @@ func TestParseDocumentIDFromURLPath(t *testing.T) {
 func TestAuthorizeDocumentPatchRequest(t *testing.T) {
 	cases := map[string]struct {
 		userEmail string
+		grants    []authz.Grant
 		doc       document.Document
 		req       DocumentPatchRequest
 		shouldErr bool
@@ -85,6 +93,39 @@ func TestAuthorizeDocumentPatchRequest(t *testing.T) {
 			req:       DocumentPatchRequest{},
 			shouldErr: true,
 		},
+		"site admin should be authorized": {
+			userEmail: "admin@example.com",
+			grants:    []authz.Grant{{Role: authz.RoleSiteAdmin}},
+			doc: document.Document{
+				Owners: []string{"owner@example.com"},
+			},
+			req:       DocumentPatchRequest{},
+			shouldErr: false,
+		},
+		"product lead should be authorized for their product": {
+			userEmail: "lead@example.com",
+			grants: []authz.Grant{
+				{Role: authz.RoleProductLead, Product: "Vault"},
+			},
+			doc: document.Document{
+				Owners:  []string{"owner@example.com"},
+				Product: "Vault",
+			},
+			req:       DocumentPatchRequest{},
+			shouldErr: false,
+		},
+		"product lead should not be authorized for other products": {
+			userEmail: "lead@example.com",
+			grants: []authz.Grant{
+				{Role: authz.RoleProductLead, Product: "Vault"},
+			},
+			doc: document.Document{
+				Owners:  []string{"owner@example.com"},
+				Product: "Consul",
+			},
+			req:       DocumentPatchRequest{},
+			shouldErr: true,
+		},
 		"approver should be authorized with valid patch request": {
 			userEmail: "approver2@example.com",
 			doc: document.Document{
@@ -174,7 +215,8 @@ func TestAuthorizeDocumentPatchRequest(t *testing.T) {
 	for name, c := range cases {
 		t.Run(name, func(t *testing.T) {
 			assert := assert.New(t)
-			err := authorizeDocumentPatchRequest(c.userEmail, c.doc, c.req)
+			err := authorizeDocumentPatchRequest(
+				authz.Principal{Email: c.userEmail, Grants: c.grants}, c.doc, c.req)
 
 			if c.shouldErr {
 				assert.Error(err)
@@ -1,11 +1,11 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
-	"reflect"
 	"strconv"
 	"strings"
 	"time"
@@ -13,33 +13,42 @@ import (
 	"github.com/hashicorp-forge/hermes/internal/config"
 	"github.com/hashicorp-forge/hermes/internal/email"
 	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/pkg/activity"
 	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+	"github.com/hashicorp-forge/hermes/pkg/authz"
+	"github.com/hashicorp-forge/hermes/pkg/database"
 	"github.com/hashicorp-forge/hermes/pkg/document"
 	hcd "github.com/hashicorp-forge/hermes/pkg/hashicorpdocs"
 	"github.com/hashicorp-forge/hermes/pkg/models"
 	"github.com/hashicorp-forge/hermes/pkg/search"
+	"github.com/hashicorp-forge/hermes/pkg/tenant"
 	"github.com/hashicorp-forge/hermes/pkg/workspace"
 	"gorm.io/gorm"
 )
 
 type DraftsRequest struct {
-	Contributors        []string `json:"contributors,omitempty"`
-	DocType             string   `json:"docType,omitempty"`
+	Contributors        []string `json:"contributors,omitempty" validate:"email"`
+	DocType             string   `json:"docType,omitempty" validate:"required"`
 	Product             string   `json:"product,omitempty"`
 	ProductAbbreviation string   `json:"productAbbreviation,omitempty"`
 	Summary             string   `json:"summary,omitempty"`
 	Tags                []string `json:"tags,omitempty"`
-	Title               string   `json:"title"`
+
+	// Template is the name of the template of the document type to create the
+	// draft from (optional). The default template is used if it's empty.
+	Template string `json:"template,omitempty"`
+
+	Title string `json:"title" validate:"required"`
 }
 
 // DraftsPatchRequest contains a subset of drafts fields that are allowed to
 // be updated with a PATCH request.
 type DraftsPatchRequest struct {
-	Approvers      *[]string               `json:"approvers,omitempty"`
-	ApproverGroups *[]string               `json:"approverGroups,omitempty"`
-	Contributors   *[]string               `json:"contributors,omitempty"`
+	Approvers      *[]string               `json:"approvers,omitempty" validate:"email"`
+	ApproverGroups *[]string               `json:"approverGroups,omitempty" validate:"email"`
+	Contributors   *[]string               `json:"contributors,omitempty" validate:"email"`
 	CustomFields   *[]document.CustomField `json:"customFields,omitempty"`
-	Owners         *[]string               `json:"owners,omitempty"`
+	Owners         *[]string               `json:"owners,omitempty" validate:"len=1,email"`
 	Product        *string                 `json:"product,omitempty"`
 	Summary        *string                 `json:"summary,omitempty"`
 	// Tags                []string `json:"tags,omitempty"`
@@ -52,13 +61,14 @@ type DraftsResponse struct {
 
 func DraftsHandler(srv server.Server) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
 		errResp := func(httpCode int, userErrMsg, logErrMsg string, err error) {
 			srv.Logger.Error(logErrMsg,
 				"method", r.Method,
 				"path", r.URL.Path,
 				"error", err,
 			)
-			http.Error(w, userErrMsg, httpCode)
+			writeProblem(w, r, httpCode, errorCodeForStatus(httpCode), userErrMsg)
 		}
 
 		// Authorize request.
@@ -75,44 +85,51 @@ func DraftsHandler(srv server.Server) http.Handler {
 
 		switch r.Method {
 		case "POST":
-			// Decode request.
+			// Decode and validate request.
 			var req DraftsRequest
-			if err := decodeRequest(r, &req); err != nil {
-				srv.Logger.Error("error decoding drafts request", "error", err)
-				http.Error(w, fmt.Sprintf("Bad request: %q", err),
-					http.StatusBadRequest)
+			if !decodeAndValidateRequest(w, r, srv.Logger, &req) {
 				return
 			}
 
 			// Validate document type.
-			if !validateDocType(srv.Config.DocumentTypes.DocumentType, req.DocType) {
+			if !validateDocType(documentTypes(r.Context(), srv), req.DocType) {
 				srv.Logger.Error("invalid document type",
 					"method", r.Method,
 					"path", r.URL.Path,
 					"doc_type", req.DocType,
 				)
-				http.Error(
-					w, "Bad request: invalid document type", http.StatusBadRequest)
+				writeValidationProblem(w, r, ValidationError{{
+					Field: "docType", Message: "is not a valid document type"}})
 				return
 			}
 
-			if req.Title == "" {
-				http.Error(w, "Bad request: title is required", http.StatusBadRequest)
+			// Get doc type template.
+			template, err := resolveDraftTemplate(
+				r.Context(), srv, req.DocType, req.Template)
+			if err != nil {
+				respondError(w, r, srv.Logger, http.StatusInternalServerError,
+					"Error creating document draft",
+					"error getting document type template", err,
+					"doc_type", req.DocType,
+					"template", req.Template,
+				)
+				return
+			}
+			if template == "" && req.Template != "" {
+				writeValidationProblem(w, r, ValidationError{{
+					Field:   "template",
+					Message: "is not a template of the document type",
+				}})
 				return
 			}
-
-			// Get doc type template.
-			template := getDocTypeTemplate(
-				srv.Config.DocumentTypes.DocumentType, req.DocType)
 			if template == "" {
 				srv.Logger.Error("Bad request: no template configured for doc type",
 					"method", r.Method,
 					"path", r.URL.Path,
 					"doc_type", req.DocType,
 				)
-				http.Error(w,
-					"Bad request: no template configured for doc type",
-					http.StatusBadRequest)
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					"Bad request: no template configured for doc type")
 				return
 			}
 
@@ -122,24 +139,24 @@ func DraftsHandler(srv server.Server) http.Handler {
 			}
 			title := fmt.Sprintf("[%s-???] %s", req.ProductAbbreviation, req.Title)
 
-			var (
-				err     error
-				docMeta *workspace.DocumentMetadata
-			)
+			var docMeta *workspace.DocumentMetadata
 
 			// Copy template to new draft file using RFC-084.
-			// Use the appropriate provider prefix based on workspace configuration
-			workspaceProvider := "google" // default for backwards compatibility
-			if srv.Config.Providers != nil && srv.Config.Providers.Workspace != "" {
-				workspaceProvider = srv.Config.Providers.Workspace
+			destFolderID, err := folderLayout(srv).DraftsFolder(r.Context())
+			if err != nil {
+				respondError(w, r, srv.Logger, http.StatusInternalServerError,
+					"Error creating document draft",
+					"error getting drafts folder", err,
+				)
+				return
 			}
+			workspaceProvider := primaryProviderType(srv)
 			templateProviderID := fmt.Sprintf("%s:%s", workspaceProvider, template)
 
-			// Get the appropriate destination folder based on provider
-			destFolderID := srv.Config.GoogleWorkspace.DraftsFolder
-			if workspaceProvider == "local" && srv.Config.LocalWorkspace != nil {
-				destFolderID = srv.Config.LocalWorkspace.DraftsPath
-			}
+			// Undo completed steps if a later step fails so we don't leak orphaned
+			// files, search objects, or database records.
+			cleanup := newSaga("create draft", srv.Logger)
+			defer cleanup.Compensate(r.Context())
 
 			// Use RFC-084 CopyDocument (RFC-084 doesn't support user impersonation directly)
 			docMeta, err = srv.WorkspaceProvider.CopyDocument(
@@ -150,10 +167,10 @@ func DraftsHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"path", r.URL.Path,
 					"template", template,
-					"drafts_folder", srv.Config.GoogleWorkspace.DraftsFolder,
+					"drafts_folder", destFolderID,
 				)
-				http.Error(w, "Error creating document draft",
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error creating document draft")
 				return
 			}
 
@@ -163,6 +180,17 @@ func DraftsHandler(srv server.Server) http.Handler {
 			if idx := strings.Index(fileID, ":"); idx != -1 {
 				fileID = fileID[idx+1:]
 			}
+			cleanup.AddCompensation("delete draft file", func(ctx context.Context) error {
+				return srv.WorkspaceProvider.DeleteDocument(ctx, docMeta.ProviderID)
+			})
+			// Some workspace providers (e.g., local) index new files on creation.
+			cleanup.AddCompensation("delete draft search object", func(ctx context.Context) error {
+				err := srv.SearchProvider.DraftIndex().Delete(ctx, fileID)
+				if errors.Is(err, search.ErrNotFound) {
+					return nil
+				}
+				return err
+			})
 
 			// Build created date.
 			ct := docMeta.CreatedTime
@@ -181,7 +209,7 @@ func DraftsHandler(srv server.Server) http.Handler {
 				)
 			}
 			if len(people) > 0 && people[0].PhotoURL != "" {
-				op = append(op, people[0].PhotoURL)
+				op = append(op, personPhotoURL(userEmail))
 			}
 
 			// Create tag
@@ -237,8 +265,8 @@ func DraftsHandler(srv server.Server) http.Handler {
 							"error", err,
 							"doc_id", fileID,
 						)
-						http.Error(w, "Error creating document draft",
-							http.StatusInternalServerError)
+						writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+							"Error creating document draft")
 						return
 					}
 
@@ -249,21 +277,16 @@ func DraftsHandler(srv server.Server) http.Handler {
 				}
 			}
 
-			// Replace the doc header (Google Docs specific).
-			googleUpdater := getGoogleDocsUpdater(srv.WorkspaceProvider)
-			if googleUpdater == nil {
-				srv.Logger.Warn("ReplaceHeader skipped - not using Google Workspace", "doc_id", fileID)
-			} else if err = doc.ReplaceHeader(
-				srv.Config.BaseURL, true, googleUpdater,
-			); err != nil {
+			// Replace the doc header.
+			if err = replaceDocumentHeader(r.Context(), srv, doc, true); err != nil {
 				srv.Logger.Error("error replacing draft doc header",
 					"error", err,
 					"method", r.Method,
 					"path", r.URL.Path,
 					"doc_id", fileID,
 				)
-				http.Error(w, "Error creating document draft",
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error creating document draft")
 				return
 			}
 
@@ -289,9 +312,10 @@ func DraftsHandler(srv server.Server) http.Handler {
 				Product: models.Product{
 					Name: req.Product,
 				},
-				Status:  models.WIPDocumentStatus,
-				Summary: &req.Summary,
-				Title:   req.Title,
+				Status:   models.WIPDocumentStatus,
+				Summary:  &req.Summary,
+				TenantID: tenant.IDFromContext(r.Context()),
+				Title:    req.Title,
 			}
 			if err := model.Create(srv.DB); err != nil {
 				srv.Logger.Error("error creating document in database",
@@ -300,15 +324,24 @@ func DraftsHandler(srv server.Server) http.Handler {
 					"path", r.URL.Path,
 					"doc_id", fileID,
 				)
-				http.Error(w, "Error creating document draft",
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error creating document draft")
 				return
 			}
+			cleanup.AddCompensation("delete draft database record", func(ctx context.Context) error {
+				return model.Purge(srv.DB.WithContext(ctx))
+			})
 
-			// Share document with the owner
+			// Share document with the owner and contributors in one batch, so
+			// providers without a batch API share with them concurrently.
 			// Skip sharing for local workspace (not supported)
-			if err := srv.WorkspaceProvider.ShareDocument(r.Context(), docMeta.ProviderID, userEmail, "writer"); err != nil {
-				// Only log as warning for local workspace, not an error
+			shares := []workspace.Share{{Email: userEmail, Role: "writer"}}
+			for _, c := range req.Contributors {
+				shares = append(shares, workspace.Share{Email: c, Role: "writer"})
+			}
+			if err := workspace.ShareDocumentBatch(r.Context(), srv.WorkspaceProvider,
+				docMeta.ProviderID, shares, 0); err != nil {
+				// Only log as debug for local workspace, not an error
 				if workspaceProvider == "local" {
 					srv.Logger.Debug("skipping document sharing for local workspace",
 						"method", r.Method,
@@ -316,48 +349,20 @@ func DraftsHandler(srv server.Server) http.Handler {
 						"doc_id", fileID,
 					)
 				} else {
-					srv.Logger.Error("error sharing document with the owner",
+					srv.Logger.Error("error sharing document with the owner and contributors",
 						"error", err,
 						"method", r.Method,
 						"path", r.URL.Path,
 						"doc_id", fileID,
 					)
-					http.Error(w, "Error creating document draft",
-						http.StatusInternalServerError)
+					writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error creating document draft")
 					return
 				}
 			}
 
-			// Share document with contributors.
-			// Google Drive API limitation is that you can only share files with one
-			// user at a time.
-			// Skip sharing for local workspace (not supported)
-			for _, c := range req.Contributors {
-				if err := srv.WorkspaceProvider.ShareDocument(r.Context(), docMeta.ProviderID, c, "writer"); err != nil {
-					// Only log as warning for local workspace, not an error
-					if workspaceProvider == "local" {
-						srv.Logger.Debug("skipping contributor sharing for local workspace",
-							"method", r.Method,
-							"path", r.URL.Path,
-							"doc_id", fileID,
-							"contributor", c,
-						)
-						continue
-					}
-					srv.Logger.Error("error sharing file with the contributor",
-						"error", err,
-						"method", r.Method,
-						"path", r.URL.Path,
-						"doc_id", fileID,
-						"contributor", c,
-					)
-					http.Error(w, "Error creating document draft",
-						http.StatusInternalServerError)
-					return
-				}
-			}
-
-			// TODO: Delete draft file in the case of an error.
+			// All steps succeeded; keep the draft.
+			cleanup.Complete()
 
 			// Write response.
 			w.Header().Set("Content-Type", "application/json")
@@ -376,8 +381,8 @@ func DraftsHandler(srv server.Server) http.Handler {
 					"path", r.URL.Path,
 					"doc_id", fileID,
 				)
-				http.Error(w, "Error creating document draft",
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error creating document draft")
 				return
 			}
 
@@ -388,99 +393,28 @@ func DraftsHandler(srv server.Server) http.Handler {
 			)
 
 			// Request post-processing.
-			go func() {
-				// Convert document.Document to search.Document for indexing
-				searchDoc := &search.Document{
-					ObjectID:     doc.ObjectID,
-					DocID:        doc.ObjectID,
-					Title:        doc.Title,
-					DocNumber:    doc.DocNumber,
-					DocType:      doc.DocType,
-					Product:      doc.Product,
-					Status:       doc.Status,
-					Owners:       doc.Owners,
-					Contributors: doc.Contributors,
-					Approvers:    doc.Approvers,
-					Summary:      doc.Summary,
-					Content:      doc.Content,
-					CreatedTime:  doc.CreatedTime,
-					ModifiedTime: doc.ModifiedTime,
-				}
-
-				// Save document object in search index.
-				err := srv.SearchProvider.DraftIndex().Index(r.Context(), searchDoc)
-				if err != nil {
-					srv.Logger.Error("error saving draft doc in search index",
-						"error", err,
-						"method", r.Method,
-						"path", r.URL.Path,
-						"doc_id", fileID,
-					)
-					http.Error(w, "Error creating document draft",
-						http.StatusInternalServerError)
-					return
-				}
-
-				// Compare search index and database documents to find data inconsistencies.
-				// Get document object from search index.
-				indexedDoc, err := srv.SearchProvider.DraftIndex().GetObject(r.Context(), fileID)
-				if err != nil {
-					srv.Logger.Error("error getting search object for data comparison",
-						"error", err,
-						"method", r.Method,
-						"path", r.URL.Path,
-						"doc_id", fileID,
-					)
-					return
-				}
-
-				// Convert search.Document to map for comparison
-				algoDocBytes, _ := json.Marshal(indexedDoc)
-				var algoDoc map[string]any
-				json.Unmarshal(algoDocBytes, &algoDoc)
-
-				// Get document from database.
-				dbDoc := models.Document{
-					GoogleFileID: fileID,
-				}
-				if err := dbDoc.Get(srv.DB); err != nil {
-					srv.Logger.Error(
-						"error getting document from database for data comparison",
-						"error", err,
-						"path", r.URL.Path,
-						"method", r.Method,
-						"doc_id", fileID,
-					)
-					return
-				}
-				// Get all reviews for the document.
-				var reviews models.DocumentReviews
-				if err := reviews.Find(srv.DB, models.DocumentReview{
-					Document: models.Document{
-						GoogleFileID: fileID,
-					},
-				}); err != nil {
-					srv.Logger.Error(
-						"error getting all reviews for document for data comparison",
-						"error", err,
-						"method", r.Method,
-						"path", r.URL.Path,
-						"doc_id", fileID,
-					)
-					return
-				}
-				if err := CompareAlgoliaAndDatabaseDocument(
-					algoDoc, dbDoc, reviews, srv.Config.DocumentTypes.DocumentType,
-				); err != nil {
-					srv.Logger.Warn(
-						"inconsistencies detected between Algolia and database docs",
-						"error", err,
-						"method", r.Method,
-						"path", r.URL.Path,
-						"doc_id", fileID,
-					)
-				}
-			}()
+			// Convert document.Document to search.Document for indexing.
+			searchDoc := &search.Document{
+				ObjectID:     doc.ObjectID,
+				DocID:        doc.ObjectID,
+				Title:        doc.Title,
+				DocNumber:    doc.DocNumber,
+				DocType:      doc.DocType,
+				Product:      doc.Product,
+				Status:       doc.Status,
+				Owners:       doc.Owners,
+				Contributors: doc.Contributors,
+				Approvers:    doc.Approvers,
+				Summary:      doc.Summary,
+				Content:      doc.Content,
+				CreatedTime:  doc.CreatedTime,
+				ModifiedTime: doc.ModifiedTime,
+			}
+			enqueueJob(srv, r, indexDocumentJobType, indexDocumentJob{
+				Document: searchDoc,
+				Draft:    true,
+				Compare:  true,
+			})
 
 		case "GET":
 			// Try database-first approach for better testability
@@ -491,16 +425,18 @@ func DraftsHandler(srv server.Server) http.Handler {
 			hasSearchParams := q.Get("facetFilters") != "" || q.Get("facets") != "" || q.Get("hitsPerPage") != ""
 
 			if !hasSearchParams && srv.DB != nil {
-				// Simple database query for drafts owned by or contributed to by user
-				drafts, err := getDraftsFromDatabase(srv.DB, userEmail)
+				// Simple database query for drafts owned by or contributed to by
+				// user, served from the read replica if one is configured.
+				drafts, err := getDraftsFromDatabase(
+					database.Replica(srv.DB), userEmail)
 				if err != nil {
 					srv.Logger.Error("error retrieving drafts from database",
 						"error", err,
 						"method", r.Method,
 						"path", r.URL.Path,
 					)
-					http.Error(w, "Error retrieving document drafts",
-						http.StatusInternalServerError)
+					writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error retrieving document drafts")
 					return
 				}
 
@@ -556,8 +492,8 @@ func DraftsHandler(srv server.Server) http.Handler {
 					"path", r.URL.Path,
 					"hits_per_page", hitsPerPageStr,
 				)
-				http.Error(w, "Error retrieving document drafts",
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error retrieving document drafts")
 				return
 			}
 			_, err = strconv.Atoi(maxValuesPerFacetStr)
@@ -568,8 +504,8 @@ func DraftsHandler(srv server.Server) http.Handler {
 					"path", r.URL.Path,
 					"max_values_per_facet", maxValuesPerFacetStr,
 				)
-				http.Error(w, "Error retrieving document drafts",
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error retrieving document drafts")
 				return
 			}
 			page, err := strconv.Atoi(pageStr)
@@ -580,8 +516,8 @@ func DraftsHandler(srv server.Server) http.Handler {
 					"path", r.URL.Path,
 					"page", pageStr,
 				)
-				http.Error(w, "Error retrieving document drafts",
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error retrieving document drafts")
 				return
 			}
 
@@ -604,16 +540,11 @@ func DraftsHandler(srv server.Server) http.Handler {
 				}
 			}
 
-			// Add owner/contributor filter
-			if filters["owners"] == nil {
-				filters["owners"] = []string{}
-			}
-			filters["owners"] = append(filters["owners"], userEmail)
-
-			if filters["contributors"] == nil {
-				filters["contributors"] = []string{}
-			}
-			filters["contributors"] = append(filters["contributors"], userEmail)
+			// List the user's drafts: drafts they own or contribute to, but not
+			// drafts of other users shared with everyone. The search provider
+			// limits drafts to the ones the user can view, regardless of the
+			// filters in the request.
+			filters[search.VisibilityFilter] = []string{userEmail}
 
 			searchQuery := &search.SearchQuery{
 				Query:     "",
@@ -633,8 +564,8 @@ func DraftsHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"path", r.URL.Path,
 				)
-				http.Error(w, "Error retrieving document drafts",
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error retrieving document drafts")
 				return
 			} // Write response.
 			w.Header().Set("Content-Type", "application/json")
@@ -648,8 +579,8 @@ func DraftsHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"path", r.URL.Path,
 				)
-				http.Error(w, "Error requesting document draft",
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error requesting document draft")
 				return
 			}
 
@@ -660,7 +591,8 @@ func DraftsHandler(srv server.Server) http.Handler {
 			)
 
 		default:
-			w.WriteHeader(http.StatusMethodNotAllowed)
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
 			return
 		}
 	})
@@ -677,6 +609,7 @@ func getWorkspaceProviderID(cfg *config.Config, docID string) string {
 
 func DraftsDocumentHandler(srv server.Server) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
 		// Parse document ID and request type from the URL path.
 		docID, reqType, err := parseDocumentsURLPath(
 			r.URL.Path, "drafts")
@@ -686,7 +619,8 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 				"path", r.URL.Path,
 				"method", r.Method,
 			)
-			http.Error(w, "Bad request", http.StatusBadRequest)
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				"Bad request")
 			return
 		}
 
@@ -701,7 +635,8 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"doc_id", docID,
 				)
-				http.Error(w, "Draft not found", http.StatusNotFound)
+				writeProblem(w, r, http.StatusNotFound, ErrCodeDraftNotFound,
+					"Draft not found")
 				return
 			} else {
 				srv.Logger.Error("error getting document draft from database",
@@ -710,8 +645,8 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"doc_id", docID,
 				)
-				http.Error(w, "Error requesting document draft",
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error requesting document draft")
 				return
 			}
 		}
@@ -758,32 +693,28 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 				"path", r.URL.Path,
 				"doc_id", docID,
 			)
-			http.Error(w, "Error accessing draft document",
-				http.StatusInternalServerError)
+			writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Error accessing draft document")
 			return
 		}
 
 		// Make sure document is a draft.
 		if doc.Status != "WIP" {
-			http.Error(w, "Draft not found", http.StatusNotFound)
+			writeProblem(w, r, http.StatusNotFound, ErrCodeDraftNotFound,
+				"Draft not found")
 			return
 		}
 
-		// Authorize request (only allow owners or contributors to get past this
-		// point in the handler). We further authorize some methods later that
-		// require owner access only.
+		// Authorize request (only allow users who can view the draft to get past
+		// this point in the handler). We further authorize some methods later
+		// that require owner access only.
 		userEmail := pkgauth.MustGetUserEmail(r.Context())
-		var isOwner, isContributor bool
-		if len(doc.Owners) > 0 && doc.Owners[0] == userEmail {
-			isOwner = true
-		}
-		if contains(doc.Contributors, userEmail) {
-			isContributor = true
-		}
-		if !isOwner && !isContributor && !model.ShareableAsDraft {
-			http.Error(w,
-				"Only owners or contributors can access a non-shared draft document",
-				http.StatusUnauthorized)
+		authzRes := documentAuthzResource(*doc)
+		authzRes.Shared = model.ShareableAsDraft
+		if !authorizeRequest(w, r, srv.Config, srv.DB, srv.Logger,
+			authz.ActionDraftView, authzRes,
+			"Only owners or contributors can access a non-shared draft document",
+		) {
 			return
 		}
 
@@ -795,8 +726,10 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 				w, r, docID, *doc, srv.Config, srv.Logger, srv.SearchProvider, srv.DB)
 			return
 		case shareableDocumentSubcollectionRequestType:
-			draftsShareableHandler(w, r, docID, *doc, *srv.Config, srv.Logger,
-				srv.SearchProvider, getCompatProvider(srv.WorkspaceProvider), srv.DB)
+			draftsShareableHandler(w, r, srv, docID, *doc)
+			return
+		case activityDocumentSubcollectionRequestType:
+			documentActivityHandler(w, r, srv, model)
 			return
 		}
 
@@ -814,8 +747,8 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"doc_id", docID,
 				)
-				http.Error(w,
-					"Error requesting document draft", http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error requesting document draft")
 				return
 			}
 
@@ -832,8 +765,8 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 					"path", r.URL.Path,
 					"doc_id", docID,
 				)
-				http.Error(w, "Error getting document draft",
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error getting document draft")
 				return
 			}
 
@@ -850,8 +783,8 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 					"path", r.URL.Path,
 					"doc_id", docID,
 				)
-				http.Error(w, "Error requesting document draft",
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error requesting document draft")
 				return
 			}
 
@@ -862,128 +795,61 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 			)
 
 			// Request post-processing.
-			go func() {
-				// Update recently viewed documents if this is a document view event. The
-				// Add-To-Recently-Viewed header is set in the request from the frontend
-				// to differentiate between document views and requests to only retrieve
-				// document metadata.
-				if r.Header.Get("Add-To-Recently-Viewed") != "" {
-					if err := updateRecentlyViewedDocs(
-						userEmail, docID, srv.DB, now,
+			// Update recently viewed documents if this is a document view event. The
+			// Add-To-Recently-Viewed header is set in the request from the frontend
+			// to differentiate between document views and requests to only retrieve
+			// document metadata.
+			if r.Header.Get("Add-To-Recently-Viewed") != "" {
+				go func() {
+					if err := activity.RecordView(
+						srv.DB, userEmail, model, now,
 					); err != nil {
-						srv.Logger.Error("error updating recently viewed docs",
+						srv.Logger.Error("error recording document view",
 							"error", err,
 							"path", r.URL.Path,
 							"method", r.Method,
 							"doc_id", docID,
 						)
 					}
-				}
+				}()
+			}
 
-				// Compare search index and database documents to find data inconsistencies.
-				// Get document object from search index.
-				indexedDoc, err := srv.SearchProvider.DraftIndex().GetObject(r.Context(), docID)
-				if err != nil {
-					// Only warn because we might be in the process of saving the search index
-					// object for a new draft.
-					srv.Logger.Warn("error getting search object for data comparison",
-						"error", err,
-						"method", r.Method,
-						"path", r.URL.Path,
-						"doc_id", docID,
-					)
-					return
-				}
-
-				// Convert search.Document to map for comparison
-				algoDocBytes, _ := json.Marshal(indexedDoc)
-				var algoDoc map[string]any
-				json.Unmarshal(algoDocBytes, &algoDoc)
-				// Get document from database.
-				dbDoc := models.Document{
-					GoogleFileID: docID,
-				}
-				if err := dbDoc.Get(srv.DB); err != nil {
-					srv.Logger.Error(
-						"error getting document from database for data comparison",
-						"error", err,
-						"path", r.URL.Path,
-						"method", r.Method,
-						"doc_id", docID,
-					)
-					return
-				}
-				// Get all reviews for the document.
-				var reviews models.DocumentReviews
-				if err := reviews.Find(srv.DB, models.DocumentReview{
-					Document: models.Document{
-						GoogleFileID: docID,
-					},
-				}); err != nil {
-					srv.Logger.Error(
-						"error getting all reviews for document for data comparison",
-						"error", err,
-						"method", r.Method,
-						"path", r.URL.Path,
-						"doc_id", docID,
-					)
-					return
-				}
-				if err := CompareAlgoliaAndDatabaseDocument(
-					algoDoc, dbDoc, reviews, srv.Config.DocumentTypes.DocumentType,
-				); err != nil {
-					srv.Logger.Warn(
-						"inconsistencies detected between Algolia and database docs",
-						"error", err,
-						"method", r.Method,
-						"path", r.URL.Path,
-						"doc_id", docID,
-					)
-				}
-			}()
+			// Compare search index and database documents to find data inconsistencies.
+			enqueueJob(srv, r, compareDocumentJobType, compareDocumentJob{
+				DocID: docID,
+				Draft: true,
+			})
 
 		case "DELETE":
 			// Authorize request.
-			if !isOwner {
-				http.Error(w,
-					"Only owners can delete a draft document",
-					http.StatusUnauthorized)
+			if !authorizeRequest(w, r, srv.Config, srv.DB, srv.Logger,
+				authz.ActionDraftDelete, authzRes,
+				"Only owners can delete a draft document",
+			) {
 				return
 			}
 
-			// Delete document in workspace provider.
-			providerID := getWorkspaceProviderID(srv.Config, docID)
-			err = srv.WorkspaceProvider.DeleteDocument(r.Context(), providerID)
-			if err != nil {
-				srv.Logger.Error(
-					"error deleting document",
-					"error", err,
-					"method", r.Method,
-					"path", r.URL.Path,
-					"doc_id", docID,
-				)
-				http.Error(w, "Error deleting document draft",
-					http.StatusInternalServerError)
-				return
+			// Soft delete document in the database. The document is kept in the
+			// workspace provider until it is purged after the recovery window,
+			// so site admins can restore it.
+			d := models.Document{
+				GoogleFileID: docID,
 			}
-
-			// Delete object from search index.
-			err := srv.SearchProvider.DraftIndex().Delete(r.Context(), docID)
-			if err != nil {
+			if err := d.Delete(srv.DB); err != nil {
 				srv.Logger.Error(
-					"error deleting document draft from search index",
+					"error deleting document draft in database",
 					"error", err,
 					"method", r.Method,
 					"path", r.URL.Path,
 					"doc_id", docID,
 				)
-				http.Error(w, "Error deleting document draft",
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error deleting document draft")
 				return
 			}
 
-			// Note: Delete is synchronous with the new provider API
-			if false { // Remove the old Wait() logic
+			// Delete object from search index.
+			if err := srv.SearchProvider.DraftIndex().Delete(r.Context(), docID); err != nil {
 				srv.Logger.Error(
 					"error deleting document draft from search index",
 					"error", err,
@@ -991,25 +857,8 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 					"path", r.URL.Path,
 					"doc_id", docID,
 				)
-				http.Error(w, "Error deleting document draft",
-					http.StatusInternalServerError)
-				return
-			}
-
-			// Delete document in the database.
-			d := models.Document{
-				GoogleFileID: docID,
-			}
-			if err := d.Delete(srv.DB); err != nil {
-				srv.Logger.Error(
-					"error deleting document draft in database",
-					"error", err,
-					"method", r.Method,
-					"path", r.URL.Path,
-					"doc_id", docID,
-				)
-				http.Error(w, "Error deleting document draft",
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error deleting document draft")
 				return
 			}
 
@@ -1031,44 +880,27 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 					"path", r.URL.Path,
 					"doc_id", docID,
 				)
-				http.Error(w, "Error deleting document draft",
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error deleting document draft")
 				return
 			}
 
 		case "PATCH":
 			// Authorize request.
-			if !isOwner {
-				http.Error(w,
-					"Only owners can patch a draft document",
-					http.StatusUnauthorized)
+			if !authorizeRequest(w, r, srv.Config, srv.DB, srv.Logger,
+				authz.ActionDraftEdit, authzRes,
+				"Only owners can patch a draft document",
+			) {
 				return
 			}
 
-			// Decode request. The request struct validates that the request only
-			// contains fields that are allowed to be patched.
+			// Decode and validate request. The request struct validates that the
+			// request only contains fields that are allowed to be patched.
 			var req DraftsPatchRequest
-			if err := decodeRequest(r, &req); err != nil {
-				srv.Logger.Error("error decoding draft patch request", "error", err)
-				http.Error(w, fmt.Sprintf("Bad request: %q", err),
-					http.StatusBadRequest)
+			if !decodeAndValidateRequest(w, r, srv.Logger, &req) {
 				return
 			}
 
-			// Validate owners.
-			if req.Owners != nil {
-				if len(*req.Owners) != 1 {
-					srv.Logger.Warn("invalid number of owners in patch request",
-						"method", r.Method,
-						"path", r.URL.Path,
-						"doc_id", docID)
-					http.Error(w,
-						"Bad request: invalid number of owners (only 1 allowed)",
-						http.StatusBadRequest)
-					return
-				}
-			}
-
 			// Validate product if it is in the patch request.
 			var productAbbreviation string
 			if req.Product != nil && *req.Product != "" {
@@ -1080,8 +912,8 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 						"path", r.URL.Path,
 						"product", req.Product,
 						"doc_id", docID)
-					http.Error(w, "Bad request: invalid product",
-						http.StatusBadRequest)
+					writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+						"Bad request: invalid product")
 					return
 				}
 
@@ -1090,46 +922,26 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 				productAbbreviation = p.Abbreviation
 			}
 
-			// Validate custom fields.
+			// Validate custom fields against the custom field schemas of the
+			// document type, and normalize their values.
 			if req.CustomFields != nil {
-				for _, cf := range *req.CustomFields {
-					cef, ok := doc.CustomEditableFields[cf.Name]
-					if !ok {
-						srv.Logger.Error("custom field not found",
-							"error", err,
-							"method", r.Method,
-							"path", r.URL.Path,
-							"custom_field", cf.Name,
-							"doc_id", docID)
-						http.Error(w, "Bad request: invalid custom field",
-							http.StatusBadRequest)
-						return
-					}
-					if cf.DisplayName != cef.DisplayName {
-						srv.Logger.Error("invalid custom field display name",
-							"error", err,
-							"method", r.Method,
-							"path", r.URL.Path,
-							"custom_field", cf.Name,
-							"custom_field_display_name", cf.DisplayName,
-							"doc_id", docID)
-						http.Error(w, "Bad request: invalid custom field display name",
-							http.StatusBadRequest)
-						return
-					}
-					if cf.Type != cef.Type {
-						srv.Logger.Error("invalid custom field type",
-							"error", err,
-							"method", r.Method,
-							"path", r.URL.Path,
-							"custom_field", cf.Name,
-							"custom_field_type", cf.Type,
-							"doc_id", docID)
-						http.Error(w, "Bad request: invalid custom field type",
-							http.StatusBadRequest)
-						return
-					}
+				cfs, err := doc.ValidateCustomFields(*req.CustomFields)
+				if err != nil {
+					srv.Logger.Error("invalid custom field",
+						"error", err,
+						"method", r.Method,
+						"path", r.URL.Path,
+						"doc_id", docID)
+					writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+						fmt.Sprintf("Bad request: %v", err))
+					return
 				}
+				req.CustomFields = &cfs
+			}
+
+			// Don't continue if the document is locked by another user.
+			if !checkDocumentLock(w, r, srv, model.ID, userEmail) {
+				return
 			}
 
 			// Check if document is locked (Google Docs specific).
@@ -1143,12 +955,14 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 						"path", r.URL.Path,
 						"doc_id", docID,
 					)
-					http.Error(w, "Error getting document status", http.StatusNotFound)
+					writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound,
+						"Error getting document status")
 					return
 				}
 				// Don't continue if document is locked.
 				if locked {
-					http.Error(w, "Document is locked", http.StatusLocked)
+					writeProblem(w, r, http.StatusLocked, ErrCodeDocumentLocked,
+						"Document is locked")
 					return
 				}
 			}
@@ -1181,22 +995,22 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 				}
 			}
 
-			// Share file with contributors.
-			// Google Drive API limitation is that you can only share files with one
-			// user at a time.
+			// Share file with contributors in one batch.
 			providerID := getWorkspaceProviderID(srv.Config, docID)
+			shares := make([]workspace.Share, 0, len(contributorsToAddSharing))
 			for _, c := range contributorsToAddSharing {
-				if err := srv.WorkspaceProvider.ShareDocument(r.Context(), providerID, c, "writer"); err != nil {
-					srv.Logger.Error("error sharing file with the contributor",
-						"error", err,
-						"method", r.Method,
-						"path", r.URL.Path,
-						"doc_id", docID,
-						"contributor", c)
-					http.Error(w, "Error patching document draft",
-						http.StatusInternalServerError)
-					return
-				}
+				shares = append(shares, workspace.Share{Email: c, Role: "writer"})
+			}
+			if err := workspace.ShareDocumentBatch(
+				r.Context(), srv.WorkspaceProvider, providerID, shares, 0); err != nil {
+				srv.Logger.Error("error sharing file with contributors",
+					"error", err,
+					"method", r.Method,
+					"path", r.URL.Path,
+					"doc_id", docID)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error patching document draft")
+				return
 			}
 			if len(contributorsToAddSharing) > 0 {
 				srv.Logger.Info("shared document with contributors",
@@ -1220,8 +1034,8 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 							"path", r.URL.Path,
 							"doc_id", docID,
 							"contributor", c)
-						http.Error(w, "Error patching document draft",
-							http.StatusInternalServerError)
+						writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+							"Error patching document draft")
 						return
 					}
 				}
@@ -1279,134 +1093,32 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 			// Custom fields.
 			if req.CustomFields != nil {
 				for _, cf := range *req.CustomFields {
-					switch cf.Type {
-					case "STRING":
-						if v, ok := cf.Value.(string); ok {
-							if err := doc.UpsertCustomField(cf); err != nil {
-								srv.Logger.Error("error upserting custom string field",
-									"error", err,
-									"method", r.Method,
-									"path", r.URL.Path,
-									"custom_field", cf.Name,
-									"doc_id", docID,
-								)
-								http.Error(w,
-									"Error patching document",
-									http.StatusInternalServerError)
-								return
-							}
-
-							model.CustomFields = models.UpsertStringDocumentCustomField(
-								model.CustomFields,
-								doc.DocType,
-								cf.DisplayName,
-								v,
-							)
-						} else {
-							srv.Logger.Error("invalid value type for string custom field",
-								"error", err,
-								"method", r.Method,
-								"path", r.URL.Path,
-								"custom_field", cf.Name,
-								"doc_id", docID)
-							http.Error(w,
-								fmt.Sprintf(
-									"Bad request: invalid value type for custom field %q",
-									cf.Name,
-								),
-								http.StatusBadRequest)
-							return
-						}
-					case "PEOPLE":
-						if reflect.TypeOf(cf.Value).Kind() != reflect.Slice {
-							srv.Logger.Error("invalid value type for people custom field",
-								"error", err,
-								"method", r.Method,
-								"path", r.URL.Path,
-								"custom_field", cf.Name,
-								"doc_id", docID)
-							http.Error(w,
-								fmt.Sprintf(
-									"Bad request: invalid value type for custom field %q",
-									cf.Name,
-								),
-								http.StatusBadRequest)
-							return
-						}
-						cfVal := []string{}
-						for _, v := range cf.Value.([]any) {
-							if v, ok := v.(string); ok {
-								cfVal = append(cfVal, v)
-							} else {
-								srv.Logger.Error("invalid value type for people custom field",
-									"error", err,
-									"method", r.Method,
-									"path", r.URL.Path,
-									"custom_field", cf.Name,
-									"doc_id", docID)
-								http.Error(w,
-									fmt.Sprintf(
-										"Bad request: invalid value type for custom field %q",
-										cf.Name,
-									),
-									http.StatusBadRequest)
-								return
-							}
-						}
-
-						if err := doc.UpsertCustomField(cf); err != nil {
-							srv.Logger.Error("error upserting custom people field",
-								"error", err,
-								"method", r.Method,
-								"path", r.URL.Path,
-								"custom_field", cf.Name,
-								"doc_id", docID,
-							)
-							http.Error(w,
-								"Error patching document",
-								http.StatusInternalServerError)
-							return
-						}
-
-						model.CustomFields, err = models.
-							UpsertStringSliceDocumentCustomField(
-								model.CustomFields,
-								doc.DocType,
-								cf.DisplayName,
-								cfVal,
-							)
-						if err != nil {
-							srv.Logger.Error("invalid value type for people custom field",
-								"error", err,
-								"method", r.Method,
-								"path", r.URL.Path,
-								"custom_field", cf.Name,
-								"doc_id", docID)
-							http.Error(w,
-								fmt.Sprintf(
-									"Bad request: invalid value type for custom field %q",
-									cf.Name,
-								),
-								http.StatusBadRequest)
-							return
-						}
-					default:
-						srv.Logger.Error("invalid custom field type",
+					if err := doc.UpsertCustomField(cf); err != nil {
+						srv.Logger.Error("error upserting custom field",
 							"error", err,
 							"method", r.Method,
 							"path", r.URL.Path,
 							"custom_field", cf.Name,
-							"custom_field_type", cf.Type,
-							"doc_id", docID)
-						http.Error(w,
-							fmt.Sprintf(
-								"Bad request: invalid type for custom field %q",
-								cf.Name,
-							),
-							http.StatusBadRequest)
+							"doc_id", docID,
+						)
+						writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+							"Error patching document")
 						return
 					}
 				}
+
+				model.CustomFields, err = document.UpsertCustomFieldModels(
+					model.CustomFields, doc.DocType, *req.CustomFields)
+				if err != nil {
+					srv.Logger.Error("error upserting custom fields",
+						"error", err,
+						"method", r.Method,
+						"path", r.URL.Path,
+						"doc_id", docID)
+					writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error patching document")
+					return
+				}
 			}
 
 			// Make sure all custom fields in the database model have the document ID.
@@ -1434,8 +1146,8 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 						"path", r.URL.Path,
 						"doc_id", docID,
 						"new_owner", doc.Owners[0])
-					http.Error(w, "Error patching document draft",
-						http.StatusInternalServerError)
+					writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error patching document draft")
 					return
 				}
 			}
@@ -1477,8 +1189,8 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 						"method", r.Method,
 						"path", r.URL.Path,
 					)
-					http.Error(w, "Error updating document draft",
-						http.StatusInternalServerError)
+					writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error updating document draft")
 					return
 				}
 
@@ -1531,10 +1243,11 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 						NewDocumentOwner:  newOwner,
 						OldDocumentOwner:  oldOwner,
 						Product:           doc.Product,
+						Locale:            userLocale(r.Context(), srv, doc.Owners[0]),
 					},
 					[]string{doc.Owners[0]},
 					srv.Config.Email.FromAddress,
-					getCompatProvider(srv.WorkspaceProvider),
+					emailSender(srv),
 				); err != nil {
 					srv.Logger.Error("error sending new owner email",
 						"error", err,
@@ -1542,8 +1255,8 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 						"path", r.URL.Path,
 						"doc_id", docID,
 					)
-					http.Error(w, "Error updating document draft",
-						http.StatusInternalServerError)
+					writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error updating document draft")
 					return
 				}
 			}
@@ -1556,26 +1269,20 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 					"path", r.URL.Path,
 					"doc_id", docID,
 				)
-				http.Error(w, "Error updating document draft",
-					http.StatusInternalServerError)
+				writeUpsertProblem(w, r, err, "Error updating document draft")
 				return
 			}
 
-			// Replace the doc header (Google Docs specific).
-			googleUpdater := getGoogleDocsUpdater(srv.WorkspaceProvider)
-			if googleUpdater == nil {
-				srv.Logger.Warn("ReplaceHeader skipped - not using Google Workspace", "doc_id", docID)
-			} else if err := doc.ReplaceHeader(
-				srv.Config.BaseURL, true, googleUpdater,
-			); err != nil {
+			// Replace the doc header.
+			if err := replaceDocumentHeader(r.Context(), srv, doc, true); err != nil {
 				srv.Logger.Error("error replacing draft doc header",
 					"error", err,
 					"method", r.Method,
 					"path", r.URL.Path,
 					"doc_id", docID,
 				)
-				http.Error(w, "Error replacing header of document draft",
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error replacing header of document draft")
 				return
 			}
 
@@ -1591,113 +1298,37 @@ func DraftsDocumentHandler(srv server.Server) http.Handler {
 				"path", r.URL.Path,
 				"doc_id", docID,
 			)
+			recordActivity(srv, r, userEmail, model, models.EditUserActivityAction)
 
 			// Request post-processing.
-			go func() {
-				// Convert document.Document to search.Document for indexing
-				searchDoc := &search.Document{
-					ObjectID:     doc.ObjectID,
-					DocID:        doc.ObjectID,
-					Title:        doc.Title,
-					DocNumber:    doc.DocNumber,
-					DocType:      doc.DocType,
-					Product:      doc.Product,
-					Status:       doc.Status,
-					Owners:       doc.Owners,
-					Contributors: doc.Contributors,
-					Approvers:    doc.Approvers,
-					Summary:      doc.Summary,
-					Content:      doc.Content,
-					CreatedTime:  doc.CreatedTime,
-					ModifiedTime: doc.ModifiedTime,
-				}
-
-				// Save modified draft doc object in search index.
-				err := srv.SearchProvider.DraftIndex().Index(r.Context(), searchDoc)
-				if err != nil {
-					srv.Logger.Error("error saving patched draft doc in search index",
-						"error", err,
-						"method", r.Method,
-						"path", r.URL.Path,
-						"doc_id", docID,
-					)
-					return
-				}
-
-				// Note: Index is synchronous with the new provider API
-				if false { // Remove the old Wait() logic
-					srv.Logger.Error("error saving patched draft doc in search index",
-						"error", err,
-						"method", r.Method,
-						"path", r.URL.Path,
-						"doc_id", docID,
-					)
-					return
-				}
-
-				// Compare search index and database documents to find data inconsistencies.
-				// Get document object from search index.
-				indexedDoc, err := srv.SearchProvider.DraftIndex().GetObject(r.Context(), docID)
-				if err != nil {
-					srv.Logger.Error("error getting search object for data comparison",
-						"error", err,
-						"method", r.Method,
-						"path", r.URL.Path,
-						"doc_id", docID,
-					)
-					return
-				}
-
-				// Convert search.Document to map for comparison
-				algoDocBytes, _ := json.Marshal(indexedDoc)
-				var algoDoc map[string]any
-				json.Unmarshal(algoDocBytes, &algoDoc)
-
-				// Get document from database.
-				dbDoc := models.Document{
-					GoogleFileID: docID,
-				}
-				if err := dbDoc.Get(srv.DB); err != nil {
-					srv.Logger.Error(
-						"error getting document from database for data comparison",
-						"error", err,
-						"path", r.URL.Path,
-						"method", r.Method,
-						"doc_id", docID,
-					)
-					return
-				}
-				// Get all reviews for the document.
-				var reviews models.DocumentReviews
-				if err := reviews.Find(srv.DB, models.DocumentReview{
-					Document: models.Document{
-						GoogleFileID: docID,
-					},
-				}); err != nil {
-					srv.Logger.Error(
-						"error getting all reviews for document for data comparison",
-						"error", err,
-						"method", r.Method,
-						"path", r.URL.Path,
-						"doc_id", docID,
-					)
-					return
-				}
-				if err := CompareAlgoliaAndDatabaseDocument(
-					algoDoc, dbDoc, reviews, srv.Config.DocumentTypes.DocumentType,
-				); err != nil {
-					srv.Logger.Warn(
-						"inconsistencies detected between Algolia and database docs",
-						"error", err,
-						"method", r.Method,
-						"path", r.URL.Path,
-						"doc_id", docID,
-					)
-				}
-			}()
+			// Convert document.Document to search.Document for indexing.
+			searchDoc := &search.Document{
+				ObjectID:     doc.ObjectID,
+				DocID:        doc.ObjectID,
+				Title:        doc.Title,
+				DocNumber:    doc.DocNumber,
+				DocType:      doc.DocType,
+				Product:      doc.Product,
+				Status:       doc.Status,
+				Owners:       doc.Owners,
+				Contributors: doc.Contributors,
+				Approvers:    doc.Approvers,
+				Summary:      doc.Summary,
+				Content:      doc.Content,
+				CreatedTime:  doc.CreatedTime,
+				ModifiedTime: doc.ModifiedTime,
+
+				ShareableAsDraft: model.ShareableAsDraft,
+			}
+			enqueueJob(srv, r, indexDocumentJobType, indexDocumentJob{
+				Document: searchDoc,
+				Draft:    true,
+				Compare:  true,
+			})
 
 		default:
-			w.WriteHeader(http.StatusMethodNotAllowed)
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
 			return
 		}
 	})
@@ -1721,6 +1352,50 @@ func getDocTypeTemplate(
 	return template
 }
 
+// resolveDraftTemplate returns the file ID of the template named name of
+// document type docType, or of its default template if name is empty. It
+// returns an empty string if the document type doesn't have the template.
+func resolveDraftTemplate(
+	ctx context.Context, srv server.Server, docType, name string,
+) (string, error) {
+	if name == "" {
+		name = config.DefaultTemplateVariant
+	}
+
+	var t models.DocumentTypeTemplate
+	err := t.Get(srv.DB.WithContext(ctx), docType, name)
+	if err == nil {
+		return t.TemplateID, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", err
+	}
+
+	// Fall back to the configuration if the templates weren't synchronized to
+	// the database.
+	for _, t := range configDocTypeTemplates(
+		srv.Config.DocumentTypes.DocumentType, docType) {
+		if t.Name == name {
+			return t.Template, nil
+		}
+	}
+	return "", nil
+}
+
+// configDocTypeTemplates returns the configured templates of the document type
+// named docType, or nil if it isn't configured.
+func configDocTypeTemplates(
+	docTypes []*config.DocumentType,
+	docType string,
+) []*config.DocumentTypeTemplateVariant {
+	for _, t := range docTypes {
+		if t.Name == docType {
+			return t.Templates()
+		}
+	}
+	return nil
+}
+
 // validateDocType returns true if the name (docType) is contained in the a
 // slice of configured document types.
 func validateDocType(
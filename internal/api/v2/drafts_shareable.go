@@ -1,38 +1,44 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
-	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
-	"github.com/hashicorp-forge/hermes/pkg/search"
-	"github.com/hashicorp-forge/hermes/pkg/workspace"
-
-	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/pkg/authz"
 	"github.com/hashicorp-forge/hermes/pkg/document"
 	"github.com/hashicorp-forge/hermes/pkg/models"
-	"github.com/hashicorp/go-hclog"
-	"gorm.io/gorm"
+	"github.com/hashicorp-forge/hermes/pkg/search"
+	"github.com/hashicorp-forge/hermes/pkg/workspace"
 )
 
+// draftShareLinkTTL is how long share links of drafts are valid.
+const draftShareLinkTTL = 30 * 24 * time.Hour
+
 type draftsShareablePutRequest struct {
 	IsShareable *bool `json:"isShareable"`
 }
 
 type draftsShareableGetResponse struct {
 	IsShareable bool `json:"isShareable"`
+
+	// ShareLink is a signed link to the content of the draft. It is set if the
+	// draft is shareable but the workspace provider doesn't share it with the
+	// domain (e.g., local and S3 workspaces).
+	ShareLink string `json:"shareLink,omitempty"`
 }
 
 func draftsShareableHandler(
 	w http.ResponseWriter,
 	r *http.Request,
+	srv server.Server,
 	docID string,
 	doc document.Document,
-	cfg config.Config,
-	l hclog.Logger,
-	searchProvider search.Provider,
-	workspaceProvider workspace.Provider,
-	db *gorm.DB,
 ) {
 	switch r.Method {
 	case "GET":
@@ -40,67 +46,80 @@ func draftsShareableHandler(
 		d := models.Document{
 			GoogleFileID: docID,
 		}
-		if err := d.Get(db); err != nil {
-			l.Error("error getting document from database",
+		if err := d.Get(srv.DB); err != nil {
+			srv.Logger.Error("error getting document from database",
 				"error", err,
 				"path", r.URL.Path,
 				"method", r.Method,
 				"doc_id", docID,
 			)
-			http.Error(w, "Error accessing document",
-				http.StatusInternalServerError)
+			writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Error accessing document")
 			return
 		}
 
 		resp := draftsShareableGetResponse{
 			IsShareable: d.ShareableAsDraft,
 		}
-
-		// Write response.
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
-		enc := json.NewEncoder(w)
-		if err := enc.Encode(resp); err != nil {
-			l.Error("error encoding response",
-				"error", err,
-				"doc_id", docID,
-			)
-			http.Error(w, "Error building response", http.StatusInternalServerError)
-			return
+		if d.ShareableAsDraft {
+			provider, providerID, err := documentContentProvider(
+				r.Context(), srv, &d, docID)
+			var permIDs []string
+			if err == nil {
+				permIDs, err = draftDomainPermissionIDs(
+					r.Context(), srv, provider, providerID)
+			}
+			if err != nil && !errors.Is(err, workspace.ErrNotImplemented) {
+				srv.Logger.Error("error listing workspace permissions",
+					"error", err,
+					"path", r.URL.Path,
+					"method", r.Method,
+					"doc_id", docID,
+				)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error accessing document")
+				return
+			}
+			if len(permIDs) == 0 {
+				resp.ShareLink = draftShareLink(srv, docID)
+			}
 		}
 
+		writeDraftsShareableResponse(w, r, srv, docID, resp)
+
 	case "PUT":
-		// Authorize request (only the document owner is authorized).
-		userEmail := pkgauth.MustGetUserEmail(r.Context())
-		if doc.Owners[0] != userEmail {
-			http.Error(w, "Only the document owner can change shareable settings",
-				http.StatusForbidden)
+		// Authorize request (only the document owner or a site admin is
+		// authorized).
+		if !authorizeRequest(w, r, srv.Config, srv.DB, srv.Logger,
+			authz.ActionDraftEdit, documentAuthzResource(doc),
+			"Only the document owner can change shareable settings",
+		) {
 			return
 		}
 
 		// Decode request.
 		var req draftsShareablePutRequest
 		if err := decodeRequest(r, &req); err != nil {
-			l.Error("error decoding request",
+			srv.Logger.Error("error decoding request",
 				"error", err,
 				"path", r.URL.Path,
 				"method", r.Method,
 				"doc_id", docID,
 			)
-			http.Error(w, "Bad request", http.StatusBadRequest)
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				"Bad request")
 			return
 		}
 
 		// Validate request.
 		if req.IsShareable == nil {
-			l.Warn("bad request: missing required 'isShareable' field",
+			srv.Logger.Warn("bad request: missing required 'isShareable' field",
 				"path", r.URL.Path,
 				"method", r.Method,
 				"doc_id", docID,
 			)
-			http.Error(w,
-				"Bad request: missing required 'isShareable' field",
-				http.StatusBadRequest)
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				"Bad request: missing required 'isShareable' field")
 			return
 		}
 
@@ -108,112 +127,198 @@ func draftsShareableHandler(
 		doc := models.Document{
 			GoogleFileID: docID,
 		}
-		if err := doc.Get(db); err != nil {
-			l.Error("error getting document from database",
+		if err := doc.Get(srv.DB); err != nil {
+			srv.Logger.Error("error getting document from database",
 				"error", err,
 				"path", r.URL.Path,
 				"method", r.Method,
 				"doc_id", docID,
 			)
-			http.Error(w, "Error accessing document",
-				http.StatusInternalServerError)
+			writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Error accessing document")
 			return
 		}
 
-		// Find out if the draft is already shared with the domain.
-		perms, err := workspaceProvider.ListPermissions(docID)
+		// Share the draft with the domain in the workspace provider, or unshare
+		// it, if the provider supports it.
+		provider, providerID, err := documentContentProvider(
+			r.Context(), srv, &doc, docID)
+		var sharedWithDomain bool
+		if err == nil {
+			sharedWithDomain, err = setDraftDomainSharing(
+				r.Context(), srv, provider, providerID, *req.IsShareable)
+		}
 		if err != nil {
-			l.Error("error listing workspace permissions",
+			srv.Logger.Error("error updating workspace permissions",
 				"error", err,
 				"path", r.URL.Path,
 				"method", r.Method,
 				"doc_id", docID,
 			)
-			http.Error(w,
-				"Error updating document permissions",
-				http.StatusInternalServerError)
+			writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Error updating document permissions")
 			return
 		}
-		alreadySharedPermIDs := []string{}
-		for _, p := range perms {
-			isInherited := false
-			for _, pd := range p.PermissionDetails {
-				if pd.Inherited {
-					isInherited = true
-				}
-			}
-			if p.Domain == cfg.GoogleWorkspace.Domain &&
-				p.Role == "commenter" &&
-				!isInherited {
-				alreadySharedPermIDs = append(alreadySharedPermIDs, p.Id)
-			}
-		}
-
-		// Update file permissions, if necessary.
-		if *req.IsShareable {
-			if len(alreadySharedPermIDs) == 0 {
-				// File is not already shared with domain, so share it.
-				err := workspaceProvider.ShareFileWithDomain(docID, cfg.GoogleWorkspace.Domain, "commenter")
-				if err != nil {
-					l.Error("error sharing file with domain",
-						"error", err,
-						"path", r.URL.Path,
-						"method", r.Method,
-						"doc_id", docID,
-					)
-					http.Error(w,
-						"Error updating document permissions",
-						http.StatusInternalServerError)
-					return
-				}
-			}
-		} else {
-			for _, id := range alreadySharedPermIDs {
-				// File is already shared with domain, so remove the permission.
-				err := workspaceProvider.DeletePermission(docID, id)
-				if err != nil {
-					l.Error("error deleting permission",
-						"error", err,
-						"path", r.URL.Path,
-						"method", r.Method,
-						"doc_id", docID,
-						"permission_id", id,
-					)
-					http.Error(w,
-						"Error updating document permissions",
-						http.StatusInternalServerError)
-					return
-				}
-			}
-		}
 
 		// Update ShareableAsDraft for document in the database.
-		if err := db.Model(&doc).
+		if err := srv.DB.Model(&doc).
 			// We need to update using Select because ShareableAsDraft is a
 			// boolean.
 			Select("ShareableAsDraft").
 			Updates(models.Document{ShareableAsDraft: *req.IsShareable}).
 			Error; err != nil {
-			l.Error("error updating ShareableAsDraft in the database",
+			srv.Logger.Error("error updating ShareableAsDraft in the database",
 				"error", err,
 				"path", r.URL.Path,
 				"method", r.Method,
 				"doc_id", docID,
 			)
-			http.Error(w, "Error updating document draft",
-				http.StatusInternalServerError)
+			writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Error updating document draft")
 			return
 		}
 
-		l.Info("updated ShareableAsDraft for document",
+		// Update the draft in the search index, so users can find it if it's
+		// shared with everyone.
+		if u, ok := srv.SearchProvider.DraftIndex().(search.DocumentUpdater); ok {
+			if err := u.UpdateFields(r.Context(), docID, map[string]any{
+				"shareableAsDraft": *req.IsShareable,
+			}); err != nil {
+				srv.Logger.Warn("error updating ShareableAsDraft in the search index",
+					"error", err,
+					"path", r.URL.Path,
+					"method", r.Method,
+					"doc_id", docID,
+				)
+			}
+		}
+
+		srv.Logger.Info("updated ShareableAsDraft for document",
 			"path", r.URL.Path,
 			"method", r.Method,
 			"doc_id", docID,
 			"shareable_as_draft", doc.ShareableAsDraft,
+			"shared_with_domain", sharedWithDomain,
 		)
 
+		resp := draftsShareableGetResponse{
+			IsShareable: *req.IsShareable,
+		}
+		if *req.IsShareable && !sharedWithDomain {
+			resp.ShareLink = draftShareLink(srv, docID)
+		}
+		writeDraftsShareableResponse(w, r, srv, docID, resp)
+
 	default:
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+			"Method not allowed")
 		return
 	}
 }
+
+// writeDraftsShareableResponse writes the shareable response resp of draft
+// docID.
+func writeDraftsShareableResponse(
+	w http.ResponseWriter,
+	r *http.Request,
+	srv server.Server,
+	docID string,
+	resp draftsShareableGetResponse,
+) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		srv.Logger.Error("error encoding response",
+			"error", err,
+			"doc_id", docID,
+		)
+	}
+}
+
+// draftDomainPermissionIDs returns the IDs of the permissions that share the
+// document providerID in provider p with the workspace domain. It returns an
+// error matching workspace.ErrNotImplemented if p doesn't support permissions.
+func draftDomainPermissionIDs(
+	ctx context.Context,
+	srv server.Server,
+	p workspace.PermissionProvider,
+	providerID string,
+) ([]string, error) {
+	domain := srv.Config.WorkspaceDomain(primaryProviderType(srv))
+	if domain == "" {
+		return nil, nil
+	}
+
+	perms, err := p.ListPermissions(ctx, providerID)
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, perm := range perms {
+		if perm.Domain == domain && perm.Role == "commenter" && !perm.Inherited {
+			ids = append(ids, perm.ID)
+		}
+	}
+	return ids, nil
+}
+
+// setDraftDomainSharing shares the document providerID in provider p with the
+// workspace domain if shareable is true, and unshares it otherwise. It returns
+// true if the document is shared with the domain afterwards, which it isn't
+// if p can't share documents with a domain.
+func setDraftDomainSharing(
+	ctx context.Context,
+	srv server.Server,
+	p workspace.PermissionProvider,
+	providerID string,
+	shareable bool,
+) (bool, error) {
+	domain := srv.Config.WorkspaceDomain(primaryProviderType(srv))
+	if domain == "" {
+		return false, nil
+	}
+
+	// Find out if the draft is already shared with the domain.
+	permIDs, err := draftDomainPermissionIDs(ctx, srv, p, providerID)
+	if errors.Is(err, workspace.ErrNotImplemented) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("error listing permissions: %w", err)
+	}
+
+	if !shareable {
+		for _, id := range permIDs {
+			if err := p.RemovePermission(ctx, providerID, id); err != nil {
+				return false, fmt.Errorf("error removing permission %q: %w", id, err)
+			}
+		}
+		return false, nil
+	}
+
+	if len(permIDs) > 0 {
+		return true, nil
+	}
+	if err := p.ShareDocumentWithDomain(
+		ctx, providerID, domain, "commenter",
+	); errors.Is(err, workspace.ErrNotImplemented) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("error sharing with domain: %w", err)
+	}
+	return true, nil
+}
+
+// draftShareLink returns a signed link to the content of draft docID, or "" if
+// signed sessions aren't enabled.
+func draftShareLink(srv server.Server, docID string) string {
+	if srv.Sessions == nil {
+		return ""
+	}
+	token, err := srv.Sessions.SignLink(
+		"draft:"+docID, time.Now().Add(draftShareLinkTTL))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSuffix(srv.Config.BaseURL, "/") +
+		"/api/v2/shared-drafts/" + token
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/internal/auth"
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/pkg/workspace"
+	"github.com/hashicorp-forge/hermes/pkg/workspace/adapters/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetDraftDomainSharing(t *testing.T) {
+	ctx := context.Background()
+
+	newServer := func(t *testing.T) (server.Server, *mock.FakeAdapter, string) {
+		p := mock.NewFakeAdapter()
+		doc, err := p.CreateDocument(ctx, "", "drafts", "Draft")
+		require.NoError(t, err)
+		return server.Server{
+			Config: &config.Config{
+				GoogleWorkspace: &config.GoogleWorkspace{Domain: "example.com"},
+			},
+			WorkspaceProvider: p,
+		}, p, doc.ProviderID
+	}
+
+	t.Run("share and unshare with the domain", func(t *testing.T) {
+		srv, p, providerID := newServer(t)
+
+		shared, err := setDraftDomainSharing(ctx, srv, p, providerID, true)
+		require.NoError(t, err)
+		assert.True(t, shared)
+		ids, err := draftDomainPermissionIDs(ctx, srv, p, providerID)
+		require.NoError(t, err)
+		assert.Len(t, ids, 1)
+
+		// Sharing again doesn't add another permission.
+		shared, err = setDraftDomainSharing(ctx, srv, p, providerID, true)
+		require.NoError(t, err)
+		assert.True(t, shared)
+		ids, err = draftDomainPermissionIDs(ctx, srv, p, providerID)
+		require.NoError(t, err)
+		assert.Len(t, ids, 1)
+
+		shared, err = setDraftDomainSharing(ctx, srv, p, providerID, false)
+		require.NoError(t, err)
+		assert.False(t, shared)
+		ids, err = draftDomainPermissionIDs(ctx, srv, p, providerID)
+		require.NoError(t, err)
+		assert.Empty(t, ids)
+	})
+
+	t.Run("providers without permissions don't share", func(t *testing.T) {
+		srv, p, providerID := newServer(t)
+		p.WithFault("ListPermissions",
+			mock.Fault{FailFirst: 1, Err: workspace.ErrNotImplemented})
+
+		shared, err := setDraftDomainSharing(ctx, srv, p, providerID, true)
+		require.NoError(t, err)
+		assert.False(t, shared)
+	})
+
+	t.Run("permission errors are returned", func(t *testing.T) {
+		srv, p, providerID := newServer(t)
+		p.WithFault("ShareDocumentWithDomain", mock.Fault{FailFirst: 1})
+
+		_, err := setDraftDomainSharing(ctx, srv, p, providerID, true)
+		assert.ErrorIs(t, err, mock.ErrInjected)
+	})
+
+	t.Run("no domain", func(t *testing.T) {
+		srv, p, providerID := newServer(t)
+		srv.Config.GoogleWorkspace.Domain = ""
+
+		shared, err := setDraftDomainSharing(ctx, srv, p, providerID, true)
+		require.NoError(t, err)
+		assert.False(t, shared)
+		assert.Zero(t, p.Calls("ShareDocumentWithDomain"))
+	})
+}
+
+func TestDraftShareLink(t *testing.T) {
+	sessions, err := auth.NewSessions(&config.Auth{
+		SessionSecret: strings.Repeat("s", 32),
+	})
+	require.NoError(t, err)
+	srv := server.Server{
+		Config:   &config.Config{BaseURL: "https://hermes.example.com/"},
+		Sessions: sessions,
+	}
+
+	link := draftShareLink(srv, "doc1")
+	token, ok := strings.CutPrefix(link,
+		"https://hermes.example.com"+sharedDraftsPathPrefix)
+	require.True(t, ok, link)
+
+	docID, ok := sharedDraftID(srv, token)
+	assert.True(t, ok)
+	assert.Equal(t, "doc1", docID)
+
+	_, ok = sharedDraftID(srv, token+"x")
+	assert.False(t, ok)
+
+	// Links aren't signed without sessions.
+	assert.Empty(t, draftShareLink(server.Server{Config: srv.Config}, "doc1"))
+}
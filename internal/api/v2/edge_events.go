@@ -0,0 +1,79 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+
+	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/internal/services"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+)
+
+// edgePermissionFields are the audited document fields that control who can
+// access or approve a document.
+var edgePermissionFields = []string{
+	"owner", "approvers", "approverGroups", "shareableAsDraft",
+}
+
+// publishEdgeEvents publishes an edge event (RFC-085) for audited requests
+// that approved a document or changed its permissions, so the edge instance
+// the document is registered from learns about it without polling. Errors
+// are only logged because they don't affect the request.
+func publishEdgeEvents(ctx context.Context, srv server.Server, e models.AuditEvent) {
+	eventType := edgeEventType(e)
+	if eventType == "" {
+		return
+	}
+
+	doc := models.Document{GoogleFileID: e.TargetID}
+	if err := doc.Get(srv.DB); err != nil || doc.DocumentUUID == nil {
+		return
+	}
+
+	n, err := services.NewDocumentSyncService(srv.DB).PublishEdgeDocumentEvent(
+		ctx, *doc.DocumentUUID, eventType, map[string]any{
+			"actor":       e.Actor,
+			"document_id": e.TargetID,
+			"request_id":  e.RequestID,
+		})
+	if err != nil {
+		srv.Logger.Error("error publishing edge event",
+			"error", err,
+			"doc_id", e.TargetID,
+			"event_type", eventType,
+		)
+		return
+	}
+	if n > 0 {
+		srv.Logger.Debug("published edge event",
+			"doc_id", e.TargetID,
+			"event_type", eventType,
+			"edges", n,
+		)
+	}
+}
+
+// edgeEventType returns the type of the edge event for an audit event, or an
+// empty string if edges don't need to know about it.
+func edgeEventType(e models.AuditEvent) string {
+	if e.TargetType != auditTargetDocument || e.TargetID == "" ||
+		e.StatusCode < 200 || e.StatusCode >= 300 {
+		return ""
+	}
+	if e.Action == "approvals.create" {
+		return services.EdgeEventDocumentApproved
+	}
+
+	var before, after map[string]any
+	if json.Unmarshal(e.Before, &before) != nil ||
+		json.Unmarshal(e.After, &after) != nil {
+		return ""
+	}
+	for _, f := range edgePermissionFields {
+		if !reflect.DeepEqual(before[f], after[f]) {
+			return services.EdgeEventDocumentPermissions
+		}
+	}
+	return ""
+}
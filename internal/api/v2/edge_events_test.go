@@ -0,0 +1,60 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/internal/services"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEdgeEventType(t *testing.T) {
+	doc := func(action string, status int, before, after string) models.AuditEvent {
+		return models.AuditEvent{
+			Action:     action,
+			StatusCode: status,
+			TargetType: auditTargetDocument,
+			TargetID:   "abc",
+			Before:     models.JSON(before),
+			After:      models.JSON(after),
+		}
+	}
+
+	cases := []struct {
+		name  string
+		event models.AuditEvent
+		want  string
+	}{
+		{"approval",
+			doc("approvals.create", 200, `{}`, `{}`),
+			services.EdgeEventDocumentApproved},
+		{"failed approval",
+			doc("approvals.create", 403, `{}`, `{}`),
+			""},
+		{"approvers changed",
+			doc("documents.update", 200,
+				`{"title":"A","approvers":["a@example.com"]}`,
+				`{"title":"A","approvers":["a@example.com","b@example.com"]}`),
+			services.EdgeEventDocumentPermissions},
+		{"shared as draft",
+			doc("drafts.shareable.update", 200,
+				`{"shareableAsDraft":false}`, `{"shareableAsDraft":true}`),
+			services.EdgeEventDocumentPermissions},
+		{"title changed",
+			doc("documents.update", 200, `{"title":"A"}`, `{"title":"B"}`),
+			""},
+		{"document created",
+			doc("drafts.create", 200, ``, `{"owner":"a@example.com"}`),
+			""},
+		{"project",
+			models.AuditEvent{Action: "projects.update", StatusCode: 200,
+				TargetType: auditTargetProject, TargetID: "1"},
+			""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.want, edgeEventType(c.event))
+		})
+	}
+}
@@ -2,6 +2,8 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
@@ -10,6 +12,8 @@ import (
 	"github.com/hashicorp-forge/hermes/internal/server"
 	"github.com/hashicorp-forge/hermes/internal/services"
 	"github.com/hashicorp-forge/hermes/pkg/docid"
+	"github.com/hashicorp-forge/hermes/pkg/merge"
+	"github.com/hashicorp-forge/hermes/pkg/models"
 	"github.com/hashicorp-forge/hermes/pkg/workspace"
 )
 
@@ -47,8 +51,83 @@ type SyncStatusResponse struct {
 	Stats        map[string]any                 `json:"stats,omitempty"`
 }
 
+// EdgeSearchResponse contains the documents matching an edge document search
+type EdgeSearchResponse struct {
+	Documents []*services.EdgeDocumentRecord `json:"documents"`
+	Count     int                            `json:"count"`
+}
+
+// EdgeSyncRequest is a batch of an edge instance's document changes
+type EdgeSyncRequest struct {
+	EdgeInstance string `json:"edge_instance"`
+
+	// Checkpoint is the checkpoint returned by the previous request, or 0 to
+	// get all of the edge instance's documents.
+	Checkpoint int64 `json:"checkpoint"`
+
+	// Scope limits the sync to a subset of documents. All documents are
+	// synced if it's empty.
+	Scope *services.EdgeSyncScope `json:"scope,omitempty"`
+
+	Documents []*services.EdgeSyncDocument `json:"documents"`
+}
+
+// EdgeHeartbeatRequest reports the state of an edge instance to central
+type EdgeHeartbeatRequest struct {
+	EdgeInstance  string `json:"edge_instance"`
+	Version       string `json:"version,omitempty"`
+	Hostname      string `json:"hostname,omitempty"`
+	DocumentCount int    `json:"document_count"`
+	ConflictCount int    `json:"conflict_count"`
+}
+
+// EdgeHeartbeatResponse acknowledges an edge heartbeat
+type EdgeHeartbeatResponse struct {
+	Acknowledged bool      `json:"acknowledged"`
+	ServerTime   time.Time `json:"server_time"`
+}
+
+// EdgeConflictsResponse lists edge document conflicts
+type EdgeConflictsResponse struct {
+	Conflicts []*services.EdgeDocumentConflict `json:"conflicts"`
+}
+
+// EdgeConflictResolveRequest resolves an edge document conflict
+type EdgeConflictResolveRequest struct {
+	services.EdgeConflictResolution
+
+	// ResolvedBy identifies who resolved the conflict. Defaults to the name
+	// of the service token.
+	ResolvedBy string `json:"resolved_by,omitempty"`
+}
+
+// EdgeEventsResponse lists the events of an edge instance
+type EdgeEventsResponse struct {
+	Events []*services.EdgeEvent `json:"events"`
+
+	// Cursor is the ID of the last event, to pass as "after" to get the next
+	// events. It is the "after" of the request if there are no events.
+	Cursor int64 `json:"cursor"`
+}
+
+// maxEdgeSyncDocuments is the maximum number of documents of an edge sync
+// request.
+const maxEdgeSyncDocuments = 500
+
+// maxEdgeEventsWait is the maximum time an events request waits for events.
+const maxEdgeEventsWait = time.Minute
+
 // EdgeSyncHandler handles edge-to-central document synchronization endpoints
 //
+// Edges sync their documents with POST /api/v2/edge/sync, which supersedes
+// registering and updating documents one at a time.
+//
+// POST   /api/v2/edge/sync                        - Sync a batch of documents
+// POST   /api/v2/edge/heartbeat                   - Report edge instance state
+// GET    /api/v2/edge/events                      - Long-poll edge events
+// GET    /api/v2/edge/conflicts                   - List document conflicts
+// GET    /api/v2/edge/conflicts/:id               - Get a document conflict
+// POST   /api/v2/edge/conflicts/:id/resolve       - Resolve a document conflict
 // POST   /api/v2/edge/documents/register          - Register document from edge
 // PUT    /api/v2/edge/documents/:uuid/sync        - Sync metadata updates
 // GET    /api/v2/edge/documents/sync-status       - Get sync status
@@ -60,10 +139,40 @@ func EdgeSyncHandler(srv server.Server) http.Handler {
 	syncService := services.NewDocumentSyncService(srv.DB)
 
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
 		// Parse the path to determine which endpoint was called
 		path := strings.TrimPrefix(r.URL.Path, "/api/v2/edge/")
 
 		switch {
+		case r.Method == "POST" && path == "sync":
+			handleEdgeSync(w, r, syncService, srv)
+
+		case r.Method == "POST" && path == "heartbeat":
+			handleEdgeHeartbeat(w, r, srv)
+
+		case r.Method == "GET" && path == "events":
+			handleEdgeEvents(w, r, syncService, srv)
+
+		case r.Method == "GET" && path == "conflicts":
+			handleListEdgeConflicts(w, r, syncService, srv)
+
+		case strings.HasPrefix(path, "conflicts/"):
+			parts := strings.Split(path, "/")
+			id, err := strconv.ParseInt(parts[1], 10, 64)
+			if err != nil {
+				writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound,
+					"Conflict not found")
+				return
+			}
+			switch {
+			case len(parts) == 2 && r.Method == "GET":
+				handleGetEdgeConflict(w, r, id, syncService, srv)
+			case len(parts) == 3 && parts[2] == "resolve" && r.Method == "POST":
+				handleResolveEdgeConflict(w, r, id, syncService, srv)
+			default:
+				writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound, "Not found")
+			}
+
 		case r.Method == "POST" && path == "documents/register":
 			handleRegisterDocument(w, r, syncService, srv)
 
@@ -80,7 +189,8 @@ func EdgeSyncHandler(srv server.Server) http.Handler {
 			// Extract UUID from path
 			parts := strings.Split(path, "/")
 			if len(parts) < 2 {
-				http.Error(w, "Invalid path", http.StatusBadRequest)
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					"Invalid path")
 				return
 			}
 			uuid := parts[1]
@@ -99,39 +209,139 @@ func EdgeSyncHandler(srv server.Server) http.Handler {
 				case "DELETE":
 					handleDeleteDocument(w, r, uuid, syncService, srv)
 				default:
-					http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+					writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+						"Method not allowed")
 				}
 				return
 			}
 
-			http.Error(w, "Not found", http.StatusNotFound)
+			writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound, "Not found")
 
 		default:
-			http.Error(w, "Not found", http.StatusNotFound)
+			writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound, "Not found")
 		}
 	})
 }
 
+// handleEdgeSync applies a batch of an edge instance's document changes and
+// returns the changes since the request's checkpoint
+func handleEdgeSync(w http.ResponseWriter, r *http.Request, syncService *services.DocumentSyncService, srv server.Server) {
+	var req EdgeSyncRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		srv.Logger.Error("failed to decode edge sync request", "error", err)
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			"invalid request body")
+		return
+	}
+
+	if req.EdgeInstance == "" {
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			"edge_instance is required")
+		return
+	}
+	if len(req.Documents) > maxEdgeSyncDocuments {
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			fmt.Sprintf("at most %d documents can be synced per request",
+				maxEdgeSyncDocuments))
+		return
+	}
+	for _, doc := range req.Documents {
+		if doc == nil || doc.UUID.IsZero() {
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				"uuid is required")
+			return
+		}
+		if !doc.Deleted && doc.Title == "" {
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				"title is required")
+			return
+		}
+	}
+
+	resp, err := syncService.SyncEdgeDocuments(
+		r.Context(), req.EdgeInstance, req.Checkpoint, req.Scope, req.Documents)
+	if err != nil {
+		srv.Logger.Error("failed to sync edge documents", "error", err,
+			"edge_instance", req.EdgeInstance)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"failed to sync documents")
+		return
+	}
+
+	// The sync succeeded even if it can't be recorded in the edge registry.
+	if err := models.RecordEdgeSync(srv.DB, req.EdgeInstance); err != nil {
+		srv.Logger.Warn("failed to record edge sync", "error", err,
+			"edge_instance", req.EdgeInstance)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// handleEdgeHeartbeat records the state reported by an edge instance in the
+// edge registry
+func handleEdgeHeartbeat(w http.ResponseWriter, r *http.Request, srv server.Server) {
+	var req EdgeHeartbeatRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		srv.Logger.Error("failed to decode edge heartbeat request", "error", err)
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			"invalid request body")
+		return
+	}
+
+	if req.EdgeInstance == "" {
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			"edge_instance is required")
+		return
+	}
+
+	edge := models.EdgeInstance{
+		Name:          req.EdgeInstance,
+		Version:       req.Version,
+		Hostname:      req.Hostname,
+		RemoteAddr:    r.RemoteAddr,
+		DocumentCount: req.DocumentCount,
+		ConflictCount: req.ConflictCount,
+	}
+	if err := edge.RecordHeartbeat(srv.DB); err != nil {
+		srv.Logger.Error("failed to record edge heartbeat", "error", err,
+			"edge_instance", req.EdgeInstance)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"failed to record heartbeat")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(EdgeHeartbeatResponse{
+		Acknowledged: true,
+		ServerTime:   time.Now().UTC(),
+	})
+}
+
 // handleRegisterDocument registers a document from edge instance
 func handleRegisterDocument(w http.ResponseWriter, r *http.Request, syncService *services.DocumentSyncService, srv server.Server) {
 	var req RegisterDocumentRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		srv.Logger.Error("failed to decode register request", "error", err)
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			"invalid request body")
 		return
 	}
 
 	// Validate required fields
 	if req.UUID == "" {
-		http.Error(w, "uuid is required", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			"uuid is required")
 		return
 	}
 	if req.Title == "" {
-		http.Error(w, "title is required", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			"title is required")
 		return
 	}
 	if req.EdgeInstance == "" {
-		http.Error(w, "edge_instance is required", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			"edge_instance is required")
 		return
 	}
 
@@ -139,7 +349,8 @@ func handleRegisterDocument(w http.ResponseWriter, r *http.Request, syncService
 	uuid, err := docid.ParseUUID(req.UUID)
 	if err != nil {
 		srv.Logger.Error("invalid uuid format", "error", err, "uuid", req.UUID)
-		http.Error(w, "invalid uuid format", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			"invalid uuid format")
 		return
 	}
 
@@ -147,14 +358,16 @@ func handleRegisterDocument(w http.ResponseWriter, r *http.Request, syncService
 	createdAt, err := parseTimestamp(req.CreatedAt)
 	if err != nil {
 		srv.Logger.Error("invalid created_at timestamp", "error", err)
-		http.Error(w, "invalid created_at timestamp", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			"invalid created_at timestamp")
 		return
 	}
 
 	updatedAt, err := parseTimestamp(req.UpdatedAt)
 	if err != nil {
 		srv.Logger.Error("invalid updated_at timestamp", "error", err)
-		http.Error(w, "invalid updated_at timestamp", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			"invalid updated_at timestamp")
 		return
 	}
 
@@ -178,7 +391,8 @@ func handleRegisterDocument(w http.ResponseWriter, r *http.Request, syncService
 	record, err := syncService.RegisterDocument(r.Context(), doc, req.EdgeInstance)
 	if err != nil {
 		srv.Logger.Error("failed to register document", "error", err, "uuid", uuid)
-		http.Error(w, "failed to register document: "+err.Error(), http.StatusInternalServerError)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"failed to register document: "+err.Error())
 		return
 	}
 
@@ -191,14 +405,16 @@ func handleSyncMetadata(w http.ResponseWriter, r *http.Request, uuidStr string,
 	uuid, err := docid.ParseUUID(uuidStr)
 	if err != nil {
 		srv.Logger.Error("invalid uuid format", "error", err, "uuid", uuidStr)
-		http.Error(w, "invalid uuid format", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			"invalid uuid format")
 		return
 	}
 
 	var req SyncMetadataRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		srv.Logger.Error("failed to decode sync metadata request", "error", err)
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			"invalid request body")
 		return
 	}
 
@@ -224,7 +440,8 @@ func handleSyncMetadata(w http.ResponseWriter, r *http.Request, uuidStr string,
 	record, err := syncService.UpdateDocumentMetadata(r.Context(), uuid, updates)
 	if err != nil {
 		srv.Logger.Error("failed to update metadata", "error", err, "uuid", uuid)
-		http.Error(w, "failed to update metadata: "+err.Error(), http.StatusInternalServerError)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"failed to update metadata: "+err.Error())
 		return
 	}
 
@@ -236,17 +453,26 @@ func handleSyncMetadata(w http.ResponseWriter, r *http.Request, uuidStr string,
 func handleGetSyncStatus(w http.ResponseWriter, r *http.Request, syncService *services.DocumentSyncService, srv server.Server) {
 	edgeInstance := r.URL.Query().Get("edge_instance")
 	if edgeInstance == "" {
-		http.Error(w, "edge_instance query parameter is required", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			"edge_instance query parameter is required")
 		return
 	}
 
 	limit := parseIntQueryParam(r, "limit", 100)
 
+	// Limit documents to the edge instance's sync scope, if any.
+	scope := &services.EdgeSyncScope{
+		Products:      parseListQueryParam(r, "product"),
+		DocumentTypes: parseListQueryParam(r, "document_type"),
+		Projects:      parseListQueryParam(r, "project"),
+	}
+
 	// Get documents
-	documents, err := syncService.GetSyncStatus(r.Context(), edgeInstance, limit)
+	documents, err := syncService.GetSyncStatus(r.Context(), edgeInstance, scope, limit)
 	if err != nil {
 		srv.Logger.Error("failed to get sync status", "error", err, "edge_instance", edgeInstance)
-		http.Error(w, "failed to get sync status: "+err.Error(), http.StatusInternalServerError)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"failed to get sync status: "+err.Error())
 		return
 	}
 
@@ -273,14 +499,16 @@ func handleGetDocumentByUUID(w http.ResponseWriter, r *http.Request, uuidStr str
 	uuid, err := docid.ParseUUID(uuidStr)
 	if err != nil {
 		srv.Logger.Error("invalid uuid format", "error", err, "uuid", uuidStr)
-		http.Error(w, "invalid uuid format", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			"invalid uuid format")
 		return
 	}
 
 	record, err := syncService.GetDocumentByUUID(r.Context(), uuid)
 	if err != nil {
 		srv.Logger.Error("document not found", "error", err, "uuid", uuid)
-		http.Error(w, "document not found: "+err.Error(), http.StatusNotFound)
+		writeProblem(w, r, http.StatusNotFound, ErrCodeDocumentNotFound,
+			"document not found: "+err.Error())
 		return
 	}
 
@@ -310,13 +538,14 @@ func handleSearchDocuments(w http.ResponseWriter, r *http.Request, syncService *
 	documents, err := syncService.SearchDocuments(r.Context(), query, filters, limit)
 	if err != nil {
 		srv.Logger.Error("search failed", "error", err, "query", query)
-		http.Error(w, "search failed: "+err.Error(), http.StatusInternalServerError)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"search failed: "+err.Error())
 		return
 	}
 
-	response := map[string]any{
-		"documents": documents,
-		"count":     len(documents),
+	response := EdgeSearchResponse{
+		Documents: documents,
+		Count:     len(documents),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -328,13 +557,15 @@ func handleDeleteDocument(w http.ResponseWriter, r *http.Request, uuidStr string
 	uuid, err := docid.ParseUUID(uuidStr)
 	if err != nil {
 		srv.Logger.Error("invalid uuid format", "error", err, "uuid", uuidStr)
-		http.Error(w, "invalid uuid format", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			"invalid uuid format")
 		return
 	}
 
 	if err := syncService.DeleteDocument(r.Context(), uuid); err != nil {
 		srv.Logger.Error("failed to delete document", "error", err, "uuid", uuid)
-		http.Error(w, "failed to delete document: "+err.Error(), http.StatusInternalServerError)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"failed to delete document: "+err.Error())
 		return
 	}
 
@@ -345,14 +576,16 @@ func handleDeleteDocument(w http.ResponseWriter, r *http.Request, uuidStr string
 func handleGetEdgeInstanceStats(w http.ResponseWriter, r *http.Request, syncService *services.DocumentSyncService, srv server.Server) {
 	edgeInstance := r.URL.Query().Get("edge_instance")
 	if edgeInstance == "" {
-		http.Error(w, "edge_instance query parameter is required", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			"edge_instance query parameter is required")
 		return
 	}
 
 	stats, err := syncService.GetEdgeInstanceStats(r.Context(), edgeInstance)
 	if err != nil {
 		srv.Logger.Error("failed to get stats", "error", err, "edge_instance", edgeInstance)
-		http.Error(w, "failed to get stats: "+err.Error(), http.StatusInternalServerError)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"failed to get stats: "+err.Error())
 		return
 	}
 
@@ -360,6 +593,153 @@ func handleGetEdgeInstanceStats(w http.ResponseWriter, r *http.Request, syncServ
 	json.NewEncoder(w).Encode(stats)
 }
 
+// handleListEdgeConflicts lists document conflicts of an edge instance
+func handleListEdgeConflicts(w http.ResponseWriter, r *http.Request, syncService *services.DocumentSyncService, srv server.Server) {
+	edgeInstance := r.URL.Query().Get("edge_instance")
+	status := r.URL.Query().Get("status")
+	switch status {
+	case "", services.EdgeConflictOpen, services.EdgeConflictResolved:
+	default:
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			"status must be open or resolved")
+		return
+	}
+	limit := parseIntQueryParam(r, "limit", 100)
+
+	conflicts, err := syncService.ListEdgeConflicts(
+		r.Context(), edgeInstance, status, limit)
+	if err != nil {
+		srv.Logger.Error("failed to list conflicts", "error", err,
+			"edge_instance", edgeInstance)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"failed to list conflicts")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(EdgeConflictsResponse{Conflicts: conflicts})
+}
+
+// handleEdgeEvents lists the events of an edge instance after the "after"
+// event ID. With the "wait" query parameter (e.g., "30s"), the request waits
+// up to that long for events if there are none yet, so edges can long-poll
+// for changes instead of polling sync-status.
+func handleEdgeEvents(w http.ResponseWriter, r *http.Request, syncService *services.DocumentSyncService, srv server.Server) {
+	edgeInstance := r.URL.Query().Get("edge_instance")
+	if edgeInstance == "" {
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			"edge_instance query parameter is required")
+		return
+	}
+
+	var after int64
+	if v := r.URL.Query().Get("after"); v != "" {
+		var err error
+		if after, err = strconv.ParseInt(v, 10, 64); err != nil || after < 0 {
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				"after must be an event ID")
+			return
+		}
+	}
+
+	var wait time.Duration
+	if v := r.URL.Query().Get("wait"); v != "" {
+		var err error
+		if wait, err = time.ParseDuration(v); err != nil || wait < 0 {
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				"wait must be a duration like 30s")
+			return
+		}
+		wait = min(wait, maxEdgeEventsWait)
+	}
+	limit := parseIntQueryParam(r, "limit", 100)
+
+	events, err := syncService.WaitEdgeEvents(
+		r.Context(), edgeInstance, after, limit, wait)
+	if err != nil {
+		srv.Logger.Error("failed to list events", "error", err,
+			"edge_instance", edgeInstance)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"failed to list events")
+		return
+	}
+
+	resp := EdgeEventsResponse{Events: events, Cursor: after}
+	if len(events) > 0 {
+		resp.Cursor = events[len(events)-1].ID
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// handleGetEdgeConflict retrieves a document conflict by ID
+func handleGetEdgeConflict(w http.ResponseWriter, r *http.Request, id int64, syncService *services.DocumentSyncService, srv server.Server) {
+	conflict, err := syncService.GetEdgeConflict(r.Context(), id)
+	if errors.Is(err, services.ErrEdgeConflictNotFound) {
+		writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound,
+			"Conflict not found")
+		return
+	}
+	if err != nil {
+		srv.Logger.Error("failed to get conflict", "error", err, "id", id)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"failed to get conflict")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(conflict)
+}
+
+// handleResolveEdgeConflict applies the resolution of a document conflict,
+// which the edge instance pulls at its next sync
+func handleResolveEdgeConflict(w http.ResponseWriter, r *http.Request, id int64, syncService *services.DocumentSyncService, srv server.Server) {
+	var req EdgeConflictResolveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		srv.Logger.Error("failed to decode resolve request", "error", err)
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			"invalid request body")
+		return
+	}
+
+	if strings.TrimSpace(req.Content) == "" {
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			"content is required")
+		return
+	}
+	if merge.HasConflictMarkers(req.Content) {
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			"content must not contain conflict markers")
+		return
+	}
+	if req.ResolvedBy == "" {
+		if t, ok := serviceTokenFromContext(r.Context()); ok {
+			req.ResolvedBy = t.Name
+		}
+	}
+
+	record, err := syncService.ResolveEdgeConflict(
+		r.Context(), id, &req.EdgeConflictResolution, req.ResolvedBy)
+	switch {
+	case errors.Is(err, services.ErrEdgeConflictNotFound):
+		writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound,
+			"Conflict not found")
+		return
+	case errors.Is(err, services.ErrEdgeConflictResolved):
+		writeProblem(w, r, http.StatusConflict, ErrCodeConflict,
+			"Conflict is already resolved")
+		return
+	case err != nil:
+		srv.Logger.Error("failed to resolve conflict", "error", err, "id", id)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"failed to resolve conflict")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(record)
+}
+
 // parseTimestamp parses a timestamp string in RFC3339 format
 func parseTimestamp(ts string) (time.Time, error) {
 	if ts == "" {
@@ -380,3 +760,17 @@ func parseIntQueryParam(r *http.Request, param string, defaultValue int) int {
 	}
 	return value
 }
+
+// parseListQueryParam parses a comma-separated query parameter, which may also
+// be repeated, ignoring empty values
+func parseListQueryParam(r *http.Request, param string) []string {
+	var values []string
+	for _, v := range r.URL.Query()[param] {
+		for _, s := range strings.Split(v, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				values = append(values, s)
+			}
+		}
+	}
+	return values
+}
@@ -278,6 +278,105 @@ func TestEdgeSyncAuthenticationFlow(t *testing.T) {
 		assert.NotEqual(t, http.StatusUnauthorized, w.Code)
 		assert.NotEqual(t, http.StatusForbidden, w.Code)
 	})
+
+	t.Run("RejectReadOnlyTokenForSync", func(t *testing.T) {
+		srv := createTestServer(t, db)
+		handler := EdgeSyncAuthMiddleware(srv, EdgeSyncHandler(srv))
+
+		// Create a read-only token
+		token := generateTestToken()
+		hash := hashToken(token)
+
+		tokenModel := models.IndexerToken{
+			ID:        uuid.New(),
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+			TokenHash: hash,
+			TokenType: "edge",
+			Scopes:    models.StringArray{models.TokenScopeRead},
+		}
+		require.NoError(t, db.Create(&tokenModel).Error)
+		defer db.Delete(&tokenModel)
+
+		// Reading is allowed
+		req := httptest.NewRequest("GET", "/api/v2/edge/documents/sync-status?edge_instance=test-edge", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		assert.NotEqual(t, http.StatusUnauthorized, w.Code)
+		assert.NotEqual(t, http.StatusForbidden, w.Code)
+
+		// Last use is recorded
+		var used models.IndexerToken
+		require.NoError(t, db.First(&used, "id = ?", tokenModel.ID).Error)
+		assert.NotNil(t, used.LastUsedAt)
+
+		// Syncing is not allowed
+		req = httptest.NewRequest("POST", "/api/v2/edge/documents/register", bytes.NewReader([]byte("{}")))
+		req.Header.Set("Authorization", "Bearer "+token)
+		w = httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), "scope")
+	})
+
+	t.Run("RejectTokenWithoutNotificationsScope", func(t *testing.T) {
+		srv := createTestServer(t, db)
+		handler := NotificationsHandler(srv)
+
+		token := generateTestToken()
+		tokenModel := models.IndexerToken{
+			ID:        uuid.New(),
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+			TokenHash: hashToken(token),
+			TokenType: "api",
+			Scopes:    models.StringArray{models.TokenScopeSync},
+		}
+		require.NoError(t, db.Create(&tokenModel).Error)
+		defer db.Delete(&tokenModel)
+
+		req := httptest.NewRequest("POST", "/api/v2/notifications/email",
+			bytes.NewReader([]byte(`{"to":["a@example.com"]}`)))
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), "notifications")
+	})
+
+	t.Run("RejectIndexerHeartbeatWithoutSyncScope", func(t *testing.T) {
+		srv := createTestServer(t, db)
+		handler := IndexerHandler(srv)
+
+		token := generateTestToken()
+		tokenModel := models.IndexerToken{
+			ID:        uuid.New(),
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+			TokenHash: hashToken(token),
+			TokenType: "api",
+			Scopes:    models.StringArray{models.TokenScopeRead},
+		}
+		require.NoError(t, db.Create(&tokenModel).Error)
+		defer db.Delete(&tokenModel)
+
+		req := httptest.NewRequest("POST", "/api/v2/indexer/heartbeat",
+			bytes.NewReader([]byte("{}")))
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), "scope")
+	})
 }
 
 // TestEdgeSyncEndpointsWithAuth tests actual API endpoints with authentication
@@ -0,0 +1,97 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// computeETag returns a strong entity tag derived from a response body and
+// the modified time of the underlying document. Including the modified time
+// ensures the tag changes even when a provider returns identical bytes for a
+// newer revision.
+func computeETag(body []byte, modifiedTime time.Time) string {
+	h := sha256.New()
+	h.Write(body)
+	fmt.Fprintf(h, "|%d", modifiedTime.UnixNano())
+	return fmt.Sprintf("%q", hex.EncodeToString(h.Sum(nil))[:32])
+}
+
+// etagMatches reports whether an If-None-Match header value matches the
+// provided entity tag. The header may contain a comma-separated list of tags
+// or "*". Weak validators are compared using the weak comparison function
+// described in RFC 9110, section 8.8.3.2.
+func etagMatches(header, etag string) bool {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return false
+	}
+	if header == "*" {
+		return true
+	}
+
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, t := range strings.Split(header, ",") {
+		t = strings.TrimPrefix(strings.TrimSpace(t), "W/")
+		if t == etag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// etagStrongMatches reports whether an If-Match header value matches the
+// provided entity tag using the strong comparison function described in RFC
+// 9110, section 8.8.3.2, as required for If-Match. Weak validators (W/"...")
+// never match.
+func etagStrongMatches(header, etag string) bool {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return false
+	}
+	if header == "*" {
+		return true
+	}
+	if strings.HasPrefix(etag, "W/") {
+		return false
+	}
+
+	for _, t := range strings.Split(header, ",") {
+		if strings.TrimSpace(t) == etag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkIfNoneMatch sets the ETag response header and, if the request's
+// If-None-Match header matches it, responds with 304 Not Modified. It returns
+// true if a response was written and the caller should stop processing.
+func checkIfNoneMatch(w http.ResponseWriter, r *http.Request, etag string) bool {
+	w.Header().Set("ETag", etag)
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return true
+	}
+	return false
+}
+
+// checkIfMatch evaluates the request's If-Match precondition against the
+// current entity tag. If the header is present and does not match, it
+// responds with 412 Precondition Failed and returns true. Requests without an
+// If-Match header always pass so existing clients are unaffected.
+func checkIfMatch(w http.ResponseWriter, r *http.Request, etag string) bool {
+	ifMatch := r.Header.Get("If-Match")
+	if ifMatch == "" || etagStrongMatches(ifMatch, etag) {
+		return false
+	}
+	w.Header().Set("ETag", etag)
+	writeProblem(w, r, http.StatusPreconditionFailed, ErrCodePreconditionFailed,
+		"Precondition failed: document has been modified")
+	return true
+}
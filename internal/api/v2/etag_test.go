@@ -0,0 +1,162 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeETag(t *testing.T) {
+	assert := assert.New(t)
+	now := time.Now()
+
+	a := computeETag([]byte("content"), now)
+	assert.Equal(a, computeETag([]byte("content"), now))
+	assert.NotEqual(a, computeETag([]byte("content2"), now))
+	assert.NotEqual(a, computeETag([]byte("content"), now.Add(time.Second)))
+	assert.Equal(byte('"'), a[0])
+	assert.Equal(byte('"'), a[len(a)-1])
+}
+
+func TestETagMatches(t *testing.T) {
+	cases := map[string]struct {
+		header string
+		etag   string
+		want   bool
+	}{
+		"empty header": {
+			header: "",
+			etag:   `"abc"`,
+			want:   false,
+		},
+		"wildcard": {
+			header: "*",
+			etag:   `"abc"`,
+			want:   true,
+		},
+		"exact match": {
+			header: `"abc"`,
+			etag:   `"abc"`,
+			want:   true,
+		},
+		"weak match": {
+			header: `W/"abc"`,
+			etag:   `"abc"`,
+			want:   true,
+		},
+		"list match": {
+			header: `"def", "abc"`,
+			etag:   `"abc"`,
+			want:   true,
+		},
+		"no match": {
+			header: `"def"`,
+			etag:   `"abc"`,
+			want:   false,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, c.want, etagMatches(c.header, c.etag))
+		})
+	}
+}
+
+func TestETagStrongMatches(t *testing.T) {
+	cases := map[string]struct {
+		header string
+		etag   string
+		want   bool
+	}{
+		"empty header": {
+			header: "",
+			etag:   `"abc"`,
+			want:   false,
+		},
+		"wildcard": {
+			header: "*",
+			etag:   `"abc"`,
+			want:   true,
+		},
+		"exact match": {
+			header: `"abc"`,
+			etag:   `"abc"`,
+			want:   true,
+		},
+		"weak header": {
+			header: `W/"abc"`,
+			etag:   `"abc"`,
+			want:   false,
+		},
+		"weak etag": {
+			header: `W/"abc"`,
+			etag:   `W/"abc"`,
+			want:   false,
+		},
+		"list match": {
+			header: `W/"abc", "abc"`,
+			etag:   `"abc"`,
+			want:   true,
+		},
+		"no match": {
+			header: `"def"`,
+			etag:   `"abc"`,
+			want:   false,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, c.want, etagStrongMatches(c.header, c.etag))
+		})
+	}
+}
+
+func TestCheckIfNoneMatch(t *testing.T) {
+	assert := assert.New(t)
+	etag := computeETag([]byte("content"), time.Unix(0, 0))
+
+	// Matching tag returns 304.
+	r := httptest.NewRequest("GET", "/api/v2/documents/doc123", nil)
+	r.Header.Set("If-None-Match", etag)
+	w := httptest.NewRecorder()
+	assert.True(checkIfNoneMatch(w, r, etag))
+	assert.Equal(http.StatusNotModified, w.Code)
+	assert.Equal(etag, w.Header().Get("ETag"))
+
+	// Stale tag continues processing with ETag set.
+	r = httptest.NewRequest("GET", "/api/v2/documents/doc123", nil)
+	r.Header.Set("If-None-Match", `"stale"`)
+	w = httptest.NewRecorder()
+	assert.False(checkIfNoneMatch(w, r, etag))
+	assert.Equal(etag, w.Header().Get("ETag"))
+}
+
+func TestCheckIfMatch(t *testing.T) {
+	assert := assert.New(t)
+	etag := computeETag([]byte("content"), time.Unix(0, 0))
+
+	// No header passes.
+	r := httptest.NewRequest("PATCH", "/api/v2/documents/doc123", nil)
+	w := httptest.NewRecorder()
+	assert.False(checkIfMatch(w, r, etag))
+
+	// Matching header passes.
+	r.Header.Set("If-Match", etag)
+	assert.False(checkIfMatch(w, r, etag))
+
+	// Weak header fails with 412.
+	r.Header.Set("If-Match", "W/"+etag)
+	assert.True(checkIfMatch(w, r, etag))
+	assert.Equal(http.StatusPreconditionFailed, w.Code)
+
+	// Stale header fails with 412.
+	w = httptest.NewRecorder()
+	r.Header.Set("If-Match", `"stale"`)
+	assert.True(checkIfMatch(w, r, etag))
+	assert.Equal(http.StatusPreconditionFailed, w.Code)
+}
@@ -0,0 +1,459 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/server"
+	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+	"github.com/hashicorp-forge/hermes/pkg/docid"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"gorm.io/gorm"
+)
+
+// Types of document events.
+const (
+	// documentEventCreated is sent when a draft is created or a document is
+	// imported.
+	documentEventCreated = "document.created"
+
+	// documentEventStatusChanged is sent when the status of a document
+	// changes (e.g., when a draft is published for review).
+	documentEventStatusChanged = "document.status_changed"
+
+	// documentEventApproved is sent when a reviewer approves a document.
+	documentEventApproved = "document.approved"
+
+	// documentEventRevisionCreated is sent when the indexer records a new
+	// revision of a document.
+	documentEventRevisionCreated = "document.revision_created"
+)
+
+const (
+	// documentEventsPollInterval is the interval at which streams check for
+	// new events. Events are read from the database, so streams see changes
+	// made through any server instance.
+	documentEventsPollInterval = time.Second
+
+	// documentEventsKeepAlive is the interval at which idle streams send a
+	// comment, so proxies don't close them.
+	documentEventsKeepAlive = 15 * time.Second
+
+	// documentEventsRetry is how long clients wait before reconnecting to a
+	// stream that ended.
+	documentEventsRetry = 5 * time.Second
+
+	// documentEventsBatchSize is the maximum number of audit events and
+	// revisions read at a time.
+	documentEventsBatchSize = 100
+)
+
+// DocumentEvent is a change in the lifecycle of a document.
+type DocumentEvent struct {
+	// Type is the type of the event ("document.created",
+	// "document.status_changed", "document.approved", or
+	// "document.revision_created").
+	Type       string `json:"type"`
+	DocumentID string `json:"documentId"`
+	Title      string `json:"title"`
+	DocType    string `json:"docType"`
+	Product    string `json:"product"`
+
+	// Status is the status of the document after the event.
+	Status string `json:"status"`
+
+	// PreviousStatus is the status of the document before a status change.
+	PreviousStatus string `json:"previousStatus,omitempty"`
+
+	// Actor is the email address of the user who made the change, or empty
+	// for new revisions.
+	Actor string `json:"actor,omitempty"`
+
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// documentEvent is a document event at a position of the event stream.
+type documentEvent struct {
+	DocumentEvent
+
+	// owner is the email address of the owner of the document.
+	owner string
+
+	// cursor is the position of the stream after the event.
+	cursor documentEventsCursor
+}
+
+// documentEventsCursor is a position in the audit log and the revision outbox
+// that document events are read from.
+type documentEventsCursor struct {
+	audit  uint64
+	outbox uint
+}
+
+// String returns the cursor as the ID of server-sent events (e.g., "12-34").
+func (c documentEventsCursor) String() string {
+	return fmt.Sprintf("%d-%d", c.audit, c.outbox)
+}
+
+// parseDocumentEventsCursor parses the ID of a server-sent event.
+func parseDocumentEventsCursor(s string) (documentEventsCursor, error) {
+	audit, outbox, ok := strings.Cut(s, "-")
+	if !ok {
+		return documentEventsCursor{}, errors.New("invalid event ID")
+	}
+	a, err := strconv.ParseUint(audit, 10, 64)
+	if err != nil {
+		return documentEventsCursor{}, errors.New("invalid event ID")
+	}
+	o, err := strconv.ParseUint(outbox, 10, 0)
+	if err != nil {
+		return documentEventsCursor{}, errors.New("invalid event ID")
+	}
+	return documentEventsCursor{audit: a, outbox: uint(o)}, nil
+}
+
+// DocumentEventsHandler streams document lifecycle events (GET
+// /api/v2/events) as server-sent events, so the web app can update document
+// lists without polling. Events are read from the audit log and the revision
+// outbox, so lifecycle events other than new revisions are only sent if audit
+// logging is enabled.
+//
+// The "product" query parameter limits events to documents of the given
+// products, and "subscriptions=true" to the products the user is subscribed
+// to. Events of drafts are only sent to their owners. Streams start with
+// events after the last event ID in the Last-Event-ID header (which browsers
+// send when reconnecting) or the "lastEventId" query parameter, or with new
+// events if neither is set.
+func DocumentEventsHandler(srv server.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
+		if r.Method != "GET" {
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
+			return
+		}
+
+		userEmail, ok := pkgauth.GetUserEmail(r.Context())
+		if !ok || userEmail == "" {
+			writeProblem(w, r, http.StatusUnauthorized, ErrCodeUnauthorized,
+				"No authorization information for request")
+			return
+		}
+
+		var cursor documentEventsCursor
+		lastEventID := r.Header.Get("Last-Event-ID")
+		if lastEventID == "" {
+			lastEventID = r.URL.Query().Get("lastEventId")
+		}
+		if lastEventID != "" {
+			var err error
+			if cursor, err = parseDocumentEventsCursor(lastEventID); err != nil {
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					"Invalid last event ID")
+				return
+			}
+		} else {
+			var err error
+			if cursor, err = lastDocumentEventsCursor(srv.DB); err != nil {
+				respondError(w, r, srv.Logger, http.StatusInternalServerError,
+					"Error streaming events",
+					"error getting last document events cursor", err,
+				)
+				return
+			}
+		}
+
+		products, err := documentEventsProducts(srv, r, userEmail)
+		if err != nil {
+			respondError(w, r, srv.Logger, http.StatusInternalServerError,
+				"Error streaming events",
+				"error getting product subscriptions", err,
+			)
+			return
+		}
+
+		// Streams are long-lived, so they aren't subject to the server's write
+		// timeout.
+		rc := http.NewResponseController(w)
+		if err := rc.SetWriteDeadline(time.Time{}); err != nil &&
+			!errors.Is(err, http.ErrNotSupported) {
+			srv.Logger.Warn("error clearing write deadline of event stream",
+				"error", err,
+			)
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-store")
+		// Disable response buffering by nginx.
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, "retry: %d\n\n", documentEventsRetry.Milliseconds())
+		if err := rc.Flush(); err != nil {
+			srv.Logger.Error("error flushing event stream",
+				"error", err,
+			)
+			return
+		}
+
+		ticker := time.NewTicker(documentEventsPollInterval)
+		defer ticker.Stop()
+		lastWrite := time.Now()
+		for {
+			events, next, more, err := findDocumentEvents(
+				srv, cursor, documentEventsBatchSize)
+			if err != nil {
+				// The client reconnects and resumes after the last event it
+				// received.
+				srv.Logger.Error("error finding document events",
+					"error", err,
+				)
+				return
+			}
+			cursor = next
+
+			var wrote bool
+			for _, e := range events {
+				if !documentEventVisible(e, userEmail, products) {
+					continue
+				}
+				if err := writeDocumentEvent(w, e); err != nil {
+					return
+				}
+				wrote = true
+			}
+			if !wrote && time.Since(lastWrite) >= documentEventsKeepAlive {
+				if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+					return
+				}
+				wrote = true
+			}
+			if wrote {
+				if err := rc.Flush(); err != nil {
+					return
+				}
+				lastWrite = time.Now()
+			}
+
+			if more {
+				continue
+			}
+			select {
+			case <-r.Context().Done():
+				return
+			case <-srv.Stopping:
+				return
+			case <-ticker.C:
+			}
+		}
+	})
+}
+
+// writeDocumentEvent writes event e as a server-sent event.
+func writeDocumentEvent(w http.ResponseWriter, e documentEvent) error {
+	data, err := json.Marshal(e.DocumentEvent)
+	if err != nil {
+		return fmt.Errorf("error marshaling event: %w", err)
+	}
+	_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n",
+		e.cursor, e.Type, data)
+	return err
+}
+
+// documentEventsProducts returns the names of the products whose events are
+// streamed to the user with email address userEmail, or nil for all products.
+func documentEventsProducts(
+	srv server.Server, r *http.Request, userEmail string,
+) (map[string]bool, error) {
+	names := parseListQueryParam(r, "product")
+	subscriptions := r.URL.Query().Get("subscriptions") == "true"
+	if len(names) == 0 && !subscriptions {
+		return nil, nil
+	}
+
+	products := make(map[string]bool, len(names))
+	for _, n := range names {
+		products[n] = true
+	}
+	if subscriptions {
+		u := models.User{
+			EmailAddress: userEmail,
+		}
+		if err := u.Get(srv.DB); err != nil &&
+			!errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		for _, p := range u.ProductSubscriptions {
+			products[p.Name] = true
+		}
+	}
+	return products, nil
+}
+
+// documentEventVisible returns true if event e is streamed to the user with
+// email address userEmail that follows products (nil for all products).
+func documentEventVisible(
+	e documentEvent, userEmail string, products map[string]bool,
+) bool {
+	if products != nil && !products[e.Product] {
+		return false
+	}
+	if e.Status == documentStatusString(models.WIPDocumentStatus) {
+		return strings.EqualFold(e.owner, userEmail)
+	}
+	return true
+}
+
+// lastDocumentEventsCursor returns the cursor after the newest audit event and
+// outbox entry, where streams without a last event ID start.
+func lastDocumentEventsCursor(db *gorm.DB) (documentEventsCursor, error) {
+	audit, err := models.LastAuditEventID(db)
+	if err != nil {
+		return documentEventsCursor{}, err
+	}
+	outbox, err := models.LastOutboxEntryID(db)
+	if err != nil {
+		return documentEventsCursor{}, fmt.Errorf(
+			"error getting last outbox entry ID: %w", err)
+	}
+	return documentEventsCursor{audit: audit, outbox: outbox}, nil
+}
+
+// findDocumentEvents finds the document events after cursor, reading up to
+// limit audit events and outbox entries. It returns the cursor after the
+// events, and true if there may be more events.
+func findDocumentEvents(
+	srv server.Server, cursor documentEventsCursor, limit int,
+) ([]documentEvent, documentEventsCursor, bool, error) {
+	var auditEvents models.AuditEvents
+	if err := auditEvents.FindAfter(
+		srv.DB, auditTargetDocument, cursor.audit, limit,
+	); err != nil {
+		return nil, cursor, false, fmt.Errorf(
+			"error finding audit events: %w", err)
+	}
+	entries, err := models.FindOutboxEntriesAfter(
+		srv.DB, cursor.outbox, models.RevisionEventCreated, limit)
+	if err != nil {
+		return nil, cursor, false, fmt.Errorf(
+			"error finding outbox entries: %w", err)
+	}
+
+	var events []documentEvent
+	for _, ae := range auditEvents {
+		cursor.audit = ae.ID
+		for _, e := range auditDocumentEvents(ae) {
+			e.cursor = cursor
+			events = append(events, e)
+		}
+	}
+	for _, o := range entries {
+		cursor.outbox = o.ID
+		e, ok, err := revisionDocumentEvent(srv.DB, o)
+		if err != nil {
+			return nil, cursor, false, err
+		}
+		if ok {
+			e.cursor = cursor
+			events = append(events, e)
+		}
+	}
+
+	more := len(auditEvents) == limit || len(entries) == limit
+	return events, cursor, more, nil
+}
+
+// auditDocumentEvents returns the document events of audit event ae, which
+// are determined from the snapshots of the document before and after the
+// request.
+func auditDocumentEvents(ae models.AuditEvent) []documentEvent {
+	if ae.TargetID == "" || ae.StatusCode < 200 || ae.StatusCode >= 300 {
+		return nil
+	}
+
+	var before, after map[string]any
+	if len(ae.After) == 0 || json.Unmarshal(ae.After, &after) != nil ||
+		after == nil {
+		// The document was deleted.
+		return nil
+	}
+	if len(ae.Before) > 0 && json.Unmarshal(ae.Before, &before) != nil {
+		return nil
+	}
+	field := func(m map[string]any, k string) string {
+		s, _ := m[k].(string)
+		return s
+	}
+
+	base := documentEvent{
+		DocumentEvent: DocumentEvent{
+			DocumentID: ae.TargetID,
+			Title:      field(after, "title"),
+			DocType:    field(after, "docType"),
+			Product:    field(after, "product"),
+			Status:     field(after, "status"),
+			Actor:      ae.Actor,
+			CreatedAt:  ae.CreatedAt,
+		},
+		owner: field(after, "owner"),
+	}
+	var events []documentEvent
+	add := func(typ string) {
+		e := base
+		e.Type = typ
+		events = append(events, e)
+	}
+
+	if before == nil {
+		if strings.HasSuffix(ae.Action, ".create") {
+			add(documentEventCreated)
+		}
+		return events
+	}
+	if ae.Action == "approvals.create" {
+		add(documentEventApproved)
+	}
+	if prev := field(before, "status"); prev != "" && prev != base.Status {
+		add(documentEventStatusChanged)
+		events[len(events)-1].PreviousStatus = prev
+	}
+	return events
+}
+
+// revisionDocumentEvent returns the document event of outbox entry o, and
+// false if the document of the revision isn't in the database.
+func revisionDocumentEvent(
+	db *gorm.DB, o models.DocumentRevisionOutbox,
+) (documentEvent, bool, error) {
+	uuid, err := docid.ParseUUID(o.DocumentUUID.String())
+	if err != nil {
+		return documentEvent{}, false, nil
+	}
+	var doc models.Document
+	if err := doc.GetByUUID(db, uuid); errors.Is(err, gorm.ErrRecordNotFound) {
+		return documentEvent{}, false, nil
+	} else if err != nil {
+		return documentEvent{}, false, fmt.Errorf(
+			"error getting document of revision: %w", err)
+	}
+
+	e := documentEvent{
+		DocumentEvent: DocumentEvent{
+			Type:       documentEventRevisionCreated,
+			DocumentID: doc.GoogleFileID,
+			Title:      doc.Title,
+			DocType:    doc.DocumentType.Name,
+			Product:    doc.Product.Name,
+			Status:     documentStatusString(doc.Status),
+			CreatedAt:  o.CreatedAt,
+		},
+	}
+	if doc.Owner != nil {
+		e.owner = doc.Owner.EmailAddress
+	}
+	return e, true, nil
+}
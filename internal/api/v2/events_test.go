@@ -0,0 +1,116 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditDocumentEvents(t *testing.T) {
+	doc := func(action string, status int, before, after string) models.AuditEvent {
+		return models.AuditEvent{
+			Actor:      "a@example.com",
+			Action:     action,
+			StatusCode: status,
+			TargetType: auditTargetDocument,
+			TargetID:   "abc",
+			Before:     models.JSON(before),
+			After:      models.JSON(after),
+		}
+	}
+
+	cases := []struct {
+		name  string
+		event models.AuditEvent
+		want  []string
+	}{
+		{"draft created",
+			doc("drafts.create", 200, ``,
+				`{"title":"A","status":"WIP","owner":"a@example.com"}`),
+			[]string{documentEventCreated}},
+		{"failed draft creation",
+			doc("drafts.create", 400, ``, ``),
+			nil},
+		{"published for review",
+			doc("reviews.create", 200, `{"status":"WIP"}`, `{"status":"In-Review"}`),
+			[]string{documentEventStatusChanged}},
+		{"approval",
+			doc("approvals.create", 200,
+				`{"status":"In-Review"}`, `{"status":"In-Review"}`),
+			[]string{documentEventApproved}},
+		{"approval that changed the status",
+			doc("approvals.create", 200,
+				`{"status":"In-Review"}`, `{"status":"Approved"}`),
+			[]string{documentEventApproved, documentEventStatusChanged}},
+		{"title changed",
+			doc("documents.update", 200, `{"title":"A"}`, `{"title":"B"}`),
+			nil},
+		{"document deleted",
+			doc("drafts.delete", 200, `{"status":"WIP"}`, ``),
+			nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var got []string
+			for _, e := range auditDocumentEvents(c.event) {
+				got = append(got, e.Type)
+				assert.Equal(t, "abc", e.DocumentID)
+				assert.Equal(t, "a@example.com", e.Actor)
+			}
+			assert.Equal(t, c.want, got)
+		})
+	}
+
+	t.Run("status change", func(t *testing.T) {
+		events := auditDocumentEvents(doc("reviews.create", 200,
+			`{"status":"WIP"}`,
+			`{"title":"A","status":"In-Review","product":"Terraform","docType":"RFC"}`))
+		require.Len(t, events, 1)
+		assert.Equal(t, DocumentEvent{
+			Type:           documentEventStatusChanged,
+			DocumentID:     "abc",
+			Title:          "A",
+			DocType:        "RFC",
+			Product:        "Terraform",
+			Status:         "In-Review",
+			PreviousStatus: "WIP",
+			Actor:          "a@example.com",
+		}, events[0].DocumentEvent)
+	})
+}
+
+func TestDocumentEventVisible(t *testing.T) {
+	draft := documentEvent{
+		DocumentEvent: DocumentEvent{Product: "Terraform", Status: "WIP"},
+		owner:         "owner@example.com",
+	}
+	published := documentEvent{
+		DocumentEvent: DocumentEvent{Product: "Terraform", Status: "In-Review"},
+		owner:         "owner@example.com",
+	}
+
+	assert.True(t, documentEventVisible(draft, "Owner@example.com", nil))
+	assert.False(t, documentEventVisible(draft, "other@example.com", nil))
+	assert.True(t, documentEventVisible(published, "other@example.com", nil))
+	assert.True(t, documentEventVisible(published, "other@example.com",
+		map[string]bool{"Terraform": true}))
+	assert.False(t, documentEventVisible(published, "other@example.com",
+		map[string]bool{"Vault": true}))
+	assert.False(t, documentEventVisible(published, "other@example.com",
+		map[string]bool{}))
+}
+
+func TestParseDocumentEventsCursor(t *testing.T) {
+	c, err := parseDocumentEventsCursor("12-34")
+	require.NoError(t, err)
+	assert.Equal(t, documentEventsCursor{audit: 12, outbox: 34}, c)
+	assert.Equal(t, "12-34", c.String())
+
+	for _, s := range []string{"", "12", "a-1", "1-b", "-1-2"} {
+		_, err := parseDocumentEventsCursor(s)
+		assert.Error(t, err, s)
+	}
+}
@@ -0,0 +1,349 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/pkg/authz"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"gorm.io/gorm"
+)
+
+// GroupReviewApproval is an approval counted toward a group review.
+type GroupReviewApproval struct {
+	// Member is the group member the approval counts for.
+	Member string `json:"member"`
+
+	// Approver is the user who approved. It differs from Member when a
+	// delegate approved on the member's behalf.
+	Approver string `json:"approver"`
+
+	ApprovedAt time.Time `json:"approvedAt"`
+}
+
+// GroupReviewStatus is the quorum status of a document's group review.
+type GroupReviewStatus struct {
+	Group             string                `json:"group"`
+	RequiredApprovals uint                  `json:"requiredApprovals"`
+	Approvals         []GroupReviewApproval `json:"approvals"`
+	Satisfied         bool                  `json:"satisfied"`
+}
+
+// GroupReviewQuorum sets the number of members who must approve for a group.
+type GroupReviewQuorum struct {
+	Group             string `json:"group"`
+	RequiredApprovals uint   `json:"requiredApprovals"`
+}
+
+// GroupReviewsPatchRequest contains the fields that are allowed to be patched
+// for a document's group reviews.
+type GroupReviewsPatchRequest struct {
+	Quorums []GroupReviewQuorum `json:"quorums"`
+}
+
+// GroupReviewsHandler handles a document's group review quorums
+// (GET/PATCH /api/v2/group-reviews/:document_id). GET returns each approver
+// group's approvals and whether its quorum is met; PATCH lets the document
+// owner set how many members of each group must approve.
+func GroupReviewsHandler(srv server.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
+		docID, err := parseResourceIDFromURL(r.URL.Path, "group-reviews")
+		if err != nil {
+			writeProblem(w, r, http.StatusNotFound, ErrCodeDocumentNotFound,
+				"Document ID not found")
+			return
+		}
+
+		errResp := func(httpCode int, userErrMsg, logErrMsg string, err error) {
+			srv.Logger.Error(logErrMsg,
+				"error", err,
+				"method", r.Method,
+				"path", r.URL.Path,
+				"doc_id", docID,
+			)
+			writeProblem(w, r, httpCode, errorCodeForStatus(httpCode), userErrMsg)
+		}
+
+		model := models.Document{
+			GoogleFileID: docID,
+		}
+		if err := model.Get(srv.DB); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				writeProblem(w, r, http.StatusNotFound, ErrCodeDocumentNotFound,
+					"Document not found")
+				return
+			}
+			errResp(http.StatusInternalServerError, "Error accessing document",
+				"error getting document from database", err)
+			return
+		}
+
+		var groupReviews models.DocumentGroupReviews
+		if err := groupReviews.Find(srv.DB, models.DocumentGroupReview{
+			Document: models.Document{
+				GoogleFileID: docID,
+			},
+		}); err != nil {
+			errResp(http.StatusInternalServerError, "Error accessing group reviews",
+				"error getting group reviews for document", err)
+			return
+		}
+
+		switch r.Method {
+		case "GET":
+			// Respond with the current status below.
+
+		case "PATCH":
+			if !authorizeRequest(w, r, srv.Config, srv.DB, srv.Logger,
+				authz.ActionDocumentEdit, documentModelAuthzResource(model),
+				"Only the document owner can change group review quorums",
+			) {
+				return
+			}
+
+			var req GroupReviewsPatchRequest
+			if err := decodeRequest(r, &req); err != nil {
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					fmt.Sprintf("Bad request: %q", err))
+				return
+			}
+
+			byGroup := make(map[string]*models.DocumentGroupReview, len(groupReviews))
+			for i := range groupReviews {
+				byGroup[groupReviews[i].Group.EmailAddress] = &groupReviews[i]
+			}
+			for _, q := range req.Quorums {
+				if _, ok := byGroup[q.Group]; !ok {
+					writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+						fmt.Sprintf("Group %q is not an approver group of the document",
+							q.Group))
+					return
+				}
+				if q.RequiredApprovals < 1 {
+					writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+						"requiredApprovals must be at least 1")
+					return
+				}
+			}
+
+			if err := srv.DB.Transaction(func(tx *gorm.DB) error {
+				for _, q := range req.Quorums {
+					gr := byGroup[q.Group]
+					gr.RequiredApprovals = q.RequiredApprovals
+					if err := gr.Update(tx); err != nil {
+						return fmt.Errorf("error updating group review for %s: %w",
+							q.Group, err)
+					}
+				}
+				return nil
+			}); err != nil {
+				errResp(http.StatusInternalServerError,
+					"Error updating group reviews",
+					"error updating group review quorums", err)
+				return
+			}
+
+		default:
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
+			return
+		}
+
+		var approvals models.DocumentGroupReviewApprovals
+		if err := approvals.Find(srv.DB, model.ID); err != nil {
+			errResp(http.StatusInternalServerError, "Error accessing group reviews",
+				"error getting group review approvals for document", err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(
+			groupReviewStatuses(groupReviews, approvals),
+		); err != nil {
+			srv.Logger.Error("error encoding group reviews response",
+				"error", err,
+				"method", r.Method,
+				"path", r.URL.Path,
+				"doc_id", docID,
+			)
+		}
+	})
+}
+
+// groupReviewStatuses returns the quorum status of each group review.
+func groupReviewStatuses(
+	groupReviews models.DocumentGroupReviews,
+	approvals models.DocumentGroupReviewApprovals,
+) []GroupReviewStatus {
+	res := []GroupReviewStatus{}
+	for _, gr := range groupReviews {
+		required := gr.RequiredApprovals
+		if required < 1 {
+			required = 1
+		}
+
+		s := GroupReviewStatus{
+			Group:             gr.Group.EmailAddress,
+			RequiredApprovals: required,
+			Approvals:         []GroupReviewApproval{},
+		}
+		members := make(map[uint]bool)
+		for _, a := range approvals {
+			if a.GroupID != gr.GroupID {
+				continue
+			}
+			members[a.MemberID] = true
+			s.Approvals = append(s.Approvals, GroupReviewApproval{
+				Member:     a.Member.EmailAddress,
+				Approver:   a.Approver.EmailAddress,
+				ApprovedAt: a.ApprovedAt,
+			})
+		}
+		s.Satisfied = uint(len(members)) >= required
+		res = append(res, s)
+	}
+	return res
+}
+
+// groupApproval is an approval a user can give for an approver group, as a
+// member of the group or as a delegate of Member.
+type groupApproval struct {
+	Group  string
+	Member string
+}
+
+// delegationGrant is an active review delegation to a user.
+type delegationGrant struct {
+	Delegator string
+
+	// Group is the group the delegation applies to, or empty for all of the
+	// delegator's groups.
+	Group string
+
+	// DelegatorGroups are the groups the delegator is a member of.
+	DelegatorGroups []string
+}
+
+// resolveGroupApprovals returns the approver groups a user can approve for,
+// either as a member or on behalf of a member who delegated their review to
+// the user.
+func resolveGroupApprovals(
+	ctx context.Context,
+	srv server.Server,
+	userEmail string,
+	approverGroups []string,
+) ([]groupApproval, error) {
+	if len(approverGroups) == 0 {
+		return nil, nil
+	}
+
+	teamEmails := func(email string) ([]string, error) {
+		teams, err := srv.WorkspaceProvider.GetUserTeams(ctx, email)
+		if err != nil {
+			return nil, fmt.Errorf("error getting teams for user: %w", err)
+		}
+		var emails []string
+		for _, t := range teams {
+			if t != nil {
+				emails = append(emails, t.Email)
+			}
+		}
+		return emails, nil
+	}
+
+	userGroups, err := teamEmails(userEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	var delegations models.ReviewDelegations
+	if err := delegations.FindActiveForDelegate(
+		srv.DB.WithContext(ctx), userEmail, time.Now(),
+	); err != nil {
+		return nil, fmt.Errorf("error getting review delegations: %w", err)
+	}
+
+	delegatorGroups := make(map[string][]string)
+	var grants []delegationGrant
+	for _, d := range delegations {
+		delegator := d.Delegator.EmailAddress
+		groups, ok := delegatorGroups[delegator]
+		if !ok {
+			if groups, err = teamEmails(delegator); err != nil {
+				return nil, err
+			}
+			delegatorGroups[delegator] = groups
+		}
+
+		grant := delegationGrant{
+			Delegator:       delegator,
+			DelegatorGroups: groups,
+		}
+		if d.Group != nil {
+			grant.Group = d.Group.EmailAddress
+		}
+		grants = append(grants, grant)
+	}
+
+	return matchGroupApprovals(userEmail, approverGroups, userGroups, grants), nil
+}
+
+// matchGroupApprovals matches approver groups to the user's own membership,
+// falling back to delegations from group members.
+func matchGroupApprovals(
+	userEmail string,
+	approverGroups, userGroups []string,
+	grants []delegationGrant,
+) []groupApproval {
+	var res []groupApproval
+	for _, g := range approverGroups {
+		if contains(userGroups, g) {
+			res = append(res, groupApproval{Group: g, Member: userEmail})
+			continue
+		}
+		for _, d := range grants {
+			if d.Group != "" && d.Group != g {
+				continue
+			}
+			if contains(d.DelegatorGroups, g) {
+				res = append(res, groupApproval{Group: g, Member: d.Delegator})
+				break
+			}
+		}
+	}
+	return res
+}
+
+// recordGroupApprovals records a user's approval toward group reviews.
+func recordGroupApprovals(
+	db *gorm.DB, docID, approverEmail string, approvals []groupApproval,
+) error {
+	for _, a := range approvals {
+		ga := models.DocumentGroupReviewApproval{
+			Document: models.Document{
+				GoogleFileID: docID,
+			},
+			Group: models.Group{
+				EmailAddress: a.Group,
+			},
+			Member: models.User{
+				EmailAddress: a.Member,
+			},
+			Approver: models.User{
+				EmailAddress: approverEmail,
+			},
+		}
+		if err := ga.Create(db); err != nil {
+			return fmt.Errorf("error recording approval for group %s: %w",
+				a.Group, err)
+		}
+	}
+	return nil
+}
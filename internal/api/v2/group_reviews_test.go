@@ -0,0 +1,150 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchGroupApprovals(t *testing.T) {
+	approverGroups := []string{"eng@example.com", "sec@example.com"}
+
+	t.Run("member approves for own groups", func(t *testing.T) {
+		got := matchGroupApprovals("a@example.com", approverGroups,
+			[]string{"eng@example.com", "other@example.com"}, nil)
+		assert.Equal(t, []groupApproval{
+			{Group: "eng@example.com", Member: "a@example.com"},
+		}, got)
+	})
+
+	t.Run("delegate approves on behalf of a member", func(t *testing.T) {
+		got := matchGroupApprovals("b@example.com", approverGroups, nil,
+			[]delegationGrant{
+				{
+					Delegator:       "a@example.com",
+					DelegatorGroups: []string{"eng@example.com", "sec@example.com"},
+				},
+			})
+		assert.Equal(t, []groupApproval{
+			{Group: "eng@example.com", Member: "a@example.com"},
+			{Group: "sec@example.com", Member: "a@example.com"},
+		}, got)
+	})
+
+	t.Run("group-scoped delegation", func(t *testing.T) {
+		got := matchGroupApprovals("b@example.com", approverGroups, nil,
+			[]delegationGrant{
+				{
+					Delegator:       "a@example.com",
+					Group:           "sec@example.com",
+					DelegatorGroups: []string{"eng@example.com", "sec@example.com"},
+				},
+			})
+		assert.Equal(t, []groupApproval{
+			{Group: "sec@example.com", Member: "a@example.com"},
+		}, got)
+	})
+
+	t.Run("own membership takes precedence over delegation", func(t *testing.T) {
+		got := matchGroupApprovals("b@example.com", approverGroups,
+			[]string{"eng@example.com"},
+			[]delegationGrant{
+				{
+					Delegator:       "a@example.com",
+					DelegatorGroups: []string{"eng@example.com"},
+				},
+			})
+		assert.Equal(t, []groupApproval{
+			{Group: "eng@example.com", Member: "b@example.com"},
+		}, got)
+	})
+
+	t.Run("delegator not in group", func(t *testing.T) {
+		got := matchGroupApprovals("b@example.com", approverGroups, nil,
+			[]delegationGrant{
+				{
+					Delegator:       "a@example.com",
+					DelegatorGroups: []string{"other@example.com"},
+				},
+			})
+		assert.Empty(t, got)
+	})
+}
+
+func TestGroupReviewStatuses(t *testing.T) {
+	eng := models.Group{EmailAddress: "eng@example.com"}
+	eng.ID = 1
+	sec := models.Group{EmailAddress: "sec@example.com"}
+	sec.ID = 2
+	alice := models.User{EmailAddress: "alice@example.com"}
+	alice.ID = 10
+	bob := models.User{EmailAddress: "bob@example.com"}
+	bob.ID = 11
+
+	groupReviews := models.DocumentGroupReviews{
+		{GroupID: eng.ID, Group: eng, RequiredApprovals: 2},
+		{GroupID: sec.ID, Group: sec},
+	}
+	approvedAt := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	approvals := models.DocumentGroupReviewApprovals{
+		{
+			GroupID:    eng.ID,
+			MemberID:   alice.ID,
+			Member:     alice,
+			ApproverID: bob.ID,
+			Approver:   bob,
+			ApprovedAt: approvedAt,
+		},
+	}
+
+	got := groupReviewStatuses(groupReviews, approvals)
+	if assert.Len(t, got, 2) {
+		assert.Equal(t, "eng@example.com", got[0].Group)
+		assert.Equal(t, uint(2), got[0].RequiredApprovals)
+		assert.False(t, got[0].Satisfied)
+		assert.Equal(t, []GroupReviewApproval{
+			{
+				Member:     "alice@example.com",
+				Approver:   "bob@example.com",
+				ApprovedAt: approvedAt,
+			},
+		}, got[0].Approvals)
+
+		// A zero quorum is treated as one approval.
+		assert.Equal(t, uint(1), got[1].RequiredApprovals)
+		assert.False(t, got[1].Satisfied)
+		assert.Empty(t, got[1].Approvals)
+	}
+
+	approvals = append(approvals, models.DocumentGroupReviewApproval{
+		GroupID:    eng.ID,
+		MemberID:   bob.ID,
+		Member:     bob,
+		ApproverID: bob.ID,
+		Approver:   bob,
+		ApprovedAt: approvedAt,
+	})
+	got = groupReviewStatuses(groupReviews, approvals)
+	assert.True(t, got[0].Satisfied)
+}
+
+func TestValidateReviewDelegation(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	later := now.Add(24 * time.Hour)
+
+	assert.Empty(t, validateReviewDelegation(
+		"a@example.com", "b@example.com", now, later, now))
+	assert.NotEmpty(t, validateReviewDelegation(
+		"a@example.com", "", now, later, now))
+	assert.NotEmpty(t, validateReviewDelegation(
+		"a@example.com", "A@example.com", now, later, now))
+	assert.NotEmpty(t, validateReviewDelegation(
+		"a@example.com", "b@example.com", now, time.Time{}, now))
+	assert.NotEmpty(t, validateReviewDelegation(
+		"a@example.com", "b@example.com", later, now, now))
+	assert.NotEmpty(t, validateReviewDelegation(
+		"a@example.com", "b@example.com", now.Add(-48*time.Hour),
+		now.Add(-24*time.Hour), now))
+}
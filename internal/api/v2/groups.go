@@ -34,6 +34,7 @@ type GroupsPostResponseGroup struct {
 // GroupsHandler returns information about Google Groups.
 func GroupsHandler(srv server.Server) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
 		logArgs := []any{
 			"method", r.Method,
 			"path", r.URL.Path,
@@ -43,16 +44,16 @@ func GroupsHandler(srv server.Server) http.Handler {
 		userEmail, ok := pkgauth.GetUserEmail(r.Context())
 		if !ok || userEmail == "" {
 			srv.Logger.Error("user email not found in request context", logArgs...)
-			http.Error(
-				w, "No authorization information in request", http.StatusUnauthorized)
+			writeProblem(w, r, http.StatusUnauthorized, ErrCodeUnauthorized,
+				"No authorization information in request")
 			return
 		}
 
 		// Respond with error if group approvals are not enabled.
 		if srv.Config.GoogleWorkspace.GroupApprovals == nil ||
 			!srv.Config.GoogleWorkspace.GroupApprovals.Enabled {
-			http.Error(w,
-				"Group approvals have not been enabled", http.StatusUnprocessableEntity)
+			writeProblem(w, r, http.StatusUnprocessableEntity, ErrCodeUnprocessable,
+				"Group approvals have not been enabled")
 			return
 		}
 
@@ -65,8 +66,8 @@ func GroupsHandler(srv server.Server) http.Handler {
 					append([]interface{}{
 						"error", err,
 					}, logArgs...)...)
-				http.Error(w, fmt.Sprintf("Bad request: %q", err),
-					http.StatusBadRequest)
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					fmt.Sprintf("Bad request: %q", err))
 				return
 			}
 
@@ -103,8 +104,8 @@ func GroupsHandler(srv server.Server) http.Handler {
 						append([]interface{}{
 							"error", err,
 						}, logArgs...)...)
-					http.Error(w, fmt.Sprintf("Error searching groups: %q", err),
-						http.StatusInternalServerError)
+					writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						fmt.Sprintf("Error searching groups: %q", err))
 					return
 				}
 				// Convert teams to admin.Groups format for compatibility
@@ -130,8 +131,8 @@ func GroupsHandler(srv server.Server) http.Handler {
 					append([]interface{}{
 						"error", err,
 					}, logArgs...)...)
-				http.Error(w, fmt.Sprintf("Error searching groups: %q", err),
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					fmt.Sprintf("Error searching groups: %q", err))
 				return
 			}
 			// Convert teams to admin.Groups format for compatibility
@@ -166,13 +167,14 @@ func GroupsHandler(srv server.Server) http.Handler {
 					append([]interface{}{
 						"error", err,
 					}, logArgs...)...)
-				http.Error(w, "Error searching groups",
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error searching groups")
 				return
 			}
 
 		default:
-			w.WriteHeader(http.StatusMethodNotAllowed)
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
 			return
 		}
 	})
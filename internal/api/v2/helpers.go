@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -11,8 +12,12 @@ import (
 	"strings"
 
 	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/internal/email"
+	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/pkg/document"
 	"github.com/hashicorp-forge/hermes/pkg/hashicorpdocs"
 	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp-forge/hermes/pkg/requestid"
 	"github.com/hashicorp-forge/hermes/pkg/search"
 	"github.com/hashicorp-forge/hermes/pkg/workspace"
 	gw "github.com/hashicorp-forge/hermes/pkg/workspace/adapters/google"
@@ -137,7 +142,9 @@ func parseResourceIDFromURL(url, apiPath string) (string, error) {
 	return resultPath[0], nil
 }
 
-// respondError responds to an HTTP request and logs an error.
+// respondError responds to an HTTP request and logs an error. Workspace
+// provider timeouts are responded to with 504 Gateway Timeout instead of
+// httpCode, so clients can tell them apart and retry.
 func respondError(
 	w http.ResponseWriter, r *http.Request, l hclog.Logger,
 	httpCode int, userErrMsg, logErrMsg string, err error,
@@ -150,7 +157,34 @@ func respondError(
 			"path", r.URL.Path,
 		}, extraArgs...)...,
 	)
-	http.Error(w, userErrMsg, httpCode)
+	if errors.Is(err, workspace.ErrTimeout) {
+		httpCode = http.StatusGatewayTimeout
+		userErrMsg = "The workspace provider didn't respond in time; try again"
+	}
+	writeProblem(w, r, httpCode, errorCodeForStatus(httpCode), userErrMsg)
+}
+
+// postProcessingContext returns the context for request post-processing
+// goroutines, which run after the response is written and r's context is
+// canceled. It keeps the values of r's context, such as the authenticated user
+// and request ID.
+func postProcessingContext(r *http.Request) context.Context {
+	return context.WithoutCancel(r.Context())
+}
+
+// writeUpsertProblem writes the problem for an error upserting a document:
+// 409 Conflict if the document was updated concurrently since it was read, so
+// the client can retry with the current document, or 500 with userErrMsg.
+func writeUpsertProblem(
+	w http.ResponseWriter, r *http.Request, err error, userErrMsg string,
+) {
+	if errors.Is(err, models.ErrDocumentVersionConflict) {
+		writeProblem(w, r, http.StatusConflict, ErrCodeConflict,
+			"The document was updated by another request; reload it and try again")
+		return
+	}
+	writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+		userErrMsg)
 }
 
 // fakeT fulfills the assert.TestingT interface so we can use
@@ -682,7 +716,7 @@ func getStringSliceValue(in map[string]any, key string) ([]string, error) {
 // Returns nil if the provider is not Google Workspace.
 func getGoogleDocsProvider(provider workspace.WorkspaceProvider) hashicorpdocs.GoogleDocsProvider {
 	// Check if provider is Google Workspace adapter
-	if googleAdapter, ok := provider.(*gw.Adapter); ok {
+	if googleAdapter, ok := workspace.Unwrap(provider).(*gw.Adapter); ok {
 		return googleAdapter.GetService()
 	}
 	return nil
@@ -691,7 +725,7 @@ func getGoogleDocsProvider(provider workspace.WorkspaceProvider) hashicorpdocs.G
 // getCompatProvider converts WorkspaceProvider to the old Provider interface.
 // This is a temporary helper during migration to support legacy code expecting workspace.Provider.
 func getCompatProvider(provider workspace.WorkspaceProvider) workspace.Provider {
-	if googleAdapter, ok := provider.(*gw.Adapter); ok {
+	if googleAdapter, ok := workspace.Unwrap(provider).(*gw.Adapter); ok {
 		// Return a compat adapter that implements the full Provider interface
 		return gw.NewCompatAdapter(googleAdapter.GetService())
 	}
@@ -699,12 +733,73 @@ func getCompatProvider(provider workspace.WorkspaceProvider) workspace.Provider
 	return nil
 }
 
+// emailSender returns the sender of email notifications: the SMTP sender if
+// configured, or else the workspace provider if it can send emails.
+func emailSender(srv server.Server) email.Sender {
+	if srv.EmailSender != nil {
+		return srv.EmailSender
+	}
+	if p := getCompatProvider(srv.WorkspaceProvider); p != nil {
+		return p
+	}
+	return nil
+}
+
+// userLocale returns the preferred locale of the user with the given email
+// address, or "" if the user doesn't have one.
+func userLocale(ctx context.Context, srv server.Server, userEmail string) string {
+	if srv.DB == nil {
+		return ""
+	}
+	locales, err := models.GetUserLocales(
+		srv.DB.WithContext(ctx), []string{userEmail})
+	if err != nil {
+		requestid.Logger(ctx, srv.Logger).Warn("error getting user locale",
+			"error", err,
+			"user_email", userEmail,
+		)
+		return ""
+	}
+	return locales[userEmail]
+}
+
 // getGoogleDocsUpdater extracts the old Provider interface from WorkspaceProvider if it's Google.
 // This is needed for ReplaceHeader operations which require GetDoc, UpdateDoc, and RenameFile.
 func getGoogleDocsUpdater(provider workspace.WorkspaceProvider) workspace.Provider {
 	return getCompatProvider(provider)
 }
 
+// replaceDocumentHeader replaces the metadata header of a document: the header
+// table of Google Docs, or the Markdown header of documents whose content is
+// edited through the workspace provider. Documents in other formats are
+// skipped.
+func replaceDocumentHeader(
+	ctx context.Context, srv server.Server, doc *document.Document, isDraft bool,
+) error {
+	// Only show the custom fields in the header layout of the document type.
+	if dt := findDocumentType(
+		documentTypes(ctx, srv), doc.DocType); dt != nil &&
+		len(dt.HeaderCustomFields) > 0 {
+		d := *doc
+		d.CustomFields = doc.HeaderCustomFields(dt.HeaderCustomFields)
+		doc = &d
+	}
+
+	if googleUpdater := getGoogleDocsUpdater(srv.WorkspaceProvider); googleUpdater != nil {
+		return doc.ReplaceHeader(srv.Config.BaseURL, isDraft, googleUpdater)
+	}
+
+	err := doc.ReplaceMarkdownHeader(ctx,
+		getWorkspaceProviderID(srv.Config, doc.ObjectID),
+		srv.Config.BaseURL, isDraft, srv.WorkspaceProvider)
+	if errors.Is(err, document.ErrUnsupportedContentFormat) {
+		srv.Logger.Warn("header replacement skipped",
+			"error", err, "doc_id", doc.ObjectID)
+		return nil
+	}
+	return err
+}
+
 // isUserInGroupsRFC084 checks if a user is in any supplied groups using RFC-084 interfaces.
 func isUserInGroupsRFC084(
 	ctx context.Context,
@@ -1,6 +1,10 @@
 package api
 
 import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"testing"
 	"time"
@@ -1289,3 +1293,29 @@ func TestCompareAlgoliaAndDatabaseDocument(t *testing.T) {
 		})
 	}
 }
+
+func TestWriteUpsertProblem(t *testing.T) {
+	cases := map[string]struct {
+		err        error
+		wantStatus int
+	}{
+		"version conflict": {
+			err: fmt.Errorf("error upserting: %w",
+				models.ErrDocumentVersionConflict),
+			wantStatus: http.StatusConflict,
+		},
+		"other error": {
+			err:        errors.New("connection refused"),
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("PATCH", "/api/v2/documents/abc", nil)
+			writeUpsertProblem(w, r, c.err, "Error patching document")
+			assert.Equal(t, c.wantStatus, w.Code)
+		})
+	}
+}
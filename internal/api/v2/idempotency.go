@@ -0,0 +1,234 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/server"
+	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"gorm.io/gorm"
+)
+
+const (
+	// idempotencyKeyHeader is the request header clients use to make POST
+	// requests safe to retry.
+	idempotencyKeyHeader = "Idempotency-Key"
+
+	// idempotentReplayedHeader is set on responses replayed from a stored
+	// idempotency key.
+	idempotentReplayedHeader = "Idempotent-Replayed"
+
+	// idempotencyKeyTTL is how long a stored response is replayed for.
+	idempotencyKeyTTL = 24 * time.Hour
+
+	// maxIdempotencyKeyLength is the maximum allowed Idempotency-Key length.
+	maxIdempotencyKeyLength = 255
+)
+
+const (
+	ErrCodeIdempotencyKeyInvalid    ErrorCode = "idempotency_key_invalid"
+	ErrCodeIdempotencyKeyReused     ErrorCode = "idempotency_key_reused"
+	ErrCodeIdempotencyKeyInProgress ErrorCode = "idempotency_key_in_progress"
+)
+
+// IdempotentHandler wraps a resource creation handler so POST requests with
+// an Idempotency-Key header are executed at most once per user. Retries with
+// the same key and request body replay the original response; reusing a key
+// with a different request body is rejected. Requests without the header, and
+// non-POST requests, are passed through unchanged.
+func IdempotentHandler(srv server.Server, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
+		key := r.Header.Get(idempotencyKeyHeader)
+		if r.Method != "POST" || key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if len(key) > maxIdempotencyKeyLength {
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeIdempotencyKeyInvalid,
+				"Bad request: Idempotency-Key header is too long")
+			return
+		}
+
+		userEmail, ok := pkgauth.GetUserEmail(r.Context())
+		if !ok || userEmail == "" {
+			writeProblem(w, r, http.StatusUnauthorized, ErrCodeUnauthorized,
+				"Unauthorized")
+			return
+		}
+
+		// Read and restore the request body so it can be hashed.
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			respondError(w, r, srv.Logger, http.StatusBadRequest,
+				"Bad request", "error reading request body", err)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		reqHash := idempotencyRequestHash(r, body)
+
+		now := time.Now()
+		rec := models.IdempotencyKey{
+			Key:       key,
+			UserEmail: userEmail,
+		}
+		if err := rec.Get(srv.DB); err == nil {
+			switch {
+			case rec.Expired(now):
+				// Expired keys may be reused.
+				if err := rec.Delete(srv.DB); err != nil {
+					respondError(w, r, srv.Logger, http.StatusInternalServerError,
+						"Error processing request",
+						"error deleting expired idempotency key", err)
+					return
+				}
+			case rec.RequestHash != reqHash:
+				writeProblem(w, r, http.StatusUnprocessableEntity,
+					ErrCodeIdempotencyKeyReused,
+					"Idempotency-Key has already been used with a different request")
+				return
+			case !rec.Completed:
+				writeProblem(w, r, http.StatusConflict,
+					ErrCodeIdempotencyKeyInProgress,
+					"A request with this Idempotency-Key is already in progress")
+				return
+			default:
+				srv.Logger.Info("replaying idempotent response",
+					"method", r.Method,
+					"path", r.URL.Path,
+					"user", userEmail,
+				)
+				replayIdempotentResponse(w, &rec)
+				return
+			}
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			respondError(w, r, srv.Logger, http.StatusInternalServerError,
+				"Error processing request", "error getting idempotency key", err)
+			return
+		}
+
+		// Reserve the key before executing the request. A unique constraint
+		// violation here means a concurrent request won the race.
+		rec = models.IdempotencyKey{
+			Key:         key,
+			UserEmail:   userEmail,
+			Method:      r.Method,
+			Path:        r.URL.Path,
+			RequestHash: reqHash,
+			ExpiresAt:   now.Add(idempotencyKeyTTL),
+		}
+		if err := rec.Create(srv.DB); errors.Is(err, models.ErrIdempotencyKeyExists) {
+			writeProblem(w, r, http.StatusConflict,
+				ErrCodeIdempotencyKeyInProgress,
+				"A request with this Idempotency-Key is already in progress")
+			return
+		} else if err != nil {
+			respondError(w, r, srv.Logger, http.StatusInternalServerError,
+				"Error processing request", "error reserving idempotency key", err,
+				"user", userEmail)
+			return
+		}
+
+		// Release the reservation if the handler panics, so the client can
+		// retry instead of getting "in progress" responses until the key
+		// expires.
+		defer func() {
+			if p := recover(); p != nil {
+				releaseIdempotencyKey(srv, r, &rec)
+				panic(p)
+			}
+		}()
+
+		rw := &recordingResponseWriter{ResponseWriter: w}
+		next.ServeHTTP(rw, r)
+
+		// Server errors are not stored so the client can retry.
+		if rw.Status() >= 500 {
+			releaseIdempotencyKey(srv, r, &rec)
+			return
+		}
+
+		if err := rec.Complete(srv.DB,
+			rw.Status(), rw.Header().Get("Content-Type"), rw.body.Bytes(),
+		); err != nil {
+			srv.Logger.Error("error storing idempotent response",
+				"error", err,
+				"method", r.Method,
+				"path", r.URL.Path,
+			)
+		}
+	})
+}
+
+// releaseIdempotencyKey deletes an in-flight idempotency key reservation.
+func releaseIdempotencyKey(
+	srv server.Server, r *http.Request, rec *models.IdempotencyKey,
+) {
+	if err := rec.Delete(srv.DB); err != nil {
+		srv.Logger.Error("error releasing idempotency key",
+			"error", err,
+			"method", r.Method,
+			"path", r.URL.Path,
+		)
+	}
+}
+
+// idempotencyRequestHash returns a hash of the request method, path, and
+// body.
+func idempotencyRequestHash(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method))
+	h.Write([]byte{0})
+	h.Write([]byte(r.URL.Path))
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// replayIdempotentResponse writes a stored response.
+func replayIdempotentResponse(w http.ResponseWriter, rec *models.IdempotencyKey) {
+	if rec.ResponseContentType != "" {
+		w.Header().Set("Content-Type", rec.ResponseContentType)
+	}
+	w.Header().Set(idempotentReplayedHeader, "true")
+	w.WriteHeader(rec.ResponseStatus)
+	w.Write(rec.ResponseBody)
+}
+
+// recordingResponseWriter captures the status code and body written by a
+// handler while passing them through to the client.
+type recordingResponseWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (rw *recordingResponseWriter) WriteHeader(status int) {
+	if rw.status == 0 {
+		rw.status = status
+	}
+	rw.ResponseWriter.WriteHeader(status)
+}
+
+func (rw *recordingResponseWriter) Write(b []byte) (int, error) {
+	if rw.status == 0 {
+		rw.status = http.StatusOK
+	}
+	rw.body.Write(b)
+	return rw.ResponseWriter.Write(b)
+}
+
+// Status returns the response status code.
+func (rw *recordingResponseWriter) Status() int {
+	if rw.status == 0 {
+		return http.StatusOK
+	}
+	return rw.status
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotencyRequestHash(t *testing.T) {
+	assert := assert.New(t)
+
+	r1 := httptest.NewRequest("POST", "/api/v2/drafts", nil)
+	r2 := httptest.NewRequest("POST", "/api/v2/projects", nil)
+
+	h := idempotencyRequestHash(r1, []byte(`{"title":"a"}`))
+	assert.Len(h, 64)
+	assert.Equal(h, idempotencyRequestHash(r1, []byte(`{"title":"a"}`)))
+	assert.NotEqual(h, idempotencyRequestHash(r1, []byte(`{"title":"b"}`)))
+	assert.NotEqual(h, idempotencyRequestHash(r2, []byte(`{"title":"a"}`)))
+}
+
+func TestRecordingResponseWriter(t *testing.T) {
+	t.Run("implicit status", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		rw := &recordingResponseWriter{ResponseWriter: rec}
+
+		rw.Write([]byte("hello"))
+
+		assert.Equal(t, http.StatusOK, rw.Status())
+		assert.Equal(t, "hello", rw.body.String())
+		assert.Equal(t, "hello", rec.Body.String())
+	})
+
+	t.Run("explicit status", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		rw := &recordingResponseWriter{ResponseWriter: rec}
+
+		rw.WriteHeader(http.StatusCreated)
+		rw.Write([]byte(`{"id":"1"}`))
+
+		assert.Equal(t, http.StatusCreated, rw.Status())
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, `{"id":"1"}`, rw.body.String())
+	})
+}
@@ -46,9 +46,24 @@ type IndexerHeartbeatResponse struct {
 	ServerTime   time.Time `json:"server_time"`
 }
 
+// indexerRegistrationPolicy authorizes indexer registration tokens.
+var indexerRegistrationPolicy = ServiceTokenPolicy{
+	Name:       "indexer registration",
+	TokenTypes: []string{"registration"},
+	Scope:      scope(models.TokenScopeSync),
+}
+
+// indexerAPIPolicy authorizes the API tokens issued to registered indexers.
+var indexerAPIPolicy = ServiceTokenPolicy{
+	Name:       "indexer",
+	TokenTypes: []string{"api"},
+	Scope:      scope(models.TokenScopeSync),
+}
+
 // IndexerHandler handles indexer-related API endpoints.
 func IndexerHandler(srv server.Server) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
 		// Route based on method and path
 		path := strings.TrimPrefix(r.URL.Path, "/api/v2/indexer")
 
@@ -56,11 +71,17 @@ func IndexerHandler(srv server.Server) http.Handler {
 		case path == "/register" && r.Method == http.MethodPost:
 			handleIndexerRegister(srv, w, r)
 		case path == "/heartbeat" && r.Method == http.MethodPost:
-			handleIndexerHeartbeat(srv, w, r)
+			ServiceTokenAuthMiddleware(srv, indexerAPIPolicy,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					handleIndexerHeartbeat(srv, w, r)
+				})).ServeHTTP(w, r)
 		case path == "/documents" && r.Method == http.MethodPost:
-			handleIndexerDocuments(srv, w, r)
+			ServiceTokenAuthMiddleware(srv, indexerAPIPolicy,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					handleIndexerDocuments(srv, w, r)
+				})).ServeHTTP(w, r)
 		default:
-			http.Error(w, "Not Found", http.StatusNotFound)
+			writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound, "Not Found")
 		}
 	})
 }
@@ -71,33 +92,30 @@ func handleIndexerRegister(srv server.Server, w http.ResponseWriter, r *http.Req
 	var req IndexerRegisterRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		srv.Logger.Error("error decoding indexer registration request", "error", err)
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			"Invalid request body")
 		return
 	}
 
 	// Validate required fields
 	if req.Token == "" {
-		http.Error(w, "token is required", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			"token is required")
 		return
 	}
 	if req.IndexerType == "" {
-		http.Error(w, "indexer_type is required", http.StatusBadRequest)
-		return
-	}
-
-	// Validate token (for now, just check it's not empty - in production, verify against stored registration tokens)
-	// TODO: Implement proper token validation against service_tokens table
-	tokenHash := models.HashToken(req.Token)
-	var token models.IndexerToken
-	if err := token.GetByHash(srv.DB, tokenHash); err != nil {
-		srv.Logger.Warn("invalid registration token", "error", err)
-		http.Error(w, "Invalid or expired token", http.StatusUnauthorized)
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			"indexer_type is required")
 		return
 	}
 
-	// Check if token is valid
-	if !token.IsValid() {
-		http.Error(w, "Token has expired or been revoked", http.StatusUnauthorized)
+	// The registration token is sent in the body rather than the
+	// Authorization header, so it's checked here instead of by
+	// ServiceTokenAuthMiddleware.
+	token, tErr := authorizeServiceToken(srv, r, indexerRegistrationPolicy, req.Token)
+	if tErr != nil {
+		srv.Logger.Warn("invalid registration token", "reason", tErr.detail)
+		writeProblem(w, r, tErr.status, tErr.code, tErr.detail)
 		return
 	}
 
@@ -128,7 +146,8 @@ func handleIndexerRegister(srv server.Server, w http.ResponseWriter, r *http.Req
 
 	if err := indexer.Create(srv.DB); err != nil {
 		srv.Logger.Error("error creating indexer", "error", err)
-		http.Error(w, "Failed to register indexer", http.StatusInternalServerError)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"Failed to register indexer")
 		return
 	}
 
@@ -136,7 +155,8 @@ func handleIndexerRegister(srv server.Server, w http.ResponseWriter, r *http.Req
 	apiToken, err := models.GenerateToken("api")
 	if err != nil {
 		srv.Logger.Error("error generating API token", "error", err)
-		http.Error(w, "Failed to generate API token", http.StatusInternalServerError)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"Failed to generate API token")
 		return
 	}
 
@@ -150,13 +170,14 @@ func handleIndexerRegister(srv server.Server, w http.ResponseWriter, r *http.Req
 
 	if err := indexerToken.Create(srv.DB, apiToken); err != nil {
 		srv.Logger.Error("error creating API token", "error", err)
-		http.Error(w, "Failed to store API token", http.StatusInternalServerError)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"Failed to store API token")
 		return
 	}
 
 	// Mark registration token as used by associating it with the indexer
 	token.IndexerID = &indexer.ID
-	if err := srv.DB.Save(&token).Error; err != nil {
+	if err := srv.DB.Save(token).Error; err != nil {
 		srv.Logger.Warn("error updating registration token", "error", err)
 	}
 
@@ -180,29 +201,16 @@ func handleIndexerRegister(srv server.Server, w http.ResponseWriter, r *http.Req
 
 // handleIndexerHeartbeat processes heartbeat updates from indexers.
 func handleIndexerHeartbeat(srv server.Server, w http.ResponseWriter, r *http.Request) {
-	// Extract and validate bearer token
-	authHeader := r.Header.Get("Authorization")
-	if !strings.HasPrefix(authHeader, "Bearer ") {
-		http.Error(w, "Missing or invalid authorization header", http.StatusUnauthorized)
-		return
-	}
-	token := strings.TrimPrefix(authHeader, "Bearer ")
-
-	// Validate token
-	var indexerToken models.IndexerToken
-	if err := indexerToken.GetByToken(srv.DB, token); err != nil {
-		srv.Logger.Warn("invalid API token", "error", err)
-		http.Error(w, "Invalid or expired token", http.StatusUnauthorized)
-		return
-	}
-
-	if !indexerToken.IsValid() {
-		http.Error(w, "Token has expired or been revoked", http.StatusUnauthorized)
+	indexerToken, ok := serviceTokenFromContext(r.Context())
+	if !ok {
+		writeProblem(w, r, http.StatusUnauthorized, ErrCodeUnauthorized,
+			"Missing authorization header")
 		return
 	}
 
 	if indexerToken.IndexerID == nil {
-		http.Error(w, "Token not associated with an indexer", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			"Token not associated with an indexer")
 		return
 	}
 
@@ -210,13 +218,15 @@ func handleIndexerHeartbeat(srv server.Server, w http.ResponseWriter, r *http.Re
 	var req IndexerHeartbeatRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		srv.Logger.Error("error decoding heartbeat request", "error", err)
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			"Invalid request body")
 		return
 	}
 
 	// Verify indexer ID matches token
 	if req.IndexerID != *indexerToken.IndexerID {
-		http.Error(w, "Indexer ID mismatch", http.StatusForbidden)
+		writeProblem(w, r, http.StatusForbidden, ErrCodeForbidden,
+			"Indexer ID mismatch")
 		return
 	}
 
@@ -225,14 +235,16 @@ func handleIndexerHeartbeat(srv server.Server, w http.ResponseWriter, r *http.Re
 	indexer.ID = req.IndexerID
 	if err := indexer.Get(srv.DB); err != nil {
 		srv.Logger.Error("error loading indexer", "error", err)
-		http.Error(w, "Indexer not found", http.StatusNotFound)
+		writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound,
+			"Indexer not found")
 		return
 	}
 
 	// Update heartbeat
 	if err := indexer.UpdateHeartbeat(srv.DB, req.DocumentCount); err != nil {
 		srv.Logger.Error("error updating heartbeat", "error", err)
-		http.Error(w, "Failed to update heartbeat", http.StatusInternalServerError)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"Failed to update heartbeat")
 		return
 	}
 
@@ -261,29 +273,16 @@ func handleIndexerHeartbeat(srv server.Server, w http.ResponseWriter, r *http.Re
 
 // handleIndexerDocuments processes document submissions from indexers.
 func handleIndexerDocuments(srv server.Server, w http.ResponseWriter, r *http.Request) {
-	// Extract and validate bearer token
-	authHeader := r.Header.Get("Authorization")
-	if !strings.HasPrefix(authHeader, "Bearer ") {
-		http.Error(w, "Missing or invalid authorization header", http.StatusUnauthorized)
-		return
-	}
-	token := strings.TrimPrefix(authHeader, "Bearer ")
-
-	// Validate token
-	var indexerToken models.IndexerToken
-	if err := indexerToken.GetByToken(srv.DB, token); err != nil {
-		srv.Logger.Warn("invalid API token", "error", err)
-		http.Error(w, "Invalid or expired token", http.StatusUnauthorized)
-		return
-	}
-
-	if !indexerToken.IsValid() {
-		http.Error(w, "Token has expired or been revoked", http.StatusUnauthorized)
+	indexerToken, ok := serviceTokenFromContext(r.Context())
+	if !ok {
+		writeProblem(w, r, http.StatusUnauthorized, ErrCodeUnauthorized,
+			"Missing authorization header")
 		return
 	}
 
 	if indexerToken.IndexerID == nil {
-		http.Error(w, "Token not associated with an indexer", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			"Token not associated with an indexer")
 		return
 	}
 
@@ -33,6 +33,7 @@ type JiraIssueGetResponse struct {
 // JiraIssueHandler proxies Jira issue API requests.
 func JiraIssueHandler(srv server.Server) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
 		log := srv.Logger
 		logArgs := []any{
 			"path", r.URL.Path,
@@ -42,16 +43,16 @@ func JiraIssueHandler(srv server.Server) http.Handler {
 		userEmail, ok := pkgauth.GetUserEmail(r.Context())
 		if !ok || userEmail == "" {
 			log.Error("user email not found in request context", logArgs...)
-			http.Error(
-				w, "No authorization information for request", http.StatusUnauthorized)
+			writeProblem(w, r, http.StatusUnauthorized, ErrCodeUnauthorized,
+				"No authorization information for request")
 			return
 		}
 
 		// Respond with error if Jira is not enabled.
 		if srv.Jira == nil || srv.Config.Jira == nil || !srv.Config.Jira.Enabled {
 			log.Warn("Jira not enabled", logArgs...)
-			http.Error(
-				w, "Jira has not been enabled", http.StatusUnprocessableEntity)
+			writeProblem(w, r, http.StatusUnprocessableEntity, ErrCodeUnprocessable,
+				"Jira has not been enabled")
 			return
 		}
 
@@ -65,7 +66,8 @@ func JiraIssueHandler(srv server.Server) http.Handler {
 					append([]interface{}{
 						"error", err,
 					}, logArgs...)...)
-				http.Error(w, "Jira issue not found", http.StatusNotFound)
+				writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound,
+					"Jira issue not found")
 				return
 			}
 			logArgs = append(logArgs, "jira_issue_id", issueID)
@@ -83,8 +85,8 @@ func JiraIssueHandler(srv server.Server) http.Handler {
 						append([]interface{}{
 							"error", err,
 						}, logArgs...)...)
-					http.Error(w, "Error processing request",
-						http.StatusInternalServerError)
+					writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error processing request")
 					return
 				}
 
@@ -105,8 +107,8 @@ func JiraIssueHandler(srv server.Server) http.Handler {
 						append([]interface{}{
 							"error", err,
 						}, logArgs...)...)
-					http.Error(w, "Error processing request",
-						http.StatusInternalServerError)
+					writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error processing request")
 					return
 				}
 				defer resp.Body.Close()
@@ -119,8 +121,8 @@ func JiraIssueHandler(srv server.Server) http.Handler {
 							append([]interface{}{
 								"error", err,
 							}, logArgs...)...)
-						http.Error(w, "Error processing request",
-							http.StatusInternalServerError)
+						writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+							"Error processing request")
 						return
 					}
 
@@ -130,8 +132,8 @@ func JiraIssueHandler(srv server.Server) http.Handler {
 							append([]interface{}{
 								"error", err,
 							}, logArgs...)...)
-						http.Error(w, "Error processing request",
-							http.StatusInternalServerError)
+						writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+							"Error processing request")
 						return
 					}
 
@@ -168,14 +170,15 @@ func JiraIssueHandler(srv server.Server) http.Handler {
 								"error", err,
 							}, logArgs...)...,
 						)
-						http.Error(
-							w, "Error processing request", http.StatusInternalServerError)
+						writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+							"Error processing request")
 						return
 					}
 
 				case resp.StatusCode == http.StatusNotFound:
 					log.Warn("issue not found", logArgs...)
-					http.Error(w, "Not found", http.StatusNotFound)
+					writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound,
+						"Not found")
 					return
 
 				default:
@@ -184,18 +187,19 @@ func JiraIssueHandler(srv server.Server) http.Handler {
 							"error", err,
 							"status_code", resp.StatusCode,
 						}, logArgs...)...)
-					http.Error(w, "Error processing request",
-						http.StatusInternalServerError)
+					writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error processing request")
 					return
 				}
 
 			default:
-				w.WriteHeader(http.StatusMethodNotAllowed)
+				writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+					"Method not allowed")
 				return
 			}
 		} else {
 			log.Warn("path not found", logArgs...)
-			http.Error(w, "Not found", http.StatusNotFound)
+			writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound, "Not found")
 			return
 		}
 	})
@@ -27,6 +27,7 @@ type JiraIssuePickerGetResponseIssue struct {
 // JiraIssuePickerHandler proxies Jira issue picker API requests.
 func JiraIssuePickerHandler(srv server.Server) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
 		log := srv.Logger
 		logArgs := []any{
 			"path", r.URL.Path,
@@ -36,16 +37,16 @@ func JiraIssuePickerHandler(srv server.Server) http.Handler {
 		userEmail, ok := pkgauth.GetUserEmail(r.Context())
 		if !ok || userEmail == "" {
 			log.Error("user email not found in request context", logArgs...)
-			http.Error(
-				w, "No authorization information for request", http.StatusUnauthorized)
+			writeProblem(w, r, http.StatusUnauthorized, ErrCodeUnauthorized,
+				"No authorization information for request")
 			return
 		}
 
 		// Respond with error if Jira is not enabled.
 		if srv.Jira == nil || srv.Config.Jira == nil || !srv.Config.Jira.Enabled {
 			log.Warn("Jira not enabled", logArgs...)
-			http.Error(
-				w, "Jira has not been enabled", http.StatusUnprocessableEntity)
+			writeProblem(w, r, http.StatusUnprocessableEntity, ErrCodeUnprocessable,
+				"Jira has not been enabled")
 			return
 		}
 
@@ -65,8 +66,8 @@ func JiraIssuePickerHandler(srv server.Server) http.Handler {
 					append([]interface{}{
 						"error", err,
 					}, logArgs...)...)
-				http.Error(w, "Error processing request",
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error processing request")
 				return
 			}
 
@@ -104,8 +105,8 @@ func JiraIssuePickerHandler(srv server.Server) http.Handler {
 							"error", err,
 							"url", jiraGetIssueURL.String(),
 						}, logArgs...)...)
-					http.Error(w, "Error processing request",
-						http.StatusInternalServerError)
+					writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error processing request")
 					return
 				}
 				defer resp.Body.Close()
@@ -117,8 +118,8 @@ func JiraIssuePickerHandler(srv server.Server) http.Handler {
 							append([]interface{}{
 								"error", err,
 							}, logArgs...)...)
-						http.Error(w, "Error processing request",
-							http.StatusInternalServerError)
+						writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+							"Error processing request")
 						return
 					}
 
@@ -128,8 +129,8 @@ func JiraIssuePickerHandler(srv server.Server) http.Handler {
 							append([]interface{}{
 								"error", err,
 							}, logArgs...)...)
-						http.Error(w, "Error processing request",
-							http.StatusInternalServerError)
+						writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+							"Error processing request")
 						return
 					}
 
@@ -170,8 +171,8 @@ func JiraIssuePickerHandler(srv server.Server) http.Handler {
 						append([]interface{}{
 							"error", err,
 						}, logArgs...)...)
-					http.Error(w, "Error processing request",
-						http.StatusInternalServerError)
+					writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error processing request")
 					return
 				}
 				defer resp.Body.Close()
@@ -184,8 +185,8 @@ func JiraIssuePickerHandler(srv server.Server) http.Handler {
 							append([]interface{}{
 								"error", err,
 							}, logArgs...)...)
-						http.Error(w, "Error processing request",
-							http.StatusInternalServerError)
+						writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+							"Error processing request")
 						return
 					}
 
@@ -194,8 +195,8 @@ func JiraIssuePickerHandler(srv server.Server) http.Handler {
 							append([]interface{}{
 								"error", err,
 							}, logArgs...)...)
-						http.Error(w, "Error processing request",
-							http.StatusInternalServerError)
+						writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+							"Error processing request")
 						return
 					}
 				} else {
@@ -204,8 +205,8 @@ func JiraIssuePickerHandler(srv server.Server) http.Handler {
 							"error", err,
 							"status_code", resp.StatusCode,
 						}, logArgs...)...)
-					http.Error(w, "Error processing request",
-						http.StatusInternalServerError)
+					writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error processing request")
 					return
 				}
 			}
@@ -249,13 +250,14 @@ func JiraIssuePickerHandler(srv server.Server) http.Handler {
 						"error", err,
 					}, logArgs...)...,
 				)
-				http.Error(
-					w, "Error processing request", http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error processing request")
 				return
 			}
 
 		default:
-			w.WriteHeader(http.StatusMethodNotAllowed)
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
 			return
 		}
 	})
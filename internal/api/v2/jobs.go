@@ -0,0 +1,374 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp-forge/hermes/internal/email"
+	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/pkg/document"
+	"github.com/hashicorp-forge/hermes/pkg/jobs"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp-forge/hermes/pkg/requestid"
+	"github.com/hashicorp-forge/hermes/pkg/search"
+)
+
+// Types of the background jobs queued by API requests.
+const (
+	// indexDocumentJobType jobs save a document in the documents or drafts
+	// search index.
+	indexDocumentJobType = "search.index_document"
+
+	// compareDocumentJobType jobs compare a document in the search index with
+	// the database to find data inconsistencies.
+	compareDocumentJobType = "search.compare_document"
+
+	// indexProjectJobType jobs save a project in the projects search index.
+	indexProjectJobType = "search.index_project"
+
+	// documentApprovedEmailJobType jobs email the owner of a document that
+	// was approved.
+	documentApprovedEmailJobType = "email.document_approved"
+
+	// documentPublishedEmailsJobType jobs queue emails to the subscribers of
+	// the product of a published document.
+	documentPublishedEmailsJobType = "email.document_published"
+
+	// subscriberEmailJobType jobs email a product subscriber about a
+	// published document.
+	subscriberEmailJobType = "email.subscriber_document_published"
+)
+
+// indexDocumentJob is the payload of indexDocumentJobType jobs.
+type indexDocumentJob struct {
+	Document *search.Document `json:"document"`
+
+	// Draft saves the document in the drafts index instead of the documents
+	// index.
+	Draft bool `json:"draft,omitempty"`
+
+	// DeleteDraft deletes the document from the drafts index after it's saved
+	// in the documents index (e.g., when a draft is published).
+	DeleteDraft bool `json:"deleteDraft,omitempty"`
+
+	// Compare compares the saved document with the database.
+	Compare bool `json:"compare,omitempty"`
+}
+
+// compareDocumentJob is the payload of compareDocumentJobType jobs.
+type compareDocumentJob struct {
+	DocID string `json:"docID"`
+	Draft bool   `json:"draft,omitempty"`
+}
+
+// indexProjectJob is the payload of indexProjectJobType jobs.
+type indexProjectJob struct {
+	ProjectID uint `json:"projectID"`
+}
+
+// documentApprovedEmailJob is the payload of documentApprovedEmailJobType
+// jobs. The name of the approver is looked up when the job runs.
+type documentApprovedEmailJob struct {
+	Data email.DocumentApprovedEmailData `json:"data"`
+	To   string                          `json:"to"`
+}
+
+// documentPublishedEmailsJob is the payload of documentPublishedEmailsJobType
+// jobs.
+type documentPublishedEmailsJob struct {
+	Data email.SubscriberDocumentPublishedEmailData `json:"data"`
+}
+
+// subscriberEmailJob is the payload of subscriberEmailJobType jobs.
+type subscriberEmailJob struct {
+	Data email.SubscriberDocumentPublishedEmailData `json:"data"`
+	To   string                                     `json:"to"`
+}
+
+// RegisterJobs registers the handlers of the background jobs queued by API
+// requests with the job runner of srv.
+func RegisterJobs(srv server.Server) {
+	for jobType, h := range jobHandlers(srv) {
+		srv.Jobs.Register(jobType, h)
+	}
+}
+
+// jobHandlers returns the handlers of the background jobs queued by API
+// requests, keyed by job type.
+func jobHandlers(srv server.Server) map[string]jobs.Handler {
+	return map[string]jobs.Handler{
+		indexDocumentJobType: func(ctx context.Context, p json.RawMessage) error {
+			var j indexDocumentJob
+			if err := json.Unmarshal(p, &j); err != nil {
+				return err
+			}
+			return runIndexDocumentJob(ctx, srv, j)
+		},
+		compareDocumentJobType: func(ctx context.Context, p json.RawMessage) error {
+			var j compareDocumentJob
+			if err := json.Unmarshal(p, &j); err != nil {
+				return err
+			}
+			return compareIndexedDocument(ctx, srv, j.DocID, j.Draft)
+		},
+		indexProjectJobType: func(ctx context.Context, p json.RawMessage) error {
+			var j indexProjectJob
+			if err := json.Unmarshal(p, &j); err != nil {
+				return err
+			}
+			proj := models.Project{}
+			if err := proj.Get(srv.DB.WithContext(ctx), j.ProjectID); err != nil {
+				return fmt.Errorf("error getting project from database: %w", err)
+			}
+			return saveProjectInAlgolia(proj, srv.SearchProvider)
+		},
+		documentApprovedEmailJobType: func(ctx context.Context, p json.RawMessage) error {
+			var j documentApprovedEmailJob
+			if err := json.Unmarshal(p, &j); err != nil {
+				return err
+			}
+			return runDocumentApprovedEmailJob(ctx, srv, j)
+		},
+		documentPublishedEmailsJobType: func(ctx context.Context, p json.RawMessage) error {
+			var j documentPublishedEmailsJob
+			if err := json.Unmarshal(p, &j); err != nil {
+				return err
+			}
+			return runDocumentPublishedEmailsJob(ctx, srv, j)
+		},
+		subscriberEmailJobType: func(ctx context.Context, p json.RawMessage) error {
+			var j subscriberEmailJob
+			if err := json.Unmarshal(p, &j); err != nil {
+				return err
+			}
+			j.Data.Locale = userLocale(ctx, srv, j.To)
+			if err := email.SendSubscriberDocumentPublishedEmail(
+				j.Data,
+				[]string{j.To},
+				srv.Config.Email.FromAddress,
+				emailSender(srv),
+			); err != nil {
+				return fmt.Errorf("error sending subscriber email: %w", err)
+			}
+			return nil
+		},
+	}
+}
+
+// enqueueJob queues a background job of type jobType with payload for request
+// r. Without a job runner, the job is run once in a goroutine.
+func enqueueJob(
+	srv server.Server, r *http.Request, jobType string, payload any) {
+	ctx := postProcessingContext(r)
+
+	if srv.Jobs != nil {
+		if err := srv.Jobs.Enqueue(ctx, jobType, payload); err != nil {
+			srv.Logger.Error("error queuing job",
+				"error", err,
+				"job_type", jobType,
+				"method", r.Method,
+				"path", r.URL.Path,
+			)
+		}
+		return
+	}
+
+	h, ok := jobHandlers(srv)[jobType]
+	if !ok {
+		srv.Logger.Error("no handler registered for job type",
+			"job_type", jobType)
+		return
+	}
+	b, err := json.Marshal(payload)
+	if err != nil {
+		srv.Logger.Error("error encoding job payload",
+			"error", err,
+			"job_type", jobType,
+		)
+		return
+	}
+	go func() {
+		if err := h(ctx, b); err != nil {
+			srv.Logger.Error("job failed",
+				"error", err,
+				"job_type", jobType,
+				"method", r.Method,
+				"path", r.URL.Path,
+			)
+		}
+	}()
+}
+
+// toSearchDocument converts doc to a search index object.
+func toSearchDocument(doc *document.Document) (*search.Document, error) {
+	docObjMap, err := doc.ToAlgoliaObject(true)
+	if err != nil {
+		return nil, fmt.Errorf("error converting document to search object: %w", err)
+	}
+	return mapToSearchDocument(docObjMap)
+}
+
+// runIndexDocumentJob saves the document of job j in the search index.
+func runIndexDocumentJob(
+	ctx context.Context, srv server.Server, j indexDocumentJob) error {
+	if j.Document == nil {
+		return fmt.Errorf("document is required")
+	}
+	docID := j.Document.ObjectID
+
+	var idx search.DocumentIndex = srv.SearchProvider.DocumentIndex()
+	if j.Draft {
+		idx = srv.SearchProvider.DraftIndex()
+	}
+	if err := idx.Index(ctx, j.Document); err != nil {
+		return fmt.Errorf("error saving document in search index: %w", err)
+	}
+
+	if j.DeleteDraft {
+		if err := srv.SearchProvider.DraftIndex().Delete(ctx, docID); err != nil {
+			return fmt.Errorf("error deleting draft from search index: %w", err)
+		}
+	}
+
+	if j.Compare {
+		// Inconsistencies are logged rather than retried, because the document
+		// is already indexed.
+		if err := compareIndexedDocument(ctx, srv, docID, j.Draft); err != nil {
+			requestid.Logger(ctx, srv.Logger).Warn(
+				"error comparing search index and database documents",
+				"error", err,
+				"doc_id", docID,
+			)
+		}
+	}
+
+	return nil
+}
+
+// compareIndexedDocument compares the document with ID docID in the documents
+// or drafts search index with the database, and logs data inconsistencies.
+func compareIndexedDocument(
+	ctx context.Context, srv server.Server, docID string, draft bool) error {
+	var idx search.DocumentIndex = srv.SearchProvider.DocumentIndex()
+	if draft {
+		idx = srv.SearchProvider.DraftIndex()
+	}
+
+	// Get document object from search index.
+	indexedDoc, err := idx.GetObject(ctx, docID)
+	if err != nil {
+		return fmt.Errorf("error getting search object for data comparison: %w", err)
+	}
+
+	// Convert search.Document to map for comparison.
+	b, err := json.Marshal(indexedDoc)
+	if err != nil {
+		return fmt.Errorf("error encoding search object: %w", err)
+	}
+	var algoDoc map[string]any
+	if err := json.Unmarshal(b, &algoDoc); err != nil {
+		return fmt.Errorf("error decoding search object: %w", err)
+	}
+
+	// Get document from database.
+	db := srv.DB.WithContext(ctx)
+	dbDoc := models.Document{
+		GoogleFileID: docID,
+	}
+	if err := dbDoc.Get(db); err != nil {
+		return fmt.Errorf(
+			"error getting document from database for data comparison: %w", err)
+	}
+
+	// Get all reviews for the document.
+	var reviews models.DocumentReviews
+	if err := reviews.Find(db, models.DocumentReview{
+		Document: models.Document{
+			GoogleFileID: docID,
+		},
+	}); err != nil {
+		return fmt.Errorf(
+			"error getting all reviews for document for data comparison: %w", err)
+	}
+
+	if err := CompareAlgoliaAndDatabaseDocument(
+		algoDoc, dbDoc, reviews, documentTypes(ctx, srv),
+	); err != nil {
+		requestid.Logger(ctx, srv.Logger).Warn(
+			"inconsistencies detected between search index and database docs",
+			"error", err,
+			"doc_id", docID,
+		)
+	}
+	return nil
+}
+
+// runDocumentApprovedEmailJob emails the owner of an approved document.
+func runDocumentApprovedEmailJob(
+	ctx context.Context, srv server.Server, j documentApprovedEmailJob) error {
+	// Get name of document approver.
+	ppl, err := srv.WorkspaceProvider.SearchPeople(
+		ctx, j.Data.DocumentApprover.EmailAddress)
+	if err != nil {
+		requestid.Logger(ctx, srv.Logger).Warn(
+			"error searching directory for approver",
+			"error", err,
+			"person", j.Data.DocumentApprover.EmailAddress,
+		)
+	}
+	if len(ppl) == 1 {
+		j.Data.DocumentApprover.Name = ppl[0].DisplayName
+	}
+
+	j.Data.Locale = userLocale(ctx, srv, j.To)
+	if err := email.SendDocumentApprovedEmail(
+		j.Data,
+		[]string{j.To},
+		srv.Config.Email.FromAddress,
+		emailSender(srv),
+	); err != nil {
+		return fmt.Errorf("error sending document approved email: %w", err)
+	}
+	return nil
+}
+
+// runDocumentPublishedEmailsJob queues an email to each subscriber of the
+// product of a published document, so failed emails are retried individually.
+func runDocumentPublishedEmailsJob(
+	ctx context.Context, srv server.Server, j documentPublishedEmailsJob) error {
+	p := models.Product{
+		Name: j.Data.Product,
+	}
+	if err := p.Get(srv.DB.WithContext(ctx)); err != nil {
+		return fmt.Errorf("error getting product from database: %w", err)
+	}
+
+	for _, subscriber := range p.UserSubscribers {
+		payload := subscriberEmailJob{
+			Data: j.Data,
+			To:   subscriber.EmailAddress,
+		}
+		if srv.Jobs == nil {
+			// Without a job runner, send the emails now and log failures so
+			// they don't stop the remaining emails.
+			b, err := json.Marshal(payload)
+			if err != nil {
+				return err
+			}
+			if err := jobHandlers(srv)[subscriberEmailJobType](ctx, b); err != nil {
+				requestid.Logger(ctx, srv.Logger).Error(
+					"error sending subscriber email",
+					"error", err,
+					"product", j.Data.Product,
+				)
+			}
+			continue
+		}
+		if err := srv.Jobs.Enqueue(ctx, subscriberEmailJobType, payload); err != nil {
+			return err
+		}
+	}
+	return nil
+}
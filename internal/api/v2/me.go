@@ -2,15 +2,19 @@ package api
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strings"
 
 	"github.com/hashicorp-forge/hermes/internal/server"
 	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+	"github.com/hashicorp-forge/hermes/pkg/i18n"
+	"github.com/hashicorp-forge/hermes/pkg/models"
 )
 
 // MeGetResponse mimics the response from Google's `userinfo/me` API
-// (https://www.googleapis.com/userinfo/v2/me).
+// (https://www.googleapis.com/userinfo/v2/me), extended with the profile data
+// the dashboard needs so that it loads with a single request.
 type MeGetResponse struct {
 	ID            string `json:"id"`
 	Email         string `json:"email"`
@@ -21,17 +25,42 @@ type MeGetResponse struct {
 	Picture       string `json:"picture"`
 	Locale        string `json:"locale,omitempty"`
 	HD            string `json:"hd,omitempty"`
+
+	// Profile aggregation. These fields are null if they could not be loaded.
+	Teams                   []MeTeam                   `json:"teams"`
+	Documents               *MeDocumentCounts          `json:"documents"`
+	PendingReviews          []MePendingReview          `json:"pendingReviews"`
+	NotificationPreferences *MeNotificationPreferences `json:"notificationPreferences"`
+
+	// Impersonation is set if a site admin is impersonating the user, so the
+	// web app can show a banner.
+	Impersonation *MeImpersonation `json:"impersonation,omitempty"`
+}
+
+// MePatchRequest contains the preferences of the current user to update.
+type MePatchRequest struct {
+	// Locale is the preferred locale of the user (a BCP 47 language tag like
+	// "de" or "pt-BR"), which selects the language of the emails and
+	// notifications the user receives. Empty uses the default language.
+	Locale *string `json:"locale,omitempty"`
+}
+
+// MeImpersonation describes the impersonation session a request is made in.
+type MeImpersonation struct {
+	// Admin is the email address of the admin impersonating the user.
+	Admin string `json:"admin"`
 }
 
 func MeHandler(srv server.Server) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
 		errResp := func(httpCode int, userErrMsg, logErrMsg string, err error) {
 			srv.Logger.Error(logErrMsg,
 				"method", r.Method,
 				"path", r.URL.Path,
 				"error", err,
 			)
-			http.Error(w, userErrMsg, httpCode)
+			writeProblem(w, r, httpCode, errorCodeForStatus(httpCode), userErrMsg)
 		}
 
 		// Authorize request.
@@ -64,7 +93,8 @@ func MeHandler(srv server.Server) http.Handler {
 						"path", r.URL.Path,
 					}, extraArgs...)...,
 				)
-				http.Error(w, userErrMsg, httpCode)
+				writeProblem(w, r, httpCode, errorCodeForStatus(httpCode),
+					userErrMsg)
 			}
 
 			// Try to get user information from auth claims first
@@ -202,11 +232,16 @@ func MeHandler(srv server.Server) http.Handler {
 						// GivenName and FamilyName not available in RFC-084 UserIdentity
 					}
 					if p.PhotoURL != "" {
-						resp.Picture = p.PhotoURL
+						resp.Picture = personPhotoURL(p.Email)
 					}
 				}
 			}
 
+			addMeProfile(r.Context(), srv, userEmail, &resp)
+			if admin, ok := pkgauth.GetImpersonator(r.Context()); ok {
+				resp.Impersonation = &MeImpersonation{Admin: admin}
+			}
+
 			// Write response (common for both paths)
 			w.Header().Set("Content-Type", "application/json")
 			w.WriteHeader(http.StatusOK)
@@ -222,8 +257,38 @@ func MeHandler(srv server.Server) http.Handler {
 				return
 			}
 
+		case "PATCH":
+			var req MePatchRequest
+			if err := decodeRequest(r, &req); err != nil {
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					fmt.Sprintf("Bad request: %q", err))
+				return
+			}
+
+			if req.Locale != nil {
+				locale, err := i18n.Normalize(*req.Locale)
+				if err != nil {
+					writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+						fmt.Sprintf("Bad request: %v", err))
+					return
+				}
+				u := models.User{EmailAddress: userEmail}
+				if err := u.SetLocale(srv.DB.WithContext(r.Context()), locale); err != nil {
+					errResp(
+						http.StatusInternalServerError,
+						"Error updating user preferences",
+						"error setting user locale",
+						err,
+					)
+					return
+				}
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+
 		default:
-			w.WriteHeader(http.StatusMethodNotAllowed)
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
 			return
 		}
 	})
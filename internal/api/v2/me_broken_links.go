@@ -0,0 +1,81 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/hashicorp-forge/hermes/internal/server"
+	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+)
+
+// MeBrokenLinksDocument is the broken link report for a document owned by the
+// authenticated user.
+type MeBrokenLinksDocument struct {
+	DocumentID string                      `json:"documentId"`
+	Title      string                      `json:"title"`
+	Links      []models.DocumentBrokenLink `json:"links"`
+}
+
+// MeBrokenLinksHandler returns broken outbound links found by the link check
+// job in documents owned by the authenticated user
+// (GET /api/v2/me/broken-links).
+func MeBrokenLinksHandler(srv server.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
+		if r.Method != "GET" {
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
+			return
+		}
+
+		userEmail, ok := pkgauth.GetUserEmail(r.Context())
+		if !ok || userEmail == "" {
+			writeProblem(w, r, http.StatusUnauthorized, ErrCodeUnauthorized,
+				"No authorization information for request")
+			return
+		}
+
+		var links models.DocumentBrokenLinks
+		if err := links.FindForOwner(
+			srv.DB.WithContext(r.Context()), userEmail,
+		); err != nil {
+			srv.Logger.Error("error finding broken links",
+				"error", err,
+				"method", r.Method,
+				"path", r.URL.Path,
+			)
+			writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Error finding broken links")
+			return
+		}
+
+		resp := groupBrokenLinks(links)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			srv.Logger.Error("error encoding broken links response",
+				"error", err,
+				"method", r.Method,
+				"path", r.URL.Path,
+			)
+		}
+	})
+}
+
+// groupBrokenLinks groups broken links, which must be ordered by document, into
+// a report for each document.
+func groupBrokenLinks(links models.DocumentBrokenLinks) []MeBrokenLinksDocument {
+	resp := []MeBrokenLinksDocument{}
+	for _, l := range links {
+		if n := len(resp); n == 0 ||
+			resp[n-1].DocumentID != l.Document.GoogleFileID {
+			resp = append(resp, MeBrokenLinksDocument{
+				DocumentID: l.Document.GoogleFileID,
+				Title:      l.Document.Title,
+			})
+		}
+		resp[len(resp)-1].Links = append(resp[len(resp)-1].Links, l)
+	}
+	return resp
+}
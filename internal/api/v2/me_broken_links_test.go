@@ -0,0 +1,30 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupBrokenLinks(t *testing.T) {
+	doc1 := models.Document{GoogleFileID: "doc1", Title: "One"}
+	doc2 := models.Document{GoogleFileID: "doc2", Title: "Two"}
+	links := models.DocumentBrokenLinks{
+		{URL: "https://a.example.com", Document: doc1},
+		{URL: "https://b.example.com", Document: doc1},
+		{URL: "/document/gone", Document: doc2},
+	}
+
+	got := groupBrokenLinks(links)
+	if assert.Len(t, got, 2) {
+		assert.Equal(t, "doc1", got[0].DocumentID)
+		assert.Equal(t, "One", got[0].Title)
+		assert.Len(t, got[0].Links, 2)
+		assert.Equal(t, "doc2", got[1].DocumentID)
+		assert.Equal(t, "/document/gone", got[1].Links[0].URL)
+	}
+
+	assert.Empty(t, groupBrokenLinks(nil))
+	assert.NotNil(t, groupBrokenLinks(nil))
+}
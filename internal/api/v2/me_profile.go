@@ -0,0 +1,216 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp-forge/hermes/pkg/workspace"
+	"gorm.io/gorm"
+)
+
+// MeTeam is a team the authenticated user belongs to.
+type MeTeam struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email,omitempty"`
+}
+
+// MeDocumentCounts summarizes the documents the authenticated user owns or
+// contributes to. Owned counts are broken down by document status.
+type MeDocumentCounts struct {
+	Owned       int64 `json:"owned"`
+	Drafts      int64 `json:"drafts"`
+	InReview    int64 `json:"inReview"`
+	Approved    int64 `json:"approved"`
+	Obsolete    int64 `json:"obsolete"`
+	Contributed int64 `json:"contributed"`
+}
+
+// MePendingReview is a document awaiting the authenticated user's review.
+type MePendingReview struct {
+	DocumentID  string    `json:"documentId"`
+	Title       string    `json:"title"`
+	RequestedAt time.Time `json:"requestedAt"`
+}
+
+// MeNotificationPreferences describes which notifications the authenticated
+// user receives.
+type MeNotificationPreferences struct {
+	// EmailEnabled is true if the server sends email notifications.
+	EmailEnabled bool `json:"emailEnabled"`
+
+	// ProductSubscriptions are the products the user is notified about when
+	// documents are published.
+	ProductSubscriptions []string `json:"productSubscriptions"`
+}
+
+// addMeProfile populates the dashboard aggregation fields of resp for the
+// user. Each part is best-effort: failures are logged and the corresponding
+// field is left nil so that identity information is always returned.
+func addMeProfile(
+	ctx context.Context, srv server.Server, userEmail string, resp *MeGetResponse,
+) {
+	log := srv.Logger.With("user_email", userEmail)
+
+	if srv.WorkspaceProvider != nil {
+		teams, err := srv.WorkspaceProvider.GetUserTeams(ctx, userEmail)
+		if err != nil {
+			log.Warn("error getting teams for user", "error", err)
+		} else {
+			resp.Teams = meTeams(teams)
+		}
+	}
+
+	if srv.DB == nil {
+		return
+	}
+	db := srv.DB.WithContext(ctx)
+
+	counts, err := getMeDocumentCounts(db, userEmail)
+	if err != nil {
+		log.Warn("error counting documents for user", "error", err)
+	} else {
+		resp.Documents = counts
+	}
+
+	reviews, err := getMePendingReviews(db, userEmail)
+	if err != nil {
+		log.Warn("error getting pending reviews for user", "error", err)
+	} else {
+		resp.PendingReviews = reviews
+	}
+
+	// The locale preference of the user overrides the locale of their
+	// directory profile.
+	locales, err := models.GetUserLocales(db, []string{userEmail})
+	if err != nil {
+		log.Warn("error getting locale of user", "error", err)
+	} else if locale, ok := locales[userEmail]; ok {
+		resp.Locale = locale
+	}
+
+	prefs, err := getMeNotificationPreferences(srv, db, userEmail)
+	if err != nil {
+		log.Warn("error getting notification preferences for user",
+			"error", err)
+	} else {
+		resp.NotificationPreferences = prefs
+	}
+}
+
+// meTeams converts workspace teams to their response representation.
+func meTeams(teams []*workspace.Team) []MeTeam {
+	res := []MeTeam{}
+	for _, t := range teams {
+		if t == nil {
+			continue
+		}
+		res = append(res, MeTeam{
+			ID:    t.ID,
+			Name:  t.Name,
+			Email: t.Email,
+		})
+	}
+	return res
+}
+
+// getMeDocumentCounts counts the documents owned by and contributed to by the
+// user.
+func getMeDocumentCounts(db *gorm.DB, userEmail string) (*MeDocumentCounts, error) {
+	var rows []struct {
+		Status models.DocumentStatus
+		Count  int64
+	}
+	if err := db.Model(&models.Document{}).
+		Select("documents.status AS status, COUNT(*) AS count").
+		Joins("JOIN users ON users.id = documents.owner_id").
+		Where("users.email_address = ?", userEmail).
+		Group("documents.status").
+		Scan(&rows).
+		Error; err != nil {
+		return nil, err
+	}
+
+	byStatus := make(map[models.DocumentStatus]int64, len(rows))
+	for _, r := range rows {
+		byStatus[r.Status] = r.Count
+	}
+	counts := documentCountsByStatus(byStatus)
+
+	if err := db.Model(&models.Document{}).
+		Joins("JOIN document_contributors ON documents.id = document_contributors.document_id").
+		Joins("JOIN users ON users.id = document_contributors.user_id").
+		Where("users.email_address = ?", userEmail).
+		Count(&counts.Contributed).
+		Error; err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+// documentCountsByStatus builds owned document counts from per-status totals.
+func documentCountsByStatus(byStatus map[models.DocumentStatus]int64) *MeDocumentCounts {
+	counts := &MeDocumentCounts{
+		Drafts:   byStatus[models.WIPDocumentStatus],
+		InReview: byStatus[models.InReviewDocumentStatus],
+		Approved: byStatus[models.ApprovedDocumentStatus],
+		Obsolete: byStatus[models.ObsoleteDocumentStatus],
+	}
+	for _, n := range byStatus {
+		counts.Owned += n
+	}
+	return counts
+}
+
+// getMePendingReviews returns the documents awaiting the user's review, most
+// recently requested first.
+func getMePendingReviews(db *gorm.DB, userEmail string) ([]MePendingReview, error) {
+	reviews := []MePendingReview{}
+	if err := db.Model(&models.DocumentReview{}).
+		Select("documents.google_file_id AS document_id, "+
+			"documents.title AS title, "+
+			"document_reviews.created_at AS requested_at").
+		Joins("JOIN documents ON documents.id = document_reviews.document_id "+
+			"AND documents.deleted_at IS NULL").
+		Joins("JOIN users ON users.id = document_reviews.user_id").
+		Where("users.email_address = ?", userEmail).
+		Where("document_reviews.status = ?",
+			models.UnspecifiedDocumentReviewStatus).
+		Order("document_reviews.created_at DESC").
+		Scan(&reviews).
+		Error; err != nil {
+		return nil, err
+	}
+	return reviews, nil
+}
+
+// getMeNotificationPreferences returns the user's notification preferences.
+// Users without a database record have no subscriptions.
+func getMeNotificationPreferences(
+	srv server.Server, db *gorm.DB, userEmail string,
+) (*MeNotificationPreferences, error) {
+	prefs := &MeNotificationPreferences{
+		EmailEnabled: srv.Config != nil &&
+			srv.Config.Email != nil && srv.Config.Email.Enabled,
+		ProductSubscriptions: []string{},
+	}
+
+	u := models.User{
+		EmailAddress: userEmail,
+	}
+	if err := u.Get(db); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return prefs, nil
+		}
+		return nil, err
+	}
+	for _, p := range u.ProductSubscriptions {
+		prefs.ProductSubscriptions = append(prefs.ProductSubscriptions, p.Name)
+	}
+
+	return prefs, nil
+}
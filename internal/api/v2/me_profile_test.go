@@ -0,0 +1,43 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp-forge/hermes/pkg/workspace"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocumentCountsByStatus(t *testing.T) {
+	counts := documentCountsByStatus(map[models.DocumentStatus]int64{
+		models.WIPDocumentStatus:      3,
+		models.InReviewDocumentStatus: 2,
+		models.ApprovedDocumentStatus: 4,
+		models.ObsoleteDocumentStatus: 1,
+	})
+
+	assert.Equal(t, &MeDocumentCounts{
+		Owned:    10,
+		Drafts:   3,
+		InReview: 2,
+		Approved: 4,
+		Obsolete: 1,
+	}, counts)
+
+	assert.Equal(t, &MeDocumentCounts{}, documentCountsByStatus(nil))
+}
+
+func TestMeTeams(t *testing.T) {
+	teams := meTeams([]*workspace.Team{
+		{ID: "t1", Name: "Platform", Email: "platform@example.com"},
+		nil,
+		{ID: "t2", Name: "Docs"},
+	})
+
+	assert.Equal(t, []MeTeam{
+		{ID: "t1", Name: "Platform", Email: "platform@example.com"},
+		{ID: "t2", Name: "Docs"},
+	}, teams)
+
+	assert.Equal(t, []MeTeam{}, meTeams(nil))
+}
@@ -19,6 +19,7 @@ type recentlyViewedDoc struct {
 
 func MeRecentlyViewedDocsHandler(srv server.Server) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
 		errResp := func(
 			httpCode int, userErrMsg, logErrMsg string, err error,
 			extraArgs ...interface{}) {
@@ -120,7 +121,8 @@ func MeRecentlyViewedDocsHandler(srv server.Server) http.Handler {
 			}
 
 		default:
-			w.WriteHeader(http.StatusMethodNotAllowed)
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
 			return
 		}
 	})
@@ -16,6 +16,7 @@ type recentlyViewedProject struct {
 
 func MeRecentlyViewedProjectsHandler(srv server.Server) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
 		errResp := func(
 			httpCode int, userErrMsg, logErrMsg string, err error,
 			extraArgs ...interface{}) {
@@ -90,7 +91,8 @@ func MeRecentlyViewedProjectsHandler(srv server.Server) http.Handler {
 			}
 
 		default:
-			w.WriteHeader(http.StatusMethodNotAllowed)
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
 			return
 		}
 	})
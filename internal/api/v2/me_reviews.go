@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp-forge/hermes/internal/server"
 	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+	"github.com/hashicorp-forge/hermes/pkg/database"
 	"github.com/hashicorp-forge/hermes/pkg/document"
 	"github.com/hashicorp-forge/hermes/pkg/models"
 )
@@ -40,13 +41,14 @@ type DocumentReviewResponse struct {
 
 func MeReviewsHandler(srv server.Server) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
 		errResp := func(httpCode int, userErrMsg, logErrMsg string, err error) {
 			srv.Logger.Error(logErrMsg,
 				"method", r.Method,
 				"path", r.URL.Path,
 				"error", err,
 			)
-			http.Error(w, userErrMsg, httpCode)
+			writeProblem(w, r, httpCode, errorCodeForStatus(httpCode), userErrMsg)
 		}
 
 		// Authorize request.
@@ -67,11 +69,15 @@ func MeReviewsHandler(srv server.Server) http.Handler {
 				"email", userEmail,
 				"path", r.URL.Path)
 
+			// The reviews dashboard tolerates replication lag, so it is served
+			// from the read replica if one is configured.
+			db := database.Replica(srv.DB)
+
 			// Get the user from database
 			user := models.User{
 				EmailAddress: userEmail,
 			}
-			if err := user.Get(srv.DB); err != nil {
+			if err := user.Get(db); err != nil {
 				srv.Logger.Error("error getting user from database",
 					"error", err,
 					"email", userEmail,
@@ -90,7 +96,7 @@ func MeReviewsHandler(srv server.Server) http.Handler {
 
 			// Find all document reviews for this user
 			var reviews models.DocumentReviews
-			if err := reviews.Find(srv.DB, models.DocumentReview{
+			if err := reviews.Find(db, models.DocumentReview{
 				User: models.User{
 					EmailAddress: userEmail,
 				},
@@ -129,7 +135,7 @@ func MeReviewsHandler(srv server.Server) http.Handler {
 				doc := models.Document{
 					GoogleFileID: review.Document.GoogleFileID,
 				}
-				if err := doc.Get(srv.DB); err != nil {
+				if err := doc.Get(db); err != nil {
 					srv.Logger.Warn("error getting document for review",
 						"error", err,
 						"document_id", review.Document.GoogleFileID,
@@ -144,7 +150,7 @@ func MeReviewsHandler(srv server.Server) http.Handler {
 
 				// Get reviews and group reviews for the document
 				var docReviews models.DocumentReviews
-				if err := docReviews.Find(srv.DB, models.DocumentReview{
+				if err := docReviews.Find(db, models.DocumentReview{
 					Document: models.Document{
 						GoogleFileID: doc.GoogleFileID,
 					},
@@ -157,7 +163,7 @@ func MeReviewsHandler(srv server.Server) http.Handler {
 				}
 
 				var groupReviews models.DocumentGroupReviews
-				if err := groupReviews.Find(srv.DB, models.DocumentGroupReview{
+				if err := groupReviews.Find(db, models.DocumentGroupReview{
 					Document: models.Document{
 						GoogleFileID: doc.GoogleFileID,
 					},
@@ -225,7 +231,8 @@ func MeReviewsHandler(srv server.Server) http.Handler {
 			}
 
 		default:
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
 		}
 	})
 }
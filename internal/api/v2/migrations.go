@@ -1,13 +1,16 @@
 package api
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
 
 	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/pkg/authz"
 	"github.com/hashicorp-forge/hermes/pkg/docid"
 	"github.com/hashicorp-forge/hermes/pkg/migration"
 )
@@ -17,6 +20,14 @@ func getSQLDB(srv server.Server) (*sql.DB, error) {
 	return srv.DB.DB()
 }
 
+// newMigrationManager creates a migration manager that publishes lifecycle
+// events through the server's migration notifier, if configured.
+func newMigrationManager(srv server.Server, sqlDB *sql.DB) *migration.Manager {
+	m := migration.NewManager(sqlDB, srv.Logger)
+	m.SetNotifier(srv.MigrationNotifier)
+	return m
+}
+
 // MigrationsHandler handles migration job management endpoints
 // Routes:
 //
@@ -28,8 +39,23 @@ func getSQLDB(srv server.Server) (*sql.DB, error) {
 //	POST   /api/v2/migrations/jobs/:id/cancel     - Cancel a job
 //	GET    /api/v2/migrations/jobs/:id/progress   - Get job progress
 //	GET    /api/v2/migrations/jobs/:id/items      - List migration items
+//	GET    /api/v2/migrations/items/:id/audit     - Get migration item audit log
+//	POST   /api/v2/migrations/items/:id/rollback  - Roll back a moved item
+//	GET    /api/v2/migrations/documents/:uuid     - Get document migration history
+//
+// Only site admins can create, start, pause, cancel, or roll back migrations.
 func MigrationsHandler(srv server.Server) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
+		// Only site admins can make changes.
+		if r.Method != http.MethodGet &&
+			!authorizeRequest(w, r, srv.Config, srv.DB, srv.Logger,
+				authz.ActionAdmin, authz.Resource{},
+				"Only site admins can manage migrations",
+			) {
+			return
+		}
+
 		// Extract path after /api/v2/migrations/
 		path := strings.TrimPrefix(r.URL.Path, "/api/v2/migrations/")
 
@@ -40,7 +66,8 @@ func MigrationsHandler(srv server.Server) http.Handler {
 			} else if r.Method == http.MethodPost {
 				createMigrationJob(w, r, srv)
 			} else {
-				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+					"Method not allowed")
 			}
 
 		case strings.HasPrefix(path, "jobs/"):
@@ -48,13 +75,15 @@ func MigrationsHandler(srv server.Server) http.Handler {
 			parts := strings.Split(jobPath, "/")
 
 			if len(parts) == 0 || parts[0] == "" {
-				http.Error(w, "Job ID required", http.StatusBadRequest)
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					"Job ID required")
 				return
 			}
 
 			jobID, err := strconv.ParseInt(parts[0], 10, 64)
 			if err != nil {
-				http.Error(w, "Invalid job ID", http.StatusBadRequest)
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					"Invalid job ID")
 				return
 			}
 
@@ -65,7 +94,8 @@ func MigrationsHandler(srv server.Server) http.Handler {
 				} else if r.Method == http.MethodDelete {
 					cancelMigrationJob(w, r, srv, jobID)
 				} else {
-					http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+					writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+						"Method not allowed")
 				}
 			} else if len(parts) == 2 {
 				// /jobs/:id/:action
@@ -75,41 +105,99 @@ func MigrationsHandler(srv server.Server) http.Handler {
 					if r.Method == http.MethodPost {
 						startMigrationJob(w, r, srv, jobID)
 					} else {
-						http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+						writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+							"Method not allowed")
 					}
 				case "pause":
 					if r.Method == http.MethodPost {
 						pauseMigrationJob(w, r, srv, jobID)
 					} else {
-						http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+						writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+							"Method not allowed")
 					}
 				case "cancel":
 					if r.Method == http.MethodPost {
 						cancelMigrationJob(w, r, srv, jobID)
 					} else {
-						http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+						writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+							"Method not allowed")
 					}
 				case "progress":
 					if r.Method == http.MethodGet {
 						getMigrationProgress(w, r, srv, jobID)
 					} else {
-						http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+						writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+							"Method not allowed")
 					}
 				case "items":
 					if r.Method == http.MethodGet {
 						listMigrationItems(w, r, srv, jobID)
 					} else {
-						http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+						writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+							"Method not allowed")
 					}
 				default:
-					http.Error(w, "Unknown action", http.StatusNotFound)
+					writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound,
+						"Unknown action")
 				}
 			} else {
-				http.Error(w, "Invalid path", http.StatusBadRequest)
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					"Invalid path")
+			}
+
+		case strings.HasPrefix(path, "items/"):
+			parts := strings.Split(strings.TrimPrefix(path, "items/"), "/")
+			if len(parts) != 2 {
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					"Invalid path")
+				return
+			}
+
+			itemID, err := strconv.ParseInt(parts[0], 10, 64)
+			if err != nil {
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					"Invalid item ID")
+				return
+			}
+
+			switch parts[1] {
+			case "audit":
+				if r.Method == http.MethodGet {
+					getMigrationItemAudit(w, r, srv, itemID)
+				} else {
+					writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+						"Method not allowed")
+				}
+			case "rollback":
+				if r.Method == http.MethodPost {
+					rollbackMigrationItem(w, r, srv, itemID)
+				} else {
+					writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+						"Method not allowed")
+				}
+			default:
+				writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound,
+					"Unknown action")
+			}
+
+		case strings.HasPrefix(path, "documents/"):
+			uuidStr := strings.TrimSuffix(strings.TrimPrefix(path, "documents/"), "/")
+			docUUID, err := docid.ParseUUID(uuidStr)
+			if err != nil {
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					"Invalid document UUID")
+				return
+			}
+
+			if r.Method == http.MethodGet {
+				getDocumentMigrationHistory(w, r, srv, docUUID)
+			} else {
+				writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+					"Method not allowed")
 			}
 
 		default:
-			http.Error(w, "Not found", http.StatusNotFound)
+			writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound, "Not found")
 		}
 	})
 }
@@ -126,6 +214,10 @@ type CreateMigrationJobRequest struct {
 	BatchSize      int            `json:"batchSize"`      // Default: 100
 	DryRun         bool           `json:"dryRun"`         // Default: false
 	Validate       bool           `json:"validate"`       // Default: true
+
+	// Move strategy options
+	SoakPeriodSeconds int    `json:"soakPeriodSeconds"` // Wait before removing the source. Default: 0
+	SourceAction      string `json:"sourceAction"`      // "delete" or "tombstone". Default: "delete"
 }
 
 // createMigrationJob creates a new migration job
@@ -133,21 +225,38 @@ func createMigrationJob(w http.ResponseWriter, r *http.Request, srv server.Serve
 	var req CreateMigrationJobRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		srv.Logger.Error("failed to decode request", "error", err)
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			"Invalid request body")
 		return
 	}
 
 	// Validate required fields
 	if req.JobName == "" {
-		http.Error(w, "jobName is required", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			"jobName is required")
 		return
 	}
 	if req.SourceProvider == "" {
-		http.Error(w, "sourceProvider is required", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			"sourceProvider is required")
 		return
 	}
 	if req.DestProvider == "" {
-		http.Error(w, "destProvider is required", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			"destProvider is required")
+		return
+	}
+
+	switch migration.SourceAction(req.SourceAction) {
+	case "", migration.SourceActionDelete, migration.SourceActionTombstone:
+	default:
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			"sourceAction must be \"delete\" or \"tombstone\"")
+		return
+	}
+	if req.SoakPeriodSeconds < 0 {
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			"soakPeriodSeconds must not be negative")
 		return
 	}
 
@@ -161,12 +270,13 @@ func createMigrationJob(w http.ResponseWriter, r *http.Request, srv server.Serve
 	sqlDB, err := getSQLDB(srv)
 	if err != nil {
 		srv.Logger.Error("failed to get SQL DB", "error", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"Internal server error")
 		return
 	}
 
 	// Create migration manager
-	manager := migration.NewManager(sqlDB, srv.Logger)
+	manager := newMigrationManager(srv, sqlDB)
 
 	// Create job
 	jobReq := &migration.CreateJobRequest{
@@ -180,12 +290,16 @@ func createMigrationJob(w http.ResponseWriter, r *http.Request, srv server.Serve
 		DryRun:         req.DryRun,
 		Validate:       req.Validate,
 		CreatedBy:      userEmail.(string),
+
+		SoakPeriodSeconds: req.SoakPeriodSeconds,
+		SourceAction:      migration.SourceAction(req.SourceAction),
 	}
 
 	job, err := manager.CreateJob(r.Context(), jobReq)
 	if err != nil {
 		srv.Logger.Error("failed to create migration job", "error", err)
-		http.Error(w, "Failed to create migration job", http.StatusInternalServerError)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"Failed to create migration job")
 		return
 	}
 
@@ -206,7 +320,8 @@ func createMigrationJob(w http.ResponseWriter, r *http.Request, srv server.Serve
 		if len(uuids) > 0 {
 			if err := manager.QueueDocuments(r.Context(), job.ID, uuids, providerIDs); err != nil {
 				srv.Logger.Error("failed to queue documents", "error", err)
-				http.Error(w, "Failed to queue documents", http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Failed to queue documents")
 				return
 			}
 		}
@@ -249,14 +364,16 @@ func listMigrationJobs(w http.ResponseWriter, r *http.Request, srv server.Server
 	sqlDB, err := getSQLDB(srv)
 	if err != nil {
 		srv.Logger.Error("failed to get SQL DB", "error", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"Internal server error")
 		return
 	}
 
 	rows, err := sqlDB.QueryContext(r.Context(), query, args...)
 	if err != nil {
 		srv.Logger.Error("failed to query jobs", "error", err)
-		http.Error(w, "Failed to list jobs", http.StatusInternalServerError)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"Failed to list jobs")
 		return
 	}
 	defer rows.Close()
@@ -316,19 +433,22 @@ func getMigrationJob(w http.ResponseWriter, r *http.Request, srv server.Server,
 	sqlDB, err := getSQLDB(srv)
 	if err != nil {
 		srv.Logger.Error("failed to get SQL DB", "error", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"Internal server error")
 		return
 	}
 
-	manager := migration.NewManager(sqlDB, srv.Logger)
+	manager := newMigrationManager(srv, sqlDB)
 
 	job, err := manager.GetJob(r.Context(), jobID)
 	if err != nil {
 		if err.Error() == "migration job "+strconv.FormatInt(jobID, 10)+" not found" {
-			http.Error(w, "Job not found", http.StatusNotFound)
+			writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound,
+				"Job not found")
 		} else {
 			srv.Logger.Error("failed to get job", "error", err)
-			http.Error(w, "Failed to get job", http.StatusInternalServerError)
+			writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Failed to get job")
 		}
 		return
 	}
@@ -342,15 +462,17 @@ func startMigrationJob(w http.ResponseWriter, r *http.Request, srv server.Server
 	sqlDB, err := getSQLDB(srv)
 	if err != nil {
 		srv.Logger.Error("failed to get SQL DB", "error", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"Internal server error")
 		return
 	}
 
-	manager := migration.NewManager(sqlDB, srv.Logger)
+	manager := newMigrationManager(srv, sqlDB)
 
 	if err := manager.StartJob(r.Context(), jobID); err != nil {
 		srv.Logger.Error("failed to start job", "jobID", jobID, "error", err)
-		http.Error(w, "Failed to start job", http.StatusInternalServerError)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"Failed to start job")
 		return
 	}
 
@@ -358,7 +480,8 @@ func startMigrationJob(w http.ResponseWriter, r *http.Request, srv server.Server
 	job, err := manager.GetJob(r.Context(), jobID)
 	if err != nil {
 		srv.Logger.Error("failed to get job after start", "error", err)
-		http.Error(w, "Failed to get job", http.StatusInternalServerError)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"Failed to get job")
 		return
 	}
 
@@ -371,30 +494,34 @@ func pauseMigrationJob(w http.ResponseWriter, r *http.Request, srv server.Server
 	sqlDB, err := getSQLDB(srv)
 	if err != nil {
 		srv.Logger.Error("failed to get SQL DB", "error", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"Internal server error")
 		return
 	}
 
 	// Update job status to paused
 	result, err := sqlDB.ExecContext(r.Context(), `
 		UPDATE migration_jobs
-		SET status = $1, updated_at = NOW()
+		SET status = $1, updated_at = CURRENT_TIMESTAMP
 		WHERE id = $2 AND status = $3
 	`, "paused", jobID, "running")
 
 	if err != nil {
 		srv.Logger.Error("failed to pause job", "jobID", jobID, "error", err)
-		http.Error(w, "Failed to pause job", http.StatusInternalServerError)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"Failed to pause job")
 		return
 	}
 
 	rows, err := result.RowsAffected()
 	if err != nil {
-		http.Error(w, "Failed to check result", http.StatusInternalServerError)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"Failed to check result")
 		return
 	}
 	if rows == 0 {
-		http.Error(w, "Job not found or not running", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			"Job not found or not running")
 		return
 	}
 
@@ -402,15 +529,17 @@ func pauseMigrationJob(w http.ResponseWriter, r *http.Request, srv server.Server
 	sqlDB, err = getSQLDB(srv)
 	if err != nil {
 		srv.Logger.Error("failed to get SQL DB", "error", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"Internal server error")
 		return
 	}
 
-	manager := migration.NewManager(sqlDB, srv.Logger)
+	manager := newMigrationManager(srv, sqlDB)
 	job, err := manager.GetJob(r.Context(), jobID)
 	if err != nil {
 		srv.Logger.Error("failed to get job after pause", "error", err)
-		http.Error(w, "Failed to get job", http.StatusInternalServerError)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"Failed to get job")
 		return
 	}
 
@@ -423,30 +552,34 @@ func cancelMigrationJob(w http.ResponseWriter, r *http.Request, srv server.Serve
 	sqlDB, err := getSQLDB(srv)
 	if err != nil {
 		srv.Logger.Error("failed to get SQL DB", "error", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"Internal server error")
 		return
 	}
 
 	// Update job status to cancelled
 	result, err := sqlDB.ExecContext(r.Context(), `
 		UPDATE migration_jobs
-		SET status = $1, completed_at = NOW(), updated_at = NOW()
+		SET status = $1, completed_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
 		WHERE id = $2 AND status IN ($3, $4)
 	`, "cancelled", jobID, "pending", "running")
 
 	if err != nil {
 		srv.Logger.Error("failed to cancel job", "jobID", jobID, "error", err)
-		http.Error(w, "Failed to cancel job", http.StatusInternalServerError)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"Failed to cancel job")
 		return
 	}
 
 	rows, err := result.RowsAffected()
 	if err != nil {
-		http.Error(w, "Failed to check result", http.StatusInternalServerError)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"Failed to check result")
 		return
 	}
 	if rows == 0 {
-		http.Error(w, "Job not found or already completed", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			"Job not found or already completed")
 		return
 	}
 
@@ -462,16 +595,18 @@ func getMigrationProgress(w http.ResponseWriter, r *http.Request, srv server.Ser
 	sqlDB, err := getSQLDB(srv)
 	if err != nil {
 		srv.Logger.Error("failed to get SQL DB", "error", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"Internal server error")
 		return
 	}
 
-	manager := migration.NewManager(sqlDB, srv.Logger)
+	manager := newMigrationManager(srv, sqlDB)
 
 	progress, err := manager.GetProgress(r.Context(), jobID)
 	if err != nil {
 		srv.Logger.Error("failed to get progress", "jobID", jobID, "error", err)
-		http.Error(w, "Failed to get progress", http.StatusInternalServerError)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"Failed to get progress")
 		return
 	}
 
@@ -495,7 +630,8 @@ func listMigrationItems(w http.ResponseWriter, r *http.Request, srv server.Serve
 	query := `
 		SELECT id, document_uuid, source_provider_id, dest_provider_id,
 			   status, attempt_count, error_message, duration_ms, content_match,
-			   created_at, started_at, completed_at
+			   created_at, started_at, completed_at,
+			   source_state, source_remove_after, source_removed_at
 		FROM migration_items
 		WHERE migration_job_id = $1
 	`
@@ -512,14 +648,16 @@ func listMigrationItems(w http.ResponseWriter, r *http.Request, srv server.Serve
 	sqlDB, err := getSQLDB(srv)
 	if err != nil {
 		srv.Logger.Error("failed to get SQL DB", "error", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"Internal server error")
 		return
 	}
 
 	rows, err := sqlDB.QueryContext(r.Context(), query, args...)
 	if err != nil {
 		srv.Logger.Error("failed to query items", "error", err)
-		http.Error(w, "Failed to list items", http.StatusInternalServerError)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"Failed to list items")
 		return
 	}
 	defer rows.Close()
@@ -534,11 +672,14 @@ func listMigrationItems(w http.ResponseWriter, r *http.Request, srv server.Serve
 			contentMatch                           sql.NullBool
 			createdAt                              string
 			startedAt, completedAt                 sql.NullString
+			sourceState                            sql.NullString
+			sourceRemoveAfter, sourceRemovedAt     sql.NullString
 		)
 
 		if err := rows.Scan(&id, &documentUUID, &sourceProviderID, &destProviderID,
 			&status, &attemptCount, &errorMessage, &durationMs, &contentMatch,
-			&createdAt, &startedAt, &completedAt); err != nil {
+			&createdAt, &startedAt, &completedAt,
+			&sourceState, &sourceRemoveAfter, &sourceRemovedAt); err != nil {
 			srv.Logger.Error("failed to scan item", "error", err)
 			continue
 		}
@@ -570,6 +711,15 @@ func listMigrationItems(w http.ResponseWriter, r *http.Request, srv server.Serve
 		if completedAt.Valid {
 			item["completedAt"] = completedAt.String
 		}
+		if sourceState.Valid {
+			item["sourceState"] = sourceState.String
+		}
+		if sourceRemoveAfter.Valid {
+			item["sourceRemoveAfter"] = sourceRemoveAfter.String
+		}
+		if sourceRemovedAt.Valid {
+			item["sourceRemovedAt"] = sourceRemovedAt.String
+		}
 
 		items = append(items, item)
 	}
@@ -582,3 +732,150 @@ func listMigrationItems(w http.ResponseWriter, r *http.Request, srv server.Serve
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
+
+// rollbackMigrationItem requests a rollback of a moved migration item
+func rollbackMigrationItem(w http.ResponseWriter, r *http.Request, srv server.Server, itemID int64) {
+	sqlDB, err := getSQLDB(srv)
+	if err != nil {
+		srv.Logger.Error("failed to get SQL DB", "error", err)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"Internal server error")
+		return
+	}
+
+	// Get user email from context (set by auth middleware)
+	userEmail := r.Context().Value("user_email")
+	if userEmail == nil {
+		userEmail = "system"
+	}
+
+	manager := newMigrationManager(srv, sqlDB)
+
+	if err := manager.RequestRollback(r.Context(), itemID, userEmail.(string)); err != nil {
+		switch {
+		case errors.Is(err, migration.ErrItemNotFound):
+			writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound,
+				"Migration item not found")
+		case errors.Is(err, migration.ErrRollbackNotAllowed):
+			writeProblem(w, r, http.StatusConflict, ErrCodeConflict, err.Error())
+		default:
+			srv.Logger.Error("failed to request rollback", "itemID", itemID, "error", err)
+			writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Failed to request rollback")
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"itemId":      itemID,
+		"sourceState": migration.SourceStateRollbackRequested,
+	})
+}
+
+// getMigrationItemAudit gets the audit log for a migration item
+func getMigrationItemAudit(w http.ResponseWriter, r *http.Request, srv server.Server, itemID int64) {
+	sqlDB, err := getSQLDB(srv)
+	if err != nil {
+		srv.Logger.Error("failed to get SQL DB", "error", err)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"Internal server error")
+		return
+	}
+
+	manager := newMigrationManager(srv, sqlDB)
+
+	entries, err := manager.GetItemAudit(r.Context(), itemID)
+	if err != nil {
+		srv.Logger.Error("failed to get migration item audit log", "itemID", itemID, "error", err)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"Failed to get audit log")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"itemId":  itemID,
+		"entries": entries,
+		"count":   len(entries),
+	})
+}
+
+// getDocumentMigrationHistory gets the migration audit trail for a document
+func getDocumentMigrationHistory(
+	w http.ResponseWriter, r *http.Request, srv server.Server, docUUID docid.UUID,
+) {
+	sqlDB, err := getSQLDB(srv)
+	if err != nil {
+		srv.Logger.Error("failed to get SQL DB", "error", err)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"Internal server error")
+		return
+	}
+
+	manager := newMigrationManager(srv, sqlDB)
+
+	history, err := manager.GetDocumentHistory(r.Context(), docUUID)
+	if err != nil {
+		srv.Logger.Error("failed to get document migration history",
+			"document_uuid", docUUID.String(), "error", err)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"Failed to get document migration history")
+		return
+	}
+
+	response := map[string]interface{}{
+		"documentUuid": docUUID.String(),
+		"history":      history,
+		"count":        len(history),
+	}
+	if loc := migration.CurrentLocation(history); loc != nil {
+		response["currentLocation"] = documentStorageLocation(loc)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// addMigrationHistory adds a document's migration history and current storage
+// location to a document response object. Documents that have never been
+// migrated are left unchanged.
+func addMigrationHistory(
+	ctx context.Context, srv server.Server, docUUID docid.UUID, docObj map[string]any,
+) error {
+	sqlDB, err := getSQLDB(srv)
+	if err != nil {
+		return err
+	}
+
+	history, err := newMigrationManager(srv, sqlDB).
+		GetDocumentHistory(ctx, docUUID)
+	if err != nil {
+		return err
+	}
+	if len(history) == 0 {
+		return nil
+	}
+
+	docObj["migrationHistory"] = history
+	if loc := migration.CurrentLocation(history); loc != nil {
+		docObj["storageLocation"] = documentStorageLocation(loc)
+	}
+	return nil
+}
+
+// documentStorageLocation returns the storage location recorded by a
+// completed migration.
+func documentStorageLocation(m *migration.DocumentMigration) map[string]any {
+	loc := map[string]any{
+		"provider":   m.DestProvider,
+		"jobId":      m.JobID,
+		"strategy":   m.Strategy,
+		"migratedAt": m.CompletedAt,
+	}
+	if m.DestProviderID != nil {
+		loc["providerId"] = *m.DestProviderID
+	}
+	return loc
+}
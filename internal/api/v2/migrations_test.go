@@ -0,0 +1,136 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/internal/server"
+	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+	"github.com/hashicorp-forge/hermes/pkg/migration"
+	"github.com/hashicorp-forge/hermes/pkg/models/modelstest"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newStorageTestServer returns a server with a SQLite database that has the
+// storage provider and migration job tables, which are created by SQL
+// migrations instead of the models. Rows are last updated in 2000 unless
+// updated_at is set.
+func newStorageTestServer(t *testing.T) server.Server {
+	db := modelstest.NewDB(t)
+	require.NoError(t, db.Exec(`
+		CREATE TABLE provider_storage (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			provider_name TEXT NOT NULL UNIQUE,
+			provider_type TEXT NOT NULL,
+			config TEXT NOT NULL,
+			capabilities TEXT,
+			status TEXT NOT NULL DEFAULT 'active',
+			is_primary BOOLEAN NOT NULL DEFAULT false,
+			is_writable BOOLEAN NOT NULL DEFAULT true,
+			document_count INTEGER DEFAULT 0,
+			total_size_bytes INTEGER DEFAULT 0,
+			last_health_check TIMESTAMP,
+			health_status TEXT,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP NOT NULL DEFAULT '2000-01-01 00:00:00'
+		)`).Error)
+	require.NoError(t, db.Exec(`
+		CREATE TABLE migration_jobs (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			job_uuid TEXT NOT NULL UNIQUE,
+			job_name TEXT NOT NULL,
+			source_provider_id INTEGER NOT NULL REFERENCES provider_storage(id),
+			dest_provider_id INTEGER NOT NULL REFERENCES provider_storage(id),
+			strategy TEXT NOT NULL DEFAULT 'copy',
+			status TEXT NOT NULL DEFAULT 'pending',
+			total_documents INTEGER DEFAULT 0,
+			migrated_documents INTEGER DEFAULT 0,
+			failed_documents INTEGER DEFAULT 0,
+			skipped_documents INTEGER DEFAULT 0,
+			started_at TIMESTAMP,
+			completed_at TIMESTAMP,
+			concurrency INTEGER DEFAULT 5,
+			batch_size INTEGER DEFAULT 100,
+			dry_run BOOLEAN DEFAULT false,
+			validate_after_migration BOOLEAN DEFAULT true,
+			validation_status TEXT,
+			rollback_enabled BOOLEAN DEFAULT true,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			updated_at TIMESTAMP NOT NULL DEFAULT '2000-01-01 00:00:00',
+			created_by TEXT NOT NULL,
+			soak_period_seconds INTEGER NOT NULL DEFAULT 0,
+			source_action TEXT NOT NULL DEFAULT 'delete'
+		)`).Error)
+	require.NoError(t, db.Exec(`
+		INSERT INTO provider_storage
+			(provider_name, provider_type, config, capabilities)
+		VALUES
+			('google-prod', 'google', '{}', '{}'),
+			('s3-archive', 's3', '{}', '{}')
+	`).Error)
+
+	return server.Server{
+		Config: &config.Config{
+			Authorization: &config.Authorization{
+				SiteAdmins: []string{"admin@example.com"},
+			},
+		},
+		DB:     db,
+		Logger: hclog.NewNullLogger(),
+	}
+}
+
+// newAdminRequest returns a request by a site admin of a storage test server.
+func newAdminRequest(method, path, body string) *http.Request {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	return req.WithContext(context.WithValue(
+		req.Context(), pkgauth.UserEmailKey, "admin@example.com"))
+}
+
+func TestMigrationJobLifecycleSQLite(t *testing.T) {
+	srv := newStorageTestServer(t)
+	require.NoError(t, srv.DB.Exec(`
+		INSERT INTO migration_jobs
+			(job_uuid, job_name, source_provider_id, dest_provider_id, created_by)
+		VALUES
+			('job-1', 'archive', 1, 2, 'admin@example.com'),
+			('job-2', 'archive again', 1, 2, 'admin@example.com')
+	`).Error)
+
+	serve := func(method, path string) (*httptest.ResponseRecorder, migration.Job) {
+		w := httptest.NewRecorder()
+		MigrationsHandler(srv).ServeHTTP(w, newAdminRequest(method, path, ""))
+		var job migration.Job
+		if w.Code == http.StatusOK {
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&job))
+		}
+		return w, job
+	}
+
+	w, job := serve("POST", "/api/v2/migrations/jobs/1/start")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, migration.JobStatusRunning, job.Status)
+	assert.NotNil(t, job.StartedAt)
+	assert.Greater(t, job.UpdatedAt.Year(), 2000)
+
+	w, job = serve("POST", "/api/v2/migrations/jobs/1/pause")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, migration.JobStatusPaused, job.Status)
+
+	// Paused jobs aren't running.
+	w, _ = serve("POST", "/api/v2/migrations/jobs/1/pause")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w, _ = serve("POST", "/api/v2/migrations/jobs/2/cancel")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	_, job = serve("GET", "/api/v2/migrations/jobs/2")
+	assert.Equal(t, migration.JobStatusCancelled, job.Status)
+	assert.NotNil(t, job.CompletedAt)
+}
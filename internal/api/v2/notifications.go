@@ -0,0 +1,103 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+)
+
+// NotificationsEmailRequest is the request to send an email.
+type NotificationsEmailRequest struct {
+	To      []string `json:"to"`
+	From    string   `json:"from"`
+	Subject string   `json:"subject"`
+	Body    string   `json:"body"`
+}
+
+// NotificationsEmailTemplateRequest is the request to send a templated email.
+type NotificationsEmailTemplateRequest struct {
+	To       []string       `json:"to"`
+	Template string         `json:"template"`
+	Data     map[string]any `json:"data"`
+}
+
+// notificationsPolicy authorizes service tokens that send notifications on
+// behalf of remote Hermes instances (see the api workspace adapter).
+var notificationsPolicy = ServiceTokenPolicy{
+	Name:       "notifications",
+	TokenTypes: []string{"api", "edge"},
+	Scope:      scope(models.TokenScopeNotifications),
+}
+
+// NotificationsHandler handles the /api/v2/notifications/ endpoints, which
+// send notifications with the server's workspace provider. Requests must be
+// authenticated by a service token with the "notifications" scope.
+func NotificationsHandler(srv server.Server) http.Handler {
+	return ServiceTokenAuthMiddleware(srv, notificationsPolicy,
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost {
+				writeProblem(w, r, http.StatusMethodNotAllowed,
+					ErrCodeMethodNotAllowed, "Method not allowed")
+				return
+			}
+			if srv.WorkspaceProvider == nil {
+				writeProblem(w, r, http.StatusServiceUnavailable,
+					ErrCodeServiceUnavailable, "Notifications are not configured")
+				return
+			}
+
+			switch r.URL.Path {
+			case "/api/v2/notifications/email":
+				var req NotificationsEmailRequest
+				if err := decodeRequest(r, &req); err != nil {
+					writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+						"Invalid request body")
+					return
+				}
+				if len(req.To) == 0 {
+					writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+						"to is required")
+					return
+				}
+				if err := srv.WorkspaceProvider.SendEmail(
+					r.Context(), req.To, req.From, req.Subject, req.Body,
+				); err != nil {
+					srv.Logger.Error("error sending email", "error", err)
+					writeProblem(w, r, http.StatusInternalServerError,
+						ErrCodeInternal, "Error sending email")
+					return
+				}
+
+			case "/api/v2/notifications/email/template":
+				var req NotificationsEmailTemplateRequest
+				if err := decodeRequest(r, &req); err != nil {
+					writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+						"Invalid request body")
+					return
+				}
+				if len(req.To) == 0 || req.Template == "" {
+					writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+						"to and template are required")
+					return
+				}
+				if err := srv.WorkspaceProvider.SendEmailWithTemplate(
+					r.Context(), req.To, req.Template, req.Data,
+				); err != nil {
+					srv.Logger.Error("error sending email with template",
+						"error", err,
+						"template", req.Template,
+					)
+					writeProblem(w, r, http.StatusInternalServerError,
+						ErrCodeInternal, "Error sending email")
+					return
+				}
+
+			default:
+				writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound, "Not Found")
+				return
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+		}))
+}
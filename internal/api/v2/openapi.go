@@ -0,0 +1,1472 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/internal/openapi"
+	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/internal/services"
+	"github.com/hashicorp-forge/hermes/pkg/migration"
+	"github.com/hashicorp-forge/hermes/pkg/search"
+	"github.com/hashicorp-forge/hermes/pkg/workspace"
+)
+
+// apiOperation describes an API operation for the OpenAPI document.
+type apiOperation struct {
+	method  string
+	path    string
+	id      string
+	tag     string
+	summary string
+
+	// query are the query parameters of the operation.
+	query []openapi.Parameter
+
+	// request is a value of the request body type, if the operation has a
+	// request body.
+	request any
+
+	// response is a value of the response body type, or nil if the operation
+	// doesn't respond with a body. Use rawJSON{} for untyped JSON responses.
+	response any
+
+	// status is the success status code. The default is 200.
+	status int
+
+	// errors describe the error statuses that clients are expected to handle,
+	// by status code. Other errors are covered by the default response.
+	errors map[int]string
+
+	// produces is the content type of the response body. The default is
+	// application/json.
+	produces string
+}
+
+// rawJSON is the response type of operations whose JSON response bodies don't
+// have a declared type.
+type rawJSON struct{}
+
+// queryParam returns an optional query parameter of type typ.
+func queryParam(name, typ, description string) openapi.Parameter {
+	return openapi.Parameter{
+		Name:        name,
+		In:          "query",
+		Description: description,
+		Schema:      &openapi.Schema{Type: typ},
+	}
+}
+
+// analyticsQueryParams returns the query parameters of analytics operations.
+func analyticsQueryParams() []openapi.Parameter {
+	return []openapi.Parameter{
+		queryParam("product", "string", "Only include documents of this product."),
+		queryParam("from", "string",
+			"The first month (YYYY-MM). The default is 11 months before to."),
+		queryParam("to", "string",
+			"The last month (YYYY-MM). The default is the current month."),
+	}
+}
+
+// apiOperations are the operations included in the OpenAPI document. Request
+// and response body schemas are generated from the handlers' types, so they
+// always match what the handlers decode and encode. Add new endpoints here
+// and run "make generate-api" to update the generated clients.
+var apiOperations = []apiOperation{
+	// Admin.
+	{
+		method: "GET", path: "/api/v2/admin/config", id: "getAdminConfig",
+		tag: "admin", summary: "Get the effective server configuration",
+		response: AdminConfigResponse{},
+	},
+	{
+		method: "GET", path: "/api/v2/admin/custom-fields",
+		id: "listCustomFields", tag: "admin",
+		summary:  "List the custom fields of document types",
+		response: []AdminDocumentTypeCustomFields{},
+	},
+	{
+		method: "PUT", path: "/api/v2/admin/custom-fields/{docType}/{name}",
+		id: "putCustomField", tag: "admin",
+		summary:  "Create or update a custom field of a document type",
+		request:  AdminCustomFieldPutRequest{},
+		response: AdminCustomField{},
+	},
+	{
+		method: "DELETE", path: "/api/v2/admin/custom-fields/{docType}/{name}",
+		id: "deleteCustomField", tag: "admin",
+		summary: "Delete a custom field of a document type",
+		status:  http.StatusNoContent,
+	},
+	{
+		method: "GET", path: "/api/v2/admin/deleted/documents",
+		id: "listDeletedDocuments", tag: "admin",
+		summary:  "List deleted documents that can be restored",
+		response: []DeletedDocument{},
+	},
+	{
+		method: "POST", path: "/api/v2/admin/deleted/documents/{id}/restore",
+		id: "restoreDocument", tag: "admin",
+		summary: "Restore a deleted document",
+		status:  http.StatusNoContent,
+	},
+	{
+		method: "GET", path: "/api/v2/admin/deleted/projects",
+		id: "listDeletedProjects", tag: "admin",
+		summary:  "List deleted projects that can be restored",
+		response: []DeletedProject{},
+	},
+	{
+		method: "POST", path: "/api/v2/admin/deleted/projects/{id}/restore",
+		id: "restoreProject", tag: "admin",
+		summary: "Restore a deleted project",
+		status:  http.StatusNoContent,
+	},
+	{
+		method: "GET", path: "/api/v2/admin/document-types",
+		id: "listAdminDocumentTypes", tag: "admin",
+		summary:  "List document types and their review workflows",
+		response: []AdminDocumentType{},
+	},
+	{
+		method: "GET", path: "/api/v2/admin/document-types/export",
+		id: "exportDocumentTypes", tag: "admin",
+		summary:  "Export document types as a configuration block",
+		response: "", produces: "text/plain",
+	},
+	{
+		method: "POST", path: "/api/v2/admin/document-types/import",
+		id: "importDocumentTypes", tag: "admin",
+		summary:  "Import document types from a configuration block",
+		request:  AdminDocumentTypesImportRequest{},
+		response: AdminDocumentTypesImportResponse{},
+	},
+	{
+		method: "GET", path: "/api/v2/admin/document-types/{name}",
+		id: "getAdminDocumentType", tag: "admin",
+		summary:  "Get a document type and its review workflow",
+		response: AdminDocumentType{},
+	},
+	{
+		method: "PUT", path: "/api/v2/admin/document-types/{name}",
+		id: "putDocumentType", tag: "admin",
+		summary:  "Create or update a document type",
+		request:  AdminDocumentTypePutRequest{},
+		response: AdminDocumentType{},
+	},
+	{
+		method: "DELETE", path: "/api/v2/admin/document-types/{name}",
+		id: "deleteDocumentType", tag: "admin",
+		summary: "Delete a document type without documents",
+		status:  http.StatusNoContent,
+	},
+	{
+		method: "GET", path: "/api/v2/admin/duplicates",
+		id: "listDuplicateDocuments", tag: "admin",
+		summary: "List documents that are likely duplicates",
+		query: []openapi.Parameter{
+			queryParam("minSimilarity", "number",
+				"Only list documents at least this similar (0 to 1)."),
+			queryParam("crossProduct", "boolean",
+				"Only list documents of different products."),
+			queryParam("limit", "integer",
+				"The maximum number of duplicates (default 100)."),
+		},
+		response: []AdminDuplicate{},
+	},
+	{
+		method: "GET", path: "/api/v2/admin/edges", id: "listEdges",
+		tag: "admin", summary: "List edge instances",
+		query: []openapi.Parameter{
+			queryParam("staleAfter", "string",
+				"Flag edge instances not heard from within this duration as "+
+					"stale (default 15m)."),
+		},
+		response: []AdminEdge{},
+	},
+	{
+		method: "POST", path: "/api/v2/admin/email/preview",
+		id: "previewEmail", tag: "admin",
+		summary:  "Render an email template",
+		request:  AdminEmailPreviewRequest{},
+		response: AdminEmailPreview{},
+	},
+	{
+		method: "POST", path: "/api/v2/admin/email/test-send",
+		id: "sendTestEmail", tag: "admin",
+		summary:  "Render an email template and send it to the admin",
+		request:  AdminEmailPreviewRequest{},
+		response: AdminEmailPreview{},
+	},
+	{
+		method: "GET", path: "/api/v2/admin/impersonation",
+		id: "listImpersonationSessions", tag: "admin",
+		summary: "List impersonation sessions",
+		query: []openapi.Parameter{
+			queryParam("includeInactive", "boolean",
+				"Include ended and expired sessions."),
+		},
+		response: []ImpersonationSession{},
+	},
+	{
+		method: "POST", path: "/api/v2/admin/impersonation",
+		id: "startImpersonation", tag: "admin",
+		summary:  "Start impersonating a user",
+		request:  AdminImpersonationPostRequest{},
+		response: ImpersonationSession{}, status: http.StatusCreated,
+	},
+	{
+		method: "DELETE", path: "/api/v2/admin/impersonation",
+		id: "endImpersonation", tag: "admin",
+		summary: "End the current impersonation session",
+		status:  http.StatusNoContent,
+	},
+	{
+		method: "DELETE", path: "/api/v2/admin/impersonation/{id}",
+		id: "endImpersonationSession", tag: "admin",
+		summary: "End an impersonation session",
+		status:  http.StatusNoContent,
+	},
+	{
+		method: "GET", path: "/api/v2/admin/jobs", id: "listJobs",
+		tag: "admin", summary: "List background jobs, newest first",
+		query: []openapi.Parameter{
+			queryParam("status", "string",
+				"Filter by status: pending, running, succeeded, or failed."),
+			queryParam("type", "string", "Filter by job type."),
+			queryParam("cursor", "string",
+				"The nextCursor of the previous page."),
+			queryParam("limit", "integer",
+				"Maximum number of jobs to return (default 50, max 200)."),
+		},
+		response: AdminJobsGetResponse{},
+	},
+	{
+		method: "GET", path: "/api/v2/admin/jobs/{id}", id: "getJob",
+		tag: "admin", summary: "Get a background job",
+		response: AdminJob{},
+	},
+	{
+		method: "POST", path: "/api/v2/admin/jobs/{id}/retry", id: "retryJob",
+		tag: "admin", summary: "Retry a failed background job",
+		response: AdminJob{},
+	},
+	{
+		method: "POST", path: "/api/v2/admin/revision-events/replay",
+		id: "replayRevisionEvents", tag: "admin",
+		summary:  "Re-emit document revision events onto the revision topic",
+		request:  AdminReplayRevisionEventsRequest{},
+		response: AdminReplayRevisionEventsResponse{},
+	},
+	{
+		method: "GET", path: "/api/v2/admin/roles", id: "listUserRoles",
+		tag: "admin", summary: "List user role assignments",
+		response: []UserRole{},
+	},
+	{
+		method: "POST", path: "/api/v2/admin/roles", id: "assignUserRole",
+		tag: "admin", summary: "Assign a role to a user",
+		request:  AdminRolesPostRequest{},
+		response: UserRole{}, status: http.StatusCreated,
+	},
+	{
+		method: "DELETE", path: "/api/v2/admin/roles/{id}",
+		id: "removeUserRole", tag: "admin",
+		summary: "Remove a role assignment",
+		status:  http.StatusNoContent,
+	},
+	{
+		method: "GET", path: "/api/v2/admin/search-vocabulary",
+		id: "getSearchVocabulary", tag: "admin",
+		summary:  "Get the synonyms and stop words of searches",
+		response: AdminSearchVocabulary{},
+	},
+	{
+		method: "PUT", path: "/api/v2/admin/search-vocabulary/stop-words",
+		id: "putSearchStopWords", tag: "admin",
+		summary:  "Replace the stop words of searches",
+		request:  AdminSearchStopWordsPutRequest{},
+		response: AdminSearchVocabulary{},
+	},
+	{
+		method: "PUT", path: "/api/v2/admin/search-vocabulary/synonyms/{name}",
+		id: "putSearchSynonymSet", tag: "admin",
+		summary:  "Create or update a synonym set",
+		request:  AdminSearchSynonymSetPutRequest{},
+		response: AdminSearchVocabulary{},
+	},
+	{
+		method: "DELETE", path: "/api/v2/admin/search-vocabulary/synonyms/{name}",
+		id: "deleteSearchSynonymSet", tag: "admin",
+		summary:  "Delete a synonym set",
+		response: AdminSearchVocabulary{},
+	},
+	{
+		method: "GET", path: "/api/v2/admin/service-tokens",
+		id: "listServiceTokens", tag: "admin", summary: "List service tokens",
+		query: []openapi.Parameter{
+			queryParam("type", "string", "Only list tokens of this type."),
+			queryParam("includeRevoked", "boolean", "Include revoked tokens."),
+		},
+		response: []ServiceToken{},
+	},
+	{
+		method: "POST", path: "/api/v2/admin/service-tokens",
+		id: "createServiceToken", tag: "admin",
+		summary:  "Create a service token",
+		request:  AdminServiceTokensPostRequest{},
+		response: ServiceToken{}, status: http.StatusCreated,
+	},
+	{
+		method: "GET", path: "/api/v2/admin/service-tokens/{id}",
+		id: "getServiceToken", tag: "admin", summary: "Get a service token",
+		response: ServiceToken{},
+	},
+	{
+		method: "DELETE", path: "/api/v2/admin/service-tokens/{id}",
+		id: "revokeServiceToken", tag: "admin",
+		summary: "Revoke a service token",
+		query: []openapi.Parameter{
+			queryParam("reason", "string", "Why the token is revoked."),
+		},
+		status: http.StatusNoContent,
+	},
+	{
+		method: "POST", path: "/api/v2/admin/service-tokens/{id}/rotate",
+		id: "rotateServiceToken", tag: "admin",
+		summary:  "Replace a service token with a new one",
+		request:  AdminServiceTokensRotateRequest{},
+		response: ServiceToken{}, status: http.StatusCreated,
+	},
+	{
+		method: "GET", path: "/api/v2/admin/workers", id: "listWorkers",
+		tag: "admin", summary: "List pipeline workers",
+		query: []openapi.Parameter{
+			queryParam("staleAfter", "string",
+				"Flag workers not heard from within this duration as stale "+
+					"(default 2m)."),
+		},
+		response: []AdminWorker{},
+	},
+	{
+		method: "DELETE", path: "/api/v2/admin/workers/{name}",
+		id: "deleteWorker", tag: "admin", summary: "Remove a pipeline worker",
+		status: http.StatusNoContent,
+	},
+
+	// Analytics.
+	{
+		method: "GET", path: "/api/v2/analytics/documents",
+		id: "getDocumentAnalytics", tag: "analytics",
+		summary:  "Get the documents published and approved per product per month",
+		query:    analyticsQueryParams(),
+		response: AnalyticsDocumentsGetResponse{},
+	},
+	{
+		method: "GET", path: "/api/v2/analytics/review-time",
+		id: "getReviewTimeAnalytics", tag: "analytics",
+		summary:  "Get the median time in review of approved documents",
+		query:    analyticsQueryParams(),
+		response: AnalyticsReviewTimeGetResponse{},
+	},
+	{
+		method: "GET", path: "/api/v2/analytics/contributors",
+		id: "getContributorAnalytics", tag: "analytics",
+		summary: "Get the top contributors to published documents",
+		query: append(analyticsQueryParams(),
+			queryParam("limit", "integer",
+				"The maximum number of contributors (default: 10).")),
+		response: AnalyticsContributorsGetResponse{},
+	},
+
+	// Approvals and reviews.
+	{
+		method: "POST", path: "/api/v2/approvals/{id}", id: "approveDocument",
+		tag: "reviews", summary: "Approve a document",
+	},
+	{
+		method: "DELETE", path: "/api/v2/approvals/{id}",
+		id: "requestDocumentChanges", tag: "reviews",
+		summary: "Request changes to a document",
+	},
+	{
+		method: "GET", path: "/api/v2/group-reviews/{id}",
+		id: "getGroupReviews", tag: "reviews",
+		summary:  "Get the group review quorums of a document",
+		response: []GroupReviewStatus{},
+	},
+	{
+		method: "PATCH", path: "/api/v2/group-reviews/{id}",
+		id: "updateGroupReviews", tag: "reviews",
+		summary: "Set the group review quorums of a document",
+		request: GroupReviewsPatchRequest{}, response: []GroupReviewStatus{},
+	},
+	{
+		method: "POST", path: "/api/v2/reviews/{id}", id: "requestReview",
+		tag: "reviews", summary: "Publish a draft for review",
+		errors: map[int]string{
+			http.StatusConflict: "The draft has unchecked review checklist " +
+				"items that its document type requires for In-Review " +
+				`(problem code "conflict").`,
+		},
+	},
+
+	// Audit events.
+	{
+		method: "GET", path: "/api/v2/audit-events", id: "listAuditEvents",
+		tag: "audit", summary: "List audit events",
+		query: []openapi.Parameter{
+			queryParam("actor", "string", "Only list events by this user."),
+			queryParam("action", "string", "Only list events with this action."),
+			queryParam("targetType", "string",
+				"Only list events for targets of this type."),
+			queryParam("targetId", "string",
+				"Only list events for this target."),
+			queryParam("requestId", "string",
+				"Only list events of this request."),
+			queryParam("since", "string",
+				"Only list events at or after this RFC 3339 time."),
+			queryParam("until", "string",
+				"Only list events before this RFC 3339 time."),
+			queryParam("cursor", "string",
+				"The cursor of the next page from a previous response."),
+			queryParam("limit", "integer", "The maximum number of events."),
+		},
+		response: AuditEventsGetResponse{},
+	},
+
+	// Documents.
+	{
+		method: "GET", path: "/api/v2/document-types", id: "listDocumentTypes",
+		tag: "documents", summary: "List document types",
+		response: []*config.DocumentType{},
+	},
+	{
+		method: "GET", path: "/api/v2/document-types/{name}/templates",
+		id: "listDocumentTypeTemplates", tag: "documents",
+		summary:  "List the templates of a document type",
+		response: []DocumentTypeTemplate{},
+	},
+	{
+		method: "POST", path: "/api/v2/documents/import", id: "importDocument",
+		tag: "documents", summary: "Import a document",
+		request:  DocumentImportRequest{},
+		response: DocumentImportResponse{}, status: http.StatusCreated,
+	},
+	{
+		method: "GET", path: "/api/v2/documents/{id}", id: "getDocument",
+		tag: "documents", summary: "Get a document",
+		response: rawJSON{},
+	},
+	{
+		method: "PATCH", path: "/api/v2/documents/{id}", id: "updateDocument",
+		tag: "documents", summary: "Update a document",
+		request: DocumentPatchRequest{},
+		errors: map[int]string{
+			http.StatusConflict: "The document can't move to the requested " +
+				"status because it lacks the approvals or checked review " +
+				"checklist items required by its document type " +
+				`(problem code "conflict").`,
+		},
+	},
+	{
+		method: "GET", path: "/api/v2/documents/{id}/activity",
+		id: "listDocumentActivity", tag: "documents",
+		summary: "List user activity on a document",
+		query: []openapi.Parameter{
+			queryParam("action", "string",
+				"Only return activity of this action (view, edit, or review)."),
+			queryParam("limit", "integer", "The maximum number of activities."),
+			queryParam("cursor", "string",
+				"The nextCursor of the previous page of activity."),
+		},
+		response: ActivityGetResponse{},
+	},
+	{
+		method: "GET", path: "/api/v2/documents/{id}/content",
+		id: "getDocumentContent", tag: "documents",
+		summary: "Get the content of a document",
+		query: []openapi.Parameter{
+			queryParam("asOf", "string",
+				"Get the content as of this RFC 3339 time."),
+		},
+		response: DocumentContentResponse{},
+	},
+	{
+		method: "PUT", path: "/api/v2/documents/{id}/content",
+		id: "updateDocumentContent", tag: "documents",
+		summary: "Update the content of a document",
+		request: DocumentContentRequest{},
+	},
+	{
+		method: "GET", path: "/api/v2/documents/{id}/export",
+		id: "exportDocument", tag: "documents", summary: "Export a document",
+		query: []openapi.Parameter{
+			queryParam("format", "string", "The export format."),
+		},
+		response: "", produces: "application/octet-stream",
+	},
+	{
+		method: "GET", path: "/api/v2/documents/{id}/related-resources",
+		id: "getDocumentRelatedResources", tag: "documents",
+		summary:  "Get the related resources of a document",
+		response: relatedResourcesGetResponse{},
+	},
+	{
+		method: "PUT", path: "/api/v2/documents/{id}/related-resources",
+		id: "updateDocumentRelatedResources", tag: "documents",
+		summary: "Replace the related resources of a document",
+		request: relatedResourcesPutRequest{},
+	},
+	{
+		method: "GET", path: "/api/v2/documents/{id}/attachments",
+		id: "listDocumentAttachments", tag: "documents",
+		summary:  "List the files attached to a document",
+		response: DocumentAttachmentsGetResponse{},
+	},
+	{
+		method: "POST", path: "/api/v2/documents/{id}/attachments",
+		id: "createDocumentAttachment", tag: "documents",
+		summary:  "Attach a file to a document",
+		response: DocumentAttachment{}, status: http.StatusCreated,
+	},
+	{
+		method: "GET", path: "/api/v2/documents/{id}/attachments/{name}",
+		id: "getDocumentAttachment", tag: "documents",
+		summary:  "Download a file attached to a document",
+		response: "", produces: "application/octet-stream",
+	},
+	{
+		method: "PATCH", path: "/api/v2/documents/{id}/attachments/{name}",
+		id: "updateDocumentAttachment", tag: "documents",
+		summary:  "Toggle whether the text of an attached file is searchable",
+		request:  DocumentAttachmentPatchRequest{},
+		response: DocumentAttachment{},
+	},
+	{
+		method: "DELETE", path: "/api/v2/documents/{id}/attachments/{name}",
+		id: "deleteDocumentAttachment", tag: "documents",
+		summary: "Remove a file attached to a document",
+		status:  http.StatusNoContent,
+	},
+	{
+		method: "GET", path: "/api/v2/documents/{id}/checklist",
+		id: "getDocumentChecklist", tag: "documents",
+		summary:  "Get the review checklist of a document",
+		response: DocumentChecklistGetResponse{},
+	},
+	{
+		method: "PATCH", path: "/api/v2/documents/{id}/checklist",
+		id: "updateDocumentChecklist", tag: "documents",
+		summary:  "Check or uncheck items of the review checklist of a document",
+		request:  DocumentChecklistPatchRequest{},
+		response: DocumentChecklistGetResponse{},
+	},
+	{
+		method: "GET", path: "/api/v2/documents/{id}/lock",
+		id: "getDocumentLock", tag: "documents",
+		summary:  "Get the lock on editing a document",
+		response: DocumentLock{},
+	},
+	{
+		method: "POST", path: "/api/v2/documents/{id}/lock",
+		id: "lockDocument", tag: "documents",
+		summary:  "Lock a document for editing, or renew the lock",
+		request:  DocumentLockPostRequest{},
+		response: DocumentLock{},
+	},
+	{
+		method: "DELETE", path: "/api/v2/documents/{id}/lock",
+		id: "unlockDocument", tag: "documents",
+		summary: "Release the lock on editing a document",
+		status:  http.StatusNoContent,
+	},
+	{
+		method: "POST", path: "/api/v2/documents/{id}/permissions/batch",
+		id: "shareDocumentBatch", tag: "documents",
+		summary:  "Share a document with several users at once",
+		request:  DocumentPermissionsBatchRequest{},
+		response: DocumentPermissionsBatchResponse{},
+	},
+	{
+		method: "GET", path: "/api/v2/documents/{id}/quality",
+		id: "getDocumentQuality", tag: "documents",
+		summary:  "Get the quality checklist of a document",
+		response: DocumentQualityGetResponse{},
+	},
+	{
+		method: "GET", path: "/api/v2/documents/{id}/stats",
+		id: "getDocumentStats", tag: "documents",
+		summary: "Get the view stats of a document",
+		query: []openapi.Parameter{
+			queryParam("days", "integer",
+				"The number of days of stats, up to 365 (default 30)."),
+		},
+		response: DocumentStatsGetResponse{},
+	},
+	{
+		method: "GET", path: "/api/v2/documents/{id}/runs",
+		id: "listDocumentRuns", tag: "documents",
+		summary:  "List the checklist runs of a document",
+		response: DocumentRunsGetResponse{},
+	},
+	{
+		method: "POST", path: "/api/v2/documents/{id}/runs",
+		id: "createDocumentRun", tag: "documents",
+		summary:  "Start a checklist run for a document",
+		request:  DocumentRunPostRequest{},
+		response: DocumentRun{}, status: http.StatusCreated,
+	},
+	{
+		method: "GET", path: "/api/v2/documents/{id}/runs/{runId}",
+		id: "getDocumentRun", tag: "documents",
+		summary: "Get a checklist run", response: DocumentRun{},
+	},
+	{
+		method: "PATCH", path: "/api/v2/documents/{id}/runs/{runId}",
+		id: "updateDocumentRun", tag: "documents",
+		summary: "Update the items of a checklist run",
+		request: DocumentRunPatchRequest{}, response: DocumentRun{},
+	},
+	{
+		method: "GET", path: "/api/v2/documents/{id}/similar",
+		id: "listSimilarDocuments", tag: "documents",
+		summary: "List documents similar to a document",
+		query: []openapi.Parameter{
+			queryParam("limit", "integer", "The maximum number of documents."),
+		},
+		response: SemanticSearchResponse{},
+	},
+
+	// Drafts.
+	{
+		method: "GET", path: "/api/v2/drafts", id: "listDrafts",
+		tag: "drafts", summary: "List the current user's drafts",
+		query: []openapi.Parameter{
+			queryParam("facetFilters", "string", "Search facet filters."),
+			queryParam("facets", "string", "Search facets to return."),
+			queryParam("hitsPerPage", "integer",
+				"The number of drafts per page."),
+			queryParam("maxValuesPerFacet", "integer",
+				"The maximum number of values per facet."),
+			queryParam("page", "integer", "The page number."),
+			queryParam("sortBy", "string", "The sort order."),
+		},
+		response: rawJSON{},
+	},
+	{
+		method: "POST", path: "/api/v2/drafts", id: "createDraft",
+		tag: "drafts", summary: "Create a draft",
+		request: DraftsRequest{}, response: DraftsResponse{},
+	},
+	{
+		method: "GET", path: "/api/v2/drafts/{id}", id: "getDraft",
+		tag: "drafts", summary: "Get a draft", response: rawJSON{},
+	},
+	{
+		method: "PATCH", path: "/api/v2/drafts/{id}", id: "updateDraft",
+		tag: "drafts", summary: "Update a draft",
+		request: DraftsPatchRequest{},
+	},
+	{
+		method: "DELETE", path: "/api/v2/drafts/{id}", id: "deleteDraft",
+		tag: "drafts", summary: "Delete a draft", response: DraftsResponse{},
+	},
+	{
+		method: "GET", path: "/api/v2/drafts/{id}/activity",
+		id: "listDraftActivity", tag: "drafts",
+		summary: "List user activity on a draft",
+		query: []openapi.Parameter{
+			queryParam("action", "string",
+				"Only return activity of this action (view, edit, or review)."),
+			queryParam("limit", "integer", "The maximum number of activities."),
+			queryParam("cursor", "string",
+				"The nextCursor of the previous page of activity."),
+		},
+		response: ActivityGetResponse{},
+	},
+	{
+		method: "GET", path: "/api/v2/drafts/{id}/related-resources",
+		id: "getDraftRelatedResources", tag: "drafts",
+		summary:  "Get the related resources of a draft",
+		response: relatedResourcesGetResponse{},
+	},
+	{
+		method: "PUT", path: "/api/v2/drafts/{id}/related-resources",
+		id: "updateDraftRelatedResources", tag: "drafts",
+		summary: "Replace the related resources of a draft",
+		request: relatedResourcesPutRequest{},
+	},
+	{
+		method: "GET", path: "/api/v2/drafts/{id}/shareable",
+		id: "getDraftShareable", tag: "drafts",
+		summary:  "Get whether a draft is shareable",
+		response: draftsShareableGetResponse{},
+	},
+	{
+		method: "PUT", path: "/api/v2/drafts/{id}/shareable",
+		id: "updateDraftShareable", tag: "drafts",
+		summary: "Set whether a draft is shareable",
+		request: draftsShareablePutRequest{}, response: draftsShareableGetResponse{},
+	},
+
+	// Edge document sync.
+	{
+		method: "POST", path: "/api/v2/edge/sync",
+		id: "syncEdge", tag: "edge",
+		summary: "Sync a batch of an edge instance's documents",
+		request: EdgeSyncRequest{}, response: services.EdgeSyncResponse{},
+	},
+	{
+		method: "GET", path: "/api/v2/edge/conflicts",
+		id: "listEdgeConflicts", tag: "edge",
+		summary: "List conflicting changes of edge documents",
+		query: []openapi.Parameter{
+			queryParam("edge_instance", "string", "The edge instance."),
+			queryParam("status", "string",
+				"The conflict status (open or resolved)."),
+			queryParam("limit", "integer", "The maximum number of conflicts."),
+		},
+		response: EdgeConflictsResponse{},
+	},
+	{
+		method: "GET", path: "/api/v2/edge/conflicts/{id}",
+		id: "getEdgeConflict", tag: "edge",
+		summary:  "Get a conflicting change of an edge document",
+		response: services.EdgeDocumentConflict{},
+	},
+	{
+		method: "POST", path: "/api/v2/edge/conflicts/{id}/resolve",
+		id: "resolveEdgeConflict", tag: "edge",
+		summary:  "Resolve a conflicting change of an edge document",
+		request:  EdgeConflictResolveRequest{},
+		response: services.EdgeDocumentRecord{},
+	},
+	{
+		method: "GET", path: "/api/v2/edge/events",
+		id: "listEdgeEvents", tag: "edge",
+		summary: "List or long-poll the events of an edge instance",
+		query: []openapi.Parameter{
+			queryParam("edge_instance", "string", "The edge instance."),
+			queryParam("after", "integer",
+				"Only return events after this event ID."),
+			queryParam("wait", "string",
+				"Wait up to this duration (e.g., 30s, at most 1m) for events."),
+			queryParam("limit", "integer", "The maximum number of events."),
+		},
+		response: EdgeEventsResponse{},
+	},
+	{
+		method: "POST", path: "/api/v2/edge/heartbeat",
+		id: "sendEdgeHeartbeat", tag: "edge",
+		summary:  "Report the state of an edge instance",
+		request:  EdgeHeartbeatRequest{},
+		response: EdgeHeartbeatResponse{},
+	},
+	{
+		method: "POST", path: "/api/v2/edge/documents/register",
+		id: "registerEdgeDocument", tag: "edge",
+		summary:  "Register a document from an edge instance",
+		request:  RegisterDocumentRequest{},
+		response: services.EdgeDocumentRecord{},
+	},
+	{
+		method: "GET", path: "/api/v2/edge/documents/search",
+		id: "searchEdgeDocuments", tag: "edge",
+		summary: "Search documents registered by edge instances",
+		query: []openapi.Parameter{
+			queryParam("q", "string", "The search query."),
+			queryParam("limit", "integer", "The maximum number of documents."),
+			queryParam("document_type", "string",
+				"Only return documents of this type."),
+			queryParam("status", "string",
+				"Only return documents with this status."),
+			queryParam("product", "string",
+				"Only return documents of this product."),
+			queryParam("edge_instance", "string",
+				"Only return documents of this edge instance."),
+		},
+		response: EdgeSearchResponse{},
+	},
+	{
+		method: "GET", path: "/api/v2/edge/documents/sync-status",
+		id: "getEdgeSyncStatus", tag: "edge",
+		summary: "Get the sync status of an edge instance's documents",
+		query: []openapi.Parameter{
+			queryParam("edge_instance", "string", "The edge instance."),
+			queryParam("limit", "integer", "The maximum number of documents."),
+			queryParam("product", "string",
+				"Only include documents of these comma-separated products."),
+			queryParam("document_type", "string",
+				"Only include documents of these comma-separated document types."),
+			queryParam("project", "string",
+				"Only include documents of these comma-separated projects."),
+		},
+		response: SyncStatusResponse{},
+	},
+	{
+		method: "GET", path: "/api/v2/edge/documents/{uuid}",
+		id: "getEdgeDocument", tag: "edge",
+		summary:  "Get a document registered by an edge instance",
+		response: services.EdgeDocumentRecord{},
+	},
+	{
+		method: "DELETE", path: "/api/v2/edge/documents/{uuid}",
+		id: "deleteEdgeDocument", tag: "edge",
+		summary: "Delete a document registered by an edge instance",
+		status:  http.StatusNoContent,
+	},
+	{
+		method: "PUT", path: "/api/v2/edge/documents/{uuid}/sync",
+		id: "syncEdgeDocument", tag: "edge",
+		summary: "Update the metadata of a document from an edge instance",
+		request: SyncMetadataRequest{}, response: services.EdgeDocumentRecord{},
+	},
+	{
+		method: "GET", path: "/api/v2/edge/stats", id: "getEdgeStats",
+		tag: "edge", summary: "Get the document statistics of edge instances",
+		query: []openapi.Parameter{
+			queryParam("edge_instance", "string",
+				"Only count documents of this edge instance."),
+		},
+		response: map[string]any{},
+	},
+
+	// Document events.
+	{
+		method: "GET", path: "/api/v2/events",
+		id: "streamDocumentEvents", tag: "documents",
+		summary: "Stream document lifecycle events as server-sent events",
+		query: []openapi.Parameter{
+			queryParam("product", "string",
+				"Only stream events of documents of this product."),
+			queryParam("subscriptions", "boolean",
+				"Only stream events of products the user is subscribed to."),
+			queryParam("lastEventId", "string",
+				"Stream events after this event ID, if the Last-Event-ID header isn't set."),
+		},
+		response: "", produces: "text/event-stream",
+	},
+
+	// Groups and people.
+	{
+		method: "POST", path: "/api/v2/groups", id: "searchGroups",
+		tag: "people", summary: "Search groups",
+		request: GroupsPostRequest{}, response: GroupsPostResponse{},
+	},
+	{
+		method: "GET", path: "/api/v2/people", id: "getPeople",
+		tag: "people", summary: "Get people by email address",
+		query: []openapi.Parameter{
+			queryParam("emails", "string",
+				"Comma-separated email addresses of the people."),
+		},
+		response: []*workspace.UserIdentity{},
+	},
+	{
+		method: "POST", path: "/api/v2/people", id: "searchPeople",
+		tag: "people", summary: "Search people",
+		request: PeopleDataRequest{}, response: rawJSON{},
+	},
+	{
+		method: "GET", path: "/api/v2/people/{email}/photo",
+		id: "getPersonPhoto", tag: "people",
+		summary: "Get the resized profile photo of a person",
+		query: []openapi.Parameter{
+			queryParam("size", "integer",
+				"The width and height to fit the photo in, in pixels."),
+		},
+		response: "", produces: "image/jpeg",
+	},
+
+	// Indexer.
+	{
+		method: "POST", path: "/api/v2/indexer/register", id: "registerIndexer",
+		tag: "indexer", summary: "Register an indexer",
+		request: IndexerRegisterRequest{}, response: IndexerRegisterResponse{},
+	},
+	{
+		method: "POST", path: "/api/v2/indexer/heartbeat",
+		id: "sendIndexerHeartbeat", tag: "indexer",
+		summary: "Report that an indexer is alive",
+		request: IndexerHeartbeatRequest{}, response: IndexerHeartbeatResponse{},
+	},
+
+	// Jira.
+	{
+		method: "GET", path: "/api/v2/jira/issue/picker",
+		id: "searchJiraIssues", tag: "jira", summary: "Search Jira issues",
+		query: []openapi.Parameter{
+			queryParam("query", "string", "The search query."),
+		},
+		response: JiraIssuePickerGetResponse{},
+	},
+	{
+		method: "GET", path: "/api/v2/jira/issues/{key}", id: "getJiraIssue",
+		tag: "jira", summary: "Get a Jira issue",
+		response: JiraIssueGetResponse{},
+	},
+
+	// Current user.
+	{
+		method: "GET", path: "/api/v2/me", id: "getMe", tag: "me",
+		summary: "Get the current user's profile", response: MeGetResponse{},
+	},
+	{
+		method: "PATCH", path: "/api/v2/me", id: "updateMe", tag: "me",
+		summary: "Update the current user's preferences",
+		request: MePatchRequest{}, status: http.StatusNoContent,
+	},
+	{
+		method: "GET", path: "/api/v2/me/activity",
+		id: "listMyActivity", tag: "me",
+		summary: "List the current user's activity on documents",
+		query: []openapi.Parameter{
+			queryParam("action", "string",
+				"Only return activity of this action (view, edit, or review)."),
+			queryParam("limit", "integer", "The maximum number of activities."),
+			queryParam("cursor", "string",
+				"The nextCursor of the previous page of activity."),
+		},
+		response: ActivityGetResponse{},
+	},
+	{
+		method: "GET", path: "/api/v2/me/broken-links",
+		id: "listMyBrokenLinks", tag: "me",
+		summary:  "List the current user's documents with broken links",
+		response: []MeBrokenLinksDocument{},
+	},
+	{
+		method: "GET", path: "/api/v2/me/recently-viewed-docs",
+		id: "listRecentlyViewedDocs", tag: "me",
+		summary:  "List the current user's recently viewed documents",
+		response: []recentlyViewedDoc{},
+	},
+	{
+		method: "GET", path: "/api/v2/me/recently-viewed-projects",
+		id: "listRecentlyViewedProjects", tag: "me",
+		summary:  "List the current user's recently viewed projects",
+		response: []recentlyViewedProject{},
+	},
+	{
+		method: "GET", path: "/api/v2/me/review-delegations",
+		id: "listReviewDelegations", tag: "me",
+		summary:  "List the current user's review delegations",
+		response: []ReviewDelegation{},
+	},
+	{
+		method: "POST", path: "/api/v2/me/review-delegations",
+		id: "createReviewDelegation", tag: "me",
+		summary:  "Delegate the current user's reviews",
+		request:  ReviewDelegationsPostRequest{},
+		response: ReviewDelegation{}, status: http.StatusCreated,
+	},
+	{
+		method: "DELETE", path: "/api/v2/me/review-delegations/{id}",
+		id: "deleteReviewDelegation", tag: "me",
+		summary: "Delete a review delegation", status: http.StatusNoContent,
+	},
+	{
+		method: "GET", path: "/api/v2/me/reviews", id: "listMyReviews",
+		tag: "me", summary: "List the current user's pending reviews",
+		response: MeReviewsGetResponse{},
+	},
+	{
+		method: "GET", path: "/api/v2/me/saved-searches",
+		id: "listSavedSearches", tag: "me",
+		summary:  "List the searches saved by or shared with the current user",
+		response: []SavedSearch{},
+	},
+	{
+		method: "POST", path: "/api/v2/me/saved-searches",
+		id: "createSavedSearch", tag: "me",
+		summary:  "Save a search",
+		request:  SavedSearchesPostRequest{},
+		response: SavedSearch{}, status: http.StatusCreated,
+	},
+	{
+		method: "GET", path: "/api/v2/me/saved-searches/{id}",
+		id: "getSavedSearch", tag: "me",
+		summary:  "Get a saved search",
+		response: SavedSearch{},
+	},
+	{
+		method: "PATCH", path: "/api/v2/me/saved-searches/{id}",
+		id: "updateSavedSearch", tag: "me",
+		summary:  "Update a saved search",
+		request:  SavedSearchPatchRequest{},
+		response: SavedSearch{},
+	},
+	{
+		method: "DELETE", path: "/api/v2/me/saved-searches/{id}",
+		id: "deleteSavedSearch", tag: "me",
+		summary: "Delete a saved search", status: http.StatusNoContent,
+	},
+	{
+		method: "PUT", path: "/api/v2/me/saved-searches/{id}/subscription",
+		id: "subscribeToSavedSearch", tag: "me",
+		summary: "Subscribe to digests of new documents that match a saved search",
+		status:  http.StatusNoContent,
+	},
+	{
+		method: "DELETE", path: "/api/v2/me/saved-searches/{id}/subscription",
+		id: "unsubscribeFromSavedSearch", tag: "me",
+		summary: "Unsubscribe from digests of a saved search",
+		status:  http.StatusNoContent,
+	},
+	{
+		method: "GET", path: "/api/v2/me/subscriptions", id: "getSubscriptions",
+		tag: "me", summary: "Get the current user's product subscriptions",
+		response: []string{},
+	},
+	{
+		method: "POST", path: "/api/v2/me/subscriptions",
+		id: "updateSubscriptions", tag: "me",
+		summary: "Set the current user's product subscriptions",
+		request: MeSubscriptionsPostRequest{},
+	},
+
+	// Migrations.
+	{
+		method: "GET", path: "/api/v2/migrations/documents/{uuid}",
+		id: "getDocumentMigrationHistory", tag: "migrations",
+		summary: "Get the migration history of a document", response: rawJSON{},
+	},
+	{
+		method: "GET", path: "/api/v2/migrations/items/{id}/audit",
+		id: "getMigrationItemAudit", tag: "migrations",
+		summary: "Get the audit log of a migration item", response: rawJSON{},
+	},
+	{
+		method: "POST", path: "/api/v2/migrations/items/{id}/rollback",
+		id: "rollbackMigrationItem", tag: "migrations",
+		summary:  "Roll back a migrated document",
+		response: rawJSON{}, status: http.StatusAccepted,
+	},
+	{
+		method: "GET", path: "/api/v2/migrations/jobs", id: "listMigrationJobs",
+		tag: "migrations", summary: "List migration jobs",
+		query: []openapi.Parameter{
+			queryParam("status", "string", "Only list jobs with this status."),
+			queryParam("limit", "integer", "The maximum number of jobs."),
+		},
+		response: rawJSON{},
+	},
+	{
+		method: "POST", path: "/api/v2/migrations/jobs",
+		id: "createMigrationJob", tag: "migrations",
+		summary:  "Create a migration job",
+		request:  CreateMigrationJobRequest{},
+		response: migration.Job{}, status: http.StatusCreated,
+	},
+	{
+		method: "GET", path: "/api/v2/migrations/jobs/{id}",
+		id: "getMigrationJob", tag: "migrations",
+		summary: "Get a migration job", response: migration.Job{},
+	},
+	{
+		method: "DELETE", path: "/api/v2/migrations/jobs/{id}",
+		id: "deleteMigrationJob", tag: "migrations",
+		summary: "Cancel a migration job", response: rawJSON{},
+	},
+	{
+		method: "POST", path: "/api/v2/migrations/jobs/{id}/cancel",
+		id: "cancelMigrationJob", tag: "migrations",
+		summary: "Cancel a migration job", response: rawJSON{},
+	},
+	{
+		method: "GET", path: "/api/v2/migrations/jobs/{id}/items",
+		id: "listMigrationItems", tag: "migrations",
+		summary: "List the items of a migration job",
+		query: []openapi.Parameter{
+			queryParam("status", "string", "Only list items with this status."),
+			queryParam("limit", "integer", "The maximum number of items."),
+		},
+		response: rawJSON{},
+	},
+	{
+		method: "POST", path: "/api/v2/migrations/jobs/{id}/pause",
+		id: "pauseMigrationJob", tag: "migrations",
+		summary: "Pause a migration job", response: migration.Job{},
+	},
+	{
+		method: "GET", path: "/api/v2/migrations/jobs/{id}/progress",
+		id: "getMigrationProgress", tag: "migrations",
+		summary:  "Get the progress of a migration job",
+		response: migration.Progress{},
+	},
+	{
+		method: "POST", path: "/api/v2/migrations/jobs/{id}/start",
+		id: "startMigrationJob", tag: "migrations",
+		summary: "Start a migration job", response: migration.Job{},
+	},
+
+	// Products.
+	{
+		method: "GET", path: "/api/v2/products", id: "listProducts",
+		tag: "products", summary: "List products", response: rawJSON{},
+	},
+
+	// Projects.
+	{
+		method: "GET", path: "/api/v2/projects", id: "listProjects",
+		tag: "projects", summary: "List projects",
+		query: []openapi.Parameter{
+			queryParam("hitsPerPage", "integer",
+				"The number of projects per page."),
+			queryParam("page", "integer", "The page number."),
+			queryParam("status", "string",
+				"Only list projects with this status."),
+			queryParam("title", "string",
+				"Only list projects with this title."),
+		},
+		response: ProjectsGetResponse{},
+	},
+	{
+		method: "POST", path: "/api/v2/projects", id: "createProject",
+		tag: "projects", summary: "Create a project",
+		request: ProjectsPostRequest{}, response: ProjectsPostResponse{},
+	},
+	{
+		method: "GET", path: "/api/v2/projects/{id}", id: "getProject",
+		tag: "projects", summary: "Get a project",
+		response: ProjectGetResponse{},
+	},
+	{
+		method: "PATCH", path: "/api/v2/projects/{id}", id: "updateProject",
+		tag: "projects", summary: "Update a project",
+		request: ProjectPatchRequest{},
+	},
+	{
+		method: "DELETE", path: "/api/v2/projects/{id}", id: "deleteProject",
+		tag: "projects", summary: "Delete a project",
+		status: http.StatusNoContent,
+	},
+	{
+		method: "GET", path: "/api/v2/projects/{id}/related-resources",
+		id: "getProjectRelatedResources", tag: "projects",
+		summary:  "Get the related resources of a project",
+		response: ProjectRelatedResourcesGetResponse{},
+	},
+	{
+		method: "PUT", path: "/api/v2/projects/{id}/related-resources",
+		id: "updateProjectRelatedResources", tag: "projects",
+		summary: "Replace the related resources of a project",
+		request: ProjectRelatedResourcesPutRequest{},
+	},
+
+	// Storage providers.
+	{
+		method: "GET", path: "/api/v2/providers", id: "listProviders",
+		tag: "providers", summary: "List storage providers",
+		query: []openapi.Parameter{
+			queryParam("type", "string",
+				"Only list providers of this type."),
+			queryParam("status", "string",
+				"Only list providers with this status."),
+		},
+		response: rawJSON{},
+	},
+	{
+		method: "POST", path: "/api/v2/providers", id: "registerProvider",
+		tag: "providers", summary: "Register a storage provider",
+		request:  RegisterProviderRequest{},
+		response: rawJSON{}, status: http.StatusCreated,
+	},
+	{
+		method: "GET", path: "/api/v2/providers/{id}", id: "getProvider",
+		tag: "providers", summary: "Get a storage provider",
+		response: rawJSON{},
+	},
+	{
+		method: "PATCH", path: "/api/v2/providers/{id}", id: "updateProvider",
+		tag: "providers", summary: "Update a storage provider",
+		request: UpdateProviderRequest{}, response: rawJSON{},
+	},
+	{
+		method: "DELETE", path: "/api/v2/providers/{id}", id: "removeProvider",
+		tag: "providers", summary: "Remove a storage provider",
+		response: rawJSON{},
+	},
+	{
+		method: "GET", path: "/api/v2/providers/{id}/health",
+		id: "getProviderHealth", tag: "providers",
+		summary: "Check the health of a storage provider", response: rawJSON{},
+	},
+
+	// Search.
+	{
+		method: "POST", path: "/api/v2/search/hybrid", id: "hybridSearch",
+		tag: "search", summary: "Search documents by keywords and meaning",
+		request: HybridSearchRequest{}, response: HybridSearchResponse{},
+	},
+	{
+		method: "POST", path: "/api/v2/search/semantic", id: "semanticSearch",
+		tag: "search", summary: "Search documents by meaning",
+		request: SemanticSearchRequest{}, response: SemanticSearchResponse{},
+	},
+	{
+		method: "POST", path: "/api/v2/search/{index}", id: "search",
+		tag: "search", summary: "Search an index",
+		request: SearchRequest{}, response: search.SearchResult{},
+	},
+
+	// Setup.
+	{
+		method: "POST", path: "/api/v2/setup/configure", id: "configureSetup",
+		tag: "setup", summary: "Write the initial configuration",
+		request: SetupConfigRequest{}, response: SetupConfigResponse{},
+	},
+	{
+		method: "GET", path: "/api/v2/setup/status", id: "getSetupStatus",
+		tag: "setup", summary: "Get whether Hermes is configured",
+		response: SetupStatusResponse{},
+	},
+	{
+		method: "POST", path: "/api/v2/setup/validate-ollama",
+		id: "validateOllama", tag: "setup",
+		summary: "Check the connection to an Ollama server",
+		request: OllamaValidationRequest{}, response: OllamaValidationResponse{},
+	},
+
+	// Shared drafts.
+	{
+		method: "GET", path: "/api/v2/shared-drafts/{token}",
+		id: "getSharedDraft", tag: "drafts",
+		summary:  "Get a draft with a share link",
+		response: SharedDraftGetResponse{},
+	},
+
+	// Web app.
+	{
+		method: "POST", path: "/api/v2/web/analytics", id: "recordAnalytics",
+		tag: "web", summary: "Record an analytics event",
+		request: AnalyticsRequest{}, response: AnalyticsResponse{},
+	},
+	{
+		method: "GET", path: "/api/v2/web/config", id: "getWebConfig",
+		tag: "web", summary: "Get the web app configuration",
+		response: rawJSON{},
+	},
+
+	// Workers.
+	{
+		method: "POST", path: "/api/v2/workers/heartbeat",
+		id: "sendWorkerHeartbeat", tag: "workers",
+		summary: "Report the status of a pipeline worker",
+		request: WorkerHeartbeatRequest{}, response: WorkerHeartbeatResponse{},
+	},
+
+	// Workspace projects.
+	{
+		method: "GET", path: "/api/v2/workspace-projects",
+		id: "listWorkspaceProjects", tag: "projects",
+		summary:  "List workspace projects",
+		response: WorkspaceProjectsGetResponse{},
+	},
+	{
+		method: "GET", path: "/api/v2/workspace-projects/{name}",
+		id: "getWorkspaceProject", tag: "projects",
+		summary: "Get a workspace project", response: rawJSON{},
+	},
+}
+
+var (
+	openAPIDocumentOnce sync.Once
+	openAPIDocument     *openapi.Document
+)
+
+// OpenAPIDocument returns the OpenAPI document of the API.
+func OpenAPIDocument() *openapi.Document {
+	openAPIDocumentOnce.Do(func() {
+		openAPIDocument = buildOpenAPIDocument(apiOperations)
+	})
+	return openAPIDocument
+}
+
+// openAPIPathParamRE matches the parameters of an operation path.
+var openAPIPathParamRE = regexp.MustCompile(`\{([^}]+)\}`)
+
+// buildOpenAPIDocument builds the OpenAPI document for ops.
+func buildOpenAPIDocument(ops []apiOperation) *openapi.Document {
+	gen := openapi.NewGenerator(validateFieldHook)
+	problem := &openapi.Response{
+		Description: "Error",
+		Content: map[string]openapi.MediaType{
+			ProblemContentType: {
+				Schema: gen.Schema(reflect.TypeOf(ProblemDetails{})),
+			},
+		},
+	}
+
+	doc := &openapi.Document{
+		OpenAPI: openapi.Version,
+		Info: openapi.Info{
+			Title:   "Hermes API",
+			Version: "2",
+		},
+		Paths: map[string]openapi.PathItem{},
+		Components: openapi.Components{
+			Schemas: gen.Schemas,
+			SecuritySchemes: map[string]openapi.SecurityScheme{
+				"bearerAuth": {Type: "http", Scheme: "bearer"},
+			},
+		},
+		Security: []map[string][]string{{"bearerAuth": {}}},
+	}
+
+	for _, op := range ops {
+		operation := &openapi.Operation{
+			OperationID: op.id,
+			Summary:     op.summary,
+			Tags:        []string{op.tag},
+			Responses:   map[string]*openapi.Response{"default": problem},
+		}
+
+		for _, m := range openAPIPathParamRE.FindAllStringSubmatch(op.path, -1) {
+			operation.Parameters = append(operation.Parameters,
+				openapi.Parameter{
+					Name:     m[1],
+					In:       "path",
+					Required: true,
+					Schema:   &openapi.Schema{Type: "string"},
+				})
+		}
+		operation.Parameters = append(operation.Parameters, op.query...)
+
+		if op.request != nil {
+			operation.RequestBody = &openapi.RequestBody{
+				Required: true,
+				Content: map[string]openapi.MediaType{
+					"application/json": {
+						Schema: gen.Schema(reflect.TypeOf(op.request)),
+					},
+				},
+			}
+		}
+
+		status := op.status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		resp := &openapi.Response{Description: http.StatusText(status)}
+		if op.response != nil {
+			var s *openapi.Schema
+			if _, ok := op.response.(rawJSON); ok {
+				s = &openapi.Schema{}
+			} else {
+				s = gen.Schema(reflect.TypeOf(op.response))
+			}
+			produces := op.produces
+			if produces == "" {
+				produces = "application/json"
+			}
+			resp.Content = map[string]openapi.MediaType{
+				produces: {Schema: s},
+			}
+		}
+		operation.Responses[strconv.Itoa(status)] = resp
+
+		for code, description := range op.errors {
+			operation.Responses[strconv.Itoa(code)] = &openapi.Response{
+				Description: description,
+				Content:     problem.Content,
+			}
+		}
+
+		item, ok := doc.Paths[op.path]
+		if !ok {
+			item = openapi.PathItem{}
+			doc.Paths[op.path] = item
+		}
+		item[strings.ToLower(op.method)] = operation
+	}
+
+	return doc
+}
+
+// validateFieldHook adds the constraints of a struct field's "validate" tag to
+// its schema.
+func validateFieldHook(f reflect.StructField, s *openapi.Schema) bool {
+	required := false
+	for _, r := range parseValidateTag(f.Tag.Get("validate")) {
+		if r.name == "required" {
+			required = true
+		}
+		applyValidationRule(s, r)
+	}
+	return required
+}
+
+// applyValidationRule adds the constraint of validation rule r to schema s.
+// Rules for string values of string slices are applied to the item schema.
+func applyValidationRule(s *openapi.Schema, r validationRule) {
+	typ, _ := s.BaseType()
+
+	switch r.name {
+	case "required":
+		one := 1
+		if typ == "string" {
+			s.MinLength = &one
+		} else if typ == "array" {
+			s.MinItems = &one
+		}
+
+	case "min", "max", "len":
+		n, err := strconv.Atoi(r.param)
+		if err != nil {
+			return
+		}
+		var minField, maxField **int
+		switch typ {
+		case "string":
+			minField, maxField = &s.MinLength, &s.MaxLength
+		case "array":
+			minField, maxField = &s.MinItems, &s.MaxItems
+		default:
+			return
+		}
+		if r.name != "max" {
+			*minField = &n
+		}
+		if r.name != "min" {
+			*maxField = &n
+		}
+
+	case "oneof":
+		s.ValueSchema().Enum = strings.Fields(r.param)
+
+	case "email":
+		s.ValueSchema().Format = "email"
+	}
+}
+
+// OpenAPIHandler serves the OpenAPI document of the API.
+func OpenAPIHandler(srv server.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
+		switch r.Method {
+		case "GET":
+			w.Header().Set("Content-Type", "application/json")
+			if err := json.NewEncoder(w).Encode(OpenAPIDocument()); err != nil {
+				srv.Logger.Error("error encoding OpenAPI document",
+					"error", err,
+					"method", r.Method,
+					"path", r.URL.Path,
+				)
+				return
+			}
+
+		default:
+			writeProblem(w, r, http.StatusMethodNotAllowed,
+				ErrCodeMethodNotAllowed, "Method not allowed")
+			return
+		}
+	})
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/internal/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAPIDocument(t *testing.T) {
+	assert := assert.New(t)
+
+	// Round-trip through JSON to compare with what clients receive.
+	b, err := json.Marshal(OpenAPIDocument())
+	require.NoError(t, err)
+	var doc struct {
+		Paths      map[string]map[string]any `json:"paths"`
+		Components struct {
+			Schemas map[string]map[string]any `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(b, &doc))
+
+	assert.Contains(doc.Paths["/api/v2/drafts"], "post")
+	assert.Contains(doc.Paths["/api/v2/drafts/{id}"], "patch")
+	assert.Contains(doc.Paths["/api/v2/documents/{id}"], "patch")
+	assert.Contains(doc.Paths["/api/v2/edge/documents/register"], "post")
+	assert.Contains(doc.Paths["/api/v2/migrations/jobs/{id}"], "get")
+	assert.Contains(doc.Components.Schemas, "ProblemDetails")
+
+	// Unchecked required checklist items are conflicts wherever documents
+	// change status.
+	for path, method := range map[string]string{
+		"/api/v2/documents/{id}": "patch",
+		"/api/v2/reviews/{id}":   "post",
+	} {
+		responses := doc.Paths[path][method].(map[string]any)["responses"]
+		assert.Contains(responses, "409", path)
+	}
+
+	drafts := doc.Components.Schemas["DraftsRequest"]
+	assert.ElementsMatch([]any{"docType", "title"}, drafts["required"])
+	props := drafts["properties"].(map[string]any)
+	assert.Equal(map[string]any{
+		"type": "string", "minLength": 1.0, "x-go-name": "Title",
+	},
+		props["title"])
+	assert.Equal(map[string]any{
+		"type":      "array",
+		"items":     map[string]any{"type": "string", "format": "email"},
+		"x-go-name": "Contributors",
+	}, props["contributors"])
+
+	patch := doc.Components.Schemas["DocumentPatchRequest"]
+	assert.NotContains(patch, "required")
+	props = patch["properties"].(map[string]any)
+	assert.Equal(map[string]any{
+		"type":      []any{"array", "null"},
+		"items":     map[string]any{"type": "string", "format": "email"},
+		"minItems":  1.0,
+		"maxItems":  1.0,
+		"x-go-name": "Owners",
+	}, props["owners"])
+	assert.Equal(map[string]any{
+		"type":      []any{"string", "null"},
+		"enum":      []any{"Approved", "In-Review", "Obsolete"},
+		"x-go-name": "Status",
+	}, props["status"])
+}
+
+func TestGeneratedAPIFilesUpToDate(t *testing.T) {
+	doc := OpenAPIDocument()
+
+	tests := map[string]func() ([]byte, error){
+		"../../../docs/api/openapi.json": func() ([]byte, error) {
+			return openapi.JSON(doc)
+		},
+		"../../../pkg/apiclient/client.gen.go": func() ([]byte, error) {
+			return openapi.GoClient(doc, "apiclient")
+		},
+		"../../../web/app/utils/api-client.ts": func() ([]byte, error) {
+			return openapi.TypeScriptClient(doc)
+		},
+	}
+	for path, generate := range tests {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			want, err := generate()
+			require.NoError(t, err)
+			got, err := os.ReadFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, string(want), string(got),
+				`generated file is out of date; run "make generate-api"`)
+		})
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"strings"
 
 	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/pkg/directorysync"
+	"github.com/hashicorp-forge/hermes/pkg/models"
 	"github.com/hashicorp-forge/hermes/pkg/workspace"
 )
 
@@ -20,6 +22,7 @@ type PeopleDataRequest struct {
 // to the Hermes frontend.
 func PeopleDataHandler(srv server.Server) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
 		req := &PeopleDataRequest{}
 		switch r.Method {
 		// Using POST method to avoid logging the query in browser history
@@ -27,21 +30,19 @@ func PeopleDataHandler(srv server.Server) http.Handler {
 		case "POST":
 			if err := decodeRequest(r, &req); err != nil {
 				srv.Logger.Error("error decoding people request", "error", err)
-				http.Error(w, fmt.Sprintf("Bad request: %q", err),
-					http.StatusBadRequest)
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					fmt.Sprintf("Bad request: %q", err))
 				return
 			}
 
-			users, err := srv.WorkspaceProvider.SearchPeople(
-				r.Context(),
-				req.Query,
-			)
+			users, err := searchPeople(r, srv, req.Query)
 			if err != nil {
 				srv.Logger.Error("error searching people directory", "error", err)
-				http.Error(w, fmt.Sprintf("Error searching people directory: %q", err),
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					fmt.Sprintf("Error searching people directory: %q", err))
 				return
 			}
+			users = proxyPhotoURLs(users)
 
 			// Write response.
 			w.Header().Set("Content-Type", "application/json")
@@ -51,8 +52,8 @@ func PeopleDataHandler(srv server.Server) http.Handler {
 			err = enc.Encode(users)
 			if err != nil {
 				srv.Logger.Error("error encoding people response", "error", err)
-				http.Error(w, "Error searching people directory",
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error searching people directory")
 				return
 			}
 		case "GET":
@@ -60,14 +61,19 @@ func PeopleDataHandler(srv server.Server) http.Handler {
 			if len(query["emails"]) != 1 {
 				srv.Logger.Error(
 					"attempted to get users without providing any email addresses")
-				http.Error(w,
-					"Attempted to get users without providing a single value for the emails query parameter.",
-					http.StatusBadRequest)
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					"Attempted to get users without providing a single value for the emails query parameter.")
 			} else {
 				emails := strings.Split(query["emails"][0], ",")
+				cached := getCachedPeople(r, srv, emails)
 				var people []*workspace.UserIdentity
 
 				for _, email := range emails {
+					if p, ok := cached[strings.ToLower(strings.TrimSpace(email))]; ok {
+						people = append(people, p)
+						continue
+					}
+
 					result, err := srv.WorkspaceProvider.SearchPeople(
 						r.Context(),
 						email,
@@ -78,7 +84,10 @@ func PeopleDataHandler(srv server.Server) http.Handler {
 					} else {
 						srv.Logger.Warn("Email lookup miss", "error", err)
 					}
-				} // Write response.
+				}
+				people = proxyPhotoURLs(people)
+
+				// Write response.
 				w.Header().Set("Content-Type", "application/json")
 				w.WriteHeader(http.StatusOK)
 
@@ -86,14 +95,72 @@ func PeopleDataHandler(srv server.Server) http.Handler {
 				err := enc.Encode(people)
 				if err != nil {
 					srv.Logger.Error("error encoding people response", "error", err)
-					http.Error(w, "Error getting people responses",
-						http.StatusInternalServerError)
+					writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error getting people responses")
 					return
 				}
 			}
 		default:
-			w.WriteHeader(http.StatusMethodNotAllowed)
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
 			return
 		}
 	})
 }
+
+// peopleDirectorySearchLimit is the maximum number of people returned from the
+// people directory cache for a search.
+const peopleDirectorySearchLimit = 50
+
+// peopleDirectoryEnabled returns true if the people directory cache is
+// enabled.
+func peopleDirectoryEnabled(srv server.Server) bool {
+	return srv.DB != nil && srv.Config != nil &&
+		srv.Config.PeopleDirectory != nil && srv.Config.PeopleDirectory.Enabled
+}
+
+// searchPeople searches the people directory cache, falling back to the
+// workspace provider if the cache is disabled, fails, or has no matches.
+func searchPeople(
+	r *http.Request, srv server.Server, query string,
+) ([]*workspace.UserIdentity, error) {
+	if peopleDirectoryEnabled(srv) {
+		var entries models.UserDirectoryEntries
+		if err := entries.Search(
+			srv.DB.WithContext(r.Context()), query, peopleDirectorySearchLimit,
+		); err != nil {
+			srv.Logger.Warn("error searching people directory cache", "error", err)
+		} else if len(entries) > 0 {
+			users := make([]*workspace.UserIdentity, len(entries))
+			for i, e := range entries {
+				users[i] = directorysync.UserIdentity(e)
+			}
+			return users, nil
+		}
+	}
+
+	return srv.WorkspaceProvider.SearchPeople(r.Context(), query)
+}
+
+// getCachedPeople returns the people with the provided email addresses that
+// are in the people directory cache, keyed by lowercased email address.
+func getCachedPeople(
+	r *http.Request, srv server.Server, emails []string,
+) map[string]*workspace.UserIdentity {
+	people := make(map[string]*workspace.UserIdentity)
+	if !peopleDirectoryEnabled(srv) {
+		return people
+	}
+
+	var entries models.UserDirectoryEntries
+	if err := entries.FindByEmails(
+		srv.DB.WithContext(r.Context()), emails,
+	); err != nil {
+		srv.Logger.Warn("error getting people from directory cache", "error", err)
+		return people
+	}
+	for _, e := range entries {
+		people[e.EmailAddress] = directorysync.UserIdentity(e)
+	}
+	return people
+}
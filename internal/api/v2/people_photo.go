@@ -0,0 +1,143 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/pkg/avatar"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp-forge/hermes/pkg/workspace"
+)
+
+var personPhotoURLPathRE = regexp.MustCompile(`^/api/v2/people/([^/]+)/photo$`)
+
+// PersonPhotoHandler serves the profile photos of people
+// (GET /api/v2/people/:email/photo?size=96) from the photo proxy, so viewers
+// don't load them from the workspace provider. The size query parameter is the
+// width and height the photo is resized to fit in, in pixels. People without a
+// photo respond with 404 Not Found, so the web app can show their initials.
+func PersonPhotoHandler(srv server.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
+		if r.Method != "GET" {
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
+			return
+		}
+
+		matches := personPhotoURLPathRE.FindStringSubmatch(r.URL.Path)
+		if matches == nil {
+			writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound, "Not found")
+			return
+		}
+		email := matches[1]
+
+		size := avatar.DefaultSize
+		if s := r.URL.Query().Get("size"); s != "" {
+			n, err := strconv.Atoi(s)
+			if err != nil || n < avatar.MinSize || n > avatar.MaxSize {
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					fmt.Sprintf("Bad request: size must be between %d and %d",
+						avatar.MinSize, avatar.MaxSize))
+				return
+			}
+			size = n
+		}
+
+		photos := srv.Photos
+		if photos == nil {
+			photos = avatar.NewProxy(PeoplePhotoURLFunc(srv), nil, srv.Logger, nil)
+		}
+		photo, err := photos.Photo(r.Context(), email, size)
+		if errors.Is(err, avatar.ErrNotFound) {
+			writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound,
+				"Photo not found")
+			return
+		}
+		if err != nil {
+			respondError(w, r, srv.Logger, http.StatusBadGateway,
+				"Error getting photo", "error getting person photo", err,
+				"person", email,
+			)
+			return
+		}
+
+		sum := sha256.Sum256(photo.Data)
+		w.Header().Set("Cache-Control", fmt.Sprintf("private, max-age=%d",
+			int(photos.TTL().Seconds())))
+		if checkIfNoneMatch(w, r, strconv.Quote(hex.EncodeToString(sum[:16]))) {
+			return
+		}
+		w.Header().Set("Content-Type", photo.ContentType)
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.Header().Set("Content-Length", strconv.Itoa(len(photo.Data)))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(photo.Data)
+	})
+}
+
+// PeoplePhotoURLFunc returns a function that looks up the photo URLs of people
+// in the people directory cache, falling back to the workspace provider.
+func PeoplePhotoURLFunc(srv server.Server) avatar.PhotoURLFunc {
+	return func(ctx context.Context, email string) (string, error) {
+		if peopleDirectoryEnabled(srv) {
+			var entries models.UserDirectoryEntries
+			if err := entries.FindByEmails(
+				srv.DB.WithContext(ctx), []string{email},
+			); err != nil {
+				srv.Logger.Warn("error getting person from directory cache",
+					"error", err)
+			} else if len(entries) > 0 {
+				return entries[0].PhotoURL, nil
+			}
+		}
+
+		if srv.WorkspaceProvider == nil {
+			return "", nil
+		}
+		p, err := srv.WorkspaceProvider.GetPerson(ctx, email)
+		if errors.Is(err, workspace.ErrNotFound) {
+			return "", nil
+		}
+		if err != nil {
+			return "", err
+		}
+		if p == nil {
+			return "", nil
+		}
+		return p.PhotoURL, nil
+	}
+}
+
+// personPhotoURL returns the URL of the photo of the person with email address
+// email served by PersonPhotoHandler.
+func personPhotoURL(email string) string {
+	return "/api/v2/people/" + url.PathEscape(email) + "/photo"
+}
+
+// proxyPhotoURLs returns copies of people whose photo URLs are served by
+// PersonPhotoHandler instead of the workspace provider.
+func proxyPhotoURLs(people []*workspace.UserIdentity) []*workspace.UserIdentity {
+	if people == nil {
+		return nil
+	}
+	proxied := make([]*workspace.UserIdentity, 0, len(people))
+	for _, p := range people {
+		if p == nil || p.PhotoURL == "" {
+			proxied = append(proxied, p)
+			continue
+		}
+		c := *p
+		c.PhotoURL = personPhotoURL(p.Email)
+		proxied = append(proxied, &c)
+	}
+	return proxied
+}
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/pkg/avatar"
+	"github.com/hashicorp-forge/hermes/pkg/httpcache"
+	"github.com/hashicorp-forge/hermes/pkg/workspace"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPersonPhotoHandler(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewGray(image.Rect(0, 0, 300, 300))))
+	photos := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Write(buf.Bytes())
+		}))
+	defer photos.Close()
+
+	srv := server.Server{
+		Logger: hclog.NewNullLogger(),
+		Photos: avatar.NewProxy(
+			func(ctx context.Context, email string) (string, error) {
+				if email == "user@example.com" {
+					return photos.URL, nil
+				}
+				return "", nil
+			},
+			httpcache.NewMemoryStore(10), nil, nil),
+	}
+
+	get := func(target, etag string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", target, nil)
+		if etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		w := httptest.NewRecorder()
+		PersonPhotoHandler(srv).ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("/api/v2/people/user@example.com/photo?size=64", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "image/jpeg", w.Header().Get("Content-Type"))
+	assert.Equal(t, "private, max-age=86400", w.Header().Get("Cache-Control"))
+	img, _, err := image.Decode(w.Body)
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 64, 64), img.Bounds())
+
+	etag := w.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+	w = get("/api/v2/people/user@example.com/photo?size=64", etag)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+
+	w = get("/api/v2/people/nophoto@example.com/photo", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = get("/api/v2/people/user@example.com/photo?size=4096", "")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestProxyPhotoURLs(t *testing.T) {
+	people := []*workspace.UserIdentity{
+		{Email: "user@example.com", PhotoURL: "https://lh3.example.com/a"},
+		{Email: "nophoto@example.com"},
+	}
+
+	proxied := proxyPhotoURLs(people)
+	require.Len(t, proxied, 2)
+	assert.Equal(t, "/api/v2/people/user@example.com/photo", proxied[0].PhotoURL)
+	assert.Empty(t, proxied[1].PhotoURL)
+
+	// The people aren't changed, e.g., if they're cached by the provider.
+	assert.Equal(t, "https://lh3.example.com/a", people[0].PhotoURL)
+	assert.Nil(t, proxyPhotoURLs(nil))
+}
@@ -0,0 +1,127 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// ProblemContentType is the media type for RFC 7807 problem details.
+const ProblemContentType = "application/problem+json"
+
+// ErrorCode is a machine-readable error code returned in problem details
+// responses. Clients (including the edge API workspace provider) should branch
+// on the code rather than on the human-readable detail string.
+type ErrorCode string
+
+const (
+	ErrCodeBadRequest          ErrorCode = "bad_request"
+	ErrCodeUnauthorized        ErrorCode = "unauthorized"
+	ErrCodeForbidden           ErrorCode = "forbidden"
+	ErrCodeNotFound            ErrorCode = "not_found"
+	ErrCodeDocumentNotFound    ErrorCode = "document_not_found"
+	ErrCodeDraftNotFound       ErrorCode = "draft_not_found"
+	ErrCodeProjectNotFound     ErrorCode = "project_not_found"
+	ErrCodeProviderNotFound    ErrorCode = "provider_not_found"
+	ErrCodeMethodNotAllowed    ErrorCode = "method_not_allowed"
+	ErrCodeConflict            ErrorCode = "conflict"
+	ErrCodePreconditionFailed  ErrorCode = "precondition_failed"
+	ErrCodeUnprocessable       ErrorCode = "unprocessable_entity"
+	ErrCodeDocumentLocked      ErrorCode = "document_locked"
+	ErrCodeTooManyRequests     ErrorCode = "too_many_requests"
+	ErrCodeInternal            ErrorCode = "internal_error"
+	ErrCodeNotImplemented      ErrorCode = "not_implemented"
+	ErrCodeServiceUnavailable  ErrorCode = "service_unavailable"
+	ErrCodeGatewayTimeout      ErrorCode = "gateway_timeout"
+	ErrCodeUnsupportedProvider ErrorCode = "unsupported_provider"
+)
+
+// ProblemDetails is an RFC 7807 problem details response body.
+type ProblemDetails struct {
+	// Type is a URI reference identifying the problem type.
+	Type string `json:"type"`
+
+	// Title is a short, human-readable summary of the problem type.
+	Title string `json:"title"`
+
+	// Status is the HTTP status code.
+	Status int `json:"status"`
+
+	// Detail is a human-readable explanation specific to this occurrence.
+	Detail string `json:"detail,omitempty"`
+
+	// Instance is the request path that produced the problem.
+	Instance string `json:"instance,omitempty"`
+
+	// Code is the machine-readable error code.
+	Code ErrorCode `json:"code"`
+
+	// Errors are the field errors of an invalid request.
+	Errors []FieldError `json:"errors,omitempty"`
+}
+
+// writeProblem writes an RFC 7807 problem details response. It is the v2
+// replacement for http.Error.
+func writeProblem(
+	w http.ResponseWriter, r *http.Request,
+	status int, code ErrorCode, detail string,
+) {
+	if code == "" {
+		code = errorCodeForStatus(status)
+	}
+
+	p := ProblemDetails{
+		Type:   "urn:hermes:error:" + string(code),
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+		Code:   code,
+	}
+	if r != nil {
+		p.Instance = r.URL.Path
+	}
+	writeProblemDetails(w, p)
+}
+
+// writeProblemDetails writes problem details p as the response.
+func writeProblemDetails(w http.ResponseWriter, p ProblemDetails) {
+	h := w.Header()
+	h.Del("Content-Length")
+	h.Set("Content-Type", ProblemContentType)
+	h.Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(p.Status)
+	_ = json.NewEncoder(w).Encode(p)
+}
+
+// errorCodeForStatus returns the default error code for an HTTP status code.
+func errorCodeForStatus(status int) ErrorCode {
+	switch status {
+	case http.StatusBadRequest:
+		return ErrCodeBadRequest
+	case http.StatusUnauthorized:
+		return ErrCodeUnauthorized
+	case http.StatusForbidden:
+		return ErrCodeForbidden
+	case http.StatusNotFound:
+		return ErrCodeNotFound
+	case http.StatusMethodNotAllowed:
+		return ErrCodeMethodNotAllowed
+	case http.StatusConflict:
+		return ErrCodeConflict
+	case http.StatusPreconditionFailed:
+		return ErrCodePreconditionFailed
+	case http.StatusUnprocessableEntity:
+		return ErrCodeUnprocessable
+	case http.StatusLocked:
+		return ErrCodeDocumentLocked
+	case http.StatusTooManyRequests:
+		return ErrCodeTooManyRequests
+	case http.StatusNotImplemented:
+		return ErrCodeNotImplemented
+	case http.StatusServiceUnavailable:
+		return ErrCodeServiceUnavailable
+	case http.StatusGatewayTimeout:
+		return ErrCodeGatewayTimeout
+	default:
+		return ErrCodeInternal
+	}
+}
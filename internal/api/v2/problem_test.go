@@ -0,0 +1,113 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/pkg/workspace"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteProblem(t *testing.T) {
+	assert := assert.New(t)
+
+	r := httptest.NewRequest("GET", "/api/v2/drafts/doc123", nil)
+	w := httptest.NewRecorder()
+	writeProblem(w, r, http.StatusNotFound, ErrCodeDraftNotFound,
+		"Draft not found")
+
+	assert.Equal(http.StatusNotFound, w.Code)
+	assert.Equal(ProblemContentType, w.Header().Get("Content-Type"))
+
+	var p ProblemDetails
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&p))
+	assert.Equal(ProblemDetails{
+		Type:     "urn:hermes:error:draft_not_found",
+		Title:    "Not Found",
+		Status:   http.StatusNotFound,
+		Detail:   "Draft not found",
+		Instance: "/api/v2/drafts/doc123",
+		Code:     ErrCodeDraftNotFound,
+	}, p)
+}
+
+func TestWriteProblem_DefaultCode(t *testing.T) {
+	r := httptest.NewRequest("PATCH", "/api/v2/documents/doc123", nil)
+	w := httptest.NewRecorder()
+	writeProblem(w, r, http.StatusLocked, "", "Document is locked")
+
+	var p ProblemDetails
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&p))
+	assert.Equal(t, ErrCodeDocumentLocked, p.Code)
+}
+
+func TestMethodNotAllowedProblems(t *testing.T) {
+	log := hclog.NewNullLogger()
+	handlers := map[string]http.Handler{
+		"/api/v2/analytics":       AnalyticsHandler(server.Server{Logger: log}),
+		"/api/v2/document-types":  DocumentTypesHandler(server.Server{Logger: log}),
+		"/api/v2/products":        ProductsHandler(server.Server{Logger: log}),
+		"/api/v2/setup/status":    SetupStatusHandler("", log),
+		"/api/v2/setup/configure": SetupConfigureHandler(log),
+	}
+	for path, h := range handlers {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("PUT", path, nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code, path)
+		assert.Equal(t, ProblemContentType, w.Header().Get("Content-Type"), path)
+
+		var p ProblemDetails
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&p), path)
+		assert.Equal(t, ErrCodeMethodNotAllowed, p.Code, path)
+	}
+}
+
+func TestErrorCodeForStatus(t *testing.T) {
+	cases := map[int]ErrorCode{
+		http.StatusBadRequest:          ErrCodeBadRequest,
+		http.StatusUnauthorized:        ErrCodeUnauthorized,
+		http.StatusForbidden:           ErrCodeForbidden,
+		http.StatusNotFound:            ErrCodeNotFound,
+		http.StatusMethodNotAllowed:    ErrCodeMethodNotAllowed,
+		http.StatusConflict:            ErrCodeConflict,
+		http.StatusPreconditionFailed:  ErrCodePreconditionFailed,
+		http.StatusLocked:              ErrCodeDocumentLocked,
+		http.StatusInternalServerError: ErrCodeInternal,
+		http.StatusGatewayTimeout:      ErrCodeGatewayTimeout,
+		http.StatusBadGateway:          ErrCodeInternal,
+	}
+	for status, want := range cases {
+		assert.Equal(t, want, errorCodeForStatus(status), "status %d", status)
+	}
+}
+
+func TestRespondError(t *testing.T) {
+	serve := func(err error) ProblemDetails {
+		r := httptest.NewRequest("GET", "/api/v2/documents/doc123", nil)
+		w := httptest.NewRecorder()
+		respondError(w, r, hclog.NewNullLogger(), http.StatusInternalServerError,
+			"Error getting document", "error getting document", err)
+
+		var p ProblemDetails
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&p))
+		return p
+	}
+
+	p := serve(errors.New("boom"))
+	assert.Equal(t, http.StatusInternalServerError, p.Status)
+	assert.Equal(t, "Error getting document", p.Detail)
+
+	// Workspace provider timeouts are gateway timeouts.
+	p = serve(fmt.Errorf("error getting file: %w",
+		workspace.TimeoutError("GetDocument", time.Second)))
+	assert.Equal(t, http.StatusGatewayTimeout, p.Status)
+	assert.Equal(t, ErrCodeGatewayTimeout, p.Code)
+}
@@ -13,9 +13,11 @@ import (
 // ProductsHandler returns the product mappings to the Hermes frontend.
 func ProductsHandler(srv server.Server) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
 		// Only allow GET requests.
 		if r.Method != http.MethodGet {
-			w.WriteHeader(http.StatusMethodNotAllowed)
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
 			return
 		}
 
@@ -23,8 +25,8 @@ func ProductsHandler(srv server.Server) http.Handler {
 		products, err := getProductsData(srv.DB)
 		if err != nil {
 			srv.Logger.Error("error getting products from database", "error", err)
-			http.Error(w, "Error getting product mappings",
-				http.StatusInternalServerError)
+			writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Error getting product mappings")
 			return
 		}
 
@@ -35,8 +37,8 @@ func ProductsHandler(srv server.Server) http.Handler {
 		err = enc.Encode(products)
 		if err != nil {
 			srv.Logger.Error("error encoding products response", "error", err)
-			http.Error(w, "Error getting products",
-				http.StatusInternalServerError)
+			writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Error getting products")
 			return
 		}
 	})
@@ -14,6 +14,8 @@ import (
 
 	"github.com/hashicorp-forge/hermes/internal/server"
 	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+	"github.com/hashicorp-forge/hermes/pkg/authz"
+	"github.com/hashicorp-forge/hermes/pkg/database"
 	"github.com/hashicorp-forge/hermes/pkg/models"
 	"github.com/hashicorp-forge/hermes/pkg/search"
 	"gorm.io/gorm"
@@ -65,6 +67,7 @@ type project struct {
 
 func ProjectsHandler(srv server.Server) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
 		logArgs := []any{
 			"path", r.URL.Path,
 		}
@@ -73,8 +76,8 @@ func ProjectsHandler(srv server.Server) http.Handler {
 		userEmail := pkgauth.MustGetUserEmail(r.Context())
 		if userEmail == "" {
 			srv.Logger.Error("user email not found in request context", logArgs...)
-			http.Error(
-				w, "No authorization information for request", http.StatusUnauthorized)
+			writeProblem(w, r, http.StatusUnauthorized, ErrCodeUnauthorized,
+				"No authorization information for request")
 			return
 		}
 
@@ -97,7 +100,8 @@ func ProjectsHandler(srv server.Server) http.Handler {
 			if pageParam != "" {
 				p, err := strconv.Atoi(pageParam)
 				if err != nil {
-					http.Error(w, "Invalid page parameter", http.StatusBadRequest)
+					writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+						"Invalid page parameter")
 					return
 				}
 				page = p
@@ -107,7 +111,8 @@ func ProjectsHandler(srv server.Server) http.Handler {
 			if hitsPerPageParam != "" {
 				hpp, err := strconv.Atoi(hitsPerPageParam)
 				if err != nil {
-					http.Error(w, "Invalid hitsPerPage parameter", http.StatusBadRequest)
+					writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+						"Invalid hitsPerPage parameter")
 					return
 				}
 				hitsPerPage = hpp
@@ -123,16 +128,23 @@ func ProjectsHandler(srv server.Server) http.Handler {
 						Status: statusFilter,
 					}
 				} else {
-					http.Error(w, "Invalid status", http.StatusUnprocessableEntity)
+					writeProblem(w, r, http.StatusUnprocessableEntity, ErrCodeUnprocessable,
+						"Invalid status")
 					return
 				}
 			}
 
-			// Get projects from database.
+			// Project lists tolerate replication lag, so they are served from
+			// the read replica if one is configured.
+			db := database.Replica(srv.DB)
+
+			// Get projects from database. LOWER(...) LIKE is used instead of
+			// ILIKE, which SQLite doesn't support.
 			projs := []models.Project{}
 			offset := (page - 1) * hitsPerPage
-			if err := srv.DB.
-				Where("title ILIKE ?", fmt.Sprintf("%%%s%%", titleParam)).
+			if err := db.
+				Where("LOWER(title) LIKE ?",
+					fmt.Sprintf("%%%s%%", strings.ToLower(titleParam))).
 				Offset(offset).
 				Limit(hitsPerPage).
 				Find(&projs, cond).
@@ -142,16 +154,17 @@ func ProjectsHandler(srv server.Server) http.Handler {
 					append([]interface{}{
 						"error", err,
 					}, logArgs...)...)
-				http.Error(
-					w, "Error processing request", http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error processing request")
 				return
 			}
 
 			// Calculate total number of pages.
 			var totalProjects int64
-			if err := srv.DB.
+			if err := db.
 				Model(&models.Project{}).
-				Where("title ILIKE ?", fmt.Sprintf("%%%s%%", titleParam)).
+				Where("LOWER(title) LIKE ?",
+					fmt.Sprintf("%%%s%%", strings.ToLower(titleParam))).
 				Where(&cond).
 				Count(&totalProjects).
 				Error; err != nil {
@@ -159,8 +172,8 @@ func ProjectsHandler(srv server.Server) http.Handler {
 					append([]interface{}{
 						"error", err,
 					}, logArgs...)...)
-				http.Error(
-					w, "Error processing request", http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error processing request")
 				return
 			}
 			totalPages := int(
@@ -170,15 +183,15 @@ func ProjectsHandler(srv server.Server) http.Handler {
 			projResp := []project{}
 			for _, p := range projs {
 				// Get products for the project.
-				products, err := getProductsForProject(p, srv.DB)
+				products, err := getProductsForProject(p, db)
 				if err != nil {
 					srv.Logger.Error("error getting products for project",
 						append([]interface{}{
 							"error", err,
 							"project_id", p.ID,
 						}, logArgs...)...)
-					http.Error(
-						w, "Error processing request", http.StatusInternalServerError)
+					writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error processing request")
 					return
 				}
 
@@ -210,8 +223,8 @@ func ProjectsHandler(srv server.Server) http.Handler {
 						"error", err,
 					}, logArgs...)...,
 				)
-				http.Error(
-					w, "Error processing request", http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error processing request")
 				return
 			}
 
@@ -225,14 +238,15 @@ func ProjectsHandler(srv server.Server) http.Handler {
 					append([]interface{}{
 						"error", err,
 					}, logArgs...)...)
-				http.Error(
-					w, "Bad request", http.StatusBadRequest)
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					"Bad request")
 				return
 			}
 
 			// Validate request.
 			if req.Title == "" {
-				http.Error(w, "Bad request: title is required", http.StatusBadRequest)
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					"Bad request: title is required")
 				return
 			}
 
@@ -252,7 +266,8 @@ func ProjectsHandler(srv server.Server) http.Handler {
 					append([]interface{}{
 						"error", err,
 					}, logArgs...)...)
-				http.Error(w, "Error creating project", http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error creating project")
 				return
 			}
 			logArgs = append(logArgs, "project_id", proj.ID)
@@ -271,8 +286,8 @@ func ProjectsHandler(srv server.Server) http.Handler {
 						"error", err,
 					}, logArgs...)...,
 				)
-				http.Error(w, "Error creating project",
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error creating project")
 				return
 			}
 
@@ -282,20 +297,14 @@ func ProjectsHandler(srv server.Server) http.Handler {
 				}, logArgs...)...)
 
 			// Request post-processing.
-			go func() {
-				// Save project in search index.
-				if err := saveProjectInAlgolia(proj, srv.SearchProvider); err != nil {
-					srv.Logger.Error("error saving project in search index",
-						append([]interface{}{
-							"error", err,
-						}, logArgs...)...,
-					)
-					return
-				}
-			}()
+			// Save project in search index.
+			enqueueJob(srv, r, indexProjectJobType, indexProjectJob{
+				ProjectID: proj.ID,
+			})
 
 		default:
-			w.WriteHeader(http.StatusMethodNotAllowed)
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
 			return
 		}
 	})
@@ -303,6 +312,7 @@ func ProjectsHandler(srv server.Server) http.Handler {
 
 func ProjectHandler(srv server.Server) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
 		logArgs := []any{
 			"path", r.URL.Path,
 		}
@@ -311,8 +321,8 @@ func ProjectHandler(srv server.Server) http.Handler {
 		userEmail := pkgauth.MustGetUserEmail(r.Context())
 		if userEmail == "" {
 			srv.Logger.Error("user email not found in request context", logArgs...)
-			http.Error(
-				w, "No authorization information for request", http.StatusUnauthorized)
+			writeProblem(w, r, http.StatusUnauthorized, ErrCodeUnauthorized,
+				"No authorization information for request")
 			return
 		}
 
@@ -330,7 +340,8 @@ func ProjectHandler(srv server.Server) http.Handler {
 					append([]interface{}{
 						"error", err,
 					}, logArgs...)...)
-				http.Error(w, "Project not found", http.StatusNotFound)
+				writeProblem(w, r, http.StatusNotFound, ErrCodeProjectNotFound,
+					"Project not found")
 				return
 			}
 
@@ -344,7 +355,8 @@ func ProjectHandler(srv server.Server) http.Handler {
 					append([]interface{}{
 						"error", err,
 					}, logArgs...)...)
-				http.Error(w, "Project not found", http.StatusNotFound)
+				writeProblem(w, r, http.StatusNotFound, ErrCodeProjectNotFound,
+					"Project not found")
 				return
 			}
 			logArgs = append(logArgs, "project_id", projectID)
@@ -359,15 +371,16 @@ func ProjectHandler(srv server.Server) http.Handler {
 				if err := proj.Get(srv.DB, projectID); err != nil {
 					if errors.Is(err, gorm.ErrRecordNotFound) {
 						srv.Logger.Warn("project not found", logArgs...)
-						http.Error(w, "Project not found", http.StatusNotFound)
+						writeProblem(w, r, http.StatusNotFound, ErrCodeProjectNotFound,
+							"Project not found")
 						return
 					} else {
 						srv.Logger.Error("error getting project from database",
 							append([]interface{}{
 								"error", err,
 							}, logArgs...)...)
-						http.Error(
-							w, "Error processing request", http.StatusInternalServerError)
+						writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+							"Error processing request")
 						return
 					}
 				}
@@ -379,8 +392,8 @@ func ProjectHandler(srv server.Server) http.Handler {
 						append([]interface{}{
 							"error", err,
 						}, logArgs...)...)
-					http.Error(
-						w, "Error processing request", http.StatusInternalServerError)
+					writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error processing request")
 					return
 				}
 
@@ -409,8 +422,8 @@ func ProjectHandler(srv server.Server) http.Handler {
 							"error", err,
 						}, logArgs...)...,
 					)
-					http.Error(
-						w, "Error processing request", http.StatusInternalServerError)
+					writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error processing request")
 					return
 				}
 
@@ -443,8 +456,8 @@ func ProjectHandler(srv server.Server) http.Handler {
 						append([]interface{}{
 							"error", err,
 						}, logArgs...)...)
-					http.Error(
-						w, "Bad request", http.StatusBadRequest)
+					writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+						"Bad request")
 					return
 				}
 
@@ -455,16 +468,15 @@ func ProjectHandler(srv server.Server) http.Handler {
 					case "archived":
 					case "completed":
 					default:
-						http.Error(w,
+						writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
 							"Bad request: invalid status"+
-								` (valid values are "active", "archived", "completed")`,
-							http.StatusBadRequest)
+								` (valid values are "active", "archived", "completed")`)
 						return
 					}
 				}
 				if req.Title != nil && *req.Title == "" {
-					http.Error(
-						w, "Bad request: title cannot be empty", http.StatusBadRequest)
+					writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+						"Bad request: title cannot be empty")
 					return
 				}
 
@@ -473,15 +485,16 @@ func ProjectHandler(srv server.Server) http.Handler {
 				if err := proj.Get(srv.DB, projectID); err != nil {
 					if errors.Is(err, gorm.ErrRecordNotFound) {
 						srv.Logger.Warn("project not found", logArgs...)
-						http.Error(w, "Project not found", http.StatusNotFound)
+						writeProblem(w, r, http.StatusNotFound, ErrCodeProjectNotFound,
+							"Project not found")
 						return
 					} else {
 						srv.Logger.Error("error getting project from database",
 							append([]interface{}{
 								"error", err,
 							}, logArgs...)...)
-						http.Error(
-							w, "Error processing request", http.StatusInternalServerError)
+						writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+							"Error processing request")
 						return
 					}
 				}
@@ -514,8 +527,8 @@ func ProjectHandler(srv server.Server) http.Handler {
 						append([]interface{}{
 							"error", err,
 						}, logArgs...)...)
-					http.Error(
-						w, "Error updating project", http.StatusInternalServerError)
+					writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error updating project")
 					return
 				}
 
@@ -534,26 +547,79 @@ func ProjectHandler(srv server.Server) http.Handler {
 					}, logArgs...)...)
 
 				// Request post-processing.
-				go func() {
-					// Save project in search index.
-					if err := saveProjectInAlgolia(patch, srv.SearchProvider); err != nil {
-						srv.Logger.Error("error saving project in search index",
-							append([]interface{}{
-								"error", err,
-							}, logArgs...)...,
-						)
+				// Save project in search index.
+				enqueueJob(srv, r, indexProjectJobType, indexProjectJob{
+					ProjectID: patch.ID,
+				})
+
+			case "DELETE":
+				logArgs = append(logArgs, "method", r.Method)
+
+				// Get project.
+				proj := models.Project{}
+				if err := proj.Get(srv.DB, projectID); err != nil {
+					if errors.Is(err, gorm.ErrRecordNotFound) {
+						srv.Logger.Warn("project not found", logArgs...)
+						writeProblem(w, r, http.StatusNotFound, ErrCodeProjectNotFound,
+							"Project not found")
 						return
 					}
-				}()
+					srv.Logger.Error("error getting project from database",
+						append([]interface{}{
+							"error", err,
+						}, logArgs...)...)
+					writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error processing request")
+					return
+				}
+
+				// Authorize request.
+				if !authorizeRequest(w, r, srv.Config, srv.DB, srv.Logger,
+					authz.ActionProjectDelete,
+					authz.Resource{Owner: proj.Creator.EmailAddress},
+					"Only the creator of a project can delete it",
+				) {
+					return
+				}
+
+				// Soft delete project in the database. Site admins can restore it
+				// until it is purged after the recovery window.
+				if err := proj.Delete(srv.DB); err != nil {
+					srv.Logger.Error("error deleting project",
+						append([]interface{}{
+							"error", err,
+						}, logArgs...)...)
+					writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error deleting project")
+					return
+				}
+
+				// Delete project from search index.
+				if err := srv.SearchProvider.ProjectIndex().Delete(
+					r.Context(), fmt.Sprintf("%d", projectID),
+				); err != nil {
+					srv.Logger.Error("error deleting project from search index",
+						append([]interface{}{
+							"error", err,
+						}, logArgs...)...)
+				}
+
+				srv.Logger.Info("deleted project",
+					append([]interface{}{
+						"user", userEmail,
+					}, logArgs...)...)
+
+				w.WriteHeader(http.StatusNoContent)
 
 			default:
-				w.WriteHeader(http.StatusMethodNotAllowed)
+				writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+					"Method not allowed")
 				return
 			}
 
 		default:
 			srv.Logger.Warn("path not found", logArgs...)
-			http.Error(w, "Not found", http.StatusNotFound)
+			writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound, "Not found")
 			return
 		}
 	})
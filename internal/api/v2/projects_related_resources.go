@@ -68,8 +68,8 @@ func projectsResourceRelatedResourcesHandler(
 	userEmail := pkgauth.MustGetUserEmail(r.Context())
 	if userEmail == "" {
 		srv.Logger.Error("user email not found in request context", logArgs...)
-		http.Error(
-			w, "No authorization information for request", http.StatusUnauthorized)
+		writeProblem(w, r, http.StatusUnauthorized, ErrCodeUnauthorized,
+			"No authorization information for request")
 		return
 	}
 
@@ -89,8 +89,8 @@ func projectsResourceRelatedResourcesHandler(
 				append([]interface{}{
 					"error", err,
 				}, logArgs...)...)
-			http.Error(
-				w, "Error processing request", http.StatusInternalServerError)
+			writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Error processing request")
 			return
 		}
 
@@ -120,8 +120,8 @@ func projectsResourceRelatedResourcesHandler(
 					append([]interface{}{
 						"error", err,
 					}, logArgs...)...)
-				http.Error(
-					w, "Error processing request", http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error processing request")
 				return
 			}
 
@@ -152,8 +152,8 @@ func projectsResourceRelatedResourcesHandler(
 				append([]interface{}{
 					"error", err,
 				}, logArgs...)...)
-			http.Error(
-				w, "Error processing request", http.StatusInternalServerError)
+			writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Error processing request")
 			return
 		}
 
@@ -169,8 +169,8 @@ func projectsResourceRelatedResourcesHandler(
 				append([]interface{}{
 					"error", err,
 				}, logArgs...)...)
-			http.Error(
-				w, "Bad request", http.StatusBadRequest)
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				"Bad request")
 			return
 		}
 
@@ -179,15 +179,16 @@ func projectsResourceRelatedResourcesHandler(
 		if err := proj.Get(srv.DB, projectID); err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
 				srv.Logger.Warn("project not found", logArgs...)
-				http.Error(w, "Project not found", http.StatusNotFound)
+				writeProblem(w, r, http.StatusNotFound, ErrCodeProjectNotFound,
+					"Project not found")
 				return
 			} else {
 				srv.Logger.Error("error getting project from database",
 					append([]interface{}{
 						"error", err,
 					}, logArgs...)...)
-				http.Error(
-					w, "Error processing request", http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error processing request")
 				return
 			}
 		}
@@ -225,8 +226,8 @@ func projectsResourceRelatedResourcesHandler(
 				append([]interface{}{
 					"error", err,
 				}, logArgs...)...)
-			http.Error(
-				w, "Error processing request", http.StatusInternalServerError)
+			writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Error processing request")
 			return
 		}
 
@@ -245,7 +246,8 @@ func projectsResourceRelatedResourcesHandler(
 			}, logArgs...)...)
 
 	default:
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+			"Method not allowed")
 		return
 	}
 }
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/pkg/authz"
 )
 
 // ProvidersHandler handles storage provider management endpoints
@@ -20,8 +21,20 @@ import (
 //	PATCH  /api/v2/providers/:id          - Update provider
 //	DELETE /api/v2/providers/:id          - Remove provider
 //	GET    /api/v2/providers/:id/health   - Get provider health status
+//
+// Only site admins can register, update, or remove providers.
 func ProvidersHandler(srv server.Server) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
+		// Only site admins can make changes.
+		if r.Method != http.MethodGet &&
+			!authorizeRequest(w, r, srv.Config, srv.DB, srv.Logger,
+				authz.ActionAdmin, authz.Resource{},
+				"Only site admins can manage storage providers",
+			) {
+			return
+		}
+
 		// Extract path after /api/v2/providers/
 		path := strings.TrimPrefix(r.URL.Path, "/api/v2/providers/")
 		path = strings.TrimPrefix(path, "/api/v2/providers")
@@ -34,20 +47,23 @@ func ProvidersHandler(srv server.Server) http.Handler {
 			} else if r.Method == http.MethodPost {
 				registerProvider(w, r, srv)
 			} else {
-				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+					"Method not allowed")
 			}
 			return
 		}
 
 		parts := strings.Split(path, "/")
 		if len(parts) == 0 || parts[0] == "" {
-			http.Error(w, "Provider ID required", http.StatusBadRequest)
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				"Provider ID required")
 			return
 		}
 
 		providerID, err := strconv.ParseInt(parts[0], 10, 64)
 		if err != nil {
-			http.Error(w, "Invalid provider ID", http.StatusBadRequest)
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				"Invalid provider ID")
 			return
 		}
 
@@ -61,17 +77,20 @@ func ProvidersHandler(srv server.Server) http.Handler {
 			case http.MethodDelete:
 				removeProvider(w, r, srv, providerID)
 			default:
-				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+					"Method not allowed")
 			}
 		} else if len(parts) == 2 && parts[1] == "health" {
 			// /providers/:id/health
 			if r.Method == http.MethodGet {
 				getProviderHealth(w, r, srv, providerID)
 			} else {
-				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+				writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+					"Method not allowed")
 			}
 		} else {
-			http.Error(w, "Invalid path", http.StatusBadRequest)
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				"Invalid path")
 		}
 	})
 }
@@ -126,14 +145,16 @@ func listProviders(w http.ResponseWriter, r *http.Request, srv server.Server) {
 	sqlDB, err := getSQLDB(srv)
 	if err != nil {
 		srv.Logger.Error("failed to get SQL DB", "error", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"Internal server error")
 		return
 	}
 
 	rows, err := sqlDB.QueryContext(r.Context(), query, args...)
 	if err != nil {
 		srv.Logger.Error("failed to query providers", "error", err)
-		http.Error(w, "Failed to list providers", http.StatusInternalServerError)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"Failed to list providers")
 		return
 	}
 	defer rows.Close()
@@ -209,17 +230,20 @@ func registerProvider(w http.ResponseWriter, r *http.Request, srv server.Server)
 	var req RegisterProviderRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		srv.Logger.Error("failed to decode request", "error", err)
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			"Invalid request body")
 		return
 	}
 
 	// Validate required fields
 	if req.ProviderName == "" {
-		http.Error(w, "providerName is required", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			"providerName is required")
 		return
 	}
 	if req.ProviderType == "" {
-		http.Error(w, "providerType is required", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			"providerType is required")
 		return
 	}
 
@@ -232,14 +256,16 @@ func registerProvider(w http.ResponseWriter, r *http.Request, srv server.Server)
 	configJSON, err := json.Marshal(req.Config)
 	if err != nil {
 		srv.Logger.Error("failed to marshal config", "error", err)
-		http.Error(w, "Invalid config", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			"Invalid config")
 		return
 	}
 
 	capabilitiesJSON, err := json.Marshal(req.Capabilities)
 	if err != nil {
 		srv.Logger.Error("failed to marshal capabilities", "error", err)
-		http.Error(w, "Invalid capabilities", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			"Invalid capabilities")
 		return
 	}
 
@@ -247,7 +273,8 @@ func registerProvider(w http.ResponseWriter, r *http.Request, srv server.Server)
 	sqlDB, err := getSQLDB(srv)
 	if err != nil {
 		srv.Logger.Error("failed to get SQL DB", "error", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"Internal server error")
 		return
 	}
 
@@ -264,10 +291,12 @@ func registerProvider(w http.ResponseWriter, r *http.Request, srv server.Server)
 
 	if err != nil {
 		if strings.Contains(err.Error(), "duplicate key") {
-			http.Error(w, "Provider name already exists", http.StatusConflict)
+			writeProblem(w, r, http.StatusConflict, ErrCodeConflict,
+				"Provider name already exists")
 		} else {
 			srv.Logger.Error("failed to insert provider", "error", err)
-			http.Error(w, "Failed to register provider", http.StatusInternalServerError)
+			writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Failed to register provider")
 		}
 		return
 	}
@@ -286,7 +315,8 @@ func getProvider(w http.ResponseWriter, r *http.Request, srv server.Server, prov
 	sqlDB, err := getSQLDB(srv)
 	if err != nil {
 		srv.Logger.Error("failed to get SQL DB", "error", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"Internal server error")
 		return
 	}
 
@@ -311,11 +341,13 @@ func getProvider(w http.ResponseWriter, r *http.Request, srv server.Server, prov
 		&healthStatus, &lastHealthCheck, &createdAt, &updatedAt)
 
 	if err == sql.ErrNoRows {
-		http.Error(w, "Provider not found", http.StatusNotFound)
+		writeProblem(w, r, http.StatusNotFound, ErrCodeProviderNotFound,
+			"Provider not found")
 		return
 	} else if err != nil {
 		srv.Logger.Error("failed to query provider", "error", err)
-		http.Error(w, "Failed to get provider", http.StatusInternalServerError)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"Failed to get provider")
 		return
 	}
 
@@ -363,7 +395,8 @@ func updateProvider(w http.ResponseWriter, r *http.Request, srv server.Server, p
 	var req UpdateProviderRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		srv.Logger.Error("failed to decode request", "error", err)
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			"Invalid request body")
 		return
 	}
 
@@ -375,7 +408,8 @@ func updateProvider(w http.ResponseWriter, r *http.Request, srv server.Server, p
 	if req.Config != nil {
 		configJSON, err := json.Marshal(req.Config)
 		if err != nil {
-			http.Error(w, "Invalid config", http.StatusBadRequest)
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				"Invalid config")
 			return
 		}
 		updates = append(updates, "config = $"+strconv.Itoa(argNum))
@@ -386,7 +420,8 @@ func updateProvider(w http.ResponseWriter, r *http.Request, srv server.Server, p
 	if req.Capabilities != nil {
 		capabilitiesJSON, err := json.Marshal(req.Capabilities)
 		if err != nil {
-			http.Error(w, "Invalid capabilities", http.StatusBadRequest)
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				"Invalid capabilities")
 			return
 		}
 		updates = append(updates, "capabilities = $"+strconv.Itoa(argNum))
@@ -413,12 +448,13 @@ func updateProvider(w http.ResponseWriter, r *http.Request, srv server.Server, p
 	}
 
 	if len(updates) == 0 {
-		http.Error(w, "No fields to update", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			"No fields to update")
 		return
 	}
 
 	// Always update updated_at
-	updates = append(updates, "updated_at = NOW()")
+	updates = append(updates, "updated_at = CURRENT_TIMESTAMP")
 
 	// Add provider ID to args
 	args = append(args, providerID)
@@ -429,24 +465,28 @@ func updateProvider(w http.ResponseWriter, r *http.Request, srv server.Server, p
 	sqlDB, err := getSQLDB(srv)
 	if err != nil {
 		srv.Logger.Error("failed to get SQL DB", "error", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"Internal server error")
 		return
 	}
 
 	result, err := sqlDB.ExecContext(r.Context(), query, args...)
 	if err != nil {
 		srv.Logger.Error("failed to update provider", "error", err)
-		http.Error(w, "Failed to update provider", http.StatusInternalServerError)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"Failed to update provider")
 		return
 	}
 
 	rows, err := result.RowsAffected()
 	if err != nil {
-		http.Error(w, "Failed to check result", http.StatusInternalServerError)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"Failed to check result")
 		return
 	}
 	if rows == 0 {
-		http.Error(w, "Provider not found", http.StatusNotFound)
+		writeProblem(w, r, http.StatusNotFound, ErrCodeProviderNotFound,
+			"Provider not found")
 		return
 	}
 
@@ -461,7 +501,8 @@ func removeProvider(w http.ResponseWriter, r *http.Request, srv server.Server, p
 	sqlDB, err := getSQLDB(srv)
 	if err != nil {
 		srv.Logger.Error("failed to get SQL DB", "error", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"Internal server error")
 		return
 	}
 
@@ -475,12 +516,14 @@ func removeProvider(w http.ResponseWriter, r *http.Request, srv server.Server, p
 
 	if err != nil {
 		srv.Logger.Error("failed to check migration jobs", "error", err)
-		http.Error(w, "Failed to remove provider", http.StatusInternalServerError)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"Failed to remove provider")
 		return
 	}
 
 	if jobCount > 0 {
-		http.Error(w, "Cannot remove provider with existing migration jobs", http.StatusConflict)
+		writeProblem(w, r, http.StatusConflict, ErrCodeConflict,
+			"Cannot remove provider with existing migration jobs")
 		return
 	}
 
@@ -491,17 +534,20 @@ func removeProvider(w http.ResponseWriter, r *http.Request, srv server.Server, p
 
 	if err != nil {
 		srv.Logger.Error("failed to delete provider", "error", err)
-		http.Error(w, "Failed to remove provider", http.StatusInternalServerError)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"Failed to remove provider")
 		return
 	}
 
 	rows, err := result.RowsAffected()
 	if err != nil {
-		http.Error(w, "Failed to check result", http.StatusInternalServerError)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"Failed to check result")
 		return
 	}
 	if rows == 0 {
-		http.Error(w, "Provider not found", http.StatusNotFound)
+		writeProblem(w, r, http.StatusNotFound, ErrCodeProviderNotFound,
+			"Provider not found")
 		return
 	}
 
@@ -519,7 +565,8 @@ func getProviderHealth(w http.ResponseWriter, r *http.Request, srv server.Server
 	sqlDB, err := getSQLDB(srv)
 	if err != nil {
 		srv.Logger.Error("failed to get SQL DB", "error", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"Internal server error")
 		return
 	}
 
@@ -536,11 +583,13 @@ func getProviderHealth(w http.ResponseWriter, r *http.Request, srv server.Server
 	`, providerID).Scan(&providerName, &healthStatus, &lastHealthCheck, &documentCount, &totalSizeBytes)
 
 	if err == sql.ErrNoRows {
-		http.Error(w, "Provider not found", http.StatusNotFound)
+		writeProblem(w, r, http.StatusNotFound, ErrCodeProviderNotFound,
+			"Provider not found")
 		return
 	} else if err != nil {
 		srv.Logger.Error("failed to query provider health", "error", err)
-		http.Error(w, "Failed to get health", http.StatusInternalServerError)
+		writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+			"Failed to get health")
 		return
 	}
 
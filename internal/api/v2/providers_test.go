@@ -0,0 +1,36 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateProviderSQLite(t *testing.T) {
+	srv := newStorageTestServer(t)
+
+	w := httptest.NewRecorder()
+	ProvidersHandler(srv).ServeHTTP(w, newAdminRequest("PATCH",
+		"/api/v2/providers/2", `{"status": "readonly", "isWritable": false}`))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var resp struct {
+		Status     string    `json:"status"`
+		IsWritable bool      `json:"isWritable"`
+		UpdatedAt  time.Time `json:"updatedAt"`
+	}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, "readonly", resp.Status)
+	assert.False(t, resp.IsWritable)
+	assert.Greater(t, resp.UpdatedAt.Year(), 2000)
+
+	w = httptest.NewRecorder()
+	ProvidersHandler(srv).ServeHTTP(w, newAdminRequest("PATCH",
+		"/api/v2/providers/3", `{"status": "readonly"}`))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,135 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/hashicorp-forge/hermes/internal/server"
+	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+	"github.com/hashicorp-forge/hermes/pkg/httpcache"
+)
+
+// cacheHeader is set on responses of cached endpoints to HIT or MISS.
+const cacheHeader = "X-Cache"
+
+// CachePolicy describes which requests to an endpoint are served from the
+// response cache and how their responses are keyed.
+type CachePolicy struct {
+	// Endpoint names the endpoint in cache keys.
+	Endpoint string
+
+	// PerUser keys responses by user, for endpoints whose responses depend on
+	// what the user can see. Other responses are shared by all users.
+	PerUser bool
+
+	// Cacheable returns true if the response to a request with body can be
+	// cached. All GET and POST requests are cached if nil.
+	Cacheable func(r *http.Request, body []byte) bool
+}
+
+var (
+	// PeopleCachePolicy caches people searches and lookups.
+	PeopleCachePolicy = CachePolicy{Endpoint: "people"}
+
+	// GroupsCachePolicy caches group searches.
+	GroupsCachePolicy = CachePolicy{Endpoint: "groups"}
+
+	// ProductsCachePolicy caches the product list.
+	ProductsCachePolicy = CachePolicy{Endpoint: "products"}
+
+	// DocumentTypesCachePolicy caches the document type list.
+	DocumentTypesCachePolicy = CachePolicy{Endpoint: "document_types"}
+
+	// SearchFacetsCachePolicy caches facet counts, which are keyed by user
+	// because they only count the documents the user can see.
+	SearchFacetsCachePolicy = CachePolicy{
+		Endpoint:  "search_facets",
+		PerUser:   true,
+		Cacheable: isFacetSearchRequest,
+	}
+)
+
+// CachedHandler wraps a read endpoint so successful responses are served
+// from the server's response cache until they expire. Requests are passed
+// through unchanged if the cache is disabled or the policy doesn't cache
+// them, and requests with a "Cache-Control: no-cache" header skip cached
+// responses but still refresh them.
+func CachedHandler(
+	srv server.Server, policy CachePolicy, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
+		if srv.ResponseCache == nil ||
+			(r.Method != "GET" && r.Method != "POST") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// Read and restore the request body so it can be part of the key.
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			respondError(w, r, srv.Logger, http.StatusBadRequest,
+				"Bad request", "error reading request body", err)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		if policy.Cacheable != nil && !policy.Cacheable(r, body) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// Responses are keyed by host so tenants don't share them.
+		parts := [][]byte{[]byte(r.Host)}
+		if policy.PerUser {
+			userEmail, ok := pkgauth.GetUserEmail(r.Context())
+			if !ok || userEmail == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			parts = append(parts, []byte(userEmail))
+		}
+		parts = append(parts, []byte(r.Method), []byte(r.URL.RequestURI()), body)
+		key := httpcache.Key(policy.Endpoint, parts...)
+
+		if r.Header.Get("Cache-Control") != "no-cache" {
+			if resp, ok := srv.ResponseCache.Get(r.Context(), key); ok {
+				if resp.ContentType != "" {
+					w.Header().Set("Content-Type", resp.ContentType)
+				}
+				w.Header().Set(cacheHeader, "HIT")
+				w.WriteHeader(resp.Status)
+				w.Write(resp.Body)
+				return
+			}
+		}
+
+		w.Header().Set(cacheHeader, "MISS")
+		rw := &recordingResponseWriter{ResponseWriter: w}
+		next.ServeHTTP(rw, r)
+
+		// Only successful responses are cached, so errors aren't repeated.
+		if rw.Status() != http.StatusOK {
+			return
+		}
+		srv.ResponseCache.Set(r.Context(), key, &httpcache.Response{
+			Status:      rw.Status(),
+			ContentType: rw.Header().Get("Content-Type"),
+			Body:        rw.body.Bytes(),
+		})
+	})
+}
+
+// isFacetSearchRequest returns true for search requests that only get facet
+// counts, which the web app makes for every search page.
+func isFacetSearchRequest(r *http.Request, body []byte) bool {
+	if r.Method != "POST" {
+		return false
+	}
+	var req SearchRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return false
+	}
+	return len(req.Facets) > 0 && len(convertFiltersToMap(req.Filters)) == 0
+}
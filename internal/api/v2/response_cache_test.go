@@ -0,0 +1,133 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/internal/server"
+	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+	"github.com/hashicorp-forge/hermes/pkg/httpcache"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCachedHandler(t *testing.T) {
+	srv := server.Server{
+		Logger: hclog.NewNullLogger(),
+		ResponseCache: httpcache.New(
+			httpcache.NewMemoryStore(0), 0, hclog.NewNullLogger()),
+	}
+
+	// The handler responds with the number of times it has been called and
+	// the request body, so cached responses can be told apart.
+	calls := 0
+	status := http.StatusOK
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		fmt.Fprintf(w, `{"calls":%d,"body":%q}`, calls, body)
+	})
+
+	serve := func(policy CachePolicy, method, body, userEmail string,
+	) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, "/api/v2/test", strings.NewReader(body))
+		req = req.WithContext(context.WithValue(
+			req.Context(), pkgauth.UserEmailKey, userEmail))
+		w := httptest.NewRecorder()
+		CachedHandler(srv, policy, next).ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("shared responses are cached", func(t *testing.T) {
+		assert := assert.New(t)
+		policy := CachePolicy{Endpoint: "shared"}
+		calls = 0
+
+		w := serve(policy, "POST", `{"query":"a"}`, "a@example.com")
+		assert.Equal("MISS", w.Header().Get(cacheHeader))
+		assert.JSONEq(`{"calls":1,"body":"{\"query\":\"a\"}"}`, w.Body.String())
+
+		w = serve(policy, "POST", `{"query":"a"}`, "b@example.com")
+		assert.Equal("HIT", w.Header().Get(cacheHeader))
+		assert.Equal("application/json", w.Header().Get("Content-Type"))
+		assert.JSONEq(`{"calls":1,"body":"{\"query\":\"a\"}"}`, w.Body.String())
+
+		// Requests with a different body aren't served the cached response.
+		w = serve(policy, "POST", `{"query":"b"}`, "a@example.com")
+		assert.Equal("MISS", w.Header().Get(cacheHeader))
+		assert.Equal(2, calls)
+	})
+
+	t.Run("per-user responses are cached by user", func(t *testing.T) {
+		assert := assert.New(t)
+		policy := CachePolicy{Endpoint: "per_user", PerUser: true}
+		calls = 0
+
+		serve(policy, "GET", "", "a@example.com")
+		w := serve(policy, "GET", "", "a@example.com")
+		assert.Equal("HIT", w.Header().Get(cacheHeader))
+		w = serve(policy, "GET", "", "b@example.com")
+		assert.Equal("MISS", w.Header().Get(cacheHeader))
+		assert.Equal(2, calls)
+	})
+
+	t.Run("errors aren't cached", func(t *testing.T) {
+		policy := CachePolicy{Endpoint: "errors"}
+		calls = 0
+		status = http.StatusInternalServerError
+		defer func() { status = http.StatusOK }()
+
+		serve(policy, "GET", "", "a@example.com")
+		serve(policy, "GET", "", "a@example.com")
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("no-cache requests refresh the response", func(t *testing.T) {
+		assert := assert.New(t)
+		policy := CachePolicy{Endpoint: "no_cache"}
+		calls = 0
+
+		serve(policy, "GET", "", "a@example.com")
+		req := httptest.NewRequest("GET", "/api/v2/test", nil)
+		req.Header.Set("Cache-Control", "no-cache")
+		w := httptest.NewRecorder()
+		CachedHandler(srv, policy, next).ServeHTTP(w, req)
+		assert.Equal("MISS", w.Header().Get(cacheHeader))
+
+		w = serve(policy, "GET", "", "a@example.com")
+		assert.Equal("HIT", w.Header().Get(cacheHeader))
+		assert.JSONEq(`{"calls":2,"body":""}`, w.Body.String())
+	})
+
+	t.Run("only facet searches are cached", func(t *testing.T) {
+		assert := assert.New(t)
+		calls = 0
+
+		facets := `{"query":"","facets":["docType","owners"],"hitsPerPage":0}`
+		serve(SearchFacetsCachePolicy, "POST", facets, "a@example.com")
+		w := serve(SearchFacetsCachePolicy, "POST", facets, "a@example.com")
+		assert.Equal("HIT", w.Header().Get(cacheHeader))
+
+		filtered := `{"facets":["docType"],"filters":["status:Approved"]}`
+		serve(SearchFacetsCachePolicy, "POST", filtered, "a@example.com")
+		w = serve(SearchFacetsCachePolicy, "POST", filtered, "a@example.com")
+		assert.Empty(w.Header().Get(cacheHeader))
+		assert.Equal(3, calls)
+	})
+
+	t.Run("disabled cache", func(t *testing.T) {
+		srv := server.Server{Logger: hclog.NewNullLogger()}
+		req := httptest.NewRequest("GET", "/api/v2/test", nil)
+		w := httptest.NewRecorder()
+		CachedHandler(srv, CachePolicy{Endpoint: "disabled"}, next).
+			ServeHTTP(w, req)
+		assert.Empty(t, w.Header().Get(cacheHeader))
+	})
+}
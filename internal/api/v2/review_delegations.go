@@ -0,0 +1,246 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/server"
+	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"gorm.io/gorm"
+)
+
+// ReviewDelegation is a review delegation in API responses.
+type ReviewDelegation struct {
+	ID        uint      `json:"id"`
+	Delegator string    `json:"delegator"`
+	Delegate  string    `json:"delegate"`
+	Group     string    `json:"group,omitempty"`
+	StartsAt  time.Time `json:"startsAt"`
+	EndsAt    time.Time `json:"endsAt"`
+}
+
+// ReviewDelegationsPostRequest contains the fields to create a review
+// delegation.
+type ReviewDelegationsPostRequest struct {
+	Delegate string `json:"delegate"`
+
+	// Group limits the delegation to one of the delegator's groups. If empty,
+	// the delegation applies to all of the delegator's groups.
+	Group string `json:"group,omitempty"`
+
+	// StartsAt defaults to the current time.
+	StartsAt *time.Time `json:"startsAt,omitempty"`
+	EndsAt   time.Time  `json:"endsAt"`
+}
+
+// ReviewDelegationsHandler handles the authenticated user's review delegations.
+// GET /api/v2/me/review-delegations lists delegations given or received that
+// have not ended, POST creates a delegation to a colleague, and
+// DELETE /api/v2/me/review-delegations/:id revokes one.
+func ReviewDelegationsHandler(srv server.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
+		userEmail, ok := pkgauth.GetUserEmail(r.Context())
+		if !ok || userEmail == "" {
+			writeProblem(w, r, http.StatusUnauthorized, ErrCodeUnauthorized,
+				"No authorization information for request")
+			return
+		}
+
+		errResp := func(httpCode int, userErrMsg, logErrMsg string, err error) {
+			srv.Logger.Error(logErrMsg,
+				"error", err,
+				"method", r.Method,
+				"path", r.URL.Path,
+			)
+			writeProblem(w, r, httpCode, errorCodeForStatus(httpCode), userErrMsg)
+		}
+
+		idStr := strings.TrimPrefix(
+			strings.TrimPrefix(r.URL.Path, "/api/v2/me/review-delegations"), "/")
+
+		if idStr != "" {
+			if r.Method != "DELETE" {
+				writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+					"Method not allowed")
+				return
+			}
+
+			id, err := strconv.ParseUint(idStr, 10, 64)
+			if err != nil || id == 0 {
+				writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound,
+					"Review delegation not found")
+				return
+			}
+
+			d := models.ReviewDelegation{}
+			d.ID = uint(id)
+			if err := d.Get(srv.DB); err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound,
+						"Review delegation not found")
+					return
+				}
+				errResp(http.StatusInternalServerError,
+					"Error accessing review delegation",
+					"error getting review delegation", err)
+				return
+			}
+			if d.Delegator.EmailAddress != userEmail {
+				writeProblem(w, r, http.StatusForbidden, ErrCodeForbidden,
+					"Only the delegator can revoke a review delegation")
+				return
+			}
+
+			if err := d.Delete(srv.DB); err != nil {
+				errResp(http.StatusInternalServerError,
+					"Error revoking review delegation",
+					"error deleting review delegation", err)
+				return
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		switch r.Method {
+		case "GET":
+			var delegations models.ReviewDelegations
+			if err := delegations.FindForUser(
+				srv.DB.WithContext(r.Context()), userEmail, time.Now(),
+			); err != nil {
+				errResp(http.StatusInternalServerError,
+					"Error getting review delegations",
+					"error finding review delegations", err)
+				return
+			}
+
+			resp := []ReviewDelegation{}
+			for _, d := range delegations {
+				resp = append(resp, reviewDelegationResponse(d))
+			}
+			writeReviewDelegationsResponse(srv, w, r, http.StatusOK, resp)
+
+		case "POST":
+			var req ReviewDelegationsPostRequest
+			if err := decodeRequest(r, &req); err != nil {
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					fmt.Sprintf("Bad request: %q", err))
+				return
+			}
+
+			now := time.Now()
+			startsAt := now
+			if req.StartsAt != nil {
+				startsAt = *req.StartsAt
+			}
+			if msg := validateReviewDelegation(
+				userEmail, req.Delegate, startsAt, req.EndsAt, now,
+			); msg != "" {
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest, msg)
+				return
+			}
+
+			d := models.ReviewDelegation{
+				Delegator: models.User{
+					EmailAddress: userEmail,
+				},
+				Delegate: models.User{
+					EmailAddress: req.Delegate,
+				},
+				StartsAt: startsAt,
+				EndsAt:   req.EndsAt,
+			}
+
+			if req.Group != "" {
+				inGroup, err := isUserInGroups(r.Context(), userEmail,
+					[]string{req.Group}, srv.WorkspaceProvider)
+				if err != nil {
+					errResp(http.StatusInternalServerError,
+						"Error creating review delegation",
+						"error calculating if user is in group", err)
+					return
+				}
+				if !inGroup {
+					writeProblem(w, r, http.StatusForbidden, ErrCodeForbidden,
+						"Can only delegate reviews for groups you are a member of")
+					return
+				}
+				d.Group = &models.Group{
+					EmailAddress: req.Group,
+				}
+			}
+
+			if err := d.Create(srv.DB); err != nil {
+				errResp(http.StatusInternalServerError,
+					"Error creating review delegation",
+					"error creating review delegation", err)
+				return
+			}
+
+			writeReviewDelegationsResponse(
+				srv, w, r, http.StatusCreated, reviewDelegationResponse(d))
+
+		default:
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
+			return
+		}
+	})
+}
+
+// validateReviewDelegation returns a user-facing error message if a review
+// delegation is invalid, or an empty string if it is valid.
+func validateReviewDelegation(
+	delegator, delegate string, startsAt, endsAt, now time.Time,
+) string {
+	switch {
+	case delegate == "":
+		return "delegate is required"
+	case strings.EqualFold(delegate, delegator):
+		return "Cannot delegate reviews to yourself"
+	case endsAt.IsZero():
+		return "endsAt is required"
+	case !endsAt.After(startsAt):
+		return "endsAt must be after startsAt"
+	case !endsAt.After(now):
+		return "endsAt must be in the future"
+	}
+	return ""
+}
+
+// reviewDelegationResponse converts a review delegation to its API response.
+func reviewDelegationResponse(d models.ReviewDelegation) ReviewDelegation {
+	resp := ReviewDelegation{
+		ID:        d.ID,
+		Delegator: d.Delegator.EmailAddress,
+		Delegate:  d.Delegate.EmailAddress,
+		StartsAt:  d.StartsAt,
+		EndsAt:    d.EndsAt,
+	}
+	if d.Group != nil {
+		resp.Group = d.Group.EmailAddress
+	}
+	return resp
+}
+
+func writeReviewDelegationsResponse(
+	srv server.Server, w http.ResponseWriter, r *http.Request,
+	status int, resp any,
+) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		srv.Logger.Error("error encoding review delegations response",
+			"error", err,
+			"method", r.Method,
+			"path", r.URL.Path,
+		)
+	}
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -8,13 +9,13 @@ import (
 	"strings"
 	"time"
 
-	"github.com/hashicorp-forge/hermes/internal/config"
 	"github.com/hashicorp-forge/hermes/internal/email"
 	"github.com/hashicorp-forge/hermes/internal/server"
 	"github.com/hashicorp-forge/hermes/pkg/document"
 	hcd "github.com/hashicorp-forge/hermes/pkg/hashicorpdocs"
 	"github.com/hashicorp-forge/hermes/pkg/links"
 	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp-forge/hermes/pkg/tenant"
 	"github.com/hashicorp-forge/hermes/pkg/workspace"
 	"github.com/hashicorp/go-multierror"
 	"google.golang.org/api/drive/v3"
@@ -22,6 +23,7 @@ import (
 
 func ReviewsHandler(srv server.Server) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
 		switch r.Method {
 		case "POST":
 			// revertFuncs is a slice of functions to execute in the event of an error
@@ -36,7 +38,8 @@ func ReviewsHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"path", r.URL.Path,
 				)
-				http.Error(w, "Document ID not found", http.StatusNotFound)
+				writeProblem(w, r, http.StatusNotFound, ErrCodeDocumentNotFound,
+					"Document ID not found")
 				return
 			}
 
@@ -53,12 +56,14 @@ func ReviewsHandler(srv server.Server) http.Handler {
 						"method", r.Method,
 						"doc_id", docID,
 					)
-					http.Error(w, "Error getting document status", http.StatusNotFound)
+					writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound,
+						"Error getting document status")
 					return
 				}
 				// Don't continue if document is locked.
 				if locked {
-					http.Error(w, "Document is locked", http.StatusLocked)
+					writeProblem(w, r, http.StatusLocked, ErrCodeDocumentLocked,
+						"Document is locked")
 					return
 				}
 			}
@@ -85,8 +90,8 @@ func ReviewsHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"doc_id", docID,
 				)
-				http.Error(w, "Error accessing document",
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error accessing document")
 				return
 			}
 
@@ -132,8 +137,8 @@ func ReviewsHandler(srv server.Server) http.Handler {
 					"path", r.URL.Path,
 					"doc_id", docID,
 				)
-				http.Error(w, "Error accessing document",
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error accessing document")
 				return
 			}
 
@@ -144,15 +149,68 @@ func ReviewsHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"path", r.URL.Path,
 				)
-				http.Error(w,
-					"Cannot create review for a document that is not in WIP status",
-					http.StatusUnprocessableEntity)
+				writeProblem(w, r, http.StatusUnprocessableEntity, ErrCodeUnprocessable,
+					"Cannot create review for a document that is not in WIP status")
+				return
+			}
+
+			// Don't publish documents containing sensitive data that blocks
+			// publishing until it is removed.
+			var sensitiveFindings models.DocumentSensitiveFindings
+			if err := sensitiveFindings.Find(tx, model.ID); err != nil {
+				srv.Logger.Error("error getting sensitive findings for document",
+					"error", err,
+					"method", r.Method,
+					"path", r.URL.Path,
+					"doc_id", docID,
+				)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error creating review")
+				return
+			}
+			if blocking := sensitiveFindings.Blocking(); len(blocking) > 0 {
+				srv.Logger.Warn("document contains sensitive data",
+					"doc_id", docID,
+					"method", r.Method,
+					"path", r.URL.Path,
+					"findings", len(blocking),
+				)
+				writeProblem(w, r, http.StatusUnprocessableEntity, ErrCodeUnprocessable,
+					sensitiveDataProblemDetail(blocking))
+				return
+			}
+
+			// Don't publish documents until the review checklist items required
+			// by the workflow of their document type are checked.
+			unchecked, err := uncheckedRequiredChecklistItems(tx,
+				findDocumentType(documentTypes(r.Context(), srv), doc.DocType),
+				model.ID, "In-Review")
+			if err != nil {
+				srv.Logger.Error("error getting checklist items for document",
+					"error", err,
+					"method", r.Method,
+					"path", r.URL.Path,
+					"doc_id", docID,
+				)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error creating review")
+				return
+			}
+			if len(unchecked) > 0 {
+				srv.Logger.Warn("document has unchecked required checklist items",
+					"doc_id", docID,
+					"method", r.Method,
+					"path", r.URL.Path,
+					"items", len(unchecked),
+				)
+				writeProblem(w, r, http.StatusConflict, ErrCodeConflict,
+					uncheckedChecklistProblemDetail("In-Review", unchecked))
 				return
 			}
 
 			// Get latest product number.
 			latestNum, err := models.GetLatestProductNumber(
-				tx, doc.DocType, doc.Product)
+				tx, tenant.IDFromContext(r.Context()), doc.DocType, doc.Product)
 			if err != nil {
 				srv.Logger.Error("error getting product document number",
 					"error", err,
@@ -160,8 +218,8 @@ func ReviewsHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"path", r.URL.Path,
 				)
-				http.Error(w, "Error creating review",
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error creating review")
 				return
 			}
 
@@ -176,8 +234,8 @@ func ReviewsHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"path", r.URL.Path,
 				)
-				http.Error(w, "Error creating review",
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error creating review")
 				return
 			}
 
@@ -196,14 +254,14 @@ func ReviewsHandler(srv server.Server) http.Handler {
 			doc.Status = "In-Review"
 
 			// Replace the doc header.
-			err = doc.ReplaceHeader(srv.Config.BaseURL, false, getGoogleDocsUpdater(srv.WorkspaceProvider))
+			err = replaceDocumentHeader(r.Context(), srv, doc, false)
 			revertFuncs = append(revertFuncs, func() error {
 				// Change back document number to "ABC-???" and status to "WIP".
 				doc.DocNumber = fmt.Sprintf("%s-???", product.Abbreviation)
 				doc.Status = "WIP"
 
-				if err = doc.ReplaceHeader(
-					srv.Config.BaseURL, false, getGoogleDocsUpdater(srv.WorkspaceProvider),
+				if err = replaceDocumentHeader(
+					r.Context(), srv, doc, false,
 				); err != nil {
 					return fmt.Errorf("error replacing doc header: %w", err)
 				}
@@ -213,8 +271,8 @@ func ReviewsHandler(srv server.Server) http.Handler {
 			if err != nil {
 				srv.Logger.Error("error replacing doc header",
 					"error", err, "doc_id", docID)
-				http.Error(w, "Error creating review",
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error creating review")
 
 				if err := revertReviewsPost(revertFuncs); err != nil {
 					srv.Logger.Error("error reverting review creation",
@@ -241,7 +299,8 @@ func ReviewsHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"doc_id", docID,
 				)
-				http.Error(w, "Error creating review", http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error creating review")
 				if err := revertReviewsPost(revertFuncs); err != nil {
 					srv.Logger.Error("error reverting review creation",
 						"error", err,
@@ -261,7 +320,8 @@ func ReviewsHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"doc_id", docID,
 				)
-				http.Error(w, "Error creating review", http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error creating review")
 				if err := revertReviewsPost(revertFuncs); err != nil {
 					srv.Logger.Error("error reverting review creation",
 						"error", err,
@@ -282,8 +342,8 @@ func ReviewsHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"path", r.URL.Path,
 					"doc_id", docID)
-				http.Error(w, "Error creating review",
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error creating review")
 
 				if err := revertReviewsPost(revertFuncs); err != nil {
 					srv.Logger.Error("error reverting review creation",
@@ -306,8 +366,8 @@ func ReviewsHandler(srv server.Server) http.Handler {
 					"path", r.URL.Path,
 					"doc_id", docID,
 					"rev_id", latestRev.RevisionID)
-				http.Error(w, "Error creating review",
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error creating review")
 
 				if err := revertReviewsPost(revertFuncs); err != nil {
 					srv.Logger.Error("error reverting review creation",
@@ -343,8 +403,8 @@ func ReviewsHandler(srv server.Server) http.Handler {
 					"path", r.URL.Path,
 					"doc_id", docID,
 					"rev_id", latestRev.RevisionID)
-				http.Error(w, "Error creating review",
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error creating review")
 
 				if err := revertReviewsPost(revertFuncs); err != nil {
 					srv.Logger.Error("error reverting review creation",
@@ -357,28 +417,37 @@ func ReviewsHandler(srv server.Server) http.Handler {
 			}
 
 			// Move document to published docs location.
-			providerID = fmt.Sprintf("google:%s", docID)
-			_, err = srv.WorkspaceProvider.MoveDocument(
-				r.Context(), providerID, srv.Config.GoogleWorkspace.DocsFolder)
-			revertFuncs = append(revertFuncs, func() error {
-				// Move document back to drafts folder in Google Drive.
-				if _, err := srv.WorkspaceProvider.MoveDocument(
-					r.Context(), fmt.Sprintf("google:%s", doc.ObjectID), srv.Config.GoogleWorkspace.DraftsFolder); err != nil {
+			var draftsFolderID, publishedFolderID string
+			folders := folderLayout(srv)
+			draftsFolderID, err = folders.DraftsFolder(r.Context())
+			if err == nil {
+				publishedFolderID, err = folders.PublishedFolder(
+					r.Context(), doc.DocType, doc.Product)
+			}
+			if err == nil {
+				providerID = fmt.Sprintf("google:%s", docID)
+				_, err = srv.WorkspaceProvider.MoveDocument(
+					r.Context(), providerID, publishedFolderID)
+				revertFuncs = append(revertFuncs, func() error {
+					// Move document back to drafts folder.
+					if _, err := srv.WorkspaceProvider.MoveDocument(
+						r.Context(), fmt.Sprintf("google:%s", doc.ObjectID), draftsFolderID); err != nil {
 
-					return fmt.Errorf("error moving doc back to drafts folder: %w", err)
+						return fmt.Errorf("error moving doc back to drafts folder: %w", err)
 
-				}
+					}
 
-				return nil
-			})
+					return nil
+				})
+			}
 			if err != nil {
 				srv.Logger.Error("error moving file to docs folder",
 					"error", err,
 					"doc_id", docID,
 					"method", r.Method,
 					"path", r.URL.Path)
-				http.Error(w, "Error creating review",
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error creating review")
 
 				if err := revertReviewsPost(revertFuncs); err != nil {
 					srv.Logger.Error("error reverting review creation",
@@ -396,15 +465,16 @@ func ReviewsHandler(srv server.Server) http.Handler {
 			)
 
 			// Create shortcut in hierarchical folder structure.
-			_, err = createShortcut(srv.Config, *doc, getCompatProvider(srv.WorkspaceProvider))
+			_, err = createShortcut(
+				r.Context(), folderLayout(srv), *doc, getCompatProvider(srv.WorkspaceProvider))
 			if err != nil {
 				srv.Logger.Error("error creating shortcut",
 					"error", err,
 					"doc_id", docID,
 					"method", r.Method,
 					"path", r.URL.Path)
-				http.Error(w, "Error creating review",
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error creating review")
 
 				if err := revertReviewsPost(revertFuncs); err != nil {
 					srv.Logger.Error("error reverting review creation",
@@ -440,8 +510,8 @@ func ReviewsHandler(srv server.Server) http.Handler {
 					"doc_id", docID,
 					"method", r.Method,
 					"path", r.URL.Path)
-				http.Error(w, "Error creating review",
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error creating review")
 				if err := revertReviewsPost(revertFuncs); err != nil {
 					srv.Logger.Error("error reverting review creation",
 						"error", err,
@@ -467,8 +537,8 @@ func ReviewsHandler(srv server.Server) http.Handler {
 					"doc_id", docID,
 					"method", r.Method,
 					"path", r.URL.Path)
-				http.Error(w, "Error creating review",
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error creating review")
 
 				if err := revertReviewsPost(revertFuncs); err != nil {
 					srv.Logger.Error("error reverting review creation",
@@ -489,8 +559,7 @@ func ReviewsHandler(srv server.Server) http.Handler {
 					"doc_id", docID,
 					"method", r.Method,
 					"path", r.URL.Path)
-				http.Error(w, "Error creating review",
-					http.StatusInternalServerError)
+				writeUpsertProblem(w, r, err, "Error creating review")
 
 				if err := revertReviewsPost(revertFuncs); err != nil {
 					srv.Logger.Error("error reverting review creation",
@@ -515,8 +584,8 @@ func ReviewsHandler(srv server.Server) http.Handler {
 						"method", r.Method,
 						"path", r.URL.Path,
 						"approver", a)
-					http.Error(w, "Error creating review",
-						http.StatusInternalServerError)
+					writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+						"Error creating review")
 
 					if err := revertReviewsPost(revertFuncs); err != nil {
 						srv.Logger.Error("error reverting review creation",
@@ -538,8 +607,8 @@ func ReviewsHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"path", r.URL.Path,
 				)
-				http.Error(w, "Error creating review",
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error creating review")
 				if err := revertReviewsPost(revertFuncs); err != nil {
 					srv.Logger.Error("error reverting review creation",
 						"error", err,
@@ -556,6 +625,8 @@ func ReviewsHandler(srv server.Server) http.Handler {
 					// TODO: use an asynchronous method for sending emails because we
 					// can't currently recover gracefully from a failure here.
 					for _, approverEmail := range allApprovers {
+						approveURL, requestChangesURL := approvalLinkURLs(
+							r.Context(), srv, docID, approverEmail)
 						err := email.SendReviewRequestedEmail(
 							email.ReviewRequestedEmailData{
 								BaseURL:           srv.Config.BaseURL,
@@ -566,10 +637,14 @@ func ReviewsHandler(srv server.Server) http.Handler {
 								DocumentStatus:    doc.Status,
 								DocumentURL:       docURL,
 								Product:           doc.Product,
+								ApproveURL:        approveURL,
+								RequestChangesURL: requestChangesURL,
+								Locale: userLocale(
+									r.Context(), srv, approverEmail),
 							},
 							[]string{approverEmail},
 							srv.Config.Email.FromAddress,
-							getCompatProvider(srv.WorkspaceProvider),
+							emailSender(srv),
 						)
 						if err != nil {
 							srv.Logger.Error("error sending approver email",
@@ -578,8 +653,8 @@ func ReviewsHandler(srv server.Server) http.Handler {
 								"method", r.Method,
 								"path", r.URL.Path,
 							)
-							http.Error(w, "Error creating review",
-								http.StatusInternalServerError)
+							writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+								"Error creating review")
 							if err := revertReviewsPost(revertFuncs); err != nil {
 								srv.Logger.Error("error reverting review creation",
 									"error", err,
@@ -606,8 +681,8 @@ func ReviewsHandler(srv server.Server) http.Handler {
 					"method", r.Method,
 					"path", r.URL.Path,
 				)
-				http.Error(w, "Error creating review",
-					http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error creating review")
 
 				if err := revertReviewsPost(revertFuncs); err != nil {
 					srv.Logger.Error("error reverting review creation",
@@ -630,230 +705,67 @@ func ReviewsHandler(srv server.Server) http.Handler {
 			)
 
 			// Request post-processing.
-			go func() {
-				ctx := r.Context()
-
-				// Convert document to search index object.
-				docObjMap, err := doc.ToAlgoliaObject(true)
-				if err != nil {
-					srv.Logger.Error("error converting document to search object",
-						"error", err,
-						"method", r.Method,
-						"path", r.URL.Path,
-						"doc_id", docID,
-					)
-					return
-				}
-
-				// Convert map to search.Document via JSON round-trip
-				docObj, err := mapToSearchDocument(docObjMap)
-				if err != nil {
-					srv.Logger.Error("error converting document to search document",
-						"error", err,
-						"method", r.Method,
-						"path", r.URL.Path,
-						"doc_id", docID,
-					)
-					return
-				}
-
-				// Save document object in search index.
-				err = srv.SearchProvider.DocumentIndex().Index(ctx, docObj)
-				if err != nil {
-					srv.Logger.Error("error saving document in search index",
-						"error", err,
-						"method", r.Method,
-						"path", r.URL.Path,
-						"doc_id", docID,
-					)
-					return
-				}
-
-				// Delete document object from drafts search index.
-				err = srv.SearchProvider.DraftIndex().Delete(ctx, docID)
-				if err != nil {
-					srv.Logger.Error("error deleting draft from search index",
-						"error", err,
-						"method", r.Method,
-						"path", r.URL.Path,
-						"doc_id", docID,
-					)
-					return
-				}
-
-				// Send emails to product subscribers, if enabled.
-				if srv.Config.Email != nil && srv.Config.Email.Enabled {
-					p := models.Product{
-						Name: doc.Product,
-					}
-					if err := p.Get(srv.DB); err != nil {
-						srv.Logger.Error("error getting product from database",
-							"error", err,
-							"doc_id", docID,
-							"method", r.Method,
-							"path", r.URL.Path,
-						)
-						return
-					}
-
-					if len(p.UserSubscribers) > 0 {
-						// TODO: use an asynchronous method for sending emails because we
-						// can't currently recover gracefully from a failure here.
-						for _, subscriber := range p.UserSubscribers {
-							err := email.SendSubscriberDocumentPublishedEmail(
-								email.SubscriberDocumentPublishedEmailData{
-									BaseURL:           srv.Config.BaseURL,
-									DocumentOwner:     doc.Owners[0],
-									DocumentShortName: doc.DocNumber,
-									DocumentTitle:     doc.Title,
-									DocumentType:      doc.DocType,
-									DocumentURL:       docURL,
-									Product:           doc.Product,
-								},
-								[]string{subscriber.EmailAddress},
-								srv.Config.Email.FromAddress,
-								getCompatProvider(srv.WorkspaceProvider),
-							)
-							if err != nil {
-								srv.Logger.Error("error sending subscriber email",
-									"error", err,
-									"method", r.Method,
-									"path", r.URL.Path,
-									"doc_id", docID,
-								)
-							} else {
-								srv.Logger.Info("doc subscriber email sent",
-									"doc_id", docID,
-									"method", r.Method,
-									"path", r.URL.Path,
-									"product", doc.Product,
-								)
-							}
-						}
-					}
-				}
-
-				// Compare search index and database documents to find data inconsistencies.
-				// Get document object from search index.
-				searchDoc, err := srv.SearchProvider.DocumentIndex().GetObject(ctx, docID)
-				if err != nil {
-					srv.Logger.Error("error getting search index object for data comparison",
-						"error", err,
-						"method", r.Method,
-						"path", r.URL.Path,
-						"doc_id", docID,
-					)
-					return
-				}
-				// Get document from database.
-				dbDoc := models.Document{
-					GoogleFileID: docID,
-				}
-				if err := dbDoc.Get(srv.DB); err != nil {
-					srv.Logger.Error(
-						"error getting document from database for data comparison",
-						"error", err,
-						"path", r.URL.Path,
-						"method", r.Method,
-						"doc_id", docID,
-					)
-					return
-				}
-				// Get all reviews for the document.
-				var reviews models.DocumentReviews
-				if err := reviews.Find(srv.DB, models.DocumentReview{
-					Document: models.Document{
-						GoogleFileID: docID,
-					},
-				}); err != nil {
-					srv.Logger.Error(
-						"error getting all reviews for document for data comparison",
-						"error", err,
-						"method", r.Method,
-						"path", r.URL.Path,
-						"doc_id", docID,
-					)
-					return
-				}
-
-				// Convert search.Document back to map for comparison
-				searchDocMap, err := searchDocumentToMap(searchDoc)
-				if err != nil {
-					srv.Logger.Error("error converting search document to map",
-						"error", err,
-						"method", r.Method,
-						"path", r.URL.Path,
-						"doc_id", docID,
-					)
-					return
-				}
+			docObj, err := toSearchDocument(doc)
+			if err != nil {
+				srv.Logger.Error("error converting document to search document",
+					"error", err,
+					"method", r.Method,
+					"path", r.URL.Path,
+					"doc_id", docID,
+				)
+				return
+			}
+			enqueueJob(srv, r, indexDocumentJobType, indexDocumentJob{
+				Document:    docObj,
+				DeleteDraft: true,
+				Compare:     true,
+			})
 
-				if err := CompareAlgoliaAndDatabaseDocument(
-					searchDocMap, dbDoc, reviews, srv.Config.DocumentTypes.DocumentType,
-				); err != nil {
-					srv.Logger.Warn(
-						"inconsistencies detected between search index and database docs",
-						"error", err,
-						"method", r.Method,
-						"path", r.URL.Path,
-						"doc_id", docID,
-					)
-				}
-			}()
+			// Send emails to product subscribers, if enabled.
+			if srv.Config.Email != nil && srv.Config.Email.Enabled {
+				enqueueJob(srv, r, documentPublishedEmailsJobType,
+					documentPublishedEmailsJob{
+						Data: email.SubscriberDocumentPublishedEmailData{
+							BaseURL:           srv.Config.BaseURL,
+							DocumentOwner:     doc.Owners[0],
+							DocumentShortName: doc.DocNumber,
+							DocumentTitle:     doc.Title,
+							DocumentType:      doc.DocType,
+							DocumentURL:       docURL,
+							Product:           doc.Product,
+						},
+					})
+			}
 
 		default:
-			w.WriteHeader(http.StatusMethodNotAllowed)
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
 			return
 		}
 	})
 }
 
-// createShortcut creates a shortcut in the hierarchical folder structure
-// ("Shortcuts Folder/RFC/MyProduct/") under docsFolder.
+// createShortcut creates a shortcut to a published document in the shortcuts
+// folder of its doc type and product ("Shortcuts Folder/RFC/MyProduct/"). No
+// shortcut is created if the folder layout doesn't have shortcuts.
 func createShortcut(
-	cfg *config.Config,
+	ctx context.Context,
+	folders workspace.FolderLayout,
 	doc document.Document,
 	provider workspace.Provider) (shortcut *drive.File, retErr error) {
 
-	// Get folder for doc type.
-	docTypeFolderID, err := provider.GetSubfolder(
-		cfg.GoogleWorkspace.ShortcutsFolder, doc.DocType)
+	folderID, err := folders.ShortcutsFolder(ctx, doc.DocType, doc.Product)
 	if err != nil {
-		return nil, fmt.Errorf("error getting doc type subfolder: %w", err)
+		return nil, fmt.Errorf("error getting shortcuts folder: %w", err)
 	}
-
-	// Doc type folder wasn't found, so create it.
-	if docTypeFolderID == "" {
-		var docTypeFolder *drive.File
-		docTypeFolder, err = provider.CreateFolder(
-			doc.DocType, cfg.GoogleWorkspace.ShortcutsFolder)
-		if err != nil {
-			return nil, fmt.Errorf("error creating doc type subfolder: %w", err)
-		}
-		docTypeFolderID = docTypeFolder.Id
-	}
-
-	// Get folder for doc type + product.
-	productFolderID, err := provider.GetSubfolder(docTypeFolderID, doc.Product)
-	if err != nil {
-		return nil, fmt.Errorf("error getting product subfolder: %w", err)
-	}
-
-	// Product folder wasn't found, so create it.
-	if productFolderID == "" {
-		var productFolder *drive.File
-		productFolder, err = provider.CreateFolder(
-			doc.Product, docTypeFolderID)
-		if err != nil {
-			return nil, fmt.Errorf("error creating product subfolder: %w", err)
-		}
-		productFolderID = productFolder.Id
+	if folderID == "" {
+		return nil, nil
 	}
 
 	// Create shortcut.
 	if shortcut, err = provider.CreateShortcut(
 		doc.ObjectID,
-		productFolderID); err != nil {
+		folderID); err != nil {
 
 		return nil, fmt.Errorf("error creating shortcut: %w", err)
 	}
@@ -889,3 +801,16 @@ func revertReviewsPost(funcs []func() error) error {
 
 	return result.ErrorOrNil()
 }
+
+// sensitiveDataProblemDetail describes the sensitive data that blocks
+// publishing a document.
+func sensitiveDataProblemDetail(findings models.DocumentSensitiveFindings) string {
+	found := make([]string, len(findings))
+	for i, f := range findings {
+		found[i] = fmt.Sprintf("%s on line %d", f.Rule, f.Line)
+	}
+	return fmt.Sprintf(
+		"Cannot create review for a document that contains sensitive data (%s); "+
+			"remove it from the document and try again once the document is "+
+			"scanned again", strings.Join(found, ", "))
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+// saga tracks compensating actions for a multi-step operation that spans
+// systems without a shared transaction (e.g., workspace provider, database,
+// and search index). As each step succeeds, the handler registers an action
+// that undoes it. If the operation does not complete, Compensate runs the
+// registered actions in reverse order so partial failures don't leave
+// orphaned resources behind.
+//
+// Usage:
+//
+//	s := newSaga("create draft", srv.Logger)
+//	defer s.Compensate(r.Context())
+//	// ... step 1 ...
+//	s.AddCompensation("delete file", func(ctx context.Context) error { ... })
+//	// ... step 2 ...
+//	s.Complete()
+type saga struct {
+	name      string
+	logger    hclog.Logger
+	steps     []sagaStep
+	completed bool
+}
+
+// sagaStep is a named compensating action.
+type sagaStep struct {
+	name string
+	undo func(ctx context.Context) error
+}
+
+// newSaga returns a new saga for the named operation.
+func newSaga(name string, logger hclog.Logger) *saga {
+	if logger == nil {
+		logger = hclog.NewNullLogger()
+	}
+	return &saga{
+		name:   name,
+		logger: logger,
+	}
+}
+
+// AddCompensation registers an action that undoes a completed step.
+func (s *saga) AddCompensation(name string, undo func(ctx context.Context) error) {
+	s.steps = append(s.steps, sagaStep{name: name, undo: undo})
+}
+
+// Complete marks the operation as successful so Compensate does nothing.
+func (s *saga) Complete() {
+	s.completed = true
+}
+
+// Compensate runs the registered compensating actions in reverse order if the
+// operation was not completed. All actions are attempted even if some fail;
+// failures are logged and returned joined together. The actions run with a
+// context that is not canceled when ctx is, so a client disconnecting (a
+// common cause of partial failures) does not prevent cleanup.
+func (s *saga) Compensate(ctx context.Context) error {
+	if s.completed || len(s.steps) == 0 {
+		return nil
+	}
+	ctx = context.WithoutCancel(ctx)
+
+	s.logger.Warn("compensating incomplete operation",
+		"operation", s.name,
+		"steps", len(s.steps),
+	)
+
+	var errs []error
+	for i := len(s.steps) - 1; i >= 0; i-- {
+		step := s.steps[i]
+		if err := step.undo(ctx); err != nil {
+			s.logger.Error("error compensating step",
+				"error", err,
+				"operation", s.name,
+				"step", step.name,
+			)
+			errs = append(errs, fmt.Errorf("%s: %w", step.name, err))
+		}
+	}
+	s.steps = nil
+
+	return errors.Join(errs...)
+}
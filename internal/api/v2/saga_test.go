@@ -0,0 +1,76 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSaga(t *testing.T) {
+	t.Run("compensates in reverse order", func(t *testing.T) {
+		var undone []string
+		s := newSaga("test", nil)
+		s.AddCompensation("first", func(ctx context.Context) error {
+			undone = append(undone, "first")
+			return nil
+		})
+		s.AddCompensation("second", func(ctx context.Context) error {
+			undone = append(undone, "second")
+			return nil
+		})
+
+		assert.NoError(t, s.Compensate(context.Background()))
+		assert.Equal(t, []string{"second", "first"}, undone)
+
+		// Compensating again is a no-op.
+		assert.NoError(t, s.Compensate(context.Background()))
+		assert.Len(t, undone, 2)
+	})
+
+	t.Run("completed saga does not compensate", func(t *testing.T) {
+		called := false
+		s := newSaga("test", nil)
+		s.AddCompensation("step", func(ctx context.Context) error {
+			called = true
+			return nil
+		})
+		s.Complete()
+
+		assert.NoError(t, s.Compensate(context.Background()))
+		assert.False(t, called)
+	})
+
+	t.Run("continues after errors", func(t *testing.T) {
+		errUndo := errors.New("undo failed")
+		called := false
+		s := newSaga("test", nil)
+		s.AddCompensation("first", func(ctx context.Context) error {
+			called = true
+			return nil
+		})
+		s.AddCompensation("second", func(ctx context.Context) error {
+			return errUndo
+		})
+
+		err := s.Compensate(context.Background())
+		assert.ErrorIs(t, err, errUndo)
+		assert.True(t, called)
+	})
+
+	t.Run("runs after context is canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		var ctxErr error
+		s := newSaga("test", nil)
+		s.AddCompensation("step", func(ctx context.Context) error {
+			ctxErr = ctx.Err()
+			return nil
+		})
+
+		assert.NoError(t, s.Compensate(ctx))
+		assert.NoError(t, ctxErr)
+	})
+}
@@ -0,0 +1,478 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/server"
+	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"gorm.io/gorm"
+)
+
+// SavedSearch is a saved search in API responses.
+type SavedSearch struct {
+	ID        uint                `json:"id"`
+	Name      string              `json:"name"`
+	Owner     string              `json:"owner"`
+	Query     string              `json:"query"`
+	Filters   map[string][]string `json:"filters"`
+	Facets    []string            `json:"facets"`
+	SortBy    string              `json:"sortBy,omitempty"`
+	SortOrder string              `json:"sortOrder,omitempty"`
+
+	// SharedWithGroups are the email addresses of the groups whose members can
+	// use the search.
+	SharedWithGroups []string `json:"sharedWithGroups"`
+
+	// Subscribed is true if the authenticated user receives digests of new
+	// documents that match the search.
+	Subscribed bool `json:"subscribed"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// SavedSearchesPostRequest contains the fields to save a search.
+type SavedSearchesPostRequest struct {
+	Name      string              `json:"name"`
+	Query     string              `json:"query,omitempty"`
+	Filters   map[string][]string `json:"filters,omitempty"`
+	Facets    []string            `json:"facets,omitempty"`
+	SortBy    string              `json:"sortBy,omitempty"`
+	SortOrder string              `json:"sortOrder,omitempty"`
+
+	// SharedWithGroups are the email addresses of groups to share the search
+	// with. The user must be a member of the groups.
+	SharedWithGroups []string `json:"sharedWithGroups,omitempty"`
+
+	// Subscribe subscribes the user to digests of the search.
+	Subscribe bool `json:"subscribe,omitempty"`
+}
+
+// SavedSearchPatchRequest contains the fields of a saved search to update.
+// Omitted fields are not changed.
+type SavedSearchPatchRequest struct {
+	Name             *string              `json:"name,omitempty"`
+	Query            *string              `json:"query,omitempty"`
+	Filters          *map[string][]string `json:"filters,omitempty"`
+	Facets           *[]string            `json:"facets,omitempty"`
+	SortBy           *string              `json:"sortBy,omitempty"`
+	SortOrder        *string              `json:"sortOrder,omitempty"`
+	SharedWithGroups *[]string            `json:"sharedWithGroups,omitempty"`
+}
+
+// SavedSearchesHandler handles the authenticated user's saved searches.
+// GET /api/v2/me/saved-searches lists the searches saved by the user or shared
+// with the user's groups, and POST saves a search.
+// GET, PATCH, and DELETE /api/v2/me/saved-searches/:id get, update, and delete
+// a search; only its owner can update or delete it.
+// PUT and DELETE /api/v2/me/saved-searches/:id/subscription subscribe and
+// unsubscribe the user to and from digests of new documents that match it.
+func SavedSearchesHandler(srv server.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
+		userEmail, ok := pkgauth.GetUserEmail(r.Context())
+		if !ok || userEmail == "" {
+			writeProblem(w, r, http.StatusUnauthorized, ErrCodeUnauthorized,
+				"No authorization information for request")
+			return
+		}
+
+		errResp := func(httpCode int, userErrMsg, logErrMsg string, err error) {
+			srv.Logger.Error(logErrMsg,
+				"error", err,
+				"method", r.Method,
+				"path", r.URL.Path,
+			)
+			writeProblem(w, r, httpCode, errorCodeForStatus(httpCode), userErrMsg)
+		}
+
+		db := srv.DB.WithContext(r.Context())
+
+		rest := strings.TrimPrefix(
+			strings.TrimPrefix(r.URL.Path, "/api/v2/me/saved-searches"), "/")
+
+		if rest == "" {
+			switch r.Method {
+			case "GET":
+				groups, err := userTeamEmails(r.Context(), srv, userEmail)
+				if err != nil {
+					errResp(http.StatusInternalServerError,
+						"Error getting saved searches",
+						"error getting teams for user", err)
+					return
+				}
+
+				var searches models.SavedSearches
+				if err := searches.FindForUser(db, userEmail, groups); err != nil {
+					errResp(http.StatusInternalServerError,
+						"Error getting saved searches",
+						"error finding saved searches", err)
+					return
+				}
+
+				resp := []SavedSearch{}
+				for _, s := range searches {
+					resp = append(resp, savedSearchResponse(s, userEmail))
+				}
+				writeSavedSearchesResponse(srv, w, r, http.StatusOK, resp)
+
+			case "POST":
+				var req SavedSearchesPostRequest
+				if err := decodeRequest(r, &req); err != nil {
+					writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+						fmt.Sprintf("Bad request: %q", err))
+					return
+				}
+				if msg := validateSavedSearch(req.Name, req.SortOrder); msg != "" {
+					writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest, msg)
+					return
+				}
+
+				groups, ok := savedSearchGroups(
+					w, r, srv, userEmail, req.SharedWithGroups, errResp)
+				if !ok {
+					return
+				}
+
+				s := models.SavedSearch{
+					Owner: models.User{
+						EmailAddress: userEmail,
+					},
+					Name:             req.Name,
+					Query:            req.Query,
+					Filters:          req.Filters,
+					Facets:           req.Facets,
+					SortBy:           req.SortBy,
+					SortOrder:        req.SortOrder,
+					SharedWithGroups: groups,
+				}
+				if err := s.Create(db); err != nil {
+					if errors.Is(err, models.ErrSavedSearchNameTaken) {
+						writeProblem(w, r, http.StatusConflict, ErrCodeConflict,
+							"You already have a saved search with this name")
+						return
+					}
+					errResp(http.StatusInternalServerError,
+						"Error saving search",
+						"error creating saved search", err)
+					return
+				}
+				if req.Subscribe {
+					if err := s.Subscribe(db, userEmail); err != nil {
+						errResp(http.StatusInternalServerError,
+							"Error subscribing to saved search",
+							"error subscribing to saved search", err)
+						return
+					}
+				}
+				if err := s.Get(db); err != nil {
+					errResp(http.StatusInternalServerError,
+						"Error saving search",
+						"error getting saved search", err)
+					return
+				}
+
+				writeSavedSearchesResponse(srv, w, r, http.StatusCreated,
+					savedSearchResponse(s, userEmail))
+
+			default:
+				writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+					"Method not allowed")
+			}
+			return
+		}
+
+		idStr, sub, _ := strings.Cut(rest, "/")
+		if sub != "" && sub != "subscription" {
+			writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound,
+				"Saved search not found")
+			return
+		}
+		id, err := strconv.ParseUint(idStr, 10, 64)
+		if err != nil || id == 0 {
+			writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound,
+				"Saved search not found")
+			return
+		}
+
+		s := models.SavedSearch{}
+		s.ID = uint(id)
+		if err := s.Get(db); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound,
+					"Saved search not found")
+				return
+			}
+			errResp(http.StatusInternalServerError,
+				"Error accessing saved search",
+				"error getting saved search", err)
+			return
+		}
+
+		isOwner := strings.EqualFold(s.Owner.EmailAddress, userEmail)
+		if !isOwner {
+			// Searches not shared with any of the user's groups are not found,
+			// so their existence isn't disclosed.
+			groups, err := userTeamEmails(r.Context(), srv, userEmail)
+			if err != nil {
+				errResp(http.StatusInternalServerError,
+					"Error accessing saved search",
+					"error getting teams for user", err)
+				return
+			}
+			if !savedSearchSharedWith(s, groups) {
+				writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound,
+					"Saved search not found")
+				return
+			}
+		}
+
+		if sub == "subscription" {
+			switch r.Method {
+			case "PUT":
+				if err := s.Subscribe(db, userEmail); err != nil {
+					errResp(http.StatusInternalServerError,
+						"Error subscribing to saved search",
+						"error subscribing to saved search", err)
+					return
+				}
+			case "DELETE":
+				if err := s.Unsubscribe(db, userEmail); err != nil {
+					errResp(http.StatusInternalServerError,
+						"Error unsubscribing from saved search",
+						"error unsubscribing from saved search", err)
+					return
+				}
+			default:
+				writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+					"Method not allowed")
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		switch r.Method {
+		case "GET":
+			writeSavedSearchesResponse(srv, w, r, http.StatusOK,
+				savedSearchResponse(s, userEmail))
+
+		case "PATCH":
+			if !isOwner {
+				writeProblem(w, r, http.StatusForbidden, ErrCodeForbidden,
+					"Only the owner can update a saved search")
+				return
+			}
+
+			var req SavedSearchPatchRequest
+			if err := decodeRequest(r, &req); err != nil {
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					fmt.Sprintf("Bad request: %q", err))
+				return
+			}
+			if req.Name != nil {
+				s.Name = *req.Name
+			}
+			if req.Query != nil {
+				s.Query = *req.Query
+			}
+			if req.Filters != nil {
+				s.Filters = *req.Filters
+			}
+			if req.Facets != nil {
+				s.Facets = *req.Facets
+			}
+			if req.SortBy != nil {
+				s.SortBy = *req.SortBy
+			}
+			if req.SortOrder != nil {
+				s.SortOrder = *req.SortOrder
+			}
+			if msg := validateSavedSearch(s.Name, s.SortOrder); msg != "" {
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest, msg)
+				return
+			}
+			if req.SharedWithGroups != nil {
+				groups, ok := savedSearchGroups(
+					w, r, srv, userEmail, *req.SharedWithGroups, errResp)
+				if !ok {
+					return
+				}
+				s.SharedWithGroups = groups
+			}
+
+			if err := s.Update(db); err != nil {
+				if errors.Is(err, models.ErrSavedSearchNameTaken) {
+					writeProblem(w, r, http.StatusConflict, ErrCodeConflict,
+						"You already have a saved search with this name")
+					return
+				}
+				errResp(http.StatusInternalServerError,
+					"Error updating saved search",
+					"error updating saved search", err)
+				return
+			}
+
+			writeSavedSearchesResponse(srv, w, r, http.StatusOK,
+				savedSearchResponse(s, userEmail))
+
+		case "DELETE":
+			if !isOwner {
+				writeProblem(w, r, http.StatusForbidden, ErrCodeForbidden,
+					"Only the owner can delete a saved search")
+				return
+			}
+
+			if err := s.Delete(db); err != nil {
+				errResp(http.StatusInternalServerError,
+					"Error deleting saved search",
+					"error deleting saved search", err)
+				return
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+
+		default:
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
+		}
+	})
+}
+
+// savedSearchGroups returns the groups to share a saved search with. Users can
+// only share searches with groups they are members of; if emails includes
+// another group, it writes an error response and returns false.
+func savedSearchGroups(
+	w http.ResponseWriter, r *http.Request, srv server.Server,
+	userEmail string, emails []string,
+	errResp func(httpCode int, userErrMsg, logErrMsg string, err error),
+) ([]models.Group, bool) {
+	groups := []models.Group{}
+	if len(emails) == 0 {
+		return groups, true
+	}
+
+	userGroups, err := userTeamEmails(r.Context(), srv, userEmail)
+	if err != nil {
+		errResp(http.StatusInternalServerError,
+			"Error sharing saved search",
+			"error getting teams for user", err)
+		return nil, false
+	}
+	for _, email := range emails {
+		if !containsFold(userGroups, email) {
+			writeProblem(w, r, http.StatusForbidden, ErrCodeForbidden,
+				fmt.Sprintf(
+					"Can only share searches with groups you are a member of: %s",
+					email))
+			return nil, false
+		}
+		groups = append(groups, models.Group{EmailAddress: email})
+	}
+	return groups, true
+}
+
+// userTeamEmails returns the email addresses of the teams of the user with
+// email address email.
+func userTeamEmails(
+	ctx context.Context, srv server.Server, email string,
+) ([]string, error) {
+	teams, err := srv.WorkspaceProvider.GetUserTeams(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	var emails []string
+	for _, t := range teams {
+		if t != nil && t.Email != "" {
+			emails = append(emails, t.Email)
+		}
+	}
+	return emails, nil
+}
+
+// savedSearchSharedWith returns true if saved search s is shared with any of
+// groups.
+func savedSearchSharedWith(s models.SavedSearch, groups []string) bool {
+	for _, g := range s.SharedWithGroups {
+		if containsFold(groups, g.EmailAddress) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsFold returns true if values contains v, ignoring case.
+func containsFold(values []string, v string) bool {
+	for _, s := range values {
+		if strings.EqualFold(s, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateSavedSearch returns a user-facing error message if a saved search is
+// invalid, or an empty string if it is valid.
+func validateSavedSearch(name, sortOrder string) string {
+	switch {
+	case strings.TrimSpace(name) == "":
+		return "name is required"
+	case len(name) > 255:
+		return "name must be at most 255 characters"
+	case sortOrder != "" && sortOrder != "asc" && sortOrder != "desc":
+		return `sortOrder must be "asc" or "desc"`
+	}
+	return ""
+}
+
+// savedSearchResponse converts a saved search to its API response for the user
+// with email address userEmail.
+func savedSearchResponse(s models.SavedSearch, userEmail string) SavedSearch {
+	resp := SavedSearch{
+		ID:               s.ID,
+		Name:             s.Name,
+		Owner:            s.Owner.EmailAddress,
+		Query:            s.Query,
+		Filters:          s.Filters,
+		Facets:           s.Facets,
+		SortBy:           s.SortBy,
+		SortOrder:        s.SortOrder,
+		SharedWithGroups: []string{},
+		Subscribed:       s.HasSubscriber(userEmail),
+		CreatedAt:        s.CreatedAt,
+		UpdatedAt:        s.UpdatedAt,
+	}
+	if resp.Filters == nil {
+		resp.Filters = map[string][]string{}
+	}
+	if resp.Facets == nil {
+		resp.Facets = []string{}
+	}
+	for _, g := range s.SharedWithGroups {
+		resp.SharedWithGroups = append(resp.SharedWithGroups, g.EmailAddress)
+	}
+	return resp
+}
+
+func writeSavedSearchesResponse(
+	srv server.Server, w http.ResponseWriter, r *http.Request,
+	status int, resp any,
+) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		srv.Logger.Error("error encoding saved searches response",
+			"error", err,
+			"method", r.Method,
+			"path", r.URL.Path,
+		)
+	}
+}
@@ -0,0 +1,29 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSavedSearch(t *testing.T) {
+	assert.Empty(t, validateSavedSearch("RFCs", ""))
+	assert.Empty(t, validateSavedSearch("RFCs", "desc"))
+	assert.NotEmpty(t, validateSavedSearch(" ", ""))
+	assert.NotEmpty(t, validateSavedSearch("RFCs", "newest"))
+}
+
+func TestSavedSearchSharedWith(t *testing.T) {
+	s := models.SavedSearch{
+		SharedWithGroups: []models.Group{
+			{EmailAddress: "Team@example.com"},
+		},
+	}
+
+	assert.True(t, savedSearchSharedWith(s,
+		[]string{"other@example.com", "team@example.com"}))
+	assert.False(t, savedSearchSharedWith(s, []string{"other@example.com"}))
+	assert.False(t, savedSearchSharedWith(models.SavedSearch{},
+		[]string{"team@example.com"}))
+}
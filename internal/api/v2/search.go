@@ -22,9 +22,11 @@ import (
 // The index parameter can be: "docs", "drafts", "internal", or "projects"
 func SearchHandler(srv server.Server) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
 		// Only support POST for search operations
 		if r.Method != http.MethodPost {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
 			return
 		}
 
@@ -36,8 +38,8 @@ func SearchHandler(srv server.Server) http.Handler {
 				"path", r.URL.Path,
 				"method", r.Method,
 			)
-			http.Error(w, "Invalid search path, expected /api/v2/search/{index}",
-				http.StatusBadRequest)
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				"Invalid search path, expected /api/v2/search/{index}")
 			return
 		}
 
@@ -48,7 +50,8 @@ func SearchHandler(srv server.Server) http.Handler {
 				"method", r.Method,
 				"path", r.URL.Path,
 			)
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			writeProblem(w, r, http.StatusUnauthorized, ErrCodeUnauthorized,
+				"Unauthorized")
 			return
 		}
 
@@ -60,7 +63,8 @@ func SearchHandler(srv server.Server) http.Handler {
 				"method", r.Method,
 				"path", r.URL.Path,
 			)
-			http.Error(w, "Invalid search request body", http.StatusBadRequest)
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				"Invalid search request body")
 			return
 		}
 
@@ -92,7 +96,8 @@ func SearchHandler(srv server.Server) http.Handler {
 				"method", r.Method,
 				"path", r.URL.Path,
 			)
-			http.Error(w, "Unsupported index name", http.StatusBadRequest)
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				"Unsupported index name")
 			return
 		}
 
@@ -105,7 +110,8 @@ func SearchHandler(srv server.Server) http.Handler {
 				"path", r.URL.Path,
 				"user_email", userEmail,
 			)
-			http.Error(w, "Error executing search", http.StatusInternalServerError)
+			writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Error executing search")
 			return
 		}
 
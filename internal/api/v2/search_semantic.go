@@ -74,8 +74,10 @@ type HybridSearchResult struct {
 // Uses OpenAI embeddings and pgvector to find semantically similar documents.
 func SemanticSearchHandler(srv server.Server) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
 		if r.Method != http.MethodPost {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
 			return
 		}
 
@@ -86,7 +88,8 @@ func SemanticSearchHandler(srv server.Server) http.Handler {
 				"method", r.Method,
 				"path", r.URL.Path,
 			)
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			writeProblem(w, r, http.StatusUnauthorized, ErrCodeUnauthorized,
+				"Unauthorized")
 			return
 		}
 
@@ -96,7 +99,8 @@ func SemanticSearchHandler(srv server.Server) http.Handler {
 				"method", r.Method,
 				"path", r.URL.Path,
 			)
-			http.Error(w, "Semantic search not available", http.StatusServiceUnavailable)
+			writeProblem(w, r, http.StatusServiceUnavailable, ErrCodeServiceUnavailable,
+				"Semantic search not available")
 			return
 		}
 
@@ -108,13 +112,15 @@ func SemanticSearchHandler(srv server.Server) http.Handler {
 				"method", r.Method,
 				"path", r.URL.Path,
 			)
-			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				"Invalid request body")
 			return
 		}
 
 		// Validate query
 		if strings.TrimSpace(req.Query) == "" {
-			http.Error(w, "Query cannot be empty", http.StatusBadRequest)
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				"Query cannot be empty")
 			return
 		}
 
@@ -149,7 +155,8 @@ func SemanticSearchHandler(srv server.Server) http.Handler {
 				"query", req.Query,
 				"user", userEmail,
 			)
-			http.Error(w, "Search failed", http.StatusInternalServerError)
+			writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Search failed")
 			return
 		}
 
@@ -204,8 +211,10 @@ func SemanticSearchHandler(srv server.Server) http.Handler {
 // using configurable weights.
 func HybridSearchHandler(srv server.Server) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
 		if r.Method != http.MethodPost {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
 			return
 		}
 
@@ -216,7 +225,8 @@ func HybridSearchHandler(srv server.Server) http.Handler {
 				"method", r.Method,
 				"path", r.URL.Path,
 			)
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			writeProblem(w, r, http.StatusUnauthorized, ErrCodeUnauthorized,
+				"Unauthorized")
 			return
 		}
 
@@ -226,7 +236,8 @@ func HybridSearchHandler(srv server.Server) http.Handler {
 				"method", r.Method,
 				"path", r.URL.Path,
 			)
-			http.Error(w, "Hybrid search not available", http.StatusServiceUnavailable)
+			writeProblem(w, r, http.StatusServiceUnavailable, ErrCodeServiceUnavailable,
+				"Hybrid search not available")
 			return
 		}
 
@@ -238,13 +249,15 @@ func HybridSearchHandler(srv server.Server) http.Handler {
 				"method", r.Method,
 				"path", r.URL.Path,
 			)
-			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				"Invalid request body")
 			return
 		}
 
 		// Validate query
 		if strings.TrimSpace(req.Query) == "" {
-			http.Error(w, "Query cannot be empty", http.StatusBadRequest)
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				"Query cannot be empty")
 			return
 		}
 
@@ -284,7 +297,8 @@ func HybridSearchHandler(srv server.Server) http.Handler {
 				"query", req.Query,
 				"user", userEmail,
 			)
-			http.Error(w, "Search failed", http.StatusInternalServerError)
+			writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Search failed")
 			return
 		}
 
@@ -334,8 +348,10 @@ func HybridSearchHandler(srv server.Server) http.Handler {
 // Uses the document's existing embeddings to find similar documents via vector similarity.
 func SimilarDocumentsHandler(srv server.Server) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
 		if r.Method != http.MethodGet {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
 			return
 		}
 
@@ -346,7 +362,8 @@ func SimilarDocumentsHandler(srv server.Server) http.Handler {
 				"method", r.Method,
 				"path", r.URL.Path,
 			)
-			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			writeProblem(w, r, http.StatusUnauthorized, ErrCodeUnauthorized,
+				"Unauthorized")
 			return
 		}
 
@@ -356,7 +373,8 @@ func SimilarDocumentsHandler(srv server.Server) http.Handler {
 				"method", r.Method,
 				"path", r.URL.Path,
 			)
-			http.Error(w, "Semantic search not available", http.StatusServiceUnavailable)
+			writeProblem(w, r, http.StatusServiceUnavailable, ErrCodeServiceUnavailable,
+				"Semantic search not available")
 			return
 		}
 
@@ -364,13 +382,15 @@ func SimilarDocumentsHandler(srv server.Server) http.Handler {
 		// Expected format: /api/v2/documents/{documentID}/similar
 		pathParts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
 		if len(pathParts) < 5 || pathParts[4] != "similar" {
-			http.Error(w, "Invalid path format", http.StatusBadRequest)
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				"Invalid path format")
 			return
 		}
 		documentID := pathParts[3]
 
 		if documentID == "" {
-			http.Error(w, "Document ID required", http.StatusBadRequest)
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				"Document ID required")
 			return
 		}
 
@@ -394,7 +414,8 @@ func SimilarDocumentsHandler(srv server.Server) http.Handler {
 				"documentID", documentID,
 				"user", userEmail,
 			)
-			http.Error(w, "Failed to find similar documents", http.StatusInternalServerError)
+			writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Failed to find similar documents")
 			return
 		}
 
@@ -0,0 +1,150 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+)
+
+// serviceTokenContextKey is the context key for the service token that
+// authenticated a request.
+type serviceTokenContextKey struct{}
+
+// ServiceTokenPolicy describes the service tokens allowed to make a request.
+type ServiceTokenPolicy struct {
+	// Name identifies the API in logs and errors (e.g., "edge sync").
+	Name string
+
+	// TokenTypes are the allowed token types.
+	TokenTypes []string
+
+	// Scope returns the scope a request requires.
+	Scope func(r *http.Request) string
+}
+
+// serviceTokenError is a service token authentication failure.
+type serviceTokenError struct {
+	status int
+	code   ErrorCode
+	detail string
+}
+
+// ServiceTokenAuthMiddleware authenticates requests with a service token in
+// the Authorization header (Bearer <token>) and authorizes them with policy.
+// It is shared by every service token authenticated API, so scopes and
+// last-used tracking are enforced the same way everywhere:
+//   - The token must exist and not be expired or revoked
+//   - The token type must be one of policy.TokenTypes
+//   - The token must have the scope the request requires
+//   - The token's last-used time is recorded
+//
+// The token is available to next with serviceTokenFromContext.
+func ServiceTokenAuthMiddleware(
+	srv server.Server, policy ServiceTokenPolicy, next http.Handler,
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
+		token, tErr := bearerToken(r)
+		if tErr == nil {
+			var t *models.IndexerToken
+			t, tErr = authorizeServiceToken(srv, r, policy, token)
+			if tErr == nil {
+				ctx := context.WithValue(r.Context(), serviceTokenContextKey{}, t)
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+		}
+
+		srv.Logger.Warn(policy.Name+": rejected service token request",
+			"reason", tErr.detail,
+			"path", r.URL.Path,
+			"method", r.Method,
+		)
+		writeProblem(w, r, tErr.status, tErr.code, tErr.detail)
+	})
+}
+
+// bearerToken returns the bearer token in the Authorization header of r.
+func bearerToken(r *http.Request) (string, *serviceTokenError) {
+	authHeader := r.Header.Get("Authorization")
+	if authHeader == "" {
+		return "", &serviceTokenError{http.StatusUnauthorized,
+			ErrCodeUnauthorized, "Missing authorization header"}
+	}
+	token, ok := strings.CutPrefix(authHeader, "Bearer ")
+	if !ok {
+		return "", &serviceTokenError{http.StatusUnauthorized,
+			ErrCodeUnauthorized, "Invalid authorization header format"}
+	}
+	if token == "" {
+		return "", &serviceTokenError{http.StatusUnauthorized,
+			ErrCodeUnauthorized, "Empty bearer token"}
+	}
+	return token, nil
+}
+
+// authorizeServiceToken looks up a plaintext service token, checks that it
+// may make request r under policy, and records its use.
+func authorizeServiceToken(
+	srv server.Server, r *http.Request, policy ServiceTokenPolicy, token string,
+) (*models.IndexerToken, *serviceTokenError) {
+	var t models.IndexerToken
+	if err := t.GetByToken(srv.DB, token); err != nil {
+		return nil, &serviceTokenError{http.StatusUnauthorized,
+			ErrCodeUnauthorized, "Invalid or expired token"}
+	}
+	if !t.IsValid() {
+		return nil, &serviceTokenError{http.StatusUnauthorized,
+			ErrCodeUnauthorized, "Token has expired or been revoked"}
+	}
+
+	typeAllowed := false
+	for _, tt := range policy.TokenTypes {
+		if t.TokenType == tt {
+			typeAllowed = true
+			break
+		}
+	}
+	if !typeAllowed {
+		return nil, &serviceTokenError{http.StatusForbidden, ErrCodeForbidden,
+			fmt.Sprintf("Invalid token type for %s", policy.Name)}
+	}
+
+	if scope := policy.Scope(r); !t.HasScope(scope) {
+		return nil, &serviceTokenError{http.StatusForbidden, ErrCodeForbidden,
+			fmt.Sprintf("Token does not have the %q scope", scope)}
+	}
+
+	if err := t.MarkUsed(srv.DB); err != nil {
+		// Not fatal; the request is still authenticated.
+		srv.Logger.Warn(policy.Name+": error recording token use",
+			"error", err,
+			"token_id", t.ID,
+		)
+	}
+
+	srv.Logger.Debug(policy.Name+": authenticated request",
+		"token_id", t.ID,
+		"token_type", t.TokenType,
+		"path", r.URL.Path,
+		"method", r.Method,
+	)
+	return &t, nil
+}
+
+// serviceTokenFromContext returns the service token that authenticated a
+// request.
+func serviceTokenFromContext(ctx context.Context) (*models.IndexerToken, bool) {
+	t, ok := ctx.Value(serviceTokenContextKey{}).(*models.IndexerToken)
+	return t, ok
+}
+
+// scope returns a ServiceTokenPolicy.Scope function that requires scope for
+// every request.
+func scope(s string) func(*http.Request) string {
+	return func(*http.Request) string { return s }
+}
@@ -53,14 +53,16 @@ type SetupConfigResponse struct {
 func SetupStatusHandler(configPath string, log hclog.Logger) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
-			w.WriteHeader(http.StatusMethodNotAllowed)
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
 			return
 		}
 
 		workingDir, err := os.Getwd()
 		if err != nil {
 			log.Error("error getting working directory", "error", err)
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Internal server error")
 			return
 		}
 
@@ -83,14 +85,16 @@ func SetupStatusHandler(configPath string, log hclog.Logger) http.Handler {
 func SetupConfigureHandler(log hclog.Logger) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
-			w.WriteHeader(http.StatusMethodNotAllowed)
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
 			return
 		}
 
 		var req SetupConfigRequest
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 			log.Error("error decoding setup request", "error", err)
-			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				"Invalid request body")
 			return
 		}
 
@@ -98,7 +102,8 @@ func SetupConfigureHandler(log hclog.Logger) http.Handler {
 		workingDir, err := os.Getwd()
 		if err != nil {
 			log.Error("error getting working directory", "error", err)
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				"Internal server error")
 			return
 		}
 
@@ -106,14 +111,16 @@ func SetupConfigureHandler(log hclog.Logger) http.Handler {
 		workspacePath, err := validateWorkspacePath(req.WorkspacePath, workingDir)
 		if err != nil {
 			log.Error("invalid workspace path", "error", err, "path", req.WorkspacePath)
-			http.Error(w, fmt.Sprintf("Invalid workspace path: %v", err), http.StatusBadRequest)
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				fmt.Sprintf("Invalid workspace path: %v", err))
 			return
 		}
 
 		// Create workspace directory structure if it doesn't exist
 		if err := ensureWorkspaceExists(workspacePath); err != nil {
 			log.Error("error creating workspace", "error", err)
-			http.Error(w, fmt.Sprintf("Error creating workspace: %v", err), http.StatusInternalServerError)
+			writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				fmt.Sprintf("Error creating workspace: %v", err))
 			return
 		}
 
@@ -121,7 +128,8 @@ func SetupConfigureHandler(log hclog.Logger) http.Handler {
 		configPath := filepath.Join(workingDir, "config.hcl")
 		if err := generateConfigFile(configPath, workspacePath, req.UpstreamURL, req.OllamaURL, req.OllamaModel); err != nil {
 			log.Error("error generating config file", "error", err)
-			http.Error(w, fmt.Sprintf("Error generating config: %v", err), http.StatusInternalServerError)
+			writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+				fmt.Sprintf("Error generating config: %v", err))
 			return
 		}
 
@@ -242,14 +250,16 @@ func generateConfigFile(configPath, workspacePath, upstreamURL, ollamaURL, ollam
 func OllamaValidateHandler(log hclog.Logger) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
-			w.WriteHeader(http.StatusMethodNotAllowed)
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
 			return
 		}
 
 		var req OllamaValidationRequest
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 			log.Error("error decoding ollama validation request", "error", err)
-			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				"Invalid request body")
 			return
 		}
 
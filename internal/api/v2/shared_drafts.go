@@ -0,0 +1,126 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"gorm.io/gorm"
+)
+
+const sharedDraftsPathPrefix = "/api/v2/shared-drafts/"
+
+// SharedDraftGetResponse is a draft opened with a share link.
+type SharedDraftGetResponse struct {
+	ID      string `json:"id"`
+	Title   string `json:"title"`
+	DocType string `json:"docType"`
+	Owner   string `json:"owner"`
+
+	// Content is the content of the draft.
+	Content string `json:"content"`
+}
+
+// SharedDraftsHandler returns drafts opened with the signed share links of
+// shareable drafts (GET /api/v2/shared-drafts/:token), which are used when the
+// workspace provider can't share drafts with the domain. Links stop working
+// when they expire or the draft is no longer shareable.
+func SharedDraftsHandler(srv server.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
+		if r.Method != "GET" {
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
+			return
+		}
+
+		docID, ok := sharedDraftID(srv, strings.TrimPrefix(
+			r.URL.Path, sharedDraftsPathPrefix))
+		if !ok {
+			writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound,
+				"Share link not found or expired")
+			return
+		}
+
+		model := models.Document{
+			GoogleFileID: docID,
+		}
+		if err := model.Get(srv.DB); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				writeProblem(w, r, http.StatusNotFound, ErrCodeDraftNotFound,
+					"Draft not found")
+				return
+			}
+			respondError(w, r, srv.Logger, http.StatusInternalServerError,
+				"Error accessing draft",
+				"error getting document from database", err,
+				"doc_id", docID,
+			)
+			return
+		}
+
+		// Links of drafts that were published or are no longer shareable don't
+		// work.
+		if model.Status != models.WIPDocumentStatus || !model.ShareableAsDraft {
+			writeProblem(w, r, http.StatusNotFound, ErrCodeDraftNotFound,
+				"Draft not found")
+			return
+		}
+
+		provider, providerID, err := documentContentProvider(
+			r.Context(), srv, &model, docID)
+		if err != nil {
+			respondError(w, r, srv.Logger, http.StatusInternalServerError,
+				"Error accessing draft",
+				"error resolving document provider", err,
+				"doc_id", docID,
+			)
+			return
+		}
+		content, err := provider.GetContent(r.Context(), providerID)
+		if err != nil {
+			respondError(w, r, srv.Logger, http.StatusInternalServerError,
+				"Error accessing draft",
+				"error getting document content", err,
+				"doc_id", docID,
+			)
+			return
+		}
+
+		resp := SharedDraftGetResponse{
+			ID:      docID,
+			Title:   model.Title,
+			DocType: model.DocumentType.Name,
+			Content: content.Body,
+		}
+		if model.Owner != nil {
+			resp.Owner = model.Owner.EmailAddress
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			srv.Logger.Error("error encoding shared draft response",
+				"error", err,
+				"doc_id", docID,
+			)
+		}
+	})
+}
+
+// sharedDraftID returns the ID of the draft of share link token token, and
+// false if the token isn't valid.
+func sharedDraftID(srv server.Server, token string) (string, bool) {
+	if srv.Sessions == nil || token == "" {
+		return "", false
+	}
+	resource, err := srv.Sessions.VerifyLink(token)
+	if err != nil {
+		return "", false
+	}
+	docID, ok := strings.CutPrefix(resource, "draft:")
+	return docID, ok && docID != ""
+}
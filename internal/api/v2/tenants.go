@@ -0,0 +1,139 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/internal/server"
+	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+	"github.com/hashicorp-forge/hermes/pkg/docid"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp-forge/hermes/pkg/tenant"
+	"gorm.io/gorm"
+)
+
+// TenantHandler resolves the tenant of authenticated requests from their host
+// name (see package tenant) and isolates the tenant's documents:
+//   - Users whose email domain isn't allowed by the tenant are forbidden.
+//     Site admins configured in the authorization block are always allowed.
+//   - Requests for a document or draft of another tenant get a 404 response,
+//     as if the document doesn't exist.
+//
+// If no tenants are configured, requests are passed to next unchanged.
+func TenantHandler(srv server.Server, next http.Handler) http.Handler {
+	if srv.Tenants == nil {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
+		t := srv.Tenants.Resolve(r.Host)
+		ctx := tenant.NewContext(r.Context(), t)
+
+		userEmail, _ := pkgauth.GetUserEmail(ctx)
+		if !tenantAllowsUser(srv.Config, t, userEmail) {
+			writeProblem(w, r, http.StatusForbidden, ErrCodeForbidden,
+				"You don't have access to this tenant")
+			return
+		}
+
+		target := parseAuditTarget(r.URL.Path, r.Method)
+		if target.Type == auditTargetDocument && target.ID != "" {
+			if err := checkDocumentTenant(srv.DB, target.ID, t); err != nil {
+				if errors.Is(err, errOtherTenant) {
+					writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound,
+						"Document not found")
+					return
+				}
+				respondError(w, r, srv.Logger, http.StatusInternalServerError,
+					"Error getting document", "error getting document tenant", err,
+					"doc_id", target.ID)
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// errOtherTenant is returned by checkDocumentTenant if a document belongs to
+// another tenant.
+var errOtherTenant = errors.New("document belongs to another tenant")
+
+// checkDocumentTenant returns errOtherTenant if the document with ID docID
+// belongs to a tenant other than t. Like the API handlers, it resolves docID
+// as a document UUID first and then as a Google file ID. Documents that aren't
+// in the database are left to the API handlers.
+func checkDocumentTenant(db *gorm.DB, docID string, t tenant.Tenant) error {
+	var doc models.Document
+	err := gorm.ErrRecordNotFound
+	if id, parseErr := docid.ParseUUID(docID); parseErr == nil {
+		err = db.Select("tenant_id").
+			Where("document_uuid = ?", id).
+			First(&doc).Error
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = db.Select("tenant_id").
+			Where(models.Document{GoogleFileID: docID}).
+			First(&doc).Error
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if doc.TenantID != t.ID {
+		return errOtherTenant
+	}
+	return nil
+}
+
+// tenantAllowsUser returns true if userEmail is allowed to use tenant t.
+func tenantAllowsUser(cfg *config.Config, t tenant.Tenant, userEmail string) bool {
+	tc := tenantConfig(cfg, t)
+	if tc == nil || len(tc.AllowedDomains) == 0 {
+		return true
+	}
+	if cfg.Authorization != nil && contains(cfg.Authorization.SiteAdmins, userEmail) {
+		return true
+	}
+	_, domain, ok := strings.Cut(userEmail, "@")
+	if !ok {
+		return false
+	}
+	for _, d := range tc.AllowedDomains {
+		if strings.EqualFold(d, domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// tenantConfig returns the configuration of tenant t, or nil for the default
+// tenant.
+func tenantConfig(cfg *config.Config, t tenant.Tenant) *config.Tenant {
+	if cfg == nil || t.IsDefault() {
+		return nil
+	}
+	for _, tc := range cfg.Tenants {
+		if tc.Name == t.Name {
+			return tc
+		}
+	}
+	return nil
+}
+
+// isTenantSiteAdmin returns true if userEmail is a site admin of the tenant in
+// ctx.
+func isTenantSiteAdmin(ctx context.Context, cfg *config.Config, userEmail string) bool {
+	t, ok := tenant.FromContext(ctx)
+	if !ok {
+		return false
+	}
+	tc := tenantConfig(cfg, t)
+	return tc != nil && contains(tc.SiteAdmins, userEmail)
+}
@@ -0,0 +1,218 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/internal/server"
+	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+	"github.com/hashicorp-forge/hermes/pkg/authz"
+	"github.com/hashicorp-forge/hermes/pkg/docid"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp-forge/hermes/pkg/models/modelstest"
+	"github.com/hashicorp-forge/hermes/pkg/tenant"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTenantHandler(t *testing.T) {
+	db := modelstest.NewDB(t)
+	acme := models.Tenant{Name: "acme"}
+	require.NoError(t, acme.FirstOrCreate(db))
+
+	tenants := tenant.NewResolver()
+	require.NoError(t, tenants.Add(
+		tenant.Tenant{ID: acme.ID, Name: acme.Name}, "docs.acme.example"))
+
+	defaultUUID := docid.NewUUID()
+	modelstest.CreateDocument(t, db, models.Document{
+		GoogleFileID: "default-doc",
+		DocumentUUID: &defaultUUID,
+	})
+	acmeUUID := docid.NewUUID()
+	modelstest.CreateDocument(t, db, models.Document{
+		GoogleFileID: "acme-doc",
+		DocumentUUID: &acmeUUID,
+		TenantID:     acme.ID,
+	})
+
+	srv := server.Server{
+		Config: &config.Config{
+			Tenants: []*config.Tenant{
+				{Name: "acme", Hostnames: []string{"docs.acme.example"}},
+			},
+		},
+		DB:      db,
+		Logger:  hclog.NewNullLogger(),
+		Tenants: tenants,
+	}
+	handler := TenantHandler(srv, http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+
+	cases := map[string]struct {
+		host  string
+		docID string
+		want  int
+	}{
+		"own document by UUID": {
+			host:  "docs.acme.example",
+			docID: acmeUUID.String(),
+			want:  http.StatusOK,
+		},
+		"own document by Google file ID": {
+			host:  "docs.acme.example",
+			docID: "acme-doc",
+			want:  http.StatusOK,
+		},
+		"other tenant's document by UUID": {
+			host:  "docs.acme.example",
+			docID: defaultUUID.String(),
+			want:  http.StatusNotFound,
+		},
+		"other tenant's document by Google file ID": {
+			host:  "docs.acme.example",
+			docID: "default-doc",
+			want:  http.StatusNotFound,
+		},
+		"tenant document by UUID from the default tenant": {
+			host:  "hermes.example",
+			docID: acmeUUID.String(),
+			want:  http.StatusNotFound,
+		},
+		"unknown document": {
+			host:  "docs.acme.example",
+			docID: docid.NewUUID().String(),
+			want:  http.StatusOK,
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/api/v2/documents/"+c.docID, nil)
+			req.Host = c.host
+			req = req.WithContext(context.WithValue(
+				req.Context(), pkgauth.UserEmailKey, "user@example.com"))
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+			assert.Equal(t, c.want, w.Code)
+		})
+	}
+}
+
+func TestTenantAdmins(t *testing.T) {
+	db := modelstest.NewDB(t)
+	acme := models.Tenant{Name: "acme"}
+	require.NoError(t, acme.FirstOrCreate(db))
+
+	tenants := tenant.NewResolver()
+	require.NoError(t, tenants.Add(
+		tenant.Tenant{ID: acme.ID, Name: acme.Name}, "docs.acme.example"))
+
+	for _, d := range []models.Document{
+		{GoogleFileID: "default-doc"},
+		{GoogleFileID: "acme-doc", TenantID: acme.ID},
+	} {
+		d = modelstest.CreateDocument(t, db, d)
+		require.NoError(t, d.Delete(db))
+	}
+
+	srv := server.Server{
+		Config: &config.Config{
+			Authorization: &config.Authorization{
+				SiteAdmins: []string{"admin@example.com"},
+			},
+			Tenants: []*config.Tenant{
+				{
+					Name:       "acme",
+					Hostnames:  []string{"docs.acme.example"},
+					SiteAdmins: []string{"acme-admin@example.com"},
+				},
+			},
+		},
+		DB:      db,
+		Logger:  hclog.NewNullLogger(),
+		Tenants: tenants,
+	}
+
+	serve := func(
+		h http.Handler, method, host, path, userEmail string,
+	) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.Host = host
+		req = req.WithContext(context.WithValue(
+			req.Context(), pkgauth.UserEmailKey, userEmail))
+		w := httptest.NewRecorder()
+		TenantHandler(srv, h).ServeHTTP(w, req)
+		return w
+	}
+	deletedDocIDs := func(t *testing.T, w *httptest.ResponseRecorder) []string {
+		require.Equal(t, http.StatusOK, w.Code)
+		var docs []DeletedDocument
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&docs))
+		var ids []string
+		for _, d := range docs {
+			ids = append(ids, d.ID)
+		}
+		return ids
+	}
+
+	t.Run("tenant admins aren't site admins", func(t *testing.T) {
+		ctx := tenant.NewContext(context.Background(),
+			tenant.Tenant{ID: acme.ID, Name: acme.Name})
+		p, err := loadPrincipal(ctx, srv.Config, db, "acme-admin@example.com")
+		require.NoError(t, err)
+		assert.False(t, p.IsSiteAdmin())
+		assert.Equal(t, []authz.Grant{
+			{Role: authz.RoleTenantAdmin, TenantID: acme.ID},
+		}, p.Grants)
+
+		p, err = loadPrincipal(context.Background(), srv.Config, db,
+			"acme-admin@example.com")
+		require.NoError(t, err)
+		assert.Empty(t, p.Grants)
+	})
+
+	t.Run("tenant admins can't manage the deployment", func(t *testing.T) {
+		w := serve(AdminConfigHandler(srv),
+			"GET", "docs.acme.example", "/api/v2/admin/config",
+			"acme-admin@example.com")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = serve(AdminDeletedHandler(srv),
+			"GET", "docs.acme.example", "/api/v2/admin/deleted/projects",
+			"acme-admin@example.com")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("deleted documents are listed by tenant", func(t *testing.T) {
+		w := serve(AdminDeletedHandler(srv),
+			"GET", "docs.acme.example", "/api/v2/admin/deleted/documents",
+			"acme-admin@example.com")
+		assert.Equal(t, []string{"acme-doc"}, deletedDocIDs(t, w))
+
+		w = serve(AdminDeletedHandler(srv),
+			"GET", "docs.acme.example", "/api/v2/admin/deleted/documents",
+			"admin@example.com")
+		assert.Equal(t, []string{"acme-doc"}, deletedDocIDs(t, w))
+
+		w = serve(AdminDeletedHandler(srv),
+			"GET", "hermes.example", "/api/v2/admin/deleted/documents",
+			"admin@example.com")
+		assert.Equal(t, []string{"default-doc"}, deletedDocIDs(t, w))
+	})
+
+	t.Run("tenant admins can't restore other tenants' documents",
+		func(t *testing.T) {
+			w := serve(AdminDeletedHandler(srv), "POST", "docs.acme.example",
+				"/api/v2/admin/deleted/documents/default-doc/restore",
+				"acme-admin@example.com")
+			assert.Equal(t, http.StatusNotFound, w.Code)
+		})
+}
@@ -0,0 +1,260 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/mail"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+// Request structs declare their validation rules with "validate" struct tags,
+// which are checked by validateRequest and described in the OpenAPI schemas
+// served by OpenAPIHandler. Rules are separated by commas:
+//
+//	required   - The field must be present and, for strings and slices, not
+//	             empty.
+//	min=N      - Strings must have at least N characters and slices at least N
+//	             elements.
+//	max=N      - Strings must have at most N characters and slices at most N
+//	             elements.
+//	len=N      - Strings must have exactly N characters and slices exactly N
+//	             elements.
+//	oneof=a b  - Strings (or every element of string slices) must be one of
+//	             the space-separated values.
+//	email      - Strings (or every element of string slices) must be email
+//	             addresses.
+//
+// Rules other than required are only checked for fields that are present, so
+// optional pointer fields of PATCH requests are only validated if they are
+// set.
+
+// FieldError is a validation error for a single request field.
+type FieldError struct {
+	// Field is the JSON name of the field.
+	Field string `json:"field"`
+
+	// Message describes why the field is invalid.
+	Message string `json:"message"`
+}
+
+// ValidationError contains the field errors of an invalid request.
+type ValidationError []FieldError
+
+func (e ValidationError) Error() string {
+	var msgs []string
+	for _, fe := range e {
+		msgs = append(msgs, fe.Field+" "+fe.Message)
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// validationRule is a parsed validation rule.
+type validationRule struct {
+	name  string
+	param string
+}
+
+// parseValidateTag parses the rules of a "validate" struct tag.
+func parseValidateTag(tag string) []validationRule {
+	var rules []validationRule
+	for _, s := range strings.Split(tag, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		name, param, _ := strings.Cut(s, "=")
+		rules = append(rules, validationRule{name: name, param: param})
+	}
+	return rules
+}
+
+// jsonFieldName returns the JSON name of a struct field, or an empty string if
+// the field is not encoded.
+func jsonFieldName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if name == "-" || !f.IsExported() {
+		return ""
+	}
+	if name == "" {
+		return f.Name
+	}
+	return name
+}
+
+// validateRequest validates request struct req (or a pointer to one) using
+// its "validate" struct tags. It returns a ValidationError with an error for
+// each invalid field, or nil if the request is valid.
+func validateRequest(req any) error {
+	v := reflect.ValueOf(req)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var errs ValidationError
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := jsonFieldName(f)
+		tag := f.Tag.Get("validate")
+		if name == "" || tag == "" {
+			continue
+		}
+		if msg := validateField(v.Field(i), parseValidateTag(tag)); msg != "" {
+			errs = append(errs, FieldError{Field: name, Message: msg})
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// validateField returns why field value v does not satisfy rules, or an empty
+// string if it does.
+func validateField(v reflect.Value, rules []validationRule) string {
+	present := true
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			present = false
+		} else {
+			v = v.Elem()
+		}
+	}
+
+	for _, r := range rules {
+		if r.name == "required" {
+			if !present || ((v.Kind() == reflect.String ||
+				v.Kind() == reflect.Slice) && v.Len() == 0) {
+				return "is required"
+			}
+		}
+	}
+	if !present {
+		return ""
+	}
+
+	for _, r := range rules {
+		switch r.name {
+		case "required":
+
+		case "min", "max", "len":
+			n, err := strconv.Atoi(r.param)
+			if err != nil {
+				panic(fmt.Sprintf("invalid %s validation rule %q", r.name, r.param))
+			}
+			var l int
+			unit := "characters"
+			switch v.Kind() {
+			case reflect.String:
+				l = utf8.RuneCountInString(v.String())
+			case reflect.Slice:
+				l = v.Len()
+				unit = "values"
+			default:
+				continue
+			}
+			switch {
+			case r.name == "min" && l < n:
+				return fmt.Sprintf("must have at least %d %s", n, unit)
+			case r.name == "max" && l > n:
+				return fmt.Sprintf("must have at most %d %s", n, unit)
+			case r.name == "len" && l != n:
+				return fmt.Sprintf("must have exactly %d %s", n, unit)
+			}
+
+		case "oneof":
+			allowed := strings.Fields(r.param)
+			for _, s := range stringValues(v) {
+				if !contains(allowed, s) {
+					return fmt.Sprintf("must be one of %s, got %q",
+						strings.Join(allowed, ", "), s)
+				}
+			}
+
+		case "email":
+			for _, s := range stringValues(v) {
+				if a, err := mail.ParseAddress(s); err != nil || a.Address != s {
+					return fmt.Sprintf("must be an email address, got %q", s)
+				}
+			}
+
+		default:
+			panic(fmt.Sprintf("unknown validation rule %q", r.name))
+		}
+	}
+
+	return ""
+}
+
+// stringValues returns v if it is a string or its elements if it is a string
+// slice.
+func stringValues(v reflect.Value) []string {
+	switch {
+	case v.Kind() == reflect.String:
+		return []string{v.String()}
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.String:
+		vals := make([]string, v.Len())
+		for i := range vals {
+			vals[i] = v.Index(i).String()
+		}
+		return vals
+	default:
+		return nil
+	}
+}
+
+// decodeAndValidateRequest decodes the request body into req and validates it
+// with validateRequest. It writes a problem response with the field errors if
+// the request is invalid and returns false if a response was written.
+func decodeAndValidateRequest(
+	w http.ResponseWriter, r *http.Request, l hclog.Logger, req any,
+) bool {
+	if err := decodeRequest(r, req); err != nil {
+		l.Warn("error decoding request",
+			"error", err,
+			"method", r.Method,
+			"path", r.URL.Path,
+		)
+		writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+			fmt.Sprintf("Bad request: %q", err))
+		return false
+	}
+	if err := validateRequest(req); err != nil {
+		l.Warn("invalid request",
+			"error", err,
+			"method", r.Method,
+			"path", r.URL.Path,
+		)
+		writeValidationProblem(w, r, err.(ValidationError))
+		return false
+	}
+	return true
+}
+
+// writeValidationProblem writes a problem response for an invalid request
+// that includes the field errors.
+func writeValidationProblem(
+	w http.ResponseWriter, r *http.Request, errs ValidationError,
+) {
+	writeProblemDetails(w, ProblemDetails{
+		Type:     "urn:hermes:error:" + string(ErrCodeBadRequest),
+		Title:    http.StatusText(http.StatusBadRequest),
+		Status:   http.StatusBadRequest,
+		Detail:   "Bad request: " + errs.Error(),
+		Instance: r.URL.Path,
+		Code:     ErrCodeBadRequest,
+		Errors:   errs,
+	})
+}
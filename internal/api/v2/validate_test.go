@@ -0,0 +1,98 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateRequest(t *testing.T) {
+	owners := func(o ...string) *[]string { return &o }
+	status := func(s string) *string { return &s }
+
+	cases := map[string]struct {
+		req  any
+		want ValidationError
+	}{
+		"valid draft": {
+			req: DraftsRequest{
+				DocType:      "RFC",
+				Title:        "My RFC",
+				Contributors: []string{"a@example.com"},
+			},
+		},
+		"missing required fields": {
+			req: &DraftsRequest{},
+			want: ValidationError{
+				{Field: "docType", Message: "is required"},
+				{Field: "title", Message: "is required"},
+			},
+		},
+		"invalid contributor email": {
+			req: DraftsRequest{
+				DocType:      "RFC",
+				Title:        "My RFC",
+				Contributors: []string{"a@example.com", "not an email"},
+			},
+			want: ValidationError{
+				{Field: "contributors",
+					Message: `must be an email address, got "not an email"`},
+			},
+		},
+		"empty patch": {
+			req: DocumentPatchRequest{},
+		},
+		"too many owners": {
+			req: DocumentPatchRequest{
+				Owners: owners("a@example.com", "b@example.com"),
+			},
+			want: ValidationError{
+				{Field: "owners", Message: "must have exactly 1 values"},
+			},
+		},
+		"invalid status": {
+			req: DocumentPatchRequest{Status: status("Done")},
+			want: ValidationError{
+				{Field: "status", Message: `must be one of Approved, In-Review, ` +
+					`Obsolete, got "Done"`},
+			},
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := validateRequest(c.req)
+			if c.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Equal(t, c.want, err)
+		})
+	}
+}
+
+func TestDecodeAndValidateRequest(t *testing.T) {
+	assert := assert.New(t)
+
+	r := httptest.NewRequest("POST", "/api/v2/drafts",
+		strings.NewReader(`{"docType":"RFC"}`))
+	w := httptest.NewRecorder()
+	var req DraftsRequest
+	ok := decodeAndValidateRequest(w, r, hclog.NewNullLogger(), &req)
+	assert.False(ok)
+	assert.Equal(http.StatusBadRequest, w.Code)
+	assert.Equal(ProblemContentType, w.Header().Get("Content-Type"))
+
+	var p ProblemDetails
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&p))
+	assert.Equal(ErrCodeBadRequest, p.Code)
+	assert.Equal("Bad request: title is required", p.Detail)
+	assert.Equal([]FieldError{{Field: "title", Message: "is required"}},
+		p.Errors)
+}
@@ -0,0 +1,145 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/hashicorp-forge/hermes/internal/server"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+)
+
+// WorkerHeartbeatRequest reports the status of a pipeline worker.
+type WorkerHeartbeatRequest struct {
+	// Name is the unique name of the worker.
+	Name string `json:"name"`
+
+	// Kind is the kind of worker ("indexer" or "notifier").
+	Kind string `json:"kind"`
+
+	Version  string `json:"version,omitempty"`
+	Hostname string `json:"hostname,omitempty"`
+
+	// Partitions are the topic partitions the worker processed records of.
+	Partitions []WorkerPartitionStatus `json:"partitions"`
+
+	// ProcessedCount and ErrorCount are the number of records the worker
+	// processed and failed to process since it started.
+	ProcessedCount int64 `json:"processed_count"`
+	ErrorCount     int64 `json:"error_count"`
+
+	LastError   string     `json:"last_error,omitempty"`
+	LastErrorAt *time.Time `json:"last_error_at,omitempty"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+}
+
+// WorkerPartitionStatus is the progress of a worker on a topic partition.
+type WorkerPartitionStatus struct {
+	ConsumerGroup string `json:"consumer_group"`
+	Topic         string `json:"topic"`
+	Partition     int32  `json:"partition"`
+
+	// Offset is the offset of the last record processed.
+	Offset int64 `json:"offset"`
+
+	// Lag is the number of records after Offset when it was processed.
+	Lag int64 `json:"lag"`
+}
+
+// WorkerHeartbeatResponse acknowledges a worker heartbeat.
+type WorkerHeartbeatResponse struct {
+	Acknowledged bool      `json:"acknowledged"`
+	ServerTime   time.Time `json:"server_time"`
+}
+
+// maxWorkerPartitions is the maximum number of partitions of a heartbeat.
+const maxWorkerPartitions = 1000
+
+// workersPolicy authorizes the service tokens of pipeline workers.
+var workersPolicy = ServiceTokenPolicy{
+	Name:       "workers",
+	TokenTypes: []string{"api"},
+	Scope:      scope(models.TokenScopeWorkers),
+}
+
+// WorkersHandler records the status reported by pipeline workers (indexers
+// and notifiers), which admins list with AdminWorkersHandler.
+//
+// POST /api/v2/workers/heartbeat - Report worker status
+//
+// Requests must be authenticated by an "api" service token with the "workers"
+// scope.
+func WorkersHandler(srv server.Server) http.Handler {
+	return ServiceTokenAuthMiddleware(srv, workersPolicy,
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			srv := srv.ForRequest(r)
+			if r.URL.Path != "/api/v2/workers/heartbeat" {
+				writeProblem(w, r, http.StatusNotFound, ErrCodeNotFound, "Not found")
+				return
+			}
+			if r.Method != http.MethodPost {
+				writeProblem(w, r, http.StatusMethodNotAllowed,
+					ErrCodeMethodNotAllowed, "Method not allowed")
+				return
+			}
+
+			var req WorkerHeartbeatRequest
+			if err := decodeRequest(r, &req); err != nil {
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					fmt.Sprintf("Bad request: %q", err))
+				return
+			}
+			if len(req.Partitions) > maxWorkerPartitions {
+				writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+					fmt.Sprintf("Bad request: at most %d partitions are allowed",
+						maxWorkerPartitions))
+				return
+			}
+
+			worker := models.WorkerInstance{
+				Name:           req.Name,
+				Kind:           req.Kind,
+				Version:        req.Version,
+				Hostname:       req.Hostname,
+				RemoteAddr:     r.RemoteAddr,
+				Partitions:     []models.WorkerPartition{},
+				ProcessedCount: req.ProcessedCount,
+				ErrorCount:     req.ErrorCount,
+				LastError:      req.LastError,
+				LastErrorAt:    req.LastErrorAt,
+				StartedAt:      req.StartedAt,
+			}
+			for _, p := range req.Partitions {
+				worker.Partitions = append(worker.Partitions, models.WorkerPartition{
+					ConsumerGroup: p.ConsumerGroup,
+					Topic:         p.Topic,
+					Partition:     p.Partition,
+					Offset:        p.Offset,
+					Lag:           p.Lag,
+				})
+			}
+			if err := worker.RecordHeartbeat(srv.DB); err != nil {
+				var verr validation.Errors
+				if errors.As(err, &verr) {
+					writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+						fmt.Sprintf("Bad request: %v", err))
+					return
+				}
+				respondError(w, r, srv.Logger, http.StatusInternalServerError,
+					"Error recording heartbeat",
+					"error recording worker heartbeat", err,
+					"worker", req.Name,
+				)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(WorkerHeartbeatResponse{
+				Acknowledged: true,
+				ServerTime:   time.Now().UTC(),
+			})
+		}))
+}
@@ -19,6 +19,7 @@ type WorkspaceProjectsGetResponse struct {
 // Endpoint: GET /api/v2/workspace-projects
 func WorkspaceProjectsHandler(srv server.Server) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
 		logArgs := []any{
 			"path", r.URL.Path,
 			"method", r.Method,
@@ -28,7 +29,8 @@ func WorkspaceProjectsHandler(srv server.Server) http.Handler {
 		userEmail := pkgauth.MustGetUserEmail(r.Context())
 		if userEmail == "" {
 			srv.Logger.Error("user email not found in request context", logArgs...)
-			http.Error(w, "No authorization information for request", http.StatusUnauthorized)
+			writeProblem(w, r, http.StatusUnauthorized, ErrCodeUnauthorized,
+				"No authorization information for request")
 			return
 		}
 
@@ -39,7 +41,8 @@ func WorkspaceProjectsHandler(srv server.Server) http.Handler {
 			if err != nil {
 				srv.Logger.Error("error loading workspace projects from database",
 					append(logArgs, "error", err)...)
-				http.Error(w, "Error loading workspace projects", http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error loading workspace projects")
 				return
 			}
 
@@ -63,12 +66,14 @@ func WorkspaceProjectsHandler(srv server.Server) http.Handler {
 			if err := json.NewEncoder(w).Encode(resp); err != nil {
 				srv.Logger.Error("error encoding response",
 					append(logArgs, "error", err)...)
-				http.Error(w, "Error encoding response", http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error encoding response")
 				return
 			}
 
 		default:
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
 		}
 	})
 }
@@ -77,6 +82,7 @@ func WorkspaceProjectsHandler(srv server.Server) http.Handler {
 // Endpoint: GET /api/v2/workspace-projects/{name}
 func WorkspaceProjectHandler(srv server.Server) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv := srv.ForRequest(r)
 		logArgs := []any{
 			"path", r.URL.Path,
 			"method", r.Method,
@@ -86,7 +92,8 @@ func WorkspaceProjectHandler(srv server.Server) http.Handler {
 		userEmail := pkgauth.MustGetUserEmail(r.Context())
 		if userEmail == "" {
 			srv.Logger.Error("user email not found in request context", logArgs...)
-			http.Error(w, "No authorization information for request", http.StatusUnauthorized)
+			writeProblem(w, r, http.StatusUnauthorized, ErrCodeUnauthorized,
+				"No authorization information for request")
 			return
 		}
 
@@ -94,7 +101,8 @@ func WorkspaceProjectHandler(srv server.Server) http.Handler {
 		// URL pattern: /api/v2/workspace-projects/{name}
 		pathParts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/v2/workspace-projects/"), "/")
 		if len(pathParts) == 0 || pathParts[0] == "" {
-			http.Error(w, "Project name required", http.StatusBadRequest)
+			writeProblem(w, r, http.StatusBadRequest, ErrCodeBadRequest,
+				"Project name required")
 			return
 		}
 		projectName := pathParts[0]
@@ -106,7 +114,8 @@ func WorkspaceProjectHandler(srv server.Server) http.Handler {
 			if err != nil {
 				srv.Logger.Error("project not found",
 					append(logArgs, "project_name", projectName, "error", err)...)
-				http.Error(w, "Project not found", http.StatusNotFound)
+				writeProblem(w, r, http.StatusNotFound, ErrCodeProjectNotFound,
+					"Project not found")
 				return
 			}
 
@@ -115,7 +124,8 @@ func WorkspaceProjectHandler(srv server.Server) http.Handler {
 			if err != nil {
 				srv.Logger.Error("error converting workspace project to summary",
 					append(logArgs, "project_name", projectName, "error", err)...)
-				http.Error(w, "Error processing project", http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error processing project")
 				return
 			}
 
@@ -123,12 +133,14 @@ func WorkspaceProjectHandler(srv server.Server) http.Handler {
 			if err := json.NewEncoder(w).Encode(summary); err != nil {
 				srv.Logger.Error("error encoding response",
 					append(logArgs, "error", err)...)
-				http.Error(w, "Error encoding response", http.StatusInternalServerError)
+				writeProblem(w, r, http.StatusInternalServerError, ErrCodeInternal,
+					"Error encoding response")
 				return
 			}
 
 		default:
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			writeProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed,
+				"Method not allowed")
 		}
 	})
 }
@@ -7,6 +7,8 @@ import (
 	"github.com/hashicorp-forge/hermes/internal/config"
 	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
 	googleadapter "github.com/hashicorp-forge/hermes/pkg/auth/adapters/google"
+	mockadapter "github.com/hashicorp-forge/hermes/pkg/auth/adapters/mock"
+	oidcadapter "github.com/hashicorp-forge/hermes/pkg/auth/adapters/oidc"
 	oktaadapter "github.com/hashicorp-forge/hermes/pkg/auth/adapters/okta"
 	gw "github.com/hashicorp-forge/hermes/pkg/workspace/adapters/google"
 	"github.com/hashicorp/go-hclog"
@@ -15,6 +17,10 @@ import (
 const (
 	// SessionCookieName is the name of the cookie used to store user session for Dex auth
 	SessionCookieName = "hermes_session"
+
+	// DevUserHeader is the request header that selects the user a request is
+	// authenticated as when running "hermes dev".
+	DevUserHeader = "X-Hermes-Dev-User"
 )
 
 // DexSessionProvider wraps the Dex adapter and adds session cookie support.
@@ -51,15 +57,40 @@ func (p *DexSessionProvider) Name() string {
 }
 
 // AuthenticateRequest is middleware that authenticates an HTTP request using
-// the appropriate authentication provider based on configuration.
+// the appropriate authentication provider based on configuration. oidcAdapter
+// is used if OIDC authentication is enabled and may be nil otherwise. If
+// signed cookie sessions are enabled in sessions, requests with a session
+// cookie are authenticated with it and mutating requests are checked for a
+// CSRF token if required.
 func AuthenticateRequest(
-	cfg config.Config, gwSvc *gw.Service, log hclog.Logger, next http.Handler,
+	cfg config.Config,
+	gwSvc *gw.Service,
+	oidcAdapter *oidcadapter.Adapter,
+	sessions *Sessions,
+	log hclog.Logger,
+	next http.Handler,
 ) http.Handler {
 	var provider pkgauth.Provider
 
-	// Priority: Dex > Okta > Google
-	// If Dex is configured and enabled, use Dex session-based authentication.
-	if cfg.Dex != nil && !cfg.Dex.Disabled {
+	// Priority: Dev > OIDC > Dex > Okta > Google
+	if cfg.DevUser != "" {
+		// Authenticate every request as the dev user, or as the user in the
+		// DevUserHeader header, without checking any credentials.
+		provider = &mockadapter.Adapter{
+			MockEmail:  cfg.DevUser,
+			UseHeader:  true,
+			HeaderName: DevUserHeader,
+		}
+	} else if cfg.OIDC != nil && !cfg.OIDC.Disabled {
+		if oidcAdapter == nil {
+			log.Error("OIDC authentication is enabled but not initialized")
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "Internal server error", http.StatusInternalServerError)
+			})
+		}
+		provider = oidcAdapter
+	} else if cfg.Dex != nil && !cfg.Dex.Disabled {
+		// If Dex is configured and enabled, use Dex session-based authentication.
 		// For Dex, we use session cookies instead of bearer tokens
 		provider = NewDexSessionProvider(log)
 	} else if cfg.Okta != nil && !cfg.Okta.Disabled {
@@ -85,9 +116,13 @@ func AuthenticateRequest(
 		provider = googleadapter.NewAdapter(gwSvc)
 	}
 
+	if sessions.Enabled() {
+		provider = sessions.Provider(provider)
+	}
+
 	// Wrap the handler with authentication middleware and an additional
 	// safety check to ensure the user email is set.
 	return pkgauth.Middleware(provider, log)(
-		pkgauth.RequireUserEmail(log, next),
+		pkgauth.RequireUserEmail(log, CSRFProtect(sessions, log, next)),
 	)
 }
@@ -0,0 +1,383 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/config"
+	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+	oidcadapter "github.com/hashicorp-forge/hermes/pkg/auth/adapters/oidc"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	// CSRFCookieName is the name of the cookie that holds the CSRF token for
+	// the user's session. It is readable by the web app so the token can be
+	// sent in the CSRFHeaderName header.
+	CSRFCookieName = "hermes_csrf"
+
+	// CSRFHeaderName is the request header that must contain the CSRF token.
+	CSRFHeaderName = "X-CSRF-Token"
+
+	// defaultSessionMaxAge is how long a signed cookie session lasts by
+	// default.
+	defaultSessionMaxAge = 7 * 24 * time.Hour
+
+	// minSessionSecretLength is the minimum session secret length.
+	minSessionSecretLength = 32
+)
+
+// sessionCookieNames are the cookies that authenticate a browser session and
+// so require CSRF protection.
+var sessionCookieNames = []string{
+	SessionCookieName,
+	oidcadapter.SessionCookieName,
+}
+
+// Sessions sets the flags of session cookies and, if a session secret is
+// configured, issues and verifies signed cookie sessions and CSRF tokens.
+type Sessions struct {
+	secret   []byte
+	maxAge   time.Duration
+	secure   bool
+	sameSite http.SameSite
+	csrf     bool
+}
+
+// sessionPayload is the signed content of a session cookie.
+type sessionPayload struct {
+	Email   string `json:"e"`
+	Expires int64  `json:"x"`
+	Nonce   string `json:"n"`
+}
+
+// NewSessions returns the session configuration from cfg, which may be nil.
+func NewSessions(cfg *config.Auth) (*Sessions, error) {
+	s := &Sessions{
+		maxAge:   defaultSessionMaxAge,
+		sameSite: http.SameSiteLaxMode,
+	}
+	if cfg == nil {
+		return s, nil
+	}
+
+	if cfg.SessionSecret != "" {
+		if len(cfg.SessionSecret) < minSessionSecretLength {
+			return nil, fmt.Errorf(
+				"session_secret must be at least %d characters",
+				minSessionSecretLength)
+		}
+		s.secret = []byte(cfg.SessionSecret)
+	}
+	if cfg.SessionMaxAge < 0 {
+		return nil, fmt.Errorf("session_max_age must not be negative")
+	} else if cfg.SessionMaxAge > 0 {
+		s.maxAge = cfg.SessionMaxAge
+	}
+	s.secure = cfg.SecureCookies
+
+	switch strings.ToLower(cfg.SameSite) {
+	case "", "lax":
+	case "strict":
+		s.sameSite = http.SameSiteStrictMode
+	case "none":
+		if !cfg.SecureCookies {
+			return nil, fmt.Errorf(`same_site "none" requires secure_cookies`)
+		}
+		s.sameSite = http.SameSiteNoneMode
+	default:
+		return nil, fmt.Errorf("invalid same_site %q", cfg.SameSite)
+	}
+
+	if cfg.CSRF {
+		if s.secret == nil {
+			return nil, fmt.Errorf("csrf requires session_secret")
+		}
+		s.csrf = true
+	}
+
+	return s, nil
+}
+
+// Enabled returns true if signed cookie sessions are enabled.
+func (s *Sessions) Enabled() bool {
+	return s != nil && s.secret != nil
+}
+
+// CSRFEnabled returns true if CSRF tokens are required.
+func (s *Sessions) CSRFEnabled() bool {
+	return s != nil && s.csrf
+}
+
+// Cookie returns a session cookie with the configured Secure and SameSite
+// flags. The cookie is deleted if maxAge is negative.
+func (s *Sessions) Cookie(
+	r *http.Request, name, value string, maxAge time.Duration,
+) *http.Cookie {
+	c := &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		MaxAge:   int(maxAge / time.Second),
+		HttpOnly: true,
+		Secure:   s.secure || r.TLS != nil,
+		SameSite: s.sameSite,
+	}
+	if maxAge < 0 {
+		c.MaxAge = -1
+	}
+	return c
+}
+
+// Issue starts a signed cookie session for the user with the provided email
+// address and sets its CSRF token cookie.
+func (s *Sessions) Issue(w http.ResponseWriter, r *http.Request, email string) error {
+	if !s.Enabled() {
+		return fmt.Errorf("signed sessions are not enabled")
+	}
+
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("error generating session nonce: %w", err)
+	}
+	b, err := json.Marshal(sessionPayload{
+		Email:   email,
+		Expires: time.Now().Add(s.maxAge).Unix(),
+		Nonce:   base64.RawURLEncoding.EncodeToString(nonce),
+	})
+	if err != nil {
+		return fmt.Errorf("error encoding session: %w", err)
+	}
+	payload := base64.RawURLEncoding.EncodeToString(b)
+	value := payload + "." + s.sign("session:"+payload)
+
+	http.SetCookie(w, s.Cookie(r, SessionCookieName, value, s.maxAge))
+	s.setCSRFCookie(w, r, value)
+	return nil
+}
+
+// Clear ends the session by deleting the session and CSRF token cookies.
+func (s *Sessions) Clear(w http.ResponseWriter, r *http.Request) {
+	for _, name := range append(sessionCookieNames, CSRFCookieName) {
+		http.SetCookie(w, s.Cookie(r, name, "", -1))
+	}
+}
+
+// Verify verifies a signed session cookie value and returns the email address
+// of the session's user.
+func (s *Sessions) Verify(value string) (string, error) {
+	if !s.Enabled() {
+		return "", fmt.Errorf("signed sessions are not enabled")
+	}
+
+	payload, sig, ok := strings.Cut(value, ".")
+	if !ok || !hmac.Equal([]byte(sig), []byte(s.sign("session:"+payload))) {
+		return "", fmt.Errorf("invalid session signature")
+	}
+	b, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return "", fmt.Errorf("invalid session encoding: %w", err)
+	}
+	var p sessionPayload
+	if err := json.Unmarshal(b, &p); err != nil {
+		return "", fmt.Errorf("invalid session: %w", err)
+	}
+	if time.Now().Unix() >= p.Expires {
+		return "", fmt.Errorf("session expired")
+	}
+	if p.Email == "" {
+		return "", fmt.Errorf("session has no email")
+	}
+	return p.Email, nil
+}
+
+// CSRFToken returns the CSRF token for the session with the provided session
+// cookie value.
+func (s *Sessions) CSRFToken(sessionValue string) string {
+	return s.sign("csrf:" + sessionValue)
+}
+
+// setCSRFCookie sets the CSRF token cookie for a session. Unlike the session
+// cookie, it is readable by the web app.
+func (s *Sessions) setCSRFCookie(
+	w http.ResponseWriter, r *http.Request, sessionValue string,
+) {
+	c := s.Cookie(r, CSRFCookieName, s.CSRFToken(sessionValue), s.maxAge)
+	c.HttpOnly = false
+	http.SetCookie(w, c)
+}
+
+// SignLink returns a token for a link to resource (e.g., "draft:abc") that is
+// valid until expires. Anyone with the token can verify it until then, so
+// resources must still be authorized when they are requested.
+func (s *Sessions) SignLink(resource string, expires time.Time) (string, error) {
+	if !s.Enabled() {
+		return "", fmt.Errorf("signed sessions are not enabled")
+	}
+
+	payload := base64.RawURLEncoding.EncodeToString([]byte(
+		resource + "." + strconv.FormatInt(expires.Unix(), 10)))
+	return payload + "." + s.sign("link:"+payload), nil
+}
+
+// VerifyLink verifies a link token signed by SignLink and returns its
+// resource.
+func (s *Sessions) VerifyLink(token string) (string, error) {
+	if !s.Enabled() {
+		return "", fmt.Errorf("signed sessions are not enabled")
+	}
+
+	payload, sig, ok := strings.Cut(token, ".")
+	if !ok || !hmac.Equal([]byte(sig), []byte(s.sign("link:"+payload))) {
+		return "", fmt.Errorf("invalid link signature")
+	}
+	b, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return "", fmt.Errorf("invalid link encoding: %w", err)
+	}
+	i := strings.LastIndex(string(b), ".")
+	if i == -1 {
+		return "", fmt.Errorf("invalid link")
+	}
+	expires, err := strconv.ParseInt(string(b[i+1:]), 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid link expiration: %w", err)
+	}
+	if time.Now().Unix() >= expires {
+		return "", fmt.Errorf("link expired")
+	}
+	return string(b[:i]), nil
+}
+
+// sign returns the base64-encoded HMAC-SHA256 of msg.
+func (s *Sessions) sign(msg string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(msg))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Provider returns an authentication provider that authenticates requests
+// with a signed session cookie, if the request has one, and with next
+// otherwise.
+func (s *Sessions) Provider(next pkgauth.Provider) pkgauth.Provider {
+	return &sessionProvider{sessions: s, next: next}
+}
+
+// sessionProvider authenticates requests with a signed session cookie or
+// falls back to another provider (e.g., for Authorization headers).
+type sessionProvider struct {
+	sessions *Sessions
+	next     pkgauth.Provider
+}
+
+// Authenticate implements pkgauth.Provider. Requests with an invalid session
+// cookie are not authenticated with the fallback provider.
+func (p *sessionProvider) Authenticate(r *http.Request) (string, error) {
+	if c, err := r.Cookie(SessionCookieName); err == nil && c.Value != "" {
+		return p.sessions.Verify(c.Value)
+	}
+	return p.next.Authenticate(r)
+}
+
+// GetClaims implements pkgauth.ClaimsProvider. Signed sessions only contain
+// the user's email address, so claims are only available from the fallback
+// provider.
+func (p *sessionProvider) GetClaims(r *http.Request) (*pkgauth.UserClaims, error) {
+	if c, err := r.Cookie(SessionCookieName); err == nil && c.Value != "" {
+		return nil, nil
+	}
+	if cp, ok := p.next.(pkgauth.ClaimsProvider); ok {
+		return cp.GetClaims(r)
+	}
+	return nil, nil
+}
+
+// Name implements pkgauth.Provider.
+func (p *sessionProvider) Name() string {
+	return "session+" + p.next.Name()
+}
+
+// Ensure sessionProvider implements the pkgauth.ClaimsProvider interface at
+// compile time.
+var _ pkgauth.ClaimsProvider = (*sessionProvider)(nil)
+
+// CSRFProtect is middleware that requires mutating requests (POST, PUT,
+// PATCH, and DELETE) authenticated with a session cookie to include the
+// session's CSRF token in the X-CSRF-Token header. Requests authenticated with
+// an Authorization header are not affected because browsers do not send it
+// cross-site. It also sets the CSRF token cookie if it is missing or stale,
+// so sessions started before CSRF protection was enabled get a token.
+func CSRFProtect(s *Sessions, log hclog.Logger, next http.Handler) http.Handler {
+	if !s.CSRFEnabled() {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var sessionCookie, sessionValue string
+		for _, name := range sessionCookieNames {
+			if c, err := r.Cookie(name); err == nil && c.Value != "" {
+				sessionCookie, sessionValue = name, c.Value
+				break
+			}
+		}
+		if sessionValue == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		token := s.CSRFToken(sessionValue)
+		if c, err := r.Cookie(CSRFCookieName); err != nil || c.Value != token {
+			s.setCSRFCookie(w, r, sessionValue)
+		}
+
+		switch r.Method {
+		case "POST", "PUT", "PATCH", "DELETE":
+			if authenticatedByHeader(r, sessionCookie) {
+				break
+			}
+			if !hmac.Equal([]byte(r.Header.Get(CSRFHeaderName)), []byte(token)) {
+				log.Warn("rejected request with invalid CSRF token",
+					"method", r.Method,
+					"path", r.URL.Path,
+				)
+				writeCSRFProblem(w, r)
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// authenticatedByHeader returns true if a request with the named session
+// cookie was authenticated with its Authorization header. Signed session
+// cookies take precedence over the header (see sessionProvider), so requests
+// with one are always authenticated by the cookie, whatever else they send.
+func authenticatedByHeader(r *http.Request, sessionCookie string) bool {
+	return r.Header.Get("Authorization") != "" && sessionCookie != SessionCookieName
+}
+
+// writeCSRFProblem writes a CSRF token failure as RFC 7807 problem details, in
+// the same form as API v2 errors.
+func writeCSRFProblem(w http.ResponseWriter, r *http.Request) {
+	h := w.Header()
+	h.Set("Content-Type", "application/problem+json")
+	h.Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusForbidden)
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"type":     "urn:hermes:error:csrf_token_invalid",
+		"title":    http.StatusText(http.StatusForbidden),
+		"status":   http.StatusForbidden,
+		"detail":   "Invalid CSRF token",
+		"instance": r.URL.Path,
+		"code":     "csrf_token_invalid",
+	})
+}
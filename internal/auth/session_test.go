@@ -0,0 +1,229 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/config"
+	oidcadapter "github.com/hashicorp-forge/hermes/pkg/auth/adapters/oidc"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSessionSecret = "0123456789abcdef0123456789abcdef"
+
+func TestNewSessions(t *testing.T) {
+	cases := map[string]struct {
+		cfg     *config.Auth
+		wantErr bool
+	}{
+		"no config":            {cfg: nil},
+		"signed sessions":      {cfg: &config.Auth{SessionSecret: testSessionSecret}},
+		"short secret":         {cfg: &config.Auth{SessionSecret: "secret"}, wantErr: true},
+		"csrf without secret":  {cfg: &config.Auth{CSRF: true}, wantErr: true},
+		"invalid same site":    {cfg: &config.Auth{SameSite: "sometimes"}, wantErr: true},
+		"none without secure":  {cfg: &config.Auth{SameSite: "none"}, wantErr: true},
+		"none with secure":     {cfg: &config.Auth{SameSite: "None", SecureCookies: true}},
+		"negative session age": {cfg: &config.Auth{SessionMaxAge: -time.Hour}, wantErr: true},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewSessions(c.cfg)
+			if c.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSessions(t *testing.T) {
+	s, err := NewSessions(&config.Auth{
+		SessionSecret: testSessionSecret,
+		SecureCookies: true,
+		SameSite:      "strict",
+		CSRF:          true,
+	})
+	require.NoError(t, err)
+
+	// issue starts a session and returns its cookies by name.
+	issue := func(t *testing.T) map[string]*http.Cookie {
+		w := httptest.NewRecorder()
+		require.NoError(t, s.Issue(w, httptest.NewRequest("GET", "/", nil),
+			"user@example.com"))
+		cookies := map[string]*http.Cookie{}
+		for _, c := range w.Result().Cookies() {
+			cookies[c.Name] = c
+		}
+		return cookies
+	}
+
+	t.Run("Issue sets secure session and CSRF cookies", func(t *testing.T) {
+		cookies := issue(t)
+		session, csrf := cookies[SessionCookieName], cookies[CSRFCookieName]
+		require.NotNil(t, session)
+		require.NotNil(t, csrf)
+
+		assert.True(t, session.HttpOnly)
+		assert.True(t, session.Secure)
+		assert.Equal(t, http.SameSiteStrictMode, session.SameSite)
+		assert.False(t, csrf.HttpOnly)
+		assert.Equal(t, s.CSRFToken(session.Value), csrf.Value)
+
+		email, err := s.Verify(session.Value)
+		require.NoError(t, err)
+		assert.Equal(t, "user@example.com", email)
+	})
+
+	t.Run("Verify rejects tampered sessions", func(t *testing.T) {
+		value := issue(t)[SessionCookieName].Value
+		payload, sig, _ := strings.Cut(value, ".")
+
+		_, err := s.Verify(payload + "." + sig + "x")
+		assert.Error(t, err)
+		_, err = s.Verify("e30." + sig)
+		assert.Error(t, err)
+
+		other, err := NewSessions(&config.Auth{
+			SessionSecret: strings.Repeat("x", minSessionSecretLength),
+		})
+		require.NoError(t, err)
+		_, err = other.Verify(value)
+		assert.Error(t, err)
+	})
+
+	t.Run("SignLink signs links until they expire", func(t *testing.T) {
+		token, err := s.SignLink("draft:abc", time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		resource, err := s.VerifyLink(token)
+		require.NoError(t, err)
+		assert.Equal(t, "draft:abc", resource)
+
+		// Session signatures aren't valid link signatures.
+		_, err = s.VerifyLink(issue(t)[SessionCookieName].Value)
+		assert.Error(t, err)
+
+		_, err = s.VerifyLink(token + "x")
+		assert.Error(t, err)
+
+		token, err = s.SignLink("draft:abc", time.Now().Add(-time.Second))
+		require.NoError(t, err)
+		_, err = s.VerifyLink(token)
+		assert.ErrorContains(t, err, "link expired")
+
+		disabled, err := NewSessions(nil)
+		require.NoError(t, err)
+		_, err = disabled.SignLink("draft:abc", time.Now().Add(time.Hour))
+		assert.Error(t, err)
+	})
+
+	t.Run("Provider prefers the session cookie", func(t *testing.T) {
+		p := s.Provider(NewDexSessionProvider(hclog.NewNullLogger()))
+
+		r := httptest.NewRequest("GET", "/api/v2/me", nil)
+		r.AddCookie(issue(t)[SessionCookieName])
+		email, err := p.Authenticate(r)
+		require.NoError(t, err)
+		assert.Equal(t, "user@example.com", email)
+
+		// Unsigned sessions are not accepted.
+		r = httptest.NewRequest("GET", "/api/v2/me", nil)
+		r.AddCookie(&http.Cookie{Name: SessionCookieName, Value: "user@example.com"})
+		_, err = p.Authenticate(r)
+		assert.Error(t, err)
+	})
+
+	t.Run("CSRFProtect", func(t *testing.T) {
+		cookies := issue(t)
+		h := CSRFProtect(s, hclog.NewNullLogger(),
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+		newRequest := func(method string, withSession bool) *http.Request {
+			r := httptest.NewRequest(method, "/api/v2/drafts", nil)
+			if withSession {
+				r.AddCookie(cookies[SessionCookieName])
+				r.AddCookie(cookies[CSRFCookieName])
+			}
+			return r
+		}
+
+		cases := map[string]struct {
+			req  *http.Request
+			want int
+		}{
+			"GET without token": {newRequest("GET", true), http.StatusOK},
+			"POST without token": {
+				newRequest("POST", true), http.StatusForbidden,
+			},
+			"POST with token": {
+				func() *http.Request {
+					r := newRequest("POST", true)
+					r.Header.Set(CSRFHeaderName, cookies[CSRFCookieName].Value)
+					return r
+				}(),
+				http.StatusOK,
+			},
+			"DELETE with wrong token": {
+				func() *http.Request {
+					r := newRequest("DELETE", true)
+					r.Header.Set(CSRFHeaderName, "wrong")
+					return r
+				}(),
+				http.StatusForbidden,
+			},
+			"POST with Authorization header and signed session": {
+				// The signed session cookie authenticates the request, so the
+				// header doesn't exempt it.
+				func() *http.Request {
+					r := newRequest("POST", true)
+					r.Header.Set("Authorization", "Bearer token")
+					return r
+				}(),
+				http.StatusForbidden,
+			},
+			"POST with Authorization header and OIDC session": {
+				func() *http.Request {
+					r := httptest.NewRequest("POST", "/api/v2/drafts", nil)
+					r.AddCookie(&http.Cookie{
+						Name: oidcadapter.SessionCookieName, Value: "id-token",
+					})
+					r.Header.Set("Authorization", "Bearer token")
+					return r
+				}(),
+				http.StatusOK,
+			},
+			"POST without session": {newRequest("POST", false), http.StatusOK},
+		}
+		for name, c := range cases {
+			t.Run(name, func(t *testing.T) {
+				w := httptest.NewRecorder()
+				h.ServeHTTP(w, c.req)
+				assert.Equal(t, c.want, w.Code)
+				if c.want == http.StatusForbidden {
+					assert.Equal(t, "application/problem+json",
+						w.Header().Get("Content-Type"))
+				}
+			})
+		}
+	})
+
+	t.Run("CSRFProtect sets a missing CSRF cookie", func(t *testing.T) {
+		h := CSRFProtect(s, hclog.NewNullLogger(),
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		session := issue(t)[SessionCookieName]
+
+		r := httptest.NewRequest("GET", "/api/v2/me", nil)
+		r.AddCookie(session)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		require.Len(t, w.Result().Cookies(), 1)
+		assert.Equal(t, CSRFCookieName, w.Result().Cookies()[0].Name)
+		assert.Equal(t, s.CSRFToken(session.Value), w.Result().Cookies()[0].Value)
+	})
+}
@@ -6,11 +6,19 @@ import (
 
 	"github.com/hashicorp-forge/hermes/internal/cmd/base"
 	"github.com/hashicorp-forge/hermes/internal/cmd/commands/canary"
+	"github.com/hashicorp-forge/hermes/internal/cmd/commands/config"
+	"github.com/hashicorp-forge/hermes/internal/cmd/commands/dev"
+	"github.com/hashicorp-forge/hermes/internal/cmd/commands/docs"
+	"github.com/hashicorp-forge/hermes/internal/cmd/commands/doctor"
+	"github.com/hashicorp-forge/hermes/internal/cmd/commands/edge"
 	"github.com/hashicorp-forge/hermes/internal/cmd/commands/indexer"
 	"github.com/hashicorp-forge/hermes/internal/cmd/commands/indexeragent"
+	"github.com/hashicorp-forge/hermes/internal/cmd/commands/migrate"
 	"github.com/hashicorp-forge/hermes/internal/cmd/commands/operator"
+	"github.com/hashicorp-forge/hermes/internal/cmd/commands/secrets"
 	"github.com/hashicorp-forge/hermes/internal/cmd/commands/serve"
 	"github.com/hashicorp-forge/hermes/internal/cmd/commands/server"
+	"github.com/hashicorp-forge/hermes/internal/cmd/commands/tokens"
 	"github.com/hashicorp-forge/hermes/internal/cmd/commands/version"
 )
 
@@ -26,6 +34,76 @@ func initCommands(log hclog.Logger, ui cli.Ui) {
 				Command: b,
 			}, nil
 		},
+		"config": func() (cli.Command, error) {
+			return &config.Command{
+				Command: b,
+			}, nil
+		},
+		"config schema": func() (cli.Command, error) {
+			return &config.SchemaCommand{
+				Command: b,
+			}, nil
+		},
+		"dev": func() (cli.Command, error) {
+			return &dev.Command{
+				Command: b,
+			}, nil
+		},
+		"docs": func() (cli.Command, error) {
+			return &docs.Command{
+				Command: b,
+			}, nil
+		},
+		"docs approve": func() (cli.Command, error) {
+			return &docs.ApproveCommand{
+				Command: b,
+			}, nil
+		},
+		"docs create": func() (cli.Command, error) {
+			return &docs.CreateCommand{
+				Command: b,
+			}, nil
+		},
+		"docs get": func() (cli.Command, error) {
+			return &docs.GetCommand{
+				Command: b,
+			}, nil
+		},
+		"docs list": func() (cli.Command, error) {
+			return &docs.ListCommand{
+				Command: b,
+			}, nil
+		},
+		"docs publish": func() (cli.Command, error) {
+			return &docs.PublishCommand{
+				Command: b,
+			}, nil
+		},
+		"doctor": func() (cli.Command, error) {
+			return &doctor.Command{
+				Command: b,
+			}, nil
+		},
+		"edge": func() (cli.Command, error) {
+			return &edge.Command{
+				Command: b,
+			}, nil
+		},
+		"edge list": func() (cli.Command, error) {
+			return &edge.ListCommand{
+				Command: b,
+			}, nil
+		},
+		"edge sync": func() (cli.Command, error) {
+			return &edge.SyncCommand{
+				Command: b,
+			}, nil
+		},
+		"import": func() (cli.Command, error) {
+			return &docs.ImportCommand{
+				Command: b,
+			}, nil
+		},
 		"indexer": func() (cli.Command, error) {
 			return &indexer.Command{
 				Command: b,
@@ -36,6 +114,16 @@ func initCommands(log hclog.Logger, ui cli.Ui) {
 				Command: b,
 			}, nil
 		},
+		"migrate": func() (cli.Command, error) {
+			return &migrate.Command{
+				Command: b,
+			}, nil
+		},
+		"migrate validate": func() (cli.Command, error) {
+			return &migrate.ValidateCommand{
+				Command: b,
+			}, nil
+		},
 		"operator": func() (cli.Command, error) {
 			return &operator.Command{
 				Command: b,
@@ -46,11 +134,41 @@ func initCommands(log hclog.Logger, ui cli.Ui) {
 				Command: b,
 			}, nil
 		},
+		"operator config-diff": func() (cli.Command, error) {
+			return &operator.ConfigDiffCommand{
+				Command: b,
+			}, nil
+		},
+		"operator generate-api": func() (cli.Command, error) {
+			return &operator.GenerateAPICommand{
+				Command: b,
+			}, nil
+		},
+		"operator generate-config-docs": func() (cli.Command, error) {
+			return &operator.GenerateConfigDocsCommand{
+				Command: b,
+			}, nil
+		},
 		"operator migrate-algolia-to-postgresql": func() (cli.Command, error) {
 			return &operator.MigrateAlgoliaToPostgreSQLCommand{
 				Command: b,
 			}, nil
 		},
+		"operator replay-revisions": func() (cli.Command, error) {
+			return &operator.ReplayRevisionsCommand{
+				Command: b,
+			}, nil
+		},
+		"secrets": func() (cli.Command, error) {
+			return &secrets.Command{
+				Command: b,
+			}, nil
+		},
+		"secrets check": func() (cli.Command, error) {
+			return &secrets.CheckCommand{
+				Command: b,
+			}, nil
+		},
 		"serve": func() (cli.Command, error) {
 			return &serve.Command{
 				Command: b,
@@ -61,6 +179,20 @@ func initCommands(log hclog.Logger, ui cli.Ui) {
 				Command: b,
 			}, nil
 		},
+		"tokens": func() (cli.Command, error) {
+			return &tokens.Command{
+				Command: b,
+			}, nil
+		},
+		"tokens create": func() (cli.Command, error) {
+			return tokens.NewCreateCommand(b, nil), nil
+		},
+		"tokens list": func() (cli.Command, error) {
+			return tokens.NewListCommand(b, nil), nil
+		},
+		"tokens revoke": func() (cli.Command, error) {
+			return tokens.NewRevokeCommand(b, nil), nil
+		},
 		"version": func() (cli.Command, error) {
 			return &version.Command{
 				Command: b,
@@ -0,0 +1,25 @@
+package config
+
+import (
+	"github.com/hashicorp-forge/hermes/internal/cmd/base"
+	"github.com/mitchellh/cli"
+)
+
+type Command struct {
+	*base.Command
+}
+
+func (c *Command) Synopsis() string {
+	return "Inspect Hermes configuration files"
+}
+
+func (c *Command) Help() string {
+	return `Usage: hermes config <subcommand> [options] [args]
+
+  This command groups subcommands for inspecting the configuration files of
+  the Hermes server, indexer, and notifiers.`
+}
+
+func (c *Command) Run(args []string) int {
+	return cli.RunResultHelp
+}
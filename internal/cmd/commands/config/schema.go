@@ -0,0 +1,70 @@
+package config
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp-forge/hermes/internal/cmd/base"
+	hermesconfig "github.com/hashicorp-forge/hermes/internal/config"
+)
+
+type SchemaCommand struct {
+	*base.Command
+
+	flagKind string
+}
+
+func (c *SchemaCommand) Synopsis() string {
+	return "Print the documented schema of a configuration file"
+}
+
+func (c *SchemaCommand) Help() string {
+	return `Usage: hermes config schema [options] [block ...]
+
+  This command prints every block and setting of a configuration file as
+  documented HCL, with the type of each setting and whether it's required.
+  If blocks are given, only those top-level blocks and settings are printed.
+
+  The server configuration is also used by hermes-indexer. Its settings can
+  be wrapped in profile "<name>" blocks.
+
+  Example:
+    hermes config schema postgres server` +
+		c.Flags().Help()
+}
+
+func (c *SchemaCommand) Flags() *base.FlagSet {
+	f := base.NewFlagSet(flag.NewFlagSet("schema", flag.ExitOnError))
+
+	f.StringVar(
+		&c.flagKind, "kind", "server",
+		fmt.Sprintf("Kind of configuration file (%s).",
+			strings.Join(hermesconfig.SchemaKinds(), ", ")),
+	)
+
+	return f
+}
+
+func (c *SchemaCommand) Run(args []string) int {
+	ui := c.UI
+
+	// Parse flags.
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		ui.Error(fmt.Sprintf("error parsing flags: %v", err))
+		return 1
+	}
+
+	var buf bytes.Buffer
+	if err := hermesconfig.WriteSchema(
+		&buf, c.flagKind, flags.Args()...,
+	); err != nil {
+		ui.Error(fmt.Sprintf("error writing schema: %v", err))
+		return 1
+	}
+
+	ui.Output(strings.TrimSuffix(buf.String(), "\n"))
+	return 0
+}
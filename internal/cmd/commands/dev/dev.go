@@ -0,0 +1,212 @@
+package dev
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/cmd/base"
+	"github.com/hashicorp-forge/hermes/internal/cmd/commands/server"
+	"github.com/hashicorp-forge/hermes/internal/config"
+	dbpkg "github.com/hashicorp-forge/hermes/internal/db"
+	"github.com/hashicorp-forge/hermes/pkg/workspace/adapters/api"
+)
+
+// seedTimeout is how long to wait for the server to start before importing
+// the example documents.
+const seedTimeout = 2 * time.Minute
+
+type Command struct {
+	*base.Command
+
+	flagAddr  string
+	flagDir   string
+	flagReset bool
+	flagSeed  bool
+	flagUser  string
+}
+
+func (c *Command) Synopsis() string {
+	return "Run a self-contained development server"
+}
+
+func (c *Command) Help() string {
+	return `Usage: hermes dev [options]
+
+  This command runs a Hermes server for evaluation and frontend development
+  that doesn't need Docker, Google Workspace, PostgreSQL, Kafka, or an
+  identity provider. It uses:
+
+    - The local workspace provider, with mock people and teams
+    - Bleve embedded search
+    - An SQLite database
+    - The web app embedded in the binary
+
+  The first run creates a workspace directory with a config file, the mock
+  people (users.json) and teams (teams.json), and document templates, and
+  imports a few example documents. Later runs reuse the workspace, including
+  any changes made to these files.
+
+  Every request is signed in as the -user flag's user without a login. To act
+  as another user, for example in API requests, set the X-Hermes-Dev-User
+  header to their email address. Never expose a dev server to a network.
+
+  Example:
+    hermes dev -dir=/tmp/hermes-dev -reset` +
+		c.Flags().Help()
+}
+
+func (c *Command) Flags() *base.FlagSet {
+	f := base.NewFlagSet(flag.NewFlagSet("dev", flag.ExitOnError))
+
+	f.StringVar(
+		&c.flagAddr, "addr", "127.0.0.1:8000", "Address to bind to for listening.",
+	)
+	f.StringVar(
+		&c.flagDir, "dir", ".hermes-dev", "Directory of the development workspace.",
+	)
+	f.BoolVar(
+		&c.flagReset, "reset", false,
+		"Delete the development workspace and start over.",
+	)
+	f.BoolVar(
+		&c.flagSeed, "seed", true,
+		"Import example documents into a new development workspace.",
+	)
+	f.StringVar(
+		&c.flagUser, "user", "dev@hermes.local",
+		"Email address of the user that requests are signed in as.",
+	)
+
+	return f
+}
+
+func (c *Command) Run(args []string) int {
+	ui := c.UI
+
+	// Parse flags.
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		ui.Error(fmt.Sprintf("error parsing flags: %v", err))
+		return 1
+	}
+
+	// Validate flags.
+	if c.flagUser == "" {
+		ui.Error("user flag is required")
+		return 1
+	}
+	dir, err := filepath.Abs(c.flagDir)
+	if err != nil {
+		ui.Error(fmt.Sprintf("error resolving workspace directory: %v", err))
+		return 1
+	}
+
+	// Create the workspace.
+	if c.flagReset {
+		if err := resetWorkspace(dir); err != nil {
+			ui.Error(fmt.Sprintf("error resetting workspace: %v", err))
+			return 1
+		}
+	}
+	if err := prepareWorkspace(dir, c.flagAddr); err != nil {
+		ui.Error(fmt.Sprintf("error creating workspace: %v", err))
+		return 1
+	}
+
+	cfg, err := config.NewConfig(filepath.Join(dir, "config.hcl"), "")
+	if err != nil {
+		ui.Error(fmt.Sprintf("error parsing config file: %v", err))
+		return 1
+	}
+	cfg.DevUser = c.flagUser
+	// The outbox relay needs Kafka, so don't start it.
+	cfg.Indexer = nil
+
+	// Open and migrate the database.
+	db, err := dbpkg.NewSQLiteDB(cfg.DBPath)
+	if err != nil {
+		ui.Error(fmt.Sprintf("error opening database: %v", err))
+		return 1
+	}
+	if err := dbpkg.MigrateSQLite(db); err != nil {
+		ui.Error(fmt.Sprintf("error migrating database: %v", err))
+		return 1
+	}
+
+	// Import the example documents once the server is up.
+	seeded := filepath.Join(dir, "data", seededFile)
+	if _, err := os.Stat(seeded); c.flagSeed && os.IsNotExist(err) {
+		go c.seed(cfg.BaseURL, seeded)
+	}
+
+	ui.Info(fmt.Sprintf("Development workspace: %s", dir))
+	ui.Info(fmt.Sprintf("Signed in as: %s", c.flagUser))
+	ui.Info(fmt.Sprintf("Open %s in your browser.", cfg.BaseURL))
+
+	srv := &server.Command{
+		Command: c.Command,
+		Config:  cfg,
+		DB:      db,
+	}
+	return srv.Run(nil)
+}
+
+// seed imports the example documents into the server at baseURL after it
+// starts, and then creates the seeded file.
+func (c *Command) seed(baseURL, seeded string) {
+	ctx, cancel := context.WithTimeout(context.Background(), seedTimeout)
+	defer cancel()
+
+	if err := waitForServer(ctx, baseURL); err != nil {
+		c.UI.Warn(fmt.Sprintf("Not importing example documents: %v", err))
+		return
+	}
+
+	// The dev server doesn't check the token, but the client requires one.
+	p, err := api.NewProvider(&api.Config{BaseURL: baseURL, AuthToken: "dev"})
+	if err != nil {
+		c.UI.Warn(fmt.Sprintf("error creating API client: %v", err))
+		return
+	}
+	if err := seedDocuments(ctx, p.APIClient(), func(format string, a ...any) {
+		c.UI.Info(fmt.Sprintf(format, a...))
+	}); err != nil {
+		c.UI.Warn(fmt.Sprintf("error importing example documents: %v", err))
+		return
+	}
+
+	if err := os.WriteFile(seeded, nil, 0644); err != nil {
+		c.UI.Warn(fmt.Sprintf("error creating %s: %v", seeded, err))
+	}
+}
+
+// waitForServer waits until the server at baseURL is healthy.
+func waitForServer(ctx context.Context, baseURL string) error {
+	ticker := time.NewTicker(200 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		req, err := http.NewRequestWithContext(
+			ctx, http.MethodGet, baseURL+"/health", nil)
+		if err != nil {
+			return err
+		}
+		if resp, err := http.DefaultClient.Do(req); err == nil {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for the server to start")
+		case <-ticker.C:
+		}
+	}
+}
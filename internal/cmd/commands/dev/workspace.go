@@ -0,0 +1,172 @@
+package dev
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/hashicorp-forge/hermes/pkg/apiclient"
+	"github.com/hashicorp-forge/hermes/pkg/workspace"
+)
+
+// seedFS contains the files used to create a development workspace.
+//
+//go:embed seed
+var seedFS embed.FS
+
+// markerFile is created in development workspaces, so -reset only deletes
+// directories that were created by the dev command.
+const markerFile = ".hermes-dev"
+
+// seededFile is created in the data directory after the example documents
+// are imported, so they're only imported once.
+const seededFile = "seeded"
+
+// prepareWorkspace creates the development workspace in dir, if it doesn't
+// exist, with a config file that listens on addr, mock people and teams, and
+// document templates. Existing files are left as they are.
+func prepareWorkspace(dir, addr string) error {
+	for _, d := range []string{"data", "docs", "drafts", "folders", "templates"} {
+		if err := os.MkdirAll(filepath.Join(dir, d), 0755); err != nil {
+			return err
+		}
+	}
+
+	// Create the config file.
+	tmpl, err := template.ParseFS(seedFS, "seed/config.hcl.tmpl")
+	if err != nil {
+		return err
+	}
+	var cfg bytes.Buffer
+	if err := tmpl.Execute(&cfg, struct{ Addr, Dir string }{
+		Addr: addr,
+		Dir:  filepath.ToSlash(dir),
+	}); err != nil {
+		return err
+	}
+
+	files := map[string][]byte{
+		markerFile:   {},
+		"config.hcl": cfg.Bytes(),
+	}
+	for _, name := range []string{"users.json", "teams.json"} {
+		b, err := seedFS.ReadFile(path.Join("seed", name))
+		if err != nil {
+			return err
+		}
+		files[name] = b
+	}
+	templates, err := fs.Glob(seedFS, "seed/templates/*.md")
+	if err != nil {
+		return err
+	}
+	for _, t := range templates {
+		b, err := seedFS.ReadFile(t)
+		if err != nil {
+			return err
+		}
+		files[path.Join("templates", path.Base(t))] = b
+	}
+
+	for name, b := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if _, err := os.Stat(p); err == nil {
+			continue
+		}
+		if err := os.WriteFile(p, b, 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resetWorkspace deletes the development workspace in dir. It returns an
+// error if dir exists but wasn't created by the dev command.
+func resetWorkspace(dir string) error {
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil
+	}
+	if _, err := os.Stat(filepath.Join(dir, markerFile)); err != nil {
+		return fmt.Errorf(
+			"%s wasn't created by hermes dev (no %s file), so it won't be deleted",
+			dir, markerFile)
+	}
+	return os.RemoveAll(dir)
+}
+
+// seedDocuments imports the example documents with client. logf is called
+// with the result of importing each document.
+func seedDocuments(
+	ctx context.Context, client *apiclient.Client, logf func(string, ...any),
+) error {
+	docs, err := fs.Glob(seedFS, "seed/docs/*.md")
+	if err != nil {
+		return err
+	}
+
+	for _, p := range docs {
+		data, err := seedFS.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		req, err := seedImportRequest(data, p)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path.Base(p), err)
+		}
+
+		resp, err := client.ImportDocument(ctx, req)
+		if err != nil {
+			return fmt.Errorf("error importing %q: %w", req.Title, err)
+		}
+		logf("Imported example document %q as %s", req.Title, resp.DocNumber)
+	}
+
+	return nil
+}
+
+// seedImportRequest returns the request to import the example document in
+// data, reading the document type, product, reviewers, and other metadata
+// from its frontmatter.
+func seedImportRequest(
+	data []byte, name string,
+) (apiclient.DocumentImportRequest, error) {
+	meta, body, err := workspace.NewFrontmatterParser("local").
+		ParseFrontmatter(data, name)
+	if err != nil {
+		return apiclient.DocumentImportRequest{}, err
+	}
+
+	str := func(key string) string {
+		s, _ := meta.ExtendedMetadata[key].(string)
+		return s
+	}
+	list := func(key string) []string {
+		l, _ := meta.ExtendedMetadata[key].([]string)
+		return l
+	}
+	requestReview, _ := meta.ExtendedMetadata["request_review"].(bool)
+
+	req := apiclient.DocumentImportRequest{
+		Approvers:     list("approvers"),
+		Contributors:  list("contributors"),
+		DocType:       str("doc_type"),
+		Markdown:      body,
+		Product:       str("product"),
+		RequestReview: requestReview,
+		Summary:       str("summary"),
+		Title:         meta.Name,
+	}
+	if req.Title == "" || req.DocType == "" || req.Product == "" ||
+		strings.TrimSpace(req.Markdown) == "" {
+		return req, fmt.Errorf("title, doc_type, product, and content are required")
+	}
+	return req, nil
+}
@@ -0,0 +1,63 @@
+package docs
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/hashicorp-forge/hermes/internal/cmd/base"
+)
+
+type ApproveCommand struct {
+	*base.Command
+	serverFlags
+}
+
+func (c *ApproveCommand) Synopsis() string {
+	return "Approve a document"
+}
+
+func (c *ApproveCommand) Help() string {
+	return `Usage: hermes docs approve [options] <document ID>
+
+  This command approves a document in review as the authenticated user.
+
+  Example:
+    hermes docs approve 1a2b3c4d` +
+		c.Flags().Help()
+}
+
+func (c *ApproveCommand) Flags() *base.FlagSet {
+	f := base.NewFlagSet(flag.NewFlagSet("approve", flag.ExitOnError))
+	c.serverFlags.addFlags(f)
+	return f
+}
+
+func (c *ApproveCommand) Run(args []string) int {
+	ui := c.UI
+
+	// Parse flags.
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		ui.Error(fmt.Sprintf("error parsing flags: %v", err))
+		return 1
+	}
+	id, err := documentIDArg(flags.Args())
+	if err != nil {
+		ui.Error(err.Error())
+		return 1
+	}
+
+	client, err := c.client()
+	if err != nil {
+		ui.Error(fmt.Sprintf("error creating client: %v", err))
+		return 1
+	}
+
+	if err := client.ApproveDocument(c.Context, id); err != nil {
+		ui.Error(fmt.Sprintf("error approving document: %v", err))
+		return 1
+	}
+
+	ui.Output(fmt.Sprintf("Approved document %s.", id))
+	return 0
+}
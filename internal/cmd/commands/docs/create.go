@@ -0,0 +1,137 @@
+package docs
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp-forge/hermes/internal/cmd/base"
+	"github.com/hashicorp-forge/hermes/pkg/apiclient"
+)
+
+type CreateCommand struct {
+	*base.Command
+	serverFlags
+
+	flagContentFile  string
+	flagContributors string
+	flagProduct      string
+	flagSummary      string
+	flagTags         string
+	flagTitle        string
+	flagType         string
+}
+
+func (c *CreateCommand) Synopsis() string {
+	return "Create a draft"
+}
+
+func (c *CreateCommand) Help() string {
+	return `Usage: hermes docs create [options]
+
+  This command creates a draft and prints its ID. With -content-file, the
+  draft's content is replaced with the contents of the file, e.g. to create
+  an RFC from a pull request template. Setting content requires a workspace
+  provider that supports content editing.
+
+  Example:
+    hermes docs create -type=RFC -product=Terraform \
+      -title="RFC: Remote state locking" -content-file=rfc.md` +
+		c.Flags().Help()
+}
+
+func (c *CreateCommand) Flags() *base.FlagSet {
+	f := base.NewFlagSet(flag.NewFlagSet("create", flag.ExitOnError))
+	c.serverFlags.addFlags(f)
+
+	f.StringVar(&c.flagTitle, "title", "", "(Required) Title of the draft.")
+	f.StringVar(
+		&c.flagType, "type", "", "(Required) Document type of the draft.",
+	)
+	f.StringVar(&c.flagProduct, "product", "", "Product of the draft.")
+	f.StringVar(&c.flagSummary, "summary", "", "Summary of the draft.")
+	f.StringVar(
+		&c.flagContributors, "contributors", "",
+		"Comma-separated email addresses of contributors.",
+	)
+	f.StringVar(&c.flagTags, "tags", "", "Comma-separated tags.")
+	f.StringVar(
+		&c.flagContentFile, "content-file", "",
+		"Path to a file with the content of the draft.",
+	)
+
+	return f
+}
+
+func (c *CreateCommand) Run(args []string) int {
+	ui := c.UI
+
+	// Parse flags.
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		ui.Error(fmt.Sprintf("error parsing flags: %v", err))
+		return 1
+	}
+
+	// Validate flags.
+	if c.flagTitle == "" {
+		ui.Error("title flag is required")
+		return 1
+	}
+	if c.flagType == "" {
+		ui.Error("type flag is required")
+		return 1
+	}
+	var content []byte
+	if c.flagContentFile != "" {
+		var err error
+		if content, err = os.ReadFile(c.flagContentFile); err != nil {
+			ui.Error(fmt.Sprintf("error reading content file: %v", err))
+			return 1
+		}
+	}
+
+	client, err := c.client()
+	if err != nil {
+		ui.Error(fmt.Sprintf("error creating client: %v", err))
+		return 1
+	}
+
+	resp, err := client.CreateDraft(c.Context, apiclient.DraftsRequest{
+		Title:        c.flagTitle,
+		DocType:      c.flagType,
+		Product:      c.flagProduct,
+		Summary:      c.flagSummary,
+		Contributors: splitList(c.flagContributors),
+		Tags:         splitList(c.flagTags),
+	})
+	if err != nil {
+		ui.Error(fmt.Sprintf("error creating draft: %v", err))
+		return 1
+	}
+
+	if content != nil {
+		if err := client.UpdateDocumentContent(c.Context, resp.ID,
+			apiclient.DocumentContentRequest{Content: string(content)},
+		); err != nil {
+			ui.Error(fmt.Sprintf(
+				"error setting content of draft %s: %v", resp.ID, err))
+			return 1
+		}
+	}
+
+	ui.Output(resp.ID)
+	return 0
+}
+
+// splitList splits a comma-separated list, ignoring empty elements.
+func splitList(s string) []string {
+	var values []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
@@ -0,0 +1,90 @@
+package docs
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp-forge/hermes/internal/cmd/base"
+	"github.com/hashicorp-forge/hermes/pkg/apiclient"
+	"github.com/hashicorp-forge/hermes/pkg/workspace/adapters/api"
+	"github.com/mitchellh/cli"
+)
+
+type Command struct {
+	*base.Command
+}
+
+func (c *Command) Synopsis() string {
+	return "Manage documents on a Hermes server"
+}
+
+func (c *Command) Help() string {
+	return `Usage: hermes docs <subcommand> [options] [args]
+
+  This command groups subcommands for managing documents on a running Hermes
+  server, for use in scripts and CI pipelines.
+
+  The server address and API token are set with the -addr and -token flags,
+  or the HERMES_ADDR and HERMES_TOKEN environment variables.`
+}
+
+func (c *Command) Run(args []string) int {
+	return cli.RunResultHelp
+}
+
+// serverFlags are the flags used to connect to a Hermes server.
+type serverFlags struct {
+	flagAddr  string
+	flagToken string
+}
+
+// addFlags adds the server flags to f.
+func (s *serverFlags) addFlags(f *base.FlagSet) {
+	f.StringVar(
+		&s.flagAddr, "addr", os.Getenv("HERMES_ADDR"),
+		"Base URL of the Hermes server. Defaults to $HERMES_ADDR.",
+	)
+	f.StringVar(
+		&s.flagToken, "token", os.Getenv("HERMES_TOKEN"),
+		"API token used to authenticate to the server. Defaults to $HERMES_TOKEN.",
+	)
+}
+
+// client returns an API client for the server, using the API workspace
+// provider's client so requests are authenticated and retried the same way.
+func (s *serverFlags) client() (*apiclient.Client, error) {
+	if s.flagAddr == "" {
+		return nil, fmt.Errorf("addr flag or HERMES_ADDR is required")
+	}
+	if s.flagToken == "" {
+		return nil, fmt.Errorf("token flag or HERMES_TOKEN is required")
+	}
+
+	p, err := api.NewProvider(&api.Config{
+		BaseURL:   s.flagAddr,
+		AuthToken: s.flagToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return p.APIClient(), nil
+}
+
+// documentIDArg returns the document ID argument of a command.
+func documentIDArg(args []string) (string, error) {
+	if len(args) != 1 || args[0] == "" {
+		return "", fmt.Errorf("expected exactly one document ID argument")
+	}
+	return args[0], nil
+}
+
+// outputJSON writes v as indented JSON.
+func outputJSON(ui cli.Ui, v any) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding JSON: %w", err)
+	}
+	ui.Output(string(b))
+	return nil
+}
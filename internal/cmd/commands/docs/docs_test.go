@@ -0,0 +1,166 @@
+package docs
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp-forge/hermes/internal/cmd/base"
+)
+
+// testServer returns a server that records the requests it receives and
+// responds to the endpoints used by the docs commands.
+func testServer(t *testing.T, requests *[]string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+			*requests = append(*requests, r.Method+" "+r.URL.Path)
+
+			w.Header().Set("Content-Type", "application/json")
+			switch r.Method + " " + r.URL.Path {
+			case "POST /api/v2/search/docs":
+				var req map[string]any
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+				assert.Equal(t, []any{"docType:RFC"}, req["filters"])
+				w.Write([]byte(`{"Hits": [{"objectID": "doc1", "docNumber": "TF-001", "docType": "RFC", "status": "In-Review", "title": "Locking"}]}`))
+			case "POST /api/v2/drafts":
+				w.Write([]byte(`{"id": "draft1"}`))
+			case "PUT /api/v2/documents/draft1/content":
+				var req map[string]any
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+				assert.Equal(t, "# Locking\n", req["content"])
+			case "POST /api/v2/documents/import":
+				var req map[string]any
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+				*requests = append(*requests, "import "+req["title"].(string))
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{"id": "doc2", "uuid": "u", "docNumber": "VLT-???"}`))
+			case "POST /api/v2/reviews/draft1", "POST /api/v2/approvals/doc1":
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestListCommand(t *testing.T) {
+	var requests []string
+	srv := testServer(t, &requests)
+	ui := cli.NewMockUi()
+	c := &ListCommand{Command: base.NewCommand(hclog.NewNullLogger(), ui)}
+
+	code := c.Run([]string{
+		"-addr", srv.URL, "-token", "test-token", "-type", "RFC",
+	})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	assert.Contains(t, requests, "POST /api/v2/search/docs")
+	assert.Regexp(t, `doc1\s+TF-001\s+RFC\s+In-Review\s+Locking`,
+		ui.OutputWriter.String())
+}
+
+func TestCreateCommand(t *testing.T) {
+	var requests []string
+	srv := testServer(t, &requests)
+	ui := cli.NewMockUi()
+	c := &CreateCommand{Command: base.NewCommand(hclog.NewNullLogger(), ui)}
+
+	contentFile := filepath.Join(t.TempDir(), "rfc.md")
+	require.NoError(t, os.WriteFile(contentFile, []byte("# Locking\n"), 0644))
+
+	code := c.Run([]string{
+		"-addr", srv.URL, "-token", "test-token",
+		"-title", "Locking", "-type", "RFC", "-content-file", contentFile,
+	})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	assert.Contains(t, requests, "POST /api/v2/drafts")
+	assert.Contains(t, requests, "PUT /api/v2/documents/draft1/content")
+	assert.Equal(t, "draft1\n", ui.OutputWriter.String())
+}
+
+func TestCreateCommandRequiresTitle(t *testing.T) {
+	ui := cli.NewMockUi()
+	c := &CreateCommand{Command: base.NewCommand(hclog.NewNullLogger(), ui)}
+
+	code := c.Run([]string{"-addr", "http://localhost", "-token", "t"})
+	assert.Equal(t, 1, code)
+	assert.Contains(t, ui.ErrorWriter.String(), "title flag is required")
+}
+
+func TestPublishAndApproveCommands(t *testing.T) {
+	var requests []string
+	srv := testServer(t, &requests)
+	flags := []string{"-addr", srv.URL, "-token", "test-token"}
+
+	ui := cli.NewMockUi()
+	publish := &PublishCommand{Command: base.NewCommand(hclog.NewNullLogger(), ui)}
+	require.Equal(t, 0, publish.Run(append(flags, "draft1")),
+		ui.ErrorWriter.String())
+
+	approve := &ApproveCommand{Command: base.NewCommand(hclog.NewNullLogger(), ui)}
+	require.Equal(t, 0, approve.Run(append(flags, "doc1")),
+		ui.ErrorWriter.String())
+
+	assert.Contains(t, requests, "POST /api/v2/reviews/draft1")
+	assert.Contains(t, requests, "POST /api/v2/approvals/doc1")
+
+	assert.Equal(t, 1, approve.Run(flags))
+}
+
+func TestImportCommand(t *testing.T) {
+	var requests []string
+	srv := testServer(t, &requests)
+	ui := cli.NewMockUi()
+	c := &ImportCommand{Command: base.NewCommand(hclog.NewNullLogger(), ui)}
+
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "sub"), 0755))
+	for name, content := range map[string]string{
+		"a.md":            "---\ntitle: Locking\nsummary: Locks\n---\n\nBody\n",
+		"sub/b.markdown":  "# Heading\n\nBody\n",
+		"sub/c-d_e.md":    "Body\n",
+		"sub/empty.md":    "",
+		"sub/ignored.txt": "Body\n",
+	} {
+		require.NoError(t, os.WriteFile(
+			filepath.Join(dir, name), []byte(content), 0644))
+	}
+
+	code := c.Run([]string{
+		"-addr", srv.URL, "-token", "test-token",
+		"-dir", dir, "-doc-type", "RFC", "-product", "Vault",
+	})
+	assert.Equal(t, 1, code)
+	assert.Contains(t, requests, "import Locking")
+	assert.Contains(t, requests, "import Heading")
+	assert.Contains(t, requests, "import c d e")
+	assert.Contains(t, ui.ErrorWriter.String(), "empty.md: document is empty")
+	assert.Contains(t, ui.OutputWriter.String(),
+		"Imported 3 of 4 document(s); 1 failed.")
+}
+
+func TestImportCommandFrontmatter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rfc.md")
+	require.NoError(t, os.WriteFile(path, []byte(
+		"---\ntitle: Locking\ndescription: Locks\ncontributors: [a@example.com, b@example.com]\n---\n\n# Heading\n"),
+		0644))
+
+	c := &ImportCommand{flagDocType: "RFC", flagProduct: "Vault"}
+	req, err := c.importRequest(path)
+	require.NoError(t, err)
+	assert.Equal(t, "Locking", req.Title)
+	assert.Equal(t, "Locks", req.Summary)
+	assert.Equal(t, []string{"a@example.com", "b@example.com"}, req.Contributors)
+	assert.Equal(t, "# Heading", req.Markdown)
+	assert.Equal(t, "RFC", req.DocType)
+	assert.Equal(t, "Vault", req.Product)
+}
@@ -0,0 +1,74 @@
+package docs
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/hashicorp-forge/hermes/internal/cmd/base"
+	"github.com/hashicorp-forge/hermes/pkg/workspace"
+)
+
+type GetCommand struct {
+	*base.Command
+	serverFlags
+}
+
+func (c *GetCommand) Synopsis() string {
+	return "Get a document"
+}
+
+func (c *GetCommand) Help() string {
+	return `Usage: hermes docs get [options] <document ID>
+
+  This command prints a document or draft as JSON.
+
+  Example:
+    hermes docs get 1a2b3c4d` +
+		c.Flags().Help()
+}
+
+func (c *GetCommand) Flags() *base.FlagSet {
+	f := base.NewFlagSet(flag.NewFlagSet("get", flag.ExitOnError))
+	c.serverFlags.addFlags(f)
+	return f
+}
+
+func (c *GetCommand) Run(args []string) int {
+	ui := c.UI
+
+	// Parse flags.
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		ui.Error(fmt.Sprintf("error parsing flags: %v", err))
+		return 1
+	}
+	id, err := documentIDArg(flags.Args())
+	if err != nil {
+		ui.Error(err.Error())
+		return 1
+	}
+
+	client, err := c.client()
+	if err != nil {
+		ui.Error(fmt.Sprintf("error creating client: %v", err))
+		return 1
+	}
+
+	// Documents and drafts have separate endpoints, so fall back to the
+	// drafts endpoint if the document isn't found.
+	doc, err := client.GetDocument(c.Context, id)
+	if errors.Is(err, workspace.ErrNotFound) {
+		doc, err = client.GetDraft(c.Context, id)
+	}
+	if err != nil {
+		ui.Error(fmt.Sprintf("error getting document: %v", err))
+		return 1
+	}
+
+	if err := outputJSON(ui, doc); err != nil {
+		ui.Error(err.Error())
+		return 1
+	}
+	return 0
+}
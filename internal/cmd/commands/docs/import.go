@@ -0,0 +1,249 @@
+package docs
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp-forge/hermes/internal/cmd/base"
+	"github.com/hashicorp-forge/hermes/pkg/apiclient"
+	"github.com/hashicorp-forge/hermes/pkg/workspace"
+)
+
+type ImportCommand struct {
+	*base.Command
+	serverFlags
+
+	flagDir           string
+	flagDocType       string
+	flagDryRun        bool
+	flagProduct       string
+	flagRequestReview bool
+}
+
+func (c *ImportCommand) Synopsis() string {
+	return "Import a directory of Markdown documents"
+}
+
+func (c *ImportCommand) Help() string {
+	return `Usage: hermes import [options]
+
+  This command imports every Markdown file (.md or .markdown) in a directory
+  and its subdirectories into a Hermes server. Each document is assigned a
+  UUID, recorded in the database, and indexed for search, like documents
+  imported in the web app.
+
+  Titles, summaries, and contributors are read from YAML frontmatter
+  ("title", "summary" or "description", and "contributors") if present.
+  Otherwise the title is the document's first heading or, failing that, the
+  file name. Frontmatter is not included in the imported content.
+
+  The exit code is 1 if any document failed to import.
+
+  Example:
+    hermes import -dir=./docs -doc-type=RFC -product=Vault` +
+		c.Flags().Help()
+}
+
+func (c *ImportCommand) Flags() *base.FlagSet {
+	f := base.NewFlagSet(flag.NewFlagSet("import", flag.ExitOnError))
+	c.serverFlags.addFlags(f)
+
+	f.StringVar(
+		&c.flagDir, "dir", "",
+		"(Required) Directory of Markdown documents to import.",
+	)
+	f.StringVar(
+		&c.flagDocType, "doc-type", "",
+		"(Required) Document type of the imported documents.",
+	)
+	f.StringVar(
+		&c.flagProduct, "product", "",
+		"(Required) Product of the imported documents.",
+	)
+	f.BoolVar(
+		&c.flagRequestReview, "request-review", false,
+		"Publish the imported documents for review.",
+	)
+	f.BoolVar(
+		&c.flagDryRun, "dry-run", false,
+		"Only print what would be imported without importing anything.",
+	)
+
+	return f
+}
+
+func (c *ImportCommand) Run(args []string) int {
+	ui := c.UI
+
+	// Parse flags.
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		ui.Error(fmt.Sprintf("error parsing flags: %v", err))
+		return 1
+	}
+
+	// Validate flags.
+	if c.flagDir == "" {
+		ui.Error("dir flag is required")
+		return 1
+	}
+	if c.flagDocType == "" {
+		ui.Error("doc-type flag is required")
+		return 1
+	}
+	if c.flagProduct == "" {
+		ui.Error("product flag is required")
+		return 1
+	}
+
+	// Find documents. WalkDir visits files in lexical order, so documents are
+	// imported in a predictable order.
+	var paths []string
+	if err := filepath.WalkDir(c.flagDir, func(
+		path string, d fs.DirEntry, err error,
+	) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			// Skip hidden directories like .git.
+			if path != c.flagDir && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".md", ".markdown":
+			paths = append(paths, path)
+		}
+		return nil
+	}); err != nil {
+		ui.Error(fmt.Sprintf("error reading directory: %v", err))
+		return 1
+	}
+	if len(paths) == 0 {
+		ui.Output(fmt.Sprintf("No Markdown documents found in %s.", c.flagDir))
+		return 0
+	}
+
+	var client *apiclient.Client
+	if !c.flagDryRun {
+		var err error
+		if client, err = c.client(); err != nil {
+			ui.Error(fmt.Sprintf("error creating client: %v", err))
+			return 1
+		}
+	}
+
+	imported, failed := 0, 0
+	for _, path := range paths {
+		req, err := c.importRequest(path)
+		if err != nil {
+			ui.Error(fmt.Sprintf("%s: %v", path, err))
+			failed++
+			continue
+		}
+
+		if c.flagDryRun {
+			ui.Output(fmt.Sprintf("%s: would import %q", path, req.Title))
+			imported++
+			continue
+		}
+
+		resp, err := client.ImportDocument(c.Context, req)
+		if err != nil {
+			ui.Error(fmt.Sprintf("%s: error importing document: %v", path, err))
+			failed++
+			continue
+		}
+		ui.Output(fmt.Sprintf("%s: imported %q as %s (ID: %s, UUID: %s)",
+			path, req.Title, resp.DocNumber, resp.ID, resp.UUID))
+		imported++
+	}
+
+	// Print summary.
+	verb := "Imported"
+	if c.flagDryRun {
+		verb = "Would import"
+	}
+	ui.Output("")
+	ui.Output(fmt.Sprintf("%s %d of %d document(s); %d failed.",
+		verb, imported, len(paths), failed))
+
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+// importRequest returns the request to import the Markdown document at path.
+func (c *ImportCommand) importRequest(
+	path string,
+) (apiclient.DocumentImportRequest, error) {
+	req := apiclient.DocumentImportRequest{
+		DocType:       c.flagDocType,
+		Product:       c.flagProduct,
+		RequestReview: c.flagRequestReview,
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return req, err
+	}
+	body := string(data)
+
+	// Read metadata from frontmatter.
+	if bytes.HasPrefix(data, []byte("---\n")) {
+		meta, content, err := workspace.NewFrontmatterParser("local").
+			ParseFrontmatter(data, path)
+		if err != nil {
+			return req, fmt.Errorf("error parsing frontmatter: %w", err)
+		}
+		body = content
+		req.Title = meta.Name
+		for _, key := range []string{"summary", "description"} {
+			if s, ok := meta.ExtendedMetadata[key].(string); ok && req.Summary == "" {
+				req.Summary = s
+			}
+		}
+		// The frontmatter parser leaves contributors as extended metadata.
+		switch v := meta.ExtendedMetadata["contributors"].(type) {
+		case []string:
+			req.Contributors = v
+		case string:
+			req.Contributors = splitList(v)
+		}
+	}
+
+	if strings.TrimSpace(body) == "" {
+		return req, fmt.Errorf("document is empty")
+	}
+	req.Markdown = body
+
+	if req.Title == "" {
+		req.Title = markdownTitle(body)
+	}
+	if req.Title == "" {
+		name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		req.Title = strings.NewReplacer("-", " ", "_", " ").Replace(name)
+	}
+
+	return req, nil
+}
+
+// markdownTitle returns the text of the first level 1 heading in md, or an
+// empty string if there isn't one.
+func markdownTitle(md string) string {
+	for _, line := range strings.Split(md, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "# ") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "# "))
+		}
+	}
+	return ""
+}
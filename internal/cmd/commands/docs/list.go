@@ -0,0 +1,119 @@
+package docs
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/hashicorp-forge/hermes/internal/cmd/base"
+	"github.com/hashicorp-forge/hermes/pkg/apiclient"
+)
+
+type ListCommand struct {
+	*base.Command
+	serverFlags
+
+	flagDrafts  bool
+	flagJSON    bool
+	flagLimit   int
+	flagProduct string
+	flagQuery   string
+	flagStatus  string
+	flagType    string
+}
+
+func (c *ListCommand) Synopsis() string {
+	return "List documents"
+}
+
+func (c *ListCommand) Help() string {
+	return `Usage: hermes docs list [options]
+
+  This command lists published documents (or, with -drafts, the current
+  user's drafts) matching a search query and filters.
+
+  Example:
+    hermes docs list -type=RFC -status=In-Review` +
+		c.Flags().Help()
+}
+
+func (c *ListCommand) Flags() *base.FlagSet {
+	f := base.NewFlagSet(flag.NewFlagSet("list", flag.ExitOnError))
+	c.serverFlags.addFlags(f)
+
+	f.StringVar(&c.flagQuery, "query", "", "Search query.")
+	f.StringVar(&c.flagType, "type", "", "Only list documents of this type.")
+	f.StringVar(
+		&c.flagStatus, "status", "", "Only list documents with this status.",
+	)
+	f.StringVar(
+		&c.flagProduct, "product", "", "Only list documents of this product.",
+	)
+	f.BoolVar(&c.flagDrafts, "drafts", false, "List drafts instead.")
+	f.IntVar(&c.flagLimit, "limit", 20, "Maximum number of documents to list.")
+	f.BoolVar(&c.flagJSON, "json", false, "Output the documents as JSON.")
+
+	return f
+}
+
+func (c *ListCommand) Run(args []string) int {
+	ui := c.UI
+
+	// Parse flags.
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		ui.Error(fmt.Sprintf("error parsing flags: %v", err))
+		return 1
+	}
+
+	client, err := c.client()
+	if err != nil {
+		ui.Error(fmt.Sprintf("error creating client: %v", err))
+		return 1
+	}
+
+	var filters []string
+	for _, f := range []struct{ attr, value string }{
+		{"docType", c.flagType},
+		{"status", c.flagStatus},
+		{"product", c.flagProduct},
+	} {
+		if f.value != "" {
+			filters = append(filters, f.attr+":"+f.value)
+		}
+	}
+	index := "docs"
+	if c.flagDrafts {
+		index = "drafts"
+	}
+
+	res, err := client.Search(c.Context, index, apiclient.SearchRequest{
+		Query:       c.flagQuery,
+		HitsPerPage: c.flagLimit,
+		Filters:     filters,
+	})
+	if err != nil {
+		ui.Error(fmt.Sprintf("error listing documents: %v", err))
+		return 1
+	}
+
+	if c.flagJSON {
+		if err := outputJSON(ui, res.Hits); err != nil {
+			ui.Error(err.Error())
+			return 1
+		}
+		return 0
+	}
+
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNUMBER\tTYPE\tSTATUS\tTITLE")
+	for _, doc := range res.Hits {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			doc.ObjectID, doc.DocNumber, doc.DocType, doc.Status, doc.Title)
+	}
+	w.Flush()
+	ui.Output(strings.TrimSuffix(b.String(), "\n"))
+	return 0
+}
@@ -0,0 +1,64 @@
+package docs
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/hashicorp-forge/hermes/internal/cmd/base"
+)
+
+type PublishCommand struct {
+	*base.Command
+	serverFlags
+}
+
+func (c *PublishCommand) Synopsis() string {
+	return "Publish a draft for review"
+}
+
+func (c *PublishCommand) Help() string {
+	return `Usage: hermes docs publish [options] <draft ID>
+
+  This command publishes a draft for review, which assigns it a document
+  number and notifies its approvers.
+
+  Example:
+    hermes docs publish 1a2b3c4d` +
+		c.Flags().Help()
+}
+
+func (c *PublishCommand) Flags() *base.FlagSet {
+	f := base.NewFlagSet(flag.NewFlagSet("publish", flag.ExitOnError))
+	c.serverFlags.addFlags(f)
+	return f
+}
+
+func (c *PublishCommand) Run(args []string) int {
+	ui := c.UI
+
+	// Parse flags.
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		ui.Error(fmt.Sprintf("error parsing flags: %v", err))
+		return 1
+	}
+	id, err := documentIDArg(flags.Args())
+	if err != nil {
+		ui.Error(err.Error())
+		return 1
+	}
+
+	client, err := c.client()
+	if err != nil {
+		ui.Error(fmt.Sprintf("error creating client: %v", err))
+		return 1
+	}
+
+	if err := client.RequestReview(c.Context, id); err != nil {
+		ui.Error(fmt.Sprintf("error publishing draft: %v", err))
+		return 1
+	}
+
+	ui.Output(fmt.Sprintf("Published draft %s for review.", id))
+	return 0
+}
@@ -0,0 +1,636 @@
+package doctor
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	_ "github.com/lib/pq" // PostgreSQL driver
+	"github.com/meilisearch/meilisearch-go"
+	"github.com/twmb/franz-go/pkg/kgo"
+
+	"github.com/hashicorp-forge/hermes/internal/auth"
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/internal/migrate"
+	oidcadapter "github.com/hashicorp-forge/hermes/pkg/auth/adapters/oidc"
+	"github.com/hashicorp-forge/hermes/pkg/kafka"
+	"github.com/hashicorp-forge/hermes/pkg/projectconfig"
+	searchalgolia "github.com/hashicorp-forge/hermes/pkg/search/adapters/algolia"
+	gw "github.com/hashicorp-forge/hermes/pkg/workspace/adapters/google"
+)
+
+// doctor runs the checks of a configuration.
+type doctor struct {
+	cfg               *config.Config
+	ctx               context.Context
+	report            *report
+	searchProvider    string
+	skipConnectivity  bool
+	timeout           time.Duration
+	workspaceProvider string
+}
+
+// run runs all checks.
+func (d *doctor) run() {
+	d.checkConfig()
+	d.checkAuth()
+	d.checkDatabase()
+	d.checkWorkspace()
+	d.checkSearch()
+	d.checkKafka()
+}
+
+// connect runs f with a timeout if connectivity checks are enabled, and
+// records a skipped result named name otherwise.
+func (d *doctor) connect(
+	s *section, name string, f func(ctx context.Context),
+) {
+	if d.skipConnectivity {
+		s.skip(name, "connectivity checks are disabled")
+		return
+	}
+	ctx, cancel := context.WithTimeout(d.ctx, d.timeout)
+	defer cancel()
+	f(ctx)
+}
+
+// checkConfig validates general configuration.
+func (d *doctor) checkConfig() {
+	s := d.report.section("Configuration")
+	cfg := d.cfg
+
+	if cfg.BaseURL == "" {
+		s.fail("Base URL", "base_url is not set",
+			"Set base_url or HERMES_BASE_URL to the URL users visit Hermes at.")
+	} else {
+		s.pass("Base URL", cfg.BaseURL)
+	}
+
+	switch cfg.LogFormat {
+	case "", "standard", "json":
+		s.pass("Log format", "valid")
+	default:
+		s.fail("Log format", fmt.Sprintf("invalid value %q", cfg.LogFormat),
+			`Set log_format to "standard" or "json".`)
+	}
+
+	if cfg.FeatureFlags != nil {
+		if err := config.ValidateFeatureFlags(cfg.FeatureFlags.FeatureFlag); err != nil {
+			s.fail("Feature flags", err.Error(),
+				"Set either enabled or percentage for each feature flag.")
+		} else {
+			s.pass("Feature flags", fmt.Sprintf("%d valid",
+				len(cfg.FeatureFlags.FeatureFlag)))
+		}
+	}
+
+	if cfg.Email != nil && cfg.Email.Enabled && cfg.Email.FromAddress == "" {
+		s.fail("Email", "from_address is not set",
+			"Set email.from_address or disable email.")
+	}
+
+	if cfg.DocumentTypes == nil || len(cfg.DocumentTypes.DocumentType) == 0 {
+		s.warn("Document types", "no document types are configured",
+			"Add document_type blocks so users can create documents.")
+	} else {
+		s.pass("Document types", fmt.Sprintf("%d configured",
+			len(cfg.DocumentTypes.DocumentType)))
+	}
+
+	if cfg.Products == nil || len(cfg.Products.Product) == 0 {
+		s.warn("Products", "no products are configured",
+			"Add product blocks so users can create documents.")
+	} else {
+		s.pass("Products", fmt.Sprintf("%d configured",
+			len(cfg.Products.Product)))
+	}
+
+	if cfg.Providers != nil && cfg.Providers.ProjectsConfigPath != "" {
+		path := cfg.Providers.ProjectsConfigPath
+		projects, err := projectconfig.LoadConfig(path)
+		if err != nil {
+			s.fail("Workspace projects", err.Error(),
+				fmt.Sprintf("Fix %s or unset providers.projects_config_path.", path))
+		} else if err := projectconfig.NewValidator().Validate(projects); err != nil {
+			s.fail("Workspace projects", err.Error(),
+				fmt.Sprintf("Fix the invalid projects in %s.", path))
+		} else {
+			s.pass("Workspace projects", fmt.Sprintf("%d loaded from %s",
+				len(projects.Projects), path))
+		}
+	}
+}
+
+// checkAuth validates the configuration of the authentication provider the
+// server will use.
+func (d *doctor) checkAuth() {
+	s := d.report.section("Authentication")
+	cfg := d.cfg
+
+	if _, err := auth.NewSessions(cfg.Auth); err != nil {
+		s.fail("Sessions", err.Error(), "Fix the auth block.")
+	} else {
+		s.pass("Sessions", "valid")
+	}
+
+	// The server requires an okta block, even if Okta is disabled.
+	if cfg.Okta == nil {
+		s.fail("Okta", "okta block is missing",
+			"Add an okta block; use \"okta { disabled = true }\" if Okta isn't used.")
+	}
+
+	// OIDC takes precedence over Dex, which takes precedence over Okta.
+	switch {
+	case cfg.OIDC != nil && !cfg.OIDC.Disabled:
+		if cfg.OIDC.IssuerURL == "" || cfg.OIDC.ClientID == "" {
+			s.fail("OIDC", "issuer_url and client_id are required",
+				"Set oidc.issuer_url and oidc.client_id.")
+			return
+		}
+		d.connect(s, "OIDC", func(ctx context.Context) {
+			if _, err := oidcadapter.NewAdapter(
+				ctx, *cfg.OIDC, hclog.NewNullLogger()); err != nil {
+				s.fail("OIDC", err.Error(), fmt.Sprintf(
+					"Check that %s is reachable and serves OIDC discovery.",
+					cfg.OIDC.IssuerURL))
+				return
+			}
+			s.pass("OIDC", fmt.Sprintf("discovered issuer %s", cfg.OIDC.IssuerURL))
+		})
+
+	case cfg.Dex != nil && !cfg.Dex.Disabled:
+		if cfg.Dex.IssuerURL == "" || cfg.Dex.ClientID == "" {
+			s.fail("Dex", "issuer_url and client_id are required",
+				"Set dex.issuer_url and dex.client_id.")
+			return
+		}
+		d.connect(s, "Dex", func(ctx context.Context) {
+			url := strings.TrimSuffix(cfg.Dex.IssuerURL, "/") +
+				"/.well-known/openid-configuration"
+			if err := httpGet(ctx, url); err != nil {
+				s.fail("Dex", err.Error(), fmt.Sprintf(
+					"Check that Dex is running at %s.", cfg.Dex.IssuerURL))
+				return
+			}
+			s.pass("Dex", fmt.Sprintf("reachable at %s", cfg.Dex.IssuerURL))
+		})
+
+	case cfg.Okta != nil && !cfg.Okta.Disabled:
+		var missing []string
+		for name, v := range map[string]string{
+			"auth_server_url": cfg.Okta.AuthServerURL,
+			"aws_region":      cfg.Okta.AWSRegion,
+			"client_id":       cfg.Okta.ClientID,
+			"jwt_signer":      cfg.Okta.JWTSigner,
+		} {
+			if v == "" {
+				missing = append(missing, name)
+			}
+		}
+		if len(missing) > 0 {
+			sort.Strings(missing)
+			s.fail("Okta", fmt.Sprintf("missing %s", strings.Join(missing, ", ")),
+				"Set the missing okta settings or disable Okta.")
+			return
+		}
+		s.pass("Okta", "configured")
+
+	default:
+		if d.workspaceProvider != "google" {
+			s.fail("Provider", "no authentication provider is enabled",
+				"Enable an oidc, dex, or okta block; Google authentication "+
+					"requires the google workspace provider.")
+			return
+		}
+		s.pass("Provider", "Google")
+	}
+}
+
+// checkDatabase checks that the database is reachable and migrated.
+func (d *doctor) checkDatabase() {
+	s := d.report.section("Database")
+	cfg := d.cfg
+
+	if cfg.DatabaseType == "sqlite" {
+		s.pass("Driver", fmt.Sprintf("SQLite (%s)", cfg.DBPath))
+		s.warn("Features", "the edge sync API, storage migrations, and semantic search are disabled",
+			"Configure a postgres block with a password to enable them.")
+		return
+	}
+	if cfg.Postgres == nil || cfg.Postgres.Host == "" {
+		s.fail("PostgreSQL", "postgres block is missing or has no host",
+			"Add a postgres block with host, port, user, password, and dbname.")
+		return
+	}
+	s.pass("Driver", "PostgreSQL")
+
+	password := cfg.Postgres.Password
+	if val, ok := os.LookupEnv("HERMES_SERVER_POSTGRES_PASSWORD"); ok {
+		password = val
+	}
+	addr := fmt.Sprintf("%s:%d/%s", cfg.Postgres.Host, cfg.Postgres.Port,
+		cfg.Postgres.DBName)
+
+	d.connect(s, "Connection", func(ctx context.Context) {
+		dsn := fmt.Sprintf(
+			"host=%s user=%s password=%s dbname=%s port=%d sslmode=disable",
+			cfg.Postgres.Host, cfg.Postgres.User, password,
+			cfg.Postgres.DBName, cfg.Postgres.Port)
+		db, err := sql.Open("postgres", dsn)
+		if err != nil {
+			s.fail("Connection", err.Error(), "Check the postgres block.")
+			return
+		}
+		defer db.Close()
+
+		if err := db.PingContext(ctx); err != nil {
+			s.fail("Connection", fmt.Sprintf("%s: %v", addr, err),
+				"Check that PostgreSQL is running and the postgres credentials are correct.")
+			return
+		}
+		s.pass("Connection", addr)
+
+		status, err := migrate.GetStatus(db, "postgres")
+		if err != nil {
+			s.fail("Migrations", err.Error(),
+				"Run hermes-migrate -status to inspect the schema_migrations table.")
+			return
+		}
+		pending := 0
+		for _, m := range status.Migrations {
+			if !m.Applied {
+				pending++
+			}
+		}
+		switch {
+		case status.Dirty:
+			s.fail("Migrations", fmt.Sprintf("version %d is dirty", status.Version),
+				"A migration failed partway through; run hermes-migrate -status for recovery steps.")
+		case pending > 0:
+			s.warn("Migrations", fmt.Sprintf("version %d, %d pending",
+				status.Version, pending),
+				"The server applies pending migrations on startup.")
+		default:
+			s.pass("Migrations", fmt.Sprintf("version %d, up to date", status.Version))
+		}
+	})
+}
+
+// checkWorkspace checks the configuration of the workspace provider and that
+// its folders and document type templates exist.
+func (d *doctor) checkWorkspace() {
+	s := d.report.section(fmt.Sprintf("Workspace provider (%s)", d.workspaceProvider))
+	cfg := d.cfg
+
+	switch d.workspaceProvider {
+	case "google":
+		gwCfg := cfg.GoogleWorkspace
+		if gwCfg == nil {
+			s.fail("Configuration", "google_workspace block is missing",
+				"Add a google_workspace block or select another workspace provider.")
+			return
+		}
+		folders := []struct{ name, id string }{
+			{"docs_folder", gwCfg.DocsFolder},
+			{"drafts_folder", gwCfg.DraftsFolder},
+			{"shortcuts_folder", gwCfg.ShortcutsFolder},
+		}
+		if gwCfg.Auth != nil && gwCfg.Auth.CreateDocsAsUser {
+			folders = append(folders, struct{ name, id string }{
+				"temporary_drafts_folder", gwCfg.TemporaryDraftsFolder,
+			})
+		}
+		ok := true
+		if gwCfg.Domain == "" {
+			s.fail("Configuration", "domain is not set",
+				"Set google_workspace.domain.")
+			ok = false
+		}
+		for _, f := range folders {
+			if f.id == "" {
+				s.fail("Configuration", fmt.Sprintf("%s is not set", f.name),
+					fmt.Sprintf("Set google_workspace.%s to a Google Drive folder ID.", f.name))
+				ok = false
+			}
+		}
+		if !ok {
+			return
+		}
+		s.pass("Configuration", "valid")
+
+		if gwCfg.Auth == nil {
+			s.warn("Folders and templates", "not checked",
+				"OAuth credentials can't be checked non-interactively; "+
+					"configure google_workspace.auth with a service account to check them.")
+			return
+		}
+		d.connect(s, "Folders and templates", func(ctx context.Context) {
+			svc := gw.NewFromConfig(gwCfg.Auth)
+			getFile := func(id string) error {
+				_, err := svc.Drive.Files.Get(id).
+					Fields("id").
+					SupportsAllDrives(true).
+					Context(ctx).
+					Do()
+				return err
+			}
+			for _, f := range folders {
+				if err := getFile(f.id); err != nil {
+					s.fail("Folder "+f.name, err.Error(), fmt.Sprintf(
+						"Check that folder %s exists and is shared with %s.",
+						f.id, gwCfg.Auth.Subject))
+				} else {
+					s.pass("Folder "+f.name, f.id)
+				}
+			}
+			for _, dt := range documentTypes(cfg) {
+				for _, t := range dt.Templates() {
+					name := templateCheckName(dt, t)
+					if t.Template == "" {
+						s.fail(name, "template is not set",
+							"Set the document type's template to a Google Docs file ID.")
+					} else if err := getFile(t.Template); err != nil {
+						s.fail(name, err.Error(), fmt.Sprintf(
+							"Check that template %s exists and is shared with %s.",
+							t.Template, gwCfg.Auth.Subject))
+					} else {
+						s.pass(name, t.Template)
+					}
+				}
+			}
+		})
+
+	case "local":
+		if cfg.LocalWorkspace == nil {
+			s.fail("Configuration", "local_workspace block is missing",
+				"Add a local_workspace block with base_path.")
+			return
+		}
+		localCfg := cfg.LocalWorkspace.ToLocalAdapterConfig()
+		if err := localCfg.Validate(); err != nil {
+			s.fail("Configuration", err.Error(), "Set local_workspace.base_path.")
+			return
+		}
+		s.pass("Configuration", "valid")
+
+		// The server creates missing folders, but their parent must be
+		// writable.
+		for _, dir := range []string{
+			localCfg.DocsPath, localCfg.DraftsPath, localCfg.FoldersPath,
+		} {
+			checkWritableDir(s, "Folder "+dir, dir)
+		}
+
+		templatesPath := filepath.Join(localCfg.BasePath, "templates")
+		for _, dt := range documentTypes(cfg) {
+			for _, t := range dt.Templates() {
+				name := templateCheckName(dt, t)
+				if t.Template == "" {
+					s.warn(name, "template is not set",
+						"Documents of this type will be created without a template.")
+					continue
+				}
+				path := filepath.Join(templatesPath, t.Template+".md")
+				if _, err := os.Stat(path); err != nil {
+					s.fail(name, fmt.Sprintf("%s not found", path),
+						fmt.Sprintf("Create %s or change the document type's template.", path))
+				} else {
+					s.pass(name, path)
+				}
+			}
+		}
+
+	default:
+		s.fail("Provider", fmt.Sprintf("unknown workspace provider %q",
+			d.workspaceProvider),
+			`Set providers.workspace to "google" or "local".`)
+	}
+}
+
+// checkSearch checks the configuration and health of the search provider.
+func (d *doctor) checkSearch() {
+	s := d.report.section(fmt.Sprintf("Search provider (%s)", d.searchProvider))
+	cfg := d.cfg
+
+	switch d.searchProvider {
+	case "algolia":
+		if cfg.Algolia == nil {
+			s.fail("Configuration", "algolia block is missing",
+				"Add an algolia block or select another search provider.")
+			return
+		}
+		if cfg.Algolia.AppID == "" || cfg.Algolia.SearchAPIKey == "" ||
+			cfg.Algolia.WriteAPIKey == "" {
+			s.fail("Configuration",
+				"application_id, search_api_key, and write_api_key are required",
+				"Set the missing algolia settings.")
+			return
+		}
+		s.pass("Configuration", "valid")
+
+		d.connect(s, "Connection", func(ctx context.Context) {
+			adapter, err := searchalgolia.NewAdapter(&searchalgolia.Config{
+				AppID:           cfg.Algolia.AppID,
+				WriteAPIKey:     cfg.Algolia.WriteAPIKey,
+				DocsIndexName:   cfg.Algolia.DocsIndexName,
+				DraftsIndexName: cfg.Algolia.DraftsIndexName,
+			})
+			if err == nil {
+				err = adapter.Healthy(ctx)
+			}
+			if err != nil {
+				s.fail("Connection", err.Error(),
+					"Check the Algolia credentials and docs index name.")
+				return
+			}
+			s.pass("Connection", fmt.Sprintf("application %s", cfg.Algolia.AppID))
+		})
+
+	case "meilisearch":
+		if cfg.Meilisearch == nil || cfg.Meilisearch.Host == "" {
+			s.fail("Configuration", "meilisearch block is missing or has no host",
+				"Add a meilisearch block with host or select another search provider.")
+			return
+		}
+		s.pass("Configuration", "valid")
+
+		d.connect(s, "Connection", func(ctx context.Context) {
+			client := meilisearch.New(cfg.Meilisearch.Host,
+				meilisearch.WithAPIKey(cfg.Meilisearch.APIKey))
+			health, err := client.HealthWithContext(ctx)
+			if err != nil {
+				s.fail("Connection", err.Error(), fmt.Sprintf(
+					"Check that Meilisearch is running at %s and the API key is correct.",
+					cfg.Meilisearch.Host))
+				return
+			}
+			if health.Status != "available" {
+				s.fail("Connection", fmt.Sprintf("status is %q", health.Status),
+					"Check the Meilisearch server logs.")
+				return
+			}
+			s.pass("Connection", cfg.Meilisearch.Host)
+
+			for _, index := range []string{
+				cfg.Meilisearch.DocsIndexName, cfg.Meilisearch.DraftsIndexName,
+			} {
+				if index == "" {
+					continue
+				}
+				if _, err := client.GetIndexWithContext(ctx, index); err != nil {
+					s.warn("Index "+index, "not found",
+						"The server creates missing indexes on startup.")
+				} else {
+					s.pass("Index "+index, "exists")
+				}
+			}
+		})
+
+	case "bleve":
+		if cfg.Bleve == nil || cfg.Bleve.IndexPath == "" {
+			s.fail("Configuration", "bleve block is missing or has no index_path",
+				"Add a bleve block with index_path or select another search provider.")
+			return
+		}
+		s.pass("Configuration", "valid")
+		checkWritableDir(s, "Index path", cfg.Bleve.IndexPath)
+
+	default:
+		s.fail("Provider", fmt.Sprintf("unknown search provider %q",
+			d.searchProvider),
+			`Set providers.search to "algolia", "meilisearch", or "bleve".`)
+	}
+}
+
+// checkKafka checks that the Kafka/Redpanda brokers used by the outbox relay
+// and notifications are reachable.
+func (d *doctor) checkKafka() {
+	cfg := d.cfg
+
+	type brokers struct {
+		name  string
+		addrs []string
+	}
+	var checks []brokers
+	if cfg.Indexer != nil {
+		checks = append(checks, brokers{"Indexer brokers", kafka.GetBrokers(cfg)})
+	}
+	if cfg.Notifications != nil && cfg.Notifications.Enabled {
+		var addrs []string
+		for _, b := range strings.Split(cfg.Notifications.Brokers, ",") {
+			if b = strings.TrimSpace(b); b != "" {
+				addrs = append(addrs, b)
+			}
+		}
+		checks = append(checks, brokers{"Notification brokers", addrs})
+	}
+	if len(checks) == 0 {
+		return
+	}
+
+	s := d.report.section("Kafka/Redpanda")
+	for _, b := range checks {
+		if len(b.addrs) == 0 {
+			s.fail(b.name, "no brokers are configured",
+				"Set notifications.brokers to a comma-separated list of broker addresses.")
+			continue
+		}
+		b := b
+		d.connect(s, b.name, func(ctx context.Context) {
+			client, err := kgo.NewClient(kgo.SeedBrokers(b.addrs...))
+			if err != nil {
+				s.fail(b.name, err.Error(), "Check the broker addresses.")
+				return
+			}
+			defer client.Close()
+
+			if err := client.Ping(ctx); err != nil {
+				s.fail(b.name, fmt.Sprintf("%s: %v", strings.Join(b.addrs, ","), err),
+					"Check that Kafka/Redpanda is running and reachable from this host.")
+				return
+			}
+			s.pass(b.name, strings.Join(b.addrs, ","))
+		})
+	}
+}
+
+// documentTypes returns the configured document types.
+func documentTypes(cfg *config.Config) []*config.DocumentType {
+	if cfg.DocumentTypes == nil {
+		return nil
+	}
+	return cfg.DocumentTypes.DocumentType
+}
+
+// templateCheckName returns the name of the check of template t of document
+// type dt, e.g., "Template RFC" or "Template RFC/short" for template variants.
+func templateCheckName(
+	dt *config.DocumentType, t *config.DocumentTypeTemplateVariant,
+) string {
+	if t.Name == config.DefaultTemplateVariant {
+		return "Template " + dt.Name
+	}
+	return "Template " + dt.Name + "/" + t.Name
+}
+
+// checkWritableDir checks that dir, or its closest existing parent, is a
+// writable directory.
+func checkWritableDir(s *section, name, dir string) {
+	path := dir
+	for {
+		info, err := os.Stat(path)
+		if err == nil {
+			if !info.IsDir() {
+				s.fail(name, fmt.Sprintf("%s is not a directory", path),
+					"Remove the file or change the configured path.")
+				return
+			}
+			break
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			s.fail(name, fmt.Sprintf("%s: %v", dir, err), "Check the configured path.")
+			return
+		}
+		path = parent
+	}
+
+	f, err := os.CreateTemp(path, ".hermes-doctor-*")
+	if err != nil {
+		s.fail(name, fmt.Sprintf("%s is not writable: %v", path, err),
+			"Fix the directory's permissions for the user running Hermes.")
+		return
+	}
+	f.Close()
+	os.Remove(f.Name())
+
+	if path != dir {
+		s.pass(name, fmt.Sprintf("%s (will be created)", dir))
+	} else {
+		s.pass(name, dir)
+	}
+}
+
+// httpGet checks that a GET request to url succeeds.
+func httpGet(ctx context.Context, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+package doctor
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/cmd/base"
+	"github.com/hashicorp-forge/hermes/internal/config"
+)
+
+type Command struct {
+	*base.Command
+
+	flagConfig            string
+	flagProfile           string
+	flagSearchProvider    string
+	flagSkipConnectivity  bool
+	flagTimeout           time.Duration
+	flagWorkspaceProvider string
+}
+
+func (c *Command) Synopsis() string {
+	return "Check configuration and connectivity before running the server"
+}
+
+func (c *Command) Help() string {
+	return `Usage: hermes doctor -config=<path> [options]
+
+  This command loads a Hermes config file and checks that a server started
+  with it will work: the configuration is valid, the selected workspace and
+  search providers are configured, and the database, search provider,
+  Kafka/Redpanda brokers, and workspace provider are reachable. It also
+  verifies that the configured workspace folders and document type templates
+  exist.
+
+  Each check is reported as PASS, WARN, FAIL, or SKIP, with a hint on how to
+  fix any failure. The exit code is 1 if any check failed.
+
+  Providers are selected like the server selects them, from the
+  HERMES_WORKSPACE_PROVIDER and HERMES_SEARCH_PROVIDER environment variables
+  or the providers block of the config.
+
+  Example:
+    hermes doctor -config=config.hcl -profile=production` +
+		c.Flags().Help()
+}
+
+func (c *Command) Flags() *base.FlagSet {
+	f := base.NewFlagSet(flag.NewFlagSet("doctor", flag.ExitOnError))
+
+	f.StringVar(
+		&c.flagConfig, "config", "", "(Required) Path to Hermes config file",
+	)
+	f.StringVar(
+		&c.flagProfile, "profile", "",
+		"[HERMES_SERVER_PROFILE] Configuration profile to use.",
+	)
+	f.StringVar(
+		&c.flagWorkspaceProvider, "workspace-provider", "",
+		"[HERMES_WORKSPACE_PROVIDER] Workspace provider to check. "+
+			"Overrides the provider specified in the config profile.",
+	)
+	f.StringVar(
+		&c.flagSearchProvider, "search-provider", "",
+		"[HERMES_SEARCH_PROVIDER] Search provider to check. "+
+			"Overrides the provider specified in the config profile.",
+	)
+	f.BoolVar(
+		&c.flagSkipConnectivity, "skip-connectivity", false,
+		"Only validate the configuration without connecting to any service.",
+	)
+	f.DurationVar(
+		&c.flagTimeout, "timeout", 10*time.Second,
+		"Timeout of each connectivity check.",
+	)
+
+	return f
+}
+
+func (c *Command) Run(args []string) int {
+	ui := c.UI
+
+	// Parse flags.
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		ui.Error(fmt.Sprintf("error parsing flags: %v", err))
+		return 1
+	}
+
+	// Validate flags.
+	if c.flagConfig == "" {
+		ui.Error("config flag is required")
+		return 1
+	}
+
+	profile := c.flagProfile
+	if val, ok := os.LookupEnv("HERMES_SERVER_PROFILE"); ok && profile == "" {
+		profile = val
+	}
+
+	r := &report{}
+	cfgSection := r.section("Configuration")
+	cfg, err := config.NewConfig(c.flagConfig, profile)
+	if err != nil {
+		cfgSection.fail("Config file", err.Error(),
+			"Fix the config file; see configs/config.hcl for an example.")
+		c.print(r)
+		return 1
+	}
+	cfgSection.pass("Config file", fmt.Sprintf("loaded %s", c.flagConfig))
+
+	d := &doctor{
+		cfg:               cfg,
+		ctx:               c.Context,
+		report:            r,
+		skipConnectivity:  c.flagSkipConnectivity,
+		timeout:           c.flagTimeout,
+		workspaceProvider: providerName(c.flagWorkspaceProvider, "HERMES_WORKSPACE_PROVIDER", cfg.Providers, true),
+		searchProvider:    providerName(c.flagSearchProvider, "HERMES_SEARCH_PROVIDER", cfg.Providers, false),
+	}
+	d.run()
+
+	c.print(r)
+	if r.failed() {
+		return 1
+	}
+	return 0
+}
+
+// print writes the report to the UI.
+func (c *Command) print(r *report) {
+	ui := c.UI
+
+	var passed, warned, failed, skipped int
+	for _, s := range r.sections {
+		ui.Output(s.name)
+		for _, res := range s.results {
+			line := fmt.Sprintf("  [%s] %s: %s", res.status, res.name, res.message)
+			if res.status == statusFail {
+				ui.Error(line)
+			} else {
+				ui.Output(line)
+			}
+			if res.hint != "" && res.status != statusPass {
+				ui.Output(fmt.Sprintf("         %s", res.hint))
+			}
+
+			switch res.status {
+			case statusPass:
+				passed++
+			case statusWarn:
+				warned++
+			case statusFail:
+				failed++
+			case statusSkip:
+				skipped++
+			}
+		}
+		ui.Output("")
+	}
+
+	summary := fmt.Sprintf("%d passed, %d warning(s), %d failed, %d skipped",
+		passed, warned, failed, skipped)
+	if failed > 0 {
+		ui.Error(summary)
+		ui.Error("Fix the failed checks before running \"hermes server\".")
+	} else {
+		ui.Output(summary)
+	}
+}
+
+// providerName returns the name of the workspace or search provider selected
+// by the flag value, environment variable, or config, in that order, like the
+// server selects it.
+func providerName(
+	flagValue, envVar string, providers *config.Providers, workspace bool,
+) string {
+	name := flagValue
+	if val, ok := os.LookupEnv(envVar); ok && name == "" {
+		name = val
+	}
+	if name == "" && providers != nil {
+		if workspace {
+			name = providers.Workspace
+		} else {
+			name = providers.Search
+		}
+	}
+	if name == "" {
+		// Match the server's defaults.
+		if workspace {
+			name = "google"
+		} else {
+			name = "algolia"
+		}
+	}
+	return strings.ToLower(name)
+}
@@ -0,0 +1,159 @@
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp-forge/hermes/internal/cmd/base"
+)
+
+// writeConfig writes a config using the local workspace and Bleve search
+// providers to a temporary directory and returns its path.
+func writeConfig(t *testing.T, extra string) string {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "templates"), 0755))
+	require.NoError(t, os.WriteFile(
+		filepath.Join(dir, "templates", "template-rfc.md"), []byte("# RFC\n"), 0644))
+
+	cfg := `
+base_url = "http://localhost:8000"
+
+providers {
+  workspace = "local"
+  search    = "bleve"
+}
+
+local_workspace {
+  base_path    = "` + dir + `"
+  docs_path    = ""
+  drafts_path  = ""
+  folders_path = ""
+  users_path   = ""
+  tokens_path  = ""
+  domain       = "example.com"
+}
+
+bleve {
+  index_path = "` + filepath.Join(dir, "index") + `"
+}
+
+postgres {
+  dbname   = "hermes"
+  host     = "localhost"
+  password = "postgres"
+  port     = 5432
+  user     = "postgres"
+}
+
+oidc {
+  issuer_url = "http://localhost:5556"
+  client_id  = "hermes"
+}
+
+okta {
+  disabled = true
+}
+
+products {
+  product "Vault" {
+    abbreviation = "VLT"
+  }
+}
+` + extra
+	path := filepath.Join(dir, "config.hcl")
+	require.NoError(t, os.WriteFile(path, []byte(cfg), 0644))
+	return path
+}
+
+func TestDoctor(t *testing.T) {
+	tests := map[string]struct {
+		extra    string
+		wantCode int
+		want     []string
+		wantErr  []string
+	}{
+		"valid": {
+			extra: `
+document_types {
+  document_type "RFC" {
+    template = "template-rfc"
+  }
+}
+`,
+			want: []string{
+				"[PASS] Template RFC:",
+				"[SKIP] OIDC: connectivity checks are disabled",
+				"[SKIP] Connection: connectivity checks are disabled",
+				"(will be created)",
+			},
+		},
+		"missing template": {
+			extra: `
+document_types {
+  document_type "PRD" {
+    template = "template-prd"
+  }
+}
+`,
+			wantCode: 1,
+			wantErr:  []string{"[FAIL] Template PRD:", "1 failed"},
+		},
+		"missing template variant": {
+			extra: `
+document_types {
+  document_type "RFC" {
+    template = "template-rfc"
+
+    template_variant "short" {
+      template = "template-rfc-short"
+    }
+  }
+}
+`,
+			wantCode: 1,
+			want:     []string{"[PASS] Template RFC:"},
+			wantErr:  []string{"[FAIL] Template RFC/short:", "1 failed"},
+		},
+		"invalid log format": {
+			extra:    `log_format = "xml"`,
+			wantCode: 1,
+			wantErr:  []string{`[FAIL] Log format: invalid value "xml"`},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ui := cli.NewMockUi()
+			c := &Command{Command: base.NewCommand(hclog.NewNullLogger(), ui)}
+
+			code := c.Run([]string{
+				"-config", writeConfig(t, tc.extra), "-skip-connectivity",
+			})
+			assert.Equal(t, tc.wantCode, code, ui.ErrorWriter.String())
+			for _, s := range tc.want {
+				assert.Contains(t, ui.OutputWriter.String(), s)
+			}
+			for _, s := range tc.wantErr {
+				assert.Contains(t, ui.ErrorWriter.String(), s)
+			}
+		})
+	}
+}
+
+func TestDoctorInvalidConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.hcl")
+	require.NoError(t, os.WriteFile(path, []byte("base_url = "), 0644))
+
+	ui := cli.NewMockUi()
+	c := &Command{Command: base.NewCommand(hclog.NewNullLogger(), ui)}
+
+	assert.Equal(t, 1, c.Run([]string{"-config", path}))
+	assert.Contains(t, ui.ErrorWriter.String(), "[FAIL] Config file:")
+}
@@ -0,0 +1,81 @@
+package doctor
+
+// status is the outcome of a check.
+type status string
+
+const (
+	statusPass status = "PASS"
+	statusWarn status = "WARN"
+	statusFail status = "FAIL"
+	statusSkip status = "SKIP"
+)
+
+// result is the result of a single check.
+type result struct {
+	name    string
+	status  status
+	message string
+
+	// hint is an actionable suggestion shown for checks that didn't pass.
+	hint string
+}
+
+// section is a group of related check results.
+type section struct {
+	name    string
+	results []result
+}
+
+func (s *section) add(st status, name, message, hint string) {
+	s.results = append(s.results, result{
+		name:    name,
+		status:  st,
+		message: message,
+		hint:    hint,
+	})
+}
+
+func (s *section) pass(name, message string) {
+	s.add(statusPass, name, message, "")
+}
+
+func (s *section) warn(name, message, hint string) {
+	s.add(statusWarn, name, message, hint)
+}
+
+func (s *section) fail(name, message, hint string) {
+	s.add(statusFail, name, message, hint)
+}
+
+func (s *section) skip(name, message string) {
+	s.add(statusSkip, name, message, "")
+}
+
+// report is the report of all checks, grouped into sections.
+type report struct {
+	sections []*section
+}
+
+// section returns the section with the given name, adding it if needed.
+func (r *report) section(name string) *section {
+	for _, s := range r.sections {
+		if s.name == name {
+			return s
+		}
+	}
+	s := &section{name: name}
+	r.sections = append(r.sections, s)
+	return s
+}
+
+// failed returns true if any check failed.
+func (r *report) failed() bool {
+	for _, s := range r.sections {
+		for _, res := range s.results {
+			if res.status == statusFail {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,25 @@
+package edge
+
+import (
+	"github.com/hashicorp-forge/hermes/internal/cmd/base"
+	"github.com/mitchellh/cli"
+)
+
+type Command struct {
+	*base.Command
+}
+
+func (c *Command) Synopsis() string {
+	return "Manage an edge Hermes instance"
+}
+
+func (c *Command) Help() string {
+	return `Usage: hermes edge <subcommand> [options] [args]
+
+  This command groups subcommands for edge Hermes instances, which keep
+  documents locally and sync them with a central Hermes server (RFC-085).`
+}
+
+func (c *Command) Run(args []string) int {
+	return cli.RunResultHelp
+}
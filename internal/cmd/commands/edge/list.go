@@ -0,0 +1,158 @@
+package edge
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/cmd/base"
+	"github.com/hashicorp-forge/hermes/pkg/apiclient"
+	"github.com/hashicorp-forge/hermes/pkg/workspace/adapters/api"
+)
+
+type ListCommand struct {
+	*base.Command
+
+	flagAddr       string
+	flagJSON       bool
+	flagStaleAfter time.Duration
+	flagToken      string
+}
+
+func (c *ListCommand) Synopsis() string {
+	return "List the edge instances syncing with a central server"
+}
+
+func (c *ListCommand) Help() string {
+	return `Usage: hermes edge list [options]
+
+  This command lists the edge instances that have sent a heartbeat to or
+  synced documents with a central Hermes server, with their version, document
+  counts, and when they were last heard from. Edge instances not heard from
+  within -stale-after are flagged as stale.
+
+  Listing edge instances requires a site admin's API token.
+
+  The exit code is 1 if the command failed or any edge instance is stale.
+
+  Example:
+    hermes edge list -addr=https://hermes.example.com -stale-after=1h` +
+		c.Flags().Help()
+}
+
+func (c *ListCommand) Flags() *base.FlagSet {
+	f := base.NewFlagSet(flag.NewFlagSet("edge list", flag.ExitOnError))
+
+	f.StringVar(
+		&c.flagAddr, "addr", os.Getenv("HERMES_ADDR"),
+		"Base URL of the central Hermes server. Defaults to $HERMES_ADDR.",
+	)
+	f.StringVar(
+		&c.flagToken, "token", os.Getenv("HERMES_TOKEN"),
+		"API token used to authenticate to central. Defaults to $HERMES_TOKEN.",
+	)
+	f.DurationVar(
+		&c.flagStaleAfter, "stale-after", 15*time.Minute,
+		"Flag edge instances not heard from within this duration as stale.",
+	)
+	f.BoolVar(&c.flagJSON, "json", false, "Output the edge instances as JSON.")
+
+	return f
+}
+
+func (c *ListCommand) Run(args []string) int {
+	ui := c.UI
+
+	// Parse flags.
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		ui.Error(fmt.Sprintf("error parsing flags: %v", err))
+		return 1
+	}
+
+	// Validate flags.
+	if c.flagAddr == "" {
+		ui.Error("addr flag or HERMES_ADDR is required")
+		return 1
+	}
+	if c.flagToken == "" {
+		ui.Error("token flag or HERMES_TOKEN is required")
+		return 1
+	}
+	if c.flagStaleAfter <= 0 {
+		ui.Error("stale-after flag must be positive")
+		return 1
+	}
+
+	p, err := api.NewProvider(&api.Config{
+		BaseURL:   c.flagAddr,
+		AuthToken: c.flagToken,
+	})
+	if err != nil {
+		ui.Error(fmt.Sprintf("error creating client: %v", err))
+		return 1
+	}
+
+	edges, err := p.APIClient().ListEdges(c.Context, &apiclient.ListEdgesParams{
+		StaleAfter: c.flagStaleAfter.String(),
+	})
+	if err != nil {
+		ui.Error(fmt.Sprintf("error listing edge instances: %v", err))
+		return 1
+	}
+
+	stale := 0
+	for _, e := range edges {
+		if e.Stale {
+			stale++
+		}
+	}
+
+	if c.flagJSON {
+		b, err := json.MarshalIndent(edges, "", "  ")
+		if err != nil {
+			ui.Error(fmt.Sprintf("error encoding JSON: %v", err))
+			return 1
+		}
+		ui.Output(string(b))
+	} else {
+		var b strings.Builder
+		w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tVERSION\tDOCUMENTS\tCONFLICTS\tLAST SEEN\tSTATUS")
+		for _, e := range edges {
+			status := "ok"
+			if e.Stale {
+				status = "stale"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%s\t%s\n",
+				e.Name, e.Version, e.DocumentCount, e.ConflictCount,
+				lastSeen(e), status)
+		}
+		w.Flush()
+		ui.Output(strings.TrimSuffix(b.String(), "\n"))
+	}
+
+	if stale > 0 {
+		ui.Warn(fmt.Sprintf("%d edge instance(s) not heard from in %s.",
+			stale, c.flagStaleAfter))
+		return 1
+	}
+	return 0
+}
+
+// lastSeen returns when the edge instance was last heard from, formatted for
+// output.
+func lastSeen(e apiclient.AdminEdge) string {
+	last := e.LastHeartbeatAt
+	if e.LastSyncAt != nil && (last == nil || e.LastSyncAt.After(*last)) {
+		last = e.LastSyncAt
+	}
+	if last == nil {
+		return "never"
+	}
+	return last.Local().Format(time.RFC3339)
+}
@@ -0,0 +1,240 @@
+package edge
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp-forge/hermes/pkg/edgesync"
+	"github.com/hashicorp-forge/hermes/pkg/workspace"
+	"github.com/hashicorp/go-hclog"
+)
+
+// markdownStore is an edgesync.Store of the Markdown documents with YAML
+// frontmatter in a directory and its subdirectories.
+type markdownStore struct {
+	dir string
+	log hclog.Logger
+
+	// paths are the file paths of the documents by UUID, as of the last call
+	// to Documents.
+	paths map[string]string
+}
+
+var _ edgesync.Store = (*markdownStore)(nil)
+
+// Documents returns the documents with a UUID in their frontmatter.
+func (s *markdownStore) Documents(ctx context.Context) ([]*edgesync.Document, error) {
+	s.paths = map[string]string{}
+
+	var docs []*edgesync.Document
+	err := filepath.WalkDir(s.dir, func(
+		path string, d fs.DirEntry, err error,
+	) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			// Skip hidden directories like .git and .hermes.
+			if path != s.dir && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".md", ".markdown":
+		default:
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if !bytes.HasPrefix(data, []byte("---\n")) {
+			s.log.Debug("skipping document without frontmatter", "path", path)
+			return nil
+		}
+		meta, content, err := workspace.NewFrontmatterParser("local").
+			ParseFrontmatter(data, path)
+		if err != nil {
+			return fmt.Errorf("%s: error parsing frontmatter: %w", path, err)
+		}
+		if meta.UUID.IsZero() {
+			s.log.Debug("skipping document without a UUID", "path", path)
+			return nil
+		}
+
+		doc := documentFromMetadata(meta)
+		doc.Content = content
+		if other, ok := s.paths[doc.UUID]; ok {
+			return fmt.Errorf("%s and %s have the same UUID", other, path)
+		}
+		s.paths[doc.UUID] = path
+		docs = append(docs, doc)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+// documentFromMetadata returns the document with the frontmatter metadata
+// meta.
+func documentFromMetadata(meta *workspace.DocumentMetadata) *edgesync.Document {
+	str := func(keys ...string) string {
+		for _, key := range keys {
+			if s, ok := meta.ExtendedMetadata[key].(string); ok && s != "" {
+				return s
+			}
+		}
+		return ""
+	}
+
+	doc := &edgesync.Document{
+		UUID:         meta.UUID.String(),
+		ProviderID:   meta.ProviderID,
+		Title:        meta.Name,
+		DocumentType: str("doc_type", "document_type"),
+		Status:       meta.WorkflowStatus,
+		Summary:      str("summary", "description"),
+		Product:      str("product"),
+		Project:      meta.Project,
+		Tags:         meta.Tags,
+		ContentHash:  meta.ContentHash,
+		ModifiedTime: meta.ModifiedTime,
+	}
+	if doc.Product == "" {
+		doc.Product = meta.Project
+	}
+	if meta.Owner != nil {
+		doc.Owners = []string{meta.Owner.Email}
+	}
+	return doc
+}
+
+// UpdateMetadata writes the metadata changed on central to the document's
+// frontmatter. Other frontmatter fields and the content are kept as is.
+func (s *markdownStore) UpdateMetadata(ctx context.Context, doc *edgesync.Document) error {
+	path, ok := s.paths[doc.UUID]
+	if !ok {
+		return fmt.Errorf("document %s not found", doc.UUID)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	updated, err := setFrontmatterFields(data, []frontmatterField{
+		{keys: []string{"title", "name"}, value: doc.Title},
+		{keys: []string{"status", "workflow_status"}, value: doc.Status},
+		{keys: []string{"summary", "description"}, value: doc.Summary},
+		{keys: []string{"product"}, value: doc.Product},
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return os.WriteFile(path, updated, 0o644)
+}
+
+// UpdateContent replaces the content of the document with the content changed
+// on central or merged. The frontmatter is kept as is.
+func (s *markdownStore) UpdateContent(ctx context.Context, doc *edgesync.Document) error {
+	path, ok := s.paths[doc.UUID]
+	if !ok {
+		return fmt.Errorf("document %s not found", doc.UUID)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	lines := strings.Split(string(data), "\n")
+	end := -1
+	for i := 1; i < len(lines); i++ {
+		if lines[i] == "---" {
+			end = i
+			break
+		}
+	}
+	if len(lines) == 0 || lines[0] != "---" || end == -1 {
+		return fmt.Errorf("%s: missing frontmatter", path)
+	}
+
+	frontmatter := strings.Join(lines[:end+1], "\n")
+	return os.WriteFile(path,
+		[]byte(frontmatter+"\n\n"+strings.TrimSpace(doc.Content)+"\n"), 0o644)
+}
+
+// frontmatterField is a frontmatter field to set. The first of keys that is
+// present is set; if none are, the first key is added.
+type frontmatterField struct {
+	keys  []string
+	value string
+}
+
+// setFrontmatterFields sets fields in the frontmatter of the document data.
+// Empty fields that aren't present aren't added.
+func setFrontmatterFields(data []byte, fields []frontmatterField) ([]byte, error) {
+	lines := strings.Split(string(data), "\n")
+	if len(lines) == 0 || lines[0] != "---" {
+		return nil, fmt.Errorf("missing frontmatter")
+	}
+	end := -1
+	for i := 1; i < len(lines); i++ {
+		if lines[i] == "---" {
+			end = i
+			break
+		}
+	}
+	if end == -1 {
+		return nil, fmt.Errorf("unterminated frontmatter")
+	}
+
+	// lineOf returns the line index of key in the frontmatter, or -1.
+	lineOf := func(key string) int {
+		for i := 1; i < end; i++ {
+			k, _, ok := strings.Cut(lines[i], ":")
+			if ok && strings.TrimSpace(k) == key {
+				return i
+			}
+		}
+		return -1
+	}
+
+	var added []string
+	for _, f := range fields {
+		line := fmt.Sprintf("%s: %s", f.keys[0], quoteYAML(f.value))
+		set := false
+		for _, key := range f.keys {
+			if i := lineOf(key); i != -1 {
+				lines[i] = fmt.Sprintf("%s: %s", key, quoteYAML(f.value))
+				set = true
+				break
+			}
+		}
+		if !set && f.value != "" {
+			added = append(added, line)
+		}
+	}
+
+	out := append([]string{}, lines[:end]...)
+	out = append(out, added...)
+	out = append(out, lines[end:]...)
+	return []byte(strings.Join(out, "\n")), nil
+}
+
+// quoteYAML quotes s if it would otherwise not be read back as the same
+// string by the frontmatter parser.
+func quoteYAML(s string) string {
+	if s == "" || strings.ContainsAny(s, ":#'\"[]{}") ||
+		strings.TrimSpace(s) != s {
+		return `"` + strings.ReplaceAll(s, `"`, `'`) + `"`
+	}
+	return s
+}
@@ -0,0 +1,68 @@
+package edge
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkdownStore(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "rfc", "locking.md")
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(`---
+uuid: 7e8f5b5a-3c1d-4f2e-9a6b-1c2d3e4f5a6b
+title: Locking
+doc_type: RFC
+product: Terraform
+project: State
+---
+
+# Locking
+`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.md"),
+		[]byte("# No frontmatter\n"), 0o644))
+
+	s := &markdownStore{dir: dir, log: hclog.NewNullLogger()}
+	docs, err := s.Documents(ctx)
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	doc := docs[0]
+	assert.Equal(t, "7e8f5b5a-3c1d-4f2e-9a6b-1c2d3e4f5a6b", doc.UUID)
+	assert.Equal(t, "Locking", doc.Title)
+	assert.Equal(t, "RFC", doc.DocumentType)
+	assert.Equal(t, "Terraform", doc.Product)
+	assert.Equal(t, "State", doc.Project)
+	assert.NotEmpty(t, doc.ContentHash)
+
+	doc.Title = "Locking: a proposal"
+	doc.Status = "Approved"
+	require.NoError(t, s.UpdateMetadata(ctx, doc))
+
+	docs, err = s.Documents(ctx)
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	assert.Equal(t, "Locking: a proposal", docs[0].Title)
+	assert.Equal(t, "Approved", docs[0].Status)
+	assert.Equal(t, doc.ContentHash, docs[0].ContentHash,
+		"content must not change")
+
+	doc = docs[0]
+	assert.Equal(t, "# Locking", doc.Content)
+	doc.Content = "# Locking\n\nUse leases."
+	require.NoError(t, s.UpdateContent(ctx, doc))
+
+	docs, err = s.Documents(ctx)
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	assert.Equal(t, "# Locking\n\nUse leases.", docs[0].Content)
+	assert.Equal(t, "Locking: a proposal", docs[0].Title,
+		"frontmatter must not change")
+	assert.NotEqual(t, doc.ContentHash, docs[0].ContentHash)
+}
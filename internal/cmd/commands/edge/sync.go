@@ -0,0 +1,334 @@
+package edge
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/cmd/base"
+	"github.com/hashicorp-forge/hermes/internal/version"
+	"github.com/hashicorp-forge/hermes/pkg/apiclient"
+	"github.com/hashicorp-forge/hermes/pkg/edgesync"
+	"github.com/hashicorp-forge/hermes/pkg/workspace/adapters/api"
+)
+
+type SyncCommand struct {
+	*base.Command
+
+	flagAddr         string
+	flagBatchSize    int
+	flagCheckpoint   string
+	flagDir          string
+	flagDocTypes     string
+	flagEdgeInstance string
+	flagForce        bool
+	flagInterval     time.Duration
+	flagProducts     string
+	flagProjects     string
+	flagToken        string
+	flagWatch        bool
+}
+
+const (
+	// defaultWatchInterval is the sync interval with -watch if -interval
+	// isn't set.
+	defaultWatchInterval = 5 * time.Minute
+
+	// maxEventsWait is the longest an events request waits, which is below
+	// the API client's request timeout.
+	maxEventsWait = 20 * time.Second
+)
+
+func (c *SyncCommand) Synopsis() string {
+	return "Sync the documents of an edge instance with central"
+}
+
+func (c *SyncCommand) Help() string {
+	return `Usage: hermes edge sync [options]
+
+  This command syncs the Markdown documents in a directory with a central
+  Hermes server. Documents created or changed locally since the last sync
+  are pushed to central, documents deleted locally are deleted on central,
+  and the content and metadata (title, status, summary, and product) changed
+  on central are written to the documents.
+
+  Documents are identified by the "uuid" field of their frontmatter; files
+  without one are skipped. Documents changed both locally and on central are
+  merged by central when the changes don't overlap. Overlapping changes are
+  reported as conflicts, which are resolved on central with the conflicts API
+  (/api/v2/edge/conflicts), or by pushing the local version again with
+  -force.
+
+  With -products, -doc-types, or -projects, only the documents of those
+  products, document types, and projects are synced, e.g., the documents of
+  an office or team. Documents outside of the scope are neither pushed nor
+  deleted on central.
+
+  The sync state is saved to a checkpoint file after each batch, so an
+  interrupted sync resumes where it stopped. With -interval, the command
+  keeps running and syncs at that interval until interrupted. With -watch,
+  it also long-polls central for events about this instance's documents,
+  like new approvals and permission changes, and syncs as soon as one
+  arrives; the interval then defaults to 5m.
+
+  After each sync, a heartbeat with this instance's version and document
+  counts is sent to central, which lists edge instances with
+  "hermes edge list".
+
+  The exit code is 1 if the sync failed or any document is in conflict.
+
+  Example:
+    hermes edge sync -dir=./docs -addr=https://hermes.example.com` +
+		c.Flags().Help()
+}
+
+func (c *SyncCommand) Flags() *base.FlagSet {
+	f := base.NewFlagSet(flag.NewFlagSet("edge sync", flag.ExitOnError))
+
+	f.StringVar(
+		&c.flagAddr, "addr", os.Getenv("HERMES_ADDR"),
+		"Base URL of the central Hermes server. Defaults to $HERMES_ADDR.",
+	)
+	f.StringVar(
+		&c.flagToken, "token", os.Getenv("HERMES_TOKEN"),
+		"Edge sync token used to authenticate to central. Defaults to "+
+			"$HERMES_TOKEN.",
+	)
+	f.StringVar(
+		&c.flagDir, "dir", "",
+		"(Required) Directory of Markdown documents to sync.",
+	)
+	f.StringVar(
+		&c.flagEdgeInstance, "edge-instance", "",
+		"Name of this edge instance. Defaults to the hostname.",
+	)
+	f.StringVar(
+		&c.flagCheckpoint, "checkpoint", "",
+		"Path of the checkpoint file. Defaults to .hermes/edge-sync.json in "+
+			"the directory.",
+	)
+	f.IntVar(
+		&c.flagBatchSize, "batch-size", edgesync.DefaultBatchSize,
+		"Number of documents pushed per request.",
+	)
+	f.DurationVar(
+		&c.flagInterval, "interval", 0,
+		"Sync at this interval until interrupted, instead of once.",
+	)
+	f.StringVar(
+		&c.flagProducts, "products", "",
+		"Comma-separated products of the documents to sync. Defaults to all.",
+	)
+	f.StringVar(
+		&c.flagDocTypes, "doc-types", "",
+		"Comma-separated document types of the documents to sync. Defaults "+
+			"to all.",
+	)
+	f.StringVar(
+		&c.flagProjects, "projects", "",
+		"Comma-separated projects of the documents to sync. Defaults to all.",
+	)
+	f.BoolVar(
+		&c.flagWatch, "watch", false,
+		"Sync as soon as central reports changes to this instance's "+
+			"documents, in addition to at the interval.",
+	)
+	f.BoolVar(
+		&c.flagForce, "force", false,
+		"Push documents in conflict, overwriting the changes made on central.",
+	)
+
+	return f
+}
+
+func (c *SyncCommand) Run(args []string) int {
+	ui := c.UI
+
+	// Parse flags.
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		ui.Error(fmt.Sprintf("error parsing flags: %v", err))
+		return 1
+	}
+
+	// Validate flags.
+	if c.flagDir == "" {
+		ui.Error("dir flag is required")
+		return 1
+	}
+	if c.flagAddr == "" {
+		ui.Error("addr flag or HERMES_ADDR is required")
+		return 1
+	}
+	if c.flagToken == "" {
+		ui.Error("token flag or HERMES_TOKEN is required")
+		return 1
+	}
+	if c.flagInterval < 0 {
+		ui.Error("interval flag must not be negative")
+		return 1
+	}
+	if c.flagWatch && c.flagInterval == 0 {
+		c.flagInterval = defaultWatchInterval
+	}
+	if c.flagCheckpoint == "" {
+		c.flagCheckpoint = filepath.Join(c.flagDir, ".hermes", "edge-sync.json")
+	}
+
+	// The API workspace provider's client authenticates and retries requests
+	// like the edge server does.
+	cfg := &api.Config{
+		BaseURL:      c.flagAddr,
+		AuthToken:    c.flagToken,
+		EdgeInstance: c.flagEdgeInstance,
+	}
+	p, err := api.NewProvider(cfg)
+	if err != nil {
+		ui.Error(fmt.Sprintf("error creating client: %v", err))
+		return 1
+	}
+
+	engine := &edgesync.Engine{
+		Client:         p.APIClient(),
+		Store:          &markdownStore{dir: c.flagDir, log: c.Log},
+		EdgeInstance:   cfg.EdgeInstance,
+		CheckpointPath: c.flagCheckpoint,
+		BatchSize:      c.flagBatchSize,
+		Scope:          c.scope(),
+		Force:          c.flagForce,
+		Logger:         c.Log,
+	}
+
+	if c.flagInterval == 0 {
+		return c.sync(engine)
+	}
+
+	ticker := time.NewTicker(c.flagInterval)
+	defer ticker.Stop()
+	for {
+		// Errors are reported and retried at the next interval.
+		c.sync(engine)
+
+		if c.flagWatch {
+			c.waitForEvents(engine, time.Now().Add(c.flagInterval))
+			ticker.Reset(c.flagInterval)
+			if c.Context.Err() != nil {
+				return 0
+			}
+			continue
+		}
+
+		select {
+		case <-c.Context.Done():
+			return 0
+		case <-ticker.C:
+		}
+	}
+}
+
+// waitForEvents long-polls central for events until one arrives or deadline
+// passes. Errors are logged and retried until the deadline.
+func (c *SyncCommand) waitForEvents(engine *edgesync.Engine, deadline time.Time) {
+	for c.Context.Err() == nil {
+		wait := min(time.Until(deadline), maxEventsWait)
+		if wait <= 0 {
+			return
+		}
+
+		events, err := engine.WaitForEvents(c.Context, wait)
+		if err != nil {
+			if c.Context.Err() != nil {
+				return
+			}
+			c.Log.Warn("error waiting for events", "error", err)
+			select {
+			case <-c.Context.Done():
+			case <-time.After(min(time.Until(deadline), maxEventsWait)):
+			}
+			continue
+		}
+		if len(events) > 0 {
+			for _, e := range events {
+				var uuid string
+				if e.DocumentUUID != nil {
+					uuid = *e.DocumentUUID
+				}
+				c.Log.Info("received event", "type", e.Type,
+					"document_uuid", uuid)
+			}
+			return
+		}
+	}
+}
+
+// scope returns the sync scope set by the flags, or nil to sync all documents.
+func (c *SyncCommand) scope() *apiclient.EdgeSyncScope {
+	scope := &apiclient.EdgeSyncScope{
+		Products:      splitList(c.flagProducts),
+		DocumentTypes: splitList(c.flagDocTypes),
+		Projects:      splitList(c.flagProjects),
+	}
+	if len(scope.Products) == 0 && len(scope.DocumentTypes) == 0 &&
+		len(scope.Projects) == 0 {
+		return nil
+	}
+	return scope
+}
+
+// splitList splits a comma-separated list, ignoring empty elements.
+func splitList(s string) []string {
+	var list []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+// sync runs one sync with engine and reports the result. It returns the exit
+// code of the command.
+func (c *SyncCommand) sync(engine *edgesync.Engine) int {
+	ui := c.UI
+
+	res, err := engine.Sync(c.Context)
+	if err != nil {
+		ui.Error(fmt.Sprintf("error syncing documents: %v", err))
+		return 1
+	}
+	c.heartbeat(engine, res)
+
+	ui.Output(fmt.Sprintf(
+		"Pushed %d, pulled %d, merged %d, and deleted %d document(s); "+
+			"%d in conflict.",
+		res.Pushed, res.Pulled, res.Merged, res.Deleted, len(res.Conflicts)))
+	for _, conflict := range res.Conflicts {
+		ui.Warn(fmt.Sprintf("  %s (%s): %s",
+			conflict.UUID, conflict.Title, conflict.Message))
+	}
+
+	if len(res.Conflicts) > 0 {
+		return 1
+	}
+	return 0
+}
+
+// heartbeat reports the state of the edge instance after a sync to central.
+// Errors are only logged because they don't affect the sync.
+func (c *SyncCommand) heartbeat(engine *edgesync.Engine, res *edgesync.Result) {
+	hostname, _ := os.Hostname()
+	_, err := engine.Client.SendEdgeHeartbeat(c.Context,
+		apiclient.EdgeHeartbeatRequest{
+			EdgeInstance:  engine.EdgeInstance,
+			Version:       version.GetVersion(),
+			Hostname:      hostname,
+			DocumentCount: res.Documents,
+			ConflictCount: len(res.Conflicts),
+		})
+	if err != nil {
+		c.Log.Warn("error sending heartbeat", "error", err)
+	}
+}
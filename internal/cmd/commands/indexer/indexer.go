@@ -134,6 +134,7 @@ func (c *Command) Run(args []string) int {
 		LinksIndexName:         cfg.Algolia.LinksIndexName,
 		MissingFieldsIndexName: cfg.Algolia.MissingFieldsIndexName,
 		ProjectsIndexName:      cfg.Algolia.ProjectsIndexName,
+		Ranking:                cfg.Search.Rankings(),
 	}
 	algo, err = algolia.New(algoliaClientCfg)
 	if err != nil {
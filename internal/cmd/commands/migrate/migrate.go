@@ -0,0 +1,25 @@
+package migrate
+
+import (
+	"github.com/hashicorp-forge/hermes/internal/cmd/base"
+	"github.com/mitchellh/cli"
+)
+
+type Command struct {
+	*base.Command
+}
+
+func (c *Command) Synopsis() string {
+	return "Inspect and validate document migration jobs"
+}
+
+func (c *Command) Help() string {
+	return `Usage: hermes migrate <subcommand> [options] [args]
+
+  This command groups subcommands for working with RFC-089 document
+  migration jobs.`
+}
+
+func (c *Command) Run(args []string) int {
+	return cli.RunResultHelp
+}
@@ -0,0 +1,123 @@
+package migrate
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp-forge/hermes/internal/cmd/base"
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/internal/db"
+	"github.com/hashicorp-forge/hermes/pkg/migration/validate"
+)
+
+type ValidateCommand struct {
+	*base.Command
+
+	flagConfig string
+	flagJSON   bool
+}
+
+func (c *ValidateCommand) Synopsis() string {
+	return "Validate a completed migration job"
+}
+
+func (c *ValidateCommand) Help() string {
+	return `Usage: hermes migrate validate [options] <job-uuid>
+
+  This command runs the migration validator against a migration job and
+  prints a completeness, content integrity, outbox integrity, and invariant
+  report. Content integrity is checked using the recorded source and
+  destination content hashes.
+
+  The command exits with status 2 if any check fails.` +
+		c.Flags().Help()
+}
+
+func (c *ValidateCommand) Flags() *base.FlagSet {
+	f := base.NewFlagSet(
+		flag.NewFlagSet("migrate validate", flag.ExitOnError))
+
+	f.StringVar(
+		&c.flagConfig, "config", "", "(Required) Path to Hermes config file",
+	)
+	f.BoolVar(
+		&c.flagJSON, "json", false,
+		"Output the validation report as JSON.",
+	)
+
+	return f
+}
+
+func (c *ValidateCommand) Run(args []string) int {
+	logger, ui := c.Log, c.UI
+
+	// Parse flags.
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		ui.Error(fmt.Sprintf("error parsing flags: %v", err))
+		return 1
+	}
+	args = flags.Args()
+
+	// Validate flags and arguments.
+	if c.flagConfig == "" {
+		ui.Error("config flag is required")
+		return 1
+	}
+	if len(args) != 1 {
+		ui.Error("expected exactly one argument: <job-uuid>")
+		return 1
+	}
+	jobUUID := args[0]
+
+	// Parse configuration.
+	cfg, err := config.NewConfig(c.flagConfig, "")
+	if err != nil {
+		ui.Error(fmt.Sprintf("error parsing config file: %v", err))
+		return 1
+	}
+
+	// Initialize database.
+	database, err := db.NewDB(*cfg.Postgres)
+	if err != nil {
+		ui.Error(fmt.Sprintf("error initializing database: %v", err))
+		return 1
+	}
+	sqlDB, err := database.DB()
+	if err != nil {
+		ui.Error(fmt.Sprintf("error getting SQL database: %v", err))
+		return 1
+	}
+
+	// Run validation.
+	validator := validate.New(sqlDB, logger)
+	report, err := validator.ValidateJob(c.Context, jobUUID, nil)
+	if err != nil {
+		ui.Error(fmt.Sprintf("error validating migration job: %v", err))
+		return 1
+	}
+
+	// Output report.
+	if c.flagJSON {
+		out, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			ui.Error(fmt.Sprintf("error encoding report: %v", err))
+			return 1
+		}
+		ui.Output(string(out))
+	} else {
+		ui.Info(fmt.Sprintf("Migration job %s (%s), status: %s",
+			report.JobUUID, report.JobName, report.Status))
+		var buf bytes.Buffer
+		validate.WriteReport(&buf, report.Results)
+		ui.Output(strings.TrimRight(buf.String(), "\n"))
+	}
+
+	if !report.Passed() {
+		return 2
+	}
+	return 0
+}
@@ -0,0 +1,203 @@
+package operator
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/cmd/base"
+	"github.com/hashicorp-forge/hermes/internal/config"
+)
+
+// effectiveConfig is the effective configuration returned by a running
+// server.
+type effectiveConfig struct {
+	Version   string `json:"version"`
+	Revision  string `json:"revision"`
+	GoVersion string `json:"goVersion"`
+	Providers struct {
+		Search    string `json:"search"`
+		Workspace string `json:"workspace"`
+	} `json:"providers"`
+	Settings map[string]any `json:"settings"`
+}
+
+type ConfigDiffCommand struct {
+	*base.Command
+
+	flagAddr      string
+	flagConfig    string
+	flagEffective string
+	flagHeader    string
+	flagProfile   string
+}
+
+func (c *ConfigDiffCommand) Synopsis() string {
+	return "Compare a config file with the configuration of a running server"
+}
+
+func (c *ConfigDiffCommand) Help() string {
+	return `Usage: hermes operator config-diff
+
+  This command compares the effective settings of a config file with the
+  effective configuration of a running Hermes server (central or edge),
+  fetched from GET /api/v2/admin/config, and prints the settings that differ.
+  Secrets are redacted on both sides, so only whether they are set is
+  compared.
+
+  The exit code is 0 if the settings match and 2 if they differ.
+
+  Example:
+    hermes operator config-diff -config=config.hcl \
+      -addr=https://hermes.example.com \
+      -header="Authorization: Bearer $TOKEN"` +
+		c.Flags().Help()
+}
+
+func (c *ConfigDiffCommand) Flags() *base.FlagSet {
+	f := base.NewFlagSet(
+		flag.NewFlagSet("config-diff", flag.ExitOnError))
+
+	f.StringVar(
+		&c.flagConfig, "config", "", "(Required) Path to Hermes config file",
+	)
+	f.StringVar(
+		&c.flagProfile, "profile", "",
+		"Configuration profile to use from the config file.",
+	)
+	f.StringVar(
+		&c.flagAddr, "addr", "",
+		"Base URL of the running Hermes server.",
+	)
+	f.StringVar(
+		&c.flagHeader, "header", "",
+		"Header used to authenticate to the server (e.g., \"Authorization: Bearer <token>\").",
+	)
+	f.StringVar(
+		&c.flagEffective, "effective", "",
+		"Path to a saved response of the server's admin config endpoint, used instead of -addr.",
+	)
+
+	return f
+}
+
+func (c *ConfigDiffCommand) Run(args []string) int {
+	ui := c.UI
+
+	// Parse flags.
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		ui.Error(fmt.Sprintf("error parsing flags: %v", err))
+		return 1
+	}
+
+	// Validate flags.
+	if c.flagConfig == "" {
+		ui.Error("config flag is required")
+		return 1
+	}
+	if (c.flagAddr == "") == (c.flagEffective == "") {
+		ui.Error("exactly one of the addr and effective flags is required")
+		return 1
+	}
+
+	// Parse configuration.
+	cfg, err := config.NewConfig(c.flagConfig, c.flagProfile)
+	if err != nil {
+		ui.Error(fmt.Sprintf("error parsing config file: %v", err))
+		return 1
+	}
+
+	// Get the configuration of the running server.
+	var running effectiveConfig
+	if c.flagEffective != "" {
+		b, err := os.ReadFile(c.flagEffective)
+		if err != nil {
+			ui.Error(fmt.Sprintf("error reading effective config: %v", err))
+			return 1
+		}
+		if err := json.Unmarshal(b, &running); err != nil {
+			ui.Error(fmt.Sprintf("error parsing effective config: %v", err))
+			return 1
+		}
+	} else {
+		running, err = c.fetchEffectiveConfig()
+		if err != nil {
+			ui.Error(fmt.Sprintf("error getting server config: %v", err))
+			return 1
+		}
+	}
+
+	ui.Output(fmt.Sprintf("Server version: %s (revision %s, %s)",
+		running.Version, orNone(running.Revision), running.GoVersion))
+	ui.Output(fmt.Sprintf("Server providers: workspace=%s search=%s",
+		orNone(running.Providers.Workspace), orNone(running.Providers.Search)))
+	ui.Output("")
+
+	diffs := config.DiffSettings(cfg.EffectiveSettings(), running.Settings)
+	if len(diffs) == 0 {
+		ui.Output("No differences found.")
+		return 0
+	}
+
+	ui.Output(fmt.Sprintf("%d setting(s) differ (- config file, + server):",
+		len(diffs)))
+	for _, d := range diffs {
+		ui.Output(d.Setting)
+		if d.Want != nil {
+			ui.Output(fmt.Sprintf("  - %v", d.Want))
+		}
+		if d.Got != nil {
+			ui.Output(fmt.Sprintf("  + %v", d.Got))
+		}
+	}
+	return 2
+}
+
+// fetchEffectiveConfig gets the effective configuration of the running
+// server.
+func (c *ConfigDiffCommand) fetchEffectiveConfig() (effectiveConfig, error) {
+	var ec effectiveConfig
+
+	req, err := http.NewRequest("GET",
+		strings.TrimSuffix(c.flagAddr, "/")+"/api/v2/admin/config", nil)
+	if err != nil {
+		return ec, fmt.Errorf("error creating request: %w", err)
+	}
+	if c.flagHeader != "" {
+		name, value, ok := strings.Cut(c.flagHeader, ":")
+		if !ok {
+			return ec, fmt.Errorf("invalid header %q", c.flagHeader)
+		}
+		req.Header.Set(strings.TrimSpace(name), strings.TrimSpace(value))
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return ec, fmt.Errorf("error sending request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return ec, fmt.Errorf("unexpected status %d: %s",
+			resp.StatusCode, strings.TrimSpace(string(b)))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&ec); err != nil {
+		return ec, fmt.Errorf("error decoding response: %w", err)
+	}
+	return ec, nil
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "none"
+	}
+	return s
+}
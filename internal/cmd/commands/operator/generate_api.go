@@ -0,0 +1,136 @@
+package operator
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+
+	apiv2 "github.com/hashicorp-forge/hermes/internal/api/v2"
+	"github.com/hashicorp-forge/hermes/internal/cmd/base"
+	"github.com/hashicorp-forge/hermes/internal/openapi"
+)
+
+type GenerateAPICommand struct {
+	*base.Command
+
+	flagCheck     bool
+	flagGo        string
+	flagGoPackage string
+	flagSpec      string
+	flagTS        string
+}
+
+func (c *GenerateAPICommand) Synopsis() string {
+	return "Generate the OpenAPI document and API clients"
+}
+
+func (c *GenerateAPICommand) Help() string {
+	return `Usage: hermes operator generate-api
+
+  This command generates the OpenAPI 3.1 document of the /api/v2 endpoints
+  (the same document served at /api/v2/openapi.json) and the Go and
+  TypeScript API clients generated from it. Run it from the repository root
+  after changing an API endpoint, or run "make generate-api".
+
+  With -check, the files aren't written. Instead, the exit code is 2 if any
+  file is out of date.
+
+  Example:
+    hermes operator generate-api -check` +
+		c.Flags().Help()
+}
+
+func (c *GenerateAPICommand) Flags() *base.FlagSet {
+	f := base.NewFlagSet(
+		flag.NewFlagSet("generate-api", flag.ExitOnError))
+
+	f.StringVar(
+		&c.flagSpec, "spec", "docs/api/openapi.json",
+		"Path of the OpenAPI document. Set to an empty string to skip.",
+	)
+	f.StringVar(
+		&c.flagGo, "go", "pkg/apiclient/client.gen.go",
+		"Path of the Go client. Set to an empty string to skip.",
+	)
+	f.StringVar(
+		&c.flagGoPackage, "go-package", "apiclient",
+		"Package name of the Go client.",
+	)
+	f.StringVar(
+		&c.flagTS, "ts", "web/app/utils/api-client.ts",
+		"Path of the TypeScript client. Set to an empty string to skip.",
+	)
+	f.BoolVar(
+		&c.flagCheck, "check", false,
+		"Check that the files are up to date instead of writing them.",
+	)
+
+	return f
+}
+
+func (c *GenerateAPICommand) Run(args []string) int {
+	ui := c.UI
+
+	// Parse flags.
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		ui.Error(fmt.Sprintf("error parsing flags: %v", err))
+		return 1
+	}
+
+	doc := apiv2.OpenAPIDocument()
+
+	type output struct {
+		path     string
+		generate func() ([]byte, error)
+	}
+	outputs := []output{
+		{c.flagSpec, func() ([]byte, error) {
+			return openapi.JSON(doc)
+		}},
+		{c.flagGo, func() ([]byte, error) {
+			return openapi.GoClient(doc, c.flagGoPackage)
+		}},
+		{c.flagTS, func() ([]byte, error) {
+			return openapi.TypeScriptClient(doc)
+		}},
+	}
+
+	stale := false
+	for _, o := range outputs {
+		if o.path == "" {
+			continue
+		}
+		b, err := o.generate()
+		if err != nil {
+			ui.Error(fmt.Sprintf("error generating %s: %v", o.path, err))
+			return 1
+		}
+
+		if c.flagCheck {
+			existing, err := os.ReadFile(o.path)
+			if err != nil && !os.IsNotExist(err) {
+				ui.Error(fmt.Sprintf("error reading %s: %v", o.path, err))
+				return 1
+			}
+			if !bytes.Equal(existing, b) {
+				ui.Output(fmt.Sprintf("%s is out of date", o.path))
+				stale = true
+			}
+			continue
+		}
+
+		if err := os.WriteFile(o.path, b, 0644); err != nil {
+			ui.Error(fmt.Sprintf("error writing %s: %v", o.path, err))
+			return 1
+		}
+		ui.Output(fmt.Sprintf("Wrote %s", o.path))
+	}
+
+	if stale {
+		ui.Error(`generated API files are out of date; run "make generate-api"`)
+		return 2
+	}
+	return 0
+}
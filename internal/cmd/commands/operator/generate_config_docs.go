@@ -0,0 +1,97 @@
+package operator
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp-forge/hermes/internal/cmd/base"
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/internal/config/decode"
+)
+
+// modulePath is the path of the Hermes Go module.
+const modulePath = "github.com/hashicorp-forge/hermes"
+
+type GenerateConfigDocsCommand struct {
+	*base.Command
+
+	flagCheck bool
+	flagOut   string
+}
+
+func (c *GenerateConfigDocsCommand) Synopsis() string {
+	return "Generate the documentation printed by \"hermes config schema\""
+}
+
+func (c *GenerateConfigDocsCommand) Help() string {
+	return `Usage: hermes operator generate-config-docs
+
+  This command generates the documentation of the configuration settings
+  printed by "hermes config schema" from the doc comments of the
+  configuration structs. Run it from the repository root after changing a
+  configuration setting, or run "make generate-config-docs".
+
+  With -check, the file isn't written. Instead, the exit code is 2 if it's
+  out of date.` +
+		c.Flags().Help()
+}
+
+func (c *GenerateConfigDocsCommand) Flags() *base.FlagSet {
+	f := base.NewFlagSet(
+		flag.NewFlagSet("generate-config-docs", flag.ExitOnError))
+
+	f.StringVar(
+		&c.flagOut, "out", "internal/config/schema_docs.gen.go",
+		"Path of the generated Go file.",
+	)
+	f.BoolVar(
+		&c.flagCheck, "check", false,
+		"Check that the file is up to date instead of writing it.",
+	)
+
+	return f
+}
+
+func (c *GenerateConfigDocsCommand) Run(args []string) int {
+	ui := c.UI
+
+	// Parse flags.
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		ui.Error(fmt.Sprintf("error parsing flags: %v", err))
+		return 1
+	}
+
+	docs, err := decode.FieldDocs(".", modulePath, config.SchemaTargets()...)
+	if err != nil {
+		ui.Error(fmt.Sprintf("error reading doc comments: %v", err))
+		return 1
+	}
+	var buf bytes.Buffer
+	if err := decode.WriteFieldDocs(&buf, "config", "fieldDocs", docs); err != nil {
+		ui.Error(fmt.Sprintf("error generating %s: %v", c.flagOut, err))
+		return 1
+	}
+
+	if c.flagCheck {
+		existing, err := os.ReadFile(c.flagOut)
+		if err != nil && !os.IsNotExist(err) {
+			ui.Error(fmt.Sprintf("error reading %s: %v", c.flagOut, err))
+			return 1
+		}
+		if !bytes.Equal(existing, buf.Bytes()) {
+			ui.Error(`config docs are out of date; run "make generate-config-docs"`)
+			return 2
+		}
+		return 0
+	}
+
+	if err := os.WriteFile(c.flagOut, buf.Bytes(), 0644); err != nil {
+		ui.Error(fmt.Sprintf("error writing %s: %v", c.flagOut, err))
+		return 1
+	}
+	ui.Output(fmt.Sprintf("Wrote %s", c.flagOut))
+	return 0
+}
@@ -129,6 +129,7 @@ func (c *MigrateAlgoliaToPostgreSQLCommand) Run(args []string) int {
 		LinksIndexName:         cfg.Algolia.LinksIndexName,
 		MissingFieldsIndexName: cfg.Algolia.MissingFieldsIndexName,
 		ProjectsIndexName:      cfg.Algolia.ProjectsIndexName,
+		Ranking:                cfg.Search.Rankings(),
 	}
 	algo, err := algolia.New(algoliaClientCfg)
 	if err != nil {
@@ -0,0 +1,200 @@
+package operator
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp-forge/hermes/internal/cmd/base"
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/internal/db"
+	"github.com/hashicorp-forge/hermes/pkg/indexer/replay"
+	"github.com/hashicorp-forge/hermes/pkg/kafka"
+	"github.com/hashicorp-forge/hermes/pkg/kafka/producer"
+)
+
+type ReplayRevisionsCommand struct {
+	*base.Command
+
+	flagConfig   string
+	flagDocument string
+	flagProduct  string
+	flagSince    string
+	flagUntil    string
+	flagLimit    int
+	flagDryRun   bool
+	flagVerbose  bool
+}
+
+func (c *ReplayRevisionsCommand) Synopsis() string {
+	return "Re-emit document revision events onto the revision topic"
+}
+
+func (c *ReplayRevisionsCommand) Help() string {
+	return `Usage: hermes operator replay-revisions [options]
+
+  This command re-emits document revision events for the active revisions in
+  the database onto the document revision topic, so fixes to the indexer and
+  notifier can be re-applied without a full backfill. Revisions are selected
+  by document, product, and/or a modified time window, and are replayed
+  oldest first.` +
+		c.Flags().Help()
+}
+
+func (c *ReplayRevisionsCommand) Flags() *base.FlagSet {
+	f := base.NewFlagSet(
+		flag.NewFlagSet("replay-revisions", flag.ExitOnError))
+
+	f.StringVar(
+		&c.flagConfig, "config", "", "(Required) Path to Hermes config file",
+	)
+	f.StringVar(
+		&c.flagDocument, "document", "",
+		"Replay the revisions of the document with this UUID.",
+	)
+	f.StringVar(
+		&c.flagProduct, "product", "",
+		"Replay the revisions of documents of this product.",
+	)
+	f.StringVar(
+		&c.flagSince, "since", "",
+		"Replay revisions modified at or after this RFC 3339 time.",
+	)
+	f.StringVar(
+		&c.flagUntil, "until", "",
+		"Replay revisions modified before this RFC 3339 time.",
+	)
+	f.IntVar(
+		&c.flagLimit, "limit", replay.DefaultLimit,
+		fmt.Sprintf("Maximum number of revisions to replay (at most %d).",
+			replay.MaxLimit),
+	)
+	f.BoolVar(
+		&c.flagDryRun, "dry-run", false,
+		"Only print the revisions that would be replayed.",
+	)
+	f.BoolVar(
+		&c.flagVerbose, "verbose", false,
+		"Print each revision that is replayed.",
+	)
+
+	return f
+}
+
+func (c *ReplayRevisionsCommand) Run(args []string) int {
+	ui := c.UI
+
+	// Parse flags.
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		ui.Error(fmt.Sprintf("error parsing flags: %v", err))
+		return 1
+	}
+
+	// Validate flags.
+	if c.flagConfig == "" {
+		ui.Error("config flag is required")
+		return 1
+	}
+	filter := replay.Filter{
+		Product: c.flagProduct,
+		Limit:   c.flagLimit,
+	}
+	if c.flagDocument != "" {
+		id, err := uuid.Parse(c.flagDocument)
+		if err != nil {
+			ui.Error(fmt.Sprintf("invalid document UUID: %v", err))
+			return 1
+		}
+		filter.DocumentUUID = &id
+	}
+	for _, tf := range []struct {
+		name  string
+		value string
+		dst   **time.Time
+	}{
+		{"since", c.flagSince, &filter.Since},
+		{"until", c.flagUntil, &filter.Until},
+	} {
+		if tf.value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, tf.value)
+		if err != nil {
+			ui.Error(fmt.Sprintf("invalid %s time: %v", tf.name, err))
+			return 1
+		}
+		*tf.dst = &t
+	}
+	if err := filter.Validate(); err != nil {
+		ui.Error(err.Error())
+		return 1
+	}
+
+	// Parse configuration.
+	cfg, err := config.NewConfig(c.flagConfig, "") // No profile support in operator commands
+	if err != nil {
+		ui.Error(fmt.Sprintf("error parsing config file: %v", err))
+		return 1
+	}
+
+	// Initialize database.
+	database, err := db.NewDB(*cfg.Postgres)
+	if err != nil {
+		ui.Error(fmt.Sprintf("error initializing database: %v", err))
+		return 1
+	}
+
+	revisions, truncated, err := replay.FindRevisions(database, filter)
+	if err != nil {
+		ui.Error(err.Error())
+		return 1
+	}
+	if len(revisions) == 0 {
+		ui.Info("No revisions matched")
+		return 0
+	}
+
+	topic := kafka.GetDocumentRevisionTopic(cfg)
+	ui.Info(fmt.Sprintf("Found %d revisions to replay to %s", len(revisions), topic))
+	if truncated {
+		ui.Warn(fmt.Sprintf(
+			"More revisions matched than the limit of %d; replay again with a "+
+				"later -since time to replay the rest", len(revisions)))
+	}
+	if c.flagVerbose || c.flagDryRun {
+		for _, rev := range revisions {
+			ui.Info(fmt.Sprintf("  %s (revision %d, %s): %s",
+				rev.DocumentUUID, rev.ID,
+				rev.ModifiedTime.Format(time.RFC3339), rev.Title))
+		}
+	}
+	if c.flagDryRun {
+		ui.Warn("DRY RUN completed - no events were published")
+		return 0
+	}
+
+	var producerCfg *config.KafkaProducer
+	if cfg.Indexer != nil {
+		producerCfg = cfg.Indexer.Producer
+	}
+	p, err := producer.New(producerCfg.ToProducerConfig(
+		kafka.GetBrokers(cfg), "revision-replay"))
+	if err != nil {
+		ui.Error(fmt.Sprintf("error creating kafka producer: %v", err))
+		return 1
+	}
+	defer p.Close()
+
+	n, err := replay.Publish(context.Background(), p, topic, revisions)
+	if err != nil {
+		ui.Error(fmt.Sprintf("error after publishing %d of %d events: %v",
+			n, len(revisions), err))
+		return 1
+	}
+
+	ui.Info(fmt.Sprintf("Published %d revision events to %s", n, topic))
+	return 0
+}
@@ -0,0 +1,285 @@
+package secrets
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/cmd/base"
+	"github.com/hashicorp-forge/hermes/internal/config"
+	dbpkg "github.com/hashicorp-forge/hermes/internal/db"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"gorm.io/gorm"
+)
+
+// minSessionSecretLength is the minimum length of auth.session_secret.
+const minSessionSecretLength = 32
+
+// minSecretLength is the length below which secrets are reported as short.
+const minSecretLength = 12
+
+// defaultSecrets are well-known default or placeholder values that must not
+// be used as secrets.
+var defaultSecrets = []string{
+	"admin", "change-me", "change_me", "changeme", "default", "hermes",
+	"password", "postgres", "replace-me", "replaceme", "secret", "test",
+	"todo", "xxx",
+}
+
+// weakSecretWords are words that suggest a secret is a test or example value.
+var weakSecretWords = []string{
+	"changeme", "dummy", "example", "masterkey", "placeholder", "test",
+}
+
+type CheckCommand struct {
+	*base.Command
+
+	flagConfig     string
+	flagExpiryWarn time.Duration
+	flagProfile    string
+	flagSkipTokens bool
+	flagStaleAfter time.Duration
+
+	// testDB is used instead of connecting to the configured database, if set.
+	testDB *gorm.DB
+}
+
+func (c *CheckCommand) Synopsis() string {
+	return "Check configured secrets and service tokens"
+}
+
+func (c *CheckCommand) Help() string {
+	return `Usage: hermes secrets check -config=<path> [options]
+
+  This command checks the secrets in a config file and the service tokens in
+  its database for common problems:
+
+    - Secrets set to well-known default or placeholder values (FAIL), or
+      that look like test values or are short (WARN)
+    - A session secret shorter than 32 characters (FAIL)
+    - Active service tokens that are allowed every scope, never expire,
+      expire soon, have expired without being revoked, or haven't been used
+      recently (WARN)
+
+  Secret values are never printed. The exit code is 1 if any check failed.
+
+  Example:
+    hermes secrets check -config=config.hcl -stale-after=720h` +
+		c.Flags().Help()
+}
+
+func (c *CheckCommand) Flags() *base.FlagSet {
+	f := base.NewFlagSet(flag.NewFlagSet("check", flag.ExitOnError))
+
+	f.StringVar(
+		&c.flagConfig, "config", "", "(Required) Path to Hermes config file",
+	)
+	f.StringVar(
+		&c.flagProfile, "profile", "",
+		"[HERMES_SERVER_PROFILE] Configuration profile to use.",
+	)
+	f.BoolVar(
+		&c.flagSkipTokens, "skip-tokens", false,
+		"Don't check service tokens, so no database connection is needed.",
+	)
+	f.DurationVar(
+		&c.flagStaleAfter, "stale-after", 90*24*time.Hour,
+		"Report active tokens that haven't been used for this long.",
+	)
+	f.DurationVar(
+		&c.flagExpiryWarn, "expiry-warning", 7*24*time.Hour,
+		"Report active tokens that expire within this long.",
+	)
+
+	return f
+}
+
+func (c *CheckCommand) Run(args []string) int {
+	ui := c.UI
+
+	// Parse flags.
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		ui.Error(fmt.Sprintf("error parsing flags: %v", err))
+		return 1
+	}
+
+	// Validate flags.
+	if c.flagConfig == "" {
+		ui.Error("config flag is required")
+		return 1
+	}
+
+	profile := c.flagProfile
+	if val, ok := os.LookupEnv("HERMES_SERVER_PROFILE"); ok && profile == "" {
+		profile = val
+	}
+	cfg, err := config.NewConfig(c.flagConfig, profile)
+	if err != nil {
+		ui.Error(fmt.Sprintf("error parsing config file: %v", err))
+		return 1
+	}
+	if cfg.Postgres != nil {
+		if val, ok := os.LookupEnv("HERMES_SERVER_POSTGRES_PASSWORD"); ok {
+			cfg.Postgres.Password = val
+		}
+	}
+
+	var warned, failed int
+	report := func(level, name, msg string) {
+		line := fmt.Sprintf("  [%s] %s: %s", level, name, msg)
+		switch level {
+		case "FAIL":
+			ui.Error(line)
+			failed++
+		case "WARN":
+			ui.Warn(line)
+			warned++
+		default:
+			ui.Output(line)
+		}
+	}
+
+	// Check config secrets.
+	ui.Output("Config secrets")
+	secrets := cfg.SecretSettings()
+	names := make([]string, 0, len(secrets))
+	for name := range secrets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		level, msg := checkSecret(name, secrets[name])
+		report(level, name, msg)
+	}
+	if len(names) == 0 {
+		ui.Output("  No secrets are set.")
+	}
+	ui.Output("")
+
+	// Check service tokens.
+	if !c.flagSkipTokens {
+		ui.Output("Service tokens")
+		db := c.testDB
+		if db == nil {
+			if cfg.Postgres == nil {
+				ui.Error("config file has no postgres block; use -skip-tokens")
+				return 1
+			}
+			db, err = dbpkg.NewDB(*cfg.Postgres)
+			if err != nil {
+				ui.Error(fmt.Sprintf("error connecting to database: %v", err))
+				return 1
+			}
+		}
+
+		var tokens models.IndexerTokens
+		if err := tokens.FindByType(db, "", false); err != nil {
+			ui.Error(fmt.Sprintf("error listing tokens: %v", err))
+			return 1
+		}
+		problems := 0
+		for _, t := range tokens {
+			name := fmt.Sprintf("%s token %s", t.TokenType, t.ID)
+			if t.Name != "" {
+				name += fmt.Sprintf(" (%s)", t.Name)
+			}
+			for _, msg := range c.checkToken(t, time.Now()) {
+				report("WARN", name, msg)
+				problems++
+			}
+		}
+		if problems == 0 {
+			ui.Output(fmt.Sprintf("  No problems found in %d active token(s).",
+				len(tokens)))
+		}
+		ui.Output("")
+	}
+
+	summary := fmt.Sprintf("%d warning(s), %d failed", warned, failed)
+	if failed > 0 {
+		ui.Error(summary)
+		return 1
+	}
+	ui.Output(summary)
+	return 0
+}
+
+// checkSecret returns the result level ("PASS", "WARN", or "FAIL") and message
+// of checking the value of the secret setting name.
+func checkSecret(name, value string) (string, string) {
+	lower := strings.ToLower(value)
+	for _, d := range defaultSecrets {
+		if lower == d {
+			return "FAIL", "set to a well-known default or placeholder value"
+		}
+	}
+	if strings.HasPrefix(lower, "your-") || strings.HasPrefix(lower, "your_") ||
+		(strings.HasPrefix(lower, "<") && strings.HasSuffix(lower, ">")) {
+		return "FAIL", "set to a placeholder value"
+	}
+
+	if name == "auth.session_secret" && len(value) < minSessionSecretLength {
+		return "FAIL", fmt.Sprintf("shorter than %d characters",
+			minSessionSecretLength)
+	}
+
+	for _, w := range weakSecretWords {
+		if strings.Contains(lower, w) {
+			return "WARN", "looks like a test or example value"
+		}
+	}
+	if len(value) < minSecretLength {
+		return "WARN", fmt.Sprintf("shorter than %d characters", minSecretLength)
+	}
+
+	return "PASS", "set"
+}
+
+// checkToken returns the problems with the active service token t at time
+// now.
+func (c *CheckCommand) checkToken(t models.IndexerToken, now time.Time) []string {
+	var problems []string
+
+	if len(t.Scopes) == 0 {
+		problems = append(problems,
+			"allowed every scope; create a replacement with -scopes and revoke it")
+	}
+
+	switch {
+	case t.ExpiresAt == nil:
+		// Registration tokens are short-lived by design, so only report
+		// long-lived tokens that never expire.
+		if t.TokenType != "registration" {
+			problems = append(problems, "never expires")
+		}
+	case now.After(*t.ExpiresAt):
+		problems = append(problems, fmt.Sprintf(
+			"expired %s but isn't revoked", t.ExpiresAt.Format(time.DateOnly)))
+		return problems
+	case t.ExpiresAt.Sub(now) < c.flagExpiryWarn:
+		problems = append(problems, fmt.Sprintf(
+			"expires %s; rotate it before then", t.ExpiresAt.Format(time.DateOnly)))
+	}
+
+	lastUsed := t.CreatedAt
+	if t.LastUsedAt != nil {
+		lastUsed = *t.LastUsedAt
+	}
+	if c.flagStaleAfter > 0 && now.Sub(lastUsed) > c.flagStaleAfter {
+		if t.LastUsedAt == nil {
+			problems = append(problems, fmt.Sprintf(
+				"never used since it was created %s; revoke it if it's not needed",
+				t.CreatedAt.Format(time.DateOnly)))
+		} else {
+			problems = append(problems, fmt.Sprintf(
+				"not used since %s; revoke it if it's not needed",
+				t.LastUsedAt.Format(time.DateOnly)))
+		}
+	}
+
+	return problems
+}
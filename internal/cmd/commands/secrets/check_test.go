@@ -0,0 +1,213 @@
+package secrets
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/hashicorp-forge/hermes/internal/cmd/base"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+)
+
+func TestCheckSecret(t *testing.T) {
+	tests := map[string]struct {
+		name      string
+		value     string
+		wantLevel string
+	}{
+		"default value": {
+			name:      "postgres.password",
+			value:     "Postgres",
+			wantLevel: "FAIL",
+		},
+		"placeholder value": {
+			name:      "jira.api_token",
+			value:     "your-jira-token",
+			wantLevel: "FAIL",
+		},
+		"angle bracket placeholder": {
+			name:      "jira.api_token",
+			value:     "<api token>",
+			wantLevel: "FAIL",
+		},
+		"short session secret": {
+			name:      "auth.session_secret",
+			value:     "k3Jd8s0Qm2Lx9Vb4",
+			wantLevel: "FAIL",
+		},
+		"test value": {
+			name:      "meilisearch.master_key",
+			value:     "masterKey123456789",
+			wantLevel: "WARN",
+		},
+		"short value": {
+			name:      "postgres.password",
+			value:     "k3Jd8s0Q",
+			wantLevel: "WARN",
+		},
+		"strong value": {
+			name:      "postgres.password",
+			value:     "k3Jd8s0Qm2Lx9Vb4",
+			wantLevel: "PASS",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			level, _ := checkSecret(tc.name, tc.value)
+			assert.Equal(t, tc.wantLevel, level)
+		})
+	}
+}
+
+func TestCheckToken(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	ptr := func(t time.Time) *time.Time { return &t }
+
+	c := &CheckCommand{
+		flagExpiryWarn: 7 * 24 * time.Hour,
+		flagStaleAfter: 90 * 24 * time.Hour,
+	}
+
+	tests := map[string]struct {
+		token        models.IndexerToken
+		wantProblems []string
+	}{
+		"healthy": {
+			token: models.IndexerToken{
+				TokenType:  "edge",
+				Scopes:     []string{"read"},
+				CreatedAt:  now.AddDate(0, -6, 0),
+				ExpiresAt:  ptr(now.AddDate(0, 1, 0)),
+				LastUsedAt: ptr(now.AddDate(0, 0, -1)),
+			},
+		},
+		"all scopes and never expires": {
+			token: models.IndexerToken{
+				TokenType:  "api",
+				CreatedAt:  now.AddDate(0, -1, 0),
+				LastUsedAt: ptr(now.AddDate(0, 0, -1)),
+			},
+			wantProblems: []string{
+				"allowed every scope; create a replacement with -scopes and revoke it",
+				"never expires",
+			},
+		},
+		"registration token without expiry": {
+			token: models.IndexerToken{
+				TokenType: "registration",
+				Scopes:    []string{"register"},
+				CreatedAt: now.AddDate(0, 0, -1),
+			},
+		},
+		"expired": {
+			token: models.IndexerToken{
+				TokenType: "edge",
+				Scopes:    []string{"read"},
+				CreatedAt: now.AddDate(-1, 0, 0),
+				ExpiresAt: ptr(now.AddDate(0, 0, -2)),
+			},
+			wantProblems: []string{"expired 2026-05-30 but isn't revoked"},
+		},
+		"expires soon": {
+			token: models.IndexerToken{
+				TokenType:  "edge",
+				Scopes:     []string{"read"},
+				CreatedAt:  now.AddDate(0, -1, 0),
+				ExpiresAt:  ptr(now.AddDate(0, 0, 3)),
+				LastUsedAt: ptr(now),
+			},
+			wantProblems: []string{"expires 2026-06-04; rotate it before then"},
+		},
+		"never used": {
+			token: models.IndexerToken{
+				TokenType: "edge",
+				Scopes:    []string{"read"},
+				CreatedAt: now.AddDate(0, -6, 0),
+				ExpiresAt: ptr(now.AddDate(1, 0, 0)),
+			},
+			wantProblems: []string{
+				"never used since it was created 2025-12-01; revoke it if it's not needed",
+			},
+		},
+		"stale": {
+			token: models.IndexerToken{
+				TokenType:  "edge",
+				Scopes:     []string{"read"},
+				CreatedAt:  now.AddDate(-1, 0, 0),
+				ExpiresAt:  ptr(now.AddDate(1, 0, 0)),
+				LastUsedAt: ptr(now.AddDate(0, -4, 0)),
+			},
+			wantProblems: []string{
+				"not used since 2026-02-01; revoke it if it's not needed",
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.wantProblems, c.checkToken(tc.token, now))
+		})
+	}
+}
+
+func TestCheckCommand(t *testing.T) {
+	dir := t.TempDir()
+	cfgPath := filepath.Join(dir, "config.hcl")
+	require.NoError(t, os.WriteFile(cfgPath, []byte(`
+base_url = "http://localhost:8000"
+
+postgres {
+  dbname   = "hermes"
+  host     = "localhost"
+  password = "k3Jd8s0Qm2Lx9Vb4"
+  port     = 5432
+  user     = "postgres"
+}
+`), 0644))
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.Indexer{}, &models.IndexerToken{}))
+
+	t.Run("no problems", func(t *testing.T) {
+		ui := cli.NewMockUi()
+		c := &CheckCommand{
+			Command: base.NewCommand(hclog.NewNullLogger(), ui),
+			testDB:  db,
+		}
+
+		assert.Equal(t, 0, c.Run([]string{"-config=" + cfgPath}),
+			ui.ErrorWriter.String())
+		assert.Contains(t, ui.OutputWriter.String(), "[PASS] postgres.password")
+		assert.Contains(t, ui.OutputWriter.String(),
+			"No problems found in 0 active token(s).")
+	})
+
+	t.Run("token without scopes", func(t *testing.T) {
+		plaintext, err := models.GenerateToken("api")
+		require.NoError(t, err)
+		token := models.IndexerToken{TokenType: "api"}
+		require.NoError(t, token.Create(db, plaintext))
+
+		ui := cli.NewMockUi()
+		c := &CheckCommand{
+			Command: base.NewCommand(hclog.NewNullLogger(), ui),
+			testDB:  db,
+		}
+
+		// Warnings don't fail the check.
+		assert.Equal(t, 0, c.Run([]string{"-config=" + cfgPath}),
+			ui.ErrorWriter.String())
+		assert.Contains(t, ui.ErrorWriter.String(), "allowed every scope")
+		assert.Contains(t, ui.ErrorWriter.String(), "never expires")
+	})
+}
@@ -0,0 +1,25 @@
+package secrets
+
+import (
+	"github.com/hashicorp-forge/hermes/internal/cmd/base"
+	"github.com/mitchellh/cli"
+)
+
+type Command struct {
+	*base.Command
+}
+
+func (c *Command) Synopsis() string {
+	return "Check secrets and credentials"
+}
+
+func (c *Command) Help() string {
+	return `Usage: hermes secrets <subcommand> [options] [args]
+
+  This command groups subcommands for checking the secrets in a Hermes
+  configuration and the service tokens issued to other services.`
+}
+
+func (c *Command) Run(args []string) int {
+	return cli.RunResultHelp
+}
@@ -3,9 +3,9 @@ package server
 import (
 	"context"
 	"database/sql"
-	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -19,29 +19,53 @@ import (
 	"github.com/hashicorp-forge/hermes/internal/config"
 	"github.com/hashicorp-forge/hermes/internal/datadog"
 	dbpkg "github.com/hashicorp-forge/hermes/internal/db"
+	"github.com/hashicorp-forge/hermes/internal/email"
 	"github.com/hashicorp-forge/hermes/internal/instance"
 	"github.com/hashicorp-forge/hermes/internal/jira"
 	"github.com/hashicorp-forge/hermes/internal/migrate"
+	notifyprovider "github.com/hashicorp-forge/hermes/internal/notifications"
 	"github.com/hashicorp-forge/hermes/internal/pkg/doctypes"
 	"github.com/hashicorp-forge/hermes/internal/projects"
 	"github.com/hashicorp-forge/hermes/internal/pub"
 	"github.com/hashicorp-forge/hermes/internal/server"
 	"github.com/hashicorp-forge/hermes/internal/structs"
+	"github.com/hashicorp-forge/hermes/pkg/activity"
 	"github.com/hashicorp-forge/hermes/pkg/algolia"
+	oidcadapter "github.com/hashicorp-forge/hermes/pkg/auth/adapters/oidc"
+	"github.com/hashicorp-forge/hermes/pkg/avatar"
+	"github.com/hashicorp-forge/hermes/pkg/directorysync"
+	"github.com/hashicorp-forge/hermes/pkg/document"
+	"github.com/hashicorp-forge/hermes/pkg/freshness"
 	hcd "github.com/hashicorp-forge/hermes/pkg/hashicorpdocs"
+	"github.com/hashicorp-forge/hermes/pkg/health"
+	"github.com/hashicorp-forge/hermes/pkg/httpcache"
 	"github.com/hashicorp-forge/hermes/pkg/indexer/relay"
+	"github.com/hashicorp-forge/hermes/pkg/integrity"
+	"github.com/hashicorp-forge/hermes/pkg/jobs"
 	"github.com/hashicorp-forge/hermes/pkg/kafka"
+	"github.com/hashicorp-forge/hermes/pkg/linkcheck"
 	"github.com/hashicorp-forge/hermes/pkg/links"
+	"github.com/hashicorp-forge/hermes/pkg/metrics"
 	"github.com/hashicorp-forge/hermes/pkg/migration"
 	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp-forge/hermes/pkg/notifications"
 	"github.com/hashicorp-forge/hermes/pkg/projectconfig"
+	"github.com/hashicorp-forge/hermes/pkg/purge"
+	"github.com/hashicorp-forge/hermes/pkg/requestid"
+	"github.com/hashicorp-forge/hermes/pkg/retention"
+	"github.com/hashicorp-forge/hermes/pkg/reviewsla"
+	"github.com/hashicorp-forge/hermes/pkg/savedsearch"
 	"github.com/hashicorp-forge/hermes/pkg/search"
 	searchalgolia "github.com/hashicorp-forge/hermes/pkg/search/adapters/algolia"
 	bleveadapter "github.com/hashicorp-forge/hermes/pkg/search/adapters/bleve"
 	meilisearchadapter "github.com/hashicorp-forge/hermes/pkg/search/adapters/meilisearch"
+	"github.com/hashicorp-forge/hermes/pkg/shutdown"
+	"github.com/hashicorp-forge/hermes/pkg/tenant"
+	"github.com/hashicorp-forge/hermes/pkg/tracing"
 	"github.com/hashicorp-forge/hermes/pkg/workspace"
 	gw "github.com/hashicorp-forge/hermes/pkg/workspace/adapters/google"
 	localadapter "github.com/hashicorp-forge/hermes/pkg/workspace/adapters/local"
+	"github.com/hashicorp-forge/hermes/pkg/workspace/router"
 	"github.com/hashicorp-forge/hermes/web"
 	"github.com/hashicorp/go-hclog"
 	_ "github.com/lib/pq" // PostgreSQL driver for migrations
@@ -63,6 +87,11 @@ type Command struct {
 	flagOktaAuthServerURL string
 	flagOktaClientID      string
 	flagOktaDisabled      bool
+
+	// Config and DB are used instead of loading a config file and connecting
+	// to PostgreSQL, if set. They're set by the dev command.
+	Config *config.Config
+	DB     *gorm.DB
 }
 
 type endpoint struct {
@@ -111,8 +140,8 @@ func (c *Command) Flags() *base.FlagSet {
 	)
 	f.StringVar(
 		&c.flagAuthProvider, "auth-provider", "",
-		"[HERMES_AUTH_PROVIDER] Authentication provider to use (e.g., 'dex', 'okta', 'google'). "+
-			"Overrides the provider auto-selection based on config. When set to 'dex' or 'okta', "+
+		"[HERMES_AUTH_PROVIDER] Authentication provider to use (e.g., 'oidc', 'dex', 'okta', 'google'). "+
+			"Overrides the provider auto-selection based on config. When set to 'oidc', 'dex', or 'okta', "+
 			"will disable other providers to force that provider to be used.",
 	)
 	f.StringVar(
@@ -142,7 +171,9 @@ func (c *Command) Run(args []string) int {
 		cfg *config.Config
 		err error
 	)
-	if c.flagConfig != "" {
+	if c.Config != nil {
+		cfg = c.Config
+	} else if c.flagConfig != "" {
 		// Get profile from flag or environment variable
 		profile := c.flagProfile
 		if val, ok := os.LookupEnv("HERMES_SERVER_PROFILE"); ok && profile == "" {
@@ -206,7 +237,21 @@ func (c *Command) Run(args []string) int {
 	if authProvider != "" {
 		// Force the specified provider by disabling others
 		switch strings.ToLower(authProvider) {
+		case "oidc":
+			if cfg.OIDC != nil {
+				cfg.OIDC.Disabled = false
+			}
+			if cfg.Dex != nil {
+				cfg.Dex.Disabled = true
+			}
+			if cfg.Okta != nil {
+				cfg.Okta.Disabled = true
+			}
+			c.Log.Info("auth provider selection", "provider", "oidc", "source", "flag/env")
 		case "dex":
+			if cfg.OIDC != nil {
+				cfg.OIDC.Disabled = true
+			}
 			if cfg.Dex != nil {
 				cfg.Dex.Disabled = false
 			}
@@ -215,6 +260,9 @@ func (c *Command) Run(args []string) int {
 			}
 			c.Log.Info("auth provider selection", "provider", "dex", "source", "flag/env")
 		case "okta":
+			if cfg.OIDC != nil {
+				cfg.OIDC.Disabled = true
+			}
 			if cfg.Dex != nil {
 				cfg.Dex.Disabled = true
 			}
@@ -223,7 +271,10 @@ func (c *Command) Run(args []string) int {
 			}
 			c.Log.Info("auth provider selection", "provider", "okta", "source", "flag/env")
 		case "google":
-			// Disable both Dex and Okta to fall back to Google
+			// Disable OIDC, Dex, and Okta to fall back to Google
+			if cfg.OIDC != nil {
+				cfg.OIDC.Disabled = true
+			}
 			if cfg.Dex != nil {
 				cfg.Dex.Disabled = true
 			}
@@ -232,7 +283,7 @@ func (c *Command) Run(args []string) int {
 			}
 			c.Log.Info("auth provider selection", "provider", "google", "source", "flag/env")
 		default:
-			c.UI.Error(fmt.Sprintf("invalid auth provider: %s (valid options: dex, okta, google)", authProvider))
+			c.UI.Error(fmt.Sprintf("invalid auth provider: %s (valid options: oidc, dex, okta, google)", authProvider))
 			return 1
 		}
 	}
@@ -288,6 +339,26 @@ func (c *Command) Run(args []string) int {
 		}
 	}
 
+	// Initialize OIDC authentication. The provider configuration is
+	// discovered from the issuer.
+	var oidcAdapter *oidcadapter.Adapter
+	if cfg.OIDC != nil && !cfg.OIDC.Disabled {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		oidcAdapter, err = oidcadapter.NewAdapter(ctx, *cfg.OIDC, c.Log.Named("oidc"))
+		cancel()
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("error initializing OIDC authentication: %v", err))
+			return 1
+		}
+	}
+
+	// Initialize browser sessions.
+	sessions, err := auth.NewSessions(cfg.Auth)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("error initializing sessions: %v", err))
+		return 1
+	}
+
 	// Initialize Datadog.
 	dd := datadog.NewConfig(*cfg)
 	if dd.Enabled {
@@ -311,6 +382,23 @@ func (c *Command) Run(args []string) int {
 		tracer.Start(tracerOpts...)
 	}
 
+	// Initialize OpenTelemetry tracing.
+	if cfg.Tracing != nil && cfg.Tracing.Enabled {
+		shutdownTracing, err := tracing.Setup(
+			context.Background(), cfg.Tracing.ToTracingConfig("hermes"))
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("error initializing tracing: %v", err))
+			return 1
+		}
+		defer func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := shutdownTracing(ctx); err != nil {
+				c.Log.Error("error flushing traces", "error", err)
+			}
+		}()
+	}
+
 	// Determine which providers to use (from flags, env vars, or config).
 	workspaceProviderName := c.flagWorkspaceProvider
 	if val, ok := os.LookupEnv("HERMES_WORKSPACE_PROVIDER"); ok && workspaceProviderName == "" {
@@ -435,6 +523,7 @@ func (c *Command) Run(args []string) int {
 			LinksIndexName:         cfg.Algolia.LinksIndexName,
 			MissingFieldsIndexName: cfg.Algolia.MissingFieldsIndexName,
 			ProjectsIndexName:      cfg.Algolia.ProjectsIndexName,
+			Ranking:                cfg.Search.Rankings(),
 		}
 
 		// Initialize Algolia search client (legacy - still needed for proxy handler).
@@ -471,6 +560,7 @@ func (c *Command) Run(args []string) int {
 		}
 
 		meilisearchCfg := cfg.Meilisearch.ToMeilisearchAdapterConfig()
+		meilisearchCfg.Ranking = cfg.Search.Rankings()
 		meilisearchAdapter, err := meilisearchadapter.NewAdapter(meilisearchCfg)
 		if err != nil {
 			c.UI.Error(fmt.Sprintf("error initializing meilisearch adapter: %v", err))
@@ -486,6 +576,7 @@ func (c *Command) Run(args []string) int {
 
 		bleveCfg := &bleveadapter.Config{
 			IndexPath: cfg.Bleve.IndexPath,
+			Ranking:   cfg.Search.Rankings(),
 		}
 		bleveSearchAdapter, err := bleveadapter.NewAdapter(bleveCfg)
 		if err != nil {
@@ -507,6 +598,12 @@ func (c *Command) Run(args []string) int {
 		if wsAdapter, ok := workspaceProvider.(*localadapter.WorkspaceAdapter); ok {
 			localAdapter := wsAdapter.GetAdapter()
 			indexer := localadapter.NewDocumentIndexer(localAdapter, searchProvider, c.Log)
+			if cfg.Indexer != nil {
+				indexer.BatchOptions = search.BatchOptions{
+					BatchSize:   cfg.Indexer.SearchBatchSize,
+					Concurrency: cfg.Indexer.SearchBatchConcurrency,
+				}
+			}
 
 			c.UI.Info("Indexing documents from local workspace into search provider...")
 			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
@@ -534,12 +631,22 @@ func (c *Command) Run(args []string) int {
 
 	// Initialize database.
 	var db *gorm.DB
-	if cfg.SimplifiedMode {
-		// Simplified mode: use SQLite
-		// NOTE: Server binary does not support SQLite to avoid driver conflicts.
-		// Use hermes-migrate binary for SQLite databases.
-		c.UI.Error("SQLite mode not supported in server binary. Use hermes-migrate for migrations. See docs-internal/SQLITE_DRIVER_CONFLICT.md")
-		return 1
+	if c.DB != nil {
+		db = c.DB
+	} else if cfg.DatabaseType == "sqlite" {
+		// SQLite (simplified mode, single-user and edge deployments). The SQL
+		// migrations are written for PostgreSQL, so the models are migrated
+		// directly.
+		db, err = dbpkg.NewSQLiteDB(cfg.DBPath)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("error initializing database: %v", err))
+			return 1
+		}
+		if err := dbpkg.MigrateSQLite(db); err != nil {
+			c.UI.Error(fmt.Sprintf("error migrating database: %v", err))
+			return 1
+		}
+		c.Log.Info("using SQLite database", "path", cfg.DBPath)
 	} else {
 		// Traditional mode: use PostgreSQL
 		if val, ok := os.LookupEnv("HERMES_SERVER_POSTGRES_PASSWORD"); ok {
@@ -605,6 +712,46 @@ func (c *Command) Run(args []string) int {
 		return 1
 	}
 
+	// Keep the unwrapped providers, so they can be closed on shutdown.
+	baseSearchProvider, baseWorkspaceProvider := searchProvider, workspaceProvider
+
+	// Register tenants and partition the workspace and search providers by
+	// tenant.
+	var tenants *tenant.Resolver
+	if len(cfg.Tenants) > 0 {
+		tenants, workspaceProvider, err = registerTenants(cfg, db, workspaceProvider)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("error registering tenants: %v", err))
+			return 1
+		}
+		searchProvider = search.WithTenants(searchProvider)
+	}
+
+	// Limit drafts in search results to the drafts each user can view.
+	searchProvider = search.WithVisibility(searchProvider)
+
+	// Limit the time allowed for workspace provider calls, so a hung backend
+	// can't block requests forever.
+	var workspaceTimeouts workspace.Timeouts
+	if cfg.Providers != nil && cfg.Providers.WorkspaceTimeouts != nil {
+		t := cfg.Providers.WorkspaceTimeouts
+		workspaceTimeouts = workspace.Timeouts{
+			Default: t.Default,
+			Read:    t.Read,
+			Write:   t.Write,
+			Email:   t.Email,
+		}
+	}
+	workspaceProvider = workspace.WithTimeouts(
+		workspaceProvider, workspaceTimeouts)
+
+	// Record metrics and traces of the workspace and search provider calls.
+	workspaceProvider = metrics.InstrumentWorkspaceProvider(
+		workspaceProviderName, tracing.InstrumentWorkspaceProvider(
+			workspaceProviderName, workspaceProvider))
+	searchProvider = metrics.InstrumentSearchProvider(
+		tracing.InstrumentSearchProvider(searchProvider))
+
 	// Register document types.
 	// TODO: remove this and use the database for all document type lookups.
 	docTypes := map[string]hcd.Doc{
@@ -682,6 +829,46 @@ func (c *Command) Run(args []string) int {
 		}
 	}
 
+	// RFC-087: Publish migration lifecycle events to the ops channel so
+	// operators can follow long-running migrations.
+	var migrationNotifier migration.Notifier
+	var notificationProvider *notifyprovider.Provider
+	if cfg.Notifications != nil && cfg.Notifications.Enabled {
+		notificationProvider, err = notifyprovider.NewProvider(
+			notifications.PublisherConfig{
+				Brokers: splitList(cfg.Notifications.Brokers),
+				Topic:   cfg.Notifications.Topic,
+				Producer: cfg.Notifications.Producer.ToProducerConfig(
+					splitList(cfg.Notifications.Brokers), "notifications"),
+			})
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("error initializing notification provider: %v", err))
+			return 1
+		}
+		defer notificationProvider.Close()
+
+		// Send notifications in the preferred language of their recipients.
+		notificationProvider.SetLocaleLookup(
+			func(ctx context.Context, emails []string) map[string]string {
+				locales, err := models.GetUserLocales(db.WithContext(ctx), emails)
+				if err != nil {
+					c.Log.Warn("error getting user locales for notification",
+						"error", err)
+				}
+				return locales
+			})
+
+		opsBackends := splitList(cfg.Notifications.OpsBackends)
+		if len(opsBackends) == 0 {
+			opsBackends = []string{"audit"}
+		}
+		migrationNotifier = notifyprovider.NewMigrationNotifier(
+			notificationProvider,
+			opsBackends,
+			splitList(cfg.Notifications.OpsRecipients),
+		)
+	}
+
 	type serveMux interface {
 		Handle(pattern string, handler http.Handler)
 		ServeHTTP(http.ResponseWriter, *http.Request)
@@ -693,45 +880,228 @@ func (c *Command) Run(args []string) int {
 		mux = http.NewServeMux()
 	}
 
+	// For now, the provider map only contains the primary provider.
+	// TODO: In the future, this should use the multi-provider router
+	storageProviders := map[string]workspace.WorkspaceProvider{
+		workspaceProviderName: workspaceProvider,
+	}
+
+	// Send emails through an SMTP server if configured, instead of the
+	// workspace provider.
+	var emailSender email.Sender
+	if cfg.Email != nil && cfg.Email.SMTP != nil {
+		smtpCfg := cfg.Email.SMTP
+		senderCfg := email.SMTPConfig{
+			Host:               smtpCfg.Host,
+			Port:               smtpCfg.Port,
+			Username:           smtpCfg.Username,
+			Password:           smtpCfg.Password,
+			TLS:                smtpCfg.TLS,
+			InsecureSkipVerify: smtpCfg.InsecureSkipVerify,
+			MaxConnections:     smtpCfg.MaxConnections,
+			Timeout:            smtpCfg.Timeout,
+			IdleTimeout:        smtpCfg.IdleTimeout,
+		}
+		if d := smtpCfg.DKIM; d != nil {
+			senderCfg.DKIM = &email.DKIMConfig{
+				Domain:         d.Domain,
+				Selector:       d.Selector,
+				PrivateKeyFile: d.PrivateKeyFile,
+				Headers:        d.Headers,
+			}
+		}
+		smtpSender, err := email.NewSMTPSender(senderCfg)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("error initializing SMTP email sender: %v", err))
+			return 1
+		}
+		defer smtpSender.Close()
+		emailSender = smtpSender
+	}
+
+	// Resolve the folders documents are stored in, creating missing shortcut
+	// folders as documents are published.
+	folders := workspace.NewFolderLayout(
+		workspaceProvider, cfg.WorkspaceFolders(workspaceProviderName))
+
+	// stopping is closed when the HTTP server starts shutting down.
+	stopping := make(chan struct{})
+
 	srv := server.Server{
 		SearchProvider:    searchProvider,
 		WorkspaceProvider: workspaceProvider,
+		StorageProviders:  storageProviders,
+		Folders:           folders,
 		Config:            cfg,
 		DB:                db,
+		EmailSender:       emailSender,
 		Jira:              jiraSvc,
 		Logger:            c.Log,
 		ProjectConfig:     projectConfig,
+		MigrationNotifier: migrationNotifier,
+		Tenants:           tenants,
+		Sessions:          sessions,
+		Stopping:          stopping,
+	}
+
+	// Run request post-processing, such as indexing documents and sending
+	// emails, as background jobs queued in the database.
+	var jobsCfg *jobs.Config
+	if cfg.Jobs != nil {
+		jobsCfg = &jobs.Config{
+			MaxAttempts:  cfg.Jobs.MaxAttempts,
+			PollInterval: cfg.Jobs.PollInterval,
+			Retention:    cfg.Jobs.Retention,
+			Workers:      cfg.Jobs.Workers,
+		}
+	}
+	srv.Jobs = jobs.NewRunner(db, c.Log, jobsCfg)
+	apiv2.RegisterJobs(srv)
+
+	// Push the search vocabulary managed with the admin API to the search
+	// provider, if it supports it. Searches work without it, so errors don't
+	// stop the server.
+	if v, ok := baseSearchProvider.(search.VocabularyUpdater); ok {
+		srv.SearchVocabulary = v
+		reindex, err := apiv2.PushSearchVocabulary(context.Background(), srv)
+		if err != nil {
+			c.UI.Warn(fmt.Sprintf("warning: error pushing search vocabulary: %v", err))
+		} else if reindex {
+			c.UI.Warn("The search vocabulary changed since the search indexes " +
+				"were built: reindex documents for it to fully apply")
+		}
 	}
 
+	// Check the dependencies of the server for readiness. The Kafka check is
+	// added when the outbox relay is started.
+	healthChecker := health.NewChecker()
+	healthChecker.Add("database", func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.PingContext(ctx)
+	})
+	healthChecker.Add("search", searchProvider.Healthy)
+	if hc, ok := workspace.Unwrap(workspaceProvider).(workspace.HealthChecker); ok {
+		healthChecker.Add("workspace", hc.Healthy)
+	}
+
+	// Coordinate the graceful shutdown of the server and its background
+	// tasks. The server reports that it isn't ready once it's shutting down,
+	// so load balancers stop sending it requests while they're drained.
+	var shutdownCfg *shutdown.Config
+	if cfg.Server != nil {
+		shutdownCfg = &shutdown.Config{
+			DrainDelay: cfg.Server.ShutdownDrainDelay,
+			Timeout:    cfg.Server.ShutdownTimeout,
+		}
+	}
+	shutdownCoordinator := shutdown.New(c.Log, shutdownCfg)
+	healthChecker.Add("shutdown", shutdownCoordinator.Ready)
+
+	// Cache the responses of expensive read endpoints, in Redis if configured
+	// so the cache is shared by all servers. Profile photos are cached in the
+	// same store, or in memory if the response cache is disabled.
+	var photoStore httpcache.Store = httpcache.NewMemoryStore(
+		avatar.DefaultMaxEntries)
+	if rc := cfg.ResponseCache; rc != nil && rc.Enabled {
+		var store httpcache.Store
+		if rc.Redis != nil {
+			redisStore := httpcache.NewRedisStore(httpcache.RedisConfig{
+				Address:  rc.Redis.Address,
+				Password: rc.Redis.Password,
+				DB:       rc.Redis.DB,
+			})
+			defer redisStore.Close()
+			healthChecker.Add("response_cache", redisStore.Ping)
+			store = redisStore
+		} else {
+			store = httpcache.NewMemoryStore(rc.MaxEntries)
+		}
+		srv.ResponseCache = httpcache.New(store, rc.TTL, c.Log)
+		if rc.Redis != nil {
+			photoStore = store
+		}
+	}
+	srv.Photos = avatar.NewProxy(
+		apiv2.PeoplePhotoURLFunc(srv), photoStore, c.Log, nil)
+
 	// Define handlers for authenticated endpoints.
 	// All API endpoints use v2.
 	authenticatedEndpoints := []endpoint{
+		{"/api/v2/admin/config", apiv2.AdminConfigHandler(srv)},
+		{"/api/v2/admin/custom-fields", apiv2.AdminCustomFieldsHandler(srv)},
+		{"/api/v2/admin/custom-fields/", apiv2.AdminCustomFieldsHandler(srv)},
+		{"/api/v2/admin/deleted/", apiv2.AdminDeletedHandler(srv)},
+		{"/api/v2/admin/document-types", apiv2.AdminDocumentTypesHandler(srv)},
+		{"/api/v2/admin/document-types/", apiv2.AdminDocumentTypesHandler(srv)},
+		{"/api/v2/admin/duplicates", apiv2.AdminDuplicatesHandler(srv)},
+		{"/api/v2/admin/edges", apiv2.AdminEdgesHandler(srv)},
+		{"/api/v2/admin/email/", apiv2.AdminEmailHandler(srv)},
+		{"/api/v2/admin/impersonation", apiv2.AdminImpersonationHandler(srv)},
+		{"/api/v2/admin/impersonation/", apiv2.AdminImpersonationHandler(srv)},
+		{"/api/v2/admin/jobs", apiv2.AdminJobsHandler(srv)},
+		{"/api/v2/admin/jobs/", apiv2.AdminJobsHandler(srv)},
+		{"/api/v2/admin/revision-events/replay", apiv2.AdminRevisionEventsHandler(srv)},
+		{"/api/v2/admin/roles", apiv2.AdminRolesHandler(srv)},
+		{"/api/v2/admin/roles/", apiv2.AdminRolesHandler(srv)},
+		{"/api/v2/admin/search-vocabulary", apiv2.AdminSearchVocabularyHandler(srv)},
+		{"/api/v2/admin/search-vocabulary/", apiv2.AdminSearchVocabularyHandler(srv)},
+		{"/api/v2/admin/service-tokens", apiv2.AdminServiceTokensHandler(srv)},
+		{"/api/v2/admin/service-tokens/", apiv2.AdminServiceTokensHandler(srv)},
+		{"/api/v2/admin/workers", apiv2.AdminWorkersHandler(srv)},
+		{"/api/v2/admin/workers/", apiv2.AdminWorkersHandler(srv)},
+		{"/api/v2/analytics/",
+			apiv2.CachedHandler(srv, apiv2.AnalyticsCachePolicy, apiv2.AnalyticsDashboardsHandler(srv))},
 		{"/api/v2/approvals/", apiv2.ApprovalsHandler(srv)},
-		{"/api/v2/document-types", apiv2.DocumentTypesHandler(srv)},
-		{"/api/v2/documents/", apiv2.DocumentHandler(srv)}, // Handles /content suffix too
-		{"/api/v2/drafts", apiv2.DraftsHandler(srv)},
+		{"/api/v2/audit-events", apiv2.AuditEventsHandler(srv)},
+		{"/api/v2/document-types",
+			apiv2.CachedHandler(srv, apiv2.DocumentTypesCachePolicy, apiv2.DocumentTypesHandler(srv))},
+		{"/api/v2/document-types/", apiv2.DocumentTypeTemplatesHandler(srv)},
+		{"/api/v2/documents/", apiv2.DocumentHandler(srv)}, // Handles /content and /similar suffixes too
+		{"/api/v2/documents/import",
+			apiv2.IdempotentHandler(srv, apiv2.DocumentImportHandler(srv))},
+		{"/api/v2/drafts", apiv2.IdempotentHandler(srv, apiv2.DraftsHandler(srv))},
 		{"/api/v2/drafts/", apiv2.DraftsDocumentHandler(srv)},
-		{"/api/v2/groups", apiv2.GroupsHandler(srv)},
+		{"/api/v2/events", apiv2.DocumentEventsHandler(srv)},
+		{"/api/v2/group-reviews/", apiv2.GroupReviewsHandler(srv)},
+		{"/api/v2/groups",
+			apiv2.CachedHandler(srv, apiv2.GroupsCachePolicy, apiv2.GroupsHandler(srv))},
 		{"/api/v2/jira/issues/", apiv2.JiraIssueHandler(srv)},
 		{"/api/v2/jira/issue/picker", apiv2.JiraIssuePickerHandler(srv)},
 		{"/api/v2/me", apiv2.MeHandler(srv)},
+		{"/api/v2/me/activity", apiv2.MeActivityHandler(srv)},
+		{"/api/v2/me/broken-links", apiv2.MeBrokenLinksHandler(srv)},
 		{"/api/v2/me/recently-viewed-docs", apiv2.MeRecentlyViewedDocsHandler(srv)},
 		{"/api/v2/me/recently-viewed-projects",
 			apiv2.MeRecentlyViewedProjectsHandler(srv)},
+		{"/api/v2/me/review-delegations", apiv2.ReviewDelegationsHandler(srv)},
+		{"/api/v2/me/review-delegations/", apiv2.ReviewDelegationsHandler(srv)},
 		{"/api/v2/me/reviews", apiv2.MeReviewsHandler(srv)},
+		{"/api/v2/me/saved-searches", apiv2.SavedSearchesHandler(srv)},
+		{"/api/v2/me/saved-searches/", apiv2.SavedSearchesHandler(srv)},
 		{"/api/v2/me/subscriptions", apiv2.MeSubscriptionsHandler(srv)},
-		{"/api/v2/migrations/", apiv2.MigrationsHandler(srv)},
-		{"/api/v2/people", apiv2.PeopleDataHandler(srv)},
-		{"/api/v2/products", apiv2.ProductsHandler(srv)},
+		{"/api/v2/migrations/",
+			apiv2.PostgresRequiredHandler(srv, apiv2.MigrationsHandler(srv))},
+		{"/api/v2/openapi.json", apiv2.OpenAPIHandler(srv)},
+		{"/api/v2/people",
+			apiv2.CachedHandler(srv, apiv2.PeopleCachePolicy, apiv2.PeopleDataHandler(srv))},
+		{"/api/v2/people/", apiv2.PersonPhotoHandler(srv)},
+		{"/api/v2/products",
+			apiv2.CachedHandler(srv, apiv2.ProductsCachePolicy, apiv2.ProductsHandler(srv))},
 		{"/api/v2/projects", apiv2.ProjectsHandler(srv)},
 		{"/api/v2/projects/", apiv2.ProjectHandler(srv)},
-		{"/api/v2/providers", apiv2.ProvidersHandler(srv)},
-		{"/api/v2/providers/", apiv2.ProvidersHandler(srv)},
+		{"/api/v2/providers",
+			apiv2.PostgresRequiredHandler(srv, apiv2.ProvidersHandler(srv))},
+		{"/api/v2/providers/",
+			apiv2.PostgresRequiredHandler(srv, apiv2.ProvidersHandler(srv))},
 		{"/api/v2/reviews/", apiv2.ReviewsHandler(srv)},
-		{"/api/v2/search/", apiv2.SearchHandler(srv)},
+		{"/api/v2/search/",
+			apiv2.CachedHandler(srv, apiv2.SearchFacetsCachePolicy, apiv2.SearchHandler(srv))},
 		{"/api/v2/search/semantic", apiv2.SemanticSearchHandler(srv)}, // RFC-088: Semantic search
 		{"/api/v2/search/hybrid", apiv2.HybridSearchHandler(srv)},     // RFC-088: Hybrid search
-		{"/api/v2/documents/", apiv2.SimilarDocumentsHandler(srv)},    // RFC-088: Similar documents
+		{"/api/v2/shared-drafts/", apiv2.SharedDraftsHandler(srv)},
 		{"/api/v2/web/analytics", apiv2.AnalyticsHandler(srv)},
 		{"/api/v2/workspace-projects", apiv2.WorkspaceProjectsHandler(srv)},
 		{"/api/v2/workspace-projects/", apiv2.WorkspaceProjectHandler(srv)},
@@ -747,18 +1117,32 @@ func (c *Command) Run(args []string) int {
 
 	// Define handlers for unauthenticated endpoints.
 	unauthenticatedEndpoints := []endpoint{
-		{"/health", healthHandler()},
+		{"/health", health.LivenessHandler()}, // Deprecated: use /healthz.
+		{"/healthz", health.LivenessHandler()},
+		{"/readyz", healthChecker.ReadinessHandler()},
+		{"/metrics", metrics.Handler()},
 		{"/pub/", http.StripPrefix("/pub/", pub.Handler())},
-		{"/api/v2/indexer/", apiv2.IndexerHandler(srv)},                                  // Indexer API (handles own token auth)
-		{"/api/v2/edge/", apiv2.EdgeSyncAuthMiddleware(srv, apiv2.EdgeSyncHandler(srv))}, // Edge sync API (token auth)
+		{"/api/v2/indexer/", apiv2.IndexerHandler(srv)}, // Indexer API (token auth)
+		{"/api/v2/edge/", apiv2.PostgresRequiredHandler(srv,
+			apiv2.EdgeSyncAuthMiddleware(srv, apiv2.EdgeSyncHandler(srv)))}, // Edge sync API (token auth)
+		{"/api/v2/notifications/", apiv2.NotificationsHandler(srv)}, // Notifications API (token auth)
+		{"/api/v2/workers/", apiv2.PostgresRequiredHandler(srv,
+			apiv2.WorkersHandler(srv))}, // Worker status API (token auth)
+		{"/approval-links/", apiv2.ApprovalLinksHandler(srv)}, // Approval links (token auth)
 	}
 
-	// Add Dex OIDC auth endpoints if Dex is configured
-	if cfg.Dex != nil && !cfg.Dex.Disabled {
+	// Add OIDC or Dex auth endpoints if either is configured
+	if oidcAdapter != nil {
 		unauthenticatedEndpoints = append(unauthenticatedEndpoints,
-			endpoint{"/auth/login", api.LoginHandler(*cfg, c.Log)},
-			endpoint{"/auth/callback", api.CallbackHandler(*cfg, c.Log)},
-			endpoint{"/auth/logout", api.LogoutHandler(c.Log)},
+			endpoint{"/auth/login", api.OIDCLoginHandler(oidcAdapter, sessions, c.Log)},
+			endpoint{"/auth/callback", api.OIDCCallbackHandler(*cfg, oidcAdapter, sessions, c.Log)},
+			endpoint{"/auth/logout", api.OIDCLogoutHandler(*cfg, oidcAdapter, sessions, c.Log)},
+		)
+	} else if cfg.Dex != nil && !cfg.Dex.Disabled {
+		unauthenticatedEndpoints = append(unauthenticatedEndpoints,
+			endpoint{"/auth/login", api.LoginHandler(*cfg, sessions, c.Log)},
+			endpoint{"/auth/callback", api.CallbackHandler(*cfg, sessions, c.Log)},
+			endpoint{"/auth/logout", api.LogoutHandler(sessions, c.Log)},
 		)
 	}
 
@@ -788,36 +1172,57 @@ func (c *Command) Run(args []string) int {
 		{"/", web.Handler()},
 	}
 
-	// If Okta or Dex is enabled, add the SPA handler as an authenticated endpoint.
-	if (cfg.Okta != nil && !cfg.Okta.Disabled) || (cfg.Dex != nil && !cfg.Dex.Disabled) {
+	// If OIDC, Okta, or Dex is enabled, add the SPA handler as an authenticated
+	// endpoint.
+	if oidcAdapter != nil || (cfg.Okta != nil && !cfg.Okta.Disabled) ||
+		(cfg.Dex != nil && !cfg.Dex.Disabled) {
 		authenticatedEndpoints = append(authenticatedEndpoints, spaEndpoints...)
 	} else {
-		// If both Okta and Dex are disabled, add the SPA handler as an unauthenticated endpoint.
+		// If OIDC, Okta, and Dex are disabled, add the SPA handler as an
+		// unauthenticated endpoint.
 		unauthenticatedEndpoints = append(unauthenticatedEndpoints, spaEndpoints...)
 	}
 
 	// Register handlers.
 	for _, e := range authenticatedEndpoints {
-		// Note: auth.AuthenticateRequest supports Dex, Okta, or Google authentication.
-		// When using non-Google workspace providers with non-Dex authentication,
-		// Okta authentication must be enabled.
-		if goog == nil && (cfg.Okta == nil || cfg.Okta.Disabled) && (cfg.Dex == nil || cfg.Dex.Disabled) {
-			c.UI.Error("error: when using non-Google workspace providers, Okta or Dex authentication must be enabled")
+		// Note: auth.AuthenticateRequest supports OIDC, Dex, Okta, or Google
+		// authentication. When using non-Google workspace providers, OIDC, Okta,
+		// or Dex authentication must be enabled (except with "hermes dev").
+		if goog == nil && oidcAdapter == nil && cfg.DevUser == "" &&
+			(cfg.Okta == nil || cfg.Okta.Disabled) && (cfg.Dex == nil || cfg.Dex.Disabled) {
+			c.UI.Error("error: when using non-Google workspace providers, OIDC, Okta, or Dex authentication must be enabled")
 			return 1
 		}
+		handler := e.handler
+		if cfg.Audit != nil && cfg.Audit.Enabled &&
+			strings.HasPrefix(e.pattern, "/api/v2/") {
+			handler = apiv2.AuditHandler(srv, handler)
+		}
+		handler = apiv2.TenantHandler(srv, handler)
+		handler = apiv2.ImpersonationHandler(srv, handler)
 		mux.Handle(
 			e.pattern,
-			auth.AuthenticateRequest(*cfg, goog, c.Log, e.handler),
+			instrumentHandler(e.pattern, auth.AuthenticateRequest(
+				*cfg, goog, oidcAdapter, sessions, c.Log, handler)),
 		)
 	}
 	for _, e := range unauthenticatedEndpoints {
-		mux.Handle(e.pattern, e.handler)
+		handler := e.handler
+		// The v2 API endpoints here authenticate with service tokens.
+		if cfg.Audit != nil && cfg.Audit.Enabled &&
+			strings.HasPrefix(e.pattern, "/api/v2/") {
+			handler = apiv2.AuditHandler(srv, handler)
+		}
+		mux.Handle(e.pattern, instrumentHandler(e.pattern, handler))
 	}
 
 	server := &http.Server{
-		Addr:    cfg.Server.Addr,
-		Handler: mux,
+		Addr: cfg.Server.Addr,
+		// Requests are logged with their request ID, which is added to the
+		// log lines of the API handlers.
+		Handler: requestid.Handler(c.Log, mux),
 	}
+	server.RegisterOnShutdown(func() { close(stopping) })
 	go func() {
 		c.Log.Info(fmt.Sprintf("listening on %s...", cfg.Server.Addr))
 
@@ -830,9 +1235,6 @@ func (c *Command) Run(args []string) int {
 	// RFC-088: Start outbox relay goroutine (publishes outbox events to Redpanda)
 	// The relay runs in the main server process to keep database writes transactional
 	if cfg.Indexer != nil {
-		ctx, cancel := context.WithCancel(context.Background())
-		defer cancel()
-
 		brokers := kafka.GetBrokers(cfg)
 		topic := kafka.GetDocumentRevisionTopic(cfg)
 
@@ -840,6 +1242,7 @@ func (c *Command) Run(args []string) int {
 			DB:           db,
 			Brokers:      brokers,
 			Topic:        topic,
+			Producer:     cfg.Indexer.Producer.ToProducerConfig(brokers, "outbox-relay"),
 			PollInterval: cfg.Indexer.PollInterval,
 			BatchSize:    cfg.Indexer.BatchSize,
 			Logger:       c.Log.Named("outbox-relay"),
@@ -849,41 +1252,42 @@ func (c *Command) Run(args []string) int {
 			os.Exit(1)
 		}
 
+		healthChecker.Add("kafka", relayService.Ping)
+
 		// Start relay goroutine
-		go func() {
+		shutdownCoordinator.Go("outbox relay", func(ctx context.Context) error {
 			c.Log.Info("starting outbox relay service")
-			if err := relayService.Start(ctx); err != nil {
-				c.Log.Error(fmt.Sprintf("outbox relay service failed: %v", err))
-			}
-		}()
+			return relayService.Start(ctx)
+		})
 
 		// Start cleanup goroutine (runs every 24 hours)
-		go func() {
+		shutdownCoordinator.Go("outbox cleanup", func(ctx context.Context) error {
 			ticker := time.NewTicker(24 * time.Hour)
 			defer ticker.Stop()
 
 			for {
 				select {
 				case <-ctx.Done():
-					return
+					return ctx.Err()
 				case <-ticker.C:
 					if err := relayService.CleanupOldEntries(7 * 24 * time.Hour); err != nil {
 						c.Log.Error(fmt.Sprintf("failed to cleanup old outbox entries: %v", err))
 					}
 				}
 			}
-		}()
+		})
 
-		// Cancel relay context on shutdown
-		defer cancel()
+		// Publish the outbox entries written by the last requests before the
+		// Kafka client is closed.
+		shutdownCoordinator.OnStop("outbox relay", func(ctx context.Context) error {
+			defer relayService.Stop()
+			return relayService.Flush(ctx)
+		})
 	}
 
 	// RFC-089: Start migration worker goroutine (processes migration tasks)
 	// The worker runs in the main server process to handle document migrations
 	if cfg.Migration != nil && cfg.Migration.Enabled {
-		ctx, cancel := context.WithCancel(context.Background())
-		defer cancel()
-
 		// Get underlying SQL DB from GORM
 		sqlDB, err := db.DB()
 		if err != nil {
@@ -891,127 +1295,290 @@ func (c *Command) Run(args []string) int {
 			os.Exit(1)
 		}
 
-		// For now, create a simple provider map with just the primary provider
-		// TODO: In the future, this should use the multi-provider router
-		providerMap := make(map[string]workspace.WorkspaceProvider)
-		providerMap[workspaceProviderName] = workspaceProvider
+		providerMap := srv.StorageProviders
 
 		// Set defaults for migration config
-		pollInterval := 5 * time.Second
+		pollInterval := migration.DefaultWorkerPollInterval
 		if cfg.Migration.PollInterval > 0 {
 			pollInterval = cfg.Migration.PollInterval
 		}
 
-		maxConcurrency := 5
+		maxConcurrency := migration.DefaultWorkerMaxConcurrency
 		if cfg.Migration.MaxConcurrency > 0 {
 			maxConcurrency = cfg.Migration.MaxConcurrency
 		}
 
+		// Serve and reindex documents from their migrated copy after they are
+		// repointed.
 		workerCfg := &migration.WorkerConfig{
 			PollInterval:   pollInterval,
 			MaxConcurrency: maxConcurrency,
+			RepointHooks: []migration.RepointHook{
+				apiv2.DocumentLocationRepointHook(),
+				migration.NewSearchReindexHook(
+					searchProvider.DocumentIndex(), providerMap),
+			},
+			Notifier: migrationNotifier,
 		}
 
 		migrationWorker := migration.NewWorker(sqlDB, providerMap, c.Log.Named("migration-worker"), workerCfg)
 
 		// Start worker goroutine
-		go func() {
+		shutdownCoordinator.Go("migration worker", func(ctx context.Context) error {
 			c.Log.Info("starting migration worker",
 				"poll_interval", pollInterval,
 				"max_concurrency", maxConcurrency)
-			if err := migrationWorker.Start(ctx); err != nil && err != context.Canceled {
-				c.Log.Error(fmt.Sprintf("migration worker failed: %v", err))
-			}
-		}()
+			return migrationWorker.Start(ctx)
+		})
 
 		c.Log.Info("RFC-089 migration system enabled",
 			"write_strategy", cfg.Migration.WriteStrategy,
 			"read_strategy", cfg.Migration.ReadStrategy)
+	}
+
+	// Start people directory sync job goroutine.
+	if cfg.PeopleDirectory != nil && cfg.PeopleDirectory.Enabled {
+		directorySyncJob := directorysync.NewJob(db, workspaceProvider, c.Log,
+			&directorysync.Config{
+				Interval: cfg.PeopleDirectory.SyncInterval,
+			})
 
-		// Cancel worker context on shutdown
-		defer cancel()
+		shutdownCoordinator.Go("directory sync job", directorySyncJob.Start)
 	}
 
-	return c.WaitForInterrupt(c.ShutdownServer(server))
-}
+	// Start document freshness scoring job goroutine.
+	if cfg.Freshness != nil && cfg.Freshness.Enabled {
+		freshnessJob := freshness.NewJob(db, searchProvider, c.Log,
+			&freshness.Config{
+				Interval: cfg.Freshness.Interval,
+			})
 
-// healthHandler responds with the health of the service.
-func healthHandler() http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		w.Write([]byte("OK"))
-	})
-}
+		shutdownCoordinator.Go("freshness job", freshnessJob.Start)
+	}
 
-// ShutdownServer gracefully shuts down the HTTP server.
-func (c *Command) ShutdownServer(s *http.Server) func() {
-	return func() {
-		c.Log.Debug("shutting down HTTP server...")
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
-		if err := s.Shutdown(ctx); err != nil {
-			c.Log.Error(fmt.Sprintf("error shutting down HTTP server: %v", err))
+	// Start review SLA tracking job goroutine.
+	if cfg.ReviewSLA != nil && cfg.ReviewSLA.Enabled {
+		slas := map[string]time.Duration{}
+		if cfg.DocumentTypes != nil {
+			for _, dt := range cfg.DocumentTypes.DocumentType {
+				slas[dt.Name] = dt.ReviewSLA
+			}
+		}
+
+		var notifier reviewsla.Notifier
+		if notificationProvider != nil {
+			backends := cfg.ReviewSLA.NotificationBackends
+			if len(backends) == 0 {
+				backends = []string{"mail", "audit"}
+			}
+			notifier = notifyprovider.NewReviewSLANotifier(
+				notificationProvider, backends, cfg.BaseURL)
 		}
+
+		reviewSLAJob := reviewsla.NewJob(db, searchProvider, notifier, c.Log,
+			&reviewsla.Config{
+				SLAs:       slas,
+				Interval:   cfg.ReviewSLA.Interval,
+				WarnBefore: cfg.ReviewSLA.WarnBefore,
+			})
+
+		shutdownCoordinator.Go("review SLA job", reviewSLAJob.Start)
 	}
-}
 
-// registerDocumentTypes registers all products configured in the application
-// config in the database.
-func registerDocumentTypes(cfg config.Config, db *gorm.DB) error {
-	for _, d := range cfg.DocumentTypes.DocumentType {
-		// Marshal Checks to JSON.
-		checksJSON, err := json.Marshal(d.Checks)
+	// Start document retention job goroutine.
+	if cfg.Retention != nil && cfg.Retention.Enabled {
+		policies, err := cfg.Retention.Policies()
 		if err != nil {
-			return fmt.Errorf("error marshaling checks to JSON: %w", err)
+			c.Log.Error(fmt.Sprintf("error parsing retention policies: %v", err))
+			os.Exit(1)
 		}
 
-		// Convert custom fields to model's version.
-		var cfs []models.DocumentTypeCustomField
-		for _, c := range d.CustomFields {
-			cf := models.DocumentTypeCustomField{
-				Name:     c.Name,
-				ReadOnly: c.ReadOnly,
+		var notifier retention.Notifier
+		if notificationProvider != nil {
+			backends := cfg.Retention.NotificationBackends
+			if len(backends) == 0 {
+				backends = []string{"mail", "audit"}
 			}
+			notifier = notifyprovider.NewRetentionNotifier(
+				notificationProvider, backends, cfg.BaseURL)
+		}
+
+		retentionJob := retention.NewJob(db, searchProvider, notifier, c.Log,
+			&retention.Config{
+				Policies: policies,
+				Interval: cfg.Retention.Interval,
+			})
 
-			// Convert custom field type.
-			t := strings.ToLower(c.Type)
-			switch t {
-			case "string":
-				cf.Type = models.DocumentTypeCustomFieldType(
-					models.StringDocumentTypeCustomFieldType)
-			case "person":
-				cf.Type = models.DocumentTypeCustomFieldType(
-					models.PersonDocumentTypeCustomFieldType)
-			case "people":
-				cf.Type = models.DocumentTypeCustomFieldType(
-					models.PeopleDocumentTypeCustomFieldType)
-			case "":
-				return fmt.Errorf("missing document type custom field")
-			default:
-				return fmt.Errorf("invalid document type custom field: %s", t)
+		shutdownCoordinator.Go("retention job", retentionJob.Start)
+	}
+
+	// Start saved search digest job goroutine.
+	if cfg.SavedSearchDigests != nil && cfg.SavedSearchDigests.Enabled {
+		var notifier savedsearch.Notifier
+		if notificationProvider != nil {
+			backends := cfg.SavedSearchDigests.NotificationBackends
+			if len(backends) == 0 {
+				backends = []string{"mail", "audit"}
 			}
+			notifier = notifyprovider.NewSavedSearchNotifier(
+				notificationProvider, backends, cfg.BaseURL)
+		}
 
-			cfs = append(cfs, cf)
+		savedSearchJob := savedsearch.NewJob(db, searchProvider, notifier, c.Log,
+			&savedsearch.Config{
+				Interval: cfg.SavedSearchDigests.Interval,
+			})
+
+		shutdownCoordinator.Go("saved search digest job", savedSearchJob.Start)
+	}
+
+	// Start broken-link detection job goroutine.
+	if cfg.LinkCheck != nil && cfg.LinkCheck.Enabled {
+		linkCheckJob := linkcheck.NewJob(db, searchProvider, c.Log, &linkcheck.Config{
+			BaseURLs:             []string{cfg.BaseURL, cfg.ShortenerBaseURL},
+			Interval:             cfg.LinkCheck.Interval,
+			Timeout:              cfg.LinkCheck.Timeout,
+			MaxConcurrency:       cfg.LinkCheck.MaxConcurrency,
+			MaxExternalPerRun:    cfg.LinkCheck.MaxExternalPerRun,
+			MaxRequestsPerSecond: cfg.LinkCheck.MaxRequestsPerSecond,
+		})
+
+		shutdownCoordinator.Go("link check job", linkCheckJob.Start)
+	}
+
+	// Start document integrity job goroutine.
+	if cfg.Integrity != nil && cfg.Integrity.Enabled {
+		var notifier integrity.Notifier
+		if notificationProvider != nil {
+			opsBackends := splitList(cfg.Notifications.OpsBackends)
+			if len(opsBackends) == 0 {
+				opsBackends = []string{"audit"}
+			}
+			notifier = notifyprovider.NewIntegrityNotifier(
+				notificationProvider,
+				opsBackends,
+				splitList(cfg.Notifications.OpsRecipients),
+				cfg.BaseURL,
+			)
 		}
 
-		dt := models.DocumentType{
-			Name:         d.Name,
-			LongName:     d.LongName,
-			Description:  d.Description,
-			FlightIcon:   d.FlightIcon,
-			Checks:       checksJSON,
-			CustomFields: cfs,
+		integrityJob := integrity.NewJob(db, workspaceProvider, notifier, c.Log,
+			&integrity.Config{
+				Interval:           cfg.Integrity.Interval,
+				ProviderName:       workspaceProviderName,
+				StorageProviders:   storageProviders,
+				MaxDocumentsPerRun: cfg.Integrity.MaxDocumentsPerRun,
+			})
+
+		shutdownCoordinator.Go("integrity job", integrityJob.Start)
+	}
+
+	// Start the background job runner. Jobs being run when the server shuts
+	// down are finished before it exits.
+	shutdownCoordinator.Go("job runner", srv.Jobs.Start)
+
+	// Start the job that deletes user activity after the retention period.
+	{
+		var activityCfg *activity.Config
+		if cfg.Activity != nil {
+			activityCfg = &activity.Config{
+				PruneInterval: cfg.Activity.PruneInterval,
+				Retention:     cfg.Activity.Retention,
+			}
 		}
+		activityJob := activity.NewJob(db, c.Log, activityCfg)
 
-		if d.MoreInfoLink != nil {
-			dt.MoreInfoLinkText = d.MoreInfoLink.Text
-			dt.MoreInfoLinkURL = d.MoreInfoLink.URL
+		shutdownCoordinator.Go("user activity job", activityJob.Start)
+	}
+
+	// Start the job that purges deleted documents and projects after the
+	// recovery window.
+	{
+		purgeCfg := &purge.Config{
+			ProviderName: workspaceProviderName,
+		}
+		if cfg.SoftDelete != nil {
+			purgeCfg.Interval = cfg.SoftDelete.PurgeInterval
+			purgeCfg.RecoveryWindow = cfg.SoftDelete.RecoveryWindow
+		}
+		purgeJob := purge.NewJob(db, workspaceProvider, c.Log, purgeCfg)
+
+		shutdownCoordinator.Go("purge job", purgeJob.Start)
+	}
+
+	// Close the providers and the database once background tasks have
+	// stopped.
+	if closer, ok := baseSearchProvider.(io.Closer); ok {
+		shutdownCoordinator.OnStop("search provider", func(context.Context) error {
+			return closer.Close()
+		})
+	}
+	if closer, ok := workspace.Unwrap(baseWorkspaceProvider).(io.Closer); ok {
+		shutdownCoordinator.OnStop("workspace provider", func(context.Context) error {
+			return closer.Close()
+		})
+	}
+	shutdownCoordinator.OnStop("database", func(context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.Close()
+	})
+
+	return c.WaitForInterrupt(c.ShutdownServer(shutdownCoordinator, server))
+}
+
+// splitList splits a comma-separated config value, dropping empty entries.
+func splitList(s string) []string {
+	var list []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+// instrumentHandler returns h with metrics recorded and spans started for
+// its requests. route is the pattern h is registered with.
+func instrumentHandler(route string, h http.Handler) http.Handler {
+	return tracing.InstrumentHandler(route, metrics.InstrumentHandler(route, h))
+}
+
+// ShutdownServer gracefully shuts down the HTTP server: in-flight requests
+// are drained, and then background tasks are stopped and providers closed.
+func (c *Command) ShutdownServer(
+	coordinator *shutdown.Coordinator, s *http.Server) func() {
+	return func() {
+		c.Log.Debug("shutting down HTTP server...")
+		if err := coordinator.Shutdown(s); err != nil {
+			c.Log.Error(fmt.Sprintf("error shutting down server: %v", err))
+		}
+	}
+}
+
+// registerDocumentTypes registers all products configured in the application
+// config in the database.
+func registerDocumentTypes(cfg config.Config, db *gorm.DB) error {
+	for _, d := range cfg.DocumentTypes.DocumentType {
+		// Configured document types replace document types of the same name
+		// managed with the admin API.
+		dt, templates, err := document.NewDocumentTypeModel(d)
+		if err != nil {
+			return err
 		}
 
 		// Upsert document type.
 		if err := dt.Upsert(db); err != nil {
 			return fmt.Errorf("error upserting document type: %w", err)
 		}
+
+		// Replace the document type's templates.
+		if err := models.SetDocumentTypeTemplates(
+			db, dt.ID, templates); err != nil {
+			return fmt.Errorf("error setting document type templates: %w", err)
+		}
 	}
 
 	return nil
@@ -1060,6 +1627,51 @@ func registerProducts(
 	return nil
 }
 
+// registerTenants registers the tenants configured in the application config in
+// the database and returns their resolver. If a tenant has its own local
+// workspace, the returned workspace provider routes the tenant's calls to it
+// and other calls to workspaceProvider.
+func registerTenants(
+	cfg *config.Config, db *gorm.DB, workspaceProvider workspace.WorkspaceProvider,
+) (*tenant.Resolver, workspace.WorkspaceProvider, error) {
+	resolver := tenant.NewResolver()
+	var tenantRouter *router.TenantRouter
+
+	for _, tc := range cfg.Tenants {
+		tm := models.Tenant{
+			Name:        tc.Name,
+			DisplayName: tc.DisplayName,
+		}
+		if err := tm.FirstOrCreate(db); err != nil {
+			return nil, nil, fmt.Errorf("error upserting tenant %q: %w", tc.Name, err)
+		}
+		if err := resolver.Add(
+			tenant.Tenant{ID: tm.ID, Name: tm.Name}, tc.Hostnames...,
+		); err != nil {
+			return nil, nil, err
+		}
+
+		if tc.LocalWorkspace != nil {
+			adapter, err := localadapter.NewAdapter(
+				tc.LocalWorkspace.ToLocalAdapterConfig())
+			if err != nil {
+				return nil, nil, fmt.Errorf(
+					"error initializing local workspace of tenant %q: %w", tc.Name, err)
+			}
+			if tenantRouter == nil {
+				tenantRouter = router.NewTenantRouter(workspaceProvider)
+			}
+			tenantRouter.SetTenantProvider(
+				tc.Name, localadapter.NewWorkspaceAdapter(adapter))
+		}
+	}
+
+	if tenantRouter != nil {
+		workspaceProvider = tenantRouter
+	}
+	return resolver, workspaceProvider, nil
+}
+
 // generateIndexerToken generates a registration token for indexers and writes it to a file.
 func generateIndexerToken(db *gorm.DB, tokenPath string, logger hclog.Logger) error {
 	// Create parent directory if it doesn't exist
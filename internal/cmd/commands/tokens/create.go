@@ -0,0 +1,167 @@
+package tokens
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/cmd/base"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+)
+
+// tokenTypes are the token types that can be created.
+var tokenTypes = []string{"api", "edge", "registration"}
+
+type CreateCommand struct {
+	*base.Command
+	dbFlags
+
+	flagExpiresIn time.Duration
+	flagJSON      bool
+	flagName      string
+	flagScopes    string
+	flagType      string
+}
+
+// NewCreateCommand returns the "tokens create" command. It opens the database
+// with openDB, or connects to the database configured by the -config flag if
+// openDB is nil.
+func NewCreateCommand(b *base.Command, openDB OpenDBFunc) *CreateCommand {
+	return &CreateCommand{Command: b, dbFlags: dbFlags{openDB: openDB}}
+}
+
+func (c *CreateCommand) Synopsis() string {
+	return "Create a service token"
+}
+
+func (c *CreateCommand) Help() string {
+	return `Usage: hermes tokens create [options]
+
+  This command creates a service token and prints it. The token is only
+  stored as a hash, so it can't be shown again; store it somewhere safe, such
+  as a secrets manager. Edge instances use it as the auth_token of their api
+  workspace provider.
+
+  Example:
+    hermes tokens create -config=config.hcl -type=edge \
+      -name=edge-us-east -scopes=sync -expires-in=720h` +
+		c.Flags().Help()
+}
+
+func (c *CreateCommand) Flags() *base.FlagSet {
+	f := base.NewFlagSet(flag.NewFlagSet("create", flag.ExitOnError))
+	c.dbFlags.addFlags(f)
+
+	f.StringVar(
+		&c.flagType, "type", "edge",
+		fmt.Sprintf("Token type (%s).", strings.Join(tokenTypes, ", ")),
+	)
+	f.StringVar(
+		&c.flagName, "name", "",
+		"Human-readable name of the token, such as the edge instance that uses it.",
+	)
+	f.StringVar(
+		&c.flagScopes, "scopes", "",
+		fmt.Sprintf("(Required) Comma-separated scopes the token is allowed (%s).",
+			strings.Join(models.TokenScopes, ", ")),
+	)
+	f.DurationVar(
+		&c.flagExpiresIn, "expires-in", 0,
+		"Lifetime of the token (e.g., 720h). The token never expires if unset.",
+	)
+	f.BoolVar(
+		&c.flagJSON, "json", false, "Output the token as JSON.",
+	)
+
+	return f
+}
+
+func (c *CreateCommand) Run(args []string) int {
+	ui := c.UI
+
+	// Parse flags.
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		ui.Error(fmt.Sprintf("error parsing flags: %v", err))
+		return 1
+	}
+
+	// Validate flags.
+	if !contains(tokenTypes, c.flagType) {
+		ui.Error(fmt.Sprintf("invalid token type %q (valid types: %s)",
+			c.flagType, strings.Join(tokenTypes, ", ")))
+		return 1
+	}
+	var scopes []string
+	for _, s := range strings.Split(c.flagScopes, ",") {
+		if s = strings.TrimSpace(s); s == "" {
+			continue
+		}
+		if !models.IsValidTokenScope(s) {
+			ui.Error(fmt.Sprintf("invalid scope %q (valid scopes: %s)",
+				s, strings.Join(models.TokenScopes, ", ")))
+			return 1
+		}
+		scopes = append(scopes, s)
+	}
+	if len(scopes) == 0 {
+		ui.Error("scopes flag is required")
+		return 1
+	}
+	if c.flagExpiresIn < 0 {
+		ui.Error("expires-in must be positive")
+		return 1
+	}
+
+	db, err := c.db()
+	if err != nil {
+		ui.Error(fmt.Sprintf("error connecting to database: %v", err))
+		return 1
+	}
+
+	token := models.IndexerToken{
+		TokenType: c.flagType,
+		Name:      c.flagName,
+		Scopes:    models.StringArray(scopes),
+	}
+	if c.flagExpiresIn > 0 {
+		exp := time.Now().Add(c.flagExpiresIn)
+		token.ExpiresAt = &exp
+	}
+
+	plaintext, err := models.GenerateToken(c.flagType)
+	if err != nil {
+		ui.Error(fmt.Sprintf("error generating token: %v", err))
+		return 1
+	}
+	if err := token.Create(db, plaintext); err != nil {
+		ui.Error(fmt.Sprintf("error creating token: %v", err))
+		return 1
+	}
+
+	if c.flagJSON {
+		resp := newTokenOutput(token)
+		resp.Token = plaintext
+		if err := outputJSON(ui, resp); err != nil {
+			ui.Error(err.Error())
+			return 1
+		}
+		return 0
+	}
+
+	ui.Output(fmt.Sprintf("Created %s token %s.", token.TokenType, token.ID))
+	ui.Output("Store the token now; it can't be shown again:")
+	ui.Output("")
+	ui.Output(plaintext)
+	return 0
+}
+
+func contains(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
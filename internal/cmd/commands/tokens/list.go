@@ -0,0 +1,172 @@
+package tokens
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp-forge/hermes/internal/cmd/base"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+)
+
+// tokenOutput is a service token in JSON output. Token is only set when a
+// token is created.
+type tokenOutput struct {
+	ID            uuid.UUID  `json:"id"`
+	Type          string     `json:"type"`
+	Name          string     `json:"name,omitempty"`
+	Scopes        []string   `json:"scopes"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	ExpiresAt     *time.Time `json:"expiresAt,omitempty"`
+	LastUsedAt    *time.Time `json:"lastUsedAt,omitempty"`
+	Revoked       bool       `json:"revoked"`
+	RevokedAt     *time.Time `json:"revokedAt,omitempty"`
+	RevokedReason string     `json:"revokedReason,omitempty"`
+	Token         string     `json:"token,omitempty"`
+}
+
+func newTokenOutput(t models.IndexerToken) tokenOutput {
+	scopes := []string(t.Scopes)
+	if scopes == nil {
+		scopes = []string{}
+	}
+	return tokenOutput{
+		ID:            t.ID,
+		Type:          t.TokenType,
+		Name:          t.Name,
+		Scopes:        scopes,
+		CreatedAt:     t.CreatedAt,
+		ExpiresAt:     t.ExpiresAt,
+		LastUsedAt:    t.LastUsedAt,
+		Revoked:       t.Revoked,
+		RevokedAt:     t.RevokedAt,
+		RevokedReason: t.RevokedReason,
+	}
+}
+
+type ListCommand struct {
+	*base.Command
+	dbFlags
+
+	flagIncludeRevoked bool
+	flagJSON           bool
+	flagType           string
+}
+
+// NewListCommand returns the "tokens list" command. It opens the database
+// with openDB, or connects to the database configured by the -config flag if
+// openDB is nil.
+func NewListCommand(b *base.Command, openDB OpenDBFunc) *ListCommand {
+	return &ListCommand{Command: b, dbFlags: dbFlags{openDB: openDB}}
+}
+
+func (c *ListCommand) Synopsis() string {
+	return "List service tokens"
+}
+
+func (c *ListCommand) Help() string {
+	return `Usage: hermes tokens list [options]
+
+  This command lists service tokens, newest first. Revoked tokens are only
+  listed with -include-revoked.
+
+  Example:
+    hermes tokens list -config=config.hcl -type=edge` +
+		c.Flags().Help()
+}
+
+func (c *ListCommand) Flags() *base.FlagSet {
+	f := base.NewFlagSet(flag.NewFlagSet("list", flag.ExitOnError))
+	c.dbFlags.addFlags(f)
+
+	f.StringVar(&c.flagType, "type", "", "Only list tokens of this type.")
+	f.BoolVar(
+		&c.flagIncludeRevoked, "include-revoked", false,
+		"Also list revoked tokens.",
+	)
+	f.BoolVar(&c.flagJSON, "json", false, "Output the tokens as JSON.")
+
+	return f
+}
+
+func (c *ListCommand) Run(args []string) int {
+	ui := c.UI
+
+	// Parse flags.
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		ui.Error(fmt.Sprintf("error parsing flags: %v", err))
+		return 1
+	}
+
+	db, err := c.db()
+	if err != nil {
+		ui.Error(fmt.Sprintf("error connecting to database: %v", err))
+		return 1
+	}
+
+	var tokens models.IndexerTokens
+	if err := tokens.FindByType(
+		db, c.flagType, c.flagIncludeRevoked,
+	); err != nil {
+		ui.Error(fmt.Sprintf("error listing tokens: %v", err))
+		return 1
+	}
+
+	if c.flagJSON {
+		out := []tokenOutput{}
+		for _, t := range tokens {
+			out = append(out, newTokenOutput(t))
+		}
+		if err := outputJSON(ui, out); err != nil {
+			ui.Error(err.Error())
+			return 1
+		}
+		return 0
+	}
+
+	if len(tokens) == 0 {
+		ui.Output("No tokens found.")
+		return 0
+	}
+
+	var b strings.Builder
+	tw := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tTYPE\tNAME\tSCOPES\tCREATED\tEXPIRES\tLAST USED\tSTATUS")
+	for _, t := range tokens {
+		scopes := strings.Join(t.Scopes, ",")
+		if scopes == "" {
+			scopes = "(all)"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			t.ID, t.TokenType, t.Name, scopes,
+			formatTime(&t.CreatedAt), formatTime(t.ExpiresAt),
+			formatTime(t.LastUsedAt), tokenStatus(t))
+	}
+	tw.Flush()
+	ui.Output(strings.TrimSuffix(b.String(), "\n"))
+	return 0
+}
+
+// formatTime formats a token timestamp, or returns "-" if it isn't set.
+func formatTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
+
+// tokenStatus returns "revoked", "expired", or "active".
+func tokenStatus(t models.IndexerToken) string {
+	switch {
+	case t.Revoked:
+		return "revoked"
+	case !t.IsValid():
+		return "expired"
+	default:
+		return "active"
+	}
+}
@@ -0,0 +1,106 @@
+package tokens
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp-forge/hermes/internal/cmd/base"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"gorm.io/gorm"
+)
+
+type RevokeCommand struct {
+	*base.Command
+	dbFlags
+
+	flagReason string
+}
+
+// NewRevokeCommand returns the "tokens revoke" command. It opens the database
+// with openDB, or connects to the database configured by the -config flag if
+// openDB is nil.
+func NewRevokeCommand(b *base.Command, openDB OpenDBFunc) *RevokeCommand {
+	return &RevokeCommand{Command: b, dbFlags: dbFlags{openDB: openDB}}
+}
+
+func (c *RevokeCommand) Synopsis() string {
+	return "Revoke a service token"
+}
+
+func (c *RevokeCommand) Help() string {
+	return `Usage: hermes tokens revoke [options] TOKEN_ID
+
+  This command revokes a service token, so it can no longer be used to
+  authenticate. Revoking a token that is already revoked has no effect.
+
+  Example:
+    hermes tokens revoke -config=config.hcl \
+      -reason="edge instance decommissioned" \
+      9f1c6c9e-3f0e-4b8e-a6a1-1d2f3c4b5a69` +
+		c.Flags().Help()
+}
+
+func (c *RevokeCommand) Flags() *base.FlagSet {
+	f := base.NewFlagSet(flag.NewFlagSet("revoke", flag.ExitOnError))
+	c.dbFlags.addFlags(f)
+
+	f.StringVar(
+		&c.flagReason, "reason", "revoked with hermes tokens revoke",
+		"Reason the token was revoked.",
+	)
+
+	return f
+}
+
+func (c *RevokeCommand) Run(args []string) int {
+	ui := c.UI
+
+	// Parse flags.
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		ui.Error(fmt.Sprintf("error parsing flags: %v", err))
+		return 1
+	}
+
+	// Validate arguments.
+	args = flags.Args()
+	if len(args) != 1 {
+		ui.Error("expected exactly one token ID argument")
+		return 1
+	}
+	id, err := uuid.Parse(args[0])
+	if err != nil {
+		ui.Error(fmt.Sprintf("invalid token ID %q", args[0]))
+		return 1
+	}
+
+	db, err := c.db()
+	if err != nil {
+		ui.Error(fmt.Sprintf("error connecting to database: %v", err))
+		return 1
+	}
+
+	token := models.IndexerToken{ID: id}
+	if err := token.Get(db); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			ui.Error(fmt.Sprintf("token %s not found", id))
+			return 1
+		}
+		ui.Error(fmt.Sprintf("error getting token: %v", err))
+		return 1
+	}
+
+	if token.Revoked {
+		ui.Output(fmt.Sprintf("Token %s is already revoked.", id))
+		return 0
+	}
+	if err := token.Revoke(db, c.flagReason); err != nil {
+		ui.Error(fmt.Sprintf("error revoking token: %v", err))
+		return 1
+	}
+
+	ui.Output(fmt.Sprintf("Revoked token %s.", id))
+	return 0
+}
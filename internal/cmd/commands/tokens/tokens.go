@@ -0,0 +1,105 @@
+package tokens
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp-forge/hermes/internal/cmd/base"
+	"github.com/hashicorp-forge/hermes/internal/config"
+	dbpkg "github.com/hashicorp-forge/hermes/internal/db"
+	"github.com/mitchellh/cli"
+	"gorm.io/gorm"
+)
+
+type Command struct {
+	*base.Command
+}
+
+func (c *Command) Synopsis() string {
+	return "Manage service tokens"
+}
+
+func (c *Command) Help() string {
+	return `Usage: hermes tokens <subcommand> [options] [args]
+
+  This command groups subcommands for managing the service tokens used by
+  edge instances, indexers, and other services to authenticate to Hermes
+  (RFC-086).
+
+  Tokens are managed directly in the database configured by the -config
+  file, so they can be issued before the server is running or any admin user
+  exists. The HERMES_SERVER_POSTGRES_PASSWORD environment variable overrides
+  the database password in the config file.`
+}
+
+func (c *Command) Run(args []string) int {
+	return cli.RunResultHelp
+}
+
+// OpenDBFunc opens a connection to the Hermes database.
+type OpenDBFunc func() (*gorm.DB, error)
+
+// dbFlags are the flags used to connect to the Hermes database.
+type dbFlags struct {
+	flagConfig  string
+	flagProfile string
+
+	// openDB opens the database instead of the one configured by the -config
+	// flag, if set.
+	openDB OpenDBFunc
+}
+
+// addFlags adds the database flags to f.
+func (d *dbFlags) addFlags(f *base.FlagSet) {
+	f.StringVar(
+		&d.flagConfig, "config", "", "(Required) Path to Hermes config file",
+	)
+	f.StringVar(
+		&d.flagProfile, "profile", "",
+		"[HERMES_SERVER_PROFILE] Configuration profile to use.",
+	)
+}
+
+// db returns a connection to the Hermes database.
+func (d *dbFlags) db() (*gorm.DB, error) {
+	if d.openDB != nil {
+		return d.openDB()
+	}
+	return d.configDB()
+}
+
+// configDB returns a connection to the database configured by the config
+// file.
+func (d *dbFlags) configDB() (*gorm.DB, error) {
+	if d.flagConfig == "" {
+		return nil, fmt.Errorf("config flag is required")
+	}
+
+	profile := d.flagProfile
+	if val, ok := os.LookupEnv("HERMES_SERVER_PROFILE"); ok && profile == "" {
+		profile = val
+	}
+	cfg, err := config.NewConfig(d.flagConfig, profile)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing config file: %w", err)
+	}
+	if cfg.Postgres == nil {
+		return nil, fmt.Errorf("config file has no postgres block")
+	}
+	if val, ok := os.LookupEnv("HERMES_SERVER_POSTGRES_PASSWORD"); ok {
+		cfg.Postgres.Password = val
+	}
+
+	return dbpkg.NewDB(*cfg.Postgres)
+}
+
+// outputJSON writes v as indented JSON.
+func outputJSON(ui cli.Ui, v any) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding JSON: %w", err)
+	}
+	ui.Output(string(b))
+	return nil
+}
@@ -0,0 +1,126 @@
+package tokens
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/hashicorp-forge/hermes/internal/cmd/base"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+)
+
+func testDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.Indexer{}, &models.IndexerToken{}))
+	return db
+}
+
+// openDB returns an OpenDBFunc that always returns db.
+func openDB(db *gorm.DB) OpenDBFunc {
+	return func() (*gorm.DB, error) { return db, nil }
+}
+
+func TestTokensCommands(t *testing.T) {
+	db := testDB(t)
+	newBase := func(ui cli.Ui) *base.Command {
+		return base.NewCommand(hclog.NewNullLogger(), ui)
+	}
+
+	// Create a token.
+	ui := cli.NewMockUi()
+	create := NewCreateCommand(newBase(ui), openDB(db))
+	require.Equal(t, 0, create.Run([]string{
+		"-type=edge", "-name=edge-1", "-scopes=read,sync", "-expires-in=24h",
+		"-json",
+	}), ui.ErrorWriter.String())
+
+	var created tokenOutput
+	require.NoError(t, json.Unmarshal(ui.OutputWriter.Bytes(), &created))
+	assert.Equal(t, "edge", created.Type)
+	assert.Equal(t, "edge-1", created.Name)
+	assert.Equal(t, []string{"read", "sync"}, created.Scopes)
+	assert.NotNil(t, created.ExpiresAt)
+	assert.Contains(t, created.Token, "hermes-edge-token-")
+
+	// The stored token authenticates with the printed value.
+	var stored models.IndexerToken
+	require.NoError(t, stored.GetByToken(db, created.Token))
+	assert.Equal(t, created.ID, stored.ID)
+
+	// List tokens.
+	ui = cli.NewMockUi()
+	list := NewListCommand(newBase(ui), openDB(db))
+	require.Equal(t, 0, list.Run([]string{"-type=edge"}), ui.ErrorWriter.String())
+	assert.Contains(t, ui.OutputWriter.String(), created.ID.String())
+	assert.Contains(t, ui.OutputWriter.String(), "active")
+
+	// Revoke the token.
+	ui = cli.NewMockUi()
+	revoke := NewRevokeCommand(newBase(ui), openDB(db))
+	require.Equal(t, 0, revoke.Run([]string{
+		"-reason=decommissioned", created.ID.String(),
+	}), ui.ErrorWriter.String())
+
+	require.NoError(t, stored.Get(db))
+	assert.True(t, stored.Revoked)
+	assert.Equal(t, "decommissioned", stored.RevokedReason)
+
+	// Revoked tokens are only listed with -include-revoked.
+	ui = cli.NewMockUi()
+	list = NewListCommand(newBase(ui), openDB(db))
+	require.Equal(t, 0, list.Run(nil))
+	assert.Equal(t, "No tokens found.\n", ui.OutputWriter.String())
+
+	ui = cli.NewMockUi()
+	list = NewListCommand(newBase(ui), openDB(db))
+	require.Equal(t, 0, list.Run([]string{"-include-revoked"}))
+	assert.Contains(t, ui.OutputWriter.String(), "revoked")
+}
+
+func TestCreateCommandValidation(t *testing.T) {
+	tests := map[string]struct {
+		args    []string
+		wantErr string
+	}{
+		"missing scopes": {
+			args:    []string{"-type=edge"},
+			wantErr: "scopes flag is required",
+		},
+		"invalid scope": {
+			args:    []string{"-scopes=admin"},
+			wantErr: `invalid scope "admin"`,
+		},
+		"invalid type": {
+			args:    []string{"-type=indexer", "-scopes=read"},
+			wantErr: `invalid token type "indexer"`,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ui := cli.NewMockUi()
+			c := NewCreateCommand(base.NewCommand(hclog.NewNullLogger(), ui),
+				openDB(testDB(t)))
+
+			assert.Equal(t, 1, c.Run(tc.args))
+			assert.Contains(t, ui.ErrorWriter.String(), tc.wantErr)
+		})
+	}
+}
+
+func TestRevokeCommandNotFound(t *testing.T) {
+	ui := cli.NewMockUi()
+	c := NewRevokeCommand(base.NewCommand(hclog.NewNullLogger(), ui),
+		openDB(testDB(t)))
+
+	assert.Equal(t, 1, c.Run([]string{"9f1c6c9e-3f0e-4b8e-a6a1-1d2f3c4b5a69"}))
+	assert.Contains(t, ui.ErrorWriter.String(), "not found")
+}
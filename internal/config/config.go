@@ -6,29 +6,57 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/hashicorp-forge/hermes/internal/config/decode"
 	dexadapter "github.com/hashicorp-forge/hermes/pkg/auth/adapters/dex"
+	oidcadapter "github.com/hashicorp-forge/hermes/pkg/auth/adapters/oidc"
 	oktaadapter "github.com/hashicorp-forge/hermes/pkg/auth/adapters/okta"
+	"github.com/hashicorp-forge/hermes/pkg/kafka/producer"
+	"github.com/hashicorp-forge/hermes/pkg/retention"
+	"github.com/hashicorp-forge/hermes/pkg/search"
 	algoliaadapter "github.com/hashicorp-forge/hermes/pkg/search/adapters/algolia"
 	meilisearchadapter "github.com/hashicorp-forge/hermes/pkg/search/adapters/meilisearch"
+	"github.com/hashicorp-forge/hermes/pkg/tracing"
+	"github.com/hashicorp-forge/hermes/pkg/workspace"
 	gw "github.com/hashicorp-forge/hermes/pkg/workspace/adapters/google"
 	localadapter "github.com/hashicorp-forge/hermes/pkg/workspace/adapters/local"
+	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/hcl/v2"
-	"github.com/hashicorp/hcl/v2/gohcl"
-	"github.com/hashicorp/hcl/v2/hclsimple"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 )
 
 // Config contains the Hermes configuration.
 type Config struct {
+	// Activity configures the recording and retention of user activity on
+	// documents.
+	Activity *Activity `hcl:"activity,block"`
+
 	// Algolia configures Hermes to work with Algolia.
 	Algolia *algoliaadapter.Config `hcl:"algolia,block"`
 
+	// Attachments configures extracting the text of files attached to
+	// documents and including it in search.
+	Attachments *Attachments `hcl:"attachments,block"`
+
+	// Audit configures the audit log of mutating API requests.
+	Audit *Audit `hcl:"audit,block"`
+
+	// Auth configures browser sessions and CSRF protection.
+	Auth *Auth `hcl:"auth,block"`
+
+	// Authorization configures roles used to authorize API requests.
+	Authorization *Authorization `hcl:"authorization,block"`
+
 	// BaseURL is the base URL used for building links.
 	BaseURL string `hcl:"base_url,optional"`
 
 	// Datadog contains the configuration for Datadog.
 	Datadog *Datadog `hcl:"datadog,block"`
 
+	// DeploymentSize is the declared size of the deployment ("small", "medium",
+	// or "large"). It sets defaults for pool sizes, batch sizes, intervals, and
+	// concurrency limits that are not set explicitly.
+	DeploymentSize string `hcl:"deployment_size,optional"`
+
 	// Dex configures Hermes to work with Dex OIDC.
 	Dex *dexadapter.Config `hcl:"dex,block"`
 
@@ -44,6 +72,9 @@ type Config struct {
 	// FeatureFlags contain available feature flags.
 	FeatureFlags *FeatureFlags `hcl:"feature_flags,block"`
 
+	// Freshness configures document freshness scoring for search.
+	Freshness *Freshness `hcl:"freshness,block"`
+
 	// GoogleAnalyticsTagID is the tag ID for Google Analytics
 	GoogleAnalyticsTagID string `hcl:"google_analytics_tag_id,optional"`
 
@@ -53,9 +84,20 @@ type Config struct {
 	// Indexer contains the configuration for the Hermes indexer.
 	Indexer *Indexer `hcl:"indexer,block"`
 
+	// Integrity configures the scheduled job that verifies the content hashes
+	// of documents and their attachments.
+	Integrity *Integrity `hcl:"integrity,block"`
+
 	// Jira is the configuration for Hermes to work with Jira.
 	Jira *Jira `hcl:"jira,block"`
 
+	// Jobs configures the background jobs run after API requests, such as
+	// indexing documents and sending emails.
+	Jobs *Jobs `hcl:"jobs,block"`
+
+	// LinkCheck configures the scheduled broken-link detection job.
+	LinkCheck *LinkCheck `hcl:"link_check,block"`
+
 	// LocalWorkspace configures local filesystem workspace storage.
 	LocalWorkspace *LocalWorkspace `hcl:"local_workspace,block"`
 
@@ -75,9 +117,16 @@ type Config struct {
 	// Ollama configures Hermes to work with Ollama for local AI summarization.
 	Ollama *Ollama `hcl:"ollama,block"`
 
+	// OIDC configures Hermes to authenticate users with any OpenID Connect
+	// provider. It takes precedence over Dex, Okta, and Google authentication.
+	OIDC *oidcadapter.Config `hcl:"oidc,block"`
+
 	// Okta configures Hermes to work with Okta.
 	Okta *oktaadapter.Config `hcl:"okta,block"`
 
+	// PeopleDirectory configures the local people directory cache.
+	PeopleDirectory *PeopleDirectory `hcl:"people_directory,block"`
+
 	// Products contain available products.
 	Products *Products `hcl:"products,block"`
 
@@ -87,24 +136,65 @@ type Config struct {
 	// Providers specifies which workspace and search providers to use.
 	Providers *Providers `hcl:"providers,block"`
 
+	// ResponseCache configures caching the responses of expensive read
+	// endpoints.
+	ResponseCache *ResponseCache `hcl:"response_cache,block"`
+
+	// Retention configures retention policies that remove old documents from
+	// search or archive stale drafts.
+	Retention *Retention `hcl:"retention,block"`
+
+	// ReviewSLA configures tracking the review SLAs of document types.
+	ReviewSLA *ReviewSLA `hcl:"review_sla,block"`
+
+	// SavedSearchDigests configures sending the subscribers of saved searches
+	// digests of new documents that match them.
+	SavedSearchDigests *SavedSearchDigests `hcl:"saved_search_digests,block"`
+
+	// Search configures the relevance of search results.
+	Search *Search `hcl:"search,block"`
+
 	// Server contains the configuration for the Hermes server.
 	Server *Server `hcl:"server,block"`
 
 	// ShortenerBaseURL is the base URL for building short links.
 	ShortenerBaseURL string `hcl:"shortener_base_url,optional"`
 
+	// SoftDelete configures the recovery and purging of deleted documents and
+	// projects.
+	SoftDelete *SoftDelete `hcl:"soft_delete,block"`
+
 	// SupportLinkURL is the URL for the support documentation.
 	SupportLinkURL string `hcl:"support_link_url,optional"`
 
+	// Tenants partition the deployment into isolated document spaces. Requests
+	// are served for the tenant whose host names include the request's host,
+	// or for the default tenant if there isn't one.
+	Tenants []*Tenant `hcl:"tenant,block"`
+
+	// Tracing configures exporting OpenTelemetry traces of requests, provider
+	// calls, and Kafka records.
+	Tracing *Tracing `hcl:"tracing,block"`
+
 	// SimplifiedMode indicates whether Hermes is running in simplified mode
 	// (zero-config, embedded database, local-first).
 	SimplifiedMode bool
 
-	// DatabaseType specifies which database to use: "postgres" or "sqlite".
-	DatabaseType string
-
-	// DBPath is the path to the SQLite database file (for simplified mode).
-	DBPath string
+	// DatabaseType is the app database: "postgres" or "sqlite". It defaults
+	// to "sqlite" if there is a local_workspace block and no postgres
+	// password, and to "postgres" otherwise. SQLite suits single-user and edge
+	// deployments; the central edge sync API, storage migrations, and semantic
+	// search require PostgreSQL.
+	DatabaseType string `hcl:"database_type,optional"`
+
+	// DBPath is the path of the SQLite database file (default:
+	// "data/hermes.db" in the local workspace).
+	DBPath string `hcl:"db_path,optional"`
+
+	// DevUser is the email address of the user that requests are
+	// authenticated as when running "hermes dev". It can't be set in a config
+	// file.
+	DevUser string
 }
 
 // Datadog configures Hermes to send metrics to Datadog.
@@ -148,9 +238,15 @@ type DocumentType struct {
 	FlightIcon string `hcl:"flight_icon,optional" json:"flightIcon"`
 
 	// Template is the Google file ID for the document template used for this
-	// document type.
+	// document type. It is the "default" template if the document type has
+	// template variants.
 	Template string `hcl:"template"`
 
+	// TemplateVariants are other templates that drafts of this document type
+	// can be created from.
+	// Example: a short RFC template for small changes
+	TemplateVariants []*DocumentTypeTemplateVariant `hcl:"template_variant,block" json:"-"`
+
 	// MoreInfoLink defines a link to more info for the document type.
 	// Example: "When should I create an RFC?"
 	MoreInfoLink *DocumentTypeLink `hcl:"more_info_link,block" json:"moreInfoLink"`
@@ -161,6 +257,72 @@ type DocumentType struct {
 
 	// CustomFields are custom fields specific to the document type.
 	CustomFields []*DocumentTypeCustomField `hcl:"custom_field,block" json:"customFields"`
+
+	// ReviewSLA is how long documents of this type are expected to be in
+	// review before they're approved. Reviews aren't tracked if it's zero.
+	// Example: "120h"
+	ReviewSLA time.Duration `hcl:"review_sla,optional" json:"-"`
+
+	// RequiredApprovals is the number of approvals documents of this type need
+	// before they can be marked approved (default: 0).
+	RequiredApprovals int `hcl:"required_approvals,optional" json:"requiredApprovals,omitempty"`
+
+	// HeaderCustomFields are the names of the custom fields shown in document
+	// headers, in order (default: all custom fields).
+	HeaderCustomFields []string `hcl:"header_custom_fields,optional" json:"-"`
+
+	// ReviewChecklist are the items of the checklist completed on documents of
+	// this type during review (e.g., security review done, legal sign-off, test
+	// plan linked).
+	ReviewChecklist []*DocumentTypeChecklistItem `hcl:"review_checklist_item,block" json:"reviewChecklist,omitempty"`
+}
+
+// DefaultTemplateVariant is the name of the template set by the template
+// attribute of a document type, which drafts are created from by default.
+const DefaultTemplateVariant = "default"
+
+// DocumentTypeTemplateVariant is a named template of a document type.
+type DocumentTypeTemplateVariant struct {
+	// Name identifies the template variant in requests to create drafts.
+	// Example: "short"
+	Name string `hcl:"name,label"`
+
+	// LongName is the name of the template variant displayed in the UI.
+	// Example: "Short RFC"
+	LongName string `hcl:"long_name,optional"`
+
+	// Description is the description of the template variant.
+	Description string `hcl:"description,optional"`
+
+	// Template is the file ID of the template.
+	Template string `hcl:"template"`
+}
+
+// Templates returns the templates of the document type: its default template,
+// named DefaultTemplateVariant, followed by its template variants.
+func (d *DocumentType) Templates() []*DocumentTypeTemplateVariant {
+	ts := []*DocumentTypeTemplateVariant{{
+		Name:        DefaultTemplateVariant,
+		LongName:    d.LongName,
+		Description: d.Description,
+		Template:    d.Template,
+	}}
+	return append(ts, d.TemplateVariants...)
+}
+
+// RequiredChecklistItems returns the review checklist items that must be
+// checked before documents of the type can move to status status.
+func (d *DocumentType) RequiredChecklistItems(
+	status string,
+) []*DocumentTypeChecklistItem {
+	var items []*DocumentTypeChecklistItem
+	for _, item := range d.ReviewChecklist {
+		if item.RequiredFor != "" && (item.RequiredFor == status ||
+			status == "Approved" && item.RequiredFor == "In-Review") {
+			items = append(items, item)
+		}
+	}
+	return items
 }
 
 // DocumentTypeCheck is a document type check, which require acknowledging a
@@ -176,6 +338,27 @@ type DocumentTypeCheck struct {
 	Links []*DocumentTypeLink `hcl:"link,block" json:"links"`
 }
 
+// DocumentTypeChecklistItem is an item of the review checklist of a document
+// type.
+type DocumentTypeChecklistItem struct {
+	// Name is the identifier of the checklist item.
+	// Example: "security-review"
+	Name string `hcl:"name" json:"name"`
+
+	// Label is the displayed text of the checklist item.
+	// Example: "Security review done"
+	Label string `hcl:"label" json:"label"`
+
+	// HelperText contains more details for the checklist item.
+	HelperText string `hcl:"helper_text,optional" json:"helperText,omitempty"`
+
+	// RequiredFor is the document status that the item must be checked before
+	// documents can move to. Valid values are "In-Review" and "Approved". Items
+	// required for "In-Review" are also required for "Approved". The item is
+	// optional if it's empty.
+	RequiredFor string `hcl:"required_for,optional" json:"requiredFor,omitempty"`
+}
+
 type DocumentTypeCustomField struct {
 	// Name is the name of the custom field. This is used as the custom field
 	// identifier.
@@ -184,9 +367,12 @@ type DocumentTypeCustomField struct {
 	// ReadOnly is true if the custom field can only be read.
 	ReadOnly bool `hcl:"read_only,optional" json:"readOnly"`
 
-	// Type is the type of custom field. Valid values are "people", "person", and
-	// "string".
+	// Type is the type of custom field. Valid values are "boolean", "date",
+	// "enum", "number", "people", "person", and "string".
 	Type string `hcl:"type" json:"type"`
+
+	// Options are the allowed values of "enum" custom fields.
+	Options []string `hcl:"options,optional" json:"options,omitempty"`
 }
 
 // DocumentTypeLink is a document type link.
@@ -205,6 +391,79 @@ type Email struct {
 
 	// FromAddress is the email address to send emails from.
 	FromAddress string `hcl:"from_address,optional"`
+
+	// SMTP sends emails through an SMTP server instead of the workspace
+	// provider, e.g., for deployments that don't use Google Workspace.
+	SMTP *EmailSMTP `hcl:"smtp,block"`
+
+	// ApprovalLinks adds single-use links to review request emails that
+	// approve the document or request changes without opening Hermes.
+	ApprovalLinks *EmailApprovalLinks `hcl:"approval_links,block"`
+}
+
+// EmailApprovalLinks configures the approval links of review request emails.
+type EmailApprovalLinks struct {
+	// Enabled adds approval links to review request emails.
+	Enabled bool `hcl:"enabled,optional"`
+
+	// TTL is how long approval links are valid (default: 168h).
+	TTL time.Duration `hcl:"ttl,optional"`
+}
+
+// EmailSMTP configures sending emails through an SMTP server.
+type EmailSMTP struct {
+	// Host is the SMTP server host name.
+	Host string `hcl:"host"`
+
+	// Port is the SMTP server port (default: 587).
+	Port int `hcl:"port,optional"`
+
+	// Username is the username to authenticate with the SMTP server
+	// (optional).
+	Username string `hcl:"username,optional"`
+
+	// Password is the password to authenticate with the SMTP server.
+	Password string `hcl:"password,optional"`
+
+	// TLS is "starttls" to upgrade connections with STARTTLS, "tls" to
+	// connect with TLS (e.g., to port 465), or "none" (default: "starttls").
+	TLS string `hcl:"tls,optional"`
+
+	// InsecureSkipVerify disables verifying the certificate of the SMTP
+	// server.
+	InsecureSkipVerify bool `hcl:"insecure_skip_verify,optional"`
+
+	// MaxConnections is the maximum number of open connections to the SMTP
+	// server, which are reused for later emails (default: 2).
+	MaxConnections int `hcl:"max_connections,optional"`
+
+	// Timeout is the timeout of sending an email (default: 30s).
+	Timeout time.Duration `hcl:"timeout,optional"`
+
+	// IdleTimeout is how long unused connections are kept open (default: 1m).
+	IdleTimeout time.Duration `hcl:"idle_timeout,optional"`
+
+	// DKIM signs emails with DKIM.
+	DKIM *EmailDKIM `hcl:"dkim,block"`
+}
+
+// EmailDKIM configures signing emails with DKIM. The public key must be
+// published in the DNS TXT record "<selector>._domainkey.<domain>".
+type EmailDKIM struct {
+	// Domain is the signing domain, usually the domain of from_address.
+	Domain string `hcl:"domain"`
+
+	// Selector selects the public key of the domain.
+	Selector string `hcl:"selector"`
+
+	// PrivateKeyFile is the path of the PEM-encoded RSA or Ed25519 private
+	// key.
+	PrivateKeyFile string `hcl:"private_key_file"`
+
+	// Headers are the names of the headers that are signed (default: From,
+	// To, Subject, Date, Message-ID, MIME-Version, Content-Type, and
+	// Content-Transfer-Encoding).
+	Headers []string `hcl:"headers,optional"`
 }
 
 // Notifications configures the RFC-087 notification system.
@@ -226,8 +485,20 @@ type Notifications struct {
 	// If not specified, uses embedded templates from internal/notifications/templates.
 	TemplatesPath string `hcl:"templates_path,optional"`
 
+	// OpsBackends is a comma-separated list of backends that make up the ops
+	// channel, used for operational events such as migration progress
+	// (e.g., "audit,ntfy"). Defaults to "audit".
+	OpsBackends string `hcl:"ops_backends,optional"`
+
+	// OpsRecipients is a comma-separated list of email addresses that receive
+	// ops channel notifications through recipient-based backends like mail.
+	OpsRecipients string `hcl:"ops_recipients,optional"`
+
 	// SMTP configuration for mail backend
 	SMTP *SMTPConfig `hcl:"smtp,block"`
+
+	// Producer configures producing notifications to Topic.
+	Producer *KafkaProducer `hcl:"producer,block"`
 }
 
 // SMTPConfig configures SMTP for email notifications.
@@ -278,6 +549,10 @@ type Indexer struct {
 	// simultaneously indexed.
 	MaxParallelDocs int `hcl:"max_parallel_docs,optional"`
 
+	// MetricsAddress is the address (e.g., ":9102") the indexer serves
+	// Prometheus metrics on at /metrics. Metrics aren't served if it's empty.
+	MetricsAddress string `hcl:"metrics_address,optional"`
+
 	// UpdateDocHeaders enables the indexer to automatically update document
 	// headers for Hermes-managed documents with Hermes document metadata.
 	UpdateDocHeaders bool `hcl:"update_doc_headers,optional"`
@@ -307,8 +582,48 @@ type Indexer struct {
 	// BatchSize is the maximum number of outbox entries to process per batch.
 	BatchSize int `hcl:"batch_size,optional"`
 
+	// SearchBatchSize is the number of documents sent to the search provider
+	// per request when reindexing (default: 100).
+	SearchBatchSize int `hcl:"search_batch_size,optional"`
+
+	// SearchBatchConcurrency is the number of batches of documents sent to the
+	// search provider at once when reindexing (default: 4).
+	SearchBatchConcurrency int `hcl:"search_batch_concurrency,optional"`
+
+	// Producer configures producing document revision events from the outbox
+	// to Topic.
+	Producer *KafkaProducer `hcl:"producer,block"`
+
+	// Lanes are the topics the indexer consumes, each with its own consumer
+	// group and priority, so a bulk reindex doesn't delay the indexing of fresh
+	// edits. Topic is only consumed with ConsumerGroup if there are none.
+	Lanes []*IndexerLane `hcl:"lane,block"`
+
 	// Rulesets defines pipeline rulesets for document processing.
 	Rulesets []IndexerRuleset `hcl:"rulesets,block"`
+
+	// Status configures reporting the status of the indexer to the Hermes
+	// API. Status isn't reported if it's not set.
+	Status *WorkerStatus `hcl:"status,block"`
+}
+
+// IndexerLane is a topic consumed by the indexer.
+type IndexerLane struct {
+	// Name identifies the lane (e.g., "bulk-reindex").
+	Name string `hcl:"name,label"`
+
+	// Topic is the Redpanda topic name of the document revision events of the
+	// lane.
+	Topic string `hcl:"topic"`
+
+	// ConsumerGroup is the Kafka consumer group of the lane. The default is
+	// the indexer consumer group followed by "-" and the lane name.
+	ConsumerGroup string `hcl:"consumer_group,optional"`
+
+	// Priority is the priority of the lane. Events of lanes with a higher
+	// priority are processed first, so events of lower priority lanes are
+	// only processed while higher priority lanes have none pending.
+	Priority int `hcl:"priority,optional"`
 }
 
 // IndexerRuleset defines when and how to process a document revision.
@@ -326,6 +641,38 @@ type IndexerRuleset struct {
 	Config map[string]interface{} `hcl:"config,optional"`
 }
 
+// KafkaProducer configures producing records to Kafka/Redpanda.
+type KafkaProducer struct {
+	// Acks is the acknowledgement required of brokers for a record to be
+	// produced: "all" in-sync replicas (default), the partition "leader", or
+	// "none".
+	Acks string `hcl:"acks,optional"`
+
+	// Compression is the compression codec of record batches: "gzip"
+	// (default), "snappy", "lz4", "zstd", or "none".
+	Compression string `hcl:"compression,optional"`
+
+	// Idempotent enables idempotent produce, which keeps records that are
+	// retried from being written twice (default: true). It requires acks
+	// "all".
+	Idempotent *bool `hcl:"idempotent,optional"`
+}
+
+// ToProducerConfig converts the producer config, which may be nil, to the
+// producer package config of producer name.
+func (p *KafkaProducer) ToProducerConfig(brokers []string, name string) producer.Config {
+	cfg := producer.Config{
+		Brokers: brokers,
+		Name:    name,
+	}
+	if p != nil {
+		cfg.Acks = p.Acks
+		cfg.Compression = p.Compression
+		cfg.DisableIdempotentWrite = p.Idempotent != nil && !*p.Idempotent
+	}
+	return cfg
+}
+
 // GoogleWorkspace is the configuration to work with Google Workspace.
 type GoogleWorkspace struct {
 	// Auth contains the authentication configuration for Google Workspace.
@@ -437,6 +784,27 @@ type Postgres struct {
 
 	// Host is the PostgreSQL user name to connect as.
 	User string `hcl:"user"`
+
+	// MaxOpenConns is the maximum number of open connections (default: 25).
+	MaxOpenConns int `hcl:"max_open_conns,optional"`
+
+	// MaxIdleConns is the maximum number of idle connections (default: 10).
+	MaxIdleConns int `hcl:"max_idle_conns,optional"`
+
+	// ConnMaxLifetime is the maximum time a connection is reused before it is
+	// closed (default: 5m).
+	ConnMaxLifetime time.Duration `hcl:"conn_max_lifetime,optional"`
+
+	// ConnMaxIdleTime is the maximum time a connection is idle before it is
+	// closed (default: 10m).
+	ConnMaxIdleTime time.Duration `hcl:"conn_max_idle_time,optional"`
+
+	// ReadReplicaDSN is the DSN of a read replica (ex:
+	// "host=replica.internal port=5432 user=hermes password=... dbname=hermes").
+	// Read-heavy requests like draft and project lists and the reviews
+	// dashboard are served from the replica, using the same pool settings as
+	// the primary.
+	ReadReplicaDSN string `hcl:"read_replica_dsn,optional"`
 }
 
 // Products contain available products.
@@ -466,6 +834,31 @@ type Providers struct {
 	// This enables multi-tenant workspace isolation with different providers per project.
 	// Example: "testing/projects.hcl"
 	ProjectsConfigPath string `hcl:"projects_config_path,optional"`
+
+	// WorkspaceTimeouts configures the time allowed for workspace provider
+	// calls.
+	WorkspaceTimeouts *WorkspaceTimeouts `hcl:"workspace_timeouts,block"`
+}
+
+// WorkspaceTimeouts configures the time allowed for workspace provider calls,
+// so a hung backend can't block requests forever. Calls that time out fail
+// with 504 Gateway Timeout.
+type WorkspaceTimeouts struct {
+	// Default is the time allowed for calls without a timeout below (default:
+	// 1m).
+	Default time.Duration `hcl:"default,optional"`
+
+	// Read is the time allowed for getting documents, content, revisions,
+	// permissions, people, and teams (default: Default).
+	Read time.Duration `hcl:"read,optional"`
+
+	// Write is the time allowed for creating, copying, moving, renaming,
+	// deleting, and sharing documents and folders, and updating content
+	// (default: Default).
+	Write time.Duration `hcl:"write,optional"`
+
+	// Email is the time allowed for sending emails (default: Default).
+	Email time.Duration `hcl:"email,optional"`
 }
 
 // LocalWorkspace configures local filesystem workspace storage.
@@ -495,6 +888,35 @@ type LocalWorkspace struct {
 	SMTP *LocalWorkspaceSMTP `hcl:"smtp,block"`
 }
 
+// Tenant configures a tenant: an isolated document space served on its own
+// host names, with its own documents, document numbers, and search results.
+type Tenant struct {
+	// Name is the unique name of the tenant. "default" is reserved for the
+	// default tenant.
+	Name string `hcl:"name,label"`
+
+	// DisplayName is the name of the tenant shown to users.
+	DisplayName string `hcl:"display_name,optional"`
+
+	// Hostnames are the host names the tenant is served on (e.g.,
+	// "docs.acme.example").
+	Hostnames []string `hcl:"hostnames"`
+
+	// AllowedDomains restricts the tenant to users with email addresses in
+	// these domains (e.g., "acme.example"). All users are allowed if empty.
+	AllowedDomains []string `hcl:"allowed_domains,optional"`
+
+	// SiteAdmins are the email addresses of users who are site admins of the
+	// tenant. They can administer the tenant's documents but not the
+	// deployment (e.g., roles, providers, or service tokens). Site admins in
+	// the authorization block are site admins of every tenant.
+	SiteAdmins []string `hcl:"site_admins,optional"`
+
+	// LocalWorkspace stores the tenant's documents in its own local workspace
+	// instead of the server's workspace provider.
+	LocalWorkspace *LocalWorkspace `hcl:"local_workspace,block"`
+}
+
 // LocalWorkspaceSMTP configures SMTP for the local workspace adapter.
 type LocalWorkspaceSMTP struct {
 	// Enabled enables SMTP email sending.
@@ -561,6 +983,461 @@ type Migration struct {
 	ReadStrategy string `hcl:"read_strategy,optional"`
 }
 
+// Audit configures recording mutating API requests in the audit log.
+type Audit struct {
+	// Enabled indicates whether mutating API requests are recorded.
+	Enabled bool `hcl:"enabled,optional"`
+
+	// Readers are the email addresses of users and groups allowed to query the
+	// audit log (e.g., a compliance team's group).
+	Readers []string `hcl:"readers,optional"`
+}
+
+// Auth configures how browser sessions are kept for deployments that are not
+// behind an authenticating proxy.
+type Auth struct {
+	// SessionSecret enables signed cookie sessions. After users log in with
+	// Dex or OIDC, their session is kept in a cookie signed with this secret
+	// instead of the provider's token, and requests may authenticate with the
+	// cookie as an alternative to an Authorization header. It must be at least
+	// 32 characters.
+	SessionSecret string `hcl:"session_secret,optional"`
+
+	// SessionMaxAge is how long a signed cookie session lasts (default: 168h).
+	SessionMaxAge time.Duration `hcl:"session_max_age,optional"`
+
+	// SecureCookies always sets the Secure flag on session cookies. By
+	// default, it is only set for requests received over TLS, which is wrong
+	// behind a TLS-terminating load balancer.
+	SecureCookies bool `hcl:"secure_cookies,optional"`
+
+	// SameSite is the SameSite attribute of session cookies: "lax" (default),
+	// "strict", or "none". "none" requires secure_cookies.
+	SameSite string `hcl:"same_site,optional"`
+
+	// CSRF requires mutating requests (POST, PUT, PATCH, and DELETE)
+	// authenticated with a session cookie to include the CSRF token from the
+	// hermes_csrf cookie in the X-CSRF-Token header. Requests authenticated
+	// with an Authorization header are not affected. Requires session_secret.
+	CSRF bool `hcl:"csrf,optional"`
+}
+
+// Authorization configures roles used to authorize API requests. Roles are
+// stored in the database and managed by site admins; site admins listed here
+// always have the role, so that the first admins can be bootstrapped.
+type Authorization struct {
+	// SiteAdmins are the email addresses of users who are site admins.
+	SiteAdmins []string `hcl:"site_admins,optional"`
+
+	// ImpersonationMaxDuration is the longest a site admin can impersonate a
+	// user in a single session (default: 1h).
+	ImpersonationMaxDuration time.Duration `hcl:"impersonation_max_duration,optional"`
+}
+
+// Freshness configures the job that scores how current documents are and
+// indexes the score for sorting and faceting in search.
+type Freshness struct {
+	// Enabled indicates whether the freshness job runs.
+	Enabled bool `hcl:"enabled,optional"`
+
+	// Interval is how often documents are scored (default: 1h).
+	Interval time.Duration `hcl:"interval,optional"`
+}
+
+// ReviewSLA configures the job that tracks the reviews of documents of types
+// with a review SLA. It flags the documents whose reviews breach the SLA,
+// indexes their SLA status for faceting in search, and notifies the owners and
+// pending reviewers of the documents when the SLA is about to be or has been
+// breached.
+type ReviewSLA struct {
+	// Enabled indicates whether the review SLA job runs.
+	Enabled bool `hcl:"enabled,optional"`
+
+	// Interval is how often reviews are checked (default: 15m).
+	Interval time.Duration `hcl:"interval,optional"`
+
+	// WarnBefore is how long before the SLA is breached that reviews are at
+	// risk of breaching it (default: 24h).
+	WarnBefore time.Duration `hcl:"warn_before,optional"`
+
+	// NotificationBackends are the notification backends that SLA
+	// notifications are routed to (default: ["mail", "audit"]). Requires
+	// notifications to be enabled.
+	NotificationBackends []string `hcl:"notification_backends,optional"`
+}
+
+// SavedSearchDigests configures the job that sends the subscribers of saved
+// searches digests of the documents published since the last digest that
+// match the searches.
+type SavedSearchDigests struct {
+	// Enabled indicates whether the saved search digest job runs.
+	Enabled bool `hcl:"enabled,optional"`
+
+	// Interval is how often digests are sent (default: 24h).
+	Interval time.Duration `hcl:"interval,optional"`
+
+	// NotificationBackends are the notification backends that digests are
+	// routed to (default: ["mail", "audit"]). Requires notifications to be
+	// enabled.
+	NotificationBackends []string `hcl:"notification_backends,optional"`
+}
+
+// Attachments configures extracting the text of files attached to documents
+// and including it in search. Files can only be attached to documents of
+// workspace providers that store attachments.
+type Attachments struct {
+	// Types are the attachment types whose text is extracted: "pdf", "docx",
+	// "pptx", "text", and "image" (default: all types). Text is only
+	// extracted from images when OCR is configured.
+	Types []string `hcl:"types,optional"`
+
+	// MaxSize is the maximum size of attached files in bytes (default:
+	// 20971520).
+	MaxSize int64 `hcl:"max_size,optional"`
+
+	// MaxTextSize is the maximum size of the text extracted from a file, in
+	// bytes. Longer text is truncated (default: 100000).
+	MaxTextSize int `hcl:"max_text_size,optional"`
+
+	// OCR configures an external OCR API to recognize the text in attached
+	// images.
+	OCR *AttachmentsOCR `hcl:"ocr,block"`
+}
+
+// AttachmentsOCR configures the OCR API that recognizes the text in attached
+// images. The image is sent in a POST request, and the API responds with
+// {"text": "..."}.
+type AttachmentsOCR struct {
+	// URL is the URL of the OCR API.
+	URL string `hcl:"url"`
+
+	// Token authenticates with the OCR API as a bearer token (optional).
+	Token string `hcl:"token,optional"`
+
+	// Timeout is the timeout of OCR API requests (default: 60s).
+	Timeout time.Duration `hcl:"timeout,optional"`
+}
+
+// Retention configures the job that applies retention policies to documents
+// that haven't been modified for the period of a policy, e.g., removing
+// obsolete RFCs from search after three years or archiving drafts that haven't
+// been touched for 180 days. Owners are notified before a policy is applied.
+type Retention struct {
+	// Enabled indicates whether the retention job runs.
+	Enabled bool `hcl:"enabled,optional"`
+
+	// Interval is how often retention policies are applied (default: 24h).
+	Interval time.Duration `hcl:"interval,optional"`
+
+	// NotificationBackends are the notification backends that retention
+	// notices are routed to (default: ["mail", "audit"]). Requires
+	// notifications to be enabled.
+	NotificationBackends []string `hcl:"notification_backends,optional"`
+
+	// Policy defines a retention policy. Policies are applied in order, and a
+	// document is only handled by the first policy it matches.
+	Policy []*RetentionPolicy `hcl:"policy,block"`
+}
+
+// RetentionPolicy is a retention policy.
+type RetentionPolicy struct {
+	// Name identifies the policy.
+	Name string `hcl:"name,label"`
+
+	// DocumentTypes are the names of the document types the policy applies
+	// to (default: all document types).
+	DocumentTypes []string `hcl:"document_types,optional"`
+
+	// Products are the names of the products the policy applies to (default:
+	// all products).
+	Products []string `hcl:"products,optional"`
+
+	// Statuses are the statuses of the documents the policy applies to
+	// ("WIP", "In-Review", "Approved", or "Obsolete").
+	Statuses []string `hcl:"statuses"`
+
+	// After is how long after documents were last modified that the policy
+	// is applied.
+	// Example: "26280h"
+	After time.Duration `hcl:"after"`
+
+	// Action is what the policy does to documents: "remove_from_search"
+	// removes them from search, and "archive" moves drafts to the trash, from
+	// where site admins can restore them until they are purged.
+	Action string `hcl:"action"`
+
+	// Notice is how long before the policy is applied that document owners
+	// are notified (default: 0, owners aren't notified).
+	// Example: "336h"
+	Notice time.Duration `hcl:"notice,optional"`
+}
+
+// Policies returns the retention policies.
+func (r *Retention) Policies() ([]retention.Policy, error) {
+	var policies []retention.Policy
+	for _, p := range r.Policy {
+		policy := retention.Policy{
+			Name:          p.Name,
+			DocumentTypes: p.DocumentTypes,
+			Products:      p.Products,
+			After:         p.After,
+			Action:        retention.Action(p.Action),
+			Notice:        p.Notice,
+		}
+		for _, s := range p.Statuses {
+			status, err := retention.ParseStatus(s)
+			if err != nil {
+				return nil, fmt.Errorf("policy %q: %w", p.Name, err)
+			}
+			policy.Statuses = append(policy.Statuses, status)
+		}
+		policies = append(policies, policy)
+	}
+	return policies, nil
+}
+
+// Search configures the relevance of search results.
+type Search struct {
+	// Ranking tunes how the results of an index are ranked. Changes to the
+	// rankings of Algolia and Meilisearch indexes are applied to their
+	// settings when the server starts.
+	Ranking []*SearchRanking `hcl:"ranking,block"`
+}
+
+// SearchRanking tunes how the results of an index are ranked.
+type SearchRanking struct {
+	// Index is the index the ranking applies to: "docs", "drafts", or
+	// "projects".
+	Index string `hcl:"index,label"`
+
+	// AttributeWeights are the relative weights of matches in searchable
+	// attributes (e.g., { title = 10, summary = 4, content = 1 }). Attributes
+	// with a weight of 0 aren't searched. Algolia and Meilisearch search
+	// attributes in order of weight.
+	AttributeWeights map[string]float64 `hcl:"attribute_weights,optional"`
+
+	// RecencyDecay is the half-life of the relevance of documents (e.g.,
+	// "4380h" halves the relevance of documents modified six months ago).
+	// Algolia and Meilisearch break ties in favor of recently modified
+	// documents instead.
+	RecencyDecay time.Duration `hcl:"recency_decay,optional"`
+
+	// ExactMatchBoost boosts the relevance of results that match the query
+	// exactly, as a phrase (default: 1). Algolia and Meilisearch rank exact
+	// matches first if it's greater than 1.
+	ExactMatchBoost float64 `hcl:"exact_match_boost,optional"`
+}
+
+// Rankings returns the configured rankings, keyed by index.
+func (s *Search) Rankings() search.Rankings {
+	if s == nil || len(s.Ranking) == 0 {
+		return nil
+	}
+	rankings := make(search.Rankings, len(s.Ranking))
+	for _, r := range s.Ranking {
+		rankings[r.Index] = search.Ranking{
+			AttributeWeights: r.AttributeWeights,
+			RecencyDecay:     r.RecencyDecay,
+			ExactMatchBoost:  r.ExactMatchBoost,
+		}
+	}
+	return rankings
+}
+
+// Activity configures the recording and retention of user activity (views,
+// edits, and reviews of documents) shown in the dashboard and document
+// activity feeds.
+type Activity struct {
+	// Retention is how long user activity is kept (default: 2160h).
+	Retention time.Duration `hcl:"retention,optional"`
+
+	// PruneInterval is how often user activity past the retention period is
+	// deleted (default: 24h).
+	PruneInterval time.Duration `hcl:"prune_interval,optional"`
+	// StatsMinReaders is the minimum number of readers that document view
+	// stats are reported for. Views of fewer readers are aggregated so readers
+	// can't be told apart (default: 3).
+	StatsMinReaders int `hcl:"stats_min_readers,optional"`
+}
+
+// Jobs configures the runner of background jobs queued in the database, such
+// as indexing documents and sending emails after API requests.
+type Jobs struct {
+	// MaxAttempts is the number of times a job is started before it fails
+	// (default: 5).
+	MaxAttempts int `hcl:"max_attempts,optional"`
+
+	// PollInterval is how often the queue is polled for due jobs (default:
+	// 1s).
+	PollInterval time.Duration `hcl:"poll_interval,optional"`
+
+	// Retention is how long finished jobs are kept (default: 168h).
+	Retention time.Duration `hcl:"retention,optional"`
+
+	// Workers is the number of jobs run concurrently (default: 4).
+	Workers int `hcl:"workers,optional"`
+}
+
+// ResponseCache configures caching the responses of people and group search,
+// search facets, and product and document type lists. Responses are cached for
+// a short time, per user where they depend on what the user can see.
+type ResponseCache struct {
+	// Enabled enables the response cache.
+	Enabled bool `hcl:"enabled,optional"`
+
+	// TTL is how long responses are cached (default: 30s).
+	TTL time.Duration `hcl:"ttl,optional"`
+
+	// MaxEntries is the maximum number of responses cached in memory
+	// (default: 10000). It's ignored when Redis is configured.
+	MaxEntries int `hcl:"max_entries,optional"`
+
+	// Redis caches responses in Redis instead of memory, so the cache is
+	// shared by all servers.
+	Redis *ResponseCacheRedis `hcl:"redis,block"`
+}
+
+// ResponseCacheRedis configures the Redis server of the response cache.
+type ResponseCacheRedis struct {
+	// Address is the host and port of the Redis server (e.g.,
+	// "localhost:6379").
+	Address string `hcl:"address"`
+
+	// Password authenticates with the Redis server (optional).
+	Password string `hcl:"password,optional"`
+
+	// DB is the number of the Redis database (default: 0).
+	DB int `hcl:"db,optional"`
+}
+
+// SoftDelete configures the recovery and purging of deleted documents and
+// projects. Deleted drafts and projects can be restored by site admins until
+// they are purged.
+type SoftDelete struct {
+	// RecoveryWindow is how long deleted drafts and projects can be restored
+	// before they are purged (default: 720h).
+	RecoveryWindow time.Duration `hcl:"recovery_window,optional"`
+
+	// PurgeInterval is how often deleted drafts and projects past the
+	// recovery window are purged (default: 24h).
+	PurgeInterval time.Duration `hcl:"purge_interval,optional"`
+}
+
+// Tracing configures exporting OpenTelemetry traces to an OTLP/HTTP collector.
+type Tracing struct {
+	// Enabled enables tracing.
+	Enabled bool `hcl:"enabled,optional"`
+
+	// Endpoint is the host and port of the OTLP/HTTP collector (default:
+	// "localhost:4318").
+	Endpoint string `hcl:"endpoint,optional"`
+
+	// Insecure exports traces over HTTP instead of HTTPS.
+	Insecure bool `hcl:"insecure,optional"`
+
+	// Headers are sent with each export request (e.g., an API key).
+	Headers map[string]string `hcl:"headers,optional"`
+
+	// SampleRatio is the fraction of traces that are sampled, from 0 to 1
+	// (default: 1). Traces continued from other services follow their
+	// sampling decision.
+	SampleRatio *float64 `hcl:"sample_ratio,optional"`
+
+	// ServiceName overrides the service name of the traces (default: the
+	// binary name, e.g., "hermes" or "hermes-indexer").
+	ServiceName string `hcl:"service_name,optional"`
+}
+
+// ToTracingConfig converts the tracing config to the tracing package config,
+// using serviceName unless the service name is overridden.
+func (t *Tracing) ToTracingConfig(serviceName string) tracing.Config {
+	cfg := tracing.Config{
+		ServiceName: serviceName,
+		Endpoint:    t.Endpoint,
+		Insecure:    t.Insecure,
+		Headers:     t.Headers,
+		SampleRatio: 1,
+	}
+	if t.ServiceName != "" {
+		cfg.ServiceName = t.ServiceName
+	}
+	if t.SampleRatio != nil {
+		cfg.SampleRatio = *t.SampleRatio
+	}
+	return cfg
+}
+
+// WorkerStatus configures reporting the status of a pipeline worker (an
+// indexer or notifier) to the Hermes API, where site admins see it at
+// /api/v2/admin/workers.
+type WorkerStatus struct {
+	// URL is the base URL of the Hermes API (e.g.,
+	// "https://hermes.example.com").
+	URL string `hcl:"url"`
+
+	// Token is an "api" service token with the "workers" scope.
+	Token string `hcl:"token"`
+
+	// Name is the unique name of the worker (default: the hostname).
+	Name string `hcl:"name,optional"`
+
+	// Interval is how often the status is reported (default: "30s").
+	Interval time.Duration `hcl:"interval,optional"`
+}
+
+// LinkCheck configures the scheduled job that detects broken outbound links in
+// document content.
+type LinkCheck struct {
+	// Enabled indicates whether the link check job runs.
+	Enabled bool `hcl:"enabled,optional"`
+
+	// Interval is how often all documents are checked (default: 24h).
+	Interval time.Duration `hcl:"interval,optional"`
+
+	// Timeout is the timeout for each external link request (default: 10s).
+	Timeout time.Duration `hcl:"timeout,optional"`
+
+	// MaxConcurrency is the maximum number of concurrent external link
+	// requests (default: 5).
+	MaxConcurrency int `hcl:"max_concurrency,optional"`
+
+	// MaxExternalPerRun is the maximum number of external links requested per
+	// run. Zero means no limit.
+	MaxExternalPerRun int `hcl:"max_external_per_run,optional"`
+
+	// MaxRequestsPerSecond limits the rate of external link requests. Zero
+	// means no limit.
+	MaxRequestsPerSecond int `hcl:"max_requests_per_second,optional"`
+}
+
+// Integrity configures the scheduled job that recomputes the hashes of the
+// content and attached files of documents stored by workspace providers
+// (including the storage providers that documents were migrated to), compares
+// them to the hashes recorded when they were written, and alerts the ops
+// notification channel about silent corruption.
+type Integrity struct {
+	// Enabled indicates whether the integrity job runs.
+	Enabled bool `hcl:"enabled,optional"`
+
+	// Interval is how often documents are checked (default: 24h).
+	Interval time.Duration `hcl:"interval,optional"`
+
+	// MaxDocumentsPerRun is the maximum number of documents checked per run,
+	// least recently checked first. Zero means no limit.
+	MaxDocumentsPerRun int `hcl:"max_documents_per_run,optional"`
+}
+
+// PeopleDirectory configures caching the workspace people directory in the
+// database. When enabled, people search is served from the cache and falls back
+// to the workspace provider.
+type PeopleDirectory struct {
+	// Enabled indicates whether the directory is synced and used for search.
+	Enabled bool `hcl:"enabled,optional"`
+
+	// SyncInterval is how often the directory is synced (default: 1h).
+	SyncInterval time.Duration `hcl:"sync_interval,optional"`
+}
+
 // Ollama configures Hermes to work with Ollama for local AI summarization.
 type Ollama struct {
 	// URL is the Ollama API URL (e.g., "http://localhost:11434").
@@ -577,22 +1454,48 @@ type Ollama struct {
 type Server struct {
 	// Addr is the address to bind to for listening.
 	Addr string `hcl:"addr,optional"`
+
+	// DocumentLocationCacheTTL is how long the storage provider that serves a
+	// document is cached (default: 1m). It bounds how long a server keeps
+	// serving a document migrated by another server from its old provider.
+	DocumentLocationCacheTTL time.Duration `hcl:"document_location_cache_ttl,optional"`
+
+	// ShutdownDrainDelay is how long the server reports that it isn't ready
+	// before it stops accepting connections on shutdown, so load balancers
+	// stop sending it requests first (default: 0s).
+	ShutdownDrainDelay time.Duration `hcl:"shutdown_drain_delay,optional"`
+
+	// ShutdownTimeout is the time allowed for shutdown, including draining
+	// in-flight requests and stopping background jobs (default: 30s).
+	ShutdownTimeout time.Duration `hcl:"shutdown_timeout,optional"`
 }
 
 // NewConfig parses an HCL configuration file and returns the Hermes config.
 // If profile is non-empty, loads config from profile block with that name.
 // If profile is empty and file has profiles, uses "default" profile.
 // If profile is empty and file has no profiles, loads from root level (backward compatible).
+// Warnings about unknown settings are logged with the default logger; use
+// LoadConfig to handle them.
 func NewConfig(filename string, profile string) (*Config, error) {
+	c, warnings, err := LoadConfig(filename, profile)
+	for _, w := range warnings {
+		hclog.Default().Warn("ignoring config setting", "warning", w)
+	}
+	return c, err
+}
+
+// LoadConfig is like NewConfig, but returns warnings about unknown settings
+// instead of logging them.
+func LoadConfig(filename string, profile string) (*Config, []string, error) {
 	// Read and parse file to check if it has profiles
 	src, err := os.ReadFile(filename)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read config file: %w", err)
+		return nil, nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
 	file, diags := hclsyntax.ParseConfig(src, filename, hcl.Pos{Line: 1, Column: 1})
 	if diags.HasErrors() {
-		return nil, fmt.Errorf("failed to parse config file: %w", diags)
+		return nil, nil, fmt.Errorf("failed to parse config file: %w", diags)
 	}
 
 	// Check if file has any profile blocks
@@ -607,34 +1510,13 @@ func NewConfig(filename string, profile string) (*Config, error) {
 
 	// If no profiles in file and no profile requested, use root-level config (backward compatible)
 	if !hasProfiles && profile == "" {
-		c := &Config{
-			Algolia:         &algoliaadapter.Config{},
-			Email:           &Email{},
-			FeatureFlags:    &FeatureFlags{},
-			GoogleWorkspace: &GoogleWorkspace{},
-			Indexer:         &Indexer{},
-			Okta:            &oktaadapter.Config{},
-			Server:          &Server{},
-		}
-		err := hclsimple.DecodeFile(filename, nil, c)
+		c := newDecodeTarget()
+		warnings, err := decode.Body(body, filepath.Dir(filename), c)
 		if err != nil {
-			return nil, fmt.Errorf("failed to load configuration: %w", err)
+			return nil, warnings, fmt.Errorf("failed to load configuration: %w", err)
 		}
-
-		// Detect database type based on config
-		// If LocalWorkspace exists and no valid Postgres config, use SQLite
-		if c.LocalWorkspace != nil && (c.Postgres == nil || c.Postgres.Password == "") {
-			c.DatabaseType = "sqlite"
-			c.SimplifiedMode = true
-			// Set default DB path if not set
-			if c.DBPath == "" {
-				c.DBPath = filepath.Join(c.LocalWorkspace.BasePath, "data", "hermes.db")
-			}
-		} else if c.Postgres != nil && c.Postgres.Host != "" {
-			c.DatabaseType = "postgres"
-		}
-
-		return c, nil
+		c.detectDatabaseType()
+		return c, warnings, nil
 	}
 
 	// File has profiles - select which one to use
@@ -647,38 +1529,94 @@ func NewConfig(filename string, profile string) (*Config, error) {
 	for _, block := range body.Blocks {
 		if block.Type == "profile" && len(block.Labels) > 0 && block.Labels[0] == selectedProfile {
 			// Found the profile, decode its body into Config
-			c := &Config{
-				Algolia:         &algoliaadapter.Config{},
-				Email:           &Email{},
-				FeatureFlags:    &FeatureFlags{},
-				GoogleWorkspace: &GoogleWorkspace{},
-				Indexer:         &Indexer{},
-				Okta:            &oktaadapter.Config{},
-				Server:          &Server{},
-			}
-			err := gohcl.DecodeBody(block.Body, nil, c)
+			c := newDecodeTarget()
+			warnings, err := decode.Body(block.Body, filepath.Dir(filename), c)
 			if err != nil {
-				return nil, fmt.Errorf("failed to decode profile %q: %w", selectedProfile, err)
+				return nil, warnings, fmt.Errorf("failed to decode profile %q: %w", selectedProfile, err)
 			}
+			c.detectDatabaseType()
+			return c, warnings, nil
+		}
+	}
 
-			// Detect database type based on config
-			// If LocalWorkspace exists and no valid Postgres config, use SQLite
-			if c.LocalWorkspace != nil && (c.Postgres == nil || c.Postgres.Password == "") {
-				c.DatabaseType = "sqlite"
-				c.SimplifiedMode = true
-				// Set default DB path if not set
-				if c.DBPath == "" {
-					c.DBPath = filepath.Join(c.LocalWorkspace.BasePath, "data", "hermes.db")
-				}
-			} else if c.Postgres != nil && c.Postgres.Host != "" {
-				c.DatabaseType = "postgres"
-			}
+	return nil, nil, fmt.Errorf("profile %q not found in configuration", selectedProfile)
+}
 
-			return c, nil
+// newDecodeTarget returns the Config that a configuration file is decoded
+// into, with the blocks that are always configured.
+func newDecodeTarget() *Config {
+	return &Config{
+		Algolia:         &algoliaadapter.Config{},
+		Email:           &Email{},
+		FeatureFlags:    &FeatureFlags{},
+		GoogleWorkspace: &GoogleWorkspace{},
+		Indexer:         &Indexer{},
+		Okta:            &oktaadapter.Config{},
+		Server:          &Server{},
+	}
+}
+
+// detectDatabaseType sets the database type based on the configuration if
+// it isn't set. If LocalWorkspace exists and there's no valid Postgres
+// configuration, SQLite is used in simplified mode.
+func (c *Config) detectDatabaseType() {
+	switch {
+	case c.DatabaseType != "":
+	case c.LocalWorkspace != nil && (c.Postgres == nil || c.Postgres.Password == ""):
+		c.DatabaseType = "sqlite"
+		c.SimplifiedMode = true
+	case c.Postgres != nil && c.Postgres.Host != "":
+		c.DatabaseType = "postgres"
+	}
+
+	// Set default DB path if not set
+	if c.DatabaseType == "sqlite" && c.DBPath == "" {
+		basePath := "."
+		if c.LocalWorkspace != nil {
+			basePath = c.LocalWorkspace.BasePath
+		}
+		c.DBPath = filepath.Join(basePath, "data", "hermes.db")
+	}
+}
+
+// WorkspaceFolders returns the folders that documents are stored in by
+// workspace provider provider ("google" or "local").
+func (c *Config) WorkspaceFolders(provider string) workspace.Folders {
+	switch provider {
+	case "local":
+		if c.LocalWorkspace == nil {
+			return workspace.Folders{}
+		}
+		return workspace.Folders{
+			Drafts:    c.LocalWorkspace.DraftsPath,
+			Published: c.LocalWorkspace.DocsPath,
+		}
+	default:
+		if c.GoogleWorkspace == nil {
+			return workspace.Folders{}
+		}
+		return workspace.Folders{
+			Drafts:    c.GoogleWorkspace.DraftsFolder,
+			Published: c.GoogleWorkspace.DocsFolder,
+			Shortcuts: c.GoogleWorkspace.ShortcutsFolder,
 		}
 	}
+}
 
-	return nil, fmt.Errorf("profile %q not found in configuration", selectedProfile)
+// WorkspaceDomain returns the domain of the users of workspace provider
+// provider ("google" or "local").
+func (c *Config) WorkspaceDomain(provider string) string {
+	switch provider {
+	case "local":
+		if c.LocalWorkspace != nil {
+			return c.LocalWorkspace.Domain
+		}
+	default:
+		if c.GoogleWorkspace != nil {
+			return c.GoogleWorkspace.Domain
+		}
+	}
+	return ""
 }
 
 // ToLocalAdapterConfig converts LocalWorkspace config to local adapter config.
@@ -0,0 +1,327 @@
+// Package decode decodes HCL configuration files. It's the loader shared by
+// the Hermes binaries (the server, hermes-indexer, and hermes-notify), so they
+// support the same functions, defaults, validation, and warnings.
+package decode
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/config/hclfunc"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Validator is implemented by configuration blocks that validate their
+// settings.
+type Validator interface {
+	Validate() error
+}
+
+// Defaulter is implemented by configuration blocks that set defaults for
+// settings that aren't configured.
+type Defaulter interface {
+	ApplyDefaults()
+}
+
+// File decodes the HCL configuration file filename into target, which must be
+// a pointer to a struct with hcl tags:
+//   - The functions in package hclfunc (env, file, and vault) are available.
+//   - Unknown settings and blocks are ignored and returned as warnings, so a
+//     typo or a setting from a newer version doesn't stop a process from
+//     starting.
+//   - Defaults are applied to every block that implements Defaulter.
+//   - Every block that implements Validator is validated.
+func File(filename string, target any) (warnings []string, err error) {
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	file, diags := hclsyntax.ParseConfig(
+		src, filename, hcl.Pos{Line: 1, Column: 1})
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("failed to parse config file: %w", diags)
+	}
+
+	return Body(file.Body, filepath.Dir(filename), target)
+}
+
+// Body decodes body into target like File. Relative paths in functions are
+// resolved from baseDir.
+func Body(body hcl.Body, baseDir string, target any) ([]string, error) {
+	return decodeBody(body, hclfunc.EvalContext(baseDir), target)
+}
+
+// Untrusted decodes HCL src, e.g., uploaded to the server, into target like
+// File, except that functions aren't available, so src can't read the
+// environment, files, or secrets of the server.
+func Untrusted(src []byte, filename string, target any) ([]string, error) {
+	file, diags := hclsyntax.ParseConfig(
+		src, filename, hcl.Pos{Line: 1, Column: 1})
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	return decodeBody(file.Body, nil, target)
+}
+
+func decodeBody(
+	body hcl.Body, ctx *hcl.EvalContext, target any,
+) ([]string, error) {
+	unknown := removeUnknownSettings(body, reflect.TypeOf(target), "")
+	sort.Slice(unknown, func(i, j int) bool {
+		return unknown[i].rng.Start.Byte < unknown[j].rng.Start.Byte
+	})
+	warnings := make([]string, 0, len(unknown))
+	for _, u := range unknown {
+		warnings = append(warnings, fmt.Sprintf("%s: %s", u.rng, u.msg))
+	}
+
+	if diags := parseDurations(body, reflect.TypeOf(target)); diags.HasErrors() {
+		return warnings, diags
+	}
+	if diags := gohcl.DecodeBody(body, ctx, target); diags.HasErrors() {
+		return warnings, diags
+	}
+
+	ApplyDefaults(target)
+	if err := Validate(target); err != nil {
+		return warnings, err
+	}
+
+	return warnings, nil
+}
+
+// ApplyDefaults applies defaults to every block of the configuration in
+// target that implements Defaulter, parents before their children.
+func ApplyDefaults(target any) {
+	_ = walkBlocks(reflect.ValueOf(target), "", func(_ string, v any) error {
+		if d, ok := v.(Defaulter); ok {
+			d.ApplyDefaults()
+		}
+		return nil
+	})
+}
+
+// Validate validates every block of the configuration in target that
+// implements Validator, and returns all of the errors.
+func Validate(target any) error {
+	var errs []error
+	_ = walkBlocks(reflect.ValueOf(target), "", func(path string, v any) error {
+		if val, ok := v.(Validator); ok {
+			if err := val.Validate(); err != nil {
+				if path == "" {
+					errs = append(errs, err)
+				} else {
+					errs = append(errs, fmt.Errorf("%s: %w", path, err))
+				}
+			}
+		}
+		return nil
+	})
+	return errors.Join(errs...)
+}
+
+// walkBlocks calls fn with the dotted path and value of each configured
+// block in v, starting with v itself.
+func walkBlocks(v reflect.Value, path string, fn func(string, any) error) error {
+	if v.Kind() != reflect.Pointer || v.IsNil() ||
+		v.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	if err := fn(path, v.Interface()); err != nil {
+		return err
+	}
+
+	s := v.Elem()
+	t := s.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name, kind := hclTag(t.Field(i))
+		if kind != "block" {
+			continue
+		}
+		if path != "" {
+			name = path + "." + name
+		}
+
+		f := s.Field(i)
+		switch f.Kind() {
+		case reflect.Pointer:
+			if err := walkBlocks(f, name, fn); err != nil {
+				return err
+			}
+		case reflect.Struct:
+			if err := walkBlocks(f.Addr(), name, fn); err != nil {
+				return err
+			}
+		case reflect.Slice:
+			for j := 0; j < f.Len(); j++ {
+				e := f.Index(j)
+				if e.Kind() == reflect.Struct {
+					e = e.Addr()
+				}
+				if err := walkBlocks(
+					e, fmt.Sprintf("%s[%d]", name, j), fn,
+				); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// unknownSetting is an attribute or block that isn't decoded.
+type unknownSetting struct {
+	rng hcl.Range
+	msg string
+}
+
+// removeUnknownSettings removes the attributes and blocks in body that aren't
+// decoded into type t, and returns them.
+func removeUnknownSettings(body hcl.Body, t reflect.Type, path string) []unknownSetting {
+	b, ok := body.(*hclsyntax.Body)
+	if !ok {
+		return nil
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	attrs := map[string]bool{}
+	blocks := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		name, kind := hclTag(t.Field(i))
+		switch kind {
+		case "attr", "optional", "":
+			if name != "" {
+				attrs[name] = true
+			}
+		case "block":
+			blocks[name] = t.Field(i).Type
+		case "remain":
+			// Everything is decoded.
+			return nil
+		}
+	}
+
+	var unknown []unknownSetting
+	for name, attr := range b.Attributes {
+		if !attrs[name] {
+			unknown = append(unknown, unknownSetting{attr.SrcRange,
+				fmt.Sprintf("unknown setting %q", joinPath(path, name))})
+			delete(b.Attributes, name)
+		}
+	}
+
+	known := b.Blocks[:0]
+	for _, block := range b.Blocks {
+		bt, ok := blocks[block.Type]
+		if !ok {
+			unknown = append(unknown, unknownSetting{block.TypeRange,
+				fmt.Sprintf("unknown block %q", joinPath(path, block.Type))})
+			continue
+		}
+		for bt.Kind() == reflect.Pointer || bt.Kind() == reflect.Slice {
+			bt = bt.Elem()
+		}
+		unknown = append(unknown, removeUnknownSettings(
+			block.Body, bt, joinPath(path, block.Type))...)
+		known = append(known, block)
+	}
+	b.Blocks = known
+
+	return unknown
+}
+
+// parseDurations replaces the duration strings (e.g., "10s") of the
+// time.Duration settings in body, which is decoded into type t, with their
+// number of nanoseconds, which is how they are decoded. Settings that aren't
+// literal strings, like function calls, are left as they are.
+func parseDurations(body hcl.Body, t reflect.Type) hcl.Diagnostics {
+	b, ok := body.(*hclsyntax.Body)
+	if !ok {
+		return nil
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var diags hcl.Diagnostics
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, kind := hclTag(f)
+		switch kind {
+		case "attr", "optional", "":
+			attr, ok := b.Attributes[name]
+			if name == "" || !ok || f.Type != durationType {
+				continue
+			}
+			v, vdiags := attr.Expr.Value(nil)
+			if vdiags.HasErrors() || v.IsNull() || v.Type() != cty.String {
+				continue
+			}
+			d, err := time.ParseDuration(v.AsString())
+			if err != nil {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid duration",
+					Detail: fmt.Sprintf(
+						"%s must be a duration like \"10s\" or \"24h\": %s",
+						name, err),
+					Subject: attr.Expr.Range().Ptr(),
+				})
+				continue
+			}
+			attr.Expr = &hclsyntax.LiteralValueExpr{
+				Val:      cty.NumberIntVal(int64(d)),
+				SrcRange: attr.Expr.Range(),
+			}
+		case "block":
+			bt := f.Type
+			for bt.Kind() == reflect.Pointer || bt.Kind() == reflect.Slice {
+				bt = bt.Elem()
+			}
+			for _, block := range b.Blocks {
+				if block.Type == name {
+					diags = append(diags, parseDurations(block.Body, bt)...)
+				}
+			}
+		}
+	}
+	return diags
+}
+
+// hclTag returns the name and kind ("attr", "optional", "block", "label", or
+// "remain") from the hcl tag of a struct field. The kind of a required
+// attribute is "". The name is empty if the field has no hcl tag.
+func hclTag(f reflect.StructField) (name, kind string) {
+	tag, ok := f.Tag.Lookup("hcl")
+	if !ok {
+		return "", "-"
+	}
+	name, kind, _ = strings.Cut(tag, ",")
+	return name, kind
+}
+
+// joinPath returns name prefixed by the dotted block path.
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,213 @@
+package decode
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testConfig struct {
+	Name     string          `hcl:"name,optional"`
+	Server   *testServer     `hcl:"server,block"`
+	Backends []*testBackend  `hcl:"backend,block"`
+	Extra    map[string]bool `hcl:"extra,optional"`
+}
+
+func (c *testConfig) ApplyDefaults() {
+	if c.Name == "" {
+		c.Name = "default-name"
+	}
+}
+
+type testServer struct {
+	Addr string `hcl:"addr,optional"`
+	Port int    `hcl:"port,optional"`
+}
+
+func (s *testServer) ApplyDefaults() {
+	if s.Port == 0 {
+		s.Port = 8000
+	}
+}
+
+func (s *testServer) Validate() error {
+	if s.Port < 0 {
+		return fmt.Errorf("port must not be negative")
+	}
+	return nil
+}
+
+type testBackend struct {
+	Kind    string `hcl:"kind,label"`
+	Enabled bool   `hcl:"enabled,optional"`
+	Topic   string `hcl:"topic,optional"`
+}
+
+func (b *testBackend) Validate() error {
+	if b.Enabled && b.Topic == "" {
+		return fmt.Errorf("topic is required")
+	}
+	return nil
+}
+
+func decodeString(t *testing.T, src string) (*testConfig, []string, error) {
+	t.Helper()
+
+	filename := filepath.Join(t.TempDir(), "config.hcl")
+	require.NoError(t, os.WriteFile(filename, []byte(src), 0o600))
+
+	var c testConfig
+	warnings, err := File(filename, &c)
+	return &c, warnings, err
+}
+
+func TestFile(t *testing.T) {
+	t.Setenv("DECODE_TEST_ADDR", "0.0.0.0")
+
+	c, warnings, err := decodeString(t, `
+server {
+  addr = env("DECODE_TEST_ADDR")
+}
+
+backend "ntfy" {
+  enabled = true
+  topic   = "hermes"
+}
+`)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+	assert.Equal(t, "default-name", c.Name)
+	assert.Equal(t, "0.0.0.0", c.Server.Addr)
+	assert.Equal(t, 8000, c.Server.Port)
+	require.Len(t, c.Backends, 1)
+	assert.Equal(t, "ntfy", c.Backends[0].Kind)
+}
+
+func TestFileUnknownSettings(t *testing.T) {
+	c, warnings, err := decodeString(t, `
+name  = "hermes"
+nmae  = "typo"
+
+server {
+  addr    = "localhost"
+  timeout = "10s"
+}
+
+backend "mail" {
+  smtp_host = "localhost"
+}
+
+unknown_block {
+  setting = true
+}
+`)
+	require.NoError(t, err)
+	assert.Equal(t, "hermes", c.Name)
+	assert.Equal(t, "localhost", c.Server.Addr)
+	require.Len(t, c.Backends, 1)
+
+	require.Len(t, warnings, 4)
+	assert.Contains(t, warnings[0], `unknown setting "nmae"`)
+	assert.Contains(t, warnings[1], `unknown setting "server.timeout"`)
+	assert.Contains(t, warnings[2], `unknown setting "backend.smtp_host"`)
+	assert.Contains(t, warnings[3], `unknown block "unknown_block"`)
+	assert.Contains(t, warnings[0], "config.hcl:3,")
+}
+
+func TestFileValidation(t *testing.T) {
+	_, _, err := decodeString(t, `
+server {
+  port = -1
+}
+
+backend "mail" {}
+
+backend "ntfy" {
+  enabled = true
+}
+`)
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "server: port must not be negative")
+	assert.ErrorContains(t, err, "backend[1]: topic is required")
+	assert.NotContains(t, err.Error(), "backend[0]")
+}
+
+func TestFileErrors(t *testing.T) {
+	_, _, err := decodeString(t, `name = `)
+	assert.ErrorContains(t, err, "failed to parse config file")
+
+	_, _, err = decodeString(t, `name = env("DECODE_TEST_UNSET")`)
+	assert.ErrorContains(t, err, "is not set")
+
+	var c testConfig
+	_, err = File(filepath.Join(t.TempDir(), "missing.hcl"), &c)
+	assert.ErrorContains(t, err, "failed to read config file")
+}
+
+func TestBodyDurations(t *testing.T) {
+	type block struct {
+		Timeout time.Duration `hcl:"timeout,optional"`
+	}
+	type durations struct {
+		Interval time.Duration `hcl:"interval,optional"`
+		Blocks   []*block      `hcl:"block,block"`
+	}
+
+	decode := func(src string) (*durations, error) {
+		f, diags := hclsyntax.ParseConfig(
+			[]byte(src), "config.hcl", hcl.Pos{Line: 1, Column: 1})
+		require.False(t, diags.HasErrors(), diags)
+		var c durations
+		_, err := Body(f.Body, t.TempDir(), &c)
+		return &c, err
+	}
+
+	c, err := decode(`
+interval = "90m"
+
+block {
+  timeout = "10s"
+}
+
+block {
+  timeout = 1000000000
+}
+`)
+	require.NoError(t, err)
+	assert.Equal(t, 90*time.Minute, c.Interval)
+	require.Len(t, c.Blocks, 2)
+	assert.Equal(t, 10*time.Second, c.Blocks[0].Timeout)
+	assert.Equal(t, time.Second, c.Blocks[1].Timeout)
+
+	_, err = decode(`interval = "soon"`)
+	assert.ErrorContains(t, err, "Invalid duration")
+}
+
+func TestUntrusted(t *testing.T) {
+	type untrusted struct {
+		Name     string        `hcl:"name,optional"`
+		Interval time.Duration `hcl:"interval,optional"`
+	}
+
+	var c untrusted
+	warnings, err := Untrusted(
+		[]byte("name = \"hermes\"\ninterval = \"1h\"\nunknown = 1\n"),
+		"upload.hcl", &c)
+	require.NoError(t, err)
+	assert.Equal(t, "hermes", c.Name)
+	assert.Equal(t, time.Hour, c.Interval)
+	assert.Len(t, warnings, 1)
+
+	// Functions aren't available.
+	t.Setenv("HERMES_TEST_SECRET", "secret")
+	_, err = Untrusted(
+		[]byte(`name = env("HERMES_TEST_SECRET")`), "upload.hcl", &c)
+	assert.Error(t, err)
+}
@@ -0,0 +1,298 @@
+package decode
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// durationType is the type of time.Duration settings, which are set with
+// duration strings (e.g., "10s").
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// Schema writes the HCL schema of the configuration struct target to w: every
+// block and setting, with its type, whether it's required, and its
+// documentation from docs. docs is keyed by FieldDocKey; see FieldDocs. If
+// names are given, only the top-level blocks and settings with those names are
+// written.
+func Schema(w io.Writer, target any, docs map[string]string, names ...string) error {
+	t := reflect.TypeOf(target)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return fmt.Errorf("schema target must be a struct, got %s", t)
+	}
+
+	only := map[string]bool{}
+	for _, name := range names {
+		only[name] = true
+	}
+	for name := range only {
+		if !hasSetting(t, name) {
+			return fmt.Errorf("unknown block or setting %q", name)
+		}
+	}
+
+	var buf bytes.Buffer
+	writeSchema(&buf, t, docs, "", map[reflect.Type]bool{}, only)
+	_, err := w.Write(bytes.TrimLeft(buf.Bytes(), "\n"))
+	return err
+}
+
+// hasSetting reports whether struct type t has a block or setting name.
+func hasSetting(t reflect.Type, name string) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if n, kind := hclTag(t.Field(i)); n == name && kind != "label" {
+			return true
+		}
+	}
+	return false
+}
+
+// writeSchema writes the settings and blocks of struct type t, indented by
+// indent. seen holds the types of the enclosing blocks, so recursive types
+// are only expanded once. If only isn't empty, only the settings and blocks
+// with those names are written.
+func writeSchema(
+	w *bytes.Buffer, t reflect.Type, docs map[string]string, indent string,
+	seen map[reflect.Type]bool, only map[string]bool,
+) {
+	seen[t] = true
+	defer delete(seen, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, kind := hclTag(f)
+		if name == "" || kind == "label" || kind == "remain" || kind == "-" ||
+			(len(only) > 0 && !only[name]) {
+			continue
+		}
+
+		w.WriteString("\n")
+		writeDoc(w, docs[FieldDocKey(t, f.Name)], indent)
+
+		if kind != "block" {
+			req := ""
+			if kind == "" || kind == "attr" {
+				req = " # required"
+			}
+			fmt.Fprintf(w, "%s%s = %s%s\n", indent, name, typeName(f.Type), req)
+			continue
+		}
+
+		bt := f.Type
+		repeated := false
+		for bt.Kind() == reflect.Pointer || bt.Kind() == reflect.Slice {
+			if bt.Kind() == reflect.Slice {
+				repeated = true
+			}
+			bt = bt.Elem()
+		}
+
+		header := indent + name
+		for j := 0; j < bt.NumField(); j++ {
+			if label, kind := hclTag(bt.Field(j)); kind == "label" {
+				header += fmt.Sprintf(" %q", "<"+label+">")
+			}
+		}
+		if repeated {
+			fmt.Fprintf(w, "%s# This block can be repeated.\n", indent)
+		}
+		if seen[bt] {
+			fmt.Fprintf(w, "%s {\n%s  # Same settings as the enclosing %s block.\n%s}\n",
+				header, indent, name, indent)
+			continue
+		}
+		fmt.Fprintf(w, "%s {", header)
+		writeSchema(w, bt, docs, indent+"  ", seen, nil)
+		fmt.Fprintf(w, "%s}\n", indent)
+	}
+}
+
+// writeDoc writes doc as HCL comments indented by indent.
+func writeDoc(w *bytes.Buffer, doc, indent string) {
+	if doc == "" {
+		return
+	}
+	for _, line := range strings.Split(doc, "\n") {
+		if line == "" {
+			fmt.Fprintf(w, "%s#\n", indent)
+		} else {
+			fmt.Fprintf(w, "%s# %s\n", indent, line)
+		}
+	}
+}
+
+// typeName returns the HCL type name of a setting of type t.
+func typeName(t reflect.Type) string {
+	if t == durationType {
+		return "duration"
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		return typeName(t.Elem())
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "bool"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64, reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "list(" + typeName(t.Elem()) + ")"
+	case reflect.Map:
+		return "map(" + typeName(t.Elem()) + ")"
+	default:
+		return "any"
+	}
+}
+
+// FieldDocKey returns the key of the documentation of field name of struct
+// type t (e.g., "github.com/hashicorp-forge/hermes/internal/config.Server.Addr").
+func FieldDocKey(t reflect.Type, name string) string {
+	return t.PkgPath() + "." + t.Name() + "." + name
+}
+
+// FieldDocs returns the doc comments of the settings and blocks of the
+// configuration structs targets, keyed by FieldDocKey. The comments are read
+// from the Go source files of module modulePath, which is in directory root.
+func FieldDocs(root, modulePath string, targets ...any) (map[string]string, error) {
+	fields := map[string]bool{}
+	for _, target := range targets {
+		schemaFields(reflect.TypeOf(target), fields)
+	}
+
+	docs := map[string]string{}
+	fset := token.NewFileSet()
+
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			switch d.Name() {
+			case "node_modules", "vendor", "testdata", "web":
+				return filepath.SkipDir
+			}
+			if strings.HasPrefix(d.Name(), ".") && p != root {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(p, ".go") || strings.HasSuffix(p, "_test.go") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, filepath.Dir(p))
+		if err != nil {
+			return err
+		}
+		pkgPath := path.Join(modulePath, filepath.ToSlash(rel))
+
+		file, err := parser.ParseFile(fset, p, nil, parser.ParseComments)
+		if err != nil {
+			return fmt.Errorf("error parsing %s: %w", p, err)
+		}
+		if file.Name.Name == "main" {
+			// Matches reflect.Type.PkgPath for types in package main.
+			pkgPath = "main"
+		}
+
+		ast.Inspect(file, func(n ast.Node) bool {
+			ts, ok := n.(*ast.TypeSpec)
+			if !ok {
+				return true
+			}
+			st, ok := ts.Type.(*ast.StructType)
+			if !ok {
+				return true
+			}
+			for _, f := range st.Fields.List {
+				if f.Doc == nil || f.Tag == nil ||
+					!strings.Contains(f.Tag.Value, `hcl:"`) {
+					continue
+				}
+				doc := strings.TrimSpace(f.Doc.Text())
+				for _, name := range f.Names {
+					key := pkgPath + "." + ts.Name.Name + "." + name.Name
+					if fields[key] {
+						docs[key] = doc
+					}
+				}
+			}
+			return true
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+// schemaFields adds the FieldDocKey of each setting and block of the
+// configuration struct type t, and of its blocks, to fields.
+func schemaFields(t reflect.Type, fields map[string]bool) {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, kind := hclTag(f)
+		if name == "" || kind == "-" {
+			continue
+		}
+		key := FieldDocKey(t, f.Name)
+		if fields[key] {
+			continue
+		}
+		fields[key] = true
+		if kind == "block" {
+			schemaFields(f.Type, fields)
+		}
+	}
+}
+
+// WriteFieldDocs writes a Go source file of package pkg to w that declares
+// the variable varName with docs.
+func WriteFieldDocs(w io.Writer, pkg, varName string, docs map[string]string) error {
+	keys := make([]string, 0, len(docs))
+	for k := range docs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by \"hermes operator generate-config-docs\". DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", pkg)
+	fmt.Fprintf(&buf, "// %s are the doc comments of the configuration settings, keyed by\n", varName)
+	fmt.Fprintf(&buf, "// decode.FieldDocKey.\n")
+	fmt.Fprintf(&buf, "var %s = map[string]string{\n", varName)
+	for _, k := range keys {
+		fmt.Fprintf(&buf, "\t%q: %q,\n", k, docs[k])
+	}
+	fmt.Fprintf(&buf, "}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("error formatting generated source: %w", err)
+	}
+	_, err = w.Write(src)
+	return err
+}
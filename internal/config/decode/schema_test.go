@@ -0,0 +1,112 @@
+package decode
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type schemaConfig struct {
+	// Name is the name.
+	Name string `hcl:"name"`
+
+	// Timeout is how long to wait.
+	Timeout time.Duration `hcl:"timeout,optional"`
+
+	// Tags are tags.
+	Tags []string `hcl:"tags,optional"`
+
+	// Server configures the server.
+	Server *testServer `hcl:"server,block"`
+
+	// Backends configure backends.
+	Backends []*testBackend `hcl:"backend,block"`
+
+	Internal string
+}
+
+func TestSchema(t *testing.T) {
+	typ := reflect.TypeOf(schemaConfig{})
+	docs := map[string]string{
+		FieldDocKey(typ, "Name"):    "Name is the name.",
+		FieldDocKey(typ, "Timeout"): "Timeout is how long\nto wait.",
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, Schema(&buf, &schemaConfig{}, docs))
+	assert.Equal(t, `# Name is the name.
+name = string # required
+
+# Timeout is how long
+# to wait.
+timeout = duration
+
+tags = list(string)
+
+server {
+  addr = string
+
+  port = number
+}
+
+# This block can be repeated.
+backend "<kind>" {
+  enabled = bool
+
+  topic = string
+}
+`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, Schema(&buf, &schemaConfig{}, nil, "server"))
+	assert.Equal(t, "server {\n  addr = string\n\n  port = number\n}\n", buf.String())
+
+	assert.ErrorContains(t, Schema(&buf, &schemaConfig{}, nil, "nope"),
+		`unknown block or setting "nope"`)
+}
+
+func TestFieldDocs(t *testing.T) {
+	// Lay out the module so the source types have the package path of the
+	// types in this test.
+	root := t.TempDir()
+	dir := filepath.Join(root, "internal", "config", "decode")
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.go"),
+		[]byte(`package decode
+
+type schemaConfig struct {
+	// Name is the name.
+	Name string `+"`hcl:\"name\"`"+`
+
+	// Server configures the server.
+	Server *testServer `+"`hcl:\"server,block\"`"+`
+}
+
+type testServer struct {
+	// Addr is the address.
+	Addr string `+"`hcl:\"addr,optional\"`"+`
+}
+
+type unrelated struct {
+	// Field isn't a setting of schemaConfig.
+	Field string `+"`hcl:\"field\"`"+`
+}
+`), 0o600))
+
+	docs, err := FieldDocs(
+		root, "github.com/hashicorp-forge/hermes", &schemaConfig{})
+	require.NoError(t, err)
+
+	pkg := reflect.TypeOf(schemaConfig{}).PkgPath()
+	assert.Equal(t, map[string]string{
+		pkg + ".schemaConfig.Name":   "Name is the name.",
+		pkg + ".schemaConfig.Server": "Server configures the server.",
+		pkg + ".testServer.Addr":     "Addr is the address.",
+	}, docs)
+}
@@ -0,0 +1,185 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Deployment sizes.
+const (
+	DeploymentSizeSmall  = "small"
+	DeploymentSizeMedium = "medium"
+	DeploymentSizeLarge  = "large"
+)
+
+// DefaultDocumentLocationCacheTTL is how long the storage provider that serves
+// a document is cached when Server.DocumentLocationCacheTTL is unset.
+const DefaultDocumentLocationCacheTTL = time.Minute
+
+// deploymentSizePreset contains the defaults for a deployment size.
+type deploymentSizePreset struct {
+	// Database connection pool.
+	PostgresMaxOpenConns int
+	PostgresMaxIdleConns int
+
+	// Indexing.
+	IndexerMaxParallelDocs        int
+	IndexerBatchSize              int
+	IndexerPollInterval           time.Duration
+	IndexerSearchBatchSize        int
+	IndexerSearchBatchConcurrency int
+
+	// Storage migration workers.
+	MigrationMaxConcurrency int
+	MigrationPollInterval   time.Duration
+
+	// Broken-link detection. MaxExternalPerRun and MaxRequestsPerSecond limit
+	// requests to external sites; zero means no limit.
+	LinkCheckMaxConcurrency       int
+	LinkCheckMaxExternalPerRun    int
+	LinkCheckMaxRequestsPerSecond int
+
+	// How long cached and derived data is used before it is refreshed.
+	DocumentLocationCacheTTL    time.Duration
+	PeopleDirectorySyncInterval time.Duration
+	FreshnessInterval           time.Duration
+}
+
+// deploymentSizePresets are the defaults for each deployment size. Medium
+// matches the defaults used when no deployment size is declared.
+var deploymentSizePresets = map[string]deploymentSizePreset{
+	DeploymentSizeSmall: {
+		PostgresMaxOpenConns:          10,
+		PostgresMaxIdleConns:          5,
+		IndexerMaxParallelDocs:        2,
+		IndexerBatchSize:              50,
+		IndexerPollInterval:           2 * time.Second,
+		IndexerSearchBatchSize:        50,
+		IndexerSearchBatchConcurrency: 2,
+		MigrationMaxConcurrency:       2,
+		MigrationPollInterval:         10 * time.Second,
+		LinkCheckMaxConcurrency:       2,
+		LinkCheckMaxExternalPerRun:    500,
+		LinkCheckMaxRequestsPerSecond: 2,
+		DocumentLocationCacheTTL:      5 * time.Minute,
+		PeopleDirectorySyncInterval:   6 * time.Hour,
+		FreshnessInterval:             6 * time.Hour,
+	},
+	DeploymentSizeMedium: {
+		PostgresMaxOpenConns:          25,
+		PostgresMaxIdleConns:          10,
+		IndexerMaxParallelDocs:        5,
+		IndexerBatchSize:              100,
+		IndexerPollInterval:           1 * time.Second,
+		IndexerSearchBatchSize:        100,
+		IndexerSearchBatchConcurrency: 4,
+		MigrationMaxConcurrency:       5,
+		MigrationPollInterval:         5 * time.Second,
+		LinkCheckMaxConcurrency:       5,
+		LinkCheckMaxExternalPerRun:    0,
+		LinkCheckMaxRequestsPerSecond: 0,
+		DocumentLocationCacheTTL:      DefaultDocumentLocationCacheTTL,
+		PeopleDirectorySyncInterval:   1 * time.Hour,
+		FreshnessInterval:             1 * time.Hour,
+	},
+	DeploymentSizeLarge: {
+		PostgresMaxOpenConns:          100,
+		PostgresMaxIdleConns:          25,
+		IndexerMaxParallelDocs:        20,
+		IndexerBatchSize:              500,
+		IndexerPollInterval:           500 * time.Millisecond,
+		IndexerSearchBatchSize:        500,
+		IndexerSearchBatchConcurrency: 8,
+		MigrationMaxConcurrency:       20,
+		MigrationPollInterval:         2 * time.Second,
+		LinkCheckMaxConcurrency:       20,
+		LinkCheckMaxExternalPerRun:    0,
+		LinkCheckMaxRequestsPerSecond: 0,
+		DocumentLocationCacheTTL:      2 * time.Minute,
+		PeopleDirectorySyncInterval:   30 * time.Minute,
+		FreshnessInterval:             30 * time.Minute,
+	},
+}
+
+// ApplyDeploymentSize sets unset tuning settings to the defaults for the
+// declared deployment size. Settings that are set explicitly are kept, and
+// blocks that are not configured are not created, so declaring a size never
+// enables a feature. It does nothing if no deployment size is declared.
+func (c *Config) ApplyDeploymentSize() error {
+	if c.DeploymentSize == "" {
+		return nil
+	}
+	if err := c.validateDeploymentSize(); err != nil {
+		return err
+	}
+	p := deploymentSizePresets[strings.ToLower(c.DeploymentSize)]
+
+	if c.Postgres != nil {
+		setDefaultInt(&c.Postgres.MaxOpenConns, p.PostgresMaxOpenConns)
+		setDefaultInt(&c.Postgres.MaxIdleConns, p.PostgresMaxIdleConns)
+	}
+	if c.Indexer != nil {
+		setDefaultInt(&c.Indexer.MaxParallelDocs, p.IndexerMaxParallelDocs)
+		setDefaultInt(&c.Indexer.BatchSize, p.IndexerBatchSize)
+		setDefaultDuration(&c.Indexer.PollInterval, p.IndexerPollInterval)
+		setDefaultInt(&c.Indexer.SearchBatchSize, p.IndexerSearchBatchSize)
+		setDefaultInt(
+			&c.Indexer.SearchBatchConcurrency, p.IndexerSearchBatchConcurrency)
+	}
+	if c.Migration != nil {
+		setDefaultInt(&c.Migration.MaxConcurrency, p.MigrationMaxConcurrency)
+		setDefaultDuration(&c.Migration.PollInterval, p.MigrationPollInterval)
+	}
+	if c.LinkCheck != nil {
+		setDefaultInt(&c.LinkCheck.MaxConcurrency, p.LinkCheckMaxConcurrency)
+		setDefaultInt(&c.LinkCheck.MaxExternalPerRun, p.LinkCheckMaxExternalPerRun)
+		setDefaultInt(
+			&c.LinkCheck.MaxRequestsPerSecond, p.LinkCheckMaxRequestsPerSecond)
+	}
+	if c.Server != nil {
+		setDefaultDuration(
+			&c.Server.DocumentLocationCacheTTL, p.DocumentLocationCacheTTL)
+	}
+	if c.PeopleDirectory != nil {
+		setDefaultDuration(
+			&c.PeopleDirectory.SyncInterval, p.PeopleDirectorySyncInterval)
+	}
+	if c.Freshness != nil {
+		setDefaultDuration(&c.Freshness.Interval, p.FreshnessInterval)
+	}
+
+	return nil
+}
+
+func setDefaultInt(v *int, def int) {
+	if *v == 0 {
+		*v = def
+	}
+}
+
+func setDefaultDuration(v *time.Duration, def time.Duration) {
+	if *v == 0 {
+		*v = def
+	}
+}
+
+// validateDeploymentSize returns an error if the declared deployment size is
+// not supported.
+func (c *Config) validateDeploymentSize() error {
+	if c.DeploymentSize == "" {
+		return nil
+	}
+	if _, ok := deploymentSizePresets[strings.ToLower(c.DeploymentSize)]; ok {
+		return nil
+	}
+
+	var sizes []string
+	for s := range deploymentSizePresets {
+		sizes = append(sizes, s)
+	}
+	sort.Strings(sizes)
+	return fmt.Errorf("invalid deployment_size %q: must be one of %s",
+		c.DeploymentSize, strings.Join(sizes, ", "))
+}
@@ -0,0 +1,51 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/pkg/database"
+	"github.com/hashicorp-forge/hermes/pkg/directorysync"
+	"github.com/hashicorp-forge/hermes/pkg/freshness"
+	"github.com/hashicorp-forge/hermes/pkg/indexer/relay"
+	"github.com/hashicorp-forge/hermes/pkg/linkcheck"
+	"github.com/hashicorp-forge/hermes/pkg/migration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMediumDeploymentSizeMatchesDefaults verifies that declaring the medium
+// deployment size doesn't change any setting from the defaults used when no
+// deployment size is declared.
+func TestMediumDeploymentSizeMatchesDefaults(t *testing.T) {
+	assert := assert.New(t)
+
+	c := &config.Config{
+		DeploymentSize:  config.DeploymentSizeMedium,
+		Postgres:        &config.Postgres{},
+		Indexer:         &config.Indexer{},
+		Migration:       &config.Migration{},
+		LinkCheck:       &config.LinkCheck{},
+		PeopleDirectory: &config.PeopleDirectory{},
+		Freshness:       &config.Freshness{},
+		Server:          &config.Server{},
+	}
+	require.NoError(t, c.ApplyDeploymentSize())
+
+	assert.Equal(database.DefaultMaxOpenConns, c.Postgres.MaxOpenConns)
+	assert.Equal(database.DefaultMaxIdleConns, c.Postgres.MaxIdleConns)
+	assert.Equal(
+		config.GenerateSimplifiedConfig(t.TempDir()).Indexer.MaxParallelDocs,
+		c.Indexer.MaxParallelDocs)
+	assert.Equal(relay.DefaultBatchSize, c.Indexer.BatchSize)
+	assert.Equal(relay.DefaultPollInterval, c.Indexer.PollInterval)
+	assert.Equal(migration.DefaultWorkerMaxConcurrency, c.Migration.MaxConcurrency)
+	assert.Equal(migration.DefaultWorkerPollInterval, c.Migration.PollInterval)
+	assert.Equal(linkcheck.DefaultMaxConcurrency, c.LinkCheck.MaxConcurrency)
+	assert.Zero(c.LinkCheck.MaxExternalPerRun)
+	assert.Zero(c.LinkCheck.MaxRequestsPerSecond)
+	assert.Equal(config.DefaultDocumentLocationCacheTTL,
+		c.Server.DocumentLocationCacheTTL)
+	assert.Equal(directorysync.DefaultInterval, c.PeopleDirectory.SyncInterval)
+	assert.Equal(freshness.DefaultInterval, c.Freshness.Interval)
+}
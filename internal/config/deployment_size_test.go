@@ -0,0 +1,79 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyDeploymentSize(t *testing.T) {
+	t.Run("no deployment size", func(t *testing.T) {
+		c := &Config{Indexer: &Indexer{}}
+		require.NoError(t, c.ApplyDeploymentSize())
+		assert.Zero(t, c.Indexer.BatchSize)
+	})
+
+	t.Run("invalid deployment size", func(t *testing.T) {
+		c := &Config{DeploymentSize: "huge"}
+		assert.ErrorContains(t, c.ApplyDeploymentSize(),
+			`invalid deployment_size "huge": must be one of large, medium, small`)
+	})
+
+	t.Run("sets unset values and keeps explicit values", func(t *testing.T) {
+		c := &Config{
+			DeploymentSize: "Large",
+			Indexer: &Indexer{
+				BatchSize: 42,
+			},
+			Postgres:  &Postgres{},
+			Migration: &Migration{},
+			LinkCheck: &LinkCheck{MaxRequestsPerSecond: 7},
+			Server:    &Server{},
+		}
+		require.NoError(t, c.ApplyDeploymentSize())
+
+		assert.Equal(t, 42, c.Indexer.BatchSize)
+		assert.Equal(t, 20, c.Indexer.MaxParallelDocs)
+		assert.Equal(t, 500*time.Millisecond, c.Indexer.PollInterval)
+		assert.Equal(t, 100, c.Postgres.MaxOpenConns)
+		assert.Equal(t, 25, c.Postgres.MaxIdleConns)
+		assert.Equal(t, 20, c.Migration.MaxConcurrency)
+		assert.Equal(t, 7, c.LinkCheck.MaxRequestsPerSecond)
+		assert.Equal(t, 2*time.Minute, c.Server.DocumentLocationCacheTTL)
+
+		// Unconfigured blocks are not created.
+		assert.Nil(t, c.PeopleDirectory)
+		assert.Nil(t, c.Freshness)
+	})
+}
+
+func TestNewConfigDeploymentSize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.hcl")
+	require.NoError(t, os.WriteFile(path, []byte(`
+deployment_size = "small"
+
+indexer {
+  max_parallel_docs = 3
+}
+
+link_check {
+  enabled = true
+}
+`), 0644))
+
+	c, err := NewConfig(path, "")
+	require.NoError(t, err)
+	assert.Equal(t, 3, c.Indexer.MaxParallelDocs)
+	assert.Equal(t, 50, c.Indexer.BatchSize)
+	assert.Equal(t, 2, c.LinkCheck.MaxConcurrency)
+	assert.Equal(t, 500, c.LinkCheck.MaxExternalPerRun)
+	assert.Equal(t, 2, c.LinkCheck.MaxRequestsPerSecond)
+
+	require.NoError(t, os.WriteFile(path, []byte(`deployment_size = "tiny"`), 0644))
+	_, err = NewConfig(path, "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocumentTypeReviewChecklist(t *testing.T) {
+	checklist := []*DocumentTypeChecklistItem{
+		{
+			Name:        "security-review",
+			Label:       "Security review done",
+			RequiredFor: "In-Review",
+		},
+		{Name: "legal", Label: "Legal sign-off", RequiredFor: "Approved"},
+		{Name: "test-plan", Label: "Test plan linked"},
+	}
+	d := &DocumentType{Name: "RFC", ReviewChecklist: checklist}
+	assert.NoError(t, d.Validate())
+
+	assert.Equal(t, checklist[:1], d.RequiredChecklistItems("In-Review"))
+	assert.Equal(t, checklist[:2], d.RequiredChecklistItems("Approved"),
+		"items required for In-Review are also required for Approved")
+	assert.Empty(t, d.RequiredChecklistItems("Obsolete"))
+
+	for name, item := range map[string]*DocumentTypeChecklistItem{
+		"missing name":   {Label: "Design review done"},
+		"missing label":  {Name: "design-review"},
+		"duplicate name": {Name: "legal", Label: "Legal review"},
+		"invalid status": {Name: "design-review", Label: "Design review done",
+			RequiredFor: "Obsolete"},
+	} {
+		items := append([]*DocumentTypeChecklistItem{}, checklist...)
+		d := &DocumentType{Name: "RFC", ReviewChecklist: append(items, item)}
+		assert.Error(t, d.Validate(), name)
+	}
+}
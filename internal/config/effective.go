@@ -0,0 +1,213 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// RedactedValue replaces the value of secret settings in effective settings.
+const RedactedValue = "(redacted)"
+
+// secretSettingSuffixes are the last words of setting names that hold secrets
+// (e.g., "client_secret" or "write_api_key"). Data source names (e.g.,
+// "read_replica_dsn") are secret because they can include a password.
+var secretSettingSuffixes = []string{"dsn", "key", "password", "secret", "token"}
+
+// SettingDiff is a setting with a different value in two sets of effective
+// settings. Want or Got is nil if the setting is missing from that set.
+type SettingDiff struct {
+	Setting string `json:"setting"`
+	Want    any    `json:"want"`
+	Got     any    `json:"got"`
+}
+
+// EffectiveSettings returns the configuration as a flat map of settings keyed
+// by their dotted HCL path (e.g., "postgres.max_open_conns"). Blocks that are
+// not configured are omitted, and the values of secret settings are replaced
+// with RedactedValue if set. Defaults that were applied when the
+// configuration was loaded are included.
+func (c *Config) EffectiveSettings() map[string]any {
+	settings := map[string]any{}
+	flattenSetting("", reflect.ValueOf(c), settings, true)
+	return settings
+}
+
+// SecretSettings returns the values of the secret settings that are set,
+// keyed by their dotted HCL path (e.g., "postgres.password").
+func (c *Config) SecretSettings() map[string]string {
+	settings := map[string]any{}
+	flattenSetting("", reflect.ValueOf(c), settings, false)
+
+	secrets := map[string]string{}
+	for name, v := range settings {
+		if s, ok := v.(string); ok && s != "" && isSecretSetting(name) {
+			secrets[name] = s
+		}
+	}
+	return secrets
+}
+
+// DiffSettings returns the settings that differ between want and got, sorted
+// by setting name.
+func DiffSettings(want, got map[string]any) []SettingDiff {
+	names := map[string]struct{}{}
+	for k := range want {
+		names[k] = struct{}{}
+	}
+	for k := range got {
+		names[k] = struct{}{}
+	}
+
+	var diffs []SettingDiff
+	for k := range names {
+		w, wok := want[k]
+		g, gok := got[k]
+		if wok && gok && settingString(w) == settingString(g) {
+			continue
+		}
+		diffs = append(diffs, SettingDiff{
+			Setting: k,
+			Want:    w,
+			Got:     g,
+		})
+	}
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Setting < diffs[j].Setting
+	})
+	return diffs
+}
+
+// settingString returns the string used to compare setting values. Values
+// that were decoded from JSON are compared by their formatted value so
+// numbers match regardless of their type.
+func settingString(v any) string {
+	if vs, ok := v.([]any); ok {
+		var ss []string
+		for _, s := range vs {
+			ss = append(ss, settingString(s))
+		}
+		return fmt.Sprint(ss)
+	}
+	return fmt.Sprint(v)
+}
+
+// flattenSetting adds the settings in v to settings, prefixing their names
+// with name. The values of secret settings are replaced with RedactedValue if
+// redact is true.
+func flattenSetting(
+	name string, v reflect.Value, settings map[string]any, redact bool,
+) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return
+		}
+		flattenSetting(name, v.Elem(), settings, redact)
+
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			fieldName, ok := settingName(f)
+			if !ok {
+				continue
+			}
+			if name != "" {
+				fieldName = name + "." + fieldName
+			}
+			if redact && isSecretSetting(fieldName) && !v.Field(i).IsZero() {
+				settings[fieldName] = RedactedValue
+				continue
+			}
+			flattenSetting(fieldName, v.Field(i), settings, redact)
+		}
+
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Struct ||
+			v.Type().Elem().Kind() == reflect.Pointer {
+			for i := 0; i < v.Len(); i++ {
+				flattenSetting(fmt.Sprintf("%s[%d]", name, i), v.Index(i), settings,
+					redact)
+			}
+			return
+		}
+		if v.Len() > 0 {
+			settings[name] = v.Interface()
+		}
+
+	case reflect.Map:
+		keys := v.MapKeys()
+		for _, k := range keys {
+			flattenSetting(fmt.Sprintf("%s.%v", name, k.Interface()),
+				v.MapIndex(k), settings, redact)
+		}
+
+	case reflect.Int64:
+		if d, ok := v.Interface().(time.Duration); ok {
+			settings[name] = d.String()
+			return
+		}
+		settings[name] = v.Interface()
+
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64, reflect.Float32, reflect.Float64:
+		settings[name] = v.Interface()
+	}
+}
+
+// settingName returns the name of the setting for a struct field. It is the
+// HCL attribute or block name, or the field name in snake case for fields
+// that are not decoded from HCL. It returns false for fields that are not
+// settings, such as the remaining HCL body.
+func settingName(f reflect.StructField) (string, bool) {
+	tag, ok := f.Tag.Lookup("hcl")
+	if !ok {
+		return toSnakeCase(f.Name), true
+	}
+	name, opts, _ := strings.Cut(tag, ",")
+	if name == "" || opts == "remain" {
+		return "", false
+	}
+	return name, true
+}
+
+// isSecretSetting returns true if the setting with the provided dotted name
+// holds a secret.
+func isSecretSetting(name string) bool {
+	if i := strings.LastIndexAny(name, "._"); i != -1 {
+		name = name[i+1:]
+	}
+	for _, s := range secretSettingSuffixes {
+		if name == s {
+			return true
+		}
+	}
+	return false
+}
+
+// toSnakeCase converts a Go field name to snake case (e.g., "DBPath" to
+// "db_path").
+func toSnakeCase(s string) string {
+	var b strings.Builder
+	runes := []rune(s)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) ||
+				(i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,120 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEffectiveSettings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.hcl")
+	require.NoError(t, os.WriteFile(path, []byte(`
+base_url        = "https://hermes.example.com"
+deployment_size = "small"
+
+jira {
+  enabled   = true
+  api_token = "jira-secret"
+  url       = "https://jira.example.com"
+  user      = "hermes@example.com"
+}
+
+postgres {
+  dbname   = "hermes"
+  host     = "localhost"
+  password = "postgres-secret"
+  port     = 5432
+  user     = "postgres"
+
+  read_replica_dsn = "host=replica user=hermes password=replica-secret"
+}
+
+products {
+  product "Engineering" {
+    abbreviation = "ENG"
+  }
+}
+`), 0o600))
+
+	cfg, err := NewConfig(path, "")
+	require.NoError(t, err)
+	settings := cfg.EffectiveSettings()
+
+	assert.Equal(t, "https://hermes.example.com", settings["base_url"])
+	assert.Equal(t, "localhost", settings["postgres.host"])
+	assert.Equal(t, 5432, settings["postgres.port"])
+	assert.Equal(t, "Engineering", settings["products.product[0].name"])
+
+	// Defaults from the deployment size are resolved.
+	assert.Equal(t, 10, settings["postgres.max_open_conns"])
+	assert.Equal(t, "2s", settings["indexer.poll_interval"])
+
+	// Secrets are redacted.
+	assert.Equal(t, RedactedValue, settings["postgres.password"])
+	assert.Equal(t, RedactedValue, settings["jira.api_token"])
+	assert.Equal(t, RedactedValue, settings["postgres.read_replica_dsn"])
+	for _, v := range settings {
+		assert.NotEqual(t, "postgres-secret", v)
+		assert.NotEqual(t, "jira-secret", v)
+		if s, ok := v.(string); ok {
+			assert.NotContains(t, s, "replica-secret")
+		}
+	}
+
+	// Unconfigured blocks are omitted.
+	for k := range settings {
+		assert.NotContains(t, k, "link_check")
+	}
+
+	// Secret settings are available unredacted.
+	assert.Equal(t, map[string]string{
+		"jira.api_token":            "jira-secret",
+		"postgres.password":         "postgres-secret",
+		"postgres.read_replica_dsn": "host=replica user=hermes password=replica-secret",
+	}, cfg.SecretSettings())
+}
+
+func TestIsSecretSetting(t *testing.T) {
+	assert.True(t, isSecretSetting("postgres.password"))
+	assert.True(t, isSecretSetting("algolia.write_api_key"))
+	assert.True(t, isSecretSetting("google_workspace.oauth2.client_secret"))
+	assert.True(t, isSecretSetting("jira.api_token"))
+	assert.True(t, isSecretSetting("postgres.read_replica_dsn"))
+	assert.False(t, isSecretSetting("local_workspace.tokens_path"))
+	assert.False(t, isSecretSetting("dex.token_endpoint"))
+	assert.False(t, isSecretSetting("google_workspace.credentials_path"))
+}
+
+func TestDiffSettings(t *testing.T) {
+	want := map[string]any{
+		"base_url":                 "https://hermes.example.com",
+		"postgres.max_open_conns":  25,
+		"products.product[0].name": "Engineering",
+		"server.addr":              "127.0.0.1:8000",
+	}
+
+	// Settings returned by a running server are decoded from JSON.
+	var got map[string]any
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"base_url": "https://hermes.example.com",
+		"postgres.max_open_conns": 100,
+		"server.addr": "127.0.0.1:8000",
+		"deployment_size": "large"
+	}`), &got))
+
+	assert.Equal(t, []SettingDiff{
+		{Setting: "deployment_size", Want: nil, Got: "large"},
+		{Setting: "postgres.max_open_conns", Want: 25, Got: float64(100)},
+		{Setting: "products.product[0].name", Want: "Engineering", Got: nil},
+	}, DiffSettings(want, got))
+}
+
+func TestToSnakeCase(t *testing.T) {
+	assert.Equal(t, "db_path", toSnakeCase("DBPath"))
+	assert.Equal(t, "simplified_mode", toSnakeCase("SimplifiedMode"))
+	assert.Equal(t, "database_type", toSnakeCase("DatabaseType"))
+}
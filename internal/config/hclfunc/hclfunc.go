@@ -0,0 +1,167 @@
+// Package hclfunc provides the functions available in Hermes HCL config files,
+// so secrets can be referenced instead of stored in the files:
+//
+//	auth_token = env("HERMES_API_TOKEN")
+//	password   = file("/run/secrets/postgres-password")
+//	api_key    = vault("secret/data/hermes/algolia", "api_key")
+//
+// env(name) returns the value of an environment variable, and fails if it's
+// unset. env(name, default) returns default instead.
+//
+// file(path) returns the contents of a file, with surrounding whitespace
+// trimmed. Relative paths are resolved from the config file's directory.
+//
+// vault(path, key) returns a key of a secret in HashiCorp Vault, read with the
+// VAULT_ADDR and VAULT_TOKEN environment variables. Both KV version 1 and 2
+// secret paths are supported.
+package hclfunc
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsimple"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+)
+
+// vaultTimeout is the timeout for reading a secret from Vault.
+const vaultTimeout = 10 * time.Second
+
+// EvalContext returns the evaluation context for a config file in baseDir.
+func EvalContext(baseDir string) *hcl.EvalContext {
+	return &hcl.EvalContext{
+		Functions: map[string]function.Function{
+			"env":   envFunc,
+			"file":  fileFunc(baseDir),
+			"vault": vaultFunc,
+		},
+	}
+}
+
+// DecodeFile decodes the HCL config file filename into target, with the
+// functions provided by EvalContext.
+func DecodeFile(filename string, target interface{}) error {
+	return hclsimple.DecodeFile(
+		filename, EvalContext(filepath.Dir(filename)), target)
+}
+
+var envFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "name", Type: cty.String},
+	},
+	VarParam: &function.Parameter{Name: "default", Type: cty.String},
+	Type:     function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		if len(args) > 2 {
+			return cty.NilVal, fmt.Errorf("env takes at most 2 arguments")
+		}
+
+		name := args[0].AsString()
+		if val, ok := os.LookupEnv(name); ok {
+			return cty.StringVal(val), nil
+		}
+		if len(args) == 2 {
+			return args[1], nil
+		}
+		return cty.NilVal, fmt.Errorf(
+			"environment variable %q is not set", name)
+	},
+})
+
+func fileFunc(baseDir string) function.Function {
+	return function.New(&function.Spec{
+		Params: []function.Parameter{
+			{Name: "path", Type: cty.String},
+		},
+		Type: function.StaticReturnType(cty.String),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			path := args[0].AsString()
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(baseDir, path)
+			}
+
+			b, err := os.ReadFile(path)
+			if err != nil {
+				return cty.NilVal, fmt.Errorf("error reading file: %w", err)
+			}
+			return cty.StringVal(strings.TrimSpace(string(b))), nil
+		},
+	})
+}
+
+var vaultFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "path", Type: cty.String},
+		{Name: "key", Type: cty.String},
+	},
+	Type: function.StaticReturnType(cty.String),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		val, err := readVaultSecret(args[0].AsString(), args[1].AsString())
+		if err != nil {
+			return cty.NilVal, err
+		}
+		return cty.StringVal(val), nil
+	},
+})
+
+// readVaultSecret reads key from the secret at path in Vault.
+func readVaultSecret(path, key string) (string, error) {
+	addr := os.Getenv("VAULT_ADDR")
+	if addr == "" {
+		return "", fmt.Errorf("VAULT_ADDR must be set to read secrets from Vault")
+	}
+	token := os.Getenv("VAULT_TOKEN")
+	if token == "" {
+		return "", fmt.Errorf("VAULT_TOKEN must be set to read secrets from Vault")
+	}
+
+	req, err := http.NewRequest(http.MethodGet,
+		strings.TrimSuffix(addr, "/")+"/v1/"+strings.TrimPrefix(path, "/"), nil)
+	if err != nil {
+		return "", fmt.Errorf("error creating Vault request: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", token)
+
+	client := &http.Client{Timeout: vaultTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("error reading secret %q from Vault: %w", path, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("error reading secret %q from Vault: status %d",
+			path, resp.StatusCode)
+	}
+
+	var secret struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&secret); err != nil {
+		return "", fmt.Errorf("error decoding secret %q from Vault: %w", path, err)
+	}
+
+	// KV version 2 secrets nest the key/value pairs under data.data.
+	data := secret.Data
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		if _, ok := data["metadata"]; ok {
+			data = nested
+		}
+	}
+
+	val, ok := data[key]
+	if !ok {
+		return "", fmt.Errorf("secret %q has no key %q", path, key)
+	}
+	s, ok := val.(string)
+	if !ok {
+		return "", fmt.Errorf("key %q of secret %q is not a string", key, path)
+	}
+	return s, nil
+}
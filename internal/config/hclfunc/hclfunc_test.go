@@ -0,0 +1,102 @@
+package hclfunc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testConfig struct {
+	Token    string `hcl:"token,optional"`
+	Password string `hcl:"password,optional"`
+	APIKey   string `hcl:"api_key,optional"`
+}
+
+func decode(t *testing.T, src string) (*testConfig, error) {
+	t.Helper()
+
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "config.hcl")
+	require.NoError(t, os.WriteFile(filename, []byte(src), 0o600))
+
+	var c testConfig
+	err := DecodeFile(filename, &c)
+	return &c, err
+}
+
+func TestEnv(t *testing.T) {
+	t.Setenv("HCLFUNC_TEST_TOKEN", "secret-token")
+
+	c, err := decode(t, `token = env("HCLFUNC_TEST_TOKEN")`)
+	require.NoError(t, err)
+	assert.Equal(t, "secret-token", c.Token)
+
+	c, err = decode(t, `token = env("HCLFUNC_TEST_UNSET", "fallback")`)
+	require.NoError(t, err)
+	assert.Equal(t, "fallback", c.Token)
+
+	_, err = decode(t, `token = env("HCLFUNC_TEST_UNSET")`)
+	assert.ErrorContains(t, err, `environment variable "HCLFUNC_TEST_UNSET" is not set`)
+}
+
+func TestFile(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "config.hcl")
+	require.NoError(t, os.WriteFile(
+		filepath.Join(dir, "password"), []byte("hunter2\n"), 0o600))
+	require.NoError(t, os.WriteFile(
+		filename, []byte(`password = file("password")`), 0o600))
+
+	// Relative paths are resolved from the config file's directory.
+	var c testConfig
+	require.NoError(t, DecodeFile(filename, &c))
+	assert.Equal(t, "hunter2", c.Password)
+
+	_, err := decode(t, `password = file("does-not-exist")`)
+	assert.ErrorContains(t, err, "error reading file")
+}
+
+func TestVault(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("X-Vault-Token") != "vault-token" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			switch r.URL.Path {
+			case "/v1/secret/data/hermes":
+				w.Write([]byte(`{"data":{"data":{"api_key":"kv2-key"},"metadata":{}}}`))
+			case "/v1/kv/hermes":
+				w.Write([]byte(`{"data":{"api_key":"kv1-key"}}`))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+	defer srv.Close()
+
+	t.Setenv("VAULT_ADDR", srv.URL)
+	t.Setenv("VAULT_TOKEN", "vault-token")
+
+	c, err := decode(t, `api_key = vault("secret/data/hermes", "api_key")`)
+	require.NoError(t, err)
+	assert.Equal(t, "kv2-key", c.APIKey)
+
+	c, err = decode(t, `api_key = vault("kv/hermes", "api_key")`)
+	require.NoError(t, err)
+	assert.Equal(t, "kv1-key", c.APIKey)
+
+	_, err = decode(t, `api_key = vault("kv/hermes", "missing")`)
+	assert.ErrorContains(t, err, `has no key "missing"`)
+
+	_, err = decode(t, `api_key = vault("kv/other", "api_key")`)
+	assert.ErrorContains(t, err, "status 404")
+
+	t.Setenv("VAULT_TOKEN", "")
+	_, err = decode(t, `api_key = vault("kv/hermes", "api_key")`)
+	assert.ErrorContains(t, err, "VAULT_TOKEN must be set")
+}
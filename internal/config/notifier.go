@@ -0,0 +1,71 @@
+package config
+
+import (
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/config/decode"
+	"github.com/hashicorp-forge/hermes/pkg/notifications/backends"
+)
+
+// NotifierConfig contains the configuration of a notifier (hermes-notify).
+type NotifierConfig struct {
+	// Backends configures the backends that deliver notifications.
+	Backends *backends.Config `hcl:"backends,block"`
+
+	// Brokers is the comma-separated list of Kafka brokers (default:
+	// "localhost:9092").
+	Brokers string `hcl:"brokers,optional"`
+
+	// Topic is the Kafka topic notifications are consumed from (default:
+	// "hermes.notifications").
+	Topic string `hcl:"topic,optional"`
+
+	// ConsumerGroup is the Kafka consumer group of the notifier (default:
+	// "hermes-notifiers").
+	ConsumerGroup string `hcl:"consumer_group,optional"`
+
+	// DedupWindow is how long identical notifications (same recipient,
+	// template, document, and event) are collapsed into one, e.g., when both
+	// the API server and an indexer step publish the same event (default:
+	// "5m"). Set it to a negative duration to disable deduplication.
+	DedupWindow time.Duration `hcl:"dedup_window,optional"`
+
+	// MetricsAddress is the address (e.g., ":9101") Prometheus metrics are
+	// served on at /metrics. Metrics aren't served if it's empty.
+	MetricsAddress string `hcl:"metrics_address,optional"`
+
+	// Status configures reporting the status of the notifier to the Hermes
+	// API. Status isn't reported if it's not set.
+	Status *WorkerStatus `hcl:"status,block"`
+
+	// Tracing configures exporting OpenTelemetry traces of notification
+	// deliveries.
+	Tracing *Tracing `hcl:"tracing,block"`
+}
+
+// LoadNotifierConfig loads a notifier configuration file and returns warnings
+// about unknown settings.
+func LoadNotifierConfig(filename string) (*NotifierConfig, []string, error) {
+	var c NotifierConfig
+	warnings, err := decode.File(filename, &c)
+	if err != nil {
+		return nil, warnings, err
+	}
+	return &c, warnings, nil
+}
+
+// ApplyDefaults sets defaults for the settings that aren't configured.
+func (c *NotifierConfig) ApplyDefaults() {
+	if c.Brokers == "" {
+		c.Brokers = "localhost:9092"
+	}
+	if c.Topic == "" {
+		c.Topic = "hermes.notifications"
+	}
+	if c.ConsumerGroup == "" {
+		c.ConsumerGroup = "hermes-notifiers"
+	}
+	if c.DedupWindow == 0 {
+		c.DedupWindow = 5 * time.Minute
+	}
+}
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/hashicorp-forge/hermes/internal/config/decode"
+)
+
+// schemaTargets are the configuration files with a schema, keyed by the
+// process that loads them.
+var schemaTargets = map[string]any{
+	"server":   &Config{},
+	"notifier": &NotifierConfig{},
+}
+
+// SchemaKinds returns the kinds of configuration files accepted by
+// WriteSchema.
+func SchemaKinds() []string {
+	kinds := make([]string, 0, len(schemaTargets))
+	for k := range schemaTargets {
+		kinds = append(kinds, k)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// SchemaTargets returns the configuration structs of every kind of
+// configuration file, used to generate their documentation.
+func SchemaTargets() []any {
+	targets := []any{}
+	for _, k := range SchemaKinds() {
+		targets = append(targets, schemaTargets[k])
+	}
+	return targets
+}
+
+// WriteSchema writes the documented HCL schema of a kind of configuration file
+// ("server" or "notifier") to w. If names are given, only the top-level
+// blocks and settings with those names are written.
+func WriteSchema(w io.Writer, kind string, names ...string) error {
+	target, ok := schemaTargets[kind]
+	if !ok {
+		return fmt.Errorf("unknown configuration kind %q", kind)
+	}
+	return decode.Schema(w, target, fieldDocs, names...)
+}
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp-forge/hermes/internal/config/decode"
+)
+
+func TestFieldDocsUpToDate(t *testing.T) {
+	docs, err := decode.FieldDocs(
+		"../..", "github.com/hashicorp-forge/hermes", SchemaTargets()...)
+	require.NoError(t, err)
+
+	var want bytes.Buffer
+	require.NoError(t, decode.WriteFieldDocs(&want, "config", "fieldDocs", docs))
+	got, err := os.ReadFile("schema_docs.gen.go")
+	require.NoError(t, err)
+	assert.Equal(t, want.String(), string(got),
+		`generated file is out of date; run "make generate-config-docs"`)
+}
+
+func TestWriteSchema(t *testing.T) {
+	for _, kind := range SchemaKinds() {
+		var buf bytes.Buffer
+		require.NoError(t, WriteSchema(&buf, kind), kind)
+		assert.NotEmpty(t, buf.String(), kind)
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteSchema(&buf, "server", "postgres"))
+	assert.Contains(t, buf.String(), "# Postgres configures PostgreSQL as the app database.\npostgres {")
+	assert.Contains(t, buf.String(), "  port = number # required\n")
+	assert.NotContains(t, buf.String(), "algolia {")
+
+	assert.Error(t, WriteSchema(&buf, "unknown"))
+	assert.Error(t, WriteSchema(&buf, "server", "unknown_block"))
+}
@@ -0,0 +1,450 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp-forge/hermes/pkg/attachments"
+	"github.com/hashicorp-forge/hermes/pkg/kafka/producer"
+	"github.com/hashicorp-forge/hermes/pkg/search"
+	"github.com/hashicorp-forge/hermes/pkg/tenant"
+)
+
+// ApplyDefaults applies the defaults for the declared deployment size.
+func (c *Config) ApplyDefaults() {
+	// An invalid deployment size is reported by Validate.
+	_ = c.ApplyDeploymentSize()
+}
+
+// Validate validates the top-level settings.
+func (c *Config) Validate() error {
+	if err := c.validateDeploymentSize(); err != nil {
+		return err
+	}
+	switch c.DatabaseType {
+	case "", "postgres", "sqlite":
+	default:
+		return fmt.Errorf(
+			`invalid database_type %q: must be "postgres" or "sqlite"`,
+			c.DatabaseType)
+	}
+	switch c.LogFormat {
+	case "", "standard", "json":
+	default:
+		return fmt.Errorf(
+			`invalid log_format %q: must be "standard" or "json"`, c.LogFormat)
+	}
+
+	names := map[string]bool{}
+	hosts := map[string]string{}
+	for _, t := range c.Tenants {
+		if names[t.Name] {
+			return fmt.Errorf("duplicate tenant %q", t.Name)
+		}
+		names[t.Name] = true
+		for _, h := range t.Hostnames {
+			h = strings.ToLower(h)
+			if other, ok := hosts[h]; ok {
+				return fmt.Errorf("host %q belongs to tenants %q and %q",
+					h, other, t.Name)
+			}
+			hosts[h] = t.Name
+		}
+	}
+	return nil
+}
+
+// Validate validates the document type settings.
+func (d *DocumentType) Validate() error {
+	if d.ReviewSLA < 0 {
+		return fmt.Errorf("document type %q: review_sla must not be negative",
+			d.Name)
+	}
+	if d.RequiredApprovals < 0 {
+		return fmt.Errorf(
+			"document type %q: required_approvals must not be negative", d.Name)
+	}
+	fields := map[string]bool{}
+	for _, f := range d.CustomFields {
+		if fields[f.Name] {
+			return fmt.Errorf("document type %q: duplicate custom field %q",
+				d.Name, f.Name)
+		}
+		fields[f.Name] = true
+	}
+	for _, name := range d.HeaderCustomFields {
+		if !fields[name] {
+			return fmt.Errorf("document type %q: header custom field %q "+
+				"isn't a custom field of the document type", d.Name, name)
+		}
+	}
+	names := map[string]bool{DefaultTemplateVariant: true}
+	for _, v := range d.TemplateVariants {
+		if names[v.Name] {
+			return fmt.Errorf("document type %q: duplicate template variant %q",
+				d.Name, v.Name)
+		}
+		names[v.Name] = true
+		if v.Template == "" {
+			return fmt.Errorf("document type %q: template variant %q: "+
+				"template is required", d.Name, v.Name)
+		}
+	}
+	items := map[string]bool{}
+	for _, item := range d.ReviewChecklist {
+		if item.Name == "" {
+			return fmt.Errorf(
+				"document type %q: review checklist item name is required", d.Name)
+		}
+		if items[item.Name] {
+			return fmt.Errorf("document type %q: duplicate review checklist item %q",
+				d.Name, item.Name)
+		}
+		items[item.Name] = true
+		if item.Label == "" {
+			return fmt.Errorf("document type %q: review checklist item %q: "+
+				"label is required", d.Name, item.Name)
+		}
+		switch item.RequiredFor {
+		case "", "In-Review", "Approved":
+		default:
+			return fmt.Errorf("document type %q: review checklist item %q: "+
+				"required_for must be \"In-Review\" or \"Approved\"",
+				d.Name, item.Name)
+		}
+	}
+	return nil
+}
+
+// Validate validates the document type custom field settings.
+func (f *DocumentTypeCustomField) Validate() error {
+	switch strings.ToLower(f.Type) {
+	case "boolean", "date", "number", "people", "person", "string":
+		if len(f.Options) > 0 {
+			return fmt.Errorf(
+				"custom field %q: options are only allowed for enum fields", f.Name)
+		}
+	case "enum":
+		if len(f.Options) == 0 {
+			return fmt.Errorf("custom field %q: options must not be empty", f.Name)
+		}
+		seen := map[string]bool{}
+		for _, o := range f.Options {
+			if o == "" || seen[o] {
+				return fmt.Errorf(
+					"custom field %q: options must be unique and not empty", f.Name)
+			}
+			seen[o] = true
+		}
+	default:
+		return fmt.Errorf("custom field %q: invalid type %q", f.Name, f.Type)
+	}
+	return nil
+}
+
+// Validate validates the email settings.
+func (e *Email) Validate() error {
+	if e.Enabled && e.FromAddress == "" {
+		return fmt.Errorf("from_address must be set if email is enabled")
+	}
+	if s := e.SMTP; s != nil {
+		if s.Host == "" {
+			return fmt.Errorf("smtp host is required")
+		}
+		if s.Port < 0 || s.Port > 65535 {
+			return fmt.Errorf("invalid smtp port %d", s.Port)
+		}
+		switch s.TLS {
+		case "", "starttls", "tls", "none":
+		default:
+			return fmt.Errorf(
+				`invalid smtp tls %q (valid: "starttls", "tls", "none")`, s.TLS)
+		}
+		if s.MaxConnections < 0 || s.Timeout < 0 || s.IdleTimeout < 0 {
+			return fmt.Errorf("smtp limits must not be negative")
+		}
+		if d := s.DKIM; d != nil &&
+			(d.Domain == "" || d.Selector == "" || d.PrivateKeyFile == "") {
+			return fmt.Errorf(
+				"dkim domain, selector, and private_key_file are required")
+		}
+	}
+	if a := e.ApprovalLinks; a != nil && a.TTL < 0 {
+		return fmt.Errorf("approval_links ttl must not be negative")
+	}
+	return nil
+}
+
+// Validate validates the integrity settings.
+func (i *Integrity) Validate() error {
+	if i.Interval < 0 {
+		return fmt.Errorf("interval must not be negative")
+	}
+	if i.MaxDocumentsPerRun < 0 {
+		return fmt.Errorf("max_documents_per_run must not be negative")
+	}
+	return nil
+}
+
+// Validate validates the link check settings.
+func (l *LinkCheck) Validate() error {
+	if l.Interval < 0 || l.Timeout < 0 {
+		return fmt.Errorf("interval and timeout must not be negative")
+	}
+	if l.MaxConcurrency < 0 || l.MaxExternalPerRun < 0 ||
+		l.MaxRequestsPerSecond < 0 {
+		return fmt.Errorf("limits must not be negative")
+	}
+	return nil
+}
+
+// Validate validates the migration settings.
+func (m *Migration) Validate() error {
+	switch m.WriteStrategy {
+	case "", "primary_only", "all_writable", "mirror":
+	default:
+		return fmt.Errorf("invalid write_strategy %q", m.WriteStrategy)
+	}
+	switch m.ReadStrategy {
+	case "", "primary_only", "primary_fallback", "load_balance":
+	default:
+		return fmt.Errorf("invalid read_strategy %q", m.ReadStrategy)
+	}
+	if m.PollInterval < 0 || m.MaxConcurrency < 0 {
+		return fmt.Errorf(
+			"poll_interval and max_concurrency must not be negative")
+	}
+	return nil
+}
+
+// Validate validates the PostgreSQL settings.
+func (p *Postgres) Validate() error {
+	if p.Port < 0 || p.Port > 65535 {
+		return fmt.Errorf("invalid port %d", p.Port)
+	}
+	if p.MaxOpenConns < 0 || p.MaxIdleConns < 0 {
+		return fmt.Errorf(
+			"max_open_conns and max_idle_conns must not be negative")
+	}
+	if p.MaxOpenConns > 0 && p.MaxIdleConns > p.MaxOpenConns {
+		return fmt.Errorf(
+			"max_idle_conns (%d) must not be greater than max_open_conns (%d)",
+			p.MaxIdleConns, p.MaxOpenConns)
+	}
+	if p.ConnMaxLifetime < 0 || p.ConnMaxIdleTime < 0 {
+		return fmt.Errorf(
+			"conn_max_lifetime and conn_max_idle_time must not be negative")
+	}
+	return nil
+}
+
+// Validate validates the tenant settings.
+func (t *Tenant) Validate() error {
+	if t.Name == tenant.DefaultName {
+		return fmt.Errorf("tenant name %q is reserved", t.Name)
+	}
+	if len(t.Hostnames) == 0 {
+		return fmt.Errorf("hostnames must not be empty")
+	}
+	return nil
+}
+
+// Validate validates the attachment settings.
+func (a *Attachments) Validate() error {
+	if a.MaxSize < 0 || a.MaxTextSize < 0 {
+		return fmt.Errorf("max_size and max_text_size must not be negative")
+	}
+	if _, err := attachments.New(attachments.Config{Types: a.Types}, nil); err != nil {
+		return err
+	}
+	if a.OCR != nil && a.OCR.Timeout < 0 {
+		return fmt.Errorf("ocr timeout must not be negative")
+	}
+	return nil
+}
+
+// Validate validates the retention settings.
+func (r *Retention) Validate() error {
+	if r.Interval < 0 {
+		return fmt.Errorf("interval must not be negative")
+	}
+	policies, err := r.Policies()
+	if err != nil {
+		return err
+	}
+	names := map[string]bool{}
+	for _, p := range policies {
+		if names[p.Name] {
+			return fmt.Errorf("duplicate policy %q", p.Name)
+		}
+		names[p.Name] = true
+		if err := p.Validate(); err != nil {
+			return fmt.Errorf("policy %q: %w", p.Name, err)
+		}
+	}
+	return nil
+}
+
+// Validate validates the review SLA settings.
+func (r *ReviewSLA) Validate() error {
+	if r.Interval < 0 || r.WarnBefore < 0 {
+		return fmt.Errorf("interval and warn_before must not be negative")
+	}
+	return nil
+}
+
+// Validate validates the saved search digest settings.
+func (s *SavedSearchDigests) Validate() error {
+	if s.Interval < 0 {
+		return fmt.Errorf("interval must not be negative")
+	}
+	return nil
+}
+
+// Validate validates the search settings.
+func (s *Search) Validate() error {
+	indexes := map[string]bool{}
+	for _, r := range s.Ranking {
+		switch r.Index {
+		case search.RankingIndexDocs, search.RankingIndexDrafts,
+			search.RankingIndexProjects:
+		default:
+			return fmt.Errorf(
+				`invalid ranking %q: must be "docs", "drafts", or "projects"`,
+				r.Index)
+		}
+		if indexes[r.Index] {
+			return fmt.Errorf("duplicate ranking %q", r.Index)
+		}
+		indexes[r.Index] = true
+	}
+	for index, r := range s.Rankings() {
+		if err := r.Validate(); err != nil {
+			return fmt.Errorf("ranking %q: %w", index, err)
+		}
+	}
+	return nil
+}
+
+// Validate validates the server settings.
+func (s *Server) Validate() error {
+	if s.DocumentLocationCacheTTL < 0 {
+		return fmt.Errorf("document_location_cache_ttl must not be negative")
+	}
+	if s.ShutdownDrainDelay < 0 || s.ShutdownTimeout < 0 {
+		return fmt.Errorf(
+			"shutdown_drain_delay and shutdown_timeout must not be negative")
+	}
+	return nil
+}
+
+// Validate validates the user activity settings.
+func (a *Activity) Validate() error {
+	if a.Retention < 0 || a.PruneInterval < 0 {
+		return fmt.Errorf("retention and prune_interval must not be negative")
+	}
+	if a.StatsMinReaders < 0 {
+		return fmt.Errorf("stats_min_readers must not be negative")
+	}
+	return nil
+}
+
+// Validate validates the indexer settings.
+func (i *Indexer) Validate() error {
+	names := map[string]bool{}
+	topics := map[string]string{}
+	for _, l := range i.Lanes {
+		if l.Name == "" {
+			return fmt.Errorf("lane name must not be empty")
+		}
+		if names[l.Name] {
+			return fmt.Errorf("duplicate lane %q", l.Name)
+		}
+		names[l.Name] = true
+		if l.Topic == "" {
+			return fmt.Errorf("lane %q: topic must not be empty", l.Name)
+		}
+		if other, ok := topics[l.Topic]; ok {
+			return fmt.Errorf("topic %q belongs to lanes %q and %q",
+				l.Topic, other, l.Name)
+		}
+		topics[l.Topic] = l.Name
+	}
+	return nil
+}
+
+// Validate validates the Kafka producer settings.
+func (p *KafkaProducer) Validate() error {
+	if err := producer.ValidateAcks(p.Acks); err != nil {
+		return err
+	}
+	if err := producer.ValidateCompression(p.Compression); err != nil {
+		return err
+	}
+	if p.Idempotent != nil && *p.Idempotent &&
+		p.Acks != "" && p.Acks != producer.AcksAll {
+		return fmt.Errorf(`idempotent produce requires acks "all"`)
+	}
+	return nil
+}
+
+// Validate validates the job runner settings.
+func (j *Jobs) Validate() error {
+	if j.MaxAttempts < 0 || j.PollInterval < 0 || j.Retention < 0 ||
+		j.Workers < 0 {
+		return fmt.Errorf(
+			"max_attempts, poll_interval, retention, and workers must not be negative")
+	}
+	return nil
+}
+
+// Validate validates the response cache settings.
+func (c *ResponseCache) Validate() error {
+	if c.TTL < 0 || c.MaxEntries < 0 {
+		return fmt.Errorf("ttl and max_entries must not be negative")
+	}
+	if c.Redis != nil && c.Redis.DB < 0 {
+		return fmt.Errorf("redis db must not be negative")
+	}
+	return nil
+}
+
+// Validate validates the soft delete settings.
+func (s *SoftDelete) Validate() error {
+	if s.RecoveryWindow < 0 || s.PurgeInterval < 0 {
+		return fmt.Errorf("recovery_window and purge_interval must not be negative")
+	}
+	return nil
+}
+
+// Validate validates the tracing settings.
+func (t *Tracing) Validate() error {
+	if t.SampleRatio != nil && (*t.SampleRatio < 0 || *t.SampleRatio > 1) {
+		return fmt.Errorf("sample_ratio must be between 0 and 1")
+	}
+	return nil
+}
+
+// Validate validates the worker status settings.
+func (w *WorkerStatus) Validate() error {
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("url must be an http or https URL")
+	}
+	if w.Token == "" {
+		return fmt.Errorf("token must not be empty")
+	}
+	if w.Interval < 0 {
+		return fmt.Errorf("interval must not be negative")
+	}
+	return nil
+}
+
+// Validate validates the workspace provider timeouts.
+func (t *WorkspaceTimeouts) Validate() error {
+	if t.Default < 0 || t.Read < 0 || t.Write < 0 || t.Email < 0 {
+		return fmt.Errorf("default, read, write, and email must not be negative")
+	}
+	return nil
+}
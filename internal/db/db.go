@@ -2,13 +2,17 @@ package db
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 
 	"github.com/hashicorp-forge/hermes/internal/config"
 	"github.com/hashicorp-forge/hermes/pkg/database"
 	"github.com/hashicorp-forge/hermes/pkg/models"
 	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
+	_ "modernc.org/sqlite" // SQLite driver for NewSQLiteDB
 )
 
 // DatabaseConfig holds configuration for database connection.
@@ -39,6 +43,12 @@ func NewDB(cfg config.Postgres) (*gorm.DB, error) {
 		Password: cfg.Password,
 		DBName:   cfg.DBName,
 		SSLMode:  "disable", // Default for backward compatibility
+
+		MaxOpenConns:    cfg.MaxOpenConns,
+		MaxIdleConns:    cfg.MaxIdleConns,
+		ConnMaxLifetime: cfg.ConnMaxLifetime,
+		ConnMaxIdleTime: cfg.ConnMaxIdleTime,
+		ReadReplicaDSN:  cfg.ReadReplicaDSN,
 	}
 
 	// Use shared database connection logic (no logger here for backward compatibility)
@@ -55,9 +65,59 @@ func NewDB(cfg config.Postgres) (*gorm.DB, error) {
 	return db, nil
 }
 
+// NewSQLiteDB returns a new SQLite database stored at path, for single-user
+// and edge deployments and "hermes dev". The directory of path is created if
+// it doesn't exist. The database isn't migrated; see MigrateSQLite.
+//
+// The pure Go modernc.org/sqlite driver is used so the server binary doesn't
+// require cgo, and because it's already linked in for golang-migrate's SQLite
+// support.
+func NewSQLiteDB(path string) (*gorm.DB, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("error creating SQLite database directory: %w", err)
+	}
+
+	dsn := "file:" + path +
+		"?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)"
+	db, err := gorm.Open(sqlite.New(sqlite.Config{
+		DriverName: "sqlite",
+		DSN:        dsn,
+	}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error opening SQLite database: %w", err)
+	}
+
+	if err := setupJoinTables(db); err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// MigrateSQLite migrates an SQLite database opened by NewSQLiteDB. The SQL
+// migrations are written for PostgreSQL, so the models are migrated directly,
+// including the ones that only SQL migrations create on PostgreSQL. Tables
+// without a model, like those of edge sync and storage migrations, aren't
+// created; the features that use them require PostgreSQL.
+func MigrateSQLite(db *gorm.DB) error {
+	if err := db.AutoMigrate(append(models.ModelsToAutoMigrate(),
+		&models.DocumentEmbedding{},
+		&models.DocumentRevisionOutbox{},
+		&models.DocumentRevisionPipelineExecution{},
+		&models.DocumentSummary{},
+		&models.HermesInstance{},
+		&models.Indexer{},
+		&models.IndexerToken{},
+	)...); err != nil {
+		return fmt.Errorf("error migrating SQLite database: %w", err)
+	}
+	return nil
+}
+
 // NewDBWithConfig returns a new database connection using DatabaseConfig.
-// NOTE: Server binary only supports PostgreSQL to avoid SQLite driver conflicts.
-// For SQLite support, use the hermes-migrate binary.
+// SQLite databases are opened with NewSQLiteDB.
 //
 // Deprecated: This function is kept for backward compatibility.
 // New code should use database.Connect() from pkg/database instead.
@@ -71,10 +131,10 @@ func NewDBWithConfig(cfg DatabaseConfig) (*gorm.DB, error) {
 		dialector = postgres.Open(dsn)
 
 	case "sqlite":
-		return nil, fmt.Errorf("SQLite not supported in server binary (avoid driver conflicts). Use hermes-migrate for SQLite migrations. See docs-internal/SQLITE_DRIVER_CONFLICT.md")
+		return NewSQLiteDB(cfg.Path)
 
 	default:
-		return nil, fmt.Errorf("unsupported database driver: %s (server only supports postgres)", cfg.Driver)
+		return nil, fmt.Errorf("unsupported database driver: %s (supported: postgres, sqlite)", cfg.Driver)
 	}
 
 	// Open database connection
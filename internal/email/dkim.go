@@ -0,0 +1,266 @@
+package email
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultDKIMHeaders are the headers signed by default.
+var defaultDKIMHeaders = []string{
+	"From", "To", "Subject", "Date", "Message-ID", "MIME-Version",
+	"Content-Type", "Content-Transfer-Encoding",
+}
+
+// DKIMConfig configures signing emails with DKIM (RFC 6376), so receiving
+// servers can verify that they were sent by the domain of the from address.
+// The public key must be published in the DNS TXT record
+// "<selector>._domainkey.<domain>".
+type DKIMConfig struct {
+	// Domain is the signing domain, usually the domain of the from address.
+	Domain string
+
+	// Selector selects the public key of the domain.
+	Selector string
+
+	// PrivateKey is the PEM-encoded RSA or Ed25519 private key (PKCS #1 or
+	// PKCS #8). PrivateKeyFile is read if PrivateKey is empty.
+	PrivateKey     []byte
+	PrivateKeyFile string
+
+	// Headers are the names of the headers that are signed, if present
+	// (default: From, To, Subject, Date, Message-ID, MIME-Version,
+	// Content-Type, and Content-Transfer-Encoding). From is always signed.
+	Headers []string
+}
+
+// dkimSigner signs messages with DKIM using relaxed header and body
+// canonicalization.
+type dkimSigner struct {
+	domain    string
+	selector  string
+	key       crypto.Signer
+	algorithm string
+	headers   []string
+}
+
+// newDKIMSigner creates a new DKIM signer.
+func newDKIMSigner(cfg DKIMConfig) (*dkimSigner, error) {
+	if cfg.Domain == "" || cfg.Selector == "" {
+		return nil, fmt.Errorf("domain and selector are required")
+	}
+
+	keyPEM := cfg.PrivateKey
+	if len(keyPEM) == 0 {
+		if cfg.PrivateKeyFile == "" {
+			return nil, fmt.Errorf("private key is required")
+		}
+		b, err := os.ReadFile(cfg.PrivateKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading private key: %w", err)
+		}
+		keyPEM = b
+	}
+	key, algorithm, err := parseDKIMKey(keyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := cfg.Headers
+	if len(headers) == 0 {
+		headers = defaultDKIMHeaders
+	}
+	hasFrom := false
+	for _, h := range headers {
+		if strings.EqualFold(h, "From") {
+			hasFrom = true
+		}
+	}
+	if !hasFrom {
+		headers = append([]string{"From"}, headers...)
+	}
+
+	return &dkimSigner{
+		domain:    cfg.Domain,
+		selector:  cfg.Selector,
+		key:       key,
+		algorithm: algorithm,
+		headers:   headers,
+	}, nil
+}
+
+// parseDKIMKey parses a PEM-encoded private key and returns it with its DKIM
+// signing algorithm.
+func parseDKIMKey(keyPEM []byte) (crypto.Signer, string, error) {
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, "", fmt.Errorf("private key isn't PEM-encoded")
+	}
+
+	var key any
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return nil, "", fmt.Errorf("unsupported private key type %q", block.Type)
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("error parsing private key: %w", err)
+	}
+
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return k, "rsa-sha256", nil
+	case ed25519.PrivateKey:
+		return k, "ed25519-sha256", nil
+	}
+	return nil, "", fmt.Errorf("private key must be an RSA or Ed25519 key")
+}
+
+// Sign returns msg, which must have CRLF line endings, with a DKIM-Signature
+// header.
+func (s *dkimSigner) Sign(msg []byte) ([]byte, error) {
+	return s.sign(msg, time.Now())
+}
+
+func (s *dkimSigner) sign(msg []byte, now time.Time) ([]byte, error) {
+	i := bytes.Index(msg, []byte("\r\n\r\n"))
+	if i < 0 {
+		return nil, fmt.Errorf("message has no header")
+	}
+	headers := splitHeaders(string(msg[:i+2]))
+	body := msg[i+4:]
+
+	bodyHash := sha256.Sum256(relaxedBody(body))
+
+	// Headers are signed from the bottom up (RFC 6376, section 5.4.2).
+	var signed []string
+	var names []string
+	for _, name := range s.headers {
+		for j := len(headers) - 1; j >= 0; j-- {
+			if strings.EqualFold(headerName(headers[j]), name) {
+				signed = append(signed, relaxedHeader(headers[j]))
+				names = append(names, strings.ToLower(name))
+				break
+			}
+		}
+	}
+
+	sigHeader := fmt.Sprintf(
+		"DKIM-Signature: v=1; a=%s; c=relaxed/relaxed; d=%s; s=%s; t=%s; h=%s; bh=%s; b=",
+		s.algorithm, s.domain, s.selector,
+		strconv.FormatInt(now.Unix(), 10),
+		strings.Join(names, ":"),
+		base64.StdEncoding.EncodeToString(bodyHash[:]),
+	)
+
+	h := sha256.New()
+	for _, hdr := range signed {
+		h.Write([]byte(hdr))
+		h.Write([]byte("\r\n"))
+	}
+	// The signature header is signed without the trailing CRLF.
+	h.Write([]byte(relaxedHeader(sigHeader)))
+	digest := h.Sum(nil)
+
+	var sig []byte
+	var err error
+	if s.algorithm == "ed25519-sha256" {
+		// Ed25519 signs the SHA-256 digest as the message (RFC 8463).
+		sig, err = s.key.Sign(rand.Reader, digest, crypto.Hash(0))
+	} else {
+		sig, err = s.key.Sign(rand.Reader, digest, crypto.SHA256)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	out.WriteString(sigHeader)
+	out.WriteString(base64.StdEncoding.EncodeToString(sig))
+	out.WriteString("\r\n")
+	out.Write(msg)
+	return out.Bytes(), nil
+}
+
+// splitHeaders splits a message header into header fields, keeping folded
+// lines with their fields.
+func splitHeaders(header string) []string {
+	var fields []string
+	for _, line := range strings.SplitAfter(header, "\r\n") {
+		if line == "" {
+			continue
+		}
+		if (line[0] == ' ' || line[0] == '\t') && len(fields) > 0 {
+			fields[len(fields)-1] += line
+			continue
+		}
+		fields = append(fields, line)
+	}
+	return fields
+}
+
+// headerName returns the name of a header field.
+func headerName(field string) string {
+	name, _, _ := strings.Cut(field, ":")
+	return strings.TrimSpace(name)
+}
+
+// relaxedHeader returns the relaxed canonicalization of a header field,
+// without a trailing CRLF (RFC 6376, section 3.4.2).
+func relaxedHeader(field string) string {
+	name, value, _ := strings.Cut(field, ":")
+	value = strings.ReplaceAll(value, "\r\n", "")
+	value = strings.Join(strings.FieldsFunc(value, isWSP), " ")
+	return strings.ToLower(strings.TrimSpace(name)) + ":" + value
+}
+
+// relaxedBody returns the relaxed canonicalization of a message body
+// (RFC 6376, section 3.4.4).
+func relaxedBody(body []byte) []byte {
+	lines := strings.Split(string(body), "\r\n")
+	for i, line := range lines {
+		// Reduce whitespace to single spaces and remove it at the end of lines.
+		var b strings.Builder
+		space := false
+		for _, r := range line {
+			if isWSP(r) {
+				space = true
+				continue
+			}
+			if space {
+				b.WriteByte(' ')
+			}
+			space = false
+			b.WriteRune(r)
+		}
+		lines[i] = b.String()
+	}
+
+	// Remove empty lines at the end of the body.
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	return []byte(strings.Join(lines, "\r\n") + "\r\n")
+}
+
+func isWSP(r rune) bool {
+	return r == ' ' || r == '\t'
+}
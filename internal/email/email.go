@@ -3,24 +3,44 @@ package email
 import (
 	"bytes"
 	"embed"
+	"errors"
 	"fmt"
+	"io/fs"
+	"path"
 	"strings"
 	"text/template"
 	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
-	"github.com/hashicorp-forge/hermes/pkg/workspace"
+	"github.com/hashicorp-forge/hermes/pkg/i18n"
 )
 
+// Email templates are "templates/<name>.html". Translations are
+// "templates/<locale>/<name>.html" (e.g., "templates/de/new-owner.html"). Each
+// template defines its subject as the "subject" template.
+//
 //go:embed templates/*
 var tmplFS embed.FS
 
-// emailSender defines the interface for sending emails.
-// This is satisfied by workspace.Provider.
-type emailSender interface {
+// templatesFS contains the email templates.
+var templatesFS fs.FS = tmplFS
+
+// ErrNoSender is returned when sending an email without a sender, e.g., with
+// a workspace provider that can't send emails and no SMTP transport.
+var ErrNoSender = errors.New("no email sender configured")
+
+// Sender defines the interface for sending emails.
+// This is satisfied by workspace.Provider and SMTPSender.
+type Sender interface {
 	SendEmail(to []string, from, subject, body string) error
 }
 
+// Message is a rendered email.
+type Message struct {
+	Subject string
+	Body    string
+}
+
 type User struct {
 	EmailAddress string
 	Name         string
@@ -39,6 +59,10 @@ type DocumentApprovedEmailData struct {
 	DocumentType             string
 	DocumentURL              string
 	Product                  string
+
+	// Locale is the locale of the recipient, which selects the translation of
+	// the email (optional).
+	Locale string
 }
 
 type NewOwnerEmailData struct {
@@ -53,6 +77,10 @@ type NewOwnerEmailData struct {
 	NewDocumentOwner    User
 	OldDocumentOwner    User
 	Product             string
+
+	// Locale is the locale of the recipient, which selects the translation of
+	// the email (optional).
+	Locale string
 }
 
 type ReviewRequestedEmailData struct {
@@ -66,6 +94,15 @@ type ReviewRequestedEmailData struct {
 	DocumentStatusClass string
 	DocumentURL         string
 	Product             string
+
+	// ApproveURL and RequestChangesURL are single-use links that approve the
+	// document or request changes of it without opening Hermes (optional).
+	ApproveURL        string
+	RequestChangesURL string
+
+	// Locale is the locale of the recipient, which selects the translation of
+	// the email (optional).
+	Locale string
 }
 
 type SubscriberDocumentPublishedEmailData struct {
@@ -77,14 +114,28 @@ type SubscriberDocumentPublishedEmailData struct {
 	DocumentType      string
 	DocumentURL       string
 	Product           string
+
+	// Locale is the locale of the recipient, which selects the translation of
+	// the email (optional).
+	Locale string
 }
 
 func SendDocumentApprovedEmail(
 	data DocumentApprovedEmailData,
 	to []string,
 	from string,
-	provider workspace.Provider,
+	provider Sender,
 ) error {
+	msg, err := RenderDocumentApprovedEmail(data)
+	if err != nil {
+		return err
+	}
+	return Send(msg, to, from, provider)
+}
+
+// RenderDocumentApprovedEmail renders the email sent to the owner of a
+// document when it's approved.
+func RenderDocumentApprovedEmail(data DocumentApprovedEmailData) (Message, error) {
 	// Validate data.
 	if err := validation.ValidateStruct(&data,
 		validation.Field(&data.BaseURL, validation.Required),
@@ -96,19 +147,12 @@ func SendDocumentApprovedEmail(
 		validation.Field(&data.DocumentType, validation.Required),
 		validation.Field(&data.DocumentStatus, validation.Required),
 	); err != nil {
-		return fmt.Errorf("error validating email data: %w", err)
+		return Message{}, fmt.Errorf("error validating email data: %w", err)
 	}
 	if err := validation.ValidateStruct(&data.DocumentApprover,
 		validation.Field(&data.DocumentApprover.EmailAddress, validation.Required),
 	); err != nil {
-		return fmt.Errorf("error validating email data user: %w", err)
-	}
-
-	// Apply template.
-	var body bytes.Buffer
-	tmpl, err := template.ParseFS(tmplFS, "templates/document-approved.html")
-	if err != nil {
-		return fmt.Errorf("error parsing template: %w", err)
+		return Message{}, fmt.Errorf("error validating email data user: %w", err)
 	}
 
 	// Set current year.
@@ -117,36 +161,25 @@ func SendDocumentApprovedEmail(
 	// Set status class.
 	data.DocumentStatusClass = dasherizeStatus(data.DocumentStatus)
 
-	if err := tmpl.Execute(&body, data); err != nil {
-		return fmt.Errorf("error executing template: %w", err)
-	}
-
-	// Build email subject (name is preferred over email address).
-	approver := data.DocumentApprover.EmailAddress
-	if data.DocumentApprover.Name != "" {
-		approver = data.DocumentApprover.Name
-	}
-	subject := fmt.Sprintf("%s approved by %s",
-		data.DocumentShortName,
-		approver,
-	)
-
-	// Send email.
-	err = provider.SendEmail(
-		to,
-		from,
-		subject,
-		body.String(),
-	)
-	return err
+	return renderEmail("document-approved", data.Locale, data)
 }
 
 func SendNewOwnerEmail(
 	data NewOwnerEmailData,
 	to []string,
 	from string,
-	provider workspace.Provider,
+	provider Sender,
 ) error {
+	msg, err := RenderNewOwnerEmail(data)
+	if err != nil {
+		return err
+	}
+	return Send(msg, to, from, provider)
+}
+
+// RenderNewOwnerEmail renders the email sent to the new owner of a document
+// when its ownership is transferred.
+func RenderNewOwnerEmail(data NewOwnerEmailData) (Message, error) {
 	// Validate data.
 	if err := validation.ValidateStruct(&data,
 		validation.Field(&data.BaseURL, validation.Required),
@@ -159,24 +192,17 @@ func SendNewOwnerEmail(
 		validation.Field(&data.OldDocumentOwner, validation.Required),
 		validation.Field(&data.Product, validation.Required),
 	); err != nil {
-		return fmt.Errorf("error validating email data: %w", err)
+		return Message{}, fmt.Errorf("error validating email data: %w", err)
 	}
 	if err := validation.ValidateStruct(&data.NewDocumentOwner,
 		validation.Field(&data.NewDocumentOwner.EmailAddress, validation.Required),
 	); err != nil {
-		return fmt.Errorf("error validating new document owner: %w", err)
+		return Message{}, fmt.Errorf("error validating new document owner: %w", err)
 	}
 	if err := validation.ValidateStruct(&data.OldDocumentOwner,
 		validation.Field(&data.OldDocumentOwner.EmailAddress, validation.Required),
 	); err != nil {
-		return fmt.Errorf("error validating old document owner: %w", err)
-	}
-
-	// Apply template.
-	var body bytes.Buffer
-	tmpl, err := template.ParseFS(tmplFS, "templates/new-owner.html")
-	if err != nil {
-		return fmt.Errorf("error parsing template: %w", err)
+		return Message{}, fmt.Errorf("error validating old document owner: %w", err)
 	}
 
 	// Set current year.
@@ -185,26 +211,25 @@ func SendNewOwnerEmail(
 	// Set status class.
 	data.DocumentStatusClass = dasherizeStatus(data.DocumentStatus)
 
-	if err := tmpl.Execute(&body, data); err != nil {
-		return fmt.Errorf("error executing template: %w", err)
-	}
-
-	// Send email.
-	err = provider.SendEmail(
-		to,
-		from,
-		fmt.Sprintf("%s transferred to you", data.DocumentShortName),
-		body.String(),
-	)
-	return err
+	return renderEmail("new-owner", data.Locale, data)
 }
 
 func SendReviewRequestedEmail(
 	d ReviewRequestedEmailData,
 	to []string,
 	from string,
-	provider workspace.Provider,
+	provider Sender,
 ) error {
+	msg, err := RenderReviewRequestedEmail(d)
+	if err != nil {
+		return err
+	}
+	return Send(msg, to, from, provider)
+}
+
+// RenderReviewRequestedEmail renders the email sent to the approvers of a
+// document when their review is requested.
+func RenderReviewRequestedEmail(d ReviewRequestedEmailData) (Message, error) {
 	// Validate data.
 	if err := validation.ValidateStruct(&d,
 		validation.Field(&d.BaseURL, validation.Required),
@@ -215,13 +240,7 @@ func SendReviewRequestedEmail(
 		validation.Field(&d.DocumentStatus, validation.Required),
 		validation.Field(&d.DocumentType, validation.Required),
 	); err != nil {
-		return fmt.Errorf("error validating email data: %w", err)
-	}
-
-	var body bytes.Buffer
-	tmpl, err := template.ParseFS(tmplFS, "templates/review-requested.html")
-	if err != nil {
-		return fmt.Errorf("error parsing template: %w", err)
+		return Message{}, fmt.Errorf("error validating email data: %w", err)
 	}
 
 	// Set current year.
@@ -230,25 +249,27 @@ func SendReviewRequestedEmail(
 	// Set status class.
 	d.DocumentStatusClass = dasherizeStatus(d.DocumentStatus)
 
-	if err := tmpl.Execute(&body, d); err != nil {
-		return fmt.Errorf("error executing template: %w", err)
-	}
-
-	err = provider.SendEmail(
-		to,
-		from,
-		fmt.Sprintf("Document review requested for %s", d.DocumentShortName),
-		body.String(),
-	)
-	return err
+	return renderEmail("review-requested", d.Locale, d)
 }
 
 func SendSubscriberDocumentPublishedEmail(
 	d SubscriberDocumentPublishedEmailData,
 	to []string,
 	from string,
-	provider emailSender,
+	provider Sender,
 ) error {
+	msg, err := RenderSubscriberDocumentPublishedEmail(d)
+	if err != nil {
+		return err
+	}
+	return Send(msg, to, from, provider)
+}
+
+// RenderSubscriberDocumentPublishedEmail renders the email sent to the
+// subscribers of a product when a document of the product is published.
+func RenderSubscriberDocumentPublishedEmail(
+	d SubscriberDocumentPublishedEmailData,
+) (Message, error) {
 	// Validate data.
 	if err := validation.ValidateStruct(&d,
 		validation.Field(&d.BaseURL, validation.Required),
@@ -257,34 +278,57 @@ func SendSubscriberDocumentPublishedEmail(
 		validation.Field(&d.DocumentURL, validation.Required),
 		validation.Field(&d.Product, validation.Required),
 	); err != nil {
-		return fmt.Errorf("error validating email data: %w", err)
-	}
-
-	var body bytes.Buffer
-	tmpl, err := template.ParseFS(
-		tmplFS, "templates/subscriber-document-published.html")
-	if err != nil {
-		return fmt.Errorf("error parsing template: %w", err)
+		return Message{}, fmt.Errorf("error validating email data: %w", err)
 	}
 
 	// Set current year.
 	d.CurrentYear = time.Now().Year()
 
-	if err := tmpl.Execute(&body, d); err != nil {
-		return fmt.Errorf("error executing template: %w", err)
+	return renderEmail("subscriber-document-published", d.Locale, d)
+}
+
+// Send sends a rendered email.
+func Send(msg Message, to []string, from string, provider Sender) error {
+	if provider == nil {
+		return ErrNoSender
+	}
+	return provider.SendEmail(to, from, msg.Subject, msg.Body)
+}
+
+// renderEmail renders the email template name in the given locale, or in the
+// default language if the template isn't translated to the locale.
+func renderEmail(name, locale string, data any) (Message, error) {
+	tmpl, err := parseEmailTemplate(name, locale)
+	if err != nil {
+		return Message{}, fmt.Errorf("error parsing template: %w", err)
+	}
+
+	var body bytes.Buffer
+	if err := tmpl.Execute(&body, data); err != nil {
+		return Message{}, fmt.Errorf("error executing template: %w", err)
 	}
+	var subject bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return Message{}, fmt.Errorf("error executing subject template: %w", err)
+	}
+
+	return Message{
+		// Subjects are a single line.
+		Subject: strings.Join(strings.Fields(subject.String()), " "),
+		Body:    body.String(),
+	}, nil
+}
 
-	err = provider.SendEmail(
-		to,
-		from,
-		fmt.Sprintf("New %s: [%s] %s",
-			d.DocumentType,
-			d.DocumentShortName,
-			d.DocumentTitle,
-		),
-		body.String(),
-	)
-	return err
+// parseEmailTemplate parses the first template name of the fallback chain of
+// locale that exists.
+func parseEmailTemplate(name, locale string) (*template.Template, error) {
+	for _, l := range i18n.Chain(locale) {
+		p := path.Join("templates", l, name+".html")
+		if _, err := fs.Stat(templatesFS, p); err == nil {
+			return template.ParseFS(templatesFS, p)
+		}
+	}
+	return template.ParseFS(templatesFS, path.Join("templates", name+".html"))
 }
 
 func dasherizeStatus(status string) string {
@@ -0,0 +1,104 @@
+package email
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderDocumentApprovedEmail(t *testing.T) {
+	data := DocumentApprovedEmailData{
+		BaseURL:           "https://hermes.example.com",
+		DocumentApprover:  User{EmailAddress: "approver@example.com"},
+		DocumentShortName: "HER-001",
+		DocumentTitle:     "Rollout",
+		DocumentStatus:    "In-Review",
+		DocumentType:      "RFC",
+		DocumentURL:       "https://hermes.example.com/document/1",
+		Product:           "Hermes",
+	}
+
+	msg, err := RenderDocumentApprovedEmail(data)
+	require.NoError(t, err)
+	assert.Equal(t, "HER-001 approved by approver@example.com", msg.Subject)
+	assert.Contains(t, msg.Body, "Rollout")
+
+	// The name of the approver is preferred over their email address.
+	data.DocumentApprover.Name = "Approver"
+	msg, err = RenderDocumentApprovedEmail(data)
+	require.NoError(t, err)
+	assert.Equal(t, "HER-001 approved by Approver", msg.Subject)
+
+	// Untranslated locales use the default templates.
+	data.Locale = "xx-YY"
+	msg, err = RenderDocumentApprovedEmail(data)
+	require.NoError(t, err)
+	assert.Equal(t, "HER-001 approved by Approver", msg.Subject)
+}
+
+func TestRenderReviewRequestedEmail_ApprovalLinks(t *testing.T) {
+	data := ReviewRequestedEmailData{
+		BaseURL:           "https://hermes.example.com",
+		DocumentOwner:     "owner@example.com",
+		DocumentShortName: "HER-001",
+		DocumentTitle:     "Rollout",
+		DocumentType:      "RFC",
+		DocumentStatus:    "In-Review",
+		DocumentURL:       "https://hermes.example.com/document/1",
+		Product:           "Hermes",
+	}
+
+	msg, err := RenderReviewRequestedEmail(data)
+	require.NoError(t, err)
+	assert.NotContains(t, msg.Body, "/approval-links/")
+
+	data.ApproveURL = "https://hermes.example.com/approval-links/t?action=approve"
+	data.RequestChangesURL =
+		"https://hermes.example.com/approval-links/t?action=request-changes"
+	msg, err = RenderReviewRequestedEmail(data)
+	require.NoError(t, err)
+	assert.Contains(t, msg.Body,
+		`href="https://hermes.example.com/approval-links/t?action=approve"`)
+	assert.Contains(t, msg.Body,
+		`href="https://hermes.example.com/approval-links/t?action=request-changes"`)
+}
+
+func TestRenderEmail_LocaleFallback(t *testing.T) {
+	orig := templatesFS
+	defer func() { templatesFS = orig }()
+	templatesFS = fstest.MapFS{
+		"templates/greeting.html": {Data: []byte(
+			`Hello {{.}}{{define "subject"}}Hi{{end}}`)},
+		"templates/de/greeting.html": {Data: []byte(
+			`Hallo {{.}}{{define "subject"}}Hallo{{end}}`)},
+		"templates/pt-BR/greeting.html": {Data: []byte(
+			`Olá {{.}}{{define "subject"}}
+  Oi
+{{end}}`)},
+	}
+
+	tests := map[string]struct {
+		locale      string
+		wantSubject string
+		wantBody    string
+	}{
+		"default":         {locale: "", wantSubject: "Hi", wantBody: "Hello Ada"},
+		"exact locale":    {locale: "pt-BR", wantSubject: "Oi", wantBody: "Olá Ada"},
+		"normalized":      {locale: "pt_br", wantSubject: "Oi", wantBody: "Olá Ada"},
+		"base language":   {locale: "de-AT", wantSubject: "Hallo", wantBody: "Hallo Ada"},
+		"untranslated":    {locale: "fr", wantSubject: "Hi", wantBody: "Hello Ada"},
+		"invalid locale":  {locale: "../de", wantSubject: "Hi", wantBody: "Hello Ada"},
+		"other base lang": {locale: "pt-PT", wantSubject: "Hi", wantBody: "Hello Ada"},
+	}
+
+	for name, c := range tests {
+		t.Run(name, func(t *testing.T) {
+			msg, err := renderEmail("greeting", c.locale, "Ada")
+			require.NoError(t, err)
+			assert.Equal(t, c.wantSubject, msg.Subject)
+			assert.Equal(t, c.wantBody, msg.Body)
+		})
+	}
+}
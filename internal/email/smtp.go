@@ -0,0 +1,360 @@
+package email
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SMTP TLS modes.
+const (
+	// SMTPTLSStartTLS upgrades connections with STARTTLS, which the server
+	// must support.
+	SMTPTLSStartTLS = "starttls"
+
+	// SMTPTLSImplicit connects with TLS (e.g., to port 465).
+	SMTPTLSImplicit = "tls"
+
+	// SMTPTLSNone doesn't encrypt connections. Credentials are only sent over
+	// unencrypted connections to localhost.
+	SMTPTLSNone = "none"
+)
+
+const (
+	defaultSMTPPort           = 587
+	defaultSMTPMaxConnections = 2
+	defaultSMTPTimeout        = 30 * time.Second
+	defaultSMTPIdleTimeout    = time.Minute
+)
+
+// SMTPConfig configures sending email through an SMTP server.
+type SMTPConfig struct {
+	// Host is the SMTP server host name.
+	Host string
+
+	// Port is the SMTP server port (default: 587).
+	Port int
+
+	// Username and Password authenticate with the server with the PLAIN
+	// mechanism (optional).
+	Username string
+	Password string
+
+	// TLS is SMTPTLSStartTLS (default), SMTPTLSImplicit, or SMTPTLSNone.
+	TLS string
+
+	// InsecureSkipVerify disables verifying the certificate of the server.
+	InsecureSkipVerify bool
+
+	// MaxConnections is the maximum number of open connections to the server
+	// (default: 2). Connections are reused for later emails.
+	MaxConnections int
+
+	// Timeout is the timeout of sending an email, including connecting to the
+	// server (default: 30s).
+	Timeout time.Duration
+
+	// IdleTimeout is how long unused connections are kept open (default: 1m).
+	IdleTimeout time.Duration
+
+	// DKIM signs emails with DKIM if it isn't nil.
+	DKIM *DKIMConfig
+}
+
+// SMTPSender sends emails through an SMTP server. It implements Sender, so it
+// can be used instead of the workspace provider, e.g., by deployments that
+// don't use Google Workspace.
+type SMTPSender struct {
+	cfg    SMTPConfig
+	addr   string
+	dkim   *dkimSigner
+	tokens chan struct{}
+
+	mu   sync.Mutex
+	idle []*smtpConn
+}
+
+// smtpConn is a connection to the SMTP server.
+type smtpConn struct {
+	conn     net.Conn
+	client   *smtp.Client
+	lastUsed time.Time
+}
+
+var _ Sender = (*SMTPSender)(nil)
+
+// NewSMTPSender creates a new SMTP sender. Connections are opened when emails
+// are sent.
+func NewSMTPSender(cfg SMTPConfig) (*SMTPSender, error) {
+	if cfg.Host == "" {
+		return nil, fmt.Errorf("host is required")
+	}
+	if cfg.Port == 0 {
+		cfg.Port = defaultSMTPPort
+	}
+	switch cfg.TLS {
+	case "":
+		cfg.TLS = SMTPTLSStartTLS
+	case SMTPTLSStartTLS, SMTPTLSImplicit, SMTPTLSNone:
+	default:
+		return nil, fmt.Errorf("invalid TLS mode %q", cfg.TLS)
+	}
+	if cfg.MaxConnections <= 0 {
+		cfg.MaxConnections = defaultSMTPMaxConnections
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultSMTPTimeout
+	}
+	if cfg.IdleTimeout <= 0 {
+		cfg.IdleTimeout = defaultSMTPIdleTimeout
+	}
+
+	s := &SMTPSender{
+		cfg:    cfg,
+		addr:   net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)),
+		tokens: make(chan struct{}, cfg.MaxConnections),
+	}
+	if cfg.DKIM != nil {
+		d, err := newDKIMSigner(*cfg.DKIM)
+		if err != nil {
+			return nil, fmt.Errorf("error configuring DKIM: %w", err)
+		}
+		s.dkim = d
+	}
+	return s, nil
+}
+
+// SendEmail sends an HTML email.
+func (s *SMTPSender) SendEmail(to []string, from, subject, body string) error {
+	if len(to) == 0 {
+		return fmt.Errorf("no recipients")
+	}
+	sender, err := mail.ParseAddress(from)
+	if err != nil {
+		return fmt.Errorf("invalid from address: %w", err)
+	}
+
+	msg, err := buildMessage(to, from, subject, body, time.Now())
+	if err != nil {
+		return err
+	}
+	if s.dkim != nil {
+		if msg, err = s.dkim.Sign(msg); err != nil {
+			return fmt.Errorf("error signing email: %w", err)
+		}
+	}
+
+	// Limit the number of open connections.
+	s.tokens <- struct{}{}
+	defer func() { <-s.tokens }()
+
+	c, err := s.conn()
+	if err != nil {
+		return err
+	}
+	if err := s.send(c, sender.Address, to, msg); err != nil {
+		c.close()
+		return err
+	}
+	s.release(c)
+	return nil
+}
+
+// Close closes the idle connections to the server.
+func (s *SMTPSender) Close() error {
+	s.mu.Lock()
+	idle := s.idle
+	s.idle = nil
+	s.mu.Unlock()
+
+	for _, c := range idle {
+		_ = c.client.Quit()
+		c.close()
+	}
+	return nil
+}
+
+// conn returns an idle connection that is still usable, or a new connection.
+func (s *SMTPSender) conn() (*smtpConn, error) {
+	for {
+		s.mu.Lock()
+		if len(s.idle) == 0 {
+			s.mu.Unlock()
+			break
+		}
+		c := s.idle[len(s.idle)-1]
+		s.idle = s.idle[:len(s.idle)-1]
+		s.mu.Unlock()
+
+		if time.Since(c.lastUsed) > s.cfg.IdleTimeout {
+			_ = c.client.Quit()
+			c.close()
+			continue
+		}
+		// The server may have closed the connection.
+		_ = c.conn.SetDeadline(time.Now().Add(s.cfg.Timeout))
+		if err := c.client.Reset(); err != nil {
+			c.close()
+			continue
+		}
+		return c, nil
+	}
+
+	return s.dial()
+}
+
+// dial opens and authenticates a new connection.
+func (s *SMTPSender) dial() (*smtpConn, error) {
+	tlsConfig := &tls.Config{
+		ServerName:         s.cfg.Host,
+		InsecureSkipVerify: s.cfg.InsecureSkipVerify,
+	}
+	dialer := &net.Dialer{Timeout: s.cfg.Timeout}
+
+	var conn net.Conn
+	var err error
+	if s.cfg.TLS == SMTPTLSImplicit {
+		conn, err = tls.DialWithDialer(dialer, "tcp", s.addr, tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", s.addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to SMTP server: %w", err)
+	}
+	_ = conn.SetDeadline(time.Now().Add(s.cfg.Timeout))
+
+	client, err := smtp.NewClient(conn, s.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("error connecting to SMTP server: %w", err)
+	}
+	c := &smtpConn{conn: conn, client: client}
+
+	if s.cfg.TLS == SMTPTLSStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			c.close()
+			return nil, fmt.Errorf("SMTP server doesn't support STARTTLS")
+		}
+		if err := client.StartTLS(tlsConfig); err != nil {
+			c.close()
+			return nil, fmt.Errorf("error starting TLS: %w", err)
+		}
+	}
+
+	if s.cfg.Username != "" {
+		auth := smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)
+		if err := client.Auth(auth); err != nil {
+			c.close()
+			return nil, fmt.Errorf("error authenticating with SMTP server: %w", err)
+		}
+	}
+	return c, nil
+}
+
+// send sends a message over connection c.
+func (s *SMTPSender) send(c *smtpConn, from string, to []string, msg []byte) error {
+	_ = c.conn.SetDeadline(time.Now().Add(s.cfg.Timeout))
+
+	if err := c.client.Mail(from); err != nil {
+		return fmt.Errorf("error sending email: %w", err)
+	}
+	for _, addr := range to {
+		if err := c.client.Rcpt(addr); err != nil {
+			return fmt.Errorf("error sending email to %s: %w", addr, err)
+		}
+	}
+	w, err := c.client.Data()
+	if err != nil {
+		return fmt.Errorf("error sending email: %w", err)
+	}
+	if _, err := w.Write(msg); err != nil {
+		w.Close()
+		return fmt.Errorf("error sending email: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("error sending email: %w", err)
+	}
+	return nil
+}
+
+// release returns a connection to the idle connections.
+func (s *SMTPSender) release(c *smtpConn) {
+	c.lastUsed = time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.idle) >= s.cfg.MaxConnections {
+		_ = c.client.Quit()
+		c.close()
+		return
+	}
+	s.idle = append(s.idle, c)
+}
+
+func (c *smtpConn) close() {
+	_ = c.client.Close()
+}
+
+// buildMessage builds an HTML email message with CRLF line endings.
+func buildMessage(
+	to []string, from, subject, body string, date time.Time,
+) ([]byte, error) {
+	for _, v := range append([]string{from, subject}, to...) {
+		if strings.ContainsAny(v, "\r\n") {
+			return nil, errors.New("email headers must not contain line breaks")
+		}
+	}
+
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, fmt.Errorf("error generating message ID: %w", err)
+	}
+	domain := "localhost"
+	if addr, err := mail.ParseAddress(from); err == nil {
+		if i := strings.LastIndex(addr.Address, "@"); i >= 0 {
+			domain = addr.Address[i+1:]
+		}
+	}
+
+	var b bytes.Buffer
+	header := func(name, value string) {
+		b.WriteString(name)
+		b.WriteString(": ")
+		b.WriteString(value)
+		b.WriteString("\r\n")
+	}
+	header("From", from)
+	header("To", strings.Join(to, ", "))
+	header("Subject", mime.QEncoding.Encode("utf-8", subject))
+	header("Date", date.Format(time.RFC1123Z))
+	header("Message-ID", "<"+hex.EncodeToString(id)+"@"+domain+">")
+	header("MIME-Version", "1.0")
+	header("Content-Type", "text/html; charset=UTF-8")
+	header("Content-Transfer-Encoding", "quoted-printable")
+	b.WriteString("\r\n")
+
+	var qp bytes.Buffer
+	w := quotedprintable.NewWriter(&qp)
+	if _, err := w.Write([]byte(body)); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	// Quoted-printable keeps line breaks of the body, which must be CRLF.
+	encoded := strings.ReplaceAll(qp.String(), "\r\n", "\n")
+	b.WriteString(strings.ReplaceAll(encoded, "\n", "\r\n"))
+	b.WriteString("\r\n")
+	return b.Bytes(), nil
+}
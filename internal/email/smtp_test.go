@@ -0,0 +1,249 @@
+package email
+
+import (
+	"bufio"
+	"bytes"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSMTPServer is a minimal SMTP server that records the messages it
+// receives.
+type fakeSMTPServer struct {
+	ln net.Listener
+
+	mu       sync.Mutex
+	conns    int
+	messages []string
+}
+
+func newFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &fakeSMTPServer{ln: ln}
+	t.Cleanup(func() { ln.Close() })
+	go s.serve()
+	return s
+}
+
+func (s *fakeSMTPServer) serve() {
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.conns++
+		s.mu.Unlock()
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeSMTPServer) handle(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+
+	reply("220 localhost ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			reply("250 localhost")
+		case cmd == "DATA":
+			reply("354 go ahead")
+			var msg strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+				msg.WriteString(l)
+			}
+			s.mu.Lock()
+			s.messages = append(s.messages, msg.String())
+			s.mu.Unlock()
+			reply("250 ok")
+		case cmd == "QUIT":
+			reply("221 bye")
+			return
+		default:
+			// MAIL, RCPT, RSET, and NOOP.
+			reply("250 ok")
+		}
+	}
+}
+
+func TestSMTPSender_SendEmail(t *testing.T) {
+	srv := newFakeSMTPServer(t)
+	host, port, err := net.SplitHostPort(srv.ln.Addr().String())
+	require.NoError(t, err)
+	p, err := strconv.Atoi(port)
+	require.NoError(t, err)
+
+	sender, err := NewSMTPSender(SMTPConfig{
+		Host: host,
+		Port: p,
+		TLS:  SMTPTLSNone,
+	})
+	require.NoError(t, err)
+	defer sender.Close()
+
+	for i := 0; i < 3; i++ {
+		err := sender.SendEmail(
+			[]string{"to@example.com"},
+			"Hermes <hermes@example.com>",
+			"Document approved",
+			"<p>Your document was approved.</p>",
+		)
+		require.NoError(t, err)
+	}
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	assert.Equal(t, 1, srv.conns, "connection should be reused")
+	require.Len(t, srv.messages, 3)
+	assert.Contains(t, srv.messages[0], "Subject: Document approved\r\n")
+	assert.Contains(t, srv.messages[0], "To: to@example.com\r\n")
+	assert.Contains(t, srv.messages[0], "@example.com>\r\n")
+	assert.Contains(t, srv.messages[0], "<p>Your document was approved.</p>")
+}
+
+func TestNewSMTPSender_InvalidConfig(t *testing.T) {
+	_, err := NewSMTPSender(SMTPConfig{})
+	assert.Error(t, err)
+
+	_, err = NewSMTPSender(SMTPConfig{Host: "localhost", TLS: "ssl"})
+	assert.Error(t, err)
+}
+
+func TestBuildMessage_RejectsLineBreaks(t *testing.T) {
+	_, err := buildMessage(
+		[]string{"to@example.com"},
+		"hermes@example.com",
+		"Subject\r\nBcc: attacker@example.com",
+		"body",
+		time.Now(),
+	)
+	assert.Error(t, err)
+}
+
+func TestRelaxedCanonicalization(t *testing.T) {
+	assert.Equal(t, "subject:Hello world",
+		relaxedHeader("SubJect :  Hello \r\n\t world \r\n"))
+
+	assert.Equal(t, " C\r\nD E\r\n",
+		string(relaxedBody([]byte(" C \r\nD \t E\r\n\r\n\r\n"))))
+	assert.Nil(t, relaxedBody([]byte("\r\n\r\n")))
+}
+
+func TestDKIMSigner_Sign(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	rsaPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(rsaKey),
+	})
+
+	edPub, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	edDER, err := x509.MarshalPKCS8PrivateKey(edKey)
+	require.NoError(t, err)
+	edPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: edDER})
+
+	tests := map[string]struct {
+		key       []byte
+		algorithm string
+		verify    func(t *testing.T, digest, sig []byte)
+	}{
+		"rsa": {
+			key:       rsaPEM,
+			algorithm: "rsa-sha256",
+			verify: func(t *testing.T, digest, sig []byte) {
+				assert.NoError(t, rsa.VerifyPKCS1v15(
+					&rsaKey.PublicKey, crypto.SHA256, digest, sig))
+			},
+		},
+		"ed25519": {
+			key:       edPEM,
+			algorithm: "ed25519-sha256",
+			verify: func(t *testing.T, digest, sig []byte) {
+				assert.True(t, ed25519.Verify(edPub, digest, sig))
+			},
+		},
+	}
+
+	for name, c := range tests {
+		t.Run(name, func(t *testing.T) {
+			s, err := newDKIMSigner(DKIMConfig{
+				Domain:     "example.com",
+				Selector:   "hermes",
+				PrivateKey: c.key,
+			})
+			require.NoError(t, err)
+
+			date := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+			msg, err := buildMessage(
+				[]string{"to@example.com"}, "hermes@example.com",
+				"Hello", "<p>Hi</p>", date)
+			require.NoError(t, err)
+
+			signed, err := s.sign(msg, date)
+			require.NoError(t, err)
+			require.True(t, bytes.HasSuffix(signed, msg))
+
+			sigLine := strings.TrimSuffix(
+				string(signed[:len(signed)-len(msg)]), "\r\n")
+			assert.Contains(t, sigLine, "a="+c.algorithm+";")
+			assert.Contains(t, sigLine, "d=example.com;")
+			assert.Contains(t, sigLine, "s=hermes;")
+			assert.Contains(t, sigLine,
+				"t="+strconv.FormatInt(date.Unix(), 10)+";")
+
+			// Verify the signature like a receiving server.
+			i := strings.LastIndex(sigLine, "b=")
+			sig, err := base64.StdEncoding.DecodeString(sigLine[i+2:])
+			require.NoError(t, err)
+
+			hdrEnd := bytes.Index(msg, []byte("\r\n\r\n"))
+			headers := splitHeaders(string(msg[:hdrEnd+2]))
+			h := sha256.New()
+			for _, name := range s.headers {
+				for _, field := range headers {
+					if strings.EqualFold(headerName(field), name) {
+						h.Write([]byte(relaxedHeader(field) + "\r\n"))
+					}
+				}
+			}
+			h.Write([]byte(relaxedHeader(sigLine[:i+2])))
+			c.verify(t, h.Sum(nil), sig)
+
+			bodyHash := sha256.Sum256(relaxedBody(msg[hdrEnd+4:]))
+			assert.Contains(t, sigLine,
+				"bh="+base64.StdEncoding.EncodeToString(bodyHash[:])+";")
+		})
+	}
+}
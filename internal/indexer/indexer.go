@@ -11,6 +11,7 @@ import (
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/hashicorp-forge/hermes/internal/config"
 	"github.com/hashicorp-forge/hermes/pkg/algolia"
+	"github.com/hashicorp-forge/hermes/pkg/attachments"
 	"github.com/hashicorp-forge/hermes/pkg/document"
 	"github.com/hashicorp-forge/hermes/pkg/links"
 	"github.com/hashicorp-forge/hermes/pkg/models"
@@ -507,6 +508,15 @@ func (idx *Indexer) Run() error {
 
 			// Update document object with content and latest modified time.
 			doc.Content = (string(content))
+
+			// Include the text of attached files in search.
+			var atts models.DocumentAttachments
+			if err := atts.Find(db, dbDoc.ID); err != nil {
+				logError("error getting document attachments", err)
+				os.Exit(1)
+			}
+			doc.Content = attachments.WithText(
+				doc.Content, attachments.IndexedText(atts))
 			doc.ModifiedTime = modifiedTime.Unix()
 
 			// Save the document in Algolia.
@@ -3,30 +3,33 @@ package migrate
 import (
 	"database/sql"
 	"embed"
+	"errors"
 	"fmt"
+	"os"
 
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
 	"github.com/golang-migrate/migrate/v4/database/sqlite"
+	"github.com/golang-migrate/migrate/v4/source"
 	"github.com/golang-migrate/migrate/v4/source/iofs"
 )
 
 //go:embed migrations/*.sql migrations/db-specific/*.sql
 var migrationsFS embed.FS
 
-// RunMigrations applies all pending migrations for the given database driver.
-// Supports both PostgreSQL and SQLite with core + database-specific migrations.
-func RunMigrations(db *sql.DB, driver string) error {
+// newMigrator returns a migration instance for the embedded migrations and
+// the given database driver.
+func newMigrator(db *sql.DB, driver string) (*migrate.Migrate, source.Driver, error) {
 	// Validate driver
 	if driver != "postgres" && driver != "sqlite" {
-		return fmt.Errorf("unsupported database driver: %s (supported: postgres, sqlite)", driver)
+		return nil, nil, fmt.Errorf("unsupported database driver: %s (supported: postgres, sqlite)", driver)
 	}
 
 	// Create source driver from embedded migrations
 	sourceDriver, err := iofs.New(migrationsFS, "migrations")
 	if err != nil {
-		return fmt.Errorf("failed to load migration source: %w", err)
+		return nil, nil, fmt.Errorf("failed to load migration source: %w", err)
 	}
 
 	// Create database driver based on type
@@ -35,12 +38,12 @@ func RunMigrations(db *sql.DB, driver string) error {
 	case "postgres":
 		databaseDriver, err = postgres.WithInstance(db, &postgres.Config{})
 		if err != nil {
-			return fmt.Errorf("failed to create postgres driver: %w", err)
+			return nil, nil, fmt.Errorf("failed to create postgres driver: %w", err)
 		}
 	case "sqlite":
 		databaseDriver, err = sqlite.WithInstance(db, &sqlite.Config{})
 		if err != nil {
-			return fmt.Errorf("failed to create sqlite driver: %w", err)
+			return nil, nil, fmt.Errorf("failed to create sqlite driver: %w", err)
 		}
 	}
 
@@ -50,7 +53,18 @@ func RunMigrations(db *sql.DB, driver string) error {
 		driver, databaseDriver,
 	)
 	if err != nil {
-		return fmt.Errorf("failed to create migration instance: %w", err)
+		return nil, nil, fmt.Errorf("failed to create migration instance: %w", err)
+	}
+
+	return m, sourceDriver, nil
+}
+
+// RunMigrations applies all pending migrations for the given database driver.
+// Supports both PostgreSQL and SQLite with core + database-specific migrations.
+func RunMigrations(db *sql.DB, driver string) error {
+	m, _, err := newMigrator(db, driver)
+	if err != nil {
+		return err
 	}
 
 	// Run core migrations (works for both databases)
@@ -68,6 +82,200 @@ func RunMigrations(db *sql.DB, driver string) error {
 	return nil
 }
 
+// MigrateTo migrates up or down to the given version, or rolls back all
+// migrations if version is 0. Database-specific enhancements are only applied
+// when the target is the latest version, since they depend on the complete
+// core schema, and are rolled back when migrating down from it.
+func MigrateTo(db *sql.DB, driver string, version uint) error {
+	m, sourceDriver, err := newMigrator(db, driver)
+	if err != nil {
+		return err
+	}
+	if version > 0 && !hasVersion(sourceDriver, version) {
+		return fmt.Errorf("unknown migration version %d", version)
+	}
+	latest, err := latestVersion(sourceDriver)
+	if err != nil {
+		return err
+	}
+
+	// Database-specific enhancements depend on the complete core schema, so
+	// they're rolled back before migrating down from the latest version.
+	if version < latest {
+		if err := rollbackFromLatest(db, driver, m, latest); err != nil {
+			return err
+		}
+	}
+
+	// Version 0 rolls back all migrations.
+	if version == 0 {
+		if err := m.Down(); err != nil && err != migrate.ErrNoChange {
+			return fmt.Errorf("down migration failed: %w", err)
+		}
+		return nil
+	}
+
+	if err := m.Migrate(version); err != nil && err != migrate.ErrNoChange {
+		return fmt.Errorf("migration to version %d failed: %w", version, err)
+	}
+
+	if version == latest {
+		if err := applyDatabaseSpecificMigrations(db, driver); err != nil {
+			return fmt.Errorf("database-specific migrations failed: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// MigrateDown rolls back the given number of applied migrations. If the
+// database is at the latest version, the database-specific enhancements are
+// rolled back first.
+func MigrateDown(db *sql.DB, driver string, steps int) error {
+	if steps <= 0 {
+		return fmt.Errorf("number of migrations to roll back must be positive")
+	}
+
+	m, sourceDriver, err := newMigrator(db, driver)
+	if err != nil {
+		return err
+	}
+	latest, err := latestVersion(sourceDriver)
+	if err != nil {
+		return err
+	}
+	if err := rollbackFromLatest(db, driver, m, latest); err != nil {
+		return err
+	}
+
+	if err := m.Steps(-steps); err != nil && err != migrate.ErrNoChange {
+		return fmt.Errorf("down migration failed: %w", err)
+	}
+
+	return nil
+}
+
+// Baseline records the given version as applied without running any
+// migrations. Use it to adopt an existing database whose schema was created
+// outside the migration tool, or to clear the dirty flag after manually
+// repairing a failed migration. Version 0 records that no migrations are
+// applied.
+func Baseline(db *sql.DB, driver string, version uint) error {
+	m, sourceDriver, err := newMigrator(db, driver)
+	if err != nil {
+		return err
+	}
+	forceVersion := database.NilVersion
+	if version > 0 {
+		if !hasVersion(sourceDriver, version) {
+			return fmt.Errorf("unknown migration version %d", version)
+		}
+		forceVersion = int(version)
+	}
+
+	if err := m.Force(forceVersion); err != nil {
+		return fmt.Errorf("failed to set version %d: %w", version, err)
+	}
+
+	return nil
+}
+
+// MigrationStatus is the state of a single migration.
+type MigrationStatus struct {
+	Version uint
+	Name    string
+	Applied bool
+}
+
+// Status is the migration state of a database.
+type Status struct {
+	// Version is the current migration version, or 0 if no migrations have
+	// been applied.
+	Version uint
+
+	// Dirty is true if the migration to Version failed partway through.
+	Dirty bool
+
+	// Migrations are all known migrations in order.
+	Migrations []MigrationStatus
+}
+
+// GetStatus returns the current version and the applied and pending
+// migrations of the database.
+func GetStatus(db *sql.DB, driver string) (*Status, error) {
+	m, sourceDriver, err := newMigrator(db, driver)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &Status{}
+	status.Version, status.Dirty, err = m.Version()
+	if err != nil && err != migrate.ErrNilVersion {
+		return nil, fmt.Errorf("failed to get migration version: %w", err)
+	}
+
+	// Migrations up to the current version are applied. A dirty migration is
+	// reported as applied, since it was at least partially run.
+	v, err := sourceDriver.First()
+	for err == nil {
+		r, identifier, readErr := sourceDriver.ReadUp(v)
+		if readErr != nil {
+			return nil, fmt.Errorf("failed to read migration %d: %w", v, readErr)
+		}
+		r.Close()
+
+		status.Migrations = append(status.Migrations, MigrationStatus{
+			Version: v,
+			Name:    identifier,
+			Applied: status.Version > 0 && v <= status.Version,
+		})
+		v, err = sourceDriver.Next(v)
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to list migrations: %w", err)
+	}
+
+	return status, nil
+}
+
+// DirtyVersion returns the version of the failed migration if err is caused by
+// a dirty database.
+func DirtyVersion(err error) (int, bool) {
+	var dirtyErr migrate.ErrDirty
+	if errors.As(err, &dirtyErr) {
+		return dirtyErr.Version, true
+	}
+	return 0, false
+}
+
+// hasVersion returns true if there is a migration with the given version.
+func hasVersion(sourceDriver source.Driver, version uint) bool {
+	r, _, err := sourceDriver.ReadUp(version)
+	if err != nil {
+		return false
+	}
+	r.Close()
+	return true
+}
+
+// latestVersion returns the version of the last migration.
+func latestVersion(sourceDriver source.Driver) (uint, error) {
+	v, err := sourceDriver.First()
+	if err != nil {
+		return 0, fmt.Errorf("failed to list migrations: %w", err)
+	}
+	for {
+		next, err := sourceDriver.Next(v)
+		if errors.Is(err, os.ErrNotExist) {
+			return v, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to list migrations: %w", err)
+		}
+		v = next
+	}
+}
+
 // applyDatabaseSpecificMigrations applies PostgreSQL or SQLite specific schema enhancements.
 // These migrations are applied after core migrations and handle database-specific features.
 func applyDatabaseSpecificMigrations(db *sql.DB, driver string) error {
@@ -104,32 +312,63 @@ func applyDatabaseSpecificMigrations(db *sql.DB, driver string) error {
 	return nil
 }
 
-// GetMigrationVersion returns the current migration version.
-func GetMigrationVersion(db *sql.DB, driver string) (version uint, dirty bool, err error) {
-	sourceDriver, err := iofs.New(migrationsFS, "migrations")
+// rollbackFromLatest rolls back the database-specific enhancements if the
+// database is at the latest version, where they were applied.
+func rollbackFromLatest(db *sql.DB, driver string, m *migrate.Migrate, latest uint) error {
+	current, dirty, err := m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return nil
+	}
 	if err != nil {
-		return 0, false, fmt.Errorf("failed to load migration source: %w", err)
+		return fmt.Errorf("failed to get migration version: %w", err)
+	}
+	if current != latest || dirty {
+		return nil
+	}
+	if err := rollbackDatabaseSpecificMigrations(db, driver); err != nil {
+		return fmt.Errorf("database-specific rollback failed: %w", err)
 	}
+	return nil
+}
+
+// rollbackDatabaseSpecificMigrations rolls back the PostgreSQL or SQLite
+// specific schema enhancements applied by applyDatabaseSpecificMigrations, in
+// reverse order.
+func rollbackDatabaseSpecificMigrations(db *sql.DB, driver string) error {
+	var migrations []string
 
-	var databaseDriver database.Driver
 	switch driver {
 	case "postgres":
-		databaseDriver, err = postgres.WithInstance(db, &postgres.Config{})
+		migrations = []string{
+			"db-specific/000005_postgres_extras.down.sql",
+			"db-specific/000003_indexer_postgres.down.sql",
+		}
 	case "sqlite":
-		databaseDriver, err = sqlite.WithInstance(db, &sqlite.Config{})
-	default:
-		return 0, false, fmt.Errorf("unsupported database driver: %s", driver)
+		migrations = []string{
+			"db-specific/000006_sqlite_extras.down.sql",
+			"db-specific/000004_indexer_sqlite.down.sql",
+		}
 	}
-	if err != nil {
-		return 0, false, fmt.Errorf("failed to create database driver: %w", err)
+
+	for _, migrationFile := range migrations {
+		sqlBytes, err := migrationsFS.ReadFile("migrations/" + migrationFile)
+		if err != nil {
+			continue
+		}
+
+		if _, err := db.Exec(string(sqlBytes)); err != nil {
+			return fmt.Errorf("failed to apply %s: %w", migrationFile, err)
+		}
 	}
 
-	m, err := migrate.NewWithInstance(
-		"iofs", sourceDriver,
-		driver, databaseDriver,
-	)
+	return nil
+}
+
+// GetMigrationVersion returns the current migration version.
+func GetMigrationVersion(db *sql.DB, driver string) (version uint, dirty bool, err error) {
+	m, _, err := newMigrator(db, driver)
 	if err != nil {
-		return 0, false, fmt.Errorf("failed to create migration instance: %w", err)
+		return 0, false, err
 	}
 
 	return m.Version()
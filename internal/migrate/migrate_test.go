@@ -0,0 +1,149 @@
+package migrate
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Only the first two core migrations are SQLite compatible, so tests that run
+// migrations stay at or below version 2.
+
+// newTestDB returns an in-memory SQLite database. It uses a single connection
+// so every query sees the same database and connection-level PRAGMAs.
+func newTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func tableExists(t *testing.T, db *sql.DB, name string) bool {
+	t.Helper()
+
+	var n int
+	require.NoError(t, db.QueryRow(
+		"SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?",
+		name).Scan(&n))
+	return n > 0
+}
+
+func foreignKeysEnabled(t *testing.T, db *sql.DB) bool {
+	t.Helper()
+
+	var enabled int
+	require.NoError(t, db.QueryRow("PRAGMA foreign_keys").Scan(&enabled))
+	return enabled == 1
+}
+
+func TestMigrateTo(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	db := newTestDB(t)
+
+	require.NoError(MigrateTo(db, "sqlite", 2))
+	v, dirty, err := GetMigrationVersion(db, "sqlite")
+	require.NoError(err)
+	assert.Equal(uint(2), v)
+	assert.False(dirty)
+	assert.True(tableExists(t, db, "indexers"))
+
+	require.NoError(MigrateTo(db, "sqlite", 1))
+	v, _, err = GetMigrationVersion(db, "sqlite")
+	require.NoError(err)
+	assert.Equal(uint(1), v)
+	assert.False(tableExists(t, db, "indexers"))
+	assert.True(tableExists(t, db, "documents"))
+
+	require.NoError(MigrateTo(db, "sqlite", 0))
+	assert.False(tableExists(t, db, "documents"))
+
+	assert.ErrorContains(MigrateTo(db, "sqlite", 9999), "unknown migration version")
+	assert.ErrorContains(MigrateTo(db, "mysql", 1), "unsupported database driver")
+}
+
+func TestMigrateDown(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	db := newTestDB(t)
+
+	require.NoError(MigrateTo(db, "sqlite", 2))
+	require.NoError(MigrateDown(db, "sqlite", 1))
+	v, _, err := GetMigrationVersion(db, "sqlite")
+	require.NoError(err)
+	assert.Equal(uint(1), v)
+	assert.False(tableExists(t, db, "indexers"))
+
+	assert.Error(MigrateDown(db, "sqlite", 0))
+	assert.Error(MigrateDown(db, "sqlite", -1))
+}
+
+func TestRollbackFromLatest(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	db := newTestDB(t)
+
+	m, sourceDriver, err := newMigrator(db, "sqlite")
+	require.NoError(err)
+	latest, err := latestVersion(sourceDriver)
+	require.NoError(err)
+
+	// Below the latest version, database-specific enhancements are left alone.
+	require.NoError(Baseline(db, "sqlite", latest-1))
+	require.NoError(applyDatabaseSpecificMigrations(db, "sqlite"))
+	require.True(foreignKeysEnabled(t, db))
+	require.NoError(rollbackFromLatest(db, "sqlite", m, latest))
+	assert.True(foreignKeysEnabled(t, db))
+
+	// At the latest version, they're rolled back.
+	require.NoError(Baseline(db, "sqlite", latest))
+	require.NoError(rollbackFromLatest(db, "sqlite", m, latest))
+	assert.False(foreignKeysEnabled(t, db))
+}
+
+func TestBaseline(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	db := newTestDB(t)
+
+	require.NoError(Baseline(db, "sqlite", 5))
+	v, dirty, err := GetMigrationVersion(db, "sqlite")
+	require.NoError(err)
+	assert.Equal(uint(5), v)
+	assert.False(dirty)
+	// No migrations were run.
+	assert.False(tableExists(t, db, "documents"))
+
+	require.NoError(Baseline(db, "sqlite", 0))
+	status, err := GetStatus(db, "sqlite")
+	require.NoError(err)
+	assert.Equal(uint(0), status.Version)
+
+	assert.ErrorContains(Baseline(db, "sqlite", 9999), "unknown migration version")
+}
+
+func TestGetStatus(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	db := newTestDB(t)
+
+	status, err := GetStatus(db, "sqlite")
+	require.NoError(err)
+	assert.Equal(uint(0), status.Version)
+	assert.False(status.Dirty)
+	require.NotEmpty(status.Migrations)
+	for _, m := range status.Migrations {
+		assert.False(m.Applied, "migration %d", m.Version)
+	}
+
+	require.NoError(MigrateTo(db, "sqlite", 2))
+	status, err = GetStatus(db, "sqlite")
+	require.NoError(err)
+	assert.Equal(uint(2), status.Version)
+	require.Greater(len(status.Migrations), 2)
+	assert.Equal(uint(1), status.Migrations[0].Version)
+	assert.Equal("core_schema", status.Migrations[0].Name)
+	assert.True(status.Migrations[0].Applied)
+	assert.True(status.Migrations[1].Applied)
+	assert.False(status.Migrations[2].Applied)
+}
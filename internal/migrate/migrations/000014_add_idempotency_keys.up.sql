@@ -0,0 +1,34 @@
+-- Idempotency keys for document creation endpoints
+--
+-- Clients may send an Idempotency-Key header with POST requests that create
+-- resources (e.g. drafts, projects). The first request with a key stores its
+-- response here; retries with the same key replay the stored response instead
+-- of creating a duplicate document.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    id SERIAL PRIMARY KEY,
+
+    -- Client-supplied key, scoped to the authenticated user
+    key VARCHAR(255) NOT NULL,
+    user_email VARCHAR(320) NOT NULL,
+
+    -- Request identification
+    method VARCHAR(10) NOT NULL,
+    path TEXT NOT NULL,
+    request_hash VARCHAR(64) NOT NULL,
+
+    -- Stored response (NULL/false while the original request is in flight)
+    completed BOOLEAN NOT NULL DEFAULT FALSE,
+    response_status INTEGER,
+    response_content_type VARCHAR(255),
+    response_body BYTEA,
+
+    -- Lifecycle
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_idempotency_keys_user_key
+    ON idempotency_keys (key, user_email);
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at
+    ON idempotency_keys (expires_at);
@@ -0,0 +1,15 @@
+-- Rollback: remove move strategy columns and audit log
+DROP TABLE IF EXISTS migration_audit_log;
+
+DROP INDEX IF EXISTS idx_migration_items_rollback;
+DROP INDEX IF EXISTS idx_migration_items_source_removal;
+
+ALTER TABLE migration_items
+    DROP COLUMN IF EXISTS source_removed_at,
+    DROP COLUMN IF EXISTS source_remove_after,
+    DROP COLUMN IF EXISTS source_name,
+    DROP COLUMN IF EXISTS source_state;
+
+ALTER TABLE migration_jobs
+    DROP COLUMN IF EXISTS source_action,
+    DROP COLUMN IF EXISTS soak_period_seconds;
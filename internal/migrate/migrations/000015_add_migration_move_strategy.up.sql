@@ -0,0 +1,43 @@
+-- Move strategy for document migrations
+--
+-- A move copies the document, verifies the copy by content hash, waits for a
+-- configurable soak period, and then deletes or tombstones (renames) the
+-- source. Every step is recorded in migration_audit_log and can be rolled
+-- back per item while rollback_enabled is set on the job.
+
+-- Job-level move options
+ALTER TABLE migration_jobs
+    ADD COLUMN IF NOT EXISTS soak_period_seconds INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS source_action VARCHAR(20) NOT NULL DEFAULT 'delete';  -- 'delete', 'tombstone'
+
+-- Item-level source tracking
+ALTER TABLE migration_items
+    ADD COLUMN IF NOT EXISTS source_state VARCHAR(20),  -- 'pending_removal', 'deleted', 'tombstoned', 'retained', 'rollback_requested', 'rolled_back'
+    ADD COLUMN IF NOT EXISTS source_name TEXT,          -- Original source document name, used to restore it
+    ADD COLUMN IF NOT EXISTS source_remove_after TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS source_removed_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_migration_items_source_removal
+    ON migration_items(source_remove_after) WHERE source_state = 'pending_removal';
+CREATE INDEX IF NOT EXISTS idx_migration_items_rollback
+    ON migration_items(updated_at) WHERE source_state = 'rollback_requested';
+
+-- Migration Audit Log
+-- Append-only record of destructive and restorative migration actions
+CREATE TABLE IF NOT EXISTS migration_audit_log (
+    id BIGSERIAL PRIMARY KEY,
+
+    migration_job_id BIGINT NOT NULL REFERENCES migration_jobs(id) ON DELETE CASCADE,
+    migration_item_id BIGINT REFERENCES migration_items(id) ON DELETE CASCADE,
+    document_uuid UUID,
+
+    action VARCHAR(50) NOT NULL,  -- e.g. 'source_removal_scheduled', 'source_deleted', 'rolled_back'
+    actor TEXT NOT NULL,          -- User email, or 'system' for the worker
+    details JSONB,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_migration_audit_log_job ON migration_audit_log(migration_job_id);
+CREATE INDEX idx_migration_audit_log_item ON migration_audit_log(migration_item_id);
+CREATE INDEX idx_migration_audit_log_uuid ON migration_audit_log(document_uuid);
@@ -0,0 +1,2 @@
+-- Rollback: drop document_broken_links table
+DROP TABLE IF EXISTS document_broken_links;
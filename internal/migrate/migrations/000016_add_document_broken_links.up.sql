@@ -0,0 +1,25 @@
+-- Broken outbound links found in document content
+--
+-- A scheduled job extracts links from indexed document content and checks
+-- internal links against the documents table and redirect registry, and
+-- external links with HTTP HEAD requests. Each row is a link that was broken
+-- when last checked; rows are removed once the link works again or is no
+-- longer in the document. Owners see the report for their documents.
+CREATE TABLE IF NOT EXISTS document_broken_links (
+    id SERIAL PRIMARY KEY,
+    document_id INTEGER NOT NULL REFERENCES documents(id) ON DELETE CASCADE,
+
+    -- Link as written in the document and how it was checked
+    url TEXT NOT NULL,
+    link_type VARCHAR(20) NOT NULL,
+
+    -- Result of the last check (status_code is 0 if the request failed)
+    status_code INTEGER NOT NULL DEFAULT 0,
+    error TEXT,
+
+    first_detected_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_checked_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_document_broken_links_document_url
+    ON document_broken_links (document_id, url);
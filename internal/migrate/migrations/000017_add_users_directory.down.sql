@@ -0,0 +1,2 @@
+-- Rollback: drop users_directory table
+DROP TABLE IF EXISTS users_directory;
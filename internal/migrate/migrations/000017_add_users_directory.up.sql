@@ -0,0 +1,26 @@
+-- Local cache of the people directory
+--
+-- A periodic sync job copies people (and photo URLs) from the workspace
+-- people provider so typeahead people search can query the database instead
+-- of calling the provider on every keystroke. People no longer returned by
+-- the provider are soft-deleted and restored if they reappear.
+CREATE TABLE IF NOT EXISTS users_directory (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+
+    -- Lowercased primary email address
+    email_address TEXT NOT NULL,
+    display_name TEXT,
+    photo_url TEXT,
+    unified_user_id TEXT,
+
+    -- When the person was last returned by the provider
+    synced_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_directory_email_address
+    ON users_directory (email_address);
+CREATE INDEX IF NOT EXISTS idx_users_directory_deleted_at
+    ON users_directory (deleted_at);
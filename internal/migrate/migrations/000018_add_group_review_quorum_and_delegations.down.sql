@@ -0,0 +1,4 @@
+-- Rollback: drop review delegations and group review approvals
+DROP TABLE IF EXISTS review_delegations;
+DROP TABLE IF EXISTS document_group_review_approvals;
+ALTER TABLE document_group_reviews DROP COLUMN IF EXISTS required_approvals;
@@ -0,0 +1,51 @@
+-- Group review quorum and review delegation
+--
+-- A group review is satisfied once required_approvals distinct members of the
+-- group have approved (N-of-M). Members can delegate their reviews to a
+-- colleague for a time window; approvals record both the member the approval
+-- counts for and the user who actually approved.
+ALTER TABLE document_group_reviews
+    ADD COLUMN IF NOT EXISTS required_approvals INTEGER NOT NULL DEFAULT 1;
+
+CREATE TABLE IF NOT EXISTS document_group_review_approvals (
+    id SERIAL PRIMARY KEY,
+    document_id INTEGER NOT NULL REFERENCES documents(id) ON DELETE CASCADE,
+    group_id INTEGER NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+
+    -- Group member the approval counts for
+    member_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    -- User who approved (the member, or their delegate)
+    approver_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    approved_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_document_group_review_approvals_member
+    ON document_group_review_approvals (document_id, group_id, member_id);
+
+CREATE TABLE IF NOT EXISTS review_delegations (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+
+    delegator_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    delegate_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    -- Group the delegation applies to, or NULL for all of the delegator's
+    -- groups
+    group_id INTEGER REFERENCES groups(id) ON DELETE CASCADE,
+
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,
+
+    CONSTRAINT chk_review_delegations_window CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_review_delegations_delegate
+    ON review_delegations (delegate_id, ends_at);
+CREATE INDEX IF NOT EXISTS idx_review_delegations_delegator
+    ON review_delegations (delegator_id);
+CREATE INDEX IF NOT EXISTS idx_review_delegations_deleted_at
+    ON review_delegations (deleted_at);
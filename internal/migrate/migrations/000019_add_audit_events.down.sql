@@ -0,0 +1,3 @@
+-- Rollback: drop audit_events table
+DROP TABLE IF EXISTS audit_events;
+DROP FUNCTION IF EXISTS audit_events_append_only();
@@ -0,0 +1,51 @@
+-- Audit events for mutating API operations
+--
+-- Every mutating v2 API request (POST, PUT, PATCH, DELETE) records who made
+-- it, what it targeted, the response status, and snapshots of the target's key
+-- fields before and after the request. Rows are append-only: a trigger rejects
+-- updates and deletes so the log can be relied on for compliance review.
+CREATE TABLE IF NOT EXISTS audit_events (
+    id BIGSERIAL PRIMARY KEY,
+
+    -- Who made the request and how to correlate it with logs
+    actor VARCHAR(320) NOT NULL,
+    request_id VARCHAR(128) NOT NULL,
+
+    -- What was done (e.g. "documents.update", "approvals.create")
+    action VARCHAR(255) NOT NULL,
+    method VARCHAR(10) NOT NULL,
+    path TEXT NOT NULL,
+    status_code INTEGER NOT NULL,
+
+    -- What it was done to (target_id is empty if it could not be determined)
+    target_type VARCHAR(64) NOT NULL,
+    target_id VARCHAR(255) NOT NULL DEFAULT '',
+
+    -- Key fields of the target before and after the request (NULL if the
+    -- target did not exist)
+    before JSONB,
+    after JSONB,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_events_created_at
+    ON audit_events (created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_events_actor
+    ON audit_events (actor, created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_events_target
+    ON audit_events (target_type, target_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_events_request_id
+    ON audit_events (request_id);
+
+CREATE OR REPLACE FUNCTION audit_events_append_only()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS audit_events_append_only ON audit_events;
+CREATE TRIGGER audit_events_append_only
+    BEFORE UPDATE OR DELETE ON audit_events
+    FOR EACH ROW EXECUTE FUNCTION audit_events_append_only();
@@ -0,0 +1,3 @@
+-- Rollback: drop document runbook execution tables
+DROP TABLE IF EXISTS document_run_items;
+DROP TABLE IF EXISTS document_runs;
@@ -0,0 +1,46 @@
+-- Runbook executions of document checklists
+--
+-- Checklist items in a document (e.g. an ops runbook) can be instantiated into
+-- a run. Items are copied from the document content when the run starts, so
+-- execution history (who checked what, and when) is kept separate from the
+-- document and is unaffected by later edits to it.
+CREATE TABLE IF NOT EXISTS document_runs (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+
+    document_id INTEGER NOT NULL REFERENCES documents(id) ON DELETE CASCADE,
+    started_by_id INTEGER NOT NULL REFERENCES users(id),
+    name TEXT,
+
+    -- 1 = in progress, 2 = completed, 3 = aborted
+    status INTEGER NOT NULL,
+
+    -- Revision of the document content the checklist was copied from
+    content_revision TEXT,
+    completed_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_document_runs_document_id
+    ON document_runs (document_id);
+CREATE INDEX IF NOT EXISTS idx_document_runs_deleted_at
+    ON document_runs (deleted_at);
+
+CREATE TABLE IF NOT EXISTS document_run_items (
+    id SERIAL PRIMARY KEY,
+    run_id INTEGER NOT NULL REFERENCES document_runs(id) ON DELETE CASCADE,
+
+    -- 1-based position of the item in the checklist
+    position INTEGER NOT NULL,
+    section TEXT,
+    text TEXT NOT NULL,
+
+    -- Who checked the item and when (NULL if unchecked)
+    checked_by_id INTEGER REFERENCES users(id),
+    checked_at TIMESTAMPTZ,
+    note TEXT
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_document_run_items_run_position
+    ON document_run_items (run_id, position);
@@ -0,0 +1,2 @@
+-- Rollback: drop user roles table
+DROP TABLE IF EXISTS user_roles;
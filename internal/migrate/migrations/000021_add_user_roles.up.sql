@@ -0,0 +1,29 @@
+-- User roles for authorization
+--
+-- Roles grant permissions beyond the implicit owner, contributor, and approver
+-- relationships users have with documents. Document type admin and product
+-- lead roles are scoped to a single document type or product; the site admin
+-- role is global. Users without a role are regular users.
+CREATE TABLE IF NOT EXISTS user_roles (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    -- 1 = site admin, 2 = document type admin, 3 = product lead
+    role INTEGER NOT NULL,
+
+    -- Scope of the role (set for document type admin and product lead roles)
+    document_type_id INTEGER REFERENCES document_types(id) ON DELETE CASCADE,
+    product_id INTEGER REFERENCES products(id) ON DELETE CASCADE,
+
+    -- Email address of the user who granted the role
+    granted_by TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_roles_user_id
+    ON user_roles (user_id);
+CREATE INDEX IF NOT EXISTS idx_user_roles_deleted_at
+    ON user_roles (deleted_at);
@@ -0,0 +1,4 @@
+-- Rollback: remove service token name, scopes, and last-used tracking
+ALTER TABLE service_tokens DROP COLUMN IF EXISTS last_used_at;
+ALTER TABLE service_tokens DROP COLUMN IF EXISTS scopes;
+ALTER TABLE service_tokens DROP COLUMN IF EXISTS name;
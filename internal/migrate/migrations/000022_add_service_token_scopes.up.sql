@@ -0,0 +1,17 @@
+-- Service token management
+--
+-- Adds a human-readable name, scopes, and last-used tracking to service
+-- tokens so they can be managed through the admin API instead of by hand.
+--
+-- Token scopes:
+--   - 'read' - Read data
+--   - 'sync' - Synchronize documents (includes 'read')
+--   - 'notifications' - Send notifications
+--
+-- Tokens without scopes are allowed every scope.
+ALTER TABLE service_tokens ADD COLUMN IF NOT EXISTS name VARCHAR(255);
+ALTER TABLE service_tokens ADD COLUMN IF NOT EXISTS scopes JSONB;
+ALTER TABLE service_tokens ADD COLUMN IF NOT EXISTS last_used_at TIMESTAMPTZ;
+
+COMMENT ON COLUMN service_tokens.scopes IS 'Scopes the token is allowed: read, sync, or notifications. NULL or empty = all scopes.';
+COMMENT ON COLUMN service_tokens.last_used_at IS 'When the token was last used to authenticate a request.';
@@ -0,0 +1,3 @@
+-- Rollback: remove admin impersonation sessions
+ALTER TABLE audit_events DROP COLUMN IF EXISTS impersonator;
+DROP TABLE IF EXISTS impersonation_sessions;
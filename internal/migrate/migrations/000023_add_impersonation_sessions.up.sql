@@ -0,0 +1,29 @@
+-- Admin impersonation sessions
+--
+-- Site admins can act as another user to debug permission issues the user
+-- reports without asking for their credentials. Each session records who
+-- impersonated whom and why, and audit events record the admin who made a
+-- request during a session.
+CREATE TABLE IF NOT EXISTS impersonation_sessions (
+    id UUID PRIMARY KEY,
+
+    admin_email VARCHAR(320) NOT NULL,
+    user_email VARCHAR(320) NOT NULL,
+    reason TEXT NOT NULL,
+
+    expires_at TIMESTAMPTZ NOT NULL,
+    ended_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_impersonation_sessions_admin_email
+    ON impersonation_sessions (admin_email);
+CREATE INDEX IF NOT EXISTS idx_impersonation_sessions_user_email
+    ON impersonation_sessions (user_email);
+CREATE INDEX IF NOT EXISTS idx_impersonation_sessions_created_at
+    ON impersonation_sessions (created_at);
+
+ALTER TABLE audit_events
+    ADD COLUMN IF NOT EXISTS impersonator VARCHAR(320) NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN audit_events.impersonator IS 'Admin who made the request while impersonating the actor, or empty.';
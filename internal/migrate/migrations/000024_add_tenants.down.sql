@@ -0,0 +1,4 @@
+-- Rollback: remove tenants
+DROP INDEX IF EXISTS idx_documents_tenant_id;
+ALTER TABLE documents DROP COLUMN IF EXISTS tenant_id;
+DROP TABLE IF EXISTS tenants;
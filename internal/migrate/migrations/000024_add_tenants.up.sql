@@ -0,0 +1,24 @@
+-- Tenants
+--
+-- Tenants partition a deployment into isolated document spaces. Documents of
+-- the default tenant, which is the only tenant of deployments without tenants,
+-- have a tenant_id of 0 and no tenant record. Document numbers are unique per
+-- tenant, product, and document type.
+CREATE TABLE IF NOT EXISTS tenants (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ,
+
+    name TEXT NOT NULL UNIQUE,
+    display_name TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_tenants_deleted_at
+    ON tenants (deleted_at);
+
+ALTER TABLE documents
+    ADD COLUMN IF NOT EXISTS tenant_id INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_documents_tenant_id
+    ON documents (tenant_id);
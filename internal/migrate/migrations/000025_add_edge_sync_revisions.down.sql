@@ -0,0 +1,7 @@
+-- Rollback: remove edge document sync revisions
+DROP TRIGGER IF EXISTS edge_document_registry_revision ON edge_document_registry;
+DROP FUNCTION IF EXISTS edge_document_registry_next_revision();
+DROP INDEX IF EXISTS idx_edge_document_registry_change_seq;
+ALTER TABLE edge_document_registry DROP COLUMN IF EXISTS change_seq;
+ALTER TABLE edge_document_registry DROP COLUMN IF EXISTS revision;
+DROP SEQUENCE IF EXISTS edge_document_registry_change_seq;
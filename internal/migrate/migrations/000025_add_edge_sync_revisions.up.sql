@@ -0,0 +1,29 @@
+-- RFC-085: Edge document sync revisions
+--
+-- Each edge document registry record gets a revision that is incremented on
+-- every change, which edges send back as the base revision of their changes
+-- to detect conflicting changes, and a change sequence number that edges use
+-- as a checkpoint to fetch the records changed since their last sync.
+CREATE SEQUENCE IF NOT EXISTS edge_document_registry_change_seq;
+
+ALTER TABLE edge_document_registry
+    ADD COLUMN IF NOT EXISTS revision BIGINT NOT NULL DEFAULT 1,
+    ADD COLUMN IF NOT EXISTS change_seq BIGINT NOT NULL
+        DEFAULT nextval('edge_document_registry_change_seq');
+
+CREATE INDEX IF NOT EXISTS idx_edge_document_registry_change_seq
+    ON edge_document_registry (edge_instance, change_seq);
+
+CREATE OR REPLACE FUNCTION edge_document_registry_next_revision()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.revision = OLD.revision + 1;
+    NEW.change_seq = nextval('edge_document_registry_change_seq');
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER edge_document_registry_revision
+    BEFORE UPDATE ON edge_document_registry
+    FOR EACH ROW
+    EXECUTE FUNCTION edge_document_registry_next_revision();
@@ -0,0 +1,2 @@
+-- Rollback: remove the edge instance registry
+DROP TABLE IF EXISTS edge_instances;
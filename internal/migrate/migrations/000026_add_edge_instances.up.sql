@@ -0,0 +1,19 @@
+-- RFC-085: Edge instance registry
+--
+-- Central records each edge instance that syncs documents with it, along with
+-- the version, document counts, and connectivity reported by its heartbeats,
+-- so admins can find edges that stopped syncing.
+CREATE TABLE IF NOT EXISTS edge_instances (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+
+    name VARCHAR(255) NOT NULL UNIQUE,
+    version VARCHAR(50),
+    hostname VARCHAR(255),
+    remote_addr VARCHAR(255),
+    document_count INTEGER DEFAULT 0,
+    conflict_count INTEGER DEFAULT 0,
+    last_heartbeat_at TIMESTAMPTZ,
+    last_sync_at TIMESTAMPTZ
+);
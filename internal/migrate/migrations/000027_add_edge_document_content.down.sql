@@ -0,0 +1,6 @@
+-- Rollback: remove edge document content and conflicts
+DROP TABLE IF EXISTS edge_document_conflicts;
+DROP TRIGGER IF EXISTS edge_document_registry_save_revision ON edge_document_registry;
+DROP FUNCTION IF EXISTS edge_document_registry_save_revision();
+DROP TABLE IF EXISTS edge_document_revisions;
+ALTER TABLE edge_document_registry DROP COLUMN IF EXISTS content;
@@ -0,0 +1,96 @@
+-- RFC-085: Edge document content and conflicts
+--
+-- Edges sync the Markdown content of their documents along with the metadata,
+-- so central can merge concurrent changes. Each revision of a registry record
+-- is kept in edge_document_revisions, which is the base of a three-way merge
+-- when an edge's changes are based on an older revision than central's.
+-- Changes that can't be merged are recorded in edge_document_conflicts for
+-- manual resolution.
+ALTER TABLE edge_document_registry
+    ADD COLUMN IF NOT EXISTS content TEXT;
+
+CREATE TABLE IF NOT EXISTS edge_document_revisions (
+    uuid UUID NOT NULL
+        REFERENCES edge_document_registry (uuid) ON DELETE CASCADE,
+    revision BIGINT NOT NULL,
+
+    title TEXT NOT NULL,
+    document_type TEXT NOT NULL,
+    status TEXT,
+    summary TEXT,
+    product TEXT,
+    content TEXT,
+    content_hash TEXT,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (uuid, revision)
+);
+
+CREATE OR REPLACE FUNCTION edge_document_registry_save_revision()
+RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO edge_document_revisions (
+        uuid, revision, title, document_type, status, summary, product,
+        content, content_hash
+    ) VALUES (
+        NEW.uuid, NEW.revision, NEW.title, NEW.document_type, NEW.status,
+        NEW.summary, NEW.product, NEW.content, NEW.content_hash
+    )
+    ON CONFLICT (uuid, revision) DO NOTHING;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER edge_document_registry_save_revision
+    AFTER INSERT OR UPDATE ON edge_document_registry
+    FOR EACH ROW
+    EXECUTE FUNCTION edge_document_registry_save_revision();
+
+-- Existing records have no content, so changes based on them can't be merged
+-- until they are synced again.
+INSERT INTO edge_document_revisions (
+    uuid, revision, title, document_type, status, summary, product,
+    content_hash
+)
+SELECT uuid, revision, title, document_type, status, summary, product,
+    content_hash
+FROM edge_document_registry
+ON CONFLICT (uuid, revision) DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS edge_document_conflicts (
+    id BIGSERIAL PRIMARY KEY,
+    uuid UUID NOT NULL
+        REFERENCES edge_document_registry (uuid) ON DELETE CASCADE,
+    edge_instance TEXT NOT NULL,
+
+    -- The revision the edge's changes were based on, and central's revision
+    -- they conflicted with.
+    base_revision BIGINT NOT NULL,
+    central_revision BIGINT NOT NULL,
+
+    edge_content TEXT NOT NULL,
+    edge_content_hash TEXT NOT NULL,
+
+    -- The merged content with conflict markers around conflicting changes.
+    merged_content TEXT NOT NULL,
+
+    -- The conflicting metadata fields, if any.
+    conflicting_fields TEXT[] NOT NULL DEFAULT '{}',
+
+    status TEXT NOT NULL DEFAULT 'open', -- 'open', 'resolved'
+    resolved_by TEXT,
+    resolved_revision BIGINT,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMPTZ
+);
+
+-- A document has at most one open conflict, which is updated when the edge
+-- pushes conflicting changes again.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_edge_document_conflicts_open
+    ON edge_document_conflicts (uuid) WHERE status = 'open';
+
+CREATE INDEX IF NOT EXISTS idx_edge_document_conflicts_edge_instance
+    ON edge_document_conflicts (edge_instance, status);
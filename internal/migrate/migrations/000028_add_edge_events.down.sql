@@ -0,0 +1,2 @@
+-- Rollback: remove edge events
+DROP TABLE IF EXISTS edge_events;
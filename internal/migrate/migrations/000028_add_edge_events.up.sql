@@ -0,0 +1,21 @@
+-- RFC-085: Edge event fan-out
+--
+-- Central records changes relevant to edge instances, like new approvals and
+-- permission changes of their documents, as events per edge. Edges long-poll
+-- GET /api/v2/edge/events for events after the last one they've seen, so they
+-- sync as soon as something changes instead of polling sync-status.
+CREATE TABLE IF NOT EXISTS edge_events (
+    id BIGSERIAL PRIMARY KEY,
+    edge_instance TEXT NOT NULL,
+    type TEXT NOT NULL, -- e.g., 'document.approved'
+    document_uuid UUID,
+    data JSONB,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_edge_events_edge_instance
+    ON edge_events (edge_instance, id);
+
+-- Index for pruning old events.
+CREATE INDEX IF NOT EXISTS idx_edge_events_created_at
+    ON edge_events (created_at);
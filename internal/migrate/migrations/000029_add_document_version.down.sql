@@ -0,0 +1,2 @@
+-- Rollback: remove document versions
+ALTER TABLE documents DROP COLUMN IF EXISTS version;
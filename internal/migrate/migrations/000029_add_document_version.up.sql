@@ -0,0 +1,8 @@
+-- Optimistic locking of documents
+--
+-- Every update of a document through the API increments its version, and
+-- fails if the version was incremented since the document was read, so
+-- concurrent read-modify-write updates (e.g., of approvers and custom fields)
+-- don't silently overwrite each other.
+
+ALTER TABLE documents ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
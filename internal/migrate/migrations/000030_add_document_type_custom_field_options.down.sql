@@ -0,0 +1,2 @@
+-- Rollback: remove custom field options
+ALTER TABLE document_type_custom_fields DROP COLUMN IF EXISTS options;
@@ -0,0 +1,7 @@
+-- Typed custom fields
+--
+-- Enum custom fields of document types have a list of allowed values, stored
+-- as a JSON array. The new custom field types (enum, date, number, and
+-- boolean) are stored in the existing integer type column.
+
+ALTER TABLE document_type_custom_fields ADD COLUMN IF NOT EXISTS options JSONB;
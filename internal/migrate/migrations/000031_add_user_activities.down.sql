@@ -0,0 +1,2 @@
+-- Rollback: drop user activity table
+DROP TABLE IF EXISTS user_activities;
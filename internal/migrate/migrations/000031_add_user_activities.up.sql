@@ -0,0 +1,21 @@
+-- User activity on documents
+--
+-- Views, edits, and reviews of documents are recorded for the activity feeds
+-- of the dashboard and the document side panel. Activity is deleted after the
+-- configured retention period.
+CREATE TABLE IF NOT EXISTS user_activities (
+    id BIGSERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    document_id INTEGER NOT NULL REFERENCES documents(id) ON DELETE CASCADE,
+
+    -- "view", "edit", or "review"
+    action VARCHAR(32) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_activities_user
+    ON user_activities (user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_user_activities_document
+    ON user_activities (document_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_user_activities_created_at
+    ON user_activities (created_at);
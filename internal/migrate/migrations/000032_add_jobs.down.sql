@@ -0,0 +1,2 @@
+-- Rollback: drop background jobs table
+DROP TABLE IF EXISTS jobs;
@@ -0,0 +1,2 @@
+-- Rollback: drop document locks table
+DROP TABLE IF EXISTS document_locks;
@@ -0,0 +1,16 @@
+-- Document locks
+--
+-- Advisory locks on editing documents, held by a user until they're released
+-- or expire. The API rejects edits of documents locked by another user for
+-- all workspace providers.
+CREATE TABLE IF NOT EXISTS document_locks (
+    document_id INTEGER PRIMARY KEY REFERENCES documents(id) ON DELETE CASCADE,
+    owner_email VARCHAR(320) NOT NULL,
+    reason TEXT,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_document_locks_expires_at
+    ON document_locks (expires_at);
@@ -0,0 +1,3 @@
+-- Rollback: remove document approval times
+DROP INDEX IF EXISTS idx_documents_approved_at;
+ALTER TABLE documents DROP COLUMN IF EXISTS approved_at;
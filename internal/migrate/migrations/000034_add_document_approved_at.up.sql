@@ -0,0 +1,19 @@
+-- Document approval times
+--
+-- The time documents are approved, for analytics such as time in review.
+-- Documents approved before this migration are backfilled with the time of
+-- their latest approval by a reviewer, or their last modification.
+
+ALTER TABLE documents ADD COLUMN IF NOT EXISTS approved_at TIMESTAMPTZ;
+
+UPDATE documents
+SET approved_at = COALESCE(
+    (SELECT MAX(document_reviews.updated_at)
+     FROM document_reviews
+     WHERE document_reviews.document_id = documents.id
+       AND document_reviews.status = 1),
+    documents.document_modified_at)
+WHERE documents.status = 3 AND documents.approved_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_documents_approved_at
+    ON documents (approved_at);
@@ -0,0 +1,3 @@
+-- Rollback: remove document review SLA flags
+ALTER TABLE documents DROP COLUMN IF EXISTS review_sla_warned_at;
+ALTER TABLE documents DROP COLUMN IF EXISTS review_sla_breached_at;
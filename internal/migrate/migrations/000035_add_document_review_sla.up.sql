@@ -0,0 +1,8 @@
+-- Document review SLAs
+--
+-- Review SLAs are configured per document type. The review SLA job flags
+-- documents whose reviews breached their SLA, and records when their owners
+-- and reviewers were notified so they're only notified once.
+
+ALTER TABLE documents ADD COLUMN IF NOT EXISTS review_sla_breached_at TIMESTAMPTZ;
+ALTER TABLE documents ADD COLUMN IF NOT EXISTS review_sla_warned_at TIMESTAMPTZ;
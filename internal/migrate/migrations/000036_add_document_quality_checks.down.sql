@@ -0,0 +1,2 @@
+-- Rollback: drop document_quality_checks table
+DROP TABLE IF EXISTS document_quality_checks;
@@ -0,0 +1,22 @@
+-- Document quality checks
+--
+-- The quality_lint indexer pipeline step runs lint rules over document content
+-- (e.g., missing summary section, TODO markers, broken internal links, heading
+-- structure). Each row is the result of one rule for the latest linted
+-- content of a document, shown as a quality checklist before review is
+-- requested.
+CREATE TABLE IF NOT EXISTS document_quality_checks (
+    id SERIAL PRIMARY KEY,
+    document_id INTEGER NOT NULL REFERENCES documents(id) ON DELETE CASCADE,
+
+    rule VARCHAR(50) NOT NULL,
+    passed BOOLEAN NOT NULL,
+    findings JSONB,
+
+    -- Hash of the linted content
+    content_hash VARCHAR(64),
+    checked_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_document_quality_checks_document_rule
+    ON document_quality_checks (document_id, rule);
@@ -0,0 +1,2 @@
+-- Rollback: drop document_sensitive_findings table
+DROP TABLE IF EXISTS document_sensitive_findings;
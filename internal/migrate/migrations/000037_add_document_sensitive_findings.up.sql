@@ -0,0 +1,25 @@
+-- Sensitive data found in document content
+--
+-- The sensitive_data indexer pipeline step scans document content for
+-- credentials, tokens, and personal data. Each row is something found in the
+-- latest scanned content of a document; the data itself is never stored, only
+-- a redacted form. Depending on policy, findings block publishing the document
+-- for review until they're removed.
+CREATE TABLE IF NOT EXISTS document_sensitive_findings (
+    id SERIAL PRIMARY KEY,
+    document_id INTEGER NOT NULL REFERENCES documents(id) ON DELETE CASCADE,
+
+    rule VARCHAR(100) NOT NULL,
+    category VARCHAR(20) NOT NULL,
+    line INTEGER NOT NULL DEFAULT 0,
+    redacted TEXT,
+    source VARCHAR(20),
+    blocking BOOLEAN NOT NULL DEFAULT FALSE,
+
+    -- Hash of the scanned content
+    content_hash VARCHAR(64),
+    detected_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_document_sensitive_findings_document_id
+    ON document_sensitive_findings (document_id);
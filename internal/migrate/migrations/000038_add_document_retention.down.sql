@@ -0,0 +1,4 @@
+-- Rollback: remove document retention flags
+ALTER TABLE documents DROP COLUMN IF EXISTS retention_policy;
+ALTER TABLE documents DROP COLUMN IF EXISTS retention_notice_sent_at;
+ALTER TABLE documents DROP COLUMN IF EXISTS retention_applied_at;
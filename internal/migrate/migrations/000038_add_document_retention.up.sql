@@ -0,0 +1,10 @@
+-- Document retention
+--
+-- Retention policies archive or remove documents from search after a period
+-- of time. The retention job records when the owners of documents were
+-- notified about a policy and when it was applied, so owners are only
+-- notified once and policies are only applied once.
+
+ALTER TABLE documents ADD COLUMN IF NOT EXISTS retention_applied_at TIMESTAMPTZ;
+ALTER TABLE documents ADD COLUMN IF NOT EXISTS retention_notice_sent_at TIMESTAMPTZ;
+ALTER TABLE documents ADD COLUMN IF NOT EXISTS retention_policy VARCHAR(100);
@@ -0,0 +1,2 @@
+-- Rollback: drop document_attachments table
+DROP TABLE IF EXISTS document_attachments;
@@ -0,0 +1,27 @@
+-- Document attachments
+--
+-- Files (e.g., PDFs, presentations, and images) attached to documents. The
+-- files are stored by the workspace provider. The text extracted from them is
+-- stored here and included in the search document content of the documents,
+-- unless indexing is turned off for an attachment.
+CREATE TABLE IF NOT EXISTS document_attachments (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    document_id INTEGER NOT NULL REFERENCES documents(id) ON DELETE CASCADE,
+
+    name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(255),
+    size BIGINT NOT NULL DEFAULT 0,
+    content_hash VARCHAR(64),
+    indexed BOOLEAN NOT NULL DEFAULT TRUE,
+
+    -- Extracted text, or why no text was extracted
+    text TEXT,
+    extraction_error TEXT,
+
+    uploaded_by_id INTEGER REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_document_attachments_document_name
+    ON document_attachments (document_id, name);
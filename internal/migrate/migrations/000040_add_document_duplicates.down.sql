@@ -0,0 +1,3 @@
+-- Rollback: drop document_duplicates and document_fingerprints tables
+DROP TABLE IF EXISTS document_duplicates;
+DROP TABLE IF EXISTS document_fingerprints;
@@ -0,0 +1,37 @@
+-- Near-duplicate document detection
+--
+-- The duplicate_detection indexer pipeline step stores a MinHash signature of
+-- the latest content of each document, and compares it with the signatures of
+-- other documents to find likely duplicates (e.g., copies of documents created
+-- instead of using the document type's template).
+CREATE TABLE IF NOT EXISTS document_fingerprints (
+    id SERIAL PRIMARY KEY,
+    document_id INTEGER NOT NULL REFERENCES documents(id) ON DELETE CASCADE,
+
+    -- MinHash signature, or NULL if the content is too short to compare
+    signature JSONB,
+    shingles INTEGER NOT NULL DEFAULT 0,
+
+    -- Hash of the fingerprinted content
+    content_hash VARCHAR(64),
+    fingerprinted_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_document_fingerprints_document_id
+    ON document_fingerprints (document_id);
+
+-- Pairs of likely duplicate documents, stored once with the lower document ID
+-- first.
+CREATE TABLE IF NOT EXISTS document_duplicates (
+    id SERIAL PRIMARY KEY,
+    document_id INTEGER NOT NULL REFERENCES documents(id) ON DELETE CASCADE,
+    duplicate_id INTEGER NOT NULL REFERENCES documents(id) ON DELETE CASCADE,
+
+    similarity DOUBLE PRECISION NOT NULL,
+    detected_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_document_duplicates_pair
+    ON document_duplicates (document_id, duplicate_id);
+CREATE INDEX IF NOT EXISTS idx_document_duplicates_duplicate_id
+    ON document_duplicates (duplicate_id);
@@ -0,0 +1,2 @@
+-- Rollback: remove user locales
+ALTER TABLE users DROP COLUMN IF EXISTS locale;
@@ -0,0 +1,7 @@
+-- User locales
+--
+-- The preferred locale of users (a BCP 47 language tag like "de" or "pt-BR"),
+-- which selects the language of the emails and notifications they receive.
+-- Users without a locale receive them in the default language.
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS locale VARCHAR(35);
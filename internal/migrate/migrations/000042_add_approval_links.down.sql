@@ -0,0 +1,2 @@
+-- Rollback: remove approval links
+DROP TABLE IF EXISTS approval_links;
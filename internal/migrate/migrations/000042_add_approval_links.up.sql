@@ -0,0 +1,25 @@
+-- Approval links
+--
+-- Single-use links in review request emails that approve a document or
+-- request changes of it without opening Hermes. Only the SHA-256 hash of the
+-- token of each link is stored.
+CREATE TABLE IF NOT EXISTS approval_links (
+    id SERIAL PRIMARY KEY,
+    token_hash VARCHAR(64) NOT NULL,
+    document_id INTEGER NOT NULL REFERENCES documents(id) ON DELETE CASCADE,
+    approver_email VARCHAR(320) NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+
+    -- When, and for which action, the link was used
+    used_at TIMESTAMPTZ,
+    action VARCHAR(20),
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_approval_links_token_hash
+    ON approval_links (token_hash);
+CREATE INDEX IF NOT EXISTS idx_approval_links_document_id
+    ON approval_links (document_id);
+CREATE INDEX IF NOT EXISTS idx_approval_links_expires_at
+    ON approval_links (expires_at);
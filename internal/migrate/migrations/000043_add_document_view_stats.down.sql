@@ -0,0 +1,2 @@
+-- Rollback: remove document view stats
+DROP TABLE IF EXISTS document_view_stats;
@@ -0,0 +1,17 @@
+-- Document view stats
+--
+-- The number of times each user viewed each document per day (UTC), which
+-- the document stats API reports as view counts, unique readers, and views
+-- over time. Views repeated within the user activity dedup window are counted
+-- once. Unlike user activity, view stats are kept after the retention period.
+CREATE TABLE IF NOT EXISTS document_view_stats (
+    document_id INTEGER NOT NULL REFERENCES documents(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    views INTEGER NOT NULL DEFAULT 0,
+
+    PRIMARY KEY (document_id, user_id, day)
+);
+
+CREATE INDEX IF NOT EXISTS idx_document_view_stats_day
+    ON document_view_stats (day);
@@ -0,0 +1,2 @@
+-- Rollback: remove document type templates
+DROP TABLE IF EXISTS document_type_templates;
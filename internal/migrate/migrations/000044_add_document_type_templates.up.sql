@@ -0,0 +1,23 @@
+-- Document type templates
+--
+-- The named templates that drafts of each document type can be created from,
+-- synchronized from the document type configuration at startup: the default
+-- template, and template variants such as a short RFC template.
+CREATE TABLE IF NOT EXISTS document_type_templates (
+    id SERIAL PRIMARY KEY,
+    document_type_id INTEGER NOT NULL
+        REFERENCES document_types(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    long_name VARCHAR(255),
+    description TEXT,
+
+    -- File ID of the template
+    template_id VARCHAR(255) NOT NULL,
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_document_type_templates_name
+    ON document_type_templates (document_type_id, name);
@@ -0,0 +1,4 @@
+-- Rollback: remove saved searches
+DROP TABLE IF EXISTS saved_search_subscribers;
+DROP TABLE IF EXISTS saved_search_groups;
+DROP TABLE IF EXISTS saved_searches;
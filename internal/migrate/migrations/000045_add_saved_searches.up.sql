@@ -0,0 +1,37 @@
+-- Saved searches
+--
+-- Named document searches saved by users, the groups they are shared with,
+-- and the users subscribed to digests of new documents that match them.
+CREATE TABLE IF NOT EXISTS saved_searches (
+    id SERIAL PRIMARY KEY,
+    owner_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    query TEXT NOT NULL DEFAULT '',
+    filters JSONB,
+    facets JSONB,
+    sort_by VARCHAR(100) NOT NULL DEFAULT '',
+    sort_order VARCHAR(4) NOT NULL DEFAULT '',
+
+    -- When the last digest of the search was sent
+    digested_at TIMESTAMPTZ,
+
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_saved_searches_owner_name
+    ON saved_searches (owner_id, name);
+
+CREATE TABLE IF NOT EXISTS saved_search_groups (
+    saved_search_id INTEGER NOT NULL
+        REFERENCES saved_searches(id) ON DELETE CASCADE,
+    group_id INTEGER NOT NULL REFERENCES groups(id) ON DELETE CASCADE,
+    PRIMARY KEY (saved_search_id, group_id)
+);
+
+CREATE TABLE IF NOT EXISTS saved_search_subscribers (
+    saved_search_id INTEGER NOT NULL
+        REFERENCES saved_searches(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    PRIMARY KEY (saved_search_id, user_id)
+);
@@ -0,0 +1,3 @@
+-- Rollback: remove document type workflow
+ALTER TABLE document_types DROP COLUMN IF EXISTS workflow;
+ALTER TABLE document_types DROP COLUMN IF EXISTS managed;
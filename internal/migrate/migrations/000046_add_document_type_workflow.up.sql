@@ -0,0 +1,9 @@
+-- Document type workflow
+--
+-- Document types can be managed with the admin API instead of the
+-- configuration, and have a review workflow: the review SLA, the number of
+-- approvals documents need before they can be marked approved, and the custom
+-- fields shown in document headers.
+
+ALTER TABLE document_types ADD COLUMN IF NOT EXISTS managed BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE document_types ADD COLUMN IF NOT EXISTS workflow JSONB;
@@ -0,0 +1,3 @@
+-- Rollback: remove the search vocabulary
+DROP TABLE IF EXISTS search_stop_words;
+DROP TABLE IF EXISTS search_synonym_sets;
@@ -0,0 +1,19 @@
+-- Search vocabulary
+--
+-- Synonym sets and stop words managed by site admins and pushed to the search
+-- provider.
+CREATE TABLE IF NOT EXISTS search_synonym_sets (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    terms JSONB NOT NULL,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_search_synonym_sets_name
+    ON search_synonym_sets (name);
+
+CREATE TABLE IF NOT EXISTS search_stop_words (
+    word VARCHAR(100) PRIMARY KEY,
+    created_at TIMESTAMPTZ
+);
@@ -0,0 +1,2 @@
+-- Rollback: remove the worker registry
+DROP TABLE IF EXISTS worker_instances;
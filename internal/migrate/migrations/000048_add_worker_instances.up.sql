@@ -0,0 +1,23 @@
+-- Worker registry
+--
+-- Pipeline workers (indexers and notifiers) report their consumer groups,
+-- offsets, lag, and error counts in heartbeats, so operators can see the
+-- health of the pipeline in the admin API.
+CREATE TABLE IF NOT EXISTS worker_instances (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+
+    name VARCHAR(255) NOT NULL UNIQUE,
+    kind VARCHAR(50) NOT NULL,
+    version VARCHAR(50),
+    hostname VARCHAR(255),
+    remote_addr VARCHAR(255),
+    partitions JSONB,
+    processed_count BIGINT DEFAULT 0,
+    error_count BIGINT DEFAULT 0,
+    last_error TEXT,
+    last_error_at TIMESTAMPTZ,
+    started_at TIMESTAMPTZ,
+    last_heartbeat_at TIMESTAMPTZ
+);
@@ -0,0 +1,2 @@
+-- Rollback: remove document integrity checks
+DROP TABLE IF EXISTS document_integrity;
@@ -0,0 +1,35 @@
+-- Document integrity checks
+--
+-- A scheduled job recomputes the hashes of document content and attached
+-- files stored by workspace providers and compares them to the hashes recorded
+-- when they were written, to detect silent corruption (e.g., in the S3 archive
+-- tier). Each row is the result of the last check of an object; drift is
+-- alerted on the ops notification channel.
+CREATE TABLE IF NOT EXISTS document_integrity (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    document_id INTEGER NOT NULL REFERENCES documents(id) ON DELETE CASCADE,
+
+    -- Object checked: the content ("content") or an attached file
+    -- ("attachment", with its file name)
+    kind VARCHAR(20) NOT NULL,
+    name VARCHAR(255) NOT NULL DEFAULT '',
+    provider_id VARCHAR(500) NOT NULL,
+
+    -- Hash recorded when the object was written and hash when last checked
+    expected_hash VARCHAR(100),
+    actual_hash VARCHAR(100),
+    modified_at TIMESTAMPTZ,
+
+    -- "ok", "drift", or "missing"
+    status VARCHAR(20) NOT NULL,
+    drift_detected_at TIMESTAMPTZ,
+    last_checked_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_document_integrity_document_object
+    ON document_integrity (document_id, kind, name);
+
+CREATE INDEX IF NOT EXISTS idx_document_integrity_status
+    ON document_integrity (status);
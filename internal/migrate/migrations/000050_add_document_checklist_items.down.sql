@@ -0,0 +1,2 @@
+-- Rollback: remove document review checklists
+DROP TABLE IF EXISTS document_checklist_items;
@@ -0,0 +1,21 @@
+-- Document review checklists
+--
+-- Document types can define a review checklist (e.g., security review done,
+-- legal sign-off, test plan linked). Each row records whether an item of the
+-- checklist is checked on a document; documents can't move to In-Review or
+-- Approved until the items required for that status are checked.
+CREATE TABLE IF NOT EXISTS document_checklist_items (
+    id SERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    document_id INTEGER NOT NULL REFERENCES documents(id) ON DELETE CASCADE,
+
+    -- Name of the checklist item of the document type
+    name VARCHAR(255) NOT NULL,
+    checked BOOLEAN NOT NULL DEFAULT FALSE,
+    checked_by_email VARCHAR(320),
+    checked_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_document_checklist_items_document_name
+    ON document_checklist_items (document_id, name);
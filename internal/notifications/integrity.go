@@ -0,0 +1,83 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/integrity"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp-forge/hermes/pkg/notifications"
+)
+
+// IntegrityNotifier publishes integrity check failures to the notification
+// topic, routed to the backends that make up the ops channel.
+type IntegrityNotifier struct {
+	provider   *Provider
+	backends   []string
+	recipients []notifications.Recipient
+	baseURL    string
+}
+
+// NewIntegrityNotifier creates a notifier that routes integrity check failures
+// to the given backends. Recipients are only needed by backends that deliver
+// to people (e.g., mail). Document links are relative to the Hermes base URL
+// baseURL.
+func NewIntegrityNotifier(
+	provider *Provider, backends []string, recipients []string, baseURL string,
+) *IntegrityNotifier {
+	rs := make([]notifications.Recipient, len(recipients))
+	for i, email := range recipients {
+		rs[i] = notifications.Recipient{Email: email}
+	}
+	return &IntegrityNotifier{
+		provider:   provider,
+		backends:   backends,
+		recipients: rs,
+		baseURL:    baseURL,
+	}
+}
+
+// NotifyIntegrityFailure implements integrity.Notifier.
+func (n *IntegrityNotifier) NotifyIntegrityFailure(ctx context.Context, ev *integrity.Event) error {
+	docURL, err := url.Parse(n.baseURL)
+	if err != nil {
+		return fmt.Errorf("error parsing base URL: %w", err)
+	}
+	docURL.Path = path.Join(docURL.Path, "document", ev.DocumentID)
+
+	object := "content"
+	if ev.Kind == models.DocumentIntegrityKindAttachment {
+		object = fmt.Sprintf("attachment %q", ev.Name)
+	}
+	description, actualHash := "doesn't match the hash recorded when it was written", ev.ActualHash
+	if ev.Status == models.DocumentIntegrityStatusMissing {
+		description, actualHash = "is missing", "none"
+	}
+
+	req := NotificationRequest{
+		Type:       notifications.NotificationTypeDocumentIntegrityFailed,
+		Recipients: n.recipients,
+		TemplateContext: map[string]any{
+			"DocumentTitle":     ev.Title,
+			"DocumentURL":       docURL.String(),
+			"ProviderID":        ev.ProviderID,
+			"Object":            object,
+			"Status":            ev.Status,
+			"StatusDescription": description,
+			"ExpectedHash":      ev.ExpectedHash,
+			"ActualHash":        actualHash,
+			"DetectedAt":        ev.DetectedAt.UTC().Format(time.RFC1123),
+		},
+		Backends:     n.backends,
+		DocumentUUID: ev.DocumentUUID,
+		Priority:     1,
+	}
+	if err := n.provider.SendNotification(ctx, req); err != nil {
+		return fmt.Errorf("failed to send %s notification: %w",
+			notifications.NotificationTypeDocumentIntegrityFailed, err)
+	}
+	return nil
+}
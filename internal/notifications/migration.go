@@ -0,0 +1,74 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp-forge/hermes/pkg/migration"
+	"github.com/hashicorp-forge/hermes/pkg/notifications"
+)
+
+// MigrationNotifier publishes migration lifecycle events to the notification
+// topic, routed to the backends that make up the ops channel.
+type MigrationNotifier struct {
+	provider   *Provider
+	backends   []string
+	recipients []notifications.Recipient
+}
+
+// NewMigrationNotifier creates a notifier that routes migration events to the
+// given backends. Recipients are only needed by backends that deliver to
+// people (e.g., mail).
+func NewMigrationNotifier(provider *Provider, backends []string, recipients []string) *MigrationNotifier {
+	rs := make([]notifications.Recipient, len(recipients))
+	for i, email := range recipients {
+		rs[i] = notifications.Recipient{Email: email}
+	}
+	return &MigrationNotifier{
+		provider:   provider,
+		backends:   backends,
+		recipients: rs,
+	}
+}
+
+// NotifyMigrationEvent implements migration.Notifier.
+func (n *MigrationNotifier) NotifyMigrationEvent(ctx context.Context, ev *migration.Event) error {
+	req := NotificationRequest{
+		Type:            notifications.NotificationType(ev.Type),
+		Recipients:      n.recipients,
+		TemplateContext: migrationTemplateContext(ev),
+		Backends:        n.backends,
+		DocumentUUID:    ev.DocumentUUID,
+	}
+	if ev.Type == migration.EventItemFailed {
+		req.Priority = 1
+	}
+
+	if err := n.provider.SendNotification(ctx, req); err != nil {
+		return fmt.Errorf("failed to send %s notification: %w", ev.Type, err)
+	}
+	return nil
+}
+
+// migrationTemplateContext flattens an event into the fields used by the
+// migration templates. Every key is always set so templates never render
+// "<no value>".
+func migrationTemplateContext(ev *migration.Event) map[string]any {
+	return map[string]any{
+		"JobID":          ev.JobID,
+		"JobUUID":        ev.JobUUID,
+		"JobName":        ev.JobName,
+		"Strategy":       string(ev.Strategy),
+		"SourceProvider": ev.SourceProvider,
+		"DestProvider":   ev.DestProvider,
+		"Total":          ev.Total,
+		"Migrated":       ev.Migrated,
+		"Failed":         ev.Failed,
+		"Skipped":        ev.Skipped,
+		"Completed":      ev.Completed(),
+		"Percent":        ev.Percent(),
+		"ItemID":         ev.ItemID,
+		"DocumentUUID":   ev.DocumentUUID,
+		"Error":          ev.Error,
+	}
+}
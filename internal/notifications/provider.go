@@ -3,9 +3,11 @@ package notifications
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/hashicorp-forge/hermes/pkg/i18n"
 	"github.com/hashicorp-forge/hermes/pkg/notifications"
 )
 
@@ -21,10 +23,15 @@ type NotificationRequest struct {
 	UserID          string
 }
 
+// LocaleLookup returns the preferred locales of the users with the given email
+// addresses, keyed by email address. Users without a locale are omitted.
+type LocaleLookup func(ctx context.Context, emails []string) map[string]string
+
 // Provider handles notification creation and publishing
 type Provider struct {
 	resolver  *TemplateResolver
 	publisher *notifications.Publisher
+	locales   LocaleLookup
 }
 
 // NewProvider creates a new notification provider
@@ -47,37 +54,98 @@ func NewProvider(publisherConfig notifications.PublisherConfig) (*Provider, erro
 	}, nil
 }
 
-// SendNotification resolves templates and publishes notification to the queue
+// SetLocaleLookup sets the lookup of the locales of recipients without one,
+// so notifications are sent in their preferred language.
+func (p *Provider) SetLocaleLookup(lookup LocaleLookup) {
+	p.locales = lookup
+}
+
+// SendNotification resolves templates and publishes notification to the queue.
+// Recipients with different locales receive separate messages, each resolved
+// in their locale.
 func (p *Provider) SendNotification(ctx context.Context, req NotificationRequest) error {
-	// Resolve templates
-	content, err := p.resolver.Resolve(req.Type, req.TemplateContext)
-	if err != nil {
-		return fmt.Errorf("failed to resolve templates: %w", err)
+	for _, group := range p.recipientsByLocale(ctx, req.Recipients) {
+		// Resolve templates
+		content, err := p.resolver.ResolveLocale(req.Type, group.locale, req.TemplateContext)
+		if err != nil {
+			return fmt.Errorf("failed to resolve templates: %w", err)
+		}
+
+		// Create notification message with resolved content
+		msg := &notifications.NotificationMessage{
+			ID:              uuid.New().String(),
+			Type:            req.Type,
+			Timestamp:       time.Now(),
+			Priority:        req.Priority,
+			Recipients:      group.recipients,
+			Subject:         content.Subject,
+			Body:            content.Body,
+			BodyHTML:        content.BodyHTML,
+			TemplateContext: req.TemplateContext, // Keep for audit/debugging
+			Backends:        req.Backends,
+			DocumentUUID:    req.DocumentUUID,
+			ProjectID:       req.ProjectID,
+			UserID:          req.UserID,
+		}
+
+		// Publish to queue
+		if err := p.publisher.PublishMessage(ctx, msg); err != nil {
+			return fmt.Errorf("failed to publish notification: %w", err)
+		}
 	}
 
-	// Create notification message with resolved content
-	msg := &notifications.NotificationMessage{
-		ID:              uuid.New().String(),
-		Type:            req.Type,
-		Timestamp:       time.Now(),
-		Priority:        req.Priority,
-		Recipients:      req.Recipients,
-		Subject:         content.Subject,
-		Body:            content.Body,
-		BodyHTML:        content.BodyHTML,
-		TemplateContext: req.TemplateContext, // Keep for audit/debugging
-		Backends:        req.Backends,
-		DocumentUUID:    req.DocumentUUID,
-		ProjectID:       req.ProjectID,
-		UserID:          req.UserID,
-	}
+	return nil
+}
 
-	// Publish to queue
-	if err := p.publisher.PublishMessage(ctx, msg); err != nil {
-		return fmt.Errorf("failed to publish notification: %w", err)
+// localeRecipients are the recipients of a notification with the same locale.
+type localeRecipients struct {
+	locale     string
+	recipients []notifications.Recipient
+}
+
+// recipientsByLocale groups recipients by locale, in the order of their first
+// recipients. The locales of recipients without one are looked up. There is
+// always at least one group, so notifications without recipients are still
+// published.
+func (p *Provider) recipientsByLocale(
+	ctx context.Context, recipients []notifications.Recipient,
+) []localeRecipients {
+	var lookup []string
+	for _, r := range recipients {
+		if r.Locale == "" && r.Email != "" {
+			lookup = append(lookup, r.Email)
+		}
+	}
+	var locales map[string]string
+	if p.locales != nil && len(lookup) > 0 {
+		locales = p.locales(ctx, lookup)
 	}
 
-	return nil
+	var groups []localeRecipients
+	for _, r := range recipients {
+		if r.Locale == "" {
+			r.Locale = locales[r.Email]
+		}
+		// Normalize locales so that, e.g., "pt_br" and "pt-BR" are grouped.
+		locale, err := i18n.Normalize(r.Locale)
+		if err != nil {
+			locale = ""
+		}
+		r.Locale = locale
+
+		i := slices.IndexFunc(groups, func(g localeRecipients) bool {
+			return g.locale == locale
+		})
+		if i < 0 {
+			groups = append(groups, localeRecipients{locale: locale})
+			i = len(groups) - 1
+		}
+		groups[i].recipients = append(groups[i].recipients, r)
+	}
+	if len(groups) == 0 {
+		groups = append(groups, localeRecipients{recipients: recipients})
+	}
+	return groups
 }
 
 // SendEmail provides backward compatibility with existing email system
@@ -0,0 +1,72 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/notifications"
+	"github.com/hashicorp-forge/hermes/pkg/retention"
+)
+
+// RetentionNotifier publishes retention notices to the notification topic,
+// addressed to the owners of the documents.
+type RetentionNotifier struct {
+	provider *Provider
+	backends []string
+	baseURL  string
+}
+
+// NewRetentionNotifier creates a notifier that routes retention notices to the
+// given backends. Document links are relative to the Hermes base URL baseURL.
+func NewRetentionNotifier(provider *Provider, backends []string, baseURL string) *RetentionNotifier {
+	return &RetentionNotifier{
+		provider: provider,
+		backends: backends,
+		baseURL:  baseURL,
+	}
+}
+
+// NotifyRetentionEvent implements retention.Notifier.
+func (n *RetentionNotifier) NotifyRetentionEvent(ctx context.Context, ev *retention.Event) error {
+	if ev.Owner == "" {
+		return nil
+	}
+
+	docURL, err := url.Parse(n.baseURL)
+	if err != nil {
+		return fmt.Errorf("error parsing base URL: %w", err)
+	}
+	docURL.Path = path.Join(docURL.Path, "document", ev.DocumentID)
+
+	action, description := "removal from search", "removed from search"
+	if ev.Action == retention.ActionArchive {
+		action, description = "archival", "archived"
+	}
+
+	req := NotificationRequest{
+		Type:       notifications.NotificationTypeRetentionNotice,
+		Recipients: []notifications.Recipient{{Email: ev.Owner}},
+		TemplateContext: map[string]any{
+			"DocumentTitle":     ev.Title,
+			"DocumentShortName": ev.DocNumber,
+			"DocumentURL":       docURL.String(),
+			"DocumentType":      ev.DocType,
+			"Product":           ev.Product,
+			"Policy":            ev.Policy,
+			"Action":            action,
+			"ActionDescription": description,
+			"ModifiedAt":        ev.ModifiedAt.UTC().Format(time.RFC1123),
+			"ActionAt":          ev.ActionAt.UTC().Format(time.RFC1123),
+		},
+		Backends:     n.backends,
+		DocumentUUID: ev.DocumentUUID,
+	}
+	if err := n.provider.SendNotification(ctx, req); err != nil {
+		return fmt.Errorf("failed to send %s notification: %w",
+			notifications.NotificationTypeRetentionNotice, err)
+	}
+	return nil
+}
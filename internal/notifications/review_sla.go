@@ -0,0 +1,88 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/notifications"
+	"github.com/hashicorp-forge/hermes/pkg/reviewsla"
+)
+
+// ReviewSLANotifier publishes review SLA events to the notification topic,
+// addressed to the owners and pending reviewers of the documents.
+type ReviewSLANotifier struct {
+	provider *Provider
+	backends []string
+	baseURL  string
+}
+
+// NewReviewSLANotifier creates a notifier that routes review SLA events to the
+// given backends. Document links are relative to the Hermes base URL baseURL.
+func NewReviewSLANotifier(provider *Provider, backends []string, baseURL string) *ReviewSLANotifier {
+	return &ReviewSLANotifier{
+		provider: provider,
+		backends: backends,
+		baseURL:  baseURL,
+	}
+}
+
+// NotifyReviewSLAEvent implements reviewsla.Notifier.
+func (n *ReviewSLANotifier) NotifyReviewSLAEvent(ctx context.Context, ev *reviewsla.Event) error {
+	var recipients []notifications.Recipient
+	var emails []string
+	for _, email := range append([]string{ev.Owner}, ev.PendingReviewers...) {
+		if email != "" && !slices.Contains(emails, email) {
+			emails = append(emails, email)
+			recipients = append(recipients, notifications.Recipient{Email: email})
+		}
+	}
+
+	docURL, err := url.Parse(n.baseURL)
+	if err != nil {
+		return fmt.Errorf("error parsing base URL: %w", err)
+	}
+	docURL.Path = path.Join(docURL.Path, "document", ev.DocumentID)
+
+	req := NotificationRequest{
+		Type:            notifications.NotificationType(ev.Type),
+		Recipients:      recipients,
+		TemplateContext: reviewSLATemplateContext(ev, docURL.String()),
+		Backends:        n.backends,
+		DocumentUUID:    ev.DocumentUUID,
+	}
+	if ev.Type == reviewsla.EventBreached {
+		req.Priority = 1
+	}
+
+	if err := n.provider.SendNotification(ctx, req); err != nil {
+		return fmt.Errorf("failed to send %s notification: %w", ev.Type, err)
+	}
+	return nil
+}
+
+// reviewSLATemplateContext flattens an event into the fields used by the review
+// SLA templates. Every key is always set so templates never render
+// "<no value>".
+func reviewSLATemplateContext(ev *reviewsla.Event, docURL string) map[string]any {
+	pendingReviewers := strings.Join(ev.PendingReviewers, ", ")
+	if pendingReviewers == "" {
+		pendingReviewers = "none"
+	}
+	return map[string]any{
+		"DocumentTitle":     ev.Title,
+		"DocumentShortName": ev.DocNumber,
+		"DocumentURL":       docURL,
+		"DocumentOwner":     ev.Owner,
+		"DocumentType":      ev.DocType,
+		"Product":           ev.Product,
+		"RequestedAt":       ev.RequestedAt.UTC().Format(time.RFC1123),
+		"DueAt":             ev.DueAt.UTC().Format(time.RFC1123),
+		"SLA":               ev.SLA.String(),
+		"PendingReviewers":  pendingReviewers,
+	}
+}
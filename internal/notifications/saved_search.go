@@ -0,0 +1,115 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/notifications"
+	"github.com/hashicorp-forge/hermes/pkg/savedsearch"
+)
+
+// SavedSearchNotifier publishes saved search digests to the notification
+// topic, addressed to the subscribers of the searches.
+type SavedSearchNotifier struct {
+	provider *Provider
+	backends []string
+	baseURL  string
+}
+
+// NewSavedSearchNotifier creates a notifier that routes saved search digests
+// to the given backends. Links are relative to the Hermes base URL baseURL.
+func NewSavedSearchNotifier(provider *Provider, backends []string, baseURL string) *SavedSearchNotifier {
+	return &SavedSearchNotifier{
+		provider: provider,
+		backends: backends,
+		baseURL:  baseURL,
+	}
+}
+
+// NotifySavedSearchDigest implements savedsearch.Notifier.
+func (n *SavedSearchNotifier) NotifySavedSearchDigest(ctx context.Context, d *savedsearch.Digest) error {
+	if len(d.Subscribers) == 0 {
+		return nil
+	}
+
+	baseURL, err := url.Parse(n.baseURL)
+	if err != nil {
+		return fmt.Errorf("error parsing base URL: %w", err)
+	}
+
+	var recipients []notifications.Recipient
+	for _, email := range d.Subscribers {
+		recipients = append(recipients, notifications.Recipient{Email: email})
+	}
+
+	req := NotificationRequest{
+		Type:            notifications.NotificationTypeSavedSearchDigest,
+		Recipients:      recipients,
+		TemplateContext: savedSearchTemplateContext(d, *baseURL),
+		Backends:        n.backends,
+	}
+	if err := n.provider.SendNotification(ctx, req); err != nil {
+		return fmt.Errorf("failed to send saved search digest notification: %w", err)
+	}
+	return nil
+}
+
+// savedSearchTemplateContext flattens a digest into the fields used by the
+// saved search digest templates. Every key is always set so templates never
+// render "<no value>".
+func savedSearchTemplateContext(d *savedsearch.Digest, baseURL url.URL) map[string]any {
+	docs := make([]map[string]any, 0, len(d.Documents))
+	for _, doc := range d.Documents {
+		docURL := baseURL
+		docURL.Path = path.Join(docURL.Path, "document", doc.ID)
+		docs = append(docs, map[string]any{
+			"DocumentTitle":     doc.Title,
+			"DocumentShortName": doc.DocNumber,
+			"DocumentURL":       docURL.String(),
+			"DocumentOwner":     doc.Owner,
+			"DocumentType":      doc.DocType,
+			"Product":           doc.Product,
+		})
+	}
+
+	return map[string]any{
+		"SearchName":    d.Name,
+		"SearchURL":     savedSearchURL(d, baseURL),
+		"DocumentCount": len(d.Documents),
+		"Documents":     docs,
+		"Since":         d.Since.UTC().Format(time.RFC1123),
+	}
+}
+
+// savedSearchURL returns the URL of the results of the saved search in the web
+// application. Filters are passed as JSON array query parameters.
+func savedSearchURL(d *savedsearch.Digest, baseURL url.URL) string {
+	baseURL.Path = path.Join(baseURL.Path, "results")
+
+	q := url.Values{}
+	if d.Query != "" {
+		q.Set("q", d.Query)
+	}
+	keys := make([]string, 0, len(d.Filters))
+	for k := range d.Filters {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if len(d.Filters[k]) == 0 {
+			continue
+		}
+		v, err := json.Marshal(d.Filters[k])
+		if err != nil {
+			continue
+		}
+		q.Set(k, string(v))
+	}
+	baseURL.RawQuery = q.Encode()
+	return baseURL.String()
+}
@@ -0,0 +1,68 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/hashicorp-forge/hermes/pkg/notifications"
+	"github.com/hashicorp-forge/hermes/pkg/sensitive"
+)
+
+// SensitiveDataNotifier publishes sensitive data events to the notification
+// topic, addressed to the owners of the documents.
+type SensitiveDataNotifier struct {
+	provider *Provider
+	backends []string
+	baseURL  string
+}
+
+// NewSensitiveDataNotifier creates a notifier that routes sensitive data events
+// to the given backends. Document links are relative to the Hermes base URL
+// baseURL.
+func NewSensitiveDataNotifier(provider *Provider, backends []string, baseURL string) *SensitiveDataNotifier {
+	return &SensitiveDataNotifier{
+		provider: provider,
+		backends: backends,
+		baseURL:  baseURL,
+	}
+}
+
+// NotifySensitiveData implements sensitive.Notifier.
+func (n *SensitiveDataNotifier) NotifySensitiveData(ctx context.Context, ev *sensitive.Event) error {
+	if ev.Owner == "" {
+		return nil
+	}
+
+	docURL, err := url.Parse(n.baseURL)
+	if err != nil {
+		return fmt.Errorf("error parsing base URL: %w", err)
+	}
+	docURL.Path = path.Join(docURL.Path, "document", ev.DocumentID)
+
+	findings := make([]string, len(ev.Findings))
+	for i, f := range ev.Findings {
+		findings[i] = fmt.Sprintf("%s on line %d (%s)", f.Rule, f.Line, f.Match)
+	}
+
+	req := NotificationRequest{
+		Type:       notifications.NotificationTypeSensitiveDataDetected,
+		Recipients: []notifications.Recipient{{Email: ev.Owner}},
+		TemplateContext: map[string]any{
+			"DocumentTitle": ev.Title,
+			"DocumentURL":   docURL.String(),
+			"Blocked":       ev.Blocked,
+			"Findings":      strings.Join(findings, ", "),
+		},
+		Backends:     n.backends,
+		DocumentUUID: ev.DocumentUUID,
+		Priority:     1,
+	}
+	if err := n.provider.SendNotification(ctx, req); err != nil {
+		return fmt.Errorf("failed to send %s notification: %w",
+			notifications.NotificationTypeSensitiveDataDetected, err)
+	}
+	return nil
+}
@@ -5,19 +5,26 @@ import (
 	"embed"
 	"fmt"
 	"html/template"
+	"io/fs"
 	"path"
 	"regexp"
 	"strings"
 	"sync"
 	texttemplate "text/template"
 
+	"github.com/hashicorp-forge/hermes/pkg/i18n"
 	"github.com/hashicorp-forge/hermes/pkg/notifications"
 )
 
+// Notification templates are in "templates/<type>/". Translations are in
+// "templates/<type>/<locale>/" (e.g., "templates/new_owner/de/"), with the same
+// three templates.
+//
 //go:embed templates/*
 var templatesFS embed.FS
 
-// TemplateResolver loads and executes notification templates
+// TemplateResolver loads and executes notification templates. Templates are
+// keyed by notification type, and translations by "<type>/<locale>".
 type TemplateResolver struct {
 	subjectTemplates map[string]*texttemplate.Template
 	bodyTemplates    map[string]*texttemplate.Template
@@ -39,32 +46,60 @@ func NewTemplateResolver() (*TemplateResolver, error) {
 		notifications.NotificationTypeReviewRequested,
 		notifications.NotificationTypeNewOwner,
 		notifications.NotificationTypeDocumentPublished,
+		notifications.NotificationTypeMigrationJobStarted,
+		notifications.NotificationTypeMigrationJobHalfway,
+		notifications.NotificationTypeMigrationItemFailed,
+		notifications.NotificationTypeMigrationJobCompleted,
+		notifications.NotificationTypeReviewSLAAtRisk,
+		notifications.NotificationTypeReviewSLABreached,
+		notifications.NotificationTypeSensitiveDataDetected,
+		notifications.NotificationTypeRetentionNotice,
+		notifications.NotificationTypeSavedSearchDigest,
+		notifications.NotificationTypeDocumentIntegrityFailed,
 	}
 
 	for _, notifType := range templateTypes {
-		if err := resolver.loadTemplates(string(notifType)); err != nil {
+		baseDir := path.Join("templates", string(notifType))
+		if err := resolver.loadTemplates(string(notifType), baseDir); err != nil {
 			return nil, fmt.Errorf("failed to load templates for %s: %w", notifType, err)
 		}
+
+		// Load translations.
+		entries, err := fs.ReadDir(templatesFS, baseDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read templates for %s: %w", notifType, err)
+		}
+		for _, e := range entries {
+			if !e.IsDir() {
+				continue
+			}
+			locale, err := i18n.Normalize(e.Name())
+			if err != nil || locale != e.Name() {
+				return nil, fmt.Errorf("invalid locale directory %q for %s", e.Name(), notifType)
+			}
+			key := string(notifType) + "/" + locale
+			if err := resolver.loadTemplates(key, path.Join(baseDir, locale)); err != nil {
+				return nil, fmt.Errorf("failed to load %s templates for %s: %w", locale, notifType, err)
+			}
+		}
 	}
 
 	return resolver, nil
 }
 
-// loadTemplates loads all three template files for a notification type
-func (tr *TemplateResolver) loadTemplates(notifType string) error {
-	baseDir := path.Join("templates", notifType)
-
+// loadTemplates loads all three template files in baseDir with the given key
+func (tr *TemplateResolver) loadTemplates(key, baseDir string) error {
 	// Load subject template (text)
 	subjectPath := path.Join(baseDir, "subject.tmpl")
 	subjectData, err := templatesFS.ReadFile(subjectPath)
 	if err != nil {
 		return fmt.Errorf("failed to read subject template: %w", err)
 	}
-	subjectTmpl, err := texttemplate.New(notifType + "_subject").Parse(string(subjectData))
+	subjectTmpl, err := texttemplate.New(key + "_subject").Parse(string(subjectData))
 	if err != nil {
 		return fmt.Errorf("failed to parse subject template: %w", err)
 	}
-	tr.subjectTemplates[notifType] = subjectTmpl
+	tr.subjectTemplates[key] = subjectTmpl
 
 	// Load body markdown template (text)
 	bodyPath := path.Join(baseDir, "body.md.tmpl")
@@ -72,11 +107,11 @@ func (tr *TemplateResolver) loadTemplates(notifType string) error {
 	if err != nil {
 		return fmt.Errorf("failed to read body template: %w", err)
 	}
-	bodyTmpl, err := texttemplate.New(notifType + "_body").Parse(string(bodyData))
+	bodyTmpl, err := texttemplate.New(key + "_body").Parse(string(bodyData))
 	if err != nil {
 		return fmt.Errorf("failed to parse body template: %w", err)
 	}
-	tr.bodyTemplates[notifType] = bodyTmpl
+	tr.bodyTemplates[key] = bodyTmpl
 
 	// Load HTML template (html/template for auto-escaping)
 	htmlPath := path.Join(baseDir, "body.html.tmpl")
@@ -84,11 +119,11 @@ func (tr *TemplateResolver) loadTemplates(notifType string) error {
 	if err != nil {
 		return fmt.Errorf("failed to read HTML template: %w", err)
 	}
-	htmlTmpl, err := template.New(notifType + "_html").Parse(string(htmlData))
+	htmlTmpl, err := template.New(key + "_html").Parse(string(htmlData))
 	if err != nil {
 		return fmt.Errorf("failed to parse HTML template: %w", err)
 	}
-	tr.htmlTemplates[notifType] = htmlTmpl
+	tr.htmlTemplates[key] = htmlTmpl
 
 	return nil
 }
@@ -102,13 +137,26 @@ type ResolvedContent struct {
 
 // Resolve renders all templates for a notification type with the given context
 func (tr *TemplateResolver) Resolve(notifType notifications.NotificationType, context map[string]any) (*ResolvedContent, error) {
+	return tr.ResolveLocale(notifType, "", context)
+}
+
+// ResolveLocale renders all templates for a notification type in the given
+// locale, or in the default language if they aren't translated to the locale.
+func (tr *TemplateResolver) ResolveLocale(notifType notifications.NotificationType, locale string, context map[string]any) (*ResolvedContent, error) {
 	tr.mu.RLock()
 	defer tr.mu.RUnlock()
 
 	typeStr := string(notifType)
+	key := typeStr
+	for _, l := range i18n.Chain(locale) {
+		if _, ok := tr.subjectTemplates[typeStr+"/"+l]; ok {
+			key = typeStr + "/" + l
+			break
+		}
+	}
 
 	// Resolve subject
-	subjectTmpl, ok := tr.subjectTemplates[typeStr]
+	subjectTmpl, ok := tr.subjectTemplates[key]
 	if !ok {
 		return nil, fmt.Errorf("no subject template found for notification type: %s", notifType)
 	}
@@ -124,7 +172,7 @@ func (tr *TemplateResolver) Resolve(notifType notifications.NotificationType, co
 	}
 
 	// Resolve body (markdown)
-	bodyTmpl, ok := tr.bodyTemplates[typeStr]
+	bodyTmpl, ok := tr.bodyTemplates[key]
 	if !ok {
 		return nil, fmt.Errorf("no body template found for notification type: %s", notifType)
 	}
@@ -140,7 +188,7 @@ func (tr *TemplateResolver) Resolve(notifType notifications.NotificationType, co
 	}
 
 	// Resolve HTML
-	htmlTmpl, ok := tr.htmlTemplates[typeStr]
+	htmlTmpl, ok := tr.htmlTemplates[key]
 	if !ok {
 		return nil, fmt.Errorf("no HTML template found for notification type: %s", notifType)
 	}
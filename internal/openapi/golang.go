@@ -0,0 +1,432 @@
+package openapi
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// pathParamRE matches the parameters of a path template.
+var pathParamRE = regexp.MustCompile(`\{([^}]+)\}`)
+
+// goInitialisms are words that are written in upper case in Go identifiers.
+var goInitialisms = map[string]bool{
+	"api": true, "id": true, "json": true, "url": true, "uuid": true,
+}
+
+// operationRef is an operation with its method and path.
+type operationRef struct {
+	Method string
+	Path   string
+	*Operation
+}
+
+// operations returns the operations of doc sorted by operation ID.
+func operations(doc *Document) []operationRef {
+	var ops []operationRef
+	for p, item := range doc.Paths {
+		for m, op := range item {
+			ops = append(ops, operationRef{
+				Method:    strings.ToUpper(m),
+				Path:      p,
+				Operation: op,
+			})
+		}
+	}
+	sort.Slice(ops, func(i, j int) bool {
+		return ops[i].OperationID < ops[j].OperationID
+	})
+	return ops
+}
+
+// jsonBody returns the schema of the JSON content of a request or response
+// body, and whether the content is JSON.
+func jsonBody(content map[string]MediaType) (*Schema, bool) {
+	for ct, mt := range content {
+		if ct == "application/json" || strings.HasSuffix(ct, "+json") {
+			if mt.Schema == nil {
+				return &Schema{}, true
+			}
+			return mt.Schema, true
+		}
+	}
+	return nil, false
+}
+
+// successResponse returns the first 2xx response of op.
+func successResponse(op *Operation) *Response {
+	var codes []string
+	for code := range op.Responses {
+		if strings.HasPrefix(code, "2") {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+	if len(codes) == 0 {
+		return nil
+	}
+	return op.Responses[codes[0]]
+}
+
+// sortedKeys returns the keys of m in order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// goIdentifier converts a name like "edge_instance" or "docType" to an
+// exported Go identifier like "EdgeInstance" or "DocType".
+func goIdentifier(name string) string {
+	var words []string
+	for _, w := range strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		// Split camel case words.
+		start := 0
+		rs := []rune(w)
+		for i := 1; i < len(rs); i++ {
+			if unicode.IsUpper(rs[i]) && !unicode.IsUpper(rs[i-1]) {
+				words = append(words, string(rs[start:i]))
+				start = i
+			}
+		}
+		words = append(words, string(rs[start:]))
+	}
+
+	var b strings.Builder
+	for _, w := range words {
+		if goInitialisms[strings.ToLower(w)] {
+			b.WriteString(strings.ToUpper(w))
+		} else {
+			b.WriteString(exportedName(w))
+		}
+	}
+	return b.String()
+}
+
+// goGenerator generates a Go client.
+type goGenerator struct {
+	buf     bytes.Buffer
+	imports map[string]bool
+}
+
+// use records that the generated code uses package imp.
+func (g *goGenerator) use(imp string) {
+	if g.imports == nil {
+		g.imports = map[string]bool{}
+	}
+	g.imports[imp] = true
+}
+
+func (g *goGenerator) printf(format string, args ...any) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// GoClient generates the types and methods of a Go API client in package pkg
+// from doc. The generated methods send requests with the Client type and its
+// Doer interface, which the package must declare:
+//
+//	type Doer interface {
+//		Do(ctx context.Context, method, path string, body, result any) error
+//	}
+//
+//	type Client struct {
+//		doer Doer
+//	}
+//
+// Operations without a JSON response (e.g., file downloads) are skipped.
+func GoClient(doc *Document, pkg string) ([]byte, error) {
+	g := &goGenerator{}
+	var body goGenerator
+
+	// Types.
+	for _, name := range sortedKeys(doc.Components.Schemas) {
+		body.goStruct(name, doc.Components.Schemas[name])
+	}
+
+	// Methods.
+	for _, op := range operations(doc) {
+		if err := body.goMethod(op); err != nil {
+			return nil, err
+		}
+	}
+
+	g.printf("// Code generated by \"hermes operator generate-api\"; DO NOT EDIT.\n\n")
+	g.printf("package %s\n\n", pkg)
+	g.printf("import (\n")
+	for _, imp := range sortedKeys(body.imports) {
+		g.printf("\t%q\n", imp)
+	}
+	g.printf(")\n\n")
+	g.buf.Write(body.buf.Bytes())
+
+	out, err := format.Source(g.buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("error formatting generated Go client: %w", err)
+	}
+	return out, nil
+}
+
+// goType returns the Go type of schema s.
+func (g *goGenerator) goType(s *Schema) string {
+	if s == nil {
+		return "any"
+	}
+	if s.Ref != "" {
+		return s.RefName()
+	}
+	if ref := s.NullableRef(); ref != nil {
+		return "*" + g.goType(ref)
+	}
+
+	typ, nullable := s.BaseType()
+	var t string
+	switch typ {
+	case "string":
+		switch s.Format {
+		case "date-time":
+			g.use("time")
+			t = "time.Time"
+		case "byte":
+			t = "[]byte"
+		default:
+			t = "string"
+		}
+	case "integer":
+		if s.Format == "int64" {
+			t = "int64"
+		} else {
+			t = "int"
+		}
+	case "number":
+		t = "float64"
+	case "boolean":
+		t = "bool"
+	case "array":
+		t = "[]" + g.goType(s.Items)
+	case "object":
+		if s.AdditionalProperties != nil {
+			t = "map[string]" + g.goType(s.AdditionalProperties)
+		} else {
+			t = "map[string]any"
+		}
+	default:
+		return "any"
+	}
+	if nullable {
+		return "*" + t
+	}
+	return t
+}
+
+// goStruct generates the Go struct type for component schema s.
+func (g *goGenerator) goStruct(name string, s *Schema) {
+	g.printf("type %s struct {\n", name)
+	for _, prop := range sortedKeys(s.Properties) {
+		ps := s.Properties[prop]
+		field := ps.GoName
+		if field == "" {
+			field = goIdentifier(prop)
+		}
+		tag := prop
+		if !contains(s.Required, prop) {
+			tag += ",omitempty"
+		}
+		if ps.Description != "" {
+			g.printf("// %s\n", ps.Description)
+		}
+		g.printf("%s %s `json:%q`\n", field, g.goType(ps), tag)
+	}
+	g.printf("}\n\n")
+}
+
+// goMethod generates the Client method for op.
+func (g *goGenerator) goMethod(op operationRef) error {
+	var resultType string
+	if resp := successResponse(op.Operation); resp != nil && len(resp.Content) > 0 {
+		s, ok := jsonBody(resp.Content)
+		if !ok {
+			return nil
+		}
+		resultType = g.goType(s)
+		if resultType == "any" {
+			g.use("encoding/json")
+			resultType = "json.RawMessage"
+		}
+	}
+
+	name := goIdentifier(op.OperationID)
+	g.use("context")
+
+	// Query parameters.
+	var query []Parameter
+	for _, p := range op.Parameters {
+		if p.In == "query" {
+			query = append(query, p)
+		}
+	}
+	if len(query) > 0 {
+		g.goParams(name+"Params", query)
+	}
+
+	// Signature.
+	args := []string{"ctx context.Context"}
+	for _, m := range pathParamRE.FindAllStringSubmatch(op.Path, -1) {
+		args = append(args, goArgName(m[1])+" string")
+	}
+	if len(query) > 0 {
+		args = append(args, fmt.Sprintf("params *%sParams", name))
+	}
+	bodyArg := "nil"
+	if op.RequestBody != nil {
+		s, ok := jsonBody(op.RequestBody.Content)
+		if !ok {
+			return fmt.Errorf("operation %s has a non-JSON request body",
+				op.OperationID)
+		}
+		args = append(args, "body "+g.goType(s))
+		bodyArg = "body"
+	}
+	returns := "error"
+	if resultType != "" {
+		returns = fmt.Sprintf("(%s, error)", goResultType(resultType))
+	}
+
+	g.printf("// %s calls %s %s.\n", name, op.Method, op.Path)
+	if op.Summary != "" {
+		g.printf("//\n// %s.\n", strings.TrimSuffix(op.Summary, "."))
+	}
+	g.printf("func (c *Client) %s(%s) %s {\n",
+		name, strings.Join(args, ", "), returns)
+
+	// Path.
+	var parts []string
+	last := 0
+	for _, loc := range pathParamRE.FindAllStringSubmatchIndex(op.Path, -1) {
+		parts = append(parts, fmt.Sprintf("%q", op.Path[last:loc[0]]))
+		g.use("net/url")
+		parts = append(parts, fmt.Sprintf("url.PathEscape(%s)",
+			goArgName(op.Path[loc[2]:loc[3]])))
+		last = loc[1]
+	}
+	if last < len(op.Path) {
+		parts = append(parts, fmt.Sprintf("%q", op.Path[last:]))
+	}
+	g.printf("path := %s\n", strings.Join(parts, " + "))
+	if len(query) > 0 {
+		g.printf("path += params.encode()\n")
+	}
+
+	// Request.
+	if resultType == "" {
+		g.printf("return c.doer.Do(ctx, %q, path, %s, nil)\n}\n\n",
+			op.Method, bodyArg)
+		return nil
+	}
+	g.printf("var result %s\n", resultType)
+	g.printf("if err := c.doer.Do(ctx, %q, path, %s, &result); err != nil {\n",
+		op.Method, bodyArg)
+	if rt := goResultType(resultType); rt != resultType {
+		g.printf("return nil, err\n}\nreturn &result, nil\n}\n\n")
+	} else {
+		g.printf("return %s, err\n}\nreturn result, nil\n}\n\n",
+			goZeroValue(rt))
+	}
+	return nil
+}
+
+// goParams generates the query parameters type of an operation.
+func (g *goGenerator) goParams(name string, params []Parameter) {
+	g.printf("// %s are the query parameters of %s.\n",
+		name, strings.TrimSuffix(name, "Params"))
+	g.printf("type %s struct {\n", name)
+	for _, p := range params {
+		if p.Description != "" {
+			g.printf("// %s\n", p.Description)
+		}
+		g.printf("%s %s\n", goIdentifier(p.Name), g.goType(p.Schema))
+	}
+	g.printf("}\n\n")
+
+	g.printf("func (p *%s) encode() string {\n", name)
+	g.printf("if p == nil {\nreturn \"\"\n}\n")
+	g.use("net/url")
+	g.printf("q := url.Values{}\n")
+	for _, p := range params {
+		field := "p." + goIdentifier(p.Name)
+		switch g.goType(p.Schema) {
+		case "int":
+			g.use("strconv")
+			g.printf("if %s != 0 {\nq.Set(%q, strconv.Itoa(%s))\n}\n",
+				field, p.Name, field)
+		case "float64":
+			g.use("strconv")
+			g.printf("if %s != 0 {\nq.Set(%q, strconv.FormatFloat(%s, 'g', -1, 64))\n}\n",
+				field, p.Name, field)
+		case "bool":
+			g.printf("if %s {\nq.Set(%q, \"true\")\n}\n", field, p.Name)
+		default:
+			g.printf("if %s != \"\" {\nq.Set(%q, %s)\n}\n", field, p.Name, field)
+		}
+	}
+	g.printf("if len(q) == 0 {\nreturn \"\"\n}\n")
+	g.printf("return \"?\" + q.Encode()\n}\n\n")
+}
+
+// goResultType returns the return type of a method whose result is decoded
+// into a value of type t. Structs are returned as pointers.
+func goResultType(t string) string {
+	switch {
+	case strings.HasPrefix(t, "[]"), strings.HasPrefix(t, "map["),
+		strings.HasPrefix(t, "*"), t == "json.RawMessage", t == "string",
+		t == "int", t == "int64", t == "float64", t == "bool":
+		return t
+	}
+	return "*" + t
+}
+
+// goZeroValue returns the zero value of Go type t.
+func goZeroValue(t string) string {
+	switch t {
+	case "string":
+		return `""`
+	case "int", "int64", "float64":
+		return "0"
+	case "bool":
+		return "false"
+	}
+	return "nil"
+}
+
+// goArgName returns the Go argument name for a path parameter.
+func goArgName(name string) string {
+	return lowerFirst(goIdentifier(name))
+}
+
+// lowerFirst returns s with its first letter in lower case, or in lower case
+// entirely if s is an initialism like "ID".
+func lowerFirst(s string) string {
+	if s == strings.ToUpper(s) {
+		return strings.ToLower(s)
+	}
+	r := []rune(s)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
+
+func contains(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
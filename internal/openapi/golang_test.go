@@ -0,0 +1,129 @@
+package openapi
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testRequest struct {
+	Name string `json:"name"`
+}
+
+type testResponse struct {
+	ID    string   `json:"id"`
+	Items []string `json:"items"`
+}
+
+// testDocument returns a document with an operation that has a path
+// parameter, a query parameter, and request and response bodies.
+func testDocument() *Document {
+	g := NewGenerator(nil)
+	return &Document{
+		OpenAPI: Version,
+		Info:    Info{Title: "Test", Version: "1"},
+		Paths: map[string]PathItem{
+			"/things/{thingId}": {
+				"put": {
+					OperationID: "updateThing",
+					Summary:     "Update a thing",
+					Parameters: []Parameter{
+						{Name: "thingId", In: "path", Required: true,
+							Schema: &Schema{Type: "string"}},
+						{Name: "limit", In: "query",
+							Schema: &Schema{Type: "integer"}},
+						{Name: "minScore", In: "query",
+							Schema: &Schema{Type: "number"}},
+					},
+					RequestBody: &RequestBody{
+						Required: true,
+						Content: map[string]MediaType{
+							"application/json": {
+								Schema: g.Schema(reflect.TypeOf(testRequest{})),
+							},
+						},
+					},
+					Responses: map[string]*Response{
+						"200": {
+							Description: "OK",
+							Content: map[string]MediaType{
+								"application/json": {
+									Schema: g.Schema(reflect.TypeOf(testResponse{})),
+								},
+							},
+						},
+					},
+				},
+				"delete": {
+					OperationID: "deleteThing",
+					Responses: map[string]*Response{
+						"204": {Description: "No Content"},
+					},
+				},
+			},
+			"/things/{thingId}/export": {
+				"get": {
+					OperationID: "exportThing",
+					Responses: map[string]*Response{
+						"200": {
+							Description: "OK",
+							Content: map[string]MediaType{
+								"application/octet-stream": {},
+							},
+						},
+					},
+				},
+			},
+		},
+		Components: Components{Schemas: g.Schemas},
+	}
+}
+
+func TestGoClient(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := GoClient(testDocument(), "client")
+	require.NoError(t, err)
+	src := string(b)
+
+	assert.True(strings.HasPrefix(src, "// Code generated"))
+	assert.Contains(src, "package client\n")
+	assert.Contains(src, "type TestRequest struct {\n\tName string `json:\"name,omitempty\"`\n}")
+	assert.Contains(src, "func (c *Client) UpdateThing(ctx context.Context, thingID string, params *UpdateThingParams, body TestRequest) (*TestResponse, error) {")
+	assert.Contains(src, `path := "/things/" + url.PathEscape(thingID)`)
+	assert.Contains(src, `q.Set("limit", strconv.Itoa(p.Limit))`)
+	assert.Contains(src, `q.Set("minScore", strconv.FormatFloat(p.MinScore, 'g', -1, 64))`)
+	assert.Contains(src, "func (c *Client) DeleteThing(ctx context.Context, thingID string) error {")
+	assert.NotContains(src, "ExportThing")
+}
+
+func TestTypeScriptClient(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := TypeScriptClient(testDocument())
+	require.NoError(t, err)
+	src := string(b)
+
+	assert.Contains(src, "export interface TestResponse {\n  id?: string;\n  items?: string[];\n}")
+	assert.Contains(src, "export type UpdateThingParams = {\n  limit?: number;\n  minScore?: number;\n};")
+	assert.Contains(src, "  updateThing(\n    thingID: string,\n    params: UpdateThingParams = {},\n    body: TestRequest,\n  ): Promise<TestResponse> {")
+	assert.Contains(src, "return this.request(\"PUT\", `/things/${encodeURIComponent(thingID)}${queryString(params)}`, body);")
+	assert.Contains(src, "deleteThing(\n    thingID: string,\n  ): Promise<void> {")
+	assert.NotContains(src, "exportThing")
+}
+
+func TestGoIdentifier(t *testing.T) {
+	for in, want := range map[string]string{
+		"edge_instance":   "EdgeInstance",
+		"docType":         "DocType",
+		"targetId":        "TargetID",
+		"getAPIConfig":    "GetAPIConfig",
+		"includeInactive": "IncludeInactive",
+		"uuid":            "UUID",
+	} {
+		assert.Equal(t, want, goIdentifier(in), in)
+	}
+}
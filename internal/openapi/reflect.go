@@ -0,0 +1,192 @@
+package openapi
+
+import (
+	"encoding"
+	"encoding/json"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	timeType          = reflect.TypeOf(time.Time{})
+)
+
+// FieldHook is called for the schema of each struct field. It may add
+// constraints to the schema and returns true if the field is required.
+type FieldHook func(f reflect.StructField, s *Schema) (required bool)
+
+// Generator generates JSON schemas for Go types using the property names of
+// their json struct tags. Struct types are added to Schemas and referenced by
+// name.
+type Generator struct {
+	// Schemas are the generated component schemas keyed by name.
+	Schemas map[string]*Schema
+
+	// FieldHook, if set, is called for the schema of each struct field.
+	FieldHook FieldHook
+
+	names map[reflect.Type]string
+}
+
+// NewGenerator returns a new schema generator.
+func NewGenerator(hook FieldHook) *Generator {
+	return &Generator{
+		Schemas:   map[string]*Schema{},
+		FieldHook: hook,
+		names:     map[reflect.Type]string{},
+	}
+}
+
+// Schema returns the schema of type t, which is a reference for named struct
+// types.
+func (g *Generator) Schema(t reflect.Type) *Schema {
+	return g.schema(t, "")
+}
+
+// schema returns the schema of type t. nameHint names anonymous struct types.
+func (g *Generator) schema(t reflect.Type, nameHint string) *Schema {
+	if t.Kind() == reflect.Pointer {
+		s := g.schema(t.Elem(), nameHint)
+		if s.Ref != "" {
+			return &Schema{AnyOf: []*Schema{s, {Type: "null"}}}
+		}
+		if typ, nullable := s.BaseType(); typ != "" && !nullable {
+			s.Type = []string{typ, "null"}
+		}
+		return s
+	}
+
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t.Name() == "UUID":
+		return &Schema{Type: "string", Format: "uuid"}
+	case t.Implements(textMarshalerType) ||
+		reflect.PointerTo(t).Implements(textMarshalerType):
+		return &Schema{Type: "string"}
+	case t.Implements(jsonMarshalerType) ||
+		reflect.PointerTo(t).Implements(jsonMarshalerType):
+		// The encoding is unknown.
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// Byte slices are encoded as base64 strings.
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.schema(t.Elem(), nameHint+"Item")}
+	case reflect.Map:
+		return &Schema{
+			Type:                 "object",
+			AdditionalProperties: g.schema(t.Elem(), nameHint+"Value"),
+		}
+	case reflect.Struct:
+		return g.structSchema(t, nameHint)
+	default:
+		// Interfaces can hold any value.
+		return &Schema{}
+	}
+}
+
+// structSchema returns a reference to the component schema of struct type t,
+// adding the schema if needed.
+func (g *Generator) structSchema(t reflect.Type, nameHint string) *Schema {
+	if name, ok := g.names[t]; ok {
+		return &Schema{Ref: schemaRefPrefix + name}
+	}
+
+	name := exportedName(t.Name())
+	if name == "" {
+		name = nameHint
+	}
+	if _, ok := g.Schemas[name]; ok {
+		// Disambiguate types with the same name from different packages.
+		name = exportedName(path.Base(t.PkgPath())) + name
+	}
+	for base, i := name, 2; g.Schemas[name] != nil; i++ {
+		name = base + strconv.Itoa(i)
+	}
+
+	// Register the name before generating field schemas so recursive types
+	// reference it.
+	g.names[t] = name
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	g.Schemas[name] = s
+	g.addFields(s, t, name)
+	if len(s.Properties) == 0 {
+		s.Properties = nil
+	}
+
+	return &Schema{Ref: schemaRefPrefix + name}
+}
+
+// addFields adds the properties of the fields of struct type t to s. The
+// fields of embedded structs are promoted like encoding/json does.
+func (g *Generator) addFields(s *Schema, t reflect.Type, name string) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		jsonName, _, _ := strings.Cut(tag, ",")
+
+		ft := f.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && jsonName == "" && ft.Kind() == reflect.Struct {
+			g.addFields(s, ft, name)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if jsonName == "" {
+			jsonName = f.Name
+		}
+
+		switch ft.Kind() {
+		case reflect.Chan, reflect.Func, reflect.UnsafePointer,
+			reflect.Complex64, reflect.Complex128:
+			// These can't be encoded as JSON.
+			continue
+		}
+
+		fs := g.schema(f.Type, name+f.Name)
+		fs.GoName = f.Name
+		if g.FieldHook != nil && g.FieldHook(f, fs) {
+			s.Required = append(s.Required, jsonName)
+		}
+		s.Properties[jsonName] = fs
+	}
+}
+
+// exportedName returns name with its first letter in upper case.
+func exportedName(name string) string {
+	if name == "" {
+		return ""
+	}
+	r := []rune(name)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
@@ -0,0 +1,107 @@
+package openapi
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testEmbedded struct {
+	Embedded string `json:"embedded"`
+}
+
+type testChild struct {
+	Name string `json:"name"`
+}
+
+type testParent struct {
+	testEmbedded
+
+	ID       int64             `json:"id"`
+	Title    string            `json:"title" test:"required"`
+	Count    *int              `json:"count,omitempty"`
+	Created  time.Time         `json:"created"`
+	Tags     []string          `json:"tags"`
+	Labels   map[string]string `json:"labels"`
+	Child    *testChild        `json:"child"`
+	Children []testChild       `json:"children"`
+	Inline   struct {
+		Value bool `json:"value"`
+	} `json:"inline"`
+	Any      any    `json:"any"`
+	Ignored  string `json:"-"`
+	NoTag    string
+	internal string
+}
+
+func TestGeneratorSchema(t *testing.T) {
+	assert := assert.New(t)
+
+	g := NewGenerator(func(f reflect.StructField, s *Schema) bool {
+		return f.Tag.Get("test") == "required"
+	})
+	s := g.Schema(reflect.TypeOf(testParent{}))
+	assert.Equal("#/components/schemas/TestParent", s.Ref)
+	assert.Equal("TestParent", s.RefName())
+
+	parent := g.Schemas["TestParent"]
+	require.NotNil(t, parent)
+	assert.Equal([]string{"title"}, parent.Required)
+	assert.ElementsMatch([]string{
+		"embedded", "id", "title", "count", "created", "tags", "labels",
+		"child", "children", "inline", "any", "NoTag",
+	}, sortedKeys(parent.Properties))
+
+	props := parent.Properties
+	assert.Equal(&Schema{Type: "integer", Format: "int64", GoName: "ID"},
+		props["id"])
+	assert.Equal(&Schema{Type: []string{"integer", "null"}, GoName: "Count"},
+		props["count"])
+	assert.Equal(&Schema{Type: "string", Format: "date-time", GoName: "Created"},
+		props["created"])
+	assert.Equal(&Schema{
+		Type: "array", Items: &Schema{Type: "string"}, GoName: "Tags",
+	}, props["tags"])
+	assert.Equal(&Schema{
+		Type:                 "object",
+		AdditionalProperties: &Schema{Type: "string"},
+		GoName:               "Labels",
+	}, props["labels"])
+	assert.Equal(&Schema{
+		AnyOf: []*Schema{
+			{Ref: "#/components/schemas/TestChild"}, {Type: "null"},
+		},
+		GoName: "Child",
+	}, props["child"])
+	assert.Equal(&Schema{
+		Type:   "array",
+		Items:  &Schema{Ref: "#/components/schemas/TestChild"},
+		GoName: "Children",
+	}, props["children"])
+	assert.Equal(&Schema{Ref: "#/components/schemas/TestParentInline",
+		GoName: "Inline"}, props["inline"])
+	assert.Equal(&Schema{GoName: "Any"}, props["any"])
+
+	assert.Contains(g.Schemas, "TestChild")
+	assert.Contains(g.Schemas["TestParentInline"].Properties, "value")
+}
+
+func TestSchemaNullableRef(t *testing.T) {
+	ref := &Schema{Ref: "#/components/schemas/A"}
+	assert.Equal(t, ref,
+		(&Schema{AnyOf: []*Schema{ref, {Type: "null"}}}).NullableRef())
+	assert.Nil(t, ref.NullableRef())
+}
+
+func TestSchemaBaseType(t *testing.T) {
+	typ, nullable := (&Schema{Type: []string{"string", "null"}}).BaseType()
+	assert.Equal(t, "string", typ)
+	assert.True(t, nullable)
+
+	typ, nullable = (&Schema{Type: "array"}).BaseType()
+	assert.Equal(t, "array", typ)
+	assert.False(t, nullable)
+}
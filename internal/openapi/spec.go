@@ -0,0 +1,160 @@
+// Package openapi builds OpenAPI 3.1 documents from Go request and response
+// types and generates API clients from them.
+package openapi
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Version is the OpenAPI version of generated documents.
+const Version = "3.1.0"
+
+// Document is an OpenAPI document.
+type Document struct {
+	OpenAPI    string                `json:"openapi"`
+	Info       Info                  `json:"info"`
+	Paths      map[string]PathItem   `json:"paths"`
+	Components Components            `json:"components"`
+	Security   []map[string][]string `json:"security,omitempty"`
+}
+
+// Info is the metadata of an API.
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// PathItem contains the operations of a path keyed by lowercase HTTP method.
+type PathItem map[string]*Operation
+
+// Operation is an API operation.
+type Operation struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	Parameters  []Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+// Parameter is a path or query parameter of an operation.
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody is the request body of an operation.
+type RequestBody struct {
+	Required bool                 `json:"required,omitempty"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Response is a response of an operation.
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType is the schema of a request or response body.
+type MediaType struct {
+	Schema *Schema `json:"schema,omitempty"`
+}
+
+// Components contains the reusable schemas of a document.
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// SecurityScheme is an authentication scheme of an API.
+type SecurityScheme struct {
+	Type   string `json:"type"`
+	Scheme string `json:"scheme,omitempty"`
+}
+
+// Schema is a JSON schema (draft 2020-12, as used by OpenAPI 3.1).
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	Type                 any                `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+
+	// GoName is the name of the Go struct field of a property, used by the Go
+	// client generator.
+	GoName string `json:"x-go-name,omitempty"`
+}
+
+// JSON returns doc as indented JSON, as it's written to files.
+func JSON(doc *Document) ([]byte, error) {
+	b, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
+
+// schemaRefPrefix is the prefix of references to component schemas.
+const schemaRefPrefix = "#/components/schemas/"
+
+// RefName returns the name of the component schema referenced by s, or an
+// empty string if s is not a reference.
+func (s *Schema) RefName() string {
+	return strings.TrimPrefix(s.Ref, schemaRefPrefix)
+}
+
+// BaseType returns the type of s without "null", and whether s is nullable.
+func (s *Schema) BaseType() (typ string, nullable bool) {
+	switch t := s.Type.(type) {
+	case string:
+		return t, false
+	case []string:
+		for _, v := range t {
+			if v == "null" {
+				nullable = true
+			} else {
+				typ = v
+			}
+		}
+		return typ, nullable
+	}
+	return "", false
+}
+
+// NullableRef returns the schema referenced by s if s is a nullable reference
+// (i.e., anyOf a reference and null), or nil otherwise.
+func (s *Schema) NullableRef() *Schema {
+	if len(s.AnyOf) != 2 {
+		return nil
+	}
+	for i, o := range s.AnyOf {
+		if o.Type == "null" {
+			return s.AnyOf[1-i]
+		}
+	}
+	return nil
+}
+
+// ValueSchema returns the item schema of array schema s, or s otherwise.
+// Constraints on string values apply to the value schema so that they also
+// apply to each element of string arrays.
+func (s *Schema) ValueSchema() *Schema {
+	if s.Items != nil {
+		return s.Items
+	}
+	return s
+}
@@ -0,0 +1,243 @@
+package openapi
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// tsIdentifierRE matches property names that don't need quotes in
+// TypeScript.
+var tsIdentifierRE = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// tsClientSource is the hand-written part of the generated TypeScript client.
+const tsClientSource = `/**
+ * Sends an API request, like the fetch service's fetch method.
+ */
+export type ApiFetch = (
+  url: string,
+  options: { method: string; headers?: Record<string, string>; body?: string },
+) => Promise<Response | undefined>;
+
+type QueryParams = Record<string, string | number | boolean | undefined>;
+
+/**
+ * Returns the query string for the set parameters, if any.
+ */
+function queryString(params: QueryParams = {}): string {
+  const query = new URLSearchParams();
+  for (const [name, value] of Object.entries(params)) {
+    if (value !== undefined && value !== "" && value !== false) {
+      query.set(name, String(value));
+    }
+  }
+  const s = query.toString();
+  return s ? ` + "`?${s}`" + ` : "";
+}
+`
+
+// tsGenerator generates a TypeScript client.
+type tsGenerator struct {
+	buf bytes.Buffer
+}
+
+func (g *tsGenerator) printf(format string, args ...any) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// TypeScriptClient generates the types of doc and a client class with a method
+// for each operation. Operations without a JSON response are skipped.
+func TypeScriptClient(doc *Document) ([]byte, error) {
+	g := &tsGenerator{}
+	g.printf("// Code generated by \"hermes operator generate-api\"; DO NOT EDIT.\n")
+	g.printf("/* eslint-disable */\n\n")
+
+	// Types.
+	for _, name := range sortedKeys(doc.Components.Schemas) {
+		g.tsInterface(name, doc.Components.Schemas[name])
+	}
+
+	// Query parameter types.
+	ops := operations(doc)
+	for _, op := range ops {
+		if params := queryParams(op.Operation); len(params) > 0 {
+			g.printf("export type %sParams = {\n", goIdentifier(op.OperationID))
+			for _, p := range params {
+				g.printf("  %s?: %s;\n", tsPropertyName(p.Name), g.tsType(p.Schema))
+			}
+			g.printf("};\n\n")
+		}
+	}
+
+	g.buf.WriteString(tsClientSource)
+
+	// Client.
+	g.printf("\n/**\n * A client for the Hermes API.\n */\n")
+	g.printf("export default class HermesApiClient {\n")
+	g.printf("  constructor(private apiFetch: ApiFetch) {}\n")
+	for _, op := range ops {
+		if err := g.tsMethod(op); err != nil {
+			return nil, err
+		}
+	}
+	g.printf(`
+  private async request<T>(
+    method: string,
+    path: string,
+    body?: unknown,
+  ): Promise<T> {
+    const response = await this.apiFetch(path, {
+      method,
+      headers:
+        body === undefined ? {} : { "Content-Type": "application/json" },
+      body: body === undefined ? undefined : JSON.stringify(body),
+    });
+    if (!response || response.status === 204) {
+      return undefined as T;
+    }
+    const text = await response.text();
+    return (text ? JSON.parse(text) : undefined) as T;
+  }
+}
+`)
+
+	return g.buf.Bytes(), nil
+}
+
+// queryParams returns the query parameters of op.
+func queryParams(op *Operation) []Parameter {
+	var params []Parameter
+	for _, p := range op.Parameters {
+		if p.In == "query" {
+			params = append(params, p)
+		}
+	}
+	return params
+}
+
+// tsPropertyName returns name, quoted if needed.
+func tsPropertyName(name string) string {
+	if tsIdentifierRE.MatchString(name) {
+		return name
+	}
+	return fmt.Sprintf("%q", name)
+}
+
+// tsType returns the TypeScript type of schema s.
+func (g *tsGenerator) tsType(s *Schema) string {
+	if s == nil {
+		return "unknown"
+	}
+	if s.Ref != "" {
+		return s.RefName()
+	}
+	if ref := s.NullableRef(); ref != nil {
+		return g.tsType(ref) + " | null"
+	}
+
+	typ, nullable := s.BaseType()
+	var t string
+	switch typ {
+	case "string":
+		t = "string"
+	case "integer", "number":
+		t = "number"
+	case "boolean":
+		t = "boolean"
+	case "array":
+		item := g.tsType(s.Items)
+		if strings.Contains(item, " ") {
+			item = "(" + item + ")"
+		}
+		t = item + "[]"
+	case "object":
+		if s.AdditionalProperties != nil {
+			t = "Record<string, " + g.tsType(s.AdditionalProperties) + ">"
+		} else {
+			t = "Record<string, unknown>"
+		}
+	default:
+		return "unknown"
+	}
+	if nullable {
+		return t + " | null"
+	}
+	return t
+}
+
+// tsInterface generates the TypeScript interface for component schema s.
+func (g *tsGenerator) tsInterface(name string, s *Schema) {
+	if len(s.Properties) == 0 {
+		g.printf("export type %s = Record<string, unknown>;\n\n", name)
+		return
+	}
+	g.printf("export interface %s {\n", name)
+	for _, prop := range sortedKeys(s.Properties) {
+		optional := "?"
+		if contains(s.Required, prop) {
+			optional = ""
+		}
+		g.printf("  %s%s: %s;\n",
+			tsPropertyName(prop), optional, g.tsType(s.Properties[prop]))
+	}
+	g.printf("}\n\n")
+}
+
+// tsMethod generates the client method for op.
+func (g *tsGenerator) tsMethod(op operationRef) error {
+	resultType := "void"
+	if resp := successResponse(op.Operation); resp != nil && len(resp.Content) > 0 {
+		s, ok := jsonBody(resp.Content)
+		if !ok {
+			return nil
+		}
+		resultType = g.tsType(s)
+	}
+
+	var args []string
+	for _, m := range pathParamRE.FindAllStringSubmatch(op.Path, -1) {
+		args = append(args, goArgName(m[1])+": string")
+	}
+	params := queryParams(op.Operation)
+	if len(params) > 0 {
+		args = append(args,
+			fmt.Sprintf("params: %sParams = {}", goIdentifier(op.OperationID)))
+	}
+	bodyArg := ""
+	if op.RequestBody != nil {
+		s, ok := jsonBody(op.RequestBody.Content)
+		if !ok {
+			return fmt.Errorf("operation %s has a non-JSON request body",
+				op.OperationID)
+		}
+		args = append(args, "body: "+g.tsType(s))
+		bodyArg = ", body"
+	}
+
+	path := pathParamRE.ReplaceAllStringFunc(op.Path, func(m string) string {
+		return "${encodeURIComponent(" + goArgName(m[1:len(m)-1]) + ")}"
+	})
+	if len(params) > 0 {
+		path += "${queryString(params)}"
+	}
+
+	g.printf("\n  /**\n")
+	if op.Summary != "" {
+		g.printf("   * %s.\n   *\n", strings.TrimSuffix(op.Summary, "."))
+	}
+	g.printf("   * `%s %s`\n   */\n", op.Method, op.Path)
+	name := lowerFirst(goIdentifier(op.OperationID))
+	if len(args) == 0 {
+		g.printf("  %s(): Promise<%s> {\n", name, resultType)
+	} else {
+		g.printf("  %s(\n", name)
+		for _, a := range args {
+			g.printf("    %s,\n", a)
+		}
+		g.printf("  ): Promise<%s> {\n", resultType)
+	}
+	g.printf("    return this.request(\"%s\", `%s`%s);\n", op.Method, path, bodyArg)
+	g.printf("  }\n")
+	return nil
+}
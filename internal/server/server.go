@@ -1,10 +1,20 @@
 package server
 
 import (
+	"net/http"
+
+	"github.com/hashicorp-forge/hermes/internal/auth"
 	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/internal/email"
 	"github.com/hashicorp-forge/hermes/internal/jira"
+	"github.com/hashicorp-forge/hermes/pkg/avatar"
+	"github.com/hashicorp-forge/hermes/pkg/httpcache"
+	"github.com/hashicorp-forge/hermes/pkg/jobs"
+	"github.com/hashicorp-forge/hermes/pkg/migration"
 	"github.com/hashicorp-forge/hermes/pkg/projectconfig"
+	"github.com/hashicorp-forge/hermes/pkg/requestid"
 	"github.com/hashicorp-forge/hermes/pkg/search"
+	"github.com/hashicorp-forge/hermes/pkg/tenant"
 	"github.com/hashicorp-forge/hermes/pkg/workspace"
 	"github.com/hashicorp/go-hclog"
 	"gorm.io/gorm"
@@ -16,19 +26,42 @@ type Server struct {
 	// This is the preferred way to access search functionality.
 	SearchProvider search.Provider
 
+	// SearchVocabulary updates the synonyms and stop words of the search
+	// provider. Nil if the search provider doesn't support it.
+	SearchVocabulary search.VocabularyUpdater
+
 	// WorkspaceProvider is the workspace/storage backend (Google Drive, local, etc).
 	// Uses RFC-084 WorkspaceProvider interface for multi-provider architecture.
 	WorkspaceProvider workspace.WorkspaceProvider
 
+	// StorageProviders are the configured storage providers keyed by their
+	// provider_storage name (RFC-089), used to read documents that were stored
+	// in another provider before a migration.
+	StorageProviders map[string]workspace.WorkspaceProvider
+
+	// Folders resolves the folders that documents are stored in by the
+	// workspace provider. Nil uses the configured folders of the workspace
+	// provider.
+	Folders workspace.FolderLayout
+
 	// Config is the config for the server.
 	Config *config.Config
 
 	// DB is the database for the server.
 	DB *gorm.DB
 
+	// EmailSender sends email notifications. Nil sends them with the
+	// workspace provider.
+	EmailSender email.Sender
+
 	// Jira is the Jira service for the server.
 	Jira *jira.Service
 
+	// Jobs runs the background jobs queued by requests, such as indexing
+	// documents and sending emails. Nil runs them in goroutines without
+	// retries.
+	Jobs *jobs.Runner
+
 	// Logger is the logger for the server.
 	Logger hclog.Logger
 
@@ -44,4 +77,36 @@ type Server struct {
 	// HybridSearch combines keyword and semantic search (RFC-088).
 	// Provides weighted combination of Meilisearch and pgvector results.
 	HybridSearch *search.HybridSearch
+
+	// MigrationNotifier publishes migration lifecycle events (RFC-089) to the
+	// ops notification channel. Nil when notifications are disabled.
+	MigrationNotifier migration.Notifier
+
+	// ResponseCache caches the responses of expensive read endpoints. Nil if
+	// the response cache is disabled.
+	ResponseCache *httpcache.Cache
+
+	// Photos serves the profile photos of people. Nil fetches photos without
+	// caching them.
+	Photos *avatar.Proxy
+
+	// Tenants resolves the tenant of requests from their host names. Nil if no
+	// tenants are configured.
+	Tenants *tenant.Resolver
+
+	// Sessions sets the Secure and SameSite flags of cookies set by the API.
+	// Nil uses the default flags.
+	Sessions *auth.Sessions
+
+	// Stopping is closed when the server starts shutting down, so long-lived
+	// requests such as event streams end instead of holding up the shutdown.
+	// Nil is never closed.
+	Stopping <-chan struct{}
+}
+
+// ForRequest returns a copy of srv whose logger adds the request ID of r to
+// every line.
+func (srv Server) ForRequest(r *http.Request) Server {
+	srv.Logger = requestid.Logger(r.Context(), srv.Logger)
+	return srv
 }
@@ -9,6 +9,7 @@ import (
 
 	"github.com/hashicorp-forge/hermes/pkg/docid"
 	"github.com/hashicorp-forge/hermes/pkg/workspace"
+	"github.com/lib/pq"
 	"gorm.io/gorm"
 )
 
@@ -45,6 +46,55 @@ type EdgeDocumentRecord struct {
 	SyncedAt       time.Time      `json:"synced_at"`
 	LastSyncStatus string         `json:"last_sync_status"`
 	SyncError      string         `json:"sync_error,omitempty"`
+
+	// Revision is incremented on every change of the record. Edges send the
+	// revision their changes are based on to detect conflicting changes.
+	Revision int64 `json:"revision"`
+
+	// ChangeSeq orders the changes of all records. Edges use it as the
+	// checkpoint of the changes they have seen.
+	ChangeSeq int64 `json:"change_seq"`
+
+	// Content is the Markdown content of the document. It is only included in
+	// edge sync responses.
+	Content string `json:"content,omitempty"`
+}
+
+// edgeDocumentColumns are the columns of an EdgeDocumentRecord, in the order
+// scanned by scanEdgeDocument.
+const edgeDocumentColumns = `
+	uuid, title, document_type, COALESCE(status, ''), COALESCE(summary, ''),
+	owners, COALESCE(contributors, '{}'), edge_instance,
+	COALESCE(edge_provider_id, ''), COALESCE(product, ''),
+	COALESCE(tags, '{}'), COALESCE(parent_folders, '{}'),
+	COALESCE(metadata, '{}'::jsonb), COALESCE(content_hash, ''),
+	created_at, updated_at, synced_at, COALESCE(last_sync_status, ''),
+	COALESCE(sync_error, ''), revision, change_seq`
+
+// scanEdgeDocument scans a row of edgeDocumentColumns.
+func scanEdgeDocument(row interface{ Scan(...any) error }) (*EdgeDocumentRecord, error) {
+	var record EdgeDocumentRecord
+	var metadataBytes []byte
+
+	err := row.Scan(
+		&record.UUID, &record.Title, &record.DocumentType, &record.Status, &record.Summary,
+		pq.Array(&record.Owners), pq.Array(&record.Contributors), &record.EdgeInstance,
+		&record.EdgeProviderID, &record.Product,
+		pq.Array(&record.Tags), pq.Array(&record.ParentFolders),
+		&metadataBytes, &record.ContentHash,
+		&record.CreatedAt, &record.UpdatedAt, &record.SyncedAt, &record.LastSyncStatus,
+		&record.SyncError, &record.Revision, &record.ChangeSeq,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Unmarshal metadata
+	if err := json.Unmarshal(metadataBytes, &record.Metadata); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
+	}
+
+	return &record, nil
 }
 
 // RegisterDocument registers a document from an edge instance
@@ -102,33 +152,20 @@ func (s *DocumentSyncService) RegisterDocument(ctx context.Context, doc *workspa
 			updated_at = EXCLUDED.updated_at,
 			synced_at = EXCLUDED.synced_at,
 			last_sync_status = EXCLUDED.last_sync_status
-		RETURNING *
+		RETURNING ` + edgeDocumentColumns + `
 	`
 
-	var record EdgeDocumentRecord
-	var metadataBytes []byte
-
-	err = sqlDB.QueryRowContext(ctx, query,
+	record, err := scanEdgeDocument(sqlDB.QueryRowContext(ctx, query,
 		doc.UUID, doc.Name, doc.ProviderType, doc.WorkflowStatus, "", // summary empty for now
 		owners, contributors, edgeInstance, doc.ProviderID,
 		doc.Project, doc.Tags, doc.Parents, metadataJSON, doc.ContentHash,
 		doc.CreatedTime, doc.ModifiedTime, now, "synced",
-	).Scan(
-		&record.UUID, &record.Title, &record.DocumentType, &record.Status, &record.Summary,
-		&record.Owners, &record.Contributors, &record.EdgeInstance, &record.EdgeProviderID,
-		&record.Product, &record.Tags, &record.ParentFolders, &metadataBytes, &record.ContentHash,
-		&record.CreatedAt, &record.UpdatedAt, &record.SyncedAt, &record.LastSyncStatus, &record.SyncError,
-	)
+	))
 	if err != nil {
 		return nil, fmt.Errorf("failed to register document: %w", err)
 	}
 
-	// Unmarshal metadata
-	if err := json.Unmarshal(metadataBytes, &record.Metadata); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
-	}
-
-	return &record, nil
+	return record, nil
 }
 
 // UpdateDocumentMetadata updates document metadata from edge
@@ -154,7 +191,7 @@ func (s *DocumentSyncService) UpdateDocumentMetadata(ctx context.Context, uuid d
 			synced_at = $8,
 			last_sync_status = 'synced'
 		WHERE uuid = $1
-		RETURNING *
+		RETURNING ` + edgeDocumentColumns + `
 	`
 
 	// Extract update fields (with nil for unchanged)
@@ -175,32 +212,20 @@ func (s *DocumentSyncService) UpdateDocumentMetadata(ctx context.Context, uuid d
 		contentHash = &v
 	}
 
-	var record EdgeDocumentRecord
-	var metadataBytes []byte
-
-	err = sqlDB.QueryRowContext(ctx, query,
+	record, err := scanEdgeDocument(sqlDB.QueryRowContext(ctx, query,
 		uuid, title, status, summary, product, contentHash,
 		now, now,
-	).Scan(
-		&record.UUID, &record.Title, &record.DocumentType, &record.Status, &record.Summary,
-		&record.Owners, &record.Contributors, &record.EdgeInstance, &record.EdgeProviderID,
-		&record.Product, &record.Tags, &record.ParentFolders, &metadataBytes, &record.ContentHash,
-		&record.CreatedAt, &record.UpdatedAt, &record.SyncedAt, &record.LastSyncStatus, &record.SyncError,
-	)
+	))
 	if err != nil {
 		return nil, fmt.Errorf("failed to update document metadata: %w", err)
 	}
 
-	// Unmarshal metadata
-	if err := json.Unmarshal(metadataBytes, &record.Metadata); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
-	}
-
-	return &record, nil
+	return record, nil
 }
 
 // GetSyncStatus gets synchronization status for documents from an edge instance
-func (s *DocumentSyncService) GetSyncStatus(ctx context.Context, edgeInstance string, limit int) ([]*EdgeDocumentRecord, error) {
+// in scope (all documents if scope is empty)
+func (s *DocumentSyncService) GetSyncStatus(ctx context.Context, edgeInstance string, scope *EdgeSyncScope, limit int) ([]*EdgeDocumentRecord, error) {
 	// Get underlying sql.DB from gorm
 	sqlDB, err := s.db.DB()
 	if err != nil {
@@ -211,19 +236,17 @@ func (s *DocumentSyncService) GetSyncStatus(ctx context.Context, edgeInstance st
 		limit = 100
 	}
 
+	scopeCond, scopeArgs := scope.sqlCondition(3)
 	query := `
-		SELECT
-			uuid, title, document_type, status, summary,
-			owners, contributors, edge_instance, edge_provider_id,
-			product, tags, parent_folders, metadata, content_hash,
-			created_at, updated_at, synced_at, last_sync_status, sync_error
+		SELECT ` + edgeDocumentColumns + `
 		FROM edge_document_registry
-		WHERE edge_instance = $1
+		WHERE edge_instance = $1 AND ` + scopeCond + `
 		ORDER BY synced_at DESC
 		LIMIT $2
 	`
 
-	rows, err := sqlDB.QueryContext(ctx, query, edgeInstance, limit)
+	args := append([]any{edgeInstance, limit}, scopeArgs...)
+	rows, err := sqlDB.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query sync status: %w", err)
 	}
@@ -231,25 +254,11 @@ func (s *DocumentSyncService) GetSyncStatus(ctx context.Context, edgeInstance st
 
 	var records []*EdgeDocumentRecord
 	for rows.Next() {
-		var record EdgeDocumentRecord
-		var metadataBytes []byte
-
-		err := rows.Scan(
-			&record.UUID, &record.Title, &record.DocumentType, &record.Status, &record.Summary,
-			&record.Owners, &record.Contributors, &record.EdgeInstance, &record.EdgeProviderID,
-			&record.Product, &record.Tags, &record.ParentFolders, &metadataBytes, &record.ContentHash,
-			&record.CreatedAt, &record.UpdatedAt, &record.SyncedAt, &record.LastSyncStatus, &record.SyncError,
-		)
+		record, err := scanEdgeDocument(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan record: %w", err)
 		}
-
-		// Unmarshal metadata
-		if err := json.Unmarshal(metadataBytes, &record.Metadata); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
-		}
-
-		records = append(records, &record)
+		records = append(records, record)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -268,24 +277,12 @@ func (s *DocumentSyncService) GetDocumentByUUID(ctx context.Context, uuid docid.
 	}
 
 	query := `
-		SELECT
-			uuid, title, document_type, status, summary,
-			owners, contributors, edge_instance, edge_provider_id,
-			product, tags, parent_folders, metadata, content_hash,
-			created_at, updated_at, synced_at, last_sync_status, sync_error
+		SELECT ` + edgeDocumentColumns + `
 		FROM edge_document_registry
 		WHERE uuid = $1
 	`
 
-	var record EdgeDocumentRecord
-	var metadataBytes []byte
-
-	err = sqlDB.QueryRowContext(ctx, query, uuid).Scan(
-		&record.UUID, &record.Title, &record.DocumentType, &record.Status, &record.Summary,
-		&record.Owners, &record.Contributors, &record.EdgeInstance, &record.EdgeProviderID,
-		&record.Product, &record.Tags, &record.ParentFolders, &metadataBytes, &record.ContentHash,
-		&record.CreatedAt, &record.UpdatedAt, &record.SyncedAt, &record.LastSyncStatus, &record.SyncError,
-	)
+	record, err := scanEdgeDocument(sqlDB.QueryRowContext(ctx, query, uuid))
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("document not found: %s", uuid)
 	}
@@ -293,12 +290,7 @@ func (s *DocumentSyncService) GetDocumentByUUID(ctx context.Context, uuid docid.
 		return nil, fmt.Errorf("failed to get document: %w", err)
 	}
 
-	// Unmarshal metadata
-	if err := json.Unmarshal(metadataBytes, &record.Metadata); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
-	}
-
-	return &record, nil
+	return record, nil
 }
 
 // SearchDocuments searches edge documents by various criteria
@@ -315,11 +307,7 @@ func (s *DocumentSyncService) SearchDocuments(ctx context.Context, query string,
 
 	// Build search query
 	sqlQuery := `
-		SELECT
-			uuid, title, document_type, status, summary,
-			owners, contributors, edge_instance, edge_provider_id,
-			product, tags, parent_folders, metadata, content_hash,
-			created_at, updated_at, synced_at, last_sync_status, sync_error
+		SELECT ` + edgeDocumentColumns + `
 		FROM edge_document_registry
 		WHERE 1=1
 	`
@@ -369,25 +357,11 @@ func (s *DocumentSyncService) SearchDocuments(ctx context.Context, query string,
 
 	var records []*EdgeDocumentRecord
 	for rows.Next() {
-		var record EdgeDocumentRecord
-		var metadataBytes []byte
-
-		err := rows.Scan(
-			&record.UUID, &record.Title, &record.DocumentType, &record.Status, &record.Summary,
-			&record.Owners, &record.Contributors, &record.EdgeInstance, &record.EdgeProviderID,
-			&record.Product, &record.Tags, &record.ParentFolders, &metadataBytes, &record.ContentHash,
-			&record.CreatedAt, &record.UpdatedAt, &record.SyncedAt, &record.LastSyncStatus, &record.SyncError,
-		)
+		record, err := scanEdgeDocument(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan record: %w", err)
 		}
-
-		// Unmarshal metadata
-		if err := json.Unmarshal(metadataBytes, &record.Metadata); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
-		}
-
-		records = append(records, &record)
+		records = append(records, record)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/docid"
+)
+
+// Types of edge events.
+const (
+	// EdgeEventDocumentApproved is published when a document is approved.
+	EdgeEventDocumentApproved = "document.approved"
+
+	// EdgeEventDocumentPermissions is published when the owner, approvers, or
+	// sharing of a document change.
+	EdgeEventDocumentPermissions = "document.permissions_changed"
+
+	// EdgeEventConflictResolved is published when a conflict of a document is
+	// resolved on central, so the edge pulls the resolved version.
+	EdgeEventConflictResolved = "conflict.resolved"
+)
+
+const (
+	// edgeEventRetention is how long edge events are kept. Edges that were
+	// offline for longer catch up with a full sync.
+	edgeEventRetention = 7 * 24 * time.Hour
+
+	// edgeEventPollInterval is the interval at which WaitEdgeEvents checks for
+	// new events. Events are read from the database, so they are seen by the
+	// edge regardless of which central instance published them.
+	edgeEventPollInterval = time.Second
+)
+
+// EdgeEvent is a change on central that is relevant to an edge instance.
+type EdgeEvent struct {
+	// ID orders the events of an edge. Edges pass the ID of the last event
+	// they've seen to get the events after it.
+	ID           int64          `json:"id"`
+	EdgeInstance string         `json:"edge_instance"`
+	Type         string         `json:"type"`
+	DocumentUUID *docid.UUID    `json:"document_uuid,omitempty"`
+	Data         map[string]any `json:"data,omitempty"`
+	CreatedAt    time.Time      `json:"created_at"`
+}
+
+// edgeEventExecer executes the statements that publish edge events, in or
+// outside of a transaction.
+type edgeEventExecer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// PublishEdgeDocumentEvent publishes an event about a document to the edge
+// instance the document is registered from. It returns the number of events
+// published, which is 0 for documents that aren't edge documents.
+func (s *DocumentSyncService) PublishEdgeDocumentEvent(ctx context.Context, uuid docid.UUID, eventType string, data map[string]any) (int, error) {
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get sql.DB: %w", err)
+	}
+	return publishEdgeDocumentEvent(ctx, sqlDB, uuid, eventType, data)
+}
+
+// publishEdgeDocumentEvent publishes an event about a document to the edge
+// instance the document is registered from, and prunes expired events.
+func publishEdgeDocumentEvent(ctx context.Context, db edgeEventExecer, uuid docid.UUID, eventType string, data map[string]any) (int, error) {
+	var dataBytes []byte
+	if len(data) > 0 {
+		var err error
+		if dataBytes, err = json.Marshal(data); err != nil {
+			return 0, fmt.Errorf("failed to marshal event data: %w", err)
+		}
+	}
+
+	result, err := db.ExecContext(ctx, `
+		INSERT INTO edge_events (edge_instance, type, document_uuid, data)
+		SELECT edge_instance, $2, uuid, $3
+		FROM edge_document_registry
+		WHERE uuid = $1
+	`, uuid, eventType, dataBytes)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert event: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	_, err = db.ExecContext(ctx,
+		`DELETE FROM edge_events WHERE created_at < $1`,
+		time.Now().Add(-edgeEventRetention))
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune events: %w", err)
+	}
+	return int(n), nil
+}
+
+// ListEdgeEvents lists up to limit events of an edge instance after the event
+// with ID after, oldest first.
+func (s *DocumentSyncService) ListEdgeEvents(ctx context.Context, edgeInstance string, after int64, limit int) ([]*EdgeEvent, error) {
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sql.DB: %w", err)
+	}
+
+	if limit <= 0 {
+		limit = 100
+	}
+
+	rows, err := sqlDB.QueryContext(ctx, `
+		SELECT id, edge_instance, type, document_uuid, data, created_at
+		FROM edge_events
+		WHERE edge_instance = $1 AND id > $2
+		ORDER BY id
+		LIMIT $3
+	`, edgeInstance, after, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []*EdgeEvent{}
+	for rows.Next() {
+		var (
+			e         EdgeEvent
+			uuid      sql.NullString
+			dataBytes []byte
+		)
+		if err := rows.Scan(&e.ID, &e.EdgeInstance, &e.Type, &uuid,
+			&dataBytes, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+		if uuid.Valid {
+			id, err := docid.ParseUUID(uuid.String)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse event document UUID: %w", err)
+			}
+			e.DocumentUUID = &id
+		}
+		if len(dataBytes) > 0 {
+			if err := json.Unmarshal(dataBytes, &e.Data); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal event data: %w", err)
+			}
+		}
+		events = append(events, &e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return events, nil
+}
+
+// WaitEdgeEvents is ListEdgeEvents that waits up to wait for events if there
+// are none yet. It returns no events if none were published in time or ctx
+// is done.
+func (s *DocumentSyncService) WaitEdgeEvents(ctx context.Context, edgeInstance string, after int64, limit int, wait time.Duration) ([]*EdgeEvent, error) {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	ticker := time.NewTicker(edgeEventPollInterval)
+	defer ticker.Stop()
+
+	for {
+		events, err := s.ListEdgeEvents(ctx, edgeInstance, after, limit)
+		if err != nil || len(events) > 0 {
+			return events, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return events, nil
+		case <-timer.C:
+			return events, nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,396 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/docid"
+	"github.com/hashicorp-forge/hermes/pkg/merge"
+	"github.com/lib/pq"
+)
+
+// Statuses of edge document conflicts.
+const (
+	EdgeConflictOpen     = "open"
+	EdgeConflictResolved = "resolved"
+)
+
+var (
+	// ErrEdgeConflictNotFound is returned for conflicts that don't exist.
+	ErrEdgeConflictNotFound = errors.New("conflict not found")
+
+	// ErrEdgeConflictResolved is returned when resolving a conflict that was
+	// already resolved.
+	ErrEdgeConflictResolved = errors.New("conflict is already resolved")
+)
+
+// EdgeDocumentConflict is a change of an edge document that couldn't be
+// merged with the changes made on central.
+type EdgeDocumentConflict struct {
+	ID           int64      `json:"id"`
+	UUID         docid.UUID `json:"uuid"`
+	EdgeInstance string     `json:"edge_instance"`
+
+	// BaseRevision is the revision the edge's changes were based on, and
+	// CentralRevision is central's revision they conflicted with.
+	BaseRevision    int64 `json:"base_revision"`
+	CentralRevision int64 `json:"central_revision"`
+
+	EdgeContent string `json:"edge_content"`
+
+	// MergedContent is the merged content with conflict markers around the
+	// conflicting changes.
+	MergedContent string `json:"merged_content"`
+
+	// ConflictingFields are the metadata fields changed differently on the
+	// edge and central.
+	ConflictingFields []string `json:"conflicting_fields"`
+
+	Status           string     `json:"status"`
+	ResolvedBy       string     `json:"resolved_by,omitempty"`
+	ResolvedRevision int64      `json:"resolved_revision,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+	ResolvedAt       *time.Time `json:"resolved_at,omitempty"`
+}
+
+// EdgeConflictResolution is the resolved version of a conflicting document.
+type EdgeConflictResolution struct {
+	// Content is the resolved content.
+	Content string `json:"content"`
+
+	// Title, Status, Summary, and Product are the resolved metadata. Empty
+	// fields keep central's value.
+	Title   string `json:"title,omitempty"`
+	Status  string `json:"status,omitempty"`
+	Summary string `json:"summary,omitempty"`
+	Product string `json:"product,omitempty"`
+}
+
+// edgeConflictColumns are the columns of an EdgeDocumentConflict, in the
+// order scanned by scanEdgeConflict.
+const edgeConflictColumns = `
+	id, uuid, edge_instance, base_revision, central_revision, edge_content,
+	merged_content, conflicting_fields, status, COALESCE(resolved_by, ''),
+	COALESCE(resolved_revision, 0), created_at, updated_at, resolved_at`
+
+// scanEdgeConflict scans a row of edgeConflictColumns.
+func scanEdgeConflict(row interface{ Scan(...any) error }) (*EdgeDocumentConflict, error) {
+	var c EdgeDocumentConflict
+	var resolvedAt sql.NullTime
+	err := row.Scan(
+		&c.ID, &c.UUID, &c.EdgeInstance, &c.BaseRevision, &c.CentralRevision,
+		&c.EdgeContent, &c.MergedContent, pq.Array(&c.ConflictingFields),
+		&c.Status, &c.ResolvedBy, &c.ResolvedRevision, &c.CreatedAt,
+		&c.UpdatedAt, &resolvedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if resolvedAt.Valid {
+		c.ResolvedAt = &resolvedAt.Time
+	}
+	return &c, nil
+}
+
+// edgeContentHash returns the content hash of content, computed like the
+// frontmatter parser of edge instances does.
+func edgeContentHash(content string) string {
+	hash := sha256.Sum256([]byte(strings.TrimSpace(content)))
+	return "sha256:" + hex.EncodeToString(hash[:])
+}
+
+// edgeConflictResult returns the result of a change of doc that conflicts with
+// central's current record.
+func edgeConflictResult(current *EdgeDocumentRecord, doc *EdgeSyncDocument) *EdgeSyncResult {
+	return &EdgeSyncResult{
+		UUID:  doc.UUID,
+		State: EdgeSyncConflict,
+		Message: fmt.Sprintf(
+			"document was changed on central since revision %d (now %d)",
+			doc.BaseRevision, current.Revision),
+		Document: current,
+	}
+}
+
+// mergeEdgeDocument merges the changes of doc with the changes made on
+// central since doc's base revision, using the base revision as the common
+// ancestor. Merged changes are applied; otherwise the conflict is recorded
+// for manual resolution.
+func mergeEdgeDocument(ctx context.Context, tx *sql.Tx, current *EdgeDocumentRecord, doc *EdgeSyncDocument) (*EdgeSyncResult, error) {
+	if err := loadEdgeDocumentContent(ctx, tx, []*EdgeDocumentRecord{current}); err != nil {
+		return nil, err
+	}
+
+	// If the edge pushes the version a resolved conflict was recorded for,
+	// the resolution replaces it.
+	var resolved int64
+	err := tx.QueryRowContext(ctx, `
+		SELECT id
+		FROM edge_document_conflicts
+		WHERE uuid = $1 AND status = $2 AND base_revision = $3
+			AND edge_content_hash = $4
+		ORDER BY resolved_at DESC
+		LIMIT 1
+	`, doc.UUID, EdgeConflictResolved, doc.BaseRevision, doc.ContentHash).
+		Scan(&resolved)
+	if err == nil {
+		return &EdgeSyncResult{
+			UUID:     doc.UUID,
+			State:    EdgeSyncMerged,
+			Revision: current.Revision,
+			Message:  fmt.Sprintf("conflict %d was resolved on central", resolved),
+			Document: current,
+		}, nil
+	}
+	if err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to query resolved conflicts: %w", err)
+	}
+
+	var (
+		base        EdgeSyncDocument
+		baseContent sql.NullString
+	)
+	err = tx.QueryRowContext(ctx, `
+		SELECT title, document_type, COALESCE(status, ''),
+			COALESCE(summary, ''), COALESCE(product, ''), content
+		FROM edge_document_revisions
+		WHERE uuid = $1 AND revision = $2
+	`, doc.UUID, doc.BaseRevision).Scan(
+		&base.Title, &base.DocumentType, &base.Status, &base.Summary,
+		&base.Product, &baseContent,
+	)
+	if err == sql.ErrNoRows || (err == nil && !baseContent.Valid) ||
+		current.Content == "" {
+		// Without the content of both versions there is nothing to merge.
+		return edgeConflictResult(current, doc), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get base revision: %w", err)
+	}
+
+	// Merge the metadata fields: a field changed on one side only takes that
+	// side's value.
+	merged := *doc
+	var conflicting []string
+	for _, f := range []struct {
+		name          string
+		base, central string
+		edge          *string
+	}{
+		{"title", base.Title, current.Title, &merged.Title},
+		{"document_type", base.DocumentType, current.DocumentType, &merged.DocumentType},
+		{"status", base.Status, current.Status, &merged.Status},
+		{"summary", base.Summary, current.Summary, &merged.Summary},
+		{"product", base.Product, current.Product, &merged.Product},
+	} {
+		switch {
+		case *f.edge == f.central, f.central == f.base:
+		case *f.edge == f.base:
+			*f.edge = f.central
+		default:
+			conflicting = append(conflicting, f.name)
+		}
+	}
+
+	res := merge.ThreeWay(baseContent.String, doc.Content, current.Content,
+		merge.Labels{
+			Ours:   "edge " + current.EdgeInstance,
+			Base:   fmt.Sprintf("revision %d", doc.BaseRevision),
+			Theirs: fmt.Sprintf("central revision %d", current.Revision),
+		})
+
+	if res.Clean() && len(conflicting) == 0 {
+		merged.Content = strings.TrimSpace(res.Content)
+		merged.ContentHash = edgeContentHash(merged.Content)
+		record, err := updateEdgeDocument(ctx, tx, &merged)
+		if err != nil {
+			return nil, err
+		}
+		record.Content = merged.Content
+		return &EdgeSyncResult{
+			UUID:     doc.UUID,
+			State:    EdgeSyncMerged,
+			Revision: record.Revision,
+			Document: record,
+		}, nil
+	}
+
+	// Record the conflict, replacing the document's open conflict if the
+	// edge pushed conflicting changes before.
+	var id int64
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO edge_document_conflicts (
+			uuid, edge_instance, base_revision, central_revision,
+			edge_content, edge_content_hash, merged_content, conflicting_fields
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (uuid) WHERE status = 'open' DO UPDATE SET
+			edge_instance = EXCLUDED.edge_instance,
+			base_revision = EXCLUDED.base_revision,
+			central_revision = EXCLUDED.central_revision,
+			edge_content = EXCLUDED.edge_content,
+			edge_content_hash = EXCLUDED.edge_content_hash,
+			merged_content = EXCLUDED.merged_content,
+			conflicting_fields = EXCLUDED.conflicting_fields,
+			updated_at = NOW()
+		RETURNING id
+	`, doc.UUID, current.EdgeInstance, doc.BaseRevision, current.Revision,
+		doc.Content, doc.ContentHash, res.Content, pq.Array(conflicting),
+	).Scan(&id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record conflict: %w", err)
+	}
+
+	conflict := edgeConflictResult(current, doc)
+	conflict.ConflictID = id
+	parts := conflicting
+	if !res.Clean() {
+		parts = append([]string{"content"}, parts...)
+	}
+	conflict.Message = fmt.Sprintf(
+		"document has conflicting changes on the edge and central (%s); "+
+			"resolve conflict %d", strings.Join(parts, ", "), id)
+	return conflict, nil
+}
+
+// ListEdgeConflicts lists the conflicts of edgeInstance's documents with
+// status, most recent first. Empty arguments match all conflicts.
+func (s *DocumentSyncService) ListEdgeConflicts(ctx context.Context, edgeInstance, status string, limit int) ([]*EdgeDocumentConflict, error) {
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sql.DB: %w", err)
+	}
+
+	if limit <= 0 {
+		limit = 100
+	}
+
+	rows, err := sqlDB.QueryContext(ctx, `
+		SELECT `+edgeConflictColumns+`
+		FROM edge_document_conflicts
+		WHERE ($1 = '' OR edge_instance = $1) AND ($2 = '' OR status = $2)
+		ORDER BY updated_at DESC
+		LIMIT $3
+	`, edgeInstance, status, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query conflicts: %w", err)
+	}
+	defer rows.Close()
+
+	conflicts := []*EdgeDocumentConflict{}
+	for rows.Next() {
+		c, err := scanEdgeConflict(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan conflict: %w", err)
+		}
+		conflicts = append(conflicts, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	return conflicts, nil
+}
+
+// GetEdgeConflict gets a conflict by ID.
+func (s *DocumentSyncService) GetEdgeConflict(ctx context.Context, id int64) (*EdgeDocumentConflict, error) {
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sql.DB: %w", err)
+	}
+
+	c, err := scanEdgeConflict(sqlDB.QueryRowContext(ctx, `
+		SELECT `+edgeConflictColumns+`
+		FROM edge_document_conflicts
+		WHERE id = $1
+	`, id))
+	if err == sql.ErrNoRows {
+		return nil, ErrEdgeConflictNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get conflict: %w", err)
+	}
+	return c, nil
+}
+
+// ResolveEdgeConflict applies the resolution of an open conflict to the
+// document as a new revision, which the edge pulls at its next sync.
+func (s *DocumentSyncService) ResolveEdgeConflict(ctx context.Context, id int64, resolution *EdgeConflictResolution, resolvedBy string) (*EdgeDocumentRecord, error) {
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sql.DB: %w", err)
+	}
+
+	tx, err := sqlDB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	c, err := scanEdgeConflict(tx.QueryRowContext(ctx, `
+		SELECT `+edgeConflictColumns+`
+		FROM edge_document_conflicts
+		WHERE id = $1
+		FOR UPDATE
+	`, id))
+	if err == sql.ErrNoRows {
+		return nil, ErrEdgeConflictNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get conflict: %w", err)
+	}
+	if c.Status != EdgeConflictOpen {
+		return nil, ErrEdgeConflictResolved
+	}
+
+	content := strings.TrimSpace(resolution.Content)
+	record, err := scanEdgeDocument(tx.QueryRowContext(ctx, `
+		UPDATE edge_document_registry
+		SET
+			content = $2,
+			content_hash = $3,
+			title = COALESCE(NULLIF($4, ''), title),
+			status = COALESCE(NULLIF($5, ''), status),
+			summary = COALESCE(NULLIF($6, ''), summary),
+			product = COALESCE(NULLIF($7, ''), product),
+			updated_at = NOW()
+		WHERE uuid = $1
+		RETURNING `+edgeDocumentColumns+`
+	`, c.UUID, content, edgeContentHash(content), resolution.Title,
+		resolution.Status, resolution.Summary, resolution.Product))
+	if err != nil {
+		return nil, fmt.Errorf("failed to update document: %w", err)
+	}
+	record.Content = content
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE edge_document_conflicts
+		SET status = $2, resolved_by = $3, resolved_revision = $4,
+			resolved_at = NOW(), updated_at = NOW()
+		WHERE id = $1
+	`, id, EdgeConflictResolved, resolvedBy, record.Revision)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve conflict: %w", err)
+	}
+
+	// Let the edge know that the resolved version is ready to be pulled.
+	_, err = publishEdgeDocumentEvent(ctx, tx, c.UUID, EdgeEventConflictResolved,
+		map[string]any{
+			"conflict_id": id,
+			"revision":    record.Revision,
+			"resolved_by": resolvedBy,
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return record, nil
+}
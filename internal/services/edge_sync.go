@@ -0,0 +1,458 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/docid"
+	"github.com/lib/pq"
+)
+
+// Sync states of the documents of an edge sync request.
+const (
+	// EdgeSyncCreated means the document wasn't registered and was created.
+	EdgeSyncCreated = "created"
+	// EdgeSyncUpdated means the edge's changes were applied.
+	EdgeSyncUpdated = "updated"
+	// EdgeSyncDeleted means the document was deleted, or was already gone.
+	EdgeSyncDeleted = "deleted"
+	// EdgeSyncUnchanged means central already has the edge's version.
+	EdgeSyncUnchanged = "unchanged"
+	// EdgeSyncMerged means the document was changed on central since the
+	// edge's base revision, and the edge's changes were merged with central's.
+	// The edge must apply the merged document of the result.
+	EdgeSyncMerged = "merged"
+	// EdgeSyncConflict means the document was changed on central since the
+	// edge's base revision, and the changes couldn't be merged. The edge's
+	// changes weren't applied.
+	EdgeSyncConflict = "conflict"
+	// EdgeSyncRejected means the document is registered by another edge
+	// instance.
+	EdgeSyncRejected = "rejected"
+)
+
+// EdgeSyncScope is the subset of documents an edge instance syncs, e.g., the
+// documents of an office or team. Documents match the scope if they match
+// every non-empty list of it.
+type EdgeSyncScope struct {
+	Products      []string `json:"products,omitempty"`
+	DocumentTypes []string `json:"document_types,omitempty"`
+
+	// Projects are matched against the "project" metadata of documents.
+	Projects []string `json:"projects,omitempty"`
+}
+
+// IsEmpty returns true if the scope matches all documents.
+func (sc *EdgeSyncScope) IsEmpty() bool {
+	return sc == nil ||
+		len(sc.Products) == 0 && len(sc.DocumentTypes) == 0 && len(sc.Projects) == 0
+}
+
+// Includes returns true if doc matches the scope.
+func (sc *EdgeSyncScope) Includes(doc *EdgeSyncDocument) bool {
+	if sc.IsEmpty() {
+		return true
+	}
+	project, _ := doc.Metadata["project"].(string)
+	return matchesScope(sc.Products, doc.Product) &&
+		matchesScope(sc.DocumentTypes, doc.DocumentType) &&
+		matchesScope(sc.Projects, project)
+}
+
+// matchesScope returns true if values is empty or contains v.
+func matchesScope(values []string, v string) bool {
+	return len(values) == 0 || slices.Contains(values, v)
+}
+
+// sqlCondition returns the SQL condition matching edge_document_registry
+// records in the scope, starting at parameter $n, and its arguments.
+func (sc *EdgeSyncScope) sqlCondition(n int) (string, []any) {
+	if sc.IsEmpty() {
+		return "TRUE", nil
+	}
+	var (
+		conds []string
+		args  []any
+	)
+	for _, f := range []struct {
+		expr   string
+		values []string
+	}{
+		{"product", sc.Products},
+		{"document_type", sc.DocumentTypes},
+		{"metadata->>'project'", sc.Projects},
+	} {
+		if len(f.values) == 0 {
+			continue
+		}
+		conds = append(conds, fmt.Sprintf("%s = ANY($%d)", f.expr, n))
+		args = append(args, pq.Array(f.values))
+		n++
+	}
+	return strings.Join(conds, " AND "), args
+}
+
+// maxEdgeSyncChanges is the maximum number of changed records returned by a
+// SyncEdgeDocuments call.
+const maxEdgeSyncChanges = 500
+
+// EdgeSyncDocument is the version of a document on an edge instance.
+type EdgeSyncDocument struct {
+	UUID docid.UUID `json:"uuid"`
+
+	// BaseRevision is the revision of the central record the edge's version is
+	// based on, or 0 if the document was never synced.
+	BaseRevision int64 `json:"base_revision"`
+
+	// Deleted is true if the document was deleted on the edge.
+	Deleted bool `json:"deleted,omitempty"`
+
+	Title        string         `json:"title"`
+	DocumentType string         `json:"document_type"`
+	Status       string         `json:"status"`
+	Summary      string         `json:"summary"`
+	Product      string         `json:"product"`
+	Owners       []string       `json:"owners"`
+	ProviderID   string         `json:"provider_id"`
+	Tags         []string       `json:"tags"`
+	Metadata     map[string]any `json:"metadata"`
+	ContentHash  string         `json:"content_hash"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+
+	// Content is the Markdown content of the document, without frontmatter.
+	// Changes to documents synced without content can't be merged.
+	Content string `json:"content,omitempty"`
+}
+
+// EdgeSyncResult is the sync state of a document of an edge sync request.
+type EdgeSyncResult struct {
+	UUID  docid.UUID `json:"uuid"`
+	State string     `json:"state"`
+
+	// Revision is the revision of the central record after the sync, or 0 if
+	// the document was deleted.
+	Revision int64 `json:"revision"`
+
+	// Message explains conflicts and rejections.
+	Message string `json:"message,omitempty"`
+
+	// ConflictID is the ID of the conflict to resolve for conflicting
+	// documents whose changes couldn't be merged.
+	ConflictID int64 `json:"conflict_id,omitempty"`
+
+	// Document is the central record of conflicting documents, or the merged
+	// record of merged documents, with its content.
+	Document *EdgeDocumentRecord `json:"document,omitempty"`
+}
+
+// EdgeSyncResponse is the result of an edge sync request.
+type EdgeSyncResponse struct {
+	// Results are the sync states of the documents of the request, in the
+	// same order.
+	Results []*EdgeSyncResult `json:"results"`
+
+	// Changes are the records of the edge instance changed since the
+	// checkpoint of the request, including the changes of the request, in
+	// the order they were changed, with their content.
+	Changes []*EdgeDocumentRecord `json:"changes"`
+
+	// Checkpoint is the checkpoint of the next request.
+	Checkpoint int64 `json:"checkpoint"`
+
+	// More is true if there are more changes after Checkpoint.
+	More bool `json:"more"`
+}
+
+// SyncEdgeDocuments applies the changes of edgeInstance's documents to
+// central and returns the records of edgeInstance changed since checkpoint.
+// If scope isn't empty, only the records in the scope are returned and
+// documents outside of it are rejected, so they aren't registered on central.
+//
+// Changes are only applied if the document wasn't changed on central since
+// the edge's base revision; otherwise the document is in conflict, unless
+// both versions are the same. All changes are applied in one transaction.
+func (s *DocumentSyncService) SyncEdgeDocuments(ctx context.Context, edgeInstance string, checkpoint int64, scope *EdgeSyncScope, docs []*EdgeSyncDocument) (*EdgeSyncResponse, error) {
+	// Get underlying sql.DB from gorm
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sql.DB: %w", err)
+	}
+
+	tx, err := sqlDB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	resp := &EdgeSyncResponse{
+		Results: make([]*EdgeSyncResult, 0, len(docs)),
+		Changes: []*EdgeDocumentRecord{},
+	}
+	for _, doc := range docs {
+		// Deletions are always applied, which also removes documents that
+		// were synced before the scope changed.
+		if !doc.Deleted && !scope.Includes(doc) {
+			resp.Results = append(resp.Results, &EdgeSyncResult{
+				UUID:    doc.UUID,
+				State:   EdgeSyncRejected,
+				Message: "document is outside of the edge instance's sync scope",
+			})
+			continue
+		}
+		res, err := syncEdgeDocument(ctx, tx, edgeInstance, doc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sync document %s: %w", doc.UUID, err)
+		}
+		resp.Results = append(resp.Results, res)
+	}
+
+	scopeCond, scopeArgs := scope.sqlCondition(4)
+	query := `
+		SELECT ` + edgeDocumentColumns + `
+		FROM edge_document_registry
+		WHERE edge_instance = $1 AND change_seq > $2 AND ` + scopeCond + `
+		ORDER BY change_seq
+		LIMIT $3
+	`
+
+	args := append([]any{edgeInstance, checkpoint, maxEdgeSyncChanges + 1},
+		scopeArgs...)
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query changes: %w", err)
+	}
+	defer rows.Close()
+
+	resp.Checkpoint = checkpoint
+	for rows.Next() {
+		record, err := scanEdgeDocument(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan record: %w", err)
+		}
+		if len(resp.Changes) == maxEdgeSyncChanges {
+			resp.More = true
+			break
+		}
+		resp.Changes = append(resp.Changes, record)
+		resp.Checkpoint = record.ChangeSeq
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+	rows.Close()
+
+	if err := loadEdgeDocumentContent(ctx, tx, resp.Changes); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return resp, nil
+}
+
+// syncEdgeDocument applies the changes of doc in tx.
+func syncEdgeDocument(ctx context.Context, tx *sql.Tx, edgeInstance string, doc *EdgeSyncDocument) (*EdgeSyncResult, error) {
+	res := &EdgeSyncResult{UUID: doc.UUID}
+
+	query := `
+		SELECT ` + edgeDocumentColumns + `
+		FROM edge_document_registry
+		WHERE uuid = $1
+		FOR UPDATE
+	`
+	current, err := scanEdgeDocument(tx.QueryRowContext(ctx, query, doc.UUID))
+	if err == sql.ErrNoRows {
+		if doc.Deleted {
+			res.State = EdgeSyncDeleted
+			return res, nil
+		}
+		record, err := insertEdgeDocument(ctx, tx, edgeInstance, doc)
+		if err != nil {
+			return nil, err
+		}
+		res.State = EdgeSyncCreated
+		res.Revision = record.Revision
+		return res, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if current.EdgeInstance != edgeInstance {
+		res.State = EdgeSyncRejected
+		res.Message = fmt.Sprintf(
+			"document is registered by edge instance %q", current.EdgeInstance)
+		return res, nil
+	}
+
+	if current.Revision != doc.BaseRevision {
+		if !doc.Deleted && sameEdgeDocument(current, doc) {
+			res.State = EdgeSyncUnchanged
+			res.Revision = current.Revision
+			return res, nil
+		}
+		if !doc.Deleted && doc.Content != "" && doc.BaseRevision > 0 {
+			return mergeEdgeDocument(ctx, tx, current, doc)
+		}
+		return edgeConflictResult(current, doc), nil
+	}
+
+	if doc.Deleted {
+		_, err := tx.ExecContext(ctx,
+			`DELETE FROM edge_document_registry WHERE uuid = $1`, doc.UUID)
+		if err != nil {
+			return nil, err
+		}
+		res.State = EdgeSyncDeleted
+		return res, nil
+	}
+
+	if sameEdgeDocument(current, doc) {
+		res.State = EdgeSyncUnchanged
+		res.Revision = current.Revision
+		return res, nil
+	}
+
+	record, err := updateEdgeDocument(ctx, tx, doc)
+	if err != nil {
+		return nil, err
+	}
+	res.State = EdgeSyncUpdated
+	res.Revision = record.Revision
+	return res, nil
+}
+
+// insertEdgeDocument registers doc for edgeInstance in tx.
+func insertEdgeDocument(ctx context.Context, tx *sql.Tx, edgeInstance string, doc *EdgeSyncDocument) (*EdgeDocumentRecord, error) {
+	metadataJSON, err := json.Marshal(doc.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+
+	now := time.Now()
+	query := `
+		INSERT INTO edge_document_registry (
+			uuid, title, document_type, status, summary,
+			owners, edge_instance, edge_provider_id,
+			product, tags, metadata, content_hash,
+			created_at, updated_at, synced_at, last_sync_status, content
+		) VALUES (
+			$1, $2, $3, $4, $5,
+			$6, $7, $8,
+			$9, $10, $11, $12,
+			$13, $14, $15, 'synced', $16
+		)
+		RETURNING ` + edgeDocumentColumns + `
+	`
+	return scanEdgeDocument(tx.QueryRowContext(ctx, query,
+		doc.UUID, doc.Title, doc.DocumentType, doc.Status, doc.Summary,
+		pq.Array(doc.Owners), edgeInstance, doc.ProviderID,
+		doc.Product, pq.Array(doc.Tags), metadataJSON, doc.ContentHash,
+		updatedAtOrNow(doc.UpdatedAt, now), updatedAtOrNow(doc.UpdatedAt, now), now,
+		edgeContent(doc.Content),
+	))
+}
+
+// updateEdgeDocument applies the changes of doc in tx.
+func updateEdgeDocument(ctx context.Context, tx *sql.Tx, doc *EdgeSyncDocument) (*EdgeDocumentRecord, error) {
+	metadataJSON, err := json.Marshal(doc.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+
+	now := time.Now()
+	query := `
+		UPDATE edge_document_registry
+		SET
+			title = $2,
+			document_type = $3,
+			status = $4,
+			summary = $5,
+			owners = $6,
+			edge_provider_id = $7,
+			product = $8,
+			tags = $9,
+			metadata = $10,
+			content_hash = $11,
+			updated_at = $12,
+			synced_at = $13,
+			last_sync_status = 'synced',
+			sync_error = NULL,
+			content = COALESCE($14, content)
+		WHERE uuid = $1
+		RETURNING ` + edgeDocumentColumns + `
+	`
+	return scanEdgeDocument(tx.QueryRowContext(ctx, query,
+		doc.UUID, doc.Title, doc.DocumentType, doc.Status, doc.Summary,
+		pq.Array(doc.Owners), doc.ProviderID, doc.Product, pq.Array(doc.Tags),
+		metadataJSON, doc.ContentHash, updatedAtOrNow(doc.UpdatedAt, now), now,
+		edgeContent(doc.Content),
+	))
+}
+
+// sameEdgeDocument returns true if record has the content and metadata of doc.
+func sameEdgeDocument(record *EdgeDocumentRecord, doc *EdgeSyncDocument) bool {
+	return record.ContentHash == doc.ContentHash &&
+		record.Title == doc.Title &&
+		record.DocumentType == doc.DocumentType &&
+		record.Status == doc.Status &&
+		record.Summary == doc.Summary &&
+		record.Product == doc.Product
+}
+
+// edgeContent returns the content column value of content, which is NULL if
+// the edge didn't send the content.
+func edgeContent(content string) sql.NullString {
+	return sql.NullString{String: content, Valid: content != ""}
+}
+
+// loadEdgeDocumentContent sets the content of records.
+func loadEdgeDocumentContent(ctx context.Context, tx *sql.Tx, records []*EdgeDocumentRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+	byUUID := make(map[docid.UUID]*EdgeDocumentRecord, len(records))
+	uuids := make([]string, 0, len(records))
+	for _, r := range records {
+		byUUID[r.UUID] = r
+		uuids = append(uuids, r.UUID.String())
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT uuid, content
+		FROM edge_document_registry
+		WHERE uuid = ANY($1::uuid[]) AND content IS NOT NULL
+	`, pq.Array(uuids))
+	if err != nil {
+		return fmt.Errorf("failed to query content: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			uuid    docid.UUID
+			content string
+		)
+		if err := rows.Scan(&uuid, &content); err != nil {
+			return fmt.Errorf("failed to scan content: %w", err)
+		}
+		if r, ok := byUUID[uuid]; ok {
+			r.Content = content
+		}
+	}
+	return rows.Err()
+}
+
+// updatedAtOrNow returns t, or now if t is zero.
+func updatedAtOrNow(t, now time.Time) time.Time {
+	if t.IsZero() {
+		return now
+	}
+	return t
+}
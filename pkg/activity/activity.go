@@ -0,0 +1,190 @@
+// Package activity records the activity of users on documents (views, edits,
+// and reviews) for the dashboard and document activity feeds, and deletes it
+// after a retention period.
+package activity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"gorm.io/gorm"
+)
+
+const (
+	// DedupWindow is how long repeated activity of the same kind by the same
+	// user on the same document is recorded only once. Opening a document
+	// several times in a row is one view.
+	DedupWindow = 15 * time.Minute
+
+	// recentlyViewedDocsLimit is the number of recently viewed documents kept
+	// for each user.
+	recentlyViewedDocsLimit = 10
+)
+
+// Record records that the user with email address email performed action on
+// document doc at time at. Activity repeated within the dedup window is not
+// recorded again.
+func Record(
+	db *gorm.DB,
+	email string,
+	doc models.Document,
+	action models.UserActivityAction,
+	at time.Time,
+) error {
+	u, err := getOrCreateUser(db, email)
+	if err != nil {
+		return err
+	}
+	_, err = record(db, u, doc, action, at)
+	return err
+}
+
+// RecordView records that the user with email address email viewed document
+// doc at time at, updates the user's recently viewed documents, and counts the
+// view in the document's view stats.
+func RecordView(db *gorm.DB, email string, doc models.Document, at time.Time) error {
+	u, err := getOrCreateUser(db, email)
+	if err != nil {
+		return err
+	}
+	if err := updateRecentlyViewedDocs(db, u, doc, at); err != nil {
+		return err
+	}
+	recorded, err := record(db, u, doc, models.ViewUserActivityAction, at)
+	if err != nil || !recorded {
+		return err
+	}
+	return models.IncrementDocumentViews(db, doc.ID, u.ID, at)
+}
+
+// ForUser returns the activity of the user with email address email, newest
+// first. If the user doesn't exist, it has no activity.
+func ForUser(
+	db *gorm.DB, email string, f models.UserActivityFilter,
+) (models.UserActivities, error) {
+	u := models.User{
+		EmailAddress: email,
+	}
+	if err := db.Where(&u).First(&u).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.UserActivities{}, nil
+		}
+		return nil, fmt.Errorf("error getting user: %w", err)
+	}
+
+	f.UserID = u.ID
+	var as models.UserActivities
+	if err := as.Find(db, f); err != nil {
+		return nil, fmt.Errorf("error finding user activities: %w", err)
+	}
+	return as, nil
+}
+
+// ForDocument returns the activity on document doc, newest first.
+func ForDocument(
+	db *gorm.DB, doc models.Document, f models.UserActivityFilter,
+) (models.UserActivities, error) {
+	f.DocumentID = doc.ID
+	var as models.UserActivities
+	if err := as.Find(db, f); err != nil {
+		return nil, fmt.Errorf("error finding user activities: %w", err)
+	}
+	return as, nil
+}
+
+// record records the user activity unless it is repeated within the dedup
+// window, and returns true if it was recorded.
+func record(
+	db *gorm.DB,
+	u models.User,
+	doc models.Document,
+	action models.UserActivityAction,
+	at time.Time,
+) (bool, error) {
+	a := models.UserActivity{
+		UserID:     u.ID,
+		DocumentID: doc.ID,
+		Action:     action,
+		CreatedAt:  at,
+	}
+	exists, err := a.Exists(db, at.Add(-DedupWindow))
+	if err != nil {
+		return false, err
+	}
+	if exists {
+		return false, nil
+	}
+	if err := a.Create(db); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// getOrCreateUser gets the user with email address email and its
+// associations, creating it if it doesn't exist.
+func getOrCreateUser(db *gorm.DB, email string) (models.User, error) {
+	u := models.User{
+		EmailAddress: email,
+	}
+	err := u.Get(db)
+	if err == nil {
+		return u, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return u, fmt.Errorf("error getting user: %w", err)
+	}
+	if err := u.Upsert(db); err != nil {
+		return u, fmt.Errorf("error upserting user: %w", err)
+	}
+	return u, nil
+}
+
+// updateRecentlyViewedDocs makes document doc the most recently viewed
+// document of user u, viewed at time viewedAt, keeping the user's other most
+// recently viewed documents.
+func updateRecentlyViewedDocs(
+	db *gorm.DB, u models.User, doc models.Document, viewedAt time.Time,
+) error {
+	// Find recently viewed documents (excluding the current viewed document).
+	var rvd []models.RecentlyViewedDoc
+	if err := db.Where(&models.RecentlyViewedDoc{UserID: int(u.ID)}).
+		Not("document_id = ?", doc.ID).
+		Limit(recentlyViewedDocsLimit - 1).
+		Order("viewed_at desc").
+		Find(&rvd).Error; err != nil {
+		return fmt.Errorf("error finding recently viewed docs for user: %w", err)
+	}
+
+	// Prepend viewed document to recently viewed documents.
+	docIDs := []int{int(doc.ID)}
+	for _, d := range rvd {
+		docIDs = append(docIDs, d.DocumentID)
+	}
+
+	// Get document records for recently viewed documents.
+	var docs []models.Document
+	if err := db.Where("id IN ?", docIDs).Find(&docs).Error; err != nil {
+		return fmt.Errorf("error getting documents: %w", err)
+	}
+
+	// Update user.
+	u.RecentlyViewedDocs = docs
+	if err := u.Upsert(db); err != nil {
+		return fmt.Errorf("error upserting user: %w", err)
+	}
+
+	// Update ViewedAt time for the viewed document.
+	viewedDoc := models.RecentlyViewedDoc{
+		UserID:     int(u.ID),
+		DocumentID: int(doc.ID),
+		ViewedAt:   viewedAt,
+	}
+	if err := db.Updates(&viewedDoc).Error; err != nil {
+		return fmt.Errorf(
+			"error updating recently viewed document in database: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package activity
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp-forge/hermes/pkg/models/modelstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func createDocument(t *testing.T, db *gorm.DB, id string) models.Document {
+	return modelstest.CreateDocument(t, db, models.Document{GoogleFileID: id})
+}
+
+func TestRecord(t *testing.T) {
+	db := modelstest.NewDB(t)
+	doc1 := createDocument(t, db, "doc1")
+	doc2 := createDocument(t, db, "doc2")
+	now := time.Now()
+
+	// Views update the recently viewed documents.
+	require.NoError(t, RecordView(db, "alice@example.com", doc1, now.Add(-time.Hour)))
+	require.NoError(t, RecordView(db, "alice@example.com", doc2, now.Add(-30*time.Minute)))
+	u := models.User{EmailAddress: "alice@example.com"}
+	require.NoError(t, u.Get(db))
+	assert.Len(t, u.RecentlyViewedDocs, 2)
+
+	// Repeated activity within the dedup window is recorded once.
+	require.NoError(t, RecordView(db, "alice@example.com", doc2, now.Add(-25*time.Minute)))
+	require.NoError(t, Record(db, "alice@example.com", doc1,
+		models.EditUserActivityAction, now.Add(-10*time.Minute)))
+	require.NoError(t, Record(db, "bob@example.com", doc1,
+		models.ReviewUserActivityAction, now))
+
+	as, err := ForUser(db, "alice@example.com", models.UserActivityFilter{})
+	require.NoError(t, err)
+	require.Len(t, as, 3)
+	assert.Equal(t, models.EditUserActivityAction, as[0].Action)
+	assert.Equal(t, "doc1", as[0].Document.GoogleFileID)
+	assert.Equal(t, models.ViewUserActivityAction, as[1].Action)
+	assert.Equal(t, "doc2", as[1].Document.GoogleFileID)
+
+	as, err = ForUser(db, "alice@example.com", models.UserActivityFilter{
+		BeforeID: as[0].ID,
+		Limit:    1,
+	})
+	require.NoError(t, err)
+	require.Len(t, as, 1)
+	assert.Equal(t, "doc2", as[0].Document.GoogleFileID)
+
+	as, err = ForDocument(db, doc1, models.UserActivityFilter{})
+	require.NoError(t, err)
+	require.Len(t, as, 3)
+	assert.Equal(t, "bob@example.com", as[0].User.EmailAddress)
+	assert.Equal(t, models.ReviewUserActivityAction, as[0].Action)
+
+	as, err = ForUser(db, "nobody@example.com", models.UserActivityFilter{})
+	require.NoError(t, err)
+	assert.Empty(t, as)
+
+	// Activity on deleted documents isn't returned.
+	require.NoError(t, db.Delete(&doc2).Error)
+	as, err = ForUser(db, "alice@example.com", models.UserActivityFilter{})
+	require.NoError(t, err)
+	assert.Len(t, as, 2)
+}
+
+func TestJob(t *testing.T) {
+	db := modelstest.NewDB(t)
+	doc := createDocument(t, db, "doc1")
+	now := time.Now()
+
+	require.NoError(t, Record(db, "alice@example.com", doc,
+		models.ViewUserActivityAction, now.Add(-48*time.Hour)))
+	require.NoError(t, Record(db, "alice@example.com", doc,
+		models.EditUserActivityAction, now))
+
+	j := NewJob(db, nil, &Config{Retention: 24 * time.Hour})
+	n, err := j.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	as, err := ForDocument(db, doc, models.UserActivityFilter{})
+	require.NoError(t, err)
+	require.Len(t, as, 1)
+	assert.Equal(t, models.EditUserActivityAction, as[0].Action)
+}
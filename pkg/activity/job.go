@@ -0,0 +1,94 @@
+package activity
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp/go-hclog"
+	"gorm.io/gorm"
+)
+
+const (
+	// DefaultRetention is how long user activity is kept by default.
+	DefaultRetention = 90 * 24 * time.Hour
+
+	// DefaultPruneInterval is how often user activity past the retention
+	// period is deleted by default.
+	DefaultPruneInterval = 24 * time.Hour
+)
+
+// Job periodically deletes the user activity recorded longer than the
+// retention period ago.
+type Job struct {
+	db        *gorm.DB
+	logger    hclog.Logger
+	interval  time.Duration
+	retention time.Duration
+}
+
+// Config contains user activity job configuration.
+type Config struct {
+	// PruneInterval is how often user activity past the retention period is
+	// deleted.
+	PruneInterval time.Duration
+
+	// Retention is how long user activity is kept.
+	Retention time.Duration
+}
+
+// NewJob creates a new user activity job.
+func NewJob(db *gorm.DB, logger hclog.Logger, cfg *Config) *Job {
+	if logger == nil {
+		logger = hclog.NewNullLogger()
+	}
+	j := &Job{
+		db:        db,
+		logger:    logger.Named("activity"),
+		interval:  DefaultPruneInterval,
+		retention: DefaultRetention,
+	}
+	if cfg != nil {
+		if cfg.PruneInterval > 0 {
+			j.interval = cfg.PruneInterval
+		}
+		if cfg.Retention > 0 {
+			j.retention = cfg.Retention
+		}
+	}
+	return j
+}
+
+// Start deletes expired user activity immediately and then every interval
+// until ctx is canceled.
+func (j *Job) Start(ctx context.Context) error {
+	j.logger.Info("user activity job started",
+		"interval", j.interval,
+		"retention", j.retention)
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := j.Run(ctx)
+		if err != nil {
+			j.logger.Error("error deleting expired user activity", "error", err)
+		} else if n > 0 {
+			j.logger.Info("deleted expired user activity", "activities", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			j.logger.Info("user activity job stopped")
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Run deletes the user activity recorded before the retention period once and
+// returns the number of deleted activities.
+func (j *Job) Run(ctx context.Context) (int64, error) {
+	return models.DeleteUserActivitiesBefore(
+		j.db.WithContext(ctx), time.Now().Add(-j.retention))
+}
@@ -0,0 +1,119 @@
+package activity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"gorm.io/gorm"
+)
+
+// DefaultStatsMinReaders is the minimum number of readers that view stats are
+// reported for by default.
+const DefaultStatsMinReaders = 3
+
+// Stats are the view stats of a document.
+type Stats struct {
+	// Views is the number of views.
+	Views int
+
+	// UniqueReaders is the number of distinct users who viewed the document,
+	// or nil if it is less than the minimum number of readers.
+	UniqueReaders *int
+
+	// Series are the views by period, oldest first. Each period has at least
+	// the minimum number of readers, so periods with fewer readers are merged
+	// with their neighbors. It is empty if the document has fewer readers.
+	Series []StatsPeriod
+}
+
+// StatsPeriod are the views of a document during a period of days.
+type StatsPeriod struct {
+	// Start is the first day of the period.
+	Start time.Time
+
+	// End is the day after the last day of the period.
+	End time.Time
+
+	Views         int
+	UniqueReaders int
+}
+
+// DocumentStats returns the view stats of document doc since time since.
+// Readers can't be told apart in stats of fewer than minReaders readers, so
+// they are aggregated until there are at least minReaders readers. If
+// minReaders is not positive, DefaultStatsMinReaders is used.
+func DocumentStats(
+	db *gorm.DB, doc models.Document, since time.Time, minReaders int,
+) (Stats, error) {
+	var ss models.DocumentViewStats
+	if err := ss.Find(db, doc.ID, since); err != nil {
+		return Stats{}, fmt.Errorf("error finding document view stats: %w", err)
+	}
+	if minReaders <= 0 {
+		minReaders = DefaultStatsMinReaders
+	}
+	return aggregateStats(ss, minReaders), nil
+}
+
+// aggregateStats aggregates the daily view stats ss, which are ordered by day,
+// into periods of at least minReaders readers.
+func aggregateStats(ss models.DocumentViewStats, minReaders int) Stats {
+	type period struct {
+		StatsPeriod
+		readers map[uint]bool
+	}
+	newPeriod := func(day time.Time) *period {
+		return &period{
+			StatsPeriod: StatsPeriod{Start: day},
+			readers:     map[uint]bool{},
+		}
+	}
+
+	var (
+		st      Stats
+		readers = map[uint]bool{}
+		periods []*period
+		cur     *period
+	)
+	for i, s := range ss {
+		day := models.ViewDay(s.Day)
+		if cur == nil {
+			cur = newPeriod(day)
+		}
+		cur.Views += s.Views
+		cur.readers[s.UserID] = true
+		cur.End = day.AddDate(0, 0, 1)
+		st.Views += s.Views
+		readers[s.UserID] = true
+
+		// Close the period at the end of a day with enough readers.
+		lastOfDay := i == len(ss)-1 || !models.ViewDay(ss[i+1].Day).Equal(day)
+		if lastOfDay && len(cur.readers) >= minReaders {
+			periods = append(periods, cur)
+			cur = nil
+		}
+	}
+	if len(readers) < minReaders {
+		return st
+	}
+
+	// Merge the last days without enough readers into the last period.
+	if cur != nil {
+		last := periods[len(periods)-1]
+		last.Views += cur.Views
+		last.End = cur.End
+		for id := range cur.readers {
+			last.readers[id] = true
+		}
+	}
+
+	n := len(readers)
+	st.UniqueReaders = &n
+	st.Series = make([]StatsPeriod, len(periods))
+	for i, p := range periods {
+		p.UniqueReaders = len(p.readers)
+		st.Series[i] = p.StatsPeriod
+	}
+	return st
+}
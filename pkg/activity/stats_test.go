@@ -0,0 +1,86 @@
+package activity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp-forge/hermes/pkg/models/modelstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregateStats(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC)
+	}
+	stat := func(d int, userID uint, views int) models.DocumentViewStat {
+		return models.DocumentViewStat{Day: day(d), UserID: userID, Views: views}
+	}
+
+	t.Run("fewer readers than the minimum", func(t *testing.T) {
+		st := aggregateStats(models.DocumentViewStats{
+			stat(1, 1, 2),
+			stat(2, 2, 1),
+		}, 3)
+		assert.Equal(t, 3, st.Views)
+		assert.Nil(t, st.UniqueReaders)
+		assert.Empty(t, st.Series)
+	})
+
+	t.Run("days are merged until they have enough readers", func(t *testing.T) {
+		st := aggregateStats(models.DocumentViewStats{
+			// Day 1 has enough readers.
+			stat(1, 1, 1),
+			stat(1, 2, 1),
+			// Days 2 and 4 have enough readers together.
+			stat(2, 1, 3),
+			stat(4, 3, 1),
+			// Day 5 doesn't, so it's merged into the previous period.
+			stat(5, 3, 2),
+		}, 2)
+		assert.Equal(t, 8, st.Views)
+		require.NotNil(t, st.UniqueReaders)
+		assert.Equal(t, 3, *st.UniqueReaders)
+		assert.Equal(t, []StatsPeriod{
+			{Start: day(1), End: day(2), Views: 2, UniqueReaders: 2},
+			{Start: day(2), End: day(6), Views: 6, UniqueReaders: 2},
+		}, st.Series)
+	})
+
+	t.Run("readers of all days are merged", func(t *testing.T) {
+		st := aggregateStats(models.DocumentViewStats{
+			stat(1, 1, 1),
+			stat(2, 2, 1),
+			stat(3, 3, 1),
+		}, 3)
+		require.NotNil(t, st.UniqueReaders)
+		assert.Equal(t, 3, *st.UniqueReaders)
+		assert.Equal(t, []StatsPeriod{
+			{Start: day(1), End: day(4), Views: 3, UniqueReaders: 3},
+		}, st.Series)
+	})
+}
+
+func TestDocumentStats(t *testing.T) {
+	db := modelstest.NewDB(t)
+	doc := createDocument(t, db, "doc1")
+	now := time.Now()
+
+	// Views within the dedup window are counted once.
+	require.NoError(t, RecordView(db, "alice@example.com", doc, now.Add(-time.Hour)))
+	require.NoError(t, RecordView(db, "alice@example.com", doc, now.Add(-50*time.Minute)))
+	require.NoError(t, RecordView(db, "alice@example.com", doc, now.Add(-time.Minute)))
+	require.NoError(t, RecordView(db, "bob@example.com", doc, now))
+
+	st, err := DocumentStats(db, doc, now.AddDate(0, 0, -7), 2)
+	require.NoError(t, err)
+	assert.Equal(t, 3, st.Views)
+	require.NotNil(t, st.UniqueReaders)
+	assert.Equal(t, 2, *st.UniqueReaders)
+
+	st, err = DocumentStats(db, doc, now.AddDate(0, 0, -7), 0)
+	require.NoError(t, err)
+	assert.Equal(t, 3, st.Views)
+	assert.Nil(t, st.UniqueReaders)
+}
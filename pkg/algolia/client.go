@@ -2,11 +2,13 @@ package algolia
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
 	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
+	hermessearch "github.com/hashicorp-forge/hermes/pkg/search"
 )
 
 // Client provides access to Hermes indexes in Algolia.
@@ -97,6 +99,21 @@ type Config struct {
 
 	// WriteAPIKey is the Algolia API Key for writing to Hermes indices.
 	WriteAPIKey string `hcl:"write_api_key,optional"`
+
+	// Ranking tunes how the results of the docs and drafts indexes are
+	// ranked. It's applied to the index settings by New.
+	Ranking hermessearch.Rankings
+}
+
+// defaultRanking is the default Algolia ranking formula.
+var defaultRanking = []string{
+	"typo", "geo", "words", "filters", "proximity", "attribute", "exact", "custom",
+}
+
+// searchableAttributes are the searchable attributes of the docs and drafts
+// indexes, in order of importance, if their ranking has attribute weights.
+var searchableAttributes = []string{
+	"title", "docNumber", "summary", "content", "owners", "contributors",
 }
 
 // New initializes Hermes indices and returns a new Algolia client for
@@ -124,17 +141,20 @@ func New(cfg *Config) (*Client, error) {
 	c.Projects = a.InitIndex(cfg.ProjectsIndexName)
 
 	// Configure the docs index.
-	err := configureMainIndex(cfg.DocsIndexName, c.Docs, search.Settings{
+	docsSettings := search.Settings{
 		// Attributes
 		AttributesForFaceting: opt.AttributesForFaceting(
 			"appCreated",
 			"approvers",
 			"approvedBy",
 			"docType",
+			"freshness",
 			"searchable(owners)",
 			"searchable(product)",
+			"reviewSla",
 			"status",
 			"searchable(tags)",
+			"filterOnly(tenant)",
 		),
 
 		// Highlighting/snippeting
@@ -152,7 +172,9 @@ func New(cfg *Config) (*Client, error) {
 			cfg.DocsIndexName+"_modifiedTime_desc",
 			cfg.DocsIndexName+"_modifiedTime_asc",
 		),
-	})
+	}
+	applyRanking(&docsSettings, cfg.Ranking.For(hermessearch.RankingIndexDocs))
+	err := configureMainIndex(cfg.DocsIndexName, c.Docs, docsSettings)
 	if err != nil {
 		return nil, err
 	}
@@ -174,15 +196,18 @@ func New(cfg *Config) (*Client, error) {
 	}
 
 	// Configure the drafts index.
-	err = configureMainIndex(cfg.DraftsIndexName, c.Drafts, search.Settings{
+	draftsSettings := search.Settings{
 		// Attributes
 		AttributesForFaceting: opt.AttributesForFaceting(
 			"contributors",
 			"docType",
+			"freshness",
 			"owners",
 			"product",
 			"status",
 			"tags",
+			"filterOnly(tenant)",
+			"filterOnly(viewers)",
 		),
 
 		// Ranking
@@ -192,7 +217,9 @@ func New(cfg *Config) (*Client, error) {
 			cfg.DraftsIndexName+"_modifiedTime_desc",
 			cfg.DraftsIndexName+"_modifiedTime_asc",
 		),
-	})
+	}
+	applyRanking(&draftsSettings, cfg.Ranking.For(hermessearch.RankingIndexDrafts))
+	err = configureMainIndex(cfg.DraftsIndexName, c.Drafts, draftsSettings)
 	if err != nil {
 		return nil, err
 	}
@@ -216,6 +243,39 @@ func New(cfg *Config) (*Client, error) {
 	return c, nil
 }
 
+// applyRanking applies a ranking to the settings of a main index. Algolia ranks
+// by criteria instead of scores, so attribute weights order the searchable
+// attributes (attributes of equal weight are searched as one), an exact match
+// boost above 1 moves the exact criterion after the words criterion, and
+// recency decay ranks recently modified documents first among ties.
+func applyRanking(settings *search.Settings, ranking hermessearch.Ranking) {
+	if len(ranking.AttributeWeights) > 0 {
+		var attrs []string
+		for _, g := range ranking.AttributeGroups(searchableAttributes) {
+			attrs = append(attrs, strings.Join(g, ","))
+		}
+		settings.SearchableAttributes = opt.SearchableAttributes(attrs...)
+	}
+
+	rules := make([]string, 0, len(defaultRanking))
+	for _, rule := range defaultRanking {
+		if rule == "exact" && ranking.Boost() > 1 {
+			continue
+		}
+		rules = append(rules, rule)
+		if rule == "words" && ranking.Boost() > 1 {
+			rules = append(rules, "exact")
+		}
+	}
+	settings.Ranking = opt.Ranking(rules...)
+
+	if ranking.RecencyDecay > 0 {
+		settings.CustomRanking = opt.CustomRanking("desc(modifiedTime)")
+	} else {
+		settings.CustomRanking = opt.CustomRanking()
+	}
+}
+
 // configureMainIndex configures the main index with settings
 func configureMainIndex(indexName string, mainIndex *search.Index, settings search.Settings) error {
 	res, err := mainIndex.SetSettings(settings)
@@ -0,0 +1,258 @@
+// Package analytics computes aggregate document analytics for dashboards, such
+// as the documents created and approved per product per month, time in review,
+// and top contributors, so they don't require exporting the database.
+//
+// Only published documents (not drafts) are counted. Documents are published
+// at their DocumentCreatedAt time and approved at their ApprovedAt time.
+package analytics
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"gorm.io/gorm"
+)
+
+// monthLayout is the layout of months in results.
+const monthLayout = "2006-01"
+
+// Filter limits the documents that analytics are computed for.
+type Filter struct {
+	// TenantID is the tenant of the documents.
+	TenantID uint
+
+	// Product is the name of the product of the documents, or empty for all
+	// products.
+	Product string
+
+	// Since and Until limit documents to those published (or, for approvals,
+	// approved) in [Since, Until). Zero times are not filtered on.
+	Since time.Time
+	Until time.Time
+}
+
+// ProductMonth is the number of documents of a product published and approved
+// in a month.
+type ProductMonth struct {
+	// Month is the month in "YYYY-MM" format (UTC).
+	Month    string `json:"month"`
+	Product  string `json:"product"`
+	Created  int64  `json:"created"`
+	Approved int64  `json:"approved"`
+}
+
+// ReviewTime is the time documents of a product were in review before they
+// were approved.
+type ReviewTime struct {
+	// Product is the product of the documents, or empty for all products.
+	Product string `json:"product,omitempty"`
+
+	// Approved is the number of approved documents.
+	Approved int `json:"approved"`
+
+	// MedianHours is the median number of hours from publishing to approval.
+	MedianHours float64 `json:"medianHours"`
+}
+
+// Contributor is a user who owns or contributes to documents.
+type Contributor struct {
+	Email     string `json:"email"`
+	Documents int64  `json:"documents"`
+}
+
+// DocumentsByProductMonth returns the number of documents published and
+// approved per product per month, by month and then product.
+func DocumentsByProductMonth(db *gorm.DB, f Filter) ([]ProductMonth, error) {
+	type row struct {
+		Month   string
+		Product string
+		Count   int64
+	}
+
+	var created []row
+	if err := db.Model(&models.Document{}).
+		Scopes(f.documents("documents.document_created_at")).
+		Select(monthExpr(db, "documents.document_created_at") + " AS month, " +
+			"products.name AS product, COUNT(*) AS count").
+		Group("month, products.name").
+		Scan(&created).
+		Error; err != nil {
+		return nil, fmt.Errorf("error counting created documents: %w", err)
+	}
+
+	var approved []row
+	if err := db.Model(&models.Document{}).
+		Scopes(f.documents("documents.approved_at")).
+		Select(monthExpr(db, "documents.approved_at") + " AS month, " +
+			"products.name AS product, COUNT(*) AS count").
+		Group("month, products.name").
+		Scan(&approved).
+		Error; err != nil {
+		return nil, fmt.Errorf("error counting approved documents: %w", err)
+	}
+
+	counts := map[[2]string]*ProductMonth{}
+	get := func(r row) *ProductMonth {
+		k := [2]string{r.Month, r.Product}
+		if counts[k] == nil {
+			counts[k] = &ProductMonth{Month: r.Month, Product: r.Product}
+		}
+		return counts[k]
+	}
+	for _, r := range created {
+		get(r).Created += r.Count
+	}
+	for _, r := range approved {
+		get(r).Approved += r.Count
+	}
+
+	res := make([]ProductMonth, 0, len(counts))
+	for _, c := range counts {
+		res = append(res, *c)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Month != res[j].Month {
+			return res[i].Month < res[j].Month
+		}
+		return res[i].Product < res[j].Product
+	})
+	return res, nil
+}
+
+// ReviewTimes returns the median time in review of documents approved in the
+// filter's time range, for all products and per product (by product).
+func ReviewTimes(db *gorm.DB, f Filter) (ReviewTime, []ReviewTime, error) {
+	var rows []struct {
+		Product           string
+		DocumentCreatedAt time.Time
+		ApprovedAt        time.Time
+	}
+	if err := db.Model(&models.Document{}).
+		Scopes(f.documents("documents.approved_at")).
+		Select("products.name AS product, documents.document_created_at, " +
+			"documents.approved_at").
+		Scan(&rows).
+		Error; err != nil {
+		return ReviewTime{}, nil, fmt.Errorf(
+			"error getting approved documents: %w", err)
+	}
+
+	var all []time.Duration
+	byProduct := map[string][]time.Duration{}
+	for _, r := range rows {
+		d := r.ApprovedAt.Sub(r.DocumentCreatedAt)
+		if d < 0 {
+			// Documents approved before they were (re)published.
+			d = 0
+		}
+		all = append(all, d)
+		byProduct[r.Product] = append(byProduct[r.Product], d)
+	}
+
+	products := make([]ReviewTime, 0, len(byProduct))
+	for p, ds := range byProduct {
+		products = append(products, reviewTime(p, ds))
+	}
+	sort.Slice(products, func(i, j int) bool {
+		return products[i].Product < products[j].Product
+	})
+	return reviewTime("", all), products, nil
+}
+
+// TopContributors returns the limit users who own or contribute to the most
+// documents published in the filter's time range, most documents first.
+func TopContributors(db *gorm.DB, f Filter, limit int) ([]Contributor, error) {
+	contributions := db.Raw(
+		"SELECT id AS document_id, owner_id AS user_id FROM documents " +
+			"UNION SELECT document_id, user_id FROM document_contributors")
+
+	res := []Contributor{}
+	if err := db.Table("(?) AS contributions", contributions).
+		Joins("JOIN documents ON documents.id = contributions.document_id").
+		Joins("JOIN users ON users.id = contributions.user_id").
+		Scopes(f.documents("documents.document_created_at")).
+		Select("users.email_address AS email, " +
+			"COUNT(DISTINCT documents.id) AS documents").
+		Group("users.email_address").
+		Order("COUNT(DISTINCT documents.id) DESC, users.email_address").
+		Limit(limit).
+		Scan(&res).
+		Error; err != nil {
+		return nil, fmt.Errorf("error counting contributions: %w", err)
+	}
+	return res, nil
+}
+
+// documents returns a query scope that limits documents to published
+// documents that match the filter, with their time column in the filter's time
+// range. It joins the products of the documents.
+func (f Filter) documents(timeColumn string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		db = db.
+			Joins("JOIN products ON products.id = documents.product_id").
+			Where("documents.deleted_at IS NULL").
+			Where("documents.tenant_id = ?", f.TenantID).
+			Where("documents.status IN ?", []models.DocumentStatus{
+				models.InReviewDocumentStatus,
+				models.ApprovedDocumentStatus,
+				models.ObsoleteDocumentStatus,
+			}).
+			Where(timeColumn + " IS NOT NULL")
+		if f.Product != "" {
+			db = db.Where("products.name = ?", f.Product)
+		}
+		if !f.Since.IsZero() {
+			db = db.Where(timeColumn+" >= ?", f.Since)
+		}
+		if !f.Until.IsZero() {
+			db = db.Where(timeColumn+" < ?", f.Until)
+		}
+		return db
+	}
+}
+
+// monthExpr returns the SQL expression of the UTC month of a time column in
+// "YYYY-MM" format.
+func monthExpr(db *gorm.DB, column string) string {
+	if db.Dialector.Name() == "sqlite" {
+		return fmt.Sprintf("strftime('%%Y-%%m', %s)", column)
+	}
+	return fmt.Sprintf("to_char(%s AT TIME ZONE 'UTC', 'YYYY-MM')", column)
+}
+
+// reviewTime returns the review time of the product with durations ds.
+func reviewTime(product string, ds []time.Duration) ReviewTime {
+	return ReviewTime{
+		Product:     product,
+		Approved:    len(ds),
+		MedianHours: median(ds).Hours(),
+	}
+}
+
+// median returns the median of ds, or zero if ds is empty.
+func median(ds []time.Duration) time.Duration {
+	if len(ds) == 0 {
+		return 0
+	}
+	s := slices.Clone(ds)
+	slices.Sort(s)
+	mid := len(s) / 2
+	if len(s)%2 == 1 {
+		return s[mid]
+	}
+	return (s[mid-1] + s[mid]) / 2
+}
+
+// MonthStart returns the start of the UTC month of t.
+func MonthStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// ParseMonth parses a month in "YYYY-MM" format, returning its start.
+func ParseMonth(s string) (time.Time, error) {
+	return time.Parse(monthLayout, s)
+}
@@ -0,0 +1,106 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp-forge/hermes/pkg/models/modelstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// createDocument creates a document of product with status, owned by owner
+// and published at publishedAt. Approved documents are approved after
+// inReview.
+func createDocument(
+	t *testing.T, db *gorm.DB, id, product, owner string,
+	status models.DocumentStatus, publishedAt time.Time, inReview time.Duration,
+	contributors ...string,
+) {
+	d := models.Document{
+		GoogleFileID:      id,
+		Status:            status,
+		DocumentCreatedAt: publishedAt,
+		Product:           models.Product{Name: product, Abbreviation: product[:3]},
+		Owner:             &models.User{EmailAddress: owner},
+	}
+	if status == models.ApprovedDocumentStatus {
+		approvedAt := publishedAt.Add(inReview)
+		d.ApprovedAt = &approvedAt
+	}
+	for _, c := range contributors {
+		d.Contributors = append(d.Contributors, &models.User{EmailAddress: c})
+	}
+	modelstest.CreateDocument(t, db, d)
+}
+
+func TestAnalytics(t *testing.T) {
+	db := modelstest.NewDB(t)
+
+	jan := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC)
+	createDocument(t, db, "doc1", "Hermes", "alice@example.com",
+		models.ApprovedDocumentStatus, jan, 24*time.Hour, "bob@example.com")
+	createDocument(t, db, "doc2", "Hermes", "alice@example.com",
+		models.ApprovedDocumentStatus, jan, 30*24*time.Hour)
+	createDocument(t, db, "doc3", "Terraform", "bob@example.com",
+		models.InReviewDocumentStatus, feb, 0, "alice@example.com")
+	createDocument(t, db, "doc4", "Terraform", "carol@example.com",
+		models.ApprovedDocumentStatus, feb, 2*time.Hour)
+	createDocument(t, db, "draft", "Terraform", "carol@example.com",
+		models.WIPDocumentStatus, feb, 0)
+
+	t.Run("DocumentsByProductMonth", func(t *testing.T) {
+		months, err := DocumentsByProductMonth(db, Filter{})
+		require.NoError(t, err)
+		assert.Equal(t, []ProductMonth{
+			{Month: "2026-01", Product: "Hermes", Created: 2, Approved: 1},
+			{Month: "2026-02", Product: "Hermes", Created: 0, Approved: 1},
+			{Month: "2026-02", Product: "Terraform", Created: 2, Approved: 1},
+		}, months)
+
+		months, err = DocumentsByProductMonth(db, Filter{
+			Product: "Hermes",
+			Since:   time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []ProductMonth{
+			{Month: "2026-02", Product: "Hermes", Created: 0, Approved: 1},
+		}, months)
+	})
+
+	t.Run("ReviewTimes", func(t *testing.T) {
+		overall, products, err := ReviewTimes(db, Filter{})
+		require.NoError(t, err)
+		assert.Equal(t, ReviewTime{Approved: 3, MedianHours: 24}, overall)
+		assert.Equal(t, []ReviewTime{
+			{Product: "Hermes", Approved: 2, MedianHours: 15.5 * 24},
+			{Product: "Terraform", Approved: 1, MedianHours: 2},
+		}, products)
+	})
+
+	t.Run("TopContributors", func(t *testing.T) {
+		contributors, err := TopContributors(db, Filter{}, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []Contributor{
+			{Email: "alice@example.com", Documents: 3},
+			{Email: "bob@example.com", Documents: 2},
+		}, contributors)
+
+		contributors, err = TopContributors(db, Filter{TenantID: 1}, 10)
+		require.NoError(t, err)
+		assert.Empty(t, contributors)
+	})
+}
+
+func TestMedian(t *testing.T) {
+	assert.Zero(t, median(nil))
+	assert.Equal(t, 2*time.Hour, median([]time.Duration{
+		3 * time.Hour, time.Hour, 2 * time.Hour,
+	}))
+	assert.Equal(t, 90*time.Minute, median([]time.Duration{
+		2 * time.Hour, time.Hour,
+	}))
+}
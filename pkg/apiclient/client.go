@@ -0,0 +1,39 @@
+// Package apiclient is a client for the Hermes API.
+//
+// The request and response types and the Client methods in client.gen.go are
+// generated from the server's OpenAPI document by
+// "hermes operator generate-api" (run "make generate-api" after changing an
+// API endpoint), so they stay in sync with the server.
+package apiclient
+
+import (
+	"context"
+)
+
+// Doer sends API requests. It marshals body, if not nil, as the JSON request
+// body, and unmarshals the JSON response body into result, if not nil.
+// Implementations handle the base URL, authentication, retries, and error
+// responses.
+type Doer interface {
+	Do(ctx context.Context, method, path string, body, result any) error
+}
+
+// DoerFunc is an adapter to use a function as a Doer.
+type DoerFunc func(ctx context.Context, method, path string, body, result any) error
+
+// Do calls f(ctx, method, path, body, result).
+func (f DoerFunc) Do(
+	ctx context.Context, method, path string, body, result any,
+) error {
+	return f(ctx, method, path, body, result)
+}
+
+// Client is a Hermes API client.
+type Client struct {
+	doer Doer
+}
+
+// New returns a new client that sends requests with d.
+func New(d Doer) *Client {
+	return &Client{doer: d}
+}
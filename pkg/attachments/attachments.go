@@ -0,0 +1,200 @@
+// Package attachments extracts the text of document attachments (PDFs, Word
+// documents, PowerPoint presentations, text files, and images with OCR) so it
+// can be included in the search document content of the documents.
+package attachments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// Attachment types.
+const (
+	TypePDF   = "pdf"
+	TypeDOCX  = "docx"
+	TypePPTX  = "pptx"
+	TypeText  = "text"
+	TypeImage = "image"
+)
+
+const (
+	// DefaultMaxSize is the default maximum size of attachments whose text is
+	// extracted, in bytes.
+	DefaultMaxSize = 20 << 20
+
+	// DefaultMaxTextSize is the default maximum size of the text extracted
+	// from an attachment, in bytes.
+	DefaultMaxTextSize = 100_000
+)
+
+// DefaultTypes are the attachment types whose text is extracted by default.
+// Images are only extracted when OCR is configured.
+var DefaultTypes = []string{TypePDF, TypeDOCX, TypePPTX, TypeText, TypeImage}
+
+var (
+	// ErrUnsupported is returned for attachments of unknown types.
+	ErrUnsupported = errors.New("unsupported attachment type")
+
+	// ErrDisabled is returned for attachments of types whose text extraction
+	// is disabled.
+	ErrDisabled = errors.New("text extraction disabled for attachment type")
+
+	// ErrTooLarge is returned for attachments larger than the maximum size.
+	ErrTooLarge = errors.New("attachment too large")
+)
+
+// OCR recognizes the text in images.
+type OCR interface {
+	Recognize(ctx context.Context, contentType string, data []byte) (string, error)
+}
+
+// Config contains text extraction configuration.
+type Config struct {
+	// Types are the attachment types whose text is extracted (default:
+	// DefaultTypes).
+	Types []string
+
+	// MaxSize is the maximum size of attachments whose text is extracted, in
+	// bytes (default: DefaultMaxSize).
+	MaxSize int64
+
+	// MaxTextSize is the maximum size of the text extracted from an
+	// attachment, in bytes. Longer text is truncated (default:
+	// DefaultMaxTextSize).
+	MaxTextSize int
+}
+
+// Extractor extracts the text of attachments.
+type Extractor struct {
+	types       []string
+	maxSize     int64
+	maxTextSize int
+	ocr         OCR
+}
+
+// New creates a new extractor. Text isn't extracted from images if ocr is nil.
+func New(cfg Config, ocr OCR) (*Extractor, error) {
+	e := &Extractor{
+		types:       DefaultTypes,
+		maxSize:     DefaultMaxSize,
+		maxTextSize: DefaultMaxTextSize,
+		ocr:         ocr,
+	}
+	if cfg.Types != nil {
+		for _, t := range cfg.Types {
+			if !slices.Contains(DefaultTypes, t) {
+				return nil, fmt.Errorf("unknown attachment type %q", t)
+			}
+		}
+		e.types = cfg.Types
+	}
+	if cfg.MaxSize > 0 {
+		e.maxSize = cfg.MaxSize
+	}
+	if cfg.MaxTextSize > 0 {
+		e.maxTextSize = cfg.MaxTextSize
+	}
+	return e, nil
+}
+
+// MaxSize returns the maximum size of attachments whose text is extracted.
+func (e *Extractor) MaxSize() int64 {
+	return e.maxSize
+}
+
+// Enabled returns true if text is extracted from attachments of type typ.
+func (e *Extractor) Enabled(typ string) bool {
+	if typ == TypeImage && e.ocr == nil {
+		return false
+	}
+	return slices.Contains(e.types, typ)
+}
+
+// Extract extracts the text of the attachment with file name name, content
+// type contentType, and content data.
+func (e *Extractor) Extract(
+	ctx context.Context, name, contentType string, data []byte,
+) (string, error) {
+	typ := DetectType(name, contentType)
+	if typ == "" {
+		return "", ErrUnsupported
+	}
+	if !e.Enabled(typ) {
+		return "", ErrDisabled
+	}
+	if int64(len(data)) > e.maxSize {
+		return "", ErrTooLarge
+	}
+
+	var text string
+	var err error
+	switch typ {
+	case TypePDF:
+		text, err = extractPDF(data)
+	case TypeDOCX:
+		text, err = extractOOXML(data, "word/document.xml")
+	case TypePPTX:
+		text, err = extractOOXML(data, "ppt/slides/slide")
+	case TypeText:
+		if !utf8.Valid(data) {
+			return "", fmt.Errorf("text attachment isn't valid UTF-8")
+		}
+		text = string(data)
+	case TypeImage:
+		text, err = e.ocr.Recognize(ctx, contentType, data)
+	}
+	if err != nil {
+		return "", fmt.Errorf("error extracting %s text: %w", typ, err)
+	}
+
+	return truncate(strings.TrimSpace(text), e.maxTextSize), nil
+}
+
+// DetectType returns the attachment type of a file with name name and content
+// type contentType, or an empty string if the type isn't supported.
+func DetectType(name, contentType string) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case mediaType == "application/pdf":
+		return TypePDF
+	case mediaType == "application/vnd.openxmlformats-officedocument.wordprocessingml.document":
+		return TypeDOCX
+	case mediaType == "application/vnd.openxmlformats-officedocument.presentationml.presentation":
+		return TypePPTX
+	case strings.HasPrefix(mediaType, "image/"):
+		return TypeImage
+	case strings.HasPrefix(mediaType, "text/"):
+		return TypeText
+	}
+
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".pdf":
+		return TypePDF
+	case ".docx":
+		return TypeDOCX
+	case ".pptx":
+		return TypePPTX
+	case ".txt", ".md", ".csv", ".json", ".yaml", ".yml", ".hcl", ".log":
+		return TypeText
+	case ".png", ".jpg", ".jpeg", ".gif", ".tif", ".tiff", ".webp":
+		return TypeImage
+	}
+	return ""
+}
+
+// truncate truncates s to at most n bytes without splitting a UTF-8 sequence.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
@@ -0,0 +1,169 @@
+package attachments
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/zlib"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeOCR struct{}
+
+func (fakeOCR) Recognize(ctx context.Context, contentType string, data []byte) (string, error) {
+	return "text in " + contentType, nil
+}
+
+func testPDF(t *testing.T) []byte {
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	_, err := zw.Write([]byte("BT /F1 12 Tf 72 700 Td [(Second) -250 (page)] TJ ET"))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	var b bytes.Buffer
+	b.WriteString("%PDF-1.4\n")
+	content := `BT /F1 12 Tf 72 720 Td (Rollout \(phase 1\)) Tj 0 -14 Td <506C616E> Tj ET`
+	fmt.Fprintf(&b, "4 0 obj\n<< /Length %d >>\nstream\n%s\nendstream\nendobj\n",
+		len(content), content)
+	fmt.Fprintf(&b, "5 0 obj\n<< /Length %d /Filter /FlateDecode >>\nstream\n",
+		compressed.Len())
+	b.Write(compressed.Bytes())
+	b.WriteString("\nendstream\nendobj\n")
+	b.WriteString("6 0 obj\n<< /Length 3 /Filter /DCTDecode >>\nstream\n(x) Tj\nendstream\nendobj\n%%EOF\n")
+	return b.Bytes()
+}
+
+func testOOXML(t *testing.T, parts map[string]string) []byte {
+	var b bytes.Buffer
+	zw := zip.NewWriter(&b)
+	for name, body := range parts {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(body))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return b.Bytes()
+}
+
+func TestExtract(t *testing.T) {
+	e, err := New(Config{}, fakeOCR{})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	text, err := e.Extract(ctx, "plan.pdf", "application/pdf", testPDF(t))
+	require.NoError(t, err)
+	assert.Equal(t, "Rollout (phase 1)\nPlan\nSecond page", text)
+
+	docx := testOOXML(t, map[string]string{
+		"word/document.xml": `<w:document xmlns:w="w"><w:body>` +
+			`<w:p><w:r><w:t>Hello</w:t></w:r><w:r><w:t xml:space="preserve"> world</w:t></w:r></w:p>` +
+			`<w:p><w:r><w:t>Bye</w:t></w:r></w:p></w:body></w:document>`,
+	})
+	text, err = e.Extract(ctx, "notes.docx", "", docx)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello world\nBye", text)
+
+	pptx := testOOXML(t, map[string]string{
+		"ppt/slides/slide10.xml": `<p:sld xmlns:a="a" xmlns:p="p"><a:p><a:r><a:t>Last</a:t></a:r></a:p></p:sld>`,
+		"ppt/slides/slide2.xml":  `<p:sld xmlns:a="a" xmlns:p="p"><a:p><a:r><a:t>Second</a:t></a:r></a:p></p:sld>`,
+		"ppt/slides/slide1.xml":  `<p:sld xmlns:a="a" xmlns:p="p"><a:p><a:r><a:t>First</a:t></a:r></a:p></p:sld>`,
+	})
+	text, err = e.Extract(ctx, "deck.pptx", "application/octet-stream", pptx)
+	require.NoError(t, err)
+	assert.Equal(t, "First\nSecond\nLast", text)
+
+	text, err = e.Extract(ctx, "diagram.png", "image/png", []byte("png"))
+	require.NoError(t, err)
+	assert.Equal(t, "text in image/png", text)
+
+	text, err = e.Extract(ctx, "notes.md", "text/markdown; charset=utf-8",
+		[]byte("  # Notes\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "# Notes", text)
+
+	_, err = e.Extract(ctx, "app.exe", "application/octet-stream", []byte("MZ"))
+	assert.ErrorIs(t, err, ErrUnsupported)
+	_, err = e.Extract(ctx, "plan.pdf", "application/pdf", []byte("not a pdf"))
+	assert.Error(t, err)
+}
+
+func TestExtractConfig(t *testing.T) {
+	e, err := New(Config{
+		Types:       []string{TypeText, TypeImage},
+		MaxSize:     10,
+		MaxTextSize: 5,
+	}, nil)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	assert.False(t, e.Enabled(TypePDF))
+	// Images aren't extracted without OCR.
+	assert.False(t, e.Enabled(TypeImage))
+
+	_, err = e.Extract(ctx, "plan.pdf", "", testPDF(t))
+	assert.ErrorIs(t, err, ErrDisabled)
+	_, err = e.Extract(ctx, "diagram.png", "", []byte("png"))
+	assert.ErrorIs(t, err, ErrDisabled)
+	_, err = e.Extract(ctx, "notes.txt", "", []byte("0123456789a"))
+	assert.ErrorIs(t, err, ErrTooLarge)
+
+	text, err := e.Extract(ctx, "notes.txt", "", []byte("héllo!"))
+	require.NoError(t, err)
+	assert.Equal(t, "héll", text)
+
+	_, err = New(Config{Types: []string{"xlsx"}}, nil)
+	assert.Error(t, err)
+}
+
+func TestWithText(t *testing.T) {
+	content := WithText("Body", []Text{
+		{Name: "plan.pdf", Text: "Plan"},
+		{Name: "empty.png"},
+		{Name: "deck.pptx", Text: "Deck"},
+	})
+	assert.Equal(t, "Body"+contentSeparator+
+		"\n## plan.pdf\n\nPlan\n\n## deck.pptx\n\nDeck\n", content)
+	assert.Equal(t, "Body", ContentWithoutText(content))
+
+	// Attachment text is replaced.
+	assert.Equal(t, "Body"+contentSeparator+"\n## notes.txt\n\nNotes\n",
+		WithText(content, []Text{{Name: "notes.txt", Text: "Notes"}}))
+	assert.Equal(t, "Body", WithText(content, nil))
+
+	assert.Equal(t, []Text{{Name: "plan.pdf", Text: "Plan"}},
+		IndexedText(models.DocumentAttachments{
+			{Name: "plan.pdf", Text: "Plan", Indexed: true},
+			{Name: "deck.pptx", Text: "Deck"},
+		}))
+}
+
+func TestOCRClient(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		assert.Equal(t, "image/png", r.Header.Get("Content-Type"))
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "png", string(b))
+		_, _ = w.Write([]byte(`{"text": "Architecture diagram"}`))
+	}))
+	defer ts.Close()
+
+	ocr, err := NewOCRClient(OCRConfig{URL: ts.URL, Token: "token"})
+	require.NoError(t, err)
+	e, err := New(Config{}, ocr)
+	require.NoError(t, err)
+
+	text, err := e.Extract(context.Background(), "diagram.png", "image/png", []byte("png"))
+	require.NoError(t, err)
+	assert.Equal(t, "Architecture diagram", text)
+}
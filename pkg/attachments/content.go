@@ -0,0 +1,62 @@
+package attachments
+
+import (
+	"strings"
+
+	"github.com/hashicorp-forge/hermes/pkg/models"
+)
+
+// contentSeparator separates the content of a document from the text of its
+// attachments in search document content.
+const contentSeparator = "\n\n==== Attachments ====\n"
+
+// Text is the extracted text of an attachment.
+type Text struct {
+	Name string
+	Text string
+}
+
+// IndexedText returns the text of the attachments in atts whose text is
+// included in search.
+func IndexedText(atts models.DocumentAttachments) []Text {
+	var texts []Text
+	for _, a := range atts {
+		if a.Indexed {
+			texts = append(texts, Text{Name: a.Name, Text: a.Text})
+		}
+	}
+	return texts
+}
+
+// WithText returns the search document content of a document with content
+// content and attachments texts. The text of attachments that was previously
+// added to content is replaced, so WithText can be applied to indexed content.
+func WithText(content string, texts []Text) string {
+	content = ContentWithoutText(content)
+
+	var b strings.Builder
+	b.WriteString(content)
+	for _, t := range texts {
+		if t.Text == "" {
+			continue
+		}
+		if b.Len() == len(content) {
+			b.WriteString(contentSeparator)
+		}
+		b.WriteString("\n## ")
+		b.WriteString(t.Name)
+		b.WriteString("\n\n")
+		b.WriteString(t.Text)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// ContentWithoutText returns search document content without the text of
+// attachments.
+func ContentWithoutText(content string) string {
+	if i := strings.Index(content, contentSeparator); i >= 0 {
+		return content[:i]
+	}
+	return content
+}
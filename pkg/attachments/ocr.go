@@ -0,0 +1,87 @@
+package attachments
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// OCRClient recognizes the text in images with an external OCR API over HTTP.
+//
+// The image is sent as the body of a POST request to the API URL, with its
+// content type, and the API responds with the recognized text:
+//
+//	{"text": "..."}
+type OCRClient struct {
+	url        string
+	token      string
+	httpClient *http.Client
+}
+
+// OCRConfig holds configuration for the OCR client.
+type OCRConfig struct {
+	URL     string        // API URL (required)
+	Token   string        // Bearer token (optional)
+	Timeout time.Duration // HTTP timeout (default: 60s)
+}
+
+// NewOCRClient creates a new OCR client.
+func NewOCRClient(cfg OCRConfig) (*OCRClient, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("OCR API URL is required")
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 60 * time.Second
+	}
+
+	return &OCRClient{
+		url:   cfg.URL,
+		token: cfg.Token,
+		httpClient: &http.Client{
+			Timeout: cfg.Timeout,
+		},
+	}, nil
+}
+
+type ocrResponse struct {
+	Text string `json:"text"`
+}
+
+// Recognize implements OCR.
+func (c *OCRClient) Recognize(ctx context.Context, contentType string, data []byte) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", c.url, bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	if contentType == "" {
+		contentType = http.DetectContentType(data)
+	}
+	req.Header.Set("Content-Type", contentType)
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 10<<20))
+	if err != nil {
+		return "", fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("OCR API error (%d): %s", resp.StatusCode, respBody)
+	}
+
+	var ocrResp ocrResponse
+	if err := json.Unmarshal(respBody, &ocrResp); err != nil {
+		return "", fmt.Errorf("failed to parse response: %w", err)
+	}
+	return ocrResp.Text, nil
+}
@@ -0,0 +1,96 @@
+package attachments
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// extractOOXML extracts the text of the parts of an Office Open XML file (a
+// Word document or PowerPoint presentation) whose names start with prefix, in
+// order.
+func extractOOXML(data []byte, prefix string) (string, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", fmt.Errorf("error opening file: %w", err)
+	}
+
+	var parts []*zip.File
+	for _, f := range zr.File {
+		if strings.HasPrefix(f.Name, prefix) && strings.HasSuffix(f.Name, ".xml") {
+			parts = append(parts, f)
+		}
+	}
+	if len(parts) == 0 {
+		return "", fmt.Errorf("no %s parts found", prefix)
+	}
+	// Slides are numbered, e.g., "ppt/slides/slide10.xml".
+	sort.Slice(parts, func(i, j int) bool {
+		return partNumber(parts[i].Name, prefix) < partNumber(parts[j].Name, prefix)
+	})
+
+	var text strings.Builder
+	for _, f := range parts {
+		rc, err := f.Open()
+		if err != nil {
+			return "", fmt.Errorf("error opening %s: %w", f.Name, err)
+		}
+		err = ooxmlPartText(rc, &text)
+		rc.Close()
+		if err != nil {
+			return "", fmt.Errorf("error reading %s: %w", f.Name, err)
+		}
+	}
+	return text.String(), nil
+}
+
+// ooxmlPartText writes the text runs of an XML part to w, with a line per
+// paragraph.
+func ooxmlPartText(r io.Reader, w *strings.Builder) error {
+	d := xml.NewDecoder(r)
+	inText := false
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "t":
+				inText = true
+			case "tab":
+				w.WriteByte('\t')
+			case "br":
+				w.WriteByte('\n')
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "t":
+				inText = false
+			case "p":
+				w.WriteByte('\n')
+			}
+		case xml.CharData:
+			if inText {
+				w.Write(t)
+			}
+		}
+	}
+}
+
+// partNumber returns the number of a part named, e.g., "ppt/slides/slide10.xml"
+// with prefix "ppt/slides/slide".
+func partNumber(name, prefix string) int {
+	n, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(name, prefix), ".xml"))
+	return n
+}
@@ -0,0 +1,225 @@
+package attachments
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// pdfStreamRE matches the dictionary and data of PDF streams.
+var pdfStreamRE = regexp.MustCompile(`(?s)<<(.*?)>>\s*stream\r?\n`)
+
+// extractPDF extracts the text shown by the content streams of a PDF. Only
+// text in fonts with standard encodings is extracted, which covers the PDFs
+// exported by word processors and presentation software.
+func extractPDF(data []byte) (string, error) {
+	if !bytes.HasPrefix(data, []byte("%PDF-")) {
+		return "", fmt.Errorf("not a PDF file")
+	}
+
+	var text strings.Builder
+	for _, m := range pdfStreamRE.FindAllSubmatchIndex(data, -1) {
+		dict := data[m[2]:m[3]]
+		start := m[1]
+		end := bytes.Index(data[start:], []byte("endstream"))
+		if end < 0 {
+			break
+		}
+		stream := data[start : start+end]
+
+		switch {
+		case bytes.Contains(dict, []byte("/FlateDecode")):
+			r, err := zlib.NewReader(bytes.NewReader(stream))
+			if err != nil {
+				continue
+			}
+			// Streams are often followed by an end-of-line marker that isn't
+			// part of the data, so read errors at the end are ignored.
+			stream, _ = io.ReadAll(r)
+		case bytes.Contains(dict, []byte("/Filter")):
+			// Images and streams with other filters don't contain text.
+			continue
+		}
+
+		pdfContentText(stream, &text)
+	}
+	return text.String(), nil
+}
+
+// pdfContentText writes the text shown by the text operators of a PDF content
+// stream to w.
+func pdfContentText(stream []byte, w *strings.Builder) {
+	var (
+		operands []string
+		inArray  bool
+		inText   bool
+	)
+	newline := func() {
+		if w.Len() > 0 && !strings.HasSuffix(w.String(), "\n") {
+			w.WriteByte('\n')
+		}
+	}
+
+	for i := 0; i < len(stream); {
+		c := stream[i]
+		switch {
+		case c == '%':
+			for i < len(stream) && stream[i] != '\n' && stream[i] != '\r' {
+				i++
+			}
+		case c == '(':
+			s, n := pdfLiteralString(stream[i:])
+			operands = append(operands, s)
+			i += n
+		case c == '<' && i+1 < len(stream) && stream[i+1] != '<':
+			end := bytes.IndexByte(stream[i:], '>')
+			if end < 0 {
+				return
+			}
+			operands = append(operands, pdfHexString(stream[i+1:i+end]))
+			i += end + 1
+		case c == '[':
+			inArray = true
+			i++
+		case c == ']':
+			inArray = false
+			i++
+		case isPDFWhitespace(c) || isPDFDelimiter(c):
+			i++
+		default:
+			start := i
+			for i < len(stream) && !isPDFWhitespace(stream[i]) &&
+				!isPDFDelimiter(stream[i]) {
+				i++
+			}
+			tok := string(stream[start:i])
+
+			if n, err := strconv.ParseFloat(tok, 64); err == nil {
+				// Large negative adjustments in TJ arrays separate words.
+				if inArray && n <= -200 {
+					operands = append(operands, " ")
+				}
+				continue
+			}
+			if strings.HasPrefix(tok, "/") {
+				continue
+			}
+
+			switch tok {
+			case "BT":
+				inText = true
+			case "ET":
+				inText = false
+				newline()
+			case "Td", "TD", "T*":
+				if inText {
+					newline()
+				}
+			case "Tj", "TJ":
+				w.WriteString(strings.Join(operands, ""))
+			case "'", `"`:
+				newline()
+				if len(operands) > 0 {
+					w.WriteString(operands[len(operands)-1])
+				}
+			}
+			operands = operands[:0]
+		}
+	}
+}
+
+// pdfLiteralString decodes the PDF literal string at the start of b and
+// returns it and its encoded length.
+func pdfLiteralString(b []byte) (string, int) {
+	var s strings.Builder
+	depth := 0
+	i := 0
+	for ; i < len(b); i++ {
+		c := b[i]
+		switch c {
+		case '(':
+			depth++
+			if depth == 1 {
+				continue
+			}
+		case ')':
+			depth--
+			if depth == 0 {
+				return s.String(), i + 1
+			}
+		case '\\':
+			i++
+			if i >= len(b) {
+				return s.String(), i
+			}
+			switch e := b[i]; e {
+			case 'n':
+				s.WriteByte('\n')
+			case 'r':
+				s.WriteByte('\r')
+			case 't':
+				s.WriteByte('\t')
+			case 'b', 'f':
+			case '\r', '\n':
+				// Line continuation.
+			default:
+				if e >= '0' && e <= '7' {
+					j := i
+					for j < len(b) && j < i+3 && b[j] >= '0' && b[j] <= '7' {
+						j++
+					}
+					n, _ := strconv.ParseUint(string(b[i:j]), 8, 8)
+					s.WriteRune(rune(n))
+					i = j - 1
+				} else {
+					s.WriteByte(e)
+				}
+			}
+			continue
+		}
+		s.WriteByte(c)
+	}
+	return s.String(), i
+}
+
+// pdfHexString decodes the digits of a PDF hexadecimal string.
+func pdfHexString(digits []byte) string {
+	var clean []byte
+	for _, c := range digits {
+		if !isPDFWhitespace(c) {
+			clean = append(clean, c)
+		}
+	}
+	if len(clean)%2 == 1 {
+		clean = append(clean, '0')
+	}
+	var s strings.Builder
+	for i := 0; i < len(clean); i += 2 {
+		n, err := strconv.ParseUint(string(clean[i:i+2]), 16, 8)
+		if err != nil {
+			return ""
+		}
+		s.WriteRune(rune(n))
+	}
+	return s.String()
+}
+
+func isPDFWhitespace(c byte) bool {
+	switch c {
+	case ' ', '\t', '\r', '\n', '\f', 0:
+		return true
+	}
+	return false
+}
+
+func isPDFDelimiter(c byte) bool {
+	switch c {
+	case '(', ')', '<', '>', '[', ']', '{', '}', '%':
+		return true
+	}
+	return false
+}
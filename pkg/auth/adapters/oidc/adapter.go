@@ -0,0 +1,312 @@
+// Package oidc provides a generic OpenID Connect authentication adapter for
+// Hermes that works with any compliant identity provider (Okta, Azure AD,
+// Keycloak, Dex, etc.) without relying on a load balancer to authenticate
+// users.
+package oidc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	gooidc "github.com/coreos/go-oidc/v3/oidc"
+	"github.com/hashicorp-forge/hermes/pkg/auth"
+	"github.com/hashicorp/go-hclog"
+	"golang.org/x/oauth2"
+)
+
+// SessionCookieName is the name of the cookie that stores the ID token of a
+// user who logged in with the authorization code flow.
+const SessionCookieName = "hermes_oidc_session"
+
+// Config is the configuration for OIDC authentication.
+type Config struct {
+	// IssuerURL is the URL of the OIDC issuer. The provider configuration and
+	// signing keys are discovered from
+	// <issuer_url>/.well-known/openid-configuration.
+	IssuerURL string `hcl:"issuer_url,optional"`
+
+	// ClientID is the OIDC client ID for Hermes.
+	ClientID string `hcl:"client_id,optional"`
+
+	// ClientSecret is the OIDC client secret for Hermes. It is optional for
+	// public clients, which are protected by PKCE.
+	ClientSecret string `hcl:"client_secret,optional"`
+
+	// RedirectURL is the callback URL for OIDC authentication (e.g.,
+	// https://hermes.example.com/auth/callback).
+	RedirectURL string `hcl:"redirect_url,optional"`
+
+	// Scopes are the scopes requested when logging in. Defaults to "openid",
+	// "email", and "profile".
+	Scopes []string `hcl:"scopes,optional"`
+
+	// EmailClaim is the ID token claim that contains the user's email address.
+	// Defaults to "email". Some providers use another claim, such as
+	// "preferred_username" or "upn" for Azure AD.
+	EmailClaim string `hcl:"email_claim,optional"`
+
+	// EmailDomain is appended to the email claim value if it does not contain
+	// a domain (e.g., when the claim is a username).
+	EmailDomain string `hcl:"email_domain,optional"`
+
+	// NameClaim is the ID token claim that contains the user's full name.
+	// Defaults to "name".
+	NameClaim string `hcl:"name_claim,optional"`
+
+	// GroupsClaim is the ID token claim that contains the user's groups.
+	// Defaults to "groups".
+	GroupsClaim string `hcl:"groups_claim,optional"`
+
+	// AllowUnverifiedEmail allows users whose ID token has an
+	// "email_verified" claim set to false.
+	AllowUnverifiedEmail bool `hcl:"allow_unverified_email,optional"`
+
+	// Disabled disables OIDC authentication.
+	Disabled bool `hcl:"disabled,optional"`
+}
+
+// Adapter implements the auth.Provider and auth.ClaimsProvider interfaces
+// using OIDC ID tokens. Tokens are read from the Authorization header
+// ("Bearer <token>"), for clients that run the login flow themselves, or from
+// the session cookie set after the server-side login flow.
+type Adapter struct {
+	cfg          Config
+	log          hclog.Logger
+	provider     *gooidc.Provider
+	verifier     *gooidc.IDTokenVerifier
+	oauth2Config *oauth2.Config
+
+	// endSessionURL is the provider's RP-initiated logout endpoint, if any.
+	endSessionURL string
+}
+
+// NewAdapter creates a new OIDC authentication adapter. The provider
+// configuration is discovered from the issuer.
+func NewAdapter(ctx context.Context, cfg Config, log hclog.Logger) (*Adapter, error) {
+	if cfg.IssuerURL == "" {
+		return nil, fmt.Errorf("issuer URL not configured")
+	}
+	if cfg.ClientID == "" {
+		return nil, fmt.Errorf("client ID not configured")
+	}
+
+	provider, err := gooidc.NewProvider(ctx, cfg.IssuerURL)
+	if err != nil {
+		return nil, fmt.Errorf("error discovering OIDC provider: %w", err)
+	}
+
+	var discovery struct {
+		EndSessionEndpoint string `json:"end_session_endpoint"`
+	}
+	if err := provider.Claims(&discovery); err != nil {
+		return nil, fmt.Errorf("error parsing OIDC provider configuration: %w", err)
+	}
+
+	scopes := cfg.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{gooidc.ScopeOpenID, "email", "profile"}
+	}
+
+	return &Adapter{
+		cfg:      cfg,
+		log:      log,
+		provider: provider,
+		verifier: provider.Verifier(&gooidc.Config{
+			ClientID: cfg.ClientID,
+		}),
+		oauth2Config: &oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.RedirectURL,
+			Endpoint:     provider.Endpoint(),
+			Scopes:       scopes,
+		},
+		endSessionURL: discovery.EndSessionEndpoint,
+	}, nil
+}
+
+// Authenticate verifies the ID token of the request and returns the
+// authenticated user's email address.
+func (a *Adapter) Authenticate(r *http.Request) (string, error) {
+	claims, err := a.GetClaims(r)
+	if err != nil {
+		return "", err
+	}
+	return claims.Email, nil
+}
+
+// GetClaims verifies the ID token of the request and returns the user's
+// claims. This implements the auth.ClaimsProvider interface.
+func (a *Adapter) GetClaims(r *http.Request) (*auth.UserClaims, error) {
+	rawIDToken, err := requestIDToken(r)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, _, err := a.verify(r.Context(), rawIDToken)
+	return claims, err
+}
+
+// Name returns the provider name for logging.
+func (a *Adapter) Name() string {
+	return "oidc"
+}
+
+// AuthCodeURL returns the URL for starting the authorization code flow with
+// PKCE. The verifier must be passed to Exchange when the user is redirected
+// back.
+func (a *Adapter) AuthCodeURL(state, verifier string) string {
+	return a.oauth2Config.AuthCodeURL(state, oauth2.S256ChallengeOption(verifier))
+}
+
+// Exchange exchanges an authorization code and its PKCE verifier for an ID
+// token. It returns the raw ID token, the user's claims, and when the ID token
+// expires.
+func (a *Adapter) Exchange(
+	ctx context.Context, code, verifier string,
+) (string, *auth.UserClaims, time.Time, error) {
+	token, err := a.oauth2Config.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return "", nil, time.Time{},
+			fmt.Errorf("error exchanging authorization code: %w", err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return "", nil, time.Time{}, fmt.Errorf("no id_token in token response")
+	}
+
+	claims, expiry, err := a.verify(ctx, rawIDToken)
+	if err != nil {
+		return "", nil, time.Time{}, err
+	}
+	return rawIDToken, claims, expiry, nil
+}
+
+// LogoutURL returns the provider's URL for ending the user's session and then
+// redirecting to postLogoutRedirectURL, or an empty string if the provider
+// does not support RP-initiated logout.
+func (a *Adapter) LogoutURL(idTokenHint, postLogoutRedirectURL string) string {
+	if a.endSessionURL == "" {
+		return ""
+	}
+	u, err := url.Parse(a.endSessionURL)
+	if err != nil {
+		return ""
+	}
+
+	q := u.Query()
+	q.Set("client_id", a.cfg.ClientID)
+	if idTokenHint != "" {
+		q.Set("id_token_hint", idTokenHint)
+	}
+	if postLogoutRedirectURL != "" {
+		q.Set("post_logout_redirect_uri", postLogoutRedirectURL)
+	}
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// verify verifies an ID token's signature, issuer, audience, and expiry and
+// maps its claims to user claims.
+func (a *Adapter) verify(
+	ctx context.Context, rawIDToken string,
+) (*auth.UserClaims, time.Time, error) {
+	idToken, err := a.verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("error verifying ID token: %w", err)
+	}
+
+	var raw map[string]any
+	if err := idToken.Claims(&raw); err != nil {
+		return nil, time.Time{}, fmt.Errorf("error parsing ID token claims: %w", err)
+	}
+
+	claims, err := MapClaims(a.cfg, raw)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	a.log.Debug("verified OIDC ID token",
+		"email", claims.Email,
+		"subject", idToken.Subject,
+	)
+	return claims, idToken.Expiry, nil
+}
+
+// MapClaims maps ID token claims to user claims using the claim names in
+// cfg. An error is returned if the email claim is missing or the email
+// address is not verified.
+func MapClaims(cfg Config, raw map[string]any) (*auth.UserClaims, error) {
+	emailClaim := cfg.EmailClaim
+	if emailClaim == "" {
+		emailClaim = "email"
+	}
+	nameClaim := cfg.NameClaim
+	if nameClaim == "" {
+		nameClaim = "name"
+	}
+	groupsClaim := cfg.GroupsClaim
+	if groupsClaim == "" {
+		groupsClaim = "groups"
+	}
+
+	email, _ := raw[emailClaim].(string)
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return nil, fmt.Errorf("%s claim not found in ID token", emailClaim)
+	}
+	if !strings.Contains(email, "@") {
+		if cfg.EmailDomain == "" {
+			return nil, fmt.Errorf("%s claim is not an email address", emailClaim)
+		}
+		email = email + "@" + strings.TrimPrefix(cfg.EmailDomain, "@")
+	}
+
+	if verified, ok := raw["email_verified"].(bool); ok && !verified &&
+		!cfg.AllowUnverifiedEmail {
+		return nil, fmt.Errorf("email address %q is not verified", email)
+	}
+
+	claims := &auth.UserClaims{
+		Email: email,
+	}
+	claims.Name, _ = raw[nameClaim].(string)
+	claims.GivenName, _ = raw["given_name"].(string)
+	claims.FamilyName, _ = raw["family_name"].(string)
+	claims.PreferredUsername, _ = raw["preferred_username"].(string)
+	if groups, ok := raw[groupsClaim].([]any); ok {
+		for _, g := range groups {
+			if s, ok := g.(string); ok {
+				claims.Groups = append(claims.Groups, s)
+			}
+		}
+	}
+
+	return claims, nil
+}
+
+// requestIDToken returns the raw ID token from the Authorization header or
+// the session cookie.
+func requestIDToken(r *http.Request) (string, error) {
+	if authHeader := r.Header.Get("Authorization"); authHeader != "" {
+		token, ok := strings.CutPrefix(authHeader, "Bearer ")
+		if !ok || token == "" {
+			return "", fmt.Errorf("invalid Authorization header format")
+		}
+		return token, nil
+	}
+
+	cookie, err := r.Cookie(SessionCookieName)
+	if err != nil || cookie.Value == "" {
+		return "", fmt.Errorf("no ID token found in Authorization header or session cookie")
+	}
+	return cookie.Value, nil
+}
+
+// Ensure Adapter implements the auth.ClaimsProvider interface at compile time.
+var _ auth.ClaimsProvider = (*Adapter)(nil)
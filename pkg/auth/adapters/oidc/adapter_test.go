@@ -0,0 +1,264 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testProvider is a minimal OIDC provider that serves discovery and signing
+// keys.
+type testProvider struct {
+	*httptest.Server
+	key *rsa.PrivateKey
+}
+
+func newTestProvider(t *testing.T) *testProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	p := &testProvider{key: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration",
+		func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(map[string]any{
+				"issuer":                                p.URL,
+				"authorization_endpoint":                p.URL + "/authorize",
+				"token_endpoint":                        p.URL + "/token",
+				"jwks_uri":                              p.URL + "/keys",
+				"end_session_endpoint":                  p.URL + "/logout",
+				"id_token_signing_alg_values_supported": []string{"RS256"},
+			})
+		})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"alg": "RS256",
+				"use": "sig",
+				"kid": "test",
+				"n": base64.RawURLEncoding.EncodeToString(
+					key.PublicKey.N.Bytes()),
+				"e": base64.RawURLEncoding.EncodeToString(
+					big.NewInt(int64(key.PublicKey.E)).Bytes()),
+			}},
+		})
+	})
+	p.Server = httptest.NewServer(mux)
+	t.Cleanup(p.Close)
+	return p
+}
+
+// idToken returns a signed ID token with the provided claims in addition to
+// valid registered claims.
+func (p *testProvider) idToken(t *testing.T, claims jwt.MapClaims) string {
+	c := jwt.MapClaims{
+		"iss": p.URL,
+		"aud": "hermes",
+		"sub": "user-1",
+		"iat": time.Now().Unix(),
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}
+	for k, v := range claims {
+		c[k] = v
+	}
+	tok := jwt.NewWithClaims(jwt.SigningMethodRS256, c)
+	tok.Header["kid"] = "test"
+	s, err := tok.SignedString(p.key)
+	require.NoError(t, err)
+	return s
+}
+
+func TestAdapter(t *testing.T) {
+	p := newTestProvider(t)
+	a, err := NewAdapter(context.Background(), Config{
+		IssuerURL:   p.URL,
+		ClientID:    "hermes",
+		RedirectURL: "https://hermes.example.com/auth/callback",
+	}, hclog.NewNullLogger())
+	require.NoError(t, err)
+
+	t.Run("bearer token", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/api/v2/me", nil)
+		r.Header.Set("Authorization", "Bearer "+p.idToken(t, jwt.MapClaims{
+			"email":  "user@example.com",
+			"name":   "Test User",
+			"groups": []string{"engineering"},
+		}))
+
+		email, err := a.Authenticate(r)
+		require.NoError(t, err)
+		assert.Equal(t, "user@example.com", email)
+
+		claims, err := a.GetClaims(r)
+		require.NoError(t, err)
+		assert.Equal(t, "Test User", claims.Name)
+		assert.Equal(t, []string{"engineering"}, claims.Groups)
+	})
+
+	t.Run("session cookie", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/api/v2/me", nil)
+		r.AddCookie(&http.Cookie{
+			Name: SessionCookieName,
+			Value: p.idToken(t, jwt.MapClaims{
+				"email": "user@example.com",
+			}),
+		})
+
+		email, err := a.Authenticate(r)
+		require.NoError(t, err)
+		assert.Equal(t, "user@example.com", email)
+	})
+
+	t.Run("no token", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/api/v2/me", nil)
+		_, err := a.Authenticate(r)
+		assert.Error(t, err)
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/api/v2/me", nil)
+		r.Header.Set("Authorization", "Bearer "+p.idToken(t, jwt.MapClaims{
+			"email": "user@example.com",
+			"exp":   time.Now().Add(-time.Hour).Unix(),
+		}))
+		_, err := a.Authenticate(r)
+		assert.Error(t, err)
+	})
+
+	t.Run("wrong audience", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/api/v2/me", nil)
+		r.Header.Set("Authorization", "Bearer "+p.idToken(t, jwt.MapClaims{
+			"email": "user@example.com",
+			"aud":   "another-app",
+		}))
+		_, err := a.Authenticate(r)
+		assert.Error(t, err)
+	})
+
+	t.Run("token signed by another key", func(t *testing.T) {
+		other := newTestProvider(t)
+		tok := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+			"iss":   p.URL,
+			"aud":   "hermes",
+			"sub":   "user-1",
+			"exp":   time.Now().Add(time.Hour).Unix(),
+			"email": "user@example.com",
+		})
+		tok.Header["kid"] = "test"
+		s, err := tok.SignedString(other.key)
+		require.NoError(t, err)
+
+		r := httptest.NewRequest("GET", "/api/v2/me", nil)
+		r.Header.Set("Authorization", "Bearer "+s)
+		_, err = a.Authenticate(r)
+		assert.Error(t, err)
+	})
+
+	t.Run("auth code URL uses PKCE", func(t *testing.T) {
+		u, err := url.Parse(a.AuthCodeURL("state-1", "verifier-1"))
+		require.NoError(t, err)
+		assert.Equal(t, p.URL+"/authorize", u.Scheme+"://"+u.Host+u.Path)
+
+		q := u.Query()
+		assert.Equal(t, "state-1", q.Get("state"))
+		assert.Equal(t, "S256", q.Get("code_challenge_method"))
+		assert.NotEmpty(t, q.Get("code_challenge"))
+		assert.NotEqual(t, "verifier-1", q.Get("code_challenge"))
+		assert.Equal(t, "openid email profile", q.Get("scope"))
+	})
+
+	t.Run("logout URL", func(t *testing.T) {
+		u, err := url.Parse(a.LogoutURL("id-token", "https://hermes.example.com"))
+		require.NoError(t, err)
+		assert.Equal(t, "/logout", u.Path)
+		assert.Equal(t, "id-token", u.Query().Get("id_token_hint"))
+		assert.Equal(t, "https://hermes.example.com",
+			u.Query().Get("post_logout_redirect_uri"))
+	})
+}
+
+func TestMapClaims(t *testing.T) {
+	cases := map[string]struct {
+		cfg       Config
+		raw       map[string]any
+		wantEmail string
+		wantErr   bool
+	}{
+		"default email claim": {
+			raw:       map[string]any{"email": "user@example.com"},
+			wantEmail: "user@example.com",
+		},
+		"custom email claim": {
+			cfg: Config{EmailClaim: "upn"},
+			raw: map[string]any{
+				"email": "other@example.com",
+				"upn":   "user@example.com",
+			},
+			wantEmail: "user@example.com",
+		},
+		"username with email domain": {
+			cfg:       Config{EmailClaim: "preferred_username", EmailDomain: "example.com"},
+			raw:       map[string]any{"preferred_username": "user"},
+			wantEmail: "user@example.com",
+		},
+		"username without email domain": {
+			cfg:     Config{EmailClaim: "preferred_username"},
+			raw:     map[string]any{"preferred_username": "user"},
+			wantErr: true,
+		},
+		"missing email claim": {
+			raw:     map[string]any{"name": "Test User"},
+			wantErr: true,
+		},
+		"unverified email": {
+			raw: map[string]any{
+				"email":          "user@example.com",
+				"email_verified": false,
+			},
+			wantErr: true,
+		},
+		"unverified email allowed": {
+			cfg: Config{AllowUnverifiedEmail: true},
+			raw: map[string]any{
+				"email":          "user@example.com",
+				"email_verified": false,
+			},
+			wantEmail: "user@example.com",
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			claims, err := MapClaims(c.cfg, c.raw)
+			if c.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, c.wantEmail, claims.Email)
+		})
+	}
+
+	t.Run("custom groups claim", func(t *testing.T) {
+		claims, err := MapClaims(Config{GroupsClaim: "roles"}, map[string]any{
+			"email": "user@example.com",
+			"roles": []any{"admin", "reader"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"admin", "reader"}, claims.Groups)
+	})
+}
@@ -52,6 +52,10 @@ const UserEmailKey contextKey = "userEmail"
 // UserClaimsKey is the context key for storing the authenticated user's claims.
 const UserClaimsKey contextKey = "userClaims"
 
+// ImpersonatorKey is the context key for storing the email address of the
+// admin who is impersonating the user identified by UserEmailKey.
+const ImpersonatorKey contextKey = "impersonator"
+
 // Middleware creates HTTP middleware that authenticates requests using the provided
 // authentication provider. On successful authentication, the user's email is stored
 // in the request context using UserEmailKey. If the provider implements ClaimsProvider,
@@ -161,3 +165,20 @@ func GetUserClaimsOrError(ctx context.Context) (*UserClaims, error) {
 	}
 	return claims, nil
 }
+
+// GetImpersonator extracts the email address of the admin impersonating the
+// authenticated user from the request context. Returns the email and a boolean
+// indicating whether the request is made during an impersonation session.
+func GetImpersonator(ctx context.Context) (string, bool) {
+	email, ok := ctx.Value(ImpersonatorKey).(string)
+	return email, ok && email != ""
+}
+
+// WithImpersonation returns a copy of ctx in which the authenticated user is
+// userEmail, impersonated by adminEmail. The admin's claims are removed so
+// they are not mistaken for the user's.
+func WithImpersonation(ctx context.Context, adminEmail, userEmail string) context.Context {
+	ctx = context.WithValue(ctx, UserEmailKey, userEmail)
+	ctx = context.WithValue(ctx, UserClaimsKey, (*UserClaims)(nil))
+	return context.WithValue(ctx, ImpersonatorKey, adminEmail)
+}
@@ -0,0 +1,223 @@
+// Package authz decides whether users may perform actions on Hermes resources.
+// Permissions come from a user's relationship with a document (owner,
+// contributor, or approver) and from roles granted to the user.
+package authz
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrForbidden is returned when a user is not allowed to perform an action.
+var ErrForbidden = errors.New("forbidden")
+
+// Role is a role granted to a user. Users without a role are regular users.
+type Role string
+
+const (
+	// RoleSiteAdmin can perform any action except reviewing documents, which
+	// is reserved for approvers.
+	RoleSiteAdmin Role = "site_admin"
+
+	// RoleTenantAdmin can perform any action a site admin can on the documents
+	// of a tenant, but can't manage Hermes itself (ActionAdmin).
+	RoleTenantAdmin Role = "tenant_admin"
+
+	// RoleDocumentTypeAdmin can edit published documents of a document type.
+	RoleDocumentTypeAdmin Role = "document_type_admin"
+
+	// RoleProductLead can edit published documents of a product.
+	RoleProductLead Role = "product_lead"
+)
+
+// Grant is a role granted to a user, with the document type, product, or
+// tenant the role is scoped to, if any.
+type Grant struct {
+	Role         Role
+	DocumentType string
+	Product      string
+	TenantID     uint
+}
+
+// Principal is a user and the roles granted to them.
+type Principal struct {
+	Email  string
+	Grants []Grant
+}
+
+// IsSiteAdmin returns true if the principal has the site admin role.
+func (p Principal) IsSiteAdmin() bool {
+	for _, g := range p.Grants {
+		if g.Role == RoleSiteAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+// Action is an action a principal can perform on a resource.
+type Action string
+
+const (
+	// ActionAdmin is managing Hermes itself (e.g., roles, providers, and
+	// migrations).
+	ActionAdmin Action = "admin"
+
+	// ActionTenantAdmin is managing the documents of a tenant (e.g., restoring
+	// deleted documents). The tenant of the resource is the tenant managed.
+	ActionTenantAdmin Action = "tenant.admin"
+
+	// ActionDocumentEdit is editing a published document's metadata or related
+	// resources.
+	ActionDocumentEdit Action = "document.edit"
+
+	// ActionDocumentEditContent is editing a published document's content.
+	ActionDocumentEditContent Action = "document.edit_content"
+
+	// ActionDocumentReview is approving or requesting changes to a document.
+	ActionDocumentReview Action = "document.review"
+
+	// ActionDraftView is viewing a draft.
+	ActionDraftView Action = "draft.view"
+
+	// ActionDraftEdit is editing a draft's metadata, content, sharing settings,
+	// or related resources, or publishing it.
+	ActionDraftEdit Action = "draft.edit"
+
+	// ActionDraftDelete is deleting a draft.
+	ActionDraftDelete Action = "draft.delete"
+
+	// ActionProjectDelete is deleting a project. The owner of the resource is
+	// the creator of the project.
+	ActionProjectDelete Action = "project.delete"
+)
+
+// Resource is the document or project an action is performed on. It is empty
+// for actions that do not target one (e.g., ActionAdmin).
+type Resource struct {
+	Owner        string
+	Contributors []string
+
+	// Approvers are the users who can review the document, including users
+	// who can approve on behalf of an approver group.
+	Approvers []string
+
+	DocumentType string
+	Product      string
+
+	// TenantID is the ID of the tenant of the document. It's zero for the
+	// default tenant and for projects.
+	TenantID uint
+
+	// Shared is true if a draft is shared with everyone.
+	Shared bool
+}
+
+// Authorize returns nil if principal p is allowed to perform action a on
+// resource res, or an error wrapping ErrForbidden otherwise.
+func Authorize(p Principal, a Action, res Resource) error {
+	isOwner := p.Email != "" && p.Email == res.Owner
+	isContributor := contains(res.Contributors, p.Email)
+
+	switch a {
+	case ActionAdmin:
+		if p.IsSiteAdmin() {
+			return nil
+		}
+		return forbidden(a, "only site admins are allowed")
+
+	case ActionTenantAdmin:
+		if p.administers(res) {
+			return nil
+		}
+		return forbidden(a, "only site admins and tenant admins are allowed")
+
+	case ActionDocumentReview:
+		if contains(res.Approvers, p.Email) {
+			return nil
+		}
+		return forbidden(a, "only approvers are allowed")
+
+	case ActionDocumentEdit:
+		if isOwner || p.administers(res) || p.leads(res) {
+			return nil
+		}
+		return forbidden(a, "only owners and admins are allowed")
+
+	case ActionDocumentEditContent:
+		if isOwner || isContributor || p.administers(res) || p.leads(res) {
+			return nil
+		}
+		return forbidden(a, "only owners, contributors, and admins are allowed")
+
+	case ActionDraftView:
+		if isOwner || isContributor || res.Shared || p.administers(res) {
+			return nil
+		}
+		return forbidden(a, "only owners and contributors are allowed")
+
+	case ActionDraftEdit, ActionDraftDelete:
+		if isOwner || p.administers(res) {
+			return nil
+		}
+		return forbidden(a, "only owners are allowed")
+
+	case ActionProjectDelete:
+		if isOwner || p.IsSiteAdmin() {
+			return nil
+		}
+		return forbidden(a, "only owners are allowed")
+
+	default:
+		return forbidden(a, "unknown action")
+	}
+}
+
+// administers returns true if the principal is a site admin, or a tenant admin
+// of the tenant of the resource.
+func (p Principal) administers(res Resource) bool {
+	if p.IsSiteAdmin() {
+		return true
+	}
+	for _, g := range p.Grants {
+		if g.Role == RoleTenantAdmin && g.TenantID != 0 &&
+			g.TenantID == res.TenantID {
+			return true
+		}
+	}
+	return false
+}
+
+// leads returns true if the principal is a document type admin or product lead
+// for the resource.
+func (p Principal) leads(res Resource) bool {
+	for _, g := range p.Grants {
+		switch g.Role {
+		case RoleDocumentTypeAdmin:
+			if g.DocumentType != "" && g.DocumentType == res.DocumentType {
+				return true
+			}
+		case RoleProductLead:
+			if g.Product != "" && g.Product == res.Product {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func forbidden(a Action, reason string) error {
+	return fmt.Errorf("%w: %s: %s", ErrForbidden, a, reason)
+}
+
+func contains(values []string, s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,112 @@
+package authz
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthorize(t *testing.T) {
+	doc := Resource{
+		Owner:        "owner@example.com",
+		Contributors: []string{"contributor@example.com"},
+		Approvers:    []string{"approver@example.com"},
+		DocumentType: "RFC",
+		Product:      "Vault",
+	}
+
+	user := func(email string, grants ...Grant) Principal {
+		return Principal{Email: email, Grants: grants}
+	}
+	admin := user("admin@example.com", Grant{Role: RoleSiteAdmin})
+	rfcAdmin := user("rfc-admin@example.com",
+		Grant{Role: RoleDocumentTypeAdmin, DocumentType: "RFC"})
+	prdAdmin := user("prd-admin@example.com",
+		Grant{Role: RoleDocumentTypeAdmin, DocumentType: "PRD"})
+	vaultLead := user("lead@example.com",
+		Grant{Role: RoleProductLead, Product: "Vault"})
+	tenantAdmin := user("tenant-admin@example.com",
+		Grant{Role: RoleTenantAdmin, TenantID: 1})
+	tenantDoc := doc
+	tenantDoc.TenantID = 1
+	owner := user("owner@example.com")
+	contributor := user("contributor@example.com")
+	approver := user("approver@example.com")
+	other := user("other@example.com")
+
+	cases := []struct {
+		name    string
+		p       Principal
+		action  Action
+		res     Resource
+		allowed bool
+	}{
+		{"site admin can administer", admin, ActionAdmin, Resource{}, true},
+		{"owner cannot administer", owner, ActionAdmin, Resource{}, false},
+		{"tenant admin cannot administer",
+			tenantAdmin, ActionAdmin, Resource{}, false},
+
+		{"site admin can administer tenant",
+			admin, ActionTenantAdmin, Resource{TenantID: 1}, true},
+		{"tenant admin can administer tenant",
+			tenantAdmin, ActionTenantAdmin, Resource{TenantID: 1}, true},
+		{"tenant admin cannot administer other tenant",
+			tenantAdmin, ActionTenantAdmin, Resource{TenantID: 2}, false},
+		{"tenant admin cannot administer default tenant",
+			tenantAdmin, ActionTenantAdmin, Resource{}, false},
+
+		{"owner can edit", owner, ActionDocumentEdit, doc, true},
+		{"site admin can edit", admin, ActionDocumentEdit, doc, true},
+		{"document type admin can edit", rfcAdmin, ActionDocumentEdit, doc, true},
+		{"other document type admin cannot edit",
+			prdAdmin, ActionDocumentEdit, doc, false},
+		{"product lead can edit", vaultLead, ActionDocumentEdit, doc, true},
+		{"contributor cannot edit metadata",
+			contributor, ActionDocumentEdit, doc, false},
+		{"tenant admin can edit tenant document",
+			tenantAdmin, ActionDocumentEdit, tenantDoc, true},
+		{"tenant admin cannot edit other tenant document",
+			tenantAdmin, ActionDocumentEdit, doc, false},
+
+		{"contributor can edit content",
+			contributor, ActionDocumentEditContent, doc, true},
+		{"other user cannot edit content",
+			other, ActionDocumentEditContent, doc, false},
+
+		{"approver can review", approver, ActionDocumentReview, doc, true},
+		{"owner cannot review", owner, ActionDocumentReview, doc, false},
+		{"site admin cannot review", admin, ActionDocumentReview, doc, false},
+
+		{"contributor can view draft", contributor, ActionDraftView, doc, true},
+		{"other user cannot view draft", other, ActionDraftView, doc, false},
+		{"other user can view shared draft",
+			other, ActionDraftView, Resource{Owner: "owner@example.com", Shared: true},
+			true},
+		{"product lead cannot view draft", vaultLead, ActionDraftView, doc, false},
+		{"contributor cannot edit draft", contributor, ActionDraftEdit, doc, false},
+		{"site admin can delete draft", admin, ActionDraftDelete, doc, true},
+		{"tenant admin can delete tenant draft",
+			tenantAdmin, ActionDraftDelete, tenantDoc, true},
+		{"creator can delete project", owner, ActionProjectDelete, doc, true},
+		{"site admin can delete project", admin, ActionProjectDelete, doc, true},
+		{"tenant admin cannot delete project",
+			tenantAdmin, ActionProjectDelete, tenantDoc, false},
+		{"other user cannot delete project", other, ActionProjectDelete, doc, false},
+
+		{"empty email is never the owner",
+			user(""), ActionDocumentEdit, Resource{}, false},
+		{"unknown action", admin, Action("unknown"), doc, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := Authorize(c.p, c.action, c.res)
+			if c.allowed {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, errors.Is(err, ErrForbidden), "got error %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,288 @@
+// Package avatar serves the profile photos of people through Hermes. Photos
+// are fetched from the workspace provider, resized, and cached, so viewers
+// don't load them from the provider, which can require signing in to it and
+// leaks the pages they're shown on in the Referer header.
+package avatar
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif" // Decode GIF photos.
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/httpcache"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	// DefaultSize is the default width and height of photos, in pixels.
+	DefaultSize = 96
+
+	// MinSize and MaxSize are the smallest and largest sizes photos can be
+	// requested in.
+	MinSize = 16
+	MaxSize = 512
+
+	// DefaultTTL is how long photos are cached by default.
+	DefaultTTL = 24 * time.Hour
+
+	// DefaultMaxEntries is how many photos are cached in memory by default.
+	DefaultMaxEntries = 2000
+
+	// maxPhotoBytes is the maximum size of photos fetched from the provider.
+	maxPhotoBytes = 5 << 20
+)
+
+// ErrNotFound is returned for people without a photo.
+var ErrNotFound = errors.New("photo not found")
+
+// PhotoURLFunc returns the URL of the profile photo of the person with email
+// address email, or an empty string if they don't have one.
+type PhotoURLFunc func(ctx context.Context, email string) (string, error)
+
+// Config contains photo proxy configuration.
+type Config struct {
+	// TTL is how long photos, and the absence of photos, are cached.
+	TTL time.Duration
+
+	// Client fetches photos. The default client times out after 10 seconds.
+	Client *http.Client
+}
+
+// Photo is a resized profile photo.
+type Photo struct {
+	ContentType string `json:"contentType"`
+	Data        []byte `json:"data"`
+}
+
+// Proxy fetches, resizes, and caches profile photos.
+type Proxy struct {
+	photoURL PhotoURLFunc
+	store    httpcache.Store
+	client   *http.Client
+	ttl      time.Duration
+	logger   hclog.Logger
+}
+
+// NewProxy creates a photo proxy that looks up photos with photoURL and caches
+// them in store. Photos aren't cached if store is nil.
+func NewProxy(
+	photoURL PhotoURLFunc,
+	store httpcache.Store,
+	logger hclog.Logger,
+	cfg *Config,
+) *Proxy {
+	if logger == nil {
+		logger = hclog.NewNullLogger()
+	}
+	p := &Proxy{
+		photoURL: photoURL,
+		store:    store,
+		client:   &http.Client{Timeout: 10 * time.Second},
+		ttl:      DefaultTTL,
+		logger:   logger.Named("avatar"),
+	}
+	if cfg != nil {
+		if cfg.TTL > 0 {
+			p.ttl = cfg.TTL
+		}
+		if cfg.Client != nil {
+			p.client = cfg.Client
+		}
+	}
+	return p
+}
+
+// TTL returns how long photos are cached.
+func (p *Proxy) TTL() time.Duration {
+	return p.ttl
+}
+
+// Photo returns the photo of the person with email address email, resized to
+// fit in size by size pixels. Smaller photos aren't enlarged. It returns
+// ErrNotFound if the person doesn't have a photo.
+func (p *Proxy) Photo(ctx context.Context, email string, size int) (*Photo, error) {
+	if size < MinSize || size > MaxSize {
+		return nil, fmt.Errorf("size must be between %d and %d", MinSize, MaxSize)
+	}
+
+	key := httpcache.Key("avatar",
+		[]byte(strings.ToLower(email)), []byte(strconv.Itoa(size)))
+	if photo, ok := p.cached(ctx, key); ok {
+		if len(photo.Data) == 0 {
+			return nil, ErrNotFound
+		}
+		return photo, nil
+	}
+
+	photo, err := p.fetch(ctx, email, size)
+	if errors.Is(err, ErrNotFound) {
+		// Remember people without photos, so the provider isn't asked again
+		// on every page view.
+		p.cache(ctx, key, &Photo{})
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	p.cache(ctx, key, photo)
+	return photo, nil
+}
+
+// fetch fetches and resizes the photo of the person with email address email.
+func (p *Proxy) fetch(ctx context.Context, email string, size int) (*Photo, error) {
+	photoURL, err := p.photoURL(ctx, email)
+	if err != nil {
+		return nil, fmt.Errorf("error getting photo URL: %w", err)
+	}
+	if photoURL == "" {
+		return nil, ErrNotFound
+	}
+	u, err := url.Parse(photoURL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") {
+		return nil, fmt.Errorf("invalid photo URL %q", photoURL)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching photo: %w", err)
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, ErrNotFound
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("error fetching photo: %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxPhotoBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("error reading photo: %w", err)
+	}
+	if len(data) > maxPhotoBytes {
+		return nil, fmt.Errorf("photo is larger than %d bytes", maxPhotoBytes)
+	}
+
+	return Resize(data, size)
+}
+
+// Resize decodes a JPEG, PNG, or GIF photo and resizes it to fit in size by
+// size pixels, keeping its aspect ratio. Smaller photos aren't enlarged.
+// Photos are always encoded again, as JPEG if they're opaque and PNG
+// otherwise, so only images are served whatever the provider returned.
+func Resize(data []byte, size int) (*Photo, error) {
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("error decoding photo: %w", err)
+	}
+	img := scale(src, size)
+
+	var buf bytes.Buffer
+	photo := &Photo{}
+	if o, ok := img.(interface{ Opaque() bool }); ok && o.Opaque() {
+		photo.ContentType = "image/jpeg"
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: 90})
+	} else {
+		photo.ContentType = "image/png"
+		err = png.Encode(&buf, img)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error encoding photo: %w", err)
+	}
+	photo.Data = buf.Bytes()
+	return photo, nil
+}
+
+// scale scales src down to fit in size by size pixels by averaging the pixels
+// each pixel of the result covers.
+func scale(src image.Image, size int) image.Image {
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w <= size && h <= size {
+		return src
+	}
+
+	dw, dh := size, size
+	if w > h {
+		dh = max(1, h*size/w)
+	} else {
+		dw = max(1, w*size/h)
+	}
+
+	dst := image.NewRGBA64(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		y0, y1 := b.Min.Y+y*h/dh, b.Min.Y+(y+1)*h/dh
+		for x := 0; x < dw; x++ {
+			x0, x1 := b.Min.X+x*w/dw, b.Min.X+(x+1)*w/dw
+			var r, g, bl, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					c := color.RGBA64Model.Convert(src.At(sx, sy)).(color.RGBA64)
+					r += uint64(c.R)
+					g += uint64(c.G)
+					bl += uint64(c.B)
+					a += uint64(c.A)
+					n++
+				}
+			}
+			dst.SetRGBA64(x, y, color.RGBA64{
+				R: uint16(r / n),
+				G: uint16(g / n),
+				B: uint16(bl / n),
+				A: uint16(a / n),
+			})
+		}
+	}
+	return dst
+}
+
+// cached returns the photo cached for key, if any. Cache errors are logged and
+// treated as misses.
+func (p *Proxy) cached(ctx context.Context, key string) (*Photo, bool) {
+	if p.store == nil {
+		return nil, false
+	}
+	b, err := p.store.Get(ctx, key)
+	if err != nil {
+		if !errors.Is(err, httpcache.ErrNotFound) {
+			p.logger.Warn("error getting cached photo", "error", err)
+		}
+		return nil, false
+	}
+	var photo Photo
+	if err := json.Unmarshal(b, &photo); err != nil {
+		p.logger.Warn("error decoding cached photo", "error", err)
+		return nil, false
+	}
+	return &photo, true
+}
+
+// cache caches photo for key. Cache errors are logged.
+func (p *Proxy) cache(ctx context.Context, key string, photo *Photo) {
+	if p.store == nil {
+		return
+	}
+	b, err := json.Marshal(photo)
+	if err != nil {
+		p.logger.Warn("error encoding photo", "error", err)
+		return
+	}
+	if err := p.store.Set(ctx, key, b, p.ttl); err != nil {
+		p.logger.Warn("error caching photo", "error", err)
+	}
+}
@@ -0,0 +1,102 @@
+package avatar
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/pkg/httpcache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPNG returns a w by h PNG image, transparent if alpha is true.
+func testPNG(t *testing.T, w, h int, alpha bool) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	c := color.NRGBA{R: 200, G: 100, B: 50, A: 255}
+	if alpha {
+		c.A = 128
+	}
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.SetNRGBA(x, y, c)
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestResize(t *testing.T) {
+	photo, err := Resize(testPNG(t, 400, 200, false), 96)
+	require.NoError(t, err)
+	assert.Equal(t, "image/jpeg", photo.ContentType)
+	img, _, err := image.Decode(bytes.NewReader(photo.Data))
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 96, 48), img.Bounds())
+
+	// Smaller photos aren't enlarged, and transparency is kept.
+	photo, err = Resize(testPNG(t, 32, 32, true), 96)
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", photo.ContentType)
+	img, err = png.Decode(bytes.NewReader(photo.Data))
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 32, 32), img.Bounds())
+
+	// Other content isn't served.
+	_, err = Resize([]byte("<svg></svg>"), 96)
+	assert.Error(t, err)
+}
+
+func TestProxy(t *testing.T) {
+	ctx := context.Background()
+
+	var fetches int
+	ts := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			fetches++
+			w.Header().Set("Content-Type", "image/png")
+			w.Write(testPNG(t, 200, 200, false))
+		}))
+	defer ts.Close()
+
+	var lookups int
+	photoURL := func(ctx context.Context, email string) (string, error) {
+		lookups++
+		if email == "user@example.com" {
+			return ts.URL + "/photo.png", nil
+		}
+		return "", nil
+	}
+	p := NewProxy(photoURL, httpcache.NewMemoryStore(10), nil, nil)
+
+	photo, err := p.Photo(ctx, "user@example.com", 64)
+	require.NoError(t, err)
+	assert.Equal(t, "image/jpeg", photo.ContentType)
+
+	// Photos are cached.
+	cached, err := p.Photo(ctx, "User@example.com", 64)
+	require.NoError(t, err)
+	assert.Equal(t, photo, cached)
+	assert.Equal(t, 1, fetches)
+
+	// Other sizes are fetched again.
+	_, err = p.Photo(ctx, "user@example.com", 128)
+	require.NoError(t, err)
+	assert.Equal(t, 2, fetches)
+
+	// People without photos are remembered.
+	_, err = p.Photo(ctx, "nophoto@example.com", 64)
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, err = p.Photo(ctx, "nophoto@example.com", 64)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Equal(t, 3, lookups)
+
+	_, err = p.Photo(ctx, "user@example.com", MaxSize+1)
+	assert.Error(t, err)
+}
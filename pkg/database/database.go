@@ -11,6 +11,14 @@ import (
 	"gorm.io/gorm/logger"
 )
 
+// Default connection pool settings.
+const (
+	DefaultMaxIdleConns    = 10
+	DefaultMaxOpenConns    = 25
+	DefaultConnMaxLifetime = 5 * time.Minute
+	DefaultConnMaxIdleTime = 10 * time.Minute
+)
+
 // Config holds configuration for database connection.
 type Config struct {
 	Host     string
@@ -25,6 +33,11 @@ type Config struct {
 	MaxOpenConns    int           // Maximum open connections (default: 25)
 	ConnMaxLifetime time.Duration // Maximum connection lifetime (default: 5 minutes)
 	ConnMaxIdleTime time.Duration // Maximum connection idle time (default: 10 minutes)
+
+	// ReadReplicaDSN is the DSN of a read replica. If set, queries run with a
+	// DB returned by Replica are routed to it. The replica uses the same pool
+	// settings as the primary.
+	ReadReplicaDSN string
 }
 
 // Connect establishes a database connection using the provided configuration.
@@ -53,48 +66,67 @@ func Connect(cfg Config, log hclog.Logger) (*gorm.DB, error) {
 	}
 
 	// Configure connection pooling for optimal performance (RFC-088)
-	sqlDB, err := db.DB()
+	pool, err := configurePool(db, cfg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get underlying SQL DB: %w", err)
+		return nil, err
 	}
 
-	// Apply connection pool settings with sensible defaults
-	maxIdleConns := cfg.MaxIdleConns
-	if maxIdleConns == 0 {
-		maxIdleConns = 10 // Default: maintain 10 idle connections
+	if log != nil {
+		log.Info("connected to database with connection pooling",
+			"host", cfg.Host,
+			"database", cfg.DBName,
+			"max_idle_conns", pool.MaxIdleConns,
+			"max_open_conns", pool.MaxOpenConns,
+			"conn_max_lifetime", pool.ConnMaxLifetime,
+			"conn_max_idle_time", pool.ConnMaxIdleTime,
+		)
 	}
-	sqlDB.SetMaxIdleConns(maxIdleConns)
 
-	maxOpenConns := cfg.MaxOpenConns
-	if maxOpenConns == 0 {
-		maxOpenConns = 25 // Default: allow up to 25 concurrent connections
+	if cfg.ReadReplicaDSN != "" {
+		replica, err := gorm.Open(postgres.Open(cfg.ReadReplicaDSN), gormConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open read replica: %w", err)
+		}
+		if _, err := configurePool(replica, cfg); err != nil {
+			return nil, err
+		}
+		if err := RegisterReadReplica(db, replica); err != nil {
+			return nil, err
+		}
+		if log != nil {
+			log.Info("routing read-heavy queries to read replica")
+		}
 	}
-	sqlDB.SetMaxOpenConns(maxOpenConns)
 
-	connMaxLifetime := cfg.ConnMaxLifetime
-	if connMaxLifetime == 0 {
-		connMaxLifetime = 5 * time.Minute // Default: recycle connections after 5 minutes
-	}
-	sqlDB.SetConnMaxLifetime(connMaxLifetime)
+	return db, nil
+}
 
-	connMaxIdleTime := cfg.ConnMaxIdleTime
-	if connMaxIdleTime == 0 {
-		connMaxIdleTime = 10 * time.Minute // Default: close idle connections after 10 minutes
+// configurePool applies the connection pool settings of cfg to db, using the
+// defaults for unset settings, and returns the settings applied.
+func configurePool(db *gorm.DB, cfg Config) (Config, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return cfg, fmt.Errorf("failed to get underlying SQL DB: %w", err)
 	}
-	sqlDB.SetConnMaxIdleTime(connMaxIdleTime)
 
-	if log != nil {
-		log.Info("connected to database with connection pooling",
-			"host", cfg.Host,
-			"database", cfg.DBName,
-			"max_idle_conns", maxIdleConns,
-			"max_open_conns", maxOpenConns,
-			"conn_max_lifetime", connMaxLifetime,
-			"conn_max_idle_time", connMaxIdleTime,
-		)
+	if cfg.MaxIdleConns == 0 {
+		cfg.MaxIdleConns = DefaultMaxIdleConns
+	}
+	if cfg.MaxOpenConns == 0 {
+		cfg.MaxOpenConns = DefaultMaxOpenConns
+	}
+	if cfg.ConnMaxLifetime == 0 {
+		cfg.ConnMaxLifetime = DefaultConnMaxLifetime
+	}
+	if cfg.ConnMaxIdleTime == 0 {
+		cfg.ConnMaxIdleTime = DefaultConnMaxIdleTime
 	}
 
-	return db, nil
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+	return cfg, nil
 }
 
 // PoolStats holds database connection pool statistics.
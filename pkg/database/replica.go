@@ -0,0 +1,63 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// replicaContextKey marks the context of queries that can be routed to the
+// read replica.
+type replicaContextKey struct{}
+
+// replicaCallbackName is the name of the callbacks that route queries to the
+// read replica.
+const replicaCallbackName = "hermes:read_replica"
+
+// RegisterReadReplica routes the queries of db that are run with a DB returned
+// by Replica to replica.
+//
+// Queries are only routed if opted into with Replica, because the replica may
+// lag behind the primary: reads that must see a preceding write, like the
+// reads of a request that changes data, keep using the primary. Queries in a
+// transaction always use the primary.
+func RegisterReadReplica(db, replica *gorm.DB) error {
+	pool := replica.ConnPool
+	route := func(tx *gorm.DB) {
+		if tx.Statement.Context == nil ||
+			tx.Statement.Context.Value(replicaContextKey{}) == nil {
+			return
+		}
+		if _, inTx := tx.Statement.ConnPool.(gorm.TxCommitter); inTx {
+			return
+		}
+		tx.Statement.ConnPool = pool
+	}
+
+	if err := db.Callback().Query().Before("gorm:query").
+		Register(replicaCallbackName, route); err != nil {
+		return fmt.Errorf("failed to register read replica query callback: %w", err)
+	}
+	if err := db.Callback().Row().Before("gorm:row").
+		Register(replicaCallbackName, route); err != nil {
+		return fmt.Errorf("failed to register read replica row callback: %w", err)
+	}
+	return nil
+}
+
+// Replica returns db with its queries routed to the read replica, if one is
+// registered with RegisterReadReplica. Otherwise, queries use db as is.
+//
+// Use it for read-heavy requests that tolerate replication lag, like lists
+// and dashboards.
+func Replica(db *gorm.DB) *gorm.DB {
+	if db == nil {
+		return nil
+	}
+	ctx := db.Statement.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return db.WithContext(context.WithValue(ctx, replicaContextKey{}, true))
+}
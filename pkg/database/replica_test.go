@@ -0,0 +1,76 @@
+package database
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type replicaTestRow struct {
+	ID   uint
+	Name string
+}
+
+func TestReplica(t *testing.T) {
+	open := func(name string) *gorm.DB {
+		db, err := gorm.Open(
+			sqlite.Open(filepath.Join(t.TempDir(), name)), &gorm.Config{})
+		require.NoError(t, err)
+		require.NoError(t, db.AutoMigrate(&replicaTestRow{}))
+		require.NoError(t, db.Create(&replicaTestRow{Name: name}).Error)
+		return db
+	}
+	primary := open("primary.db")
+	replica := open("replica.db")
+
+	name := func(db *gorm.DB) string {
+		var row replicaTestRow
+		require.NoError(t, db.First(&row).Error)
+		return row.Name
+	}
+
+	t.Run("without a registered replica", func(t *testing.T) {
+		assert.Equal(t, "primary.db", name(Replica(primary)))
+	})
+
+	require.NoError(t, RegisterReadReplica(primary, replica))
+
+	t.Run("queries use the primary by default", func(t *testing.T) {
+		assert.Equal(t, "primary.db", name(primary))
+	})
+
+	t.Run("Replica routes queries to the replica", func(t *testing.T) {
+		db := Replica(primary)
+		assert.Equal(t, "replica.db", name(db))
+
+		var count int64
+		require.NoError(t, db.Model(&replicaTestRow{}).Count(&count).Error)
+		assert.EqualValues(t, 1, count)
+
+		var rowName string
+		require.NoError(t, db.Raw("SELECT name FROM replica_test_rows").
+			Row().Scan(&rowName))
+		assert.Equal(t, "replica.db", rowName)
+	})
+
+	t.Run("writes use the primary", func(t *testing.T) {
+		db := Replica(primary)
+		require.NoError(t, db.Create(&replicaTestRow{Name: "new"}).Error)
+
+		var count int64
+		require.NoError(t, primary.Model(&replicaTestRow{}).Count(&count).Error)
+		assert.EqualValues(t, 2, count)
+	})
+
+	t.Run("transactions use the primary", func(t *testing.T) {
+		err := Replica(primary).Transaction(func(tx *gorm.DB) error {
+			assert.Equal(t, "primary.db", name(tx))
+			return nil
+		})
+		require.NoError(t, err)
+	})
+}
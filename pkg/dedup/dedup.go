@@ -0,0 +1,139 @@
+// Package dedup detects near-duplicate documents by comparing MinHash
+// signatures of their content. The Jaccard similarity of the sets of word
+// shingles of two documents is estimated by the fraction of equal values in
+// their signatures, so documents can be compared without their content.
+package dedup
+
+import (
+	"hash/fnv"
+	"strings"
+	"unicode"
+)
+
+const (
+	// SignatureSize is the number of values in a signature. The standard
+	// error of similarity estimates is about 1/sqrt(SignatureSize).
+	SignatureSize = 128
+
+	// DefaultShingleSize is the default number of words in a shingle.
+	DefaultShingleSize = 5
+
+	// DefaultMinShingles is the default minimum number of shingles of content
+	// that is compared. Shorter content, e.g., of documents that only contain
+	// template boilerplate, is too similar to be compared reliably.
+	DefaultMinShingles = 50
+
+	// DefaultThreshold is the default similarity at or above which documents
+	// are likely duplicates.
+	DefaultThreshold = 0.8
+)
+
+// Signature is the MinHash signature of content.
+type Signature []uint32
+
+// Config contains fingerprinting configuration.
+type Config struct {
+	// ShingleSize is the number of words in a shingle (default:
+	// DefaultShingleSize).
+	ShingleSize int
+
+	// MinShingles is the minimum number of shingles of content that is
+	// fingerprinted (default: DefaultMinShingles).
+	MinShingles int
+}
+
+// Fingerprint returns the signature of content and its number of distinct
+// shingles. The signature is nil if content has fewer than the minimum number
+// of shingles.
+func Fingerprint(content string, cfg Config) (Signature, int) {
+	size := cfg.ShingleSize
+	if size <= 0 {
+		size = DefaultShingleSize
+	}
+	minShingles := cfg.MinShingles
+	if minShingles <= 0 {
+		minShingles = DefaultMinShingles
+	}
+
+	shingles := shingleHashes(words(content), size)
+	if len(shingles) < minShingles {
+		return nil, len(shingles)
+	}
+
+	sig := make(Signature, SignatureSize)
+	for i := range sig {
+		sig[i] = ^uint32(0)
+	}
+	for h := range shingles {
+		for i := range sig {
+			if v := uint32(mix(h ^ seeds[i])); v < sig[i] {
+				sig[i] = v
+			}
+		}
+	}
+	return sig, len(shingles)
+}
+
+// Similarity returns the estimated Jaccard similarity of the content of two
+// signatures, from 0 to 1. Signatures of different sizes have no similarity.
+func Similarity(a, b Signature) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+	equal := 0
+	for i := range a {
+		if a[i] == b[i] {
+			equal++
+		}
+	}
+	return float64(equal) / float64(len(a))
+}
+
+// words returns the normalized words of content: runs of letters and digits,
+// lowercased, so formatting and punctuation don't affect similarity.
+func words(content string) []string {
+	return strings.FieldsFunc(strings.ToLower(content), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// shingleHashes returns the set of hashes of the shingles (runs of size words)
+// of words. Content with fewer words than size is a single shingle.
+func shingleHashes(words []string, size int) map[uint64]struct{} {
+	hashes := make(map[uint64]struct{})
+	if len(words) == 0 {
+		return hashes
+	}
+	if len(words) < size {
+		size = len(words)
+	}
+	for i := 0; i+size <= len(words); i++ {
+		h := fnv.New64a()
+		for _, w := range words[i : i+size] {
+			h.Write([]byte(w))
+			h.Write([]byte{0})
+		}
+		hashes[h.Sum64()] = struct{}{}
+	}
+	return hashes
+}
+
+// seeds are the seeds of the hash functions of signature values. They are
+// fixed so signatures stay comparable across processes and releases.
+var seeds = func() [SignatureSize]uint64 {
+	var s [SignatureSize]uint64
+	x := uint64(0x9e3779b97f4a7c15)
+	for i := range s {
+		x = mix(x + uint64(i))
+		s[i] = x
+	}
+	return s
+}()
+
+// mix is the finalizer of SplitMix64, a fast hash of 64-bit values.
+func mix(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
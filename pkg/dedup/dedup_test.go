@@ -0,0 +1,53 @@
+package dedup
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testContent returns n sentences of content that differ by seed.
+func testContent(seed string, n int) string {
+	var b strings.Builder
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&b, "The %s service handles request %d with retries and backoff. ", seed, i)
+	}
+	return b.String()
+}
+
+func TestFingerprint(t *testing.T) {
+	rfc := testContent("billing", 40)
+	sig, n := Fingerprint(rfc, Config{})
+	require.Len(t, sig, SignatureSize)
+	assert.Greater(t, n, DefaultMinShingles)
+
+	// Formatting doesn't affect signatures.
+	formatted, _ := Fingerprint("# "+strings.ToUpper(rfc)+"\n\n---", Config{})
+	assert.Equal(t, 1.0, Similarity(sig, formatted))
+
+	// A copy with a few changes is a likely duplicate.
+	copied := strings.Replace(rfc, "request 3 ", "call 3 ", 1) +
+		"Open questions: none."
+	copiedSig, _ := Fingerprint(copied, Config{})
+	assert.GreaterOrEqual(t, Similarity(sig, copiedSig), DefaultThreshold)
+
+	// Different content isn't.
+	other, _ := Fingerprint(testContent("search", 10)+testContent("auth", 30), Config{})
+	assert.Less(t, Similarity(sig, other), 0.5)
+
+	// Short content isn't fingerprinted.
+	sig, n = Fingerprint("## Summary\n\nTODO", Config{})
+	assert.Nil(t, sig)
+	assert.Equal(t, 1, n)
+	sig, _ = Fingerprint("## Summary\n\nTODO", Config{ShingleSize: 1, MinShingles: 1})
+	assert.Len(t, sig, SignatureSize)
+}
+
+func TestSimilarity(t *testing.T) {
+	assert.Equal(t, 0.5, Similarity(Signature{1, 2, 3, 4}, Signature{1, 2, 0, 0}))
+	assert.Equal(t, 0.0, Similarity(Signature{1, 2}, Signature{1, 2, 3}))
+	assert.Equal(t, 0.0, Similarity(nil, nil))
+}
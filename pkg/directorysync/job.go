@@ -0,0 +1,151 @@
+// Package directorysync caches the workspace people directory in the
+// database.
+package directorysync
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp-forge/hermes/pkg/workspace"
+	"github.com/hashicorp/go-hclog"
+	"gorm.io/gorm"
+)
+
+// Job periodically syncs people from a people provider into the
+// users_directory table.
+type Job struct {
+	db       *gorm.DB
+	provider workspace.PeopleProvider
+	logger   hclog.Logger
+	interval time.Duration
+}
+
+// DefaultInterval is how often the directory is synced by default.
+const DefaultInterval = time.Hour
+
+// Config contains directory sync job configuration.
+type Config struct {
+	// Interval is how often the directory is synced.
+	Interval time.Duration
+}
+
+// NewJob creates a new directory sync job.
+func NewJob(
+	db *gorm.DB,
+	provider workspace.PeopleProvider,
+	logger hclog.Logger,
+	cfg *Config,
+) *Job {
+	if logger == nil {
+		logger = hclog.NewNullLogger()
+	}
+	interval := DefaultInterval
+	if cfg != nil && cfg.Interval > 0 {
+		interval = cfg.Interval
+	}
+
+	return &Job{
+		db:       db,
+		provider: provider,
+		logger:   logger.Named("directory-sync"),
+		interval: interval,
+	}
+}
+
+// Start syncs the directory immediately and then every interval until ctx is
+// canceled.
+func (j *Job) Start(ctx context.Context) error {
+	j.logger.Info("directory sync job started", "interval", j.interval)
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := j.Run(ctx)
+		if err != nil {
+			j.logger.Error("error syncing people directory", "error", err)
+		} else {
+			j.logger.Info("people directory synced", "people", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			j.logger.Info("directory sync job stopped")
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Run syncs the directory once and returns the number of people synced.
+func (j *Job) Run(ctx context.Context) (int, error) {
+	people, err := j.listPeople(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("error listing people: %w", err)
+	}
+
+	// An empty result is far more likely to be a provider problem than an
+	// empty directory, so keep the existing cache rather than deleting it.
+	entries := directoryEntries(people)
+	if len(entries) == 0 {
+		return 0, fmt.Errorf("people provider returned no people")
+	}
+
+	if err := models.SyncUsersDirectory(
+		j.db.WithContext(ctx), entries, time.Now(),
+	); err != nil {
+		return 0, err
+	}
+	return len(entries), nil
+}
+
+// listPeople lists the whole directory, using an empty search for providers
+// that can't list it directly.
+func (j *Job) listPeople(ctx context.Context) ([]*workspace.UserIdentity, error) {
+	var provider any = j.provider
+	if wp, ok := j.provider.(workspace.WorkspaceProvider); ok {
+		provider = workspace.Unwrap(wp)
+	}
+	if lister, ok := provider.(workspace.PeopleDirectoryProvider); ok {
+		return lister.ListPeople(ctx)
+	}
+	return j.provider.SearchPeople(ctx, "")
+}
+
+// directoryEntries converts people to directory entries, skipping people
+// without an email address and duplicates.
+func directoryEntries(people []*workspace.UserIdentity) []models.UserDirectoryEntry {
+	seen := make(map[string]bool)
+	var entries []models.UserDirectoryEntry
+	for _, p := range people {
+		if p == nil {
+			continue
+		}
+		email := strings.ToLower(strings.TrimSpace(p.Email))
+		if email == "" || seen[email] {
+			continue
+		}
+		seen[email] = true
+
+		entries = append(entries, models.UserDirectoryEntry{
+			EmailAddress:  email,
+			DisplayName:   p.DisplayName,
+			PhotoURL:      p.PhotoURL,
+			UnifiedUserID: p.UnifiedUserID,
+		})
+	}
+	return entries
+}
+
+// UserIdentity converts a directory entry to a user identity.
+func UserIdentity(e models.UserDirectoryEntry) *workspace.UserIdentity {
+	return &workspace.UserIdentity{
+		Email:         e.EmailAddress,
+		DisplayName:   e.DisplayName,
+		PhotoURL:      e.PhotoURL,
+		UnifiedUserID: e.UnifiedUserID,
+	}
+}
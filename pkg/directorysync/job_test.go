@@ -0,0 +1,47 @@
+package directorysync
+
+import (
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp-forge/hermes/pkg/workspace"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDirectoryEntries(t *testing.T) {
+	people := []*workspace.UserIdentity{
+		{Email: "Alice@Example.com", DisplayName: "Alice",
+			PhotoURL: "https://example.com/alice.jpg"},
+		nil,
+		{Email: "", DisplayName: "No Email"},
+		{Email: "alice@example.com", DisplayName: "Alice Duplicate"},
+		{Email: "bob@example.com", DisplayName: "Bob", UnifiedUserID: "user-1"},
+	}
+
+	got := directoryEntries(people)
+	assert.Equal(t, []models.UserDirectoryEntry{
+		{
+			EmailAddress: "alice@example.com",
+			DisplayName:  "Alice",
+			PhotoURL:     "https://example.com/alice.jpg",
+		},
+		{
+			EmailAddress:  "bob@example.com",
+			DisplayName:   "Bob",
+			UnifiedUserID: "user-1",
+		},
+	}, got)
+}
+
+func TestUserIdentity(t *testing.T) {
+	got := UserIdentity(models.UserDirectoryEntry{
+		EmailAddress: "alice@example.com",
+		DisplayName:  "Alice",
+		PhotoURL:     "https://example.com/alice.jpg",
+	})
+	assert.Equal(t, &workspace.UserIdentity{
+		Email:       "alice@example.com",
+		DisplayName: "Alice",
+		PhotoURL:    "https://example.com/alice.jpg",
+	}, got)
+}
@@ -0,0 +1,314 @@
+// Package doclint lints document content for common quality issues, so authors
+// can fix them before requesting review. Content is expected to be Markdown;
+// rules based on headings find no headings in plain text content, such as the
+// text of Google Docs.
+package doclint
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp-forge/hermes/pkg/linkcheck"
+)
+
+// Rule identifies a lint rule.
+type Rule string
+
+const (
+	// RuleSummarySection checks that the document has a non-empty summary
+	// section.
+	RuleSummarySection Rule = "summary_section"
+
+	// RuleTODOMarkers checks that the document has no TODO markers left.
+	RuleTODOMarkers Rule = "todo_markers"
+
+	// RuleBrokenInternalLinks checks that links to other Hermes documents point
+	// to documents that exist.
+	RuleBrokenInternalLinks Rule = "broken_internal_links"
+
+	// RuleHeadingStructure checks that headings aren't empty and that heading
+	// levels aren't skipped (e.g., a level 3 heading directly under a level 1
+	// heading).
+	RuleHeadingStructure Rule = "heading_structure"
+)
+
+// Rules are all lint rules, in the order they are run.
+var Rules = []Rule{
+	RuleSummarySection,
+	RuleTODOMarkers,
+	RuleBrokenInternalLinks,
+	RuleHeadingStructure,
+}
+
+var (
+	// DefaultSummaryHeadings are the default headings of summary sections.
+	DefaultSummaryHeadings = []string{"Summary"}
+
+	// DefaultTODOMarkers are the default TODO markers.
+	DefaultTODOMarkers = []string{"TODO", "FIXME", "TBD", "XXX"}
+)
+
+// Config configures the linter.
+type Config struct {
+	// Rules are the rules to run. All rules are run if empty.
+	Rules []Rule
+
+	// SummaryHeadings are the headings (case-insensitive) of summary sections.
+	// A "Summary: ..." line, as in the header of Google Docs templates, is also
+	// a summary section.
+	SummaryHeadings []string
+
+	// TODOMarkers are the words (case-sensitive) that mark unfinished content.
+	TODOMarkers []string
+
+	// BaseURLs are the Hermes base URL and short link base URL, used to
+	// recognize absolute links to Hermes documents.
+	BaseURLs []string
+}
+
+// Finding is an issue found by a rule.
+type Finding struct {
+	// Line is the 1-based line of the issue, or 0 if the issue isn't on a
+	// specific line.
+	Line    int    `json:"line,omitempty"`
+	Message string `json:"message"`
+}
+
+// Result is the result of running a rule, shown as an item of the document's
+// quality checklist.
+type Result struct {
+	Rule     Rule      `json:"rule"`
+	Passed   bool      `json:"passed"`
+	Findings []Finding `json:"findings,omitempty"`
+}
+
+// DocumentExistsFunc reports whether the Hermes document with the workspace
+// provider ID id exists.
+type DocumentExistsFunc func(ctx context.Context, id string) (bool, error)
+
+// Linter lints document content.
+type Linter struct {
+	rules           []Rule
+	summaryHeadings []string
+	todoRE          *regexp.Regexp
+	baseURLs        []string
+	documentExists  DocumentExistsFunc
+}
+
+// New creates a linter. Links to Hermes documents aren't checked if
+// documentExists is nil.
+func New(cfg Config, documentExists DocumentExistsFunc) (*Linter, error) {
+	l := &Linter{
+		rules:           Rules,
+		summaryHeadings: DefaultSummaryHeadings,
+		baseURLs:        cfg.BaseURLs,
+		documentExists:  documentExists,
+	}
+	if len(cfg.Rules) > 0 {
+		for _, r := range cfg.Rules {
+			if !slices.Contains(Rules, r) {
+				return nil, fmt.Errorf("unknown lint rule %q", r)
+			}
+		}
+		l.rules = cfg.Rules
+	}
+	if len(cfg.SummaryHeadings) > 0 {
+		l.summaryHeadings = cfg.SummaryHeadings
+	}
+
+	markers := DefaultTODOMarkers
+	if len(cfg.TODOMarkers) > 0 {
+		markers = cfg.TODOMarkers
+	}
+	quoted := make([]string, len(markers))
+	for i, m := range markers {
+		quoted[i] = regexp.QuoteMeta(m)
+	}
+	l.todoRE = regexp.MustCompile(`\b(` + strings.Join(quoted, "|") + `)\b`)
+
+	return l, nil
+}
+
+// heading is a Markdown ATX heading.
+type heading struct {
+	line  int
+	level int
+	text  string
+}
+
+// atxHeadingRE matches Markdown ATX headings, e.g. "## Background".
+var atxHeadingRE = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?[ \t#]*$`)
+
+// parseHeadings returns the headings of content, skipping fenced code blocks.
+func parseHeadings(lines []string) []heading {
+	var headings []heading
+	inFence := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		if m := atxHeadingRE.FindStringSubmatch(line); m != nil {
+			headings = append(headings, heading{
+				line:  i + 1,
+				level: len(m[1]),
+				text:  strings.TrimSpace(m[2]),
+			})
+		}
+	}
+	return headings
+}
+
+// Lint runs the configured rules over content and returns their results in
+// rule order.
+func (l *Linter) Lint(ctx context.Context, content string) ([]Result, error) {
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	headings := parseHeadings(lines)
+
+	results := make([]Result, 0, len(l.rules))
+	for _, rule := range l.rules {
+		var findings []Finding
+		switch rule {
+		case RuleSummarySection:
+			findings = l.lintSummarySection(lines, headings)
+		case RuleTODOMarkers:
+			findings = l.lintTODOMarkers(lines)
+		case RuleBrokenInternalLinks:
+			var err error
+			findings, err = l.lintInternalLinks(ctx, content)
+			if err != nil {
+				return nil, fmt.Errorf("error checking internal links: %w", err)
+			}
+		case RuleHeadingStructure:
+			findings = lintHeadingStructure(headings)
+		}
+		results = append(results, Result{
+			Rule:     rule,
+			Passed:   len(findings) == 0,
+			Findings: findings,
+		})
+	}
+	return results, nil
+}
+
+// summaryLineRE matches "Summary: ..." lines.
+var summaryLineRE = regexp.MustCompile(`(?i)^\s*(?:\*\*)?summary(?:\*\*)?:(?:\*\*)?\s*(.*)$`)
+
+func (l *Linter) lintSummarySection(lines []string, headings []heading) []Finding {
+	for _, line := range lines {
+		if m := summaryLineRE.FindStringSubmatch(line); m != nil &&
+			strings.TrimSpace(m[1]) != "" {
+			return nil
+		}
+	}
+
+	for i, h := range headings {
+		if !slices.ContainsFunc(l.summaryHeadings, func(s string) bool {
+			return strings.EqualFold(s, h.text)
+		}) {
+			continue
+		}
+
+		// The section ends at the next heading of the same or a higher level,
+		// and has content if any line that isn't a heading isn't blank.
+		end := len(lines)
+		subheadings := map[int]bool{}
+		for _, next := range headings[i+1:] {
+			if next.level <= h.level {
+				end = next.line - 1
+				break
+			}
+			subheadings[next.line] = true
+		}
+		for j := h.line; j < end; j++ {
+			if !subheadings[j+1] && strings.TrimSpace(lines[j]) != "" {
+				return nil
+			}
+		}
+		return []Finding{{
+			Line:    h.line,
+			Message: fmt.Sprintf("The %q section is empty", h.text),
+		}}
+	}
+
+	return []Finding{{
+		Message: fmt.Sprintf("The document has no %s section",
+			strings.Join(l.summaryHeadings, " or ")),
+	}}
+}
+
+func (l *Linter) lintTODOMarkers(lines []string) []Finding {
+	var findings []Finding
+	for i, line := range lines {
+		if m := l.todoRE.FindString(line); m != "" {
+			findings = append(findings, Finding{
+				Line:    i + 1,
+				Message: fmt.Sprintf("%s: %s", m, strings.TrimSpace(line)),
+			})
+		}
+	}
+	return findings
+}
+
+func (l *Linter) lintInternalLinks(ctx context.Context, content string) ([]Finding, error) {
+	if l.documentExists == nil {
+		return nil, nil
+	}
+
+	var findings []Finding
+	for _, link := range linkcheck.ExtractLinks(content, l.baseURLs...) {
+		if link.Type != linkcheck.LinkTypeDocument {
+			continue
+		}
+		ok, err := l.documentExists(ctx, link.Target)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			findings = append(findings, Finding{
+				Line:    lineOf(content, link.URL),
+				Message: fmt.Sprintf("Linked document not found: %s", link.URL),
+			})
+		}
+	}
+	return findings, nil
+}
+
+func lintHeadingStructure(headings []heading) []Finding {
+	var findings []Finding
+	prev := 0
+	for _, h := range headings {
+		if h.text == "" {
+			findings = append(findings, Finding{
+				Line:    h.line,
+				Message: "Heading is empty",
+			})
+		}
+		if prev > 0 && h.level > prev+1 {
+			findings = append(findings, Finding{
+				Line: h.line,
+				Message: fmt.Sprintf(
+					"Heading level %d follows heading level %d", h.level, prev),
+			})
+		}
+		prev = h.level
+	}
+	return findings
+}
+
+// lineOf returns the 1-based line of the first occurrence of s in content, or
+// 0 if content doesn't contain s.
+func lineOf(content, s string) int {
+	i := strings.Index(content, s)
+	if i < 0 {
+		return 0
+	}
+	return strings.Count(content[:i], "\n") + 1
+}
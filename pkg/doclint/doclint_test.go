@@ -0,0 +1,90 @@
+package doclint
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLint(t *testing.T) {
+	exists := func(ctx context.Context, id string) (bool, error) {
+		return id == "exists", nil
+	}
+
+	cases := map[string]struct {
+		content string
+		want    map[Rule][]Finding
+	}{
+		"clean": {
+			content: "# Title\n\n## Summary\n\nWhat and why.\n\n## Background\n\n" +
+				"See [this](/document/exists).\n\n### Details\n\nText.\n",
+			want: map[Rule][]Finding{},
+		},
+		"summary line": {
+			content: "**Summary:** What and why.\n\n# Background\n",
+			want:    map[Rule][]Finding{},
+		},
+		"empty summary": {
+			content: "# Title\n\n## Summary\n\n### Details\n\n## Background\n\nText.\n",
+			want: map[Rule][]Finding{
+				RuleSummarySection: {{Line: 3, Message: `The "Summary" section is empty`}},
+			},
+		},
+		"issues": {
+			content: "# Title\n\nTODO: write this.\n\n### Deep\n\n" +
+				"[old](https://hermes.example.com/document/missing) FIXME\n\n" +
+				"```\n# not a heading\n```\n\n##\n",
+			want: map[Rule][]Finding{
+				RuleSummarySection: {{Message: "The document has no Summary section"}},
+				RuleTODOMarkers: {
+					{Line: 3, Message: "TODO: TODO: write this."},
+					{Line: 7, Message: "FIXME: [old](https://hermes.example.com/document/missing) FIXME"},
+				},
+				RuleBrokenInternalLinks: {{
+					Line:    7,
+					Message: "Linked document not found: https://hermes.example.com/document/missing",
+				}},
+				RuleHeadingStructure: {
+					{Line: 5, Message: "Heading level 3 follows heading level 1"},
+					{Line: 13, Message: "Heading is empty"},
+				},
+			},
+		},
+	}
+
+	l, err := New(Config{BaseURLs: []string{"https://hermes.example.com"}}, exists)
+	require.NoError(t, err)
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			results, err := l.Lint(context.Background(), c.content)
+			require.NoError(t, err)
+			require.Len(t, results, len(Rules))
+			for i, res := range results {
+				assert.Equal(t, Rules[i], res.Rule)
+				assert.Equal(t, c.want[res.Rule], res.Findings, res.Rule)
+				assert.Equal(t, len(c.want[res.Rule]) == 0, res.Passed, res.Rule)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	l, err := New(Config{
+		Rules:       []Rule{RuleTODOMarkers},
+		TODOMarkers: []string{"WIP"},
+	}, nil)
+	require.NoError(t, err)
+
+	results, err := l.Lint(context.Background(), "TODO\nWIP\n")
+	require.NoError(t, err)
+	assert.Equal(t, []Result{{
+		Rule:     RuleTODOMarkers,
+		Findings: []Finding{{Line: 2, Message: "WIP: WIP"}},
+	}}, results)
+
+	_, err = New(Config{Rules: []Rule{"spelling"}}, nil)
+	assert.Error(t, err)
+}
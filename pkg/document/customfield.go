@@ -0,0 +1,386 @@
+package document
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/iancoleman/strcase"
+)
+
+// Custom field types, as used by the API and frontend.
+const (
+	CustomFieldTypeString  = "STRING"
+	CustomFieldTypePerson  = "PERSON"
+	CustomFieldTypePeople  = "PEOPLE"
+	CustomFieldTypeEnum    = "ENUM"
+	CustomFieldTypeDate    = "DATE"
+	CustomFieldTypeNumber  = "NUMBER"
+	CustomFieldTypeBoolean = "BOOLEAN"
+)
+
+// customFieldDateLayout is the layout of date custom field values.
+const customFieldDateLayout = time.DateOnly
+
+// CustomFieldSchema is the schema of a custom field of a document type. It
+// determines the values the custom field accepts, and how they are stored in
+// the database and indexed for search.
+type CustomFieldSchema struct {
+	// Name is the key of the custom field in documents and search objects,
+	// which is the display name in lower camel case.
+	Name string `json:"name"`
+
+	// DisplayName is the display name of the custom field, which identifies it
+	// in the database and configuration.
+	DisplayName string `json:"displayName"`
+
+	// Type is the type of the custom field (e.g., "STRING" or "ENUM").
+	Type string `json:"type"`
+
+	// Options are the allowed values of enum custom fields.
+	Options []string `json:"options,omitempty"`
+
+	// ReadOnly is true if the custom field can only be read.
+	ReadOnly bool `json:"readOnly"`
+}
+
+// NewCustomFieldSchema returns the schema of a document type custom field
+// database model.
+func NewCustomFieldSchema(m models.DocumentTypeCustomField) (CustomFieldSchema, error) {
+	typ := m.Type.String()
+	if typ == "" {
+		return CustomFieldSchema{}, fmt.Errorf(
+			"invalid type for custom field %q: %d", m.Name, m.Type)
+	}
+	opts, err := m.GetOptions()
+	if err != nil {
+		return CustomFieldSchema{}, fmt.Errorf(
+			"invalid options for custom field %q: %w", m.Name, err)
+	}
+	return CustomFieldSchema{
+		Name:        strcase.ToLowerCamel(m.Name),
+		DisplayName: m.Name,
+		Type:        strings.ToUpper(typ),
+		Options:     opts,
+		ReadOnly:    m.ReadOnly,
+	}, nil
+}
+
+// NewCustomFieldSchemaFromConfig returns the schema of a document type custom
+// field in the configuration.
+func NewCustomFieldSchemaFromConfig(
+	c *config.DocumentTypeCustomField) (CustomFieldSchema, error) {
+	t, ok := models.ParseDocumentTypeCustomFieldType(c.Type)
+	if !ok {
+		return CustomFieldSchema{}, fmt.Errorf(
+			"unknown type for custom field %q: %s", c.Name, c.Type)
+	}
+	return CustomFieldSchema{
+		Name:        strcase.ToLowerCamel(c.Name),
+		DisplayName: c.Name,
+		Type:        strings.ToUpper(t.String()),
+		Options:     c.Options,
+		ReadOnly:    c.ReadOnly,
+	}, nil
+}
+
+// editableField returns the custom field as a custom editable field of
+// documents.
+func (s CustomFieldSchema) editableField() CustomDocTypeField {
+	return CustomDocTypeField{
+		DisplayName: s.DisplayName,
+		Type:        s.Type,
+		Options:     s.Options,
+	}
+}
+
+// Validate validates a custom field against the schema, and returns it with
+// its value normalized: a string for string, person, enum, and date fields
+// ("2006-01-02"), a []string for people fields, a float64 for number fields,
+// and a bool for boolean fields. An empty value (nil, "", or an empty list)
+// removes the custom field from a document.
+func (s CustomFieldSchema) Validate(cf CustomField) (CustomField, error) {
+	if cf.DisplayName != s.DisplayName {
+		return cf, fmt.Errorf("invalid display name %q", cf.DisplayName)
+	}
+	if cf.Type != s.Type {
+		return cf, fmt.Errorf("invalid type %q", cf.Type)
+	}
+	v, err := s.normalizeValue(cf.Value)
+	if err != nil {
+		return cf, err
+	}
+	cf.Value = v
+	return cf, nil
+}
+
+// normalizeValue validates and normalizes a custom field value. Empty values
+// are returned as nil.
+func (s CustomFieldSchema) normalizeValue(v any) (any, error) {
+	if IsEmptyCustomFieldValue(v) {
+		return nil, nil
+	}
+
+	switch s.Type {
+	case CustomFieldTypeString, CustomFieldTypePerson:
+		str, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid value type, want string")
+		}
+		return str, nil
+
+	case CustomFieldTypePeople:
+		switch vals := v.(type) {
+		case []string:
+			return vals, nil
+		case []any:
+			people := make([]string, 0, len(vals))
+			for _, val := range vals {
+				str, ok := val.(string)
+				if !ok {
+					return nil, fmt.Errorf("invalid value type, want []string")
+				}
+				people = append(people, str)
+			}
+			return people, nil
+		}
+		return nil, fmt.Errorf("invalid value type, want []string")
+
+	case CustomFieldTypeEnum:
+		str, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid value type, want string")
+		}
+		if !slices.Contains(s.Options, str) {
+			return nil, fmt.Errorf("invalid value %q, want one of %s",
+				str, strings.Join(s.Options, ", "))
+		}
+		return str, nil
+
+	case CustomFieldTypeDate:
+		str, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf(
+				"invalid value type, want date string (YYYY-MM-DD)")
+		}
+		t, err := time.Parse(customFieldDateLayout, str)
+		if err != nil {
+			// Accept timestamps from date pickers, and keep their date.
+			if t, err = time.Parse(time.RFC3339, str); err != nil {
+				return nil, fmt.Errorf("invalid date %q, want YYYY-MM-DD", str)
+			}
+		}
+		return t.Format(customFieldDateLayout), nil
+
+	case CustomFieldTypeNumber:
+		var f float64
+		switch n := v.(type) {
+		case float64:
+			f = n
+		case int:
+			f = float64(n)
+		case int64:
+			f = float64(n)
+		case json.Number:
+			var err error
+			if f, err = n.Float64(); err != nil {
+				return nil, fmt.Errorf("invalid number %q", n)
+			}
+		default:
+			return nil, fmt.Errorf("invalid value type, want number")
+		}
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, fmt.Errorf("invalid number %v", f)
+		}
+		return f, nil
+
+	case CustomFieldTypeBoolean:
+		b, ok := v.(bool)
+		if !ok {
+			return nil, fmt.Errorf("invalid value type, want boolean")
+		}
+		return b, nil
+	}
+
+	return nil, fmt.Errorf("unknown custom field type %q", s.Type)
+}
+
+// IsEmptyCustomFieldValue returns true if v is an empty custom field value,
+// which removes the custom field from a document. False and zero aren't empty.
+func IsEmptyCustomFieldValue(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case []string:
+		return len(v) == 0
+	case []any:
+		return len(v) == 0
+	}
+	return false
+}
+
+// DatabaseValue returns the value of a custom field, validated with
+// CustomFieldSchema.Validate, as stored in the database. People are stored as
+// a JSON array.
+func (cf CustomField) DatabaseValue() (string, error) {
+	if IsEmptyCustomFieldValue(cf.Value) {
+		return "", nil
+	}
+
+	switch v := cf.Value.(type) {
+	case string:
+		return v, nil
+	case []string:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return "", fmt.Errorf(
+				"error marshaling custom field value to JSON: %w", err)
+		}
+		return string(b), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	}
+	return "", fmt.Errorf("invalid value type %T for custom field %q",
+		cf.Value, cf.Name)
+}
+
+// ParseDatabaseValue parses a custom field value stored in the database.
+func (s CustomFieldSchema) ParseDatabaseValue(v string) (any, error) {
+	switch s.Type {
+	case CustomFieldTypePeople:
+		var people []string
+		if err := json.Unmarshal([]byte(v), &people); err != nil {
+			return nil, fmt.Errorf("error unmarshaling value for field %q: %w",
+				s.DisplayName, err)
+		}
+		return people, nil
+	case CustomFieldTypeNumber:
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing value for field %q: %w",
+				s.DisplayName, err)
+		}
+		return f, nil
+	case CustomFieldTypeBoolean:
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing value for field %q: %w",
+				s.DisplayName, err)
+		}
+		return b, nil
+	}
+	return v, nil
+}
+
+// SearchValue returns the value of a custom field, validated with
+// CustomFieldSchema.Validate, as indexed for search. Dates are indexed as Unix
+// timestamps so they can be filtered by range and sorted; other values are
+// indexed as is.
+func (cf CustomField) SearchValue() any {
+	if cf.Type == CustomFieldTypeDate {
+		if str, ok := cf.Value.(string); ok {
+			if t, err := time.Parse(customFieldDateLayout, str); err == nil {
+				return t.Unix()
+			}
+		}
+	}
+	return cf.Value
+}
+
+// ParseSearchValue parses a custom field value indexed for search.
+func (s CustomFieldSchema) ParseSearchValue(v any) (any, error) {
+	if s.Type == CustomFieldTypeDate {
+		switch ts := v.(type) {
+		case float64:
+			return time.Unix(int64(ts), 0).UTC().Format(customFieldDateLayout), nil
+		case int64:
+			return time.Unix(ts, 0).UTC().Format(customFieldDateLayout), nil
+		}
+	}
+	return s.normalizeValue(v)
+}
+
+// CustomFieldRegistry holds the custom field schemas of document types, by
+// document type name.
+type CustomFieldRegistry map[string][]CustomFieldSchema
+
+// NewCustomFieldRegistry returns the registry of the custom fields of document
+// types with their custom fields loaded (e.g., with DocumentType.Get).
+func NewCustomFieldRegistry(docTypes []models.DocumentType) (CustomFieldRegistry, error) {
+	r := CustomFieldRegistry{}
+	for _, dt := range docTypes {
+		schemas := []CustomFieldSchema{}
+		for _, cf := range dt.CustomFields {
+			s, err := NewCustomFieldSchema(cf)
+			if err != nil {
+				return nil, fmt.Errorf("document type %q: %w", dt.Name, err)
+			}
+			schemas = append(schemas, s)
+		}
+		r[dt.Name] = schemas
+	}
+	return r, nil
+}
+
+// Lookup returns the schema of the custom field with name (the key of the
+// custom field in documents) of a document type.
+func (r CustomFieldRegistry) Lookup(docType, name string) (CustomFieldSchema, bool) {
+	for _, s := range r[docType] {
+		if s.Name == name {
+			return s, true
+		}
+	}
+	return CustomFieldSchema{}, false
+}
+
+// ValidateCustomFields validates custom fields against the custom editable
+// fields of the document, and returns them with their values normalized.
+func (d *Document) ValidateCustomFields(cfs []CustomField) ([]CustomField, error) {
+	validated := make([]CustomField, 0, len(cfs))
+	for _, cf := range cfs {
+		cef, ok := d.CustomEditableFields[cf.Name]
+		if !ok {
+			return nil, fmt.Errorf("unknown custom field %q", cf.Name)
+		}
+		s := CustomFieldSchema{
+			Name:        cf.Name,
+			DisplayName: cef.DisplayName,
+			Type:        cef.Type,
+			Options:     cef.Options,
+		}
+		v, err := s.Validate(cf)
+		if err != nil {
+			return nil, fmt.Errorf("custom field %q: %w", cf.Name, err)
+		}
+		validated = append(validated, v)
+	}
+	return validated, nil
+}
+
+// UpsertCustomFieldModels upserts custom fields, validated with
+// ValidateCustomFields, of a document of document type docType into the
+// document's database custom fields, and returns the result. Custom fields with
+// empty values are removed.
+func UpsertCustomFieldModels(
+	dbCFs []*models.DocumentCustomField, docType string, cfs []CustomField,
+) ([]*models.DocumentCustomField, error) {
+	for _, cf := range cfs {
+		v, err := cf.DatabaseValue()
+		if err != nil {
+			return nil, err
+		}
+		dbCFs = models.UpsertStringDocumentCustomField(
+			dbCFs, docType, cf.DisplayName, v)
+	}
+	return dbCFs, nil
+}
@@ -0,0 +1,297 @@
+package document
+
+import (
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomFieldSchemaValidate(t *testing.T) {
+	cases := map[string]struct {
+		schema  CustomFieldSchema
+		value   any
+		want    any
+		wantErr bool
+	}{
+		"string": {
+			schema: CustomFieldSchema{Type: CustomFieldTypeString},
+			value:  "value",
+			want:   "value",
+		},
+		"string with wrong type": {
+			schema:  CustomFieldSchema{Type: CustomFieldTypeString},
+			value:   1.0,
+			wantErr: true,
+		},
+		"empty string": {
+			schema: CustomFieldSchema{Type: CustomFieldTypeString},
+			value:  "",
+			want:   nil,
+		},
+		"people": {
+			schema: CustomFieldSchema{Type: CustomFieldTypePeople},
+			value:  []any{"a@example.com", "b@example.com"},
+			want:   []string{"a@example.com", "b@example.com"},
+		},
+		"people with wrong element type": {
+			schema:  CustomFieldSchema{Type: CustomFieldTypePeople},
+			value:   []any{"a@example.com", 1.0},
+			wantErr: true,
+		},
+		"empty people": {
+			schema: CustomFieldSchema{Type: CustomFieldTypePeople},
+			value:  []any{},
+			want:   nil,
+		},
+		"enum": {
+			schema: CustomFieldSchema{
+				Type: CustomFieldTypeEnum, Options: []string{"Low", "High"}},
+			value: "High",
+			want:  "High",
+		},
+		"enum with unknown option": {
+			schema: CustomFieldSchema{
+				Type: CustomFieldTypeEnum, Options: []string{"Low", "High"}},
+			value:   "Medium",
+			wantErr: true,
+		},
+		"date": {
+			schema: CustomFieldSchema{Type: CustomFieldTypeDate},
+			value:  "2024-03-01",
+			want:   "2024-03-01",
+		},
+		"date from timestamp": {
+			schema: CustomFieldSchema{Type: CustomFieldTypeDate},
+			value:  "2024-03-01T10:00:00Z",
+			want:   "2024-03-01",
+		},
+		"invalid date": {
+			schema:  CustomFieldSchema{Type: CustomFieldTypeDate},
+			value:   "March 1st",
+			wantErr: true,
+		},
+		"number": {
+			schema: CustomFieldSchema{Type: CustomFieldTypeNumber},
+			value:  2.5,
+			want:   2.5,
+		},
+		"zero": {
+			schema: CustomFieldSchema{Type: CustomFieldTypeNumber},
+			value:  0.0,
+			want:   0.0,
+		},
+		"number with wrong type": {
+			schema:  CustomFieldSchema{Type: CustomFieldTypeNumber},
+			value:   "2.5",
+			wantErr: true,
+		},
+		"boolean": {
+			schema: CustomFieldSchema{Type: CustomFieldTypeBoolean},
+			value:  false,
+			want:   false,
+		},
+		"boolean with wrong type": {
+			schema:  CustomFieldSchema{Type: CustomFieldTypeBoolean},
+			value:   "true",
+			wantErr: true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			c.schema.Name = "field"
+			c.schema.DisplayName = "Field"
+			cf, err := c.schema.Validate(CustomField{
+				Name:        "field",
+				DisplayName: "Field",
+				Type:        c.schema.Type,
+				Value:       c.value,
+			})
+			if c.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, c.want, cf.Value)
+		})
+	}
+
+	t.Run("wrong type", func(t *testing.T) {
+		s := CustomFieldSchema{
+			Name: "field", DisplayName: "Field", Type: CustomFieldTypeString}
+		_, err := s.Validate(CustomField{
+			Name: "field", DisplayName: "Field", Type: CustomFieldTypePeople,
+			Value: []any{"a@example.com"},
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestCustomFieldValues(t *testing.T) {
+	cases := map[string]struct {
+		schema   CustomFieldSchema
+		value    any
+		database string
+		search   any
+	}{
+		"string": {
+			schema:   CustomFieldSchema{Type: CustomFieldTypeString},
+			value:    "value",
+			database: "value",
+			search:   "value",
+		},
+		"people": {
+			schema:   CustomFieldSchema{Type: CustomFieldTypePeople},
+			value:    []string{"a@example.com"},
+			database: `["a@example.com"]`,
+			search:   []string{"a@example.com"},
+		},
+		"date": {
+			schema:   CustomFieldSchema{Type: CustomFieldTypeDate},
+			value:    "2024-03-01",
+			database: "2024-03-01",
+			search:   int64(1709251200),
+		},
+		"number": {
+			schema:   CustomFieldSchema{Type: CustomFieldTypeNumber},
+			value:    2.5,
+			database: "2.5",
+			search:   2.5,
+		},
+		"boolean": {
+			schema:   CustomFieldSchema{Type: CustomFieldTypeBoolean},
+			value:    true,
+			database: "true",
+			search:   true,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			cf := CustomField{Type: c.schema.Type, Value: c.value}
+
+			dbVal, err := cf.DatabaseValue()
+			require.NoError(t, err)
+			assert.Equal(t, c.database, dbVal)
+			parsed, err := c.schema.ParseDatabaseValue(dbVal)
+			require.NoError(t, err)
+			assert.Equal(t, c.value, parsed)
+
+			searchVal := cf.SearchValue()
+			assert.Equal(t, c.search, searchVal)
+			parsed, err = c.schema.ParseSearchValue(searchVal)
+			require.NoError(t, err)
+			assert.Equal(t, c.value, parsed)
+		})
+	}
+
+	t.Run("date indexed as JSON number", func(t *testing.T) {
+		s := CustomFieldSchema{Type: CustomFieldTypeDate}
+		v, err := s.ParseSearchValue(float64(1709251200))
+		require.NoError(t, err)
+		assert.Equal(t, "2024-03-01", v)
+	})
+}
+
+func TestCustomFieldRegistry(t *testing.T) {
+	enum := models.DocumentTypeCustomField{
+		Name: "Priority Level",
+		Type: models.EnumDocumentTypeCustomFieldType,
+	}
+	require.NoError(t, enum.SetOptions([]string{"Low", "High"}))
+
+	r, err := NewCustomFieldRegistry([]models.DocumentType{
+		{
+			Name: "RFC",
+			CustomFields: []models.DocumentTypeCustomField{
+				enum,
+				{Name: "Due Date", Type: models.DateDocumentTypeCustomFieldType},
+			},
+		},
+		{Name: "PRD"},
+	})
+	require.NoError(t, err)
+
+	s, ok := r.Lookup("RFC", "priorityLevel")
+	require.True(t, ok)
+	assert.Equal(t, CustomFieldSchema{
+		Name:        "priorityLevel",
+		DisplayName: "Priority Level",
+		Type:        CustomFieldTypeEnum,
+		Options:     []string{"Low", "High"},
+	}, s)
+
+	s, ok = r.Lookup("RFC", "dueDate")
+	require.True(t, ok)
+	assert.Equal(t, CustomFieldTypeDate, s.Type)
+
+	_, ok = r.Lookup("PRD", "dueDate")
+	assert.False(t, ok)
+
+	_, err = NewCustomFieldRegistry([]models.DocumentType{{
+		Name:         "RFC",
+		CustomFields: []models.DocumentTypeCustomField{{Name: "Unknown"}},
+	}})
+	assert.Error(t, err)
+}
+
+func TestDocumentValidateCustomFields(t *testing.T) {
+	doc := &Document{
+		CustomEditableFields: map[string]CustomDocTypeField{
+			"priority": {
+				DisplayName: "Priority",
+				Type:        CustomFieldTypeEnum,
+				Options:     []string{"Low", "High"},
+			},
+			"stakeholders": {
+				DisplayName: "Stakeholders",
+				Type:        CustomFieldTypePeople,
+			},
+		},
+		CustomFields: []CustomField{{
+			Name:        "stakeholders",
+			DisplayName: "Stakeholders",
+			Type:        CustomFieldTypePeople,
+			Value:       []string{"a@example.com"},
+		}},
+	}
+
+	cfs, err := doc.ValidateCustomFields([]CustomField{
+		{
+			Name: "priority", DisplayName: "Priority",
+			Type: CustomFieldTypeEnum, Value: "High",
+		},
+		{
+			Name: "stakeholders", DisplayName: "Stakeholders",
+			Type: CustomFieldTypePeople, Value: []any{},
+		},
+	})
+	require.NoError(t, err)
+	for _, cf := range cfs {
+		require.NoError(t, doc.UpsertCustomField(cf))
+	}
+	assert.Equal(t, []CustomField{{
+		Name: "priority", DisplayName: "Priority",
+		Type: CustomFieldTypeEnum, Value: "High",
+	}}, doc.CustomFields)
+
+	dbCFs, err := UpsertCustomFieldModels(nil, "RFC", cfs)
+	require.NoError(t, err)
+	require.Len(t, dbCFs, 1)
+	assert.Equal(t, "Priority", dbCFs[0].DocumentTypeCustomField.Name)
+	assert.Equal(t, "High", dbCFs[0].Value)
+
+	_, err = doc.ValidateCustomFields([]CustomField{{
+		Name: "priority", DisplayName: "Priority",
+		Type: CustomFieldTypeEnum, Value: "Medium",
+	}})
+	assert.Error(t, err)
+
+	_, err = doc.ValidateCustomFields([]CustomField{{
+		Name: "unknown", DisplayName: "Unknown",
+		Type: CustomFieldTypeString, Value: "value",
+	}})
+	assert.Error(t, err)
+}
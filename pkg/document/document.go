@@ -12,7 +12,6 @@ import (
 	"github.com/hashicorp-forge/hermes/internal/config"
 	"github.com/hashicorp-forge/hermes/internal/helpers"
 	"github.com/hashicorp-forge/hermes/pkg/models"
-	"github.com/iancoleman/strcase"
 	"github.com/mitchellh/mapstructure"
 )
 
@@ -113,6 +112,10 @@ type Document struct {
 	// interests.
 	Tags []string `json:"tags,omitempty"`
 
+	// TenantID is the ID of the tenant the document belongs to. It's zero for
+	// the default tenant, and isn't serialized.
+	TenantID uint `json:"-"`
+
 	// ThumbnailLink is a URL string for the document thumbnail image.
 	ThumbnailLink string `json:"thumbnailLink,omitempty"`
 }
@@ -123,8 +126,12 @@ type CustomDocTypeField struct {
 
 	// Type is the type of the custom document-type field. It is used by the
 	// frontend to display the proper input component.
-	// Valid values: "PEOPLE", "STRING".
+	// Valid values: "BOOLEAN", "DATE", "ENUM", "NUMBER", "PEOPLE", "PERSON",
+	// "STRING".
 	Type string `json:"type"`
+
+	// Options are the allowed values of "ENUM" fields.
+	Options []string `json:"options,omitempty"`
 }
 
 type CustomField struct {
@@ -137,8 +144,7 @@ type CustomField struct {
 	DisplayName string `json:"displayName"`
 
 	// Type is the type of the custom field. It is used by the frontend to display
-	// the proper input component.
-	// Valid values: "PEOPLE", "STRING".
+	// the proper input component. See CustomDocTypeField.Type.
 	Type string `json:"type"`
 
 	// Value is the value of the custom field.
@@ -169,59 +175,27 @@ func NewFromAlgoliaObject(
 		for _, dt := range docTypes {
 			if dt.Name == objDocType {
 				foundDocType = true
-				for _, cf := range dt.CustomFields {
-					ccName := strcase.ToLowerCamel(cf.Name)
-					switch cf.Type {
-					case "string":
-						if v, ok := in[ccName]; ok {
-							if v, ok := v.(string); ok {
-								cfs = append(cfs, CustomField{
-									Name:        ccName,
-									DisplayName: cf.Name,
-									Type:        "STRING",
-									Value:       v,
-								})
-							} else {
-								return nil, fmt.Errorf(
-									"wrong type for custom field key %q, want string", ccName)
-							}
-						}
-						cefs[ccName] = CustomDocTypeField{
-							DisplayName: cf.Name,
-							Type:        "STRING",
-						}
-					case "people":
-						cfVal := []string{}
-						if v, ok := in[ccName]; ok {
-							if reflect.TypeOf(v).Kind() == reflect.Slice {
-								for _, vv := range v.([]any) {
-									if vv, ok := vv.(string); ok {
-										cfVal = append(cfVal, vv)
-									} else {
-										return nil, fmt.Errorf(
-											"wrong type for custom field key %q, want []string",
-											ccName)
-									}
-								}
-								cfs = append(cfs, CustomField{
-									Name:        ccName,
-									DisplayName: cf.Name,
-									Type:        "PEOPLE",
-									Value:       cfVal,
-								})
-							} else {
-								return nil, fmt.Errorf(
-									"wrong type for custom field key %q, want []string", dt.Name)
-							}
+				for _, c := range dt.CustomFields {
+					cs, err := NewCustomFieldSchemaFromConfig(c)
+					if err != nil {
+						return nil, err
+					}
+					if v, ok := in[cs.Name]; ok {
+						val, err := cs.ParseSearchValue(v)
+						if err != nil {
+							return nil, fmt.Errorf(
+								"wrong type for custom field key %q: %w", cs.Name, err)
 						}
-						cefs[ccName] = CustomDocTypeField{
-							DisplayName: cf.Name,
-							Type:        "PEOPLE",
+						if !IsEmptyCustomFieldValue(val) {
+							cfs = append(cfs, CustomField{
+								Name:        cs.Name,
+								DisplayName: cs.DisplayName,
+								Type:        cs.Type,
+								Value:       val,
+							})
 						}
-					default:
-						return nil, fmt.Errorf(
-							"unknown type for custom field key %q: %s", dt.Name, cf.Type)
 					}
+					cefs[cs.Name] = cs.editableField()
 				}
 				break
 			}
@@ -302,46 +276,31 @@ func NewFromDatabaseModel(
 	// CustomEditableFields.
 	customEditableFields := make(map[string]CustomDocTypeField)
 	for _, c := range model.DocumentType.CustomFields {
-		var cType string
-		switch c.Type {
-		case models.PeopleDocumentTypeCustomFieldType:
-			cType = "PEOPLE"
-		case models.PersonDocumentTypeCustomFieldType:
-			cType = "PERSON"
-		case models.StringDocumentTypeCustomFieldType:
-			cType = "STRING"
-		}
-		customEditableFields[strcase.ToLowerCamel(c.Name)] = CustomDocTypeField{
-			DisplayName: c.Name,
-			Type:        cType,
+		cs, err := NewCustomFieldSchema(c)
+		if err != nil {
+			return nil, err
 		}
+		customEditableFields[cs.Name] = cs.editableField()
 	}
 	doc.CustomEditableFields = customEditableFields
 
 	// CustomFields.
 	var customFields []CustomField
 	for _, c := range model.CustomFields {
-		cf := CustomField{
-			Name:        strcase.ToLowerCamel(c.DocumentTypeCustomField.Name),
-			DisplayName: c.DocumentTypeCustomField.Name,
+		cs, err := NewCustomFieldSchema(c.DocumentTypeCustomField)
+		if err != nil {
+			return nil, err
 		}
-		switch c.DocumentTypeCustomField.Type {
-		case models.PeopleDocumentTypeCustomFieldType:
-			cf.Type = "PEOPLE"
-			var val []string
-			if err := json.Unmarshal([]byte(c.Value), &val); err != nil {
-				return nil, fmt.Errorf("error unmarshaling value for field %q: %w",
-					c.DocumentTypeCustomField.Name, err)
-			}
-			cf.Value = val
-		case models.PersonDocumentTypeCustomFieldType:
-			cf.Type = "PERSON"
-			cf.Value = c.Value
-		case models.StringDocumentTypeCustomFieldType:
-			cf.Type = "STRING"
-			cf.Value = c.Value
+		val, err := cs.ParseDatabaseValue(c.Value)
+		if err != nil {
+			return nil, err
 		}
-		customFields = append(customFields, cf)
+		customFields = append(customFields, CustomField{
+			Name:        cs.Name,
+			DisplayName: cs.DisplayName,
+			Type:        cs.Type,
+			Value:       val,
+		})
 	}
 	doc.CustomFields = customFields
 
@@ -389,6 +348,9 @@ func NewFromDatabaseModel(
 	}
 	doc.Status = status
 
+	// TenantID.
+	doc.TenantID = model.TenantID
+
 	// Note: ThumbnailLink is not stored in the database.
 
 	return doc, nil
@@ -419,7 +381,7 @@ func (d Document) ToAlgoliaObject(
 
 	// Set custom fields.
 	for _, cf := range cfs {
-		obj[cf.Name] = cf.Value
+		obj[cf.Name] = cf.SearchValue()
 	}
 
 	return obj, nil
@@ -485,39 +447,22 @@ func (d Document) ToDatabaseModels(
 	// CustomFields.
 	customFields := []*models.DocumentCustomField{}
 	for _, cf := range d.CustomFields {
-		switch cf.Type {
-		case "STRING":
-			if v, ok := cf.Value.(string); ok {
-				customFields = append(customFields, &models.DocumentCustomField{
-					DocumentTypeCustomField: models.DocumentTypeCustomField{
-						Name: cf.DisplayName,
-						DocumentType: models.DocumentType{
-							Name: doc.DocumentType.Name,
-						},
-					},
-					Value: v,
-				})
-			}
-		case "PEOPLE":
-			if reflect.TypeOf(cf.Value).Kind() == reflect.Slice {
-				if v, ok := cf.Value.([]string); ok {
-					cfValJSON, err := json.Marshal(v)
-					if err != nil {
-						return doc, reviews, fmt.Errorf(
-							"error marshaling custom field value to JSON: %w", err)
-					}
-					customFields = append(customFields, &models.DocumentCustomField{
-						DocumentTypeCustomField: models.DocumentTypeCustomField{
-							Name: cf.DisplayName,
-							DocumentType: models.DocumentType{
-								Name: doc.DocumentType.Name,
-							},
-						},
-						Value: string(cfValJSON),
-					})
-				}
-			}
+		v, err := cf.DatabaseValue()
+		if err != nil {
+			return doc, reviews, err
+		}
+		if v == "" {
+			continue
 		}
+		customFields = append(customFields, &models.DocumentCustomField{
+			DocumentTypeCustomField: models.DocumentTypeCustomField{
+				Name: cf.DisplayName,
+				DocumentType: models.DocumentType{
+					Name: doc.DocumentType.Name,
+				},
+			},
+			Value: v,
+		})
 	}
 	doc.CustomFields = customFields
 
@@ -631,6 +576,9 @@ func (d Document) ToDatabaseModels(
 	return doc, reviews, nil
 }
 
+// UpsertCustomField upserts a custom field, validated with
+// ValidateCustomFields, into the document. Custom fields with empty values are
+// removed.
 func (d *Document) UpsertCustomField(cf CustomField) error {
 	// Build new document CustomFields.
 	var newCFs []CustomField
@@ -641,32 +589,13 @@ func (d *Document) UpsertCustomField(cf CustomField) error {
 			if cf.DisplayName != docCF.DisplayName {
 				return fmt.Errorf("incorrect display name for custom field")
 			}
-			switch cf.Type {
-			case "PEOPLE":
-				if reflect.TypeOf(cf.Value).Kind() != reflect.Slice {
-					return fmt.Errorf("incorrect value type for custom field")
-				}
-				for _, v := range cf.Value.([]any) {
-					// Make sure slice is a string slice.
-					if _, ok := v.(string); !ok {
-						return fmt.Errorf("incorrect value type for custom field")
-					}
-				}
-				// If the value is empty, remove it from the document (by not appending
-				// here).
-				if len(cf.Value.([]any)) > 0 {
-					newCFs = append(newCFs, cf)
-				}
-			case "STRING":
-				v, ok := cf.Value.(string)
-				if !ok {
-					return fmt.Errorf("incorrect value type for custom field")
-				}
-				// If the value is empty, remove it from the document (by not appending
-				// here).
-				if v != "" {
-					newCFs = append(newCFs, cf)
-				}
+			if cf.Type != docCF.Type {
+				return fmt.Errorf("incorrect type for custom field")
+			}
+			// If the value is empty, remove it from the document (by not appending
+			// here).
+			if !IsEmptyCustomFieldValue(cf.Value) {
+				newCFs = append(newCFs, cf)
 			}
 			foundCF = true
 		} else {
@@ -675,7 +604,7 @@ func (d *Document) UpsertCustomField(cf CustomField) error {
 	}
 
 	// If we didn't find the custom field, insert it.
-	if !foundCF {
+	if !foundCF && !IsEmptyCustomFieldValue(cf.Value) {
 		newCFs = append(newCFs, cf)
 	}
 
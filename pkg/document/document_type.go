@@ -0,0 +1,173 @@
+package document
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+)
+
+// NewDocumentTypeModel returns the database model and the templates of a
+// document type defined like in the configuration.
+func NewDocumentTypeModel(
+	d *config.DocumentType,
+) (models.DocumentType, models.DocumentTypeTemplates, error) {
+	checksJSON, err := json.Marshal(d.Checks)
+	if err != nil {
+		return models.DocumentType{}, nil,
+			fmt.Errorf("error marshaling checks to JSON: %w", err)
+	}
+
+	var cfs []models.DocumentTypeCustomField
+	for _, c := range d.CustomFields {
+		cf := models.DocumentTypeCustomField{
+			Name:     c.Name,
+			ReadOnly: c.ReadOnly,
+		}
+		if c.Type == "" {
+			return models.DocumentType{}, nil,
+				fmt.Errorf("missing document type custom field")
+		}
+		t, ok := models.ParseDocumentTypeCustomFieldType(c.Type)
+		if !ok {
+			return models.DocumentType{}, nil,
+				fmt.Errorf("invalid document type custom field: %s", c.Type)
+		}
+		cf.Type = t
+		if err := cf.SetOptions(c.Options); err != nil {
+			return models.DocumentType{}, nil,
+				fmt.Errorf("error setting custom field options: %w", err)
+		}
+		cfs = append(cfs, cf)
+	}
+
+	dt := models.DocumentType{
+		Name:         d.Name,
+		LongName:     d.LongName,
+		Description:  d.Description,
+		FlightIcon:   d.FlightIcon,
+		Checks:       checksJSON,
+		CustomFields: cfs,
+		Workflow: models.DocumentTypeWorkflow{
+			ReviewSLA:          d.ReviewSLA,
+			RequiredApprovals:  d.RequiredApprovals,
+			HeaderCustomFields: d.HeaderCustomFields,
+		},
+	}
+	for _, item := range d.ReviewChecklist {
+		dt.Workflow.ReviewChecklist = append(dt.Workflow.ReviewChecklist,
+			models.DocumentTypeChecklistItem{
+				Name:        item.Name,
+				Label:       item.Label,
+				HelperText:  item.HelperText,
+				RequiredFor: item.RequiredFor,
+			})
+	}
+	if d.MoreInfoLink != nil {
+		dt.MoreInfoLinkText = d.MoreInfoLink.Text
+		dt.MoreInfoLinkURL = d.MoreInfoLink.URL
+	}
+
+	var templates models.DocumentTypeTemplates
+	for _, t := range d.Templates() {
+		if t.Template == "" {
+			continue
+		}
+		templates = append(templates, models.DocumentTypeTemplate{
+			Name:        t.Name,
+			LongName:    t.LongName,
+			Description: t.Description,
+			TemplateID:  t.Template,
+			Default:     t.Name == config.DefaultTemplateVariant,
+		})
+	}
+
+	return dt, templates, nil
+}
+
+// NewDocumentTypeFromModel returns the definition of a document type like in
+// the configuration from its database model and templates.
+func NewDocumentTypeFromModel(
+	dt models.DocumentType, templates models.DocumentTypeTemplates,
+) (*config.DocumentType, error) {
+	d := &config.DocumentType{
+		Name:               dt.Name,
+		LongName:           dt.LongName,
+		Description:        dt.Description,
+		FlightIcon:         dt.FlightIcon,
+		ReviewSLA:          dt.Workflow.ReviewSLA,
+		RequiredApprovals:  dt.Workflow.RequiredApprovals,
+		HeaderCustomFields: dt.Workflow.HeaderCustomFields,
+	}
+	if dt.MoreInfoLinkText != "" || dt.MoreInfoLinkURL != "" {
+		d.MoreInfoLink = &config.DocumentTypeLink{
+			Text: dt.MoreInfoLinkText,
+			URL:  dt.MoreInfoLinkURL,
+		}
+	}
+	if len(dt.Checks) > 0 {
+		if err := json.Unmarshal(dt.Checks, &d.Checks); err != nil {
+			return nil, fmt.Errorf("error unmarshaling checks: %w", err)
+		}
+	}
+
+	for _, cf := range dt.CustomFields {
+		opts, err := cf.GetOptions()
+		if err != nil {
+			return nil, fmt.Errorf(
+				"invalid options for custom field %q: %w", cf.Name, err)
+		}
+		d.CustomFields = append(d.CustomFields, &config.DocumentTypeCustomField{
+			Name:     cf.Name,
+			ReadOnly: cf.ReadOnly,
+			Type:     cf.Type.String(),
+			Options:  opts,
+		})
+	}
+
+	for _, item := range dt.Workflow.ReviewChecklist {
+		d.ReviewChecklist = append(d.ReviewChecklist,
+			&config.DocumentTypeChecklistItem{
+				Name:        item.Name,
+				Label:       item.Label,
+				HelperText:  item.HelperText,
+				RequiredFor: item.RequiredFor,
+			})
+	}
+
+	for _, t := range templates {
+		if t.Default {
+			d.Template = t.TemplateID
+			continue
+		}
+		d.TemplateVariants = append(d.TemplateVariants,
+			&config.DocumentTypeTemplateVariant{
+				Name:        t.Name,
+				LongName:    t.LongName,
+				Description: t.Description,
+				Template:    t.TemplateID,
+			})
+	}
+
+	return d, nil
+}
+
+// HeaderCustomFields returns the custom fields of the document shown in its
+// header: the custom fields named names, in order, or all custom fields if
+// names is empty.
+func (doc *Document) HeaderCustomFields(names []string) []CustomField {
+	if len(names) == 0 {
+		return doc.CustomFields
+	}
+	var cfs []CustomField
+	for _, name := range names {
+		for _, cf := range doc.CustomFields {
+			if cf.DisplayName == name {
+				cfs = append(cfs, cf)
+				break
+			}
+		}
+	}
+	return cfs
+}
@@ -0,0 +1,248 @@
+package document
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp-forge/hermes/pkg/workspace"
+	"gopkg.in/yaml.v3"
+)
+
+// markdownHeaderEnd marks the end of the header rendered by MarkdownHeader, so
+// it can be replaced without touching the rest of the document.
+const markdownHeaderEnd = "<!-- hermes:header:end -->"
+
+// ErrUnsupportedContentFormat is returned by ReplaceMarkdownHeader for
+// documents whose content isn't Markdown.
+var ErrUnsupportedContentFormat = errors.New("unsupported content format")
+
+// markdownFrontmatter is the frontmatter of the header rendered by
+// MarkdownHeader.
+type markdownFrontmatter struct {
+	Title        string   `yaml:"title"`
+	DocNumber    string   `yaml:"docNumber,omitempty"`
+	DocType      string   `yaml:"docType,omitempty"`
+	Product      string   `yaml:"product,omitempty"`
+	Status       string   `yaml:"status,omitempty"`
+	Owners       []string `yaml:"owners,omitempty"`
+	Contributors []string `yaml:"contributors,omitempty"`
+	Approvers    []string `yaml:"approvers,omitempty"`
+	ApprovedBy   []string `yaml:"approvedBy,omitempty"`
+	Created      string   `yaml:"created,omitempty"`
+	HermesURL    string   `yaml:"hermesURL"`
+}
+
+// MarkdownHeader renders the document header for documents whose content is
+// Markdown: YAML frontmatter with the document metadata, followed by a summary
+// table like the header table ReplaceHeader writes to Google Docs.
+//
+// The result looks like this:
+//
+//	---
+//	title: {{title}}
+//	docNumber: {{doc_number}}
+//	...
+//	hermesURL: {{base_url}}/document/{{id}}
+//	---
+//
+//	| | |
+//	| --- | --- |
+//	| **Summary** | {{summary}} |
+//	| **Created** | {{created}} |
+//	| **Status** | {{status}} |
+//	| **Product** | {{product}} |
+//	| **Owner** | {{owner}} |
+//	| **Contributors** | {{contributors}} |
+//	| **Approvers** | {{approvers}} |
+//	| **{{custom_field}}** | {{custom_field_value}} |
+//
+//	> NOTE: This document is managed by Hermes...
+//	<!-- hermes:header:end -->
+func (doc *Document) MarkdownHeader(baseURL string, isDraft bool) (string, error) {
+	docURL, err := url.Parse(baseURL)
+	if err != nil {
+		return "", fmt.Errorf("error parsing base URL: %w", err)
+	}
+	docURL.Path = path.Join(docURL.Path, "document", doc.ObjectID)
+	docURLString := strings.TrimRight(docURL.String(), "/")
+	if isDraft {
+		docURLString += "?draft=true"
+	}
+
+	fm, err := yaml.Marshal(markdownFrontmatter{
+		Title:        doc.Title,
+		DocNumber:    doc.DocNumber,
+		DocType:      doc.DocType,
+		Product:      doc.Product,
+		Status:       doc.Status,
+		Owners:       doc.Owners,
+		Contributors: doc.Contributors,
+		Approvers:    doc.Approvers,
+		ApprovedBy:   doc.ApprovedBy,
+		Created:      doc.Created,
+		HermesURL:    docURLString,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error marshaling frontmatter: %w", err)
+	}
+
+	var b strings.Builder
+	b.WriteString("---\n")
+	b.Write(fm)
+	b.WriteString("---\n\n")
+
+	// Build approvers with a check next to approvers who have approved, like
+	// the Google Docs header. Approver groups are listed first.
+	approvers := slices.Clone(doc.ApproverGroups)
+	for _, approver := range doc.Approvers {
+		if slices.Contains(doc.ApprovedBy, approver) {
+			approvers = append(approvers, "✅ "+approver)
+		} else if slices.Contains(doc.ChangesRequestedBy, approver) {
+			approvers = append(approvers, "❌ "+approver)
+		} else {
+			approvers = append(approvers, approver)
+		}
+	}
+	var owner string
+	if len(doc.Owners) > 0 {
+		owner = doc.Owners[0]
+	}
+
+	row := func(name, value string) {
+		fmt.Fprintf(&b, "| **%s** | %s |\n",
+			markdownTableCell(name), markdownTableCell(value))
+	}
+	b.WriteString("| | |\n| --- | --- |\n")
+	row("Summary", doc.Summary)
+	row("Created", doc.Created)
+	row("Status", doc.Status)
+	row("Product", doc.Product)
+	row("Owner", owner)
+	row("Contributors", strings.Join(doc.Contributors, ", "))
+	row("Approvers", strings.Join(approvers, ", "))
+	for _, cf := range doc.CustomFields {
+		value, err := markdownCustomFieldValue(cf)
+		if err != nil {
+			return "", err
+		}
+		row(cf.DisplayName, value)
+	}
+
+	fmt.Fprintf(&b, "\n> NOTE: This [document](%s) is managed by [Hermes](%s) "+
+		"and this header will be periodically overwritten using document "+
+		"metadata.\n", docURLString, baseURL)
+	b.WriteString(markdownHeaderEnd + "\n")
+
+	return b.String(), nil
+}
+
+// ReplaceMarkdownHeader replaces the header of a Markdown document with the
+// header rendered by MarkdownHeader. The header is the frontmatter and summary
+// table up to the end marker written by MarkdownHeader, or any frontmatter at
+// the start of the document if it doesn't have the marker yet. The rest of the
+// document is kept as is.
+func ReplaceMarkdownHeader(body, header string) string {
+	rest := body
+	if _, after, ok := strings.Cut(body, markdownHeaderEnd); ok {
+		rest = after
+	} else if strings.HasPrefix(body, "---\n") {
+		if i := strings.Index(body[len("---\n"):], "\n---\n"); i >= 0 {
+			rest = body[len("---\n")+i+len("\n---\n"):]
+		}
+	}
+	rest = strings.TrimLeft(rest, "\n")
+	if rest == "" {
+		return header
+	}
+	return header + "\n" + rest
+}
+
+// ReplaceMarkdownHeader replaces the header of a document whose content is
+// edited through the workspace provider (e.g., the local provider), which
+// doesn't support the Google Docs API used by ReplaceHeader. It returns
+// ErrUnsupportedContentFormat if the document content isn't Markdown.
+func (doc *Document) ReplaceMarkdownHeader(
+	ctx context.Context,
+	providerID, baseURL string,
+	isDraft bool,
+	provider workspace.WorkspaceProvider,
+) error {
+	content, err := provider.GetContent(ctx, providerID)
+	if err != nil {
+		return fmt.Errorf("error getting document content: %w", err)
+	}
+	if content.Format != "" && content.Format != "markdown" {
+		return fmt.Errorf("%w: %q", ErrUnsupportedContentFormat, content.Format)
+	}
+
+	header, err := doc.MarkdownHeader(baseURL, isDraft)
+	if err != nil {
+		return fmt.Errorf("error rendering header: %w", err)
+	}
+	body := ReplaceMarkdownHeader(content.Body, header)
+	if body == content.Body {
+		return nil
+	}
+	if _, err := provider.UpdateContent(ctx, providerID, body); err != nil {
+		return fmt.Errorf("error updating document content: %w", err)
+	}
+	return nil
+}
+
+// markdownCustomFieldValue returns the value of a custom field for the summary
+// table of a Markdown header.
+func markdownCustomFieldValue(cf CustomField) (string, error) {
+	switch cf.Type {
+	case "PEOPLE":
+		switch v := cf.Value.(type) {
+		case nil:
+			return "", nil
+		case []string:
+			return strings.Join(v, ", "), nil
+		case []any:
+			people := make([]string, 0, len(v))
+			for _, p := range v {
+				s, ok := p.(string)
+				if !ok {
+					return "", fmt.Errorf(
+						"wrong type for custom field %q, want []string", cf.Name)
+				}
+				people = append(people, s)
+			}
+			return strings.Join(people, ", "), nil
+		default:
+			return "", fmt.Errorf(
+				"wrong type for custom field %q, want []string", cf.Name)
+		}
+
+	case "STRING":
+		v, ok := cf.Value.(string)
+		if !ok {
+			return "", fmt.Errorf(
+				"wrong type for custom field %q, want string", cf.Name)
+		}
+		// "PRD" and "RFC" fields are links to those documents.
+		switch cf.DisplayName {
+		case "PRD", "RFC":
+			if v == "" {
+				return "", nil
+			}
+			return fmt.Sprintf("[%s](%s)", cf.DisplayName, v), nil
+		}
+		return v, nil
+
+	default:
+		return "", fmt.Errorf("invalid custom field type: %s", cf.Type)
+	}
+}
+
+// markdownTableCell escapes a value for a Markdown table cell.
+func markdownTableCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.Join(strings.Fields(s), " ")
+}
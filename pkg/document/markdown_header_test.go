@@ -0,0 +1,146 @@
+package document
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/pkg/workspace/adapters/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkdownHeader(t *testing.T) {
+	doc := &Document{
+		ObjectID:     "doc-1",
+		Title:        "Kafka | Streams",
+		DocNumber:    "TF-001",
+		DocType:      "RFC",
+		Product:      "Terraform",
+		Status:       "In-Review",
+		Summary:      "A summary\nover two lines.",
+		Created:      "Jan 2, 2026",
+		Owners:       []string{"alice@example.com"},
+		Contributors: []string{"bob@example.com"},
+		Approvers:    []string{"carol@example.com", "dan@example.com"},
+		ApprovedBy:   []string{"carol@example.com"},
+		CustomFields: []CustomField{
+			{Name: "stakeholders", DisplayName: "Stakeholders", Type: "PEOPLE",
+				Value: []any{"erin@example.com", "frank@example.com"}},
+			{Name: "prd", DisplayName: "PRD", Type: "STRING",
+				Value: "https://hermes.example.com/document/prd-1"},
+		},
+	}
+
+	header, err := doc.MarkdownHeader("https://hermes.example.com", true)
+	require.NoError(t, err)
+	assert.Equal(t, `---
+title: Kafka | Streams
+docNumber: TF-001
+docType: RFC
+product: Terraform
+status: In-Review
+owners:
+    - alice@example.com
+contributors:
+    - bob@example.com
+approvers:
+    - carol@example.com
+    - dan@example.com
+approvedBy:
+    - carol@example.com
+created: Jan 2, 2026
+hermesURL: https://hermes.example.com/document/doc-1?draft=true
+---
+
+| | |
+| --- | --- |
+| **Summary** | A summary over two lines. |
+| **Created** | Jan 2, 2026 |
+| **Status** | In-Review |
+| **Product** | Terraform |
+| **Owner** | alice@example.com |
+| **Contributors** | bob@example.com |
+| **Approvers** | ✅ carol@example.com, dan@example.com |
+| **Stakeholders** | erin@example.com, frank@example.com |
+| **PRD** | [PRD](https://hermes.example.com/document/prd-1) |
+
+> NOTE: This [document](https://hermes.example.com/document/doc-1?draft=true) is managed by [Hermes](https://hermes.example.com) and this header will be periodically overwritten using document metadata.
+<!-- hermes:header:end -->
+`, header)
+
+	doc.CustomFields = []CustomField{{Name: "x", Type: "STRING", Value: 1}}
+	_, err = doc.MarkdownHeader("https://hermes.example.com", false)
+	assert.ErrorContains(t, err, `wrong type for custom field "x"`)
+}
+
+func TestReplaceMarkdownHeader(t *testing.T) {
+	const header = "---\ntitle: New\n---\n\n" + markdownHeaderEnd + "\n"
+
+	cases := map[string]struct {
+		body string
+		want string
+	}{
+		"no header": {
+			body: "# Background\n\nText.",
+			want: header + "\n# Background\n\nText.",
+		},
+		"empty document": {
+			body: "",
+			want: header,
+		},
+		"frontmatter": {
+			body: "---\ntitle: Old\n---\n\n# Background\n",
+			want: header + "\n# Background\n",
+		},
+		"rendered header": {
+			body: "---\ntitle: Old\n---\n\n| | |\n| --- | --- |\n\n" +
+				markdownHeaderEnd + "\n\n# Background\n\n---\n\nMore.",
+			want: header + "\n# Background\n\n---\n\nMore.",
+		},
+		"unterminated frontmatter": {
+			body: "---\ntitle: Old\n",
+			want: header + "\n---\ntitle: Old\n",
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ReplaceMarkdownHeader(c.body, header)
+			assert.Equal(t, c.want, got)
+			assert.Equal(t, got, ReplaceMarkdownHeader(got, header),
+				"replacing a header is idempotent")
+		})
+	}
+}
+
+func TestDocumentReplaceMarkdownHeader(t *testing.T) {
+	ctx := context.Background()
+	provider := mock.NewFakeAdapter()
+	meta, err := provider.CreateDocument(ctx, "", "folder", "Doc")
+	require.NoError(t, err)
+	_, err = provider.UpdateContent(ctx, meta.ProviderID, "# Background\n\nText.")
+	require.NoError(t, err)
+
+	doc := &Document{
+		ObjectID: "doc-1",
+		Title:    "Doc",
+		Status:   "WIP",
+		Owners:   []string{"alice@example.com"},
+	}
+	require.NoError(t, doc.ReplaceMarkdownHeader(
+		ctx, meta.ProviderID, "https://hermes.example.com", true, provider))
+
+	content, err := provider.GetContent(ctx, meta.ProviderID)
+	require.NoError(t, err)
+	assert.Contains(t, content.Body, "status: WIP\n")
+	assert.Contains(t, content.Body, markdownHeaderEnd+"\n\n# Background\n\nText.")
+
+	// Header updates replace the header.
+	doc.Status = "In-Review"
+	require.NoError(t, doc.ReplaceMarkdownHeader(
+		ctx, meta.ProviderID, "https://hermes.example.com", true, provider))
+	content, err = provider.GetContent(ctx, meta.ProviderID)
+	require.NoError(t, err)
+	assert.Contains(t, content.Body, "status: In-Review\n")
+	assert.NotContains(t, content.Body, "status: WIP\n")
+	assert.Contains(t, content.Body, markdownHeaderEnd+"\n\n# Background\n\nText.")
+}
@@ -0,0 +1,79 @@
+package edgesync
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Checkpoint is the sync state of an edge instance. It is saved after each
+// batch of a sync, so interrupted syncs resume where they stopped.
+type Checkpoint struct {
+	// Cursor is the checkpoint of the central changes seen by the edge.
+	Cursor int64 `json:"cursor"`
+
+	// EventCursor is the ID of the last central event seen by the edge.
+	EventCursor int64 `json:"event_cursor,omitempty"`
+
+	// Documents is the sync state of the edge's documents by UUID.
+	Documents map[string]*DocumentState `json:"documents"`
+}
+
+// DocumentState is the sync state of a document.
+type DocumentState struct {
+	// Revision is the revision of the central record the edge's version is
+	// based on, or 0 if the document was never synced.
+	Revision int64 `json:"revision"`
+
+	// Fingerprint is the fingerprint of the edge's version when it was last
+	// synced. The document has local changes if it has another fingerprint.
+	Fingerprint string `json:"fingerprint"`
+
+	// Conflict explains why the document is in conflict, or is empty.
+	Conflict string `json:"conflict,omitempty"`
+}
+
+// LoadCheckpoint loads the checkpoint saved at path. It returns an empty
+// checkpoint if the file doesn't exist.
+func LoadCheckpoint(path string) (*Checkpoint, error) {
+	cp := &Checkpoint{Documents: map[string]*DocumentState{}}
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cp, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading checkpoint: %w", err)
+	}
+	if err := json.Unmarshal(b, cp); err != nil {
+		return nil, fmt.Errorf("error decoding checkpoint %s: %w", path, err)
+	}
+	if cp.Documents == nil {
+		cp.Documents = map[string]*DocumentState{}
+	}
+	return cp, nil
+}
+
+// Save saves the checkpoint to path. The file is replaced atomically, so an
+// interrupted save doesn't corrupt the previous checkpoint.
+func (c *Checkpoint) Save(path string) error {
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding checkpoint: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("error creating checkpoint directory: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o644); err != nil {
+		return fmt.Errorf("error writing checkpoint: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("error writing checkpoint: %w", err)
+	}
+	return nil
+}
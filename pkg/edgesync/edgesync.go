@@ -0,0 +1,501 @@
+// Package edgesync syncs the documents of an edge Hermes instance with the
+// central Hermes instance (RFC-085).
+//
+// Each sync pushes the documents changed on the edge since the last sync to
+// central in batches, and pulls the changes made on central. Central tracks a
+// revision per document; edge changes that aren't based on its current
+// revision are merged with central's changes (three-way, with the edge's base
+// revision as the common ancestor), and documents whose changes can't be
+// merged are reported as conflicts and aren't synced until they are resolved.
+// The sync state is saved in a checkpoint after each batch, so interrupted
+// syncs resume where they stopped.
+//
+// Between syncs, edges can long-poll central for events about their
+// documents, like new approvals and permission changes, to sync as soon as
+// something changes instead of syncing often.
+package edgesync
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/apiclient"
+	"github.com/hashicorp/go-hclog"
+)
+
+// DefaultBatchSize is the default number of documents pushed per request.
+const DefaultBatchSize = 100
+
+// Sync states of documents returned by central.
+const (
+	stateCreated   = "created"
+	stateUpdated   = "updated"
+	stateDeleted   = "deleted"
+	stateUnchanged = "unchanged"
+	stateMerged    = "merged"
+	stateConflict  = "conflict"
+	stateRejected  = "rejected"
+)
+
+// Document is the version of a document on the edge.
+type Document struct {
+	UUID         string
+	ProviderID   string
+	Title        string
+	DocumentType string
+	Status       string
+	Summary      string
+	Product      string
+	Project      string
+	Owners       []string
+	Tags         []string
+	ContentHash  string
+	ModifiedTime time.Time
+
+	// Content is the Markdown content of the document, without frontmatter.
+	Content string
+}
+
+// fingerprint returns a hash of the content hash and synced metadata of d.
+func (d *Document) fingerprint() string {
+	h := sha256.Sum256([]byte(strings.Join([]string{
+		d.ContentHash, d.Title, d.DocumentType, d.Status, d.Summary, d.Product,
+	}, "\x00")))
+	return hex.EncodeToString(h[:])
+}
+
+// Store is the edge's document store.
+type Store interface {
+	// Documents returns the edge's documents.
+	Documents(ctx context.Context) ([]*Document, error)
+
+	// UpdateMetadata updates the metadata of a document to the metadata of
+	// doc, which was changed on central.
+	UpdateMetadata(ctx context.Context, doc *Document) error
+
+	// UpdateContent updates the content of a document to the content of doc,
+	// which was changed on central or merged.
+	UpdateContent(ctx context.Context, doc *Document) error
+}
+
+// Conflict is a document that couldn't be synced.
+type Conflict struct {
+	UUID    string
+	Title   string
+	Message string
+}
+
+// Result is the result of a sync.
+type Result struct {
+	// Documents is the number of documents in the edge's store.
+	Documents int
+
+	// Pushed is the number of documents created or updated on central.
+	Pushed int
+
+	// Pulled is the number of documents updated with central's changes.
+	Pulled int
+
+	// Merged is the number of documents whose changes were merged with
+	// central's changes.
+	Merged int
+
+	// Deleted is the number of documents deleted on central.
+	Deleted int
+
+	// Conflicts are the documents that couldn't be synced.
+	Conflicts []Conflict
+}
+
+// Engine syncs the documents of an edge instance with central.
+type Engine struct {
+	// Client is the API client of central.
+	Client *apiclient.Client
+
+	// Store is the edge's document store.
+	Store Store
+
+	// EdgeInstance is the name of the edge instance.
+	EdgeInstance string
+
+	// CheckpointPath is the path of the checkpoint file.
+	CheckpointPath string
+
+	// BatchSize is the number of documents pushed per request.
+	// Default: DefaultBatchSize
+	BatchSize int
+
+	// Scope limits the sync to the documents of some products, document
+	// types, or projects. Documents outside of it are neither pushed nor
+	// deleted on central, and central only returns changes inside of it.
+	Scope *apiclient.EdgeSyncScope
+
+	// Force pushes conflicting documents again based on central's current
+	// revision, overwriting the changes made on central.
+	Force bool
+
+	Logger hclog.Logger
+}
+
+// Sync pushes the edge's changes to central and pulls central's changes.
+// The checkpoint is saved after each batch; if Sync returns an error, the
+// next sync resumes from the last saved checkpoint.
+func (e *Engine) Sync(ctx context.Context) (*Result, error) {
+	batchSize := e.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	cp, err := LoadCheckpoint(e.CheckpointPath)
+	if err != nil {
+		return nil, err
+	}
+
+	docs, err := e.Store.Documents(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error listing documents: %w", err)
+	}
+	local := make(map[string]*Document, len(docs))
+	outOfScope := map[string]bool{}
+	for _, d := range docs {
+		if !inScope(e.Scope, d) {
+			outOfScope[d.UUID] = true
+			continue
+		}
+		local[d.UUID] = d
+	}
+
+	pending := pendingDocuments(cp, local, outOfScope)
+	e.logger().Debug("syncing documents", "pending", len(pending), "cursor", cp.Cursor)
+
+	res := &Result{Documents: len(local)}
+	forced := map[string]bool{}
+	for {
+		n := min(batchSize, len(pending))
+		batch := pending[:n]
+		pending = pending[n:]
+
+		resp, err := e.Client.SyncEdge(ctx, apiclient.EdgeSyncRequest{
+			EdgeInstance: e.EdgeInstance,
+			Checkpoint:   cp.Cursor,
+			Scope:        e.Scope,
+			Documents:    batch,
+		})
+		if err != nil {
+			return res, fmt.Errorf("error syncing documents: %w", err)
+		}
+		if len(resp.Results) != len(batch) {
+			return res, fmt.Errorf(
+				"central returned %d results for %d documents",
+				len(resp.Results), len(batch))
+		}
+
+		for i, r := range resp.Results {
+			retry, err := e.applyResult(ctx, cp, local, batch[i], r, res, forced)
+			if err != nil {
+				return res, err
+			}
+			if retry != nil {
+				pending = append(pending, retry)
+			}
+		}
+		for _, rec := range resp.Changes {
+			if err := e.applyChange(ctx, cp, local, rec, res); err != nil {
+				return res, err
+			}
+		}
+		cp.Cursor = resp.Checkpoint
+
+		if err := cp.Save(e.CheckpointPath); err != nil {
+			return res, err
+		}
+		if len(pending) == 0 && !resp.More {
+			break
+		}
+	}
+
+	for uuid, st := range cp.Documents {
+		if st.Conflict == "" {
+			continue
+		}
+		c := Conflict{UUID: uuid, Message: st.Conflict}
+		if d, ok := local[uuid]; ok {
+			c.Title = d.Title
+		}
+		res.Conflicts = append(res.Conflicts, c)
+	}
+	sort.Slice(res.Conflicts, func(i, j int) bool {
+		return res.Conflicts[i].UUID < res.Conflicts[j].UUID
+	})
+
+	return res, nil
+}
+
+// WaitForEvents waits up to wait for central events about the edge's
+// documents, like new approvals and permission changes, and returns them, or
+// nil if there were none. The last event seen is saved in the checkpoint, so
+// each event is returned once.
+func (e *Engine) WaitForEvents(ctx context.Context, wait time.Duration) ([]*apiclient.EdgeEvent, error) {
+	cp, err := LoadCheckpoint(e.CheckpointPath)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := e.Client.ListEdgeEvents(ctx, &apiclient.ListEdgeEventsParams{
+		EdgeInstance: e.EdgeInstance,
+		After:        int(cp.EventCursor),
+		Wait:         wait.String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error getting events: %w", err)
+	}
+	if len(resp.Events) == 0 {
+		return nil, nil
+	}
+
+	cp.EventCursor = resp.Cursor
+	if err := cp.Save(e.CheckpointPath); err != nil {
+		return nil, err
+	}
+	return resp.Events, nil
+}
+
+// pendingDocuments returns the documents to push: the documents that were
+// changed or deleted since the last sync, or are in conflict, in UUID order.
+// Documents in outOfScope still exist on the edge, so they aren't deleted.
+func pendingDocuments(cp *Checkpoint, local map[string]*Document, outOfScope map[string]bool) []*apiclient.EdgeSyncDocument {
+	var pending []*apiclient.EdgeSyncDocument
+	for uuid, d := range local {
+		st := cp.Documents[uuid]
+		if st != nil && st.Conflict == "" && st.Fingerprint == d.fingerprint() {
+			continue
+		}
+		var base int64
+		if st != nil {
+			base = st.Revision
+		}
+		pending = append(pending, syncDocument(d, base))
+	}
+	for uuid, st := range cp.Documents {
+		if _, ok := local[uuid]; !ok && !outOfScope[uuid] {
+			pending = append(pending, &apiclient.EdgeSyncDocument{
+				UUID:         uuid,
+				BaseRevision: st.Revision,
+				Deleted:      true,
+			})
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].UUID < pending[j].UUID
+	})
+	return pending
+}
+
+// syncDocument returns the sync request document of d based on revision base.
+func syncDocument(d *Document, base int64) *apiclient.EdgeSyncDocument {
+	doc := &apiclient.EdgeSyncDocument{
+		UUID:         d.UUID,
+		BaseRevision: base,
+		Title:        d.Title,
+		DocumentType: d.DocumentType,
+		Status:       d.Status,
+		Summary:      d.Summary,
+		Product:      d.Product,
+		Owners:       d.Owners,
+		ProviderID:   d.ProviderID,
+		Tags:         d.Tags,
+		ContentHash:  d.ContentHash,
+		UpdatedAt:    d.ModifiedTime,
+		Content:      d.Content,
+	}
+	if d.Project != "" {
+		// Central matches sync scopes against the project metadata.
+		doc.Metadata = map[string]any{"project": d.Project}
+	}
+	return doc
+}
+
+// inScope returns true if d is in scope, which includes all documents if nil.
+func inScope(scope *apiclient.EdgeSyncScope, d *Document) bool {
+	if scope == nil {
+		return true
+	}
+	return matchesScope(scope.Products, d.Product) &&
+		matchesScope(scope.DocumentTypes, d.DocumentType) &&
+		matchesScope(scope.Projects, d.Project)
+}
+
+// matchesScope returns true if values is empty or contains v.
+func matchesScope(values []string, v string) bool {
+	return len(values) == 0 || slices.Contains(values, v)
+}
+
+// applyResult records the sync state returned by central for the pushed
+// document sent. It returns the document to push again, if any.
+func (e *Engine) applyResult(
+	ctx context.Context,
+	cp *Checkpoint,
+	local map[string]*Document,
+	sent *apiclient.EdgeSyncDocument,
+	r *apiclient.EdgeSyncResult,
+	res *Result,
+	forced map[string]bool,
+) (*apiclient.EdgeSyncDocument, error) {
+	switch r.State {
+	case stateCreated, stateUpdated, stateUnchanged:
+		cp.Documents[sent.UUID] = &DocumentState{
+			Revision:    r.Revision,
+			Fingerprint: local[sent.UUID].fingerprint(),
+		}
+		if r.State != stateUnchanged {
+			res.Pushed++
+		}
+
+	case stateDeleted:
+		delete(cp.Documents, sent.UUID)
+		res.Deleted++
+
+	case stateMerged:
+		if r.Document == nil {
+			return nil, fmt.Errorf(
+				"central returned no merged document for %s", sent.UUID)
+		}
+		updated, err := e.updateDocument(ctx, local[sent.UUID], r.Document)
+		if err != nil {
+			return nil, err
+		}
+		local[sent.UUID] = updated
+		cp.Documents[sent.UUID] = &DocumentState{
+			Revision:    r.Revision,
+			Fingerprint: updated.fingerprint(),
+		}
+		res.Merged++
+
+	case stateConflict, stateRejected:
+		if e.Force && r.State == stateConflict && r.Document != nil &&
+			!forced[sent.UUID] {
+			forced[sent.UUID] = true
+			retry := *sent
+			retry.BaseRevision = r.Document.Revision
+			return &retry, nil
+		}
+		st := cp.Documents[sent.UUID]
+		if st == nil {
+			st = &DocumentState{}
+			cp.Documents[sent.UUID] = st
+		}
+		st.Conflict = r.Message
+		if st.Conflict == "" {
+			st.Conflict = r.State
+		}
+
+	default:
+		e.logger().Warn("unknown document sync state",
+			"uuid", sent.UUID, "state", r.State)
+	}
+	return nil, nil
+}
+
+// applyChange applies a change of a central record to the edge's document.
+func (e *Engine) applyChange(
+	ctx context.Context,
+	cp *Checkpoint,
+	local map[string]*Document,
+	rec *apiclient.EdgeDocumentRecord,
+	res *Result,
+) error {
+	st := cp.Documents[rec.UUID]
+	if st != nil && st.Revision >= rec.Revision {
+		// The edge already has this revision, e.g., it pushed it.
+		return nil
+	}
+	d, ok := local[rec.UUID]
+	if !ok {
+		e.logger().Warn("central has a document that isn't on the edge",
+			"uuid", rec.UUID, "title", rec.Title)
+		return nil
+	}
+
+	conflict := func(msg string) {
+		if st == nil {
+			st = &DocumentState{}
+			cp.Documents[rec.UUID] = st
+		}
+		st.Conflict = msg
+	}
+	if st != nil && st.Fingerprint != d.fingerprint() {
+		conflict("document was changed on both the edge and central")
+		return nil
+	}
+	if rec.ContentHash != d.ContentHash && (st == nil || rec.Content == "") {
+		// Content changes are only pulled into documents that were synced
+		// before, and only if central has the content.
+		conflict("document content was changed on central")
+		return nil
+	}
+
+	updated, err := e.updateDocument(ctx, d, rec)
+	if err != nil {
+		return err
+	}
+	if updated != d {
+		local[rec.UUID] = updated
+		res.Pulled++
+	}
+
+	cp.Documents[rec.UUID] = &DocumentState{
+		Revision:    rec.Revision,
+		Fingerprint: updated.fingerprint(),
+	}
+	return nil
+}
+
+// updateDocument updates the edge's document d to the metadata and content of
+// central's record rec. It returns the updated document, or d if it didn't
+// change.
+func (e *Engine) updateDocument(
+	ctx context.Context,
+	d *Document,
+	rec *apiclient.EdgeDocumentRecord,
+) (*Document, error) {
+	if d == nil {
+		return nil, fmt.Errorf("document %s not found", rec.UUID)
+	}
+
+	updated := *d
+	updated.Title = rec.Title
+	updated.DocumentType = rec.DocumentType
+	updated.Status = rec.Status
+	updated.Summary = rec.Summary
+	updated.Product = rec.Product
+	if updated.fingerprint() != d.fingerprint() {
+		if err := e.Store.UpdateMetadata(ctx, &updated); err != nil {
+			return nil, fmt.Errorf("error updating document %s: %w", rec.UUID, err)
+		}
+	}
+	if rec.Content != "" && rec.ContentHash != d.ContentHash {
+		updated.Content = rec.Content
+		updated.ContentHash = rec.ContentHash
+		if err := e.Store.UpdateContent(ctx, &updated); err != nil {
+			return nil, fmt.Errorf("error updating document %s: %w", rec.UUID, err)
+		}
+	}
+
+	if updated.fingerprint() == d.fingerprint() {
+		return d, nil
+	}
+	return &updated, nil
+}
+
+func (e *Engine) logger() hclog.Logger {
+	if e.Logger == nil {
+		return hclog.NewNullLogger()
+	}
+	return e.Logger
+}
@@ -0,0 +1,402 @@
+package edgesync
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/apiclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCentral is an in-memory central instance with the conflict detection of
+// the edge sync endpoint.
+type fakeCentral struct {
+	records  map[string]*apiclient.EdgeDocumentRecord
+	seq      int64
+	requests int
+
+	// failAfter fails requests after this many requests, if not 0.
+	failAfter int
+
+	// merge merges changes based on an older revision, if set. It returns
+	// the merged record, or nil for a conflict.
+	merge func(cur *apiclient.EdgeDocumentRecord, d *apiclient.EdgeSyncDocument) *apiclient.EdgeDocumentRecord
+
+	// events are the events published to edges.
+	events []*apiclient.EdgeEvent
+}
+
+func newFakeCentral() *fakeCentral {
+	return &fakeCentral{records: map[string]*apiclient.EdgeDocumentRecord{}}
+}
+
+func (c *fakeCentral) client() *apiclient.Client {
+	return apiclient.New(apiclient.DoerFunc(
+		func(ctx context.Context, method, path string, body, result any) error {
+			c.requests++
+			if c.failAfter > 0 && c.requests > c.failAfter {
+				return errors.New("connection refused")
+			}
+			if resp, ok := result.(*apiclient.EdgeEventsResponse); ok {
+				*resp = *c.listEvents(path)
+				return nil
+			}
+			req := body.(apiclient.EdgeSyncRequest)
+			*result.(*apiclient.EdgeSyncResponse) = *c.sync(req)
+			return nil
+		}))
+}
+
+// save creates or updates a record, as the sync endpoint and other writers do.
+func (c *fakeCentral) save(rec *apiclient.EdgeDocumentRecord) {
+	c.seq++
+	rec.ChangeSeq = c.seq
+	if old, ok := c.records[rec.UUID]; ok {
+		rec.Revision = old.Revision + 1
+	} else {
+		rec.Revision = 1
+	}
+	c.records[rec.UUID] = rec
+}
+
+func (c *fakeCentral) sync(req apiclient.EdgeSyncRequest) *apiclient.EdgeSyncResponse {
+	resp := &apiclient.EdgeSyncResponse{Checkpoint: req.Checkpoint}
+	for _, d := range req.Documents {
+		cur, ok := c.records[d.UUID]
+		r := &apiclient.EdgeSyncResult{UUID: d.UUID}
+		switch {
+		case !ok && d.Deleted:
+			r.State = stateDeleted
+		case !ok:
+			r.State = stateCreated
+		case cur.Revision != d.BaseRevision && c.merge != nil &&
+			c.merge(cur, d) != nil:
+			c.save(c.merge(cur, d))
+			r.State = stateMerged
+			r.Document = c.records[d.UUID]
+			r.Revision = r.Document.Revision
+		case cur.Revision != d.BaseRevision:
+			r.State = stateConflict
+			r.Document = cur
+		case d.Deleted:
+			delete(c.records, d.UUID)
+			r.State = stateDeleted
+		default:
+			r.State = stateUpdated
+		}
+		if r.State == stateCreated || r.State == stateUpdated {
+			c.save(&apiclient.EdgeDocumentRecord{
+				UUID:         d.UUID,
+				EdgeInstance: req.EdgeInstance,
+				Title:        d.Title,
+				Status:       d.Status,
+				ContentHash:  d.ContentHash,
+				Content:      d.Content,
+			})
+			r.Revision = c.records[d.UUID].Revision
+		}
+		resp.Results = append(resp.Results, r)
+	}
+	for _, rec := range c.records {
+		if rec.ChangeSeq > req.Checkpoint {
+			resp.Changes = append(resp.Changes, rec)
+			resp.Checkpoint = max(resp.Checkpoint, rec.ChangeSeq)
+		}
+	}
+	return resp
+}
+
+// listEvents lists the events of the edge events request with path, without
+// waiting.
+func (c *fakeCentral) listEvents(path string) *apiclient.EdgeEventsResponse {
+	u, _ := url.Parse(path)
+	after, _ := strconv.ParseInt(u.Query().Get("after"), 10, 64)
+	resp := &apiclient.EdgeEventsResponse{Cursor: after}
+	for _, e := range c.events {
+		if e.EdgeInstance == u.Query().Get("edge_instance") && e.ID > after {
+			resp.Events = append(resp.Events, e)
+			resp.Cursor = e.ID
+		}
+	}
+	return resp
+}
+
+// fakeStore is an in-memory edge document store.
+type fakeStore struct {
+	docs map[string]*Document
+}
+
+func (s *fakeStore) Documents(ctx context.Context) ([]*Document, error) {
+	var docs []*Document
+	for _, d := range s.docs {
+		copied := *d
+		docs = append(docs, &copied)
+	}
+	return docs, nil
+}
+
+func (s *fakeStore) UpdateMetadata(ctx context.Context, doc *Document) error {
+	copied := *s.docs[doc.UUID]
+	copied.Title, copied.Status = doc.Title, doc.Status
+	copied.Summary, copied.Product = doc.Summary, doc.Product
+	s.docs[doc.UUID] = &copied
+	return nil
+}
+
+func (s *fakeStore) UpdateContent(ctx context.Context, doc *Document) error {
+	copied := *s.docs[doc.UUID]
+	copied.Content, copied.ContentHash = doc.Content, doc.ContentHash
+	s.docs[doc.UUID] = &copied
+	return nil
+}
+
+func TestEngineSync(t *testing.T) {
+	ctx := context.Background()
+	central := newFakeCentral()
+	store := &fakeStore{docs: map[string]*Document{
+		"a": {UUID: "a", Title: "RFC A", ContentHash: "h1"},
+		"b": {UUID: "b", Title: "RFC B", ContentHash: "h1"},
+		"c": {UUID: "c", Title: "RFC C", ContentHash: "h1"},
+	}}
+	e := &Engine{
+		Client:         central.client(),
+		Store:          store,
+		EdgeInstance:   "laptop",
+		CheckpointPath: filepath.Join(t.TempDir(), "checkpoint.json"),
+		BatchSize:      2,
+	}
+
+	t.Run("pushes new documents", func(t *testing.T) {
+		res, err := e.Sync(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 3, res.Pushed)
+		assert.Equal(t, 3, res.Documents)
+		assert.Empty(t, res.Conflicts)
+		assert.Len(t, central.records, 3)
+	})
+
+	t.Run("pushes nothing without changes", func(t *testing.T) {
+		res, err := e.Sync(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, Result{Documents: 3}, *res)
+	})
+
+	t.Run("pushes local changes and deletions", func(t *testing.T) {
+		store.docs["a"].ContentHash = "h2"
+		delete(store.docs, "c")
+
+		res, err := e.Sync(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, res.Pushed)
+		assert.Equal(t, 1, res.Deleted)
+		assert.Equal(t, "h2", central.records["a"].ContentHash)
+		assert.Equal(t, int64(2), central.records["a"].Revision)
+		assert.NotContains(t, central.records, "c")
+	})
+
+	t.Run("pulls central metadata changes", func(t *testing.T) {
+		rec := *central.records["b"]
+		rec.Status = "Approved"
+		central.save(&rec)
+
+		res, err := e.Sync(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, res.Pulled)
+		assert.Equal(t, "Approved", store.docs["b"].Status)
+	})
+
+	t.Run("reports conflicting changes", func(t *testing.T) {
+		rec := *central.records["a"]
+		rec.Title = "Central title"
+		central.save(&rec)
+		store.docs["a"].Title = "Edge title"
+
+		res, err := e.Sync(ctx)
+		require.NoError(t, err)
+		require.Len(t, res.Conflicts, 1)
+		assert.Equal(t, "a", res.Conflicts[0].UUID)
+		assert.Equal(t, "Central title", central.records["a"].Title)
+
+		// Conflicts are reported until they are resolved.
+		res, err = e.Sync(ctx)
+		require.NoError(t, err)
+		assert.Len(t, res.Conflicts, 1)
+	})
+
+	t.Run("force overwrites conflicting changes", func(t *testing.T) {
+		e.Force = true
+		defer func() { e.Force = false }()
+
+		res, err := e.Sync(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, res.Conflicts)
+		assert.Equal(t, "Edge title", central.records["a"].Title)
+	})
+}
+
+func TestEngineSyncResumes(t *testing.T) {
+	ctx := context.Background()
+	central := newFakeCentral()
+	store := &fakeStore{docs: map[string]*Document{
+		"a": {UUID: "a", Title: "RFC A", ContentHash: "h1"},
+		"b": {UUID: "b", Title: "RFC B", ContentHash: "h1"},
+	}}
+	e := &Engine{
+		Client:         central.client(),
+		Store:          store,
+		EdgeInstance:   "laptop",
+		CheckpointPath: filepath.Join(t.TempDir(), "checkpoint.json"),
+		BatchSize:      1,
+	}
+
+	central.failAfter = 1
+	_, err := e.Sync(ctx)
+	require.Error(t, err)
+
+	cp, err := LoadCheckpoint(e.CheckpointPath)
+	require.NoError(t, err)
+	assert.Contains(t, cp.Documents, "a")
+	assert.NotContains(t, cp.Documents, "b")
+
+	central.failAfter = 0
+	res, err := e.Sync(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, res.Pushed, "only the document of the failed batch is pushed")
+	assert.Len(t, central.records, 2)
+}
+
+func TestEngineSyncScope(t *testing.T) {
+	ctx := context.Background()
+	central := newFakeCentral()
+	store := &fakeStore{docs: map[string]*Document{
+		"a": {UUID: "a", Title: "RFC A", Product: "Vault", ContentHash: "h1"},
+		"b": {UUID: "b", Title: "RFC B", Product: "Consul", ContentHash: "h1"},
+		"c": {UUID: "c", Title: "RFC C", Product: "Vault", Project: "Secrets",
+			ContentHash: "h1"},
+	}}
+	e := &Engine{
+		Client:         central.client(),
+		Store:          store,
+		EdgeInstance:   "laptop",
+		CheckpointPath: filepath.Join(t.TempDir(), "checkpoint.json"),
+	}
+
+	res, err := e.Sync(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, res.Pushed)
+
+	// Narrowing the scope stops syncing documents outside of it, without
+	// deleting them on central.
+	e.Scope = &apiclient.EdgeSyncScope{
+		Products: []string{"Vault"},
+		Projects: []string{"Secrets"},
+	}
+	store.docs["c"].Title = "RFC C: Secrets"
+	store.docs["a"].Title = "RFC A: Vault"
+	res, err = e.Sync(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, res.Documents)
+	assert.Equal(t, 1, res.Pushed)
+	assert.Zero(t, res.Deleted)
+	assert.Len(t, central.records, 3)
+	assert.Equal(t, "RFC C: Secrets", central.records["c"].Title)
+	assert.Equal(t, "RFC A", central.records["a"].Title)
+}
+
+func TestEngineSyncMerge(t *testing.T) {
+	ctx := context.Background()
+	central := newFakeCentral()
+	store := &fakeStore{docs: map[string]*Document{
+		"a": {UUID: "a", Title: "RFC A", Content: "Intro.", ContentHash: "h1"},
+		"b": {UUID: "b", Title: "RFC B", Content: "Intro.", ContentHash: "h1"},
+	}}
+	e := &Engine{
+		Client:         central.client(),
+		Store:          store,
+		EdgeInstance:   "laptop",
+		CheckpointPath: filepath.Join(t.TempDir(), "checkpoint.json"),
+	}
+	_, err := e.Sync(ctx)
+	require.NoError(t, err)
+
+	t.Run("merges concurrent changes", func(t *testing.T) {
+		rec := *central.records["a"]
+		rec.Content, rec.ContentHash = "Intro.\nCentral.", "h2"
+		central.save(&rec)
+		store.docs["a"].Content = "Edge.\nIntro."
+		store.docs["a"].ContentHash = "h3"
+
+		central.merge = func(
+			cur *apiclient.EdgeDocumentRecord, d *apiclient.EdgeSyncDocument,
+		) *apiclient.EdgeDocumentRecord {
+			merged := *cur
+			merged.Content = "Edge.\nIntro.\nCentral."
+			merged.ContentHash = "h4"
+			return &merged
+		}
+		defer func() { central.merge = nil }()
+
+		res, err := e.Sync(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, res.Merged)
+		assert.Empty(t, res.Conflicts)
+		assert.Equal(t, "Edge.\nIntro.\nCentral.", store.docs["a"].Content)
+		assert.Equal(t, "h4", store.docs["a"].ContentHash)
+
+		res, err = e.Sync(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, Result{Documents: 2}, *res, "merged documents are synced")
+	})
+
+	t.Run("pulls content changed on central", func(t *testing.T) {
+		rec := *central.records["b"]
+		rec.Content, rec.ContentHash = "Intro, revised.", "h5"
+		central.save(&rec)
+
+		res, err := e.Sync(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, res.Pulled)
+		assert.Equal(t, "Intro, revised.", store.docs["b"].Content)
+		assert.Equal(t, "h5", store.docs["b"].ContentHash)
+	})
+}
+
+func TestEngineWaitForEvents(t *testing.T) {
+	ctx := context.Background()
+	central := newFakeCentral()
+	engine := &Engine{
+		Client:         central.client(),
+		Store:          &fakeStore{docs: map[string]*Document{}},
+		EdgeInstance:   "laptop",
+		CheckpointPath: filepath.Join(t.TempDir(), "checkpoint.json"),
+	}
+
+	events, err := engine.WaitForEvents(ctx, time.Second)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	central.events = []*apiclient.EdgeEvent{
+		{ID: 1, EdgeInstance: "laptop", Type: "document.approved"},
+		{ID: 2, EdgeInstance: "office", Type: "document.approved"},
+		{ID: 3, EdgeInstance: "laptop", Type: "document.permissions_changed"},
+	}
+	events, err = engine.WaitForEvents(ctx, time.Second)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, int64(1), events[0].ID)
+	assert.Equal(t, int64(3), events[1].ID)
+
+	cp, err := LoadCheckpoint(engine.CheckpointPath)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), cp.EventCursor)
+
+	events, err = engine.WaitForEvents(ctx, time.Second)
+	require.NoError(t, err)
+	assert.Empty(t, events, "events are only returned once")
+}
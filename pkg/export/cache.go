@@ -0,0 +1,101 @@
+package export
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+
+	"github.com/hashicorp-forge/hermes/pkg/workspace"
+)
+
+// Cache is an in-memory LRU cache of rendered exports keyed by CacheKey.
+// Because keys are derived from content, entries never need to be
+// invalidated; edited or renamed documents simply produce new keys and stale
+// entries age out.
+type Cache struct {
+	mu         sync.Mutex
+	maxEntries int
+	maxBytes   int
+	size       int
+	entries    map[string]*list.Element
+	order      *list.List
+}
+
+type cacheEntry struct {
+	key  string
+	data []byte
+}
+
+// NewCache creates a cache that holds at most maxEntries entries and maxBytes
+// bytes of rendered data.
+func NewCache(maxEntries, maxBytes int) *Cache {
+	return &Cache{
+		maxEntries: maxEntries,
+		maxBytes:   maxBytes,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+	}
+}
+
+// CacheKey returns the cache key for the content of the document with ID docID
+// rendered in format f. Rendered artifacts embed the document title, so the
+// key covers the document, its title, and the hash of its body.
+func CacheKey(docID string, content *workspace.DocumentContent, f Format) string {
+	h := sha256.New()
+	for _, s := range []string{docID, content.Title, ContentHash(content)} {
+		h.Write([]byte(s))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil)) + "." + string(f)
+}
+
+// Get returns the rendered data for a key.
+func (c *Cache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return el.Value.(*cacheEntry).data, true
+}
+
+// Put adds rendered data for a key, evicting the least recently used entries
+// if the cache is full. Data larger than the cache is not stored.
+func (c *Cache) Put(key string, data []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(data) > c.maxBytes {
+		return
+	}
+
+	if el, ok := c.entries[key]; ok {
+		c.size -= len(el.Value.(*cacheEntry).data)
+		el.Value.(*cacheEntry).data = data
+		c.size += len(data)
+		c.order.MoveToFront(el)
+	} else {
+		c.entries[key] = c.order.PushFront(&cacheEntry{key: key, data: data})
+		c.size += len(data)
+	}
+
+	for c.order.Len() > c.maxEntries || c.size > c.maxBytes {
+		el := c.order.Back()
+		e := el.Value.(*cacheEntry)
+		c.order.Remove(el)
+		delete(c.entries, e.key)
+		c.size -= len(e.data)
+	}
+}
+
+// Len returns the number of cached entries.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
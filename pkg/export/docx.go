@@ -0,0 +1,118 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+const docxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/>
+</Types>`
+
+const docxRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/>
+</Relationships>`
+
+// renderDOCX renders blocks as a minimal Office Open XML document. Formatting
+// is applied directly to runs so the document doesn't need a styles part.
+func renderDOCX(blocks []block) ([]byte, error) {
+	var body bytes.Buffer
+	for _, bl := range blocks {
+		switch bl.kind {
+		case blockHeading:
+			size := []int{40, 32, 28, 24, 22, 22}[bl.level-1]
+			docxParagraph(&body, inlineText(bl.text), docxRun{bold: true, size: size}, 0)
+		case blockListItem:
+			marker := "• "
+			if bl.ordered {
+				marker = bl.number + ". "
+			}
+			docxParagraph(&body, marker+inlineText(bl.text), docxRun{}, 360)
+		case blockQuote:
+			docxParagraph(&body, inlineText(bl.text), docxRun{italic: true}, 720)
+		case blockCode:
+			for _, line := range strings.Split(bl.text, "\n") {
+				docxParagraph(&body, line, docxRun{mono: true, size: 18}, 240)
+			}
+		case blockRule:
+			docxParagraph(&body, strings.Repeat("_", 60), docxRun{}, 0)
+		default:
+			docxParagraph(&body, inlineText(bl.text), docxRun{}, 0)
+		}
+	}
+
+	var doc bytes.Buffer
+	doc.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n")
+	doc.WriteString(`<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">`)
+	doc.WriteString("<w:body>")
+	doc.Write(body.Bytes())
+	doc.WriteString("</w:body></w:document>")
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, f := range []struct {
+		name string
+		data []byte
+	}{
+		{"[Content_Types].xml", []byte(docxContentTypes)},
+		{"_rels/.rels", []byte(docxRels)},
+		{"word/document.xml", doc.Bytes()},
+	} {
+		w, err := zw.Create(f.name)
+		if err != nil {
+			return nil, fmt.Errorf("error creating %s: %w", f.name, err)
+		}
+		if _, err := w.Write(f.data); err != nil {
+			return nil, fmt.Errorf("error writing %s: %w", f.name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("error closing docx archive: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// docxRun is run formatting. Size is in half-points; zero uses the default.
+type docxRun struct {
+	bold   bool
+	italic bool
+	mono   bool
+	size   int
+}
+
+// docxParagraph writes a paragraph with a single run of text. Indent is in
+// twentieths of a point.
+func docxParagraph(b *bytes.Buffer, text string, run docxRun, indent int) {
+	b.WriteString("<w:p>")
+	if indent > 0 {
+		fmt.Fprintf(b, `<w:pPr><w:ind w:left="%d"/></w:pPr>`, indent)
+	}
+	b.WriteString("<w:r>")
+	if run.bold || run.italic || run.mono || run.size > 0 {
+		b.WriteString("<w:rPr>")
+		if run.mono {
+			b.WriteString(`<w:rFonts w:ascii="Courier New" w:hAnsi="Courier New"/>`)
+		}
+		if run.bold {
+			b.WriteString("<w:b/>")
+		}
+		if run.italic {
+			b.WriteString("<w:i/>")
+		}
+		if run.size > 0 {
+			fmt.Fprintf(b, `<w:sz w:val="%d"/>`, run.size)
+		}
+		b.WriteString("</w:rPr>")
+	}
+	b.WriteString(`<w:t xml:space="preserve">`)
+	xml.EscapeText(b, []byte(text))
+	b.WriteString("</w:t></w:r></w:p>")
+}
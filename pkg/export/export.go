@@ -0,0 +1,132 @@
+// Package export renders document content into downloadable formats
+// (Markdown, HTML, PDF, and DOCX).
+//
+// Content is read from a workspace provider as either Markdown (local, S3,
+// and mock providers) or plain text (Google Docs). It is parsed into a small
+// block model (headings, paragraphs, list items, code blocks, quotes, and
+// rules) that each renderer walks. Renderers use only the standard library so
+// exports work without external converters.
+//
+// Example usage:
+//
+//	f, err := export.ParseFormat("pdf")
+//	if err != nil {
+//	    return err
+//	}
+//	data, err := export.Render(content, f)
+package export
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp-forge/hermes/pkg/workspace"
+)
+
+// Format is an export format.
+type Format string
+
+const (
+	FormatMarkdown Format = "md"
+	FormatHTML     Format = "html"
+	FormatPDF      Format = "pdf"
+	FormatDOCX     Format = "docx"
+)
+
+// Formats is the list of supported export formats.
+var Formats = []Format{FormatMarkdown, FormatHTML, FormatPDF, FormatDOCX}
+
+// ParseFormat parses an export format. An empty string defaults to Markdown.
+func ParseFormat(s string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "md", "markdown":
+		return FormatMarkdown, nil
+	case "html":
+		return FormatHTML, nil
+	case "pdf":
+		return FormatPDF, nil
+	case "docx":
+		return FormatDOCX, nil
+	default:
+		return "", fmt.Errorf("unsupported export format %q", s)
+	}
+}
+
+// ContentType returns the MIME type for the format.
+func (f Format) ContentType() string {
+	switch f {
+	case FormatHTML:
+		return "text/html; charset=utf-8"
+	case FormatPDF:
+		return "application/pdf"
+	case FormatDOCX:
+		return "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+	default:
+		return "text/markdown; charset=utf-8"
+	}
+}
+
+// Extension returns the file extension for the format, without a leading dot.
+func (f Format) Extension() string {
+	return string(f)
+}
+
+// Render converts document content into the requested format.
+func Render(content *workspace.DocumentContent, f Format) ([]byte, error) {
+	if content == nil {
+		return nil, fmt.Errorf("content is required")
+	}
+
+	switch f {
+	case FormatMarkdown:
+		return []byte(content.Body), nil
+	case FormatHTML:
+		return renderHTML(content.Title, parseContent(content)), nil
+	case FormatPDF:
+		return renderPDF(content.Title, parseContent(content)), nil
+	case FormatDOCX:
+		return renderDOCX(parseContent(content))
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", f)
+	}
+}
+
+// ContentHash returns the hash of the body of content: the provider's content
+// hash if set, or the SHA-256 hash of the body otherwise.
+func ContentHash(content *workspace.DocumentContent) string {
+	if content.ContentHash != "" {
+		return strings.TrimPrefix(content.ContentHash, "sha256:")
+	}
+	sum := sha256.Sum256([]byte(content.Body))
+	return hex.EncodeToString(sum[:])
+}
+
+// Filename returns a safe download filename for a document title.
+func Filename(title string, f Format) string {
+	var b strings.Builder
+	for _, r := range title {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9',
+			r == '-', r == '_', r == '.':
+			b.WriteRune(r)
+		case r == ' ':
+			b.WriteRune('_')
+		}
+	}
+	name := strings.Trim(b.String(), "._")
+	if name == "" {
+		name = "document"
+	}
+	return name + "." + f.Extension()
+}
+
+// parseContent parses document content into blocks based on its source
+// format.
+func parseContent(content *workspace.DocumentContent) []block {
+	if content.Format == "markdown" {
+		return parseMarkdown(content.Body)
+	}
+	return parsePlainText(content.Body)
+}
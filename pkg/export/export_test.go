@@ -0,0 +1,193 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/pkg/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMarkdown = "# Title\n\nSome **bold** and *italic* text with `code`.\n" +
+	"Continued line.\n\n- one\n- two\n\n1. first\n\n> quoted\n\n---\n\n" +
+	"```\nfunc main() {}\n```\n\n[link](https://example.com) [bad](javascript:alert(1))\n"
+
+func TestParseFormat(t *testing.T) {
+	for in, want := range map[string]Format{
+		"":         FormatMarkdown,
+		"md":       FormatMarkdown,
+		"markdown": FormatMarkdown,
+		"HTML":     FormatHTML,
+		"pdf":      FormatPDF,
+		"docx":     FormatDOCX,
+	} {
+		got, err := ParseFormat(in)
+		assert.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+
+	_, err := ParseFormat("rtf")
+	assert.Error(t, err)
+}
+
+func TestParseMarkdown(t *testing.T) {
+	blocks := parseMarkdown(testMarkdown)
+
+	kinds := make([]blockKind, len(blocks))
+	for i, b := range blocks {
+		kinds[i] = b.kind
+	}
+	assert.Equal(t, []blockKind{
+		blockHeading, blockParagraph, blockListItem, blockListItem,
+		blockListItem, blockQuote, blockRule, blockCode, blockParagraph,
+	}, kinds)
+
+	assert.Equal(t, "Title", blocks[0].text)
+	assert.Equal(t,
+		"Some **bold** and *italic* text with `code`. Continued line.",
+		blocks[1].text)
+	assert.True(t, blocks[4].ordered)
+	assert.Equal(t, "1", blocks[4].number)
+	assert.Equal(t, "func main() {}", blocks[7].text)
+}
+
+func TestRenderHTML(t *testing.T) {
+	out, err := Render(&workspace.DocumentContent{
+		Title:  "Doc <1>",
+		Body:   testMarkdown,
+		Format: "markdown",
+	}, FormatHTML)
+	require.NoError(t, err)
+
+	html := string(out)
+	assert.Contains(t, html, "<title>Doc &lt;1&gt;</title>")
+	assert.Contains(t, html, "<h1>Title</h1>")
+	assert.Contains(t, html,
+		"<p>Some <strong>bold</strong> and <em>italic</em> text with <code>code</code>. Continued line.</p>")
+	assert.Contains(t, html, "<ul>\n<li>one</li>\n<li>two</li>\n</ul>")
+	assert.Contains(t, html, "<ol>\n<li>first</li>\n</ol>")
+	assert.Contains(t, html, "<pre><code>func main() {}</code></pre>")
+	assert.Contains(t, html, `<a href="https://example.com">link</a>`)
+	assert.NotContains(t, html, "javascript:")
+}
+
+func TestRenderPlainText(t *testing.T) {
+	out, err := Render(&workspace.DocumentContent{
+		Body:   "First paragraph\n\nSecond <paragraph>\n",
+		Format: "richtext",
+	}, FormatHTML)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(out), "<p>First paragraph</p>")
+	assert.Contains(t, string(out), "<p>Second &lt;paragraph&gt;</p>")
+}
+
+func TestRenderPDF(t *testing.T) {
+	// Enough content to span multiple pages.
+	body := strings.Repeat("A paragraph with (parentheses) and more words.\n\n", 100)
+	out, err := Render(&workspace.DocumentContent{
+		Title:  "Doc",
+		Body:   body,
+		Format: "markdown",
+	}, FormatPDF)
+	require.NoError(t, err)
+
+	pdf := string(out)
+	assert.True(t, strings.HasPrefix(pdf, "%PDF-1.4\n"))
+	assert.True(t, strings.HasSuffix(pdf, "%%EOF\n"))
+	assert.Contains(t, pdf, `\(parentheses\)`)
+	assert.NotContains(t, pdf, "/Count 1 ")
+}
+
+func TestRenderDOCX(t *testing.T) {
+	out, err := Render(&workspace.DocumentContent{
+		Body:   testMarkdown,
+		Format: "markdown",
+	}, FormatDOCX)
+	require.NoError(t, err)
+
+	zr, err := zip.NewReader(bytes.NewReader(out), int64(len(out)))
+	require.NoError(t, err)
+
+	files := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		b, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+		files[f.Name] = string(b)
+	}
+	require.Contains(t, files, "[Content_Types].xml")
+	require.Contains(t, files, "_rels/.rels")
+	require.Contains(t, files, "word/document.xml")
+	assert.Contains(t, files["word/document.xml"], "Some bold and italic text with code.")
+	assert.Contains(t, files["word/document.xml"], "func main() {}")
+}
+
+func TestFilename(t *testing.T) {
+	assert.Equal(t, "My_RFC_v2.pdf", Filename("My RFC: v2", FormatPDF))
+	assert.Equal(t, "document.md", Filename("???", FormatMarkdown))
+}
+
+func TestContentHash(t *testing.T) {
+	assert.Equal(t, "abc",
+		ContentHash(&workspace.DocumentContent{ContentHash: "sha256:abc"}))
+
+	a := ContentHash(&workspace.DocumentContent{Body: "a"})
+	b := ContentHash(&workspace.DocumentContent{Body: "b"})
+	assert.Len(t, a, 64)
+	assert.NotEqual(t, a, b)
+}
+
+func TestCacheKey(t *testing.T) {
+	content := &workspace.DocumentContent{
+		Title:       "RFC",
+		Body:        "body",
+		ContentHash: "sha256:abc",
+	}
+	key := CacheKey("doc1", content, FormatPDF)
+	assert.Equal(t, key, CacheKey("doc1", content, FormatPDF))
+	assert.True(t, strings.HasSuffix(key, ".pdf"))
+
+	// Documents with the same body have different keys.
+	assert.NotEqual(t, key, CacheKey("doc2", content, FormatPDF))
+
+	// Renamed documents have different keys.
+	renamed := *content
+	renamed.Title = "Renamed RFC"
+	assert.NotEqual(t, key, CacheKey("doc1", &renamed, FormatPDF))
+
+	assert.NotEqual(t, key, CacheKey("doc1", content, FormatHTML))
+}
+
+func TestCache(t *testing.T) {
+	c := NewCache(2, 10)
+
+	c.Put("a", []byte("1"))
+	c.Put("b", []byte("2"))
+	_, ok := c.Get("a") // a is now most recently used
+	assert.True(t, ok)
+
+	c.Put("c", []byte("3"))
+	assert.Equal(t, 2, c.Len())
+	_, ok = c.Get("b")
+	assert.False(t, ok, "least recently used entry should be evicted")
+
+	// Entries larger than the cache are not stored.
+	c.Put("big", bytes.Repeat([]byte("x"), 11))
+	_, ok = c.Get("big")
+	assert.False(t, ok)
+
+	// Byte limit evicts older entries.
+	c.Put("d", bytes.Repeat([]byte("x"), 9))
+	_, ok = c.Get("a")
+	assert.False(t, ok)
+	data, ok := c.Get("d")
+	assert.True(t, ok)
+	assert.Len(t, data, 9)
+}
@@ -0,0 +1,60 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+)
+
+// renderHTML renders blocks as a standalone HTML document.
+func renderHTML(title string, blocks []block) []byte {
+	var b bytes.Buffer
+
+	b.WriteString("<!DOCTYPE html>\n<html>\n<head>\n")
+	b.WriteString(`<meta charset="utf-8">` + "\n")
+	fmt.Fprintf(&b, "<title>%s</title>\n", html.EscapeString(title))
+	b.WriteString("</head>\n<body>\n")
+
+	// Group consecutive list items into lists.
+	var openList string
+	closeList := func() {
+		if openList != "" {
+			fmt.Fprintf(&b, "</%s>\n", openList)
+			openList = ""
+		}
+	}
+
+	for _, bl := range blocks {
+		if bl.kind == blockListItem {
+			tag := "ul"
+			if bl.ordered {
+				tag = "ol"
+			}
+			if tag != openList {
+				closeList()
+				fmt.Fprintf(&b, "<%s>\n", tag)
+				openList = tag
+			}
+			fmt.Fprintf(&b, "<li>%s</li>\n", inlineHTML(bl.text))
+			continue
+		}
+		closeList()
+
+		switch bl.kind {
+		case blockHeading:
+			fmt.Fprintf(&b, "<h%d>%s</h%d>\n", bl.level, inlineHTML(bl.text), bl.level)
+		case blockCode:
+			fmt.Fprintf(&b, "<pre><code>%s</code></pre>\n", html.EscapeString(bl.text))
+		case blockQuote:
+			fmt.Fprintf(&b, "<blockquote>%s</blockquote>\n", inlineHTML(bl.text))
+		case blockRule:
+			b.WriteString("<hr>\n")
+		default:
+			fmt.Fprintf(&b, "<p>%s</p>\n", inlineHTML(bl.text))
+		}
+	}
+	closeList()
+
+	b.WriteString("</body>\n</html>\n")
+	return b.Bytes()
+}
@@ -0,0 +1,201 @@
+package export
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// blockKind is the kind of a content block.
+type blockKind int
+
+const (
+	blockParagraph blockKind = iota
+	blockHeading
+	blockListItem
+	blockCode
+	blockQuote
+	blockRule
+)
+
+// block is a block-level element of a document.
+type block struct {
+	kind blockKind
+
+	// level is the heading level (1-6) for headings.
+	level int
+
+	// ordered is true for numbered list items, and number is the item number.
+	ordered bool
+	number  string
+
+	// text is the block text. Inline Markdown is preserved for paragraphs,
+	// headings, list items, and quotes; code blocks contain raw text.
+	text string
+}
+
+var (
+	headingRe     = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	unorderedRe   = regexp.MustCompile(`^\s*[-*+]\s+(.*)$`)
+	orderedRe     = regexp.MustCompile(`^\s*(\d+)[.)]\s+(.*)$`)
+	quoteRe       = regexp.MustCompile(`^\s*>\s?(.*)$`)
+	ruleRe        = regexp.MustCompile(`^\s*([-*_])(\s*([-*_])){2,}\s*$`)
+	codeSpanRe    = regexp.MustCompile("`([^`]+)`")
+	linkRe        = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	boldRe        = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	italicRe      = regexp.MustCompile(`\*([^*]+)\*|\b_([^_]+)_\b`)
+	placeholderRe = regexp.MustCompile("\x00(\\d+)\x00")
+)
+
+// parseMarkdown parses a subset of Markdown into blocks.
+func parseMarkdown(src string) []block {
+	var (
+		blocks    []block
+		para      []string
+		inCode    bool
+		fence     string
+		codeLines []string
+	)
+
+	flush := func() {
+		if len(para) > 0 {
+			blocks = append(blocks, block{
+				kind: blockParagraph,
+				text: strings.Join(para, " "),
+			})
+			para = nil
+		}
+	}
+
+	for _, line := range strings.Split(src, "\n") {
+		line = strings.TrimRight(line, "\r")
+		trimmed := strings.TrimSpace(line)
+
+		if inCode {
+			if strings.HasPrefix(trimmed, fence) {
+				blocks = append(blocks, block{
+					kind: blockCode,
+					text: strings.Join(codeLines, "\n"),
+				})
+				inCode, codeLines = false, nil
+				continue
+			}
+			codeLines = append(codeLines, line)
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(trimmed, "```"), strings.HasPrefix(trimmed, "~~~"):
+			flush()
+			inCode, fence = true, trimmed[:3]
+		case trimmed == "":
+			flush()
+		case headingRe.MatchString(trimmed):
+			flush()
+			m := headingRe.FindStringSubmatch(trimmed)
+			blocks = append(blocks, block{
+				kind:  blockHeading,
+				level: len(m[1]),
+				text:  m[2],
+			})
+		case ruleRe.MatchString(trimmed):
+			flush()
+			blocks = append(blocks, block{kind: blockRule})
+		case unorderedRe.MatchString(line):
+			flush()
+			blocks = append(blocks, block{
+				kind: blockListItem,
+				text: unorderedRe.FindStringSubmatch(line)[1],
+			})
+		case orderedRe.MatchString(line):
+			flush()
+			m := orderedRe.FindStringSubmatch(line)
+			blocks = append(blocks, block{
+				kind:    blockListItem,
+				ordered: true,
+				number:  m[1],
+				text:    m[2],
+			})
+		case quoteRe.MatchString(line):
+			flush()
+			blocks = append(blocks, block{
+				kind: blockQuote,
+				text: quoteRe.FindStringSubmatch(line)[1],
+			})
+		default:
+			para = append(para, trimmed)
+		}
+	}
+
+	// An unterminated code fence runs to the end of the document.
+	if inCode {
+		blocks = append(blocks, block{
+			kind: blockCode,
+			text: strings.Join(codeLines, "\n"),
+		})
+	}
+	flush()
+
+	return blocks
+}
+
+// parsePlainText parses plain text into one paragraph per non-empty line.
+func parsePlainText(src string) []block {
+	var blocks []block
+	for _, line := range strings.Split(src, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			blocks = append(blocks, block{kind: blockParagraph, text: line})
+		}
+	}
+	return blocks
+}
+
+// inlineHTML renders inline Markdown (code spans, links, bold, and italics)
+// as escaped HTML.
+func inlineHTML(s string) string {
+	// Pull out code spans first so their contents are not formatted.
+	var spans []string
+	s = codeSpanRe.ReplaceAllStringFunc(s, func(m string) string {
+		spans = append(spans, "<code>"+html.EscapeString(m[1:len(m)-1])+"</code>")
+		return "\x00" + strconv.Itoa(len(spans)-1) + "\x00"
+	})
+
+	s = html.EscapeString(s)
+	s = linkRe.ReplaceAllStringFunc(s, func(m string) string {
+		sm := linkRe.FindStringSubmatch(m)
+		if !safeURL(html.UnescapeString(sm[2])) {
+			return sm[1]
+		}
+		return `<a href="` + sm[2] + `">` + sm[1] + `</a>`
+	})
+	s = boldRe.ReplaceAllString(s, "<strong>$1$2</strong>")
+	s = italicRe.ReplaceAllString(s, "<em>$1$2</em>")
+
+	return placeholderRe.ReplaceAllStringFunc(s, func(m string) string {
+		i, _ := strconv.Atoi(m[1 : len(m)-1])
+		return spans[i]
+	})
+}
+
+// inlineText strips inline Markdown, leaving plain text. Link targets are
+// kept in parentheses after the link text.
+func inlineText(s string) string {
+	s = codeSpanRe.ReplaceAllString(s, "$1")
+	s = linkRe.ReplaceAllString(s, "$1 ($2)")
+	s = boldRe.ReplaceAllString(s, "$1$2")
+	s = italicRe.ReplaceAllString(s, "$1$2")
+	return s
+}
+
+// safeURL returns true if a link target uses a scheme that is safe to render
+// (http, https, or mailto) or is relative.
+func safeURL(u string) bool {
+	lower := strings.ToLower(strings.TrimSpace(u))
+	if i := strings.IndexAny(lower, ":/?#"); i == -1 || lower[i] != ':' {
+		return true
+	}
+	return strings.HasPrefix(lower, "http:") ||
+		strings.HasPrefix(lower, "https:") ||
+		strings.HasPrefix(lower, "mailto:")
+}
@@ -0,0 +1,218 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// PDF page layout, in points (US Letter with 1 inch margins).
+const (
+	pdfPageWidth  = 612
+	pdfPageHeight = 792
+	pdfMargin     = 72
+)
+
+// PDF fonts. The standard 14 fonts don't need to be embedded.
+const (
+	pdfFontRegular = "F1"
+	pdfFontBold    = "F2"
+	pdfFontMono    = "F3"
+)
+
+// pdfLine is a single line of text to draw.
+type pdfLine struct {
+	font   string
+	size   float64
+	indent float64
+	text   string
+}
+
+// renderPDF renders blocks as a text-only PDF document.
+func renderPDF(title string, blocks []block) []byte {
+	var lines []pdfLine
+	for _, bl := range blocks {
+		lines = append(lines, pdfBlockLines(bl)...)
+		// Blank line between blocks.
+		lines = append(lines, pdfLine{font: pdfFontRegular, size: 6})
+	}
+
+	// Lay out lines on pages.
+	var pages [][]byte
+	var page bytes.Buffer
+	y := float64(pdfPageHeight - pdfMargin)
+	newPage := func() {
+		pages = append(pages, append([]byte(nil), page.Bytes()...))
+		page.Reset()
+		y = pdfPageHeight - pdfMargin
+	}
+	for _, l := range lines {
+		leading := l.size * 1.3
+		if y-leading < pdfMargin {
+			newPage()
+		}
+		y -= leading
+		if l.text == "" {
+			continue
+		}
+		fmt.Fprintf(&page, "BT /%s %.1f Tf %.1f %.1f Td (%s) Tj ET\n",
+			l.font, l.size, pdfMargin+l.indent, y, pdfEscape(l.text))
+	}
+	if page.Len() > 0 || len(pages) == 0 {
+		newPage()
+	}
+
+	return pdfDocument(title, pages)
+}
+
+// pdfBlockLines converts a block into wrapped lines.
+func pdfBlockLines(bl block) []pdfLine {
+	switch bl.kind {
+	case blockHeading:
+		size := []float64{20, 16, 14, 12, 11, 11}[bl.level-1]
+		return pdfWrap(inlineText(bl.text), pdfFontBold, size, 0)
+	case blockListItem:
+		marker := "- "
+		if bl.ordered {
+			marker = bl.number + ". "
+		}
+		return pdfWrap(marker+inlineText(bl.text), pdfFontRegular, 11, 18)
+	case blockQuote:
+		return pdfWrap(inlineText(bl.text), pdfFontRegular, 11, 24)
+	case blockCode:
+		var lines []pdfLine
+		for _, s := range strings.Split(bl.text, "\n") {
+			lines = append(lines, pdfWrap(s, pdfFontMono, 9, 12)...)
+		}
+		return lines
+	case blockRule:
+		return []pdfLine{{
+			font: pdfFontRegular,
+			size: 11,
+			text: strings.Repeat("_", 78),
+		}}
+	default:
+		return pdfWrap(inlineText(bl.text), pdfFontRegular, 11, 0)
+	}
+}
+
+// pdfWrap wraps text to the page width using an approximate character width
+// for the font.
+func pdfWrap(text, font string, size, indent float64) []pdfLine {
+	charWidth := size * 0.5
+	if font == pdfFontMono {
+		charWidth = size * 0.6
+	} else if font == pdfFontBold {
+		charWidth = size * 0.55
+	}
+	maxChars := int((pdfPageWidth - 2*pdfMargin - indent) / charWidth)
+
+	newLine := func(s string) pdfLine {
+		return pdfLine{font: font, size: size, indent: indent, text: s}
+	}
+
+	// Preserve leading whitespace in code.
+	if font == pdfFontMono {
+		var lines []pdfLine
+		r := []rune(text)
+		for len(r) > maxChars {
+			lines = append(lines, newLine(string(r[:maxChars])))
+			r = r[maxChars:]
+		}
+		return append(lines, newLine(string(r)))
+	}
+
+	var (
+		lines []pdfLine
+		cur   string
+	)
+	for _, word := range strings.Fields(text) {
+		switch {
+		case cur == "":
+			cur = word
+		case len([]rune(cur))+1+len([]rune(word)) <= maxChars:
+			cur += " " + word
+		default:
+			lines = append(lines, newLine(cur))
+			cur = word
+		}
+	}
+	return append(lines, newLine(cur))
+}
+
+// pdfEscape escapes a string for use in a PDF literal string. Characters
+// outside of Latin-1 can't be represented with the standard fonts and are
+// replaced with "?".
+func pdfEscape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\t':
+			b.WriteString("    ")
+		case r < 0x20:
+			// Drop control characters.
+		case r < 0x80:
+			b.WriteRune(r)
+		case r <= 0xFF:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+// pdfDocument assembles page content streams into a PDF file.
+func pdfDocument(title string, pages [][]byte) []byte {
+	var (
+		buf     bytes.Buffer
+		offsets []int
+	)
+	obj := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buf.WriteString("%PDF-1.4\n")
+
+	// Object numbers: 1 catalog, 2 pages, 3-5 fonts, 6 info, then a page and
+	// content stream object for each page.
+	const firstPageObj = 7
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPageObj+2*i)
+	}
+
+	obj("<< /Type /Catalog /Pages 2 0 R >>")
+	obj(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>",
+		strings.Join(kids, " "), len(pages)))
+	obj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	obj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	obj("<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>")
+	obj(fmt.Sprintf("<< /Title (%s) /Producer (Hermes) >>", pdfEscape(title)))
+
+	for i, content := range pages {
+		obj(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] "+
+			"/Resources << /Font << /%s 3 0 R /%s 4 0 R /%s 5 0 R >> >> "+
+			"/Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight,
+			pdfFontRegular, pdfFontBold, pdfFontMono,
+			firstPageObj+2*i+1))
+		obj(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream",
+			len(content), content))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R /Info 6 0 R >>\n",
+		len(offsets)+1)
+	fmt.Fprintf(&buf, "startxref\n%d\n%%%%EOF\n", xref)
+
+	return buf.Bytes()
+}
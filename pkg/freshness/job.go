@@ -0,0 +1,167 @@
+package freshness
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp-forge/hermes/pkg/search"
+	"github.com/hashicorp/go-hclog"
+	"gorm.io/gorm"
+)
+
+// Job periodically scores all documents and updates their freshness in the
+// search index. Scores decay over time, so they are recomputed on every run
+// rather than when documents change. Documents reindexed between runs are
+// unscored until the next run.
+type Job struct {
+	db             *gorm.DB
+	searchProvider search.Provider
+	logger         hclog.Logger
+	interval       time.Duration
+}
+
+// DefaultInterval is how often documents are scored by default.
+const DefaultInterval = time.Hour
+
+// Config contains freshness job configuration.
+type Config struct {
+	// Interval is how often documents are scored.
+	Interval time.Duration
+}
+
+// NewJob creates a new freshness job.
+func NewJob(
+	db *gorm.DB,
+	searchProvider search.Provider,
+	logger hclog.Logger,
+	cfg *Config,
+) *Job {
+	if logger == nil {
+		logger = hclog.NewNullLogger()
+	}
+	interval := DefaultInterval
+	if cfg != nil && cfg.Interval > 0 {
+		interval = cfg.Interval
+	}
+
+	return &Job{
+		db:             db,
+		searchProvider: searchProvider,
+		logger:         logger.Named("freshness"),
+		interval:       interval,
+	}
+}
+
+// Start scores documents immediately and then every interval until ctx is
+// canceled.
+func (j *Job) Start(ctx context.Context) error {
+	j.logger.Info("freshness job started", "interval", j.interval)
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := j.Run(ctx)
+		if err != nil {
+			j.logger.Error("error scoring document freshness", "error", err)
+		} else {
+			j.logger.Info("document freshness scored", "documents", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			j.logger.Info("freshness job stopped")
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// documentSignals are the freshness signals for a document.
+type documentSignals struct {
+	GoogleFileID       string
+	Status             models.DocumentStatus
+	DocumentModifiedAt time.Time
+	OwnerLastActiveAt  *time.Time
+	OwnerDeparted      bool
+	BrokenLinks        int
+}
+
+// Run scores all documents once and returns the number of documents updated
+// in the search index.
+func (j *Job) Run(ctx context.Context) (int, error) {
+	docs, ok := j.searchProvider.DocumentIndex().(search.DocumentUpdater)
+	if !ok {
+		return 0, fmt.Errorf("search provider %q does not support updating "+
+			"document fields", j.searchProvider.Name())
+	}
+	drafts, _ := j.searchProvider.DraftIndex().(search.DocumentUpdater)
+
+	var signals []documentSignals
+	if err := j.db.WithContext(ctx).
+		Table("documents AS d").
+		Select(`d.google_file_id, d.status, d.document_modified_at,
+			(SELECT MAX(o.document_modified_at) FROM documents o
+				WHERE o.owner_id = d.owner_id AND o.deleted_at IS NULL)
+				AS owner_last_active_at,
+			EXISTS (SELECT 1 FROM users u
+				JOIN users_directory ud ON ud.email_address = LOWER(u.email_address)
+				WHERE u.id = d.owner_id AND ud.deleted_at IS NOT NULL)
+				AS owner_departed,
+			(SELECT COUNT(*) FROM document_broken_links b
+				WHERE b.document_id = d.id) AS broken_links`).
+		Where("d.deleted_at IS NULL").
+		Scan(&signals).
+		Error; err != nil {
+		return 0, fmt.Errorf("error getting document freshness signals: %w", err)
+	}
+
+	now := time.Now()
+	updated := 0
+	for _, s := range signals {
+		if ctx.Err() != nil {
+			return updated, ctx.Err()
+		}
+
+		idx := docs
+		if s.Status == models.WIPDocumentStatus {
+			if drafts == nil {
+				continue
+			}
+			idx = drafts
+		}
+
+		score := Score(s.inputs(), now)
+		if err := idx.UpdateFields(ctx, s.GoogleFileID, map[string]any{
+			"freshnessScore": score,
+			"freshness":      Bucket(score),
+		}); err != nil {
+			if !errors.Is(err, search.ErrNotFound) {
+				j.logger.Warn("error updating document freshness",
+					"error", err,
+					"doc_id", s.GoogleFileID)
+			}
+			continue
+		}
+		updated++
+	}
+
+	return updated, nil
+}
+
+// inputs returns the scoring inputs for a document.
+func (s documentSignals) inputs() Inputs {
+	in := Inputs{
+		ModifiedAt:    s.DocumentModifiedAt,
+		OwnerDeparted: s.OwnerDeparted,
+		BrokenLinks:   s.BrokenLinks,
+		Status:        s.Status,
+	}
+	if s.OwnerLastActiveAt != nil {
+		in.OwnerLastActiveAt = *s.OwnerLastActiveAt
+	}
+	return in
+}
@@ -0,0 +1,123 @@
+// Package freshness scores how current documents are so that readers can
+// prefer up-to-date documents in search.
+package freshness
+
+import (
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/models"
+)
+
+// Freshness buckets used for faceting.
+const (
+	BucketFresh = "fresh"
+	BucketAging = "aging"
+	BucketStale = "stale"
+)
+
+// Score component weights. They add up to the maximum score of 100.
+const (
+	modifiedWeight    = 40
+	ownerWeight       = 20
+	brokenLinksWeight = 20
+	statusWeight      = 20
+)
+
+const (
+	day = 24 * time.Hour
+
+	// Documents modified within modifiedFullCredit get full credit for
+	// recency, decaying linearly to none at modifiedNoCredit.
+	modifiedFullCredit = 90 * day
+	modifiedNoCredit   = 2 * 365 * day
+
+	// Owners active within ownerFullCredit get full credit, decaying linearly
+	// to none at ownerNoCredit.
+	ownerFullCredit = 180 * day
+	ownerNoCredit   = 365 * day
+
+	// brokenLinkPenalty is subtracted for each broken link.
+	brokenLinkPenalty = 5
+
+	// obsoleteMaxScore caps the score of obsolete documents so they are
+	// always stale.
+	obsoleteMaxScore = 20
+)
+
+// Inputs are the signals used to score a document.
+type Inputs struct {
+	// ModifiedAt is when the document was last modified.
+	ModifiedAt time.Time
+
+	// OwnerLastActiveAt is when the document's owner last modified any
+	// document, or zero if unknown.
+	OwnerLastActiveAt time.Time
+
+	// OwnerDeparted is true if the owner is no longer in the people
+	// directory.
+	OwnerDeparted bool
+
+	// BrokenLinks is the number of broken outbound links in the document.
+	BrokenLinks int
+
+	// Status is the document's review status.
+	Status models.DocumentStatus
+}
+
+// Score returns a freshness score from 0 (stale) to 100 (fresh) as of now.
+func Score(in Inputs, now time.Time) int {
+	score := decay(now.Sub(in.ModifiedAt), modifiedFullCredit, modifiedNoCredit,
+		modifiedWeight)
+
+	switch {
+	case in.OwnerDeparted:
+	case in.OwnerLastActiveAt.IsZero():
+		// Give partial credit when owner activity is unknown.
+		score += ownerWeight / 2
+	default:
+		score += decay(now.Sub(in.OwnerLastActiveAt), ownerFullCredit,
+			ownerNoCredit, ownerWeight)
+	}
+
+	if links := brokenLinksWeight - in.BrokenLinks*brokenLinkPenalty; links > 0 {
+		score += links
+	}
+
+	switch in.Status {
+	case models.ApprovedDocumentStatus:
+		score += statusWeight
+	case models.InReviewDocumentStatus:
+		score += statusWeight * 3 / 4
+	case models.WIPDocumentStatus:
+		score += statusWeight / 2
+	case models.ObsoleteDocumentStatus:
+		if score > obsoleteMaxScore {
+			score = obsoleteMaxScore
+		}
+	}
+
+	return score
+}
+
+// Bucket returns the freshness bucket for a score.
+func Bucket(score int) string {
+	switch {
+	case score >= 70:
+		return BucketFresh
+	case score >= 40:
+		return BucketAging
+	default:
+		return BucketStale
+	}
+}
+
+// decay returns weight for ages up to full, decaying linearly to zero at none.
+func decay(age, full, none time.Duration, weight int) int {
+	switch {
+	case age <= full:
+		return weight
+	case age >= none:
+		return 0
+	}
+	return int(float64(weight) * float64(none-age) / float64(none-full))
+}
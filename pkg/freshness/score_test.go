@@ -0,0 +1,87 @@
+package freshness
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScore(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	daysAgo := func(n int) time.Time {
+		return now.Add(-time.Duration(n) * day)
+	}
+
+	cases := map[string]struct {
+		in   Inputs
+		want int
+	}{
+		"recent approved document with active owner": {
+			in: Inputs{
+				ModifiedAt:        daysAgo(10),
+				OwnerLastActiveAt: daysAgo(1),
+				Status:            models.ApprovedDocumentStatus,
+			},
+			want: 100,
+		},
+		"broken links reduce the score": {
+			in: Inputs{
+				ModifiedAt:        daysAgo(10),
+				OwnerLastActiveAt: daysAgo(1),
+				BrokenLinks:       2,
+				Status:            models.ApprovedDocumentStatus,
+			},
+			want: 90,
+		},
+		"unknown owner activity gets partial credit": {
+			in: Inputs{
+				ModifiedAt: daysAgo(10),
+				Status:     models.InReviewDocumentStatus,
+			},
+			want: 40 + 10 + 20 + 15,
+		},
+		"departed owner gets no credit": {
+			in: Inputs{
+				ModifiedAt:        daysAgo(10),
+				OwnerLastActiveAt: daysAgo(1),
+				OwnerDeparted:     true,
+				Status:            models.WIPDocumentStatus,
+			},
+			want: 40 + 0 + 20 + 10,
+		},
+		"modification recency decays": {
+			in: Inputs{
+				ModifiedAt:        daysAgo(90 + 320),
+				OwnerLastActiveAt: daysAgo(365),
+				BrokenLinks:       4,
+				Status:            models.ApprovedDocumentStatus,
+			},
+			want: 20 + 0 + 0 + 20,
+		},
+		"obsolete documents are capped": {
+			in: Inputs{
+				ModifiedAt:        daysAgo(1),
+				OwnerLastActiveAt: daysAgo(1),
+				Status:            models.ObsoleteDocumentStatus,
+			},
+			want: 20,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, c.want, Score(c.in, now))
+		})
+	}
+}
+
+func TestBucket(t *testing.T) {
+	assert.Equal(t, BucketFresh, Bucket(100))
+	assert.Equal(t, BucketFresh, Bucket(70))
+	assert.Equal(t, BucketAging, Bucket(69))
+	assert.Equal(t, BucketAging, Bucket(40))
+	assert.Equal(t, BucketStale, Bucket(39))
+	assert.Equal(t, BucketStale, Bucket(0))
+}
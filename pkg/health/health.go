@@ -0,0 +1,165 @@
+// Package health serves the liveness and readiness of a Hermes process.
+//
+// The liveness endpoint (/healthz) reports whether the process is serving
+// requests and doesn't check dependencies, so an unavailable dependency
+// doesn't get the process restarted. The readiness endpoint (/readyz) checks
+// each registered dependency (e.g., the database, search provider, workspace
+// provider, and Kafka) and reports the result of each check, so the process
+// is taken out of rotation while a dependency is unavailable.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultTimeout is the default timeout of each dependency check.
+const DefaultTimeout = 5 * time.Second
+
+// Check checks a dependency and returns an error if it's unavailable.
+type Check func(ctx context.Context) error
+
+// Checker checks the dependencies of a process. Checks may be added while
+// the handlers are serving requests (e.g., for dependencies that are started
+// after the HTTP server).
+type Checker struct {
+	// Timeout is the timeout of each check. Zero uses DefaultTimeout.
+	Timeout time.Duration
+
+	mu     sync.RWMutex
+	checks map[string]Check
+}
+
+// NewChecker returns a checker with no checks.
+func NewChecker() *Checker {
+	return &Checker{checks: map[string]Check{}}
+}
+
+// Add adds the check of the dependency name, replacing any check with the
+// same name.
+func (c *Checker) Add(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks[name] = check
+}
+
+// Status values.
+const (
+	StatusOK          = "ok"
+	StatusUnavailable = "unavailable"
+)
+
+// Result is the result of checking a dependency.
+type Result struct {
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"durationMs"`
+}
+
+// Report is the result of checking all dependencies.
+type Report struct {
+	Status string            `json:"status"`
+	Checks map[string]Result `json:"checks,omitempty"`
+}
+
+// Run runs all checks concurrently and returns their results. The status of
+// the report is StatusOK if all checks passed.
+func (c *Checker) Run(ctx context.Context) Report {
+	c.mu.RLock()
+	names := make([]string, 0, len(c.checks))
+	checks := make([]Check, 0, len(c.checks))
+	for name := range c.checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		checks = append(checks, c.checks[name])
+	}
+	c.mu.RUnlock()
+
+	timeout := c.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+
+	results := make([]Result, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = run(ctx, timeout, check)
+		}()
+	}
+	wg.Wait()
+
+	r := Report{Status: StatusOK, Checks: make(map[string]Result, len(names))}
+	for i, name := range names {
+		r.Checks[name] = results[i]
+		if results[i].Status != StatusOK {
+			r.Status = StatusUnavailable
+		}
+	}
+	return r
+}
+
+// run runs check with timeout. Checks that don't return when the timeout
+// expires fail.
+func run(ctx context.Context, timeout time.Duration, check Check) Result {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	errc := make(chan error, 1)
+	go func() {
+		errc <- check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-errc:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	r := Result{
+		Status:     StatusOK,
+		DurationMs: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		r.Status = StatusUnavailable
+		r.Error = err.Error()
+	}
+	return r
+}
+
+// LivenessHandler responds with 200 OK while the process is serving requests.
+func LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeReport(w, http.StatusOK, Report{Status: StatusOK})
+	})
+}
+
+// ReadinessHandler responds with the result of each check of c, and 200 OK if
+// all checks passed or 503 Service Unavailable otherwise.
+func (c *Checker) ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := c.Run(r.Context())
+		status := http.StatusOK
+		if report.Status != StatusOK {
+			status = http.StatusServiceUnavailable
+		}
+		writeReport(w, status, report)
+	})
+}
+
+func writeReport(w http.ResponseWriter, status int, report Report) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(report)
+}
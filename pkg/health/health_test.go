@@ -0,0 +1,64 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadinessHandler(t *testing.T) {
+	c := NewChecker()
+	c.Timeout = 50 * time.Millisecond
+	c.Add("database", func(ctx context.Context) error { return nil })
+
+	serve := func() (int, Report) {
+		w := httptest.NewRecorder()
+		c.ReadinessHandler().ServeHTTP(w, httptest.NewRequest("GET", "/readyz", nil))
+		var report Report
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&report))
+		return w.Code, report
+	}
+
+	t.Run("Ready", func(t *testing.T) {
+		code, report := serve()
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, StatusOK, report.Status)
+		assert.Equal(t, StatusOK, report.Checks["database"].Status)
+	})
+
+	t.Run("Dependency unavailable", func(t *testing.T) {
+		c.Add("search", func(ctx context.Context) error {
+			return errors.New("connection refused")
+		})
+		c.Add("kafka", func(ctx context.Context) error {
+			<-make(chan struct{}) // Never returns.
+			return nil
+		})
+
+		code, report := serve()
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, StatusUnavailable, report.Status)
+		assert.Equal(t, StatusOK, report.Checks["database"].Status)
+		assert.Equal(t, Result{
+			Status: StatusUnavailable, Error: "connection refused",
+		}, Result{
+			Status: report.Checks["search"].Status,
+			Error:  report.Checks["search"].Error,
+		})
+		assert.Equal(t, context.DeadlineExceeded.Error(), report.Checks["kafka"].Error)
+	})
+}
+
+func TestLivenessHandler(t *testing.T) {
+	w := httptest.NewRecorder()
+	LivenessHandler().ServeHTTP(w, httptest.NewRequest("GET", "/healthz", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"status":"ok"}`, w.Body.String())
+}
@@ -0,0 +1,113 @@
+// Package httpcache caches the responses of expensive read endpoints for a
+// short time, in memory or in Redis so the cache is shared by all servers.
+package httpcache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	// DefaultTTL is how long responses are cached by default.
+	DefaultTTL = 30 * time.Second
+
+	// DefaultMaxEntries is the default maximum number of responses cached in
+	// memory.
+	DefaultMaxEntries = 10000
+
+	// keyPrefix is the prefix of cache keys, so they can be told apart from
+	// other keys in a shared Redis database.
+	keyPrefix = "hermes:httpcache:"
+)
+
+// ErrNotFound is returned by Store.Get for keys that aren't cached or have
+// expired.
+var ErrNotFound = errors.New("not found in cache")
+
+// Store stores cached values with a TTL.
+type Store interface {
+	// Get returns the value of key, or ErrNotFound.
+	Get(ctx context.Context, key string) ([]byte, error)
+
+	// Set stores value for key until ttl has passed.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// Response is a cached response.
+type Response struct {
+	Status      int    `json:"status"`
+	ContentType string `json:"contentType,omitempty"`
+	Body        []byte `json:"body"`
+}
+
+// Cache caches responses in a store.
+type Cache struct {
+	store  Store
+	ttl    time.Duration
+	logger hclog.Logger
+}
+
+// New creates a cache of responses in store that are kept for ttl, or
+// DefaultTTL if ttl is zero.
+func New(store Store, ttl time.Duration, logger hclog.Logger) *Cache {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	if logger == nil {
+		logger = hclog.NewNullLogger()
+	}
+	return &Cache{
+		store:  store,
+		ttl:    ttl,
+		logger: logger.Named("httpcache"),
+	}
+}
+
+// Key returns the cache key of the response to a request for endpoint that is
+// identified by parts (e.g., the user, URL, and request body).
+func Key(endpoint string, parts ...[]byte) string {
+	h := sha256.New()
+	for _, p := range parts {
+		fmt.Fprintf(h, "%d:", len(p))
+		h.Write(p)
+	}
+	return keyPrefix + endpoint + ":" + hex.EncodeToString(h.Sum(nil))
+}
+
+// Get returns the cached response for key, if any. Store errors are logged
+// and treated as cache misses, so an unavailable cache doesn't fail requests.
+func (c *Cache) Get(ctx context.Context, key string) (*Response, bool) {
+	b, err := c.store.Get(ctx, key)
+	if err != nil {
+		if !errors.Is(err, ErrNotFound) {
+			c.logger.Warn("error getting cached response", "error", err)
+		}
+		return nil, false
+	}
+
+	var resp Response
+	if err := json.Unmarshal(b, &resp); err != nil {
+		c.logger.Warn("error decoding cached response", "error", err)
+		return nil, false
+	}
+	return &resp, true
+}
+
+// Set caches resp for key. Store errors are logged.
+func (c *Cache) Set(ctx context.Context, key string, resp *Response) {
+	b, err := json.Marshal(resp)
+	if err != nil {
+		c.logger.Warn("error encoding response", "error", err)
+		return
+	}
+	if err := c.store.Set(ctx, key, b, c.ttl); err != nil {
+		c.logger.Warn("error caching response", "error", err)
+	}
+}
@@ -0,0 +1,72 @@
+package httpcache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	s := NewMemoryStore(2)
+	s.now = func() time.Time { return now }
+
+	_, err := s.Get(ctx, "a")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, s.Set(ctx, "a", []byte("1"), time.Minute))
+	require.NoError(t, s.Set(ctx, "b", []byte("2"), time.Second))
+	v, err := s.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("1"), v)
+
+	t.Run("least recently used values are evicted", func(t *testing.T) {
+		require.NoError(t, s.Set(ctx, "c", []byte("3"), time.Minute))
+		assert.Equal(t, 2, s.Len())
+		_, err := s.Get(ctx, "b")
+		assert.ErrorIs(t, err, ErrNotFound)
+		_, err = s.Get(ctx, "a")
+		assert.NoError(t, err)
+	})
+
+	t.Run("values expire", func(t *testing.T) {
+		now = now.Add(time.Minute)
+		_, err := s.Get(ctx, "a")
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.Equal(t, 1, s.Len())
+	})
+}
+
+func TestCache(t *testing.T) {
+	ctx := context.Background()
+	c := New(NewMemoryStore(0), 0, nil)
+	assert.Equal(t, DefaultTTL, c.ttl)
+
+	key := Key("people", []byte("user@example.com"), []byte(`{"query":"a"}`))
+	_, ok := c.Get(ctx, key)
+	assert.False(t, ok)
+
+	want := &Response{
+		Status:      200,
+		ContentType: "application/json",
+		Body:        []byte(`[]`),
+	}
+	c.Set(ctx, key, want)
+	got, ok := c.Get(ctx, key)
+	require.True(t, ok)
+	assert.Equal(t, want, got)
+}
+
+func TestKey(t *testing.T) {
+	assert.Equal(t, Key("people", []byte("a")), Key("people", []byte("a")))
+	assert.NotEqual(t, Key("people", []byte("a")), Key("groups", []byte("a")))
+
+	// Parts are delimited, so moving bytes between parts changes the key.
+	assert.NotEqual(t,
+		Key("people", []byte("ab"), []byte("c")),
+		Key("people", []byte("a"), []byte("bc")))
+}
@@ -0,0 +1,236 @@
+package httpcache
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"time"
+)
+
+const (
+	// defaultRedisTimeout is the default timeout of Redis commands.
+	defaultRedisTimeout = time.Second
+
+	// defaultRedisPoolSize is the default number of idle Redis connections
+	// kept open.
+	defaultRedisPoolSize = 8
+)
+
+// RedisConfig is the configuration of a Redis store.
+type RedisConfig struct {
+	// Address is the host and port of the Redis server.
+	Address string
+
+	// Password authenticates with the Redis server, if set.
+	Password string
+
+	// DB is the number of the Redis database.
+	DB int
+
+	// Timeout is the timeout of commands, including dialing (default: 1s).
+	Timeout time.Duration
+
+	// PoolSize is the number of idle connections kept open (default: 8).
+	PoolSize int
+}
+
+// RedisStore stores values in Redis, so they're shared between servers. It
+// speaks the Redis protocol (RESP) directly and only uses the commands needed
+// by the cache.
+type RedisStore struct {
+	cfg  RedisConfig
+	pool chan *redisConn
+}
+
+// NewRedisStore creates a Redis store. Connections are opened when needed.
+func NewRedisStore(cfg RedisConfig) *RedisStore {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultRedisTimeout
+	}
+	if cfg.PoolSize <= 0 {
+		cfg.PoolSize = defaultRedisPoolSize
+	}
+	return &RedisStore{
+		cfg:  cfg,
+		pool: make(chan *redisConn, cfg.PoolSize),
+	}
+}
+
+// Get returns the value of key, or ErrNotFound.
+func (s *RedisStore) Get(ctx context.Context, key string) ([]byte, error) {
+	reply, err := s.do(ctx, "GET", key)
+	if err != nil {
+		return nil, err
+	}
+	if reply == nil {
+		return nil, ErrNotFound
+	}
+	b, ok := reply.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("unexpected reply to GET: %v", reply)
+	}
+	return b, nil
+}
+
+// Set stores value for key until ttl has passed.
+func (s *RedisStore) Set(
+	ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	_, err := s.do(ctx, "SET", key, string(value),
+		"PX", strconv.FormatInt(max(ttl.Milliseconds(), 1), 10))
+	return err
+}
+
+// Ping checks that the Redis server is reachable.
+func (s *RedisStore) Ping(ctx context.Context) error {
+	_, err := s.do(ctx, "PING")
+	return err
+}
+
+// Close closes the idle connections.
+func (s *RedisStore) Close() error {
+	for {
+		select {
+		case c := <-s.pool:
+			c.Close()
+		default:
+			return nil
+		}
+	}
+}
+
+// do runs a command on a pooled connection and returns its reply.
+func (s *RedisStore) do(
+	ctx context.Context, args ...string) (any, error) {
+	c, err := s.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := c.do(ctx, s.cfg.Timeout, args...)
+	var redisErr redisError
+	if err != nil && !errors.As(err, &redisErr) {
+		// The connection may be in an unknown state.
+		c.Close()
+		return nil, err
+	}
+	s.put(c)
+	return reply, err
+}
+
+// get returns an idle connection, or opens one.
+func (s *RedisStore) get(ctx context.Context) (*redisConn, error) {
+	select {
+	case c := <-s.pool:
+		return c, nil
+	default:
+	}
+
+	d := net.Dialer{Timeout: s.cfg.Timeout}
+	nc, err := d.DialContext(ctx, "tcp", s.cfg.Address)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to Redis: %w", err)
+	}
+	c := &redisConn{Conn: nc, r: bufio.NewReader(nc)}
+
+	if s.cfg.Password != "" {
+		if _, err := c.do(ctx, s.cfg.Timeout, "AUTH", s.cfg.Password); err != nil {
+			c.Close()
+			return nil, fmt.Errorf("error authenticating with Redis: %w", err)
+		}
+	}
+	if s.cfg.DB != 0 {
+		if _, err := c.do(
+			ctx, s.cfg.Timeout, "SELECT", strconv.Itoa(s.cfg.DB)); err != nil {
+			c.Close()
+			return nil, fmt.Errorf("error selecting Redis database: %w", err)
+		}
+	}
+	return c, nil
+}
+
+// put returns a connection to the pool, or closes it if the pool is full.
+func (s *RedisStore) put(c *redisConn) {
+	select {
+	case s.pool <- c:
+	default:
+		c.Close()
+	}
+}
+
+// redisError is an error reply from Redis.
+type redisError string
+
+func (e redisError) Error() string {
+	return "redis: " + string(e)
+}
+
+type redisConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+// do writes a command and reads its reply.
+func (c *redisConn) do(
+	ctx context.Context, timeout time.Duration, args ...string) (any, error) {
+	deadline := time.Now().Add(timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := c.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	// Commands are sent as arrays of bulk strings.
+	buf := []byte("*" + strconv.Itoa(len(args)) + "\r\n")
+	for _, a := range args {
+		buf = append(buf, "$"+strconv.Itoa(len(a))+"\r\n"...)
+		buf = append(buf, a...)
+		buf = append(buf, "\r\n"...)
+	}
+	if _, err := c.Write(buf); err != nil {
+		return nil, err
+	}
+	return c.readReply()
+}
+
+// readReply reads a reply. Simple strings are returned as strings, bulk
+// strings as byte slices, integers as int64, and nil bulk strings as nil.
+// Arrays aren't returned by the commands used by the store.
+func (c *redisConn) readReply() (any, error) {
+	line, err := c.r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return nil, fmt.Errorf("invalid reply from Redis: %q", line)
+	}
+	kind, rest := line[0], line[1:len(line)-2]
+
+	switch kind {
+	case '+':
+		return rest, nil
+	case '-':
+		return nil, redisError(rest)
+	case ':':
+		return strconv.ParseInt(rest, 10, 64)
+	case '$':
+		n, err := strconv.Atoi(rest)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bulk string length: %q", rest)
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		b := make([]byte, n+2)
+		if _, err := io.ReadFull(c.r, b); err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	default:
+		return nil, fmt.Errorf("invalid reply from Redis: %q", line)
+	}
+}
@@ -0,0 +1,62 @@
+// Package i18n resolves the locale of localized templates, such as email and
+// notification templates.
+//
+// Locales are BCP 47 language tags like "de" or "pt-BR". A template is looked
+// up for each locale of the fallback chain of the recipient's locale, and the
+// default (English) template is used if there isn't a translation, so
+// translations can be added one template at a time.
+package i18n
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// localeRE matches the language, script, and region subtags of a locale.
+var localeRE = regexp.MustCompile(
+	`^([a-zA-Z]{2,3})(?:[-_]([a-zA-Z]{4}))?(?:[-_]([a-zA-Z]{2}|[0-9]{3}))?$`)
+
+// Normalize returns the canonical form of locale, e.g., "pt-BR" for "pt_br",
+// or an error if it isn't a locale. Empty locales are returned as is.
+func Normalize(locale string) (string, error) {
+	locale = strings.TrimSpace(locale)
+	if locale == "" {
+		return "", nil
+	}
+	m := localeRE.FindStringSubmatch(locale)
+	if m == nil {
+		return "", fmt.Errorf("invalid locale %q", locale)
+	}
+
+	parts := []string{strings.ToLower(m[1])}
+	if m[2] != "" {
+		parts = append(parts,
+			strings.ToUpper(m[2][:1])+strings.ToLower(m[2][1:]))
+	}
+	if m[3] != "" {
+		parts = append(parts, strings.ToUpper(m[3]))
+	}
+	return strings.Join(parts, "-"), nil
+}
+
+// Chain returns the locales whose templates are used for locale, most
+// specific first, e.g., ["zh-Hant-TW", "zh-Hant", "zh"] for "zh-Hant-TW". The
+// default templates are used after the chain. Invalid and empty locales have
+// empty chains.
+func Chain(locale string) []string {
+	locale, err := Normalize(locale)
+	if err != nil || locale == "" {
+		return nil
+	}
+
+	var chain []string
+	for {
+		chain = append(chain, locale)
+		i := strings.LastIndex(locale, "-")
+		if i < 0 {
+			return chain
+		}
+		locale = locale[:i]
+	}
+}
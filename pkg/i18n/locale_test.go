@@ -0,0 +1,43 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]struct {
+		locale  string
+		want    string
+		wantErr bool
+	}{
+		"empty":             {locale: "", want: ""},
+		"language":          {locale: "DE", want: "de"},
+		"region":            {locale: "pt_br", want: "pt-BR"},
+		"script and region": {locale: "zh-hant-tw", want: "zh-Hant-TW"},
+		"numeric region":    {locale: "es-419", want: "es-419"},
+		"invalid":           {locale: "english", wantErr: true},
+		"path":              {locale: "../de", wantErr: true},
+	}
+
+	for name, c := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := Normalize(c.locale)
+			if c.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, c.want, got)
+		})
+	}
+}
+
+func TestChain(t *testing.T) {
+	assert.Equal(t, []string{"zh-Hant-TW", "zh-Hant", "zh"}, Chain("zh_Hant_TW"))
+	assert.Equal(t, []string{"pt-BR", "pt"}, Chain("pt-BR"))
+	assert.Equal(t, []string{"de"}, Chain("de"))
+	assert.Nil(t, Chain(""))
+	assert.Nil(t, Chain("not a locale"))
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/hashicorp-forge/hermes/pkg/document"
 	"github.com/hashicorp-forge/hermes/pkg/indexer"
 	"github.com/hashicorp-forge/hermes/pkg/search"
 )
@@ -22,7 +21,8 @@ const (
 type IndexCommand struct {
 	SearchProvider search.Provider
 	IndexType      IndexType
-	BatchSize      int // For batch operations
+	BatchSize      int // Documents per IndexBatch call
+	Concurrency    int // IndexBatch calls in flight
 }
 
 // Name returns the command name.
@@ -37,7 +37,7 @@ func (c *IndexCommand) Execute(ctx context.Context, doc *indexer.DocumentContext
 	}
 
 	// Convert to search document format
-	searchDoc, err := c.toSearchDocument(doc.Transformed)
+	searchDoc, err := c.toSearchDocument(doc)
 	if err != nil {
 		return fmt.Errorf("failed to convert to search document: %w", err)
 	}
@@ -60,9 +60,11 @@ func (c *IndexCommand) Execute(ctx context.Context, doc *indexer.DocumentContext
 	return nil
 }
 
-// ExecuteBatch implements BatchCommand for batch indexing.
+// ExecuteBatch implements BatchCommand for batch indexing. Documents are
+// indexed in batches of BatchSize, with up to Concurrency batches in flight.
 func (c *IndexCommand) ExecuteBatch(ctx context.Context, docs []*indexer.DocumentContext) error {
 	searchDocs := make([]*search.Document, 0, len(docs))
+	contexts := make(map[string]*indexer.DocumentContext, len(docs))
 
 	for _, doc := range docs {
 		if doc.Transformed == nil {
@@ -70,12 +72,13 @@ func (c *IndexCommand) ExecuteBatch(ctx context.Context, docs []*indexer.Documen
 			continue
 		}
 
-		searchDoc, err := c.toSearchDocument(doc.Transformed)
+		searchDoc, err := c.toSearchDocument(doc)
 		if err != nil {
 			doc.AddError(fmt.Errorf("failed to convert to search document: %w", err))
 			continue
 		}
 		searchDocs = append(searchDocs, searchDoc)
+		contexts[searchDoc.ObjectID] = doc
 	}
 
 	if len(searchDocs) == 0 {
@@ -92,11 +95,28 @@ func (c *IndexCommand) ExecuteBatch(ctx context.Context, docs []*indexer.Documen
 		return fmt.Errorf("unknown index type: %s", c.IndexType)
 	}
 
-	return idx.IndexBatch(ctx, searchDocs)
+	batcher := search.NewBatcher(idx, search.BatchOptions{
+		BatchSize:   c.BatchSize,
+		Concurrency: c.Concurrency,
+		OnError: func(failed []*search.Document, err error) {
+			for _, sd := range failed {
+				if doc, ok := contexts[sd.ObjectID]; ok {
+					doc.AddError(fmt.Errorf("failed to index document: %w", err))
+				}
+			}
+		},
+	})
+	for _, sd := range searchDocs {
+		batcher.Add(ctx, sd)
+	}
+	return batcher.Flush(ctx)
 }
 
-// toSearchDocument converts a document.Document to search.Document
-func (c *IndexCommand) toSearchDocument(doc *document.Document) (*search.Document, error) {
+// toSearchDocument converts the transformed document of docCtx to
+// search.Document
+func (c *IndexCommand) toSearchDocument(docCtx *indexer.DocumentContext) (*search.Document, error) {
+	doc := docCtx.Transformed
+
 	// Create search document from the Hermes document
 	searchDoc := &search.Document{
 		ObjectID:     doc.ObjectID,
@@ -116,6 +136,11 @@ func (c *IndexCommand) toSearchDocument(doc *document.Document) (*search.Documen
 		CustomFields: make(map[string]interface{}),
 	}
 
+	// Drafts shared with everyone can be found by all users.
+	if docCtx.Metadata != nil {
+		searchDoc.ShareableAsDraft = docCtx.Metadata.ShareableAsDraft
+	}
+
 	// Add custom fields
 	for _, cf := range doc.CustomFields {
 		searchDoc.CustomFields[cf.Name] = cf.Value
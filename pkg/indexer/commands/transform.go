@@ -5,8 +5,11 @@ import (
 	"fmt"
 
 	"github.com/hashicorp-forge/hermes/internal/config"
+	"github.com/hashicorp-forge/hermes/pkg/attachments"
 	"github.com/hashicorp-forge/hermes/pkg/document"
 	"github.com/hashicorp-forge/hermes/pkg/indexer"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"gorm.io/gorm"
 )
 
 // TransformCommand converts a workspace document to a search document.
@@ -14,6 +17,7 @@ import (
 // Hermes-specific information.
 type TransformCommand struct {
 	DocumentTypes []*config.DocumentType
+	DB            *gorm.DB // Optional: to include the text of attachments
 }
 
 // Name returns the command name.
@@ -39,6 +43,14 @@ func (c *TransformCommand) Execute(ctx context.Context, doc *indexer.DocumentCon
 
 	// Add content and modified time
 	searchDoc.Content = doc.Content
+	if c.DB != nil {
+		var atts models.DocumentAttachments
+		if err := atts.Find(c.DB.WithContext(ctx), doc.Metadata.ID); err != nil {
+			return fmt.Errorf("failed to get attachments: %w", err)
+		}
+		searchDoc.Content = attachments.WithText(
+			searchDoc.Content, attachments.IndexedText(atts))
+	}
 	searchDoc.ModifiedTime = doc.Document.ModifiedTime.Unix()
 
 	doc.Transformed = searchDoc
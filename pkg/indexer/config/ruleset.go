@@ -4,8 +4,8 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/hashicorp-forge/hermes/internal/config/hclfunc"
 	"github.com/hashicorp-forge/hermes/pkg/indexer/ruleset"
-	"github.com/hashicorp/hcl/v2/hclsimple"
 )
 
 // IndexerConfig represents the indexer configuration from HCL.
@@ -70,7 +70,7 @@ func LoadRulesetsFromFile(filename string) ([]ruleset.Ruleset, error) {
 	}
 
 	var config IndexerConfig
-	err := hclsimple.DecodeFile(filename, nil, &config)
+	err := hclfunc.DecodeFile(filename, &config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse configuration file: %w", err)
 	}
@@ -101,7 +101,7 @@ func LoadIndexerConfig(filename string) (*IndexerConfig, error) {
 	}
 
 	var config IndexerConfig
-	err := hclsimple.DecodeFile(filename, nil, &config)
+	err := hclfunc.DecodeFile(filename, &config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse configuration file: %w", err)
 	}
@@ -150,14 +150,17 @@ func ValidateRulesets(rulesets []ruleset.Ruleset) error {
 
 		// Validate pipeline step names
 		validSteps := map[string]bool{
-			"search_index": true,
-			"llm_summary":  true,
-			"embeddings":   true,
+			"search_index":        true,
+			"llm_summary":         true,
+			"embeddings":          true,
+			"quality_lint":        true,
+			"sensitive_data":      true,
+			"duplicate_detection": true,
 		}
 
 		for _, step := range rs.Pipeline {
 			if !validSteps[step] {
-				return fmt.Errorf("ruleset %s: invalid pipeline step '%s' (valid: search_index, llm_summary, embeddings)", rs.Name, step)
+				return fmt.Errorf("ruleset %s: invalid pipeline step '%s' (valid: search_index, llm_summary, embeddings, quality_lint, sensitive_data, duplicate_detection)", rs.Name, step)
 			}
 		}
 	}
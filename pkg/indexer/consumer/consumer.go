@@ -4,25 +4,34 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/hashicorp-forge/hermes/pkg/indexer/pipeline"
 	"github.com/hashicorp-forge/hermes/pkg/indexer/ruleset"
+	"github.com/hashicorp-forge/hermes/pkg/metrics"
 	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp-forge/hermes/pkg/tracing"
+	"github.com/hashicorp-forge/hermes/pkg/workerstatus"
 	"github.com/hashicorp/go-hclog"
 	"github.com/twmb/franz-go/pkg/kgo"
+	"go.opentelemetry.io/otel/trace"
 	"gorm.io/gorm"
 )
 
 // Consumer consumes document revision events from Redpanda and processes them.
 type Consumer struct {
-	kafkaClient *kgo.Client
-	db          *gorm.DB
-	matcher     *ruleset.Matcher
-	executor    *pipeline.Executor
-	logger      hclog.Logger
-	stopCh      chan struct{}
+	lanes    []*lane
+	db       *gorm.DB
+	matcher  *ruleset.Matcher
+	executor *pipeline.Executor
+	status   *workerstatus.Reporter
+	logger   hclog.Logger
+	stopCh   chan struct{}
+
+	// ready is signaled when a lane has records pending.
+	ready chan struct{}
 }
 
 // Config holds configuration for the consumer.
@@ -35,6 +44,10 @@ type Config struct {
 	Topic         string
 	ConsumerGroup string
 
+	// Lanes are the topics to consume, each with its own consumer group and
+	// priority. Topic is consumed with ConsumerGroup if there are none.
+	Lanes []Lane
+
 	// Consumer offset configuration (optional, defaults to AtEnd for new consumers)
 	// Use AtStart for testing to ensure messages are consumed even if published before consumer joins
 	ConsumeFromStart bool
@@ -43,6 +56,10 @@ type Config struct {
 	Rulesets ruleset.Rulesets
 	Executor *pipeline.Executor
 
+	// Status reports the progress of the consumer to the Hermes API
+	// (optional).
+	Status *workerstatus.Reporter
+
 	// Logger
 	Logger hclog.Logger
 }
@@ -53,12 +70,22 @@ func New(cfg Config) (*Consumer, error) {
 	if len(cfg.Brokers) == 0 {
 		return nil, fmt.Errorf("at least one broker is required")
 	}
-	if cfg.Topic == "" {
-		return nil, fmt.Errorf("topic is required")
-	}
 	if cfg.ConsumerGroup == "" {
 		cfg.ConsumerGroup = "hermes-indexer-workers"
 	}
+	if len(cfg.Lanes) == 0 {
+		if cfg.Topic == "" {
+			return nil, fmt.Errorf("topic is required")
+		}
+		cfg.Lanes = []Lane{{
+			Name:          "default",
+			Topic:         cfg.Topic,
+			ConsumerGroup: cfg.ConsumerGroup,
+		}}
+	}
+	if err := validateLanes(cfg.Lanes); err != nil {
+		return nil, err
+	}
 	if cfg.Logger == nil {
 		cfg.Logger = hclog.NewNullLogger()
 	}
@@ -77,97 +104,211 @@ func New(cfg Config) (*Consumer, error) {
 		offset = kgo.NewOffset().AtStart() // Start from beginning (useful for testing)
 	}
 
-	// Create Kafka consumer client
-	kafkaClient, err := kgo.NewClient(
-		kgo.SeedBrokers(cfg.Brokers...),
-		kgo.ConsumerGroup(cfg.ConsumerGroup),
-		kgo.ConsumeTopics(cfg.Topic),
-
-		// Consumer configuration
-		kgo.ConsumeResetOffset(offset),
-		kgo.SessionTimeout(10*time.Second),
-		kgo.RebalanceTimeout(30*time.Second),
-
-		// Enable auto-commit (commit after successful processing)
-		kgo.DisableAutoCommit(), // We'll commit manually after successful processing
-
-		// Fetch configuration
-		kgo.FetchMaxWait(500*time.Millisecond),
-		kgo.FetchMinBytes(1),
-		kgo.FetchMaxBytes(5<<20), // 5MB
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create kafka client: %w", err)
+	// Create a Kafka consumer client for each lane, from the highest priority
+	// to the lowest.
+	lanes := make([]*lane, 0, len(cfg.Lanes))
+	for _, l := range sortLanes(cfg.Lanes) {
+		kafkaClient, err := kgo.NewClient(
+			kgo.SeedBrokers(cfg.Brokers...),
+			kgo.ConsumerGroup(l.ConsumerGroup),
+			kgo.ConsumeTopics(l.Topic),
+
+			// Consumer configuration
+			kgo.ConsumeResetOffset(offset),
+			kgo.SessionTimeout(10*time.Second),
+			kgo.RebalanceTimeout(30*time.Second),
+
+			// Enable auto-commit (commit after successful processing)
+			kgo.DisableAutoCommit(), // We'll commit manually after successful processing
+
+			// Fetch configuration
+			kgo.FetchMaxWait(500*time.Millisecond),
+			kgo.FetchMinBytes(1),
+			kgo.FetchMaxBytes(5<<20), // 5MB
+		)
+		if err != nil {
+			for _, created := range lanes {
+				created.client.Close()
+			}
+			return nil, fmt.Errorf("failed to create kafka client for lane %s: %w",
+				l.Name, err)
+		}
+		lanes = append(lanes, &lane{
+			Lane:       l,
+			client:     kafkaClient,
+			partitions: make(chan kgo.FetchTopicPartition, 1),
+		})
 	}
 
 	matcher := ruleset.NewMatcher(cfg.Rulesets)
 
 	return &Consumer{
-		kafkaClient: kafkaClient,
-		db:          cfg.DB,
-		matcher:     matcher,
-		executor:    cfg.Executor,
-		logger:      cfg.Logger.Named("indexer-consumer"),
-		stopCh:      make(chan struct{}),
+		lanes:    lanes,
+		db:       cfg.DB,
+		matcher:  matcher,
+		executor: cfg.Executor,
+		status:   cfg.Status,
+		logger:   cfg.Logger.Named("indexer-consumer"),
+		stopCh:   make(chan struct{}),
+		ready:    make(chan struct{}, 1),
 	}, nil
 }
 
-// Start starts the consumer polling loop.
+// Start starts polling the topics of the lanes and processes their records
+// until the context is done or the consumer is stopped. Records of the
+// highest priority lane with records pending are processed first.
 func (c *Consumer) Start(ctx context.Context) error {
-	group, _ := c.kafkaClient.GroupMetadata()
-	c.logger.Info("starting indexer consumer",
-		"consumer_group", group,
-	)
+	for _, l := range c.lanes {
+		c.logger.Info("starting indexer consumer",
+			"lane", l.Name,
+			"topic", l.Topic,
+			"consumer_group", l.ConsumerGroup,
+			"priority", l.Priority,
+		)
+	}
+
+	// Stop polling when processing stops.
+	ctx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+	for _, l := range c.lanes {
+		wg.Add(1)
+		go func(l *lane) {
+			defer wg.Done()
+			c.poll(ctx, l)
+		}(l)
+	}
 
 	for {
-		select {
-		case <-ctx.Done():
-			c.logger.Info("indexer consumer stopped by context")
-			return ctx.Err()
+		l, p, ok := c.next()
+		if !ok {
+			select {
+			case <-ctx.Done():
+				c.logger.Info("indexer consumer stopped by context")
+				return ctx.Err()
+
+			case <-c.stopCh:
+				c.logger.Info("indexer consumer stopped")
+				return nil
+
+			case <-c.ready:
+			}
+			continue
+		}
 
-		case <-c.stopCh:
-			c.logger.Info("indexer consumer stopped")
-			return nil
+		c.processPartition(ctx, l, p)
+	}
+}
 
-		default:
-			// Poll for messages
-			fetches := c.kafkaClient.PollFetches(ctx)
+// poll polls the topic of lane l and queues the records of each partition to
+// be processed, until ctx is done or the consumer is stopped. Polling waits
+// while the lane has records queued, so lanes only fetch records as fast as
+// they're processed.
+func (c *Consumer) poll(ctx context.Context, l *lane) {
+	for {
+		fetches := l.client.PollFetches(ctx)
+		if fetches.IsClientClosed() || ctx.Err() != nil {
+			return
+		}
 
-			// Handle errors
-			if errs := fetches.Errors(); len(errs) > 0 {
-				for _, err := range errs {
-					c.logger.Error("kafka fetch error", "error", err.Err)
-				}
-				continue
+		// Handle errors
+		if errs := fetches.Errors(); len(errs) > 0 {
+			for _, err := range errs {
+				c.logger.Error("kafka fetch error", "lane", l.Name, "error", err.Err)
 			}
+			continue
+		}
 
-			// Process records
-			fetches.EachPartition(func(p kgo.FetchTopicPartition) {
-				for _, record := range p.Records {
-					if err := c.processRecord(ctx, record); err != nil {
-						c.logger.Error("failed to process record",
-							"partition", record.Partition,
-							"offset", record.Offset,
-							"error", err,
-						)
-						// Continue processing other records
-						// TODO: Consider DLQ for permanently failed records
-						continue
-					}
-
-					// Commit offset after successful processing
-					if err := c.kafkaClient.CommitRecords(ctx, record); err != nil {
-						c.logger.Warn("failed to commit Kafka offset",
-							"partition", record.Partition,
-							"offset", record.Offset,
-							"error", err)
-					}
+		var stopped bool
+		fetches.EachPartition(func(p kgo.FetchTopicPartition) {
+			if stopped || len(p.Records) == 0 {
+				return
+			}
+			select {
+			case l.partitions <- p:
+				select {
+				case c.ready <- struct{}{}:
+				default:
 				}
-			})
+			case <-ctx.Done():
+				stopped = true
+			case <-c.stopCh:
+				stopped = true
+			}
+		})
+		if stopped {
+			return
+		}
+	}
+}
+
+// next returns records of the highest priority lane with records queued, if
+// any.
+func (c *Consumer) next() (*lane, kgo.FetchTopicPartition, bool) {
+	for _, l := range c.lanes {
+		select {
+		case p := <-l.partitions:
+			return l, p, true
+		default:
+		}
+	}
+	return nil, kgo.FetchTopicPartition{}, false
+}
+
+// processPartition processes the records fetched from a partition of the topic
+// of lane l, and commits the offsets of the records that were processed
+// successfully.
+func (c *Consumer) processPartition(ctx context.Context, l *lane, p kgo.FetchTopicPartition) {
+	if n := len(p.Records); n > 0 {
+		last := p.Records[n-1].Offset
+		lag := p.HighWatermark - last - 1
+		metrics.SetKafkaConsumerLag(l.ConsumerGroup, p.Topic, p.Partition, lag)
+
+		// Report the progress on the partition once its records are processed.
+		defer c.status.ObservePartition(
+			l.ConsumerGroup, p.Topic, p.Partition, last, lag)
+	}
+	errs := c.processRecords(ctx, p.Records)
+	for i, record := range p.Records {
+		c.status.ObserveRecord(errs[i])
+		if err := errs[i]; err != nil {
+			c.logger.Error("failed to process record",
+				"lane", l.Name,
+				"partition", record.Partition,
+				"offset", record.Offset,
+				"error", err,
+			)
+			// Continue processing other records
+			// TODO: Consider DLQ for permanently failed records
+			continue
+		}
+
+		// Commit offset after successful processing
+		if err := l.client.CommitRecords(ctx, record); err != nil {
+			c.logger.Warn("failed to commit Kafka offset",
+				"lane", l.Name,
+				"partition", record.Partition,
+				"offset", record.Offset,
+				"error", err)
 		}
 	}
 }
 
+// UpdateRulesets validates rulesets and, if they're valid, uses them for
+// records processed from now on. Records already being processed keep the
+// rulesets they matched.
+func (c *Consumer) UpdateRulesets(rulesets ruleset.Rulesets) error {
+	if err := c.matcher.Update(rulesets); err != nil {
+		return fmt.Errorf("invalid rulesets: %w", err)
+	}
+
+	c.logger.Info("updated rulesets", "rulesets", len(rulesets))
+	return nil
+}
+
 // Stop gracefully stops the consumer.
 func (c *Consumer) Stop() {
 	select {
@@ -176,35 +317,113 @@ func (c *Consumer) Stop() {
 		return
 	default:
 		close(c.stopCh)
-		c.kafkaClient.Close()
+		for _, l := range c.lanes {
+			l.client.Close()
+		}
 	}
 }
 
-// processRecord processes a single Kafka record.
-func (c *Consumer) processRecord(ctx context.Context, record *kgo.Record) error {
+// processRecords processes the records fetched from a partition and returns
+// the error of each record. Revisions that matched the same ruleset are
+// executed as a batch, so batch steps (e.g., search indexing) process them
+// together.
+func (c *Consumer) processRecords(ctx context.Context, records []*kgo.Record) []error {
+	errs := make([]error, len(records))
+	spans := make([]trace.Span, len(records))
+	defer func() {
+		for i, span := range spans {
+			tracing.End(span, errs[i])
+		}
+	}()
+
+	// Group the records' revisions by the rulesets they matched, in the order
+	// the rulesets were first matched.
+	type batch struct {
+		ruleset ruleset.Ruleset
+		items   []pipeline.BatchItem
+		records []int
+	}
+	var batches []*batch
+	batchesByName := map[string]*batch{}
+	matchedCounts := make([]int, len(records))
+	revisions := make([]*models.DocumentRevision, len(records))
+
+	for i, record := range records {
+		_, spans[i] = tracing.StartConsume(ctx, record)
+
+		event, revision, matched, err := c.prepareRecord(record)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		revisions[i] = revision
+		matchedCounts[i] = len(matched)
+		for _, rs := range matched {
+			b, ok := batchesByName[rs.Name]
+			if !ok {
+				b = &batch{ruleset: rs}
+				batchesByName[rs.Name] = b
+				batches = append(batches, b)
+			}
+			b.items = append(b.items, pipeline.BatchItem{
+				Revision: revision,
+				OutboxID: uint(event.ID),
+			})
+			b.records = append(b.records, i)
+		}
+	}
+
+	// Execute the pipeline of each ruleset for its batch of revisions.
+	for _, b := range batches {
+		batchErrs := c.executor.ExecuteBatch(ctx, b.items, &b.ruleset)
+		for j, err := range batchErrs {
+			if err == nil {
+				continue
+			}
+			c.logger.Error("pipeline execution failed", "error", err)
+
+			// Keep the first error of each record for retry logic.
+			if i := b.records[j]; errs[i] == nil {
+				errs[i] = fmt.Errorf("ruleset %s: %w", b.ruleset.Name, err)
+			}
+		}
+	}
+
+	for i, revision := range revisions {
+		if revision == nil || matchedCounts[i] == 0 || errs[i] != nil {
+			continue
+		}
+		c.logger.Info("successfully processed revision",
+			"document_uuid", revision.DocumentUUID,
+			"revision_id", revision.ID,
+			"pipelines_executed", matchedCounts[i],
+		)
+	}
+
+	return errs
+}
+
+// prepareRecord parses a Kafka record and matches its revision against the
+// rulesets. No rulesets are returned for records that were already
+// processed or didn't match any rulesets.
+func (c *Consumer) prepareRecord(record *kgo.Record) (*DocumentRevisionEvent, *models.DocumentRevision, []ruleset.Ruleset, error) {
 	c.logger.Debug("processing record",
 		"partition", record.Partition,
 		"offset", record.Offset,
 		"key", string(record.Key),
 	)
 
-	// Deserialize event
-	var event DocumentRevisionEvent
-	if err := json.Unmarshal(record.Value, &event); err != nil {
-		return fmt.Errorf("failed to unmarshal event: %w", err)
-	}
-
-	// Parse document UUID
-	documentUUID, err := uuid.Parse(event.DocumentUUID)
+	event, revision, metadata, err := ParseEvent(record.Value)
 	if err != nil {
-		return fmt.Errorf("invalid document UUID: %w", err)
+		return nil, nil, nil, err
 	}
+	documentUUID := event.DocumentUUID
 
 	// Check for idempotency (only if database is available)
 	if c.db != nil {
 		executions, err := models.GetExecutionsByOutbox(c.db, uint(event.ID))
 		if err != nil && err != gorm.ErrRecordNotFound {
-			return fmt.Errorf("failed to check for existing executions: %w", err)
+			return nil, nil, nil, fmt.Errorf("failed to check for existing executions: %w", err)
 		}
 
 		if len(executions) > 0 {
@@ -213,22 +432,10 @@ func (c *Consumer) processRecord(ctx context.Context, record *kgo.Record) error
 				"outbox_id", event.ID,
 				"executions", len(executions),
 			)
-			return nil
+			return event, revision, nil, nil
 		}
 	}
 
-	// Reconstruct revision from payload (no database fetch needed)
-	revision, err := reconstructRevisionFromPayload(event.Payload)
-	if err != nil {
-		return fmt.Errorf("failed to reconstruct revision from payload: %w", err)
-	}
-
-	// Extract metadata from payload
-	metadata, ok := event.Payload["metadata"].(map[string]interface{})
-	if !ok {
-		metadata = make(map[string]interface{})
-	}
-
 	// Match rulesets
 	matched := c.matcher.Match(revision, metadata)
 
@@ -237,7 +444,7 @@ func (c *Consumer) processRecord(ctx context.Context, record *kgo.Record) error
 			"document_uuid", documentUUID,
 			"revision_id", revision.ID,
 		)
-		return nil
+		return event, revision, nil, nil
 	}
 
 	c.logger.Info("matched rulesets for revision",
@@ -246,26 +453,7 @@ func (c *Consumer) processRecord(ctx context.Context, record *kgo.Record) error
 		"rulesets", len(matched),
 	)
 
-	// Execute pipelines for each matched ruleset
-	errs := c.executor.ExecuteMultiple(ctx, revision, uint(event.ID), matched)
-
-	if len(errs) > 0 {
-		// Log errors but don't fail the entire processing
-		for _, err := range errs {
-			c.logger.Error("pipeline execution failed", "error", err)
-		}
-
-		// Return the first error for retry logic
-		return errs[0]
-	}
-
-	c.logger.Info("successfully processed revision",
-		"document_uuid", documentUUID,
-		"revision_id", revision.ID,
-		"pipelines_executed", len(matched),
-	)
-
-	return nil
+	return event, revision, matched, nil
 }
 
 // DocumentRevisionEvent represents the event structure from Redpanda.
@@ -281,6 +469,64 @@ type DocumentRevisionEvent struct {
 	Timestamp    time.Time              `json:"timestamp"`
 }
 
+// ParseEvent decodes a document revision event and reconstructs the revision
+// and metadata that rulesets are matched against.
+func ParseEvent(data []byte) (*DocumentRevisionEvent, *models.DocumentRevision, map[string]interface{}, error) {
+	var event DocumentRevisionEvent
+	if err := json.Unmarshal(data, &event); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to unmarshal event: %w", err)
+	}
+
+	if _, err := uuid.Parse(event.DocumentUUID); err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid document UUID: %w", err)
+	}
+
+	// Reconstruct revision from payload (no database fetch needed)
+	revision, err := reconstructRevisionFromPayload(event.Payload)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf(
+			"failed to reconstruct revision from payload: %w", err)
+	}
+
+	// Extract metadata from payload
+	metadata, ok := event.Payload["metadata"].(map[string]interface{})
+	if !ok {
+		metadata = make(map[string]interface{})
+	}
+
+	return &event, revision, metadata, nil
+}
+
+// NewEvent builds a document revision event for the given revision in the
+// format read by ParseEvent. It is used to inject synthetic events, so the
+// event has no outbox ID.
+func NewEvent(revision *models.DocumentRevision, eventType string, metadata map[string]interface{}) *DocumentRevisionEvent {
+	payload := map[string]interface{}{
+		"document_uuid": revision.DocumentUUID.String(),
+		"document_id":   revision.DocumentID,
+		"provider_type": revision.ProviderType,
+		"revision": map[string]interface{}{
+			"id":           revision.ID,
+			"content_hash": revision.ContentHash,
+			"title":        revision.Title,
+			"status":       revision.Status,
+		},
+	}
+	if metadata != nil {
+		payload["metadata"] = metadata
+	}
+
+	return &DocumentRevisionEvent{
+		DocumentUUID: revision.DocumentUUID.String(),
+		DocumentID:   revision.DocumentID,
+		EventType:    eventType,
+		ProviderType: revision.ProviderType,
+		ContentHash:  revision.ContentHash,
+		Payload:      payload,
+		Timestamp:    time.Now(),
+	}
+}
+
 // reconstructRevisionFromPayload reconstructs a DocumentRevision from the event payload.
 // This allows the indexer to be database-independent.
 func reconstructRevisionFromPayload(payload map[string]interface{}) (*models.DocumentRevision, error) {
@@ -295,6 +541,8 @@ func reconstructRevisionFromPayload(payload map[string]interface{}) (*models.Doc
 	documentID, _ := payload["document_id"].(string)
 	providerType, _ := payload["provider_type"].(string)
 	contentHash, _ := revisionData["content_hash"].(string)
+	title, _ := revisionData["title"].(string)
+	status, _ := revisionData["status"].(string)
 
 	// Parse UUID
 	parsedUUID, err := uuid.Parse(documentUUID)
@@ -309,6 +557,8 @@ func reconstructRevisionFromPayload(payload map[string]interface{}) (*models.Doc
 		DocumentID:   documentID,
 		ProviderType: providerType,
 		ContentHash:  contentHash,
+		Title:        title,
+		Status:       status,
 	}
 
 	// Add any additional fields from the payload as needed
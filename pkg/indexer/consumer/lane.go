@@ -0,0 +1,74 @@
+package consumer
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+// Lane is a topic consumed by the indexer with its own consumer group and
+// priority, so events of one topic (e.g., a bulk reindex) don't delay the
+// processing of events of another (e.g., fresh edits).
+type Lane struct {
+	// Name identifies the lane in logs.
+	Name string
+
+	// Topic is the topic of the document revision events of the lane.
+	Topic string
+
+	// ConsumerGroup is the Kafka consumer group of the lane.
+	ConsumerGroup string
+
+	// Priority is the priority of the lane. Records of lanes with a higher
+	// priority are processed first.
+	Priority int
+}
+
+// lane is a lane being consumed.
+type lane struct {
+	Lane
+
+	client *kgo.Client
+
+	// partitions are the fetched records of a partition waiting to be
+	// processed.
+	partitions chan kgo.FetchTopicPartition
+}
+
+// validateLanes validates lanes.
+func validateLanes(lanes []Lane) error {
+	names := map[string]bool{}
+	topics := map[string]bool{}
+	for _, l := range lanes {
+		if l.Name == "" {
+			return fmt.Errorf("lane name is required")
+		}
+		if names[l.Name] {
+			return fmt.Errorf("duplicate lane %q", l.Name)
+		}
+		names[l.Name] = true
+		if l.Topic == "" {
+			return fmt.Errorf("lane %q: topic is required", l.Name)
+		}
+		if topics[l.Topic] {
+			return fmt.Errorf("lane %q: topic %q is consumed by another lane",
+				l.Name, l.Topic)
+		}
+		topics[l.Topic] = true
+		if l.ConsumerGroup == "" {
+			return fmt.Errorf("lane %q: consumer group is required", l.Name)
+		}
+	}
+	return nil
+}
+
+// sortLanes returns lanes sorted from the highest priority to the lowest,
+// keeping the order of lanes of equal priority.
+func sortLanes(lanes []Lane) []Lane {
+	sorted := append([]Lane{}, lanes...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Priority > sorted[j].Priority
+	})
+	return sorted
+}
@@ -0,0 +1,66 @@
+package consumer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+func TestSortLanes(t *testing.T) {
+	lanes := []Lane{
+		{Name: "bulk-reindex", Priority: -1},
+		{Name: "revisions", Priority: 10},
+		{Name: "replay"},
+		{Name: "repairs"},
+	}
+	var names []string
+	for _, l := range sortLanes(lanes) {
+		names = append(names, l.Name)
+	}
+	assert.Equal(t,
+		[]string{"revisions", "replay", "repairs", "bulk-reindex"}, names)
+	assert.Equal(t, "bulk-reindex", lanes[0].Name, "lanes must not be sorted in place")
+}
+
+func TestValidateLanes(t *testing.T) {
+	valid := Lane{Name: "revisions", Topic: "revisions", ConsumerGroup: "indexer"}
+	assert.NoError(t, validateLanes([]Lane{valid}))
+
+	for name, lanes := range map[string][]Lane{
+		"missing name":     {{Topic: "t", ConsumerGroup: "g"}},
+		"missing topic":    {{Name: "n", ConsumerGroup: "g"}},
+		"missing group":    {{Name: "n", Topic: "t"}},
+		"duplicate name":   {valid, {Name: "revisions", Topic: "bulk", ConsumerGroup: "g"}},
+		"duplicate topics": {valid, {Name: "bulk", Topic: "revisions", ConsumerGroup: "g"}},
+	} {
+		assert.Error(t, validateLanes(lanes), name)
+	}
+}
+
+func TestConsumerNext(t *testing.T) {
+	newLane := func(name string) *lane {
+		return &lane{
+			Lane:       Lane{Name: name},
+			partitions: make(chan kgo.FetchTopicPartition, 1),
+		}
+	}
+	high, low := newLane("revisions"), newLane("bulk-reindex")
+	c := &Consumer{lanes: []*lane{high, low}}
+
+	_, _, ok := c.next()
+	assert.False(t, ok)
+
+	low.partitions <- kgo.FetchTopicPartition{Topic: "bulk"}
+	high.partitions <- kgo.FetchTopicPartition{Topic: "revisions"}
+
+	l, p, ok := c.next()
+	assert.True(t, ok)
+	assert.Equal(t, high, l)
+	assert.Equal(t, "revisions", p.Topic)
+
+	l, p, ok = c.next()
+	assert.True(t, ok)
+	assert.Equal(t, low, l)
+	assert.Equal(t, "bulk", p.Topic)
+}
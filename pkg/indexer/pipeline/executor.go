@@ -57,110 +57,200 @@ func NewExecutor(cfg ExecutorConfig) (*Executor, error) {
 	}, nil
 }
 
+// BatchStep is an optional interface for steps that can process the
+// revisions of a batch together, e.g., to index them in the search provider
+// with a single request.
+type BatchStep interface {
+	Step
+
+	// ExecuteBatch runs the step for revisions and returns the error of each
+	// revision, in the same order.
+	ExecuteBatch(ctx context.Context, revisions []*models.DocumentRevision, config map[string]interface{}) []error
+}
+
+// BatchItem is a document revision executed as part of a batch.
+type BatchItem struct {
+	Revision *models.DocumentRevision
+	OutboxID uint
+}
+
+// pipelineRun is the state of a pipeline executed for one revision.
+type pipelineRun struct {
+	BatchItem
+	execution *models.DocumentRevisionPipelineExecution
+	firstErr  error // The error of the first failed step, if any.
+	err       error // The error of the run, if it has stopped.
+	stopped   bool
+}
+
 // Execute executes a pipeline for a document revision based on the matched ruleset.
 func (e *Executor) Execute(ctx context.Context, revision *models.DocumentRevision, outboxID uint, rs *ruleset.Ruleset) error {
-	e.logger.Info("executing pipeline",
-		"ruleset", rs.Name,
-		"document_uuid", revision.DocumentUUID,
-		"revision_id", revision.ID,
-		"steps", rs.Pipeline,
-	)
+	return e.ExecuteBatch(ctx, []BatchItem{{Revision: revision, OutboxID: outboxID}}, rs)[0]
+}
 
-	// Create pipeline execution record (only if database is available)
-	var execution *models.DocumentRevisionPipelineExecution
-	if e.db != nil {
-		execution = models.NewPipelineExecution(revision.ID, outboxID, rs.Name, rs.Pipeline)
-		if err := e.db.Create(execution).Error; err != nil {
-			return fmt.Errorf("failed to create pipeline execution: %w", err)
-		}
+// ExecuteBatch executes a pipeline for each of a batch of document revisions
+// that matched the same ruleset, and returns the error of each revision in
+// the same order. Steps that implement BatchStep process the revisions
+// together; other steps process them one by one. Each revision's execution is
+// recorded as if it was executed on its own.
+func (e *Executor) ExecuteBatch(ctx context.Context, items []BatchItem, rs *ruleset.Ruleset) []error {
+	runs := make([]*pipelineRun, len(items))
+	for i, item := range items {
+		run := &pipelineRun{BatchItem: item}
+		runs[i] = run
+
+		e.logger.Info("executing pipeline",
+			"ruleset", rs.Name,
+			"document_uuid", item.Revision.DocumentUUID,
+			"revision_id", item.Revision.ID,
+			"steps", rs.Pipeline,
+		)
+
+		// Create pipeline execution record (only if database is available)
+		if e.db != nil {
+			run.execution = models.NewPipelineExecution(item.Revision.ID, item.OutboxID, rs.Name, rs.Pipeline)
+			if err := e.db.Create(run.execution).Error; err != nil {
+				run.stop(fmt.Errorf("failed to create pipeline execution: %w", err))
+				continue
+			}
 
-		// Mark as running
-		if err := execution.Start(e.db); err != nil {
-			return fmt.Errorf("failed to mark execution as running: %w", err)
+			// Mark as running
+			if err := run.execution.Start(e.db); err != nil {
+				run.stop(fmt.Errorf("failed to mark execution as running: %w", err))
+				continue
+			}
 		}
 	}
 
 	// Execute each step in order
-	allSucceeded := true
-	var firstError error
-
 	for _, stepName := range rs.Pipeline {
+		var active []*pipelineRun
+		for _, run := range runs {
+			if !run.stopped {
+				active = append(active, run)
+			}
+		}
+		if len(active) == 0 {
+			break
+		}
+
 		step, ok := e.steps[stepName]
 		if !ok {
 			err := fmt.Errorf("unknown pipeline step: %s", stepName)
-			if e.db != nil && execution != nil {
-				if markErr := execution.MarkAsFailed(e.db, stepName, err); markErr != nil {
-					e.logger.Warn("failed to mark execution as failed", "error", markErr)
-				}
+			for _, run := range active {
+				e.markFailed(run, stepName, err)
+				run.stop(err)
 			}
-			return err
+			break
 		}
 
 		// Get step-specific config from ruleset
 		stepConfig := rs.GetStepConfig(stepName)
 
 		// Execute the step
-		stepStart := time.Now()
-		err := step.Execute(ctx, revision, stepConfig)
-		stepDuration := time.Since(stepStart)
-
-		if err != nil {
-			e.logger.Error("pipeline step failed",
-				"step", stepName,
-				"ruleset", rs.Name,
-				"document_uuid", revision.DocumentUUID,
-				"error", err,
-			)
-
-			// Record step failure (only if database is available)
-			if e.db != nil && execution != nil {
-				if recordErr := execution.RecordStepResult(e.db, stepName, models.StepStatusFailed, map[string]interface{}{
-					"error":       err.Error(),
-					"duration_ms": stepDuration.Milliseconds(),
-				}); recordErr != nil {
-					e.logger.Warn("failed to record step failure", "step", stepName, "error", recordErr)
-				}
+		if batchStep, ok := step.(BatchStep); ok && len(active) > 1 {
+			revisions := make([]*models.DocumentRevision, len(active))
+			for i, run := range active {
+				revisions[i] = run.Revision
 			}
-
-			allSucceeded = false
-			if firstError == nil {
-				firstError = err
-			}
-
-			// Check if we should continue or fail fast
-			if !step.IsRetryable(err) {
-				// Permanent failure, stop pipeline
-				if e.db != nil && execution != nil {
-					if markErr := execution.MarkAsFailed(e.db, stepName, err); markErr != nil {
-						e.logger.Warn("failed to mark execution as failed", "step", stepName, "error", markErr)
-					}
-				}
-				return fmt.Errorf("pipeline failed at step %s: %w", stepName, err)
+			stepStart := time.Now()
+			errs := batchStep.ExecuteBatch(ctx, revisions, stepConfig)
+			stepDuration := time.Since(stepStart)
+			for i, run := range active {
+				e.recordStepResult(run, step, rs, errs[i], stepDuration)
 			}
-
-			// Continue to next step for retryable errors
 			continue
 		}
+		for _, run := range active {
+			stepStart := time.Now()
+			err := step.Execute(ctx, run.Revision, stepConfig)
+			e.recordStepResult(run, step, rs, err, time.Since(stepStart))
+		}
+	}
 
-		// Record step success
-		e.logger.Debug("pipeline step succeeded",
+	errs := make([]error, len(runs))
+	for i, run := range runs {
+		errs[i] = e.finish(run, rs)
+	}
+	return errs
+}
+
+// recordStepResult records the result of a step executed for a run, and
+// stops the run if the step failed permanently.
+func (e *Executor) recordStepResult(run *pipelineRun, step Step, rs *ruleset.Ruleset, err error, stepDuration time.Duration) {
+	stepName := step.Name()
+	execution := run.execution
+
+	if err != nil {
+		e.logger.Error("pipeline step failed",
 			"step", stepName,
 			"ruleset", rs.Name,
-			"document_uuid", revision.DocumentUUID,
-			"duration_ms", stepDuration.Milliseconds(),
+			"document_uuid", run.Revision.DocumentUUID,
+			"error", err,
 		)
 
+		// Record step failure (only if database is available)
 		if e.db != nil && execution != nil {
-			if recordErr := execution.RecordStepResult(e.db, stepName, models.StepStatusSuccess, map[string]interface{}{
+			if recordErr := execution.RecordStepResult(e.db, stepName, models.StepStatusFailed, map[string]interface{}{
+				"error":       err.Error(),
 				"duration_ms": stepDuration.Milliseconds(),
 			}); recordErr != nil {
-				e.logger.Warn("failed to record step success", "step", stepName, "error", recordErr)
+				e.logger.Warn("failed to record step failure", "step", stepName, "error", recordErr)
 			}
 		}
+
+		if run.firstErr == nil {
+			run.firstErr = err
+		}
+
+		// Check if we should continue or fail fast
+		if !step.IsRetryable(err) {
+			// Permanent failure, stop pipeline
+			e.markFailed(run, stepName, err)
+			run.stop(fmt.Errorf("pipeline failed at step %s: %w", stepName, err))
+		}
+
+		// Continue to next step for retryable errors
+		return
 	}
 
+	// Record step success
+	e.logger.Debug("pipeline step succeeded",
+		"step", stepName,
+		"ruleset", rs.Name,
+		"document_uuid", run.Revision.DocumentUUID,
+		"duration_ms", stepDuration.Milliseconds(),
+	)
+
+	if e.db != nil && execution != nil {
+		if recordErr := execution.RecordStepResult(e.db, stepName, models.StepStatusSuccess, map[string]interface{}{
+			"duration_ms": stepDuration.Milliseconds(),
+		}); recordErr != nil {
+			e.logger.Warn("failed to record step success", "step", stepName, "error", recordErr)
+		}
+	}
+}
+
+// markFailed marks the execution of a run as failed at a step (only if
+// database is available).
+func (e *Executor) markFailed(run *pipelineRun, stepName string, err error) {
+	if e.db != nil && run.execution != nil {
+		if markErr := run.execution.MarkAsFailed(e.db, stepName, err); markErr != nil {
+			e.logger.Warn("failed to mark execution as failed", "step", stepName, "error", markErr)
+		}
+	}
+}
+
+// finish marks the execution of a run that wasn't stopped as completed or
+// partial, and returns the error of the run.
+func (e *Executor) finish(run *pipelineRun, rs *ruleset.Ruleset) error {
+	if run.stopped {
+		return run.err
+	}
+	execution := run.execution
+
 	// Mark execution as completed or partial (only if database is available)
-	if allSucceeded {
+	if run.firstErr == nil {
 		if e.db != nil && execution != nil {
 			if err := execution.MarkAsCompleted(e.db); err != nil {
 				return fmt.Errorf("failed to mark execution as completed: %w", err)
@@ -169,7 +259,7 @@ func (e *Executor) Execute(ctx context.Context, revision *models.DocumentRevisio
 
 		e.logger.Info("pipeline completed successfully",
 			"ruleset", rs.Name,
-			"document_uuid", revision.DocumentUUID,
+			"document_uuid", run.Revision.DocumentUUID,
 			"steps", len(rs.Pipeline),
 		)
 
@@ -185,11 +275,17 @@ func (e *Executor) Execute(ctx context.Context, revision *models.DocumentRevisio
 
 	e.logger.Warn("pipeline completed with failures",
 		"ruleset", rs.Name,
-		"document_uuid", revision.DocumentUUID,
-		"error", firstError,
+		"document_uuid", run.Revision.DocumentUUID,
+		"error", run.firstErr,
 	)
 
-	return firstError
+	return run.firstErr
+}
+
+// stop stops a run with an error.
+func (r *pipelineRun) stop(err error) {
+	r.stopped = true
+	r.err = err
 }
 
 // ExecuteMultiple executes pipelines for multiple matched rulesets.
@@ -267,6 +267,79 @@ func TestExecutor_Execute_UnknownStep(t *testing.T) {
 	assert.True(t, step1.executed, "step1 should have been executed before error")
 }
 
+// MockBatchStep is a test implementation of the BatchStep interface that
+// fails revisions of the document "bad-doc".
+type MockBatchStep struct {
+	MockStep
+	batches [][]string
+}
+
+func (m *MockBatchStep) ExecuteBatch(ctx context.Context, revisions []*models.DocumentRevision, config map[string]interface{}) []error {
+	var ids []string
+	errs := make([]error, len(revisions))
+	for i, revision := range revisions {
+		ids = append(ids, revision.DocumentID)
+		if revision.DocumentID == "bad-doc" {
+			errs[i] = errors.New("batch failure")
+		}
+	}
+	m.batches = append(m.batches, ids)
+	return errs
+}
+
+func TestExecutor_ExecuteBatch(t *testing.T) {
+	db := setupTestDB(t)
+	rev1 := createTestRevision(t, db)
+	rev2 := createTestRevision(t, db)
+	rev2.DocumentID = "bad-doc"
+	require.NoError(t, db.Save(rev2).Error)
+
+	batchStep := &MockBatchStep{MockStep: MockStep{name: "batch"}}
+	step2 := &MockStep{name: "step2"}
+
+	executor, err := NewExecutor(ExecutorConfig{
+		DB:     db,
+		Steps:  []Step{batchStep, step2},
+		Logger: hclog.NewNullLogger(),
+	})
+	require.NoError(t, err)
+
+	rs := &ruleset.Ruleset{
+		Name:     "test-ruleset",
+		Pipeline: []string{"batch", "step2"},
+	}
+
+	errs := executor.ExecuteBatch(context.Background(), []BatchItem{
+		{Revision: rev1, OutboxID: 1},
+		{Revision: rev2, OutboxID: 2},
+	}, rs)
+
+	require.Len(t, errs, 2)
+	assert.NoError(t, errs[0])
+	require.Error(t, errs[1])
+	assert.Contains(t, errs[1].Error(), "pipeline failed at step batch")
+
+	// Revisions are processed together by the batch step, and the failed
+	// revision is stopped before the next step.
+	assert.Equal(t, [][]string{{"test-doc-1", "bad-doc"}}, batchStep.batches)
+	assert.False(t, batchStep.executed)
+	assert.True(t, step2.executed)
+
+	// Each revision's execution is recorded.
+	var exec1, exec2 models.DocumentRevisionPipelineExecution
+	require.NoError(t, db.Where("revision_id = ?", rev1.ID).First(&exec1).Error)
+	assert.Equal(t, models.PipelineStatusCompleted, exec1.Status)
+	require.NoError(t, db.Where("revision_id = ?", rev2.ID).First(&exec2).Error)
+	assert.Equal(t, models.PipelineStatusFailed, exec2.Status)
+
+	t.Run("single revisions are executed by Execute", func(t *testing.T) {
+		batchStep.batches = nil
+		require.NoError(t, executor.Execute(context.Background(), rev1, 3, rs))
+		assert.Empty(t, batchStep.batches)
+		assert.True(t, batchStep.executed)
+	})
+}
+
 // ConfigCapturingStep is a test step that captures the config it receives
 type ConfigCapturingStep struct {
 	name           string
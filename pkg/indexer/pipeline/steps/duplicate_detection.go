@@ -0,0 +1,178 @@
+package steps
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/dedup"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp/go-hclog"
+	"gorm.io/gorm"
+)
+
+// DuplicateDetectionStep fingerprints document content with MinHash and flags
+// documents whose content is nearly the same as the content of other
+// documents, of any product, in the document_duplicates table. Site admins
+// review the flagged documents to consolidate copies.
+//
+// Step config:
+//   - threshold: the similarity from 0 to 1 at or above which documents are
+//     flagged as duplicates (default: 0.8)
+//   - shingle_size: the number of words in a shingle (default: 5)
+//   - min_shingles: the minimum number of shingles of content that is compared
+//     (default: 50)
+type DuplicateDetectionStep struct {
+	db                *gorm.DB
+	workspaceProvider WorkspaceContentProvider
+	logger            hclog.Logger
+}
+
+// NewDuplicateDetectionStep creates a new duplicate detection step.
+func NewDuplicateDetectionStep(db *gorm.DB, workspaceProvider WorkspaceContentProvider, logger hclog.Logger) *DuplicateDetectionStep {
+	if logger == nil {
+		logger = hclog.NewNullLogger()
+	}
+
+	return &DuplicateDetectionStep{
+		db:                db,
+		workspaceProvider: workspaceProvider,
+		logger:            logger.Named("duplicate-detection-step"),
+	}
+}
+
+// Name returns the step name.
+func (s *DuplicateDetectionStep) Name() string {
+	return "duplicate_detection"
+}
+
+// Execute fingerprints the content of the given revision and compares it with
+// the content of other documents.
+func (s *DuplicateDetectionStep) Execute(ctx context.Context, revision *models.DocumentRevision, config map[string]interface{}) error {
+	s.logger.Debug("executing duplicate detection step",
+		"document_uuid", revision.DocumentUUID,
+		"revision_id", revision.ID,
+		"content_hash", revision.ContentHash,
+	)
+
+	threshold := dedup.DefaultThreshold
+	if t := configFloat(config, "threshold"); t != 0 {
+		threshold = t
+	}
+	if threshold <= 0 || threshold > 1 {
+		return fmt.Errorf("invalid duplicate detection threshold %v", threshold)
+	}
+	fingerprintCfg := dedup.Config{
+		ShingleSize: configInt(config, "shingle_size"),
+		MinShingles: configInt(config, "min_shingles"),
+	}
+
+	var doc models.Document
+	if err := s.db.WithContext(ctx).
+		Where("google_file_id = ?", revision.DocumentID).
+		First(&doc).
+		Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			s.logger.Debug("document not found, skipping",
+				"document_uuid", revision.DocumentUUID,
+				"document_id", revision.DocumentID,
+			)
+			return nil
+		}
+		return fmt.Errorf("failed to get document: %w", err)
+	}
+
+	// Skip content that was already fingerprinted.
+	existing := models.DocumentFingerprint{DocumentID: doc.ID}
+	if err := existing.Get(s.db.WithContext(ctx)); err != nil &&
+		!errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("failed to get existing fingerprint: %w", err)
+	}
+	if existing.ID != 0 && revision.ContentHash != "" &&
+		existing.ContentHash == revision.ContentHash {
+		s.logger.Debug("content already fingerprinted, skipping",
+			"document_uuid", revision.DocumentUUID,
+			"content_hash", revision.ContentHash,
+		)
+		return nil
+	}
+
+	if s.workspaceProvider == nil {
+		return fmt.Errorf("workspace provider not configured")
+	}
+	content, err := s.workspaceProvider.GetDocumentContent(revision.DocumentID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch document content: %w", err)
+	}
+
+	sig, shingles := dedup.Fingerprint(content, fingerprintCfg)
+	now := time.Now()
+	fingerprint := models.DocumentFingerprint{
+		DocumentID:      doc.ID,
+		Signature:       sig,
+		Shingles:        shingles,
+		ContentHash:     revision.ContentHash,
+		FingerprintedAt: now,
+	}
+	if err := fingerprint.Upsert(s.db.WithContext(ctx)); err != nil {
+		return fmt.Errorf("failed to save fingerprint: %w", err)
+	}
+
+	var duplicates []models.DocumentDuplicate
+	if sig != nil {
+		var others models.DocumentFingerprints
+		if err := others.FindComparable(s.db.WithContext(ctx), doc.ID); err != nil {
+			return fmt.Errorf("failed to get fingerprints: %w", err)
+		}
+		for _, o := range others {
+			similarity := dedup.Similarity(sig, o.Signature)
+			if similarity >= threshold {
+				duplicates = append(duplicates, models.DocumentDuplicate{
+					DuplicateID: o.DocumentID,
+					Similarity:  similarity,
+					DetectedAt:  now,
+				})
+			}
+		}
+	}
+	if err := models.ReplaceDocumentDuplicates(
+		s.db.WithContext(ctx), doc.ID, duplicates); err != nil {
+		return fmt.Errorf("failed to save duplicates: %w", err)
+	}
+
+	if len(duplicates) > 0 {
+		s.logger.Info("found likely duplicates of document",
+			"document_uuid", revision.DocumentUUID,
+			"revision_id", revision.ID,
+			"duplicates", len(duplicates),
+		)
+	}
+
+	return nil
+}
+
+// IsRetryable determines if an error should trigger a retry.
+func (s *DuplicateDetectionStep) IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	errMsg := strings.ToLower(err.Error())
+
+	// Network errors are retryable
+	if strings.Contains(errMsg, "timeout") ||
+		strings.Contains(errMsg, "connection") {
+		return true
+	}
+
+	// Rate limiting is retryable
+	if strings.Contains(errMsg, "rate limit") ||
+		strings.Contains(errMsg, "too many requests") {
+		return true
+	}
+
+	// Other errors are not retryable (e.g., invalid config)
+	return false
+}
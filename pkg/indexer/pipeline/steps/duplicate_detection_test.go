@@ -0,0 +1,97 @@
+package steps
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp-forge/hermes/pkg/models/modelstest"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDuplicateDetectionStep(t *testing.T) {
+	db := modelstest.NewDB(t)
+
+	rfc := models.DocumentType{Name: "RFC", LongName: "RFC"}
+	require.NoError(t, rfc.FirstOrCreate(db))
+	docs := map[string]*models.Document{}
+	for _, d := range []struct{ id, product string }{
+		{"doc-original", "Hermes"},
+		{"doc-copy", "Vault"},
+		{"doc-other", "Hermes"},
+	} {
+		doc := &models.Document{
+			GoogleFileID: d.id,
+			DocumentType: rfc,
+			Product:      models.Product{Name: d.product, Abbreviation: d.product},
+		}
+		require.NoError(t, doc.Product.FirstOrCreate(db))
+		require.NoError(t, doc.Create(db))
+		docs[d.id] = doc
+	}
+
+	var original strings.Builder
+	for i := 0; i < 30; i++ {
+		fmt.Fprintf(&original, "The rollout of phase %d is gated on error budgets. ", i)
+	}
+	workspace := &MockWorkspaceProvider{
+		Content: map[string]string{
+			"doc-original": original.String(),
+			"doc-copy":     "# Vault rollout\n\n" + original.String(),
+			"doc-other":    strings.Repeat("Search relevance tuning notes. ", 5),
+		},
+	}
+	step := NewDuplicateDetectionStep(db, workspace, hclog.NewNullLogger())
+	assert.Equal(t, "duplicate_detection", step.Name())
+
+	execute := func(docID, contentHash string) {
+		t.Helper()
+		require.NoError(t, step.Execute(context.Background(), &models.DocumentRevision{
+			DocumentUUID: uuid.New(),
+			DocumentID:   docID,
+			ContentHash:  contentHash,
+		}, map[string]interface{}{"min_shingles": 10}))
+	}
+	execute("doc-copy", "hash1")
+	execute("doc-other", "hash2")
+	execute("doc-original", "hash3")
+
+	var dups models.DocumentDuplicates
+	require.NoError(t, dups.Find(db, models.DocumentDuplicateFilter{}))
+	require.Len(t, dups, 1)
+	assert.Equal(t, docs["doc-original"].ID, dups[0].DocumentID)
+	assert.Equal(t, docs["doc-copy"].ID, dups[0].DuplicateID)
+	assert.Equal(t, "Vault", dups[0].Duplicate.Product.Name)
+	assert.GreaterOrEqual(t, dups[0].Similarity, 0.8)
+
+	// Short content isn't compared.
+	var fingerprint models.DocumentFingerprint
+	fingerprint.DocumentID = docs["doc-other"].ID
+	require.NoError(t, fingerprint.Get(db))
+	assert.Nil(t, fingerprint.Signature)
+
+	dups = nil
+	require.NoError(t, dups.Find(db, models.DocumentDuplicateFilter{
+		MinSimilarity: 0.8, CrossProduct: true,
+	}))
+	assert.Len(t, dups, 1)
+
+	// Changed content replaces the duplicates of the document.
+	workspace.Content["doc-copy"] = strings.Repeat("A rewritten plan for Vault. ", 20)
+	execute("doc-copy", "hash4")
+	dups = nil
+	require.NoError(t, dups.Find(db, models.DocumentDuplicateFilter{}))
+	assert.Empty(t, dups)
+
+	// Invalid thresholds fail the step without a retry.
+	err := step.Execute(context.Background(), &models.DocumentRevision{
+		DocumentID: "doc-copy", ContentHash: "hash5",
+	}, map[string]interface{}{"threshold": 2.0})
+	require.Error(t, err)
+	assert.False(t, step.IsRetryable(err))
+}
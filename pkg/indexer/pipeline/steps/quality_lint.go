@@ -0,0 +1,210 @@
+package steps
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/doclint"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp/go-hclog"
+	"gorm.io/gorm"
+)
+
+// QualityLintStep runs lint rules over document content and stores the results
+// as the document's quality checklist in the document_quality_checks table.
+//
+// Step config:
+//   - rules: the rules to run (default: all rules)
+//   - summary_headings: the headings of summary sections (default: ["Summary"])
+//   - todo_markers: the words that mark unfinished content (default: ["TODO",
+//     "FIXME", "TBD", "XXX"])
+type QualityLintStep struct {
+	db                *gorm.DB
+	workspaceProvider WorkspaceContentProvider
+	baseURLs          []string
+	logger            hclog.Logger
+}
+
+// NewQualityLintStep creates a new quality lint step. baseURLs are the Hermes
+// base URL and short link base URL, used to recognize absolute links to Hermes
+// documents.
+func NewQualityLintStep(db *gorm.DB, workspaceProvider WorkspaceContentProvider, baseURLs []string, logger hclog.Logger) *QualityLintStep {
+	if logger == nil {
+		logger = hclog.NewNullLogger()
+	}
+
+	return &QualityLintStep{
+		db:                db,
+		workspaceProvider: workspaceProvider,
+		baseURLs:          baseURLs,
+		logger:            logger.Named("quality-lint-step"),
+	}
+}
+
+// Name returns the step name.
+func (s *QualityLintStep) Name() string {
+	return "quality_lint"
+}
+
+// Execute lints the content of the given revision.
+func (s *QualityLintStep) Execute(ctx context.Context, revision *models.DocumentRevision, config map[string]interface{}) error {
+	s.logger.Debug("executing quality lint step",
+		"document_uuid", revision.DocumentUUID,
+		"revision_id", revision.ID,
+		"content_hash", revision.ContentHash,
+	)
+
+	var doc models.Document
+	if err := s.db.WithContext(ctx).
+		Where("google_file_id = ?", revision.DocumentID).
+		First(&doc).
+		Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			s.logger.Debug("document not found, skipping",
+				"document_uuid", revision.DocumentUUID,
+				"document_id", revision.DocumentID,
+			)
+			return nil
+		}
+		return fmt.Errorf("failed to get document: %w", err)
+	}
+
+	// Skip content that was already linted.
+	var existing models.DocumentQualityCheck
+	if err := s.db.WithContext(ctx).
+		Where("document_id = ?", doc.ID).
+		Order("id").
+		Limit(1).
+		Find(&existing).
+		Error; err != nil {
+		return fmt.Errorf("failed to check for existing quality checks: %w", err)
+	}
+	if existing.ID != 0 && revision.ContentHash != "" &&
+		existing.ContentHash == revision.ContentHash {
+		s.logger.Debug("content already linted, skipping",
+			"document_uuid", revision.DocumentUUID,
+			"content_hash", revision.ContentHash,
+		)
+		return nil
+	}
+
+	if s.workspaceProvider == nil {
+		return fmt.Errorf("workspace provider not configured")
+	}
+	content, err := s.workspaceProvider.GetDocumentContent(revision.DocumentID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch document content: %w", err)
+	}
+
+	linter, err := doclint.New(doclint.Config{
+		Rules:           configRules(config),
+		SummaryHeadings: configStrings(config, "summary_headings"),
+		TODOMarkers:     configStrings(config, "todo_markers"),
+		BaseURLs:        s.baseURLs,
+	}, s.documentExists)
+	if err != nil {
+		return fmt.Errorf("invalid quality lint config: %w", err)
+	}
+
+	results, err := linter.Lint(ctx, content)
+	if err != nil {
+		return fmt.Errorf("failed to lint document: %w", err)
+	}
+
+	now := time.Now()
+	checks := make([]models.DocumentQualityCheck, len(results))
+	failed := 0
+	for i, res := range results {
+		checks[i] = models.DocumentQualityCheck{
+			Rule:        string(res.Rule),
+			Passed:      res.Passed,
+			ContentHash: revision.ContentHash,
+			CheckedAt:   now,
+		}
+		for _, f := range res.Findings {
+			checks[i].Findings = append(checks[i].Findings,
+				models.DocumentQualityFinding{Line: f.Line, Message: f.Message})
+		}
+		if !res.Passed {
+			failed++
+		}
+	}
+	if err := models.ReplaceDocumentQualityChecks(
+		s.db.WithContext(ctx), doc.ID, checks); err != nil {
+		return fmt.Errorf("failed to save quality checks: %w", err)
+	}
+
+	s.logger.Info("linted document",
+		"document_uuid", revision.DocumentUUID,
+		"revision_id", revision.ID,
+		"rules", len(results),
+		"failed", failed,
+	)
+
+	return nil
+}
+
+// IsRetryable determines if an error should trigger a retry.
+func (s *QualityLintStep) IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	errMsg := strings.ToLower(err.Error())
+
+	// Network errors are retryable
+	if strings.Contains(errMsg, "timeout") ||
+		strings.Contains(errMsg, "connection") {
+		return true
+	}
+
+	// Rate limiting is retryable
+	if strings.Contains(errMsg, "rate limit") ||
+		strings.Contains(errMsg, "too many requests") {
+		return true
+	}
+
+	// Other errors are not retryable (e.g., invalid config)
+	return false
+}
+
+// documentExists reports whether the document with the workspace provider ID
+// id exists.
+func (s *QualityLintStep) documentExists(ctx context.Context, id string) (bool, error) {
+	var count int64
+	if err := s.db.WithContext(ctx).
+		Model(&models.Document{}).
+		Where("google_file_id = ?", id).
+		Count(&count).
+		Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func configRules(config map[string]interface{}) []doclint.Rule {
+	var rules []doclint.Rule
+	for _, r := range configStrings(config, "rules") {
+		rules = append(rules, doclint.Rule(r))
+	}
+	return rules
+}
+
+func configStrings(config map[string]interface{}, key string) []string {
+	switch v := config[key].(type) {
+	case []string:
+		return v
+	case []interface{}:
+		var ss []string
+		for _, e := range v {
+			if s, ok := e.(string); ok {
+				ss = append(ss, s)
+			}
+		}
+		return ss
+	}
+	return nil
+}
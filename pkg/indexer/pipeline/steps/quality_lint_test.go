@@ -0,0 +1,72 @@
+package steps
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp-forge/hermes/pkg/models/modelstest"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQualityLintStep(t *testing.T) {
+	db := modelstest.NewDB(t)
+
+	doc := models.Document{
+		GoogleFileID: "test-doc-1",
+		DocumentType: models.DocumentType{Name: "RFC", LongName: "RFC"},
+		Product:      models.Product{Name: "Hermes", Abbreviation: "HRM"},
+	}
+	require.NoError(t, doc.DocumentType.FirstOrCreate(db))
+	require.NoError(t, doc.Product.FirstOrCreate(db))
+	require.NoError(t, doc.Create(db))
+
+	revision := createTestRevision(t, db)
+	workspace := &MockWorkspaceProvider{
+		Content: map[string]string{
+			"test-doc-1": "# Title\n\n## Summary\n\nTODO\n\nSee [this](/document/gone).\n",
+		},
+	}
+	step := NewQualityLintStep(db, workspace, nil, hclog.NewNullLogger())
+	assert.Equal(t, "quality_lint", step.Name())
+
+	require.NoError(t, step.Execute(context.Background(), revision, map[string]interface{}{
+		"rules": []interface{}{"todo_markers", "broken_internal_links", "summary_section"},
+	}))
+
+	var checks models.DocumentQualityChecks
+	require.NoError(t, checks.Find(db, doc.ID))
+	require.Len(t, checks, 3)
+	assert.Equal(t, "todo_markers", checks[0].Rule)
+	assert.False(t, checks[0].Passed)
+	assert.Equal(t, []models.DocumentQualityFinding{{Line: 5, Message: "TODO: TODO"}},
+		checks[0].Findings)
+	assert.Equal(t, "broken_internal_links", checks[1].Rule)
+	assert.False(t, checks[1].Passed)
+	assert.Equal(t, "summary_section", checks[2].Rule)
+	assert.True(t, checks[2].Passed)
+	assert.Equal(t, revision.ContentHash, checks[2].ContentHash)
+
+	// Content that was already linted is skipped.
+	workspace.Content["test-doc-1"] = "# Title\n"
+	require.NoError(t, step.Execute(context.Background(), revision, nil))
+	require.NoError(t, checks.Find(db, doc.ID))
+	assert.Len(t, checks, 3)
+
+	// Changed content is linted again.
+	revision.ContentHash = "hash456"
+	require.NoError(t, step.Execute(context.Background(), revision, nil))
+	checks = nil
+	require.NoError(t, checks.Find(db, doc.ID))
+	assert.Len(t, checks, 4)
+
+	// Invalid rules fail the step without a retry.
+	revision.ContentHash = "hash789"
+	err := step.Execute(context.Background(), revision, map[string]interface{}{
+		"rules": []interface{}{"spelling"},
+	})
+	require.Error(t, err)
+	assert.False(t, step.IsRetryable(err))
+}
@@ -73,6 +73,59 @@ func (s *SearchIndexStep) Execute(ctx context.Context, revision *models.Document
 	return nil
 }
 
+// ExecuteBatch implements pipeline.BatchStep. It indexes the revisions with
+// IndexBatch, in batches of the "batch_size" config value (default: 100) with
+// up to "concurrency" batches in flight (default: 4).
+func (s *SearchIndexStep) ExecuteBatch(ctx context.Context, revisions []*models.DocumentRevision, config map[string]interface{}) []error {
+	errs := make([]error, len(revisions))
+	opts := search.BatchOptions{
+		BatchSize:   configInt(config, "batch_size"),
+		Concurrency: configInt(config, "concurrency"),
+	}
+
+	// Convert all revisions first, so failed batches can be reported for
+	// their revisions while the rest are indexed.
+	searchDocs := make([]*search.Document, len(revisions))
+	revisionIndex := make(map[*search.Document]int, len(revisions))
+	for i, revision := range revisions {
+		doc, err := s.revisionToSearchDocument(revision)
+		if err != nil {
+			errs[i] = fmt.Errorf("failed to convert revision to search document: %w", err)
+			continue
+		}
+		searchDocs[i] = doc
+		revisionIndex[doc] = i
+	}
+
+	opts.OnError = func(docs []*search.Document, err error) {
+		for _, doc := range docs {
+			errs[revisionIndex[doc]] = fmt.Errorf("failed to index document: %w", err)
+		}
+	}
+	drafts := search.NewBatcher(s.searchProvider.DraftIndex(), opts)
+	docs := search.NewBatcher(s.searchProvider.DocumentIndex(), opts)
+	for i, doc := range searchDocs {
+		switch {
+		case doc == nil:
+		case s.isDraft(revisions[i]):
+			drafts.Add(ctx, doc)
+		default:
+			docs.Add(ctx, doc)
+		}
+	}
+
+	// Errors of failed batches were reported by OnError.
+	_ = drafts.Flush(ctx)
+	_ = docs.Flush(ctx)
+
+	s.logger.Info("indexed documents in search",
+		"revisions", len(revisions),
+		"indexed", drafts.Indexed()+docs.Indexed(),
+	)
+
+	return errs
+}
+
 // IsRetryable determines if an error should trigger a retry.
 func (s *SearchIndexStep) IsRetryable(err error) bool {
 	if err == nil {
@@ -197,3 +250,15 @@ func (s *SearchIndexStep) extractProduct(revision *models.DocumentRevision) stri
 // func (s *SearchIndexStep) fetchDocumentMetadata(revision *models.DocumentRevision) (*DocumentMetadata, error) {
 //     // Fetch metadata from database or workspace provider
 // }
+
+// configInt returns the integer value of key in a step config, or zero if it
+// isn't set. Values decoded from JSON are float64.
+func configInt(config map[string]interface{}, key string) int {
+	switch v := config[key].(type) {
+	case int:
+		return v
+	case float64:
+		return int(v)
+	}
+	return 0
+}
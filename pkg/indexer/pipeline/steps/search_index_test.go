@@ -0,0 +1,74 @@
+package steps
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp-forge/hermes/pkg/search"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBatchIndex records the IDs of each indexed batch, and fails batches
+// that include the document "bad-doc".
+type fakeBatchIndex struct {
+	search.DraftIndex
+	mu      sync.Mutex
+	batches [][]string
+}
+
+func (f *fakeBatchIndex) IndexBatch(ctx context.Context, docs []*search.Document) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var ids []string
+	for _, doc := range docs {
+		ids = append(ids, doc.ObjectID)
+	}
+	f.batches = append(f.batches, ids)
+	for _, id := range ids {
+		if id == "bad-doc" {
+			return errors.New("invalid document")
+		}
+	}
+	return nil
+}
+
+type fakeSearchProvider struct {
+	search.Provider
+	docs, drafts *fakeBatchIndex
+}
+
+func (p *fakeSearchProvider) DocumentIndex() search.DocumentIndex { return p.docs }
+func (p *fakeSearchProvider) DraftIndex() search.DraftIndex       { return p.drafts }
+func (p *fakeSearchProvider) Name() string                        { return "fake" }
+
+func TestSearchIndexStep_ExecuteBatch(t *testing.T) {
+	provider := &fakeSearchProvider{
+		docs:   &fakeBatchIndex{},
+		drafts: &fakeBatchIndex{},
+	}
+	step := NewSearchIndexStep(provider, hclog.NewNullLogger())
+
+	revisions := []*models.DocumentRevision{
+		{DocumentID: "doc1", Status: "Approved"},
+		{DocumentID: "draft1", Status: "WIP"},
+		{DocumentID: "doc2", Status: "Approved"},
+		{DocumentID: "bad-doc", Status: "Approved"},
+	}
+	errs := step.ExecuteBatch(context.Background(), revisions,
+		map[string]interface{}{"batch_size": float64(2)})
+
+	require.Len(t, errs, 4)
+	assert.NoError(t, errs[0])
+	assert.NoError(t, errs[1])
+	assert.NoError(t, errs[2])
+	assert.ErrorContains(t, errs[3], "invalid document")
+
+	assert.Equal(t, [][]string{{"draft1"}}, provider.drafts.batches)
+	assert.ElementsMatch(t, [][]string{{"doc1", "doc2"}, {"bad-doc"}},
+		provider.docs.batches)
+}
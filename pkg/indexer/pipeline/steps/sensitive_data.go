@@ -0,0 +1,257 @@
+package steps
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp-forge/hermes/pkg/sensitive"
+	"github.com/hashicorp/go-hclog"
+	"gorm.io/gorm"
+)
+
+// Sensitive data policies.
+const (
+	// SensitiveDataPolicyRecord records findings.
+	SensitiveDataPolicyRecord = "record"
+
+	// SensitiveDataPolicyNotify records findings and notifies the document
+	// owner of new findings.
+	SensitiveDataPolicyNotify = "notify"
+
+	// SensitiveDataPolicyBlock records findings, notifies the document owner of
+	// new findings, and blocks publishing the document for review until the
+	// data is removed.
+	SensitiveDataPolicyBlock = "block"
+)
+
+// SensitiveDataStep scans document content for credentials, tokens, and
+// personal data, and stores what it finds (redacted) in the
+// document_sensitive_findings table.
+//
+// Step config:
+//   - policy: "record", "notify", or "block" (default: "notify")
+//   - rules: the built-in rules to run (default: all rules but email_address)
+//   - patterns: additional credential rules, as a map of rule name to regular
+//     expression
+//   - entropy_threshold: the minimum entropy of high entropy strings, in bits
+//     per character (default: 4.0)
+//   - min_entropy_length: the minimum length of high entropy strings
+//     (default: 32)
+type SensitiveDataStep struct {
+	db                *gorm.DB
+	workspaceProvider WorkspaceContentProvider
+	dlp               sensitive.External
+	notifier          sensitive.Notifier
+	logger            hclog.Logger
+}
+
+// NewSensitiveDataStep creates a new sensitive data step. Content is also
+// scanned by the external DLP API dlp if it isn't nil, and owners aren't
+// notified if notifier is nil.
+func NewSensitiveDataStep(db *gorm.DB, workspaceProvider WorkspaceContentProvider, dlp sensitive.External, notifier sensitive.Notifier, logger hclog.Logger) *SensitiveDataStep {
+	if logger == nil {
+		logger = hclog.NewNullLogger()
+	}
+
+	return &SensitiveDataStep{
+		db:                db,
+		workspaceProvider: workspaceProvider,
+		dlp:               dlp,
+		notifier:          notifier,
+		logger:            logger.Named("sensitive-data-step"),
+	}
+}
+
+// Name returns the step name.
+func (s *SensitiveDataStep) Name() string {
+	return "sensitive_data"
+}
+
+// Execute scans the content of the given revision.
+func (s *SensitiveDataStep) Execute(ctx context.Context, revision *models.DocumentRevision, config map[string]interface{}) error {
+	s.logger.Debug("executing sensitive data step",
+		"document_uuid", revision.DocumentUUID,
+		"revision_id", revision.ID,
+		"content_hash", revision.ContentHash,
+	)
+
+	policy := SensitiveDataPolicyNotify
+	if p, ok := config["policy"].(string); ok && p != "" {
+		policy = p
+	}
+	switch policy {
+	case SensitiveDataPolicyRecord, SensitiveDataPolicyNotify, SensitiveDataPolicyBlock:
+	default:
+		return fmt.Errorf("invalid sensitive data policy %q", policy)
+	}
+
+	scanner, err := sensitive.New(sensitive.Config{
+		Rules:            configStrings(config, "rules"),
+		Patterns:         configStringMap(config, "patterns"),
+		EntropyThreshold: configFloat(config, "entropy_threshold"),
+		MinEntropyLength: configInt(config, "min_entropy_length"),
+	}, s.dlp)
+	if err != nil {
+		return fmt.Errorf("invalid sensitive data config: %w", err)
+	}
+
+	var doc struct {
+		ID    uint
+		Title string
+		Owner string
+	}
+	if err := s.db.WithContext(ctx).
+		Table("documents AS d").
+		Select("d.id, d.title, COALESCE(u.email_address, '') AS owner").
+		Joins("LEFT JOIN users u ON u.id = d.owner_id").
+		Where("d.google_file_id = ? AND d.deleted_at IS NULL", revision.DocumentID).
+		Take(&doc).
+		Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			s.logger.Debug("document not found, skipping",
+				"document_uuid", revision.DocumentUUID,
+				"document_id", revision.DocumentID,
+			)
+			return nil
+		}
+		return fmt.Errorf("failed to get document: %w", err)
+	}
+
+	var existing models.DocumentSensitiveFindings
+	if err := existing.Find(s.db.WithContext(ctx), doc.ID); err != nil {
+		return fmt.Errorf("failed to get existing sensitive findings: %w", err)
+	}
+	if len(existing) > 0 && revision.ContentHash != "" &&
+		existing[0].ContentHash == revision.ContentHash {
+		s.logger.Debug("content already scanned, skipping",
+			"document_uuid", revision.DocumentUUID,
+			"content_hash", revision.ContentHash,
+		)
+		return nil
+	}
+
+	if s.workspaceProvider == nil {
+		return fmt.Errorf("workspace provider not configured")
+	}
+	content, err := s.workspaceProvider.GetDocumentContent(revision.DocumentID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch document content: %w", err)
+	}
+
+	found, err := scanner.Scan(ctx, content)
+	if err != nil {
+		return fmt.Errorf("failed to scan document: %w", err)
+	}
+
+	// Only store and send redacted data.
+	now := time.Now()
+	known := make(map[string]bool, len(existing))
+	for _, f := range existing {
+		known[f.Rule+"\x00"+f.Redacted] = true
+	}
+	findings := make([]models.DocumentSensitiveFinding, len(found))
+	isNew := false
+	for i, f := range found {
+		f.Match = f.Redacted()
+		found[i] = f
+		findings[i] = models.DocumentSensitiveFinding{
+			Rule:        f.Rule,
+			Category:    string(f.Category),
+			Line:        f.Line,
+			Redacted:    f.Match,
+			Source:      f.Source,
+			Blocking:    policy == SensitiveDataPolicyBlock,
+			ContentHash: revision.ContentHash,
+			DetectedAt:  now,
+		}
+		if !known[f.Rule+"\x00"+f.Match] {
+			isNew = true
+		}
+	}
+	if err := models.ReplaceDocumentSensitiveFindings(
+		s.db.WithContext(ctx), doc.ID, findings); err != nil {
+		return fmt.Errorf("failed to save sensitive findings: %w", err)
+	}
+
+	if len(found) > 0 {
+		s.logger.Warn("found sensitive data in document",
+			"document_uuid", revision.DocumentUUID,
+			"revision_id", revision.ID,
+			"findings", len(found),
+			"policy", policy,
+		)
+	}
+
+	if isNew && policy != SensitiveDataPolicyRecord && s.notifier != nil {
+		if err := s.notifier.NotifySensitiveData(ctx, &sensitive.Event{
+			DocumentID:   revision.DocumentID,
+			DocumentUUID: revision.DocumentUUID.String(),
+			Title:        doc.Title,
+			Owner:        doc.Owner,
+			Blocked:      policy == SensitiveDataPolicyBlock,
+			Findings:     found,
+		}); err != nil {
+			s.logger.Error("error notifying owner about sensitive data",
+				"error", err,
+				"document_uuid", revision.DocumentUUID,
+			)
+		}
+	}
+
+	return nil
+}
+
+// IsRetryable determines if an error should trigger a retry.
+func (s *SensitiveDataStep) IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	errMsg := strings.ToLower(err.Error())
+
+	// Network errors are retryable
+	if strings.Contains(errMsg, "timeout") ||
+		strings.Contains(errMsg, "connection") {
+		return true
+	}
+
+	// Rate limiting and DLP API unavailability are retryable
+	if strings.Contains(errMsg, "rate limit") ||
+		strings.Contains(errMsg, "too many requests") ||
+		strings.Contains(errMsg, "dlp api error (5") {
+		return true
+	}
+
+	// Other errors are not retryable (e.g., invalid config)
+	return false
+}
+
+func configFloat(config map[string]interface{}, key string) float64 {
+	switch v := config[key].(type) {
+	case int:
+		return float64(v)
+	case float64:
+		return v
+	}
+	return 0
+}
+
+func configStringMap(config map[string]interface{}, key string) map[string]string {
+	switch v := config[key].(type) {
+	case map[string]string:
+		return v
+	case map[string]interface{}:
+		m := make(map[string]string, len(v))
+		for k, e := range v {
+			if s, ok := e.(string); ok {
+				m[k] = s
+			}
+		}
+		return m
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/hashicorp-forge/hermes/pkg/kafka/producer"
 	"github.com/hashicorp-forge/hermes/pkg/models"
 	"github.com/hashicorp/go-hclog"
 	"github.com/twmb/franz-go/pkg/kgo"
@@ -16,7 +17,7 @@ import (
 // Implements the outbox pattern relay component.
 type Relay struct {
 	db           *gorm.DB
-	kafkaClient  *kgo.Client
+	producer     *producer.Producer
 	topic        string
 	logger       hclog.Logger
 	pollInterval time.Duration
@@ -24,6 +25,12 @@ type Relay struct {
 	stopCh       chan struct{}
 }
 
+// Default polling configuration.
+const (
+	DefaultPollInterval = 1 * time.Second
+	DefaultBatchSize    = 100
+)
+
 // Config holds configuration for the relay service.
 type Config struct {
 	// Database connection
@@ -33,6 +40,10 @@ type Config struct {
 	Brokers []string
 	Topic   string
 
+	// Producer configures producing events (acks, compression, and
+	// idempotence). Its brokers are Brokers.
+	Producer producer.Config
+
 	// Polling configuration
 	PollInterval time.Duration // How often to poll the outbox (default: 1s)
 	BatchSize    int           // How many outbox entries to process per batch (default: 100)
@@ -55,44 +66,28 @@ func New(cfg Config) (*Relay, error) {
 
 	// Set defaults
 	if cfg.PollInterval == 0 {
-		cfg.PollInterval = 1 * time.Second
+		cfg.PollInterval = DefaultPollInterval
 	}
 	if cfg.BatchSize == 0 {
-		cfg.BatchSize = 100
+		cfg.BatchSize = DefaultBatchSize
 	}
 	if cfg.Logger == nil {
 		cfg.Logger = hclog.NewNullLogger()
 	}
 
-	// Create Kafka client
-	kafkaClient, err := kgo.NewClient(
-		kgo.SeedBrokers(cfg.Brokers...),
-
-		// Producer durability settings
-		kgo.RequiredAcks(kgo.AllISRAcks()), // Wait for all in-sync replicas
-		kgo.ProducerBatchCompression(kgo.GzipCompression()),
-
-		// Retry configuration
-		kgo.RetryBackoffFn(func(tries int) time.Duration {
-			backoff := time.Duration(tries) * 100 * time.Millisecond
-			if backoff > 60*time.Second {
-				backoff = 60 * time.Second
-			}
-			return backoff
-		}),
-		kgo.RequestRetries(10),
-
-		// Batching for better throughput
-		kgo.ProducerLinger(10*time.Millisecond),
-		kgo.ProducerBatchMaxBytes(1<<20), // 1MB
-	)
+	// Create Kafka producer
+	cfg.Producer.Brokers = cfg.Brokers
+	if cfg.Producer.Name == "" {
+		cfg.Producer.Name = "outbox-relay"
+	}
+	p, err := producer.New(cfg.Producer)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create kafka client: %w", err)
+		return nil, fmt.Errorf("failed to create kafka producer: %w", err)
 	}
 
 	return &Relay{
 		db:           cfg.DB,
-		kafkaClient:  kafkaClient,
+		producer:     p,
 		topic:        cfg.Topic,
 		logger:       cfg.Logger.Named("outbox-relay"),
 		pollInterval: cfg.PollInterval,
@@ -135,20 +130,51 @@ func (r *Relay) Start(ctx context.Context) error {
 // Stop gracefully stops the relay service.
 func (r *Relay) Stop() {
 	close(r.stopCh)
-	r.kafkaClient.Close()
+	r.producer.Close()
+}
+
+// Ping returns an error if the relay can't reach any Kafka broker.
+func (r *Relay) Ping(ctx context.Context) error {
+	return r.producer.Ping(ctx)
+}
+
+// Flush publishes pending outbox entries until there are none left, an entry
+// fails to be published, or ctx is done. It's called on shutdown, after the
+// polling loop has stopped, so entries written by the last requests are
+// published before the server exits.
+func (r *Relay) Flush(ctx context.Context) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		published, err := r.publishPending(ctx)
+		if err != nil {
+			return err
+		}
+		if published < r.batchSize {
+			return nil
+		}
+	}
 }
 
 // processBatch fetches pending outbox entries and publishes them to Redpanda.
 func (r *Relay) processBatch(ctx context.Context) error {
+	_, err := r.publishPending(ctx)
+	return err
+}
+
+// publishPending publishes a batch of pending outbox entries and returns the
+// number of entries published.
+func (r *Relay) publishPending(ctx context.Context) (int, error) {
 	// Fetch pending entries
 	entries, err := models.FindPendingOutboxEntries(r.db, r.batchSize)
 	if err != nil {
-		return fmt.Errorf("failed to find pending outbox entries: %w", err)
+		return 0, fmt.Errorf("failed to find pending outbox entries: %w", err)
 	}
 
 	if len(entries) == 0 {
 		// No pending entries, nothing to do
-		return nil
+		return 0, nil
 	}
 
 	r.logger.Debug("processing outbox batch", "count", len(entries))
@@ -196,7 +222,7 @@ func (r *Relay) processBatch(ctx context.Context) error {
 		"failed", failCount,
 	)
 
-	return nil
+	return successCount, nil
 }
 
 // publishEntry publishes a single outbox entry to Redpanda.
@@ -221,19 +247,15 @@ func (r *Relay) publishEntry(ctx context.Context, entry *models.DocumentRevision
 
 	// Create Kafka record
 	// Key: document UUID (ensures ordering of events for the same document)
-	record := &kgo.Record{
-		Topic: r.topic,
-		Key:   []byte(entry.DocumentUUID.String()),
-		Value: eventJSON,
-		Headers: []kgo.RecordHeader{
-			{Key: "event_type", Value: []byte(entry.EventType)},
-			{Key: "provider_type", Value: []byte(entry.ProviderType)},
-			{Key: "idempotent_key", Value: []byte(entry.IdempotentKey)},
-		},
-	}
+	record := producer.DocumentRecord(r.topic, entry.DocumentUUID.String(),
+		eventJSON,
+		kgo.RecordHeader{Key: "event_type", Value: []byte(entry.EventType)},
+		kgo.RecordHeader{Key: "provider_type", Value: []byte(entry.ProviderType)},
+		kgo.RecordHeader{Key: "idempotent_key", Value: []byte(entry.IdempotentKey)},
+	)
 
 	// Publish synchronously (wait for ack)
-	if err := r.kafkaClient.ProduceSync(ctx, record).FirstErr(); err != nil {
+	if _, err := r.producer.Produce(ctx, record); err != nil {
 		return fmt.Errorf("failed to publish to kafka: %w", err)
 	}
 
@@ -0,0 +1,174 @@
+// Package replay re-emits document revision events for revisions in the
+// database onto the document revision topic, so fixes to the indexer and
+// notifier can be re-applied to existing documents without a full backfill.
+//
+// Replayed events are produced directly instead of through the outbox, because
+// the outbox skips events of revisions whose content was already published.
+package replay
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp-forge/hermes/pkg/indexer/consumer"
+	"github.com/hashicorp-forge/hermes/pkg/kafka/producer"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"gorm.io/gorm"
+)
+
+const (
+	// DefaultLimit is the maximum number of revisions replayed by default.
+	DefaultLimit = 1000
+
+	// MaxLimit is the maximum number of revisions that can be replayed at once.
+	MaxLimit = 10000
+)
+
+// HeaderReplay is the record header that marks replayed events.
+const HeaderReplay = "replay"
+
+// Filter selects the revisions to replay. At least one of DocumentUUID,
+// Product, Since, or Until is required, so a replay can't turn into a full
+// backfill by accident.
+type Filter struct {
+	// DocumentUUID selects the revisions of a document.
+	DocumentUUID *uuid.UUID
+
+	// Product selects the revisions of documents of a product, by name.
+	Product string
+
+	// Since and Until select revisions modified in a time window. Since is
+	// inclusive and Until is exclusive.
+	Since *time.Time
+	Until *time.Time
+
+	// Limit is the maximum number of revisions to replay (default:
+	// DefaultLimit).
+	Limit int
+}
+
+// Validate validates the filter.
+func (f Filter) Validate() error {
+	if f.DocumentUUID == nil && f.Product == "" &&
+		f.Since == nil && f.Until == nil {
+		return fmt.Errorf(
+			"a document UUID, product, or time window is required")
+	}
+	if f.Since != nil && f.Until != nil && !f.Since.Before(*f.Until) {
+		return fmt.Errorf("since must be before until")
+	}
+	if f.Limit < 0 || f.Limit > MaxLimit {
+		return fmt.Errorf("limit must be between 1 and %d", MaxLimit)
+	}
+	return nil
+}
+
+// Revision is an active document revision with the metadata of its document
+// that rulesets match on.
+type Revision struct {
+	models.DocumentRevision `gorm:"embedded"`
+
+	// DocumentType and Product are the names of the document type and product
+	// of the document, which are empty if the document isn't in the database.
+	DocumentType string
+	Product      string
+}
+
+// Event returns the document revision event replayed for the revision.
+func (r *Revision) Event() *consumer.DocumentRevisionEvent {
+	metadata := map[string]interface{}{
+		HeaderReplay: true,
+	}
+	if r.DocumentType != "" {
+		metadata["document_type"] = r.DocumentType
+	}
+	if r.Product != "" {
+		metadata["product"] = r.Product
+	}
+	return consumer.NewEvent(
+		&r.DocumentRevision, models.RevisionEventUpdated, metadata)
+}
+
+// FindRevisions returns the active revisions matching filter, oldest modified
+// first. It returns at most filter.Limit revisions, and reports whether more
+// revisions matched.
+func FindRevisions(db *gorm.DB, filter Filter) (
+	revisions []Revision, truncated bool, err error,
+) {
+	if err := filter.Validate(); err != nil {
+		return nil, false, err
+	}
+	limit := filter.Limit
+	if limit == 0 {
+		limit = DefaultLimit
+	}
+
+	tx := db.Table("document_revisions").
+		Select("document_revisions.*, "+
+			"document_types.name AS document_type, products.name AS product").
+		Joins("LEFT JOIN documents ON "+
+			"documents.document_uuid = document_revisions.document_uuid AND "+
+			"documents.deleted_at IS NULL").
+		Joins("LEFT JOIN document_types ON "+
+			"document_types.id = documents.document_type_id").
+		Joins("LEFT JOIN products ON products.id = documents.product_id").
+		Where("document_revisions.status = ?", "active")
+	if filter.DocumentUUID != nil {
+		tx = tx.Where("document_revisions.document_uuid = ?", *filter.DocumentUUID)
+	}
+	if filter.Product != "" {
+		tx = tx.Where("products.name = ?", filter.Product)
+	}
+	if filter.Since != nil {
+		tx = tx.Where("document_revisions.modified_time >= ?", *filter.Since)
+	}
+	if filter.Until != nil {
+		tx = tx.Where("document_revisions.modified_time < ?", *filter.Until)
+	}
+
+	// Fetch one more revision than the limit to find out if there are more.
+	if err := tx.
+		Order("document_revisions.modified_time, document_revisions.id").
+		Limit(limit + 1).
+		Scan(&revisions).Error; err != nil {
+		return nil, false, fmt.Errorf("error finding revisions: %w", err)
+	}
+	if len(revisions) > limit {
+		return revisions[:limit], true, nil
+	}
+	return revisions, false, nil
+}
+
+// Producer produces records. It's implemented by *producer.Producer.
+type Producer interface {
+	Produce(ctx context.Context, record *kgo.Record) (*kgo.Record, error)
+}
+
+// Publish produces the events of revisions to topic in order, keyed by
+// document UUID like the outbox relay. It stops at the first error, and
+// returns the number of events produced.
+func Publish(
+	ctx context.Context, p Producer, topic string, revisions []Revision,
+) (int, error) {
+	for i := range revisions {
+		event := revisions[i].Event()
+		value, err := json.Marshal(event)
+		if err != nil {
+			return i, fmt.Errorf("error encoding event: %w", err)
+		}
+		record := producer.DocumentRecord(topic, event.DocumentUUID, value,
+			kgo.RecordHeader{Key: "event_type", Value: []byte(event.EventType)},
+			kgo.RecordHeader{Key: "provider_type", Value: []byte(event.ProviderType)},
+			kgo.RecordHeader{Key: HeaderReplay, Value: []byte("true")},
+		)
+		if _, err := p.Produce(ctx, record); err != nil {
+			return i, fmt.Errorf(
+				"error producing event for document %s: %w", event.DocumentUUID, err)
+		}
+	}
+	return len(revisions), nil
+}
@@ -0,0 +1,183 @@
+package replay
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp-forge/hermes/pkg/docid"
+	"github.com/hashicorp-forge/hermes/pkg/indexer/consumer"
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp-forge/hermes/pkg/models/modelstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"gorm.io/gorm"
+)
+
+// createRevision creates an active revision of a new document of product,
+// modified at modified.
+func createRevision(
+	t *testing.T, db *gorm.DB, product string, modified time.Time,
+) models.DocumentRevision {
+	id := docid.NewUUID()
+	d := modelstest.CreateDocument(t, db, models.Document{
+		GoogleFileID: id.String(),
+		DocumentUUID: &id,
+		Title:        "Doc " + id.String(),
+		Product:      models.Product{Name: product},
+	})
+
+	rev := models.DocumentRevision{
+		DocumentUUID: uuid.MustParse(id.String()),
+		DocumentID:   id.String(),
+		ProviderType: "google",
+		Title:        d.Title,
+		ContentHash:  "hash-" + id.String(),
+		ModifiedTime: modified,
+	}
+	require.NoError(t, db.Create(&rev).Error)
+	return rev
+}
+
+func TestFilterValidate(t *testing.T) {
+	id := uuid.New()
+	now := time.Now()
+	earlier := now.Add(-time.Hour)
+
+	assert.Error(t, Filter{}.Validate())
+	assert.Error(t, Filter{Limit: 10}.Validate())
+	assert.NoError(t, Filter{DocumentUUID: &id}.Validate())
+	assert.NoError(t, Filter{Product: "Hermes"}.Validate())
+	assert.NoError(t, Filter{Since: &earlier, Until: &now}.Validate())
+	assert.Error(t, Filter{Since: &now, Until: &earlier}.Validate())
+	assert.Error(t, Filter{Product: "Hermes", Limit: MaxLimit + 1}.Validate())
+	assert.Error(t, Filter{Product: "Hermes", Limit: -1}.Validate())
+}
+
+func TestFindRevisions(t *testing.T) {
+	db := modelstest.NewDB(t)
+	now := time.Now().UTC().Truncate(time.Second)
+
+	old := createRevision(t, db, "Hermes", now.Add(-48*time.Hour))
+	recent := createRevision(t, db, "Hermes", now.Add(-time.Hour))
+	other := createRevision(t, db, "Vault", now.Add(-2*time.Hour))
+	archived := createRevision(t, db, "Hermes", now.Add(-time.Hour))
+	require.NoError(t, archived.MarkAsArchived(db))
+
+	ids := func(revisions []Revision) []uint {
+		ids := []uint{}
+		for _, r := range revisions {
+			ids = append(ids, r.ID)
+		}
+		return ids
+	}
+
+	t.Run("by document", func(t *testing.T) {
+		revisions, truncated, err := FindRevisions(db,
+			Filter{DocumentUUID: &recent.DocumentUUID})
+		require.NoError(t, err)
+		assert.False(t, truncated)
+		require.Len(t, revisions, 1)
+		assert.Equal(t, recent.ID, revisions[0].ID)
+		assert.Equal(t, "RFC", revisions[0].DocumentType)
+		assert.Equal(t, "Hermes", revisions[0].Product)
+	})
+
+	t.Run("by product skips inactive revisions", func(t *testing.T) {
+		revisions, _, err := FindRevisions(db, Filter{Product: "Hermes"})
+		require.NoError(t, err)
+		assert.Equal(t, []uint{old.ID, recent.ID}, ids(revisions))
+	})
+
+	t.Run("by time window", func(t *testing.T) {
+		since, until := now.Add(-24*time.Hour), now.Add(-90*time.Minute)
+		revisions, _, err := FindRevisions(db, Filter{Since: &since, Until: &until})
+		require.NoError(t, err)
+		assert.Equal(t, []uint{other.ID}, ids(revisions))
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		since := now.Add(-72 * time.Hour)
+		revisions, truncated, err := FindRevisions(db,
+			Filter{Since: &since, Limit: 2})
+		require.NoError(t, err)
+		assert.True(t, truncated)
+		assert.Equal(t, []uint{old.ID, other.ID}, ids(revisions))
+	})
+
+	t.Run("invalid filter", func(t *testing.T) {
+		_, _, err := FindRevisions(db, Filter{})
+		assert.Error(t, err)
+	})
+}
+
+type fakeProducer struct {
+	records []*kgo.Record
+	err     error
+}
+
+func (p *fakeProducer) Produce(
+	ctx context.Context, record *kgo.Record,
+) (*kgo.Record, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	p.records = append(p.records, record)
+	return record, nil
+}
+
+func TestPublish(t *testing.T) {
+	revisions := []Revision{
+		{
+			DocumentRevision: models.DocumentRevision{
+				ID:           1,
+				DocumentUUID: uuid.New(),
+				ProviderType: "google",
+				Status:       "active",
+			},
+			DocumentType: "RFC",
+			Product:      "Hermes",
+		},
+		{
+			DocumentRevision: models.DocumentRevision{
+				ID:           2,
+				DocumentUUID: uuid.New(),
+				ProviderType: "local",
+				Status:       "active",
+			},
+		},
+	}
+
+	t.Run("produces events keyed by document", func(t *testing.T) {
+		p := &fakeProducer{}
+		n, err := Publish(context.Background(), p, "hermes.document-revisions",
+			revisions)
+		require.NoError(t, err)
+		assert.Equal(t, 2, n)
+		require.Len(t, p.records, 2)
+
+		rec := p.records[0]
+		assert.Equal(t, "hermes.document-revisions", rec.Topic)
+		assert.Equal(t, revisions[0].DocumentUUID.String(), string(rec.Key))
+		assert.Contains(t, rec.Headers,
+			kgo.RecordHeader{Key: HeaderReplay, Value: []byte("true")})
+
+		event, revision, metadata, err := consumer.ParseEvent(rec.Value)
+		require.NoError(t, err)
+		assert.Equal(t, models.RevisionEventUpdated, event.EventType)
+		assert.Equal(t, uint(1), revision.ID)
+		assert.Equal(t, "RFC", metadata["document_type"])
+		assert.Equal(t, "Hermes", metadata["product"])
+		assert.Equal(t, true, metadata[HeaderReplay])
+	})
+
+	t.Run("stops at the first error", func(t *testing.T) {
+		p := &fakeProducer{err: errors.New("broker unavailable")}
+		n, err := Publish(context.Background(), p, "topic", revisions)
+		assert.Error(t, err)
+		assert.Zero(t, n)
+	})
+}
@@ -2,8 +2,10 @@ package ruleset
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/hashicorp-forge/hermes/pkg/models"
 )
@@ -19,8 +21,10 @@ type Ruleset struct {
 // Rulesets is a collection of rulesets.
 type Rulesets []Ruleset
 
-// Matcher matches document revisions against rulesets.
+// Matcher matches document revisions against rulesets. It is safe for
+// concurrent use, and its rulesets can be replaced while it's in use.
 type Matcher struct {
+	mu       sync.RWMutex
 	rulesets Rulesets
 }
 
@@ -36,7 +40,7 @@ func NewMatcher(rulesets Rulesets) *Matcher {
 func (m *Matcher) Match(revision *models.DocumentRevision, metadata map[string]interface{}) []Ruleset {
 	var matched []Ruleset
 
-	for _, ruleset := range m.rulesets {
+	for _, ruleset := range m.Rulesets() {
 		if ruleset.Matches(revision, metadata) {
 			matched = append(matched, ruleset)
 		}
@@ -45,6 +49,26 @@ func (m *Matcher) Match(revision *models.DocumentRevision, metadata map[string]i
 	return matched
 }
 
+// Rulesets returns the matcher's rulesets.
+func (m *Matcher) Rulesets() Rulesets {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.rulesets
+}
+
+// Update validates rulesets and, if they're valid, replaces the matcher's
+// rulesets with them. If they're invalid, the current rulesets are kept.
+func (m *Matcher) Update(rulesets Rulesets) error {
+	if err := rulesets.ValidateAll(); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rulesets = rulesets
+	return nil
+}
+
 // Matches checks if this ruleset matches the given revision and metadata.
 func (r *Ruleset) Matches(revision *models.DocumentRevision, metadata map[string]interface{}) bool {
 	// If no conditions, match all (default ruleset)
@@ -62,6 +86,39 @@ func (r *Ruleset) Matches(revision *models.DocumentRevision, metadata map[string
 	return true
 }
 
+// ConditionResult is the result of evaluating a single ruleset condition.
+type ConditionResult struct {
+	Key      string
+	Expected string
+	Actual   interface{}
+	Matched  bool
+}
+
+// Evaluate evaluates each of the ruleset's conditions against the given
+// revision and metadata, sorted by key. Unlike Matches, it doesn't stop at the
+// first failed condition, so it can be used to explain why a ruleset did or
+// didn't match.
+func (r *Ruleset) Evaluate(revision *models.DocumentRevision, metadata map[string]interface{}) []ConditionResult {
+	keys := make([]string, 0, len(r.Conditions))
+	for key := range r.Conditions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	results := make([]ConditionResult, 0, len(keys))
+	for _, key := range keys {
+		expected := r.Conditions[key]
+		results = append(results, ConditionResult{
+			Key:      key,
+			Expected: expected,
+			Actual:   r.getValue(key, revision, metadata),
+			Matched:  r.matchCondition(key, expected, revision, metadata),
+		})
+	}
+
+	return results
+}
+
 // matchCondition checks if a single condition matches.
 func (r *Ruleset) matchCondition(key, expected string, revision *models.DocumentRevision, metadata map[string]interface{}) bool {
 	// Get actual value from revision or metadata
@@ -232,13 +289,16 @@ func (r *Ruleset) Validate() error {
 
 	// Validate pipeline step names (basic check)
 	validSteps := map[string]bool{
-		"search_index":     true,
-		"embeddings":       true,
-		"llm_summary":      true,
-		"validation":       true,
-		"llm_validation":   true,
-		"link_extraction":  true,
-		"metadata_extract": true,
+		"search_index":        true,
+		"embeddings":          true,
+		"llm_summary":         true,
+		"validation":          true,
+		"llm_validation":      true,
+		"link_extraction":     true,
+		"metadata_extract":    true,
+		"quality_lint":        true,
+		"sensitive_data":      true,
+		"duplicate_detection": true,
 	}
 
 	for _, step := range r.Pipeline {
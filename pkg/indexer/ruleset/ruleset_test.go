@@ -79,6 +79,35 @@ func TestMatcher_Match_MultipleRulesets(t *testing.T) {
 	assert.Equal(t, "active-docs", matched[1].Name)
 }
 
+func TestMatcher_Update(t *testing.T) {
+	matcher := NewMatcher(Rulesets{
+		{Name: "google-docs", Conditions: map[string]string{
+			"provider_type": "google",
+		}, Pipeline: []string{"search_index"}},
+	})
+	revision := createTestRevision()
+	revision.ProviderType = "local"
+	assert.Empty(t, matcher.Match(revision, nil))
+
+	// Valid rulesets replace the current ones.
+	require.NoError(t, matcher.Update(Rulesets{
+		{Name: "local-docs", Conditions: map[string]string{
+			"provider_type": "local",
+		}, Pipeline: []string{"search_index"}},
+	}))
+	matched := matcher.Match(revision, nil)
+	require.Len(t, matched, 1)
+	assert.Equal(t, "local-docs", matched[0].Name)
+
+	// Invalid rulesets are rejected and the current ones are kept.
+	err := matcher.Update(Rulesets{
+		{Name: "bad", Pipeline: []string{"does_not_exist"}},
+	})
+	assert.Error(t, err)
+	assert.Len(t, matcher.Rulesets(), 1)
+	assert.Equal(t, "local-docs", matcher.Rulesets()[0].Name)
+}
+
 func TestMatcher_Match_NoMatches(t *testing.T) {
 	rulesets := Rulesets{
 		{
@@ -621,6 +650,43 @@ func TestRuleset_ComplexConditions(t *testing.T) {
 	assert.False(t, ruleset.Matches(revision, metadata))
 }
 
+func TestRuleset_Evaluate(t *testing.T) {
+	ruleset := Ruleset{
+		Name: "rfcs",
+		Conditions: map[string]string{
+			"status":            "active,published",
+			"title_contains":    "PRD",
+			"content_length_gt": "1000",
+		},
+		Pipeline: []string{"search_index"},
+	}
+
+	revision := createTestRevision()
+	metadata := map[string]interface{}{
+		"content_length": 5000,
+	}
+
+	results := ruleset.Evaluate(revision, metadata)
+	require.Len(t, results, 3)
+
+	// Results are sorted by key and include failed conditions.
+	assert.Equal(t, ConditionResult{
+		Key: "content_length_gt", Expected: "1000", Actual: 5000, Matched: true,
+	}, results[0])
+	assert.Equal(t, ConditionResult{
+		Key: "status", Expected: "active,published", Actual: "active",
+		Matched: true,
+	}, results[1])
+	assert.Equal(t, ConditionResult{
+		Key: "title_contains", Expected: "PRD", Actual: revision.Title,
+		Matched: false,
+	}, results[2])
+	assert.False(t, ruleset.Matches(revision, metadata))
+
+	// A ruleset without conditions has nothing to evaluate.
+	assert.Empty(t, (&Ruleset{Name: "all"}).Evaluate(revision, nil))
+}
+
 func TestRuleset_CaseInsensitiveContains(t *testing.T) {
 	ruleset := Ruleset{
 		Name: "test",
@@ -0,0 +1,71 @@
+package integrity
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"strings"
+	"time"
+)
+
+// hashPrefix prefixes SHA-256 hashes, as workspace providers record them.
+const hashPrefix = "sha256:"
+
+// Hash returns the SHA-256 hash of r as "sha256:<hex>".
+func Hash(r io.Reader) (string, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return "", err
+	}
+	return hashPrefix + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// NormalizeHash returns a recorded SHA-256 hash as "sha256:<hex>". Providers
+// record hashes with or without the prefix, so a bare 64-character hex hash is
+// assumed to be SHA-256. It returns an empty string if hash is empty or of
+// another algorithm, which can't be verified.
+func NormalizeHash(hash string) string {
+	hash = strings.ToLower(strings.TrimSpace(hash))
+	if strings.HasPrefix(hash, hashPrefix) {
+		return hash
+	}
+	if len(hash) != sha256.Size*2 {
+		return ""
+	}
+	if _, err := hex.DecodeString(hash); err != nil {
+		return ""
+	}
+	return hashPrefix + hash
+}
+
+// Event describes an object of a document that failed an integrity check.
+type Event struct {
+	// DocumentID is the ID of the document in the workspace provider.
+	DocumentID   string
+	DocumentUUID string
+	Title        string
+
+	// ProviderID is the provider ID of the document that was checked.
+	ProviderID string
+
+	// Kind is "content" or "attachment", and Name is the file name of an
+	// attachment.
+	Kind string
+	Name string
+
+	// Status is "drift" or "missing".
+	Status string
+
+	// ExpectedHash is the hash recorded when the object was written, and
+	// ActualHash is its hash now (empty if it's missing).
+	ExpectedHash string
+	ActualHash   string
+
+	DetectedAt time.Time
+}
+
+// Notifier alerts operators about objects that failed integrity checks.
+type Notifier interface {
+	NotifyIntegrityFailure(ctx context.Context, ev *Event) error
+}
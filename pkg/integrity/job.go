@@ -0,0 +1,478 @@
+package integrity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp-forge/hermes/pkg/workspace"
+	"github.com/hashicorp/go-hclog"
+	"gorm.io/gorm"
+)
+
+// DefaultInterval is how often document integrity is checked by default.
+const DefaultInterval = 24 * time.Hour
+
+// Config contains integrity job configuration.
+type Config struct {
+	// Interval is how often documents are checked.
+	Interval time.Duration
+
+	// ProviderName is the name of the primary workspace provider, which
+	// prefixes the provider IDs of documents (e.g., "google").
+	ProviderName string
+
+	// StorageProviders are the configured storage providers keyed by their
+	// provider_storage name, which store the documents repointed to them
+	// (e.g., after a migration to the S3 archive tier).
+	StorageProviders map[string]workspace.WorkspaceProvider
+
+	// MaxDocumentsPerRun is the maximum number of documents checked per run.
+	// The documents checked least recently are checked first. Zero means no
+	// limit.
+	MaxDocumentsPerRun int
+}
+
+// Job periodically recomputes the hashes of the content and attached files of
+// documents stored by workspace providers, compares them to the hashes
+// recorded when they were written, and records the result of each object in
+// the document_integrity table. Operators are notified once when an object
+// drifts or goes missing.
+//
+// The content of a document is compared to the hash in its provider metadata
+// (e.g., the hash tag of an S3 object). Providers that don't record hashes are
+// compared to the hash of the last check, unless the document was modified
+// since then. Attached files are compared to the hash recorded when they were
+// uploaded.
+type Job struct {
+	db               *gorm.DB
+	provider         workspace.WorkspaceProvider
+	storageProviders map[string]workspace.WorkspaceProvider
+	notifier         Notifier
+	logger           hclog.Logger
+	interval         time.Duration
+	providerName     string
+	maxDocuments     int
+}
+
+// RunStats summarizes an integrity check run.
+type RunStats struct {
+	// Documents is the number of documents checked.
+	Documents int
+
+	// Objects is the number of objects (content and attached files) checked.
+	Objects int
+
+	// Drifted and Missing are the numbers of objects that drifted and are
+	// missing.
+	Drifted int
+	Missing int
+
+	// Errors is the number of objects that couldn't be checked.
+	Errors int
+}
+
+// NewJob creates a new integrity job. Operators aren't notified if notifier is
+// nil.
+func NewJob(
+	db *gorm.DB,
+	provider workspace.WorkspaceProvider,
+	notifier Notifier,
+	logger hclog.Logger,
+	cfg *Config,
+) *Job {
+	if logger == nil {
+		logger = hclog.NewNullLogger()
+	}
+	j := &Job{
+		db:           db,
+		provider:     provider,
+		notifier:     notifier,
+		logger:       logger.Named("integrity"),
+		interval:     DefaultInterval,
+		providerName: "google",
+	}
+	if cfg != nil {
+		if cfg.Interval > 0 {
+			j.interval = cfg.Interval
+		}
+		if cfg.ProviderName != "" {
+			j.providerName = cfg.ProviderName
+		}
+		j.storageProviders = cfg.StorageProviders
+		j.maxDocuments = cfg.MaxDocumentsPerRun
+	}
+	return j
+}
+
+// Start runs the job every interval until ctx is canceled.
+func (j *Job) Start(ctx context.Context) error {
+	j.logger.Info("integrity job started",
+		"interval", j.interval,
+		"max_documents_per_run", j.maxDocuments)
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			j.logger.Info("integrity job stopped")
+			return ctx.Err()
+		case <-ticker.C:
+			stats, err := j.Run(ctx)
+			if err != nil {
+				j.logger.Error("error running integrity check", "error", err)
+				continue
+			}
+			j.logger.Info("integrity check completed",
+				"documents", stats.Documents,
+				"objects", stats.Objects,
+				"drifted", stats.Drifted,
+				"missing", stats.Missing,
+				"errors", stats.Errors)
+		}
+	}
+}
+
+// document is a document to check.
+type document struct {
+	ID                 uint
+	GoogleFileID       string
+	DocumentUUID       string
+	Title              string
+	ProviderType       string
+	ProviderDocumentID string
+}
+
+// objectKey identifies an object of a document.
+type objectKey struct {
+	kind string
+	name string
+}
+
+// Run checks documents once.
+func (j *Job) Run(ctx context.Context) (RunStats, error) {
+	var stats RunStats
+
+	q := j.db.WithContext(ctx).
+		Table("documents AS d").
+		Select(`d.id, d.google_file_id,
+			COALESCE(CAST(d.document_uuid AS TEXT), '') AS document_uuid,
+			d.title,
+			COALESCE(d.provider_type, '') AS provider_type,
+			COALESCE(d.provider_document_id, '') AS provider_document_id`).
+		Where("d.deleted_at IS NULL").
+		Order(`COALESCE((
+			SELECT MIN(di.last_checked_at) FROM document_integrity di
+			WHERE di.document_id = d.id
+		), '1970-01-01'), d.id`)
+	if j.maxDocuments > 0 {
+		q = q.Limit(j.maxDocuments)
+	}
+	var docs []document
+	if err := q.Scan(&docs).Error; err != nil {
+		return stats, fmt.Errorf("error finding documents: %w", err)
+	}
+
+	providers := map[string]workspace.WorkspaceProvider{}
+	for _, doc := range docs {
+		if ctx.Err() != nil {
+			return stats, ctx.Err()
+		}
+
+		p, providerID, err := j.resolveProvider(ctx, doc, providers)
+		if err != nil {
+			j.logger.Warn("error resolving document provider",
+				"error", err,
+				"doc_id", doc.GoogleFileID)
+			stats.Errors++
+			continue
+		}
+		j.checkDocument(ctx, doc, p, providerID, &stats)
+	}
+
+	return stats, nil
+}
+
+// resolveProvider returns the workspace provider and provider ID that store a
+// document. Documents are stored by the provider in their canonical provider
+// reference, which is updated when a document is repointed after a migration,
+// and otherwise by the primary workspace provider. Providers of other types
+// are cached in providers by type.
+func (j *Job) resolveProvider(
+	ctx context.Context,
+	doc document,
+	providers map[string]workspace.WorkspaceProvider,
+) (workspace.WorkspaceProvider, string, error) {
+	if doc.ProviderType == "" || doc.ProviderDocumentID == "" {
+		return j.provider, fmt.Sprintf("%s:%s", j.providerName, doc.GoogleFileID), nil
+	}
+	providerID := doc.ProviderDocumentID
+	if !strings.Contains(providerID, ":") {
+		providerID = doc.ProviderType + ":" + providerID
+	}
+	if doc.ProviderType == j.providerName {
+		return j.provider, providerID, nil
+	}
+
+	if p, ok := providers[doc.ProviderType]; ok {
+		return p, providerID, nil
+	}
+	var names []string
+	if err := j.db.WithContext(ctx).Raw(`
+		SELECT provider_name FROM provider_storage
+		WHERE provider_type = ?
+		ORDER BY id
+	`, doc.ProviderType).Scan(&names).Error; err != nil {
+		return nil, "", fmt.Errorf("error getting storage providers: %w", err)
+	}
+	for _, name := range names {
+		if p, ok := j.storageProviders[name]; ok {
+			providers[doc.ProviderType] = p
+			return p, providerID, nil
+		}
+	}
+	return nil, "", fmt.Errorf(
+		"document is stored in a %q provider, which is not configured", doc.ProviderType)
+}
+
+// checkDocument checks the content and attached files of a document, records
+// the results, and notifies operators about the objects that newly failed.
+// Objects that couldn't be checked keep the result of their last check.
+func (j *Job) checkDocument(
+	ctx context.Context,
+	doc document,
+	p workspace.WorkspaceProvider,
+	providerID string,
+	stats *RunStats,
+) {
+	var prevRecords models.DocumentIntegrities
+	if err := prevRecords.FindByDocument(j.db.WithContext(ctx), doc.ID); err != nil {
+		j.logger.Error("error getting integrity records",
+			"error", err,
+			"doc_id", doc.GoogleFileID)
+		stats.Errors++
+		return
+	}
+	prev := make(map[objectKey]*models.DocumentIntegrity, len(prevRecords))
+	for i := range prevRecords {
+		r := &prevRecords[i]
+		prev[objectKey{r.Kind, r.Name}] = r
+	}
+
+	now := time.Now()
+	var records []models.DocumentIntegrity
+	record := func(r *models.DocumentIntegrity, err error) {
+		key := objectKey{r.Kind, r.Name}
+		if err != nil {
+			j.logger.Warn("error checking document integrity",
+				"error", err,
+				"doc_id", doc.GoogleFileID,
+				"kind", r.Kind,
+				"name", r.Name)
+			stats.Errors++
+			if last := prev[key]; last != nil {
+				records = append(records, *last)
+			}
+			return
+		}
+		r.ProviderID = providerID
+		r.LastCheckedAt = now
+		if r.Failed() {
+			r.DriftDetectedAt = &now
+			if last := prev[key]; last != nil && last.Failed() &&
+				last.DriftDetectedAt != nil {
+				r.DriftDetectedAt = last.DriftDetectedAt
+			}
+		}
+		records = append(records, *r)
+	}
+
+	record(j.checkContent(ctx, p, providerID, prev[objectKey{kind: models.DocumentIntegrityKindContent}]))
+	if ap, ok := workspace.Unwrap(p).(workspace.AttachmentProvider); ok {
+		var attachments models.DocumentAttachments
+		if err := j.db.WithContext(ctx).
+			Select("name", "content_hash").
+			Where("document_id = ?", doc.ID).
+			Order("name").
+			Find(&attachments).
+			Error; err != nil {
+			j.logger.Error("error getting document attachments",
+				"error", err,
+				"doc_id", doc.GoogleFileID)
+			stats.Errors++
+			return
+		}
+		for _, a := range attachments {
+			key := objectKey{models.DocumentIntegrityKindAttachment, a.Name}
+			record(j.checkAttachment(ctx, ap, providerID, a, prev[key]))
+		}
+	} else {
+		// Keep the results of attachments stored by a provider that was
+		// replaced.
+		for _, r := range prevRecords {
+			if r.Kind == models.DocumentIntegrityKindAttachment {
+				records = append(records, r)
+			}
+		}
+	}
+
+	if err := models.ReplaceDocumentIntegrity(
+		j.db.WithContext(ctx), doc.ID, records,
+	); err != nil {
+		j.logger.Error("error recording integrity checks",
+			"error", err,
+			"doc_id", doc.GoogleFileID)
+		stats.Errors++
+		return
+	}
+
+	stats.Documents++
+	for _, r := range records {
+		if !r.LastCheckedAt.Equal(now) {
+			continue
+		}
+		stats.Objects++
+		switch r.Status {
+		case models.DocumentIntegrityStatusDrift:
+			stats.Drifted++
+		case models.DocumentIntegrityStatusMissing:
+			stats.Missing++
+		}
+		if last := prev[objectKey{r.Kind, r.Name}]; r.Failed() &&
+			(last == nil || last.Status != r.Status) {
+			j.alert(ctx, doc, r)
+		}
+	}
+}
+
+// checkContent checks the content of a document. It returns the result and
+// the error if the content couldn't be checked.
+func (j *Job) checkContent(
+	ctx context.Context,
+	p workspace.WorkspaceProvider,
+	providerID string,
+	prev *models.DocumentIntegrity,
+) (*models.DocumentIntegrity, error) {
+	r := &models.DocumentIntegrity{Kind: models.DocumentIntegrityKindContent}
+	if prev != nil {
+		r.ExpectedHash = prev.ExpectedHash
+	}
+
+	meta, err := p.GetDocument(ctx, providerID)
+	if errors.Is(err, workspace.ErrNotFound) {
+		r.Status = models.DocumentIntegrityStatusMissing
+		return r, nil
+	}
+	if err != nil {
+		return r, fmt.Errorf("error getting document: %w", err)
+	}
+	content, err := p.GetContent(ctx, providerID)
+	if errors.Is(err, workspace.ErrNotFound) {
+		r.Status = models.DocumentIntegrityStatusMissing
+		return r, nil
+	}
+	if err != nil {
+		return r, fmt.Errorf("error getting document content: %w", err)
+	}
+
+	r.ActualHash, _ = Hash(strings.NewReader(content.Body))
+	if !meta.ModifiedTime.IsZero() {
+		modifiedAt := meta.ModifiedTime.UTC().Truncate(time.Second)
+		r.ModifiedAt = &modifiedAt
+	}
+
+	if expected := NormalizeHash(meta.ContentHash); expected != "" {
+		r.ExpectedHash = expected
+	} else if prev == nil || prev.ExpectedHash == "" ||
+		r.ModifiedAt == nil || prev.ModifiedAt == nil ||
+		!r.ModifiedAt.Equal(*prev.ModifiedAt) {
+		// The provider doesn't record hashes, so the content is compared to
+		// the hash of the last check unless it was modified since.
+		r.ExpectedHash = r.ActualHash
+	}
+
+	r.Status = models.DocumentIntegrityStatusOK
+	if r.ActualHash != r.ExpectedHash {
+		r.Status = models.DocumentIntegrityStatusDrift
+	}
+	return r, nil
+}
+
+// checkAttachment checks a file attached to a document. It returns the result
+// and the error if the file couldn't be checked.
+func (j *Job) checkAttachment(
+	ctx context.Context,
+	ap workspace.AttachmentProvider,
+	providerID string,
+	a models.DocumentAttachment,
+	prev *models.DocumentIntegrity,
+) (*models.DocumentIntegrity, error) {
+	r := &models.DocumentIntegrity{
+		Kind:         models.DocumentIntegrityKindAttachment,
+		Name:         a.Name,
+		ExpectedHash: NormalizeHash(a.ContentHash),
+	}
+	if r.ExpectedHash == "" && prev != nil {
+		r.ExpectedHash = prev.ExpectedHash
+	}
+
+	rc, err := ap.GetAttachment(ctx, providerID, a.Name)
+	if errors.Is(err, workspace.ErrNotFound) {
+		r.Status = models.DocumentIntegrityStatusMissing
+		return r, nil
+	}
+	if err != nil {
+		return r, fmt.Errorf("error getting attachment: %w", err)
+	}
+	defer rc.Close()
+	if r.ActualHash, err = Hash(rc); err != nil {
+		return r, fmt.Errorf("error reading attachment: %w", err)
+	}
+
+	if r.ExpectedHash == "" {
+		r.ExpectedHash = r.ActualHash
+	}
+	r.Status = models.DocumentIntegrityStatusOK
+	if r.ActualHash != r.ExpectedHash {
+		r.Status = models.DocumentIntegrityStatusDrift
+	}
+	return r, nil
+}
+
+// alert logs and notifies operators about an object that failed its integrity
+// check.
+func (j *Job) alert(ctx context.Context, doc document, r models.DocumentIntegrity) {
+	j.logger.Error("document integrity check failed",
+		"doc_id", doc.GoogleFileID,
+		"provider_id", r.ProviderID,
+		"kind", r.Kind,
+		"name", r.Name,
+		"status", r.Status,
+		"expected_hash", r.ExpectedHash,
+		"actual_hash", r.ActualHash)
+
+	if j.notifier == nil {
+		return
+	}
+	if err := j.notifier.NotifyIntegrityFailure(ctx, &Event{
+		DocumentID:   doc.GoogleFileID,
+		DocumentUUID: doc.DocumentUUID,
+		Title:        doc.Title,
+		ProviderID:   r.ProviderID,
+		Kind:         r.Kind,
+		Name:         r.Name,
+		Status:       r.Status,
+		ExpectedHash: r.ExpectedHash,
+		ActualHash:   r.ActualHash,
+		DetectedAt:   *r.DriftDetectedAt,
+	}); err != nil {
+		j.logger.Error("error notifying about integrity failure",
+			"error", err,
+			"doc_id", doc.GoogleFileID)
+	}
+}
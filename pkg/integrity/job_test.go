@@ -0,0 +1,269 @@
+package integrity
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp-forge/hermes/pkg/models/modelstest"
+	"github.com/hashicorp-forge/hermes/pkg/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type fakeNotifier struct {
+	events []*Event
+}
+
+func (n *fakeNotifier) NotifyIntegrityFailure(ctx context.Context, ev *Event) error {
+	n.events = append(n.events, ev)
+	return nil
+}
+
+// fakeObject is a document stored by fakeProvider.
+type fakeObject struct {
+	body        string
+	hash        string
+	modified    time.Time
+	attachments map[string]string
+}
+
+// fakeProvider stores documents keyed by provider ID.
+type fakeProvider struct {
+	workspace.WorkspaceProvider
+	docs map[string]*fakeObject
+}
+
+func (p *fakeProvider) GetDocument(ctx context.Context, providerID string) (*workspace.DocumentMetadata, error) {
+	d, ok := p.docs[providerID]
+	if !ok {
+		return nil, workspace.NotFoundError("document", providerID)
+	}
+	return &workspace.DocumentMetadata{
+		ProviderID:   providerID,
+		ModifiedTime: d.modified,
+		ContentHash:  d.hash,
+	}, nil
+}
+
+func (p *fakeProvider) GetContent(ctx context.Context, providerID string) (*workspace.DocumentContent, error) {
+	d, ok := p.docs[providerID]
+	if !ok {
+		return nil, workspace.NotFoundError("document", providerID)
+	}
+	return &workspace.DocumentContent{ProviderID: providerID, Body: d.body}, nil
+}
+
+func (p *fakeProvider) PutAttachment(ctx context.Context, providerID, name string, r io.Reader) error {
+	return nil
+}
+
+func (p *fakeProvider) GetAttachment(ctx context.Context, providerID, name string) (io.ReadCloser, error) {
+	d, ok := p.docs[providerID]
+	if !ok {
+		return nil, workspace.NotFoundError("document", providerID)
+	}
+	a, ok := d.attachments[name]
+	if !ok {
+		return nil, workspace.NotFoundError("attachment", name)
+	}
+	return io.NopCloser(strings.NewReader(a)), nil
+}
+
+func (p *fakeProvider) DeleteAttachment(ctx context.Context, providerID, name string) error {
+	return nil
+}
+
+func createDocument(t *testing.T, db *gorm.DB, id string) models.Document {
+	return modelstest.CreateDocument(t, db, models.Document{
+		GoogleFileID: id,
+		Owner:        &models.User{EmailAddress: "owner@example.com"},
+	})
+}
+
+func mustHash(t *testing.T, s string) string {
+	h, err := Hash(strings.NewReader(s))
+	require.NoError(t, err)
+	return h
+}
+
+func findRecords(t *testing.T, db *gorm.DB, d models.Document) models.DocumentIntegrities {
+	var records models.DocumentIntegrities
+	require.NoError(t, records.FindByDocument(db, d.ID))
+	return records
+}
+
+func TestNormalizeHash(t *testing.T) {
+	hex := strings.Repeat("ab", 32)
+	assert.Equal(t, "sha256:"+hex, NormalizeHash("sha256:"+hex))
+	assert.Equal(t, "sha256:"+hex, NormalizeHash(strings.ToUpper(hex)))
+	assert.Empty(t, NormalizeHash(""))
+	assert.Empty(t, NormalizeHash("md5:"+hex[:32]))
+	assert.Empty(t, NormalizeHash(strings.Repeat("zz", 32)))
+}
+
+func TestJob(t *testing.T) {
+	ctx := context.Background()
+	db := modelstest.NewDB(t)
+	t0 := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+
+	intact := createDocument(t, db, "intact")
+	corrupted := createDocument(t, db, "corrupted")
+	lost := createDocument(t, db, "lost")
+	require.NoError(t, (&models.DocumentAttachment{
+		DocumentID:  intact.ID,
+		Name:        "diagram.png",
+		ContentHash: strings.TrimPrefix(mustHash(t, "png"), "sha256:"),
+	}).Upsert(db))
+	require.NoError(t, (&models.DocumentAttachment{
+		DocumentID:  intact.ID,
+		Name:        "notes.txt",
+		ContentHash: strings.TrimPrefix(mustHash(t, "notes"), "sha256:"),
+	}).Upsert(db))
+
+	p := &fakeProvider{docs: map[string]*fakeObject{
+		"s3:intact": {
+			body:     "intact",
+			hash:     mustHash(t, "intact"),
+			modified: t0,
+			attachments: map[string]string{
+				"diagram.png": "png",
+				"notes.txt":   "bit rot",
+			},
+		},
+		"s3:corrupted": {
+			body:     "bit rot",
+			hash:     mustHash(t, "corrupted"),
+			modified: t0,
+		},
+	}}
+	n := &fakeNotifier{}
+	j := NewJob(db, p, n, nil, &Config{ProviderName: "s3"})
+
+	t.Run("Drift and missing objects are recorded and alerted", func(t *testing.T) {
+		stats, err := j.Run(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, RunStats{Documents: 3, Objects: 5, Drifted: 2, Missing: 1}, stats)
+
+		records := findRecords(t, db, intact)
+		require.Len(t, records, 3)
+		assert.Equal(t, "diagram.png", records[0].Name)
+		assert.Equal(t, models.DocumentIntegrityStatusOK, records[0].Status)
+		assert.Equal(t, "notes.txt", records[1].Name)
+		assert.Equal(t, models.DocumentIntegrityStatusDrift, records[1].Status)
+		assert.Equal(t, mustHash(t, "bit rot"), records[1].ActualHash)
+		assert.Equal(t, models.DocumentIntegrityKindContent, records[2].Kind)
+		assert.Equal(t, models.DocumentIntegrityStatusOK, records[2].Status)
+		assert.Equal(t, "s3:intact", records[2].ProviderID)
+
+		records = findRecords(t, db, corrupted)
+		require.Len(t, records, 1)
+		assert.Equal(t, models.DocumentIntegrityStatusDrift, records[0].Status)
+		assert.Equal(t, mustHash(t, "corrupted"), records[0].ExpectedHash)
+		require.NotNil(t, records[0].DriftDetectedAt)
+
+		records = findRecords(t, db, lost)
+		require.Len(t, records, 1)
+		assert.Equal(t, models.DocumentIntegrityStatusMissing, records[0].Status)
+
+		require.Len(t, n.events, 3)
+		assert.Equal(t, "intact", n.events[0].DocumentID)
+		assert.Equal(t, "notes.txt", n.events[0].Name)
+		assert.Equal(t, "corrupted", n.events[1].DocumentID)
+		assert.Equal(t, "lost", n.events[2].DocumentID)
+		assert.Equal(t, models.DocumentIntegrityStatusMissing, n.events[2].Status)
+	})
+
+	t.Run("Failures are only alerted once", func(t *testing.T) {
+		before := findRecords(t, db, corrupted)[0]
+
+		stats, err := j.Run(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, stats.Drifted)
+		assert.Len(t, n.events, 3)
+
+		after := findRecords(t, db, corrupted)[0]
+		assert.True(t, before.DriftDetectedAt.Equal(*after.DriftDetectedAt),
+			"first detection is kept")
+	})
+
+	t.Run("Repaired objects are ok", func(t *testing.T) {
+		p.docs["s3:corrupted"].body = "corrupted"
+		p.docs["s3:intact"].attachments["notes.txt"] = "notes"
+		require.NoError(t, db.Where("name = ?", "diagram.png").
+			Delete(&models.DocumentAttachment{}).Error)
+
+		stats, err := j.Run(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, RunStats{Documents: 3, Objects: 4, Missing: 1}, stats)
+
+		records := findRecords(t, db, corrupted)
+		assert.Equal(t, models.DocumentIntegrityStatusOK, records[0].Status)
+		assert.Nil(t, records[0].DriftDetectedAt)
+
+		records = findRecords(t, db, intact)
+		require.Len(t, records, 2, "deleted attachments are removed")
+		assert.Equal(t, models.DocumentIntegrityStatusOK, records[0].Status)
+	})
+
+	t.Run("Documents are checked least recently checked first", func(t *testing.T) {
+		j := NewJob(db, p, n, nil, &Config{
+			ProviderName:       "s3",
+			MaxDocumentsPerRun: 1,
+		})
+		require.NoError(t, db.Model(&models.DocumentIntegrity{}).
+			Where("document_id = ?", lost.ID).
+			Update("last_checked_at", t0).Error)
+
+		stats, err := j.Run(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, stats.Documents)
+		assert.True(t, findRecords(t, db, lost)[0].LastCheckedAt.After(t0))
+	})
+}
+
+func TestJobWithoutRecordedHashes(t *testing.T) {
+	ctx := context.Background()
+	db := modelstest.NewDB(t)
+	t0 := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+
+	d := createDocument(t, db, "doc")
+	p := &fakeProvider{docs: map[string]*fakeObject{
+		"google:doc": {body: "v1", modified: t0},
+	}}
+	n := &fakeNotifier{}
+	j := NewJob(db, p, n, nil, nil)
+
+	// The first check records the hash of the content.
+	_, err := j.Run(ctx)
+	require.NoError(t, err)
+	records := findRecords(t, db, d)
+	require.Len(t, records, 1)
+	assert.Equal(t, models.DocumentIntegrityStatusOK, records[0].Status)
+	assert.Equal(t, mustHash(t, "v1"), records[0].ExpectedHash)
+
+	// Content modified by the provider is ok.
+	p.docs["google:doc"] = &fakeObject{body: "v2", modified: t0.Add(time.Minute)}
+	_, err = j.Run(ctx)
+	require.NoError(t, err)
+	records = findRecords(t, db, d)
+	assert.Equal(t, models.DocumentIntegrityStatusOK, records[0].Status)
+	assert.Equal(t, mustHash(t, "v2"), records[0].ExpectedHash)
+	assert.Empty(t, n.events)
+
+	// Content that changed without being modified drifted.
+	p.docs["google:doc"].body = "v3"
+	stats, err := j.Run(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.Drifted)
+	records = findRecords(t, db, d)
+	assert.Equal(t, models.DocumentIntegrityStatusDrift, records[0].Status)
+	assert.Equal(t, mustHash(t, "v2"), records[0].ExpectedHash)
+	assert.Equal(t, mustHash(t, "v3"), records[0].ActualHash)
+	require.Len(t, n.events, 1)
+	assert.Equal(t, "doc", n.events[0].DocumentID)
+}
@@ -0,0 +1,296 @@
+// Package jobs runs background jobs queued in the database.
+//
+// Jobs are enqueued with a type and a JSON payload, and run by the handler
+// registered for their type. Jobs that return an error or panic are retried
+// with exponential backoff until they run out of attempts, and are kept in the
+// database after they finish so they can be listed and retried by admins.
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp-forge/hermes/pkg/requestid"
+	"github.com/hashicorp/go-hclog"
+	"gorm.io/gorm"
+)
+
+const (
+	// DefaultMaxAttempts is the default number of times a job is started
+	// before it fails.
+	DefaultMaxAttempts = 5
+
+	// DefaultPollInterval is how often the queue is polled for due jobs by
+	// default.
+	DefaultPollInterval = time.Second
+
+	// DefaultRetention is how long finished jobs are kept by default.
+	DefaultRetention = 7 * 24 * time.Hour
+
+	// DefaultWorkers is the default number of jobs run concurrently.
+	DefaultWorkers = 4
+
+	// retryBackoff is the delay before the first retry of a job. It doubles
+	// with every attempt.
+	retryBackoff = 10 * time.Second
+
+	// maxRetryBackoff is the maximum delay before the retry of a job.
+	maxRetryBackoff = time.Hour
+
+	// staleAfter is how long a job may run before it's assumed to have been
+	// abandoned by its process and is requeued.
+	staleAfter = 30 * time.Minute
+
+	// pruneInterval is how often finished jobs past the retention period are
+	// deleted.
+	pruneInterval = time.Hour
+)
+
+// Handler runs a job with payload. Returning an error retries the job.
+type Handler func(ctx context.Context, payload json.RawMessage) error
+
+// Config contains job runner configuration.
+type Config struct {
+	// MaxAttempts is the number of times a job is started before it fails.
+	MaxAttempts int
+
+	// PollInterval is how often the queue is polled for due jobs.
+	PollInterval time.Duration
+
+	// Retention is how long finished jobs are kept.
+	Retention time.Duration
+
+	// Workers is the number of jobs run concurrently.
+	Workers int
+}
+
+// Runner enqueues and runs jobs.
+type Runner struct {
+	db     *gorm.DB
+	logger hclog.Logger
+
+	maxAttempts  int
+	pollInterval time.Duration
+	retention    time.Duration
+	workers      int
+
+	mu       sync.RWMutex
+	handlers map[string]Handler
+}
+
+// NewRunner creates a new job runner.
+func NewRunner(db *gorm.DB, logger hclog.Logger, cfg *Config) *Runner {
+	if logger == nil {
+		logger = hclog.NewNullLogger()
+	}
+	r := &Runner{
+		db:           db,
+		logger:       logger.Named("jobs"),
+		maxAttempts:  DefaultMaxAttempts,
+		pollInterval: DefaultPollInterval,
+		retention:    DefaultRetention,
+		workers:      DefaultWorkers,
+		handlers:     map[string]Handler{},
+	}
+	if cfg != nil {
+		if cfg.MaxAttempts > 0 {
+			r.maxAttempts = cfg.MaxAttempts
+		}
+		if cfg.PollInterval > 0 {
+			r.pollInterval = cfg.PollInterval
+		}
+		if cfg.Retention > 0 {
+			r.retention = cfg.Retention
+		}
+		if cfg.Workers > 0 {
+			r.workers = cfg.Workers
+		}
+	}
+	return r
+}
+
+// Register registers handler h for jobs of type jobType, replacing any
+// handler already registered for it.
+func (r *Runner) Register(jobType string, h Handler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers[jobType] = h
+}
+
+// Enqueue queues a job of type jobType with payload encoded as JSON. The
+// request ID in ctx, if any, is recorded with the job and added to its log
+// lines.
+func (r *Runner) Enqueue(ctx context.Context, jobType string, payload any) error {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("error encoding job payload: %w", err)
+	}
+
+	j := models.Job{
+		Type:        jobType,
+		Payload:     models.JSON(b),
+		MaxAttempts: r.maxAttempts,
+		RequestID:   requestid.FromContext(ctx),
+	}
+	if err := j.Create(r.db.WithContext(ctx)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Start runs due jobs until ctx is canceled, and then waits for the jobs
+// being run to finish. Jobs that were left running by a process that exited
+// are requeued, and finished jobs are deleted after the retention period.
+func (r *Runner) Start(ctx context.Context) error {
+	r.logger.Info("job runner started",
+		"workers", r.workers,
+		"poll_interval", r.pollInterval)
+
+	var wg sync.WaitGroup
+	for i := 0; i < r.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.work(ctx)
+		}()
+	}
+
+	ticker := time.NewTicker(pruneInterval)
+	defer ticker.Stop()
+	for {
+		r.maintain(ctx)
+
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			r.logger.Info("job runner stopped")
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// work runs due jobs until ctx is canceled, and polls for due jobs while
+// there are none.
+func (r *Runner) work(ctx context.Context) {
+	for {
+		ran, err := r.RunNext(ctx)
+		if err != nil {
+			r.logger.Error("error running job", "error", err)
+		}
+		if ran && err == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(r.pollInterval):
+		}
+	}
+}
+
+// maintain requeues stale jobs and deletes expired ones.
+func (r *Runner) maintain(ctx context.Context) {
+	db := r.db.WithContext(ctx)
+	now := time.Now()
+
+	if n, err := models.RequeueStaleJobs(db, now.Add(-staleAfter)); err != nil {
+		r.logger.Error("error requeuing stale jobs", "error", err)
+	} else if n > 0 {
+		r.logger.Warn("requeued stale jobs", "jobs", n)
+	}
+
+	if n, err := models.DeleteFinishedJobsBefore(
+		db, now.Add(-r.retention)); err != nil {
+		r.logger.Error("error deleting expired jobs", "error", err)
+	} else if n > 0 {
+		r.logger.Info("deleted expired jobs", "jobs", n)
+	}
+}
+
+// RunNext runs the next due job, if any, and returns true if a job was run.
+// Errors of the job are recorded with it rather than returned. A claimed job
+// runs to completion even if ctx is canceled, so stopping the runner on
+// shutdown doesn't abandon jobs mid-run.
+func (r *Runner) RunNext(ctx context.Context) (bool, error) {
+	var j models.Job
+	if err := j.Claim(r.db.WithContext(ctx), time.Now()); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	ctx = context.WithoutCancel(ctx)
+	db := r.db.WithContext(ctx)
+
+	l := r.logger.With(
+		"job_id", j.ID,
+		"job_type", j.Type,
+		"attempt", j.Attempts,
+	)
+	if j.RequestID != "" {
+		l = l.With("request_id", j.RequestID)
+		ctx = requestid.NewContext(ctx, j.RequestID)
+	}
+
+	start := time.Now()
+	err := r.run(ctx, j)
+	now := time.Now()
+	if err == nil {
+		l.Debug("job succeeded", "duration", now.Sub(start))
+		return true, j.Succeed(db, now)
+	}
+
+	if j.Attempts < j.MaxAttempts {
+		l.Warn("job failed, will retry", "error", err)
+	} else {
+		l.Error("job failed", "error", err)
+	}
+	return true, j.Fail(db, err, now, now.Add(backoff(j.Attempts)))
+}
+
+// run runs job j with its handler and returns an error if the handler
+// returned one or panicked.
+func (r *Runner) run(ctx context.Context, j models.Job) (err error) {
+	// Jobs requeued after their process exited may have run out of attempts.
+	if j.Attempts > j.MaxAttempts {
+		return errors.New("job ran out of attempts")
+	}
+
+	r.mu.RLock()
+	h, ok := r.handlers[j.Type]
+	r.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("no handler registered for job type %q", j.Type)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			r.logger.Error("job panicked",
+				"job_id", j.ID,
+				"job_type", j.Type,
+				"panic", p,
+				"stack", string(debug.Stack()),
+			)
+			err = fmt.Errorf("panic: %v", p)
+		}
+	}()
+	return h(ctx, json.RawMessage(j.Payload))
+}
+
+// backoff returns the delay before the retry of a job that failed on attempt
+// attempt.
+func backoff(attempt int) time.Duration {
+	d := retryBackoff
+	for i := 1; i < attempt && d < maxRetryBackoff; i++ {
+		d *= 2
+	}
+	return min(d, maxRetryBackoff)
+}
@@ -0,0 +1,193 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp-forge/hermes/pkg/models/modelstest"
+	"github.com/hashicorp-forge/hermes/pkg/requestid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func getJob(t *testing.T, db *gorm.DB, id uint) models.Job {
+	j := models.Job{ID: id}
+	require.NoError(t, j.Get(db))
+	return j
+}
+
+func TestRunner(t *testing.T) {
+	db := modelstest.NewDB(t)
+	r := NewRunner(db, nil, &Config{MaxAttempts: 2})
+	ctx := requestid.NewContext(context.Background(), "req-1")
+
+	type payload struct {
+		DocID string `json:"docID"`
+	}
+	var got []payload
+	var gotRequestID string
+	r.Register("index", func(ctx context.Context, p json.RawMessage) error {
+		var v payload
+		if err := json.Unmarshal(p, &v); err != nil {
+			return err
+		}
+		got = append(got, v)
+		gotRequestID = requestid.FromContext(ctx)
+		return nil
+	})
+	r.Register("fail", func(ctx context.Context, p json.RawMessage) error {
+		return errors.New("search unavailable")
+	})
+
+	t.Run("Succeeded", func(t *testing.T) {
+		require.NoError(t, r.Enqueue(ctx, "index", payload{DocID: "doc1"}))
+		ran, err := r.RunNext(context.Background())
+		require.NoError(t, err)
+		require.True(t, ran)
+
+		assert.Equal(t, []payload{{DocID: "doc1"}}, got)
+		assert.Equal(t, "req-1", gotRequestID)
+
+		var js models.Jobs
+		require.NoError(t, js.Find(db, models.JobFilter{Type: "index"}))
+		require.Len(t, js, 1)
+		assert.Equal(t, models.SucceededJobStatus, js[0].Status)
+		assert.Equal(t, 1, js[0].Attempts)
+		assert.Equal(t, "req-1", js[0].RequestID)
+		assert.NotNil(t, js[0].FinishedAt)
+
+		ran, err = r.RunNext(context.Background())
+		require.NoError(t, err)
+		assert.False(t, ran, "no jobs are due")
+	})
+
+	t.Run("Retried then failed", func(t *testing.T) {
+		require.NoError(t, r.Enqueue(ctx, "fail", nil))
+		ran, err := r.RunNext(context.Background())
+		require.NoError(t, err)
+		require.True(t, ran)
+
+		var js models.Jobs
+		require.NoError(t, js.Find(db, models.JobFilter{Type: "fail"}))
+		require.Len(t, js, 1)
+		j := js[0]
+		assert.Equal(t, models.PendingJobStatus, j.Status)
+		assert.Equal(t, "search unavailable", j.LastError)
+		assert.True(t, j.RunAt.After(time.Now()), "retry is delayed")
+
+		// Make the retry due.
+		require.NoError(t, db.Model(&j).Update("run_at", time.Now()).Error)
+		ran, err = r.RunNext(context.Background())
+		require.NoError(t, err)
+		require.True(t, ran)
+
+		j = getJob(t, db, j.ID)
+		assert.Equal(t, models.FailedJobStatus, j.Status)
+		assert.Equal(t, 2, j.Attempts)
+
+		// Failed jobs can be retried.
+		require.NoError(t, j.Retry(db, time.Now()))
+		j = getJob(t, db, j.ID)
+		assert.Equal(t, models.PendingJobStatus, j.Status)
+		assert.Equal(t, 0, j.Attempts)
+		require.NoError(t, db.Delete(&j).Error)
+	})
+
+	t.Run("Panics are captured", func(t *testing.T) {
+		r := NewRunner(db, nil, &Config{MaxAttempts: 1})
+		r.Register("panic", func(ctx context.Context, p json.RawMessage) error {
+			panic("boom")
+		})
+		require.NoError(t, r.Enqueue(ctx, "panic", nil))
+		ran, err := r.RunNext(context.Background())
+		require.NoError(t, err)
+		require.True(t, ran)
+
+		var js models.Jobs
+		require.NoError(t, js.Find(db, models.JobFilter{Type: "panic"}))
+		require.Len(t, js, 1)
+		assert.Equal(t, models.FailedJobStatus, js[0].Status)
+		assert.Equal(t, "panic: boom", js[0].LastError)
+	})
+
+	t.Run("Unknown job type", func(t *testing.T) {
+		r := NewRunner(db, nil, &Config{MaxAttempts: 1})
+		require.NoError(t, r.Enqueue(ctx, "unknown", nil))
+		_, err := r.RunNext(context.Background())
+		require.NoError(t, err)
+
+		var js models.Jobs
+		require.NoError(t, js.Find(db, models.JobFilter{
+			Type: "unknown", Status: models.FailedJobStatus,
+		}))
+		require.Len(t, js, 1)
+		assert.Contains(t, js[0].LastError, "no handler registered")
+	})
+}
+
+func TestRunnerStop(t *testing.T) {
+	db := modelstest.NewDB(t)
+	r := NewRunner(db, nil, &Config{PollInterval: time.Millisecond})
+
+	// The job is still running when the runner is stopped.
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var jobErr error
+	r.Register("slow", func(ctx context.Context, p json.RawMessage) error {
+		close(started)
+		<-release
+		jobErr = ctx.Err()
+		return jobErr
+	})
+	require.NoError(t, r.Enqueue(context.Background(), "slow", nil))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- r.Start(ctx) }()
+	<-started
+	cancel()
+	close(release)
+	assert.ErrorIs(t, <-done, context.Canceled)
+
+	// The job finished with an uncanceled context.
+	assert.NoError(t, jobErr)
+	var js models.Jobs
+	require.NoError(t, js.Find(db, models.JobFilter{Type: "slow"}))
+	require.Len(t, js, 1)
+	assert.Equal(t, models.SucceededJobStatus, js[0].Status)
+}
+
+func TestRunnerMaintain(t *testing.T) {
+	db := modelstest.NewDB(t)
+	r := NewRunner(db, nil, &Config{Retention: time.Hour})
+	now := time.Now()
+
+	stale := models.Job{
+		Type: "index", MaxAttempts: 1, RunAt: now.Add(-3 * staleAfter),
+	}
+	require.NoError(t, stale.Create(db))
+	require.NoError(t, stale.Claim(db, now.Add(-2*staleAfter)))
+
+	expired := models.Job{Type: "index", MaxAttempts: 1, RunAt: now}
+	require.NoError(t, expired.Create(db))
+	require.NoError(t, expired.Claim(db, now))
+	require.NoError(t, expired.Succeed(db, now.Add(-2*time.Hour)))
+
+	r.maintain(context.Background())
+
+	assert.Equal(t, models.PendingJobStatus, getJob(t, db, stale.ID).Status)
+	err := (&models.Job{ID: expired.ID}).Get(db)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, 10*time.Second, backoff(1))
+	assert.Equal(t, 20*time.Second, backoff(2))
+	assert.Equal(t, 40*time.Second, backoff(3))
+	assert.Equal(t, time.Hour, backoff(20))
+}
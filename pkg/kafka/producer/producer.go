@@ -0,0 +1,232 @@
+// Package producer produces records to Kafka/Redpanda with the durability,
+// batching, and partitioning settings shared by Hermes producers (e.g., the
+// outbox relay and the notification publisher), and records produce metrics.
+package producer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/metrics"
+	"github.com/hashicorp-forge/hermes/pkg/tracing"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+// Acknowledgements required of brokers.
+const (
+	// AcksAll waits for all in-sync replicas to write a record.
+	AcksAll = "all"
+
+	// AcksLeader waits for the partition leader to write a record.
+	AcksLeader = "leader"
+
+	// AcksNone doesn't wait for brokers to write a record.
+	AcksNone = "none"
+)
+
+// Compression codecs of record batches.
+const (
+	CompressionGzip   = "gzip"
+	CompressionSnappy = "snappy"
+	CompressionLZ4    = "lz4"
+	CompressionZstd   = "zstd"
+	CompressionNone   = "none"
+)
+
+// Default producer settings.
+const (
+	DefaultAcks          = AcksAll
+	DefaultCompression   = CompressionGzip
+	DefaultLinger        = 10 * time.Millisecond
+	DefaultBatchMaxBytes = 1 << 20 // 1MB
+)
+
+// Config is the configuration of a Producer.
+type Config struct {
+	// Brokers are the Kafka/Redpanda broker addresses.
+	Brokers []string
+
+	// Name identifies the producer in metrics (e.g., "outbox-relay").
+	Name string
+
+	// Acks is the acknowledgement required of brokers for a record to be
+	// produced: AcksAll (default), AcksLeader, or AcksNone.
+	Acks string
+
+	// Compression is the compression codec of record batches:
+	// CompressionGzip (default), CompressionSnappy, CompressionLZ4,
+	// CompressionZstd, or CompressionNone.
+	Compression string
+
+	// DisableIdempotentWrite disables idempotent produce, which keeps records
+	// that are retried from being written twice. Idempotent produce requires
+	// AcksAll, so it's always disabled with other acks.
+	DisableIdempotentWrite bool
+
+	// Linger is how long records are held to be batched with other records
+	// (default: DefaultLinger).
+	Linger time.Duration
+
+	// BatchMaxBytes is the maximum size of a record batch (default:
+	// DefaultBatchMaxBytes).
+	BatchMaxBytes int32
+}
+
+// Producer produces records to Kafka/Redpanda. Records with the same key are
+// produced to the same partition, so they're consumed in the order they were
+// produced. It's safe for concurrent use.
+type Producer struct {
+	client *kgo.Client
+	name   string
+}
+
+// New returns a Producer with configuration cfg.
+func New(cfg Config) (*Producer, error) {
+	if len(cfg.Brokers) == 0 {
+		return nil, fmt.Errorf("at least one broker is required")
+	}
+	if cfg.Name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	acks, err := parseAcks(cfg.Acks)
+	if err != nil {
+		return nil, err
+	}
+	compression, err := parseCompression(cfg.Compression)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Linger == 0 {
+		cfg.Linger = DefaultLinger
+	}
+	if cfg.BatchMaxBytes == 0 {
+		cfg.BatchMaxBytes = DefaultBatchMaxBytes
+	}
+
+	opts := []kgo.Opt{
+		kgo.SeedBrokers(cfg.Brokers...),
+
+		// Producer durability settings
+		kgo.RequiredAcks(acks),
+		kgo.ProducerBatchCompression(compression),
+
+		// Keyed records are hashed to partitions like the Java client does,
+		// so records of the same document stay ordered whichever client
+		// produces them.
+		kgo.RecordPartitioner(kgo.StickyKeyPartitioner(nil)),
+
+		// Retry with a linear backoff capped at 60s.
+		kgo.RetryBackoffFn(func(tries int) time.Duration {
+			backoff := time.Duration(tries) * 100 * time.Millisecond
+			if backoff > 60*time.Second {
+				backoff = 60 * time.Second
+			}
+			return backoff
+		}),
+		kgo.RequestRetries(10),
+
+		// Batching for better throughput
+		kgo.ProducerLinger(cfg.Linger),
+		kgo.ProducerBatchMaxBytes(cfg.BatchMaxBytes),
+	}
+	if cfg.DisableIdempotentWrite || cfg.Acks != "" && cfg.Acks != AcksAll {
+		opts = append(opts, kgo.DisableIdempotentWrite())
+	}
+
+	client, err := kgo.NewClient(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kafka client: %w", err)
+	}
+
+	return &Producer{
+		client: client,
+		name:   cfg.Name,
+	}, nil
+}
+
+// Produce produces record and waits until it's acknowledged, returning the
+// record with its partition and offset set.
+func (p *Producer) Produce(ctx context.Context, record *kgo.Record) (*kgo.Record, error) {
+	start := time.Now()
+	ctx, span := tracing.StartProduce(ctx, record)
+	res, err := p.client.ProduceSync(ctx, record).First()
+	tracing.End(span, err)
+	metrics.ObserveKafkaProduce(
+		p.name, record.Topic, len(record.Value), time.Since(start), err)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Ping returns an error if the producer can't reach any broker.
+func (p *Producer) Ping(ctx context.Context) error {
+	return p.client.Ping(ctx)
+}
+
+// Close waits for records being produced and closes the producer.
+func (p *Producer) Close() {
+	p.client.Close()
+}
+
+// DocumentRecord returns a record of an event of document documentUUID, keyed
+// by the document UUID so the events of a document are consumed in order.
+func DocumentRecord(topic, documentUUID string, value []byte, headers ...kgo.RecordHeader) *kgo.Record {
+	return &kgo.Record{
+		Topic:   topic,
+		Key:     []byte(documentUUID),
+		Value:   value,
+		Headers: headers,
+	}
+}
+
+// ValidateAcks returns an error if acks isn't a valid acknowledgement. Empty
+// acks are valid and mean DefaultAcks.
+func ValidateAcks(acks string) error {
+	_, err := parseAcks(acks)
+	return err
+}
+
+// ValidateCompression returns an error if compression isn't a valid
+// compression codec. An empty compression is valid and means
+// DefaultCompression.
+func ValidateCompression(compression string) error {
+	_, err := parseCompression(compression)
+	return err
+}
+
+// parseAcks parses acks.
+func parseAcks(acks string) (kgo.Acks, error) {
+	switch acks {
+	case "", AcksAll:
+		return kgo.AllISRAcks(), nil
+	case AcksLeader:
+		return kgo.LeaderAck(), nil
+	case AcksNone:
+		return kgo.NoAck(), nil
+	default:
+		return kgo.Acks{}, fmt.Errorf(
+			`invalid acks %q: must be "all", "leader", or "none"`, acks)
+	}
+}
+
+// parseCompression parses compression.
+func parseCompression(compression string) (kgo.CompressionCodec, error) {
+	switch compression {
+	case "", CompressionGzip:
+		return kgo.GzipCompression(), nil
+	case CompressionSnappy:
+		return kgo.SnappyCompression(), nil
+	case CompressionLZ4:
+		return kgo.Lz4Compression(), nil
+	case CompressionZstd:
+		return kgo.ZstdCompression(), nil
+	case CompressionNone:
+		return kgo.NoCompression(), nil
+	default:
+		return kgo.CompressionCodec{}, fmt.Errorf(
+			`invalid compression %q: must be "gzip", "snappy", "lz4", "zstd", or "none"`,
+			compression)
+	}
+}
@@ -0,0 +1,80 @@
+package producer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+func TestNew(t *testing.T) {
+	tests := map[string]struct {
+		cfg     Config
+		wantErr bool
+	}{
+		"defaults": {
+			cfg: Config{Brokers: []string{"localhost:9092"}, Name: "test"},
+		},
+		"leader acks without idempotence": {
+			cfg: Config{
+				Brokers: []string{"localhost:9092"}, Name: "test",
+				Acks: AcksLeader, Compression: CompressionZstd,
+			},
+		},
+		"no brokers": {
+			cfg:     Config{Name: "test"},
+			wantErr: true,
+		},
+		"no name": {
+			cfg:     Config{Brokers: []string{"localhost:9092"}},
+			wantErr: true,
+		},
+		"invalid acks": {
+			cfg: Config{
+				Brokers: []string{"localhost:9092"}, Name: "test", Acks: "2",
+			},
+			wantErr: true,
+		},
+		"invalid compression": {
+			cfg: Config{
+				Brokers: []string{"localhost:9092"}, Name: "test",
+				Compression: "brotli",
+			},
+			wantErr: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			p, err := New(tc.cfg)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			p.Close()
+		})
+	}
+}
+
+func TestDocumentRecord(t *testing.T) {
+	r := DocumentRecord("hermes.document-revisions", "7d9f3c2e", []byte("{}"),
+		kgo.RecordHeader{Key: "event_type", Value: []byte("revision.created")})
+	assert.Equal(t, "hermes.document-revisions", r.Topic)
+	assert.Equal(t, []byte("7d9f3c2e"), r.Key)
+	assert.Equal(t, []byte("{}"), r.Value)
+	assert.Len(t, r.Headers, 1)
+}
+
+func TestProduceUnreachableBroker(t *testing.T) {
+	p, err := New(Config{Brokers: []string{"127.0.0.1:1"}, Name: "test"})
+	require.NoError(t, err)
+	defer p.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	_, err = p.Produce(ctx, DocumentRecord("topic", "doc", []byte("{}")))
+	assert.Error(t, err)
+}
@@ -0,0 +1,265 @@
+package linkcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// maxRedirects is the number of redirects followed when checking a link.
+const maxRedirects = 10
+
+// ErrBlockedAddress is returned for links to private, loopback, link-local, and
+// other non-public addresses, which aren't checked so the checker can't be
+// used to probe internal services.
+var ErrBlockedAddress = errors.New("link target is not a public address")
+
+// Result is the outcome of checking a link.
+type Result struct {
+	// Broken is true if the link could not be followed.
+	Broken bool
+
+	// StatusCode is the HTTP status returned, or 0 if the request failed or the
+	// link is internal.
+	StatusCode int
+
+	// Error describes why the link is broken.
+	Error string
+}
+
+// HTTPChecker checks external links with HEAD requests, falling back to GET
+// for servers that don't support HEAD. The number of concurrent requests is
+// bounded and results are cached for the checker's lifetime.
+type HTTPChecker struct {
+	client *http.Client
+	sem    chan struct{}
+
+	// allowIP returns true if requests may be made to an IP address.
+	allowIP func(net.IP) bool
+
+	mu    sync.Mutex
+	cache map[string]Result
+
+	// interval is the minimum time between requests, and next is the
+	// earliest time the next request may be made. Zero means no limit.
+	interval time.Duration
+	next     time.Time
+}
+
+// NewHTTPChecker creates an HTTP checker that allows at most maxConcurrency
+// requests at a time, each limited to timeout. Only public addresses are
+// checked, including the targets of redirects.
+func NewHTTPChecker(timeout time.Duration, maxConcurrency int) *HTTPChecker {
+	if maxConcurrency <= 0 {
+		maxConcurrency = 1
+	}
+	c := &HTTPChecker{
+		sem:     make(chan struct{}, maxConcurrency),
+		cache:   make(map[string]Result),
+		allowIP: isPublicIP,
+	}
+
+	// Check the address of every connection, after DNS resolution, so hosts
+	// that resolve to internal addresses are blocked too. Proxies are not
+	// used, since the proxy's address would be checked instead of the link's.
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !c.allowIP(ip) {
+				return fmt.Errorf("%w: %s", ErrBlockedAddress, host)
+			}
+			return nil
+		},
+	}
+	c.client = &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+		},
+		CheckRedirect: c.checkRedirect,
+	}
+	return c
+}
+
+// SetRateLimit limits the checker to requestsPerSecond requests per second
+// across all concurrent checks. Zero or less means no limit.
+func (c *HTTPChecker) SetRateLimit(requestsPerSecond int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.interval = 0
+	if requestsPerSecond > 0 {
+		c.interval = time.Second / time.Duration(requestsPerSecond)
+	}
+}
+
+// wait blocks until the rate limit allows another request or ctx is done.
+func (c *HTTPChecker) wait(ctx context.Context) error {
+	c.mu.Lock()
+	if c.interval == 0 {
+		c.mu.Unlock()
+		return nil
+	}
+	now := time.Now()
+	at := c.next
+	if at.Before(now) {
+		at = now
+	}
+	c.next = at.Add(c.interval)
+	c.mu.Unlock()
+
+	if d := time.Until(at); d > 0 {
+		t := time.NewTimer(d)
+		defer t.Stop()
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// checkRedirect rejects redirects to non-HTTP URLs and to hosts that resolve
+// to non-public addresses.
+func (c *HTTPChecker) checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxRedirects)
+	}
+	return c.checkTarget(req.Context(), req.URL)
+}
+
+// checkTarget returns an error if u is not an HTTP URL or its host resolves to
+// an address that isn't allowed.
+func (c *HTTPChecker) checkTarget(ctx context.Context, u *url.URL) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupported URL scheme %q", u.Scheme)
+	}
+
+	host := u.Hostname()
+	if ip := net.ParseIP(host); ip != nil {
+		if !c.allowIP(ip) {
+			return fmt.Errorf("%w: %s", ErrBlockedAddress, host)
+		}
+		return nil
+	}
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return err
+	}
+	for _, a := range addrs {
+		if !c.allowIP(a.IP) {
+			return fmt.Errorf("%w: %s (%s)", ErrBlockedAddress, host, a.IP)
+		}
+	}
+	return nil
+}
+
+// isPublicIP returns true if ip is a public unicast address.
+func isPublicIP(ip net.IP) bool {
+	return ip.IsGlobalUnicast() && !ip.IsPrivate() && !isSharedAddress(ip)
+}
+
+// sharedAddressSpace is the carrier-grade NAT range (RFC 6598), which is
+// also used for internal services by some cloud providers.
+var sharedAddressSpace = &net.IPNet{
+	IP:   net.IPv4(100, 64, 0, 0),
+	Mask: net.CIDRMask(10, 32),
+}
+
+// isSharedAddress returns true if ip is in the shared address space.
+func isSharedAddress(ip net.IP) bool {
+	return sharedAddressSpace.Contains(ip)
+}
+
+// Check checks an external URL.
+func (c *HTTPChecker) Check(ctx context.Context, url string) Result {
+	c.mu.Lock()
+	res, ok := c.cache[url]
+	c.mu.Unlock()
+	if ok {
+		return res
+	}
+
+	select {
+	case c.sem <- struct{}{}:
+	case <-ctx.Done():
+		return Result{Broken: true, Error: ctx.Err().Error()}
+	}
+	res = c.check(ctx, url)
+	<-c.sem
+
+	// Don't cache results of a canceled run.
+	if ctx.Err() == nil {
+		c.mu.Lock()
+		c.cache[url] = res
+		c.mu.Unlock()
+	}
+	return res
+}
+
+// check makes a HEAD request for url, retrying with GET if HEAD is not
+// supported.
+func (c *HTTPChecker) check(ctx context.Context, url string) Result {
+	code, err := c.do(ctx, http.MethodHead, url)
+	if err == nil &&
+		(code == http.StatusMethodNotAllowed || code == http.StatusNotImplemented) {
+		code, err = c.do(ctx, http.MethodGet, url)
+	}
+	if err != nil {
+		return Result{Broken: true, Error: err.Error()}
+	}
+	if isBrokenStatus(code) {
+		return Result{
+			Broken:     true,
+			StatusCode: code,
+			Error:      fmt.Sprintf("HTTP %d %s", code, http.StatusText(code)),
+		}
+	}
+	return Result{StatusCode: code}
+}
+
+// do makes a request and returns the response status code. The response body
+// is not read.
+func (c *HTTPChecker) do(ctx context.Context, method, url string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return 0, fmt.Errorf("invalid URL: %w", err)
+	}
+	if err := c.checkTarget(ctx, req.URL); err != nil {
+		return 0, err
+	}
+	req.Header.Set("User-Agent", "Hermes-LinkCheck/1.0")
+	if err := c.wait(ctx); err != nil {
+		return 0, err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+// isBrokenStatus returns true if an HTTP status code means the link is broken.
+// Responses that require authentication or are rate limited are not broken;
+// the page exists but can't be seen by the checker.
+func isBrokenStatus(code int) bool {
+	switch code {
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests:
+		return false
+	}
+	return code >= 400
+}
@@ -0,0 +1,162 @@
+package linkcheck
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPChecker(t *testing.T) {
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+			switch r.URL.Path {
+			case "/ok":
+				w.WriteHeader(http.StatusOK)
+			case "/missing":
+				w.WriteHeader(http.StatusNotFound)
+			case "/private":
+				w.WriteHeader(http.StatusForbidden)
+			case "/no-head":
+				if r.Method == http.MethodHead {
+					w.WriteHeader(http.StatusMethodNotAllowed)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			default:
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+		}))
+	defer ts.Close()
+
+	c := NewHTTPChecker(5*time.Second, 2)
+	// The test server listens on a loopback address.
+	c.allowIP = func(net.IP) bool { return true }
+	ctx := context.Background()
+
+	t.Run("OK", func(t *testing.T) {
+		res := c.Check(ctx, ts.URL+"/ok")
+		assert.False(t, res.Broken)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+	})
+
+	t.Run("not found is broken", func(t *testing.T) {
+		res := c.Check(ctx, ts.URL+"/missing")
+		assert.True(t, res.Broken)
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+		assert.Equal(t, "HTTP 404 Not Found", res.Error)
+	})
+
+	t.Run("forbidden is not broken", func(t *testing.T) {
+		res := c.Check(ctx, ts.URL+"/private")
+		assert.False(t, res.Broken)
+	})
+
+	t.Run("falls back to GET", func(t *testing.T) {
+		res := c.Check(ctx, ts.URL+"/no-head")
+		assert.False(t, res.Broken)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+	})
+
+	t.Run("results are cached", func(t *testing.T) {
+		before := atomic.LoadInt32(&requests)
+		res := c.Check(ctx, ts.URL+"/missing")
+		assert.True(t, res.Broken)
+		assert.Equal(t, before, atomic.LoadInt32(&requests))
+	})
+
+	t.Run("connection error is broken", func(t *testing.T) {
+		res := c.Check(ctx, "http://127.0.0.1:1/unreachable")
+		assert.True(t, res.Broken)
+		assert.Zero(t, res.StatusCode)
+		assert.NotEmpty(t, res.Error)
+	})
+}
+
+func TestHTTPCheckerBlocksInternalAddresses(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, "http://169.254.169.254/latest/meta-data/",
+				http.StatusFound)
+		}))
+	defer ts.Close()
+	ctx := context.Background()
+
+	t.Run("loopback", func(t *testing.T) {
+		c := NewHTTPChecker(5*time.Second, 1)
+		res := c.Check(ctx, ts.URL)
+		assert.True(t, res.Broken)
+		assert.Contains(t, res.Error, ErrBlockedAddress.Error())
+	})
+
+	t.Run("redirect to link-local", func(t *testing.T) {
+		c := NewHTTPChecker(5*time.Second, 1)
+		// Allow the test server, but not the redirect target.
+		c.allowIP = func(ip net.IP) bool { return ip.IsLoopback() }
+		res := c.Check(ctx, ts.URL)
+		assert.True(t, res.Broken)
+		assert.Contains(t, res.Error, ErrBlockedAddress.Error())
+	})
+
+	t.Run("dial", func(t *testing.T) {
+		c := NewHTTPChecker(5*time.Second, 1)
+		_, err := c.client.Get(ts.URL)
+		assert.True(t, errors.Is(err, ErrBlockedAddress))
+	})
+}
+
+func TestHTTPCheckerRateLimit(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	c := NewHTTPChecker(time.Second, 5)
+	c.SetRateLimit(20)
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		assert.NoError(c.wait(ctx))
+	}
+	assert.GreaterOrEqual(time.Since(start), 100*time.Millisecond)
+
+	// A canceled context stops waiting.
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	c.SetRateLimit(1)
+	c.wait(ctx)
+	assert.ErrorIs(c.wait(canceled), context.Canceled)
+
+	// No limit.
+	c.SetRateLimit(0)
+	start = time.Now()
+	for i := 0; i < 100; i++ {
+		assert.NoError(c.wait(ctx))
+	}
+	assert.Less(time.Since(start), 100*time.Millisecond)
+}
+
+func TestIsPublicIP(t *testing.T) {
+	cases := map[string]bool{
+		"8.8.8.8":         true,
+		"2606:4700::1111": true,
+		"127.0.0.1":       false,
+		"10.1.2.3":        false,
+		"172.16.0.1":      false,
+		"192.168.1.1":     false,
+		"169.254.169.254": false,
+		"100.64.0.1":      false,
+		"0.0.0.0":         false,
+		"::1":             false,
+		"fe80::1":         false,
+		"fd00::1":         false,
+	}
+	for ip, want := range cases {
+		assert.Equal(t, want, isPublicIP(net.ParseIP(ip)), ip)
+	}
+}
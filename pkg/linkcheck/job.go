@@ -0,0 +1,284 @@
+package linkcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp-forge/hermes/pkg/search"
+	"github.com/hashicorp/go-hclog"
+	"gorm.io/gorm"
+)
+
+// DefaultMaxConcurrency is the default maximum number of concurrent external
+// link requests.
+const DefaultMaxConcurrency = 5
+
+// Config contains link check job configuration.
+type Config struct {
+	// BaseURLs are the Hermes base URL and short link base URL, used to
+	// recognize links to Hermes itself.
+	BaseURLs []string
+
+	// Interval is how often all documents are checked.
+	Interval time.Duration
+
+	// Timeout is the timeout for each external link request.
+	Timeout time.Duration
+
+	// MaxConcurrency is the maximum number of concurrent external link
+	// requests.
+	MaxConcurrency int
+
+	// MaxExternalPerRun is the maximum number of external links requested per
+	// run. Links over the budget are checked in a later run. Zero means no
+	// limit.
+	MaxExternalPerRun int
+
+	// MaxRequestsPerSecond limits the rate of external link requests. Zero
+	// means no limit.
+	MaxRequestsPerSecond int
+}
+
+// Job periodically checks outbound links in indexed document content and
+// records broken links for each document.
+type Job struct {
+	db             *gorm.DB
+	searchProvider search.Provider
+	logger         hclog.Logger
+	cfg            Config
+}
+
+// RunStats summarizes a link check run.
+type RunStats struct {
+	Documents int
+	Links     int
+	Broken    int
+	Unchecked int
+}
+
+// NewJob creates a new link check job.
+func NewJob(
+	db *gorm.DB,
+	searchProvider search.Provider,
+	logger hclog.Logger,
+	cfg *Config,
+) *Job {
+	if logger == nil {
+		logger = hclog.NewNullLogger()
+	}
+	c := Config{}
+	if cfg != nil {
+		c = *cfg
+	}
+	if c.Interval <= 0 {
+		c.Interval = 24 * time.Hour
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = 10 * time.Second
+	}
+	if c.MaxConcurrency <= 0 {
+		c.MaxConcurrency = DefaultMaxConcurrency
+	}
+
+	return &Job{
+		db:             db,
+		searchProvider: searchProvider,
+		logger:         logger.Named("link-check"),
+		cfg:            c,
+	}
+}
+
+// Start runs the job every interval until ctx is canceled.
+func (j *Job) Start(ctx context.Context) error {
+	j.logger.Info("link check job started",
+		"interval", j.cfg.Interval,
+		"max_concurrency", j.cfg.MaxConcurrency)
+
+	ticker := time.NewTicker(j.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			j.logger.Info("link check job stopped")
+			return ctx.Err()
+		case <-ticker.C:
+			stats, err := j.Run(ctx)
+			if err != nil {
+				j.logger.Error("error running link check", "error", err)
+				continue
+			}
+			j.logger.Info("link check completed",
+				"documents", stats.Documents,
+				"links", stats.Links,
+				"broken", stats.Broken,
+				"unchecked", stats.Unchecked)
+		}
+	}
+}
+
+// Run checks the links in all documents once.
+func (j *Job) Run(ctx context.Context) (RunStats, error) {
+	var stats RunStats
+
+	var docs []models.Document
+	if err := j.db.WithContext(ctx).
+		Select("id", "google_file_id", "status").
+		Find(&docs).
+		Error; err != nil {
+		return stats, fmt.Errorf("error finding documents: %w", err)
+	}
+
+	checker := NewHTTPChecker(j.cfg.Timeout, j.cfg.MaxConcurrency)
+	checker.SetRateLimit(j.cfg.MaxRequestsPerSecond)
+	budget := j.cfg.MaxExternalPerRun
+
+	for _, doc := range docs {
+		if ctx.Err() != nil {
+			return stats, ctx.Err()
+		}
+
+		content, err := j.getContent(ctx, doc)
+		if err != nil {
+			if !errors.Is(err, search.ErrNotFound) {
+				j.logger.Warn("error getting document content",
+					"error", err,
+					"doc_id", doc.GoogleFileID)
+			}
+			continue
+		}
+
+		links := ExtractLinks(content, j.cfg.BaseURLs...)
+		broken, unchecked := j.checkLinks(ctx, checker, links, &budget)
+		if ctx.Err() != nil {
+			return stats, ctx.Err()
+		}
+
+		if err := models.ReplaceDocumentBrokenLinks(
+			j.db.WithContext(ctx), doc.ID, broken, unchecked,
+		); err != nil {
+			j.logger.Error("error recording broken links",
+				"error", err,
+				"doc_id", doc.GoogleFileID)
+			continue
+		}
+
+		stats.Documents++
+		stats.Links += len(links)
+		stats.Broken += len(broken)
+		stats.Unchecked += len(unchecked)
+	}
+
+	return stats, nil
+}
+
+// getContent returns a document's indexed content.
+func (j *Job) getContent(ctx context.Context, doc models.Document) (string, error) {
+	var (
+		sd  *search.Document
+		err error
+	)
+	if doc.Status == models.WIPDocumentStatus {
+		sd, err = j.searchProvider.DraftIndex().GetObject(ctx, doc.GoogleFileID)
+	} else {
+		sd, err = j.searchProvider.DocumentIndex().GetObject(ctx, doc.GoogleFileID)
+	}
+	if err != nil {
+		return "", err
+	}
+	return sd.Content, nil
+}
+
+// checkLinks checks a document's links and returns the broken links and the
+// URLs of external links that were not checked because the run's budget was
+// used up. budget is decremented for each external link requested; a
+// non-positive starting budget means no limit.
+func (j *Job) checkLinks(
+	ctx context.Context,
+	checker *HTTPChecker,
+	links []Link,
+	budget *int,
+) ([]models.DocumentBrokenLink, []string) {
+	limited := j.cfg.MaxExternalPerRun > 0
+	now := time.Now()
+
+	results := make([]Result, len(links))
+	var unchecked []string
+	var wg sync.WaitGroup
+	for i, l := range links {
+		switch l.Type {
+		case LinkTypeDocument:
+			results[i] = j.checkDocument(ctx, l.Target)
+		case LinkTypeShortLink:
+			results[i] = j.checkShortLink(ctx, l.Target)
+		case LinkTypeExternal:
+			if limited {
+				if *budget <= 0 {
+					unchecked = append(unchecked, l.URL)
+					continue
+				}
+				*budget--
+			}
+			wg.Add(1)
+			go func(i int, target string) {
+				defer wg.Done()
+				results[i] = checker.Check(ctx, target)
+			}(i, l.Target)
+		}
+	}
+	wg.Wait()
+
+	var broken []models.DocumentBrokenLink
+	for i, res := range results {
+		if !res.Broken {
+			continue
+		}
+		broken = append(broken, models.DocumentBrokenLink{
+			URL:           links[i].URL,
+			LinkType:      string(links[i].Type),
+			StatusCode:    res.StatusCode,
+			Error:         res.Error,
+			LastCheckedAt: now,
+		})
+	}
+	return broken, unchecked
+}
+
+// checkDocument checks that a linked document exists.
+func (j *Job) checkDocument(ctx context.Context, docID string) Result {
+	var count int64
+	if err := j.db.WithContext(ctx).
+		Model(&models.Document{}).
+		Where("google_file_id = ?", docID).
+		Count(&count).
+		Error; err != nil {
+		// Don't report links as broken because of database errors.
+		j.logger.Warn("error checking linked document",
+			"error", err,
+			"linked_doc_id", docID)
+		return Result{}
+	}
+	if count == 0 {
+		return Result{Broken: true, Error: "document not found"}
+	}
+	return Result{}
+}
+
+// checkShortLink checks that a short link is in the redirect registry.
+func (j *Job) checkShortLink(ctx context.Context, objectID string) Result {
+	_, err := j.searchProvider.LinksIndex().GetLink(ctx, objectID)
+	if err == nil {
+		return Result{}
+	}
+	if errors.Is(err, search.ErrNotFound) {
+		return Result{Broken: true, Error: "short link not found"}
+	}
+	j.logger.Warn("error checking short link",
+		"error", err,
+		"object_id", objectID)
+	return Result{}
+}
@@ -0,0 +1,115 @@
+// Package linkcheck finds broken outbound links in document content.
+package linkcheck
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// LinkType describes how a link is checked.
+type LinkType string
+
+const (
+	// LinkTypeDocument is a link to a Hermes document (/document/:id).
+	LinkTypeDocument LinkType = "document"
+
+	// LinkTypeShortLink is a Hermes short link (/l/:doctype/:product-number)
+	// resolved by the redirect registry.
+	LinkTypeShortLink LinkType = "shortlink"
+
+	// LinkTypeExternal is a link to another site, checked over HTTP.
+	LinkTypeExternal LinkType = "external"
+)
+
+// Link is an outbound link found in document content.
+type Link struct {
+	// URL is the link as written in the document.
+	URL string
+
+	Type LinkType
+
+	// Target is the document ID for document links, the redirect registry
+	// object ID for short links, and the absolute URL for external links.
+	Target string
+}
+
+var (
+	// markdownLinkRE matches the target of Markdown links and images, e.g.
+	// [text](target "title").
+	markdownLinkRE = regexp.MustCompile(`\]\(\s*<?([^\s()<>]+)>?(?:\s+"[^"]*")?\s*\)`)
+
+	// bareURLRE matches absolute URLs in plain text.
+	bareURLRE = regexp.MustCompile("https?://[^\\s<>()\\[\\]\"'`]+")
+)
+
+// ExtractLinks returns the unique outbound links in content, in the order they
+// first appear. Links that can't be checked (anchors, mailto:, and relative
+// links to other Hermes pages) are skipped. baseURLs are the Hermes base URL
+// and short link base URL, used to recognize absolute links to Hermes itself.
+func ExtractLinks(content string, baseURLs ...string) []Link {
+	var hosts []string
+	for _, b := range baseURLs {
+		if u, err := url.Parse(b); err == nil && u.Host != "" {
+			hosts = append(hosts, u.Host)
+		}
+	}
+
+	var raw []string
+	for _, m := range markdownLinkRE.FindAllStringSubmatch(content, -1) {
+		raw = append(raw, m[1])
+	}
+	raw = append(raw, bareURLRE.FindAllString(content, -1)...)
+
+	seen := make(map[string]bool)
+	var links []Link
+	for _, r := range raw {
+		r = strings.TrimRight(r, ".,;:!?")
+		if r == "" || seen[r] {
+			continue
+		}
+		seen[r] = true
+
+		if l, ok := classify(r, hosts); ok {
+			links = append(links, l)
+		}
+	}
+	return links
+}
+
+// classify determines how a link is checked. It returns false for links that
+// are not checked.
+func classify(raw string, hosts []string) (Link, bool) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return Link{}, false
+	}
+
+	internal := u.Scheme == "" && u.Host == "" && strings.HasPrefix(u.Path, "/")
+	for _, h := range hosts {
+		if strings.EqualFold(u.Host, h) {
+			internal = true
+		}
+	}
+
+	if internal {
+		parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+		switch {
+		case len(parts) == 2 && parts[0] == "document" && parts[1] != "":
+			return Link{URL: raw, Type: LinkTypeDocument, Target: parts[1]}, true
+		case len(parts) == 3 && parts[0] == "l" && parts[1] != "" && parts[2] != "":
+			return Link{
+				URL:  raw,
+				Type: LinkTypeShortLink,
+				Target: "/" + strings.ToLower(parts[1]) +
+					"/" + strings.ToLower(parts[2]),
+			}, true
+		}
+		return Link{}, false
+	}
+
+	if (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" {
+		return Link{URL: raw, Type: LinkTypeExternal, Target: u.String()}, true
+	}
+	return Link{}, false
+}
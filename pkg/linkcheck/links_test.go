@@ -0,0 +1,81 @@
+package linkcheck
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractLinks(t *testing.T) {
+	cases := map[string]struct {
+		content string
+		want    []Link
+	}{
+		"markdown and bare links": {
+			content: "See [the RFC](https://hermes.example.com/document/abc123) " +
+				"and https://example.com/page. Also [short](/l/rfc/lab-042).",
+			want: []Link{
+				{
+					URL:    "https://hermes.example.com/document/abc123",
+					Type:   LinkTypeDocument,
+					Target: "abc123",
+				},
+				{
+					URL:    "/l/rfc/lab-042",
+					Type:   LinkTypeShortLink,
+					Target: "/rfc/lab-042",
+				},
+				{
+					URL:    "https://example.com/page",
+					Type:   LinkTypeExternal,
+					Target: "https://example.com/page",
+				},
+			},
+		},
+		"short link base URL is lowercased": {
+			content: "https://go.example.com/l/RFC/LAB-001",
+			want: []Link{
+				{
+					URL:    "https://go.example.com/l/RFC/LAB-001",
+					Type:   LinkTypeShortLink,
+					Target: "/rfc/lab-001",
+				},
+			},
+		},
+		"markdown link with title": {
+			content: `[docs](https://example.com/docs "Docs")`,
+			want: []Link{
+				{
+					URL:    "https://example.com/docs",
+					Type:   LinkTypeExternal,
+					Target: "https://example.com/docs",
+				},
+			},
+		},
+		"duplicates are removed": {
+			content: "[a](https://example.com/a) https://example.com/a, " +
+				"https://example.com/a",
+			want: []Link{
+				{
+					URL:    "https://example.com/a",
+					Type:   LinkTypeExternal,
+					Target: "https://example.com/a",
+				},
+			},
+		},
+		"unchecked links are skipped": {
+			content: "[anchor](#intro) [mail](mailto:a@example.com) " +
+				"[dashboard](/dashboard) [relative](other.md) " +
+				"https://hermes.example.com/projects/1",
+			want: nil,
+		},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ExtractLinks(c.content,
+				"https://hermes.example.com", "https://go.example.com")
+			assert.Equal(t, c.want, got)
+		})
+	}
+}
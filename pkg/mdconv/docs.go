@@ -0,0 +1,494 @@
+package mdconv
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"google.golang.org/api/docs/v1"
+)
+
+// codeFont is the font of code in Google Docs.
+const codeFont = "Courier New"
+
+// monospaceFonts are the fonts whose text is exported as code.
+var monospaceFonts = map[string]bool{
+	"Consolas":        true,
+	"Courier":         true,
+	"Courier New":     true,
+	"Roboto Mono":     true,
+	"Source Code Pro": true,
+}
+
+// Bullet presets for unordered and ordered lists.
+const (
+	bulletPreset   = "BULLET_DISC_CIRCLE_SQUARE"
+	numberedPreset = "NUMBERED_DECIMAL_ALPHA_ROMAN"
+)
+
+// Requests returns the Google Docs batchUpdate requests that insert the
+// Markdown document src at index (1 for the start of the document body).
+//
+// Blocks are inserted last to first at the same index, so the indexes of each
+// block's style requests don't depend on the length of the blocks before it.
+// Each block's paragraph and text styles are reset, so inserted text doesn't
+// inherit the styles of the text around it.
+func Requests(src string, index int64) []*docs.Request {
+	blocks := parse(src)
+
+	// Group consecutive list items of the same kind, so they're created as one
+	// list and can be nested.
+	var groups [][]block
+	for _, bl := range blocks {
+		if n := len(groups); n > 0 && bl.kind == blockListItem {
+			prev := groups[n-1][len(groups[n-1])-1]
+			if prev.kind == blockListItem && prev.ordered == bl.ordered {
+				groups[n-1] = append(groups[n-1], bl)
+				continue
+			}
+		}
+		groups = append(groups, []block{bl})
+	}
+
+	var reqs []*docs.Request
+	for i := len(groups) - 1; i >= 0; i-- {
+		group := groups[i]
+		if group[0].kind == blockTable {
+			reqs = append(reqs, tableRequests(group[0].rows, index)...)
+		} else {
+			reqs = append(reqs, paragraphRequests(group, index)...)
+		}
+	}
+	return reqs
+}
+
+// paragraphRequests returns the requests that insert blocks other than tables
+// as paragraphs at index.
+func paragraphRequests(blocks []block, index int64) []*docs.Request {
+	var (
+		text       strings.Builder
+		pos        = index
+		styleReqs  []*docs.Request
+		paraStyles []*docs.Request
+	)
+	// paragraph adds a paragraph with the given spans and style.
+	paragraph := func(prefix string, spans []span, style *docs.ParagraphStyle) {
+		start := pos
+		text.WriteString(prefix)
+		pos += utf16Len(prefix)
+		for _, sp := range spans {
+			t := strings.ReplaceAll(sp.text, "\n", " ")
+			text.WriteString(t)
+			end := pos + utf16Len(t)
+			if ts, fields := textStyle(sp); fields != "" {
+				styleReqs = append(styleReqs, &docs.Request{
+					UpdateTextStyle: &docs.UpdateTextStyleRequest{
+						Range:     &docs.Range{StartIndex: pos, EndIndex: end},
+						TextStyle: ts,
+						Fields:    fields,
+					},
+				})
+			}
+			pos = end
+		}
+		text.WriteString("\n")
+		pos++
+		paraStyles = append(paraStyles, &docs.Request{
+			UpdateParagraphStyle: &docs.UpdateParagraphStyleRequest{
+				Range:          &docs.Range{StartIndex: start, EndIndex: pos},
+				ParagraphStyle: style,
+				Fields:         "namedStyleType,indentStart,indentFirstLine,borderBottom,shading",
+			},
+		})
+	}
+	// codeLines adds code lines as paragraphs in the code font.
+	codeLines := func(lines []string) {
+		start := pos
+		for _, line := range lines {
+			paragraph("", []span{{text: line}}, &docs.ParagraphStyle{
+				NamedStyleType: "NORMAL_TEXT",
+				Shading: &docs.Shading{
+					BackgroundColor: &docs.OptionalColor{
+						Color: &docs.Color{
+							RgbColor: &docs.RgbColor{Blue: 0.96, Green: 0.96, Red: 0.96},
+						},
+					},
+				},
+			})
+		}
+		styleReqs = append(styleReqs, &docs.Request{
+			UpdateTextStyle: &docs.UpdateTextStyleRequest{
+				Range: &docs.Range{StartIndex: start, EndIndex: pos},
+				TextStyle: &docs.TextStyle{
+					WeightedFontFamily: &docs.WeightedFontFamily{FontFamily: codeFont},
+				},
+				Fields: "weightedFontFamily",
+			},
+		})
+	}
+
+	for _, bl := range blocks {
+		switch bl.kind {
+		case blockHeading:
+			paragraph("", bl.spans, &docs.ParagraphStyle{
+				NamedStyleType: fmt.Sprintf("HEADING_%d", bl.level),
+			})
+		case blockListItem:
+			// Leading tabs set the nesting level of bullets, and are removed when
+			// bullets are created.
+			paragraph(strings.Repeat("\t", bl.level), bl.spans,
+				&docs.ParagraphStyle{NamedStyleType: "NORMAL_TEXT"})
+		case blockQuote:
+			paragraph("", bl.spans, &docs.ParagraphStyle{
+				NamedStyleType: "NORMAL_TEXT",
+				IndentStart:    &docs.Dimension{Magnitude: 36, Unit: "PT"},
+			})
+		case blockRule:
+			paragraph("", nil, &docs.ParagraphStyle{
+				NamedStyleType: "NORMAL_TEXT",
+				BorderBottom: &docs.ParagraphBorder{
+					Color: &docs.OptionalColor{
+						Color: &docs.Color{
+							RgbColor: &docs.RgbColor{Blue: 0.8, Green: 0.8, Red: 0.8},
+						},
+					},
+					DashStyle: "SOLID",
+					Padding:   &docs.Dimension{Magnitude: 6, Unit: "PT"},
+					Width:     &docs.Dimension{Magnitude: 1, Unit: "PT"},
+				},
+			})
+		case blockCode:
+			codeLines(bl.lines)
+		case blockFrontmatter:
+			codeLines(append(append([]string{"---"}, bl.lines...), "---"))
+		default:
+			paragraph("", bl.spans,
+				&docs.ParagraphStyle{NamedStyleType: "NORMAL_TEXT"})
+		}
+	}
+
+	rng := func() *docs.Range {
+		return &docs.Range{StartIndex: index, EndIndex: pos}
+	}
+	reqs := []*docs.Request{
+		{
+			InsertText: &docs.InsertTextRequest{
+				Text:     text.String(),
+				Location: &docs.Location{Index: index},
+			},
+		},
+		{
+			UpdateTextStyle: &docs.UpdateTextStyleRequest{
+				Range:     rng(),
+				TextStyle: &docs.TextStyle{},
+				Fields:    "bold,italic,link,weightedFontFamily",
+			},
+		},
+		{
+			DeleteParagraphBullets: &docs.DeleteParagraphBulletsRequest{
+				Range: rng(),
+			},
+		},
+	}
+	reqs = append(reqs, paraStyles...)
+	reqs = append(reqs, styleReqs...)
+
+	// Create bullets last, since removing the leading tabs moves the text.
+	if blocks[0].kind == blockListItem {
+		preset := bulletPreset
+		if blocks[0].ordered {
+			preset = numberedPreset
+		}
+		reqs = append(reqs, &docs.Request{
+			CreateParagraphBullets: &docs.CreateParagraphBulletsRequest{
+				Range:        rng(),
+				BulletPreset: preset,
+			},
+		})
+	}
+
+	return reqs
+}
+
+// tableRequests returns the requests that insert a table at index. The text of
+// the header row is bold.
+func tableRequests(rows [][][]span, index int64) []*docs.Request {
+	cols := 0
+	for _, row := range rows {
+		cols = max(cols, len(row))
+	}
+
+	reqs := []*docs.Request{
+		{
+			InsertTable: &docs.InsertTableRequest{
+				Rows:     int64(len(rows)),
+				Columns:  int64(cols),
+				Location: &docs.Location{Index: index},
+			},
+		},
+	}
+
+	// A newline is inserted before the table, which starts at index+1. Each
+	// row and cell starts with one index, and each empty cell contains a
+	// newline, so the text of the cell in row r and column c starts at
+	// index+4+r*(2*cols+1)+2*c. Cells are filled last to first so the indexes
+	// of the cells before them don't move.
+	for r := len(rows) - 1; r >= 0; r-- {
+		for c := cols - 1; c >= 0; c-- {
+			if c >= len(rows[r]) {
+				continue
+			}
+			start := index + 4 + int64(r)*(2*int64(cols)+1) + 2*int64(c)
+			var (
+				text      strings.Builder
+				pos       = start
+				styleReqs []*docs.Request
+			)
+			for _, sp := range rows[r][c] {
+				// The text of the header row is bold.
+				sp.bold = sp.bold || r == 0
+				t := strings.ReplaceAll(sp.text, "\n", " ")
+				text.WriteString(t)
+				end := pos + utf16Len(t)
+				if ts, fields := textStyle(sp); fields != "" {
+					styleReqs = append(styleReqs, &docs.Request{
+						UpdateTextStyle: &docs.UpdateTextStyleRequest{
+							Range:     &docs.Range{StartIndex: pos, EndIndex: end},
+							TextStyle: ts,
+							Fields:    fields,
+						},
+					})
+				}
+				pos = end
+			}
+			if text.Len() == 0 {
+				continue
+			}
+			reqs = append(reqs, &docs.Request{
+				InsertText: &docs.InsertTextRequest{
+					Text:     text.String(),
+					Location: &docs.Location{Index: start},
+				},
+			})
+			reqs = append(reqs, styleReqs...)
+		}
+	}
+
+	return reqs
+}
+
+// textStyle returns the Google Docs text style of a span and its field mask.
+// The field mask is empty for unstyled spans.
+func textStyle(sp span) (*docs.TextStyle, string) {
+	var (
+		ts     = &docs.TextStyle{}
+		fields []string
+	)
+	if sp.bold {
+		ts.Bold = true
+		fields = append(fields, "bold")
+	}
+	if sp.italic {
+		ts.Italic = true
+		fields = append(fields, "italic")
+	}
+	if sp.code {
+		ts.WeightedFontFamily = &docs.WeightedFontFamily{FontFamily: codeFont}
+		fields = append(fields, "weightedFontFamily")
+	}
+	if sp.link != "" {
+		ts.Link = &docs.Link{Url: sp.link}
+		fields = append(fields, "link")
+	}
+	return ts, strings.Join(fields, ",")
+}
+
+// utf16Len returns the length of s in UTF-16 code units, which Google Docs
+// indexes count.
+func utf16Len(s string) int64 {
+	return int64(len(utf16.Encode([]rune(s))))
+}
+
+// FromDocument exports a Google Doc as normalized Markdown.
+func FromDocument(doc *docs.Document) string {
+	if doc == nil || doc.Body == nil {
+		return ""
+	}
+	return render(documentBlocks(doc))
+}
+
+// documentBlocks returns the blocks of a Google Doc.
+func documentBlocks(doc *docs.Document) []block {
+	var blocks []block
+	for _, el := range doc.Body.Content {
+		switch {
+		case el.Paragraph != nil:
+			blocks = appendParagraph(blocks, doc, el.Paragraph)
+		case el.Table != nil:
+			blocks = append(blocks, tableBlock(el.Table))
+		}
+	}
+
+	// Frontmatter is written as a code block delimited by "---" lines.
+	if len(blocks) > 0 && blocks[0].kind == blockCode {
+		lines := blocks[0].lines
+		if len(lines) >= 2 && lines[0] == "---" && lines[len(lines)-1] == "---" {
+			blocks[0] = block{
+				kind:  blockFrontmatter,
+				lines: lines[1 : len(lines)-1],
+			}
+		}
+	}
+
+	return blocks
+}
+
+// appendParagraph appends the block of a Google Docs paragraph to blocks.
+// Consecutive code paragraphs are appended as lines of one code block.
+func appendParagraph(blocks []block, doc *docs.Document, p *docs.Paragraph) []block {
+	var (
+		spans   []span
+		hasText bool
+		allCode = true
+	)
+	for _, el := range p.Elements {
+		if el.HorizontalRule != nil {
+			return append(blocks, block{kind: blockRule})
+		}
+		if el.TextRun == nil {
+			continue
+		}
+		sp := runSpan(el.TextRun)
+		if strings.TrimSpace(sp.text) != "" {
+			hasText = true
+			allCode = allCode && sp.code
+		}
+		spans = append(spans, sp)
+	}
+
+	style := p.ParagraphStyle
+	if style == nil {
+		style = &docs.ParagraphStyle{}
+	}
+
+	// Code blocks are shaded paragraphs in a monospace font.
+	if p.Bullet == nil && allCode && style.Shading != nil &&
+		style.Shading.BackgroundColor != nil &&
+		style.Shading.BackgroundColor.Color != nil {
+		var line strings.Builder
+		for _, sp := range spans {
+			line.WriteString(sp.text)
+		}
+		if n := len(blocks); n > 0 && blocks[n-1].kind == blockCode {
+			blocks[n-1].lines = append(blocks[n-1].lines, line.String())
+			return blocks
+		}
+		return append(blocks, block{kind: blockCode, lines: []string{line.String()}})
+	}
+
+	if !hasText {
+		if style.BorderBottom != nil && style.BorderBottom.Width != nil &&
+			style.BorderBottom.Width.Magnitude > 0 {
+			return append(blocks, block{kind: blockRule})
+		}
+		return blocks
+	}
+
+	spans = mergeSpans(spans)
+	switch {
+	case p.Bullet != nil:
+		return append(blocks, block{
+			kind:    blockListItem,
+			level:   int(p.Bullet.NestingLevel),
+			ordered: orderedList(doc, p.Bullet),
+			spans:   spans,
+		})
+	case style.NamedStyleType == "TITLE":
+		return append(blocks, block{kind: blockHeading, level: 1, spans: spans})
+	case style.NamedStyleType == "SUBTITLE":
+		return append(blocks, block{kind: blockHeading, level: 2, spans: spans})
+	case strings.HasPrefix(style.NamedStyleType, "HEADING_"):
+		level, err := strconv.Atoi(strings.TrimPrefix(style.NamedStyleType, "HEADING_"))
+		if err != nil {
+			level = 1
+		}
+		return append(blocks, block{
+			kind:  blockHeading,
+			level: min(max(level, 1), 6),
+			spans: spans,
+		})
+	case style.IndentStart != nil && style.IndentStart.Magnitude > 0:
+		return append(blocks, block{kind: blockQuote, spans: spans})
+	}
+	return append(blocks, block{kind: blockParagraph, spans: spans})
+}
+
+// runSpan returns the span of a Google Docs text run without its paragraph's
+// trailing newline.
+func runSpan(run *docs.TextRun) span {
+	text := strings.TrimSuffix(run.Content, "\n")
+	// Vertical tabs are line breaks within a paragraph.
+	text = strings.ReplaceAll(text, "\v", " ")
+
+	sp := span{text: text}
+	if ts := run.TextStyle; ts != nil {
+		sp.bold = ts.Bold
+		sp.italic = ts.Italic
+		if ts.Link != nil {
+			sp.link = ts.Link.Url
+		}
+		if ts.WeightedFontFamily != nil {
+			sp.code = monospaceFonts[ts.WeightedFontFamily.FontFamily]
+		}
+	}
+	return sp
+}
+
+// orderedList reports whether a bulleted paragraph is in a numbered list.
+func orderedList(doc *docs.Document, bullet *docs.Bullet) bool {
+	list, ok := doc.Lists[bullet.ListId]
+	if !ok || list.ListProperties == nil {
+		return false
+	}
+	levels := list.ListProperties.NestingLevels
+	if int(bullet.NestingLevel) >= len(levels) {
+		return false
+	}
+	level := levels[bullet.NestingLevel]
+	return level.GlyphSymbol == "" && level.GlyphType != "" &&
+		level.GlyphType != "GLYPH_TYPE_UNSPECIFIED" && level.GlyphType != "NONE"
+}
+
+// tableBlock returns the block of a Google Docs table. The text of cells with
+// several paragraphs is joined with spaces.
+func tableBlock(t *docs.Table) block {
+	bl := block{kind: blockTable}
+	for r, row := range t.TableRows {
+		var cells [][]span
+		for _, cell := range row.TableCells {
+			var spans []span
+			for _, el := range cell.Content {
+				if el.Paragraph == nil {
+					continue
+				}
+				if len(spans) > 0 {
+					spans = append(spans, span{text: " "})
+				}
+				for _, pe := range el.Paragraph.Elements {
+					if pe.TextRun != nil {
+						sp := runSpan(pe.TextRun)
+						// The header row is bold when written by Requests.
+						if r == 0 {
+							sp.bold = false
+						}
+						spans = append(spans, sp)
+					}
+				}
+			}
+			cells = append(cells, mergeSpans(spans))
+		}
+		bl.rows = append(bl.rows, cells)
+	}
+	return bl
+}
@@ -0,0 +1,529 @@
+// Package mdconv converts document content between Hermes Markdown and Google
+// Docs, so documents can move between the local and Google Workspace providers
+// and be compared regardless of the provider that stores them.
+//
+// Markdown is parsed into a small block model (frontmatter, headings,
+// paragraphs, list items, code blocks, quotes, rules, and tables) with inline
+// bold, italic, code, and link spans. The same model is rendered into Google
+// Docs batchUpdate requests by Requests, read back from Google Docs by
+// FromDocument, and rendered into normalized Markdown by Normalize, so a
+// document written to Google Docs and exported again normalizes to the same
+// Markdown it was written from.
+//
+// Normalization is lossy: code block languages, list numbering, soft line
+// breaks, and Markdown escapes outside table cells aren't preserved.
+//
+// Example usage:
+//
+//	reqs := mdconv.Requests(body, 1)
+//	if _, err := svc.UpdateDoc(fileID, reqs); err != nil {
+//	    return err
+//	}
+//	md := mdconv.FromDocument(doc)
+package mdconv
+
+import (
+	"regexp"
+	"strings"
+)
+
+// blockKind is the kind of a content block.
+type blockKind int
+
+const (
+	blockParagraph blockKind = iota
+	blockHeading
+	blockListItem
+	blockCode
+	blockQuote
+	blockRule
+	blockTable
+	blockFrontmatter
+)
+
+// block is a block-level element of a document.
+type block struct {
+	kind blockKind
+
+	// level is the heading level (1-6) for headings, and the nesting level
+	// (starting at 0) for list items.
+	level int
+
+	// ordered is true for numbered list items.
+	ordered bool
+
+	// spans is the inline text of paragraphs, headings, list items, and quotes.
+	spans []span
+
+	// lines are the lines of code blocks and frontmatter.
+	lines []string
+
+	// rows are the cells of tables. The first row is the header row.
+	rows [][][]span
+}
+
+// span is a run of inline text with the same style.
+type span struct {
+	text   string
+	bold   bool
+	italic bool
+	code   bool
+	link   string
+}
+
+// sameStyle reports whether two spans have the same style.
+func (s span) sameStyle(o span) bool {
+	return s.bold == o.bold && s.italic == o.italic && s.code == o.code &&
+		s.link == o.link
+}
+
+var (
+	headingRe   = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	listItemRe  = regexp.MustCompile(`^(\s*)([-*+]|\d+[.)])\s+(.*)$`)
+	quoteRe     = regexp.MustCompile(`^\s*>\s?(.*)$`)
+	ruleRe      = regexp.MustCompile(`^\s*([-*_])(\s*([-*_])){2,}\s*$`)
+	fenceRe     = regexp.MustCompile("^\\s*(```|~~~)")
+	tableSepRe  = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+	linkRe      = regexp.MustCompile(`^\[([^\]]*)\]\(([^)\s]+)\)`)
+	punctuation = "\\`*_{}[]()#+-.!|>~"
+)
+
+// Normalize returns src as normalized Markdown: the Markdown that FromDocument
+// exports for a Google Doc written from src with Requests.
+func Normalize(src string) string {
+	return render(parse(src))
+}
+
+// Equal reports whether two Markdown documents are the same after
+// normalization.
+func Equal(a, b string) bool {
+	return Normalize(a) == Normalize(b)
+}
+
+// parse parses Markdown into blocks.
+func parse(src string) []block {
+	src = strings.ReplaceAll(src, "\r\n", "\n")
+	lines := strings.Split(src, "\n")
+
+	var (
+		blocks []block
+		para   []string
+		quote  []string
+
+		// indents are the indentations of the list items containing the
+		// current list item.
+		indents []int
+	)
+	flush := func() {
+		if len(para) > 0 {
+			blocks = append(blocks, block{
+				kind:  blockParagraph,
+				spans: parseInline(strings.Join(para, " ")),
+			})
+			para = nil
+		}
+		if len(quote) > 0 {
+			blocks = append(blocks, block{
+				kind:  blockQuote,
+				spans: parseInline(strings.Join(quote, " ")),
+			})
+			quote = nil
+		}
+	}
+
+	i := 0
+
+	// Frontmatter must start on the first line.
+	if len(lines) > 1 && lines[0] == "---" {
+		for j := 1; j < len(lines); j++ {
+			if lines[j] == "---" {
+				blocks = append(blocks, block{
+					kind:  blockFrontmatter,
+					lines: append([]string{}, lines[1:j]...),
+				})
+				i = j + 1
+				break
+			}
+		}
+	}
+
+	for ; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], " \t")
+
+		if m := fenceRe.FindStringSubmatch(line); m != nil {
+			flush()
+			var code []string
+			for i++; i < len(lines); i++ {
+				if strings.HasPrefix(strings.TrimSpace(lines[i]), m[1]) {
+					break
+				}
+				code = append(code, strings.TrimRight(lines[i], " \t"))
+			}
+			blocks = append(blocks, block{kind: blockCode, lines: code})
+			continue
+		}
+
+		if strings.TrimSpace(line) == "" {
+			flush()
+			continue
+		}
+
+		if strings.HasPrefix(strings.TrimSpace(line), "|") &&
+			i+1 < len(lines) && tableSepRe.MatchString(lines[i+1]) {
+			flush()
+			rows := [][][]span{parseTableRow(line)}
+			for i += 2; i < len(lines); i++ {
+				if !strings.HasPrefix(strings.TrimSpace(lines[i]), "|") {
+					break
+				}
+				rows = append(rows, parseTableRow(lines[i]))
+			}
+			i--
+			blocks = append(blocks, block{kind: blockTable, rows: rows})
+			continue
+		}
+
+		if m := quoteRe.FindStringSubmatch(line); m != nil {
+			if len(para) > 0 {
+				flush()
+			}
+			if strings.TrimSpace(m[1]) == "" {
+				flush()
+			} else {
+				quote = append(quote, strings.TrimSpace(m[1]))
+			}
+			continue
+		}
+		if len(quote) > 0 {
+			flush()
+		}
+
+		switch {
+		case ruleRe.MatchString(line):
+			flush()
+			blocks = append(blocks, block{kind: blockRule})
+
+		case headingRe.MatchString(line):
+			flush()
+			m := headingRe.FindStringSubmatch(line)
+			blocks = append(blocks, block{
+				kind:  blockHeading,
+				level: len(m[1]),
+				spans: parseInline(m[2]),
+			})
+
+		case listItemRe.MatchString(line):
+			flush()
+			m := listItemRe.FindStringSubmatch(line)
+			indent := len(strings.ReplaceAll(m[1], "\t", "    "))
+			for len(indents) > 0 && indents[len(indents)-1] >= indent {
+				indents = indents[:len(indents)-1]
+			}
+			level := len(indents)
+			indents = append(indents, indent)
+			blocks = append(blocks, block{
+				kind:    blockListItem,
+				level:   level,
+				ordered: m[2] != "-" && m[2] != "*" && m[2] != "+",
+				spans:   parseInline(m[3]),
+			})
+
+		default:
+			para = append(para, strings.TrimSpace(line))
+		}
+		if !listItemRe.MatchString(line) {
+			indents = nil
+		}
+	}
+	flush()
+
+	return blocks
+}
+
+// parseTableRow parses the cells of a Markdown table row.
+func parseTableRow(line string) [][]span {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = line[:len(line)-1]
+	}
+
+	var (
+		cells [][]span
+		cell  strings.Builder
+	)
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteString(`\|`)
+			i++
+		case line[i] == '|':
+			cells = append(cells, parseInline(strings.TrimSpace(cell.String())))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+	return append(cells, parseInline(strings.TrimSpace(cell.String())))
+}
+
+// parseInline parses inline Markdown into spans.
+func parseInline(s string) []span {
+	return mergeSpans(parseSpans(s, span{}))
+}
+
+// parseSpans parses inline Markdown into spans with the style of parent.
+func parseSpans(s string, parent span) []span {
+	var (
+		spans []span
+		buf   strings.Builder
+	)
+	flush := func() {
+		if buf.Len() > 0 {
+			sp := parent
+			sp.text = buf.String()
+			spans = append(spans, sp)
+			buf.Reset()
+		}
+	}
+	// styled parses the text between a delimiter at i and its closing
+	// delimiter, if any.
+	styled := func(i int, delim string, style func(*span)) (int, bool) {
+		if !strings.HasPrefix(s[i:], delim) {
+			return 0, false
+		}
+		start := i + len(delim)
+		end := strings.Index(s[start:], delim)
+		if end <= 0 || strings.TrimSpace(s[start:start+end]) == "" {
+			return 0, false
+		}
+		// "_" only delimits emphasis at word boundaries.
+		if delim[0] == '_' && i > 0 && isWordByte(s[i-1]) {
+			return 0, false
+		}
+		flush()
+		sp := parent
+		style(&sp)
+		spans = append(spans, parseSpans(s[start:start+end], sp)...)
+		return start + end + len(delim), true
+	}
+
+	for i := 0; i < len(s); {
+		c := s[i]
+
+		if c == '\\' && i+1 < len(s) && strings.IndexByte(punctuation, s[i+1]) >= 0 {
+			buf.WriteByte(s[i+1])
+			i += 2
+			continue
+		}
+
+		if c == '`' {
+			if end := strings.IndexByte(s[i+1:], '`'); end > 0 {
+				flush()
+				sp := parent
+				sp.code = true
+				sp.text = s[i+1 : i+1+end]
+				spans = append(spans, sp)
+				i += end + 2
+				continue
+			}
+		}
+
+		if c == '[' {
+			if m := linkRe.FindStringSubmatchIndex(s[i:]); m != nil {
+				flush()
+				sp := parent
+				sp.link = s[i+m[4] : i+m[5]]
+				spans = append(spans, parseSpans(s[i+m[2]:i+m[3]], sp)...)
+				i += m[1]
+				continue
+			}
+		}
+
+		if c == '*' || c == '_' {
+			d := string(c)
+			if next, ok := styled(i, d+d+d, func(sp *span) {
+				sp.bold, sp.italic = true, true
+			}); ok {
+				i = next
+				continue
+			}
+			if next, ok := styled(i, d+d, func(sp *span) { sp.bold = true }); ok {
+				i = next
+				continue
+			}
+			if !strings.HasPrefix(s[i:], d+d) {
+				if next, ok := styled(i, d, func(sp *span) { sp.italic = true }); ok {
+					i = next
+					continue
+				}
+			}
+		}
+
+		buf.WriteByte(c)
+		i++
+	}
+	flush()
+
+	return spans
+}
+
+// isWordByte reports whether c is an ASCII letter or digit.
+func isWordByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// mergeSpans merges adjacent spans with the same style and drops empty spans.
+func mergeSpans(spans []span) []span {
+	var out []span
+	for _, sp := range spans {
+		if sp.text == "" {
+			continue
+		}
+		if n := len(out); n > 0 && out[n-1].sameStyle(sp) {
+			out[n-1].text += sp.text
+			continue
+		}
+		out = append(out, sp)
+	}
+	return out
+}
+
+// render renders blocks into normalized Markdown.
+func render(blocks []block) string {
+	var b strings.Builder
+	for i, bl := range blocks {
+		if i > 0 {
+			if bl.kind == blockListItem && blocks[i-1].kind == blockListItem {
+				b.WriteString("\n")
+			} else {
+				b.WriteString("\n\n")
+			}
+		}
+
+		switch bl.kind {
+		case blockHeading:
+			b.WriteString(strings.Repeat("#", bl.level) + " " + renderInline(bl.spans))
+		case blockListItem:
+			marker := "- "
+			if bl.ordered {
+				marker = "1. "
+			}
+			b.WriteString(strings.Repeat("  ", bl.level) + marker + renderInline(bl.spans))
+		case blockCode:
+			b.WriteString("```\n")
+			for _, line := range bl.lines {
+				b.WriteString(line + "\n")
+			}
+			b.WriteString("```")
+		case blockFrontmatter:
+			b.WriteString("---\n")
+			for _, line := range bl.lines {
+				b.WriteString(line + "\n")
+			}
+			b.WriteString("---")
+		case blockQuote:
+			b.WriteString("> " + renderInline(bl.spans))
+		case blockRule:
+			b.WriteString("---")
+		case blockTable:
+			b.WriteString(renderTable(bl.rows))
+		default:
+			b.WriteString(renderInline(bl.spans))
+		}
+	}
+	if b.Len() == 0 {
+		return ""
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// renderTable renders table rows into a Markdown table.
+func renderTable(rows [][][]span) string {
+	cols := 0
+	for _, row := range rows {
+		cols = max(cols, len(row))
+	}
+
+	var b strings.Builder
+	renderRow := func(row [][]span) {
+		b.WriteString("|")
+		for c := range cols {
+			var text string
+			if c < len(row) {
+				text = strings.ReplaceAll(renderInline(row[c]), "|", `\|`)
+			}
+			b.WriteString(" " + text + " |")
+		}
+	}
+	for r, row := range rows {
+		if r > 0 {
+			b.WriteString("\n")
+		}
+		renderRow(row)
+		if r == 0 {
+			b.WriteString("\n|" + strings.Repeat(" --- |", cols))
+		}
+	}
+	return b.String()
+}
+
+// renderInline renders spans into inline Markdown.
+func renderInline(spans []span) string {
+	return strings.TrimSpace(renderSpans(mergeSpans(spans)))
+}
+
+// renderSpans renders spans into inline Markdown. Consecutive spans with the
+// same link are rendered as one link.
+func renderSpans(spans []span) string {
+	var b strings.Builder
+	for i := 0; i < len(spans); {
+		link := spans[i].link
+		if link == "" {
+			b.WriteString(renderSpan(spans[i]))
+			i++
+			continue
+		}
+
+		var inner []span
+		for ; i < len(spans) && spans[i].link == link; i++ {
+			sp := spans[i]
+			sp.link = ""
+			inner = append(inner, sp)
+		}
+		text := renderSpans(inner)
+		trimmed := strings.TrimSpace(text)
+		if trimmed == "" {
+			b.WriteString(text)
+			continue
+		}
+		lead := text[:strings.Index(text, trimmed)]
+		b.WriteString(lead + "[" + trimmed + "](" + link + ")" +
+			text[len(lead)+len(trimmed):])
+	}
+	return b.String()
+}
+
+// renderSpan renders an unlinked span into inline Markdown. Whitespace is kept
+// outside of delimiters, which don't parse otherwise.
+func renderSpan(sp span) string {
+	text := strings.TrimSpace(sp.text)
+	if text == "" {
+		return sp.text
+	}
+	lead := sp.text[:strings.Index(sp.text, text)]
+	trail := sp.text[len(lead)+len(text):]
+
+	switch {
+	case sp.code:
+		text = "`" + text + "`"
+	case sp.bold && sp.italic:
+		text = "***" + text + "***"
+	case sp.bold:
+		text = "**" + text + "**"
+	case sp.italic:
+		text = "*" + text + "*"
+	}
+	return lead + text + trail
+}
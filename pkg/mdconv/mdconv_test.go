@@ -0,0 +1,263 @@
+package mdconv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/docs/v1"
+)
+
+func TestNormalize(t *testing.T) {
+	cases := map[string]struct {
+		src  string
+		want string
+	}{
+		"empty": {
+			src:  "\n\n",
+			want: "",
+		},
+		"headings and paragraphs": {
+			src:  "# Title #\n\nFirst line\nsecond line.\n\n\n## Background\nText.",
+			want: "# Title\n\nFirst line second line.\n\n## Background\n\nText.\n",
+		},
+		"emphasis": {
+			src:  "Some __bold__, _italic_, ***both***, `code`, and [a **link**](https://example.com).",
+			want: "Some **bold**, *italic*, ***both***, `code`, and [a **link**](https://example.com).\n",
+		},
+		"unmatched delimiters": {
+			src:  "2 * 3 = 6 and snake_case_name",
+			want: "2 * 3 = 6 and snake_case_name\n",
+		},
+		"lists": {
+			src:  "* One\n    * Nested\n+ Two\n\n3) First\n10. Second",
+			want: "- One\n  - Nested\n- Two\n1. First\n1. Second\n",
+		},
+		"code blocks": {
+			src:  "```go\nfunc main() {\n\n}\n```\n~~~\n# not a heading\n~~~",
+			want: "```\nfunc main() {\n\n}\n```\n\n```\n# not a heading\n```\n",
+		},
+		"quotes and rules": {
+			src:  "> Quoted\n> text.\n>\n> Again.\n\n***\nAfter.",
+			want: "> Quoted text.\n\n> Again.\n\n---\n\nAfter.\n",
+		},
+		"tables": {
+			src:  "|Name|Value|\n|:--|--:|\n| a \\| b | **1** |\n| c |",
+			want: "| Name | Value |\n| --- | --- |\n| a \\| b | **1** |\n| c |  |\n",
+		},
+		"frontmatter": {
+			src:  "---\ntitle: Doc\n---\n# Doc",
+			want: "---\ntitle: Doc\n---\n\n# Doc\n",
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := Normalize(c.src)
+			assert.Equal(t, c.want, got)
+			assert.Equal(t, got, Normalize(got), "normalization is idempotent")
+		})
+	}
+
+	assert.True(t, Equal("# A\nText", "# A\n\nText\n"))
+	assert.False(t, Equal("# A", "## A"))
+}
+
+func TestRequests(t *testing.T) {
+	reqs := Requests("# Title\n\nSome **bold**.\n\n- One\n  - Two", 1)
+
+	// Blocks are inserted last to first.
+	var inserted []string
+	for _, r := range reqs {
+		if r.InsertText != nil {
+			assert.EqualValues(t, 1, r.InsertText.Location.Index)
+			inserted = append(inserted, r.InsertText.Text)
+		}
+	}
+	assert.Equal(t, []string{"One\n\tTwo\n", "Some bold.\n", "Title\n"}, inserted)
+
+	find := func(match func(*docs.Request) bool) []*docs.Request {
+		var found []*docs.Request
+		for _, r := range reqs {
+			if match(r) {
+				found = append(found, r)
+			}
+		}
+		return found
+	}
+
+	bullets := find(func(r *docs.Request) bool { return r.CreateParagraphBullets != nil })
+	require.Len(t, bullets, 1)
+	assert.Equal(t, bulletPreset, bullets[0].CreateParagraphBullets.BulletPreset)
+	assert.EqualValues(t, 1, bullets[0].CreateParagraphBullets.Range.StartIndex)
+	assert.EqualValues(t, 10, bullets[0].CreateParagraphBullets.Range.EndIndex)
+
+	bold := find(func(r *docs.Request) bool {
+		return r.UpdateTextStyle != nil && r.UpdateTextStyle.TextStyle.Bold
+	})
+	require.Len(t, bold, 1)
+	assert.EqualValues(t, 6, bold[0].UpdateTextStyle.Range.StartIndex)
+	assert.EqualValues(t, 10, bold[0].UpdateTextStyle.Range.EndIndex)
+	assert.Equal(t, "bold", bold[0].UpdateTextStyle.Fields)
+
+	headings := find(func(r *docs.Request) bool {
+		return r.UpdateParagraphStyle != nil &&
+			r.UpdateParagraphStyle.ParagraphStyle.NamedStyleType == "HEADING_1"
+	})
+	require.Len(t, headings, 1)
+	assert.EqualValues(t, 7, headings[0].UpdateParagraphStyle.Range.EndIndex)
+
+	t.Run("indexes count UTF-16 code units", func(t *testing.T) {
+		reqs := Requests("😀 **b**", 1)
+		for _, r := range reqs {
+			if r.UpdateTextStyle != nil && r.UpdateTextStyle.TextStyle.Bold {
+				assert.EqualValues(t, 4, r.UpdateTextStyle.Range.StartIndex)
+				assert.EqualValues(t, 5, r.UpdateTextStyle.Range.EndIndex)
+			}
+		}
+	})
+
+	t.Run("tables", func(t *testing.T) {
+		reqs := Requests("| A | B |\n| --- | --- |\n| c | |", 1)
+		require.NotNil(t, reqs[0].InsertTable)
+		assert.EqualValues(t, 2, reqs[0].InsertTable.Rows)
+		assert.EqualValues(t, 2, reqs[0].InsertTable.Columns)
+
+		// Cells are filled last to first; empty cells are skipped.
+		var cells []string
+		var indexes []int64
+		for _, r := range reqs {
+			if r.InsertText != nil {
+				cells = append(cells, r.InsertText.Text)
+				indexes = append(indexes, r.InsertText.Location.Index)
+			}
+		}
+		assert.Equal(t, []string{"c", "B", "A"}, cells)
+		assert.Equal(t, []int64{10, 7, 5}, indexes)
+	})
+
+	assert.Empty(t, Requests("", 1))
+}
+
+func TestFromDocument(t *testing.T) {
+	run := func(text string, style *docs.TextStyle) *docs.ParagraphElement {
+		return &docs.ParagraphElement{
+			TextRun: &docs.TextRun{Content: text, TextStyle: style},
+		}
+	}
+	para := func(style *docs.ParagraphStyle, runs ...*docs.ParagraphElement) *docs.StructuralElement {
+		return &docs.StructuralElement{
+			Paragraph: &docs.Paragraph{Elements: runs, ParagraphStyle: style},
+		}
+	}
+	normal := &docs.ParagraphStyle{NamedStyleType: "NORMAL_TEXT"}
+	mono := &docs.TextStyle{
+		WeightedFontFamily: &docs.WeightedFontFamily{FontFamily: "Courier New"},
+	}
+	shaded := &docs.ParagraphStyle{
+		NamedStyleType: "NORMAL_TEXT",
+		Shading: &docs.Shading{
+			BackgroundColor: &docs.OptionalColor{Color: &docs.Color{}},
+		},
+	}
+	bullet := func(list string, level int64, text string) *docs.StructuralElement {
+		el := para(normal, run(text+"\n", nil))
+		el.Paragraph.Bullet = &docs.Bullet{ListId: list, NestingLevel: level}
+		return el
+	}
+	cell := func(text string, style *docs.TextStyle) *docs.TableCell {
+		return &docs.TableCell{
+			Content: []*docs.StructuralElement{para(normal, run(text+"\n", style))},
+		}
+	}
+
+	doc := &docs.Document{
+		Body: &docs.Body{Content: []*docs.StructuralElement{
+			{SectionBreak: &docs.SectionBreak{}},
+			para(shaded, run("---\n", mono)),
+			para(shaded, run("title: Doc\n", mono)),
+			para(shaded, run("---\n", mono)),
+			para(&docs.ParagraphStyle{NamedStyleType: "TITLE"}, run("Doc\n", nil)),
+			para(&docs.ParagraphStyle{NamedStyleType: "HEADING_2"}, run("Background\n", nil)),
+			para(normal,
+				run("Some ", nil),
+				run("bold", &docs.TextStyle{Bold: true}),
+				run(" and ", nil),
+				run("linked", &docs.TextStyle{Link: &docs.Link{Url: "https://example.com"}}),
+				run(" ", nil),
+				run("code", mono),
+				run(".\n", nil),
+			),
+			para(normal, run("\n", nil)),
+			bullet("bullets", 0, "One"),
+			bullet("bullets", 1, "Nested"),
+			bullet("numbers", 0, "First"),
+			para(&docs.ParagraphStyle{
+				NamedStyleType: "NORMAL_TEXT",
+				IndentStart:    &docs.Dimension{Magnitude: 36, Unit: "PT"},
+			}, run("Quoted\n", nil)),
+			para(&docs.ParagraphStyle{
+				BorderBottom: &docs.ParagraphBorder{
+					Width: &docs.Dimension{Magnitude: 1, Unit: "PT"},
+				},
+			}, run("\n", nil)),
+			para(shaded, run("x := 1\n", mono)),
+			para(shaded, run("\n", mono)),
+			para(shaded, run("y := x | 2\n", mono)),
+			{Table: &docs.Table{TableRows: []*docs.TableRow{
+				{TableCells: []*docs.TableCell{
+					cell("Name", &docs.TextStyle{Bold: true}),
+					cell("Value", &docs.TextStyle{Bold: true}),
+				}},
+				{TableCells: []*docs.TableCell{
+					cell("a | b", nil),
+					cell("1", &docs.TextStyle{Italic: true}),
+				}},
+			}}},
+			para(normal, run("Line\vbreak\n", nil)),
+		}},
+		Lists: map[string]docs.List{
+			"bullets": {ListProperties: &docs.ListProperties{
+				NestingLevels: []*docs.NestingLevel{{GlyphSymbol: "●"}, {GlyphSymbol: "○"}},
+			}},
+			"numbers": {ListProperties: &docs.ListProperties{
+				NestingLevels: []*docs.NestingLevel{{GlyphType: "DECIMAL"}},
+			}},
+		},
+	}
+
+	want := `---
+title: Doc
+---
+
+# Doc
+
+## Background
+
+Some **bold** and [linked](https://example.com) ` + "`code`" + `.
+
+- One
+  - Nested
+1. First
+
+> Quoted
+
+---
+
+` + "```" + `
+x := 1
+
+y := x | 2
+` + "```" + `
+
+| Name | Value |
+| --- | --- |
+| a \| b | *1* |
+
+Line break
+`
+	got := FromDocument(doc)
+	assert.Equal(t, want, got)
+	assert.Equal(t, got, Normalize(got), "exports are normalized")
+
+	assert.Empty(t, FromDocument(&docs.Document{}))
+}
@@ -0,0 +1,224 @@
+// Package merge merges concurrent changes to text documents, such as Markdown
+// documents edited both on an edge instance and on central (RFC-085).
+//
+// ThreeWay merges two versions of a document that were changed independently
+// from a common base version, line by line like diff3: changes to different
+// parts of the document are combined, and overlapping changes are conflicts.
+package merge
+
+import "strings"
+
+// Labels are the names of the versions shown in conflict markers.
+type Labels struct {
+	Ours   string
+	Base   string
+	Theirs string
+}
+
+// Result is the result of a three-way merge.
+type Result struct {
+	// Content is the merged content. Conflicts are marked with diff3-style
+	// conflict markers ("<<<<<<<", "|||||||", "=======", and ">>>>>>>").
+	Content string
+
+	// Conflicts is the number of conflicting changes.
+	Conflicts int
+}
+
+// Clean returns true if the merge had no conflicts.
+func (r *Result) Clean() bool {
+	return r.Conflicts == 0
+}
+
+// ThreeWay merges ours and theirs, which were both changed from base.
+func ThreeWay(base, ours, theirs string, labels Labels) *Result {
+	// Short-circuit the common cases of only one side changing.
+	switch {
+	case ours == theirs, theirs == base:
+		return &Result{Content: ours}
+	case ours == base:
+		return &Result{Content: theirs}
+	}
+
+	o, a, b := splitLines(base), splitLines(ours), splitLines(theirs)
+	ma, mb := lcsMatches(o, a), lcsMatches(o, b)
+
+	res := &Result{}
+	var out []string
+	i, ja, jb := 0, 0, 0
+	for i < len(o) || ja < len(a) || jb < len(b) {
+		// Copy lines unchanged in both versions.
+		if i < len(o) && ma[i] == ja && mb[i] == jb {
+			out = append(out, o[i])
+			i, ja, jb = i+1, ja+1, jb+1
+			continue
+		}
+
+		// Find the end of the changed chunk: the next base line that is in
+		// both versions, or the end of the document.
+		ni, na, nb := len(o), len(a), len(b)
+		for k := i; k < len(o); k++ {
+			if ma[k] != -1 && mb[k] != -1 {
+				ni, na, nb = k, ma[k], mb[k]
+				break
+			}
+		}
+
+		co, ca, cb := o[i:ni], a[ja:na], b[jb:nb]
+		switch {
+		case equal(ca, co):
+			out = append(out, cb...)
+		case equal(cb, co), equal(ca, cb):
+			out = append(out, ca...)
+		default:
+			res.Conflicts++
+			out = append(out, marker("<<<<<<<", labels.Ours))
+			out = append(out, ca...)
+			out = append(out, marker("|||||||", labels.Base))
+			out = append(out, co...)
+			out = append(out, "=======")
+			out = append(out, cb...)
+			out = append(out, marker(">>>>>>>", labels.Theirs))
+		}
+		i, ja, jb = ni, na, nb
+	}
+
+	res.Content = strings.Join(out, "\n")
+	return res
+}
+
+// HasConflictMarkers returns true if s contains the conflict markers of a
+// merge with conflicts.
+func HasConflictMarkers(s string) bool {
+	var start, sep, end bool
+	for _, line := range splitLines(s) {
+		switch {
+		case strings.HasPrefix(line, "<<<<<<<"):
+			start = true
+		case line == "=======" && start:
+			sep = true
+		case strings.HasPrefix(line, ">>>>>>>") && sep:
+			end = true
+		}
+	}
+	return end
+}
+
+// splitLines splits s into lines. A trailing newline results in a final empty
+// line, so joining the lines with newlines restores s.
+func splitLines(s string) []string {
+	return strings.Split(s, "\n")
+}
+
+// marker returns a conflict marker line with an optional label.
+func marker(m, label string) string {
+	if label == "" {
+		return m
+	}
+	return m + " " + label
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// lcsMatches returns, for each line of a, the index of the line of b it is
+// matched with in a longest common subsequence of a and b, or -1.
+func lcsMatches(a, b []string) []int {
+	m := make([]int, len(a))
+	for i := range m {
+		m[i] = -1
+	}
+
+	// Match the common prefix and suffix directly, which usually leaves a
+	// small middle section to diff.
+	pre := 0
+	for pre < len(a) && pre < len(b) && a[pre] == b[pre] {
+		m[pre] = pre
+		pre++
+	}
+	suf := 0
+	for suf < len(a)-pre && suf < len(b)-pre &&
+		a[len(a)-1-suf] == b[len(b)-1-suf] {
+		m[len(a)-1-suf] = len(b) - 1 - suf
+		suf++
+	}
+
+	myers(a[pre:len(a)-suf], b[pre:len(b)-suf], func(i, j int) {
+		m[pre+i] = pre + j
+	})
+	return m
+}
+
+// myers calls match for each pair of matched lines of a shortest edit script
+// of a into b, found with Myers' O(ND) difference algorithm.
+func myers(a, b []string, match func(i, j int)) {
+	n, m := len(a), len(b)
+	max := n + m
+	if n == 0 || m == 0 {
+		return
+	}
+
+	// v[off+k] is the furthest x reached on diagonal k = x - y. trace holds
+	// v as of the start of each round d, for backtracking.
+	off := max + 1
+	v := make([]int, 2*max+3)
+	var trace [][]int
+	for d := 0; d <= max; d++ {
+		trace = append(trace, append([]int(nil), v...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[off+k-1] < v[off+k+1]) {
+				x = v[off+k+1]
+			} else {
+				x = v[off+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x, y = x+1, y+1
+			}
+			v[off+k] = x
+			if x >= n && y >= m {
+				backtrack(trace, off, d, k, x, y, match)
+				return
+			}
+		}
+	}
+}
+
+// backtrack walks the edit path ending at (x, y) on diagonal k in round d back
+// to the origin, calling match for the diagonal moves.
+func backtrack(trace [][]int, off, d, k, x, y int, match func(i, j int)) {
+	for ; d > 0; d-- {
+		v := trace[d]
+		prevK := k - 1
+		if k == -d || (k != d && v[off+k-1] < v[off+k+1]) {
+			prevK = k + 1
+		}
+		prevX := v[off+prevK]
+		prevY := prevX - prevK
+
+		// The snake after the edit starts one step past the previous point.
+		startX, startY := prevX+1, prevY
+		if prevK == k+1 {
+			startX, startY = prevX, prevY+1
+		}
+		for x > startX && y > startY {
+			x, y = x-1, y-1
+			match(x, y)
+		}
+		x, y, k = prevX, prevY, prevK
+	}
+	for x > 0 && y > 0 {
+		x, y = x-1, y-1
+		match(x, y)
+	}
+}
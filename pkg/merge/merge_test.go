@@ -0,0 +1,125 @@
+package merge
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThreeWay(t *testing.T) {
+	base := "# Locking\n\nIntro.\n\n## Design\n\nUse leases.\n\n## Risks\n\nNone.\n"
+	labels := Labels{Ours: "edge", Base: "base", Theirs: "central"}
+
+	cases := map[string]struct {
+		ours, theirs string
+		want         string
+		conflicts    int
+	}{
+		"only ours changed": {
+			ours:   strings.Replace(base, "Intro.", "Intro, revised.", 1),
+			theirs: base,
+			want:   strings.Replace(base, "Intro.", "Intro, revised.", 1),
+		},
+		"only theirs changed": {
+			ours:   base,
+			theirs: strings.Replace(base, "None.", "Split brain.", 1),
+			want:   strings.Replace(base, "None.", "Split brain.", 1),
+		},
+		"non-overlapping changes": {
+			ours:   strings.Replace(base, "Intro.", "Intro, revised.", 1),
+			theirs: strings.Replace(base, "None.", "Split brain.", 1),
+			want: "# Locking\n\nIntro, revised.\n\n## Design\n\nUse leases.\n\n" +
+				"## Risks\n\nSplit brain.\n",
+		},
+		"insertions in different sections": {
+			ours: strings.Replace(base, "Use leases.\n",
+				"Use leases.\nRenew every 10s.\n", 1),
+			theirs: strings.Replace(base, "## Risks\n",
+				"## Alternatives\n\nConsensus.\n\n## Risks\n", 1),
+			want: "# Locking\n\nIntro.\n\n## Design\n\nUse leases.\n" +
+				"Renew every 10s.\n\n## Alternatives\n\nConsensus.\n\n" +
+				"## Risks\n\nNone.\n",
+		},
+		"same change on both sides": {
+			ours:   strings.Replace(base, "None.", "Split brain.", 1),
+			theirs: strings.Replace(base, "None.", "Split brain.", 1),
+			want:   strings.Replace(base, "None.", "Split brain.", 1),
+		},
+		"deletion and unrelated change": {
+			ours:   strings.Replace(base, "## Risks\n\nNone.\n", "", 1),
+			theirs: strings.Replace(base, "Intro.", "Intro, revised.", 1),
+			want:   "# Locking\n\nIntro, revised.\n\n## Design\n\nUse leases.\n\n",
+		},
+		"overlapping changes": {
+			ours:   strings.Replace(base, "Use leases.", "Use fencing tokens.", 1),
+			theirs: strings.Replace(base, "Use leases.", "Use a lock service.", 1),
+			want: "# Locking\n\nIntro.\n\n## Design\n\n" +
+				"<<<<<<< edge\nUse fencing tokens.\n" +
+				"||||||| base\nUse leases.\n" +
+				"=======\nUse a lock service.\n" +
+				">>>>>>> central\n\n## Risks\n\nNone.\n",
+			conflicts: 1,
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			res := ThreeWay(base, c.ours, c.theirs, labels)
+			assert.Equal(t, c.want, res.Content)
+			assert.Equal(t, c.conflicts, res.Conflicts)
+			assert.Equal(t, c.conflicts == 0, res.Clean())
+			assert.Equal(t, c.conflicts > 0, HasConflictMarkers(res.Content))
+		})
+	}
+}
+
+func TestLCSMatches(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	randomLines := func() []string {
+		lines := make([]string, rnd.Intn(30))
+		for i := range lines {
+			lines[i] = string(rune('a' + rnd.Intn(4)))
+		}
+		return lines
+	}
+
+	for i := 0; i < 500; i++ {
+		a, b := randomLines(), randomLines()
+		m := lcsMatches(a, b)
+		require.Len(t, m, len(a))
+
+		// The matches must be increasing, match equal lines, and be as long
+		// as a longest common subsequence.
+		n, last := 0, -1
+		for i, j := range m {
+			if j == -1 {
+				continue
+			}
+			require.Greater(t, j, last)
+			require.Equal(t, a[i], b[j])
+			last = j
+			n++
+		}
+		require.Equal(t, lcsLength(a, b), n, "a=%q b=%q", a, b)
+	}
+}
+
+// lcsLength returns the length of a longest common subsequence of a and b.
+func lcsLength(a, b []string) int {
+	dp := make([][]int, len(a)+1)
+	for i := range dp {
+		dp[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				dp[i][j] = dp[i+1][j+1] + 1
+			} else {
+				dp[i][j] = max(dp[i+1][j], dp[i][j+1])
+			}
+		}
+	}
+	return dp[0][0]
+}
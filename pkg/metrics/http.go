@@ -0,0 +1,63 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	httpRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "Number of HTTP requests by route, method, and status code.",
+		},
+		[]string{"route", "method", "code"},
+	)
+
+	httpRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "Duration of HTTP requests by route, method, and status code.",
+			Buckets:   durationBuckets,
+		},
+		[]string{"route", "method", "code"},
+	)
+
+	httpRequestsInFlight = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_in_flight",
+			Help:      "Number of HTTP requests being served by route.",
+		},
+		[]string{"route"},
+	)
+)
+
+func init() {
+	Registry.MustRegister(httpRequests, httpRequestDuration, httpRequestsInFlight)
+}
+
+// InstrumentHandler returns h instrumented with the rate, latency, and status
+// codes of its requests. route labels the metrics and should be the pattern h
+// is registered with (e.g., "/api/v2/documents/"), not the request path, to
+// bound the number of time series.
+func InstrumentHandler(route string, h http.Handler) http.Handler {
+	labels := prometheus.Labels{"route": route}
+	return promhttp.InstrumentHandlerInFlight(
+		httpRequestsInFlight.With(labels),
+		promhttp.InstrumentHandlerDuration(
+			httpRequestDuration.MustCurryWith(labels),
+			promhttp.InstrumentHandlerCounter(
+				httpRequests.MustCurryWith(labels),
+				h,
+			),
+		),
+	)
+}
@@ -0,0 +1,76 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	kafkaConsumerLag = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "kafka",
+			Name:      "consumer_lag",
+			Help:      "Number of records not yet consumed by consumer group, topic, and partition.",
+		},
+		[]string{"group", "topic", "partition"},
+	)
+
+	kafkaProducedRecords = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "kafka",
+			Name:      "produced_records_total",
+			Help:      "Number of records produced by producer, topic, and result.",
+		},
+		[]string{"producer", "topic", "result"},
+	)
+
+	kafkaProducedBytes = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "kafka",
+			Name:      "produced_bytes_total",
+			Help:      "Size of the values of the records produced by producer and topic.",
+		},
+		[]string{"producer", "topic"},
+	)
+
+	kafkaProduceDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "kafka",
+			Name:      "produce_duration_seconds",
+			Help:      "Duration of producing records, until they're acknowledged, by producer and topic.",
+			Buckets:   durationBuckets,
+		},
+		[]string{"producer", "topic"},
+	)
+)
+
+func init() {
+	Registry.MustRegister(kafkaConsumerLag, kafkaProducedRecords,
+		kafkaProducedBytes, kafkaProduceDuration)
+}
+
+// SetKafkaConsumerLag records the lag of a consumer group on a topic
+// partition: the number of records after the last record it fetched. Lag is
+// computed from a fetch as the partition's high watermark minus the offset of
+// the last fetched record plus one.
+func SetKafkaConsumerLag(group, topic string, partition int32, lag int64) {
+	kafkaConsumerLag.WithLabelValues(
+		group, topic, strconv.Itoa(int(partition)),
+	).Set(float64(max(lag, 0)))
+}
+
+// ObserveKafkaProduce records that producer produced a record of size bytes to
+// topic, which took d to be acknowledged or fail with err.
+func ObserveKafkaProduce(producer, topic string, size int, d time.Duration, err error) {
+	kafkaProducedRecords.WithLabelValues(producer, topic, result(err)).Inc()
+	kafkaProduceDuration.WithLabelValues(producer, topic).Observe(d.Seconds())
+	if err == nil {
+		kafkaProducedBytes.WithLabelValues(producer, topic).Add(float64(size))
+	}
+}
@@ -0,0 +1,44 @@
+// Package metrics records Prometheus metrics shared by the Hermes server,
+// indexer, and notifier, and serves them for scraping on /metrics.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "hermes"
+
+// Registry is the registry of all Hermes metrics, including Go runtime and
+// process metrics.
+var Registry = prometheus.NewRegistry()
+
+// durationBuckets are the histogram buckets, in seconds, of request and call
+// durations. Workspace provider calls can take several seconds.
+var durationBuckets = []float64{
+	.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30,
+}
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// Handler returns a handler that serves the metrics in Registry in the
+// Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
+
+// result returns the "result" label value of an operation that returned err.
+func result(err error) string {
+	if err != nil {
+		return "error"
+	}
+	return "success"
+}
@@ -0,0 +1,126 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/search"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeIndex is a DocumentIndex that fails GetObject for unknown documents.
+type fakeIndex struct {
+	search.DocumentIndex
+}
+
+func (f *fakeIndex) GetObject(ctx context.Context, docID string) (*search.Document, error) {
+	if docID != "doc1" {
+		return nil, search.ErrNotFound
+	}
+	return &search.Document{ObjectID: docID}, nil
+}
+
+// fakeUpdaterIndex is a fakeIndex that implements search.DocumentUpdater.
+type fakeUpdaterIndex struct {
+	fakeIndex
+}
+
+func (f *fakeUpdaterIndex) UpdateFields(ctx context.Context, docID string, fields map[string]any) error {
+	return nil
+}
+
+type fakeProvider struct {
+	search.Provider
+}
+
+func (p *fakeProvider) Name() string                        { return "fake" }
+func (p *fakeProvider) DocumentIndex() search.DocumentIndex { return &fakeIndex{} }
+func (p *fakeProvider) DraftIndex() search.DraftIndex       { return &fakeUpdaterIndex{} }
+
+func TestInstrumentHandler(t *testing.T) {
+	h := InstrumentHandler("/test/", http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/test/missing" {
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+
+	for _, path := range []string{"/test/a", "/test/b", "/test/missing"} {
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", path, nil))
+	}
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(
+		httpRequests.WithLabelValues("/test/", "get", "200")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(
+		httpRequests.WithLabelValues("/test/", "get", "404")))
+	assert.Equal(t, 0.0, testutil.ToFloat64(
+		httpRequestsInFlight.WithLabelValues("/test/")))
+}
+
+func TestInstrumentSearchProvider(t *testing.T) {
+	p := InstrumentSearchProvider(&fakeProvider{})
+	ctx := context.Background()
+
+	_, err := p.DocumentIndex().GetObject(ctx, "doc1")
+	require.NoError(t, err)
+	_, err = p.DocumentIndex().GetObject(ctx, "doc2")
+	require.Error(t, err)
+	assert.Equal(t, 1.0, testutil.ToFloat64(searchRequests.WithLabelValues(
+		"fake", "documents", "get_object", "success")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(searchRequests.WithLabelValues(
+		"fake", "documents", "get_object", "error")))
+
+	_, ok := p.DocumentIndex().(search.DocumentUpdater)
+	assert.False(t, ok, "document index doesn't implement DocumentUpdater")
+	u, ok := p.DraftIndex().(search.DocumentUpdater)
+	require.True(t, ok, "draft index implements DocumentUpdater")
+	require.NoError(t, u.UpdateFields(ctx, "doc1", nil))
+	assert.Equal(t, 1.0, testutil.ToFloat64(searchRequests.WithLabelValues(
+		"fake", "drafts", "update_fields", "success")))
+}
+
+func TestObserveNotificationDelivery(t *testing.T) {
+	ObserveNotificationDelivery("mail", nil)
+	ObserveNotificationDelivery("mail", errors.New("unavailable"))
+	ObserveNotificationDelivery("mail", nil)
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(
+		notificationsDelivered.WithLabelValues("mail", "success")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(
+		notificationsDelivered.WithLabelValues("mail", "error")))
+}
+
+func TestObserveNotificationDuplicates(t *testing.T) {
+	before := testutil.ToFloat64(notificationsDeduplicated)
+	ObserveNotificationDuplicates(2)
+	assert.Equal(t, before+2, testutil.ToFloat64(notificationsDeduplicated))
+}
+
+func TestObserveKafkaProduce(t *testing.T) {
+	ObserveKafkaProduce("relay", "revisions", 100, time.Millisecond, nil)
+	ObserveKafkaProduce("relay", "revisions", 50, time.Second, errors.New("timeout"))
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(
+		kafkaProducedRecords.WithLabelValues("relay", "revisions", "success")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(
+		kafkaProducedRecords.WithLabelValues("relay", "revisions", "error")))
+	assert.Equal(t, 100.0, testutil.ToFloat64(
+		kafkaProducedBytes.WithLabelValues("relay", "revisions")))
+}
+
+func TestHandler(t *testing.T) {
+	SetKafkaConsumerLag("group1", "topic1", 0, 42)
+
+	w := httptest.NewRecorder()
+	Handler().ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(),
+		`hermes_kafka_consumer_lag{group="group1",partition="0",topic="topic1"} 42`)
+	assert.Contains(t, w.Body.String(), "go_goroutines")
+}
@@ -0,0 +1,40 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var notificationsDelivered = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "notifications",
+		Name:      "delivered_total",
+		Help:      "Number of notification deliveries by backend and result.",
+	},
+	[]string{"backend", "result"},
+)
+
+var notificationsDeduplicated = prometheus.NewCounter(
+	prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "notifications",
+		Name:      "deduplicated_total",
+		Help:      "Number of notification recipients skipped as duplicates.",
+	},
+)
+
+func init() {
+	Registry.MustRegister(notificationsDelivered, notificationsDeduplicated)
+}
+
+// ObserveNotificationDelivery records the delivery of a notification by
+// backend, which returned err.
+func ObserveNotificationDelivery(backend string, err error) {
+	notificationsDelivered.WithLabelValues(backend, result(err)).Inc()
+}
+
+// ObserveNotificationDuplicates records that n recipients of a notification
+// were skipped because they were sent an identical one recently.
+func ObserveNotificationDuplicates(n int) {
+	notificationsDeduplicated.Add(float64(n))
+}
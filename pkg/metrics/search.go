@@ -0,0 +1,157 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/search"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	searchRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "search",
+			Name:      "requests_total",
+			Help:      "Number of search provider calls by provider, index, operation, and result.",
+		},
+		[]string{"provider", "index", "operation", "result"},
+	)
+
+	searchRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "search",
+			Name:      "request_duration_seconds",
+			Help:      "Duration of search provider calls by provider, index, and operation.",
+			Buckets:   durationBuckets,
+		},
+		[]string{"provider", "index", "operation"},
+	)
+)
+
+func init() {
+	Registry.MustRegister(searchRequests, searchRequestDuration)
+}
+
+// InstrumentSearchProvider returns p with the rate, latency, and errors of the
+// calls to its document and draft indexes recorded. The returned indexes
+// implement search.DocumentUpdater if the indexes of p do.
+func InstrumentSearchProvider(p search.Provider) search.Provider {
+	return &searchProvider{Provider: p}
+}
+
+type searchProvider struct {
+	search.Provider
+}
+
+func (p *searchProvider) DocumentIndex() search.DocumentIndex {
+	return newSearchIndex(p.Name(), "documents", p.Provider.DocumentIndex())
+}
+
+func (p *searchProvider) DraftIndex() search.DraftIndex {
+	return newSearchIndex(p.Name(), "drafts", p.Provider.DraftIndex())
+}
+
+// newSearchIndex returns idx instrumented with the provider and index labels.
+// The returned index implements search.DocumentUpdater if idx does.
+func newSearchIndex(
+	provider, index string,
+	idx search.DocumentIndex,
+) search.DocumentIndex {
+	si := &searchIndex{
+		DocumentIndex: idx,
+		labels:        prometheus.Labels{"provider": provider, "index": index},
+	}
+	if u, ok := idx.(search.DocumentUpdater); ok {
+		return &searchUpdaterIndex{searchIndex: si, updater: u}
+	}
+	return si
+}
+
+// searchIndex is an instrumented document or draft index. Document and draft
+// indexes have the same methods.
+type searchIndex struct {
+	search.DocumentIndex
+	labels prometheus.Labels
+}
+
+// observe records a call of operation op that started at start and returned
+// err.
+func (i *searchIndex) observe(op string, start time.Time, err error) {
+	searchRequestDuration.MustCurryWith(i.labels).
+		WithLabelValues(op).Observe(time.Since(start).Seconds())
+	searchRequests.MustCurryWith(i.labels).
+		WithLabelValues(op, result(err)).Inc()
+}
+
+func (i *searchIndex) Index(ctx context.Context, doc *search.Document) error {
+	start := time.Now()
+	err := i.DocumentIndex.Index(ctx, doc)
+	i.observe("index", start, err)
+	return err
+}
+
+func (i *searchIndex) IndexBatch(ctx context.Context, docs []*search.Document) error {
+	start := time.Now()
+	err := i.DocumentIndex.IndexBatch(ctx, docs)
+	i.observe("index_batch", start, err)
+	return err
+}
+
+func (i *searchIndex) Delete(ctx context.Context, docID string) error {
+	start := time.Now()
+	err := i.DocumentIndex.Delete(ctx, docID)
+	i.observe("delete", start, err)
+	return err
+}
+
+func (i *searchIndex) DeleteBatch(ctx context.Context, docIDs []string) error {
+	start := time.Now()
+	err := i.DocumentIndex.DeleteBatch(ctx, docIDs)
+	i.observe("delete_batch", start, err)
+	return err
+}
+
+func (i *searchIndex) Search(ctx context.Context, query *search.SearchQuery) (*search.SearchResult, error) {
+	start := time.Now()
+	res, err := i.DocumentIndex.Search(ctx, query)
+	i.observe("search", start, err)
+	return res, err
+}
+
+func (i *searchIndex) GetObject(ctx context.Context, docID string) (*search.Document, error) {
+	start := time.Now()
+	doc, err := i.DocumentIndex.GetObject(ctx, docID)
+	i.observe("get_object", start, err)
+	return doc, err
+}
+
+func (i *searchIndex) GetFacets(ctx context.Context, facetNames []string) (*search.Facets, error) {
+	start := time.Now()
+	facets, err := i.DocumentIndex.GetFacets(ctx, facetNames)
+	i.observe("get_facets", start, err)
+	return facets, err
+}
+
+func (i *searchIndex) Clear(ctx context.Context) error {
+	start := time.Now()
+	err := i.DocumentIndex.Clear(ctx)
+	i.observe("clear", start, err)
+	return err
+}
+
+// searchUpdaterIndex is a searchIndex of an index that implements
+// search.DocumentUpdater.
+type searchUpdaterIndex struct {
+	*searchIndex
+	updater search.DocumentUpdater
+}
+
+func (i *searchUpdaterIndex) UpdateFields(ctx context.Context, docID string, fields map[string]any) error {
+	start := time.Now()
+	err := i.updater.UpdateFields(ctx, docID, fields)
+	i.observe("update_fields", start, err)
+	return err
+}
@@ -0,0 +1,177 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/docid"
+	"github.com/hashicorp-forge/hermes/pkg/workspace"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	workspaceRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "workspace",
+			Name:      "requests_total",
+			Help:      "Number of workspace provider calls by provider, operation, and result.",
+		},
+		[]string{"provider", "operation", "result"},
+	)
+
+	workspaceRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "workspace",
+			Name:      "request_duration_seconds",
+			Help:      "Duration of workspace provider calls by provider and operation.",
+			Buckets:   durationBuckets,
+		},
+		[]string{"provider", "operation"},
+	)
+)
+
+func init() {
+	Registry.MustRegister(workspaceRequests, workspaceRequestDuration)
+}
+
+// InstrumentWorkspaceProvider returns p with the rate, latency, and errors of
+// its document, content, permission, people, and email calls recorded under
+// the provider label name. Other calls are passed through unrecorded.
+//
+// The returned provider doesn't implement the optional interfaces of p; use
+// workspace.Unwrap to assert them.
+func InstrumentWorkspaceProvider(
+	name string,
+	p workspace.WorkspaceProvider,
+) workspace.WorkspaceProvider {
+	return &workspaceProvider{WorkspaceProvider: p, name: name}
+}
+
+type workspaceProvider struct {
+	workspace.WorkspaceProvider
+	name string
+}
+
+// Unwrap returns the instrumented provider.
+func (p *workspaceProvider) Unwrap() workspace.WorkspaceProvider {
+	return p.WorkspaceProvider
+}
+
+// observe records a call of operation op that started at start and returned
+// err.
+func (p *workspaceProvider) observe(op string, start time.Time, err error) {
+	workspaceRequestDuration.WithLabelValues(p.name, op).
+		Observe(time.Since(start).Seconds())
+	workspaceRequests.WithLabelValues(p.name, op, result(err)).Inc()
+}
+
+// observeCall calls f and records it as a call of operation op of p.
+func observeCall[T any](p *workspaceProvider, op string, f func() (T, error)) (T, error) {
+	start := time.Now()
+	v, err := f()
+	p.observe(op, start, err)
+	return v, err
+}
+
+func (p *workspaceProvider) GetDocument(ctx context.Context, providerID string) (*workspace.DocumentMetadata, error) {
+	return observeCall(p, "get_document", func() (*workspace.DocumentMetadata, error) {
+		return p.WorkspaceProvider.GetDocument(ctx, providerID)
+	})
+}
+
+func (p *workspaceProvider) GetDocumentByUUID(ctx context.Context, uuid docid.UUID) (*workspace.DocumentMetadata, error) {
+	return observeCall(p, "get_document_by_uuid", func() (*workspace.DocumentMetadata, error) {
+		return p.WorkspaceProvider.GetDocumentByUUID(ctx, uuid)
+	})
+}
+
+func (p *workspaceProvider) CreateDocument(ctx context.Context, templateID, destFolderID, name string) (*workspace.DocumentMetadata, error) {
+	return observeCall(p, "create_document", func() (*workspace.DocumentMetadata, error) {
+		return p.WorkspaceProvider.CreateDocument(ctx, templateID, destFolderID, name)
+	})
+}
+
+func (p *workspaceProvider) CreateDocumentWithUUID(ctx context.Context, uuid docid.UUID, templateID, destFolderID, name string) (*workspace.DocumentMetadata, error) {
+	return observeCall(p, "create_document", func() (*workspace.DocumentMetadata, error) {
+		return p.WorkspaceProvider.CreateDocumentWithUUID(ctx, uuid, templateID, destFolderID, name)
+	})
+}
+
+func (p *workspaceProvider) CopyDocument(ctx context.Context, srcProviderID, destFolderID, name string) (*workspace.DocumentMetadata, error) {
+	return observeCall(p, "copy_document", func() (*workspace.DocumentMetadata, error) {
+		return p.WorkspaceProvider.CopyDocument(ctx, srcProviderID, destFolderID, name)
+	})
+}
+
+func (p *workspaceProvider) MoveDocument(ctx context.Context, providerID, destFolderID string) (*workspace.DocumentMetadata, error) {
+	return observeCall(p, "move_document", func() (*workspace.DocumentMetadata, error) {
+		return p.WorkspaceProvider.MoveDocument(ctx, providerID, destFolderID)
+	})
+}
+
+func (p *workspaceProvider) DeleteDocument(ctx context.Context, providerID string) error {
+	start := time.Now()
+	err := p.WorkspaceProvider.DeleteDocument(ctx, providerID)
+	p.observe("delete_document", start, err)
+	return err
+}
+
+func (p *workspaceProvider) RenameDocument(ctx context.Context, providerID, newName string) error {
+	start := time.Now()
+	err := p.WorkspaceProvider.RenameDocument(ctx, providerID, newName)
+	p.observe("rename_document", start, err)
+	return err
+}
+
+func (p *workspaceProvider) GetContent(ctx context.Context, providerID string) (*workspace.DocumentContent, error) {
+	return observeCall(p, "get_content", func() (*workspace.DocumentContent, error) {
+		return p.WorkspaceProvider.GetContent(ctx, providerID)
+	})
+}
+
+func (p *workspaceProvider) UpdateContent(ctx context.Context, providerID string, content string) (*workspace.DocumentContent, error) {
+	return observeCall(p, "update_content", func() (*workspace.DocumentContent, error) {
+		return p.WorkspaceProvider.UpdateContent(ctx, providerID, content)
+	})
+}
+
+func (p *workspaceProvider) ShareDocument(ctx context.Context, providerID, email, role string) error {
+	start := time.Now()
+	err := p.WorkspaceProvider.ShareDocument(ctx, providerID, email, role)
+	p.observe("share_document", start, err)
+	return err
+}
+
+func (p *workspaceProvider) ListPermissions(ctx context.Context, providerID string) ([]*workspace.FilePermission, error) {
+	return observeCall(p, "list_permissions", func() ([]*workspace.FilePermission, error) {
+		return p.WorkspaceProvider.ListPermissions(ctx, providerID)
+	})
+}
+
+func (p *workspaceProvider) RemovePermission(ctx context.Context, providerID, permissionID string) error {
+	start := time.Now()
+	err := p.WorkspaceProvider.RemovePermission(ctx, providerID, permissionID)
+	p.observe("remove_permission", start, err)
+	return err
+}
+
+func (p *workspaceProvider) SearchPeople(ctx context.Context, query string) ([]*workspace.UserIdentity, error) {
+	return observeCall(p, "search_people", func() ([]*workspace.UserIdentity, error) {
+		return p.WorkspaceProvider.SearchPeople(ctx, query)
+	})
+}
+
+func (p *workspaceProvider) GetPerson(ctx context.Context, email string) (*workspace.UserIdentity, error) {
+	return observeCall(p, "get_person", func() (*workspace.UserIdentity, error) {
+		return p.WorkspaceProvider.GetPerson(ctx, email)
+	})
+}
+
+func (p *workspaceProvider) SendEmail(ctx context.Context, to []string, from, subject, body string) error {
+	start := time.Now()
+	err := p.WorkspaceProvider.SendEmail(ctx, to, from, subject, body)
+	p.observe("send_email", start, err)
+	return err
+}
@@ -0,0 +1,136 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// EventType identifies a migration lifecycle event. Values match the
+// notification types used to render and route them.
+type EventType string
+
+const (
+	EventJobStarted   EventType = "migration_job_started"
+	EventJobHalfway   EventType = "migration_job_halfway"
+	EventItemFailed   EventType = "migration_item_failed"
+	EventJobCompleted EventType = "migration_job_completed"
+)
+
+// Event describes a migration lifecycle change that operators may want to
+// follow without polling the database.
+type Event struct {
+	Type           EventType
+	JobID          int64
+	JobUUID        string
+	JobName        string
+	Strategy       Strategy
+	SourceProvider string
+	DestProvider   string
+
+	// Job counters at the time of the event.
+	Total    int
+	Migrated int
+	Failed   int
+	Skipped  int
+
+	// Item fields are only set for EventItemFailed.
+	ItemID       int64
+	DocumentUUID string
+	Error        string
+
+	Timestamp time.Time
+}
+
+// Completed returns the number of items that have reached a terminal state.
+func (e *Event) Completed() int {
+	return e.Migrated + e.Failed + e.Skipped
+}
+
+// Percent returns the job's completion percentage, rounded down.
+func (e *Event) Percent() int {
+	if e.Total <= 0 {
+		return 0
+	}
+	return e.Completed() * 100 / e.Total
+}
+
+// Notifier publishes migration lifecycle events.
+type Notifier interface {
+	NotifyMigrationEvent(ctx context.Context, ev *Event) error
+}
+
+// SetNotifier configures the notifier used to publish lifecycle events. A nil
+// notifier disables publishing.
+func (m *Manager) SetNotifier(n Notifier) {
+	m.notifier = n
+}
+
+// notify publishes a lifecycle event for a job. Failures are logged and never
+// fail the migration.
+func (m *Manager) notify(ctx context.Context, typ EventType, jobID int64, item func(*Event)) {
+	if m.notifier == nil {
+		return
+	}
+
+	ev, err := m.jobEvent(ctx, typ, jobID)
+	if err != nil {
+		m.logger.Error("failed to build migration event",
+			"error", err, "event", typ, "job_id", jobID)
+		return
+	}
+	if item != nil {
+		item(ev)
+	}
+
+	if err := m.notifier.NotifyMigrationEvent(ctx, ev); err != nil {
+		m.logger.Error("failed to publish migration event",
+			"error", err, "event", typ, "job_id", jobID)
+	}
+}
+
+// jobEvent builds an event populated with the job's current state.
+func (m *Manager) jobEvent(ctx context.Context, typ EventType, jobID int64) (*Event, error) {
+	ev := &Event{
+		Type:      typ,
+		JobID:     jobID,
+		Timestamp: time.Now(),
+	}
+
+	err := m.db.QueryRowContext(ctx, `
+		SELECT
+			j.job_uuid, j.job_name, j.strategy,
+			s.provider_name, d.provider_name,
+			j.total_documents, j.migrated_documents,
+			j.failed_documents, j.skipped_documents
+		FROM migration_jobs j
+		JOIN provider_storage s ON s.id = j.source_provider_id
+		JOIN provider_storage d ON d.id = j.dest_provider_id
+		WHERE j.id = $1
+	`, jobID).Scan(
+		&ev.JobUUID, &ev.JobName, &ev.Strategy,
+		&ev.SourceProvider, &ev.DestProvider,
+		&ev.Total, &ev.Migrated, &ev.Failed, &ev.Skipped,
+	)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("migration job %d not found", jobID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get migration job: %w", err)
+	}
+
+	return ev, nil
+}
+
+// progressEvents reports whether finishing one more item, moving the job from
+// doneBefore to doneAfter terminal items, crosses the halfway mark or
+// completes the job. A job that completes is not also reported as halfway.
+func progressEvents(total, doneBefore, doneAfter int) (halfway, complete bool) {
+	if total <= 0 {
+		return false, false
+	}
+	complete = doneBefore < total && doneAfter >= total
+	halfway = !complete && doneBefore*2 < total && doneAfter*2 >= total
+	return halfway, complete
+}
@@ -0,0 +1,39 @@
+package migration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgressEvents(t *testing.T) {
+	cases := []struct {
+		name                 string
+		total, before, after int
+		halfway, complete    bool
+	}{
+		{"first of four", 4, 0, 1, false, false},
+		{"reaches half of four", 4, 1, 2, true, false},
+		{"past half of four", 4, 2, 3, false, false},
+		{"completes four", 4, 3, 4, false, true},
+		{"crosses half of five", 5, 2, 3, true, false},
+		{"single item completes only", 1, 0, 1, false, true},
+		{"already complete", 4, 4, 5, false, false},
+		{"empty job", 0, 0, 1, false, false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			halfway, complete := progressEvents(c.total, c.before, c.after)
+			assert.Equal(t, c.halfway, halfway, "halfway")
+			assert.Equal(t, c.complete, complete, "complete")
+		})
+	}
+}
+
+func TestEventPercent(t *testing.T) {
+	ev := &Event{Total: 3, Migrated: 1, Failed: 1}
+	assert.Equal(t, 2, ev.Completed())
+	assert.Equal(t, 66, ev.Percent())
+
+	assert.Equal(t, 0, (&Event{}).Percent())
+}
@@ -0,0 +1,156 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/docid"
+)
+
+// DocumentMigration is a single entry in a document's migration audit trail.
+// It joins a migration item with its job and provider names so support can
+// see where a document came from and where it was migrated to.
+type DocumentMigration struct {
+	ItemID            int64      `json:"itemId"`
+	JobID             int64      `json:"jobId"`
+	JobUUID           string     `json:"jobUuid"`
+	JobName           string     `json:"jobName"`
+	Strategy          Strategy   `json:"strategy"`
+	DryRun            bool       `json:"dryRun"`
+	SourceProvider    string     `json:"sourceProvider"`
+	DestProvider      string     `json:"destProvider"`
+	SourceProviderID  string     `json:"sourceProviderId"`
+	DestProviderID    *string    `json:"destProviderId,omitempty"`
+	Status            ItemStatus `json:"status"`
+	AttemptCount      int        `json:"attemptCount"`
+	SourceContentHash *string    `json:"sourceContentHash,omitempty"`
+	DestContentHash   *string    `json:"destContentHash,omitempty"`
+	ContentMatch      *bool      `json:"contentMatch,omitempty"`
+	ErrorMessage      *string    `json:"errorMessage,omitempty"`
+	DurationMS        *int       `json:"durationMs,omitempty"`
+	CreatedAt         time.Time  `json:"createdAt"`
+	StartedAt         *time.Time `json:"startedAt,omitempty"`
+	CompletedAt       *time.Time `json:"completedAt,omitempty"`
+}
+
+// GetDocumentHistory returns the migration audit trail for a document, oldest
+// first.
+func (m *Manager) GetDocumentHistory(
+	ctx context.Context, documentUUID docid.UUID) ([]*DocumentMigration, error) {
+	rows, err := m.db.QueryContext(ctx, `
+		SELECT
+			mi.id, mj.id, mj.job_uuid, mj.job_name, mj.strategy, mj.dry_run,
+			sp.provider_name, dp.provider_name,
+			mi.source_provider_id, mi.dest_provider_id, mi.status, mi.attempt_count,
+			mi.source_content_hash, mi.dest_content_hash, mi.content_match,
+			mi.error_message, mi.duration_ms,
+			mi.created_at, mi.started_at, mi.completed_at
+		FROM migration_items mi
+		JOIN migration_jobs mj ON mi.migration_job_id = mj.id
+		JOIN provider_storage sp ON mj.source_provider_id = sp.id
+		JOIN provider_storage dp ON mj.dest_provider_id = dp.id
+		WHERE mi.document_uuid = $1
+		ORDER BY mi.created_at ASC, mi.id ASC
+	`, documentUUID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query document migration history: %w", err)
+	}
+	defer rows.Close()
+
+	var history []*DocumentMigration
+	for rows.Next() {
+		var h DocumentMigration
+		if err := rows.Scan(
+			&h.ItemID, &h.JobID, &h.JobUUID, &h.JobName, &h.Strategy, &h.DryRun,
+			&h.SourceProvider, &h.DestProvider,
+			&h.SourceProviderID, &h.DestProviderID, &h.Status, &h.AttemptCount,
+			&h.SourceContentHash, &h.DestContentHash, &h.ContentMatch,
+			&h.ErrorMessage, &h.DurationMS,
+			&h.CreatedAt, &h.StartedAt, &h.CompletedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan document migration history: %w", err)
+		}
+		history = append(history, &h)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read document migration history: %w", err)
+	}
+
+	return history, nil
+}
+
+// CurrentLocation returns the most recent completed, non-dry-run migration in
+// a document's history, which identifies the provider the document is stored
+// in now. It returns nil if the document has never been migrated.
+func CurrentLocation(history []*DocumentMigration) *DocumentMigration {
+	var latest *DocumentMigration
+	for _, h := range history {
+		if h.Status != ItemStatusCompleted || h.DryRun || h.DestProviderID == nil {
+			continue
+		}
+		if latest == nil || completedAt(h).After(completedAt(latest)) {
+			latest = h
+		}
+	}
+	return latest
+}
+
+// Location identifies where a document was stored.
+type Location struct {
+	Provider   string
+	ProviderID string
+}
+
+// LocationAt returns where a document was stored at time t according to its
+// migration history: the destination of the latest migration completed by t,
+// or, if the document was only migrated after t, the source of the earliest
+// migration. Dry runs, mirrors, and unfinished migrations never moved the
+// canonical copy and are ignored. It returns nil if no migration changed the
+// document's location, in which case it has always been where it is now.
+func LocationAt(history []*DocumentMigration, t time.Time) *Location {
+	var before, after *DocumentMigration
+	for _, h := range history {
+		if h.Status != ItemStatusCompleted || h.DryRun ||
+			h.Strategy == StrategyMirror || h.DestProviderID == nil {
+			continue
+		}
+		if !completedAt(h).After(t) {
+			if before == nil || completedAt(h).After(completedAt(before)) {
+				before = h
+			}
+		} else if after == nil || completedAt(h).Before(completedAt(after)) {
+			after = h
+		}
+	}
+
+	switch {
+	case before != nil:
+		return &Location{
+			Provider:   before.DestProvider,
+			ProviderID: *before.DestProviderID,
+		}
+	case after != nil:
+		return &Location{
+			Provider:   after.SourceProvider,
+			ProviderID: after.SourceProviderID,
+		}
+	}
+	return nil
+}
+
+// completedAt returns when a migration completed, falling back to when it was
+// created.
+func completedAt(h *DocumentMigration) time.Time {
+	if h.CompletedAt != nil {
+		return *h.CompletedAt
+	}
+	return h.CreatedAt
+}
+
+// normalizeContentHash strips the "sha256:" prefix some providers add to
+// content hashes.
+func normalizeContentHash(hash string) string {
+	return strings.TrimPrefix(hash, "sha256:")
+}
@@ -0,0 +1,118 @@
+package migration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCurrentLocation(t *testing.T) {
+	strPtr := func(s string) *string { return &s }
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Hour)
+	t2 := t0.Add(2 * time.Hour)
+
+	t.Run("never migrated", func(t *testing.T) {
+		assert.Nil(t, CurrentLocation(nil))
+	})
+
+	t.Run("latest completed migration wins", func(t *testing.T) {
+		history := []*DocumentMigration{
+			{
+				ItemID:         1,
+				DestProvider:   "s3-archive",
+				DestProviderID: strPtr("s3:a"),
+				Status:         ItemStatusCompleted,
+				CompletedAt:    &t0,
+			},
+			{
+				ItemID:         2,
+				DestProvider:   "local-edge",
+				DestProviderID: strPtr("local:b"),
+				Status:         ItemStatusCompleted,
+				CompletedAt:    &t1,
+			},
+			{
+				ItemID:       3,
+				DestProvider: "google-prod",
+				Status:       ItemStatusFailed,
+				CompletedAt:  &t2,
+			},
+			{
+				ItemID:         4,
+				DestProvider:   "google-prod",
+				DestProviderID: strPtr("dry-run:skipped"),
+				DryRun:         true,
+				Status:         ItemStatusCompleted,
+				CompletedAt:    &t2,
+			},
+		}
+
+		loc := CurrentLocation(history)
+		if assert.NotNil(t, loc) {
+			assert.Equal(t, int64(2), loc.ItemID)
+			assert.Equal(t, "local-edge", loc.DestProvider)
+		}
+	})
+}
+
+func TestLocationAt(t *testing.T) {
+	strPtr := func(s string) *string { return &s }
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Hour)
+	t2 := t0.Add(2 * time.Hour)
+
+	history := []*DocumentMigration{
+		{
+			ItemID:           1,
+			Strategy:         StrategyMove,
+			SourceProvider:   "google-prod",
+			SourceProviderID: "google:a",
+			DestProvider:     "s3-archive",
+			DestProviderID:   strPtr("s3:a"),
+			Status:           ItemStatusCompleted,
+			CompletedAt:      &t1,
+		},
+		{
+			ItemID:           2,
+			Strategy:         StrategyMirror,
+			SourceProvider:   "s3-archive",
+			SourceProviderID: "s3:a",
+			DestProvider:     "local-edge",
+			DestProviderID:   strPtr("local:a"),
+			Status:           ItemStatusCompleted,
+			CompletedAt:      &t2,
+		},
+		{
+			ItemID:           3,
+			Strategy:         StrategyCopy,
+			SourceProvider:   "s3-archive",
+			SourceProviderID: "s3:a",
+			DestProvider:     "google-prod",
+			Status:           ItemStatusFailed,
+			CompletedAt:      &t2,
+		},
+	}
+
+	t.Run("never migrated", func(t *testing.T) {
+		assert.Nil(t, LocationAt(nil, t1))
+	})
+
+	t.Run("before first migration uses its source", func(t *testing.T) {
+		assert.Equal(t, &Location{Provider: "google-prod", ProviderID: "google:a"},
+			LocationAt(history, t0))
+	})
+
+	t.Run("after migration uses its destination", func(t *testing.T) {
+		assert.Equal(t, &Location{Provider: "s3-archive", ProviderID: "s3:a"},
+			LocationAt(history, t1))
+		assert.Equal(t, &Location{Provider: "s3-archive", ProviderID: "s3:a"},
+			LocationAt(history, t2.Add(time.Hour)))
+	})
+}
+
+func TestNormalizeContentHash(t *testing.T) {
+	assert.Equal(t, "abc", normalizeContentHash("sha256:abc"))
+	assert.Equal(t, "abc", normalizeContentHash("abc"))
+}
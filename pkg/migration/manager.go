@@ -14,8 +14,9 @@ import (
 
 // Manager orchestrates document migration between storage providers
 type Manager struct {
-	db     *sql.DB
-	logger hclog.Logger
+	db       *sql.DB
+	logger   hclog.Logger
+	notifier Notifier
 }
 
 // NewManager creates a new migration manager
@@ -41,6 +42,15 @@ func (m *Manager) CreateJob(ctx context.Context, req *CreateJobRequest) (*Job, e
 	if req.Strategy == "" {
 		req.Strategy = StrategyCopy // Default to copy
 	}
+	if req.SourceAction == "" {
+		req.SourceAction = SourceActionDelete
+	}
+	if req.SourceAction != SourceActionDelete && req.SourceAction != SourceActionTombstone {
+		return nil, fmt.Errorf("invalid source action: %s", req.SourceAction)
+	}
+	if req.SoakPeriodSeconds < 0 {
+		return nil, fmt.Errorf("soak period must not be negative")
+	}
 
 	// Set defaults
 	if req.Concurrency == 0 {
@@ -80,8 +90,9 @@ func (m *Manager) CreateJob(ctx context.Context, req *CreateJobRequest) (*Job, e
 		INSERT INTO migration_jobs (
 			job_uuid, job_name, source_provider_id, dest_provider_id,
 			filter_criteria, strategy, status, concurrency, batch_size,
-			dry_run, validate_after_migration, rollback_enabled, created_by
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+			dry_run, validate_after_migration, rollback_enabled, created_by,
+			soak_period_seconds, source_action
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 		RETURNING id, created_at, updated_at
 	`
 
@@ -90,7 +101,7 @@ func (m *Manager) CreateJob(ctx context.Context, req *CreateJobRequest) (*Job, e
 		jobUUID, req.JobName, sourceID, destID,
 		filterCriteriaJSON, req.Strategy, JobStatusPending,
 		req.Concurrency, req.BatchSize, req.DryRun, req.Validate,
-		true, req.CreatedBy,
+		true, req.CreatedBy, req.SoakPeriodSeconds, req.SourceAction,
 	).Scan(&job.ID, &job.CreatedAt, &job.UpdatedAt)
 
 	if err != nil {
@@ -110,6 +121,8 @@ func (m *Manager) CreateJob(ctx context.Context, req *CreateJobRequest) (*Job, e
 	job.CreatedBy = req.CreatedBy
 	job.ValidateAfter = req.Validate
 	job.RollbackEnabled = true
+	job.SoakPeriodSeconds = req.SoakPeriodSeconds
+	job.SourceAction = req.SourceAction
 
 	m.logger.Info("migration job created",
 		"job_id", job.ID,
@@ -150,14 +163,20 @@ func (m *Manager) QueueDocuments(ctx context.Context, jobID int64, documentUUIDs
 	var sourceProvider, destProvider string
 	var strategy Strategy
 	var dryRun, validate bool
+	var soakPeriodSeconds int
+	var sourceAction SourceAction
 	err = tx.QueryRowContext(ctx, `
 		SELECT
-			sp.provider_name, dp.provider_name, mj.strategy, mj.dry_run, mj.validate_after_migration
+			sp.provider_name, dp.provider_name, mj.strategy, mj.dry_run, mj.validate_after_migration,
+			mj.soak_period_seconds, mj.source_action
 		FROM migration_jobs mj
 		JOIN provider_storage sp ON mj.source_provider_id = sp.id
 		JOIN provider_storage dp ON mj.dest_provider_id = dp.id
 		WHERE mj.id = $1
-	`, jobID).Scan(&sourceProvider, &destProvider, &strategy, &dryRun, &validate)
+	`, jobID).Scan(
+		&sourceProvider, &destProvider, &strategy, &dryRun, &validate,
+		&soakPeriodSeconds, &sourceAction,
+	)
 	if err != nil {
 		return fmt.Errorf("failed to get job details: %w", err)
 	}
@@ -195,6 +214,9 @@ func (m *Manager) QueueDocuments(ctx context.Context, jobID int64, documentUUIDs
 			Validate:         validate,
 			AttemptCount:     0,
 			MaxAttempts:      3,
+
+			SoakPeriodSeconds: soakPeriodSeconds,
+			SourceAction:      sourceAction,
 		}
 		payloadJSON, err := json.Marshal(payload)
 		if err != nil {
@@ -238,7 +260,7 @@ func (m *Manager) QueueDocuments(ctx context.Context, jobID int64, documentUUIDs
 func (m *Manager) StartJob(ctx context.Context, jobID int64) error {
 	result, err := m.db.ExecContext(ctx, `
 		UPDATE migration_jobs
-		SET status = $1, started_at = NOW(), updated_at = NOW()
+		SET status = $1, started_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
 		WHERE id = $2 AND status = $3
 	`, JobStatusRunning, jobID, JobStatusPending)
 
@@ -255,6 +277,7 @@ func (m *Manager) StartJob(ctx context.Context, jobID int64) error {
 	}
 
 	m.logger.Info("migration job started", "job_id", jobID)
+	m.notify(ctx, EventJobStarted, jobID, nil)
 	return nil
 }
 
@@ -267,7 +290,8 @@ func (m *Manager) GetJob(ctx context.Context, jobID int64) (*Job, error) {
 			status, strategy, concurrency, batch_size, dry_run,
 			validate_after_migration, validation_status, rollback_enabled,
 			total_documents, migrated_documents, failed_documents, skipped_documents,
-			created_by, created_at, updated_at, started_at, completed_at
+			created_by, created_at, updated_at, started_at, completed_at,
+			soak_period_seconds, source_action
 		FROM migration_jobs
 		WHERE id = $1
 	`
@@ -278,6 +302,7 @@ func (m *Manager) GetJob(ctx context.Context, jobID int64) (*Job, error) {
 		&job.ValidateAfter, &job.ValidationStatus, &job.RollbackEnabled,
 		&job.TotalDocuments, &job.MigratedDocuments, &job.FailedDocuments, &job.SkippedDocuments,
 		&job.CreatedBy, &job.CreatedAt, &job.UpdatedAt, &job.StartedAt, &job.CompletedAt,
+		&job.SoakPeriodSeconds, &job.SourceAction,
 	)
 
 	if err == sql.ErrNoRows {
@@ -335,12 +360,16 @@ func (m *Manager) UpdateItemStatus(ctx context.Context, itemID int64, status Ite
 	now := time.Now()
 
 	// Update item
-	_, err = tx.ExecContext(ctx, `
+	var jobID int64
+	var documentUUID string
+	err = tx.QueryRowContext(ctx, `
 		UPDATE migration_items
 		SET status = $1, dest_provider_id = $2, content_match = $3,
 			error_message = $4, completed_at = $5, updated_at = NOW()
 		WHERE id = $6
-	`, status, destProviderID, contentMatch, errorMsg, now, itemID)
+		RETURNING migration_job_id, document_uuid
+	`, status, destProviderID, contentMatch, errorMsg, now, itemID).
+		Scan(&jobID, &documentUUID)
 	if err != nil {
 		return fmt.Errorf("failed to update item: %w", err)
 	}
@@ -359,16 +388,58 @@ func (m *Manager) UpdateItemStatus(ctx context.Context, itemID int64, status Ite
 		return tx.Commit()
 	}
 
-	_, err = tx.ExecContext(ctx, fmt.Sprintf(`
+	var total, done int
+	err = tx.QueryRowContext(ctx, fmt.Sprintf(`
 		UPDATE migration_jobs
 		SET %s = %s + 1, updated_at = NOW()
-		WHERE id = (SELECT migration_job_id FROM migration_items WHERE id = $1)
-	`, counterField, counterField), itemID)
+		WHERE id = $1
+		RETURNING total_documents,
+			migrated_documents + failed_documents + skipped_documents
+	`, counterField, counterField), jobID).Scan(&total, &done)
 	if err != nil {
 		return fmt.Errorf("failed to update job counters: %w", err)
 	}
 
-	return tx.Commit()
+	// Counter updates are serialized by the row lock, so exactly one item
+	// observes each threshold being crossed.
+	halfway, complete := progressEvents(total, done-1, done)
+	if complete {
+		result, err := tx.ExecContext(ctx, `
+			UPDATE migration_jobs
+			SET status = $1, completed_at = NOW(), updated_at = NOW()
+			WHERE id = $2 AND status = $3
+		`, JobStatusCompleted, jobID, JobStatusRunning)
+		if err != nil {
+			return fmt.Errorf("failed to complete job: %w", err)
+		}
+		if rows, err := result.RowsAffected(); err != nil || rows == 0 {
+			// The job was paused or cancelled; leave its status alone.
+			complete = false
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	if status == ItemStatusFailed {
+		m.notify(ctx, EventItemFailed, jobID, func(ev *Event) {
+			ev.ItemID = itemID
+			ev.DocumentUUID = documentUUID
+			if errorMsg != nil {
+				ev.Error = *errorMsg
+			}
+		})
+	}
+	if halfway {
+		m.notify(ctx, EventJobHalfway, jobID, nil)
+	}
+	if complete {
+		m.logger.Info("migration job completed", "job_id", jobID)
+		m.notify(ctx, EventJobCompleted, jobID, nil)
+	}
+
+	return nil
 }
 
 // Helper: getProviderID looks up provider ID by name
@@ -235,6 +235,11 @@ func TestMigrationE2E(t *testing.T) {
 		assert.Greater(t, progress.Migrated, 0, "Should have migrated at least one document")
 		assert.Equal(t, progress.Total, progress.Migrated+progress.Failed,
 			"All documents should be processed")
+
+		job, err := manager.GetJob(ctx, jobID)
+		require.NoError(t, err)
+		assert.Equal(t, JobStatusCompleted, job.Status)
+		assert.NotNil(t, job.CompletedAt)
 	})
 
 	t.Run("VerifyMigratedDocuments", func(t *testing.T) {
@@ -0,0 +1,542 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/docid"
+)
+
+// AuditActorSystem is the audit log actor for actions taken by the worker.
+const AuditActorSystem = "system"
+
+// Audit log actions
+const (
+	AuditActionSourceRemovalScheduled = "source_removal_scheduled"
+	AuditActionSourceDeleted          = "source_deleted"
+	AuditActionSourceTombstoned       = "source_tombstoned"
+	AuditActionSourceRetained         = "source_retained"
+	AuditActionRollbackRequested      = "rollback_requested"
+	AuditActionRolledBack             = "rolled_back"
+	AuditActionRollbackFailed         = "rollback_failed"
+)
+
+// tombstonePrefix is prepended to the name of tombstoned source documents.
+const tombstonePrefix = "[Migrated] "
+
+var (
+	// ErrItemNotFound is returned when a migration item does not exist.
+	ErrItemNotFound = errors.New("migration item not found")
+
+	// ErrRollbackNotAllowed is returned by RequestRollback when the item can't
+	// be rolled back.
+	ErrRollbackNotAllowed = errors.New("rollback not allowed")
+)
+
+// execer is implemented by *sql.DB and *sql.Tx.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// tombstoneName returns the name for a tombstoned source document.
+func tombstoneName(name string) string {
+	return tombstonePrefix + name
+}
+
+// canRollback returns true if a moved item in the given source state can be
+// rolled back.
+func canRollback(state SourceState) bool {
+	switch state {
+	case SourceStatePendingRemoval, SourceStateDeleted,
+		SourceStateTombstoned, SourceStateRetained:
+		return true
+	default:
+		return false
+	}
+}
+
+// sourceRemovedState returns the source state after a source action is
+// applied.
+func sourceRemovedState(action SourceAction) (SourceState, string) {
+	if action == SourceActionTombstone {
+		return SourceStateTombstoned, AuditActionSourceTombstoned
+	}
+	return SourceStateDeleted, AuditActionSourceDeleted
+}
+
+// recordAudit appends an entry to the migration audit log.
+func (m *Manager) recordAudit(ctx context.Context, ex execer, jobID, itemID int64, docUUID docid.UUID, action, actor string, details map[string]any) error {
+	var detailsJSON []byte
+	if details != nil {
+		var err error
+		detailsJSON, err = json.Marshal(details)
+		if err != nil {
+			return fmt.Errorf("failed to serialize audit details: %w", err)
+		}
+	}
+
+	_, err := ex.ExecContext(ctx, `
+		INSERT INTO migration_audit_log (
+			migration_job_id, migration_item_id, document_uuid, action, actor, details
+		) VALUES ($1, $2, $3, $4, $5, $6)
+	`, jobID, itemID, docUUID.String(), action, actor, detailsJSON)
+	if err != nil {
+		return fmt.Errorf("failed to record audit entry: %w", err)
+	}
+	return nil
+}
+
+// ScheduleSourceRemoval marks the source of a verified move for removal once
+// the soak period has elapsed.
+func (m *Manager) ScheduleSourceRemoval(ctx context.Context, payload *TaskPayload, sourceName string, removeAfter time.Time) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE migration_items
+		SET source_state = $1, source_name = $2, source_remove_after = $3,
+			source_removed_at = NULL, updated_at = NOW()
+		WHERE id = $4
+	`, SourceStatePendingRemoval, sourceName, removeAfter, payload.ItemID)
+	if err != nil {
+		return fmt.Errorf("failed to schedule source removal: %w", err)
+	}
+
+	if err := m.recordAudit(ctx, tx, payload.JobID, payload.ItemID, payload.DocumentUUID,
+		AuditActionSourceRemovalScheduled, AuditActorSystem, map[string]any{
+			"sourceProvider":   payload.SourceProvider,
+			"sourceProviderId": payload.SourceProviderID,
+			"sourceAction":     payload.SourceAction,
+			"removeAfter":      removeAfter,
+		}); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// RequestRollback requests that a moved item be rolled back: the source
+// document is restored (if it was removed) and becomes the canonical copy
+// again, and the destination copy is deleted. The rollback is carried out
+// asynchronously by the worker.
+func (m *Manager) RequestRollback(ctx context.Context, itemID int64, actor string) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var (
+		jobID           int64
+		docUUIDStr      string
+		strategy        Strategy
+		rollbackEnabled bool
+		state           sql.NullString
+	)
+	err = tx.QueryRowContext(ctx, `
+		SELECT mi.migration_job_id, mi.document_uuid, mj.strategy,
+			mj.rollback_enabled, mi.source_state
+		FROM migration_items mi
+		JOIN migration_jobs mj ON mi.migration_job_id = mj.id
+		WHERE mi.id = $1
+		FOR UPDATE OF mi
+	`, itemID).Scan(&jobID, &docUUIDStr, &strategy, &rollbackEnabled, &state)
+	if err == sql.ErrNoRows {
+		return ErrItemNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get migration item: %w", err)
+	}
+
+	if strategy != StrategyMove {
+		return fmt.Errorf("%w: item was not moved", ErrRollbackNotAllowed)
+	}
+	if !rollbackEnabled {
+		return fmt.Errorf("%w: rollback is disabled for job %d", ErrRollbackNotAllowed, jobID)
+	}
+	if !canRollback(SourceState(state.String)) {
+		return fmt.Errorf("%w: item source state is %q", ErrRollbackNotAllowed, state.String)
+	}
+
+	docUUID, err := docid.ParseUUID(docUUIDStr)
+	if err != nil {
+		return fmt.Errorf("failed to parse document UUID: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE migration_items
+		SET source_state = $1, updated_at = NOW()
+		WHERE id = $2
+	`, SourceStateRollbackRequested, itemID)
+	if err != nil {
+		return fmt.Errorf("failed to request rollback: %w", err)
+	}
+
+	if err := m.recordAudit(ctx, tx, jobID, itemID, docUUID,
+		AuditActionRollbackRequested, actor, map[string]any{
+			"previousSourceState": state.String,
+		}); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	m.logger.Info("migration rollback requested",
+		"item_id", itemID,
+		"document_uuid", docUUID,
+		"actor", actor)
+
+	return nil
+}
+
+// GetItemAudit returns the audit log for a migration item, oldest first.
+func (m *Manager) GetItemAudit(ctx context.Context, itemID int64) ([]AuditEntry, error) {
+	rows, err := m.db.QueryContext(ctx, `
+		SELECT id, migration_job_id, migration_item_id, document_uuid,
+			action, actor, details, created_at
+		FROM migration_audit_log
+		WHERE migration_item_id = $1
+		ORDER BY created_at ASC, id ASC
+	`, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query audit log: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []AuditEntry{}
+	for rows.Next() {
+		var (
+			e       AuditEntry
+			details []byte
+		)
+		if err := rows.Scan(&e.ID, &e.MigrationJobID, &e.MigrationItemID,
+			&e.DocumentUUID, &e.Action, &e.Actor, &details, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan audit entry: %w", err)
+		}
+		if len(details) > 0 {
+			if err := json.Unmarshal(details, &e.Details); err != nil {
+				return nil, fmt.Errorf("failed to parse audit details: %w", err)
+			}
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate audit log: %w", err)
+	}
+
+	return entries, nil
+}
+
+// movedItem is a moved migration item awaiting source removal or rollback.
+type movedItem struct {
+	itemID           int64
+	jobID            int64
+	documentUUID     docid.UUID
+	sourceProvider   string
+	sourceProviderID string
+	destProvider     string
+	destProviderID   string
+	sourceName       string
+	destHash         string
+	sourceAction     SourceAction
+	sourceRemoved    bool
+}
+
+// listMovedItems returns moved items in the given source state. If dueOnly is
+// set, only items whose soak period has elapsed are returned.
+func (m *Manager) listMovedItems(ctx context.Context, state SourceState, dueOnly bool, limit int) ([]movedItem, error) {
+	rows, err := m.db.QueryContext(ctx, `
+		SELECT mi.id, mi.migration_job_id, mi.document_uuid,
+			sp.provider_name, mi.source_provider_id,
+			dp.provider_name, COALESCE(mi.dest_provider_id, ''),
+			COALESCE(mi.source_name, ''), COALESCE(mi.dest_content_hash, ''),
+			mj.source_action, mi.source_removed_at IS NOT NULL
+		FROM migration_items mi
+		JOIN migration_jobs mj ON mi.migration_job_id = mj.id
+		JOIN provider_storage sp ON mj.source_provider_id = sp.id
+		JOIN provider_storage dp ON mj.dest_provider_id = dp.id
+		WHERE mi.source_state = $1
+			AND (NOT $2 OR mi.source_remove_after <= NOW())
+		ORDER BY mi.source_remove_after ASC, mi.id ASC
+		LIMIT $3
+	`, state, dueOnly, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query moved items: %w", err)
+	}
+	defer rows.Close()
+
+	var items []movedItem
+	for rows.Next() {
+		var (
+			it      movedItem
+			uuidStr string
+		)
+		if err := rows.Scan(&it.itemID, &it.jobID, &uuidStr,
+			&it.sourceProvider, &it.sourceProviderID,
+			&it.destProvider, &it.destProviderID,
+			&it.sourceName, &it.destHash,
+			&it.sourceAction, &it.sourceRemoved); err != nil {
+			return nil, fmt.Errorf("failed to scan moved item: %w", err)
+		}
+		it.documentUUID, err = docid.ParseUUID(uuidStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse document UUID: %w", err)
+		}
+		items = append(items, it)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate moved items: %w", err)
+	}
+
+	return items, nil
+}
+
+// setSourceState transitions a moved item's source state and records an
+// audit entry. The update only applies if the item is still in the expected
+// state, so concurrent workers don't act on the same item twice.
+func (m *Manager) setSourceState(ctx context.Context, it *movedItem, from, to SourceState, removed bool, action string, details map[string]any) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	removedAt := "source_removed_at"
+	if removed {
+		removedAt = "COALESCE(source_removed_at, NOW())"
+	}
+	result, err := tx.ExecContext(ctx, fmt.Sprintf(`
+		UPDATE migration_items
+		SET source_state = $1, source_provider_id = $2,
+			source_removed_at = %s, updated_at = NOW()
+		WHERE id = $3 AND source_state = $4
+	`, removedAt), to, it.sourceProviderID, it.itemID, from)
+	if err != nil {
+		return fmt.Errorf("failed to update source state: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return fmt.Errorf("item %d is no longer in source state %s", it.itemID, from)
+	}
+
+	if err := m.recordAudit(ctx, tx, it.jobID, it.itemID, it.documentUUID,
+		action, AuditActorSystem, details); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// processSourceRemovals removes the sources of moved items whose soak period
+// has elapsed. The copy is re-verified first; if it is missing or its content
+// no longer matches the source, the source is retained.
+func (w *Worker) processSourceRemovals(ctx context.Context) error {
+	items, err := w.manager.listMovedItems(ctx, SourceStatePendingRemoval, true, w.maxConcurrency)
+	if err != nil {
+		return err
+	}
+
+	for i := range items {
+		it := &items[i]
+		if err := w.removeSource(ctx, it); err != nil {
+			w.logger.Error("failed to remove source after move",
+				"item_id", it.itemID,
+				"document_uuid", it.documentUUID,
+				"error", err)
+		}
+	}
+
+	return nil
+}
+
+// removeSource deletes or tombstones the source of a single moved item.
+func (w *Worker) removeSource(ctx context.Context, it *movedItem) error {
+	source, ok := w.providerMap[it.sourceProvider]
+	if !ok {
+		return fmt.Errorf("source provider %s not found", it.sourceProvider)
+	}
+	dest, ok := w.providerMap[it.destProvider]
+	if !ok {
+		return fmt.Errorf("dest provider %s not found", it.destProvider)
+	}
+
+	// Re-verify the destination copy before touching the source. The copy is
+	// compared with the hash recorded when it was validated, since content
+	// hashes differ across providers that store content in different formats.
+	destContent, err := dest.GetContent(ctx, it.destProviderID)
+	if err != nil || normalizeContentHash(destContent.ContentHash) != it.destHash {
+		reason := "content hash mismatch"
+		if err != nil {
+			reason = fmt.Sprintf("destination copy unavailable: %v", err)
+		}
+		w.logger.Warn("retaining move source - destination copy failed re-verification",
+			"item_id", it.itemID,
+			"document_uuid", it.documentUUID,
+			"reason", reason)
+		return w.manager.setSourceState(ctx, it,
+			SourceStatePendingRemoval, SourceStateRetained, false,
+			AuditActionSourceRetained, map[string]any{"reason": reason})
+	}
+
+	// Only remove the source once the document is served from the destination
+	// copy; otherwise the source is still the canonical copy.
+	repointed, err := w.manager.DocumentPointsTo(ctx, it.documentUUID,
+		it.destProvider, it.destProviderID)
+	if err != nil {
+		return err
+	}
+	if !repointed {
+		reason := "document does not point at the destination copy"
+		w.logger.Warn("retaining move source - document was not repointed",
+			"item_id", it.itemID,
+			"document_uuid", it.documentUUID)
+		return w.manager.setSourceState(ctx, it,
+			SourceStatePendingRemoval, SourceStateRetained, false,
+			AuditActionSourceRetained, map[string]any{"reason": reason})
+	}
+
+	if it.sourceAction == SourceActionTombstone {
+		err = source.RenameDocument(ctx, it.sourceProviderID, tombstoneName(it.sourceName))
+	} else {
+		err = source.DeleteDocument(ctx, it.sourceProviderID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to %s source document: %w", it.sourceAction, err)
+	}
+
+	state, action := sourceRemovedState(it.sourceAction)
+	if err := w.manager.setSourceState(ctx, it,
+		SourceStatePendingRemoval, state, true, action, map[string]any{
+			"sourceProvider":   it.sourceProvider,
+			"sourceProviderId": it.sourceProviderID,
+			"sourceName":       it.sourceName,
+		}); err != nil {
+		return err
+	}
+
+	w.logger.Info("move source removed",
+		"item_id", it.itemID,
+		"document_uuid", it.documentUUID,
+		"action", it.sourceAction)
+
+	return nil
+}
+
+// processRollbacks carries out requested rollbacks of moved items.
+func (w *Worker) processRollbacks(ctx context.Context) error {
+	items, err := w.manager.listMovedItems(ctx, SourceStateRollbackRequested, false, w.maxConcurrency)
+	if err != nil {
+		return err
+	}
+
+	for i := range items {
+		it := &items[i]
+		if err := w.rollback(ctx, it); err != nil {
+			w.logger.Error("failed to roll back moved item",
+				"item_id", it.itemID,
+				"document_uuid", it.documentUUID,
+				"error", err)
+			if auditErr := w.manager.recordAudit(ctx, w.db, it.jobID, it.itemID,
+				it.documentUUID, AuditActionRollbackFailed, AuditActorSystem,
+				map[string]any{"error": err.Error()}); auditErr != nil {
+				w.logger.Error("failed to record rollback failure", "error", auditErr)
+			}
+		}
+	}
+
+	return nil
+}
+
+// rollback restores the source of a moved item, repoints the document back to
+// it, and deletes the destination copy. Rollbacks that fail are retried on the
+// next poll.
+func (w *Worker) rollback(ctx context.Context, it *movedItem) error {
+	source, ok := w.providerMap[it.sourceProvider]
+	if !ok {
+		return fmt.Errorf("source provider %s not found", it.sourceProvider)
+	}
+	dest, ok := w.providerMap[it.destProvider]
+	if !ok {
+		return fmt.Errorf("dest provider %s not found", it.destProvider)
+	}
+
+	// Restore the source if it was removed.
+	var restoredID string
+	if it.sourceRemoved {
+		if it.sourceAction == SourceActionTombstone {
+			if err := source.RenameDocument(ctx, it.sourceProviderID, it.sourceName); err != nil {
+				return fmt.Errorf("failed to restore tombstoned source: %w", err)
+			}
+		} else {
+			destContent, err := dest.GetContent(ctx, it.destProviderID)
+			if err != nil {
+				return fmt.Errorf("failed to read destination copy: %w", err)
+			}
+			restored, err := source.CreateDocumentWithUUID(ctx, it.documentUUID, "", "", it.sourceName)
+			if err != nil {
+				return fmt.Errorf("failed to recreate source document: %w", err)
+			}
+			if _, err := source.UpdateContent(ctx, restored.ProviderID, destContent.Body); err != nil {
+				_ = source.DeleteDocument(ctx, restored.ProviderID)
+				return fmt.Errorf("failed to write restored source content: %w", err)
+			}
+			restoredID = restored.ProviderID
+			it.sourceProviderID = restored.ProviderID
+		}
+	}
+
+	// Make the source canonical again. If that fails, the destination copy is
+	// still the canonical copy, so the rollback is aborted and retried.
+	if err := w.repoint(ctx, &RepointEvent{
+		JobID:            it.jobID,
+		ItemID:           it.itemID,
+		DocumentUUID:     it.documentUUID,
+		SourceProvider:   it.destProvider,
+		SourceProviderID: it.destProviderID,
+		DestProvider:     it.sourceProvider,
+		DestProviderID:   it.sourceProviderID,
+	}); err != nil {
+		if restoredID != "" {
+			// Don't leave a duplicate behind; the next attempt recreates it.
+			_ = source.DeleteDocument(ctx, restoredID)
+		}
+		return fmt.Errorf("failed to repoint document to source: %w", err)
+	}
+
+	if err := dest.DeleteDocument(ctx, it.destProviderID); err != nil {
+		w.logger.Warn("failed to delete destination copy during rollback",
+			"item_id", it.itemID,
+			"dest_provider_id", it.destProviderID,
+			"error", err)
+	}
+
+	if err := w.manager.setSourceState(ctx, it,
+		SourceStateRollbackRequested, SourceStateRolledBack, false,
+		AuditActionRolledBack, map[string]any{
+			"sourceProvider":   it.sourceProvider,
+			"sourceProviderId": it.sourceProviderID,
+			"destProvider":     it.destProvider,
+			"destProviderId":   it.destProviderID,
+			"sourceRestored":   it.sourceRemoved,
+		}); err != nil {
+		return err
+	}
+
+	w.logger.Info("moved item rolled back",
+		"item_id", it.itemID,
+		"document_uuid", it.documentUUID)
+
+	return nil
+}
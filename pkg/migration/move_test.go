@@ -0,0 +1,36 @@
+package migration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTombstoneName(t *testing.T) {
+	assert.Equal(t, "[Migrated] RFC-001: Design", tombstoneName("RFC-001: Design"))
+}
+
+func TestCanRollback(t *testing.T) {
+	cases := map[SourceState]bool{
+		SourceStatePendingRemoval:    true,
+		SourceStateDeleted:           true,
+		SourceStateTombstoned:        true,
+		SourceStateRetained:          true,
+		SourceStateRollbackRequested: false,
+		SourceStateRolledBack:        false,
+		"":                           false,
+	}
+	for state, want := range cases {
+		assert.Equal(t, want, canRollback(state), "state %q", state)
+	}
+}
+
+func TestSourceRemovedState(t *testing.T) {
+	state, action := sourceRemovedState(SourceActionDelete)
+	assert.Equal(t, SourceStateDeleted, state)
+	assert.Equal(t, AuditActionSourceDeleted, action)
+
+	state, action = sourceRemovedState(SourceActionTombstone)
+	assert.Equal(t, SourceStateTombstoned, state)
+	assert.Equal(t, AuditActionSourceTombstoned, action)
+}
@@ -0,0 +1,163 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp-forge/hermes/pkg/docid"
+	"github.com/hashicorp-forge/hermes/pkg/search"
+	"github.com/hashicorp-forge/hermes/pkg/workspace"
+)
+
+// ErrDocumentNotFound is returned by RepointDocument when no document exists
+// for the migrated document UUID.
+var ErrDocumentNotFound = errors.New("document not found")
+
+// RepointEvent describes a document whose canonical provider reference was
+// updated after a successful migration.
+type RepointEvent struct {
+	JobID        int64
+	ItemID       int64
+	DocumentUUID docid.UUID
+
+	// DocumentID is the legacy document ID (Google file ID), which is also the
+	// search index object ID.
+	DocumentID string
+
+	SourceProvider   string
+	SourceProviderID string
+	DestProvider     string
+	DestProviderType string
+	DestProviderID   string
+}
+
+// RepointHook is called after a document has been repointed to its migrated
+// copy. Hooks invalidate caches and reindex the document; errors are logged
+// and do not fail the migration.
+type RepointHook func(ctx context.Context, ev *RepointEvent) error
+
+// RepointDocument updates a document's canonical provider reference
+// (documents.provider_type and documents.provider_document_id) to point at the
+// destination of a completed migration. ev.DestProviderType and ev.DocumentID
+// are populated from the database.
+func (m *Manager) RepointDocument(ctx context.Context, ev *RepointEvent) error {
+	if ev.DocumentUUID.IsZero() {
+		return fmt.Errorf("document UUID is required")
+	}
+	if ev.DestProvider == "" || ev.DestProviderID == "" {
+		return fmt.Errorf("destination provider and provider ID are required")
+	}
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, `
+		SELECT provider_type FROM provider_storage WHERE provider_name = $1
+	`, ev.DestProvider).Scan(&ev.DestProviderType)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("provider %s not found", ev.DestProvider)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lookup dest provider type: %w", err)
+	}
+
+	err = tx.QueryRowContext(ctx, `
+		UPDATE documents
+		SET provider_type = $1, provider_document_id = $2, updated_at = NOW()
+		WHERE document_uuid = $3 AND deleted_at IS NULL
+		RETURNING google_file_id
+	`, ev.DestProviderType, ev.DestProviderID, ev.DocumentUUID.String()).
+		Scan(&ev.DocumentID)
+	if err == sql.ErrNoRows {
+		return ErrDocumentNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update document provider: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	m.logger.Info("document repointed to migrated provider",
+		"document_uuid", ev.DocumentUUID,
+		"job_id", ev.JobID,
+		"source_provider", ev.SourceProvider,
+		"dest_provider", ev.DestProvider,
+		"dest_provider_id", ev.DestProviderID)
+
+	return nil
+}
+
+// DocumentPointsTo returns true if the document's canonical provider
+// reference is providerID in the named provider.
+func (m *Manager) DocumentPointsTo(ctx context.Context, docUUID docid.UUID, provider, providerID string) (bool, error) {
+	var ok bool
+	err := m.db.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1
+			FROM documents d
+			JOIN provider_storage ps ON ps.provider_type = d.provider_type
+			WHERE d.document_uuid = $1 AND d.deleted_at IS NULL
+				AND ps.provider_name = $2 AND d.provider_document_id = $3
+		)
+	`, docUUID.String(), provider, providerID).Scan(&ok)
+	if err != nil {
+		return false, fmt.Errorf("failed to check document provider: %w", err)
+	}
+	return ok, nil
+}
+
+// shouldRepoint returns true if a completed migration should become the
+// document's canonical location. Dry runs, mirrors (which keep the source
+// canonical), and copies that failed content validation are not repointed.
+func shouldRepoint(payload *TaskPayload, validation *ValidationResult) bool {
+	if payload.DryRun || payload.Strategy == StrategyMirror {
+		return false
+	}
+	if validation != nil && !validation.Match {
+		return false
+	}
+	return true
+}
+
+// NewSearchReindexHook returns a RepointHook that refreshes a repointed
+// document's search index object with content read from its new provider.
+// The document's modified time is left as is, since moving a document doesn't
+// modify it. Documents that are not in the search index are skipped.
+func NewSearchReindexHook(
+	idx search.DocumentIndex,
+	providerMap map[string]workspace.WorkspaceProvider,
+) RepointHook {
+	return func(ctx context.Context, ev *RepointEvent) error {
+		if ev.DocumentID == "" {
+			return nil
+		}
+
+		doc, err := idx.GetObject(ctx, ev.DocumentID)
+		if err != nil {
+			if errors.Is(err, search.ErrNotFound) {
+				return nil
+			}
+			return fmt.Errorf("failed to get search object: %w", err)
+		}
+
+		if provider, ok := providerMap[ev.DestProvider]; ok {
+			content, err := provider.GetContent(ctx, ev.DestProviderID)
+			if err != nil {
+				return fmt.Errorf("failed to get content from dest provider: %w", err)
+			}
+			doc.Content = content.Body
+		}
+
+		if err := idx.Index(ctx, doc); err != nil {
+			return fmt.Errorf("failed to reindex document: %w", err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,50 @@
+package migration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldRepoint(t *testing.T) {
+	cases := []struct {
+		name       string
+		payload    TaskPayload
+		validation *ValidationResult
+		want       bool
+	}{
+		{
+			name:    "move",
+			payload: TaskPayload{Strategy: StrategyMove},
+			want:    true,
+		},
+		{
+			name:       "copy with matching content",
+			payload:    TaskPayload{Strategy: StrategyCopy},
+			validation: &ValidationResult{Match: true},
+			want:       true,
+		},
+		{
+			name:       "copy with mismatched content",
+			payload:    TaskPayload{Strategy: StrategyCopy},
+			validation: &ValidationResult{Match: false},
+			want:       false,
+		},
+		{
+			name:    "mirror",
+			payload: TaskPayload{Strategy: StrategyMirror},
+			want:    false,
+		},
+		{
+			name:    "dry run",
+			payload: TaskPayload{Strategy: StrategyMove, DryRun: true},
+			want:    false,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.want, shouldRepoint(&c.payload, c.validation))
+		})
+	}
+}
@@ -40,6 +40,27 @@ const (
 	StrategyMirror Strategy = "mirror" // Keep both in sync
 )
 
+// SourceAction defines what happens to the source document of a move once
+// the copy has been verified and the soak period has elapsed
+type SourceAction string
+
+const (
+	SourceActionDelete    SourceAction = "delete"    // Delete the source document
+	SourceActionTombstone SourceAction = "tombstone" // Rename the source document and keep it
+)
+
+// SourceState tracks the source document of a moved item
+type SourceState string
+
+const (
+	SourceStatePendingRemoval    SourceState = "pending_removal"    // Waiting for the soak period to end
+	SourceStateDeleted           SourceState = "deleted"            // Source document deleted
+	SourceStateTombstoned        SourceState = "tombstoned"         // Source document renamed and kept
+	SourceStateRetained          SourceState = "retained"           // Removal blocked by failed re-verification
+	SourceStateRollbackRequested SourceState = "rollback_requested" // Waiting for the worker to roll back
+	SourceStateRolledBack        SourceState = "rolled_back"        // Source restored as the canonical copy
+)
+
 // Job represents a migration job
 type Job struct {
 	ID          int64      `json:"id" db:"id"`
@@ -70,6 +91,10 @@ type Job struct {
 
 	// Rollback
 	RollbackEnabled bool `json:"rollbackEnabled" db:"rollback_enabled"`
+
+	// Move strategy
+	SoakPeriodSeconds int          `json:"soakPeriodSeconds" db:"soak_period_seconds"`
+	SourceAction      SourceAction `json:"sourceAction" db:"source_action"`
 }
 
 // Item represents a single document migration item
@@ -92,6 +117,12 @@ type Item struct {
 	IsRetryable       bool       `json:"isRetryable" db:"is_retryable"`
 	CreatedAt         time.Time  `json:"createdAt" db:"created_at"`
 	UpdatedAt         time.Time  `json:"updatedAt" db:"updated_at"`
+
+	// Move strategy source tracking
+	SourceState       *SourceState `json:"sourceState,omitempty" db:"source_state"`
+	SourceName        *string      `json:"sourceName,omitempty" db:"source_name"`
+	SourceRemoveAfter *time.Time   `json:"sourceRemoveAfter,omitempty" db:"source_remove_after"`
+	SourceRemovedAt   *time.Time   `json:"sourceRemovedAt,omitempty" db:"source_removed_at"`
 }
 
 // OutboxEvent represents a migration task event in the outbox
@@ -126,6 +157,10 @@ type CreateJobRequest struct {
 	DryRun         bool           `json:"dryRun"`
 	Validate       bool           `json:"validate"`
 	CreatedBy      string         `json:"createdBy"`
+
+	// Move strategy options
+	SoakPeriodSeconds int          `json:"soakPeriodSeconds,omitempty"`
+	SourceAction      SourceAction `json:"sourceAction,omitempty"` // Default: "delete"
 }
 
 // TaskPayload represents the payload for a migration task event
@@ -141,6 +176,10 @@ type TaskPayload struct {
 	Validate         bool       `json:"validate"`
 	AttemptCount     int        `json:"attemptCount"`
 	MaxAttempts      int        `json:"maxAttempts"`
+
+	// Move strategy options
+	SoakPeriodSeconds int          `json:"soakPeriodSeconds,omitempty"`
+	SourceAction      SourceAction `json:"sourceAction,omitempty"`
 }
 
 // Progress represents migration progress statistics
@@ -163,3 +202,15 @@ type ValidationResult struct {
 	BytesDiff      int    `json:"bytesDiff"`
 	ValidationTime int64  `json:"validationTimeMs"`
 }
+
+// AuditEntry is a record in the migration audit log
+type AuditEntry struct {
+	ID              int64          `json:"id" db:"id"`
+	MigrationJobID  int64          `json:"migrationJobId" db:"migration_job_id"`
+	MigrationItemID *int64         `json:"migrationItemId,omitempty" db:"migration_item_id"`
+	DocumentUUID    *string        `json:"documentUuid,omitempty" db:"document_uuid"`
+	Action          string         `json:"action" db:"action"`
+	Actor           string         `json:"actor" db:"actor"`
+	Details         map[string]any `json:"details,omitempty" db:"details"`
+	CreatedAt       time.Time      `json:"createdAt" db:"created_at"`
+}
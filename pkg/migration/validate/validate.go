@@ -0,0 +1,579 @@
+// Package validate provides strong-signal validation of RFC-089 migration
+// jobs. It checks job completeness, content integrity, outbox integrity, and
+// migration invariants directly against the migration tables so the same
+// report can be produced for test fixtures and production jobs.
+package validate
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/hashicorp-forge/hermes/pkg/workspace"
+	"github.com/hashicorp/go-hclog"
+)
+
+// Result represents the result of a single validation check.
+type Result struct {
+	Name        string      `json:"name"`
+	Passed      bool        `json:"passed"`
+	Message     string      `json:"message"`
+	ExpectedVal interface{} `json:"expected,omitempty"`
+	ActualVal   interface{} `json:"actual,omitempty"`
+}
+
+// Report is the complete validation report for a migration job.
+type Report struct {
+	JobID   int64    `json:"jobId"`
+	JobUUID string   `json:"jobUuid"`
+	JobName string   `json:"jobName"`
+	Status  string   `json:"status"`
+	Results []Result `json:"results"`
+}
+
+// Failed returns the checks that did not pass.
+func (r *Report) Failed() []Result {
+	var failed []Result
+	for _, res := range r.Results {
+		if !res.Passed {
+			failed = append(failed, res)
+		}
+	}
+	return failed
+}
+
+// Passed returns true if every check passed.
+func (r *Report) Passed() bool {
+	return len(r.Failed()) == 0
+}
+
+// ContentGetter retrieves document content from a migration destination. It
+// is satisfied by any workspace.WorkspaceProvider.
+type ContentGetter interface {
+	GetContent(ctx context.Context, providerID string) (*workspace.DocumentContent, error)
+}
+
+// Validator validates migration jobs.
+type Validator struct {
+	db     *sql.DB
+	logger hclog.Logger
+}
+
+// New creates a new validator.
+func New(db *sql.DB, logger hclog.Logger) *Validator {
+	if logger == nil {
+		logger = hclog.NewNullLogger()
+	}
+	return &Validator{
+		db:     db,
+		logger: logger.Named("migration-validator"),
+	}
+}
+
+// ValidateJob runs all validations for the job identified by UUID. The
+// expected document count is taken from the job record. If dest is nil,
+// content retrievability checks are skipped and only recorded hashes are
+// compared.
+func (v *Validator) ValidateJob(
+	ctx context.Context, jobUUID string, dest ContentGetter,
+) (*Report, error) {
+	report := &Report{JobUUID: jobUUID}
+
+	var totalDocs int
+	err := v.db.QueryRowContext(ctx, `
+		SELECT id, job_name, status, total_documents
+		FROM migration_jobs
+		WHERE job_uuid = $1
+	`, jobUUID).Scan(&report.JobID, &report.JobName, &report.Status, &totalDocs)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("migration job %q not found", jobUUID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get migration job: %w", err)
+	}
+
+	report.Results = append(report.Results,
+		v.ValidateJobCompleteness(ctx, report.JobID, totalDocs)...)
+	report.Results = append(report.Results,
+		v.ValidateContentIntegrity(ctx, report.JobID, dest)...)
+	report.Results = append(report.Results,
+		v.ValidateOutboxIntegrity(ctx, report.JobID)...)
+	report.Results = append(report.Results,
+		v.ValidateMigrationInvariants(ctx, report.JobID, totalDocs)...)
+
+	return report, nil
+}
+
+// ValidateJobCompleteness ensures the migration job completed successfully with all expected data.
+//
+// Strong signals:
+// - Job status is 'completed' or 'running' with 100% success
+// - Total documents = migrated + failed + skipped (no leaks)
+// - All migration items have terminal status (completed/failed/skipped)
+// - No items stuck in 'pending' or 'in_progress'
+// - All outbox events are processed (status != 'pending')
+func (v *Validator) ValidateJobCompleteness(ctx context.Context, jobID int64, expectedDocs int) []Result {
+	results := []Result{}
+
+	v.logger.Info("validating job completeness", "job_id", jobID, "expected_docs", expectedDocs)
+
+	// Check 1: Job exists and has correct total
+	var status string
+	var totalDocs, migratedDocs, failedDocs, skippedDocs int
+	err := v.db.QueryRowContext(ctx, `
+		SELECT status, total_documents, migrated_documents, failed_documents, skipped_documents
+		FROM migration_jobs
+		WHERE id = $1
+	`, jobID).Scan(&status, &totalDocs, &migratedDocs, &failedDocs, &skippedDocs)
+
+	results = append(results, Result{
+		Name:        "JobExists",
+		Passed:      err == nil,
+		Message:     fmt.Sprintf("Job %d should exist in database", jobID),
+		ExpectedVal: nil,
+		ActualVal:   err,
+	})
+	if err != nil {
+		return results
+	}
+
+	// Check 2: Total documents matches expected
+	results = append(results, Result{
+		Name:        "TotalDocumentsCorrect",
+		Passed:      totalDocs == expectedDocs,
+		Message:     "Job total_documents should match expected count",
+		ExpectedVal: expectedDocs,
+		ActualVal:   totalDocs,
+	})
+
+	// Check 3: Document count invariant (total = migrated + failed + skipped)
+	accountedDocs := migratedDocs + failedDocs + skippedDocs
+	results = append(results, Result{
+		Name:        "DocumentCountInvariant",
+		Passed:      totalDocs == accountedDocs,
+		Message:     fmt.Sprintf("total_documents (%d) = migrated (%d) + failed (%d) + skipped (%d)", totalDocs, migratedDocs, failedDocs, skippedDocs),
+		ExpectedVal: totalDocs,
+		ActualVal:   accountedDocs,
+	})
+
+	// Check 4: Job status is terminal or all docs processed
+	terminalStatus := status == "completed" || status == "failed" || status == "canceled"
+	allProcessed := accountedDocs == totalDocs
+	results = append(results, Result{
+		Name:        "JobStatusValid",
+		Passed:      terminalStatus || (status == "running" && allProcessed),
+		Message:     "Job status should be terminal or all documents processed",
+		ExpectedVal: "completed or running with 100%",
+		ActualVal:   fmt.Sprintf("%s with %d/%d processed", status, accountedDocs, totalDocs),
+	})
+
+	// Check 5: All migration items have terminal status
+	var pendingItems, inProgressItems int
+	err = v.db.QueryRowContext(ctx, `
+		SELECT
+			COUNT(*) FILTER (WHERE status = 'pending') as pending,
+			COUNT(*) FILTER (WHERE status = 'in_progress') as in_progress
+		FROM migration_items
+		WHERE migration_job_id = $1
+	`, jobID).Scan(&pendingItems, &inProgressItems)
+
+	noStuckItems := err == nil && pendingItems == 0 && inProgressItems == 0
+	results = append(results, Result{
+		Name:        "NoStuckMigrationItems",
+		Passed:      noStuckItems,
+		Message:     "No migration items should be stuck in pending/in_progress",
+		ExpectedVal: "0 pending, 0 in_progress",
+		ActualVal:   fmt.Sprintf("%d pending, %d in_progress", pendingItems, inProgressItems),
+	})
+
+	// Check 6: All outbox events processed
+	var pendingEvents int
+	err = v.db.QueryRowContext(ctx, `
+		SELECT COUNT(*)
+		FROM migration_outbox
+		WHERE migration_job_id = $1 AND status = 'pending'
+	`, jobID).Scan(&pendingEvents)
+
+	results = append(results, Result{
+		Name:        "AllOutboxEventsProcessed",
+		Passed:      err == nil && pendingEvents == 0,
+		Message:     "All outbox events should be processed (not pending)",
+		ExpectedVal: 0,
+		ActualVal:   pendingEvents,
+	})
+
+	// Check 7: Migration item count matches job total
+	var itemCount int
+	err = v.db.QueryRowContext(ctx, `
+		SELECT COUNT(*)
+		FROM migration_items
+		WHERE migration_job_id = $1
+	`, jobID).Scan(&itemCount)
+
+	results = append(results, Result{
+		Name:        "MigrationItemCountMatches",
+		Passed:      err == nil && itemCount == expectedDocs,
+		Message:     "Number of migration_items should match expected documents",
+		ExpectedVal: expectedDocs,
+		ActualVal:   itemCount,
+	})
+
+	return results
+}
+
+// ValidateContentIntegrity verifies that document content was migrated correctly without corruption.
+//
+// Strong signals:
+// - Content hashes match between source and destination
+// - All migration items have content_match = true
+// - Destination content is retrievable and non-empty (when dest is provided)
+// - Retrieved content hashes to the recorded destination hash
+func (v *Validator) ValidateContentIntegrity(ctx context.Context, jobID int64, dest ContentGetter) []Result {
+	results := []Result{}
+
+	v.logger.Info("validating content integrity", "job_id", jobID)
+
+	// Get all migration items for this job
+	rows, err := v.db.QueryContext(ctx, `
+		SELECT document_uuid, source_provider_id, COALESCE(dest_provider_id, ''),
+		       COALESCE(source_content_hash, ''), COALESCE(dest_content_hash, ''),
+		       COALESCE(content_match, false), status
+		FROM migration_items
+		WHERE migration_job_id = $1
+	`, jobID)
+	if err != nil {
+		results = append(results, Result{
+			Name:        "QueryMigrationItems",
+			Passed:      false,
+			Message:     "Failed to query migration items",
+			ExpectedVal: nil,
+			ActualVal:   err.Error(),
+		})
+		return results
+	}
+	defer rows.Close()
+
+	itemCount := 0
+	contentMatchCount := 0
+	hashMatchCount := 0
+	retrievableCount := 0
+
+	for rows.Next() {
+		var docUUID, sourceProviderID, destProviderID, sourceHash, destHash, status string
+		var contentMatch bool
+
+		err := rows.Scan(&docUUID, &sourceProviderID, &destProviderID, &sourceHash, &destHash, &contentMatch, &status)
+		if err != nil {
+			continue
+		}
+
+		itemCount++
+
+		// Check 1: content_match flag is true
+		if contentMatch {
+			contentMatchCount++
+		}
+
+		// Check 2: Hashes match
+		if sourceHash == destHash && sourceHash != "" {
+			hashMatchCount++
+		}
+
+		// Check 3: Can retrieve content from the destination
+		if dest != nil && destProviderID != "" {
+			content, err := dest.GetContent(ctx, destProviderID)
+			if err == nil && content != nil && content.Body != "" {
+				retrievableCount++
+
+				// Check 4: Retrieved content hash matches recorded hash
+				computedHash := ContentHash(content.Body)
+				if computedHash != destHash {
+					results = append(results, Result{
+						Name:        fmt.Sprintf("ContentHashMismatch_%s", shortID(docUUID)),
+						Passed:      false,
+						Message:     fmt.Sprintf("Computed hash doesn't match stored hash for %s", shortID(docUUID)),
+						ExpectedVal: destHash,
+						ActualVal:   computedHash,
+					})
+				}
+			}
+		}
+	}
+
+	// Overall content integrity results
+	results = append(results, Result{
+		Name:        "AllContentMatchFlagsTrue",
+		Passed:      contentMatchCount == itemCount,
+		Message:     "All migration items should have content_match = true",
+		ExpectedVal: itemCount,
+		ActualVal:   contentMatchCount,
+	})
+
+	results = append(results, Result{
+		Name:        "AllHashesMatch",
+		Passed:      hashMatchCount == itemCount,
+		Message:     "All source and destination hashes should match",
+		ExpectedVal: itemCount,
+		ActualVal:   hashMatchCount,
+	})
+
+	if dest != nil {
+		results = append(results, Result{
+			Name:        "AllDocumentsRetrievable",
+			Passed:      retrievableCount == itemCount,
+			Message:     "All migrated documents should be retrievable from the destination",
+			ExpectedVal: itemCount,
+			ActualVal:   retrievableCount,
+		})
+	}
+
+	return results
+}
+
+// ValidateOutboxIntegrity verifies the transactional outbox pattern worked correctly.
+//
+// Strong signals:
+// - Every migration item has exactly one outbox event
+// - All outbox events have unique idempotent keys
+// - No duplicate processing (same key published twice)
+// - All events have valid payload structure
+func (v *Validator) ValidateOutboxIntegrity(ctx context.Context, jobID int64) []Result {
+	results := []Result{}
+
+	v.logger.Info("validating outbox integrity", "job_id", jobID)
+
+	// Check 1: Count migration items
+	var itemCount int
+	err := v.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM migration_items WHERE migration_job_id = $1
+	`, jobID).Scan(&itemCount)
+
+	if err != nil {
+		results = append(results, Result{
+			Name:        "CountMigrationItems",
+			Passed:      false,
+			Message:     "Failed to count migration items",
+			ExpectedVal: nil,
+			ActualVal:   err.Error(),
+		})
+		return results
+	}
+
+	// Check 2: Count outbox events
+	var outboxCount int
+	err = v.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM migration_outbox WHERE migration_job_id = $1
+	`, jobID).Scan(&outboxCount)
+
+	results = append(results, Result{
+		Name:        "OneOutboxEventPerItem",
+		Passed:      err == nil && outboxCount == itemCount,
+		Message:     "Should have exactly one outbox event per migration item",
+		ExpectedVal: itemCount,
+		ActualVal:   outboxCount,
+	})
+
+	// Check 3: All idempotent keys are unique
+	var uniqueKeys, totalKeys int
+	err = v.db.QueryRowContext(ctx, `
+		SELECT COUNT(DISTINCT idempotent_key), COUNT(*)
+		FROM migration_outbox
+		WHERE migration_job_id = $1
+	`, jobID).Scan(&uniqueKeys, &totalKeys)
+
+	results = append(results, Result{
+		Name:        "AllIdempotentKeysUnique",
+		Passed:      err == nil && uniqueKeys == totalKeys,
+		Message:     "All idempotent keys should be unique (no duplicates)",
+		ExpectedVal: totalKeys,
+		ActualVal:   uniqueKeys,
+	})
+
+	// Check 4: No duplicate processing (check publish_attempts)
+	var maxAttempts int
+	var avgAttempts float64
+	err = v.db.QueryRowContext(ctx, `
+		SELECT COALESCE(MAX(publish_attempts), 0), COALESCE(AVG(publish_attempts), 0)
+		FROM migration_outbox
+		WHERE migration_job_id = $1
+	`, jobID).Scan(&maxAttempts, &avgAttempts)
+
+	results = append(results, Result{
+		Name:        "ReasonablePublishAttempts",
+		Passed:      err == nil && maxAttempts <= 3,
+		Message:     "Publish attempts should be reasonable (max 3)",
+		ExpectedVal: "≤ 3",
+		ActualVal:   fmt.Sprintf("max=%d, avg=%.2f", maxAttempts, avgAttempts),
+	})
+
+	// Check 5: All payloads are valid JSON
+	rows, err := v.db.QueryContext(ctx, `
+		SELECT id, payload::text
+		FROM migration_outbox
+		WHERE migration_job_id = $1
+	`, jobID)
+	if err == nil {
+		defer rows.Close()
+		invalidPayloads := 0
+		for rows.Next() {
+			var id int64
+			var payload string
+			if err := rows.Scan(&id, &payload); err == nil {
+				if payload == "" || payload == "{}" {
+					invalidPayloads++
+				}
+			}
+		}
+
+		results = append(results, Result{
+			Name:        "AllPayloadsValid",
+			Passed:      invalidPayloads == 0,
+			Message:     "All outbox payloads should be valid and non-empty",
+			ExpectedVal: 0,
+			ActualVal:   invalidPayloads,
+		})
+	}
+
+	return results
+}
+
+// ValidateMigrationInvariants checks critical invariants that must hold for a valid migration.
+//
+// Strong signals:
+// - No data loss: source document count = destination document count
+// - No duplication: destination has unique documents only
+// - Referential integrity: all foreign keys are valid
+// - State consistency: job progress matches item statuses
+func (v *Validator) ValidateMigrationInvariants(ctx context.Context, jobID int64, sourceDocCount int) []Result {
+	results := []Result{}
+
+	v.logger.Info("validating migration invariants", "job_id", jobID)
+
+	// Invariant 1: No data loss
+	var completedItems int
+	err := v.db.QueryRowContext(ctx, `
+		SELECT COUNT(*)
+		FROM migration_items
+		WHERE migration_job_id = $1 AND status = 'completed'
+	`, jobID).Scan(&completedItems)
+
+	results = append(results, Result{
+		Name:        "NoDataLoss",
+		Passed:      err == nil && completedItems == sourceDocCount,
+		Message:     "All source documents should be migrated",
+		ExpectedVal: sourceDocCount,
+		ActualVal:   completedItems,
+	})
+
+	// Invariant 2: No duplication (all document UUIDs unique)
+	var uniqueUUIDs, totalUUIDs int
+	err = v.db.QueryRowContext(ctx, `
+		SELECT COUNT(DISTINCT document_uuid), COUNT(*)
+		FROM migration_items
+		WHERE migration_job_id = $1
+	`, jobID).Scan(&uniqueUUIDs, &totalUUIDs)
+
+	results = append(results, Result{
+		Name:        "NoDuplication",
+		Passed:      err == nil && uniqueUUIDs == totalUUIDs,
+		Message:     "All document UUIDs should be unique (no duplicates)",
+		ExpectedVal: totalUUIDs,
+		ActualVal:   uniqueUUIDs,
+	})
+
+	// Invariant 3: Referential integrity (all items reference valid job)
+	var orphanedItems int
+	err = v.db.QueryRowContext(ctx, `
+		SELECT COUNT(*)
+		FROM migration_items mi
+		LEFT JOIN migration_jobs mj ON mi.migration_job_id = mj.id
+		WHERE mi.migration_job_id = $1 AND mj.id IS NULL
+	`, jobID).Scan(&orphanedItems)
+
+	results = append(results, Result{
+		Name:        "ReferentialIntegrity",
+		Passed:      err == nil && orphanedItems == 0,
+		Message:     "All migration items should reference valid job",
+		ExpectedVal: 0,
+		ActualVal:   orphanedItems,
+	})
+
+	// Invariant 4: State consistency (job counters match item counts)
+	var jobMigrated, itemMigrated int
+	v.db.QueryRowContext(ctx, `SELECT migrated_documents FROM migration_jobs WHERE id = $1`, jobID).Scan(&jobMigrated)
+	v.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM migration_items WHERE migration_job_id = $1 AND status = 'completed'`, jobID).Scan(&itemMigrated)
+
+	results = append(results, Result{
+		Name:        "StateConsistency",
+		Passed:      jobMigrated == itemMigrated,
+		Message:     "Job migrated_documents should match completed item count",
+		ExpectedVal: itemMigrated,
+		ActualVal:   jobMigrated,
+	})
+
+	// Invariant 5: Monotonic progress (migrated + failed + skipped ≤ total)
+	var total, migrated, failed, skipped int
+	err = v.db.QueryRowContext(ctx, `
+		SELECT total_documents, migrated_documents, failed_documents, skipped_documents
+		FROM migration_jobs WHERE id = $1
+	`, jobID).Scan(&total, &migrated, &failed, &skipped)
+
+	sum := migrated + failed + skipped
+	results = append(results, Result{
+		Name:        "MonotonicProgress",
+		Passed:      err == nil && sum <= total,
+		Message:     "Sum of processed documents should not exceed total",
+		ExpectedVal: fmt.Sprintf("≤ %d", total),
+		ActualVal:   sum,
+	})
+
+	return results
+}
+
+// WriteReport writes a human-readable validation report.
+func WriteReport(w io.Writer, results []Result) (passed, failed int) {
+	rule := strings.Repeat("=", 72)
+
+	fmt.Fprintln(w, rule)
+	fmt.Fprintln(w, "  MIGRATION VALIDATION REPORT")
+	fmt.Fprintln(w, rule)
+
+	for _, result := range results {
+		if result.Passed {
+			passed++
+			fmt.Fprintf(w, "✅ PASS: %s\n", result.Name)
+			fmt.Fprintf(w, "         %s\n", result.Message)
+		} else {
+			failed++
+			fmt.Fprintf(w, "❌ FAIL: %s\n", result.Name)
+			fmt.Fprintf(w, "         %s\n", result.Message)
+			fmt.Fprintf(w, "         Expected: %v\n", result.ExpectedVal)
+			fmt.Fprintf(w, "         Actual:   %v\n", result.ActualVal)
+		}
+	}
+
+	fmt.Fprintln(w, rule)
+	fmt.Fprintf(w, "  SUMMARY: %d passed, %d failed, %d total\n", passed, failed, len(results))
+	fmt.Fprintln(w, rule)
+
+	return passed, failed
+}
+
+// ContentHash returns the SHA-256 hex digest used for migration content
+// hashes.
+func ContentHash(content string) string {
+	h := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(h[:])
+}
+
+// shortID returns the first 8 characters of an ID for display.
+func shortID(id string) string {
+	if len(id) > 8 {
+		return id[:8]
+	}
+	return id
+}
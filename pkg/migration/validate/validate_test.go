@@ -0,0 +1,52 @@
+package validate
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReport(t *testing.T) {
+	assert := assert.New(t)
+
+	r := &Report{
+		Results: []Result{
+			{Name: "JobExists", Passed: true},
+			{Name: "NoDataLoss", Passed: false, ExpectedVal: 3, ActualVal: 2},
+		},
+	}
+	assert.False(r.Passed())
+	assert.Len(r.Failed(), 1)
+	assert.Equal("NoDataLoss", r.Failed()[0].Name)
+
+	r.Results[1].Passed = true
+	assert.True(r.Passed())
+	assert.Empty(r.Failed())
+}
+
+func TestWriteReport(t *testing.T) {
+	assert := assert.New(t)
+
+	var buf bytes.Buffer
+	passed, failed := WriteReport(&buf, []Result{
+		{Name: "JobExists", Passed: true, Message: "Job should exist"},
+		{Name: "NoDataLoss", Passed: false, Message: "All migrated",
+			ExpectedVal: 3, ActualVal: 2},
+	})
+
+	assert.Equal(1, passed)
+	assert.Equal(1, failed)
+	assert.Contains(buf.String(), "PASS: JobExists")
+	assert.Contains(buf.String(), "FAIL: NoDataLoss")
+	assert.Contains(buf.String(), "Expected: 3")
+	assert.Contains(buf.String(), "SUMMARY: 1 passed, 1 failed, 2 total")
+}
+
+func TestContentHash(t *testing.T) {
+	assert.Equal(t,
+		"e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		ContentHash(""))
+	assert.Equal(t, "abc", shortID("abc"))
+	assert.Equal(t, "12345678", shortID("1234567890"))
+}
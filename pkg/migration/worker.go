@@ -4,9 +4,11 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/hashicorp-forge/hermes/pkg/mdconv"
 	"github.com/hashicorp-forge/hermes/pkg/workspace"
 	"github.com/hashicorp/go-hclog"
 )
@@ -19,12 +21,27 @@ type Worker struct {
 	logger         hclog.Logger
 	pollInterval   time.Duration
 	maxConcurrency int
+	repointHooks   []RepointHook
 }
 
+// Default worker configuration.
+const (
+	DefaultWorkerPollInterval   = 5 * time.Second
+	DefaultWorkerMaxConcurrency = 5
+)
+
 // WorkerConfig contains worker configuration
 type WorkerConfig struct {
 	PollInterval   time.Duration
 	MaxConcurrency int
+
+	// RepointHooks are called after a migrated document is repointed to its
+	// destination provider (e.g., to invalidate caches and reindex).
+	RepointHooks []RepointHook
+
+	// Notifier, if set, publishes job lifecycle events (started, halfway,
+	// item failed, completed) for operators.
+	Notifier Notifier
 }
 
 // NewWorker creates a new migration worker
@@ -34,18 +51,22 @@ func NewWorker(db *sql.DB, providerMap map[string]workspace.WorkspaceProvider, l
 	}
 	if cfg == nil {
 		cfg = &WorkerConfig{
-			PollInterval:   5 * time.Second,
-			MaxConcurrency: 5,
+			PollInterval:   DefaultWorkerPollInterval,
+			MaxConcurrency: DefaultWorkerMaxConcurrency,
 		}
 	}
 
+	manager := NewManager(db, logger)
+	manager.SetNotifier(cfg.Notifier)
+
 	return &Worker{
 		db:             db,
 		providerMap:    providerMap,
-		manager:        NewManager(db, logger),
+		manager:        manager,
 		logger:         logger.Named("migration-worker"),
 		pollInterval:   cfg.PollInterval,
 		maxConcurrency: cfg.MaxConcurrency,
+		repointHooks:   cfg.RepointHooks,
 	}
 }
 
@@ -67,6 +88,12 @@ func (w *Worker) Start(ctx context.Context) error {
 			if err := w.processPendingTasks(ctx); err != nil {
 				w.logger.Error("failed to process pending tasks", "error", err)
 			}
+			if err := w.processSourceRemovals(ctx); err != nil {
+				w.logger.Error("failed to process source removals", "error", err)
+			}
+			if err := w.processRollbacks(ctx); err != nil {
+				w.logger.Error("failed to process rollbacks", "error", err)
+			}
 		}
 	}
 }
@@ -220,10 +247,21 @@ func (w *Worker) processTask(ctx context.Context, itemID int64, payloadJSON stri
 		return err
 	}
 
-	// Update duration
+	// Update duration and content hashes so they appear in the document's
+	// migration history.
+	var sourceHash, destHash *string
+	if validationResult != nil {
+		sh := normalizeContentHash(validationResult.SourceHash)
+		dh := normalizeContentHash(validationResult.DestHash)
+		sourceHash, destHash = &sh, &dh
+	}
 	_, _ = w.db.ExecContext(ctx, `
-		UPDATE migration_items SET duration_ms = $1 WHERE id = $2
-	`, duration, itemID)
+		UPDATE migration_items
+		SET duration_ms = $1,
+			source_content_hash = COALESCE($2, source_content_hash),
+			dest_content_hash = COALESCE($3, dest_content_hash)
+		WHERE id = $4
+	`, duration, sourceHash, destHash, itemID)
 
 	w.logger.Info("document migrated successfully",
 		"item_id", itemID,
@@ -231,6 +269,53 @@ func (w *Worker) processTask(ctx context.Context, itemID int64, payloadJSON stri
 		"dest_provider_id", destProviderID,
 		"duration_ms", duration)
 
+	if shouldRepoint(&payload, validationResult) {
+		// A failed repoint doesn't fail the migration item, since the document
+		// has already been migrated. Moves keep their source until the document
+		// points at the destination copy (see removeSource).
+		if err := w.repoint(ctx, &RepointEvent{
+			JobID:            payload.JobID,
+			ItemID:           itemID,
+			DocumentUUID:     payload.DocumentUUID,
+			SourceProvider:   payload.SourceProvider,
+			SourceProviderID: payload.SourceProviderID,
+			DestProvider:     payload.DestProvider,
+			DestProviderID:   destProviderID,
+		}); err != nil {
+			if errors.Is(err, ErrDocumentNotFound) {
+				w.logger.Warn("no document to repoint after migration",
+					"item_id", itemID,
+					"document_uuid", payload.DocumentUUID)
+			} else {
+				w.logger.Error("failed to repoint document after migration",
+					"item_id", itemID,
+					"document_uuid", payload.DocumentUUID,
+					"error", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// repoint updates the document's canonical provider reference to
+// ev.DestProviderID and runs repoint hooks. An error is returned if the
+// document couldn't be repointed; hook failures are logged but not returned,
+// since the document already points at its new location.
+func (w *Worker) repoint(ctx context.Context, ev *RepointEvent) error {
+	if err := w.manager.RepointDocument(ctx, ev); err != nil {
+		return err
+	}
+
+	for _, hook := range w.repointHooks {
+		if err := hook(ctx, ev); err != nil {
+			w.logger.Error("repoint hook failed",
+				"item_id", ev.ItemID,
+				"document_uuid", ev.DocumentUUID,
+				"error", err)
+		}
+	}
+
 	return nil
 }
 
@@ -264,8 +349,9 @@ func (w *Worker) migrateDocument(ctx context.Context, source, dest workspace.Wor
 
 	var validationResult *ValidationResult
 
-	// Validate if requested
-	if payload.Validate {
+	// Validate if requested. Moves are always validated, since the source is
+	// removed once the copy is verified.
+	if payload.Validate || payload.Strategy == StrategyMove {
 		destContent, err := dest.GetContent(ctx, destDoc.ProviderID)
 		if err != nil {
 			w.logger.Warn("validation failed - could not read dest content", "error", err)
@@ -273,16 +359,14 @@ func (w *Worker) migrateDocument(ctx context.Context, source, dest workspace.Wor
 			validationStart := time.Now()
 
 			// Normalize hashes by stripping "sha256:" prefix if present
-			sourceHash := sourceContent.ContentHash
-			destHash := destContent.ContentHash
-			if len(sourceHash) > 7 && sourceHash[:7] == "sha256:" {
-				sourceHash = sourceHash[7:]
-			}
-			if len(destHash) > 7 && destHash[:7] == "sha256:" {
-				destHash = destHash[7:]
-			}
-
-			match := sourceHash == destHash
+			sourceHash := normalizeContentHash(sourceContent.ContentHash)
+			destHash := normalizeContentHash(destContent.ContentHash)
+
+			// Providers store content in different formats (e.g., Google Docs
+			// content is exported as normalized Markdown), so content that
+			// doesn't hash the same still matches if it normalizes the same.
+			match := sourceHash == destHash ||
+				mdconv.Equal(sourceContent.Body, destContent.Body)
 			bytesDiff := len(sourceContent.Body) - len(destContent.Body)
 			if bytesDiff < 0 {
 				bytesDiff = -bytesDiff
@@ -297,7 +381,7 @@ func (w *Worker) migrateDocument(ctx context.Context, source, dest workspace.Wor
 			}
 
 			if !match {
-				w.logger.Warn("content validation failed - hashes don't match",
+				w.logger.Warn("content validation failed - content doesn't match",
 					"source_hash", sourceContent.ContentHash,
 					"dest_hash", destContent.ContentHash,
 					"normalized_source", sourceHash,
@@ -306,11 +390,18 @@ func (w *Worker) migrateDocument(ctx context.Context, source, dest workspace.Wor
 		}
 	}
 
-	// Handle move strategy - delete from source
+	// Handle move strategy - schedule removal of the source once the soak
+	// period has elapsed. The source is only removed if the copy is verified.
 	if payload.Strategy == StrategyMove {
-		if err := source.DeleteDocument(ctx, payload.SourceProviderID); err != nil {
-			w.logger.Error("failed to delete source document after move", "error", err)
-			// Don't fail the migration - document is already in dest
+		if validationResult == nil || !validationResult.Match {
+			_ = dest.DeleteDocument(ctx, destDoc.ProviderID)
+			return "", nil, fmt.Errorf("move requires a verified copy: content does not match")
+		}
+
+		removeAfter := time.Now().Add(time.Duration(payload.SoakPeriodSeconds) * time.Second)
+		if err := w.manager.ScheduleSourceRemoval(ctx, payload, sourceDoc.Name, removeAfter); err != nil {
+			_ = dest.DeleteDocument(ctx, destDoc.ProviderID)
+			return "", nil, fmt.Errorf("failed to schedule source removal: %w", err)
 		}
 	}
 
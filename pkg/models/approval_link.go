@@ -0,0 +1,130 @@
+package models
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"gorm.io/gorm"
+)
+
+// Approval link actions.
+const (
+	// ApprovalLinkActionApprove approves the document.
+	ApprovalLinkActionApprove = "approve"
+
+	// ApprovalLinkActionRequestChanges requests changes of the document.
+	ApprovalLinkActionRequestChanges = "request-changes"
+)
+
+// ErrApprovalLinkUnavailable is returned when claiming an approval link that
+// was already used or has expired.
+var ErrApprovalLinkUnavailable = errors.New("approval link was used or expired")
+
+// ApprovalLink is a single-use link in a review request email that lets the
+// approver approve a document or request changes without opening Hermes. Only
+// the hash of the link's token is stored.
+type ApprovalLink struct {
+	ID uint `gorm:"primaryKey"`
+
+	// TokenHash is the SHA-256 hash of the link's token.
+	TokenHash string `gorm:"type:varchar(64);not null;uniqueIndex"`
+
+	// DocumentID is the document to review.
+	DocumentID uint `gorm:"not null;index"`
+	Document   Document
+
+	// ApproverEmail is the email address of the approver the link was sent to,
+	// who the action is recorded as.
+	ApproverEmail string `gorm:"type:varchar(320);not null"`
+
+	// ExpiresAt is when the link expires.
+	ExpiresAt time.Time `gorm:"not null;index"`
+
+	// UsedAt is when the link was used, or nil if it wasn't.
+	UsedAt *time.Time
+
+	// Action is the action the link was used for.
+	Action string `gorm:"type:varchar(20)"`
+
+	CreatedAt time.Time `gorm:"not null"`
+}
+
+// TableName specifies the table name.
+func (ApprovalLink) TableName() string {
+	return "approval_links"
+}
+
+// Create creates the link in database db, and returns its token.
+func (l *ApprovalLink) Create(db *gorm.DB) (string, error) {
+	if err := validation.ValidateStruct(l,
+		validation.Field(&l.DocumentID, validation.Required),
+		validation.Field(&l.ApproverEmail, validation.Required),
+		validation.Field(&l.ExpiresAt, validation.Required),
+	); err != nil {
+		return "", err
+	}
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("error generating token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+
+	l.TokenHash = HashToken(token)
+	if err := db.Omit("Document").Create(l).Error; err != nil {
+		return "", fmt.Errorf("error creating approval link: %w", err)
+	}
+	return token, nil
+}
+
+// GetByToken gets the link with the token from database db, including its
+// document, and assigns it to the receiver.
+func (l *ApprovalLink) GetByToken(db *gorm.DB, token string) error {
+	return db.
+		Preload("Document").
+		First(l, "token_hash = ?", HashToken(token)).
+		Error
+}
+
+// IsUsable returns true if the link hasn't been used and hasn't expired at
+// time t.
+func (l ApprovalLink) IsUsable(t time.Time) bool {
+	return l.UsedAt == nil && t.Before(l.ExpiresAt)
+}
+
+// Claim marks the link as used for action in database db. It returns
+// ErrApprovalLinkUnavailable if the link was already used or has expired, so
+// concurrent requests can't use a link twice.
+func (l *ApprovalLink) Claim(db *gorm.DB, action string) error {
+	now := time.Now()
+	res := db.Model(&ApprovalLink{}).
+		Where("id = ? AND used_at IS NULL AND expires_at > ?", l.ID, now).
+		Updates(map[string]any{"used_at": now, "action": action})
+	if res.Error != nil {
+		return fmt.Errorf("error claiming approval link: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return ErrApprovalLinkUnavailable
+	}
+	l.UsedAt = &now
+	l.Action = action
+	return nil
+}
+
+// Release undoes claiming the link in database db, e.g., if the action
+// failed, so it can be used again until it expires.
+func (l *ApprovalLink) Release(db *gorm.DB) error {
+	if err := db.Model(&ApprovalLink{}).
+		Where("id = ?", l.ID).
+		Updates(map[string]any{"used_at": nil, "action": ""}).
+		Error; err != nil {
+		return fmt.Errorf("error releasing approval link: %w", err)
+	}
+	l.UsedAt = nil
+	l.Action = ""
+	return nil
+}
@@ -0,0 +1,96 @@
+package models
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApprovalLinkIsUsable(t *testing.T) {
+	now := time.Now()
+	used := now.Add(-time.Minute)
+
+	assert.True(t, ApprovalLink{ExpiresAt: now.Add(time.Hour)}.IsUsable(now))
+	assert.False(t, ApprovalLink{ExpiresAt: now}.IsUsable(now))
+	assert.False(t, ApprovalLink{
+		ExpiresAt: now.Add(time.Hour),
+		UsedAt:    &used,
+	}.IsUsable(now))
+}
+
+func TestApprovalLinkModel(t *testing.T) {
+	dsn := os.Getenv("HERMES_TEST_POSTGRESQL_DSN")
+	if dsn == "" {
+		t.Skip("HERMES_TEST_POSTGRESQL_DSN environment variable isn't set")
+	}
+
+	db, tearDownTest := setupTest(t, dsn)
+	defer tearDownTest(t)
+
+	require.NoError(t, (&DocumentType{Name: "DT1", LongName: "DocumentType1"}).
+		FirstOrCreate(db))
+	require.NoError(t, (&Product{Name: "Product1", Abbreviation: "P1"}).
+		FirstOrCreate(db))
+
+	d := Document{
+		GoogleFileID: "fileID1",
+		DocumentType: DocumentType{Name: "DT1"},
+		Owner:        &User{EmailAddress: "owner@example.com"},
+		Product:      Product{Name: "Product1"},
+	}
+	require.NoError(t, d.Create(db))
+
+	t.Run("Create validates fields", func(t *testing.T) {
+		_, err := (&ApprovalLink{
+			DocumentID: d.ID,
+			ExpiresAt:  time.Now().Add(time.Hour),
+		}).Create(db)
+		assert.Error(t, err)
+	})
+
+	t.Run("Create, get, claim, and release", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+
+		l := ApprovalLink{
+			DocumentID:    d.ID,
+			ApproverEmail: "approver@example.com",
+			ExpiresAt:     time.Now().Add(time.Hour),
+		}
+		token, err := l.Create(db)
+		require.NoError(err)
+		assert.NotEmpty(token)
+		assert.NotEqual(token, l.TokenHash)
+
+		var got ApprovalLink
+		require.NoError(got.GetByToken(db, token))
+		assert.Equal(l.ID, got.ID)
+		assert.Equal("fileID1", got.Document.GoogleFileID)
+		assert.Error((&ApprovalLink{}).GetByToken(db, "unknown"))
+
+		require.NoError(got.Claim(db, ApprovalLinkActionApprove))
+		assert.ErrorIs(
+			got.Claim(db, ApprovalLinkActionApprove), ErrApprovalLinkUnavailable)
+
+		require.NoError(got.Release(db))
+		require.NoError(got.Claim(db, ApprovalLinkActionRequestChanges))
+
+		require.NoError(got.GetByToken(db, token))
+		assert.NotNil(got.UsedAt)
+		assert.Equal(ApprovalLinkActionRequestChanges, got.Action)
+	})
+
+	t.Run("Expired links can't be claimed", func(t *testing.T) {
+		l := ApprovalLink{
+			DocumentID:    d.ID,
+			ApproverEmail: "approver@example.com",
+			ExpiresAt:     time.Now().Add(-time.Minute),
+		}
+		_, err := l.Create(db)
+		require.NoError(t, err)
+		assert.ErrorIs(t,
+			l.Claim(db, ApprovalLinkActionApprove), ErrApprovalLinkUnavailable)
+	})
+}
@@ -0,0 +1,157 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"gorm.io/gorm"
+)
+
+// AuditEvent records a mutating API request for compliance review. Audit
+// events are append-only; there are intentionally no methods to update or
+// delete them.
+type AuditEvent struct {
+	ID uint64 `gorm:"primaryKey" json:"id"`
+
+	// Actor is the email address of the user who made the request.
+	Actor string `gorm:"type:varchar(320);not null;index:idx_audit_events_actor,priority:1" json:"actor"`
+
+	// Impersonator is the email address of the admin who made the request
+	// while impersonating Actor, or empty if the request was not made during
+	// an impersonation session.
+	Impersonator string `gorm:"type:varchar(320);not null;default:''" json:"impersonator,omitempty"`
+
+	// RequestID correlates the event with server logs and is returned to the
+	// client in the X-Request-ID response header.
+	RequestID string `gorm:"type:varchar(128);not null;index" json:"requestId"`
+
+	// Action is the operation performed (e.g., "documents.update").
+	Action     string `gorm:"type:varchar(255);not null" json:"action"`
+	Method     string `gorm:"type:varchar(10);not null" json:"method"`
+	Path       string `gorm:"type:text;not null" json:"path"`
+	StatusCode int    `gorm:"not null" json:"statusCode"`
+
+	// TargetType and TargetID identify the resource the request targeted.
+	// TargetID is empty if it could not be determined.
+	TargetType string `gorm:"type:varchar(64);not null;index:idx_audit_events_target,priority:1" json:"targetType"`
+	TargetID   string `gorm:"type:varchar(255);not null;default:'';index:idx_audit_events_target,priority:2" json:"targetId"`
+
+	// Before and After are snapshots of the target's key fields before and
+	// after the request, or null if the target did not exist.
+	Before JSON `gorm:"type:jsonb" json:"before"`
+	After  JSON `gorm:"type:jsonb" json:"after"`
+
+	CreatedAt time.Time `gorm:"not null;index;index:idx_audit_events_actor,priority:2;index:idx_audit_events_target,priority:3" json:"createdAt"`
+}
+
+// AuditEvents is a slice of audit events.
+type AuditEvents []AuditEvent
+
+// AuditEventFilter filters audit events. Empty fields are not filtered on.
+type AuditEventFilter struct {
+	Actor      string
+	Action     string
+	TargetType string
+	TargetID   string
+	RequestID  string
+
+	// Since and Until limit events to those created in [Since, Until).
+	Since time.Time
+	Until time.Time
+
+	// BeforeID limits events to those with an ID less than BeforeID, for
+	// paging through results.
+	BeforeID uint64
+
+	// Limit is the maximum number of events to return.
+	Limit int
+}
+
+// TableName specifies the table name.
+func (AuditEvent) TableName() string {
+	return "audit_events"
+}
+
+// Create appends the audit event to database db.
+func (e *AuditEvent) Create(db *gorm.DB) error {
+	if err := validation.ValidateStruct(e,
+		validation.Field(&e.Actor, validation.Required),
+		validation.Field(&e.RequestID, validation.Required),
+		validation.Field(&e.Action, validation.Required),
+		validation.Field(&e.Method, validation.Required),
+		validation.Field(&e.TargetType, validation.Required),
+	); err != nil {
+		return err
+	}
+
+	if err := db.Create(e).Error; err != nil {
+		return fmt.Errorf("error creating audit event: %w", err)
+	}
+	return nil
+}
+
+// Find finds audit events matching filter f, newest first.
+func (e *AuditEvents) Find(db *gorm.DB, f AuditEventFilter) error {
+	q := db.Model(&AuditEvent{})
+	if f.Actor != "" {
+		q = q.Where("actor = ?", f.Actor)
+	}
+	if f.Action != "" {
+		q = q.Where("action = ?", f.Action)
+	}
+	if f.TargetType != "" {
+		q = q.Where("target_type = ?", f.TargetType)
+	}
+	if f.TargetID != "" {
+		q = q.Where("target_id = ?", f.TargetID)
+	}
+	if f.RequestID != "" {
+		q = q.Where("request_id = ?", f.RequestID)
+	}
+	if !f.Since.IsZero() {
+		q = q.Where("created_at >= ?", f.Since)
+	}
+	if !f.Until.IsZero() {
+		q = q.Where("created_at < ?", f.Until)
+	}
+	if f.BeforeID > 0 {
+		q = q.Where("id < ?", f.BeforeID)
+	}
+	if f.Limit > 0 {
+		q = q.Limit(f.Limit)
+	}
+
+	return q.
+		Order("id DESC").
+		Find(e).
+		Error
+}
+
+// FindAfter finds up to limit events with target type targetType and an ID
+// greater than afterID in database db, oldest first, for following new
+// events.
+func (e *AuditEvents) FindAfter(
+	db *gorm.DB, targetType string, afterID uint64, limit int,
+) error {
+	return db.
+		Where("target_type = ? AND id > ?", targetType, afterID).
+		Order("id").
+		Limit(limit).
+		Find(e).
+		Error
+}
+
+// LastAuditEventID returns the ID of the newest audit event in database db,
+// or 0 if there are no events.
+func LastAuditEventID(db *gorm.DB) (uint64, error) {
+	var id uint64
+	if err := db.
+		Model(&AuditEvent{}).
+		Select("COALESCE(MAX(id), 0)").
+		Scan(&id).
+		Error; err != nil {
+		return 0, fmt.Errorf("error getting last audit event ID: %w", err)
+	}
+	return id, nil
+}
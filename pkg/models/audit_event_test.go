@@ -0,0 +1,131 @@
+package models
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditEventModel(t *testing.T) {
+	dsn := os.Getenv("HERMES_TEST_POSTGRESQL_DSN")
+	if dsn == "" {
+		t.Skip("HERMES_TEST_POSTGRESQL_DSN environment variable isn't set")
+	}
+
+	db, tearDownTest := setupTest(t, dsn)
+	defer tearDownTest(t)
+
+	t.Run("Create requires actor and action", func(t *testing.T) {
+		e := AuditEvent{
+			RequestID:  "req-0",
+			Method:     "POST",
+			TargetType: "document",
+		}
+		assert.Error(t, e.Create(db))
+	})
+
+	t.Run("Create and find with filters", func(t *testing.T) {
+		events := []AuditEvent{
+			{
+				Actor:      "alice@example.com",
+				RequestID:  "req-1",
+				Action:     "drafts.create",
+				Method:     "POST",
+				Path:       "/api/v2/drafts",
+				StatusCode: 200,
+				TargetType: "document",
+				TargetID:   "doc1",
+				After:      JSON(`{"title":"One"}`),
+			},
+			{
+				Actor:      "bob@example.com",
+				RequestID:  "req-2",
+				Action:     "approvals.create",
+				Method:     "POST",
+				Path:       "/api/v2/approvals/doc1",
+				StatusCode: 200,
+				TargetType: "document",
+				TargetID:   "doc1",
+			},
+			{
+				Actor:      "alice@example.com",
+				RequestID:  "req-3",
+				Action:     "projects.update",
+				Method:     "PATCH",
+				Path:       "/api/v2/projects/1",
+				StatusCode: 403,
+				TargetType: "project",
+				TargetID:   "1",
+			},
+		}
+		for i := range events {
+			require.NoError(t, events[i].Create(db))
+		}
+
+		var got AuditEvents
+		require.NoError(t, got.Find(db, AuditEventFilter{
+			TargetType: "document",
+			TargetID:   "doc1",
+		}))
+		require.Len(t, got, 2)
+		assert.Equal(t, "req-2", got[0].RequestID)
+		assert.Equal(t, "req-1", got[1].RequestID)
+		assert.JSONEq(t, `{"title":"One"}`, got[1].After.String())
+
+		require.NoError(t, got.Find(db, AuditEventFilter{
+			Actor: "alice@example.com",
+			Limit: 1,
+		}))
+		require.Len(t, got, 1)
+		assert.Equal(t, "req-3", got[0].RequestID)
+
+		require.NoError(t, got.Find(db, AuditEventFilter{
+			Actor:    "alice@example.com",
+			BeforeID: got[0].ID,
+		}))
+		require.Len(t, got, 1)
+		assert.Equal(t, "req-1", got[0].RequestID)
+
+		require.NoError(t, got.Find(db, AuditEventFilter{
+			Until: time.Now().Add(-time.Hour),
+		}))
+		assert.Empty(t, got)
+	})
+	t.Run("FindAfter and LastAuditEventID", func(t *testing.T) {
+		last, err := LastAuditEventID(db)
+		require.NoError(t, err)
+		require.NotZero(t, last)
+
+		e := AuditEvent{
+			Actor:      "carol@example.com",
+			RequestID:  "req-4",
+			Action:     "reviews.create",
+			Method:     "POST",
+			Path:       "/api/v2/reviews/doc2",
+			StatusCode: 200,
+			TargetType: "document",
+			TargetID:   "doc2",
+		}
+		require.NoError(t, e.Create(db))
+
+		got, err := LastAuditEventID(db)
+		require.NoError(t, err)
+		assert.Equal(t, e.ID, got)
+
+		var events AuditEvents
+		require.NoError(t, events.FindAfter(db, "document", 0, 10))
+		require.Len(t, events, 3)
+		assert.Equal(t, "req-1", events[0].RequestID)
+		assert.Equal(t, "req-4", events[2].RequestID)
+
+		require.NoError(t, events.FindAfter(db, "document", last, 10))
+		require.Len(t, events, 1)
+		assert.Equal(t, "req-4", events[0].RequestID)
+
+		require.NoError(t, events.FindAfter(db, "project", last, 10))
+		assert.Empty(t, events)
+	})
+}
@@ -50,6 +50,10 @@ type Document struct {
 	// document.
 	ApproverGroups []*Group `gorm:"many2many:document_group_reviews;"`
 
+	// ApprovedAt is the time the document was approved, or nil if it hasn't
+	// been approved.
+	ApprovedAt *time.Time `gorm:"index:idx_documents_approved_at"`
+
 	// Contributors are users who have contributed to the document.
 	Contributors []*User `gorm:"many2many:document_contributors;"`
 
@@ -91,6 +95,28 @@ type Document struct {
 	// RelatedResources are the related resources for the document.
 	RelatedResources []*DocumentRelatedResource
 
+	// RetentionAppliedAt is the time the retention policy RetentionPolicy was
+	// applied to the document, or nil if no policy has been applied.
+	RetentionAppliedAt *time.Time
+
+	// RetentionNoticeSentAt is the time the owner of the document was notified
+	// that the retention policy RetentionPolicy will be applied to it, or nil
+	// if they haven't been.
+	RetentionNoticeSentAt *time.Time
+
+	// RetentionPolicy is the name of the retention policy that the owner of
+	// the document was notified about or that was applied to it.
+	RetentionPolicy string `gorm:"type:varchar(100)"`
+
+	// ReviewSLABreachedAt is the time the review of the document breached the
+	// review SLA of its document type, or nil if it hasn't.
+	ReviewSLABreachedAt *time.Time
+
+	// ReviewSLAWarnedAt is the time the owner and reviewers of the document
+	// were warned that its review was about to breach the review SLA, or nil
+	// if they haven't been.
+	ReviewSLAWarnedAt *time.Time
+
 	// Status is the status of the document.
 	Status DocumentStatus
 
@@ -100,11 +126,24 @@ type Document struct {
 	// Summary is a summary of the document.
 	Summary *string
 
+	// TenantID is the ID of the tenant the document belongs to. It's zero for
+	// documents of the default tenant, which is the only tenant of deployments
+	// without tenants. Document numbers are unique per tenant.
+	TenantID uint `gorm:"not null;default:0;index"`
+
 	// Title is the title of the document. It only contains the title, and not the
 	// product abbreviation, document number, or document type.
 	Title string
+
+	// Version is incremented by every Upsert of the document, for optimistic
+	// locking.
+	Version int `gorm:"not null;default:1"`
 }
 
+// ErrDocumentVersionConflict is returned by Document.Upsert when the document
+// was updated since it was read.
+var ErrDocumentVersionConflict = errors.New("document was updated concurrently")
+
 // Documents is a slice of documents.
 type Documents []Document
 
@@ -170,7 +209,8 @@ func (d *Document) Create(db *gorm.DB) error {
 	})
 }
 
-// Delete deletes a document in database db.
+// Delete soft deletes a document in database db. Deleted documents can be
+// restored with Restore until they are purged.
 func (d *Document) Delete(db *gorm.DB) error {
 	if err := validation.ValidateStruct(d,
 		validation.Field(
@@ -196,6 +236,114 @@ func (d *Document) Delete(db *gorm.DB) error {
 		Error
 }
 
+// Restore restores a soft-deleted document in database db by Google file ID,
+// and assigns it to the receiver. It returns gorm.ErrRecordNotFound if there
+// is no deleted document with the Google file ID.
+func (d *Document) Restore(db *gorm.DB) error {
+	if err := validation.ValidateStruct(d,
+		validation.Field(&d.GoogleFileID, validation.Required),
+	); err != nil {
+		return err
+	}
+
+	res := db.
+		Unscoped().
+		Model(&Document{}).
+		Where("google_file_id = ? AND deleted_at IS NOT NULL", d.GoogleFileID).
+		Update("deleted_at", nil)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return d.Get(db)
+}
+
+// Purge permanently deletes a document, deleted or not, in database db by
+// Google file ID, with its related resources and the related resources of
+// other documents and projects that link to it.
+func (d *Document) Purge(db *gorm.DB) error {
+	if err := validation.ValidateStruct(d,
+		validation.Field(&d.GoogleFileID, validation.Required),
+	); err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		doc := Document{}
+		if err := tx.
+			Unscoped().
+			Where("google_file_id = ?", d.GoogleFileID).
+			First(&doc).
+			Error; err != nil {
+			return err
+		}
+
+		if err := doc.ReplaceRelatedResources(tx, nil, nil); err != nil {
+			return err
+		}
+
+		// Links to the document don't cascade.
+		for _, rr := range []struct{ table, typedTable string }{
+			{"document_related_resources", "document_related_resource_hermes_documents"},
+			{"project_related_resources", "project_related_resource_hermes_documents"},
+		} {
+			if err := tx.Exec(fmt.Sprintf(
+				`DELETE FROM %q WHERE related_resource_type = ? AND
+					related_resource_id IN (SELECT id FROM %q WHERE document_id = ?)`,
+				rr.table, rr.typedTable), rr.typedTable, doc.ID).
+				Error; err != nil {
+				return fmt.Errorf("error deleting links to document: %w", err)
+			}
+			if err := tx.Exec(fmt.Sprintf(
+				`DELETE FROM %q WHERE document_id = ?`, rr.typedTable), doc.ID).
+				Error; err != nil {
+				return fmt.Errorf("error deleting links to document: %w", err)
+			}
+		}
+
+		if err := tx.
+			Where("document_id = ?", doc.ID).
+			Delete(&RecentlyViewedDoc{}).
+			Error; err != nil {
+			return fmt.Errorf("error deleting recently viewed documents: %w", err)
+		}
+		if err := tx.
+			Where("document_id = ?", doc.ID).
+			Delete(&UserActivity{}).
+			Error; err != nil {
+			return fmt.Errorf("error deleting user activities: %w", err)
+		}
+		if err := tx.
+			Unscoped().
+			Select(clause.Associations).
+			Delete(&doc).
+			Error; err != nil {
+			return fmt.Errorf("error deleting document: %w", err)
+		}
+		return nil
+	})
+}
+
+// FindDeleted finds the soft-deleted documents from database db with the
+// provided query, most recently deleted first, and assigns them to the
+// receiver.
+func (d *Documents) FindDeleted(
+	db *gorm.DB, query interface{}, queryArgs ...interface{}) error {
+
+	return db.
+		Unscoped().
+		Where("documents.deleted_at IS NOT NULL").
+		Where(query, queryArgs...).
+		Preload("DocumentType").
+		Preload("Owner").
+		Preload("Product").
+		Order("documents.deleted_at DESC").
+		Find(&d).Error
+}
+
 // Find finds all documents from database db with the provided query, and
 // assigns them to the receiver.
 func (d *Documents) Find(
@@ -254,8 +402,9 @@ func (d *Document) Get(db *gorm.DB) error {
 	return nil
 }
 
-// GetLatestProductNumber gets the latest document number for a product.
-func GetLatestProductNumber(db *gorm.DB,
+// GetLatestProductNumber gets the latest document number for a product and
+// document type in a tenant (zero for the default tenant).
+func GetLatestProductNumber(db *gorm.DB, tenantID uint,
 	documentTypeName, productName string) (int, error) {
 	// Validate required fields.
 	if err := validation.Validate(db, validation.Required); err != nil {
@@ -288,6 +437,7 @@ func GetLatestProductNumber(db *gorm.DB,
 			DocumentTypeID: dt.ID,
 			ProductID:      p.ID,
 		}).
+		Where("tenant_id = ?", tenantID).
 		Where("document_number IS NOT NULL").
 		Order("document_number desc").
 		First(&d).
@@ -494,7 +644,10 @@ func (d *Document) GetRelatedResources(db *gorm.DB) (
 	return
 }
 
-// Upsert updates or inserts the receiver document into database db.
+// Upsert updates or inserts the receiver document into database db. If the
+// receiver was read from the database, it returns ErrDocumentVersionConflict
+// when the document was updated since; documents with a zero Version are
+// updated unconditionally.
 func (d *Document) Upsert(db *gorm.DB) error {
 	if err := validation.ValidateStruct(d,
 		validation.Field(
@@ -519,6 +672,36 @@ func (d *Document) Upsert(db *gorm.DB) error {
 	}
 
 	return db.Transaction(func(tx *gorm.DB) error {
+		// Claim the next version of the document, which locks it until the
+		// transaction ends. If the document was read (its version isn't zero),
+		// fail if it was updated since.
+		existing := tx.
+			Model(&Document{}).
+			Where("google_file_id = ?", d.GoogleFileID)
+		claim := existing.Session(&gorm.Session{})
+		if d.Version != 0 {
+			claim = claim.Where("version = ?", d.Version)
+		}
+		res := claim.UpdateColumn("version", gorm.Expr("version + 1"))
+		if res.Error != nil {
+			return fmt.Errorf("error updating document version: %w", res.Error)
+		}
+		if res.RowsAffected == 0 {
+			var count int64
+			if err := existing.Session(&gorm.Session{}).Count(&count).Error; err != nil {
+				return fmt.Errorf("error checking document version: %w", err)
+			}
+			if count > 0 {
+				return ErrDocumentVersionConflict
+			}
+			d.Version = 1
+		} else if err := existing.Session(&gorm.Session{}).
+			Select("version").
+			Scan(&d.Version).
+			Error; err != nil {
+			return fmt.Errorf("error getting document version: %w", err)
+		}
+
 		if err := tx.
 			Model(&d).
 			Where(Document{GoogleFileID: d.GoogleFileID}).
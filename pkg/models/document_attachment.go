@@ -0,0 +1,113 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DocumentAttachment is a file (e.g., a PDF, presentation, or image) attached
+// to a document. The file is stored by the workspace provider, and the text
+// extracted from it is included in the search document content of the
+// document.
+type DocumentAttachment struct {
+	ID        uint      `gorm:"primaryKey"`
+	CreatedAt time.Time `gorm:"not null"`
+	UpdatedAt time.Time `gorm:"not null"`
+
+	// DocumentID is the document the file is attached to.
+	DocumentID uint `gorm:"not null;uniqueIndex:idx_document_attachments_document_name"`
+	Document   Document
+
+	// Name is the file name, unique per document.
+	Name string `gorm:"type:varchar(255);not null;uniqueIndex:idx_document_attachments_document_name"`
+
+	// ContentType is the media type of the file (e.g., "application/pdf").
+	ContentType string `gorm:"type:varchar(255)"`
+
+	// Size is the size of the file in bytes.
+	Size int64 `gorm:"not null;default:0"`
+
+	// ContentHash is the SHA-256 hash of the file.
+	ContentHash string `gorm:"type:varchar(64)"`
+
+	// Indexed is true if the text of the file is included in search.
+	Indexed bool `gorm:"not null"`
+
+	// Text is the text extracted from the file.
+	Text string `gorm:"type:text"`
+
+	// ExtractionError is why no text was extracted from the file (e.g., the
+	// file type isn't supported or the file is too large), if any.
+	ExtractionError string `gorm:"type:text"`
+
+	// UploadedBy is the user who attached the file.
+	UploadedBy   *User `gorm:"default:null"`
+	UploadedByID *uint `gorm:"default:null"`
+}
+
+// DocumentAttachments is a slice of document attachments.
+type DocumentAttachments []DocumentAttachment
+
+// TableName specifies the table name.
+func (DocumentAttachment) TableName() string {
+	return "document_attachments"
+}
+
+// Upsert creates or updates the attachment with the same document and name.
+func (a *DocumentAttachment) Upsert(db *gorm.DB) error {
+	if a.DocumentID == 0 || a.Name == "" {
+		return fmt.Errorf("document ID and name are required")
+	}
+
+	return db.
+		Omit(clause.Associations).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "document_id"}, {Name: "name"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"updated_at", "content_type", "size", "content_hash", "indexed",
+				"text", "extraction_error", "uploaded_by_id",
+			}),
+		}).
+		Create(a).
+		Error
+}
+
+// Get gets the attachment with the document ID and name of the receiver.
+func (a *DocumentAttachment) Get(db *gorm.DB) error {
+	if a.DocumentID == 0 || a.Name == "" {
+		return fmt.Errorf("document ID and name are required")
+	}
+
+	return db.
+		Where("document_id = ? AND name = ?", a.DocumentID, a.Name).
+		Preload("UploadedBy").
+		First(a).
+		Error
+}
+
+// Delete deletes the attachment with the ID of the receiver.
+func (a *DocumentAttachment) Delete(db *gorm.DB) error {
+	if a.ID == 0 {
+		return fmt.Errorf("ID is required")
+	}
+
+	return db.Delete(&DocumentAttachment{}, a.ID).Error
+}
+
+// Find finds the attachments of the document with the provided ID, ordered by
+// name.
+func (a *DocumentAttachments) Find(db *gorm.DB, documentID uint) error {
+	if documentID == 0 {
+		return fmt.Errorf("document ID is required")
+	}
+
+	return db.
+		Where("document_id = ?", documentID).
+		Preload("UploadedBy").
+		Order("name").
+		Find(a).
+		Error
+}
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocumentAttachmentModel(t *testing.T) {
+	dsn := os.Getenv("HERMES_TEST_POSTGRESQL_DSN")
+	if dsn == "" {
+		t.Skip("HERMES_TEST_POSTGRESQL_DSN environment variable isn't set")
+	}
+
+	db, tearDownTest := setupTest(t, dsn)
+	defer tearDownTest(t)
+
+	require.NoError(t, (&DocumentType{Name: "DT1", LongName: "DocumentType1"}).
+		FirstOrCreate(db))
+	require.NoError(t, (&Product{Name: "Product1", Abbreviation: "P1"}).
+		FirstOrCreate(db))
+
+	d := Document{
+		GoogleFileID: "fileID1",
+		DocumentType: DocumentType{Name: "DT1"},
+		Owner:        &User{EmailAddress: "owner@example.com"},
+		Product:      Product{Name: "Product1"},
+	}
+	require.NoError(t, d.Create(db))
+
+	t.Run("Attach files", func(t *testing.T) {
+		require.NoError(t, (&DocumentAttachment{
+			DocumentID:  d.ID,
+			Name:        "plan.pdf",
+			ContentType: "application/pdf",
+			Size:        100,
+			Indexed:     true,
+			Text:        "Plan",
+		}).Upsert(db))
+		require.NoError(t, (&DocumentAttachment{
+			DocumentID:      d.ID,
+			Name:            "app.exe",
+			Indexed:         true,
+			ExtractionError: "unsupported attachment type",
+		}).Upsert(db))
+
+		var atts DocumentAttachments
+		require.NoError(t, atts.Find(db, d.ID))
+		require.Len(t, atts, 2)
+		assert.Equal(t, "app.exe", atts[0].Name)
+		assert.Equal(t, "plan.pdf", atts[1].Name)
+		assert.Equal(t, "Plan", atts[1].Text)
+	})
+
+	t.Run("Replace a file", func(t *testing.T) {
+		require.NoError(t, (&DocumentAttachment{
+			DocumentID: d.ID,
+			Name:       "plan.pdf",
+			Size:       200,
+			Indexed:    false,
+			Text:       "Plan v2",
+		}).Upsert(db))
+
+		a := DocumentAttachment{DocumentID: d.ID, Name: "plan.pdf"}
+		require.NoError(t, a.Get(db))
+		assert.EqualValues(t, 200, a.Size)
+		assert.False(t, a.Indexed)
+		assert.Equal(t, "Plan v2", a.Text)
+
+		require.NoError(t, a.Delete(db))
+		var atts DocumentAttachments
+		require.NoError(t, atts.Find(db, d.ID))
+		assert.Len(t, atts, 1)
+	})
+}
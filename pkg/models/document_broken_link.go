@@ -0,0 +1,118 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DocumentBrokenLink is an outbound link in a document's content that was
+// broken when it was last checked by the link check job.
+type DocumentBrokenLink struct {
+	ID uint `gorm:"primaryKey" json:"id"`
+
+	// DocumentID is the document containing the link.
+	DocumentID uint     `gorm:"not null;uniqueIndex:idx_document_broken_links_document_url" json:"-"`
+	Document   Document `json:"-"`
+
+	// URL is the link as written in the document.
+	URL string `gorm:"type:text;not null;uniqueIndex:idx_document_broken_links_document_url" json:"url"`
+
+	// LinkType is how the link was checked (e.g., "document", "shortlink",
+	// "external").
+	LinkType string `gorm:"type:varchar(20);not null" json:"linkType"`
+
+	// StatusCode is the HTTP status of the last check, or 0 if the request
+	// failed or the link is internal.
+	StatusCode int `gorm:"not null;default:0" json:"statusCode"`
+
+	// Error describes why the link is broken.
+	Error string `gorm:"type:text" json:"error,omitempty"`
+
+	// FirstDetectedAt is when the link was first found to be broken.
+	FirstDetectedAt time.Time `gorm:"not null" json:"firstDetectedAt"`
+
+	// LastCheckedAt is when the link was last checked.
+	LastCheckedAt time.Time `gorm:"not null" json:"lastCheckedAt"`
+}
+
+// DocumentBrokenLinks is a slice of document broken links.
+type DocumentBrokenLinks []DocumentBrokenLink
+
+// TableName specifies the table name.
+func (DocumentBrokenLink) TableName() string {
+	return "document_broken_links"
+}
+
+// ReplaceDocumentBrokenLinks records the result of checking a document's links.
+// Links in broken are inserted or updated (keeping when they were first
+// detected). Existing records for other URLs are deleted unless the URL is in
+// unchecked, which lists links that are still in the document but were not
+// checked this time.
+func ReplaceDocumentBrokenLinks(
+	db *gorm.DB,
+	documentID uint,
+	broken []DocumentBrokenLink,
+	unchecked []string,
+) error {
+	if documentID == 0 {
+		return fmt.Errorf("document ID is required")
+	}
+
+	keep := append([]string{}, unchecked...)
+	for i := range broken {
+		broken[i].DocumentID = documentID
+		if broken[i].FirstDetectedAt.IsZero() {
+			broken[i].FirstDetectedAt = broken[i].LastCheckedAt
+		}
+		keep = append(keep, broken[i].URL)
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		del := tx.Where("document_id = ?", documentID)
+		if len(keep) > 0 {
+			del = del.Where("url NOT IN ?", keep)
+		}
+		if err := del.Delete(&DocumentBrokenLink{}).Error; err != nil {
+			return fmt.Errorf("error deleting fixed links: %w", err)
+		}
+
+		if len(broken) == 0 {
+			return nil
+		}
+		if err := tx.
+			Omit(clause.Associations).
+			Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: "document_id"}, {Name: "url"}},
+				DoUpdates: clause.AssignmentColumns([]string{
+					"link_type", "status_code", "error", "last_checked_at",
+				}),
+			}).
+			Create(&broken).
+			Error; err != nil {
+			return fmt.Errorf("error upserting broken links: %w", err)
+		}
+		return nil
+	})
+}
+
+// FindForOwner finds all broken links in documents owned by the user with the
+// provided email address, ordered by document and URL. Documents are
+// preloaded.
+func (l *DocumentBrokenLinks) FindForOwner(db *gorm.DB, email string) error {
+	if email == "" {
+		return fmt.Errorf("email is required")
+	}
+
+	return db.
+		Joins("JOIN documents ON documents.id = document_broken_links.document_id "+
+			"AND documents.deleted_at IS NULL").
+		Joins("JOIN users ON users.id = documents.owner_id").
+		Where("users.email_address = ?", email).
+		Preload("Document").
+		Order("document_broken_links.document_id, document_broken_links.url").
+		Find(l).
+		Error
+}
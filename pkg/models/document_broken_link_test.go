@@ -0,0 +1,79 @@
+package models
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocumentBrokenLinkModel(t *testing.T) {
+	dsn := os.Getenv("HERMES_TEST_POSTGRESQL_DSN")
+	if dsn == "" {
+		t.Skip("HERMES_TEST_POSTGRESQL_DSN environment variable isn't set")
+	}
+
+	db, tearDownTest := setupTest(t, dsn)
+	defer tearDownTest(t)
+
+	require.NoError(t, (&DocumentType{Name: "DT1", LongName: "DocumentType1"}).
+		FirstOrCreate(db))
+	require.NoError(t, (&Product{Name: "Product1", Abbreviation: "P1"}).
+		FirstOrCreate(db))
+
+	d := Document{
+		GoogleFileID: "fileID1",
+		DocumentType: DocumentType{Name: "DT1"},
+		Owner:        &User{EmailAddress: "owner@example.com"},
+		Product:      Product{Name: "Product1"},
+	}
+	require.NoError(t, d.Create(db))
+
+	t0 := time.Now().UTC().Truncate(time.Second)
+	t1 := t0.Add(time.Hour)
+
+	t.Run("Record broken links", func(t *testing.T) {
+		err := ReplaceDocumentBrokenLinks(db, d.ID, []DocumentBrokenLink{
+			{URL: "https://a.example.com", LinkType: "external", StatusCode: 404, LastCheckedAt: t0},
+			{URL: "/document/missing", LinkType: "document", Error: "document not found", LastCheckedAt: t0},
+		}, nil)
+		require.NoError(t, err)
+
+		var links DocumentBrokenLinks
+		require.NoError(t, links.FindForOwner(db, "owner@example.com"))
+		require.Len(t, links, 2)
+		assert.Equal(t, "/document/missing", links[0].URL)
+		assert.Equal(t, "fileID1", links[0].Document.GoogleFileID)
+		assert.True(t, t0.Equal(links[1].FirstDetectedAt))
+	})
+
+	t.Run("Fixed links are removed and unchecked links are kept", func(t *testing.T) {
+		err := ReplaceDocumentBrokenLinks(db, d.ID, []DocumentBrokenLink{
+			{URL: "https://a.example.com", LinkType: "external", StatusCode: 410, LastCheckedAt: t1},
+			{URL: "https://b.example.com", LinkType: "external", StatusCode: 500, LastCheckedAt: t1},
+		}, []string{"/document/missing"})
+		require.NoError(t, err)
+
+		var links DocumentBrokenLinks
+		require.NoError(t, links.FindForOwner(db, "owner@example.com"))
+		require.Len(t, links, 3)
+
+		a := links[1]
+		assert.Equal(t, "https://a.example.com", a.URL)
+		assert.Equal(t, 410, a.StatusCode)
+		assert.True(t, t0.Equal(a.FirstDetectedAt), "first detection is kept")
+		assert.True(t, t1.Equal(a.LastCheckedAt))
+
+		require.NoError(t, ReplaceDocumentBrokenLinks(db, d.ID, nil, nil))
+		require.NoError(t, links.FindForOwner(db, "owner@example.com"))
+		assert.Empty(t, links)
+	})
+
+	t.Run("Other owners see nothing", func(t *testing.T) {
+		var links DocumentBrokenLinks
+		require.NoError(t, links.FindForOwner(db, "other@example.com"))
+		assert.Empty(t, links)
+	})
+}
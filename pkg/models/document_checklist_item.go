@@ -0,0 +1,101 @@
+package models
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DocumentChecklistItem is the completion of an item of the review checklist
+// of a document's type (see DocumentTypeWorkflow.ReviewChecklist). Items that
+// were never checked have no record.
+type DocumentChecklistItem struct {
+	ID        uint      `gorm:"primaryKey" json:"-"`
+	CreatedAt time.Time `gorm:"not null" json:"-"`
+	UpdatedAt time.Time `gorm:"not null" json:"updatedAt"`
+
+	// DocumentID is the ID of the document.
+	DocumentID uint     `gorm:"not null;uniqueIndex:idx_document_checklist_items_document_name" json:"-"`
+	Document   Document `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+
+	// Name is the name of the checklist item of the document type.
+	Name string `gorm:"type:varchar(255);not null;uniqueIndex:idx_document_checklist_items_document_name" json:"name"`
+
+	// Checked is true if the item is checked.
+	Checked bool `gorm:"not null;default:false" json:"checked"`
+
+	// CheckedByEmail is the email address of the user who checked the item, or
+	// empty if it isn't checked.
+	CheckedByEmail string `gorm:"type:varchar(320)" json:"checkedBy,omitempty"`
+
+	// CheckedAt is when the item was checked, or nil if it isn't checked.
+	CheckedAt *time.Time `json:"checkedAt,omitempty"`
+}
+
+// DocumentChecklistItems is a slice of document checklist items.
+type DocumentChecklistItems []DocumentChecklistItem
+
+// TableName specifies the table name.
+func (DocumentChecklistItem) TableName() string {
+	return "document_checklist_items"
+}
+
+// Upsert checks or unchecks the item in database db. CheckedByEmail is
+// required to check an item; CheckedByEmail and CheckedAt are cleared when an
+// item is unchecked.
+func (i *DocumentChecklistItem) Upsert(db *gorm.DB) error {
+	if err := validation.ValidateStruct(i,
+		validation.Field(&i.DocumentID, validation.Required),
+		validation.Field(&i.Name, validation.Required),
+		validation.Field(&i.CheckedByEmail,
+			validation.When(i.Checked, validation.Required)),
+	); err != nil {
+		return err
+	}
+
+	if i.Checked {
+		if i.CheckedAt == nil {
+			now := time.Now()
+			i.CheckedAt = &now
+		}
+	} else {
+		i.CheckedByEmail = ""
+		i.CheckedAt = nil
+	}
+
+	return db.
+		Omit(clause.Associations).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "document_id"}, {Name: "name"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"updated_at", "checked", "checked_by_email", "checked_at",
+			}),
+		}).
+		Create(i).
+		Error
+}
+
+// FindByDocument finds the checklist items of the document with the provided
+// ID, ordered by name.
+func (l *DocumentChecklistItems) FindByDocument(
+	db *gorm.DB, documentID uint,
+) error {
+	return db.
+		Where("document_id = ?", documentID).
+		Order("name").
+		Find(l).
+		Error
+}
+
+// Checked returns the names of the checked items.
+func (l DocumentChecklistItems) Checked() map[string]bool {
+	checked := map[string]bool{}
+	for _, i := range l {
+		if i.Checked {
+			checked[i.Name] = true
+		}
+	}
+	return checked
+}
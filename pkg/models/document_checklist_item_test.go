@@ -0,0 +1,79 @@
+package models
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocumentChecklistItemModel(t *testing.T) {
+	dsn := os.Getenv("HERMES_TEST_POSTGRESQL_DSN")
+	if dsn == "" {
+		t.Skip("HERMES_TEST_POSTGRESQL_DSN environment variable isn't set")
+	}
+
+	db, tearDownTest := setupTest(t, dsn)
+	defer tearDownTest(t)
+
+	require.NoError(t, (&DocumentType{Name: "DT1", LongName: "DocumentType1"}).
+		FirstOrCreate(db))
+	require.NoError(t, (&Product{Name: "Product1", Abbreviation: "P1"}).
+		FirstOrCreate(db))
+
+	d := Document{
+		GoogleFileID: "fileID1",
+		DocumentType: DocumentType{Name: "DT1"},
+		Owner:        &User{EmailAddress: "owner@example.com"},
+		Product:      Product{Name: "Product1"},
+	}
+	require.NoError(t, d.Create(db))
+
+	t.Run("Check items", func(t *testing.T) {
+		require.NoError(t, (&DocumentChecklistItem{
+			DocumentID:     d.ID,
+			Name:           "security-review",
+			Checked:        true,
+			CheckedByEmail: "owner@example.com",
+		}).Upsert(db))
+		require.NoError(t, (&DocumentChecklistItem{
+			DocumentID:     d.ID,
+			Name:           "legal",
+			Checked:        true,
+			CheckedByEmail: "legal@example.com",
+		}).Upsert(db))
+
+		var items DocumentChecklistItems
+		require.NoError(t, items.FindByDocument(db, d.ID))
+		require.Len(t, items, 2)
+		assert.Equal(t, "legal", items[0].Name)
+		assert.Equal(t, "legal@example.com", items[0].CheckedByEmail)
+		assert.NotNil(t, items[0].CheckedAt)
+		assert.Equal(t, map[string]bool{"legal": true, "security-review": true},
+			items.Checked())
+	})
+
+	t.Run("Uncheck an item", func(t *testing.T) {
+		require.NoError(t, (&DocumentChecklistItem{
+			DocumentID: d.ID,
+			Name:       "legal",
+		}).Upsert(db))
+
+		var items DocumentChecklistItems
+		require.NoError(t, items.FindByDocument(db, d.ID))
+		require.Len(t, items, 2)
+		assert.False(t, items[0].Checked)
+		assert.Empty(t, items[0].CheckedByEmail)
+		assert.Nil(t, items[0].CheckedAt)
+		assert.Equal(t, map[string]bool{"security-review": true}, items.Checked())
+	})
+
+	t.Run("Checking an item requires the user", func(t *testing.T) {
+		assert.Error(t, (&DocumentChecklistItem{
+			DocumentID: d.ID,
+			Name:       "legal",
+			Checked:    true,
+		}).Upsert(db))
+	})
+}
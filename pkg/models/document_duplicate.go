@@ -0,0 +1,117 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DocumentDuplicate is a pair of documents whose content is so similar that
+// one is likely a copy of the other, e.g., a document created by copying
+// another document instead of using the document type's template. Pairs are
+// stored once, with the lower document ID first.
+type DocumentDuplicate struct {
+	ID uint `gorm:"primaryKey"`
+
+	DocumentID uint `gorm:"not null;uniqueIndex:idx_document_duplicates_pair"`
+	Document   Document
+
+	DuplicateID uint     `gorm:"not null;uniqueIndex:idx_document_duplicates_pair;index"`
+	Duplicate   Document `gorm:"foreignKey:DuplicateID"`
+
+	// Similarity is the estimated similarity of the content of the documents,
+	// from 0 to 1.
+	Similarity float64 `gorm:"not null"`
+
+	// DetectedAt is when the similarity was estimated.
+	DetectedAt time.Time `gorm:"not null"`
+}
+
+// DocumentDuplicates is a slice of document duplicates.
+type DocumentDuplicates []DocumentDuplicate
+
+// DocumentDuplicateFilter filters document duplicates.
+type DocumentDuplicateFilter struct {
+	// MinSimilarity is the minimum similarity of the documents.
+	MinSimilarity float64
+
+	// CrossProduct only includes documents of different products.
+	CrossProduct bool
+
+	// Limit is the maximum number of duplicates (default: no limit).
+	Limit int
+}
+
+// TableName specifies the table name.
+func (DocumentDuplicate) TableName() string {
+	return "document_duplicates"
+}
+
+// ReplaceDocumentDuplicates replaces the duplicates of a document with
+// duplicates, e.g., after its content was fingerprinted again. The DocumentID
+// of duplicates is set to documentID.
+func ReplaceDocumentDuplicates(
+	db *gorm.DB, documentID uint, duplicates []DocumentDuplicate,
+) error {
+	if documentID == 0 {
+		return fmt.Errorf("document ID is required")
+	}
+	for i := range duplicates {
+		duplicates[i].ID = 0
+		duplicates[i].DocumentID = documentID
+		if duplicates[i].DuplicateID < documentID {
+			duplicates[i].DocumentID, duplicates[i].DuplicateID =
+				duplicates[i].DuplicateID, documentID
+		}
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.
+			Where("document_id = ? OR duplicate_id = ?", documentID, documentID).
+			Delete(&DocumentDuplicate{}).
+			Error; err != nil {
+			return fmt.Errorf("error deleting duplicates: %w", err)
+		}
+
+		if len(duplicates) == 0 {
+			return nil
+		}
+		if err := tx.
+			Omit(clause.Associations).
+			Create(&duplicates).
+			Error; err != nil {
+			return fmt.Errorf("error creating duplicates: %w", err)
+		}
+		return nil
+	})
+}
+
+// Find finds the duplicates of documents that aren't deleted that match
+// filter, most similar first, with the documents, their products, document
+// types, and owners.
+func (d *DocumentDuplicates) Find(db *gorm.DB, filter DocumentDuplicateFilter) error {
+	q := db.
+		Joins("JOIN documents docs ON docs.id = document_duplicates.document_id").
+		Joins("JOIN documents dups ON dups.id = document_duplicates.duplicate_id").
+		Where("docs.deleted_at IS NULL AND dups.deleted_at IS NULL").
+		Where("document_duplicates.similarity >= ?", filter.MinSimilarity)
+	if filter.CrossProduct {
+		q = q.Where("docs.product_id <> dups.product_id")
+	}
+	if filter.Limit > 0 {
+		q = q.Limit(filter.Limit)
+	}
+
+	return q.
+		Preload("Document.DocumentType").
+		Preload("Document.Owner").
+		Preload("Document.Product").
+		Preload("Duplicate.DocumentType").
+		Preload("Duplicate.Owner").
+		Preload("Duplicate.Product").
+		Order("document_duplicates.similarity DESC, document_duplicates.id").
+		Find(d).
+		Error
+}
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DocumentFingerprint is the MinHash signature of a document's content, used
+// by the duplicate detection indexer step to find near-duplicate documents
+// without fetching their content.
+type DocumentFingerprint struct {
+	ID uint `gorm:"primaryKey"`
+
+	// DocumentID is the fingerprinted document.
+	DocumentID uint `gorm:"not null;uniqueIndex"`
+	Document   Document
+
+	// Signature is the MinHash signature of the content, or empty if the
+	// content is too short to be compared.
+	Signature []uint32 `gorm:"serializer:json;type:jsonb"`
+
+	// Shingles is the number of distinct word shingles of the content.
+	Shingles int `gorm:"not null;default:0"`
+
+	// ContentHash is the hash of the fingerprinted content.
+	ContentHash string `gorm:"type:varchar(64)"`
+
+	// FingerprintedAt is when the content was fingerprinted.
+	FingerprintedAt time.Time `gorm:"not null"`
+}
+
+// DocumentFingerprints is a slice of document fingerprints.
+type DocumentFingerprints []DocumentFingerprint
+
+// TableName specifies the table name.
+func (DocumentFingerprint) TableName() string {
+	return "document_fingerprints"
+}
+
+// Upsert creates or updates the fingerprint of the document with the document
+// ID of the receiver.
+func (f *DocumentFingerprint) Upsert(db *gorm.DB) error {
+	if f.DocumentID == 0 {
+		return fmt.Errorf("document ID is required")
+	}
+
+	return db.
+		Omit(clause.Associations).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "document_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"signature", "shingles", "content_hash", "fingerprinted_at",
+			}),
+		}).
+		Create(f).
+		Error
+}
+
+// Get gets the fingerprint of the document with the document ID of the
+// receiver.
+func (f *DocumentFingerprint) Get(db *gorm.DB) error {
+	if f.DocumentID == 0 {
+		return fmt.Errorf("document ID is required")
+	}
+
+	return db.
+		Where("document_id = ?", f.DocumentID).
+		First(f).
+		Error
+}
+
+// FindComparable finds the fingerprints with signatures of documents that
+// aren't deleted, other than the document with ID exceptDocumentID.
+func (f *DocumentFingerprints) FindComparable(db *gorm.DB, exceptDocumentID uint) error {
+	return db.
+		Joins("JOIN documents ON documents.id = document_fingerprints.document_id").
+		Where("documents.deleted_at IS NULL").
+		Where("document_fingerprints.document_id <> ?", exceptDocumentID).
+		Where("document_fingerprints.signature IS NOT NULL").
+		Order("document_fingerprints.document_id").
+		Find(f).
+		Error
+}
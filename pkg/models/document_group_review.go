@@ -18,6 +18,10 @@ type DocumentGroupReview struct {
 	Document   Document
 	GroupID    uint `gorm:"primaryKey"`
 	Group      Group
+
+	// RequiredApprovals is the number of distinct group members who must
+	// approve for the group's review to be satisfied (N-of-M).
+	RequiredApprovals uint `gorm:"not null;default:1"`
 }
 
 // DocumentReviews is a slice of document reviews.
@@ -0,0 +1,106 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DocumentGroupReviewApproval is an approval that counts toward a group
+// review. Member is the group member the approval counts for; Approver is the
+// user who approved, which is the member or someone the member delegated their
+// review to.
+type DocumentGroupReviewApproval struct {
+	ID uint `gorm:"primaryKey"`
+
+	DocumentID uint `gorm:"not null;uniqueIndex:idx_document_group_review_approvals_member"`
+	Document   Document
+	GroupID    uint `gorm:"not null;uniqueIndex:idx_document_group_review_approvals_member"`
+	Group      Group
+
+	// MemberID is the group member the approval counts for.
+	MemberID uint `gorm:"not null;uniqueIndex:idx_document_group_review_approvals_member"`
+	Member   User
+
+	// ApproverID is the user who approved.
+	ApproverID uint `gorm:"not null"`
+	Approver   User
+
+	ApprovedAt time.Time `gorm:"not null"`
+}
+
+// DocumentGroupReviewApprovals is a slice of document group review approvals.
+type DocumentGroupReviewApprovals []DocumentGroupReviewApproval
+
+// TableName specifies the table name.
+func (DocumentGroupReviewApproval) TableName() string {
+	return "document_group_review_approvals"
+}
+
+// Create records the approval in database db. The document, group, member, and
+// approver are looked up by Google file ID and email addresses. Approvals
+// already recorded for the member are left unchanged.
+func (a *DocumentGroupReviewApproval) Create(db *gorm.DB) error {
+	if err := a.Document.Get(db); err != nil {
+		return fmt.Errorf("error getting document: %w", err)
+	}
+	a.DocumentID = a.Document.ID
+
+	if err := a.Group.Get(db); err != nil {
+		return fmt.Errorf("error getting group: %w", err)
+	}
+	a.GroupID = a.Group.ID
+
+	if err := a.Member.FirstOrCreate(db); err != nil {
+		return fmt.Errorf("error getting member: %w", err)
+	}
+	a.MemberID = a.Member.ID
+
+	if err := a.Approver.FirstOrCreate(db); err != nil {
+		return fmt.Errorf("error getting approver: %w", err)
+	}
+	a.ApproverID = a.Approver.ID
+
+	if a.ApprovedAt.IsZero() {
+		a.ApprovedAt = time.Now()
+	}
+
+	return db.
+		Omit(clause.Associations).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(a).
+		Error
+}
+
+// Find finds all group review approvals for the document with the provided
+// ID, ordered by approval time. Groups, members, and approvers are preloaded.
+func (a *DocumentGroupReviewApprovals) Find(db *gorm.DB, documentID uint) error {
+	return db.
+		Where("document_id = ?", documentID).
+		Preload("Group").
+		Preload("Member").
+		Preload("Approver").
+		Order("approved_at, id").
+		Find(a).
+		Error
+}
+
+// DeleteDocumentGroupReviewApprovalsByApprover deletes the group review
+// approvals made by a user for the document with the provided Google file ID
+// (e.g., when they request changes).
+func DeleteDocumentGroupReviewApprovalsByApprover(
+	db *gorm.DB, googleFileID, approverEmail string,
+) error {
+	return db.
+		Where("document_id IN (?) AND approver_id IN (?)",
+			db.Model(&Document{}).
+				Select("id").
+				Where("google_file_id = ?", googleFileID),
+			db.Model(&User{}).
+				Select("id").
+				Where("email_address = ?", approverEmail)).
+		Delete(&DocumentGroupReviewApproval{}).
+		Error
+}
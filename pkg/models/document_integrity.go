@@ -0,0 +1,165 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// DocumentIntegrityKindContent is the integrity record of a document's
+	// content.
+	DocumentIntegrityKindContent = "content"
+
+	// DocumentIntegrityKindAttachment is the integrity record of a file
+	// attached to a document.
+	DocumentIntegrityKindAttachment = "attachment"
+)
+
+const (
+	// DocumentIntegrityStatusOK means the hash of the stored object matched
+	// the expected hash when it was last checked.
+	DocumentIntegrityStatusOK = "ok"
+
+	// DocumentIntegrityStatusDrift means the hash of the stored object didn't
+	// match the expected hash.
+	DocumentIntegrityStatusDrift = "drift"
+
+	// DocumentIntegrityStatusMissing means the stored object wasn't found.
+	DocumentIntegrityStatusMissing = "missing"
+)
+
+// DocumentIntegrity is the result of the last integrity check of an object
+// (the content or an attached file) of a document stored by a workspace
+// provider. The integrity job recomputes the hash of the object and compares
+// it to the hash recorded when the object was written.
+type DocumentIntegrity struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `gorm:"not null" json:"createdAt"`
+	UpdatedAt time.Time `gorm:"not null" json:"updatedAt"`
+
+	// DocumentID is the document of the object.
+	DocumentID uint     `gorm:"not null;uniqueIndex:idx_document_integrity_document_object" json:"-"`
+	Document   Document `json:"-"`
+
+	// Kind is "content" or "attachment".
+	Kind string `gorm:"type:varchar(20);not null;uniqueIndex:idx_document_integrity_document_object" json:"kind"`
+
+	// Name is the file name of an attachment, or empty for content.
+	Name string `gorm:"type:varchar(255);not null;default:'';uniqueIndex:idx_document_integrity_document_object" json:"name,omitempty"`
+
+	// ProviderID is the provider ID of the document when it was checked.
+	ProviderID string `gorm:"type:varchar(500);not null" json:"providerId"`
+
+	// ExpectedHash is the hash recorded when the object was written (e.g.,
+	// "sha256:<hex>").
+	ExpectedHash string `gorm:"type:varchar(100)" json:"expectedHash"`
+
+	// ActualHash is the hash of the object when it was last checked, or empty
+	// if it's missing.
+	ActualHash string `gorm:"type:varchar(100)" json:"actualHash,omitempty"`
+
+	// ModifiedAt is when the provider last modified the object, as of the
+	// last check.
+	ModifiedAt *time.Time `json:"modifiedAt,omitempty"`
+
+	// Status is "ok", "drift", or "missing".
+	Status string `gorm:"type:varchar(20);not null;index" json:"status"`
+
+	// DriftDetectedAt is when the object was first found to have drifted or
+	// be missing, or nil if its status is "ok".
+	DriftDetectedAt *time.Time `json:"driftDetectedAt,omitempty"`
+
+	// LastCheckedAt is when the object was last checked.
+	LastCheckedAt time.Time `gorm:"not null" json:"lastCheckedAt"`
+}
+
+// DocumentIntegrities is a slice of document integrity records.
+type DocumentIntegrities []DocumentIntegrity
+
+// TableName specifies the table name.
+func (DocumentIntegrity) TableName() string {
+	return "document_integrity"
+}
+
+// Failed returns true if the object drifted or is missing.
+func (i DocumentIntegrity) Failed() bool {
+	return i.Status != DocumentIntegrityStatusOK
+}
+
+// ReplaceDocumentIntegrity records the result of checking the objects of a
+// document. Records in records are inserted or updated by object (their IDs
+// are ignored), and existing records for other objects (e.g., attachments that
+// were deleted) are deleted.
+func ReplaceDocumentIntegrity(
+	db *gorm.DB,
+	documentID uint,
+	records []DocumentIntegrity,
+) error {
+	if documentID == 0 {
+		return fmt.Errorf("document ID is required")
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		del := tx.Where("document_id = ?", documentID)
+		for i := range records {
+			records[i].ID = 0
+			records[i].DocumentID = documentID
+			del = del.Where("NOT (kind = ? AND name = ?)",
+				records[i].Kind, records[i].Name)
+		}
+		if err := del.Delete(&DocumentIntegrity{}).Error; err != nil {
+			return fmt.Errorf("error deleting removed objects: %w", err)
+		}
+
+		if len(records) == 0 {
+			return nil
+		}
+		if err := tx.
+			Omit(clause.Associations).
+			Clauses(clause.OnConflict{
+				Columns: []clause.Column{
+					{Name: "document_id"}, {Name: "kind"}, {Name: "name"},
+				},
+				DoUpdates: clause.AssignmentColumns([]string{
+					"updated_at", "provider_id", "expected_hash", "actual_hash",
+					"modified_at", "status", "drift_detected_at", "last_checked_at",
+				}),
+			}).
+			Create(&records).
+			Error; err != nil {
+			return fmt.Errorf("error upserting integrity records: %w", err)
+		}
+		return nil
+	})
+}
+
+// FindByDocument finds the integrity records of a document, ordered by kind
+// and name.
+func (l *DocumentIntegrities) FindByDocument(db *gorm.DB, documentID uint) error {
+	if documentID == 0 {
+		return fmt.Errorf("document ID is required")
+	}
+
+	return db.
+		Where("document_id = ?", documentID).
+		Order("kind, name").
+		Find(l).
+		Error
+}
+
+// FindFailed finds the integrity records of objects that drifted or are
+// missing, in documents that aren't deleted, ordered by when the drift was
+// detected. Documents are preloaded.
+func (l *DocumentIntegrities) FindFailed(db *gorm.DB) error {
+	return db.
+		Joins("JOIN documents ON documents.id = document_integrity.document_id "+
+			"AND documents.deleted_at IS NULL").
+		Where("document_integrity.status <> ?", DocumentIntegrityStatusOK).
+		Preload("Document").
+		Order("document_integrity.drift_detected_at, document_integrity.id").
+		Find(l).
+		Error
+}
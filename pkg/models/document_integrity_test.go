@@ -0,0 +1,98 @@
+package models
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocumentIntegrityModel(t *testing.T) {
+	dsn := os.Getenv("HERMES_TEST_POSTGRESQL_DSN")
+	if dsn == "" {
+		t.Skip("HERMES_TEST_POSTGRESQL_DSN environment variable isn't set")
+	}
+
+	db, tearDownTest := setupTest(t, dsn)
+	defer tearDownTest(t)
+
+	require.NoError(t, (&DocumentType{Name: "DT1", LongName: "DocumentType1"}).
+		FirstOrCreate(db))
+	require.NoError(t, (&Product{Name: "Product1", Abbreviation: "P1"}).
+		FirstOrCreate(db))
+
+	d := Document{
+		GoogleFileID: "fileID1",
+		DocumentType: DocumentType{Name: "DT1"},
+		Owner:        &User{EmailAddress: "owner@example.com"},
+		Product:      Product{Name: "Product1"},
+	}
+	require.NoError(t, d.Create(db))
+
+	t0 := time.Now().UTC().Truncate(time.Second)
+	t1 := t0.Add(time.Hour)
+
+	t.Run("Record integrity checks", func(t *testing.T) {
+		err := ReplaceDocumentIntegrity(db, d.ID, []DocumentIntegrity{
+			{
+				Kind:          DocumentIntegrityKindContent,
+				ProviderID:    "s3:bucket/fileID1",
+				ExpectedHash:  "sha256:aa",
+				ActualHash:    "sha256:aa",
+				Status:        DocumentIntegrityStatusOK,
+				LastCheckedAt: t0,
+			},
+			{
+				Kind:            DocumentIntegrityKindAttachment,
+				Name:            "diagram.png",
+				ProviderID:      "s3:bucket/fileID1",
+				ExpectedHash:    "sha256:bb",
+				ActualHash:      "sha256:cc",
+				Status:          DocumentIntegrityStatusDrift,
+				LastCheckedAt:   t0,
+				DriftDetectedAt: &t0,
+			},
+		})
+		require.NoError(t, err)
+
+		var records DocumentIntegrities
+		require.NoError(t, records.FindByDocument(db, d.ID))
+		require.Len(t, records, 2)
+		assert.Equal(t, DocumentIntegrityKindAttachment, records[0].Kind)
+		assert.True(t, records[0].Failed())
+		assert.False(t, records[1].Failed())
+
+		var failed DocumentIntegrities
+		require.NoError(t, failed.FindFailed(db))
+		require.Len(t, failed, 1)
+		assert.Equal(t, "diagram.png", failed[0].Name)
+		assert.Equal(t, "fileID1", failed[0].Document.GoogleFileID)
+	})
+
+	t.Run("Removed objects are deleted", func(t *testing.T) {
+		err := ReplaceDocumentIntegrity(db, d.ID, []DocumentIntegrity{
+			{
+				Kind:            DocumentIntegrityKindContent,
+				ProviderID:      "s3:bucket/fileID1",
+				ExpectedHash:    "sha256:aa",
+				Status:          DocumentIntegrityStatusMissing,
+				LastCheckedAt:   t1,
+				DriftDetectedAt: &t1,
+			},
+		})
+		require.NoError(t, err)
+
+		var records DocumentIntegrities
+		require.NoError(t, records.FindByDocument(db, d.ID))
+		require.Len(t, records, 1)
+		assert.Equal(t, DocumentIntegrityStatusMissing, records[0].Status)
+		assert.Empty(t, records[0].ActualHash)
+		assert.True(t, t1.Equal(records[0].LastCheckedAt))
+
+		require.NoError(t, ReplaceDocumentIntegrity(db, d.ID, nil))
+		require.NoError(t, records.FindByDocument(db, d.ID))
+		assert.Empty(t, records)
+	})
+}
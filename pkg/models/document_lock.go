@@ -0,0 +1,157 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrDocumentLocked is returned when a document is locked by another user.
+var ErrDocumentLocked = errors.New("document is locked by another user")
+
+// DocumentLock is an advisory lock on editing a document, held by a user until
+// it is released or expires. Locks are enforced by the API for all workspace
+// providers, unlike the Google Docs header lock (see Document.Locked).
+type DocumentLock struct {
+	// DocumentID is the ID of the locked document. A document has at most one
+	// lock.
+	DocumentID uint     `gorm:"primaryKey;autoIncrement:false"`
+	Document   Document `gorm:"constraint:OnDelete:CASCADE"`
+
+	// OwnerEmail is the email address of the user holding the lock.
+	OwnerEmail string `gorm:"type:varchar(320);not null"`
+
+	// Reason is why the user locked the document, shown to other users.
+	Reason string `gorm:"type:text"`
+
+	// ExpiresAt is when the lock is released if it is not released or renewed
+	// earlier.
+	ExpiresAt time.Time `gorm:"not null;index"`
+
+	// CreatedAt is when the owner acquired the lock. Renewing the lock doesn't
+	// change it.
+	CreatedAt time.Time `gorm:"not null"`
+	UpdatedAt time.Time `gorm:"not null"`
+}
+
+// TableName specifies the table name.
+func (DocumentLock) TableName() string {
+	return "document_locks"
+}
+
+// Acquire acquires the lock on the document for the owner in database db, or
+// renews it if the owner already holds it, so it expires after ttl. Expired
+// locks of other users are taken over. If another user holds an active lock,
+// Acquire returns ErrDocumentLocked and assigns that lock to the receiver.
+func (l *DocumentLock) Acquire(db *gorm.DB, ttl time.Duration) error {
+	if err := validation.ValidateStruct(l,
+		validation.Field(&l.DocumentID, validation.Required),
+		validation.Field(&l.OwnerEmail, validation.Required),
+	); err != nil {
+		return err
+	}
+	if ttl <= 0 {
+		return fmt.Errorf("TTL must be positive")
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+
+		var cur DocumentLock
+		err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("document_id = ?", l.DocumentID).
+			First(&cur).
+			Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			l.CreatedAt = now
+			l.ExpiresAt = now.Add(ttl)
+
+			// Another request may have acquired the lock since it was read.
+			res := tx.
+				Omit(clause.Associations).
+				Clauses(clause.OnConflict{DoNothing: true}).
+				Create(l)
+			if res.Error != nil {
+				return fmt.Errorf("error creating document lock: %w", res.Error)
+			}
+			if res.RowsAffected == 0 {
+				if err := tx.First(l, "document_id = ?", l.DocumentID).Error; err != nil {
+					return fmt.Errorf("error getting document lock: %w", err)
+				}
+				return ErrDocumentLocked
+			}
+			return nil
+
+		case err != nil:
+			return fmt.Errorf("error getting document lock: %w", err)
+
+		case cur.OwnerEmail != l.OwnerEmail && cur.IsActive(now):
+			*l = cur
+			return ErrDocumentLocked
+		}
+
+		l.CreatedAt = cur.CreatedAt
+		if cur.OwnerEmail != l.OwnerEmail || !cur.IsActive(now) {
+			l.CreatedAt = now
+		}
+		l.ExpiresAt = now.Add(ttl)
+		if err := tx.Model(&DocumentLock{}).
+			Where("document_id = ?", l.DocumentID).
+			Updates(map[string]any{
+				"owner_email": l.OwnerEmail,
+				"reason":      l.Reason,
+				"expires_at":  l.ExpiresAt,
+				"created_at":  l.CreatedAt,
+				"updated_at":  now,
+			}).
+			Error; err != nil {
+			return fmt.Errorf("error updating document lock: %w", err)
+		}
+		l.UpdatedAt = now
+		return nil
+	})
+}
+
+// GetActive gets the active lock on the document from database db, and assigns
+// it to the receiver. It returns gorm.ErrRecordNotFound if the document isn't
+// locked.
+func (l *DocumentLock) GetActive(db *gorm.DB) error {
+	if l.DocumentID == 0 {
+		return fmt.Errorf("document ID is required")
+	}
+
+	return db.
+		Where("document_id = ? AND expires_at > ?", l.DocumentID, time.Now()).
+		First(l).
+		Error
+}
+
+// Release releases the lock on the document held by the owner in database db.
+// It does nothing if the owner doesn't hold the lock.
+func (l *DocumentLock) Release(db *gorm.DB) error {
+	if err := validation.ValidateStruct(l,
+		validation.Field(&l.DocumentID, validation.Required),
+		validation.Field(&l.OwnerEmail, validation.Required),
+	); err != nil {
+		return err
+	}
+
+	if err := db.
+		Where("document_id = ? AND owner_email = ?", l.DocumentID, l.OwnerEmail).
+		Delete(&DocumentLock{}).
+		Error; err != nil {
+		return fmt.Errorf("error deleting document lock: %w", err)
+	}
+	return nil
+}
+
+// IsActive returns true if the lock has not expired at time t.
+func (l DocumentLock) IsActive(t time.Time) bool {
+	return t.Before(l.ExpiresAt)
+}
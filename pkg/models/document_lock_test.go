@@ -0,0 +1,96 @@
+package models
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestDocumentLockModel(t *testing.T) {
+	dsn := os.Getenv("HERMES_TEST_POSTGRESQL_DSN")
+	if dsn == "" {
+		t.Skip("HERMES_TEST_POSTGRESQL_DSN environment variable isn't set")
+	}
+
+	db, tearDownTest := setupTest(t, dsn)
+	defer tearDownTest(t)
+
+	d := Document{
+		GoogleFileID: "fileID1",
+		DocumentType: DocumentType{
+			Name:     "DT1",
+			LongName: "DocumentType1",
+		},
+		Product: Product{
+			Name:         "Product1",
+			Abbreviation: "P1",
+		},
+	}
+	require.NoError(t, d.DocumentType.FirstOrCreate(db))
+	require.NoError(t, d.Product.FirstOrCreate(db))
+	require.NoError(t, d.Create(db))
+
+	t.Run("Acquire, renew, and release", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+
+		l := DocumentLock{
+			DocumentID: d.ID,
+			OwnerEmail: "alice@example.com",
+			Reason:     "Editing the rollout plan",
+		}
+		require.NoError(l.Acquire(db, time.Minute))
+		acquiredAt := l.CreatedAt
+
+		// Other users can't acquire the lock.
+		other := DocumentLock{DocumentID: d.ID, OwnerEmail: "bob@example.com"}
+		require.ErrorIs(other.Acquire(db, time.Minute), ErrDocumentLocked)
+		assert.Equal("alice@example.com", other.OwnerEmail)
+		assert.Equal("Editing the rollout plan", other.Reason)
+
+		// The owner renews the lock.
+		l = DocumentLock{DocumentID: d.ID, OwnerEmail: "alice@example.com"}
+		require.NoError(l.Acquire(db, time.Hour))
+		got := DocumentLock{DocumentID: d.ID}
+		require.NoError(got.GetActive(db))
+		assert.Equal("alice@example.com", got.OwnerEmail)
+		assert.WithinDuration(acquiredAt, got.CreatedAt, time.Millisecond)
+		assert.WithinDuration(time.Now().Add(time.Hour), got.ExpiresAt, time.Minute)
+
+		// Only the owner releases the lock.
+		require.NoError(other.Release(db))
+		require.NoError(got.GetActive(db))
+		require.NoError(l.Release(db))
+		got = DocumentLock{DocumentID: d.ID}
+		assert.ErrorIs(got.GetActive(db), gorm.ErrRecordNotFound)
+	})
+
+	t.Run("Expired locks are taken over", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+
+		l := DocumentLock{DocumentID: d.ID, OwnerEmail: "alice@example.com"}
+		require.NoError(l.Acquire(db, time.Minute))
+		require.NoError(db.Model(&DocumentLock{}).
+			Where("document_id = ?", d.ID).
+			Update("expires_at", time.Now().Add(-time.Second)).
+			Error)
+
+		got := DocumentLock{DocumentID: d.ID}
+		assert.ErrorIs(got.GetActive(db), gorm.ErrRecordNotFound)
+
+		other := DocumentLock{DocumentID: d.ID, OwnerEmail: "bob@example.com"}
+		require.NoError(other.Acquire(db, time.Minute))
+		require.NoError(got.GetActive(db))
+		assert.Equal("bob@example.com", got.OwnerEmail)
+	})
+
+	t.Run("Acquire validates the lock", func(t *testing.T) {
+		l := DocumentLock{DocumentID: d.ID}
+		assert.Error(t, l.Acquire(db, time.Minute))
+		l.OwnerEmail = "alice@example.com"
+		assert.Error(t, l.Acquire(db, 0))
+	})
+}
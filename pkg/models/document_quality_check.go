@@ -0,0 +1,98 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DocumentQualityCheck is the result of running a quality lint rule over a
+// document's content, shown as an item of the document's quality checklist.
+type DocumentQualityCheck struct {
+	ID uint `gorm:"primaryKey" json:"-"`
+
+	// DocumentID is the document that was checked.
+	DocumentID uint     `gorm:"not null;uniqueIndex:idx_document_quality_checks_document_rule" json:"-"`
+	Document   Document `json:"-"`
+
+	// Rule is the lint rule (e.g., "summary_section", "todo_markers").
+	Rule string `gorm:"type:varchar(50);not null;uniqueIndex:idx_document_quality_checks_document_rule" json:"rule"`
+
+	// Passed is true if the rule found no issues.
+	Passed bool `gorm:"not null" json:"passed"`
+
+	// Findings are the issues found by the rule.
+	Findings []DocumentQualityFinding `gorm:"serializer:json;type:jsonb" json:"findings,omitempty"`
+
+	// ContentHash is the hash of the content that was checked.
+	ContentHash string `gorm:"type:varchar(64)" json:"-"`
+
+	// CheckedAt is when the content was checked.
+	CheckedAt time.Time `gorm:"not null" json:"checkedAt"`
+}
+
+// DocumentQualityFinding is an issue found by a quality lint rule.
+type DocumentQualityFinding struct {
+	// Line is the 1-based line of the issue in the document's content, or 0 if
+	// the issue isn't on a specific line.
+	Line    int    `json:"line,omitempty"`
+	Message string `json:"message"`
+}
+
+// DocumentQualityChecks is a slice of document quality checks.
+type DocumentQualityChecks []DocumentQualityCheck
+
+// TableName specifies the table name.
+func (DocumentQualityCheck) TableName() string {
+	return "document_quality_checks"
+}
+
+// ReplaceDocumentQualityChecks replaces the quality checks of a document with
+// checks, e.g., after its content was linted again.
+func ReplaceDocumentQualityChecks(
+	db *gorm.DB, documentID uint, checks []DocumentQualityCheck,
+) error {
+	if documentID == 0 {
+		return fmt.Errorf("document ID is required")
+	}
+	for i := range checks {
+		checks[i].ID = 0
+		checks[i].DocumentID = documentID
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.
+			Where("document_id = ?", documentID).
+			Delete(&DocumentQualityCheck{}).
+			Error; err != nil {
+			return fmt.Errorf("error deleting quality checks: %w", err)
+		}
+
+		if len(checks) == 0 {
+			return nil
+		}
+		if err := tx.
+			Omit(clause.Associations).
+			Create(&checks).
+			Error; err != nil {
+			return fmt.Errorf("error creating quality checks: %w", err)
+		}
+		return nil
+	})
+}
+
+// Find finds the quality checks of the document with the provided ID, in the
+// order the rules were run.
+func (c *DocumentQualityChecks) Find(db *gorm.DB, documentID uint) error {
+	if documentID == 0 {
+		return fmt.Errorf("document ID is required")
+	}
+
+	return db.
+		Where("document_id = ?", documentID).
+		Order("id").
+		Find(c).
+		Error
+}
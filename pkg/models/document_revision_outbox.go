@@ -239,3 +239,28 @@ func CountOutboxByStatus(db *gorm.DB, status string) (int64, error) {
 
 	return count, err
 }
+
+// FindOutboxEntriesAfter retrieves up to limit outbox entries of event type
+// eventType with an ID greater than afterID, oldest first and regardless of
+// their status, for following new revision events.
+func FindOutboxEntriesAfter(db *gorm.DB, afterID uint, eventType string, limit int) ([]DocumentRevisionOutbox, error) {
+	var entries []DocumentRevisionOutbox
+	err := db.
+		Where("id > ? AND event_type = ?", afterID, eventType).
+		Order("id ASC").
+		Limit(limit).
+		Find(&entries).Error
+
+	return entries, err
+}
+
+// LastOutboxEntryID returns the ID of the newest outbox entry, or 0 if there
+// are no entries.
+func LastOutboxEntryID(db *gorm.DB) (uint, error) {
+	var id uint
+	err := db.Model(&DocumentRevisionOutbox{}).
+		Select("COALESCE(MAX(id), 0)").
+		Scan(&id).Error
+
+	return id, err
+}
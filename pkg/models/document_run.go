@@ -0,0 +1,232 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DocumentRun is an execution of a document's checklist as a runbook. Items are
+// copied from the document when the run starts, so execution history is kept
+// separate from, and is unaffected by later changes to, the document content.
+type DocumentRun struct {
+	gorm.Model
+
+	DocumentID uint `gorm:"not null;index"`
+	Document   Document
+
+	// StartedBy is the user who started the run.
+	StartedByID uint `gorm:"not null"`
+	StartedBy   User
+
+	// Name is an optional label for the run (e.g., an incident number).
+	Name string
+
+	Status DocumentRunStatus `gorm:"not null"`
+
+	// ContentRevision is the revision of the document content the checklist
+	// was copied from, if known.
+	ContentRevision string
+
+	// CompletedAt is when the run was completed or aborted.
+	CompletedAt *time.Time
+
+	Items []DocumentRunItem `gorm:"foreignKey:RunID"`
+}
+
+// DocumentRuns is a slice of document runs.
+type DocumentRuns []DocumentRun
+
+// DocumentRunStatus is the status of a document run.
+type DocumentRunStatus int
+
+const (
+	UnspecifiedDocumentRunStatus DocumentRunStatus = iota
+	InProgressDocumentRunStatus
+	CompletedDocumentRunStatus
+	AbortedDocumentRunStatus
+)
+
+var (
+	documentRunStatusStrings = map[DocumentRunStatus]string{
+		InProgressDocumentRunStatus: "in_progress",
+		CompletedDocumentRunStatus:  "completed",
+		AbortedDocumentRunStatus:    "aborted",
+	}
+)
+
+func (s DocumentRunStatus) String() string {
+	return documentRunStatusStrings[s]
+}
+
+func ParseDocumentRunStatusString(s string) (DocumentRunStatus, bool) {
+	for k, v := range documentRunStatusStrings {
+		if v == strings.ToLower(s) {
+			return k, true
+		}
+	}
+	return UnspecifiedDocumentRunStatus, false
+}
+
+// DocumentRunItem is a checklist item in a document run.
+type DocumentRunItem struct {
+	ID uint `gorm:"primaryKey"`
+
+	RunID uint `gorm:"not null;uniqueIndex:idx_document_run_items_run_position"`
+
+	// Position is the 1-based position of the item in the checklist.
+	Position int `gorm:"not null;uniqueIndex:idx_document_run_items_run_position"`
+
+	// Section is the heading the item is under in the document.
+	Section string
+	Text    string `gorm:"not null"`
+
+	// CheckedBy and CheckedAt record who checked the item and when. They are
+	// nil if the item is unchecked.
+	CheckedByID *uint
+	CheckedBy   *User
+	CheckedAt   *time.Time
+
+	Note string
+}
+
+// TableName specifies the table name.
+func (DocumentRun) TableName() string {
+	return "document_runs"
+}
+
+// TableName specifies the table name.
+func (DocumentRunItem) TableName() string {
+	return "document_run_items"
+}
+
+// Create creates the run and its items in database db. The document is looked
+// up by Google file ID and the user who started the run by email address.
+func (r *DocumentRun) Create(db *gorm.DB) error {
+	if err := validation.ValidateStruct(r,
+		validation.Field(&r.Items, validation.Required),
+	); err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := r.Document.Get(tx); err != nil {
+			return fmt.Errorf("error getting document: %w", err)
+		}
+		r.DocumentID = r.Document.ID
+
+		if err := r.StartedBy.FirstOrCreate(tx); err != nil {
+			return fmt.Errorf("error getting user: %w", err)
+		}
+		r.StartedByID = r.StartedBy.ID
+
+		if r.Status == UnspecifiedDocumentRunStatus {
+			r.Status = InProgressDocumentRunStatus
+		}
+
+		return tx.
+			Omit("Document", "StartedBy").
+			Create(r).
+			Error
+	})
+}
+
+// Get gets the run by ID from database db with its items, and assigns it to the
+// receiver.
+func (r *DocumentRun) Get(db *gorm.DB) error {
+	if r.ID == 0 {
+		return fmt.Errorf("ID is required")
+	}
+
+	return db.
+		Preload("Document").
+		Preload("StartedBy").
+		Preload("Items", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position")
+		}).
+		Preload("Items.CheckedBy").
+		First(r, r.ID).
+		Error
+}
+
+// FindByDocument finds the runs of the document with the provided ID, newest
+// first. Items are preloaded.
+func (r *DocumentRuns) FindByDocument(db *gorm.DB, documentID uint) error {
+	return db.
+		Where("document_id = ?", documentID).
+		Preload("StartedBy").
+		Preload("Items", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position")
+		}).
+		Preload("Items.CheckedBy").
+		Order("created_at DESC, id DESC").
+		Find(r).
+		Error
+}
+
+// SetItemChecked checks or unchecks the item at the provided position in the
+// run in database db. Checking records the user and time; unchecking clears
+// them. The run is reloaded into the receiver.
+func (r *DocumentRun) SetItemChecked(
+	db *gorm.DB, position int, userEmail string, checked bool, note string,
+) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var item DocumentRunItem
+		if err := tx.
+			Where("run_id = ? AND position = ?", r.ID, position).
+			First(&item).
+			Error; err != nil {
+			return err
+		}
+
+		updates := map[string]any{
+			"note":          note,
+			"checked_by_id": nil,
+			"checked_at":    nil,
+		}
+		if checked {
+			u := User{EmailAddress: userEmail}
+			if err := u.FirstOrCreate(tx); err != nil {
+				return fmt.Errorf("error getting user: %w", err)
+			}
+			updates["checked_by_id"] = u.ID
+			updates["checked_at"] = time.Now()
+		}
+
+		if err := tx.
+			Model(&item).
+			Omit(clause.Associations).
+			Updates(updates).
+			Error; err != nil {
+			return fmt.Errorf("error updating item: %w", err)
+		}
+
+		return r.Get(tx)
+	})
+}
+
+// SetStatus sets the status of the run in database db. Completing or aborting
+// the run records the time.
+func (r *DocumentRun) SetStatus(db *gorm.DB, status DocumentRunStatus) error {
+	updates := map[string]any{
+		"status":       status,
+		"completed_at": nil,
+	}
+	if status != InProgressDocumentRunStatus {
+		updates["completed_at"] = time.Now()
+	}
+
+	if err := db.
+		Model(r).
+		Omit(clause.Associations).
+		Updates(updates).
+		Error; err != nil {
+		return err
+	}
+
+	return r.Get(db)
+}
@@ -0,0 +1,102 @@
+package models
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocumentRunModel(t *testing.T) {
+	dsn := os.Getenv("HERMES_TEST_POSTGRESQL_DSN")
+	if dsn == "" {
+		t.Skip("HERMES_TEST_POSTGRESQL_DSN environment variable isn't set")
+	}
+
+	db, tearDownTest := setupTest(t, dsn)
+	defer tearDownTest(t)
+
+	d := Document{
+		GoogleFileID: "fileID1",
+		DocumentType: DocumentType{
+			Name:     "DT1",
+			LongName: "DocumentType1",
+		},
+		Product: Product{
+			Name:         "Product1",
+			Abbreviation: "P1",
+		},
+	}
+	require.NoError(t, d.DocumentType.FirstOrCreate(db))
+	require.NoError(t, d.Product.FirstOrCreate(db))
+	require.NoError(t, d.Create(db))
+
+	t.Run("Create requires items", func(t *testing.T) {
+		run := DocumentRun{
+			Document:  Document{GoogleFileID: "fileID1"},
+			StartedBy: User{EmailAddress: "alice@example.com"},
+		}
+		assert.Error(t, run.Create(db))
+	})
+
+	var runID uint
+	t.Run("Create and get", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+
+		run := DocumentRun{
+			Document:  Document{GoogleFileID: "fileID1"},
+			StartedBy: User{EmailAddress: "alice@example.com"},
+			Name:      "INC-1",
+			Items: []DocumentRunItem{
+				{Position: 1, Section: "Prepare", Text: "Announce"},
+				{Position: 2, Section: "Prepare", Text: "Drain traffic"},
+			},
+		}
+		require.NoError(run.Create(db))
+		runID = run.ID
+
+		got := DocumentRun{}
+		got.ID = runID
+		require.NoError(got.Get(db))
+		assert.Equal(InProgressDocumentRunStatus, got.Status)
+		assert.Equal("alice@example.com", got.StartedBy.EmailAddress)
+		require.Len(got.Items, 2)
+		assert.Equal("Announce", got.Items[0].Text)
+		assert.Nil(got.Items[0].CheckedAt)
+	})
+
+	t.Run("Check and uncheck an item", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+
+		run := DocumentRun{}
+		run.ID = runID
+		require.NoError(run.SetItemChecked(db, 2, "bob@example.com", true, "ok"))
+		require.NotNil(run.Items[1].CheckedBy)
+		assert.Equal("bob@example.com", run.Items[1].CheckedBy.EmailAddress)
+		assert.NotNil(run.Items[1].CheckedAt)
+		assert.Equal("ok", run.Items[1].Note)
+		assert.Nil(run.Items[0].CheckedAt)
+
+		require.NoError(run.SetItemChecked(db, 2, "bob@example.com", false, ""))
+		assert.Nil(run.Items[1].CheckedBy)
+		assert.Nil(run.Items[1].CheckedAt)
+
+		assert.Error(run.SetItemChecked(db, 3, "bob@example.com", true, ""))
+	})
+
+	t.Run("Set status and find by document", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+
+		run := DocumentRun{}
+		run.ID = runID
+		require.NoError(run.SetStatus(db, CompletedDocumentRunStatus))
+		assert.Equal(CompletedDocumentRunStatus, run.Status)
+		assert.NotNil(run.CompletedAt)
+
+		var runs DocumentRuns
+		require.NoError(runs.FindByDocument(db, run.DocumentID))
+		require.Len(runs, 1)
+		assert.Len(runs[0].Items, 2)
+	})
+}
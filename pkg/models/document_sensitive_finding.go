@@ -0,0 +1,112 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DocumentSensitiveFinding is sensitive data (e.g., a credential or personal
+// data) found in a document's content by the sensitive data detection indexer
+// step. The data itself is never stored.
+type DocumentSensitiveFinding struct {
+	ID uint `gorm:"primaryKey" json:"-"`
+
+	// DocumentID is the document containing the data.
+	DocumentID uint     `gorm:"not null;index" json:"-"`
+	Document   Document `json:"-"`
+
+	// Rule is the rule that found the data (e.g., "aws_access_key").
+	Rule string `gorm:"type:varchar(100);not null" json:"rule"`
+
+	// Category is the kind of data: "credential" or "pii".
+	Category string `gorm:"type:varchar(20);not null" json:"category"`
+
+	// Line is the 1-based line of the data in the document's content.
+	Line int `gorm:"not null;default:0" json:"line,omitempty"`
+
+	// Redacted is the data with all but a few characters masked.
+	Redacted string `gorm:"type:text" json:"redacted"`
+
+	// Source is what found the data: "regex", "entropy", or "dlp".
+	Source string `gorm:"type:varchar(20)" json:"source"`
+
+	// Blocking is true if the data blocks publishing the document for review
+	// until it is removed.
+	Blocking bool `gorm:"not null;default:false" json:"blocking"`
+
+	// ContentHash is the hash of the content that was scanned.
+	ContentHash string `gorm:"type:varchar(64)" json:"-"`
+
+	// DetectedAt is when the content was scanned.
+	DetectedAt time.Time `gorm:"not null" json:"detectedAt"`
+}
+
+// DocumentSensitiveFindings is a slice of document sensitive findings.
+type DocumentSensitiveFindings []DocumentSensitiveFinding
+
+// TableName specifies the table name.
+func (DocumentSensitiveFinding) TableName() string {
+	return "document_sensitive_findings"
+}
+
+// ReplaceDocumentSensitiveFindings replaces the sensitive findings of a
+// document with findings, e.g., after its content was scanned again.
+func ReplaceDocumentSensitiveFindings(
+	db *gorm.DB, documentID uint, findings []DocumentSensitiveFinding,
+) error {
+	if documentID == 0 {
+		return fmt.Errorf("document ID is required")
+	}
+	for i := range findings {
+		findings[i].ID = 0
+		findings[i].DocumentID = documentID
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.
+			Where("document_id = ?", documentID).
+			Delete(&DocumentSensitiveFinding{}).
+			Error; err != nil {
+			return fmt.Errorf("error deleting sensitive findings: %w", err)
+		}
+
+		if len(findings) == 0 {
+			return nil
+		}
+		if err := tx.
+			Omit(clause.Associations).
+			Create(&findings).
+			Error; err != nil {
+			return fmt.Errorf("error creating sensitive findings: %w", err)
+		}
+		return nil
+	})
+}
+
+// Find finds the sensitive findings of the document with the provided ID,
+// ordered by line.
+func (f *DocumentSensitiveFindings) Find(db *gorm.DB, documentID uint) error {
+	if documentID == 0 {
+		return fmt.Errorf("document ID is required")
+	}
+
+	return db.
+		Where("document_id = ?", documentID).
+		Order("line, id").
+		Find(f).
+		Error
+}
+
+// Blocking returns the findings that block publishing the document.
+func (f DocumentSensitiveFindings) Blocking() DocumentSensitiveFindings {
+	var blocking DocumentSensitiveFindings
+	for _, finding := range f {
+		if finding.Blocking {
+			blocking = append(blocking, finding)
+		}
+	}
+	return blocking
+}
@@ -990,7 +990,7 @@ func TestGetLatestProductNumber(t *testing.T) {
 	t.Run("Get latest product number without any documents", func(t *testing.T) {
 		assert, require := assert.New(t), require.New(t)
 
-		num, err := GetLatestProductNumber(db, "DT1", "Product1")
+		num, err := GetLatestProductNumber(db, 0, "DT1", "Product1")
 		require.NoError(err)
 		assert.Equal(0, num)
 	})
@@ -1016,7 +1016,7 @@ func TestGetLatestProductNumber(t *testing.T) {
 	t.Run("Get latest product number", func(t *testing.T) {
 		assert, require := assert.New(t), require.New(t)
 
-		num, err := GetLatestProductNumber(db, "DT1", "Product1")
+		num, err := GetLatestProductNumber(db, 0, "DT1", "Product1")
 		require.NoError(err)
 		assert.Equal(4, num)
 	})
@@ -1042,7 +1042,7 @@ func TestGetLatestProductNumber(t *testing.T) {
 	t.Run("Get latest product number", func(t *testing.T) {
 		assert, require := assert.New(t), require.New(t)
 
-		num, err := GetLatestProductNumber(db, "DT1", "Product1")
+		num, err := GetLatestProductNumber(db, 0, "DT1", "Product1")
 		require.NoError(err)
 		assert.Equal(42, num)
 	})
@@ -1080,7 +1080,38 @@ func TestGetLatestProductNumber(t *testing.T) {
 	t.Run("Get latest product number", func(t *testing.T) {
 		assert, require := assert.New(t), require.New(t)
 
-		num, err := GetLatestProductNumber(db, "DT2", "Product1")
+		num, err := GetLatestProductNumber(db, 0, "DT2", "Product1")
+		require.NoError(err)
+		assert.Equal(2, num)
+	})
+
+	t.Run("Document numbers are per tenant", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+
+		tenant := Tenant{Name: "acme"}
+		require.NoError(tenant.FirstOrCreate(db))
+
+		num, err := GetLatestProductNumber(db, tenant.ID, "DT2", "Product1")
+		require.NoError(err)
+		assert.Equal(0, num)
+
+		d := Document{
+			GoogleFileID: "fileID5",
+			DocumentType: DocumentType{
+				Name: "DT2",
+			},
+			Product: Product{
+				Name: "Product1",
+			},
+			DocumentNumber: 7,
+			TenantID:       tenant.ID,
+		}
+		require.NoError(d.Create(db))
+
+		num, err = GetLatestProductNumber(db, tenant.ID, "DT2", "Product1")
+		require.NoError(err)
+		assert.Equal(7, num)
+		num, err = GetLatestProductNumber(db, 0, "DT2", "Product1")
 		require.NoError(err)
 		assert.Equal(2, num)
 	})
@@ -1552,3 +1583,123 @@ func TestDocumentReplaceRelatedResources(t *testing.T) {
 		})
 	})
 }
+
+func TestDocumentSoftDelete(t *testing.T) {
+	dsn := os.Getenv("HERMES_TEST_POSTGRESQL_DSN")
+	if dsn == "" {
+		t.Skip("HERMES_TEST_POSTGRESQL_DSN environment variable isn't set")
+	}
+
+	db, tearDownTest := setupTest(t, dsn)
+	defer tearDownTest(t)
+
+	require.NoError(t, (&DocumentType{Name: "DT1", LongName: "DocumentType1"}).
+		FirstOrCreate(db))
+	require.NoError(t, (&Product{Name: "Product1", Abbreviation: "P1"}).
+		FirstOrCreate(db))
+	for _, id := range []string{"fileID1", "fileID2"} {
+		d := Document{
+			GoogleFileID: id,
+			DocumentType: DocumentType{Name: "DT1"},
+			Owner:        &User{EmailAddress: "owner@example.com"},
+			Product:      Product{Name: "Product1"},
+		}
+		require.NoError(t, d.Create(db))
+	}
+	d2 := Document{GoogleFileID: "fileID2"}
+	require.NoError(t, d2.Get(db))
+	require.NoError(t, d2.ReplaceRelatedResources(db, nil,
+		[]DocumentRelatedResourceHermesDocument{
+			{
+				RelatedResource: DocumentRelatedResource{
+					Document:  d2,
+					SortOrder: 1,
+				},
+				Document: Document{GoogleFileID: "fileID1"},
+			},
+		}))
+
+	t.Run("Delete and restore", func(t *testing.T) {
+		require.NoError(t, (&Document{GoogleFileID: "fileID1"}).Delete(db))
+		assert.ErrorIs(t, (&Document{GoogleFileID: "fileID1"}).Get(db),
+			gorm.ErrRecordNotFound)
+
+		var deleted Documents
+		require.NoError(t, deleted.FindDeleted(db, "documents.deleted_at <= ?",
+			time.Now().Add(time.Minute)))
+		require.Len(t, deleted, 1)
+		assert.Equal(t, "fileID1", deleted[0].GoogleFileID)
+		assert.Equal(t, "owner@example.com", deleted[0].Owner.EmailAddress)
+
+		d := Document{GoogleFileID: "fileID1"}
+		require.NoError(t, d.Restore(db))
+		assert.Equal(t, "Product1", d.Product.Name)
+		assert.ErrorIs(t, (&Document{GoogleFileID: "fileID1"}).Restore(db),
+			gorm.ErrRecordNotFound, "document isn't deleted")
+	})
+
+	t.Run("Purge", func(t *testing.T) {
+		require.NoError(t, (&Document{GoogleFileID: "fileID1"}).Delete(db))
+		require.NoError(t, (&Document{GoogleFileID: "fileID1"}).Purge(db))
+
+		var count int64
+		require.NoError(t, db.Unscoped().Model(&Document{}).
+			Where("google_file_id = ?", "fileID1").Count(&count).Error)
+		assert.Zero(t, count)
+
+		_, hdrrs, err := d2.GetRelatedResources(db)
+		require.NoError(t, err)
+		assert.Empty(t, hdrrs, "links to the purged document are deleted")
+	})
+}
+
+func TestDocumentUpsertVersion(t *testing.T) {
+	dsn := os.Getenv("HERMES_TEST_POSTGRESQL_DSN")
+	if dsn == "" {
+		t.Skip("HERMES_TEST_POSTGRESQL_DSN environment variable isn't set")
+	}
+
+	db, tearDownTest := setupTest(t, dsn)
+	defer tearDownTest(t)
+
+	require.NoError(t, (&DocumentType{Name: "DT1", LongName: "DocumentType1"}).
+		FirstOrCreate(db))
+	require.NoError(t, (&Product{Name: "Product1", Abbreviation: "P1"}).
+		FirstOrCreate(db))
+	d := Document{
+		GoogleFileID: "fileID1",
+		DocumentType: DocumentType{Name: "DT1"},
+		Owner:        &User{EmailAddress: "owner@example.com"},
+		Product:      Product{Name: "Product1"},
+	}
+	require.NoError(t, d.Create(db))
+
+	// Read the document twice, like two concurrent PATCH requests.
+	a, b := Document{GoogleFileID: "fileID1"}, Document{GoogleFileID: "fileID1"}
+	require.NoError(t, a.Get(db))
+	require.NoError(t, b.Get(db))
+	assert.Equal(t, 1, a.Version)
+
+	a.Title = "A"
+	require.NoError(t, a.Upsert(db))
+	assert.Equal(t, 2, a.Version)
+
+	b.Title = "B"
+	assert.ErrorIs(t, b.Upsert(db), ErrDocumentVersionConflict)
+
+	got := Document{GoogleFileID: "fileID1"}
+	require.NoError(t, got.Get(db))
+	assert.Equal(t, "A", got.Title, "conflicting update must not be saved")
+	assert.Equal(t, 2, got.Version)
+
+	// Documents that weren't read are updated unconditionally.
+	c := Document{
+		GoogleFileID: "fileID1",
+		DocumentType: DocumentType{Name: "DT1"},
+		Owner:        &User{EmailAddress: "owner@example.com"},
+		Product:      Product{Name: "Product1"},
+		Title:        "C",
+	}
+	require.NoError(t, c.Upsert(db))
+	assert.Equal(t, 3, c.Version)
+}
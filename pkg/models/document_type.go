@@ -2,6 +2,7 @@ package models
 
 import (
 	"fmt"
+	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"gorm.io/gorm"
@@ -43,6 +44,49 @@ type DocumentType struct {
 	// Checks are document type checks, which require acknowledging a check box in
 	// order to publish a document.
 	Checks JSON
+
+	// Managed is true if the document type is managed with the admin API
+	// instead of being defined in the configuration.
+	Managed bool `gorm:"not null;default:false"`
+
+	// Workflow is the review workflow of documents of the document type.
+	Workflow DocumentTypeWorkflow `gorm:"serializer:json;type:jsonb"`
+}
+
+// DocumentTypeWorkflow is the review workflow of documents of a document type.
+type DocumentTypeWorkflow struct {
+	// ReviewSLA is how long documents are expected to be in review before
+	// they're approved. Reviews aren't tracked if it's zero.
+	ReviewSLA time.Duration `json:"reviewSLA,omitempty"`
+
+	// RequiredApprovals is the number of approvals documents need before they
+	// can be marked approved.
+	RequiredApprovals int `json:"requiredApprovals,omitempty"`
+
+	// HeaderCustomFields are the names of the custom fields shown in document
+	// headers, in order. All custom fields are shown if it's empty.
+	HeaderCustomFields []string `json:"headerCustomFields,omitempty"`
+
+	// ReviewChecklist are the items of the checklist completed on documents
+	// during review.
+	ReviewChecklist []DocumentTypeChecklistItem `json:"reviewChecklist,omitempty"`
+}
+
+// DocumentTypeChecklistItem is an item of the review checklist of a document
+// type.
+type DocumentTypeChecklistItem struct {
+	// Name is the identifier of the item.
+	Name string `json:"name"`
+
+	// Label is the displayed text of the item.
+	Label string `json:"label"`
+
+	// HelperText contains more details for the item.
+	HelperText string `json:"helperText,omitempty"`
+
+	// RequiredFor is the document status that the item must be checked before
+	// documents can move to, or empty if the item is optional.
+	RequiredFor string `json:"requiredFor,omitempty"`
 }
 
 // DocumentTypes is a slice of document types.
@@ -82,6 +126,7 @@ func (d *DocumentType) Upsert(db *gorm.DB) error {
 		return err
 	}
 
+	cols := *d
 	return db.Transaction(func(tx *gorm.DB) error {
 		if err := tx.
 			Where(DocumentType{
@@ -94,6 +139,25 @@ func (d *DocumentType) Upsert(db *gorm.DB) error {
 			return err
 		}
 
+		// Assign skips zero values, so fields that can be unset are updated
+		// explicitly.
+		if err := tx.
+			Model(d).
+			Select("Description", "FlightIcon", "MoreInfoLinkText",
+				"MoreInfoLinkURL", "Checks", "Managed", "Workflow").
+			Updates(&DocumentType{
+				Description:      cols.Description,
+				FlightIcon:       cols.FlightIcon,
+				MoreInfoLinkText: cols.MoreInfoLinkText,
+				MoreInfoLinkURL:  cols.MoreInfoLinkURL,
+				Checks:           cols.Checks,
+				Managed:          cols.Managed,
+				Workflow:         cols.Workflow,
+			}).
+			Error; err != nil {
+			return err
+		}
+
 		if err := d.upsertAssocations(tx); err != nil {
 			return fmt.Errorf("error upserting associations: %w", err)
 		}
@@ -106,6 +170,61 @@ func (d *DocumentType) Upsert(db *gorm.DB) error {
 	})
 }
 
+// Delete deletes the document type, its custom fields, and its templates from
+// database db. Document types of documents can't be deleted.
+func (d *DocumentType) Delete(db *gorm.DB) error {
+	if d.ID == 0 {
+		return fmt.Errorf("ID is required")
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.
+			Unscoped().
+			Where("document_type_id = ?", d.ID).
+			Delete(&DocumentTypeCustomField{}).
+			Error; err != nil {
+			return fmt.Errorf("error deleting custom fields: %w", err)
+		}
+		if err := tx.
+			Where("document_type_id = ?", d.ID).
+			Delete(&DocumentTypeTemplate{}).
+			Error; err != nil {
+			return fmt.Errorf("error deleting templates: %w", err)
+		}
+
+		// Delete the record rather than soft-deleting it, so the name can be
+		// used again.
+		return tx.
+			Unscoped().
+			Delete(d).
+			Error
+	})
+}
+
+// CountDocuments returns the number of documents of the document type in
+// database db, including deleted ones.
+func (d *DocumentType) CountDocuments(db *gorm.DB) (int64, error) {
+	var n int64
+	err := db.
+		Unscoped().
+		Model(&Document{}).
+		Where("document_type_id = ?", d.ID).
+		Count(&n).
+		Error
+	return n, err
+}
+
+// FindManaged finds the document types managed with the admin API in database
+// db, ordered by name.
+func (d *DocumentTypes) FindManaged(db *gorm.DB) error {
+	return db.
+		Where("managed = ?", true).
+		Preload("CustomFields").
+		Order("name").
+		Find(d).
+		Error
+}
+
 // upsertAssocations creates required assocations.
 func (d *DocumentType) upsertAssocations(db *gorm.DB) error {
 	// Custom fields.
@@ -1,7 +1,9 @@
 package models
 
 import (
+	"encoding/json"
 	"fmt"
+	"strings"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"gorm.io/gorm"
@@ -16,6 +18,9 @@ type DocumentTypeCustomField struct {
 	DocumentType   DocumentType
 	ReadOnly       bool
 	Type           DocumentTypeCustomFieldType
+
+	// Options are the allowed values of enum custom fields.
+	Options JSON
 }
 
 type DocumentTypeCustomFieldType int
@@ -25,8 +30,68 @@ const (
 	StringDocumentTypeCustomFieldType
 	PersonDocumentTypeCustomFieldType
 	PeopleDocumentTypeCustomFieldType
+	EnumDocumentTypeCustomFieldType
+	DateDocumentTypeCustomFieldType
+	NumberDocumentTypeCustomFieldType
+	BooleanDocumentTypeCustomFieldType
 )
 
+// documentTypeCustomFieldTypeNames are the names of the custom field types, as
+// used in the configuration and admin API.
+var documentTypeCustomFieldTypeNames = map[DocumentTypeCustomFieldType]string{
+	StringDocumentTypeCustomFieldType:  "string",
+	PersonDocumentTypeCustomFieldType:  "person",
+	PeopleDocumentTypeCustomFieldType:  "people",
+	EnumDocumentTypeCustomFieldType:    "enum",
+	DateDocumentTypeCustomFieldType:    "date",
+	NumberDocumentTypeCustomFieldType:  "number",
+	BooleanDocumentTypeCustomFieldType: "boolean",
+}
+
+// ParseDocumentTypeCustomFieldType parses a custom field type name (e.g.,
+// "string" or "enum"), case-insensitively.
+func ParseDocumentTypeCustomFieldType(
+	s string) (DocumentTypeCustomFieldType, bool) {
+	s = strings.ToLower(s)
+	for t, name := range documentTypeCustomFieldTypeNames {
+		if name == s {
+			return t, true
+		}
+	}
+	return UnspecifiedDocumentTypeCustomFieldType, false
+}
+
+// String returns the name of the custom field type.
+func (t DocumentTypeCustomFieldType) String() string {
+	return documentTypeCustomFieldTypeNames[t]
+}
+
+// GetOptions returns the allowed values of an enum custom field.
+func (d DocumentTypeCustomField) GetOptions() ([]string, error) {
+	if len(d.Options) == 0 || string(d.Options) == "null" {
+		return nil, nil
+	}
+	var opts []string
+	if err := json.Unmarshal(d.Options, &opts); err != nil {
+		return nil, fmt.Errorf("error unmarshaling options: %w", err)
+	}
+	return opts, nil
+}
+
+// SetOptions sets the allowed values of an enum custom field.
+func (d *DocumentTypeCustomField) SetOptions(opts []string) error {
+	if len(opts) == 0 {
+		d.Options = nil
+		return nil
+	}
+	b, err := json.Marshal(opts)
+	if err != nil {
+		return fmt.Errorf("error marshaling options: %w", err)
+	}
+	d.Options = b
+	return nil
+}
+
 // Get gets a document type custom field from database db by name and document
 // type name, and assigns it to the receiver.
 func (d *DocumentTypeCustomField) Get(db *gorm.DB) error {
@@ -86,6 +151,13 @@ func (d *DocumentTypeCustomField) Upsert(db *gorm.DB) error {
 	}
 
 	return db.Transaction(func(tx *gorm.DB) error {
+		// Assign doesn't update zero values, like a cleared read-only flag or
+		// options, so they are updated explicitly.
+		fields := DocumentTypeCustomField{
+			ReadOnly: d.ReadOnly,
+			Type:     d.Type,
+			Options:  d.Options,
+		}
 		if err := tx.
 			Where(DocumentTypeCustomField{
 				Name:           d.Name,
@@ -97,6 +169,14 @@ func (d *DocumentTypeCustomField) Upsert(db *gorm.DB) error {
 			Error; err != nil {
 			return err
 		}
+		if err := tx.
+			Model(d).
+			Omit(clause.Associations).
+			Select("ReadOnly", "Type", "Options").
+			Updates(fields).
+			Error; err != nil {
+			return err
+		}
 
 		if err := d.Get(tx); err != nil {
 			return fmt.Errorf("error getting the record after upsert: %w", err)
@@ -105,3 +185,51 @@ func (d *DocumentTypeCustomField) Upsert(db *gorm.DB) error {
 		return nil
 	})
 }
+
+// Delete deletes the receiver, which must have been retrieved with Get, from
+// database db. Custom fields with values can't be deleted.
+func (d *DocumentTypeCustomField) Delete(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		n, err := d.CountValues(tx)
+		if err != nil {
+			return err
+		}
+		if n > 0 {
+			return fmt.Errorf("custom field has %d value(s)", n)
+		}
+		return tx.
+			Unscoped().
+			Omit(clause.Associations).
+			Delete(d).
+			Error
+	})
+}
+
+// CountValues returns the number of documents with a value for the receiver,
+// which must have been retrieved with Get.
+func (d *DocumentTypeCustomField) CountValues(db *gorm.DB) (int64, error) {
+	var n int64
+	if err := db.
+		Model(&DocumentCustomField{}).
+		Where(DocumentCustomField{DocumentTypeCustomFieldID: d.ID}).
+		Count(&n).
+		Error; err != nil {
+		return 0, fmt.Errorf("error counting custom field values: %w", err)
+	}
+	return n, nil
+}
+
+// Values returns the distinct values of the receiver, which must have been
+// retrieved with Get.
+func (d *DocumentTypeCustomField) Values(db *gorm.DB) ([]string, error) {
+	var vals []string
+	if err := db.
+		Model(&DocumentCustomField{}).
+		Where(DocumentCustomField{DocumentTypeCustomFieldID: d.ID}).
+		Distinct().
+		Pluck("value", &vals).
+		Error; err != nil {
+		return nil, fmt.Errorf("error getting custom field values: %w", err)
+	}
+	return vals, nil
+}
@@ -158,4 +158,79 @@ func TestDocumentTypeCustomFieldModel(t *testing.T) {
 				assert.Equal("DT2", d.DocumentType.Name)
 			})
 	})
+
+	t.Run("Enum options and Delete", func(t *testing.T) {
+		db, tearDownTest := setupTest(t, dsn)
+		defer tearDownTest(t)
+
+		dt := DocumentType{
+			Name:     "DT1",
+			LongName: "DocumentType1",
+		}
+		require.NoError(t, dt.FirstOrCreate(db))
+
+		t.Run("Upsert an enum custom field with options", func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+
+			d := DocumentTypeCustomField{
+				Name: "Priority",
+				DocumentType: DocumentType{
+					Name: "DT1",
+				},
+				Type:     EnumDocumentTypeCustomFieldType,
+				ReadOnly: true,
+			}
+			require.NoError(d.SetOptions([]string{"Low", "High"}))
+			require.NoError(d.Upsert(db))
+
+			got := DocumentTypeCustomField{
+				Name: "Priority",
+				DocumentType: DocumentType{
+					Name: "DT1",
+				},
+			}
+			require.NoError(got.Get(db))
+			assert.Equal(EnumDocumentTypeCustomFieldType, got.Type)
+			assert.True(got.ReadOnly)
+			opts, err := got.GetOptions()
+			require.NoError(err)
+			assert.Equal([]string{"Low", "High"}, opts)
+		})
+
+		t.Run("Change the type and clear options and read-only with Upsert",
+			func(t *testing.T) {
+				assert, require := assert.New(t), require.New(t)
+
+				d := DocumentTypeCustomField{
+					Name: "Priority",
+					DocumentType: DocumentType{
+						Name: "DT1",
+					},
+					Type: StringDocumentTypeCustomFieldType,
+				}
+				require.NoError(d.Upsert(db))
+				assert.Equal(StringDocumentTypeCustomFieldType, d.Type)
+				assert.False(d.ReadOnly)
+				opts, err := d.GetOptions()
+				require.NoError(err)
+				assert.Empty(opts)
+			})
+
+		t.Run("Delete the custom field", func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+
+			d := DocumentTypeCustomField{
+				Name: "Priority",
+				DocumentType: DocumentType{
+					Name: "DT1",
+				},
+			}
+			require.NoError(d.Get(db))
+			n, err := d.CountValues(db)
+			require.NoError(err)
+			assert.Zero(n)
+			require.NoError(d.Delete(db))
+			assert.Error(d.Get(db))
+		})
+	})
 }
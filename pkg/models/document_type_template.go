@@ -0,0 +1,120 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DocumentTypeTemplate is a named template that drafts of a document type can
+// be created from (e.g., a short and a full RFC template).
+type DocumentTypeTemplate struct {
+	ID uint `gorm:"primaryKey" json:"-"`
+
+	DocumentTypeID uint         `gorm:"not null;uniqueIndex:idx_document_type_templates_name,priority:1" json:"-"`
+	DocumentType   DocumentType `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+
+	// Name identifies the template in requests to create drafts.
+	Name string `gorm:"type:varchar(100);not null;uniqueIndex:idx_document_type_templates_name,priority:2" json:"name"`
+
+	// LongName is the name of the template displayed in the UI.
+	LongName string `gorm:"type:varchar(255)" json:"longName"`
+
+	// Description is the description of the template.
+	Description string `gorm:"type:text" json:"description"`
+
+	// TemplateID is the file ID of the template.
+	TemplateID string `gorm:"type:varchar(255);not null" json:"-"`
+
+	// Default is true for the template drafts are created from if no template
+	// is selected.
+	Default bool `gorm:"column:is_default;not null;default:false" json:"default"`
+
+	CreatedAt time.Time `json:"-"`
+	UpdatedAt time.Time `json:"-"`
+}
+
+// DocumentTypeTemplates is a slice of document type templates.
+type DocumentTypeTemplates []DocumentTypeTemplate
+
+// TableName specifies the table name.
+func (DocumentTypeTemplate) TableName() string {
+	return "document_type_templates"
+}
+
+// Find finds the templates of the document type named docType in database db,
+// the default template first.
+func (ts *DocumentTypeTemplates) Find(db *gorm.DB, docType string) error {
+	return db.
+		Joins("JOIN document_types ON document_types.id = "+
+			"document_type_templates.document_type_id").
+		Where("document_types.name = ?", docType).
+		Order("document_type_templates.is_default DESC").
+		Order("document_type_templates.name").
+		Find(ts).
+		Error
+}
+
+// Get gets the template named name of the document type named docType from
+// database db, and assigns it to the receiver.
+func (t *DocumentTypeTemplate) Get(db *gorm.DB, docType, name string) error {
+	return db.
+		Joins("JOIN document_types ON document_types.id = "+
+			"document_type_templates.document_type_id").
+		Where("document_types.name = ? AND document_type_templates.name = ?",
+			docType, name).
+		First(t).
+		Error
+}
+
+// SetDocumentTypeTemplates replaces the templates of document type docTypeID
+// in database db with ts.
+func SetDocumentTypeTemplates(
+	db *gorm.DB, docTypeID uint, ts DocumentTypeTemplates,
+) error {
+	names := make([]string, 0, len(ts))
+	for i := range ts {
+		t := &ts[i]
+		t.DocumentTypeID = docTypeID
+		if err := validation.ValidateStruct(t,
+			validation.Field(&t.Name, validation.Required),
+			validation.Field(&t.TemplateID, validation.Required),
+		); err != nil {
+			return fmt.Errorf("invalid template %q: %w", t.Name, err)
+		}
+		names = append(names, t.Name)
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		q := tx.Where("document_type_id = ?", docTypeID)
+		if len(names) > 0 {
+			q = q.Where("name NOT IN ?", names)
+		}
+		if err := q.Delete(&DocumentTypeTemplate{}).Error; err != nil {
+			return fmt.Errorf("error deleting document type templates: %w", err)
+		}
+		if len(ts) == 0 {
+			return nil
+		}
+
+		if err := tx.
+			Omit("DocumentType").
+			Clauses(clause.OnConflict{
+				Columns: []clause.Column{
+					{Name: "document_type_id"}, {Name: "name"},
+				},
+				DoUpdates: clause.AssignmentColumns([]string{
+					"long_name", "description", "template_id", "is_default",
+					"updated_at",
+				}),
+			}).
+			Create(&ts).
+			Error; err != nil {
+			return fmt.Errorf("error upserting document type templates: %w", err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestDocumentTypeTemplateModel(t *testing.T) {
+	dsn := os.Getenv("HERMES_TEST_POSTGRESQL_DSN")
+	if dsn == "" {
+		t.Skip("HERMES_TEST_POSTGRESQL_DSN environment variable isn't set")
+	}
+
+	db, tearDownTest := setupTest(t, dsn)
+	defer tearDownTest(t)
+
+	dt := DocumentType{Name: "RFC", LongName: "Request for Comments"}
+	require.NoError(t, dt.FirstOrCreate(db))
+
+	t.Run("Set validates templates", func(t *testing.T) {
+		assert.Error(t, SetDocumentTypeTemplates(db, dt.ID, DocumentTypeTemplates{
+			{Name: "short"},
+		}))
+	})
+
+	t.Run("Set, find, and get", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+
+		require.NoError(SetDocumentTypeTemplates(db, dt.ID, DocumentTypeTemplates{
+			{Name: "short", TemplateID: "file2"},
+			{Name: "adr", TemplateID: "file3"},
+			{Name: "default", TemplateID: "file1", Default: true},
+		}))
+
+		var ts DocumentTypeTemplates
+		require.NoError(ts.Find(db, "RFC"))
+		require.Len(ts, 3)
+		assert.Equal("default", ts[0].Name)
+		assert.True(ts[0].Default)
+		assert.Equal("adr", ts[1].Name)
+		assert.Equal("short", ts[2].Name)
+
+		var tmpl DocumentTypeTemplate
+		require.NoError(tmpl.Get(db, "RFC", "short"))
+		assert.Equal("file2", tmpl.TemplateID)
+		assert.ErrorIs(tmpl.Get(db, "RFC", "full"), gorm.ErrRecordNotFound)
+	})
+
+	t.Run("Set replaces templates", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+
+		require.NoError(SetDocumentTypeTemplates(db, dt.ID, DocumentTypeTemplates{
+			{Name: "default", TemplateID: "file4", Default: true},
+			{Name: "short", TemplateID: "file2", LongName: "Short RFC"},
+		}))
+
+		var ts DocumentTypeTemplates
+		require.NoError(ts.Find(db, "RFC"))
+		require.Len(ts, 2)
+		assert.Equal("file4", ts[0].TemplateID)
+		assert.Equal("Short RFC", ts[1].LongName)
+	})
+}
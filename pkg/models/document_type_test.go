@@ -3,6 +3,7 @@ package models
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -140,4 +141,63 @@ func TestDocumentType(t *testing.T) {
 			assert.Equal(PeopleDocumentTypeCustomFieldType, d.CustomFields[2].Type)
 		})
 	})
+
+	t.Run("Upsert managed document type and delete it", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		db, tearDownTest := setupTest(t, dsn)
+		defer tearDownTest(t)
+
+		d := DocumentType{
+			Name:        "DT2",
+			LongName:    "DocumentType2",
+			Description: "Description",
+			Managed:     true,
+			Workflow: DocumentTypeWorkflow{
+				ReviewSLA:          48 * time.Hour,
+				RequiredApprovals:  2,
+				HeaderCustomFields: []string{"Stakeholders"},
+				ReviewChecklist: []DocumentTypeChecklistItem{
+					{
+						Name:        "security-review",
+						Label:       "Security review done",
+						RequiredFor: "In-Review",
+					},
+				},
+			},
+			CustomFields: []DocumentTypeCustomField{
+				{
+					Name: "Stakeholders",
+					Type: PeopleDocumentTypeCustomFieldType,
+				},
+			},
+		}
+		require.NoError(d.Upsert(db))
+
+		var managed DocumentTypes
+		require.NoError(managed.FindManaged(db))
+		require.Len(managed, 1)
+		assert.Equal("DT2", managed[0].Name)
+		assert.Equal(d.Workflow, managed[0].Workflow)
+		require.Len(managed[0].CustomFields, 1)
+
+		// Fields can be unset.
+		d = DocumentType{
+			Name:     "DT2",
+			LongName: "DocumentType2",
+			Managed:  true,
+		}
+		require.NoError(d.Upsert(db))
+		assert.Empty(d.Description)
+		assert.Zero(d.Workflow)
+
+		n, err := d.CountDocuments(db)
+		require.NoError(err)
+		assert.Zero(n)
+
+		require.NoError(d.Delete(db))
+		require.NoError(managed.FindManaged(db))
+		assert.Empty(managed)
+		err = (&DocumentType{Name: "DT2"}).Get(db)
+		assert.ErrorIs(err, gorm.ErrRecordNotFound)
+	})
 }
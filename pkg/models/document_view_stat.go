@@ -0,0 +1,76 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DocumentViewStat is the number of times a user viewed a document on a day
+// (UTC). Views are aggregated by day so view counts and unique readers of
+// documents can be reported without keeping every view, and are kept after
+// the user activity retention period.
+type DocumentViewStat struct {
+	DocumentID uint     `gorm:"primaryKey;autoIncrement:false"`
+	Document   Document `gorm:"constraint:OnDelete:CASCADE"`
+
+	UserID uint `gorm:"primaryKey;autoIncrement:false"`
+	User   User `gorm:"constraint:OnDelete:CASCADE"`
+
+	// Day is the day of the views, at midnight UTC.
+	Day time.Time `gorm:"type:date;primaryKey;index"`
+
+	Views int `gorm:"not null;default:0"`
+}
+
+// DocumentViewStats is a slice of document view stats.
+type DocumentViewStats []DocumentViewStat
+
+// TableName specifies the table name.
+func (DocumentViewStat) TableName() string {
+	return "document_view_stats"
+}
+
+// ViewDay returns the day that a view at time t is counted on.
+func ViewDay(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// IncrementDocumentViews counts a view of document docID by user userID at
+// time at in database db.
+func IncrementDocumentViews(db *gorm.DB, docID, userID uint, at time.Time) error {
+	s := DocumentViewStat{
+		DocumentID: docID,
+		UserID:     userID,
+		Day:        ViewDay(at),
+		Views:      1,
+	}
+	if err := db.
+		Omit("Document", "User").
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{
+				{Name: "document_id"}, {Name: "user_id"}, {Name: "day"},
+			},
+			DoUpdates: clause.Assignments(map[string]any{
+				"views": gorm.Expr("document_view_stats.views + 1"),
+			}),
+		}).
+		Create(&s).
+		Error; err != nil {
+		return fmt.Errorf("error incrementing document views: %w", err)
+	}
+	return nil
+}
+
+// Find finds the view stats of document docID on days since time since, by
+// day.
+func (s *DocumentViewStats) Find(db *gorm.DB, docID uint, since time.Time) error {
+	return db.
+		Where("document_id = ? AND day >= ?", docID, ViewDay(since)).
+		Order("day, user_id").
+		Find(s).
+		Error
+}
@@ -0,0 +1,112 @@
+package models
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// EdgeInstance is an edge Hermes instance that syncs documents with central
+// (RFC-085). Edge instances are recorded by their first heartbeat or sync.
+type EdgeInstance struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Name is the unique name of the edge instance.
+	Name string `gorm:"type:varchar(255);not null;unique" json:"name"`
+
+	// Version is the Hermes version of the edge instance.
+	Version string `gorm:"type:varchar(50)" json:"version,omitempty"`
+
+	// Hostname is the hostname of the edge instance.
+	Hostname string `gorm:"type:varchar(255)" json:"hostname,omitempty"`
+
+	// RemoteAddr is the address the last heartbeat was received from.
+	RemoteAddr string `gorm:"type:varchar(255)" json:"remote_addr,omitempty"`
+
+	// DocumentCount is the number of documents on the edge instance.
+	DocumentCount int `gorm:"default:0" json:"document_count"`
+
+	// ConflictCount is the number of documents in conflict on the edge
+	// instance.
+	ConflictCount int `gorm:"default:0" json:"conflict_count"`
+
+	// LastHeartbeatAt is when the last heartbeat was received.
+	LastHeartbeatAt *time.Time `json:"last_heartbeat_at,omitempty"`
+
+	// LastSyncAt is when the edge instance last synced documents.
+	LastSyncAt *time.Time `json:"last_sync_at,omitempty"`
+}
+
+// EdgeInstances is a slice of edge instances.
+type EdgeInstances []EdgeInstance
+
+// RecordHeartbeat creates or updates the edge instance by name with the
+// heartbeat fields of e, setting its last heartbeat to now.
+func (e *EdgeInstance) RecordHeartbeat(db *gorm.DB) error {
+	if err := validation.ValidateStruct(e,
+		validation.Field(&e.Name, validation.Required),
+	); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	e.LastHeartbeatAt = &now
+	return db.
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "name"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"version", "hostname", "remote_addr", "document_count",
+				"conflict_count", "last_heartbeat_at", "updated_at",
+			}),
+		}).
+		Create(e).
+		Error
+}
+
+// RecordEdgeSync creates or updates the edge instance with name, setting its
+// last sync to now.
+func RecordEdgeSync(db *gorm.DB, name string) error {
+	now := time.Now()
+	e := EdgeInstance{Name: name, LastSyncAt: &now}
+	if err := validation.ValidateStruct(&e,
+		validation.Field(&e.Name, validation.Required),
+	); err != nil {
+		return err
+	}
+
+	return db.
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "name"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"last_sync_at", "updated_at",
+			}),
+		}).
+		Create(&e).
+		Error
+}
+
+// FindAll finds all edge instances, ordered by name.
+func (es *EdgeInstances) FindAll(db *gorm.DB) error {
+	return db.Order("name").Find(es).Error
+}
+
+// LastSeenAt returns when the edge instance was last heard from, or nil if
+// never.
+func (e *EdgeInstance) LastSeenAt() *time.Time {
+	last := e.LastHeartbeatAt
+	if e.LastSyncAt != nil && (last == nil || e.LastSyncAt.After(*last)) {
+		last = e.LastSyncAt
+	}
+	return last
+}
+
+// IsStale returns true if the edge instance wasn't heard from within
+// staleAfter of now.
+func (e *EdgeInstance) IsStale(now time.Time, staleAfter time.Duration) bool {
+	last := e.LastSeenAt()
+	return last == nil || now.Sub(*last) > staleAfter
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEdgeInstanceIsStale(t *testing.T) {
+	now := time.Now()
+	old, recent := now.Add(-time.Hour), now.Add(-time.Minute)
+
+	assert.True(t, (&EdgeInstance{}).IsStale(now, 15*time.Minute))
+	assert.True(t, (&EdgeInstance{LastHeartbeatAt: &old}).
+		IsStale(now, 15*time.Minute))
+	assert.False(t, (&EdgeInstance{LastHeartbeatAt: &recent}).
+		IsStale(now, 15*time.Minute))
+	assert.False(t, (&EdgeInstance{LastHeartbeatAt: &old, LastSyncAt: &recent}).
+		IsStale(now, 15*time.Minute), "syncs count as contact")
+}
+
+func TestEdgeInstanceModel(t *testing.T) {
+	dsn := os.Getenv("HERMES_TEST_POSTGRESQL_DSN")
+	if dsn == "" {
+		t.Skip("HERMES_TEST_POSTGRESQL_DSN environment variable isn't set")
+	}
+
+	db, tearDownTest := setupTest(t, dsn)
+	defer tearDownTest(t)
+
+	t.Run("RecordHeartbeat requires a name", func(t *testing.T) {
+		assert.Error(t, (&EdgeInstance{}).RecordHeartbeat(db))
+	})
+
+	t.Run("heartbeats and syncs upsert by name", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+
+		e := EdgeInstance{Name: "laptop", Version: "1.0.0", DocumentCount: 3}
+		require.NoError(e.RecordHeartbeat(db))
+		require.NoError(RecordEdgeSync(db, "laptop"))
+		require.NoError(RecordEdgeSync(db, "office"))
+
+		e = EdgeInstance{Name: "laptop", Version: "1.1.0", DocumentCount: 4}
+		require.NoError(e.RecordHeartbeat(db))
+
+		var edges EdgeInstances
+		require.NoError(edges.FindAll(db))
+		require.Len(edges, 2)
+		assert.Equal("laptop", edges[0].Name)
+		assert.Equal("1.1.0", edges[0].Version)
+		assert.Equal(4, edges[0].DocumentCount)
+		assert.NotNil(edges[0].LastSyncAt)
+		assert.NotNil(edges[0].LastHeartbeatAt)
+		assert.Equal("office", edges[1].Name)
+		assert.Nil(edges[1].LastHeartbeatAt)
+	})
+}
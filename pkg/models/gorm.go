@@ -12,27 +12,56 @@ func ModelsToAutoMigrate() []interface{} {
 	// - document_types: missing flight_icon, more_info_link_text, more_info_link_url, checks
 	// - (likely others - needs full audit)
 	return []interface{}{
+		&ApprovalLink{},
+		&AuditEvent{},
 		&DocumentType{},
 		&Document{},
+		&DocumentAttachment{},
+		&DocumentBrokenLink{},
+		&DocumentChecklistItem{},
 		&DocumentCustomField{},
+		&DocumentDuplicate{},
 		&DocumentFileRevision{},
+		&DocumentFingerprint{},
+		&DocumentIntegrity{},
+		&DocumentLock{},
+		&DocumentQualityCheck{},
 		&DocumentRevision{},
+		&DocumentRun{},
+		&DocumentRunItem{},
+		&DocumentSensitiveFinding{},
+		&DocumentViewStat{},
 		DocumentGroupReview{},
+		&DocumentGroupReviewApproval{},
 		&DocumentRelatedResource{},
 		&DocumentRelatedResourceExternalLink{},
 		&DocumentRelatedResourceHermesDocument{},
 		&DocumentReview{},
 		&DocumentTypeCustomField{},
+		&DocumentTypeTemplate{},
+		&EdgeInstance{},
 		&Group{},
+		&IdempotencyKey{},
+		&ImpersonationSession{},
 		// &IndexerFolder{}, // Commented out - causing GORM constraint rename bug
 		&IndexerMetadata{},
+		&Job{},
 		&Product{},
 		&ProductLatestDocumentNumber{},
 		&Project{},
 		&ProjectRelatedResource{},
 		&ProjectRelatedResourceExternalLink{},
 		&ProjectRelatedResourceHermesDocument{},
+		&ReviewDelegation{},
+		&SavedSearch{},
+		&SearchStopWord{},
+		&SearchSynonymSet{},
+		&Tenant{},
 		&User{},
+		&UserActivity{},
+		&UserDirectoryEntry{},
+		&UserRole{},
+		&WorkerInstance{},
 		&WorkspaceProject{},
 		// Do NOT include: HermesInstance, Indexer, IndexerToken (fully in migrations)
 	}
@@ -0,0 +1,152 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// ErrIdempotencyKeyExists is returned by IdempotencyKey.Create when the user
+// already has a record for the key.
+var ErrIdempotencyKeyExists = errors.New("idempotency key already exists")
+
+// IdempotencyKey records the outcome of a request made with an
+// Idempotency-Key header so retries of the same request replay the original
+// response instead of creating duplicate resources.
+type IdempotencyKey struct {
+	ID uint `gorm:"primaryKey" json:"id"`
+
+	// Key is the client-supplied Idempotency-Key header value.
+	Key string `gorm:"type:varchar(255);not null;uniqueIndex:idx_idempotency_keys_user_key" json:"key"`
+
+	// UserEmail scopes the key to the authenticated user so keys from
+	// different users cannot collide.
+	UserEmail string `gorm:"type:varchar(320);not null;uniqueIndex:idx_idempotency_keys_user_key" json:"userEmail"`
+
+	// Method and Path identify the endpoint the key was used with.
+	Method string `gorm:"type:varchar(10);not null" json:"method"`
+	Path   string `gorm:"type:text;not null" json:"path"`
+
+	// RequestHash is the SHA-256 hash of the request method, path, and body.
+	// Reusing a key with a different request is rejected.
+	RequestHash string `gorm:"type:varchar(64);not null" json:"requestHash"`
+
+	// Completed is false while the original request is still in flight.
+	Completed bool `gorm:"default:false" json:"completed"`
+
+	// ResponseStatus, ResponseContentType, and ResponseBody store the
+	// original response for replay.
+	ResponseStatus      int    `json:"responseStatus"`
+	ResponseContentType string `gorm:"type:varchar(255)" json:"responseContentType"`
+	ResponseBody        []byte `json:"-"`
+
+	// ExpiresAt is when the key may be reused.
+	ExpiresAt time.Time `gorm:"not null;index" json:"expiresAt"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// TableName specifies the table name.
+func (IdempotencyKey) TableName() string {
+	return "idempotency_keys"
+}
+
+// Create creates a new in-flight idempotency key record.
+func (k *IdempotencyKey) Create(db *gorm.DB) error {
+	if k.Key == "" {
+		return fmt.Errorf("key is required")
+	}
+	if k.UserEmail == "" {
+		return fmt.Errorf("user email is required")
+	}
+	if k.RequestHash == "" {
+		return fmt.Errorf("request hash is required")
+	}
+	k.Completed = false
+
+	if err := db.Create(k).Error; err != nil {
+		if isUniqueViolation(err) {
+			return ErrIdempotencyKeyExists
+		}
+		return err
+	}
+	return nil
+}
+
+// isUniqueViolation returns true if err is caused by a unique constraint
+// violation in PostgreSQL or SQLite.
+func isUniqueViolation(err error) bool {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return true
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == "23505" // unique_violation
+	}
+	return strings.Contains(err.Error(), "UNIQUE constraint failed")
+}
+
+// Get retrieves an idempotency key record by key and user email.
+func (k *IdempotencyKey) Get(db *gorm.DB) error {
+	if k.Key == "" {
+		return fmt.Errorf("key is required")
+	}
+	if k.UserEmail == "" {
+		return fmt.Errorf("user email is required")
+	}
+
+	return db.
+		Where(IdempotencyKey{Key: k.Key, UserEmail: k.UserEmail}).
+		First(k).
+		Error
+}
+
+// Complete stores the response for the idempotency key.
+func (k *IdempotencyKey) Complete(
+	db *gorm.DB, status int, contentType string, body []byte,
+) error {
+	if k.ID == 0 {
+		return fmt.Errorf("id is required")
+	}
+
+	k.Completed = true
+	k.ResponseStatus = status
+	k.ResponseContentType = contentType
+	k.ResponseBody = body
+
+	return db.Model(k).Updates(map[string]any{
+		"completed":             true,
+		"response_status":       status,
+		"response_content_type": contentType,
+		"response_body":         body,
+	}).Error
+}
+
+// Delete deletes the idempotency key record.
+func (k *IdempotencyKey) Delete(db *gorm.DB) error {
+	if k.ID == 0 {
+		return fmt.Errorf("id is required")
+	}
+
+	return db.Delete(k).Error
+}
+
+// Expired returns true if the key has expired at the provided time.
+func (k *IdempotencyKey) Expired(now time.Time) bool {
+	return !k.ExpiresAt.After(now)
+}
+
+// DeleteExpiredIdempotencyKeys deletes all idempotency keys that expired
+// before the provided time and returns the number of records deleted.
+func DeleteExpiredIdempotencyKeys(db *gorm.DB, now time.Time) (int64, error) {
+	res := db.Where("expires_at <= ?", now).Delete(&IdempotencyKey{})
+	if res.Error != nil && !errors.Is(res.Error, gorm.ErrRecordNotFound) {
+		return 0, res.Error
+	}
+	return res.RowsAffected, nil
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestIsUniqueViolation(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(isUniqueViolation(gorm.ErrDuplicatedKey))
+	assert.True(isUniqueViolation(
+		fmt.Errorf("insert: %w", &pgconn.PgError{Code: "23505"})))
+	assert.True(isUniqueViolation(errors.New(
+		"UNIQUE constraint failed: idempotency_keys.key, idempotency_keys.user_email")))
+
+	assert.False(isUniqueViolation(&pgconn.PgError{Code: "23503"}))
+	assert.False(isUniqueViolation(errors.New("connection refused")))
+}
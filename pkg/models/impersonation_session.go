@@ -0,0 +1,109 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ImpersonationSession records a site admin acting as another user to debug
+// an issue the user reported. While a session is active, the admin's requests
+// are authorized as the user.
+type ImpersonationSession struct {
+	// ID identifies the session in the admin's impersonation cookie.
+	ID uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+
+	// AdminEmail is the email address of the admin who started the session.
+	AdminEmail string `gorm:"type:varchar(320);not null;index" json:"adminEmail"`
+
+	// UserEmail is the email address of the impersonated user.
+	UserEmail string `gorm:"type:varchar(320);not null;index" json:"userEmail"`
+
+	// Reason is why the admin is impersonating the user (e.g., a support
+	// ticket).
+	Reason string `gorm:"type:text;not null" json:"reason"`
+
+	// ExpiresAt is when the session ends if it is not ended earlier.
+	ExpiresAt time.Time `gorm:"not null" json:"expiresAt"`
+
+	// EndedAt is when the admin ended the session, or nil if they did not.
+	EndedAt *time.Time `json:"endedAt,omitempty"`
+
+	CreatedAt time.Time `gorm:"not null;index" json:"createdAt"`
+}
+
+// ImpersonationSessions is a slice of impersonation sessions.
+type ImpersonationSessions []ImpersonationSession
+
+// TableName specifies the table name.
+func (ImpersonationSession) TableName() string {
+	return "impersonation_sessions"
+}
+
+// Create creates the session in database db with a new random ID.
+func (s *ImpersonationSession) Create(db *gorm.DB) error {
+	if err := validation.ValidateStruct(s,
+		validation.Field(&s.AdminEmail, validation.Required),
+		validation.Field(&s.UserEmail, validation.Required,
+			validation.NotIn(s.AdminEmail).Error("must not be the admin")),
+		validation.Field(&s.Reason, validation.Required),
+		validation.Field(&s.ExpiresAt, validation.Required),
+	); err != nil {
+		return err
+	}
+
+	s.ID = uuid.New()
+	if err := db.Create(s).Error; err != nil {
+		return fmt.Errorf("error creating impersonation session: %w", err)
+	}
+	return nil
+}
+
+// Get gets the session by ID from database db, and assigns it to the receiver.
+func (s *ImpersonationSession) Get(db *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		return fmt.Errorf("ID is required")
+	}
+
+	return db.First(s, "id = ?", s.ID).Error
+}
+
+// End ends the session in database db if it is active.
+func (s *ImpersonationSession) End(db *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		return fmt.Errorf("ID is required")
+	}
+	if s.EndedAt != nil {
+		return nil
+	}
+
+	now := time.Now()
+	if err := db.Model(s).Update("ended_at", now).Error; err != nil {
+		return fmt.Errorf("error ending impersonation session: %w", err)
+	}
+	s.EndedAt = &now
+	return nil
+}
+
+// IsActive returns true if the session has not been ended and has not expired
+// at time t.
+func (s ImpersonationSession) IsActive(t time.Time) bool {
+	return s.EndedAt == nil && t.Before(s.ExpiresAt)
+}
+
+// Find finds impersonation sessions, newest first. Ended and expired sessions
+// are only included if includeInactive is true.
+func (s *ImpersonationSessions) Find(db *gorm.DB, includeInactive bool) error {
+	q := db.Model(&ImpersonationSession{})
+	if !includeInactive {
+		q = q.Where("ended_at IS NULL AND expires_at > ?", time.Now())
+	}
+
+	return q.
+		Order("created_at DESC").
+		Find(s).
+		Error
+}
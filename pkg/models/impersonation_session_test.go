@@ -0,0 +1,80 @@
+package models
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImpersonationSessionIsActive(t *testing.T) {
+	now := time.Now()
+	ended := now.Add(-time.Minute)
+
+	assert.True(t, ImpersonationSession{ExpiresAt: now.Add(time.Hour)}.IsActive(now))
+	assert.False(t, ImpersonationSession{ExpiresAt: now}.IsActive(now))
+	assert.False(t, ImpersonationSession{
+		ExpiresAt: now.Add(time.Hour),
+		EndedAt:   &ended,
+	}.IsActive(now))
+}
+
+func TestImpersonationSessionModel(t *testing.T) {
+	dsn := os.Getenv("HERMES_TEST_POSTGRESQL_DSN")
+	if dsn == "" {
+		t.Skip("HERMES_TEST_POSTGRESQL_DSN environment variable isn't set")
+	}
+
+	db, tearDownTest := setupTest(t, dsn)
+	defer tearDownTest(t)
+
+	t.Run("Create validates fields", func(t *testing.T) {
+		s := ImpersonationSession{
+			AdminEmail: "admin@example.com",
+			UserEmail:  "admin@example.com",
+			Reason:     "ticket",
+			ExpiresAt:  time.Now().Add(time.Hour),
+		}
+		assert.Error(t, s.Create(db))
+
+		s = ImpersonationSession{
+			AdminEmail: "admin@example.com",
+			UserEmail:  "user@example.com",
+			ExpiresAt:  time.Now().Add(time.Hour),
+		}
+		assert.Error(t, s.Create(db))
+	})
+
+	t.Run("Create, find, and end", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+
+		s := ImpersonationSession{
+			AdminEmail: "admin@example.com",
+			UserEmail:  "user@example.com",
+			Reason:     "ticket",
+			ExpiresAt:  time.Now().Add(time.Hour),
+		}
+		require.NoError(s.Create(db))
+
+		var active ImpersonationSessions
+		require.NoError(active.Find(db, false))
+		require.Len(active, 1)
+		assert.Equal(s.ID, active[0].ID)
+
+		require.NoError(s.End(db))
+		got := ImpersonationSession{ID: s.ID}
+		require.NoError(got.Get(db))
+		assert.NotNil(got.EndedAt)
+		assert.False(got.IsActive(time.Now()))
+
+		active = nil
+		require.NoError(active.Find(db, false))
+		assert.Empty(active)
+
+		var all ImpersonationSessions
+		require.NoError(all.Find(db, true))
+		assert.Len(all, 1)
+	})
+}
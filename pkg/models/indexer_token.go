@@ -11,6 +11,31 @@ import (
 	"gorm.io/gorm"
 )
 
+// Token scopes limit what a service token can be used for. Tokens without
+// scopes were created before scopes existed and are allowed every scope.
+const (
+	// TokenScopeRead allows reading data.
+	TokenScopeRead = "read"
+
+	// TokenScopeSync allows synchronizing documents, which includes reading
+	// them.
+	TokenScopeSync = "sync"
+
+	// TokenScopeNotifications allows sending notifications.
+	TokenScopeNotifications = "notifications"
+
+	// TokenScopeWorkers allows pipeline workers to report their status.
+	TokenScopeWorkers = "workers"
+)
+
+// TokenScopes are all valid token scopes.
+var TokenScopes = []string{
+	TokenScopeRead,
+	TokenScopeSync,
+	TokenScopeNotifications,
+	TokenScopeWorkers,
+}
+
 // IndexerToken represents an authentication token for an indexer.
 type IndexerToken struct {
 	// ID is the unique token identifier (UUID).
@@ -28,9 +53,20 @@ type IndexerToken struct {
 	// TokenHash is the SHA-256 hash of the token (for secure storage).
 	TokenHash string `gorm:"type:varchar(256);not null;uniqueIndex" json:"-"`
 
-	// TokenType identifies the purpose (registration, api).
+	// TokenType identifies the purpose (registration, api, edge).
 	TokenType string `gorm:"type:varchar(50);default:'api'" json:"token_type"`
 
+	// Name is a human-readable name for the token, such as the edge instance
+	// that uses it.
+	Name string `gorm:"type:varchar(255)" json:"name,omitempty"`
+
+	// Scopes limit what the token can be used for. An empty list allows all
+	// scopes.
+	Scopes StringArray `gorm:"type:jsonb" json:"scopes,omitempty"`
+
+	// LastUsedAt is when the token was last used to authenticate a request.
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+
 	// ExpiresAt is when the token expires (nil = no expiration).
 	ExpiresAt *time.Time `json:"expires_at,omitempty"`
 
@@ -128,6 +164,88 @@ func (t *IndexerToken) Revoke(db *gorm.DB, reason string) error {
 	}).Error
 }
 
+// Rotate creates a new token with the same type, name, scopes, and indexer as
+// t and returns it with its plaintext value. If gracePeriod is positive, t
+// expires after the grace period so clients can switch to the new token;
+// otherwise, t is revoked immediately.
+func (t *IndexerToken) Rotate(
+	db *gorm.DB, gracePeriod time.Duration,
+) (IndexerToken, string, error) {
+	plaintext, err := GenerateToken(t.TokenType)
+	if err != nil {
+		return IndexerToken{}, "", err
+	}
+
+	newToken := IndexerToken{
+		TokenType: t.TokenType,
+		Name:      t.Name,
+		Scopes:    t.Scopes,
+		IndexerID: t.IndexerID,
+		Metadata:  t.Metadata,
+	}
+	if t.ExpiresAt != nil && t.ExpiresAt.After(t.CreatedAt) {
+		// Keep the lifetime of the original token.
+		exp := time.Now().Add(t.ExpiresAt.Sub(t.CreatedAt))
+		newToken.ExpiresAt = &exp
+	}
+
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		if err := newToken.Create(tx, plaintext); err != nil {
+			return fmt.Errorf("error creating new token: %w", err)
+		}
+
+		if gracePeriod > 0 {
+			exp := time.Now().Add(gracePeriod)
+			if t.ExpiresAt == nil || exp.Before(*t.ExpiresAt) {
+				if err := tx.Model(t).Update("expires_at", exp).Error; err != nil {
+					return fmt.Errorf("error setting token expiration: %w", err)
+				}
+				t.ExpiresAt = &exp
+			}
+			return nil
+		}
+
+		if err := t.Revoke(tx, "rotated"); err != nil {
+			return fmt.Errorf("error revoking token: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return IndexerToken{}, "", err
+	}
+
+	return newToken, plaintext, nil
+}
+
+// MarkUsed records that the token was used to authenticate a request.
+func (t *IndexerToken) MarkUsed(db *gorm.DB) error {
+	now := time.Now()
+	t.LastUsedAt = &now
+	return db.Model(t).UpdateColumn("last_used_at", now).Error
+}
+
+// HasScope returns true if the token is allowed the provided scope.
+func (t *IndexerToken) HasScope(scope string) bool {
+	if len(t.Scopes) == 0 {
+		return true
+	}
+	for _, s := range t.Scopes {
+		if s == scope || (s == TokenScopeSync && scope == TokenScopeRead) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidTokenScope returns true if scope is a valid token scope.
+func IsValidTokenScope(scope string) bool {
+	for _, s := range TokenScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
 // IsValid checks if the token is valid (not expired, not revoked).
 func (t *IndexerToken) IsValid() bool {
 	if t.Revoked {
@@ -146,6 +264,21 @@ func (ts *IndexerTokens) FindAll(db *gorm.DB) error {
 	return db.Preload("Indexer").Find(ts).Error
 }
 
+// FindByType retrieves all tokens of a type. Revoked tokens are included if
+// includeRevoked is true.
+func (ts *IndexerTokens) FindByType(
+	db *gorm.DB, tokenType string, includeRevoked bool,
+) error {
+	q := db.Preload("Indexer").Order("created_at DESC")
+	if tokenType != "" {
+		q = q.Where("token_type = ?", tokenType)
+	}
+	if !includeRevoked {
+		q = q.Where("revoked = ?", false)
+	}
+	return q.Find(ts).Error
+}
+
 // FindByIndexer retrieves all tokens for a specific indexer.
 func (ts *IndexerTokens) FindByIndexer(db *gorm.DB, indexerID uuid.UUID) error {
 	return db.Preload("Indexer").Where("indexer_id = ?", indexerID).Find(ts).Error
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndexerTokenHasScope(t *testing.T) {
+	t.Run("token without scopes has every scope", func(t *testing.T) {
+		tok := IndexerToken{}
+		for _, s := range TokenScopes {
+			assert.True(t, tok.HasScope(s))
+		}
+	})
+
+	t.Run("read-only token", func(t *testing.T) {
+		tok := IndexerToken{Scopes: StringArray{TokenScopeRead}}
+		assert.True(t, tok.HasScope(TokenScopeRead))
+		assert.False(t, tok.HasScope(TokenScopeSync))
+		assert.False(t, tok.HasScope(TokenScopeNotifications))
+	})
+
+	t.Run("sync scope includes read", func(t *testing.T) {
+		tok := IndexerToken{Scopes: StringArray{TokenScopeSync}}
+		assert.True(t, tok.HasScope(TokenScopeRead))
+		assert.True(t, tok.HasScope(TokenScopeSync))
+		assert.False(t, tok.HasScope(TokenScopeNotifications))
+	})
+
+	t.Run("notifications token", func(t *testing.T) {
+		tok := IndexerToken{Scopes: StringArray{TokenScopeNotifications}}
+		assert.False(t, tok.HasScope(TokenScopeRead))
+		assert.True(t, tok.HasScope(TokenScopeNotifications))
+	})
+}
+
+func TestIsValidTokenScope(t *testing.T) {
+	assert.True(t, IsValidTokenScope("read"))
+	assert.True(t, IsValidTokenScope("sync"))
+	assert.True(t, IsValidTokenScope("notifications"))
+	assert.False(t, IsValidTokenScope("admin"))
+	assert.False(t, IsValidTokenScope(""))
+}
@@ -0,0 +1,254 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"gorm.io/gorm"
+)
+
+// Job is a background job, such as indexing a document or sending an email
+// after a request. Jobs are queued in the database so they survive restarts
+// and are retried when they fail.
+type Job struct {
+	ID uint `gorm:"primaryKey" json:"id"`
+
+	// Type selects the handler that runs the job (e.g.,
+	// "search.index_document").
+	Type string `gorm:"type:varchar(100);not null;index" json:"type"`
+
+	// Payload is the JSON input of the job handler.
+	Payload JSON `gorm:"type:jsonb" json:"payload"`
+
+	Status JobStatus `gorm:"type:varchar(16);not null;index:idx_jobs_status_run_at,priority:1" json:"status"`
+
+	// Attempts is the number of times the job has been started.
+	Attempts int `gorm:"not null;default:0" json:"attempts"`
+
+	// MaxAttempts is the number of times the job is started before it fails.
+	MaxAttempts int `gorm:"not null" json:"maxAttempts"`
+
+	// LastError is the error of the last failed attempt.
+	LastError string `gorm:"type:text" json:"lastError,omitempty"`
+
+	// RequestID is the ID of the request that enqueued the job, if any.
+	RequestID string `gorm:"type:varchar(128)" json:"requestID,omitempty"`
+
+	// RunAt is when the job may next be started.
+	RunAt time.Time `gorm:"not null;index:idx_jobs_status_run_at,priority:2" json:"runAt"`
+
+	StartedAt  *time.Time `json:"startedAt,omitempty"`
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Jobs is a slice of jobs.
+type Jobs []Job
+
+// JobStatus is the status of a job.
+type JobStatus string
+
+const (
+	// PendingJobStatus jobs are waiting to be started at RunAt.
+	PendingJobStatus JobStatus = "pending"
+
+	// RunningJobStatus jobs have been started and haven't finished.
+	RunningJobStatus JobStatus = "running"
+
+	// SucceededJobStatus jobs finished without an error.
+	SucceededJobStatus JobStatus = "succeeded"
+
+	// FailedJobStatus jobs returned an error on their last attempt.
+	FailedJobStatus JobStatus = "failed"
+)
+
+// JobFilter filters jobs. Empty fields are not filtered on.
+type JobFilter struct {
+	Status JobStatus
+	Type   string
+
+	// BeforeID limits jobs to those with an ID less than BeforeID, for paging
+	// through results.
+	BeforeID uint
+
+	// Limit is the maximum number of jobs to return.
+	Limit int
+}
+
+// TableName specifies the table name.
+func (Job) TableName() string {
+	return "jobs"
+}
+
+// Create enqueues the job as pending in database db. RunAt defaults to now.
+func (j *Job) Create(db *gorm.DB) error {
+	if err := validation.ValidateStruct(j,
+		validation.Field(&j.Type, validation.Required),
+		validation.Field(&j.MaxAttempts, validation.Required, validation.Min(1)),
+	); err != nil {
+		return err
+	}
+
+	j.Status = PendingJobStatus
+	if j.RunAt.IsZero() {
+		j.RunAt = time.Now()
+	}
+
+	if err := db.Create(j).Error; err != nil {
+		return fmt.Errorf("error creating job: %w", err)
+	}
+	return nil
+}
+
+// Get gets the job by ID from database db.
+func (j *Job) Get(db *gorm.DB) error {
+	if j.ID == 0 {
+		return fmt.Errorf("id is required")
+	}
+	return db.First(j, j.ID).Error
+}
+
+// Claim starts the next pending job due at time now and increments its
+// attempts. It returns gorm.ErrRecordNotFound if no job is due.
+//
+// Jobs are claimed with a conditional update, so a job is only started once
+// when several processes share the queue.
+func (j *Job) Claim(db *gorm.DB, now time.Time) error {
+	for {
+		// Find rather than First, so polling an empty queue isn't logged as
+		// an error.
+		var next Jobs
+		if err := db.
+			Where("status = ? AND run_at <= ?", PendingJobStatus, now).
+			Order("run_at, id").
+			Limit(1).
+			Find(&next).Error; err != nil {
+			return err
+		}
+		if len(next) == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		res := db.Model(&Job{}).
+			Where("id = ? AND status = ?", next[0].ID, PendingJobStatus).
+			Updates(map[string]any{
+				"status":     RunningJobStatus,
+				"attempts":   gorm.Expr("attempts + 1"),
+				"started_at": now,
+			})
+		if res.Error != nil {
+			return fmt.Errorf("error claiming job: %w", res.Error)
+		}
+		if res.RowsAffected == 1 {
+			j.ID = next[0].ID
+			return j.Get(db)
+		}
+		// Another process claimed the job first.
+	}
+}
+
+// Succeed records that the running job finished at time now.
+func (j *Job) Succeed(db *gorm.DB, now time.Time) error {
+	j.Status = SucceededJobStatus
+	j.FinishedAt = &now
+	return j.update(db, map[string]any{
+		"status":      j.Status,
+		"finished_at": now,
+	})
+}
+
+// Fail records that the running job returned err at time now. The job is
+// retried at retryAt if it has attempts left, and fails otherwise.
+func (j *Job) Fail(db *gorm.DB, err error, now, retryAt time.Time) error {
+	j.LastError = err.Error()
+	if j.Attempts < j.MaxAttempts {
+		j.Status = PendingJobStatus
+		j.RunAt = retryAt
+		return j.update(db, map[string]any{
+			"status":     j.Status,
+			"last_error": j.LastError,
+			"run_at":     retryAt,
+		})
+	}
+
+	j.Status = FailedJobStatus
+	j.FinishedAt = &now
+	return j.update(db, map[string]any{
+		"status":      j.Status,
+		"last_error":  j.LastError,
+		"finished_at": now,
+	})
+}
+
+// Retry requeues the failed job to run at time now with its attempts reset.
+func (j *Job) Retry(db *gorm.DB, now time.Time) error {
+	if j.Status != FailedJobStatus {
+		return fmt.Errorf("only failed jobs can be retried")
+	}
+
+	j.Status = PendingJobStatus
+	j.Attempts = 0
+	j.RunAt = now
+	j.FinishedAt = nil
+	return j.update(db, map[string]any{
+		"status":      j.Status,
+		"attempts":    0,
+		"run_at":      now,
+		"finished_at": nil,
+	})
+}
+
+func (j *Job) update(db *gorm.DB, fields map[string]any) error {
+	if j.ID == 0 {
+		return fmt.Errorf("id is required")
+	}
+	if err := db.Model(&Job{ID: j.ID}).Updates(fields).Error; err != nil {
+		return fmt.Errorf("error updating job: %w", err)
+	}
+	return nil
+}
+
+// Find finds the jobs matching filter f in database db, newest first.
+func (js *Jobs) Find(db *gorm.DB, f JobFilter) error {
+	q := db.Order("id DESC")
+	if f.Status != "" {
+		q = q.Where("status = ?", f.Status)
+	}
+	if f.Type != "" {
+		q = q.Where("type = ?", f.Type)
+	}
+	if f.BeforeID > 0 {
+		q = q.Where("id < ?", f.BeforeID)
+	}
+	if f.Limit > 0 {
+		q = q.Limit(f.Limit)
+	}
+	return q.Find(js).Error
+}
+
+// RequeueStaleJobs requeues the jobs that were started before time before and
+// are still running (e.g., because their process exited), and returns the
+// number of requeued jobs.
+func RequeueStaleJobs(db *gorm.DB, before time.Time) (int64, error) {
+	res := db.Model(&Job{}).
+		Where("status = ? AND started_at < ?", RunningJobStatus, before).
+		Update("status", PendingJobStatus)
+	return res.RowsAffected, res.Error
+}
+
+// DeleteFinishedJobsBefore deletes the succeeded and failed jobs that finished
+// before time before and returns the number of deleted jobs.
+func DeleteFinishedJobsBefore(db *gorm.DB, before time.Time) (int64, error) {
+	res := db.
+		Where("status IN ? AND finished_at < ?",
+			[]JobStatus{SucceededJobStatus, FailedJobStatus}, before).
+		Delete(&Job{})
+	if res.Error != nil && !errors.Is(res.Error, gorm.ErrRecordNotFound) {
+		return 0, res.Error
+	}
+	return res.RowsAffected, nil
+}
@@ -0,0 +1,68 @@
+// Package modelstest provides fixtures for tests that need a Hermes database
+// but not a PostgreSQL server. Databases are SQLite files in the test's
+// temporary directory, migrated with every model:
+//
+//	func TestJob(t *testing.T) {
+//		db := modelstest.NewDB(t)
+//		doc := modelstest.CreateDocument(t, db, models.Document{
+//			GoogleFileID: "doc1",
+//			Status:       models.InReviewDocumentStatus,
+//		})
+//		...
+//	}
+package modelstest
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// NewDB returns a new SQLite database in the temporary directory of test t,
+// migrated with all models.
+func NewDB(t testing.TB) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(
+		sqlite.Open(filepath.Join(t.TempDir(), "hermes.db")), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.SetupJoinTable(
+		models.User{}, "RecentlyViewedDocs", &models.RecentlyViewedDoc{}))
+	require.NoError(t, db.AutoMigrate(models.ModelsToAutoMigrate()...))
+	return db
+}
+
+// CreateDocument creates document d in database db and returns it. The title
+// defaults to the Google file ID, the document type to "RFC", and the product
+// to "Hermes". The document type and product are created if they don't exist.
+func CreateDocument(t testing.TB, db *gorm.DB, d models.Document) models.Document {
+	t.Helper()
+
+	if d.Title == "" {
+		d.Title = d.GoogleFileID
+	}
+	if d.DocumentType.Name == "" {
+		d.DocumentType = models.DocumentType{
+			Name:     "RFC",
+			LongName: "Request for Comments",
+		}
+	}
+	if d.DocumentType.LongName == "" {
+		d.DocumentType.LongName = d.DocumentType.Name
+	}
+	if d.Product.Name == "" {
+		d.Product = models.Product{Name: "Hermes", Abbreviation: "HRM"}
+	}
+	if d.Product.Abbreviation == "" {
+		d.Product.Abbreviation = d.Product.Name
+	}
+
+	require.NoError(t, d.DocumentType.FirstOrCreate(db))
+	require.NoError(t, d.Product.FirstOrCreate(db))
+	require.NoError(t, d.Create(db))
+	return d
+}
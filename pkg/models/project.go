@@ -40,6 +40,9 @@ type Project struct {
 	Title string `gorm:"default:null;not null"`
 }
 
+// Projects is a slice of projects.
+type Projects []Project
+
 // ProjectStatus is the status of the project.
 type ProjectStatus int
 
@@ -128,6 +131,85 @@ func (p *Project) Get(db *gorm.DB, id uint) error {
 		Error
 }
 
+// Delete soft deletes project p. Deleted projects can be restored with
+// Restore until they are purged.
+func (p *Project) Delete(db *gorm.DB) error {
+	// Validate required fields.
+	if err := validation.Validate(p.ID, validation.Required); err != nil {
+		return err
+	}
+
+	return db.Delete(&Project{}, p.ID).Error
+}
+
+// Restore restores the soft-deleted project with the provided ID, and assigns
+// it to the receiver. It returns gorm.ErrRecordNotFound if there is no
+// deleted project with the ID.
+func (p *Project) Restore(db *gorm.DB, id uint) error {
+	// Validate required fields.
+	if err := validation.Validate(id, validation.Required); err != nil {
+		return err
+	}
+
+	res := db.
+		Unscoped().
+		Model(&Project{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return p.Get(db, id)
+}
+
+// Purge permanently deletes project p, deleted or not, with its related
+// resources.
+func (p *Project) Purge(db *gorm.DB) error {
+	// Validate required fields.
+	if err := validation.Validate(p.ID, validation.Required); err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := p.ReplaceRelatedResources(tx, nil, nil); err != nil {
+			return err
+		}
+		if err := tx.
+			Unscoped().
+			Where("project_id = ?", p.ID).
+			Delete(&RecentlyViewedProject{}).
+			Error; err != nil {
+			return fmt.Errorf("error deleting recently viewed projects: %w", err)
+		}
+		if err := tx.
+			Unscoped().
+			Delete(&Project{}, p.ID).
+			Error; err != nil {
+			return fmt.Errorf("error deleting project: %w", err)
+		}
+		return nil
+	})
+}
+
+// FindDeleted finds the soft-deleted projects from database db with the
+// provided query, most recently deleted first, and assigns them to the
+// receiver.
+func (p *Projects) FindDeleted(
+	db *gorm.DB, query interface{}, queryArgs ...interface{}) error {
+
+	return db.
+		Unscoped().
+		Where("projects.deleted_at IS NOT NULL").
+		Where(query, queryArgs...).
+		Preload("Creator").
+		Order("projects.deleted_at DESC").
+		Find(&p).Error
+}
+
 // GetRelatedResources returns typed related resources for project p.
 func (p *Project) GetRelatedResources(db *gorm.DB) (
 	elrrs []ProjectRelatedResourceExternalLink,
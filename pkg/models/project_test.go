@@ -471,3 +471,39 @@ func TestProjectReplaceRelatedResources(t *testing.T) {
 		})
 	})
 }
+
+func TestProjectSoftDelete(t *testing.T) {
+	dsn := os.Getenv("HERMES_TEST_POSTGRESQL_DSN")
+	if dsn == "" {
+		t.Skip("HERMES_TEST_POSTGRESQL_DSN environment variable isn't set")
+	}
+
+	db, tearDownTest := setupTest(t, dsn)
+	defer tearDownTest(t)
+
+	p := Project{
+		Creator: User{
+			EmailAddress: "a@a.com",
+		},
+		Title: "Title1",
+	}
+	require.NoError(t, p.Create(db))
+
+	require.NoError(t, p.Delete(db))
+	assert.ErrorIs(t, (&Project{}).Get(db, p.ID), gorm.ErrRecordNotFound)
+
+	var deleted Projects
+	require.NoError(t, deleted.FindDeleted(db, "projects.id = ?", p.ID))
+	require.Len(t, deleted, 1)
+	assert.Equal(t, "a@a.com", deleted[0].Creator.EmailAddress)
+
+	var restored Project
+	require.NoError(t, restored.Restore(db, p.ID))
+	assert.Equal(t, "Title1", restored.Title)
+
+	require.NoError(t, restored.Purge(db))
+	var count int64
+	require.NoError(t, db.Unscoped().Model(&Project{}).
+		Where("id = ?", p.ID).Count(&count).Error)
+	assert.Zero(t, count)
+}
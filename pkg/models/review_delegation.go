@@ -0,0 +1,145 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ReviewDelegation delegates a user's group reviews to a colleague for a time
+// window. While active, the delegate can approve on behalf of the delegator in
+// the delegator's approver groups.
+type ReviewDelegation struct {
+	gorm.Model
+
+	// Delegator is the user whose reviews are delegated.
+	DelegatorID uint `gorm:"not null"`
+	Delegator   User
+
+	// Delegate is the user who can review on behalf of the delegator.
+	DelegateID uint `gorm:"not null"`
+	Delegate   User
+
+	// Group is the group the delegation applies to, or nil for all of the
+	// delegator's groups.
+	GroupID *uint
+	Group   *Group
+
+	StartsAt time.Time `gorm:"not null"`
+	EndsAt   time.Time `gorm:"not null"`
+}
+
+// ReviewDelegations is a slice of review delegations.
+type ReviewDelegations []ReviewDelegation
+
+// TableName specifies the table name.
+func (ReviewDelegation) TableName() string {
+	return "review_delegations"
+}
+
+// Active returns true if the delegation is in effect at time t.
+func (d ReviewDelegation) Active(t time.Time) bool {
+	return !t.Before(d.StartsAt) && t.Before(d.EndsAt)
+}
+
+// Create creates the delegation in database db. The delegator, delegate, and
+// group (if any) are looked up by email address.
+func (d *ReviewDelegation) Create(db *gorm.DB) error {
+	if err := validation.ValidateStruct(d,
+		validation.Field(&d.EndsAt, validation.Required,
+			validation.By(func(any) error {
+				if !d.EndsAt.After(d.StartsAt) {
+					return fmt.Errorf("must be after start time")
+				}
+				return nil
+			})),
+	); err != nil {
+		return err
+	}
+	if err := validation.ValidateStruct(&d.Delegator,
+		validation.Field(&d.Delegator.EmailAddress, validation.Required),
+	); err != nil {
+		return err
+	}
+	if err := validation.ValidateStruct(&d.Delegate,
+		validation.Field(&d.Delegate.EmailAddress, validation.Required),
+	); err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := d.Delegator.FirstOrCreate(tx); err != nil {
+			return fmt.Errorf("error getting delegator: %w", err)
+		}
+		d.DelegatorID = d.Delegator.ID
+
+		if err := d.Delegate.FirstOrCreate(tx); err != nil {
+			return fmt.Errorf("error getting delegate: %w", err)
+		}
+		d.DelegateID = d.Delegate.ID
+
+		if d.Group != nil {
+			if err := d.Group.FirstOrCreate(tx); err != nil {
+				return fmt.Errorf("error getting group: %w", err)
+			}
+			d.GroupID = &d.Group.ID
+		}
+
+		return tx.
+			Omit(clause.Associations).
+			Create(d).
+			Error
+	})
+}
+
+// Get gets the delegation by ID from database db, and assigns it to the
+// receiver.
+func (d *ReviewDelegation) Get(db *gorm.DB) error {
+	if d.ID == 0 {
+		return fmt.Errorf("ID is required")
+	}
+
+	return db.
+		Preload(clause.Associations).
+		First(d, d.ID).
+		Error
+}
+
+// Delete soft-deletes the delegation in database db.
+func (d *ReviewDelegation) Delete(db *gorm.DB) error {
+	return db.Delete(d).Error
+}
+
+// FindForUser finds the delegations given or received by the user with the
+// provided email address that have not ended as of now, ordered by start
+// time.
+func (d *ReviewDelegations) FindForUser(
+	db *gorm.DB, email string, now time.Time,
+) error {
+	users := db.Model(&User{}).Select("id").Where("email_address = ?", email)
+	return db.
+		Where("(delegator_id IN (?) OR delegate_id IN (?)) AND ends_at > ?",
+			users, users, now).
+		Preload(clause.Associations).
+		Order("starts_at, id").
+		Find(d).
+		Error
+}
+
+// FindActiveForDelegate finds the delegations to the user with the provided
+// email address that are in effect at time t.
+func (d *ReviewDelegations) FindActiveForDelegate(
+	db *gorm.DB, email string, t time.Time,
+) error {
+	return db.
+		Where("delegate_id IN (?) AND starts_at <= ? AND ends_at > ?",
+			db.Model(&User{}).Select("id").Where("email_address = ?", email),
+			t, t).
+		Preload(clause.Associations).
+		Order("starts_at, id").
+		Find(d).
+		Error
+}
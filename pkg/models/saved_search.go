@@ -0,0 +1,315 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrSavedSearchNameTaken is returned when creating or renaming a saved search
+// to the name of another search of the same owner.
+var ErrSavedSearchNameTaken = errors.New("saved search name is taken")
+
+// SavedSearch is a named document search saved by a user. Saved searches can
+// be shared with groups, and users can subscribe to digests of new documents
+// that match them.
+type SavedSearch struct {
+	ID uint `gorm:"primaryKey"`
+
+	// Owner is the user who saved the search.
+	OwnerID uint `gorm:"not null;uniqueIndex:idx_saved_searches_owner_name,priority:1"`
+	Owner   User `gorm:"constraint:OnDelete:CASCADE"`
+
+	// Name is unique among the saved searches of the owner.
+	Name string `gorm:"type:varchar(255);not null;uniqueIndex:idx_saved_searches_owner_name,priority:2"`
+
+	// Query is the search query text.
+	Query string `gorm:"type:text;not null;default:''"`
+
+	// Filters are the facet filters of the search, keyed by attribute (e.g.,
+	// {"product": ["Terraform"]}).
+	Filters map[string][]string `gorm:"serializer:json;type:jsonb"`
+
+	// Facets are the facets displayed with the search results.
+	Facets []string `gorm:"serializer:json;type:jsonb"`
+
+	// SortBy and SortOrder ("asc" or "desc") sort the search results.
+	SortBy    string `gorm:"type:varchar(100);not null;default:''"`
+	SortOrder string `gorm:"type:varchar(4);not null;default:''"`
+
+	// SharedWithGroups are the groups whose members can use the search.
+	SharedWithGroups []Group `gorm:"many2many:saved_search_groups;"`
+
+	// Subscribers are the users who receive digests of new documents that
+	// match the search.
+	Subscribers []User `gorm:"many2many:saved_search_subscribers;"`
+
+	// DigestedAt is when the last digest of the search was sent. Documents
+	// published after it are included in the next digest.
+	DigestedAt *time.Time
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// SavedSearches is a slice of saved searches.
+type SavedSearches []SavedSearch
+
+// TableName specifies the table name.
+func (SavedSearch) TableName() string {
+	return "saved_searches"
+}
+
+// validate validates the fields of the saved search.
+func (s *SavedSearch) validate() error {
+	return validation.ValidateStruct(s,
+		validation.Field(&s.Name, validation.Required, validation.Length(1, 255)),
+		validation.Field(&s.SortOrder, validation.In("asc", "desc")),
+	)
+}
+
+// Create creates the saved search in database db. The owner and the groups
+// the search is shared with are looked up by email address.
+func (s *SavedSearch) Create(db *gorm.DB) error {
+	if err := s.validate(); err != nil {
+		return err
+	}
+	if err := validation.ValidateStruct(&s.Owner,
+		validation.Field(&s.Owner.EmailAddress, validation.Required),
+	); err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := s.Owner.FirstOrCreate(tx); err != nil {
+			return fmt.Errorf("error getting owner: %w", err)
+		}
+		s.OwnerID = s.Owner.ID
+
+		if err := s.checkName(tx); err != nil {
+			return err
+		}
+		if err := s.getGroups(tx); err != nil {
+			return err
+		}
+
+		if err := tx.
+			Omit(clause.Associations).
+			Create(s).
+			Error; err != nil {
+			return err
+		}
+		if err := tx.
+			Model(s).
+			Association("SharedWithGroups").
+			Replace(s.SharedWithGroups); err != nil {
+			return fmt.Errorf("error sharing with groups: %w", err)
+		}
+		return nil
+	})
+}
+
+// Get gets the saved search by ID from database db, and assigns it to the
+// receiver.
+func (s *SavedSearch) Get(db *gorm.DB) error {
+	if s.ID == 0 {
+		return fmt.Errorf("ID is required")
+	}
+
+	return db.
+		Preload(clause.Associations).
+		First(s, s.ID).
+		Error
+}
+
+// Update updates the search and the groups it is shared with in database db.
+func (s *SavedSearch) Update(db *gorm.DB) error {
+	if s.ID == 0 {
+		return fmt.Errorf("ID is required")
+	}
+	if err := s.validate(); err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if s.OwnerID == 0 {
+			if err := tx.
+				Model(&SavedSearch{}).
+				Select("owner_id").
+				Where("id = ?", s.ID).
+				Scan(&s.OwnerID).
+				Error; err != nil {
+				return err
+			}
+		}
+		if err := s.checkName(tx); err != nil {
+			return err
+		}
+		if err := s.getGroups(tx); err != nil {
+			return err
+		}
+
+		if err := tx.
+			Model(s).
+			Omit(clause.Associations).
+			Select("Name", "Query", "Filters", "Facets", "SortBy", "SortOrder").
+			Updates(s).
+			Error; err != nil {
+			return err
+		}
+		if err := tx.
+			Model(s).
+			Association("SharedWithGroups").
+			Replace(s.SharedWithGroups); err != nil {
+			return fmt.Errorf("error sharing with groups: %w", err)
+		}
+		return s.Get(tx)
+	})
+}
+
+// Delete deletes the saved search and its subscriptions from database db.
+func (s *SavedSearch) Delete(db *gorm.DB) error {
+	return db.
+		Select("SharedWithGroups", "Subscribers").
+		Delete(s).
+		Error
+}
+
+// Subscribe subscribes the user with email address email to digests of the
+// search in database db.
+func (s *SavedSearch) Subscribe(db *gorm.DB, email string) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		u := User{
+			EmailAddress: email,
+		}
+		if err := u.FirstOrCreate(tx); err != nil {
+			return fmt.Errorf("error getting user: %w", err)
+		}
+		return tx.
+			Model(s).
+			Association("Subscribers").
+			Append(&u)
+	})
+}
+
+// Unsubscribe unsubscribes the user with email address email from digests of
+// the search in database db.
+func (s *SavedSearch) Unsubscribe(db *gorm.DB, email string) error {
+	u := User{
+		EmailAddress: email,
+	}
+	if err := db.Where(&u).First(&u).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return fmt.Errorf("error getting user: %w", err)
+	}
+	return db.
+		Model(s).
+		Association("Subscribers").
+		Delete(&u)
+}
+
+// MarkDigested records in database db that a digest of the search was sent
+// at time at. It returns false if a digest was recorded since the receiver was
+// read, e.g., by another server.
+func (s *SavedSearch) MarkDigested(db *gorm.DB, at time.Time) (bool, error) {
+	q := db.
+		Model(&SavedSearch{}).
+		Where("id = ?", s.ID)
+	if s.DigestedAt == nil {
+		q = q.Where("digested_at IS NULL")
+	} else {
+		q = q.Where("digested_at = ?", *s.DigestedAt)
+	}
+	res := q.UpdateColumn("digested_at", at)
+	if res.Error != nil {
+		return false, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return false, nil
+	}
+	s.DigestedAt = &at
+	return true, nil
+}
+
+// HasSubscriber returns true if the user with email address email is
+// subscribed to digests of the search.
+func (s SavedSearch) HasSubscriber(email string) bool {
+	for _, u := range s.Subscribers {
+		if u.EmailAddress == email {
+			return true
+		}
+	}
+	return false
+}
+
+// FindForUser finds the searches saved by the user with email address email
+// or shared with any of groups, ordered by name.
+func (ss *SavedSearches) FindForUser(
+	db *gorm.DB, email string, groups []string,
+) error {
+	q := db.Where("owner_id IN (?)",
+		db.Model(&User{}).Select("id").Where("email_address = ?", email))
+	if len(groups) > 0 {
+		q = q.Or("id IN (?)", db.
+			Table("saved_search_groups").
+			Select("saved_search_groups.saved_search_id").
+			Joins("JOIN groups ON groups.id = saved_search_groups.group_id").
+			Where("groups.email_address IN ?", groups))
+	}
+	return db.
+		Where(q).
+		Preload(clause.Associations).
+		Order("name, id").
+		Find(ss).
+		Error
+}
+
+// FindSubscribed finds the saved searches that have subscribers in database
+// db.
+func (ss *SavedSearches) FindSubscribed(db *gorm.DB) error {
+	return db.
+		Where("id IN (?)", db.
+			Table("saved_search_subscribers").
+			Select("saved_search_id")).
+		Preload(clause.Associations).
+		Order("id").
+		Find(ss).
+		Error
+}
+
+// checkName returns ErrSavedSearchNameTaken if another search of the owner has
+// the name of the search.
+func (s *SavedSearch) checkName(tx *gorm.DB) error {
+	var count int64
+	if err := tx.
+		Model(&SavedSearch{}).
+		Where("owner_id = ? AND name = ? AND id <> ?", s.OwnerID, s.Name, s.ID).
+		Count(&count).
+		Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrSavedSearchNameTaken
+	}
+	return nil
+}
+
+// getGroups gets the groups the search is shared with by email address,
+// creating them where appropriate.
+func (s *SavedSearch) getGroups(tx *gorm.DB) error {
+	groups := make([]Group, 0, len(s.SharedWithGroups))
+	for _, g := range s.SharedWithGroups {
+		if err := g.FirstOrCreate(tx); err != nil {
+			return fmt.Errorf("error getting group: %w", err)
+		}
+		groups = append(groups, g)
+	}
+	s.SharedWithGroups = groups
+	return nil
+}
@@ -0,0 +1,157 @@
+package models
+
+import (
+	"sort"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SearchSynonymSet is a named set of terms that searches treat as equivalent
+// (e.g., "VM" and "virtual machine").
+type SearchSynonymSet struct {
+	ID uint `gorm:"primaryKey"`
+
+	// Name identifies the set.
+	Name string `gorm:"type:varchar(100);not null;uniqueIndex"`
+
+	// Terms are the equivalent terms. Terms may have several words.
+	Terms []string `gorm:"serializer:json;type:jsonb;not null"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// SearchSynonymSets is a slice of synonym sets.
+type SearchSynonymSets []SearchSynonymSet
+
+// TableName specifies the table name.
+func (SearchSynonymSet) TableName() string {
+	return "search_synonym_sets"
+}
+
+// SearchStopWord is a word that searches ignore.
+type SearchStopWord struct {
+	Word string `gorm:"primaryKey;type:varchar(100)"`
+
+	CreatedAt time.Time
+}
+
+// TableName specifies the table name.
+func (SearchStopWord) TableName() string {
+	return "search_stop_words"
+}
+
+// validate validates the fields of the synonym set.
+func (s *SearchSynonymSet) validate() error {
+	return validation.ValidateStruct(s,
+		validation.Field(&s.Name, validation.Required, validation.Length(1, 100)),
+		validation.Field(&s.Terms,
+			validation.Required,
+			validation.Length(2, 100),
+			validation.Each(validation.Required, validation.Length(1, 100)),
+		),
+	)
+}
+
+// Upsert creates the synonym set, or replaces the terms of the set with the
+// same name, in database db.
+func (s *SearchSynonymSet) Upsert(db *gorm.DB) error {
+	if err := s.validate(); err != nil {
+		return err
+	}
+
+	if err := db.
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "name"}},
+			DoUpdates: clause.AssignmentColumns([]string{"terms", "updated_at"}),
+		}).
+		Create(s).
+		Error; err != nil {
+		return err
+	}
+	return s.Get(db)
+}
+
+// Get gets the synonym set by name from database db, and assigns it to the
+// receiver.
+func (s *SearchSynonymSet) Get(db *gorm.DB) error {
+	return db.
+		Where("name = ?", s.Name).
+		First(s).
+		Error
+}
+
+// Delete deletes the synonym set by name from database db. It returns
+// gorm.ErrRecordNotFound if there isn't one.
+func (s *SearchSynonymSet) Delete(db *gorm.DB) error {
+	res := db.
+		Where("name = ?", s.Name).
+		Delete(&SearchSynonymSet{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// FindAll finds all synonym sets, ordered by name.
+func (ss *SearchSynonymSets) FindAll(db *gorm.DB) error {
+	return db.
+		Order("name").
+		Find(ss).
+		Error
+}
+
+// GetSearchStopWords returns the stop words in database db in alphabetical
+// order.
+func GetSearchStopWords(db *gorm.DB) ([]string, error) {
+	words := []string{}
+	if err := db.
+		Model(&SearchStopWord{}).
+		Order("word").
+		Pluck("word", &words).
+		Error; err != nil {
+		return nil, err
+	}
+	return words, nil
+}
+
+// ReplaceSearchStopWords replaces the stop words in database db with words.
+// Duplicate words are only stored once.
+func ReplaceSearchStopWords(db *gorm.DB, words []string) error {
+	if err := validation.Validate(words,
+		validation.Each(validation.Required, validation.Length(1, 100)),
+	); err != nil {
+		return err
+	}
+
+	seen := map[string]bool{}
+	stopWords := []SearchStopWord{}
+	for _, w := range words {
+		if !seen[w] {
+			seen[w] = true
+			stopWords = append(stopWords, SearchStopWord{Word: w})
+		}
+	}
+	sort.Slice(stopWords, func(i, j int) bool {
+		return stopWords[i].Word < stopWords[j].Word
+	})
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.
+			Session(&gorm.Session{AllowGlobalUpdate: true}).
+			Delete(&SearchStopWord{}).
+			Error; err != nil {
+			return err
+		}
+		if len(stopWords) == 0 {
+			return nil
+		}
+		return tx.Create(&stopWords).Error
+	})
+}
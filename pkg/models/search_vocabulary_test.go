@@ -0,0 +1,80 @@
+package models
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestSearchVocabulary(t *testing.T) {
+	dsn := os.Getenv("HERMES_TEST_POSTGRESQL_DSN")
+	if dsn == "" {
+		t.Skip("HERMES_TEST_POSTGRESQL_DSN environment variable isn't set")
+	}
+
+	t.Run("Upsert, FindAll, and Delete synonym sets", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		db, tearDownTest := setupTest(t, dsn)
+		defer tearDownTest(t)
+
+		s := SearchSynonymSet{
+			Name:  "vm",
+			Terms: []string{"VM", "virtual machine"},
+		}
+		require.NoError(s.Upsert(db))
+		id := s.ID
+
+		// Upserting a set with the same name replaces its terms.
+		s = SearchSynonymSet{
+			Name:  "vm",
+			Terms: []string{"VM", "virtual machine", "guest"},
+		}
+		require.NoError(s.Upsert(db))
+		assert.Equal(id, s.ID)
+
+		s = SearchSynonymSet{
+			Name:  "k8s",
+			Terms: []string{"k8s", "kubernetes"},
+		}
+		require.NoError(s.Upsert(db))
+
+		var sets SearchSynonymSets
+		require.NoError(sets.FindAll(db))
+		require.Len(sets, 2)
+		assert.Equal("k8s", sets[0].Name)
+		assert.Equal("vm", sets[1].Name)
+		assert.Equal([]string{"VM", "virtual machine", "guest"}, sets[1].Terms)
+
+		// Sets need at least two terms.
+		s = SearchSynonymSet{
+			Name:  "one",
+			Terms: []string{"one"},
+		}
+		assert.Error(s.Upsert(db))
+
+		s = SearchSynonymSet{Name: "vm"}
+		require.NoError(s.Delete(db))
+		assert.ErrorIs(s.Delete(db), gorm.ErrRecordNotFound)
+	})
+
+	t.Run("Replace stop words", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		db, tearDownTest := setupTest(t, dsn)
+		defer tearDownTest(t)
+
+		require.NoError(ReplaceSearchStopWords(db, []string{"the", "hashicorp", "the"}))
+		words, err := GetSearchStopWords(db)
+		require.NoError(err)
+		assert.Equal([]string{"hashicorp", "the"}, words)
+
+		require.NoError(ReplaceSearchStopWords(db, nil))
+		words, err = GetSearchStopWords(db)
+		require.NoError(err)
+		assert.Empty(words)
+
+		assert.Error(ReplaceSearchStopWords(db, []string{""}))
+	})
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"gorm.io/gorm"
+)
+
+// Tenant is a tenant of a multi-tenant deployment: an isolated document space
+// with its own documents and document numbers. Tenants are configured in the
+// server config and synced to the database when the server starts. Documents
+// of the default tenant have a TenantID of zero and no tenant record.
+type Tenant struct {
+	gorm.Model
+
+	// Name is the unique name of the tenant.
+	Name string `gorm:"default:null;not null;unique"`
+
+	// DisplayName is the name of the tenant shown to users.
+	DisplayName string
+}
+
+// FirstOrCreate finds the tenant by name or creates it if it does not exist in
+// database db, and updates its display name.
+func (t *Tenant) FirstOrCreate(db *gorm.DB) error {
+	if err := validation.ValidateStruct(t,
+		validation.Field(&t.Name, validation.Required),
+	); err != nil {
+		return err
+	}
+
+	return db.
+		Where(Tenant{Name: t.Name}).
+		Assign(Tenant{DisplayName: t.DisplayName}).
+		FirstOrCreate(&t).
+		Error
+}
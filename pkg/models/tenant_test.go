@@ -0,0 +1,40 @@
+package models
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTenantModel(t *testing.T) {
+	dsn := os.Getenv("HERMES_TEST_POSTGRESQL_DSN")
+	if dsn == "" {
+		t.Skip("HERMES_TEST_POSTGRESQL_DSN environment variable isn't set")
+	}
+
+	db, tearDownTest := setupTest(t, dsn)
+	defer tearDownTest(t)
+
+	t.Run("FirstOrCreate requires a name", func(t *testing.T) {
+		tenant := Tenant{}
+		assert.Error(t, tenant.FirstOrCreate(db))
+	})
+
+	t.Run("FirstOrCreate creates and updates", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+
+		tenant := Tenant{Name: "acme", DisplayName: "Acme"}
+		require.NoError(tenant.FirstOrCreate(db))
+		assert.NotZero(tenant.ID)
+
+		again := Tenant{Name: "acme", DisplayName: "Acme Corp"}
+		require.NoError(again.FirstOrCreate(db))
+		assert.Equal(tenant.ID, again.ID)
+
+		var got Tenant
+		require.NoError(db.First(&got, tenant.ID).Error)
+		assert.Equal("Acme Corp", got.DisplayName)
+	})
+}
@@ -2,6 +2,7 @@ package models
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
@@ -16,6 +17,11 @@ type User struct {
 	// EmailAddress is the email address of the user.
 	EmailAddress string `gorm:"default:null;index;not null;type:citext;unique"`
 
+	// Locale is the preferred locale of the user (a BCP 47 language tag like
+	// "de" or "pt-BR"), which selects the language of the emails and
+	// notifications the user receives. Empty uses the default language.
+	Locale string `gorm:"type:varchar(35)"`
+
 	// ProductSubscriptions are the products that have been subscribed to by the
 	// user.
 	ProductSubscriptions []Product `gorm:"many2many:user_product_subscriptions;"`
@@ -78,6 +84,54 @@ func (u *User) FirstOrCreate(db *gorm.DB) error {
 	})
 }
 
+// SetLocale sets the preferred locale of the user with the email address of
+// the receiver, creating the user if it doesn't exist.
+func (u *User) SetLocale(db *gorm.DB, locale string) error {
+	if err := u.FirstOrCreate(db); err != nil {
+		return err
+	}
+
+	if err := db.
+		Model(&User{}).
+		Where("id = ?", u.ID).
+		Update("locale", locale).
+		Error; err != nil {
+		return err
+	}
+	u.Locale = locale
+	return nil
+}
+
+// GetUserLocales returns the preferred locales of the users with the given
+// email addresses, keyed by the given email addresses. Users without a locale
+// aren't included.
+func GetUserLocales(db *gorm.DB, emails []string) (map[string]string, error) {
+	locales := map[string]string{}
+	if len(emails) == 0 {
+		return locales, nil
+	}
+
+	var users []User
+	if err := db.
+		Select("email_address", "locale").
+		Where("email_address IN ?", emails).
+		Where("locale IS NOT NULL AND locale <> ''").
+		Find(&users).
+		Error; err != nil {
+		return nil, err
+	}
+	for _, email := range emails {
+		for _, u := range users {
+			// Email addresses are case-insensitive.
+			if strings.EqualFold(u.EmailAddress, email) {
+				locales[email] = u.Locale
+				break
+			}
+		}
+	}
+	return locales, nil
+}
+
 // Get gets a user from database db by email address, and assigns it to the
 // receiver.
 func (u *User) Get(db *gorm.DB) error {
@@ -0,0 +1,136 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"gorm.io/gorm"
+)
+
+// UserActivity records that a user viewed, edited, or reviewed a document.
+// User activity is append-only and is deleted after the retention period.
+type UserActivity struct {
+	ID uint64 `gorm:"primaryKey"`
+
+	UserID uint `gorm:"not null;index:idx_user_activities_user,priority:1"`
+	User   User
+
+	DocumentID uint     `gorm:"not null;index:idx_user_activities_document,priority:1"`
+	Document   Document `gorm:"constraint:OnDelete:CASCADE"`
+
+	Action UserActivityAction `gorm:"type:varchar(32);not null"`
+
+	CreatedAt time.Time `gorm:"not null;index;index:idx_user_activities_user,priority:2;index:idx_user_activities_document,priority:2"`
+}
+
+// UserActivities is a slice of user activities.
+type UserActivities []UserActivity
+
+// UserActivityAction is the kind of a user activity.
+type UserActivityAction string
+
+const (
+	ViewUserActivityAction   UserActivityAction = "view"
+	EditUserActivityAction   UserActivityAction = "edit"
+	ReviewUserActivityAction UserActivityAction = "review"
+)
+
+// UserActivityFilter filters user activities. Empty fields are not filtered
+// on.
+type UserActivityFilter struct {
+	UserID     uint
+	DocumentID uint
+	Action     UserActivityAction
+
+	// BeforeID limits activities to those with an ID less than BeforeID, for
+	// paging through results.
+	BeforeID uint64
+
+	// Limit is the maximum number of activities to return.
+	Limit int
+}
+
+// TableName specifies the table name.
+func (UserActivity) TableName() string {
+	return "user_activities"
+}
+
+// Create appends the user activity to database db.
+func (a *UserActivity) Create(db *gorm.DB) error {
+	if err := validation.ValidateStruct(a,
+		validation.Field(&a.UserID, validation.Required),
+		validation.Field(&a.DocumentID, validation.Required),
+		validation.Field(&a.Action, validation.Required, validation.In(
+			ViewUserActivityAction,
+			EditUserActivityAction,
+			ReviewUserActivityAction,
+		)),
+	); err != nil {
+		return err
+	}
+
+	if err := db.Create(a).Error; err != nil {
+		return fmt.Errorf("error creating user activity: %w", err)
+	}
+	return nil
+}
+
+// Exists returns true if the user already has activity of the same action on
+// the same document since time since.
+func (a *UserActivity) Exists(db *gorm.DB, since time.Time) (bool, error) {
+	var n int64
+	if err := db.
+		Model(&UserActivity{}).
+		Where("user_id = ? AND document_id = ? AND action = ? AND created_at >= ?",
+			a.UserID, a.DocumentID, a.Action, since).
+		Count(&n).
+		Error; err != nil {
+		return false, fmt.Errorf("error counting user activities: %w", err)
+	}
+	return n > 0, nil
+}
+
+// Find finds user activities matching filter f, newest first, with their user
+// and document. Activities of deleted documents are not returned.
+func (a *UserActivities) Find(db *gorm.DB, f UserActivityFilter) error {
+	q := db.
+		Model(&UserActivity{}).
+		Joins("JOIN documents ON documents.id = user_activities.document_id " +
+			"AND documents.deleted_at IS NULL")
+	if f.UserID != 0 {
+		q = q.Where("user_activities.user_id = ?", f.UserID)
+	}
+	if f.DocumentID != 0 {
+		q = q.Where("user_activities.document_id = ?", f.DocumentID)
+	}
+	if f.Action != "" {
+		q = q.Where("user_activities.action = ?", f.Action)
+	}
+	if f.BeforeID > 0 {
+		q = q.Where("user_activities.id < ?", f.BeforeID)
+	}
+	if f.Limit > 0 {
+		q = q.Limit(f.Limit)
+	}
+
+	return q.
+		Preload("User").
+		Preload("Document").
+		Preload("Document.DocumentType").
+		Preload("Document.Owner").
+		Preload("Document.Product").
+		Order("user_activities.id DESC").
+		Find(a).
+		Error
+}
+
+// DeleteUserActivitiesBefore deletes the user activities created before time
+// t from database db and returns the number of deleted activities.
+func DeleteUserActivitiesBefore(db *gorm.DB, t time.Time) (int64, error) {
+	res := db.Where("created_at < ?", t).Delete(&UserActivity{})
+	if res.Error != nil {
+		return 0, fmt.Errorf("error deleting user activities: %w", res.Error)
+	}
+	return res.RowsAffected, nil
+}
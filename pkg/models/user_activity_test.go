@@ -0,0 +1,87 @@
+package models
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserActivityModel(t *testing.T) {
+	dsn := os.Getenv("HERMES_TEST_POSTGRESQL_DSN")
+	if dsn == "" {
+		t.Skip("HERMES_TEST_POSTGRESQL_DSN environment variable isn't set")
+	}
+
+	db, tearDownTest := setupTest(t, dsn)
+	defer tearDownTest(t)
+
+	d := Document{
+		GoogleFileID: "fileID1",
+		DocumentType: DocumentType{
+			Name:     "DT1",
+			LongName: "DocumentType1",
+		},
+		Product: Product{
+			Name:         "Product1",
+			Abbreviation: "P1",
+		},
+	}
+	require.NoError(t, d.DocumentType.FirstOrCreate(db))
+	require.NoError(t, d.Product.FirstOrCreate(db))
+	require.NoError(t, d.Create(db))
+
+	u := User{EmailAddress: "alice@example.com"}
+	require.NoError(t, u.FirstOrCreate(db))
+
+	t.Run("Create requires a valid action", func(t *testing.T) {
+		a := UserActivity{
+			UserID:     u.ID,
+			DocumentID: d.ID,
+			Action:     "delete",
+		}
+		assert.Error(t, a.Create(db))
+	})
+
+	now := time.Now()
+	t.Run("Create, find, and delete", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+
+		for _, a := range []UserActivity{
+			{Action: ViewUserActivityAction, CreatedAt: now.Add(-48 * time.Hour)},
+			{Action: EditUserActivityAction, CreatedAt: now.Add(-time.Hour)},
+			{Action: ReviewUserActivityAction, CreatedAt: now},
+		} {
+			a.UserID = u.ID
+			a.DocumentID = d.ID
+			require.NoError(a.Create(db))
+		}
+
+		exists, err := (&UserActivity{
+			UserID:     u.ID,
+			DocumentID: d.ID,
+			Action:     EditUserActivityAction,
+		}).Exists(db, now.Add(-2*time.Hour))
+		require.NoError(err)
+		assert.True(exists)
+
+		var as UserActivities
+		require.NoError(as.Find(db, UserActivityFilter{UserID: u.ID}))
+		require.Len(as, 3)
+		assert.Equal(ReviewUserActivityAction, as[0].Action)
+		assert.Equal("fileID1", as[0].Document.GoogleFileID)
+		assert.Equal("alice@example.com", as[0].User.EmailAddress)
+
+		require.NoError(as.Find(db, UserActivityFilter{
+			DocumentID: d.ID,
+			Action:     ViewUserActivityAction,
+		}))
+		assert.Len(as, 1)
+
+		n, err := DeleteUserActivitiesBefore(db, now.Add(-24*time.Hour))
+		require.NoError(err)
+		assert.Equal(int64(1), n)
+	})
+}
@@ -0,0 +1,109 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// UserDirectoryEntry is a person in the locally cached people directory. The
+// directory is synced periodically from the workspace people provider so that
+// people search doesn't call the provider on every request.
+type UserDirectoryEntry struct {
+	gorm.Model
+
+	// EmailAddress is the person's lowercased primary email address.
+	EmailAddress string `gorm:"not null;uniqueIndex:idx_users_directory_email_address"`
+
+	// DisplayName is the person's display name.
+	DisplayName string
+
+	// PhotoURL is the URL of the person's photo.
+	PhotoURL string
+
+	// UnifiedUserID links the person's identities across providers.
+	UnifiedUserID string
+
+	// SyncedAt is when the person was last returned by the provider.
+	SyncedAt time.Time `gorm:"not null"`
+}
+
+// UserDirectoryEntries is a slice of user directory entries.
+type UserDirectoryEntries []UserDirectoryEntry
+
+// TableName specifies the table name.
+func (UserDirectoryEntry) TableName() string {
+	return "users_directory"
+}
+
+// SyncUsersDirectory records the result of a directory sync at syncedAt.
+// Entries are inserted or updated (restoring previously deleted people), and
+// people that were not part of this sync are soft-deleted.
+func SyncUsersDirectory(
+	db *gorm.DB, entries []UserDirectoryEntry, syncedAt time.Time,
+) error {
+	for i := range entries {
+		entries[i].EmailAddress = strings.ToLower(entries[i].EmailAddress)
+		entries[i].SyncedAt = syncedAt
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if len(entries) > 0 {
+			if err := tx.
+				Clauses(clause.OnConflict{
+					Columns: []clause.Column{{Name: "email_address"}},
+					DoUpdates: clause.AssignmentColumns([]string{
+						"display_name", "photo_url", "unified_user_id", "synced_at",
+						"updated_at", "deleted_at",
+					}),
+				}).
+				CreateInBatches(&entries, 500).
+				Error; err != nil {
+				return fmt.Errorf("error upserting directory entries: %w", err)
+			}
+		}
+
+		if err := tx.
+			Where("synced_at < ?", syncedAt).
+			Delete(&UserDirectoryEntry{}).
+			Error; err != nil {
+			return fmt.Errorf("error deleting removed directory entries: %w", err)
+		}
+		return nil
+	})
+}
+
+// Search finds up to limit people whose email address or display name
+// contains query, ordered by display name.
+func (e *UserDirectoryEntries) Search(
+	db *gorm.DB, query string, limit int,
+) error {
+	pattern := "%" + escapeLike(strings.ToLower(strings.TrimSpace(query))) + "%"
+	return db.
+		Where("email_address LIKE ? OR LOWER(display_name) LIKE ?",
+			pattern, pattern).
+		Order("display_name, email_address").
+		Limit(limit).
+		Find(e).
+		Error
+}
+
+// FindByEmails finds the people with the provided email addresses.
+func (e *UserDirectoryEntries) FindByEmails(db *gorm.DB, emails []string) error {
+	lower := make([]string, len(emails))
+	for i, email := range emails {
+		lower[i] = strings.ToLower(strings.TrimSpace(email))
+	}
+	return db.
+		Where("email_address IN ?", lower).
+		Find(e).
+		Error
+}
+
+// escapeLike escapes LIKE pattern wildcards in s.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
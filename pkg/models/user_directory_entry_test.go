@@ -0,0 +1,82 @@
+package models
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserDirectoryEntryModel(t *testing.T) {
+	dsn := os.Getenv("HERMES_TEST_POSTGRESQL_DSN")
+	if dsn == "" {
+		t.Skip("HERMES_TEST_POSTGRESQL_DSN environment variable isn't set")
+	}
+
+	db, tearDownTest := setupTest(t, dsn)
+	defer tearDownTest(t)
+
+	t0 := time.Now().UTC().Truncate(time.Second)
+	t1 := t0.Add(time.Hour)
+	t2 := t1.Add(time.Hour)
+
+	t.Run("Sync entries", func(t *testing.T) {
+		require.NoError(t, SyncUsersDirectory(db, []UserDirectoryEntry{
+			{EmailAddress: "Alice@example.com", DisplayName: "Alice Smith"},
+			{EmailAddress: "bob@example.com", DisplayName: "Bob Jones",
+				PhotoURL: "https://example.com/bob.jpg"},
+		}, t0))
+
+		var e UserDirectoryEntries
+		require.NoError(t, e.Search(db, "", 10))
+		require.Len(t, e, 2)
+		assert.Equal(t, "alice@example.com", e[0].EmailAddress)
+		assert.Equal(t, "Bob Jones", e[1].DisplayName)
+		assert.Equal(t, "https://example.com/bob.jpg", e[1].PhotoURL)
+	})
+
+	t.Run("Search by name and email", func(t *testing.T) {
+		var e UserDirectoryEntries
+		require.NoError(t, e.Search(db, "SMITH", 10))
+		require.Len(t, e, 1)
+		assert.Equal(t, "alice@example.com", e[0].EmailAddress)
+
+		require.NoError(t, e.Search(db, "bob@", 10))
+		require.Len(t, e, 1)
+		assert.Equal(t, "bob@example.com", e[0].EmailAddress)
+
+		require.NoError(t, e.Search(db, "%", 10))
+		assert.Empty(t, e)
+	})
+
+	t.Run("People missing from a sync are soft-deleted", func(t *testing.T) {
+		require.NoError(t, SyncUsersDirectory(db, []UserDirectoryEntry{
+			{EmailAddress: "alice@example.com", DisplayName: "Alice Brown"},
+		}, t1))
+
+		var e UserDirectoryEntries
+		require.NoError(t, e.FindByEmails(db,
+			[]string{"ALICE@example.com", "bob@example.com"}))
+		require.Len(t, e, 1)
+		assert.Equal(t, "Alice Brown", e[0].DisplayName)
+
+		var count int64
+		require.NoError(t, db.Unscoped().Model(&UserDirectoryEntry{}).
+			Where("email_address = ?", "bob@example.com").Count(&count).Error)
+		assert.EqualValues(t, 1, count)
+	})
+
+	t.Run("Returning people are restored", func(t *testing.T) {
+		require.NoError(t, SyncUsersDirectory(db, []UserDirectoryEntry{
+			{EmailAddress: "alice@example.com", DisplayName: "Alice Brown"},
+			{EmailAddress: "bob@example.com", DisplayName: "Bob Jones"},
+		}, t2))
+
+		var e UserDirectoryEntries
+		require.NoError(t, e.FindByEmails(db, []string{"bob@example.com"}))
+		require.Len(t, e, 1)
+		assert.True(t, e[0].SyncedAt.Equal(t2))
+	})
+}
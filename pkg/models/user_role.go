@@ -0,0 +1,159 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// UserRole grants a role to a user. Document type admin and product lead roles
+// are scoped to a document type or product; the site admin role is global.
+// Users without a role are regular users.
+type UserRole struct {
+	gorm.Model
+
+	UserID uint `gorm:"not null;index"`
+	User   User
+
+	Role RoleType `gorm:"not null"`
+
+	// DocumentType is the document type a document type admin role is scoped
+	// to.
+	DocumentTypeID *uint
+	DocumentType   *DocumentType
+
+	// Product is the product a product lead role is scoped to.
+	ProductID *uint
+	Product   *Product
+
+	// GrantedBy is the email address of the user who granted the role.
+	GrantedBy string
+}
+
+// UserRoles is a slice of user roles.
+type UserRoles []UserRole
+
+// RoleType is the type of a user role.
+type RoleType int
+
+const (
+	UnspecifiedRoleType RoleType = iota
+	SiteAdminRoleType
+	DocumentTypeAdminRoleType
+	ProductLeadRoleType
+)
+
+var (
+	roleTypeStrings = map[RoleType]string{
+		SiteAdminRoleType:         "site_admin",
+		DocumentTypeAdminRoleType: "document_type_admin",
+		ProductLeadRoleType:       "product_lead",
+	}
+)
+
+func (r RoleType) String() string {
+	return roleTypeStrings[r]
+}
+
+func ParseRoleTypeString(s string) (RoleType, bool) {
+	for k, v := range roleTypeStrings {
+		if v == strings.ToLower(s) {
+			return k, true
+		}
+	}
+	return UnspecifiedRoleType, false
+}
+
+// TableName specifies the table name.
+func (UserRole) TableName() string {
+	return "user_roles"
+}
+
+// Create creates the role in database db. The user is looked up by email
+// address, and the scope by document type or product name.
+func (r *UserRole) Create(db *gorm.DB) error {
+	if err := validation.ValidateStruct(r,
+		validation.Field(&r.Role, validation.Required),
+		validation.Field(&r.DocumentType,
+			validation.When(r.Role == DocumentTypeAdminRoleType,
+				validation.Required.Error("is required for document type admins")).
+				Else(validation.Nil.Error(
+					"is only allowed for document type admins"))),
+		validation.Field(&r.Product,
+			validation.When(r.Role == ProductLeadRoleType,
+				validation.Required.Error("is required for product leads")).
+				Else(validation.Nil.Error("is only allowed for product leads"))),
+	); err != nil {
+		return err
+	}
+	if err := validation.ValidateStruct(&r.User,
+		validation.Field(&r.User.EmailAddress, validation.Required),
+	); err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := r.User.FirstOrCreate(tx); err != nil {
+			return fmt.Errorf("error getting user: %w", err)
+		}
+		r.UserID = r.User.ID
+
+		if r.DocumentType != nil {
+			if err := r.DocumentType.Get(tx); err != nil {
+				return fmt.Errorf("error getting document type: %w", err)
+			}
+			r.DocumentTypeID = &r.DocumentType.ID
+		}
+		if r.Product != nil {
+			if err := r.Product.Get(tx); err != nil {
+				return fmt.Errorf("error getting product: %w", err)
+			}
+			r.ProductID = &r.Product.ID
+		}
+
+		return tx.
+			Omit(clause.Associations).
+			Create(r).
+			Error
+	})
+}
+
+// Get gets the role by ID from database db, and assigns it to the receiver.
+func (r *UserRole) Get(db *gorm.DB) error {
+	if r.ID == 0 {
+		return fmt.Errorf("ID is required")
+	}
+
+	return db.
+		Preload(clause.Associations).
+		First(r, r.ID).
+		Error
+}
+
+// Delete soft-deletes the role in database db.
+func (r *UserRole) Delete(db *gorm.DB) error {
+	return db.Delete(r).Error
+}
+
+// Find finds all roles, ordered by user and role.
+func (r *UserRoles) Find(db *gorm.DB) error {
+	return db.
+		Preload(clause.Associations).
+		Order("user_id, role, id").
+		Find(r).
+		Error
+}
+
+// FindByUser finds the roles of the user with the provided email address.
+func (r *UserRoles) FindByUser(db *gorm.DB, email string) error {
+	return db.
+		Where("user_id IN (?)",
+			db.Model(&User{}).Select("id").Where("email_address = ?", email)).
+		Preload(clause.Associations).
+		Order("role, id").
+		Find(r).
+		Error
+}
@@ -0,0 +1,88 @@
+package models
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserRoleModel(t *testing.T) {
+	dsn := os.Getenv("HERMES_TEST_POSTGRESQL_DSN")
+	if dsn == "" {
+		t.Skip("HERMES_TEST_POSTGRESQL_DSN environment variable isn't set")
+	}
+
+	db, tearDownTest := setupTest(t, dsn)
+	defer tearDownTest(t)
+
+	p := Product{
+		Name:         "Product1",
+		Abbreviation: "P1",
+	}
+	require.NoError(t, p.FirstOrCreate(db))
+
+	t.Run("Create validates scope", func(t *testing.T) {
+		assert := assert.New(t)
+
+		r := UserRole{
+			User: User{EmailAddress: "alice@example.com"},
+			Role: ProductLeadRoleType,
+		}
+		assert.Error(r.Create(db))
+
+		r = UserRole{
+			User:    User{EmailAddress: "alice@example.com"},
+			Role:    SiteAdminRoleType,
+			Product: &Product{Name: "Product1"},
+		}
+		assert.Error(r.Create(db))
+	})
+
+	var leadID uint
+	t.Run("Create and find by user", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+
+		r := UserRole{
+			User:      User{EmailAddress: "alice@example.com"},
+			Role:      ProductLeadRoleType,
+			Product:   &Product{Name: "Product1"},
+			GrantedBy: "admin@example.com",
+		}
+		require.NoError(r.Create(db))
+		leadID = r.ID
+
+		r = UserRole{
+			User: User{EmailAddress: "alice@example.com"},
+			Role: SiteAdminRoleType,
+		}
+		require.NoError(r.Create(db))
+
+		var roles UserRoles
+		require.NoError(roles.FindByUser(db, "alice@example.com"))
+		require.Len(roles, 2)
+		assert.Equal(SiteAdminRoleType, roles[0].Role)
+		assert.Equal(ProductLeadRoleType, roles[1].Role)
+		require.NotNil(roles[1].Product)
+		assert.Equal("Product1", roles[1].Product.Name)
+
+		roles = nil
+		require.NoError(roles.FindByUser(db, "bob@example.com"))
+		assert.Empty(roles)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+
+		r := UserRole{}
+		r.ID = leadID
+		require.NoError(r.Get(db))
+		require.NoError(r.Delete(db))
+
+		var roles UserRoles
+		require.NoError(roles.Find(db))
+		require.Len(roles, 1)
+		assert.Equal(SiteAdminRoleType, roles[0].Role)
+	})
+}
@@ -400,4 +400,18 @@ func TestUserModel(t *testing.T) {
 				assert.Equal("Product1", u.ProductSubscriptions[0].Name)
 			})
 	})
+	t.Run("SetLocale and GetUserLocales", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		db, tearDownTest := setupTest(t, dsn)
+		defer tearDownTest(t)
+
+		u := User{EmailAddress: "a@a.com"}
+		require.NoError(u.SetLocale(db, "de"))
+		assert.Equal("de", u.Locale)
+		require.NoError((&User{EmailAddress: "b@b.com"}).FirstOrCreate(db))
+
+		locales, err := GetUserLocales(db, []string{"A@a.com", "b@b.com", "c@c.com"})
+		require.NoError(err)
+		assert.Equal(map[string]string{"A@a.com": "de"}, locales)
+	})
 }
@@ -0,0 +1,137 @@
+package models
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Kinds of workers
+const (
+	WorkerKindIndexer  = "indexer"
+	WorkerKindNotifier = "notifier"
+)
+
+// WorkerInstance is a pipeline worker (an indexer or notifier) that consumes
+// Kafka topics. Workers are recorded by their first heartbeat, which reports
+// their consumer groups, offsets, lag, and errors.
+type WorkerInstance struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Name is the unique name of the worker (e.g., "indexer-7d9f-x2lq").
+	Name string `gorm:"type:varchar(255);not null;unique" json:"name"`
+
+	// Kind is the kind of worker, WorkerKindIndexer or WorkerKindNotifier.
+	Kind string `gorm:"type:varchar(50);not null" json:"kind"`
+
+	// Version is the Hermes version of the worker.
+	Version string `gorm:"type:varchar(50)" json:"version,omitempty"`
+
+	// Hostname is the hostname of the worker.
+	Hostname string `gorm:"type:varchar(255)" json:"hostname,omitempty"`
+
+	// RemoteAddr is the address the last heartbeat was received from.
+	RemoteAddr string `gorm:"type:varchar(255)" json:"remote_addr,omitempty"`
+
+	// Partitions are the topic partitions the worker processed records of.
+	Partitions []WorkerPartition `gorm:"serializer:json;type:jsonb" json:"partitions"`
+
+	// ProcessedCount is the number of records the worker processed since it
+	// started.
+	ProcessedCount int64 `gorm:"default:0" json:"processed_count"`
+
+	// ErrorCount is the number of records the worker failed to process since
+	// it started.
+	ErrorCount int64 `gorm:"default:0" json:"error_count"`
+
+	// LastError is the last processing error.
+	LastError string `gorm:"type:text" json:"last_error,omitempty"`
+
+	// LastErrorAt is when the last processing error happened.
+	LastErrorAt *time.Time `json:"last_error_at,omitempty"`
+
+	// StartedAt is when the worker started.
+	StartedAt *time.Time `json:"started_at,omitempty"`
+
+	// LastHeartbeatAt is when the last heartbeat was received.
+	LastHeartbeatAt *time.Time `json:"last_heartbeat_at,omitempty"`
+}
+
+// WorkerPartition is the progress of a worker on a topic partition.
+type WorkerPartition struct {
+	ConsumerGroup string `json:"consumer_group"`
+	Topic         string `json:"topic"`
+	Partition     int32  `json:"partition"`
+
+	// Offset is the offset of the last record processed.
+	Offset int64 `json:"offset"`
+
+	// Lag is the number of records after Offset when it was processed.
+	Lag int64 `json:"lag"`
+}
+
+// WorkerInstances is a slice of workers.
+type WorkerInstances []WorkerInstance
+
+// RecordHeartbeat creates or updates the worker by name with the heartbeat
+// fields of w, setting its last heartbeat to now.
+func (w *WorkerInstance) RecordHeartbeat(db *gorm.DB) error {
+	if err := validation.ValidateStruct(w,
+		validation.Field(&w.Name, validation.Required, validation.Length(1, 255)),
+		validation.Field(&w.Kind, validation.Required,
+			validation.In(WorkerKindIndexer, WorkerKindNotifier)),
+	); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	w.LastHeartbeatAt = &now
+	return db.
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "name"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"kind", "version", "hostname", "remote_addr", "partitions",
+				"processed_count", "error_count", "last_error", "last_error_at",
+				"started_at", "last_heartbeat_at", "updated_at",
+			}),
+		}).
+		Create(w).
+		Error
+}
+
+// FindAll finds all workers, ordered by kind and name.
+func (ws *WorkerInstances) FindAll(db *gorm.DB) error {
+	return db.Order("kind").Order("name").Find(ws).Error
+}
+
+// DeleteWorkerInstance deletes the worker with name from database db. It
+// returns gorm.ErrRecordNotFound if there isn't one.
+func DeleteWorkerInstance(db *gorm.DB, name string) error {
+	res := db.Where("name = ?", name).Delete(&WorkerInstance{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// IsStale returns true if the worker didn't send a heartbeat within
+// staleAfter of now.
+func (w *WorkerInstance) IsStale(now time.Time, staleAfter time.Duration) bool {
+	return w.LastHeartbeatAt == nil || now.Sub(*w.LastHeartbeatAt) > staleAfter
+}
+
+// Lag returns the total lag of the worker on its partitions.
+func (w *WorkerInstance) Lag() int64 {
+	var lag int64
+	for _, p := range w.Partitions {
+		lag += p.Lag
+	}
+	return lag
+}
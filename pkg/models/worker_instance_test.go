@@ -0,0 +1,87 @@
+package models
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestWorkerInstanceIsStale(t *testing.T) {
+	now := time.Now()
+	old, recent := now.Add(-time.Hour), now.Add(-time.Minute)
+
+	assert.True(t, (&WorkerInstance{}).IsStale(now, 2*time.Minute))
+	assert.True(t, (&WorkerInstance{LastHeartbeatAt: &old}).
+		IsStale(now, 2*time.Minute))
+	assert.False(t, (&WorkerInstance{LastHeartbeatAt: &recent}).
+		IsStale(now, 2*time.Minute))
+}
+
+func TestWorkerInstanceLag(t *testing.T) {
+	w := WorkerInstance{Partitions: []WorkerPartition{
+		{Topic: "hermes.document-revisions", Partition: 0, Lag: 3},
+		{Topic: "hermes.document-revisions", Partition: 1, Lag: 4},
+	}}
+	assert.Equal(t, int64(7), w.Lag())
+	assert.Zero(t, (&WorkerInstance{}).Lag())
+}
+
+func TestWorkerInstanceModel(t *testing.T) {
+	dsn := os.Getenv("HERMES_TEST_POSTGRESQL_DSN")
+	if dsn == "" {
+		t.Skip("HERMES_TEST_POSTGRESQL_DSN environment variable isn't set")
+	}
+
+	db, tearDownTest := setupTest(t, dsn)
+	defer tearDownTest(t)
+
+	t.Run("RecordHeartbeat requires a name and kind", func(t *testing.T) {
+		assert.Error(t, (&WorkerInstance{Kind: WorkerKindIndexer}).RecordHeartbeat(db))
+		assert.Error(t, (&WorkerInstance{Name: "worker", Kind: "relay"}).RecordHeartbeat(db))
+	})
+
+	t.Run("heartbeats upsert by name", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+
+		w := WorkerInstance{
+			Name: "indexer-1",
+			Kind: WorkerKindIndexer,
+			Partitions: []WorkerPartition{{
+				ConsumerGroup: "hermes-indexer-workers",
+				Topic:         "hermes.document-revisions",
+				Offset:        41,
+				Lag:           2,
+			}},
+			ProcessedCount: 42,
+		}
+		require.NoError(w.RecordHeartbeat(db))
+		n := WorkerInstance{Name: "notifier-1", Kind: WorkerKindNotifier}
+		require.NoError(n.RecordHeartbeat(db))
+
+		w.ProcessedCount = 50
+		w.ErrorCount = 1
+		w.LastError = "search unavailable"
+		require.NoError(w.RecordHeartbeat(db))
+
+		var workers WorkerInstances
+		require.NoError(workers.FindAll(db))
+		require.Len(workers, 2)
+		assert.Equal("indexer-1", workers[0].Name)
+		assert.Equal(int64(50), workers[0].ProcessedCount)
+		assert.Equal(int64(1), workers[0].ErrorCount)
+		assert.Equal("search unavailable", workers[0].LastError)
+		assert.Equal(w.Partitions, workers[0].Partitions)
+		assert.NotNil(workers[0].LastHeartbeatAt)
+		assert.Equal("notifier-1", workers[1].Name)
+	})
+
+	t.Run("DeleteWorkerInstance", func(t *testing.T) {
+		require.NoError(t, DeleteWorkerInstance(db, "notifier-1"))
+		assert.ErrorIs(t, DeleteWorkerInstance(db, "notifier-1"),
+			gorm.ErrRecordNotFound)
+	})
+}
@@ -12,18 +12,78 @@ import (
 	"github.com/hashicorp-forge/hermes/pkg/notifications"
 )
 
-// AuditBackend logs all notifications for compliance and debugging
+// AuditBackend logs all notifications for compliance and debugging, either to
+// stdout in a human-readable format, or as structured records to a JSONL file
+// (see AuditRecord).
 type AuditBackend struct {
 	logger *log.Logger
+
+	// file is the JSONL audit log, or nil to log to stdout.
+	file *auditLog
+	host string
 }
 
-// NewAuditBackend creates a new audit backend
+// AuditBackendConfig holds configuration for the JSONL audit log of the audit
+// backend.
+type AuditBackendConfig struct {
+	// Path is the path of the JSONL file
+	Path string
+
+	// MaxSize is the size in bytes the file is rotated at (optional, defaults
+	// to 100 MiB)
+	MaxSize int64
+
+	// RotateInterval is how long after it's opened the file is rotated
+	// (optional, defaults to 24h)
+	RotateInterval time.Duration
+
+	// MaxFiles is the number of rotated files kept locally (optional,
+	// defaults to 10)
+	MaxFiles int
+
+	// Shipper ships rotated files, which are deleted once shipped (optional)
+	Shipper AuditShipper
+}
+
+// NewAuditBackend creates a new audit backend that logs to stdout
 func NewAuditBackend() *AuditBackend {
 	return &AuditBackend{
 		logger: log.New(os.Stdout, "[AUDIT] ", log.LstdFlags|log.Lmsgprefix),
 	}
 }
 
+// NewAuditFileBackend creates a new audit backend that writes to a rotated
+// JSONL file
+func NewAuditFileBackend(cfg AuditBackendConfig) (*AuditBackend, error) {
+	// Default values
+	if cfg.MaxSize == 0 {
+		cfg.MaxSize = 100 << 20
+	}
+	if cfg.RotateInterval == 0 {
+		cfg.RotateInterval = 24 * time.Hour
+	}
+	if cfg.MaxFiles == 0 {
+		cfg.MaxFiles = 10
+	}
+
+	file, err := openAuditLog(auditLogConfig{
+		path:           cfg.Path,
+		maxSize:        cfg.MaxSize,
+		rotateInterval: cfg.RotateInterval,
+		maxFiles:       cfg.MaxFiles,
+		shipper:        cfg.Shipper,
+	})
+	if err != nil {
+		return nil, err
+	}
+	host, _ := os.Hostname()
+
+	b := NewAuditBackend()
+	b.file = file
+	b.host = host
+	return b, nil
+}
+
 // Name returns the backend identifier
 func (b *AuditBackend) Name() string {
 	return "audit"
@@ -36,6 +96,13 @@ func (b *AuditBackend) SupportsBackend(backend string) bool {
 
 // Handle processes a notification message
 func (b *AuditBackend) Handle(ctx context.Context, msg *notifications.NotificationMessage) error {
+	if b.file != nil {
+		if err := b.file.Write(newAuditRecord(msg, b.host, time.Now())); err != nil {
+			return NewBackendError("audit", "write", true, err)
+		}
+		return nil
+	}
+
 	// Log notification metadata
 	b.logger.Printf("Notification ID: %s", msg.ID)
 	b.logger.Printf("  Type: %s", msg.Type)
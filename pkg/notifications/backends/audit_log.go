@@ -0,0 +1,281 @@
+package backends
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/notifications"
+)
+
+// AuditRecordSchemaVersion is the version of the schema of audit records. It's
+// incremented when fields are removed or change meaning; fields may be added
+// without a new version.
+const AuditRecordSchemaVersion = 1
+
+// AuditRecord is a line of the JSONL audit log of notifications. See
+// docs/configuration/notification-audit-log.md for the schema.
+type AuditRecord struct {
+	// SchemaVersion is AuditRecordSchemaVersion.
+	SchemaVersion int `json:"schema_version"`
+
+	// Event is the kind of event, which is always "notification".
+	Event string `json:"event"`
+
+	// Time is when the notifier processed the notification.
+	Time time.Time `json:"time"`
+
+	// Host is the hostname of the notifier.
+	Host string `json:"host,omitempty"`
+
+	ID          string                         `json:"id"`
+	Type        notifications.NotificationType `json:"type"`
+	Template    string                         `json:"template,omitempty"`
+	Priority    int                            `json:"priority"`
+	PublishedAt time.Time                      `json:"published_at"`
+	Recipients  []notifications.Recipient      `json:"recipients"`
+	Subject     string                         `json:"subject,omitempty"`
+	Backends    []string                       `json:"backends"`
+	RetryCount  int                            `json:"retry_count,omitempty"`
+
+	// Context of the notification
+	DocumentUUID string `json:"document_uuid,omitempty"`
+	ProjectID    string `json:"project_id,omitempty"`
+	UserID       string `json:"user_id,omitempty"`
+}
+
+// newAuditRecord returns the audit record of msg processed at now.
+func newAuditRecord(msg *notifications.NotificationMessage, host string, now time.Time) AuditRecord {
+	recipients := msg.Recipients
+	if recipients == nil {
+		recipients = []notifications.Recipient{}
+	}
+	backends := msg.Backends
+	if backends == nil {
+		backends = []string{}
+	}
+	return AuditRecord{
+		SchemaVersion: AuditRecordSchemaVersion,
+		Event:         "notification",
+		Time:          now.UTC(),
+		Host:          host,
+		ID:            msg.ID,
+		Type:          msg.Type,
+		Template:      msg.Template,
+		Priority:      msg.Priority,
+		PublishedAt:   msg.Timestamp.UTC(),
+		Recipients:    recipients,
+		Subject:       msg.Subject,
+		Backends:      backends,
+		RetryCount:    msg.RetryCount,
+		DocumentUUID:  msg.DocumentUUID,
+		ProjectID:     msg.ProjectID,
+		UserID:        msg.UserID,
+	}
+}
+
+// AuditShipper ships rotated audit log files to long-term storage.
+type AuditShipper interface {
+	// Ship uploads the audit log file at path.
+	Ship(ctx context.Context, path string) error
+}
+
+// auditLogConfig configures an audit log file.
+type auditLogConfig struct {
+	path           string
+	maxSize        int64
+	rotateInterval time.Duration
+	maxFiles       int
+	shipper        AuditShipper
+}
+
+// auditLog is a JSONL file that is rotated when it reaches its maximum size
+// or rotation interval. Rotated files are renamed with the UTC time of the
+// rotation (e.g., "audit-20251114T103000Z.jsonl"), shipped if a shipper is
+// configured, and deleted once shipped or when there are more than maxFiles.
+// It's safe for concurrent use.
+type auditLog struct {
+	cfg auditLogConfig
+
+	mu       sync.Mutex
+	file     *os.File
+	size     int64
+	openedAt time.Time
+
+	// shipping serializes shipping, so files are shipped in order.
+	shipping sync.Mutex
+
+	// now returns the current time, and is replaced in tests.
+	now func() time.Time
+}
+
+// openAuditLog opens (or creates) the audit log file, and ships rotated files
+// that weren't shipped yet (e.g., because the notifier stopped).
+func openAuditLog(cfg auditLogConfig) (*auditLog, error) {
+	if err := os.MkdirAll(filepath.Dir(cfg.path), 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+	l := &auditLog{
+		cfg: cfg,
+		now: time.Now,
+	}
+	if err := l.open(); err != nil {
+		return nil, err
+	}
+	if cfg.shipper != nil {
+		go l.ship()
+	}
+	return l, nil
+}
+
+// open opens the audit log file for appending.
+func (l *auditLog) open() error {
+	f, err := os.OpenFile(l.cfg.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o640)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("failed to stat audit log: %w", err)
+	}
+	l.file = f
+	l.size = info.Size()
+	l.openedAt = l.now()
+	return nil
+}
+
+// Write appends record to the audit log as a line of JSON, rotating the file
+// first if it's due.
+func (l *auditLog) Write(record AuditRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit record: %w", err)
+	}
+	line = append(line, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.size > 0 && (l.cfg.maxSize > 0 && l.size+int64(len(line)) > l.cfg.maxSize ||
+		l.cfg.rotateInterval > 0 && l.now().Sub(l.openedAt) >= l.cfg.rotateInterval) {
+		if err := l.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := l.file.Write(line)
+	l.size += int64(n)
+	if err != nil {
+		return fmt.Errorf("failed to write audit record: %w", err)
+	}
+	return nil
+}
+
+// rotate renames the audit log file and opens a new one. The caller must hold
+// l.mu.
+func (l *auditLog) rotate() error {
+	if err := l.file.Close(); err != nil {
+		log.Printf("Failed to close audit log: %v", err)
+	}
+	rotated := l.rotatedPath(l.now())
+	if err := os.Rename(l.cfg.path, rotated); err != nil {
+		// Keep appending to the current file rather than losing records.
+		if openErr := l.open(); openErr != nil {
+			return openErr
+		}
+		return fmt.Errorf("failed to rotate audit log: %w", err)
+	}
+	if err := l.open(); err != nil {
+		return err
+	}
+
+	if l.cfg.shipper != nil {
+		go l.ship()
+	} else {
+		l.prune()
+	}
+	return nil
+}
+
+// rotatedPath returns the path the audit log file is renamed to when it's
+// rotated at t.
+func (l *auditLog) rotatedPath(t time.Time) string {
+	ext := filepath.Ext(l.cfg.path)
+	base := strings.TrimSuffix(l.cfg.path, ext)
+	path := fmt.Sprintf("%s-%s%s", base, t.UTC().Format("20060102T150405Z"), ext)
+	// Don't overwrite files rotated in the same second.
+	for i := 1; fileExists(path); i++ {
+		path = fmt.Sprintf("%s-%s-%d%s", base, t.UTC().Format("20060102T150405Z"), i, ext)
+	}
+	return path
+}
+
+// rotatedFiles returns the paths of the rotated audit log files, oldest first.
+func (l *auditLog) rotatedFiles() []string {
+	ext := filepath.Ext(l.cfg.path)
+	matches, err := filepath.Glob(
+		strings.TrimSuffix(l.cfg.path, ext) + "-*T*Z*" + ext)
+	if err != nil {
+		return nil
+	}
+	// Timestamps sort chronologically.
+	sort.Strings(matches)
+	return matches
+}
+
+// ship ships the rotated files and deletes the ones that were shipped. Files
+// that fail to ship are kept, and retried on the next rotation, up to
+// maxFiles.
+func (l *auditLog) ship() {
+	l.shipping.Lock()
+	defer l.shipping.Unlock()
+
+	for _, path := range l.rotatedFiles() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		err := l.cfg.shipper.Ship(ctx, path)
+		cancel()
+		if err != nil {
+			log.Printf("Failed to ship audit log %s: %v", path, err)
+			break
+		}
+		if err := os.Remove(path); err != nil {
+			log.Printf("Failed to delete shipped audit log %s: %v", path, err)
+		}
+	}
+	l.prune()
+}
+
+// prune deletes the oldest rotated files if there are more than maxFiles.
+func (l *auditLog) prune() {
+	if l.cfg.maxFiles <= 0 {
+		return
+	}
+	files := l.rotatedFiles()
+	for len(files) > l.cfg.maxFiles {
+		if err := os.Remove(files[0]); err != nil {
+			log.Printf("Failed to delete old audit log %s: %v", files[0], err)
+		}
+		files = files[1:]
+	}
+}
+
+// Close closes the audit log file.
+func (l *auditLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.file.Close()
+}
+
+// fileExists returns true if a file exists at path.
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,208 @@
+package backends
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/notifications"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeShipper records the contents of the files it ships.
+type fakeShipper struct {
+	mu      sync.Mutex
+	shipped []string
+	err     error
+}
+
+func (s *fakeShipper) Ship(ctx context.Context, path string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	s.shipped = append(s.shipped, string(data))
+	return nil
+}
+
+func (s *fakeShipper) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.shipped)
+}
+
+// readAuditRecords returns the records of the audit log file at path.
+func readAuditRecords(t *testing.T, path string) []AuditRecord {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var records []AuditRecord
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var r AuditRecord
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &r))
+		records = append(records, r)
+	}
+	require.NoError(t, scanner.Err())
+	return records
+}
+
+func TestAuditFileBackendHandle(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit", "notifications.jsonl")
+	backend, err := NewAuditFileBackend(AuditBackendConfig{Path: path})
+	require.NoError(t, err)
+
+	published := time.Date(2025, 11, 14, 10, 30, 0, 0, time.UTC)
+	err = backend.Handle(context.Background(), &notifications.NotificationMessage{
+		ID:           "test-audit-001",
+		Type:         notifications.NotificationTypeDocumentApproved,
+		Timestamp:    published,
+		Recipients:   []notifications.Recipient{{Email: "test@example.com", Name: "Test User"}},
+		Template:     "document_approved",
+		Subject:      "RFC-087 approved",
+		Body:         "Not recorded",
+		Backends:     []string{"audit", "mail"},
+		DocumentUUID: "doc-123",
+	})
+	require.NoError(t, err)
+	require.NoError(t, backend.file.Close())
+
+	records := readAuditRecords(t, path)
+	require.Len(t, records, 1)
+	r := records[0]
+	assert.Equal(t, AuditRecordSchemaVersion, r.SchemaVersion)
+	assert.Equal(t, "notification", r.Event)
+	assert.False(t, r.Time.IsZero())
+	assert.Equal(t, "test-audit-001", r.ID)
+	assert.Equal(t, notifications.NotificationTypeDocumentApproved, r.Type)
+	assert.Equal(t, "document_approved", r.Template)
+	assert.Equal(t, published, r.PublishedAt)
+	assert.Equal(t, []notifications.Recipient{{Email: "test@example.com", Name: "Test User"}},
+		r.Recipients)
+	assert.Equal(t, "RFC-087 approved", r.Subject)
+	assert.Equal(t, []string{"audit", "mail"}, r.Backends)
+	assert.Equal(t, "doc-123", r.DocumentUUID)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "Not recorded", "bodies aren't recorded")
+}
+
+func TestAuditLogRotateBySize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	l, err := openAuditLog(auditLogConfig{
+		path:     path,
+		maxSize:  300,
+		maxFiles: 2,
+	})
+	require.NoError(t, err)
+	now := time.Date(2025, 11, 14, 10, 0, 0, 0, time.UTC)
+	l.now = func() time.Time { return now }
+
+	msg := &notifications.NotificationMessage{
+		ID:   "msg",
+		Type: notifications.NotificationTypeNewOwner,
+	}
+	for i := 0; i < 6; i++ {
+		now = now.Add(time.Second)
+		require.NoError(t, l.Write(newAuditRecord(msg, "host", now)))
+	}
+	require.NoError(t, l.Close())
+
+	files := l.rotatedFiles()
+	assert.Len(t, files, 2, "old rotated files are pruned")
+	for _, f := range append(files, path) {
+		info, err := os.Stat(f)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, info.Size(), int64(300))
+		assert.NotEmpty(t, readAuditRecords(t, f))
+	}
+}
+
+func TestAuditLogRotateByInterval(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	shipper := &fakeShipper{}
+	l, err := openAuditLog(auditLogConfig{
+		path:           path,
+		rotateInterval: time.Hour,
+		maxFiles:       10,
+		shipper:        shipper,
+	})
+	require.NoError(t, err)
+	now := time.Date(2025, 11, 14, 10, 0, 0, 0, time.UTC)
+	l.now = func() time.Time { return now }
+	l.openedAt = now
+
+	msg := &notifications.NotificationMessage{
+		ID:   "msg",
+		Type: notifications.NotificationTypeNewOwner,
+	}
+	require.NoError(t, l.Write(newAuditRecord(msg, "host", now)))
+	now = now.Add(30 * time.Minute)
+	require.NoError(t, l.Write(newAuditRecord(msg, "host", now)))
+	assert.Empty(t, l.rotatedFiles())
+
+	now = now.Add(30 * time.Minute)
+	require.NoError(t, l.Write(newAuditRecord(msg, "host", now)))
+	require.NoError(t, l.Close())
+
+	// The rotated file is shipped and deleted.
+	require.Eventually(t, func() bool {
+		return shipper.count() == 1 && len(l.rotatedFiles()) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Len(t, readAuditRecords(t, path), 1)
+}
+
+func TestAuditLogShipPending(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "audit.jsonl")
+	pending := filepath.Join(dir, "audit-20251114T100000Z.jsonl")
+	require.NoError(t, os.WriteFile(pending, []byte("{}\n"), 0o640))
+
+	// Files that fail to ship are kept.
+	shipper := &fakeShipper{err: errors.New("unavailable")}
+	l, err := openAuditLog(auditLogConfig{path: path, maxFiles: 10, shipper: shipper})
+	require.NoError(t, err)
+	l.ship()
+	require.NoError(t, l.Close())
+	assert.FileExists(t, pending)
+
+	// Files rotated before the notifier started are shipped.
+	shipper = &fakeShipper{}
+	l, err = openAuditLog(auditLogConfig{path: path, maxFiles: 10, shipper: shipper})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return shipper.count() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, l.Close())
+	assert.Equal(t, []string{"{}\n"}, shipper.shipped)
+	assert.NoFileExists(t, pending)
+}
+
+func TestAuditConfigValidate(t *testing.T) {
+	assert.NoError(t, (&AuditConfig{Enabled: true}).Validate())
+	assert.NoError(t, (&AuditConfig{
+		Enabled: true,
+		Path:    "/var/log/hermes/audit.jsonl",
+		S3:      &AuditS3Config{Bucket: "audit"},
+	}).Validate())
+	assert.Error(t, (&AuditConfig{Enabled: true, MaxSizeMB: -1}).Validate())
+	assert.Error(t, (&AuditConfig{
+		Enabled: true,
+		S3:      &AuditS3Config{Bucket: "audit"},
+	}).Validate(), "shipping requires a path")
+	assert.Error(t, (&AuditS3Config{}).Validate())
+}
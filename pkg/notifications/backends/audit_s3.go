@@ -0,0 +1,71 @@
+package backends
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// S3AuditShipper ships audit log files to an S3 bucket, under keys of the form
+// <prefix>/<YYYY>/<MM>/<DD>/<host>-<file name>, using the date the file was
+// last modified.
+type S3AuditShipper struct {
+	client *s3.Client
+	bucket string
+	prefix string
+	host   string
+}
+
+// NewS3AuditShipper returns a shipper configured by cfg. Credentials are
+// loaded from the environment (e.g., AWS_ACCESS_KEY_ID or an instance role).
+func NewS3AuditShipper(ctx context.Context, cfg *AuditS3Config, host string) (*S3AuditShipper, error) {
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(cfg.Region))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	client := s3.NewFromConfig(awsCfg, func(o *s3.Options) {
+		// Custom endpoint for MinIO or other S3-compatible services
+		if cfg.Endpoint != "" {
+			o.BaseEndpoint = aws.String(cfg.Endpoint)
+			o.UsePathStyle = true
+		}
+	})
+	return &S3AuditShipper{
+		client: client,
+		bucket: cfg.Bucket,
+		prefix: cfg.Prefix,
+		host:   host,
+	}, nil
+}
+
+// Ship implements AuditShipper.
+func (s *S3AuditShipper) Ship(ctx context.Context, file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	key := path.Join(s.prefix, info.ModTime().UTC().Format("2006/01/02"),
+		s.host+"-"+filepath.Base(file))
+	if _, err := s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:        aws.String(s.bucket),
+		Key:           aws.String(key),
+		Body:          f,
+		ContentLength: aws.Int64(info.Size()),
+		ContentType:   aws.String("application/x-ndjson"),
+	}); err != nil {
+		return fmt.Errorf("failed to put s3://%s/%s: %w", s.bucket, key, err)
+	}
+	return nil
+}
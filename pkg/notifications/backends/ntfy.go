@@ -3,18 +3,52 @@ package backends
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"net/http"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/hashicorp-forge/hermes/pkg/notifications"
 )
 
+// Actions of ntfy notifications
+const (
+	// NtfyActionView opens the document.
+	NtfyActionView = "view"
+
+	// NtfyActionApprove opens the approval link of review notifications, or
+	// the document if there isn't one.
+	NtfyActionApprove = "approve"
+)
+
+// DefaultNtfyActions are the actions of ntfy notifications if none are
+// configured.
+var DefaultNtfyActions = []string{NtfyActionView, NtfyActionApprove}
+
+// DefaultNtfyTopicPrefix is the prefix of per-user topics if none is
+// configured.
+const DefaultNtfyTopicPrefix = "hermes-"
+
+// ntfyApproveTypes are the notification types that get an approve action.
+var ntfyApproveTypes = []notifications.NotificationType{
+	notifications.NotificationTypeReviewRequested,
+	notifications.NotificationTypeReviewSLAAtRisk,
+	notifications.NotificationTypeReviewSLABreached,
+}
+
 // NtfyBackend sends push notifications via ntfy.sh
 type NtfyBackend struct {
-	serverURL string
-	topic     string
-	client    *http.Client
+	serverURL     string
+	topic         string
+	perUserTopics bool
+	topicPrefix   string
+	topicSecret   string
+	actions       []string
+	client        *http.Client
 }
 
 // NtfyBackendConfig holds configuration for the ntfy backend
@@ -22,9 +56,26 @@ type NtfyBackendConfig struct {
 	// ServerURL is the ntfy server URL (e.g., "https://ntfy.sh")
 	ServerURL string
 
-	// Topic is the ntfy topic to send notifications to
+	// Topic is the ntfy topic to send notifications to. With per-user topics,
+	// it only receives notifications without email recipients (optional).
 	Topic string
 
+	// PerUserTopics routes notifications to a topic per recipient, derived
+	// from their email address with UserTopic.
+	PerUserTopics bool
+
+	// TopicPrefix is the prefix of per-user topics (optional, defaults to
+	// DefaultNtfyTopicPrefix).
+	TopicPrefix string
+
+	// TopicSecret is the key per-user topics are derived with, so they can't
+	// be guessed from email addresses (optional).
+	TopicSecret string
+
+	// Actions are the action buttons of notifications, NtfyActionView and
+	// NtfyActionApprove (optional, nil defaults to DefaultNtfyActions).
+	Actions []string
+
 	// Timeout for HTTP requests (optional, defaults to 10s)
 	Timeout time.Duration
 }
@@ -38,10 +89,20 @@ func NewNtfyBackend(cfg NtfyBackendConfig) *NtfyBackend {
 	if cfg.Timeout == 0 {
 		cfg.Timeout = 10 * time.Second
 	}
+	if cfg.TopicPrefix == "" {
+		cfg.TopicPrefix = DefaultNtfyTopicPrefix
+	}
+	if cfg.Actions == nil {
+		cfg.Actions = DefaultNtfyActions
+	}
 
 	return &NtfyBackend{
-		serverURL: cfg.ServerURL,
-		topic:     cfg.Topic,
+		serverURL:     cfg.ServerURL,
+		topic:         cfg.Topic,
+		perUserTopics: cfg.PerUserTopics,
+		topicPrefix:   cfg.TopicPrefix,
+		topicSecret:   cfg.TopicSecret,
+		actions:       cfg.Actions,
 		client: &http.Client{
 			Timeout: cfg.Timeout,
 		},
@@ -60,6 +121,50 @@ func (b *NtfyBackend) SupportsBackend(backend string) bool {
 
 // Handle processes a notification message
 func (b *NtfyBackend) Handle(ctx context.Context, msg *notifications.NotificationMessage) error {
+	topics := b.topics(msg)
+	if len(topics) == 0 {
+		return NewBackendError("ntfy", "route", false,
+			fmt.Errorf("no topic for message %s without email recipients", msg.ID))
+	}
+
+	var errs []*BackendError
+	for _, topic := range topics {
+		if err := b.publish(ctx, topic, msg); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	default:
+		return &MultiBackendError{Errors: errs}
+	}
+}
+
+// topics returns the topics msg is published to.
+func (b *NtfyBackend) topics(msg *notifications.NotificationMessage) []string {
+	var topics []string
+	if b.perUserTopics {
+		for _, r := range msg.Recipients {
+			if r.Email == "" {
+				continue
+			}
+			topic := UserTopic(b.topicPrefix, b.topicSecret, r.Email)
+			if !slices.Contains(topics, topic) {
+				topics = append(topics, topic)
+			}
+		}
+	}
+	if len(topics) == 0 && b.topic != "" {
+		topics = append(topics, b.topic)
+	}
+	return topics
+}
+
+// publish publishes msg to topic.
+func (b *NtfyBackend) publish(ctx context.Context, topic string, msg *notifications.NotificationMessage) *BackendError {
 	// Use the resolved body (markdown format)
 	messageBody := msg.Body
 	if messageBody == "" {
@@ -67,12 +172,13 @@ func (b *NtfyBackend) Handle(ctx context.Context, msg *notifications.Notificatio
 	}
 
 	// Create the ntfy notification URL
-	url := fmt.Sprintf("%s/%s", b.serverURL, b.topic)
+	url := fmt.Sprintf("%s/%s", b.serverURL, topic)
 
 	// Create HTTP request
 	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBufferString(messageBody))
 	if err != nil {
-		return fmt.Errorf("failed to create ntfy request: %w", err)
+		return NewBackendError("ntfy", "send", false,
+			fmt.Errorf("failed to create ntfy request: %w", err))
 	}
 
 	// Set ntfy headers
@@ -93,6 +199,16 @@ func (b *NtfyBackend) Handle(ctx context.Context, msg *notifications.Notificatio
 	// Tags: Add notification type as tag
 	req.Header.Set("Tags", string(msg.Type))
 
+	// Click: Open the document when the notification is tapped
+	if docURL := contextString(msg, "DocumentURL"); docURL != "" {
+		req.Header.Set("Click", docURL)
+	}
+
+	// Actions: Add buttons to open or approve the document
+	if actions := b.actionsHeader(msg); actions != "" {
+		req.Header.Set("Actions", actions)
+	}
+
 	// Send the request
 	resp, err := b.client.Do(req)
 	if err != nil {
@@ -106,12 +222,67 @@ func (b *NtfyBackend) Handle(ctx context.Context, msg *notifications.Notificatio
 		// Classify error as retryable or permanent
 		retryable := isRetryableHTTPStatus(resp.StatusCode)
 		return NewBackendError("ntfy", "send", retryable,
-			fmt.Errorf("ntfy request failed with status %d", resp.StatusCode))
+			fmt.Errorf("ntfy request to topic %s failed with status %d", topic, resp.StatusCode))
 	}
 
 	return nil
 }
 
+// actionsHeader returns the value of the Actions header of msg, in ntfy's
+// simple format (e.g., "view, Open document, https://..., clear=true"), which
+// is empty if msg has no actions.
+func (b *NtfyBackend) actionsHeader(msg *notifications.NotificationMessage) string {
+	docURL := contextString(msg, "DocumentURL")
+
+	var actions []string
+	for _, a := range b.actions {
+		switch a {
+		case NtfyActionView:
+			if docURL != "" {
+				actions = append(actions, "view, Open document, "+docURL+", clear=true")
+			}
+		case NtfyActionApprove:
+			if !slices.Contains(ntfyApproveTypes, msg.Type) {
+				continue
+			}
+			approveURL := contextString(msg, "ApproveURL")
+			if approveURL == "" {
+				approveURL = docURL
+			}
+			if approveURL != "" {
+				actions = append(actions, "view, Approve, "+approveURL+", clear=true")
+			}
+		}
+	}
+	return strings.Join(actions, "; ")
+}
+
+// contextString returns the string value of key in the template context of
+// msg, which is empty if it isn't set or isn't a string.
+func contextString(msg *notifications.NotificationMessage, key string) string {
+	s, _ := msg.TemplateContext[key].(string)
+	return s
+}
+
+// UserTopic returns the ntfy topic of the user with email address email:
+// prefix followed by a hash of the (case-insensitive) email address, keyed
+// with secret if it isn't empty. Users subscribe to their topic to receive
+// their notifications.
+func UserTopic(prefix, secret, email string) string {
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	var sum []byte
+	if secret != "" {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(email))
+		sum = mac.Sum(nil)
+	} else {
+		s := sha256.Sum256([]byte(email))
+		sum = s[:]
+	}
+	return prefix + hex.EncodeToString(sum[:12])
+}
+
 // isRetryableHTTPStatus determines if an HTTP status code represents a retryable error
 func isRetryableHTTPStatus(status int) bool {
 	// Retryable: 5xx (server errors), 429 (rate limit), 408 (timeout)
@@ -0,0 +1,233 @@
+package backends
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/pkg/notifications"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ntfyRequest is a request received by a fake ntfy server.
+type ntfyRequest struct {
+	topic  string
+	header http.Header
+	body   string
+}
+
+// newNtfyServer returns a fake ntfy server that records the requests it
+// receives and responds with status.
+func newNtfyServer(t *testing.T, status int) (*httptest.Server, func() []ntfyRequest) {
+	var (
+		mu       sync.Mutex
+		requests []ntfyRequest
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		requests = append(requests, ntfyRequest{
+			topic:  strings.TrimPrefix(r.URL.Path, "/"),
+			header: r.Header.Clone(),
+			body:   string(body),
+		})
+		mu.Unlock()
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, func() []ntfyRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return slices.Clone(requests)
+	}
+}
+
+func TestNtfyBackendSharedTopic(t *testing.T) {
+	srv, requests := newNtfyServer(t, http.StatusOK)
+	backend := NewNtfyBackend(NtfyBackendConfig{
+		ServerURL: srv.URL,
+		Topic:     "hermes-all",
+	})
+
+	assert.Equal(t, "ntfy", backend.Name())
+	assert.True(t, backend.SupportsBackend("ntfy"))
+	assert.False(t, backend.SupportsBackend("mail"))
+
+	err := backend.Handle(context.Background(), &notifications.NotificationMessage{
+		ID:         "msg-1",
+		Type:       notifications.NotificationTypeDocumentApproved,
+		Priority:   1,
+		Subject:    "RFC-087 approved",
+		Body:       "Alice approved RFC-087.",
+		Recipients: []notifications.Recipient{{Email: "bob@example.com"}},
+		TemplateContext: map[string]any{
+			"DocumentURL": "https://hermes.example.com/document/doc1",
+		},
+	})
+	require.NoError(t, err)
+
+	reqs := requests()
+	require.Len(t, reqs, 1)
+	assert.Equal(t, "hermes-all", reqs[0].topic)
+	assert.Equal(t, "Alice approved RFC-087.", reqs[0].body)
+	assert.Equal(t, "RFC-087 approved", reqs[0].header.Get("Title"))
+	assert.Equal(t, "5", reqs[0].header.Get("Priority"))
+	assert.Equal(t, "https://hermes.example.com/document/doc1",
+		reqs[0].header.Get("Click"))
+	assert.Equal(t,
+		"view, Open document, https://hermes.example.com/document/doc1, clear=true",
+		reqs[0].header.Get("Actions"), "only review notifications can be approved")
+}
+
+func TestNtfyBackendPerUserTopics(t *testing.T) {
+	srv, requests := newNtfyServer(t, http.StatusOK)
+	backend := NewNtfyBackend(NtfyBackendConfig{
+		ServerURL:     srv.URL,
+		Topic:         "hermes-ops",
+		PerUserTopics: true,
+		TopicSecret:   "secret",
+	})
+
+	err := backend.Handle(context.Background(), &notifications.NotificationMessage{
+		ID:   "msg-1",
+		Type: notifications.NotificationTypeReviewRequested,
+		Recipients: []notifications.Recipient{
+			{Email: "alice@example.com"},
+			{Email: "Alice@Example.com"},
+			{Email: "bob@example.com"},
+			{SlackID: "U123"},
+		},
+		TemplateContext: map[string]any{
+			"DocumentURL": "https://hermes.example.com/document/doc1",
+			"ApproveURL":  "https://hermes.example.com/approval-links/abc/approve",
+		},
+	})
+	require.NoError(t, err)
+
+	reqs := requests()
+	require.Len(t, reqs, 2, "recipients are notified once on their topic")
+	assert.Equal(t, UserTopic("hermes-", "secret", "alice@example.com"), reqs[0].topic)
+	assert.Equal(t, UserTopic("hermes-", "secret", "bob@example.com"), reqs[1].topic)
+	assert.Equal(t,
+		"view, Open document, https://hermes.example.com/document/doc1, clear=true; "+
+			"view, Approve, https://hermes.example.com/approval-links/abc/approve, clear=true",
+		reqs[0].header.Get("Actions"))
+
+	// Notifications without email recipients go to the shared topic.
+	err = backend.Handle(context.Background(), &notifications.NotificationMessage{
+		ID:   "msg-2",
+		Type: notifications.NotificationTypeMigrationJobStarted,
+	})
+	require.NoError(t, err)
+	reqs = requests()
+	require.Len(t, reqs, 3)
+	assert.Equal(t, "hermes-ops", reqs[2].topic)
+	assert.Empty(t, reqs[2].header.Get("Actions"))
+	assert.Empty(t, reqs[2].header.Get("Click"))
+}
+
+func TestNtfyBackendNoTopic(t *testing.T) {
+	backend := NewNtfyBackend(NtfyBackendConfig{
+		PerUserTopics: true,
+	})
+	err := backend.Handle(context.Background(), &notifications.NotificationMessage{
+		ID:   "msg-1",
+		Type: notifications.NotificationTypeMigrationJobStarted,
+	})
+	var backendErr *BackendError
+	require.True(t, errors.As(err, &backendErr))
+	assert.False(t, backendErr.IsRetryable())
+}
+
+func TestNtfyBackendActions(t *testing.T) {
+	msg := &notifications.NotificationMessage{
+		Type: notifications.NotificationTypeReviewSLABreached,
+		TemplateContext: map[string]any{
+			"DocumentURL": "https://hermes.example.com/document/doc1",
+		},
+	}
+
+	backend := NewNtfyBackend(NtfyBackendConfig{
+		Topic:   "hermes",
+		Actions: []string{NtfyActionApprove},
+	})
+	assert.Equal(t,
+		"view, Approve, https://hermes.example.com/document/doc1, clear=true",
+		backend.actionsHeader(msg), "approving falls back to the document")
+
+	backend = NewNtfyBackend(NtfyBackendConfig{
+		Topic:   "hermes",
+		Actions: []string{},
+	})
+	assert.Empty(t, backend.actionsHeader(msg))
+}
+
+func TestNtfyBackendError(t *testing.T) {
+	for status, retryable := range map[int]bool{
+		http.StatusServiceUnavailable: true,
+		http.StatusTooManyRequests:    true,
+		http.StatusForbidden:          false,
+	} {
+		srv, _ := newNtfyServer(t, status)
+		backend := NewNtfyBackend(NtfyBackendConfig{
+			ServerURL: srv.URL,
+			Topic:     "hermes",
+		})
+		err := backend.Handle(context.Background(), &notifications.NotificationMessage{
+			ID:   "msg-1",
+			Type: notifications.NotificationTypeNewOwner,
+		})
+		var backendErr *BackendError
+		require.True(t, errors.As(err, &backendErr), "status %d", status)
+		assert.Equal(t, retryable, backendErr.IsRetryable(), "status %d", status)
+	}
+}
+
+func TestUserTopic(t *testing.T) {
+	topic := UserTopic("hermes-", "", "alice@example.com")
+	assert.Regexp(t, `^hermes-[0-9a-f]{24}$`, topic)
+	assert.Equal(t, topic, UserTopic("hermes-", "", " Alice@Example.com "))
+	assert.NotEqual(t, topic, UserTopic("hermes-", "", "bob@example.com"))
+	assert.NotEqual(t, topic, UserTopic("hermes-", "secret", "alice@example.com"))
+}
+
+func TestNtfyConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     NtfyConfig
+		wantErr string
+	}{
+		{"disabled", NtfyConfig{}, ""},
+		{"topic", NtfyConfig{Enabled: true, Topic: "hermes"}, ""},
+		{"per-user topics", NtfyConfig{Enabled: true, PerUserTopics: true}, ""},
+		{"no topic", NtfyConfig{Enabled: true}, "topic or per_user_topics"},
+		{
+			"invalid prefix",
+			NtfyConfig{Enabled: true, PerUserTopics: true, TopicPrefix: "hermes/"},
+			"topic_prefix",
+		},
+		{
+			"invalid action",
+			NtfyConfig{Enabled: true, Topic: "hermes", Actions: []string{"reject"}},
+			`"reject"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -1,7 +1,13 @@
 package backends
 
 import (
+	"context"
+	"fmt"
 	"log"
+	"os"
+	"regexp"
+	"slices"
+	"time"
 )
 
 // Config holds backend configuration from HCL
@@ -18,28 +24,157 @@ type Config struct {
 
 // AuditConfig configures the audit backend
 type AuditConfig struct {
+	// Enabled indicates whether notifications are written to the log.
 	Enabled bool `hcl:"enabled,optional"`
+
+	// Path is the JSONL file structured audit records are appended to (see
+	// docs/configuration/notification-audit-log.md). Notifications are
+	// logged to stdout in a human-readable format if it's empty.
+	Path string `hcl:"path,optional"`
+
+	// MaxSizeMB is the size in megabytes the file is rotated at (default:
+	// 100).
+	MaxSizeMB int `hcl:"max_size_mb,optional"`
+
+	// RotateInterval is how often the file is rotated, whatever its size
+	// (default: 24h). Rotation happens when a record is written.
+	RotateInterval time.Duration `hcl:"rotate_interval,optional"`
+
+	// MaxFiles is the number of rotated files kept next to the file
+	// (default: 10). Files shipped to S3 are deleted.
+	MaxFiles int `hcl:"max_files,optional"`
+
+	// S3 configures shipping rotated files to an S3 bucket.
+	S3 *AuditS3Config `hcl:"s3,block"`
+}
+
+// Validate validates the audit backend settings.
+func (c *AuditConfig) Validate() error {
+	if c.MaxSizeMB < 0 || c.RotateInterval < 0 || c.MaxFiles < 0 {
+		return fmt.Errorf(
+			"max_size_mb, rotate_interval, and max_files must not be negative")
+	}
+	if c.S3 != nil && c.Path == "" {
+		return fmt.Errorf("path must be set to ship audit logs to S3")
+	}
+	return nil
+}
+
+// AuditS3Config configures shipping audit log files to S3. Credentials are
+// loaded from the environment (e.g., AWS_ACCESS_KEY_ID or an instance role).
+type AuditS3Config struct {
+	// Bucket is the S3 bucket audit logs are shipped to.
+	Bucket string `hcl:"bucket"`
+
+	// Prefix is the key prefix of audit logs (e.g., "hermes/audit").
+	Prefix string `hcl:"prefix,optional"`
+
+	// Region is the AWS region of the bucket.
+	Region string `hcl:"region,optional"`
+
+	// Endpoint is the endpoint of an S3-compatible service (e.g., MinIO).
+	Endpoint string `hcl:"endpoint,optional"`
+}
+
+// Validate validates the S3 settings.
+func (c *AuditS3Config) Validate() error {
+	if c.Bucket == "" {
+		return fmt.Errorf("bucket must not be empty")
+	}
+	return nil
 }
 
 // MailConfig configures the mail backend
 type MailConfig struct {
+	// Enabled indicates whether notifications are sent by email.
 	Enabled bool `hcl:"enabled,optional"`
 
-	SMTPHost     string `hcl:"smtp_host,optional"`
-	SMTPPort     string `hcl:"smtp_port,optional"`
+	// SMTPHost is the host of the SMTP server.
+	SMTPHost string `hcl:"smtp_host,optional"`
+
+	// SMTPPort is the port of the SMTP server.
+	SMTPPort string `hcl:"smtp_port,optional"`
+
+	// SMTPUsername is the username used to authenticate to the SMTP server.
 	SMTPUsername string `hcl:"smtp_username,optional"`
+
+	// SMTPPassword is the password used to authenticate to the SMTP server.
 	SMTPPassword string `hcl:"smtp_password,optional"`
-	FromAddress  string `hcl:"from_address,optional"`
-	FromName     string `hcl:"from_name,optional"`
-	UseTLS       bool   `hcl:"use_tls,optional"`
+
+	// FromAddress is the email address notifications are sent from.
+	FromAddress string `hcl:"from_address,optional"`
+
+	// FromName is the display name notifications are sent from.
+	FromName string `hcl:"from_name,optional"`
+
+	// UseTLS indicates whether to connect to the SMTP server with TLS.
+	UseTLS bool `hcl:"use_tls,optional"`
+}
+
+// Validate validates the mail backend settings.
+func (c *MailConfig) Validate() error {
+	if c.Enabled && (c.SMTPHost == "" || c.FromAddress == "") {
+		return fmt.Errorf(
+			"smtp_host and from_address must be set if the mail backend is enabled")
+	}
+	return nil
 }
 
 // NtfyConfig configures the ntfy backend
 type NtfyConfig struct {
+	// Enabled indicates whether notifications are published to ntfy.
 	Enabled bool `hcl:"enabled,optional"`
 
+	// ServerURL is the URL of the ntfy server.
 	ServerURL string `hcl:"server_url,optional"`
-	Topic     string `hcl:"topic,optional"`
+
+	// Topic is the ntfy topic notifications are published to. With per-user
+	// topics, it only receives notifications without email recipients, such
+	// as operational events.
+	Topic string `hcl:"topic,optional"`
+
+	// PerUserTopics publishes notifications to a topic per recipient instead,
+	// named topic_prefix followed by a hash of their email address.
+	PerUserTopics bool `hcl:"per_user_topics,optional"`
+
+	// TopicPrefix is the prefix of per-user topics (default: "hermes-").
+	TopicPrefix string `hcl:"topic_prefix,optional"`
+
+	// TopicSecret is the key the hashes of per-user topics are derived with,
+	// so topics can't be guessed from email addresses (recommended with public
+	// ntfy servers).
+	TopicSecret string `hcl:"topic_secret,optional"`
+
+	// Actions are the action buttons added to notifications: "view" opens the
+	// document, and "approve" opens the approval link (or the document) of
+	// review notifications (default: ["view", "approve"]). Set it to [] to
+	// disable action buttons.
+	Actions []string `hcl:"actions,optional"`
+}
+
+// ntfyTopicRE matches the characters ntfy allows in topics.
+var ntfyTopicRE = regexp.MustCompile(`^[-_A-Za-z0-9]*$`)
+
+// Validate validates the ntfy backend settings.
+func (c *NtfyConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.Topic == "" && !c.PerUserTopics {
+		return fmt.Errorf(
+			"topic or per_user_topics must be set if the ntfy backend is enabled")
+	}
+	if !ntfyTopicRE.MatchString(c.TopicPrefix) || len(c.TopicPrefix) > 32 {
+		return fmt.Errorf("topic_prefix must have at most 32 letters, digits, " +
+			"hyphens, and underscores")
+	}
+	for _, a := range c.Actions {
+		if !slices.Contains([]string{NtfyActionView, NtfyActionApprove}, a) {
+			return fmt.Errorf("actions must be %q or %q, got %q",
+				NtfyActionView, NtfyActionApprove, a)
+		}
+	}
+	return nil
 }
 
 // Registry manages available notification backends
@@ -59,9 +194,17 @@ func NewRegistry(cfg *Config) (*Registry, error) {
 
 	// Initialize audit backend
 	if cfg.Audit != nil && cfg.Audit.Enabled {
-		backend := NewAuditBackend()
+		backend, err := newAuditBackend(cfg.Audit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize audit backend: %w", err)
+		}
 		registry.backends["audit"] = backend
-		log.Printf("Initialized audit backend")
+		if cfg.Audit.Path != "" {
+			log.Printf("Initialized audit backend (path=%s, s3=%t)",
+				cfg.Audit.Path, cfg.Audit.S3 != nil)
+		} else {
+			log.Printf("Initialized audit backend")
+		}
 	}
 
 	// Initialize mail backend
@@ -84,21 +227,49 @@ func NewRegistry(cfg *Config) (*Registry, error) {
 	// Initialize ntfy backend
 	if cfg.Ntfy != nil && cfg.Ntfy.Enabled {
 		backend := NewNtfyBackend(NtfyBackendConfig{
-			ServerURL: cfg.Ntfy.ServerURL,
-			Topic:     cfg.Ntfy.Topic,
+			ServerURL:     cfg.Ntfy.ServerURL,
+			Topic:         cfg.Ntfy.Topic,
+			PerUserTopics: cfg.Ntfy.PerUserTopics,
+			TopicPrefix:   cfg.Ntfy.TopicPrefix,
+			TopicSecret:   cfg.Ntfy.TopicSecret,
+			Actions:       cfg.Ntfy.Actions,
 		})
 		registry.backends["ntfy"] = backend
 		serverURL := cfg.Ntfy.ServerURL
 		if serverURL == "" {
 			serverURL = "https://ntfy.sh (default)"
 		}
-		log.Printf("Initialized ntfy backend (server=%s, topic=%s)",
-			serverURL, cfg.Ntfy.Topic)
+		log.Printf("Initialized ntfy backend (server=%s, topic=%s, per_user_topics=%t)",
+			serverURL, cfg.Ntfy.Topic, cfg.Ntfy.PerUserTopics)
 	}
 
 	return registry, nil
 }
 
+// newAuditBackend returns the audit backend configured by cfg.
+func newAuditBackend(cfg *AuditConfig) (*AuditBackend, error) {
+	if cfg.Path == "" {
+		return NewAuditBackend(), nil
+	}
+
+	var shipper AuditShipper
+	if cfg.S3 != nil {
+		host, _ := os.Hostname()
+		s, err := NewS3AuditShipper(context.Background(), cfg.S3, host)
+		if err != nil {
+			return nil, err
+		}
+		shipper = s
+	}
+	return NewAuditFileBackend(AuditBackendConfig{
+		Path:           cfg.Path,
+		MaxSize:        int64(cfg.MaxSizeMB) << 20,
+		RotateInterval: cfg.RotateInterval,
+		MaxFiles:       cfg.MaxFiles,
+		Shipper:        shipper,
+	})
+}
+
 // GetBackend returns a backend by name
 func (r *Registry) GetBackend(name string) (Backend, bool) {
 	backend, ok := r.backends[name]
@@ -0,0 +1,107 @@
+package notifications
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// Deduplicator collapses identical notifications: a recipient is notified at
+// most once per (template, document, event) within the deduplication window.
+// This prevents double notifications when several components (e.g., the API
+// server and an indexer step) publish the same event. It's safe for
+// concurrent use.
+type Deduplicator struct {
+	mu        sync.Mutex
+	window    time.Duration
+	seen      map[string]time.Time // Key => when it was last seen
+	lastPrune time.Time
+
+	// now returns the current time, and is replaced in tests.
+	now func() time.Time
+}
+
+// NewDeduplicator returns a deduplicator with window window. Deduplication is
+// disabled if window isn't positive.
+func NewDeduplicator(window time.Duration) *Deduplicator {
+	return &Deduplicator{
+		window: window,
+		seen:   map[string]time.Time{},
+		now:    time.Now,
+	}
+}
+
+// SetWindow changes the deduplication window to window.
+func (d *Deduplicator) SetWindow(window time.Duration) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.window = window
+}
+
+// Filter removes the recipients of msg that were sent an identical
+// notification within the window, and records the remaining ones as notified.
+// It returns the number of recipients removed. Messages without recipients
+// aren't changed.
+func (d *Deduplicator) Filter(msg *NotificationMessage) int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.window <= 0 || len(msg.Recipients) == 0 {
+		return 0
+	}
+
+	now := d.now()
+	d.prune(now)
+
+	recipients := make([]Recipient, 0, len(msg.Recipients))
+	for _, r := range msg.Recipients {
+		key := dedupKey(msg, r)
+		if key == "" {
+			recipients = append(recipients, r)
+			continue
+		}
+		if t, ok := d.seen[key]; ok && now.Sub(t) < d.window {
+			continue
+		}
+		d.seen[key] = now
+		recipients = append(recipients, r)
+	}
+
+	removed := len(msg.Recipients) - len(recipients)
+	msg.Recipients = recipients
+	return removed
+}
+
+// prune forgets the keys seen before the window, at most once per window.
+func (d *Deduplicator) prune(now time.Time) {
+	if now.Sub(d.lastPrune) < d.window {
+		return
+	}
+	for key, t := range d.seen {
+		if now.Sub(t) >= d.window {
+			delete(d.seen, key)
+		}
+	}
+	d.lastPrune = now
+}
+
+// dedupKey returns the deduplication key of the notification of msg to
+// recipient r, which is empty if r has no identifier.
+func dedupKey(msg *NotificationMessage, r Recipient) string {
+	var recipient string
+	switch {
+	case r.Email != "":
+		recipient = "email:" + strings.ToLower(r.Email)
+	case r.SlackID != "":
+		recipient = "slack:" + r.SlackID
+	case r.TelegramID != "":
+		recipient = "telegram:" + r.TelegramID
+	case r.DiscordID != "":
+		recipient = "discord:" + r.DiscordID
+	default:
+		return ""
+	}
+	return strings.Join([]string{
+		recipient, msg.Template, msg.DocumentUUID, string(msg.Type),
+	}, "\x00")
+}
@@ -0,0 +1,107 @@
+package notifications
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeduplicatorFilter(t *testing.T) {
+	now := time.Date(2025, 11, 14, 10, 0, 0, 0, time.UTC)
+	d := NewDeduplicator(10 * time.Minute)
+	d.now = func() time.Time { return now }
+
+	newMsg := func(docUUID string, emails ...string) *NotificationMessage {
+		msg := &NotificationMessage{
+			ID:           "msg",
+			Type:         NotificationTypeReviewRequested,
+			Template:     "review_requested",
+			DocumentUUID: docUUID,
+		}
+		for _, e := range emails {
+			msg.Recipients = append(msg.Recipients, Recipient{Email: e})
+		}
+		return msg
+	}
+
+	msg := newMsg("doc1", "alice@example.com", "bob@example.com")
+	assert.Equal(t, 0, d.Filter(msg))
+	assert.Len(t, msg.Recipients, 2)
+
+	// The same event for the same document is collapsed per recipient.
+	msg = newMsg("doc1", "Alice@example.com", "carol@example.com")
+	assert.Equal(t, 1, d.Filter(msg))
+	assert.Equal(t, []Recipient{{Email: "carol@example.com"}}, msg.Recipients)
+
+	// Other documents and events aren't.
+	msg = newMsg("doc2", "alice@example.com")
+	assert.Equal(t, 0, d.Filter(msg))
+	msg = newMsg("doc1", "alice@example.com")
+	msg.Type = NotificationTypeDocumentApproved
+	msg.Template = "document_approved"
+	assert.Equal(t, 0, d.Filter(msg))
+
+	// Recipients without an identifier are kept.
+	msg = newMsg("doc1")
+	msg.Recipients = []Recipient{{Name: "Ops"}, {Name: "Ops"}}
+	assert.Equal(t, 0, d.Filter(msg))
+	assert.Len(t, msg.Recipients, 2)
+
+	// Notifications are sent again after the window.
+	now = now.Add(10 * time.Minute)
+	msg = newMsg("doc1", "alice@example.com", "bob@example.com")
+	assert.Equal(t, 0, d.Filter(msg))
+	assert.Len(t, msg.Recipients, 2)
+	assert.Len(t, d.seen, 2, "expired keys are pruned")
+}
+
+func TestDeduplicatorDisabled(t *testing.T) {
+	d := NewDeduplicator(0)
+	for i := 0; i < 2; i++ {
+		msg := &NotificationMessage{
+			Type:       NotificationTypeNewOwner,
+			Recipients: []Recipient{{Email: "alice@example.com"}},
+		}
+		assert.Equal(t, 0, d.Filter(msg))
+		assert.Len(t, msg.Recipients, 1)
+	}
+
+	d.SetWindow(time.Minute)
+	msg := &NotificationMessage{
+		Type:       NotificationTypeNewOwner,
+		Recipients: []Recipient{{Email: "alice@example.com"}},
+	}
+	assert.Equal(t, 0, d.Filter(msg))
+	msg.Recipients = []Recipient{{Email: "alice@example.com"}}
+	assert.Equal(t, 1, d.Filter(msg))
+	assert.Empty(t, msg.Recipients)
+}
+
+func TestDeduplicatorConcurrent(t *testing.T) {
+	d := NewDeduplicator(time.Minute)
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		delivered int
+	)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			msg := &NotificationMessage{
+				Type:         NotificationTypeDocumentPublished,
+				DocumentUUID: "doc1",
+				Recipients:   []Recipient{{SlackID: "U123"}},
+			}
+			d.Filter(msg)
+			mu.Lock()
+			delivered += len(msg.Recipients)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 1, delivered)
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/hashicorp-forge/hermes/pkg/kafka/producer"
 	"github.com/twmb/franz-go/pkg/kgo"
 )
 
@@ -33,8 +34,8 @@ type DLQMessage struct {
 
 // DLQPublisher publishes messages to the Dead Letter Queue
 type DLQPublisher struct {
-	client *kgo.Client
-	topic  string
+	producer *producer.Producer
+	topic    string
 }
 
 // DLQPublisherConfig holds DLQ publisher configuration
@@ -52,20 +53,19 @@ func NewDLQPublisher(cfg DLQPublisherConfig) (*DLQPublisher, error) {
 		cfg.Topic = "hermes.notifications.dlq" // Default DLQ topic
 	}
 
-	client, err := kgo.NewClient(
-		kgo.SeedBrokers(cfg.Brokers...),
-		// DLQ messages should never be lost
-		kgo.RequiredAcks(kgo.AllISRAcks()),
-		kgo.ProducerBatchCompression(kgo.GzipCompression()),
-		kgo.RequestRetries(10),
-	)
+	// DLQ messages should never be lost, so the producer keeps the default
+	// acks of all in-sync replicas.
+	p, err := producer.New(producer.Config{
+		Brokers: cfg.Brokers,
+		Name:    "notifications-dlq",
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create DLQ kafka client: %w", err)
+		return nil, fmt.Errorf("failed to create DLQ kafka producer: %w", err)
 	}
 
 	return &DLQPublisher{
-		client: client,
-		topic:  cfg.Topic,
+		producer: p,
+		topic:    cfg.Topic,
 	}, nil
 }
 
@@ -109,7 +109,7 @@ func (p *DLQPublisher) PublishToDLQ(ctx context.Context, msg *NotificationMessag
 		Value: dlqJSON,
 	}
 
-	if err := p.client.ProduceSync(ctx, record).FirstErr(); err != nil {
+	if _, err := p.producer.Produce(ctx, record); err != nil {
 		return fmt.Errorf("failed to publish to DLQ: %w", err)
 	}
 
@@ -118,7 +118,7 @@ func (p *DLQPublisher) PublishToDLQ(ctx context.Context, msg *NotificationMessag
 
 // Close closes the DLQ publisher
 func (p *DLQPublisher) Close() {
-	p.client.Close()
+	p.producer.Close()
 }
 
 // DLQMonitor provides methods for monitoring and replaying DLQ messages
@@ -13,6 +13,29 @@ const (
 	NotificationTypeReviewRequested   NotificationType = "review_requested"
 	NotificationTypeNewOwner          NotificationType = "new_owner"
 	NotificationTypeDocumentPublished NotificationType = "document_published"
+
+	// Migration lifecycle (RFC-089), routed to the ops channel
+	NotificationTypeMigrationJobStarted   NotificationType = "migration_job_started"
+	NotificationTypeMigrationJobHalfway   NotificationType = "migration_job_halfway"
+	NotificationTypeMigrationItemFailed   NotificationType = "migration_item_failed"
+	NotificationTypeMigrationJobCompleted NotificationType = "migration_job_completed"
+
+	// Review SLAs, routed to document owners and pending reviewers
+	NotificationTypeReviewSLAAtRisk   NotificationType = "review_sla_at_risk"
+	NotificationTypeReviewSLABreached NotificationType = "review_sla_breached"
+
+	// Sensitive data found in document content, routed to document owners
+	NotificationTypeSensitiveDataDetected NotificationType = "sensitive_data_detected"
+
+	// Retention policies about to be applied, routed to document owners
+	NotificationTypeRetentionNotice NotificationType = "retention_notice"
+
+	// New documents that match saved searches, routed to the subscribers
+	NotificationTypeSavedSearchDigest NotificationType = "saved_search_digest"
+
+	// Document content or attachments that failed integrity checks, routed to
+	// the ops channel
+	NotificationTypeDocumentIntegrityFailed NotificationType = "document_integrity_failed"
 )
 
 // NotificationMessage is the envelope for all notifications
@@ -58,4 +81,5 @@ type Recipient struct {
 	SlackID    string `json:"slack_id,omitempty"`    // Slack user ID
 	TelegramID string `json:"telegram_id,omitempty"` // Telegram user ID
 	DiscordID  string `json:"discord_id,omitempty"`  // Discord user ID
+	Locale     string `json:"locale,omitempty"`      // Preferred locale (e.g., "de"), which selects the language of the notification
 }
@@ -7,19 +7,24 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/hashicorp-forge/hermes/pkg/kafka/producer"
 	"github.com/twmb/franz-go/pkg/kgo"
 )
 
 // Publisher publishes notifications to Redpanda/Kafka
 type Publisher struct {
-	client *kgo.Client
-	topic  string
+	producer *producer.Producer
+	topic    string
 }
 
 // PublisherConfig holds configuration for the publisher
 type PublisherConfig struct {
 	Brokers []string
 	Topic   string
+
+	// Producer configures producing notifications (acks, compression, and
+	// idempotence). Its brokers are Brokers.
+	Producer producer.Config
 }
 
 // NewPublisher creates a new notification publisher
@@ -31,41 +36,21 @@ func NewPublisher(cfg PublisherConfig) (*Publisher, error) {
 		return nil, fmt.Errorf("topic is required")
 	}
 
-	client, err := kgo.NewClient(
-		kgo.SeedBrokers(cfg.Brokers...),
-
-		// Producer Durability (RFC-087-ADDENDUM Section 10)
-		// Wait for all in-sync replicas to acknowledge (production-ready)
-		kgo.RequiredAcks(kgo.AllISRAcks()),
-
-		// Enable idempotent producer (prevents duplicate messages on retry)
-		// franz-go enables idempotency by default when using RequiredAcks(AllISRAcks)
-
-		// Enable compression (reduces network bandwidth)
-		kgo.ProducerBatchCompression(kgo.GzipCompression()),
-
-		// Retry configuration with exponential backoff
-		// Retry up to 10 times with exponential backoff: 100ms, 200ms, 400ms, 800ms, 1.6s, 3.2s, 6.4s, 12.8s, 25.6s, 51.2s
-		kgo.RetryBackoffFn(func(tries int) time.Duration {
-			backoff := time.Duration(tries) * 100 * time.Millisecond
-			if backoff > 60*time.Second {
-				backoff = 60 * time.Second // Cap at 60s
-			}
-			return backoff
-		}),
-		kgo.RequestRetries(10),
-
-		// Producer linger and batch settings for better throughput
-		kgo.ProducerLinger(10*time.Millisecond), // Wait up to 10ms to batch messages
-		kgo.ProducerBatchMaxBytes(1<<20),        // 1MB max batch size
-	)
+	// Producer Durability (RFC-087-ADDENDUM Section 10): by default, all
+	// in-sync replicas acknowledge notifications, which are produced
+	// idempotently so retries don't duplicate them.
+	cfg.Producer.Brokers = cfg.Brokers
+	if cfg.Producer.Name == "" {
+		cfg.Producer.Name = "notifications"
+	}
+	p, err := producer.New(cfg.Producer)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create kafka client: %w", err)
+		return nil, fmt.Errorf("failed to create kafka producer: %w", err)
 	}
 
 	return &Publisher{
-		client: client,
-		topic:  cfg.Topic,
+		producer: p,
+		topic:    cfg.Topic,
 	}, nil
 }
 
@@ -111,7 +96,7 @@ func (p *Publisher) PublishMessage(ctx context.Context, msg *NotificationMessage
 		Value: msgJSON,
 	}
 
-	if err := p.client.ProduceSync(ctx, record).FirstErr(); err != nil {
+	if _, err := p.producer.Produce(ctx, record); err != nil {
 		return fmt.Errorf("failed to publish notification: %w", err)
 	}
 
@@ -138,7 +123,7 @@ func (p *Publisher) PublishEmail(ctx context.Context, to []string, from, subject
 
 // Close closes the publisher
 func (p *Publisher) Close() {
-	p.client.Close()
+	p.producer.Close()
 }
 
 // determinePartitionKey ensures related messages go to same partition
@@ -0,0 +1,174 @@
+package purge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp-forge/hermes/pkg/workspace"
+	"github.com/hashicorp/go-hclog"
+	"gorm.io/gorm"
+)
+
+const (
+	// DefaultRecoveryWindow is how long deleted documents and projects can be
+	// restored by default.
+	DefaultRecoveryWindow = 30 * 24 * time.Hour
+
+	// DefaultInterval is how often expired documents and projects are purged
+	// by default.
+	DefaultInterval = 24 * time.Hour
+)
+
+// DocumentDeleter deletes documents in the workspace provider.
+type DocumentDeleter interface {
+	DeleteDocument(ctx context.Context, providerID string) error
+}
+
+// Job periodically purges the documents and projects that were deleted longer
+// than the recovery window ago. Deleted documents keep their workspace
+// provider file until they are purged, so they can be restored with their
+// content.
+type Job struct {
+	db             *gorm.DB
+	deleter        DocumentDeleter
+	logger         hclog.Logger
+	interval       time.Duration
+	providerName   string
+	recoveryWindow time.Duration
+}
+
+// Config contains purge job configuration.
+type Config struct {
+	// Interval is how often expired documents and projects are purged.
+	Interval time.Duration
+
+	// ProviderName is the name of the workspace provider, which prefixes the
+	// provider IDs of documents (e.g., "google").
+	ProviderName string
+
+	// RecoveryWindow is how long deleted documents and projects can be
+	// restored before they are purged.
+	RecoveryWindow time.Duration
+}
+
+// NewJob creates a new purge job.
+func NewJob(
+	db *gorm.DB,
+	deleter DocumentDeleter,
+	logger hclog.Logger,
+	cfg *Config,
+) *Job {
+	if logger == nil {
+		logger = hclog.NewNullLogger()
+	}
+	j := &Job{
+		db:             db,
+		deleter:        deleter,
+		logger:         logger.Named("purge"),
+		interval:       DefaultInterval,
+		providerName:   "google",
+		recoveryWindow: DefaultRecoveryWindow,
+	}
+	if cfg != nil {
+		if cfg.Interval > 0 {
+			j.interval = cfg.Interval
+		}
+		if cfg.ProviderName != "" {
+			j.providerName = cfg.ProviderName
+		}
+		if cfg.RecoveryWindow > 0 {
+			j.recoveryWindow = cfg.RecoveryWindow
+		}
+	}
+	return j
+}
+
+// Start purges expired documents and projects immediately and then every
+// interval until ctx is canceled.
+func (j *Job) Start(ctx context.Context) error {
+	j.logger.Info("purge job started",
+		"interval", j.interval,
+		"recovery_window", j.recoveryWindow)
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		res, err := j.Run(ctx)
+		if err != nil {
+			j.logger.Error("error purging deleted documents and projects",
+				"error", err)
+		} else if res.Documents > 0 || res.Projects > 0 {
+			j.logger.Info("purged deleted documents and projects",
+				"documents", res.Documents,
+				"projects", res.Projects)
+		}
+
+		select {
+		case <-ctx.Done():
+			j.logger.Info("purge job stopped")
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Result is the result of a purge run.
+type Result struct {
+	Documents int
+	Projects  int
+}
+
+// Run purges the documents and projects deleted before the recovery window
+// once. Documents whose workspace provider file can't be deleted are kept
+// and retried at the next run.
+func (j *Job) Run(ctx context.Context) (Result, error) {
+	var res Result
+	cutoff := time.Now().Add(-j.recoveryWindow)
+	db := j.db.WithContext(ctx)
+
+	var docs models.Documents
+	if err := docs.FindDeleted(db, "documents.deleted_at < ?", cutoff); err != nil {
+		return res, fmt.Errorf("error finding expired documents: %w", err)
+	}
+	for _, d := range docs {
+		if ctx.Err() != nil {
+			return res, ctx.Err()
+		}
+
+		providerID := fmt.Sprintf("%s:%s", j.providerName, d.GoogleFileID)
+		if err := j.deleter.DeleteDocument(ctx, providerID); err != nil &&
+			!errors.Is(err, workspace.ErrNotFound) {
+			j.logger.Warn("error deleting expired document in workspace provider",
+				"error", err,
+				"doc_id", d.GoogleFileID)
+			continue
+		}
+		if err := d.Purge(db); err != nil {
+			j.logger.Warn("error purging expired document",
+				"error", err,
+				"doc_id", d.GoogleFileID)
+			continue
+		}
+		res.Documents++
+	}
+
+	var projs models.Projects
+	if err := projs.FindDeleted(db, "projects.deleted_at < ?", cutoff); err != nil {
+		return res, fmt.Errorf("error finding expired projects: %w", err)
+	}
+	for _, p := range projs {
+		if err := p.Purge(db); err != nil {
+			j.logger.Warn("error purging expired project",
+				"error", err,
+				"project_id", p.ID)
+			continue
+		}
+		res.Projects++
+	}
+
+	return res, nil
+}
@@ -0,0 +1,97 @@
+// Package requestid correlates the log lines, audit events, and responses of
+// a request with a request ID.
+//
+// The request ID is taken from the X-Request-ID request header if the client
+// supplied a usable one, or generated otherwise. It's stored in the request
+// context and returned in the X-Request-ID response header. Contexts derived
+// from the request context (including with context.WithoutCancel, for
+// post-processing after the response is written) keep the request ID.
+package requestid
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/go-hclog"
+)
+
+// Header is the request and response header of the request ID.
+const Header = "X-Request-ID"
+
+// maxLength is the maximum length of a client-supplied request ID.
+const maxLength = 128
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx with request ID id.
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the request ID in ctx, or an empty string if ctx has
+// none.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+// Parse returns the client-supplied request ID id if it's usable, or a new
+// request ID otherwise.
+func Parse(id string) string {
+	id = strings.TrimSpace(id)
+	if id == "" || len(id) > maxLength {
+		return uuid.NewString()
+	}
+	return id
+}
+
+// Logger returns l with the request ID in ctx, if any, added to every line.
+func Logger(ctx context.Context, l hclog.Logger) hclog.Logger {
+	if id := FromContext(ctx); id != "" {
+		return l.With("request_id", id)
+	}
+	return l
+}
+
+// Handler stores the request ID of each request in its context and returns
+// it in the response, then serves the request with next. Each request is
+// logged with l when its response is written.
+func Handler(l hclog.Logger, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := Parse(r.Header.Get(Header))
+		w.Header().Set(Header, id)
+		r = r.WithContext(NewContext(r.Context(), id))
+
+		start := time.Now()
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(sw, r)
+
+		l.Info("request",
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", sw.status,
+			"duration", time.Since(start),
+			"request_id", id,
+		)
+	})
+}
+
+// statusWriter records the status code of a response.
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Unwrap returns the underlying response writer, so http.ResponseController
+// can flush it (e.g., for server-sent events).
+func (w *statusWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
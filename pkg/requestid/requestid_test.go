@@ -0,0 +1,67 @@
+package requestid
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	assert.Equal(t, "req-1", Parse(" req-1 "))
+	assert.NotEmpty(t, Parse(""))
+	assert.NotEqual(t, Parse(""), Parse(""))
+
+	long := strings.Repeat("a", maxLength+1)
+	assert.NotEqual(t, long, Parse(long))
+}
+
+func TestHandler(t *testing.T) {
+	var buf bytes.Buffer
+	l := hclog.New(&hclog.LoggerOptions{Output: &buf, JSONFormat: true})
+
+	var gotID string
+	h := Handler(l, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Post-processing contexts keep the request ID.
+		gotID = FromContext(context.WithoutCancel(r.Context()))
+		Logger(r.Context(), l).Info("handling")
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	t.Run("Client-supplied request ID", func(t *testing.T) {
+		buf.Reset()
+		req := httptest.NewRequest("POST", "/api/v2/drafts", nil)
+		req.Header.Set(Header, "req-1")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+
+		assert.Equal(t, "req-1", w.Header().Get(Header))
+		assert.Equal(t, "req-1", gotID)
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, 2)
+		assert.Contains(t, lines[0], `"request_id":"req-1"`)
+		assert.Contains(t, lines[1], `"request_id":"req-1"`)
+		assert.Contains(t, lines[1], `"status":201`)
+	})
+
+	t.Run("Generated request ID", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+
+		assert.NotEmpty(t, w.Header().Get(Header))
+		assert.Equal(t, w.Header().Get(Header), gotID)
+	})
+}
+
+func TestLogger(t *testing.T) {
+	l := hclog.NewNullLogger()
+	assert.Same(t, l, Logger(context.Background(), l),
+		"contexts without a request ID use the logger")
+}
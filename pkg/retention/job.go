@@ -0,0 +1,340 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp-forge/hermes/pkg/search"
+	"github.com/hashicorp/go-hclog"
+	"gorm.io/gorm"
+)
+
+// DefaultInterval is how often retention policies are applied by default.
+const DefaultInterval = 24 * time.Hour
+
+// Config contains retention job configuration.
+type Config struct {
+	// Policies are the retention policies, applied in order. A document is
+	// only handled by the first policy it matches.
+	Policies []Policy
+
+	// Interval is how often retention policies are applied.
+	Interval time.Duration
+}
+
+// Job periodically applies retention policies to the documents that haven't
+// been modified for the period of a policy. Owners are notified once, the
+// notice period of the policy before it's applied. The policy that a document
+// was noticed about or that was applied to it is recorded on the document
+// record, and cleared when the document is modified again.
+type Job struct {
+	db             *gorm.DB
+	searchProvider search.Provider
+	notifier       Notifier
+	logger         hclog.Logger
+	policies       []Policy
+	interval       time.Duration
+}
+
+// NewJob creates a new retention job. Owners aren't notified if notifier is
+// nil. Invalid policies are logged and skipped.
+func NewJob(
+	db *gorm.DB,
+	searchProvider search.Provider,
+	notifier Notifier,
+	logger hclog.Logger,
+	cfg *Config,
+) *Job {
+	if logger == nil {
+		logger = hclog.NewNullLogger()
+	}
+	j := &Job{
+		db:             db,
+		searchProvider: searchProvider,
+		notifier:       notifier,
+		logger:         logger.Named("retention"),
+		interval:       DefaultInterval,
+	}
+	if cfg != nil {
+		for _, p := range cfg.Policies {
+			if err := p.Validate(); err != nil {
+				j.logger.Error("skipping invalid retention policy",
+					"policy", p.Name,
+					"error", err)
+				continue
+			}
+			j.policies = append(j.policies, p)
+		}
+		if cfg.Interval > 0 {
+			j.interval = cfg.Interval
+		}
+	}
+	return j
+}
+
+// Start applies retention policies immediately and then every interval until
+// ctx is canceled.
+func (j *Job) Start(ctx context.Context) error {
+	j.logger.Info("retention job started",
+		"interval", j.interval,
+		"policies", len(j.policies))
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		res, err := j.Run(ctx)
+		if err != nil {
+			j.logger.Error("error applying retention policies", "error", err)
+		} else if res.Noticed > 0 || res.Applied > 0 {
+			j.logger.Info("retention policies applied",
+				"noticed", res.Noticed,
+				"applied", res.Applied)
+		}
+
+		select {
+		case <-ctx.Done():
+			j.logger.Info("retention job stopped")
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Result is the result of a retention run.
+type Result struct {
+	// Noticed is the number of documents whose owners were notified.
+	Noticed int
+
+	// Applied is the number of documents that policies were applied to.
+	Applied int
+}
+
+// candidate is a document that a policy applies to now or within its notice
+// period.
+type candidate struct {
+	ID                    uint
+	GoogleFileID          string
+	DocumentUUID          string
+	Title                 string
+	DocumentNumber        int
+	DocumentModifiedAt    time.Time
+	Status                models.DocumentStatus
+	RetentionNoticeSentAt *time.Time
+	DocType               string
+	Product               string
+	ProductAbbreviation   string
+	Owner                 string
+}
+
+// Run applies all retention policies once.
+func (j *Job) Run(ctx context.Context) (Result, error) {
+	var res Result
+	if len(j.policies) == 0 {
+		return res, nil
+	}
+	db := j.db.WithContext(ctx)
+
+	// Documents that were modified after their owners were notified or a
+	// policy was applied start over.
+	if err := db.
+		Model(&models.Document{}).
+		Where(`(retention_notice_sent_at IS NOT NULL AND
+			document_modified_at > retention_notice_sent_at) OR
+			(retention_applied_at IS NOT NULL AND
+			document_modified_at > retention_applied_at)`).
+		UpdateColumns(map[string]any{
+			"retention_policy":         "",
+			"retention_notice_sent_at": nil,
+			"retention_applied_at":     nil,
+		}).
+		Error; err != nil {
+		return res, fmt.Errorf("error resetting retention of modified documents: %w", err)
+	}
+
+	now := time.Now()
+	for _, p := range j.policies {
+		if ctx.Err() != nil {
+			return res, ctx.Err()
+		}
+
+		n, a, err := j.runPolicy(ctx, p, now)
+		res.Noticed += n
+		res.Applied += a
+		if err != nil {
+			return res, fmt.Errorf("error applying retention policy %q: %w",
+				p.Name, err)
+		}
+	}
+
+	return res, nil
+}
+
+// runPolicy applies policy p and returns the number of documents whose owners
+// were notified and the number of documents it was applied to.
+func (j *Job) runPolicy(
+	ctx context.Context, p Policy, now time.Time,
+) (int, int, error) {
+	q := j.db.WithContext(ctx).
+		Table("documents AS d").
+		Select(`d.id, d.google_file_id,
+			COALESCE(CAST(d.document_uuid AS TEXT), '') AS document_uuid,
+			d.title, d.document_number, d.document_modified_at, d.status,
+			d.retention_notice_sent_at,
+			dt.name AS doc_type, p.name AS product,
+			p.abbreviation AS product_abbreviation,
+			COALESCE(u.email_address, '') AS owner`).
+		Joins("JOIN document_types dt ON dt.id = d.document_type_id").
+		Joins("JOIN products p ON p.id = d.product_id").
+		Joins("LEFT JOIN users u ON u.id = d.owner_id").
+		Where("d.deleted_at IS NULL").
+		Where("d.retention_applied_at IS NULL").
+		Where("d.retention_policy IS NULL OR d.retention_policy IN ?",
+			[]string{"", p.Name}).
+		Where("d.status IN ?", p.Statuses).
+		Where("d.document_modified_at <= ?", now.Add(-(p.After - p.Notice)))
+	if len(p.DocumentTypes) > 0 {
+		q = q.Where("dt.name IN ?", p.DocumentTypes)
+	}
+	if len(p.Products) > 0 {
+		q = q.Where("p.name IN ?", p.Products)
+	}
+
+	var docs []candidate
+	if err := q.Order("d.id").Scan(&docs).Error; err != nil {
+		return 0, 0, fmt.Errorf("error getting documents: %w", err)
+	}
+
+	var noticed, applied int
+	for _, d := range docs {
+		if ctx.Err() != nil {
+			return noticed, applied, ctx.Err()
+		}
+
+		actionAt := d.DocumentModifiedAt.Add(p.After)
+		if p.Notice > 0 {
+			if d.RetentionNoticeSentAt == nil {
+				// Owners always get the full notice period.
+				if earliest := now.Add(p.Notice); actionAt.Before(earliest) {
+					actionAt = earliest
+				}
+				if j.notice(ctx, p, d, actionAt, now) {
+					noticed++
+				}
+				continue
+			}
+			if earliest := d.RetentionNoticeSentAt.Add(p.Notice); actionAt.Before(earliest) {
+				actionAt = earliest
+			}
+		}
+		if now.Before(actionAt) {
+			continue
+		}
+
+		if err := j.apply(ctx, p, d, now); err != nil {
+			j.logger.Error("error applying retention policy",
+				"error", err,
+				"policy", p.Name,
+				"doc_id", d.GoogleFileID)
+			continue
+		}
+		applied++
+	}
+
+	return noticed, applied, nil
+}
+
+// notice records that the owner of a document was notified about policy p and
+// notifies them. It returns false if the owner was already notified, e.g., by
+// another server.
+func (j *Job) notice(
+	ctx context.Context, p Policy, d candidate, actionAt, now time.Time,
+) bool {
+	res := j.db.WithContext(ctx).
+		Model(&models.Document{}).
+		Where("id = ? AND retention_notice_sent_at IS NULL", d.ID).
+		UpdateColumns(map[string]any{
+			"retention_policy":         p.Name,
+			"retention_notice_sent_at": now,
+		})
+	if res.Error != nil {
+		j.logger.Error("error recording retention notice",
+			"error", res.Error,
+			"policy", p.Name,
+			"doc_id", d.GoogleFileID)
+		return false
+	}
+	if res.RowsAffected != 1 {
+		return false
+	}
+
+	if j.notifier == nil {
+		return true
+	}
+	docNumber := fmt.Sprintf("%s-%03d", d.ProductAbbreviation, d.DocumentNumber)
+	if d.Status == models.WIPDocumentStatus {
+		docNumber = fmt.Sprintf("%s-???", d.ProductAbbreviation)
+	}
+	if err := j.notifier.NotifyRetentionEvent(ctx, &Event{
+		Policy:       p.Name,
+		Action:       p.Action,
+		DocumentID:   d.GoogleFileID,
+		DocumentUUID: d.DocumentUUID,
+		Title:        d.Title,
+		DocNumber:    docNumber,
+		DocType:      d.DocType,
+		Product:      d.Product,
+		Owner:        d.Owner,
+		ModifiedAt:   d.DocumentModifiedAt,
+		ActionAt:     actionAt,
+	}); err != nil {
+		j.logger.Error("error notifying owner about retention policy",
+			"error", err,
+			"policy", p.Name,
+			"doc_id", d.GoogleFileID)
+	}
+	return true
+}
+
+// apply applies policy p to a document and records it on the document record.
+func (j *Job) apply(
+	ctx context.Context, p Policy, d candidate, now time.Time,
+) error {
+	if j.searchProvider != nil {
+		var err error
+		if d.Status == models.WIPDocumentStatus {
+			err = j.searchProvider.DraftIndex().Delete(ctx, d.GoogleFileID)
+		} else {
+			err = j.searchProvider.DocumentIndex().Delete(ctx, d.GoogleFileID)
+		}
+		if err != nil &&
+			!errors.Is(err, search.ErrNotFound) {
+			return fmt.Errorf("error removing document from search: %w", err)
+		}
+	}
+
+	return j.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.
+			Model(&models.Document{}).
+			Where("id = ?", d.ID).
+			UpdateColumns(map[string]any{
+				"retention_policy":     p.Name,
+				"retention_applied_at": now,
+			}).
+			Error; err != nil {
+			return fmt.Errorf("error recording retention policy: %w", err)
+		}
+
+		if p.Action == ActionArchive {
+			doc := models.Document{GoogleFileID: d.GoogleFileID}
+			doc.ID = d.ID
+			if err := doc.Delete(tx); err != nil {
+				return fmt.Errorf("error archiving document: %w", err)
+			}
+		}
+		return nil
+	})
+}
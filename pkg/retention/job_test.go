@@ -0,0 +1,207 @@
+package retention
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp-forge/hermes/pkg/models/modelstest"
+	"github.com/hashicorp-forge/hermes/pkg/search"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type fakeNotifier struct {
+	events []*Event
+}
+
+func (n *fakeNotifier) NotifyRetentionEvent(ctx context.Context, ev *Event) error {
+	n.events = append(n.events, ev)
+	return nil
+}
+
+// fakeSearch records the documents removed from search.
+type fakeSearch struct {
+	search.Provider
+	removed []string
+}
+
+func (s *fakeSearch) DocumentIndex() search.DocumentIndex {
+	return fakeDocumentIndex{s: s}
+}
+
+func (s *fakeSearch) DraftIndex() search.DraftIndex {
+	return fakeDraftIndex{s: s}
+}
+
+type fakeDocumentIndex struct {
+	search.DocumentIndex
+	s *fakeSearch
+}
+
+func (i fakeDocumentIndex) Delete(ctx context.Context, docID string) error {
+	i.s.removed = append(i.s.removed, docID)
+	return nil
+}
+
+type fakeDraftIndex struct {
+	search.DraftIndex
+	s *fakeSearch
+}
+
+func (i fakeDraftIndex) Delete(ctx context.Context, docID string) error {
+	i.s.removed = append(i.s.removed, docID)
+	return nil
+}
+
+func createDocument(
+	t *testing.T, db *gorm.DB, id, docType string,
+	status models.DocumentStatus, modifiedAt time.Time,
+) models.Document {
+	return modelstest.CreateDocument(t, db, models.Document{
+		GoogleFileID:       id,
+		DocumentNumber:     7,
+		Status:             status,
+		DocumentModifiedAt: modifiedAt,
+		DocumentType:       models.DocumentType{Name: docType},
+		Owner:              &models.User{EmailAddress: "owner@example.com"},
+	})
+}
+
+func TestJob(t *testing.T) {
+	db := modelstest.NewDB(t)
+	now := time.Now()
+	day := 24 * time.Hour
+
+	createDocument(t, db, "recentRFC", "RFC",
+		models.ObsoleteDocumentStatus, now.Add(-300*day))
+	oldRFC := createDocument(t, db, "oldRFC", "RFC",
+		models.ObsoleteDocumentStatus, now.Add(-400*day))
+	createDocument(t, db, "approvedRFC", "RFC",
+		models.ApprovedDocumentStatus, now.Add(-400*day))
+	createDocument(t, db, "oldPRD", "PRD",
+		models.ObsoleteDocumentStatus, now.Add(-400*day))
+	staleDraft := createDocument(t, db, "staleDraft", "PRD",
+		models.WIPDocumentStatus, now.Add(-200*day))
+
+	n := &fakeNotifier{}
+	s := &fakeSearch{}
+	j := NewJob(db, s, n, nil, &Config{
+		Policies: []Policy{
+			{
+				Name:          "obsolete-rfcs",
+				DocumentTypes: []string{"RFC"},
+				Statuses:      []models.DocumentStatus{models.ObsoleteDocumentStatus},
+				After:         365 * day,
+				Action:        ActionRemoveFromSearch,
+			},
+			{
+				Name:     "stale-drafts",
+				Statuses: []models.DocumentStatus{models.WIPDocumentStatus},
+				After:    180 * day,
+				Action:   ActionArchive,
+				Notice:   7 * day,
+			},
+			{
+				Name:     "invalid",
+				Statuses: []models.DocumentStatus{models.ApprovedDocumentStatus},
+				After:    day,
+				Action:   ActionArchive,
+			},
+		},
+	})
+
+	// Obsolete RFCs are removed from search right away, and the owners of
+	// stale drafts are given notice first.
+	res, err := j.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, Result{Noticed: 1, Applied: 1}, res)
+	assert.Equal(t, []string{"oldRFC"}, s.removed)
+	require.NoError(t, oldRFC.Get(db))
+	require.NotNil(t, oldRFC.RetentionAppliedAt)
+	assert.Equal(t, "obsolete-rfcs", oldRFC.RetentionPolicy)
+
+	require.Len(t, n.events, 1)
+	ev := n.events[0]
+	assert.Equal(t, "stale-drafts", ev.Policy)
+	assert.Equal(t, ActionArchive, ev.Action)
+	assert.Equal(t, "staleDraft", ev.DocumentID)
+	assert.Equal(t, "HRM-???", ev.DocNumber)
+	assert.Equal(t, "owner@example.com", ev.Owner)
+	assert.WithinDuration(t, now.Add(7*day), ev.ActionAt, time.Minute)
+
+	// Policies are applied and owners are notified once.
+	n.events, s.removed = nil, nil
+	res, err = j.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, Result{}, res)
+
+	// Drafts are archived after the notice period.
+	require.NoError(t, db.Model(&models.Document{}).
+		Where("id = ?", staleDraft.ID).
+		UpdateColumn("retention_notice_sent_at", now.Add(-8*day)).
+		Error)
+	res, err = j.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, Result{Applied: 1}, res)
+	assert.Equal(t, []string{"staleDraft"}, s.removed)
+	var deleted models.Documents
+	require.NoError(t, deleted.FindDeleted(db, "google_file_id = ?", "staleDraft"))
+	assert.Len(t, deleted, 1)
+
+	// Modified documents start over.
+	require.NoError(t, db.Model(&models.Document{}).
+		Where("id = ?", oldRFC.ID).
+		UpdateColumn("document_modified_at", now.Add(time.Minute)).
+		Error)
+	_, err = j.Run(context.Background())
+	require.NoError(t, err)
+	oldRFC = models.Document{GoogleFileID: "oldRFC"}
+	require.NoError(t, oldRFC.Get(db))
+	assert.Nil(t, oldRFC.RetentionAppliedAt)
+	assert.Empty(t, oldRFC.RetentionPolicy)
+}
+
+func TestPolicyValidate(t *testing.T) {
+	valid := Policy{
+		Name:     "stale-drafts",
+		Statuses: []models.DocumentStatus{models.WIPDocumentStatus},
+		After:    180 * 24 * time.Hour,
+		Action:   ActionArchive,
+		Notice:   7 * 24 * time.Hour,
+	}
+	require.NoError(t, valid.Validate())
+
+	for name, mutate := range map[string]func(p *Policy){
+		"no name":        func(p *Policy) { p.Name = "" },
+		"no statuses":    func(p *Policy) { p.Statuses = nil },
+		"no after":       func(p *Policy) { p.After = 0 },
+		"long notice":    func(p *Policy) { p.Notice = p.After },
+		"invalid action": func(p *Policy) { p.Action = "delete" },
+		"archive published": func(p *Policy) {
+			p.Statuses = append(p.Statuses, models.ApprovedDocumentStatus)
+		},
+	} {
+		p := valid
+		mutate(&p)
+		assert.Error(t, p.Validate(), name)
+	}
+}
+
+func TestParseStatus(t *testing.T) {
+	for s, want := range map[string]models.DocumentStatus{
+		"WIP":       models.WIPDocumentStatus,
+		"In-Review": models.InReviewDocumentStatus,
+		"in review": models.InReviewDocumentStatus,
+		"approved":  models.ApprovedDocumentStatus,
+		"Obsolete":  models.ObsoleteDocumentStatus,
+	} {
+		got, err := ParseStatus(s)
+		require.NoError(t, err)
+		assert.Equal(t, want, got, s)
+	}
+	_, err := ParseStatus("draft")
+	assert.Error(t, err)
+}
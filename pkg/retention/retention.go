@@ -0,0 +1,126 @@
+// Package retention applies retention policies to documents: documents of the
+// document types, products, and statuses of a policy that haven't been
+// modified for the period of the policy are removed from search or archived,
+// after their owners are given notice.
+package retention
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/models"
+)
+
+// Action is what a retention policy does to documents.
+type Action string
+
+const (
+	// ActionRemoveFromSearch removes documents from the search index. They
+	// can still be opened by their URL.
+	ActionRemoveFromSearch Action = "remove_from_search"
+
+	// ActionArchive moves drafts to the trash, from where site admins can
+	// restore them until they are purged.
+	ActionArchive Action = "archive"
+)
+
+// Policy is a retention policy.
+type Policy struct {
+	// Name identifies the policy.
+	Name string
+
+	// DocumentTypes and Products are the names of the document types and
+	// products of the documents the policy applies to. The policy applies to
+	// all document types or products if empty.
+	DocumentTypes []string
+	Products      []string
+
+	// Statuses are the statuses of the documents the policy applies to.
+	Statuses []models.DocumentStatus
+
+	// After is how long after documents were last modified that the policy
+	// is applied.
+	After time.Duration
+
+	// Action is what the policy does to documents.
+	Action Action
+
+	// Notice is how long before the policy is applied that document owners
+	// are notified. Owners aren't notified if it's zero.
+	Notice time.Duration
+}
+
+// Validate validates the policy.
+func (p Policy) Validate() error {
+	if p.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if len(p.Statuses) == 0 {
+		return fmt.Errorf("statuses must not be empty")
+	}
+	if p.After <= 0 {
+		return fmt.Errorf("after must be positive")
+	}
+	if p.Notice < 0 || p.Notice >= p.After {
+		return fmt.Errorf("notice must not be negative or longer than after")
+	}
+	switch p.Action {
+	case ActionRemoveFromSearch:
+	case ActionArchive:
+		// Archived documents are purged with their workspace files, so only
+		// drafts are archived.
+		for _, s := range p.Statuses {
+			if s != models.WIPDocumentStatus {
+				return fmt.Errorf("only drafts (status \"WIP\") can be archived")
+			}
+		}
+	default:
+		return fmt.Errorf("invalid action %q", p.Action)
+	}
+	return nil
+}
+
+// ParseStatus parses a document status name (e.g., "WIP", "In-Review",
+// "Approved", or "Obsolete"), ignoring case.
+func ParseStatus(s string) (models.DocumentStatus, error) {
+	switch strings.ToLower(s) {
+	case "wip":
+		return models.WIPDocumentStatus, nil
+	case "in-review", "in review":
+		return models.InReviewDocumentStatus, nil
+	case "approved":
+		return models.ApprovedDocumentStatus, nil
+	case "obsolete":
+		return models.ObsoleteDocumentStatus, nil
+	}
+	return models.UnspecifiedDocumentStatus,
+		fmt.Errorf("invalid document status %q", s)
+}
+
+// Event describes a retention policy that will be applied to a document.
+type Event struct {
+	Policy string
+	Action Action
+
+	// DocumentID is the ID of the document in the workspace provider.
+	DocumentID   string
+	DocumentUUID string
+	Title        string
+	DocNumber    string
+	DocType      string
+	Product      string
+	Owner        string
+
+	// ModifiedAt is when the document was last modified and ActionAt is when
+	// the policy will be applied to it, unless it's modified before then.
+	ModifiedAt time.Time
+	ActionAt   time.Time
+}
+
+// Notifier notifies document owners about retention policies that will be
+// applied to their documents.
+type Notifier interface {
+	NotifyRetentionEvent(ctx context.Context, ev *Event) error
+}
@@ -0,0 +1,315 @@
+package reviewsla
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp-forge/hermes/pkg/search"
+	"github.com/hashicorp/go-hclog"
+	"gorm.io/gorm"
+)
+
+const (
+	// DefaultInterval is how often reviews are checked by default.
+	DefaultInterval = 15 * time.Minute
+
+	// DefaultWarnBefore is how long before the SLA is breached that reviews
+	// are at risk by default.
+	DefaultWarnBefore = 24 * time.Hour
+)
+
+// Config contains review SLA job configuration.
+type Config struct {
+	// SLAs are the review SLAs of the configured document types, keyed by
+	// document type name. The SLAs of document types managed with the admin
+	// API are read from the database. Reviews of other document types aren't
+	// tracked.
+	SLAs map[string]time.Duration
+
+	// Interval is how often reviews are checked.
+	Interval time.Duration
+
+	// WarnBefore is how long before the SLA is breached that reviews are at
+	// risk of breaching it.
+	WarnBefore time.Duration
+}
+
+// Job periodically checks the documents in review against the review SLAs of
+// their document types. It indexes the SLA status of each review, flags
+// reviews that breach their SLA on the document record, and notifies the owner
+// and pending reviewers once when a review is at risk and once when it breaches
+// its SLA. Reviews are timed from when the document was published for review.
+type Job struct {
+	db             *gorm.DB
+	searchProvider search.Provider
+	notifier       Notifier
+	logger         hclog.Logger
+	slas           map[string]time.Duration
+	configured     map[string]bool
+	interval       time.Duration
+	warnBefore     time.Duration
+}
+
+// NewJob creates a new review SLA job. Events aren't published if notifier is
+// nil.
+func NewJob(
+	db *gorm.DB,
+	searchProvider search.Provider,
+	notifier Notifier,
+	logger hclog.Logger,
+	cfg *Config,
+) *Job {
+	if logger == nil {
+		logger = hclog.NewNullLogger()
+	}
+	j := &Job{
+		db:             db,
+		searchProvider: searchProvider,
+		notifier:       notifier,
+		logger:         logger.Named("review-sla"),
+		slas:           map[string]time.Duration{},
+		configured:     map[string]bool{},
+		interval:       DefaultInterval,
+		warnBefore:     DefaultWarnBefore,
+	}
+	if cfg != nil {
+		for docType, sla := range cfg.SLAs {
+			j.configured[docType] = true
+			if sla > 0 {
+				j.slas[docType] = sla
+			}
+		}
+		if cfg.Interval > 0 {
+			j.interval = cfg.Interval
+		}
+		if cfg.WarnBefore > 0 {
+			j.warnBefore = cfg.WarnBefore
+		}
+	}
+	return j
+}
+
+// Start checks reviews immediately and then every interval until ctx is
+// canceled.
+func (j *Job) Start(ctx context.Context) error {
+	j.logger.Info("review SLA job started",
+		"interval", j.interval,
+		"document_types", len(j.slas))
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := j.Run(ctx)
+		if err != nil {
+			j.logger.Error("error checking review SLAs", "error", err)
+		} else {
+			j.logger.Info("review SLAs checked", "reviews", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			j.logger.Info("review SLA job stopped")
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// documentTypeSLAs returns the review SLAs of the configured document types
+// and the document types managed with the admin API, keyed by document type
+// name.
+func (j *Job) documentTypeSLAs(ctx context.Context) (map[string]time.Duration, error) {
+	var managed models.DocumentTypes
+	if err := managed.FindManaged(j.db.WithContext(ctx)); err != nil {
+		return nil, fmt.Errorf("error finding managed document types: %w", err)
+	}
+
+	slas := make(map[string]time.Duration, len(j.slas)+len(managed))
+	for docType, sla := range j.slas {
+		slas[docType] = sla
+	}
+	for _, dt := range managed {
+		if !j.configured[dt.Name] && dt.Workflow.ReviewSLA > 0 {
+			slas[dt.Name] = dt.Workflow.ReviewSLA
+		}
+	}
+	return slas, nil
+}
+
+// review is a document in review.
+type review struct {
+	ID                  uint
+	GoogleFileID        string
+	DocumentUUID        string
+	Title               string
+	DocumentNumber      int
+	DocumentCreatedAt   time.Time
+	ReviewSLAWarnedAt   *time.Time
+	ReviewSLABreachedAt *time.Time
+	DocType             string
+	Product             string
+	ProductAbbreviation string
+	Owner               string
+}
+
+// Run checks all documents in review once and returns the number of reviews
+// checked.
+func (j *Job) Run(ctx context.Context) (int, error) {
+	slas, err := j.documentTypeSLAs(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if len(slas) == 0 {
+		return 0, nil
+	}
+	docTypes := make([]string, 0, len(slas))
+	for docType := range slas {
+		docTypes = append(docTypes, docType)
+	}
+
+	var reviews []review
+	if err := j.db.WithContext(ctx).
+		Table("documents AS d").
+		Select(`d.id, d.google_file_id,
+			COALESCE(CAST(d.document_uuid AS TEXT), '') AS document_uuid,
+			d.title, d.document_number, d.document_created_at,
+			d.review_sla_warned_at, d.review_sla_breached_at,
+			dt.name AS doc_type, p.name AS product,
+			p.abbreviation AS product_abbreviation,
+			COALESCE(u.email_address, '') AS owner`).
+		Joins("JOIN document_types dt ON dt.id = d.document_type_id").
+		Joins("JOIN products p ON p.id = d.product_id").
+		Joins("LEFT JOIN users u ON u.id = d.owner_id").
+		Where("d.deleted_at IS NULL").
+		Where("d.status = ?", models.InReviewDocumentStatus).
+		Where("dt.name IN ?", docTypes).
+		Scan(&reviews).
+		Error; err != nil {
+		return 0, fmt.Errorf("error getting documents in review: %w", err)
+	}
+
+	var docs search.DocumentUpdater
+	if j.searchProvider != nil {
+		docs, _ = j.searchProvider.DocumentIndex().(search.DocumentUpdater)
+	}
+
+	now := time.Now()
+	for _, r := range reviews {
+		if ctx.Err() != nil {
+			return 0, ctx.Err()
+		}
+
+		sla := slas[r.DocType]
+		status := Status(r.DocumentCreatedAt, sla, j.warnBefore, now)
+
+		if docs != nil {
+			if err := docs.UpdateFields(ctx, r.GoogleFileID, map[string]any{
+				"reviewSla": status,
+			}); err != nil && !errors.Is(err, search.ErrNotFound) {
+				j.logger.Warn("error indexing review SLA status",
+					"error", err,
+					"doc_id", r.GoogleFileID)
+			}
+		}
+
+		var typ EventType
+		switch {
+		case status == StatusBreached && r.ReviewSLABreachedAt == nil:
+			typ = EventBreached
+		case status == StatusAtRisk && r.ReviewSLAWarnedAt == nil:
+			typ = EventAtRisk
+		default:
+			continue
+		}
+
+		flagged, err := j.flag(ctx, r, typ, now)
+		if err != nil {
+			j.logger.Error("error flagging review SLA",
+				"error", err,
+				"doc_id", r.GoogleFileID,
+				"event", typ)
+			continue
+		}
+		if flagged {
+			j.notify(ctx, r, typ, sla)
+		}
+	}
+
+	return len(reviews), nil
+}
+
+// flag records the event on the document record. It returns false if the event
+// was already recorded, e.g., by another server.
+func (j *Job) flag(
+	ctx context.Context, r review, typ EventType, now time.Time,
+) (bool, error) {
+	q := j.db.WithContext(ctx).
+		Model(&models.Document{}).
+		Where("id = ?", r.ID)
+
+	var res *gorm.DB
+	switch typ {
+	case EventBreached:
+		res = q.Where("review_sla_breached_at IS NULL").
+			UpdateColumns(map[string]any{
+				"review_sla_breached_at": now,
+				"review_sla_warned_at": gorm.Expr(
+					"COALESCE(review_sla_warned_at, ?)", now),
+			})
+	default:
+		res = q.Where("review_sla_warned_at IS NULL").
+			UpdateColumn("review_sla_warned_at", now)
+	}
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected == 1, nil
+}
+
+// notify publishes an event for a review. Failures are logged.
+func (j *Job) notify(
+	ctx context.Context, r review, typ EventType, sla time.Duration,
+) {
+	if j.notifier == nil {
+		return
+	}
+
+	ev := &Event{
+		Type:         typ,
+		DocumentID:   r.GoogleFileID,
+		DocumentUUID: r.DocumentUUID,
+		Title:        r.Title,
+		DocNumber: fmt.Sprintf(
+			"%s-%03d", r.ProductAbbreviation, r.DocumentNumber),
+		DocType:     r.DocType,
+		Product:     r.Product,
+		Owner:       r.Owner,
+		RequestedAt: r.DocumentCreatedAt,
+		DueAt:       r.DocumentCreatedAt.Add(sla),
+		SLA:         sla,
+	}
+	if err := j.db.WithContext(ctx).
+		Model(&models.DocumentReview{}).
+		Joins("JOIN users ON users.id = document_reviews.user_id").
+		Where("document_reviews.document_id = ?", r.ID).
+		Where("document_reviews.status IS NULL OR document_reviews.status <> ?",
+			models.ApprovedDocumentReviewStatus).
+		Order("users.email_address").
+		Pluck("users.email_address", &ev.PendingReviewers).
+		Error; err != nil {
+		j.logger.Warn("error getting pending reviewers",
+			"error", err,
+			"doc_id", r.GoogleFileID)
+	}
+
+	if err := j.notifier.NotifyReviewSLAEvent(ctx, ev); err != nil {
+		j.logger.Error("error publishing review SLA event",
+			"error", err,
+			"doc_id", r.GoogleFileID,
+			"event", typ)
+	}
+}
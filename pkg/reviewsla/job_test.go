@@ -0,0 +1,141 @@
+package reviewsla
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp-forge/hermes/pkg/models/modelstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type fakeNotifier struct {
+	events []*Event
+}
+
+func (n *fakeNotifier) NotifyReviewSLAEvent(ctx context.Context, ev *Event) error {
+	n.events = append(n.events, ev)
+	return nil
+}
+
+func createDocument(
+	t *testing.T, db *gorm.DB, id, docType string,
+	status models.DocumentStatus, publishedAt time.Time,
+) models.Document {
+	return modelstest.CreateDocument(t, db, models.Document{
+		GoogleFileID:      id,
+		DocumentNumber:    7,
+		Status:            status,
+		DocumentCreatedAt: publishedAt,
+		DocumentType:      models.DocumentType{Name: docType},
+		Owner:             &models.User{EmailAddress: "owner@example.com"},
+		Approvers: []*models.User{
+			{EmailAddress: "reviewer@example.com"},
+		},
+	})
+}
+
+func TestJob(t *testing.T) {
+	db := modelstest.NewDB(t)
+	now := time.Now()
+
+	createDocument(t, db, "onTrack", "RFC",
+		models.InReviewDocumentStatus, now.Add(-10*time.Hour))
+	createDocument(t, db, "atRisk", "RFC",
+		models.InReviewDocumentStatus, now.Add(-30*time.Hour))
+	breached := createDocument(t, db, "breached", "RFC",
+		models.InReviewDocumentStatus, now.Add(-50*time.Hour))
+	createDocument(t, db, "approved", "RFC",
+		models.ApprovedDocumentStatus, now.Add(-50*time.Hour))
+	createDocument(t, db, "noSLA", "PRD",
+		models.InReviewDocumentStatus, now.Add(-50*time.Hour))
+
+	n := &fakeNotifier{}
+	j := NewJob(db, nil, n, nil, &Config{
+		SLAs: map[string]time.Duration{"RFC": 48 * time.Hour, "PRD": 0},
+	})
+
+	checked, err := j.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, checked)
+
+	require.Len(t, n.events, 2)
+	byDoc := map[string]*Event{}
+	for _, ev := range n.events {
+		byDoc[ev.DocumentID] = ev
+	}
+	require.Contains(t, byDoc, "atRisk")
+	assert.Equal(t, EventAtRisk, byDoc["atRisk"].Type)
+	require.Contains(t, byDoc, "breached")
+	ev := byDoc["breached"]
+	assert.Equal(t, EventBreached, ev.Type)
+	assert.Equal(t, "HRM-007", ev.DocNumber)
+	assert.Equal(t, "owner@example.com", ev.Owner)
+	assert.Equal(t, []string{"reviewer@example.com"}, ev.PendingReviewers)
+	assert.Equal(t, 48*time.Hour, ev.SLA)
+	assert.WithinDuration(t, now.Add(-2*time.Hour), ev.DueAt, time.Second)
+
+	// Breaches are flagged on the document record.
+	require.NoError(t, breached.Get(db))
+	require.NotNil(t, breached.ReviewSLABreachedAt)
+	assert.NotNil(t, breached.ReviewSLAWarnedAt)
+
+	// Users are only notified once.
+	n.events = nil
+	_, err = j.Run(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, n.events)
+}
+
+func TestJobManagedDocumentTypes(t *testing.T) {
+	db := modelstest.NewDB(t)
+	now := time.Now()
+
+	// The SLAs of document types managed with the admin API are read from the
+	// database, unless a document type of the same name is configured.
+	for _, dt := range []models.DocumentType{
+		{Name: "ADR", LongName: "ADR"},
+		{Name: "RFC", LongName: "RFC"},
+	} {
+		dt.Managed = true
+		dt.Workflow.ReviewSLA = 24 * time.Hour
+		require.NoError(t, dt.Upsert(db))
+	}
+	createDocument(t, db, "adr", "ADR",
+		models.InReviewDocumentStatus, now.Add(-30*time.Hour))
+	createDocument(t, db, "rfc", "RFC",
+		models.InReviewDocumentStatus, now.Add(-30*time.Hour))
+
+	n := &fakeNotifier{}
+	j := NewJob(db, nil, n, nil, &Config{
+		SLAs: map[string]time.Duration{"RFC": 0},
+	})
+
+	checked, err := j.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, checked)
+	require.Len(t, n.events, 1)
+	assert.Equal(t, "adr", n.events[0].DocumentID)
+	assert.Equal(t, EventBreached, n.events[0].Type)
+	assert.Equal(t, 24*time.Hour, n.events[0].SLA)
+}
+
+func TestStatus(t *testing.T) {
+	requestedAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	sla, warnBefore := 72*time.Hour, 24*time.Hour
+
+	for hours, want := range map[int]string{
+		0:  StatusOnTrack,
+		47: StatusOnTrack,
+		48: StatusAtRisk,
+		71: StatusAtRisk,
+		72: StatusBreached,
+		96: StatusBreached,
+	} {
+		now := requestedAt.Add(time.Duration(hours) * time.Hour)
+		assert.Equal(t, want, Status(requestedAt, sla, warnBefore, now), hours)
+	}
+}
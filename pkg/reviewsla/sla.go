@@ -0,0 +1,71 @@
+// Package reviewsla tracks the time documents are in review against the review
+// SLAs of their document types, flags reviews that breach their SLA, and
+// notifies document owners and pending reviewers when an SLA is about to be or
+// has been breached.
+package reviewsla
+
+import (
+	"context"
+	"time"
+)
+
+// Review SLA statuses, indexed for faceting in search.
+const (
+	StatusOnTrack  = "on_track"
+	StatusAtRisk   = "at_risk"
+	StatusBreached = "breached"
+)
+
+// EventType identifies a review SLA event. Values match the notification types
+// used to render and route them.
+type EventType string
+
+const (
+	EventAtRisk   EventType = "review_sla_at_risk"
+	EventBreached EventType = "review_sla_breached"
+)
+
+// Event describes a review that is about to breach or has breached its SLA.
+type Event struct {
+	Type EventType
+
+	// DocumentID is the ID of the document in the workspace provider.
+	DocumentID   string
+	DocumentUUID string
+	Title        string
+	DocNumber    string
+	DocType      string
+	Product      string
+	Owner        string
+
+	// PendingReviewers are the reviewers who haven't approved the document.
+	PendingReviewers []string
+
+	// RequestedAt is when the review was requested and DueAt is when the
+	// review SLA is breached.
+	RequestedAt time.Time
+	DueAt       time.Time
+	SLA         time.Duration
+}
+
+// Notifier publishes review SLA events.
+type Notifier interface {
+	NotifyReviewSLAEvent(ctx context.Context, ev *Event) error
+}
+
+// Status returns the review SLA status at time now of a review requested at
+// requestedAt. Reviews are at risk of breaching the SLA warnBefore before they
+// breach it.
+func Status(
+	requestedAt time.Time, sla, warnBefore time.Duration, now time.Time,
+) string {
+	due := requestedAt.Add(sla)
+	switch {
+	case !now.Before(due):
+		return StatusBreached
+	case !now.Before(due.Add(-warnBefore)):
+		return StatusAtRisk
+	default:
+		return StatusOnTrack
+	}
+}
@@ -0,0 +1,76 @@
+// Package runbook extracts checklists from document content so they can be
+// executed as runbooks.
+package runbook
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// taskItemRE matches Markdown task list items (e.g., "- [ ] Drain node").
+	taskItemRE = regexp.MustCompile(`^\s*(?:[-*+]|\d+[.)])\s+\[[ xX]\]\s+(.+)$`)
+
+	// checkboxItemRE matches checklist items exported from rich text documents,
+	// which use ballot box characters for checkboxes.
+	checkboxItemRE = regexp.MustCompile(`^\s*[☐☑☒]\s*(.+)$`)
+
+	// headingRE matches Markdown ATX headings.
+	headingRE = regexp.MustCompile(`^\s{0,3}#{1,6}\s+(.+?)(?:\s+#+)?\s*$`)
+)
+
+// Item is a checklist item in a document.
+type Item struct {
+	// Section is the heading the item is under, or empty if there is none.
+	Section string
+
+	// Text is the item's text without its checkbox.
+	Text string
+}
+
+// ParseChecklist returns the checklist items in document content, in order.
+// Whether an item is checked in the document is ignored; the document is the
+// template and checks are recorded per run. Items in fenced code blocks are
+// skipped.
+func ParseChecklist(content string) []Item {
+	var (
+		items   []Item
+		section string
+		fence   string
+	)
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimRight(line, "\r")
+		trimmed := strings.TrimSpace(line)
+
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fence = trimmed[:3]
+			continue
+		}
+
+		if m := headingRE.FindStringSubmatch(line); m != nil {
+			section = m[1]
+			continue
+		}
+
+		m := taskItemRE.FindStringSubmatch(line)
+		if m == nil {
+			m = checkboxItemRE.FindStringSubmatch(line)
+		}
+		if m == nil {
+			continue
+		}
+		if text := strings.TrimSpace(m[1]); text != "" {
+			items = append(items, Item{
+				Section: section,
+				Text:    text,
+			})
+		}
+	}
+	return items
+}
@@ -0,0 +1,35 @@
+package runbook
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseChecklist(t *testing.T) {
+	content := "# Failover\n" +
+		"Intro text.\n" +
+		"\n" +
+		"## Prepare\n" +
+		"- [ ] Announce in #ops\n" +
+		"* [x] Snapshot the database\n" +
+		"- regular bullet\n" +
+		"1. [ ] Drain traffic\r\n" +
+		"\n" +
+		"```\n" +
+		"- [ ] not a step\n" +
+		"```\n" +
+		"## Verify ##\n" +
+		"☐ Check dashboards\n" +
+		"- [ ]   \n" +
+		"-[ ] missing space\n"
+
+	assert.Equal(t, []Item{
+		{Section: "Prepare", Text: "Announce in #ops"},
+		{Section: "Prepare", Text: "Snapshot the database"},
+		{Section: "Prepare", Text: "Drain traffic"},
+		{Section: "Verify", Text: "Check dashboards"},
+	}, ParseChecklist(content))
+
+	assert.Empty(t, ParseChecklist("no checklist here"))
+}
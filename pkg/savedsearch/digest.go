@@ -0,0 +1,228 @@
+// Package savedsearch sends the subscribers of saved searches digests of the
+// documents published since the last digest that match the searches.
+package savedsearch
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp-forge/hermes/pkg/search"
+	"github.com/hashicorp/go-hclog"
+	"gorm.io/gorm"
+)
+
+const (
+	// DefaultInterval is how often digests are sent by default.
+	DefaultInterval = 24 * time.Hour
+
+	// MaxDigestDocuments is the maximum number of documents in a digest.
+	MaxDigestDocuments = 25
+)
+
+// Config contains saved search digest job configuration.
+type Config struct {
+	// Interval is how often digests are sent.
+	Interval time.Duration
+}
+
+// Digest lists the documents published since the last digest of a saved
+// search that match it.
+type Digest struct {
+	SavedSearchID uint
+	Name          string
+	Query         string
+	Filters       map[string][]string
+
+	// Owner is the email address of the user who saved the search.
+	Owner string
+
+	// Subscribers are the email addresses of the users the digest is sent to.
+	Subscribers []string
+
+	// Documents are the new documents, newest first. There are at most
+	// MaxDigestDocuments.
+	Documents []Document
+
+	// Since is when the previous digest was sent or the search was saved.
+	Since time.Time
+}
+
+// Document is a document in a digest.
+type Document struct {
+	// ID is the ID of the document in the workspace provider.
+	ID          string
+	Title       string
+	DocNumber   string
+	DocType     string
+	Product     string
+	Owner       string
+	PublishedAt time.Time
+}
+
+// Notifier sends digests.
+type Notifier interface {
+	NotifySavedSearchDigest(ctx context.Context, d *Digest) error
+}
+
+// Job periodically sends the subscribers of saved searches digests of the
+// documents published since the last digest that match the searches. Searches
+// without new documents don't send a digest.
+type Job struct {
+	db       *gorm.DB
+	search   search.Provider
+	notifier Notifier
+	logger   hclog.Logger
+	interval time.Duration
+}
+
+// NewJob creates a new saved search digest job that searches the published
+// documents of searchProvider.
+func NewJob(
+	db *gorm.DB,
+	searchProvider search.Provider,
+	notifier Notifier,
+	logger hclog.Logger,
+	cfg *Config,
+) *Job {
+	if logger == nil {
+		logger = hclog.NewNullLogger()
+	}
+	j := &Job{
+		db:       db,
+		search:   searchProvider,
+		notifier: notifier,
+		logger:   logger.Named("saved-search-digest"),
+		interval: DefaultInterval,
+	}
+	if cfg != nil && cfg.Interval > 0 {
+		j.interval = cfg.Interval
+	}
+	return j
+}
+
+// Start sends digests every interval until ctx is canceled.
+func (j *Job) Start(ctx context.Context) error {
+	j.logger.Info("saved search digest job started", "interval", j.interval)
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			j.logger.Info("saved search digest job stopped")
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		n, err := j.Run(ctx)
+		if err != nil {
+			j.logger.Error("error sending saved search digests", "error", err)
+		} else {
+			j.logger.Info("saved search digests sent", "digests", n)
+		}
+	}
+}
+
+// Run sends the digests of all saved searches with subscribers once and
+// returns the number of digests sent.
+func (j *Job) Run(ctx context.Context) (int, error) {
+	var searches models.SavedSearches
+	if err := searches.FindSubscribed(j.db.WithContext(ctx)); err != nil {
+		return 0, fmt.Errorf("error finding saved searches: %w", err)
+	}
+
+	var sent int
+	now := time.Now()
+	for _, s := range searches {
+		if ctx.Err() != nil {
+			return sent, ctx.Err()
+		}
+
+		d, err := j.digest(ctx, s)
+		if err != nil {
+			j.logger.Error("error searching for new documents",
+				"error", err,
+				"saved_search_id", s.ID)
+			continue
+		}
+
+		// Mark the search as digested even without new documents, so the
+		// next digest starts now.
+		marked, err := s.MarkDigested(j.db.WithContext(ctx), now)
+		if err != nil {
+			j.logger.Error("error marking saved search as digested",
+				"error", err,
+				"saved_search_id", s.ID)
+			continue
+		}
+		if !marked || len(d.Documents) == 0 || j.notifier == nil {
+			continue
+		}
+
+		if err := j.notifier.NotifySavedSearchDigest(ctx, d); err != nil {
+			j.logger.Error("error sending saved search digest",
+				"error", err,
+				"saved_search_id", s.ID)
+			continue
+		}
+		sent++
+	}
+
+	return sent, nil
+}
+
+// digest returns the digest of saved search s.
+func (j *Job) digest(ctx context.Context, s models.SavedSearch) (*Digest, error) {
+	since := s.CreatedAt
+	if s.DigestedAt != nil {
+		since = *s.DigestedAt
+	}
+
+	d := &Digest{
+		SavedSearchID: s.ID,
+		Name:          s.Name,
+		Query:         s.Query,
+		Filters:       s.Filters,
+		Owner:         s.Owner.EmailAddress,
+		Since:         since,
+	}
+	for _, u := range s.Subscribers {
+		d.Subscribers = append(d.Subscribers, u.EmailAddress)
+	}
+
+	// Documents are published with their creation time reset to the time
+	// they were published, so the newest documents are the recently published
+	// ones.
+	res, err := j.search.DocumentIndex().Search(ctx, &search.SearchQuery{
+		Query:     s.Query,
+		PerPage:   MaxDigestDocuments,
+		Filters:   s.Filters,
+		SortBy:    "createdTime",
+		SortOrder: "desc",
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, h := range res.Hits {
+		publishedAt := time.Unix(h.CreatedTime, 0)
+		if !publishedAt.After(since) {
+			continue
+		}
+		doc := Document{
+			ID:          h.ObjectID,
+			Title:       h.Title,
+			DocNumber:   h.DocNumber,
+			DocType:     h.DocType,
+			Product:     h.Product,
+			PublishedAt: publishedAt,
+		}
+		if len(h.Owners) > 0 {
+			doc.Owner = h.Owners[0]
+		}
+		d.Documents = append(d.Documents, doc)
+	}
+	return d, nil
+}
@@ -0,0 +1,166 @@
+package savedsearch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp-forge/hermes/pkg/models/modelstest"
+	"github.com/hashicorp-forge/hermes/pkg/search"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeNotifier struct {
+	digests []*Digest
+}
+
+func (n *fakeNotifier) NotifySavedSearchDigest(ctx context.Context, d *Digest) error {
+	n.digests = append(n.digests, d)
+	return nil
+}
+
+// fakeSearch returns the same documents for every search of published
+// documents and records the queries.
+type fakeSearch struct {
+	search.Provider
+	index *fakeDocumentIndex
+}
+
+func (s fakeSearch) DocumentIndex() search.DocumentIndex {
+	return s.index
+}
+
+type fakeDocumentIndex struct {
+	search.DocumentIndex
+	hits    []*search.Document
+	queries []*search.SearchQuery
+}
+
+func (i *fakeDocumentIndex) Search(
+	ctx context.Context, q *search.SearchQuery,
+) (*search.SearchResult, error) {
+	i.queries = append(i.queries, q)
+	return &search.SearchResult{Hits: i.hits}, nil
+}
+
+func TestJob(t *testing.T) {
+	ctx := context.Background()
+	db := modelstest.NewDB(t)
+
+	s := models.SavedSearch{
+		Owner:   models.User{EmailAddress: "owner@example.com"},
+		Name:    "Terraform RFCs",
+		Query:   "providers",
+		Filters: map[string][]string{"product": {"Terraform"}},
+		SharedWithGroups: []models.Group{
+			{EmailAddress: "team@example.com"},
+		},
+	}
+	require.NoError(t, s.Create(db))
+	require.NoError(t, s.Subscribe(db, "owner@example.com"))
+	require.NoError(t, s.Subscribe(db, "member@example.com"))
+
+	// Names are unique per owner.
+	duplicate := models.SavedSearch{
+		Owner: models.User{EmailAddress: "owner@example.com"},
+		Name:  "Terraform RFCs",
+	}
+	assert.ErrorIs(t, duplicate.Create(db), models.ErrSavedSearchNameTaken)
+
+	// Searches without subscribers don't send digests.
+	unsubscribed := models.SavedSearch{
+		Owner: models.User{EmailAddress: "owner@example.com"},
+		Name:  "Unsubscribed",
+	}
+	require.NoError(t, unsubscribed.Create(db))
+
+	// Searches shared with the groups of a user are found for the user.
+	var found models.SavedSearches
+	require.NoError(t, found.FindForUser(
+		db, "member@example.com", []string{"team@example.com"}))
+	require.Len(t, found, 1)
+	assert.Equal(t, s.ID, found[0].ID)
+	assert.True(t, found[0].HasSubscriber("member@example.com"))
+	require.NoError(t, found.FindForUser(db, "owner@example.com", nil))
+	assert.Len(t, found, 2)
+
+	// The search was saved two hours ago.
+	since := time.Now().Add(-2 * time.Hour)
+	require.NoError(t, db.Model(&s).UpdateColumn("created_at", since).Error)
+	docs := &fakeDocumentIndex{
+		hits: []*search.Document{
+			{
+				ObjectID:    "new",
+				Title:       "New",
+				DocType:     "RFC",
+				Product:     "Terraform",
+				Owners:      []string{"author@example.com"},
+				CreatedTime: since.Add(time.Hour).Unix(),
+			},
+			{
+				ObjectID:    "old",
+				Title:       "Old",
+				CreatedTime: since.Add(-time.Hour).Unix(),
+			},
+		},
+	}
+	n := &fakeNotifier{}
+	j := NewJob(db, fakeSearch{index: docs}, n, nil, nil)
+
+	sent, err := j.Run(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	require.Len(t, docs.queries, 1)
+	assert.Equal(t, "providers", docs.queries[0].Query)
+	assert.Equal(t, s.Filters, docs.queries[0].Filters)
+	require.Len(t, n.digests, 1)
+	d := n.digests[0]
+	assert.Equal(t, "Terraform RFCs", d.Name)
+	assert.Equal(t, "owner@example.com", d.Owner)
+	assert.ElementsMatch(t,
+		[]string{"owner@example.com", "member@example.com"}, d.Subscribers)
+	require.Len(t, d.Documents, 1)
+	assert.Equal(t, "new", d.Documents[0].ID)
+	assert.Equal(t, "author@example.com", d.Documents[0].Owner)
+
+	// Documents already in a digest aren't sent again.
+	sent, err = j.Run(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, sent)
+
+	// Unsubscribed users don't receive digests, and searches without
+	// subscribers aren't searched.
+	require.NoError(t, s.Unsubscribe(db, "owner@example.com"))
+	require.NoError(t, s.Unsubscribe(db, "member@example.com"))
+	docs.queries = nil
+	sent, err = j.Run(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, sent)
+	assert.Empty(t, docs.queries)
+}
+
+func TestMarkDigested(t *testing.T) {
+	db := modelstest.NewDB(t)
+
+	s := models.SavedSearch{
+		Owner: models.User{EmailAddress: "owner@example.com"},
+		Name:  "Search",
+	}
+	require.NoError(t, s.Create(db))
+
+	var stale models.SavedSearch
+	stale.ID = s.ID
+	require.NoError(t, stale.Get(db))
+
+	marked, err := s.MarkDigested(db, time.Now())
+	require.NoError(t, err)
+	assert.True(t, marked)
+
+	// Another server that read the search before the digest doesn't send it
+	// again.
+	marked, err = stale.MarkDigested(db, time.Now())
+	require.NoError(t, err)
+	assert.False(t, marked)
+}
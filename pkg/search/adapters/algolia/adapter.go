@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/algolia/algoliasearch-client-go/v3/algolia/opt"
 	"github.com/algolia/algoliasearch-client-go/v3/algolia/search"
 	hermessearch "github.com/hashicorp-forge/hermes/pkg/search"
 )
@@ -16,6 +17,10 @@ type Adapter struct {
 	projectsIndex *search.Index
 	linksIndex    *search.Index
 	appID         string
+
+	// searchIndexes are the configured docs, drafts, and projects indexes,
+	// keyed by name.
+	searchIndexes map[string]*search.Index
 }
 
 // Config contains Algolia configuration.
@@ -39,14 +44,26 @@ func NewAdapter(cfg *Config) (*Adapter, error) {
 
 	client := search.NewClient(cfg.AppID, cfg.WriteAPIKey)
 
-	return &Adapter{
+	adapter := &Adapter{
 		client:        client,
 		docsIndex:     client.InitIndex(cfg.DocsIndexName),
 		draftsIndex:   client.InitIndex(cfg.DraftsIndexName),
 		projectsIndex: client.InitIndex(cfg.ProjectsIndexName),
 		linksIndex:    client.InitIndex(cfg.LinksIndexName),
 		appID:         cfg.AppID,
-	}, nil
+		searchIndexes: map[string]*search.Index{},
+	}
+	for name, index := range map[string]*search.Index{
+		cfg.DocsIndexName:     adapter.docsIndex,
+		cfg.DraftsIndexName:   adapter.draftsIndex,
+		cfg.ProjectsIndexName: adapter.projectsIndex,
+	} {
+		if name != "" {
+			adapter.searchIndexes[name] = index
+		}
+	}
+
+	return adapter, nil
 }
 
 // DocumentIndex returns the document search interface.
@@ -96,6 +113,36 @@ func (a *Adapter) Healthy(ctx context.Context) error {
 	return nil
 }
 
+// UpdateVocabulary replaces the synonyms of the docs, drafts, and projects
+// indexes, and makes stop words optional in their queries. Algolia only has
+// built-in stop words, so results don't have to match the stop words of a
+// query, but are still ranked higher if they do. Algolia applies settings to
+// existing records, so reindexing isn't needed.
+func (a *Adapter) UpdateVocabulary(ctx context.Context, v hermessearch.Vocabulary) (bool, error) {
+	synonyms := make([]search.Synonym, 0, len(v.Synonyms))
+	for i, terms := range v.Synonyms {
+		synonyms = append(synonyms,
+			search.NewRegularSynonym(fmt.Sprintf("hermes-%d", i), terms...))
+	}
+	stopWords := v.StopWords
+	if stopWords == nil {
+		stopWords = []string{}
+	}
+
+	for name, index := range a.searchIndexes {
+		if _, err := index.ReplaceAllSynonyms(synonyms); err != nil {
+			return false, fmt.Errorf("failed to update %s synonyms: %w", name, err)
+		}
+		if _, err := index.SetSettings(search.Settings{
+			OptionalWords: opt.OptionalWords(stopWords...),
+		}); err != nil {
+			return false, fmt.Errorf(
+				"failed to update %s optional words: %w", name, err)
+		}
+	}
+	return false, nil
+}
+
 // documentIndex implements search.DocumentIndex.
 type documentIndex struct {
 	index *search.Index
@@ -165,6 +212,11 @@ func (di *documentIndex) Search(ctx context.Context, query *hermessearch.SearchQ
 	return nil, fmt.Errorf("Search not yet implemented")
 }
 
+// UpdateFields sets fields on an indexed document without replacing it.
+func (di *documentIndex) UpdateFields(ctx context.Context, docID string, fields map[string]any) error {
+	return partialUpdate(di.index, docID, fields)
+}
+
 func (di *documentIndex) GetObject(ctx context.Context, docID string) (*hermessearch.Document, error) {
 	var doc hermessearch.Document
 	err := di.index.GetObject(docID, &doc)
@@ -258,6 +310,11 @@ func (dri *draftIndex) Search(ctx context.Context, query *hermessearch.SearchQue
 	return nil, fmt.Errorf("Search not yet implemented")
 }
 
+// UpdateFields sets fields on an indexed draft without replacing it.
+func (dri *draftIndex) UpdateFields(ctx context.Context, docID string, fields map[string]any) error {
+	return partialUpdate(dri.index, docID, fields)
+}
+
 func (dri *draftIndex) GetObject(ctx context.Context, docID string) (*hermessearch.Document, error) {
 	var doc hermessearch.Document
 	err := dri.index.GetObject(docID, &doc)
@@ -400,3 +457,22 @@ func (li *linksIndex) Clear(ctx context.Context) error {
 	}
 	return nil
 }
+
+// partialUpdate updates fields of an object without replacing it. Objects that
+// don't exist are not created.
+func partialUpdate(index *search.Index, objectID string, fields map[string]any) error {
+	obj := make(map[string]any, len(fields)+1)
+	for k, v := range fields {
+		obj[k] = v
+	}
+	obj["objectID"] = objectID
+
+	if _, err := index.PartialUpdateObject(obj, opt.CreateIfNotExists(false)); err != nil {
+		return &hermessearch.Error{
+			Op:  "UpdateFields",
+			Err: hermessearch.ErrIndexingFailed,
+			Msg: err.Error(),
+		}
+	}
+	return nil
+}
@@ -89,6 +89,7 @@ func TestAdapter_Interfaces(t *testing.T) {
 
 	// Verify adapter implements Provider interface
 	var _ hermessearch.Provider = adapter
+	var _ hermessearch.VocabularyUpdater = adapter
 
 	// Verify DocumentIndex returns correct interface
 	docIndex := adapter.DocumentIndex()
@@ -5,12 +5,15 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/blevesearch/bleve/v2"
 	"github.com/blevesearch/bleve/v2/mapping"
+	"github.com/blevesearch/bleve/v2/search"
 	"github.com/blevesearch/bleve/v2/search/query"
 
 	hermessearch "github.com/hashicorp-forge/hermes/pkg/search"
@@ -27,13 +30,34 @@ type Adapter struct {
 	draftsPath   string
 	projectsPath string
 	linksPath    string
+
+	ranking        hermessearch.Rankings
+	vocabularyPath string
+
+	// mu guards vocabulary.
+	mu         sync.RWMutex
+	vocabulary hermessearch.Vocabulary
 }
 
 // Config contains Bleve configuration.
 type Config struct {
 	IndexPath string // Base path for all indexes (e.g., "./docs-cms/data/fts.index")
+
+	// Ranking tunes how the results of the docs, drafts, and projects indexes
+	// are ranked.
+	Ranking hermessearch.Rankings
 }
 
+// Searchable attributes, in order of importance, that are weighted by rankings.
+var (
+	documentSearchableAttrs = []string{"title", "docNumber", "summary", "content"}
+	projectSearchableAttrs  = []string{"title", "description"}
+)
+
+// rescoreWindow is the number of top results rescored by recency. Results
+// beyond it aren't rescored, so pages past it keep their text relevance order.
+const rescoreWindow = 200
+
 // NewAdapter creates a new Bleve search adapter.
 func NewAdapter(cfg *Config) (*Adapter, error) {
 	if cfg.IndexPath == "" {
@@ -50,6 +74,14 @@ func NewAdapter(cfg *Config) (*Adapter, error) {
 		draftsPath:   filepath.Join(cfg.IndexPath, "drafts.bleve"),
 		projectsPath: filepath.Join(cfg.IndexPath, "projects.bleve"),
 		linksPath:    filepath.Join(cfg.IndexPath, "links.bleve"),
+		ranking:      cfg.Ranking,
+
+		vocabularyPath: filepath.Join(cfg.IndexPath, "vocabulary.json"),
+	}
+
+	// Load the vocabulary that indexes are created with
+	if err := adapter.loadVocabulary(); err != nil {
+		return nil, fmt.Errorf("failed to load vocabulary: %w", err)
 	}
 
 	// Initialize indexes
@@ -65,7 +97,11 @@ func (a *Adapter) initializeIndexes() error {
 	var err error
 
 	// Create document index mapping
-	docMapping := createDocumentMapping()
+	stopWords := a.stopWords()
+	docMapping, err := createDocumentMapping(stopWords)
+	if err != nil {
+		return fmt.Errorf("failed to create docs mapping: %w", err)
+	}
 
 	// Open or create documents index
 	a.docsIndex, err = openOrCreateIndex(a.docsPath, docMapping)
@@ -80,7 +116,10 @@ func (a *Adapter) initializeIndexes() error {
 	}
 
 	// Open or create projects index
-	projectMapping := createProjectMapping()
+	projectMapping, err := createProjectMapping(stopWords)
+	if err != nil {
+		return fmt.Errorf("failed to create projects mapping: %w", err)
+	}
 	a.projectsIndex, err = openOrCreateIndex(a.projectsPath, projectMapping)
 	if err != nil {
 		return fmt.Errorf("failed to open projects index: %w", err)
@@ -106,13 +145,18 @@ func openOrCreateIndex(path string, indexMapping mapping.IndexMapping) (bleve.In
 	return idx, err
 }
 
-// createDocumentMapping creates the index mapping for documents.
-func createDocumentMapping() mapping.IndexMapping {
+// createDocumentMapping creates the index mapping for documents, whose text
+// fields ignore stopWords.
+func createDocumentMapping(stopWords []string) (mapping.IndexMapping, error) {
 	indexMapping := bleve.NewIndexMapping()
 
 	// Define text field mappings with appropriate analyzers
+	analyzer, err := addTextAnalyzer(indexMapping, stopWords)
+	if err != nil {
+		return nil, err
+	}
 	textFieldMapping := bleve.NewTextFieldMapping()
-	textFieldMapping.Analyzer = "en" // English analyzer with stemming
+	textFieldMapping.Analyzer = analyzer // English analyzer with stemming
 
 	keywordFieldMapping := bleve.NewKeywordFieldMapping()
 
@@ -139,17 +183,35 @@ func createDocumentMapping() mapping.IndexMapping {
 	docMapping.AddFieldMappingsAt("createdTime", dateFieldMapping)
 	docMapping.AddFieldMappingsAt("modifiedTime", dateFieldMapping)
 
+	// Freshness fields
+	docMapping.AddFieldMappingsAt("freshness", keywordFieldMapping)
+	docMapping.AddFieldMappingsAt("freshnessScore", bleve.NewNumericFieldMapping())
+
+	// Review SLA field
+	docMapping.AddFieldMappingsAt("reviewSla", keywordFieldMapping)
+
+	// Tenant field
+	docMapping.AddFieldMappingsAt("tenant", keywordFieldMapping)
+
+	// Draft visibility field
+	docMapping.AddFieldMappingsAt("viewers", keywordFieldMapping)
+
 	indexMapping.AddDocumentMapping("_default", docMapping)
 
-	return indexMapping
+	return indexMapping, nil
 }
 
-// createProjectMapping creates the index mapping for projects.
-func createProjectMapping() mapping.IndexMapping {
+// createProjectMapping creates the index mapping for projects, whose text
+// fields ignore stopWords.
+func createProjectMapping(stopWords []string) (mapping.IndexMapping, error) {
 	indexMapping := bleve.NewIndexMapping()
 
+	analyzer, err := addTextAnalyzer(indexMapping, stopWords)
+	if err != nil {
+		return nil, err
+	}
 	textFieldMapping := bleve.NewTextFieldMapping()
-	textFieldMapping.Analyzer = "en"
+	textFieldMapping.Analyzer = analyzer
 
 	keywordFieldMapping := bleve.NewKeywordFieldMapping()
 	dateFieldMapping := bleve.NewDateTimeFieldMapping()
@@ -165,7 +227,7 @@ func createProjectMapping() mapping.IndexMapping {
 
 	indexMapping.AddDocumentMapping("_default", projectMapping)
 
-	return indexMapping
+	return indexMapping, nil
 }
 
 // createLinkMapping creates the index mapping for links.
@@ -294,7 +356,9 @@ func (d *documentIndex) DeleteBatch(ctx context.Context, docIDs []string) error
 
 // Search performs a search query.
 func (d *documentIndex) Search(ctx context.Context, searchQuery *hermessearch.SearchQuery) (*hermessearch.SearchResult, error) {
-	return performSearch(d.index, searchQuery)
+	return performSearch(d.index, searchQuery,
+		d.adapter.ranking.For(hermessearch.RankingIndexDocs), documentSearchableAttrs,
+		d.adapter.synonyms())
 }
 
 // GetObject retrieves a single document by ID from the search index.
@@ -366,6 +430,20 @@ func (d *documentIndex) GetFacets(ctx context.Context, facetNames []string) (*he
 		}
 	}
 
+	if freshnessFacet := searchResult.Facets["freshness"]; freshnessFacet != nil {
+		facets.Freshness = make(map[string]int)
+		for _, term := range freshnessFacet.Terms.Terms() {
+			facets.Freshness[term.Term] = term.Count
+		}
+	}
+
+	if reviewSLAFacet := searchResult.Facets["reviewSla"]; reviewSLAFacet != nil {
+		facets.ReviewSLA = make(map[string]int)
+		for _, term := range reviewSLAFacet.Terms.Terms() {
+			facets.ReviewSLA[term.Term] = term.Count
+		}
+	}
+
 	return facets, nil
 }
 
@@ -382,8 +460,12 @@ func (d *documentIndex) Clear(ctx context.Context) error {
 		return fmt.Errorf("failed to remove index: %w", err)
 	}
 
-	// Recreate the index
-	newIndex, err := bleve.New(indexPath, createDocumentMapping())
+	// Recreate the index with the current stop words
+	docMapping, err := createDocumentMapping(d.adapter.stopWords())
+	if err != nil {
+		return fmt.Errorf("failed to create mapping: %w", err)
+	}
+	newIndex, err := bleve.New(indexPath, docMapping)
 	if err != nil {
 		return fmt.Errorf("failed to recreate index: %w", err)
 	}
@@ -428,7 +510,9 @@ func (d *draftIndex) DeleteBatch(ctx context.Context, docIDs []string) error {
 }
 
 func (d *draftIndex) Search(ctx context.Context, searchQuery *hermessearch.SearchQuery) (*hermessearch.SearchResult, error) {
-	return performSearch(d.index, searchQuery)
+	return performSearch(d.index, searchQuery,
+		d.adapter.ranking.For(hermessearch.RankingIndexDrafts), documentSearchableAttrs,
+		d.adapter.synonyms())
 }
 
 func (d *draftIndex) GetObject(ctx context.Context, docID string) (*hermessearch.Document, error) {
@@ -488,6 +572,20 @@ func (d *draftIndex) GetFacets(ctx context.Context, facetNames []string) (*herme
 		}
 	}
 
+	if freshnessFacet := searchResult.Facets["freshness"]; freshnessFacet != nil {
+		facets.Freshness = make(map[string]int)
+		for _, term := range freshnessFacet.Terms.Terms() {
+			facets.Freshness[term.Term] = term.Count
+		}
+	}
+
+	if reviewSLAFacet := searchResult.Facets["reviewSla"]; reviewSLAFacet != nil {
+		facets.ReviewSLA = make(map[string]int)
+		for _, term := range reviewSLAFacet.Terms.Terms() {
+			facets.ReviewSLA[term.Term] = term.Count
+		}
+	}
+
 	return facets, nil
 }
 
@@ -502,7 +600,11 @@ func (d *draftIndex) Clear(ctx context.Context) error {
 		return err
 	}
 
-	newIndex, err := bleve.New(indexPath, createDocumentMapping())
+	docMapping, err := createDocumentMapping(d.adapter.stopWords())
+	if err != nil {
+		return err
+	}
+	newIndex, err := bleve.New(indexPath, docMapping)
 	if err != nil {
 		return err
 	}
@@ -532,7 +634,9 @@ func (p *projectIndex) Delete(ctx context.Context, projectID string) error {
 }
 
 func (p *projectIndex) Search(ctx context.Context, searchQuery *hermessearch.SearchQuery) (*hermessearch.SearchResult, error) {
-	return performSearch(p.index, searchQuery)
+	return performSearch(p.index, searchQuery,
+		p.adapter.ranking.For(hermessearch.RankingIndexProjects), projectSearchableAttrs,
+		p.adapter.synonyms())
 }
 
 func (p *projectIndex) GetObject(ctx context.Context, projectID string) (map[string]any, error) {
@@ -558,7 +662,11 @@ func (p *projectIndex) Clear(ctx context.Context) error {
 		return err
 	}
 
-	newIndex, err := bleve.New(indexPath, createProjectMapping())
+	projectMapping, err := createProjectMapping(p.adapter.stopWords())
+	if err != nil {
+		return err
+	}
+	newIndex, err := bleve.New(indexPath, projectMapping)
 	if err != nil {
 		return err
 	}
@@ -593,7 +701,11 @@ func (l *linksIndex) GetLink(ctx context.Context, objectID string) (map[string]s
 		return nil, err
 	}
 	if doc == nil {
-		return nil, fmt.Errorf("link not found: %s", objectID)
+		return nil, &hermessearch.Error{
+			Op:  "GetLink",
+			Err: hermessearch.ErrNotFound,
+			Msg: objectID,
+		}
 	}
 	// Simplified return - in production you'd need proper deserialization
 	return map[string]string{"objectID": objectID}, nil
@@ -621,8 +733,15 @@ func (l *linksIndex) Clear(ctx context.Context) error {
 	return nil
 }
 
-// performSearch executes a search query on a Bleve index.
-func performSearch(index bleve.Index, searchQuery *hermessearch.SearchQuery) (*hermessearch.SearchResult, error) {
+// performSearch executes a search query on a Bleve index, ranking text matches
+// in attrs by ranking and matching the synonyms of terms in the query.
+func performSearch(
+	index bleve.Index,
+	searchQuery *hermessearch.SearchQuery,
+	ranking hermessearch.Ranking,
+	attrs []string,
+	synonyms [][]string,
+) (*hermessearch.SearchResult, error) {
 	startTime := time.Now()
 
 	// Build Bleve query
@@ -631,8 +750,18 @@ func performSearch(index bleve.Index, searchQuery *hermessearch.SearchQuery) (*h
 	if searchQuery.Query == "" {
 		q = bleve.NewMatchAllQuery()
 	} else {
-		// Use match query for text search
-		q = bleve.NewMatchQuery(searchQuery.Query)
+		// Use match query for text search, matching any synonym of the
+		// query's terms
+		variants := expandSynonyms(searchQuery.Query, synonyms)
+		if len(variants) == 1 {
+			q = textQuery(searchQuery.Query, ranking, attrs)
+		} else {
+			disjunction := bleve.NewDisjunctionQuery()
+			for _, v := range variants {
+				disjunction.AddQuery(textQuery(v, ranking, attrs))
+			}
+			q = disjunction
+		}
 	}
 
 	// Build filter queries
@@ -676,6 +805,15 @@ func performSearch(index bleve.Index, searchQuery *hermessearch.SearchQuery) (*h
 	searchRequest.From = page * perPage
 	searchRequest.Size = perPage
 
+	// Rescore the top results by recency, unless they're sorted by a field.
+	rescore := ranking.RecencyDecay > 0 && searchQuery.Query != "" &&
+		searchQuery.SortBy == "" && searchRequest.From < rescoreWindow
+	if rescore {
+		searchRequest.From = 0
+		searchRequest.Size = rescoreWindow
+		searchRequest.Fields = append(searchRequest.Fields, "modifiedTime")
+	}
+
 	// Sorting
 	if searchQuery.SortBy != "" {
 		sortOrder := strings.ToLower(searchQuery.SortOrder) == "desc"
@@ -703,6 +841,12 @@ func performSearch(index bleve.Index, searchQuery *hermessearch.SearchQuery) (*h
 		return nil, fmt.Errorf("search failed: %w", err)
 	}
 
+	if rescore {
+		rescoreByRecency(searchResult.Hits, ranking, time.Now())
+		from := min(page*perPage, len(searchResult.Hits))
+		searchResult.Hits = searchResult.Hits[from:min(from+perPage, len(searchResult.Hits))]
+	}
+
 	// Convert results
 	hits := make([]*hermessearch.Document, 0, len(searchResult.Hits))
 	for _, hit := range searchResult.Hits {
@@ -729,6 +873,18 @@ func performSearch(index bleve.Index, searchQuery *hermessearch.SearchQuery) (*h
 		if summary, ok := hit.Fields["summary"].(string); ok {
 			doc.Summary = summary
 		}
+		if freshness, ok := hit.Fields["freshness"].(string); ok {
+			doc.Freshness = freshness
+		}
+		if score, ok := hit.Fields["freshnessScore"].(float64); ok {
+			doc.FreshnessScore = int(score)
+		}
+		if reviewSLA, ok := hit.Fields["reviewSla"].(string); ok {
+			doc.ReviewSLA = reviewSLA
+		}
+		if t, ok := hit.Fields["tenant"].(string); ok {
+			doc.Tenant = t
+		}
 
 		// Extract timestamps
 		if createdTime, ok := hit.Fields["createdTime"].(string); ok {
@@ -781,6 +937,20 @@ func performSearch(index bleve.Index, searchQuery *hermessearch.SearchQuery) (*h
 		}
 	}
 
+	if freshnessFacet := searchResult.Facets["freshness"]; freshnessFacet != nil {
+		facets.Freshness = make(map[string]int)
+		for _, term := range freshnessFacet.Terms.Terms() {
+			facets.Freshness[term.Term] = term.Count
+		}
+	}
+
+	if reviewSLAFacet := searchResult.Facets["reviewSla"]; reviewSLAFacet != nil {
+		facets.ReviewSLA = make(map[string]int)
+		for _, term := range reviewSLAFacet.Terms.Terms() {
+			facets.ReviewSLA[term.Term] = term.Count
+		}
+	}
+
 	totalPages := int(searchResult.Total) / perPage
 	if int(searchResult.Total)%perPage > 0 {
 		totalPages++
@@ -796,3 +966,74 @@ func performSearch(index bleve.Index, searchQuery *hermessearch.SearchQuery) (*h
 		QueryTime:  time.Since(startTime),
 	}, nil
 }
+
+// textQuery returns the query for text. Without a ranking, it matches text in
+// any field. With attribute weights, it matches text in attrs and the weighted
+// attributes, boosting matches by their weight. With an exact match boost,
+// results that match text as a phrase are boosted by it.
+func textQuery(text string, ranking hermessearch.Ranking, attrs []string) query.Query {
+	if len(ranking.AttributeWeights) == 0 && ranking.Boost() == 1 {
+		return bleve.NewMatchQuery(text)
+	}
+
+	var q query.Query
+	fields := []string{""} // The default field, which includes all fields.
+	if len(ranking.AttributeWeights) > 0 {
+		fields = ranking.SearchableAttributes(attrs)
+		matches := bleve.NewDisjunctionQuery()
+		for _, f := range fields {
+			m := bleve.NewMatchQuery(text)
+			m.SetField(f)
+			m.SetBoost(ranking.Weight(f))
+			matches.AddQuery(m)
+		}
+		q = matches
+	} else {
+		q = bleve.NewMatchQuery(text)
+	}
+	if ranking.Boost() == 1 {
+		return q
+	}
+
+	exact := bleve.NewDisjunctionQuery()
+	for _, f := range fields {
+		p := bleve.NewMatchPhraseQuery(text)
+		if f != "" {
+			p.SetField(f)
+		}
+		p.SetBoost(ranking.Boost() * ranking.Weight(f))
+		exact.AddQuery(p)
+	}
+	b := bleve.NewBooleanQuery()
+	b.AddMust(q)
+	b.AddShould(exact)
+	return b
+}
+
+// rescoreByRecency multiplies the scores of hits by their recency factor and
+// sorts them by score.
+func rescoreByRecency(hits search.DocumentMatchCollection, ranking hermessearch.Ranking, now time.Time) {
+	for _, hit := range hits {
+		hit.Score *= ranking.RecencyFactor(hitTime(hit.Fields["modifiedTime"]), now)
+	}
+	sort.SliceStable(hits, func(i, j int) bool {
+		return hits[i].Score > hits[j].Score
+	})
+}
+
+// hitTime returns the time of a stored timestamp field, which is indexed as
+// Unix seconds or, for datetime fields, returned in RFC 3339 format.
+func hitTime(v any) time.Time {
+	switch v := v.(type) {
+	case float64:
+		return time.Unix(int64(v), 0)
+	case string:
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			return t
+		}
+		if i, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return time.Unix(i, 0)
+		}
+	}
+	return time.Time{}
+}
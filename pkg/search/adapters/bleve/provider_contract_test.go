@@ -0,0 +1,31 @@
+package bleve
+
+import (
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/pkg/search"
+	"github.com/hashicorp-forge/hermes/pkg/search/providertest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdapter_ProviderContract(t *testing.T) {
+	providertest.Run(t, providertest.Config{
+		New: func(t *testing.T) search.Provider {
+			adapter, err := NewAdapter(&Config{IndexPath: t.TempDir()})
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = adapter.Close() })
+			return adapter
+		},
+		Skip: map[string]string{
+			providertest.TestIndex: "GetObject only returns object IDs, and " +
+				"doesn't return search.ErrNotFound for missing documents",
+			providertest.TestHitFields: "search requests don't load stored fields, " +
+				"so hits only have object IDs",
+			providertest.TestFilterGroups: "filter groups are ignored",
+			providertest.TestSorting: "timestamps are mapped as datetimes but " +
+				"indexed as numbers, so they don't sort",
+			providertest.TestDelete: "GetObject doesn't return search.ErrNotFound " +
+				"for missing documents",
+		},
+	})
+}
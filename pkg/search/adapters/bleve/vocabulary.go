@@ -0,0 +1,199 @@
+package bleve
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/custom"
+	"github.com/blevesearch/bleve/v2/analysis/lang/en"
+	"github.com/blevesearch/bleve/v2/analysis/token/lowercase"
+	"github.com/blevesearch/bleve/v2/analysis/token/porter"
+	"github.com/blevesearch/bleve/v2/analysis/token/stop"
+	"github.com/blevesearch/bleve/v2/analysis/tokenizer/unicode"
+	"github.com/blevesearch/bleve/v2/analysis/tokenmap"
+	"github.com/blevesearch/bleve/v2/mapping"
+
+	hermessearch "github.com/hashicorp-forge/hermes/pkg/search"
+)
+
+// Names of the analysis components of text fields with stop words.
+const (
+	stopWordsTokenMap = "hermes_stop_words"
+	stopWordsFilter   = "hermes_stop_words"
+	stopWordsAnalyzer = "hermes_en"
+)
+
+// maxSynonymVariants is the most variants of a query that synonyms expand it
+// to.
+const maxSynonymVariants = 16
+
+// UpdateVocabulary replaces the synonyms and stop words of the indexes, and
+// saves them with the indexes. Synonyms apply to searches right away. Stop
+// words are part of the analyzer of text fields, so they only apply to
+// indexes created after the update: it returns true if they differ from the
+// stop words of the docs index, which must then be cleared and reindexed.
+func (a *Adapter) UpdateVocabulary(ctx context.Context, v hermessearch.Vocabulary) (bool, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return false, err
+	}
+	if err := os.WriteFile(a.vocabularyPath, b, 0o644); err != nil {
+		return false, fmt.Errorf("failed to save vocabulary: %w", err)
+	}
+
+	a.mu.Lock()
+	a.vocabulary = v
+	a.mu.Unlock()
+
+	return !slices.Equal(normalizeStopWords(v.StopWords),
+		indexStopWords(a.docsIndex)), nil
+}
+
+// loadVocabulary loads the vocabulary saved by UpdateVocabulary, if any.
+func (a *Adapter) loadVocabulary() error {
+	b, err := os.ReadFile(a.vocabularyPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var v hermessearch.Vocabulary
+	if err := json.Unmarshal(b, &v); err != nil {
+		return fmt.Errorf("invalid vocabulary file %s: %w", a.vocabularyPath, err)
+	}
+	a.vocabulary = v
+	return nil
+}
+
+// stopWords returns the stop words that indexes are created with.
+func (a *Adapter) stopWords() []string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return normalizeStopWords(a.vocabulary.StopWords)
+}
+
+// synonyms returns the synonym sets that queries are expanded with.
+func (a *Adapter) synonyms() [][]string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.vocabulary.Synonyms
+}
+
+// normalizeStopWords returns stop words lowercased, sorted, and without
+// duplicates, as they're matched against lowercased tokens.
+func normalizeStopWords(words []string) []string {
+	normalized := make([]string, 0, len(words))
+	for _, w := range words {
+		normalized = append(normalized, strings.ToLower(w))
+	}
+	slices.Sort(normalized)
+	return slices.Compact(normalized)
+}
+
+// addTextAnalyzer adds an English analyzer that also ignores stopWords to
+// indexMapping, and returns its name. It returns the English analyzer if
+// there are no stop words.
+func addTextAnalyzer(indexMapping *mapping.IndexMappingImpl, stopWords []string) (string, error) {
+	if len(stopWords) == 0 {
+		return en.AnalyzerName, nil
+	}
+
+	tokens := make([]interface{}, len(stopWords))
+	for i, w := range stopWords {
+		tokens[i] = w
+	}
+	if err := indexMapping.AddCustomTokenMap(stopWordsTokenMap, map[string]interface{}{
+		"type":   tokenmap.Name,
+		"tokens": tokens,
+	}); err != nil {
+		return "", fmt.Errorf("failed to add stop words: %w", err)
+	}
+	if err := indexMapping.AddCustomTokenFilter(stopWordsFilter, map[string]interface{}{
+		"type":           stop.Name,
+		"stop_token_map": stopWordsTokenMap,
+	}); err != nil {
+		return "", fmt.Errorf("failed to add stop words filter: %w", err)
+	}
+	// The English analyzer, with the stop words filter after its own.
+	if err := indexMapping.AddCustomAnalyzer(stopWordsAnalyzer, map[string]interface{}{
+		"type":      custom.Name,
+		"tokenizer": unicode.Name,
+		"token_filters": []interface{}{
+			en.PossessiveName,
+			lowercase.Name,
+			en.StopName,
+			stopWordsFilter,
+			porter.Name,
+		},
+	}); err != nil {
+		return "", fmt.Errorf("failed to add analyzer: %w", err)
+	}
+	return stopWordsAnalyzer, nil
+}
+
+// indexStopWords returns the stop words that index was created with.
+func indexStopWords(index bleve.Index) []string {
+	m, ok := index.Mapping().(*mapping.IndexMappingImpl)
+	if !ok || m.CustomAnalysis == nil {
+		return []string{}
+	}
+	tokens, _ := m.CustomAnalysis.TokenMaps[stopWordsTokenMap]["tokens"].([]interface{})
+	words := make([]string, 0, len(tokens))
+	for _, t := range tokens {
+		if w, ok := t.(string); ok {
+			words = append(words, w)
+		}
+	}
+	return normalizeStopWords(words)
+}
+
+// expandSynonyms returns the variants of query with the terms of synonym sets
+// replaced by their synonyms, starting with query itself. Terms are matched
+// as whole words, ignoring case.
+func expandSynonyms(query string, synonyms [][]string) []string {
+	variants := []string{query}
+	for _, set := range synonyms {
+		for i := 0; i < len(variants) && len(variants) < maxSynonymVariants; i++ {
+			v := variants[i]
+			for _, term := range set {
+				re := termRE(term)
+				if re == nil || !re.MatchString(v) {
+					continue
+				}
+				for _, syn := range set {
+					if syn == term || len(variants) >= maxSynonymVariants {
+						continue
+					}
+					variant := re.ReplaceAllLiteralString(v, syn)
+					if !slices.ContainsFunc(variants, func(other string) bool {
+						return strings.EqualFold(other, variant)
+					}) {
+						variants = append(variants, variant)
+					}
+				}
+			}
+		}
+	}
+	return variants
+}
+
+// termRE returns a regular expression that matches term as whole words,
+// ignoring case, or nil if term is blank.
+func termRE(term string) *regexp.Regexp {
+	words := strings.Fields(term)
+	if len(words) == 0 {
+		return nil
+	}
+	for i, w := range words {
+		words[i] = regexp.QuoteMeta(w)
+	}
+	return regexp.MustCompile(`(?i)\b` + strings.Join(words, `\s+`) + `\b`)
+}
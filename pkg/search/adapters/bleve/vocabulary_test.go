@@ -0,0 +1,73 @@
+package bleve
+
+import (
+	"context"
+	"testing"
+
+	hermessearch "github.com/hashicorp-forge/hermes/pkg/search"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandSynonyms(t *testing.T) {
+	synonyms := [][]string{
+		{"VM", "virtual machine"},
+		{"k8s", "kubernetes"},
+	}
+
+	assert.Equal(t, []string{"deploy"}, expandSynonyms("deploy", synonyms))
+	assert.Equal(t,
+		[]string{"vm images", "virtual machine images"},
+		expandSynonyms("vm images", synonyms))
+	assert.Equal(t,
+		[]string{"Virtual Machine", "VM"},
+		expandSynonyms("Virtual Machine", synonyms))
+	assert.Equal(t,
+		[]string{
+			"VM on k8s",
+			"virtual machine on k8s",
+			"VM on kubernetes",
+			"virtual machine on kubernetes",
+		},
+		expandSynonyms("VM on k8s", synonyms))
+
+	// Terms only match whole words.
+	assert.Equal(t, []string{"vmware"}, expandSynonyms("vmware", synonyms))
+}
+
+func TestAdapter_UpdateVocabulary(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	adapter, err := NewAdapter(&Config{IndexPath: dir})
+	require.NoError(t, err)
+
+	// Synonyms apply without reindexing.
+	reindex, err := adapter.UpdateVocabulary(ctx, hermessearch.Vocabulary{
+		Synonyms: [][]string{{"VM", "virtual machine"}},
+	})
+	require.NoError(t, err)
+	assert.False(t, reindex)
+
+	// Stop words apply to indexes created after the update.
+	v := hermessearch.Vocabulary{
+		Synonyms:  [][]string{{"VM", "virtual machine"}},
+		StopWords: []string{"hashicorp"},
+	}
+	reindex, err = adapter.UpdateVocabulary(ctx, v)
+	require.NoError(t, err)
+	assert.True(t, reindex)
+
+	require.NoError(t, adapter.DocumentIndex().Clear(ctx))
+	reindex, err = adapter.UpdateVocabulary(ctx, v)
+	require.NoError(t, err)
+	assert.False(t, reindex)
+	require.NoError(t, adapter.Close())
+
+	// The vocabulary is saved with the indexes.
+	adapter, err = NewAdapter(&Config{IndexPath: dir})
+	require.NoError(t, err)
+	defer adapter.Close()
+	assert.Equal(t, v, adapter.vocabulary)
+	assert.Equal(t, []string{"hashicorp"}, indexStopWords(adapter.docsIndex))
+}
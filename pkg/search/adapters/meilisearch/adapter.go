@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -19,6 +20,7 @@ type Adapter struct {
 	draftsIndex   string
 	projectsIndex string
 	linksIndex    string
+	ranking       hermessearch.Rankings
 }
 
 // Config contains Meilisearch configuration.
@@ -29,6 +31,15 @@ type Config struct {
 	DraftsIndexName   string
 	ProjectsIndexName string
 	LinksIndexName    string
+
+	// Ranking tunes how the results of the docs, drafts, and projects indexes
+	// are ranked.
+	Ranking hermessearch.Rankings
+}
+
+// defaultRankingRules are the default Meilisearch ranking rules.
+var defaultRankingRules = []string{
+	"words", "typo", "proximity", "attribute", "sort", "exactness",
 }
 
 // NewAdapter creates a new Meilisearch search adapter.
@@ -45,6 +56,7 @@ func NewAdapter(cfg *Config) (*Adapter, error) {
 		draftsIndex:   cfg.DraftsIndexName,
 		projectsIndex: cfg.ProjectsIndexName,
 		linksIndex:    cfg.LinksIndexName,
+		ranking:       cfg.Ranking,
 	}
 
 	// Initialize indexes with settings
@@ -82,12 +94,19 @@ func (a *Adapter) initializeIndexes(ctx context.Context) error {
 	// Configure documents index
 	docsIdx := a.client.Index(a.docsIndex)
 
-	// Configure searchable attributes
+	// Configure searchable attributes, in order of importance
 	searchableAttrs := []string{"title", "docNumber", "summary", "content", "owners", "contributors"}
-	if _, err := docsIdx.UpdateSearchableAttributesWithContext(ctx, &searchableAttrs); err != nil {
+	docsSearchableAttrs := a.ranking.For(hermessearch.RankingIndexDocs).
+		SearchableAttributes(searchableAttrs)
+	if _, err := docsIdx.UpdateSearchableAttributesWithContext(ctx, &docsSearchableAttrs); err != nil {
 		return fmt.Errorf("failed to update searchable attributes: %w", err)
 	}
 
+	docsRankingRules := rankingRules(a.ranking.For(hermessearch.RankingIndexDocs))
+	if _, err := docsIdx.UpdateRankingRulesWithContext(ctx, &docsRankingRules); err != nil {
+		return fmt.Errorf("failed to update ranking rules: %w", err)
+	}
+
 	// Configure filterable attributes
 	// Include all attributes that might be used in queries by the API handlers
 	filterableAttrs := []interface{}{
@@ -95,13 +114,17 @@ func (a *Adapter) initializeIndexes(ctx context.Context) error {
 		"owners", "contributors", "approvers",
 		"createdTime", "modifiedTime",
 		"appCreated", "approvedBy", // Used by approval workflow queries
+		"freshness", "freshnessScore",
+		"reviewSla",
+		"tenant",  // Used to partition documents by tenant
+		"viewers", // Used to limit drafts to the users who can view them
 	}
 	if _, err := docsIdx.UpdateFilterableAttributesWithContext(ctx, &filterableAttrs); err != nil {
 		return fmt.Errorf("failed to update filterable attributes: %w", err)
 	}
 
 	// Configure sortable attributes
-	sortableAttrs := []string{"createdTime", "modifiedTime", "title"}
+	sortableAttrs := []string{"createdTime", "modifiedTime", "title", "freshnessScore"}
 	if _, err := docsIdx.UpdateSortableAttributesWithContext(ctx, &sortableAttrs); err != nil {
 		return fmt.Errorf("failed to update sortable attributes: %w", err)
 	}
@@ -109,10 +132,17 @@ func (a *Adapter) initializeIndexes(ctx context.Context) error {
 	// Configure the same for drafts index
 	draftsIdx := a.client.Index(a.draftsIndex)
 
-	if _, err := draftsIdx.UpdateSearchableAttributesWithContext(ctx, &searchableAttrs); err != nil {
+	draftsSearchableAttrs := a.ranking.For(hermessearch.RankingIndexDrafts).
+		SearchableAttributes(searchableAttrs)
+	if _, err := draftsIdx.UpdateSearchableAttributesWithContext(ctx, &draftsSearchableAttrs); err != nil {
 		return fmt.Errorf("failed to update drafts searchable attributes: %w", err)
 	}
 
+	draftsRankingRules := rankingRules(a.ranking.For(hermessearch.RankingIndexDrafts))
+	if _, err := draftsIdx.UpdateRankingRulesWithContext(ctx, &draftsRankingRules); err != nil {
+		return fmt.Errorf("failed to update drafts ranking rules: %w", err)
+	}
+
 	if _, err := draftsIdx.UpdateFilterableAttributesWithContext(ctx, &filterableAttrs); err != nil {
 		return fmt.Errorf("failed to update drafts filterable attributes: %w", err)
 	}
@@ -136,7 +166,8 @@ func (a *Adapter) initializeIndexes(ctx context.Context) error {
 	projectsIdx := a.client.Index(a.projectsIndex)
 
 	// Projects have different fields than documents
-	projectSearchableAttrs := []string{"title", "description", "jiraIssueID"}
+	projectSearchableAttrs := a.ranking.For(hermessearch.RankingIndexProjects).
+		SearchableAttributes([]string{"title", "description", "jiraIssueID"})
 	if _, err := projectsIdx.UpdateSearchableAttributesWithContext(ctx, &projectSearchableAttrs); err != nil {
 		return fmt.Errorf("failed to update projects searchable attributes: %w", err)
 	}
@@ -157,6 +188,11 @@ func (a *Adapter) initializeIndexes(ctx context.Context) error {
 		return fmt.Errorf("failed to update projects sortable attributes: %w", err)
 	}
 
+	projectRankingRules := rankingRules(a.ranking.For(hermessearch.RankingIndexProjects))
+	if _, err := projectsIdx.UpdateRankingRulesWithContext(ctx, &projectRankingRules); err != nil {
+		return fmt.Errorf("failed to update projects ranking rules: %w", err)
+	}
+
 	return nil
 }
 
@@ -217,6 +253,28 @@ func (a *Adapter) Healthy(ctx context.Context) error {
 	return nil
 }
 
+// UpdateVocabulary replaces the synonyms and stop words of the docs, drafts,
+// and projects indexes. Meilisearch applies settings to existing documents, so
+// reindexing isn't needed.
+func (a *Adapter) UpdateVocabulary(ctx context.Context, v hermessearch.Vocabulary) (bool, error) {
+	synonyms := synonymsMap(v.Synonyms)
+	stopWords := v.StopWords
+	if stopWords == nil {
+		stopWords = []string{}
+	}
+
+	for _, index := range []string{a.docsIndex, a.draftsIndex, a.projectsIndex} {
+		idx := a.client.Index(index)
+		if _, err := idx.UpdateSynonymsWithContext(ctx, &synonyms); err != nil {
+			return false, fmt.Errorf("failed to update %s synonyms: %w", index, err)
+		}
+		if _, err := idx.UpdateStopWordsWithContext(ctx, &stopWords); err != nil {
+			return false, fmt.Errorf("failed to update %s stop words: %w", index, err)
+		}
+	}
+	return false, nil
+}
+
 // documentIndex implements search.DocumentIndex.
 type documentIndex struct {
 	client meilisearch.ServiceManager
@@ -468,6 +526,39 @@ func (di *documentIndex) Search(ctx context.Context, query *hermessearch.SearchQ
 	return result, nil
 }
 
+// UpdateFields sets fields on an indexed document without replacing it. The
+// update is queued and not waited for.
+func (di *documentIndex) UpdateFields(ctx context.Context, docID string, fields map[string]any) error {
+	idx := di.client.Index(di.index)
+
+	// Meilisearch creates documents that don't exist on update, so check first
+	// to avoid indexing partial documents.
+	var existing meilisearch.Hit
+	if err := idx.GetDocumentWithContext(ctx, docID, nil, &existing); err != nil {
+		return &hermessearch.Error{
+			Op:  "UpdateFields",
+			Err: hermessearch.ErrNotFound,
+			Msg: err.Error(),
+		}
+	}
+
+	doc := make(map[string]any, len(fields)+1)
+	for k, v := range fields {
+		doc[k] = v
+	}
+	doc["objectID"] = docID
+
+	primaryKey := "objectID"
+	if _, err := idx.UpdateDocumentsWithContext(ctx, []map[string]any{doc}, &primaryKey); err != nil {
+		return &hermessearch.Error{
+			Op:  "UpdateFields",
+			Err: hermessearch.ErrIndexingFailed,
+			Msg: err.Error(),
+		}
+	}
+	return nil
+}
+
 func (di *documentIndex) GetObject(ctx context.Context, docID string) (*hermessearch.Document, error) {
 	idx := di.client.Index(di.index)
 
@@ -594,6 +685,11 @@ func (di *draftIndex) GetObject(ctx context.Context, docID string) (*hermessearc
 	return docIdx.GetObject(ctx, docID)
 }
 
+func (di *draftIndex) UpdateFields(ctx context.Context, docID string, fields map[string]any) error {
+	docIdx := &documentIndex{client: di.client, index: di.index}
+	return docIdx.UpdateFields(ctx, docID, fields)
+}
+
 func (di *draftIndex) GetFacets(ctx context.Context, facetNames []string) (*hermessearch.Facets, error) {
 	docIdx := &documentIndex{client: di.client, index: di.index}
 	return docIdx.GetFacets(ctx, facetNames)
@@ -606,6 +702,44 @@ func (di *draftIndex) Clear(ctx context.Context) error {
 
 // Helper functions
 
+// rankingRules returns the Meilisearch ranking rules for a ranking. Meilisearch
+// ranks by rules instead of scores, so an exact match boost above 1 moves the
+// exactness rule after the words rule, and recency decay adds a custom rule
+// that breaks ties in favor of recently modified documents. Attribute weights
+// are applied by the order of the searchable attributes.
+func rankingRules(ranking hermessearch.Ranking) []string {
+	rules := make([]string, 0, len(defaultRankingRules)+1)
+	for _, rule := range defaultRankingRules {
+		if rule == "exactness" && ranking.Boost() > 1 {
+			continue
+		}
+		rules = append(rules, rule)
+		if rule == "words" && ranking.Boost() > 1 {
+			rules = append(rules, "exactness")
+		}
+	}
+	if ranking.RecencyDecay > 0 {
+		rules = append(rules, "modifiedTime:desc")
+	}
+	return rules
+}
+
+// synonymsMap returns synonym sets as Meilisearch synonyms, which map each
+// term to the terms it's equivalent to.
+func synonymsMap(sets [][]string) map[string][]string {
+	synonyms := map[string][]string{}
+	for _, set := range sets {
+		for _, term := range set {
+			for _, syn := range set {
+				if syn != term && !slices.Contains(synonyms[term], syn) {
+					synonyms[term] = append(synonyms[term], syn)
+				}
+			}
+		}
+	}
+	return synonyms
+}
+
 func buildMeilisearchFilters(filters map[string][]string) interface{} {
 	// Convert to Meilisearch filter syntax
 	// Example: product = "terraform" AND status IN ["approved", "published"]
@@ -715,6 +849,16 @@ func convertMeilisearchFacets(facetDistRaw json.RawMessage) (*hermessearch.Facet
 			for value, count := range values {
 				facets.Owners[value] = int(count)
 			}
+		case "freshness":
+			facets.Freshness = make(map[string]int, len(values))
+			for value, count := range values {
+				facets.Freshness[value] = int(count)
+			}
+		case "reviewSla":
+			facets.ReviewSLA = make(map[string]int, len(values))
+			for value, count := range values {
+				facets.ReviewSLA[value] = int(count)
+			}
 		}
 	}
 
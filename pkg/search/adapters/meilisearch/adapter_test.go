@@ -2,7 +2,9 @@ package meilisearch
 
 import (
 	"encoding/json"
+	"slices"
 	"testing"
+	"time"
 
 	hermessearch "github.com/hashicorp-forge/hermes/pkg/search"
 )
@@ -213,11 +215,67 @@ func TestConvertMeilisearchFacets(t *testing.T) {
 	}
 }
 
+// TestRankingRules tests ranking rule generation.
+func TestRankingRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		ranking hermessearch.Ranking
+		want    []string
+	}{
+		{
+			name: "default ranking",
+			want: []string{"words", "typo", "proximity", "attribute", "sort", "exactness"},
+		},
+		{
+			name:    "exact match boost",
+			ranking: hermessearch.Ranking{ExactMatchBoost: 2},
+			want:    []string{"words", "exactness", "typo", "proximity", "attribute", "sort"},
+		},
+		{
+			name:    "recency decay",
+			ranking: hermessearch.Ranking{RecencyDecay: time.Hour},
+			want:    []string{"words", "typo", "proximity", "attribute", "sort", "exactness", "modifiedTime:desc"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rankingRules(tt.ranking); !slices.Equal(got, tt.want) {
+				t.Errorf("rankingRules() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestSynonymsMap tests synonym set conversion.
+func TestSynonymsMap(t *testing.T) {
+	got := synonymsMap([][]string{
+		{"VM", "virtual machine"},
+		{"k8s", "kubernetes", "kube"},
+	})
+	want := map[string][]string{
+		"VM":              {"virtual machine"},
+		"virtual machine": {"VM"},
+		"k8s":             {"kubernetes", "kube"},
+		"kubernetes":      {"k8s", "kube"},
+		"kube":            {"k8s", "kubernetes"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("synonymsMap() = %v, want %v", got, want)
+	}
+	for term, syns := range want {
+		if !slices.Equal(got[term], syns) {
+			t.Errorf("synonymsMap()[%q] = %v, want %v", term, got[term], syns)
+		}
+	}
+}
+
 // TestAdapterInterfaces verifies the adapter implements required interfaces.
 func TestAdapterInterfaces(t *testing.T) {
 	var _ hermessearch.Provider = (*Adapter)(nil)
 	var _ hermessearch.DocumentIndex = (*documentIndex)(nil)
 	var _ hermessearch.DraftIndex = (*draftIndex)(nil)
+	var _ hermessearch.VocabularyUpdater = (*Adapter)(nil)
 }
 
 // Note: Integration tests that require a running Meilisearch instance
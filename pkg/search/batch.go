@@ -0,0 +1,143 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+const (
+	// DefaultBatchSize is the default number of documents indexed per
+	// IndexBatch call.
+	DefaultBatchSize = 100
+
+	// DefaultBatchConcurrency is the default number of IndexBatch calls in
+	// flight.
+	DefaultBatchConcurrency = 4
+)
+
+// BatchIndexer indexes batches of documents. DocumentIndex and DraftIndex
+// implement it.
+type BatchIndexer interface {
+	IndexBatch(ctx context.Context, docs []*Document) error
+}
+
+// BatchOptions configures a Batcher.
+type BatchOptions struct {
+	// BatchSize is the number of documents indexed per IndexBatch call
+	// (default: DefaultBatchSize).
+	BatchSize int
+
+	// Concurrency is the number of IndexBatch calls in flight (default:
+	// DefaultBatchConcurrency).
+	Concurrency int
+
+	// OnError is called with the documents of each batch that fails to be
+	// indexed (optional). Calls aren't concurrent.
+	OnError func(docs []*Document, err error)
+}
+
+// Batcher accumulates documents and indexes them in batches with IndexBatch,
+// which is much faster than indexing them one by one when reindexing. It
+// isn't safe for concurrent use.
+type Batcher struct {
+	idx  BatchIndexer
+	opts BatchOptions
+
+	queue []*Document
+	sem   chan struct{}
+	wg    sync.WaitGroup
+
+	mu      sync.Mutex
+	errs    []error
+	indexed int
+}
+
+// NewBatcher creates a batcher that indexes documents in idx.
+func NewBatcher(idx BatchIndexer, opts BatchOptions) *Batcher {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultBatchSize
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = DefaultBatchConcurrency
+	}
+	return &Batcher{
+		idx:  idx,
+		opts: opts,
+		sem:  make(chan struct{}, opts.Concurrency),
+	}
+}
+
+// Add queues doc, and starts indexing the queued documents once there's a
+// full batch. It blocks while Concurrency batches are being indexed.
+func (b *Batcher) Add(ctx context.Context, doc *Document) {
+	b.queue = append(b.queue, doc)
+	if len(b.queue) >= b.opts.BatchSize {
+		b.flushQueue(ctx)
+	}
+}
+
+// Flush indexes the queued documents, waits for all batches to be indexed,
+// and returns the errors of failed batches since the last flush.
+func (b *Batcher) Flush(ctx context.Context) error {
+	if len(b.queue) > 0 {
+		b.flushQueue(ctx)
+	}
+	b.wg.Wait()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	err := errors.Join(b.errs...)
+	b.errs = nil
+	return err
+}
+
+// Indexed returns the number of documents indexed successfully.
+func (b *Batcher) Indexed() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.indexed
+}
+
+// flushQueue starts indexing the queued documents as a batch.
+func (b *Batcher) flushQueue(ctx context.Context) {
+	docs := b.queue
+	b.queue = nil
+
+	if err := ctx.Err(); err != nil {
+		b.fail(docs, err)
+		return
+	}
+	select {
+	case b.sem <- struct{}{}:
+	case <-ctx.Done():
+		b.fail(docs, ctx.Err())
+		return
+	}
+
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		defer func() { <-b.sem }()
+
+		if err := b.idx.IndexBatch(ctx, docs); err != nil {
+			b.fail(docs, err)
+			return
+		}
+		b.mu.Lock()
+		b.indexed += len(docs)
+		b.mu.Unlock()
+	}()
+}
+
+// fail records the failure to index a batch of docs.
+func (b *Batcher) fail(docs []*Document, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.errs = append(b.errs, fmt.Errorf(
+		"error indexing batch of %d documents: %w", len(docs), err))
+	if b.opts.OnError != nil {
+		b.opts.OnError(docs, err)
+	}
+}
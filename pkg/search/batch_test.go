@@ -0,0 +1,82 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBatchIndexer records the size of each batch and fails batches that
+// include a document with the ID "fail".
+type fakeBatchIndexer struct {
+	mu      sync.Mutex
+	batches []int
+}
+
+func (f *fakeBatchIndexer) IndexBatch(ctx context.Context, docs []*Document) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.batches = append(f.batches, len(docs))
+	for _, doc := range docs {
+		if doc.ObjectID == "fail" {
+			return errors.New("invalid document")
+		}
+	}
+	return nil
+}
+
+func TestBatcher(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("documents are indexed in batches", func(t *testing.T) {
+		idx := &fakeBatchIndexer{}
+		b := NewBatcher(idx, BatchOptions{BatchSize: 3, Concurrency: 2})
+		for i := range 7 {
+			b.Add(ctx, &Document{ObjectID: fmt.Sprintf("doc%d", i)})
+		}
+		require.NoError(t, b.Flush(ctx))
+
+		assert.ElementsMatch(t, []int{3, 3, 1}, idx.batches)
+		assert.Equal(t, 7, b.Indexed())
+	})
+
+	t.Run("failed batches are reported", func(t *testing.T) {
+		idx := &fakeBatchIndexer{}
+		var failed []string
+		b := NewBatcher(idx, BatchOptions{
+			BatchSize: 2,
+			OnError: func(docs []*Document, err error) {
+				for _, doc := range docs {
+					failed = append(failed, doc.ObjectID)
+				}
+			},
+		})
+		for _, id := range []string{"a", "b", "fail", "c", "d"} {
+			b.Add(ctx, &Document{ObjectID: id})
+		}
+
+		err := b.Flush(ctx)
+		assert.ErrorContains(t, err, "batch of 2 documents: invalid document")
+		assert.Equal(t, []string{"fail", "c"}, failed)
+		assert.Equal(t, 3, b.Indexed())
+
+		// Errors are only returned once.
+		assert.NoError(t, b.Flush(ctx))
+	})
+
+	t.Run("canceled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		idx := &fakeBatchIndexer{}
+		b := NewBatcher(idx, BatchOptions{BatchSize: 1})
+		b.Add(ctx, &Document{ObjectID: "a"})
+		assert.ErrorIs(t, b.Flush(ctx), context.Canceled)
+		assert.Empty(t, idx.batches)
+	})
+}
@@ -0,0 +1,433 @@
+// Package providertest provides conformance tests for search.Provider
+// implementations, so every search backend (Algolia, Meilisearch, Bleve, and
+// future ones) is verified against the same indexing, filtering, faceting,
+// sorting, pagination, and delete semantics.
+//
+// Adapters run the tests from their own test files:
+//
+//	func TestProviderContract(t *testing.T) {
+//		providertest.Run(t, providertest.Config{
+//			New: func(t *testing.T) search.Provider {
+//				adapter, err := NewAdapter(&Config{IndexPath: t.TempDir()})
+//				require.NoError(t, err)
+//				return adapter
+//			},
+//		})
+//	}
+//
+// The tests run against both the document and the draft index, and clear the
+// index before each test, so they must not run against indexes with data that
+// matters.
+package providertest
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/pkg/search"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test names, for Config.Skip.
+const (
+	TestIndex        = "Index"
+	TestFullText     = "FullText"
+	TestHitFields    = "HitFields"
+	TestBatch        = "Batch"
+	TestFilters      = "Filters"
+	TestFilterGroups = "FilterGroups"
+	TestFacets       = "Facets"
+	TestSorting      = "Sorting"
+	TestPagination   = "Pagination"
+	TestDelete       = "Delete"
+)
+
+// Config configures the conformance tests for a provider.
+type Config struct {
+	// New returns the provider to test. It's called once per test.
+	New func(t *testing.T) search.Provider
+
+	// Skip maps the names of tests that don't apply to the provider, or that
+	// cover known gaps in it, to the reason they're skipped.
+	Skip map[string]string
+}
+
+// index is the interface shared by document and draft indexes.
+type index interface {
+	Index(ctx context.Context, doc *search.Document) error
+	IndexBatch(ctx context.Context, docs []*search.Document) error
+	Delete(ctx context.Context, docID string) error
+	DeleteBatch(ctx context.Context, docIDs []string) error
+	Search(ctx context.Context, query *search.SearchQuery) (*search.SearchResult, error)
+	GetObject(ctx context.Context, docID string) (*search.Document, error)
+	GetFacets(ctx context.Context, facetNames []string) (*search.Facets, error)
+	Clear(ctx context.Context) error
+}
+
+// Run runs the conformance tests against the document and draft indexes of
+// the provider returned by cfg.New.
+func Run(t *testing.T, cfg Config) {
+	indexes := []struct {
+		name string
+		get  func(search.Provider) index
+	}{
+		{"Documents", func(p search.Provider) index { return p.DocumentIndex() }},
+		{"Drafts", func(p search.Provider) index { return p.DraftIndex() }},
+	}
+	tests := []struct {
+		name string
+		fn   func(*testing.T, *suite)
+	}{
+		{TestIndex, testIndex},
+		{TestFullText, testFullText},
+		{TestHitFields, testHitFields},
+		{TestBatch, testBatch},
+		{TestFilters, testFilters},
+		{TestFilterGroups, testFilterGroups},
+		{TestFacets, testFacets},
+		{TestSorting, testSorting},
+		{TestPagination, testPagination},
+		{TestDelete, testDelete},
+	}
+
+	for _, idx := range indexes {
+		t.Run(idx.name, func(t *testing.T) {
+			for _, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					if reason, ok := cfg.Skip[tt.name]; ok {
+						t.Skip(reason)
+					}
+					s := &suite{
+						ctx: context.Background(),
+						idx: idx.get(cfg.New(t)),
+					}
+					require.NoError(t, s.idx.Clear(s.ctx), "Clear")
+					tt.fn(t, s)
+				})
+			}
+		})
+	}
+}
+
+// suite is the state of a conformance test.
+type suite struct {
+	ctx context.Context
+	idx index
+}
+
+// documents returns the documents the tests index:
+//
+//	ID     Title         Product    Type  Status     Owner  Modified
+//	doc-1  Kafka alpha   Terraform  RFC   Approved   alice  100
+//	doc-2  Kafka beta    Terraform  PRD   In-Review  bob    300
+//	doc-3  Vault gamma   Vault      RFC   Approved   alice  200
+//	doc-4  Consul delta  Consul     RFC   WIP        carol  400
+func documents() []*search.Document {
+	doc := func(id, title, product, docType, status, owner string, modified int64) *search.Document {
+		return &search.Document{
+			ObjectID:     id,
+			DocID:        id,
+			Title:        title,
+			DocNumber:    product[:3] + "-" + id[len(id)-1:],
+			DocType:      docType,
+			Product:      product,
+			Status:       status,
+			Owners:       []string{owner + "@example.com"},
+			Summary:      "Summary of " + title,
+			CreatedTime:  modified - 50,
+			ModifiedTime: modified,
+		}
+	}
+	return []*search.Document{
+		doc("doc-1", "Kafka alpha", "Terraform", "RFC", "Approved", "alice", 100),
+		doc("doc-2", "Kafka beta", "Terraform", "PRD", "In-Review", "bob", 300),
+		doc("doc-3", "Vault gamma", "Vault", "RFC", "Approved", "alice", 200),
+		doc("doc-4", "Consul delta", "Consul", "RFC", "WIP", "carol", 400),
+	}
+}
+
+// indexAll indexes all test documents.
+func (s *suite) indexAll(t *testing.T) {
+	t.Helper()
+	require.NoError(t, s.idx.IndexBatch(s.ctx, documents()), "IndexBatch")
+}
+
+// search runs a query, returning up to 10 hits by default.
+func (s *suite) search(t *testing.T, q *search.SearchQuery) *search.SearchResult {
+	t.Helper()
+
+	if q.PerPage == 0 {
+		q.PerPage = 10
+	}
+	res, err := s.idx.Search(s.ctx, q)
+	require.NoError(t, err, "Search")
+	require.NotNil(t, res)
+	return res
+}
+
+// sortedIDs returns the sorted object IDs of the hits of a query.
+func (s *suite) sortedIDs(t *testing.T, q *search.SearchQuery) []string {
+	t.Helper()
+
+	ids := hitIDs(s.search(t, q))
+	sort.Strings(ids)
+	return ids
+}
+
+// hitIDs returns the object IDs of search hits in order.
+func hitIDs(res *search.SearchResult) []string {
+	ids := []string{}
+	for _, hit := range res.Hits {
+		ids = append(ids, hit.ObjectID)
+	}
+	return ids
+}
+
+func testIndex(t *testing.T, s *suite) {
+	doc := documents()[0]
+	require.NoError(t, s.idx.Index(s.ctx, doc))
+
+	got, err := s.idx.GetObject(s.ctx, doc.ObjectID)
+	require.NoError(t, err)
+	assert.Equal(t, doc.ObjectID, got.ObjectID)
+	assert.Equal(t, doc.Title, got.Title)
+	assert.Equal(t, doc.Product, got.Product)
+	assert.Equal(t, doc.Owners, got.Owners)
+	assert.Equal(t, doc.ModifiedTime, got.ModifiedTime)
+
+	// Indexing a document again replaces it.
+	doc.Title = "Kafka alpha v2"
+	require.NoError(t, s.idx.Index(s.ctx, doc))
+	got, err = s.idx.GetObject(s.ctx, doc.ObjectID)
+	require.NoError(t, err)
+	assert.Equal(t, "Kafka alpha v2", got.Title)
+	assert.Equal(t, 1, s.search(t, &search.SearchQuery{}).TotalHits,
+		"reindexed documents aren't duplicated")
+
+	_, err = s.idx.GetObject(s.ctx, "missing")
+	assert.ErrorIs(t, err, search.ErrNotFound)
+}
+
+func testFullText(t *testing.T, s *suite) {
+	s.indexAll(t)
+
+	assert.Equal(t, []string{"doc-1", "doc-2"},
+		s.sortedIDs(t, &search.SearchQuery{Query: "kafka"}))
+	assert.Equal(t, []string{"doc-3"},
+		s.sortedIDs(t, &search.SearchQuery{Query: "gamma"}))
+
+	res := s.search(t, &search.SearchQuery{Query: "nonexistentterm"})
+	assert.Empty(t, res.Hits)
+	assert.Equal(t, 0, res.TotalHits)
+
+	assert.Equal(t, 4, s.search(t, &search.SearchQuery{}).TotalHits,
+		"empty queries match all documents")
+}
+
+func testHitFields(t *testing.T, s *suite) {
+	s.indexAll(t)
+	want := documents()[2]
+
+	res := s.search(t, &search.SearchQuery{Query: "gamma"})
+	require.Len(t, res.Hits, 1)
+	hit := res.Hits[0]
+	assert.Equal(t, want.ObjectID, hit.ObjectID)
+	assert.Equal(t, want.Title, hit.Title)
+	assert.Equal(t, want.DocNumber, hit.DocNumber)
+	assert.Equal(t, want.DocType, hit.DocType)
+	assert.Equal(t, want.Product, hit.Product)
+	assert.Equal(t, want.Status, hit.Status)
+	assert.Equal(t, want.Owners, hit.Owners)
+	assert.Equal(t, want.ModifiedTime, hit.ModifiedTime)
+}
+
+func testBatch(t *testing.T, s *suite) {
+	s.indexAll(t)
+	assert.Equal(t, 4, s.search(t, &search.SearchQuery{}).TotalHits)
+
+	require.NoError(t, s.idx.DeleteBatch(s.ctx, []string{"doc-1", "doc-2"}))
+	assert.Equal(t, []string{"doc-3", "doc-4"},
+		s.sortedIDs(t, &search.SearchQuery{}))
+
+	// Batches replace indexed documents.
+	docs := documents()
+	docs[2].Status = "Obsolete"
+	require.NoError(t, s.idx.IndexBatch(s.ctx, docs[2:]))
+	assert.Equal(t, 2, s.search(t, &search.SearchQuery{}).TotalHits)
+	assert.Equal(t, []string{"doc-3"}, s.sortedIDs(t, &search.SearchQuery{
+		Filters: map[string][]string{"status": {"Obsolete"}},
+	}))
+}
+
+func testFilters(t *testing.T, s *suite) {
+	s.indexAll(t)
+
+	tests := []struct {
+		name    string
+		filters map[string][]string
+		want    []string
+	}{
+		{
+			name:    "one value",
+			filters: map[string][]string{"product": {"Terraform"}},
+			want:    []string{"doc-1", "doc-2"},
+		},
+		{
+			name:    "values of a field match any value",
+			filters: map[string][]string{"product": {"Terraform", "Vault"}},
+			want:    []string{"doc-1", "doc-2", "doc-3"},
+		},
+		{
+			name: "fields match all fields",
+			filters: map[string][]string{
+				"product": {"Terraform"},
+				"docType": {"RFC"},
+			},
+			want: []string{"doc-1"},
+		},
+		{
+			name:    "values with punctuation",
+			filters: map[string][]string{"status": {"In-Review"}},
+			want:    []string{"doc-2"},
+		},
+		{
+			name:    "array fields",
+			filters: map[string][]string{"owners": {"alice@example.com"}},
+			want:    []string{"doc-1", "doc-3"},
+		},
+		{
+			name:    "no matches",
+			filters: map[string][]string{"product": {"Nomad"}},
+			want:    []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, s.sortedIDs(t, &search.SearchQuery{
+				Filters: tt.filters,
+			}))
+		})
+	}
+
+	assert.Equal(t, []string{"doc-1"}, s.sortedIDs(t, &search.SearchQuery{
+		Query:   "kafka",
+		Filters: map[string][]string{"docType": {"RFC"}},
+	}), "filters apply to text queries")
+}
+
+func testFilterGroups(t *testing.T, s *suite) {
+	s.indexAll(t)
+
+	assert.Equal(t, []string{"doc-2", "doc-4"}, s.sortedIDs(t, &search.SearchQuery{
+		FilterGroups: []search.FilterGroup{{
+			Operator: search.FilterOperatorOR,
+			Filters:  []string{"owners:bob@example.com", "owners:carol@example.com"},
+		}},
+	}))
+
+	assert.Equal(t, []string{"doc-2"}, s.sortedIDs(t, &search.SearchQuery{
+		Filters: map[string][]string{"product": {"Terraform"}},
+		FilterGroups: []search.FilterGroup{{
+			Operator: search.FilterOperatorOR,
+			Filters:  []string{"owners:bob@example.com", "owners:carol@example.com"},
+		}},
+	}), "filter groups and filters must both match")
+}
+
+func testFacets(t *testing.T, s *suite) {
+	s.indexAll(t)
+	facetNames := []string{"product", "docType", "status", "owners"}
+
+	res := s.search(t, &search.SearchQuery{Facets: facetNames})
+	require.NotNil(t, res.Facets)
+	assert.Equal(t, map[string]int{"Terraform": 2, "Vault": 1, "Consul": 1},
+		res.Facets.Products)
+	assert.Equal(t, map[string]int{"RFC": 3, "PRD": 1}, res.Facets.DocTypes)
+	assert.Equal(t, map[string]int{"Approved": 2, "In-Review": 1, "WIP": 1},
+		res.Facets.Statuses)
+	assert.Equal(t, map[string]int{
+		"alice@example.com": 2,
+		"bob@example.com":   1,
+		"carol@example.com": 1,
+	}, res.Facets.Owners)
+
+	res = s.search(t, &search.SearchQuery{
+		Filters: map[string][]string{"product": {"Terraform"}},
+		Facets:  facetNames,
+	})
+	require.NotNil(t, res.Facets)
+	assert.Equal(t, map[string]int{"RFC": 1, "PRD": 1}, res.Facets.DocTypes,
+		"facets count the documents that match filters")
+
+	facets, err := s.idx.GetFacets(s.ctx, []string{"product"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"Terraform": 2, "Vault": 1, "Consul": 1},
+		facets.Products)
+}
+
+func testSorting(t *testing.T, s *suite) {
+	s.indexAll(t)
+
+	assert.Equal(t, []string{"doc-4", "doc-2", "doc-3", "doc-1"},
+		hitIDs(s.search(t, &search.SearchQuery{
+			SortBy:    "modifiedTime",
+			SortOrder: "desc",
+		})))
+	assert.Equal(t, []string{"doc-1", "doc-3", "doc-2", "doc-4"},
+		hitIDs(s.search(t, &search.SearchQuery{
+			SortBy:    "modifiedTime",
+			SortOrder: "asc",
+		})))
+	assert.Equal(t, []string{"doc-3", "doc-1"},
+		hitIDs(s.search(t, &search.SearchQuery{
+			Filters:   map[string][]string{"owners": {"alice@example.com"}},
+			SortBy:    "createdTime",
+			SortOrder: "desc",
+		})), "sorting applies to filtered results")
+}
+
+func testPagination(t *testing.T, s *suite) {
+	s.indexAll(t)
+
+	// Pages are numbered from 0.
+	first := s.search(t, &search.SearchQuery{PerPage: 3, Page: 0})
+	assert.Len(t, first.Hits, 3)
+	assert.Equal(t, 4, first.TotalHits)
+	assert.Equal(t, 2, first.TotalPages)
+	assert.Equal(t, 0, first.Page)
+	assert.Equal(t, 3, first.PerPage)
+
+	second := s.search(t, &search.SearchQuery{PerPage: 3, Page: 1})
+	assert.Len(t, second.Hits, 1)
+	assert.Equal(t, 4, second.TotalHits)
+	assert.Equal(t, 1, second.Page)
+
+	ids := append(hitIDs(first), hitIDs(second)...)
+	sort.Strings(ids)
+	assert.Equal(t, []string{"doc-1", "doc-2", "doc-3", "doc-4"}, ids,
+		"pages don't overlap")
+
+	past := s.search(t, &search.SearchQuery{PerPage: 3, Page: 2})
+	assert.Empty(t, past.Hits)
+	assert.Equal(t, 4, past.TotalHits)
+}
+
+func testDelete(t *testing.T, s *suite) {
+	s.indexAll(t)
+
+	require.NoError(t, s.idx.Delete(s.ctx, "doc-1"))
+	_, err := s.idx.GetObject(s.ctx, "doc-1")
+	assert.ErrorIs(t, err, search.ErrNotFound)
+	assert.Equal(t, []string{"doc-2", "doc-3", "doc-4"},
+		s.sortedIDs(t, &search.SearchQuery{}))
+
+	assert.NoError(t, s.idx.Delete(s.ctx, "missing"),
+		"deleting a missing document isn't an error")
+
+	require.NoError(t, s.idx.Clear(s.ctx))
+	res := s.search(t, &search.SearchQuery{})
+	assert.Empty(t, res.Hits)
+	assert.Equal(t, 0, res.TotalHits)
+}
@@ -0,0 +1,147 @@
+package search
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"time"
+)
+
+// Indexes that rankings can be configured for.
+const (
+	RankingIndexDocs     = "docs"
+	RankingIndexDrafts   = "drafts"
+	RankingIndexProjects = "projects"
+)
+
+// Ranking tunes how the results of an index are ranked. The zero value keeps
+// the provider's default ranking. Providers apply rankings as closely as their
+// relevance models allow:
+//   - Bleve boosts matches in each attribute by its weight, boosts exact
+//     (phrase) matches of the query, and multiplies scores by RecencyFactor.
+//   - Algolia and Meilisearch rank by rules instead of scores, so attributes
+//     are ordered by weight, a boost above 1 ranks exact matches ahead of
+//     typo, proximity, and attribute rules, and recency breaks ties in favor
+//     of recently modified documents.
+type Ranking struct {
+	// AttributeWeights are the relative weights of matches in searchable
+	// attributes, keyed by attribute (e.g., {"title": 10, "content": 1}).
+	// Attributes with a weight of 0 aren't searched.
+	AttributeWeights map[string]float64
+
+	// RecencyDecay is the half-life of the relevance of documents: the score
+	// of a document modified RecencyDecay ago is halved. Zero disables decay.
+	RecencyDecay time.Duration
+
+	// ExactMatchBoost boosts the relevance of results that match the query
+	// exactly, as a phrase. Zero is the same as 1 (no boost).
+	ExactMatchBoost float64
+}
+
+// Rankings are the rankings of indexes, keyed by RankingIndexDocs,
+// RankingIndexDrafts, or RankingIndexProjects.
+type Rankings map[string]Ranking
+
+// For returns the ranking of index, or the zero ranking if there isn't one.
+func (r Rankings) For(index string) Ranking {
+	return r[index]
+}
+
+// Validate validates the ranking.
+func (r Ranking) Validate() error {
+	for attr, w := range r.AttributeWeights {
+		if attr == "" {
+			return fmt.Errorf("attribute_weights must not have empty attributes")
+		}
+		if w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+			return fmt.Errorf(
+				"attribute_weights[%q] must be a non-negative number", attr)
+		}
+	}
+	if r.RecencyDecay < 0 {
+		return fmt.Errorf("recency_decay must not be negative")
+	}
+	if r.ExactMatchBoost < 0 || math.IsNaN(r.ExactMatchBoost) ||
+		math.IsInf(r.ExactMatchBoost, 0) {
+		return fmt.Errorf("exact_match_boost must be a non-negative number")
+	}
+	return nil
+}
+
+// AttributeGroups returns the searchable attributes in groups of equal weight,
+// from the highest weight to the lowest. Weighted attributes come first,
+// followed by the remaining defaults, in order and in separate groups.
+// Attributes with a weight of 0 are left out.
+func (r Ranking) AttributeGroups(defaults []string) [][]string {
+	var weighted []string
+	for attr, w := range r.AttributeWeights {
+		if w > 0 {
+			weighted = append(weighted, attr)
+		}
+	}
+	sort.Slice(weighted, func(i, j int) bool {
+		wi, wj := r.AttributeWeights[weighted[i]], r.AttributeWeights[weighted[j]]
+		if wi != wj {
+			return wi > wj
+		}
+		// Keep the default order for attributes of equal weight.
+		pi, pj := slices.Index(defaults, weighted[i]), slices.Index(defaults, weighted[j])
+		if pi != pj {
+			return uint(pi) < uint(pj) // Unknown (-1) attributes go last.
+		}
+		return weighted[i] < weighted[j]
+	})
+
+	var groups [][]string
+	for i, attr := range weighted {
+		if i > 0 && r.AttributeWeights[weighted[i-1]] == r.AttributeWeights[attr] {
+			groups[len(groups)-1] = append(groups[len(groups)-1], attr)
+			continue
+		}
+		groups = append(groups, []string{attr})
+	}
+	for _, attr := range defaults {
+		if _, ok := r.AttributeWeights[attr]; !ok {
+			groups = append(groups, []string{attr})
+		}
+	}
+	return groups
+}
+
+// SearchableAttributes returns the searchable attributes from the highest
+// weight to the lowest (see AttributeGroups).
+func (r Ranking) SearchableAttributes(defaults []string) []string {
+	var attrs []string
+	for _, g := range r.AttributeGroups(defaults) {
+		attrs = append(attrs, g...)
+	}
+	return attrs
+}
+
+// Weight returns the weight of matches in attr, which is 1 for attributes
+// without a weight.
+func (r Ranking) Weight(attr string) float64 {
+	if w, ok := r.AttributeWeights[attr]; ok {
+		return w
+	}
+	return 1
+}
+
+// Boost returns the boost of exact matches.
+func (r Ranking) Boost() float64 {
+	if r.ExactMatchBoost == 0 {
+		return 1
+	}
+	return r.ExactMatchBoost
+}
+
+// RecencyFactor returns the factor (between 0 and 1) that the relevance of a
+// document modified at modified is multiplied by at now. It's 1 if recency
+// decay is disabled or the modified time is unknown or in the future.
+func (r Ranking) RecencyFactor(modified, now time.Time) float64 {
+	if r.RecencyDecay <= 0 || modified.IsZero() || !modified.Before(now) {
+		return 1
+	}
+	return math.Exp2(-float64(now.Sub(modified)) / float64(r.RecencyDecay))
+}
@@ -0,0 +1,67 @@
+package search
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRankingAttributeGroups(t *testing.T) {
+	defaults := []string{"title", "docNumber", "summary", "content"}
+
+	assert.Equal(t, [][]string{{"title"}, {"docNumber"}, {"summary"}, {"content"}},
+		Ranking{}.AttributeGroups(defaults))
+
+	r := Ranking{AttributeWeights: map[string]float64{
+		"content": 1,
+		"summary": 5,
+		"title":   5,
+		"tags":    2,
+		"owners":  0,
+	}}
+	assert.Equal(t,
+		[][]string{{"title", "summary"}, {"tags"}, {"content"}, {"docNumber"}},
+		r.AttributeGroups(defaults))
+	assert.Equal(t,
+		[]string{"title", "summary", "tags", "content", "docNumber"},
+		r.SearchableAttributes(defaults))
+	assert.Equal(t, 5.0, r.Weight("title"))
+	assert.Equal(t, 1.0, r.Weight("docNumber"))
+}
+
+func TestRankingRecencyFactor(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	r := Ranking{RecencyDecay: 30 * 24 * time.Hour}
+
+	assert.Equal(t, 1.0, r.RecencyFactor(now, now))
+	assert.InDelta(t, 0.5, r.RecencyFactor(now.Add(-30*24*time.Hour), now), 1e-9)
+	assert.InDelta(t, 0.25, r.RecencyFactor(now.Add(-60*24*time.Hour), now), 1e-9)
+	assert.Equal(t, 1.0, r.RecencyFactor(time.Time{}, now))
+	assert.Equal(t, 1.0, r.RecencyFactor(now.Add(time.Hour), now))
+	assert.Equal(t, 1.0, Ranking{}.RecencyFactor(now.Add(-time.Hour), now))
+}
+
+func TestRankingValidate(t *testing.T) {
+	assert.NoError(t, Ranking{}.Validate())
+	assert.NoError(t, Ranking{
+		AttributeWeights: map[string]float64{"title": 10, "content": 0},
+		RecencyDecay:     time.Hour,
+		ExactMatchBoost:  2,
+	}.Validate())
+
+	for name, r := range map[string]Ranking{
+		"negative weight": {AttributeWeights: map[string]float64{"title": -1}},
+		"NaN weight":      {AttributeWeights: map[string]float64{"title": math.NaN()}},
+		"empty attribute": {AttributeWeights: map[string]float64{"": 1}},
+		"negative decay":  {RecencyDecay: -time.Hour},
+		"negative boost":  {ExactMatchBoost: -1},
+		"infinite boost":  {ExactMatchBoost: math.Inf(1)},
+	} {
+		assert.Error(t, r.Validate(), name)
+	}
+
+	assert.Equal(t, 1.0, Ranking{}.Boost())
+	assert.Equal(t, Ranking{}, Rankings(nil).For(RankingIndexDocs))
+}
@@ -136,6 +136,31 @@ type Document struct {
 	ModifiedTime int64                  `json:"modifiedTime"`
 	CustomFields map[string]interface{} `json:"customFields,omitempty"`
 
+	// Tenant is the name of the tenant the document belongs to. It's empty
+	// for deployments without tenants.
+	Tenant string `json:"tenant,omitempty"`
+
+	// ShareableAsDraft is true if the draft is shared with everyone.
+	ShareableAsDraft bool `json:"shareableAsDraft,omitempty"`
+
+	// Viewers are the users who can find the draft in search results: its
+	// owners and contributors, and AllViewers if it is shared with everyone.
+	// They are set by the provider returned by WithVisibility.
+	Viewers []string `json:"viewers,omitempty"`
+
+	// FreshnessScore (0-100) estimates how current the document is. It is
+	// set by the freshness job and can be used as a sort option.
+	FreshnessScore int `json:"freshnessScore"`
+
+	// Freshness is the freshness bucket ("fresh", "aging", "stale") for
+	// faceting and filtering.
+	Freshness string `json:"freshness,omitempty"`
+
+	// ReviewSLA is the review SLA status ("on_track", "at_risk", "breached")
+	// of documents in review of types with a review SLA, for faceting and
+	// filtering. It is set by the review SLA job.
+	ReviewSLA string `json:"reviewSla,omitempty"`
+
 	// Timestamps for internal use
 	IndexedAt time.Time `json:"-"`
 }
@@ -199,4 +224,37 @@ type Facets struct {
 	DocTypes map[string]int `json:"docType"`
 	Statuses map[string]int `json:"status"`
 	Owners   map[string]int `json:"owners"`
+
+	// Freshness counts documents by freshness bucket.
+	Freshness map[string]int `json:"freshness,omitempty"`
+
+	// ReviewSLA counts documents in review by review SLA status.
+	ReviewSLA map[string]int `json:"reviewSla,omitempty"`
+}
+
+// DocumentUpdater is implemented by document and draft indexes that can update
+// some fields of an indexed document without replacing it.
+type DocumentUpdater interface {
+	// UpdateFields sets fields (keyed by JSON name) on an indexed document.
+	// Documents that are not indexed are not created.
+	UpdateFields(ctx context.Context, docID string, fields map[string]any) error
+}
+
+// Vocabulary contains the synonyms and stop words of searches.
+type Vocabulary struct {
+	// Synonyms are sets of terms that are equivalent in searches (e.g., "VM"
+	// and "virtual machine").
+	Synonyms [][]string
+
+	// StopWords are words that searches ignore.
+	StopWords []string
+}
+
+// VocabularyUpdater is implemented by providers whose synonyms and stop words
+// can be managed.
+type VocabularyUpdater interface {
+	// UpdateVocabulary replaces the synonyms and stop words of the document,
+	// draft, and project indexes with v. It returns true if the indexes must
+	// be rebuilt for all of v to apply.
+	UpdateVocabulary(ctx context.Context, v Vocabulary) (reindex bool, err error)
 }
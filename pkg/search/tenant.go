@@ -0,0 +1,146 @@
+package search
+
+import (
+	"context"
+	"maps"
+
+	"github.com/hashicorp-forge/hermes/pkg/tenant"
+)
+
+// TenantFilter is the filter and document field that holds the name of the
+// tenant a document belongs to.
+const TenantFilter = "tenant"
+
+// WithTenants returns a provider that partitions the document and draft
+// indexes of p by the tenant in the context of each call (see package tenant):
+//   - Indexed documents are tagged with the tenant, unless they already are.
+//   - Searches and facets only include the tenant's documents.
+//   - GetObject returns ErrNotFound for documents of other tenants. Documents
+//     without a tenant belong to the default tenant.
+//
+// Calls without a tenant in their context (e.g., from background jobs) aren't
+// partitioned. Delete and Clear aren't partitioned either. Documents indexed
+// before tenants were configured must be reindexed to be found.
+func WithTenants(p Provider) Provider {
+	return &tenantProvider{Provider: p}
+}
+
+type tenantProvider struct {
+	Provider
+}
+
+func (p *tenantProvider) DocumentIndex() DocumentIndex {
+	return newTenantIndex(p.Provider.DocumentIndex())
+}
+
+func (p *tenantProvider) DraftIndex() DraftIndex {
+	return newTenantIndex(p.Provider.DraftIndex())
+}
+
+// newTenantIndex returns idx partitioned by tenant. The returned index
+// implements DocumentUpdater if idx does.
+func newTenantIndex(idx DocumentIndex) DocumentIndex {
+	ti := &tenantIndex{DocumentIndex: idx}
+	if u, ok := idx.(DocumentUpdater); ok {
+		return &tenantUpdaterIndex{tenantIndex: ti, updater: u}
+	}
+	return ti
+}
+
+// tenantIndex is a document or draft index partitioned by tenant. Document
+// and draft indexes have the same methods.
+type tenantIndex struct {
+	DocumentIndex
+}
+
+func (i *tenantIndex) Index(ctx context.Context, doc *Document) error {
+	return i.DocumentIndex.Index(ctx, withTenant(ctx, doc))
+}
+
+func (i *tenantIndex) IndexBatch(ctx context.Context, docs []*Document) error {
+	tagged := make([]*Document, len(docs))
+	for j, doc := range docs {
+		tagged[j] = withTenant(ctx, doc)
+	}
+	return i.DocumentIndex.IndexBatch(ctx, tagged)
+}
+
+func (i *tenantIndex) Search(ctx context.Context, query *SearchQuery) (*SearchResult, error) {
+	t, ok := tenant.FromContext(ctx)
+	if !ok {
+		return i.DocumentIndex.Search(ctx, query)
+	}
+
+	q := *query
+	q.Filters = maps.Clone(query.Filters)
+	if q.Filters == nil {
+		q.Filters = map[string][]string{}
+	}
+	q.Filters[TenantFilter] = []string{t.Name}
+	return i.DocumentIndex.Search(ctx, &q)
+}
+
+func (i *tenantIndex) GetObject(ctx context.Context, docID string) (*Document, error) {
+	doc, err := i.DocumentIndex.GetObject(ctx, docID)
+	if err != nil {
+		return nil, err
+	}
+	if t, ok := tenant.FromContext(ctx); ok && tenantName(doc) != t.Name {
+		return nil, &Error{Op: "GetObject", Err: ErrNotFound, Msg: docID}
+	}
+	return doc, nil
+}
+
+// GetFacets searches the tenant's documents for the facets, so the counts
+// don't include the documents of other tenants.
+func (i *tenantIndex) GetFacets(ctx context.Context, facetNames []string) (*Facets, error) {
+	if _, ok := tenant.FromContext(ctx); !ok {
+		return i.DocumentIndex.GetFacets(ctx, facetNames)
+	}
+
+	res, err := i.Search(ctx, &SearchQuery{Facets: facetNames, PerPage: 1})
+	if err != nil {
+		return nil, err
+	}
+	if res.Facets == nil {
+		return &Facets{}, nil
+	}
+	return res.Facets, nil
+}
+
+// withTenant returns doc tagged with the tenant in ctx. doc isn't modified.
+func withTenant(ctx context.Context, doc *Document) *Document {
+	t, ok := tenant.FromContext(ctx)
+	if !ok || doc.Tenant != "" {
+		return doc
+	}
+	tagged := *doc
+	tagged.Tenant = t.Name
+	return &tagged
+}
+
+// tenantName returns the name of the tenant of doc. Documents without a
+// tenant belong to the default tenant.
+func tenantName(doc *Document) string {
+	if doc.Tenant == "" {
+		return tenant.DefaultName
+	}
+	return doc.Tenant
+}
+
+// tenantUpdaterIndex is a tenantIndex of an index that implements
+// DocumentUpdater.
+type tenantUpdaterIndex struct {
+	*tenantIndex
+	updater DocumentUpdater
+}
+
+// UpdateFields updates the fields of a document of the tenant in ctx.
+func (i *tenantUpdaterIndex) UpdateFields(ctx context.Context, docID string, fields map[string]any) error {
+	if _, ok := tenant.FromContext(ctx); ok {
+		if _, err := i.GetObject(ctx, docID); err != nil {
+			return err
+		}
+	}
+	return i.updater.UpdateFields(ctx, docID, fields)
+}
@@ -0,0 +1,92 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/pkg/tenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeIndex is an in-memory DocumentIndex that records the last search query.
+type fakeIndex struct {
+	DocumentIndex
+	docs      map[string]*Document
+	lastQuery *SearchQuery
+}
+
+func (f *fakeIndex) Index(ctx context.Context, doc *Document) error {
+	f.docs[doc.ObjectID] = doc
+	return nil
+}
+
+func (f *fakeIndex) Search(ctx context.Context, query *SearchQuery) (*SearchResult, error) {
+	f.lastQuery = query
+	return &SearchResult{Facets: &Facets{}}, nil
+}
+
+func (f *fakeIndex) GetObject(ctx context.Context, docID string) (*Document, error) {
+	doc, ok := f.docs[docID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return doc, nil
+}
+
+type fakeProvider struct {
+	Provider
+	idx *fakeIndex
+}
+
+func (p *fakeProvider) DocumentIndex() DocumentIndex { return p.idx }
+
+func TestWithTenants(t *testing.T) {
+	idx := &fakeIndex{docs: map[string]*Document{}}
+	p := WithTenants(&fakeProvider{idx: idx})
+
+	acme := tenant.NewContext(context.Background(), tenant.Tenant{ID: 1, Name: "acme"})
+	other := tenant.NewContext(context.Background(), tenant.Tenant{ID: 2, Name: "other"})
+	defaultCtx := tenant.NewContext(context.Background(), tenant.Default)
+
+	t.Run("Index tags documents with the tenant", func(t *testing.T) {
+		doc := &Document{ObjectID: "doc1"}
+		require.NoError(t, p.DocumentIndex().Index(acme, doc))
+		assert.Equal(t, "acme", idx.docs["doc1"].Tenant)
+		assert.Empty(t, doc.Tenant, "caller's document must not be modified")
+
+		require.NoError(t, p.DocumentIndex().Index(context.Background(),
+			&Document{ObjectID: "doc2"}))
+		assert.Empty(t, idx.docs["doc2"].Tenant)
+	})
+
+	t.Run("Search filters by tenant", func(t *testing.T) {
+		query := &SearchQuery{Filters: map[string][]string{"status": {"Approved"}}}
+		_, err := p.DocumentIndex().Search(acme, query)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"acme"}, idx.lastQuery.Filters[TenantFilter])
+		assert.Equal(t, []string{"Approved"}, idx.lastQuery.Filters["status"])
+		assert.NotContains(t, query.Filters, TenantFilter,
+			"caller's query must not be modified")
+
+		_, err = p.DocumentIndex().Search(context.Background(), &SearchQuery{})
+		require.NoError(t, err)
+		assert.NotContains(t, idx.lastQuery.Filters, TenantFilter)
+	})
+
+	t.Run("GetObject hides documents of other tenants", func(t *testing.T) {
+		doc, err := p.DocumentIndex().GetObject(acme, "doc1")
+		require.NoError(t, err)
+		assert.Equal(t, "doc1", doc.ObjectID)
+
+		_, err = p.DocumentIndex().GetObject(other, "doc1")
+		assert.True(t, errors.Is(err, ErrNotFound))
+
+		// Documents without a tenant belong to the default tenant.
+		_, err = p.DocumentIndex().GetObject(defaultCtx, "doc2")
+		assert.NoError(t, err)
+		_, err = p.DocumentIndex().GetObject(acme, "doc2")
+		assert.True(t, errors.Is(err, ErrNotFound))
+	})
+}
@@ -0,0 +1,194 @@
+package search
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+)
+
+// Draft visibility filter and field values.
+const (
+	// VisibilityFilter is the filter and draft field that holds the viewers of
+	// a draft.
+	VisibilityFilter = "viewers"
+
+	// AllViewers is the viewer of drafts that are shared with everyone.
+	AllViewers = "*"
+)
+
+// visibilityFields are the fields that the viewers of a draft are derived
+// from.
+var visibilityFields = []string{"owners", "contributors", "shareableAsDraft"}
+
+// WithVisibility returns a provider that limits the draft index of p to the
+// drafts the authenticated user in the context of each call (see package auth)
+// can view: drafts they own or contribute to, and drafts shared with everyone
+// (ShareableAsDraft). Visibility is enforced for all providers, instead of
+// relying on owners and contributors filters supplied by clients:
+//   - Indexed drafts are tagged with their viewers (see Document.Viewers).
+//   - Searches and facets only include drafts the user can view. Searches may
+//     filter viewers to the user or AllViewers to narrow them further.
+//   - GetObject returns ErrNotFound for drafts the user can't view.
+//   - UpdateFields retags drafts whose owners, contributors, or sharing
+//     settings change.
+//
+// Calls without an authenticated user in their context (e.g., from background
+// jobs) aren't limited. Drafts indexed before visibility was enforced must be
+// reindexed to be found.
+func WithVisibility(p Provider) Provider {
+	return &visibilityProvider{Provider: p}
+}
+
+type visibilityProvider struct {
+	Provider
+}
+
+func (p *visibilityProvider) DraftIndex() DraftIndex {
+	idx := p.Provider.DraftIndex()
+	vi := &visibilityIndex{DocumentIndex: idx}
+	if u, ok := idx.(DocumentUpdater); ok {
+		return &visibilityUpdaterIndex{visibilityIndex: vi, updater: u}
+	}
+	return vi
+}
+
+// visibilityIndex is a draft index limited to the drafts the authenticated user
+// can view.
+type visibilityIndex struct {
+	DocumentIndex
+}
+
+func (i *visibilityIndex) Index(ctx context.Context, doc *Document) error {
+	return i.DocumentIndex.Index(ctx, withViewers(doc))
+}
+
+func (i *visibilityIndex) IndexBatch(ctx context.Context, docs []*Document) error {
+	tagged := make([]*Document, len(docs))
+	for j, doc := range docs {
+		tagged[j] = withViewers(doc)
+	}
+	return i.DocumentIndex.IndexBatch(ctx, tagged)
+}
+
+func (i *visibilityIndex) Search(ctx context.Context, query *SearchQuery) (*SearchResult, error) {
+	userEmail, ok := pkgauth.GetUserEmail(ctx)
+	if !ok || userEmail == "" {
+		return i.DocumentIndex.Search(ctx, query)
+	}
+
+	// Queries may narrow the viewers further (e.g., to list only the user's
+	// own drafts), but never to viewers the user isn't one of.
+	allowed := []string{userEmail, AllViewers}
+	var v []string
+	for _, viewer := range query.Filters[VisibilityFilter] {
+		if slices.Contains(allowed, viewer) && !slices.Contains(v, viewer) {
+			v = append(v, viewer)
+		}
+	}
+	if len(v) == 0 {
+		v = allowed
+	}
+
+	q := *query
+	q.Filters = maps.Clone(query.Filters)
+	if q.Filters == nil {
+		q.Filters = map[string][]string{}
+	}
+	q.Filters[VisibilityFilter] = v
+	return i.DocumentIndex.Search(ctx, &q)
+}
+
+func (i *visibilityIndex) GetObject(ctx context.Context, docID string) (*Document, error) {
+	doc, err := i.DocumentIndex.GetObject(ctx, docID)
+	if err != nil {
+		return nil, err
+	}
+	userEmail, ok := pkgauth.GetUserEmail(ctx)
+	if !ok || userEmail == "" {
+		return doc, nil
+	}
+	if v := viewers(doc); !slices.Contains(v, userEmail) &&
+		!slices.Contains(v, AllViewers) {
+		return nil, &Error{Op: "GetObject", Err: ErrNotFound, Msg: docID}
+	}
+	return doc, nil
+}
+
+// GetFacets searches the drafts the user can view for the facets, so the
+// counts don't include other drafts.
+func (i *visibilityIndex) GetFacets(ctx context.Context, facetNames []string) (*Facets, error) {
+	if userEmail, ok := pkgauth.GetUserEmail(ctx); !ok || userEmail == "" {
+		return i.DocumentIndex.GetFacets(ctx, facetNames)
+	}
+
+	res, err := i.Search(ctx, &SearchQuery{Facets: facetNames, PerPage: 1})
+	if err != nil {
+		return nil, err
+	}
+	if res.Facets == nil {
+		return &Facets{}, nil
+	}
+	return res.Facets, nil
+}
+
+// withViewers returns doc tagged with its viewers. doc isn't modified.
+func withViewers(doc *Document) *Document {
+	tagged := *doc
+	tagged.Viewers = viewers(doc)
+	return &tagged
+}
+
+// viewers returns the viewers of doc: its owners and contributors, and
+// AllViewers if it is shared with everyone.
+func viewers(doc *Document) []string {
+	var v []string
+	for _, email := range slices.Concat(doc.Owners, doc.Contributors) {
+		if email != "" && !slices.Contains(v, email) {
+			v = append(v, email)
+		}
+	}
+	if doc.ShareableAsDraft {
+		v = append(v, AllViewers)
+	}
+	return v
+}
+
+// visibilityUpdaterIndex is a visibilityIndex of an index that implements
+// DocumentUpdater.
+type visibilityUpdaterIndex struct {
+	*visibilityIndex
+	updater DocumentUpdater
+}
+
+// UpdateFields updates the fields of a draft. If the fields change who can
+// view the draft, its viewers are updated too.
+func (i *visibilityUpdaterIndex) UpdateFields(ctx context.Context, docID string, fields map[string]any) error {
+	if !slices.ContainsFunc(visibilityFields, func(f string) bool {
+		_, ok := fields[f]
+		return ok
+	}) {
+		return i.updater.UpdateFields(ctx, docID, fields)
+	}
+
+	// Derive the viewers from the indexed draft with the fields applied.
+	doc, err := i.DocumentIndex.GetObject(ctx, docID)
+	if err != nil {
+		return err
+	}
+	updated := *doc
+	if v, ok := fields["owners"].([]string); ok {
+		updated.Owners = v
+	}
+	if v, ok := fields["contributors"].([]string); ok {
+		updated.Contributors = v
+	}
+	if v, ok := fields["shareableAsDraft"].(bool); ok {
+		updated.ShareableAsDraft = v
+	}
+
+	tagged := maps.Clone(fields)
+	tagged[VisibilityFilter] = viewers(&updated)
+	return i.updater.UpdateFields(ctx, docID, tagged)
+}
@@ -0,0 +1,129 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeUpdaterIndex is a fakeIndex that implements DocumentUpdater.
+type fakeUpdaterIndex struct {
+	*fakeIndex
+	lastFields map[string]any
+}
+
+func (f *fakeUpdaterIndex) UpdateFields(ctx context.Context, docID string, fields map[string]any) error {
+	f.lastFields = fields
+	return nil
+}
+
+type fakeDraftProvider struct {
+	Provider
+	idx DraftIndex
+}
+
+func (p *fakeDraftProvider) DraftIndex() DraftIndex { return p.idx }
+
+func TestWithVisibility(t *testing.T) {
+	idx := &fakeUpdaterIndex{fakeIndex: &fakeIndex{docs: map[string]*Document{}}}
+	p := WithVisibility(&fakeDraftProvider{idx: idx})
+
+	alice := context.WithValue(context.Background(),
+		pkgauth.UserEmailKey, "alice@example.com")
+	bob := context.WithValue(context.Background(),
+		pkgauth.UserEmailKey, "bob@example.com")
+
+	t.Run("Index tags drafts with their viewers", func(t *testing.T) {
+		doc := &Document{
+			ObjectID:     "draft1",
+			Owners:       []string{"alice@example.com"},
+			Contributors: []string{"carol@example.com", "alice@example.com"},
+		}
+		require.NoError(t, p.DraftIndex().Index(context.Background(), doc))
+		assert.Equal(t, []string{"alice@example.com", "carol@example.com"},
+			idx.docs["draft1"].Viewers)
+		assert.Empty(t, doc.Viewers, "caller's document must not be modified")
+
+		require.NoError(t, p.DraftIndex().Index(context.Background(), &Document{
+			ObjectID:         "draft2",
+			Owners:           []string{"alice@example.com"},
+			ShareableAsDraft: true,
+		}))
+		assert.Equal(t, []string{"alice@example.com", AllViewers},
+			idx.docs["draft2"].Viewers)
+	})
+
+	t.Run("Search filters by viewer", func(t *testing.T) {
+		query := &SearchQuery{Filters: map[string][]string{"status": {"WIP"}}}
+		_, err := p.DraftIndex().Search(bob, query)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"bob@example.com", AllViewers},
+			idx.lastQuery.Filters[VisibilityFilter])
+		assert.Equal(t, []string{"WIP"}, idx.lastQuery.Filters["status"])
+		assert.NotContains(t, query.Filters, VisibilityFilter,
+			"caller's query must not be modified")
+
+		// Queries can narrow the viewers to the user, but can't widen them to
+		// other users.
+		_, err = p.DraftIndex().Search(bob, &SearchQuery{
+			Filters: map[string][]string{
+				VisibilityFilter: {"bob@example.com"},
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"bob@example.com"},
+			idx.lastQuery.Filters[VisibilityFilter])
+
+		_, err = p.DraftIndex().Search(bob, &SearchQuery{
+			Filters: map[string][]string{
+				VisibilityFilter: {"alice@example.com"},
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"bob@example.com", AllViewers},
+			idx.lastQuery.Filters[VisibilityFilter])
+
+		// Calls without a user aren't filtered.
+		_, err = p.DraftIndex().Search(context.Background(), &SearchQuery{})
+		require.NoError(t, err)
+		assert.NotContains(t, idx.lastQuery.Filters, VisibilityFilter)
+	})
+
+	t.Run("GetObject hides drafts the user can't view", func(t *testing.T) {
+		_, err := p.DraftIndex().GetObject(alice, "draft1")
+		assert.NoError(t, err)
+		_, err = p.DraftIndex().GetObject(bob, "draft1")
+		assert.True(t, errors.Is(err, ErrNotFound))
+
+		// Shared drafts can be viewed by everyone.
+		_, err = p.DraftIndex().GetObject(bob, "draft2")
+		assert.NoError(t, err)
+
+		_, err = p.DraftIndex().GetObject(context.Background(), "draft1")
+		assert.NoError(t, err)
+	})
+
+	t.Run("UpdateFields retags drafts", func(t *testing.T) {
+		u, ok := p.DraftIndex().(DocumentUpdater)
+		require.True(t, ok)
+
+		fields := map[string]any{"shareableAsDraft": true}
+		require.NoError(t, u.UpdateFields(alice, "draft1", fields))
+		assert.Equal(t, map[string]any{
+			"shareableAsDraft": true,
+			VisibilityFilter: []string{
+				"alice@example.com", "carol@example.com", AllViewers,
+			},
+		}, idx.lastFields)
+		assert.NotContains(t, fields, VisibilityFilter,
+			"caller's fields must not be modified")
+
+		require.NoError(t, u.UpdateFields(alice, "draft1",
+			map[string]any{"freshness": "stale"}))
+		assert.Equal(t, map[string]any{"freshness": "stale"}, idx.lastFields)
+	})
+}
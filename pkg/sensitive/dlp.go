@@ -0,0 +1,111 @@
+package sensitive
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// DLPClient scans content with an external DLP API over HTTP.
+//
+// The content is sent as a JSON object ({"content": "..."}) in a POST request
+// to the API URL, which responds with the sensitive data it found:
+//
+//	{"findings": [{"rule": "...", "category": "pii", "line": 3, "match": "..."}]}
+type DLPClient struct {
+	url        string
+	token      string
+	httpClient *http.Client
+}
+
+// DLPConfig holds configuration for the DLP client.
+type DLPConfig struct {
+	URL     string        // API URL (required)
+	Token   string        // Bearer token (optional)
+	Timeout time.Duration // HTTP timeout (default: 30s)
+}
+
+// NewDLPClient creates a new DLP client.
+func NewDLPClient(cfg DLPConfig) (*DLPClient, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("DLP API URL is required")
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 30 * time.Second
+	}
+
+	return &DLPClient{
+		url:   cfg.URL,
+		token: cfg.Token,
+		httpClient: &http.Client{
+			Timeout: cfg.Timeout,
+		},
+	}, nil
+}
+
+type dlpRequest struct {
+	Content string `json:"content"`
+}
+
+type dlpResponse struct {
+	Findings []struct {
+		Rule     string   `json:"rule"`
+		Category Category `json:"category"`
+		Line     int      `json:"line"`
+		Match    string   `json:"match"`
+	} `json:"findings"`
+}
+
+// Scan implements External.
+func (c *DLPClient) Scan(ctx context.Context, content string) ([]Finding, error) {
+	reqJSON, err := json.Marshal(dlpRequest{Content: content})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.url, bytes.NewReader(reqJSON))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 10<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("DLP API error (%d): %s", resp.StatusCode, respBody)
+	}
+
+	var dlpResp dlpResponse
+	if err := json.Unmarshal(respBody, &dlpResp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	findings := make([]Finding, 0, len(dlpResp.Findings))
+	for _, f := range dlpResp.Findings {
+		if f.Category == "" {
+			f.Category = CategoryPII
+		}
+		findings = append(findings, Finding{
+			Rule:     f.Rule,
+			Category: f.Category,
+			Line:     f.Line,
+			Match:    f.Match,
+		})
+	}
+	return findings, nil
+}
@@ -0,0 +1,393 @@
+// Package sensitive detects credentials, tokens, and personal data in document
+// content with regular expression and entropy rules and, optionally, an
+// external data loss prevention (DLP) API.
+package sensitive
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Category is the kind of sensitive data a rule detects.
+type Category string
+
+const (
+	// CategoryCredential is credentials, tokens, and keys.
+	CategoryCredential Category = "credential"
+
+	// CategoryPII is personal data.
+	CategoryPII Category = "pii"
+)
+
+// Built-in rules.
+const (
+	RuleAWSAccessKey   = "aws_access_key"
+	RuleGitHubToken    = "github_token"
+	RuleSlackToken     = "slack_token"
+	RulePrivateKey     = "private_key"
+	RuleJWT            = "jwt"
+	RuleSecretAssigned = "secret_assignment"
+	RuleHighEntropy    = "high_entropy_string"
+	RuleUSSSN          = "us_ssn"
+	RuleCreditCard     = "credit_card"
+	RuleEmailAddress   = "email_address"
+)
+
+// Finding is sensitive data found in content.
+type Finding struct {
+	Rule     string
+	Category Category
+
+	// Line is the 1-based line of the finding.
+	Line int
+
+	// Match is the sensitive data. It must not be stored or logged; use
+	// Redacted instead.
+	Match string
+
+	// Source is what found the data: "regex", "entropy", or "dlp".
+	Source string
+}
+
+// Redacted returns the match with all but a few characters masked: the first
+// four of credentials, which identify their kind, and the last four of
+// personal data.
+func (f Finding) Redacted() string {
+	r := []rune(f.Match)
+	const keep = 4
+	if len(r) <= keep*2 {
+		return strings.Repeat("*", len(r))
+	}
+	if f.Category == CategoryPII {
+		return strings.Repeat("*", len(r)-keep) + string(r[len(r)-keep:])
+	}
+	return string(r[:keep]) + strings.Repeat("*", len(r)-keep)
+}
+
+// External is an external DLP API.
+type External interface {
+	// Scan returns the sensitive data found in content.
+	Scan(ctx context.Context, content string) ([]Finding, error)
+}
+
+// Event describes sensitive data found in a document, sent to its owner.
+type Event struct {
+	// DocumentID is the ID of the document in the workspace provider.
+	DocumentID   string
+	DocumentUUID string
+	Title        string
+	Owner        string
+
+	// Blocked is true if the document can't be published for review until the
+	// data is removed.
+	Blocked bool
+
+	// Findings are the data found, with their matches redacted.
+	Findings []Finding
+}
+
+// Notifier notifies document owners about sensitive data in their documents.
+type Notifier interface {
+	NotifySensitiveData(ctx context.Context, ev *Event) error
+}
+
+// Config configures the scanner.
+type Config struct {
+	// Rules are the built-in rules to run. DefaultRules are run if empty.
+	Rules []string
+
+	// Patterns are additional credential rules, keyed by rule name, with the
+	// regular expression that matches the credential.
+	Patterns map[string]string
+
+	// EntropyThreshold is the minimum Shannon entropy, in bits per character,
+	// of strings flagged by the high entropy rule. The default is 4.0.
+	EntropyThreshold float64
+
+	// MinEntropyLength is the minimum length of strings checked by the high
+	// entropy rule. The default is 32.
+	MinEntropyLength int
+}
+
+const (
+	defaultEntropyThreshold = 4.0
+	defaultMinEntropyLength = 32
+)
+
+type regexRule struct {
+	name     string
+	category Category
+	re       *regexp.Regexp
+
+	// group is the submatch that is the sensitive data, or 0 for the whole
+	// match.
+	group int
+
+	// valid, if set, filters out matches that aren't sensitive data.
+	valid func(string) bool
+}
+
+var builtinRules = []regexRule{
+	{
+		name: RuleAWSAccessKey, category: CategoryCredential,
+		re: regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`),
+	},
+	{
+		name: RuleGitHubToken, category: CategoryCredential,
+		re: regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36}|github_pat_[A-Za-z0-9_]{82})\b`),
+	},
+	{
+		name: RuleSlackToken, category: CategoryCredential,
+		re: regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}`),
+	},
+	{
+		name: RulePrivateKey, category: CategoryCredential,
+		re: regexp.MustCompile(`-----BEGIN (?:[A-Z]+ )?PRIVATE KEY(?: BLOCK)?-----`),
+	},
+	{
+		name: RuleJWT, category: CategoryCredential,
+		re: regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{10,}\.eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}`),
+	},
+	{
+		name: RuleSecretAssigned, category: CategoryCredential,
+		re: regexp.MustCompile(
+			`(?i)\b[\w-]*?(?:password|passwd|pwd|secret|api[_-]?key|access[_-]?key|` +
+				`(?:access|auth|bearer)[_-]?token|client[_-]?secret)\b` +
+				`["']?\s*[:=]\s*["']?([^\s"'<>]{8,})`),
+		group: 1,
+		// Skip placeholders, e.g. "password = <your password>" or
+		// "api_key: ${API_KEY}".
+		valid: func(s string) bool {
+			return !strings.ContainsAny(s, "${}*") &&
+				!strings.Contains(strings.ToLower(s), "example")
+		},
+	},
+	{
+		name: RuleUSSSN, category: CategoryPII,
+		re: regexp.MustCompile(`\b(\d{3})-(\d{2})-(\d{4})\b`),
+		valid: func(s string) bool {
+			area := s[:3]
+			return area != "000" && area != "666" && area[0] != '9' &&
+				s[4:6] != "00" && s[7:] != "0000"
+		},
+	},
+	{
+		name: RuleCreditCard, category: CategoryPII,
+		re:    regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`),
+		valid: luhn,
+	},
+	{
+		name: RuleEmailAddress, category: CategoryPII,
+		re: regexp.MustCompile(`\b[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}\b`),
+	},
+}
+
+// Rules are all built-in rules.
+var Rules = func() []string {
+	rules := []string{}
+	for _, r := range builtinRules {
+		rules = append(rules, r.name)
+	}
+	return append(rules, RuleHighEntropy)
+}()
+
+// DefaultRules are the built-in rules run by default. Email addresses aren't
+// detected by default because documents routinely name their authors and
+// reviewers.
+var DefaultRules = slices.DeleteFunc(slices.Clone(Rules), func(r string) bool {
+	return r == RuleEmailAddress
+})
+
+// Scanner finds sensitive data in content.
+type Scanner struct {
+	rules            []regexRule
+	entropy          bool
+	entropyThreshold float64
+	minEntropyLength int
+	external         External
+}
+
+// New creates a scanner. Content is also scanned by external if it isn't nil.
+func New(cfg Config, external External) (*Scanner, error) {
+	enabled := DefaultRules
+	if len(cfg.Rules) > 0 {
+		for _, r := range cfg.Rules {
+			if !slices.Contains(Rules, r) {
+				return nil, fmt.Errorf("unknown rule %q", r)
+			}
+		}
+		enabled = cfg.Rules
+	}
+
+	s := &Scanner{
+		entropy:          slices.Contains(enabled, RuleHighEntropy),
+		entropyThreshold: defaultEntropyThreshold,
+		minEntropyLength: defaultMinEntropyLength,
+		external:         external,
+	}
+	if cfg.EntropyThreshold > 0 {
+		s.entropyThreshold = cfg.EntropyThreshold
+	}
+	if cfg.MinEntropyLength > 0 {
+		s.minEntropyLength = cfg.MinEntropyLength
+	}
+	for _, r := range builtinRules {
+		if slices.Contains(enabled, r.name) {
+			s.rules = append(s.rules, r)
+		}
+	}
+
+	names := make([]string, 0, len(cfg.Patterns))
+	for name := range cfg.Patterns {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		if slices.Contains(Rules, name) {
+			return nil, fmt.Errorf("pattern %q has the name of a built-in rule", name)
+		}
+		re, err := regexp.Compile(cfg.Patterns[name])
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", name, err)
+		}
+		s.rules = append(s.rules, regexRule{
+			name: name, category: CategoryCredential, re: re,
+		})
+	}
+
+	return s, nil
+}
+
+var (
+	// tokenRE matches candidate strings for the high entropy rule.
+	tokenRE = regexp.MustCompile(`[A-Za-z0-9+/_=-]+`)
+
+	// linkRE matches URLs and Markdown link targets, which are skipped by the
+	// high entropy rule because they often contain long random IDs (e.g., of
+	// linked Google Docs).
+	linkRE = regexp.MustCompile("[a-z][a-z0-9+.-]*://[^\\s<>()\\[\\]\"'`]+|\\]\\([^)]*\\)")
+)
+
+// Scan returns the sensitive data found in content, ordered by line.
+func (s *Scanner) Scan(ctx context.Context, content string) ([]Finding, error) {
+	var findings []Finding
+	seen := make(map[string]bool)
+	add := func(f Finding) {
+		if key := f.Match; !seen[key] {
+			seen[key] = true
+			findings = append(findings, f)
+		}
+	}
+
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		for _, r := range s.rules {
+			for _, m := range r.re.FindAllStringSubmatch(line, -1) {
+				match := m[r.group]
+				if r.valid != nil && !r.valid(match) {
+					continue
+				}
+				add(Finding{
+					Rule: r.name, Category: r.category,
+					Line: i + 1, Match: match, Source: "regex",
+				})
+			}
+		}
+
+		if s.entropy {
+			for _, tok := range tokenRE.FindAllString(linkRE.ReplaceAllString(line, " "), -1) {
+				// Skip parts of data found by other rules, e.g. JWTs.
+				if s.isHighEntropy(tok) && !slices.ContainsFunc(findings, func(f Finding) bool {
+					return f.Line == i+1 && strings.Contains(f.Match, tok)
+				}) {
+					add(Finding{
+						Rule: RuleHighEntropy, Category: CategoryCredential,
+						Line: i + 1, Match: tok, Source: "entropy",
+					})
+				}
+			}
+		}
+	}
+
+	if s.external != nil {
+		ext, err := s.external.Scan(ctx, content)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning content with DLP API: %w", err)
+		}
+		for _, f := range ext {
+			f.Source = "dlp"
+			add(f)
+		}
+	}
+
+	slices.SortStableFunc(findings, func(a, b Finding) int {
+		return a.Line - b.Line
+	})
+	return findings, nil
+}
+
+// isHighEntropy reports whether tok looks like a random secret: it is long,
+// mixes upper and lower case letters and digits, and has high entropy.
+func (s *Scanner) isHighEntropy(tok string) bool {
+	if len(tok) < s.minEntropyLength {
+		return false
+	}
+	var upper, lower, digit bool
+	for _, c := range tok {
+		switch {
+		case c >= 'A' && c <= 'Z':
+			upper = true
+		case c >= 'a' && c <= 'z':
+			lower = true
+		case c >= '0' && c <= '9':
+			digit = true
+		}
+	}
+	return upper && lower && digit && entropy(tok) >= s.entropyThreshold
+}
+
+// entropy returns the Shannon entropy of s in bits per character.
+func entropy(s string) float64 {
+	counts := make(map[rune]int)
+	n := 0
+	for _, c := range s {
+		counts[c]++
+		n++
+	}
+	var h float64
+	for _, c := range counts {
+		p := float64(c) / float64(n)
+		h -= p * math.Log2(p)
+	}
+	return h
+}
+
+// luhn reports whether the digits of s pass the Luhn checksum, used by payment
+// card numbers.
+func luhn(s string) bool {
+	var digits []int
+	for _, c := range s {
+		if c >= '0' && c <= '9' {
+			digits = append(digits, int(c-'0'))
+		}
+	}
+	if len(digits) < 13 {
+		return false
+	}
+	sum := 0
+	for i := range digits {
+		d := digits[len(digits)-1-i]
+		if i%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
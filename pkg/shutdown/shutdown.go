@@ -0,0 +1,162 @@
+// Package shutdown coordinates the graceful shutdown of the server, so
+// rolling deploys don't drop requests or background work mid-flight.
+//
+// Shutdown runs in phases:
+//  1. The server reports that it isn't ready, and waits for the drain delay
+//     so load balancers stop sending it new requests.
+//  2. The HTTP server stops accepting connections and waits for in-flight
+//     requests to finish.
+//  3. Background tasks are canceled, and shutdown waits for them to return.
+//  4. Stop hooks run in the order they were added, e.g., to flush queues and
+//     then close providers and connections.
+//
+// All phases share the shutdown timeout.
+package shutdown
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+// DefaultTimeout is the default time allowed for shutdown.
+const DefaultTimeout = 30 * time.Second
+
+// ErrShuttingDown is returned by Ready when the server is shutting down.
+var ErrShuttingDown = errors.New("server is shutting down")
+
+// Config contains shutdown configuration.
+type Config struct {
+	// DrainDelay is how long the server reports that it isn't ready before
+	// it stops accepting connections.
+	DrainDelay time.Duration
+
+	// Timeout is the time allowed for shutdown (default: DefaultTimeout).
+	Timeout time.Duration
+}
+
+// Coordinator runs background tasks and shuts them down with the server.
+type Coordinator struct {
+	logger     hclog.Logger
+	drainDelay time.Duration
+	timeout    time.Duration
+
+	ctx          context.Context
+	cancel       context.CancelFunc
+	tasks        sync.WaitGroup
+	shuttingDown atomic.Bool
+
+	mu    sync.Mutex
+	hooks []hook
+}
+
+type hook struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+// New creates a coordinator.
+func New(logger hclog.Logger, cfg *Config) *Coordinator {
+	if logger == nil {
+		logger = hclog.NewNullLogger()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	c := &Coordinator{
+		logger:  logger.Named("shutdown"),
+		timeout: DefaultTimeout,
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+	if cfg != nil {
+		c.drainDelay = cfg.DrainDelay
+		if cfg.Timeout > 0 {
+			c.timeout = cfg.Timeout
+		}
+	}
+	return c
+}
+
+// Go runs background task fn in a goroutine. Its context is canceled when
+// shutdown reaches the background task phase, and shutdown waits for it to
+// return. Errors other than context.Canceled are logged.
+func (c *Coordinator) Go(name string, fn func(ctx context.Context) error) {
+	c.tasks.Add(1)
+	go func() {
+		defer c.tasks.Done()
+		if err := fn(c.ctx); err != nil && !errors.Is(err, context.Canceled) {
+			c.logger.Error("background task failed", "task", name, "error", err)
+		}
+	}()
+}
+
+// OnStop adds a hook that runs after background tasks have stopped. Hooks run
+// in the order they were added, and errors are logged.
+func (c *Coordinator) OnStop(name string, fn func(ctx context.Context) error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.hooks = append(c.hooks, hook{name: name, fn: fn})
+}
+
+// Ready returns ErrShuttingDown once shutdown has started. It's a readiness
+// check.
+func (c *Coordinator) Ready(ctx context.Context) error {
+	if c.shuttingDown.Load() {
+		return ErrShuttingDown
+	}
+	return nil
+}
+
+// Shutdown shuts down server, if not nil, and then the background tasks and
+// stop hooks. It returns an error if shutdown didn't finish in time.
+func (c *Coordinator) Shutdown(server *http.Server) error {
+	c.shuttingDown.Store(true)
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	var errs []error
+	if c.drainDelay > 0 {
+		c.logger.Info("draining connections", "delay", c.drainDelay)
+		select {
+		case <-time.After(c.drainDelay):
+		case <-ctx.Done():
+		}
+	}
+
+	if server != nil {
+		c.logger.Info("waiting for in-flight requests")
+		if err := server.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("error shutting down HTTP server: %w", err))
+		}
+	}
+
+	c.logger.Info("stopping background tasks")
+	c.cancel()
+	done := make(chan struct{})
+	go func() {
+		c.tasks.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		errs = append(errs, errors.New("timed out waiting for background tasks"))
+	}
+
+	c.mu.Lock()
+	hooks := c.hooks
+	c.mu.Unlock()
+	for _, h := range hooks {
+		c.logger.Debug("running stop hook", "hook", h.name)
+		if err := h.fn(ctx); err != nil {
+			c.logger.Error("stop hook failed", "hook", h.name, "error", err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,98 @@
+package shutdown
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoordinator(t *testing.T) {
+	c := New(nil, &Config{Timeout: 5 * time.Second})
+
+	// Record the order shutdown phases finish in.
+	var mu sync.Mutex
+	var events []string
+	record := func(e string) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, e)
+	}
+
+	// The handler finishes after shutdown starts.
+	started := make(chan struct{})
+	release := make(chan struct{})
+	server := &http.Server{Handler: http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			close(started)
+			<-release
+			record("request")
+		})}
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go server.Serve(l)
+
+	c.Go("task", func(ctx context.Context) error {
+		<-ctx.Done()
+		record("task")
+		return ctx.Err()
+	})
+	c.OnStop("flush", func(ctx context.Context) error {
+		record("flush")
+		return nil
+	})
+	c.OnStop("close", func(ctx context.Context) error {
+		record("close")
+		return nil
+	})
+
+	respCh := make(chan error, 1)
+	go func() {
+		resp, err := http.Get("http://" + l.Addr().String())
+		if err == nil {
+			resp.Body.Close()
+		}
+		respCh <- err
+	}()
+	<-started
+
+	require.NoError(t, c.Ready(context.Background()))
+	shutdownCh := make(chan error, 1)
+	go func() { shutdownCh <- c.Shutdown(server) }()
+
+	// The server isn't ready once shutdown starts.
+	require.Eventually(t, func() bool {
+		return c.Ready(context.Background()) == ErrShuttingDown
+	}, time.Second, time.Millisecond)
+
+	close(release)
+	require.NoError(t, <-shutdownCh)
+	assert.NoError(t, <-respCh, "in-flight request must be served")
+	assert.Equal(t, []string{"request", "task", "flush", "close"}, events)
+}
+
+func TestCoordinator_Timeout(t *testing.T) {
+	c := New(nil, &Config{Timeout: 50 * time.Millisecond})
+
+	// The task ignores cancellation.
+	block := make(chan struct{})
+	defer close(block)
+	c.Go("stuck", func(ctx context.Context) error {
+		<-block
+		return nil
+	})
+	hookRan := false
+	c.OnStop("close", func(ctx context.Context) error {
+		hookRan = true
+		return nil
+	})
+
+	err := c.Shutdown(nil)
+	assert.ErrorContains(t, err, "timed out waiting for background tasks")
+	assert.True(t, hookRan, "stop hooks run after a timeout")
+}
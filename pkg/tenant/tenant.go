@@ -0,0 +1,109 @@
+// Package tenant partitions a Hermes deployment into tenants: isolated
+// document spaces (e.g., organizations) with their own documents, document
+// numbers, search results, site admins, and optionally workspace provider.
+//
+// The tenant of a request is resolved from its host name and stored in the
+// request context. Requests to a host that isn't configured for a tenant, and
+// deployments without tenants, use the default tenant, which has ID zero.
+package tenant
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// DefaultName is the name of the default tenant.
+const DefaultName = "default"
+
+// Tenant is a tenant of a Hermes deployment.
+type Tenant struct {
+	// ID is the database ID of the tenant. It's zero for the default tenant.
+	ID uint
+
+	// Name is the unique name of the tenant.
+	Name string
+}
+
+// Default is the default tenant.
+var Default = Tenant{Name: DefaultName}
+
+// IsDefault returns true if t is the default tenant.
+func (t Tenant) IsDefault() bool {
+	return t.ID == 0
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx with tenant t.
+func NewContext(ctx context.Context, t Tenant) context.Context {
+	return context.WithValue(ctx, contextKey{}, t)
+}
+
+// FromContext returns the tenant in ctx. ok is false if ctx has no tenant,
+// which is the case for all requests to deployments without tenants.
+func FromContext(ctx context.Context) (t Tenant, ok bool) {
+	t, ok = ctx.Value(contextKey{}).(Tenant)
+	return t, ok
+}
+
+// IDFromContext returns the ID of the tenant in ctx, or zero (the default
+// tenant) if ctx has no tenant.
+func IDFromContext(ctx context.Context) uint {
+	t, _ := FromContext(ctx)
+	return t.ID
+}
+
+// Resolver resolves the tenant of a request from its host name.
+type Resolver struct {
+	byHost map[string]Tenant
+}
+
+// NewResolver returns a resolver that resolves every host to the default
+// tenant until tenants are added.
+func NewResolver() *Resolver {
+	return &Resolver{byHost: map[string]Tenant{}}
+}
+
+// Add adds tenant t with its host names. Host names are case-insensitive and
+// can't belong to more than one tenant.
+func (r *Resolver) Add(t Tenant, hostnames ...string) error {
+	if t.IsDefault() {
+		return fmt.Errorf("host names can't be added for the default tenant")
+	}
+	for _, h := range hostnames {
+		h = strings.ToLower(h)
+		if other, ok := r.byHost[h]; ok && other.Name != t.Name {
+			return fmt.Errorf("host %q belongs to tenants %q and %q",
+				h, other.Name, t.Name)
+		}
+		r.byHost[h] = t
+	}
+	return nil
+}
+
+// Resolve returns the tenant of host, which may include a port, or the
+// default tenant if host doesn't belong to a tenant.
+func (r *Resolver) Resolve(host string) Tenant {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	if t, ok := r.byHost[strings.ToLower(host)]; ok {
+		return t
+	}
+	return Default
+}
+
+// Tenants returns the tenants that were added, in no particular order.
+func (r *Resolver) Tenants() []Tenant {
+	seen := map[string]bool{}
+	var tenants []Tenant
+	for _, t := range r.byHost {
+		if !seen[t.Name] {
+			seen[t.Name] = true
+			tenants = append(tenants, t)
+		}
+	}
+	return tenants
+}
@@ -0,0 +1,44 @@
+package tenant
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolver(t *testing.T) {
+	acme := Tenant{ID: 1, Name: "acme"}
+	globex := Tenant{ID: 2, Name: "globex"}
+
+	r := NewResolver()
+	require.NoError(t, r.Add(acme, "docs.acme.example", "Acme.Example"))
+	require.NoError(t, r.Add(globex, "docs.globex.example"))
+
+	assert.Equal(t, acme, r.Resolve("docs.acme.example"))
+	assert.Equal(t, acme, r.Resolve("ACME.example:8000"))
+	assert.Equal(t, globex, r.Resolve("docs.globex.example:443"))
+	assert.Equal(t, Default, r.Resolve("hermes.example"))
+	assert.Equal(t, Default, r.Resolve(""))
+	assert.Len(t, r.Tenants(), 2)
+
+	assert.ErrorContains(t, r.Add(globex, "acme.example"),
+		`host "acme.example" belongs to tenants "acme" and "globex"`)
+	assert.Error(t, r.Add(Default, "hermes.example"))
+}
+
+func TestContext(t *testing.T) {
+	ctx := context.Background()
+	_, ok := FromContext(ctx)
+	assert.False(t, ok)
+	assert.Zero(t, IDFromContext(ctx))
+
+	ctx = NewContext(ctx, Tenant{ID: 3, Name: "initech"})
+	got, ok := FromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "initech", got.Name)
+	assert.EqualValues(t, 3, IDFromContext(ctx))
+	assert.False(t, got.IsDefault())
+	assert.True(t, Default.IsDefault())
+}
@@ -0,0 +1,19 @@
+package tracing
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// InstrumentHandler returns h with a span started for each request, continuing
+// the trace in the request headers, if any. route names the spans and should
+// be the pattern h is registered with (e.g., "/api/v2/drafts/").
+func InstrumentHandler(route string, h http.Handler) http.Handler {
+	return otelhttp.NewHandler(h, route,
+		otelhttp.WithSpanNameFormatter(
+			func(_ string, r *http.Request) string {
+				return r.Method + " " + route
+			}),
+	)
+}
@@ -0,0 +1,77 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// recordCarrier carries the trace context in the headers of a Kafka record.
+type recordCarrier struct {
+	record *kgo.Record
+}
+
+var _ propagation.TextMapCarrier = recordCarrier{}
+
+func (c recordCarrier) Get(key string) string {
+	for _, h := range c.record.Headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+func (c recordCarrier) Set(key, value string) {
+	for i, h := range c.record.Headers {
+		if h.Key == key {
+			c.record.Headers[i].Value = []byte(value)
+			return
+		}
+	}
+	c.record.Headers = append(c.record.Headers,
+		kgo.RecordHeader{Key: key, Value: []byte(value)})
+}
+
+func (c recordCarrier) Keys() []string {
+	keys := make([]string, len(c.record.Headers))
+	for i, h := range c.record.Headers {
+		keys[i] = h.Key
+	}
+	return keys
+}
+
+// StartProduce starts a span for producing record and adds its trace context
+// to the record headers, so consumers continue the trace. The span must be
+// ended with End after the record is produced.
+func StartProduce(ctx context.Context, record *kgo.Record) (context.Context, trace.Span) {
+	ctx, span := tracer().Start(ctx, "kafka.produce "+record.Topic,
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "kafka"),
+			attribute.String("messaging.destination.name", record.Topic),
+		),
+	)
+	otel.GetTextMapPropagator().Inject(ctx, recordCarrier{record: record})
+	return ctx, span
+}
+
+// StartConsume starts a span for processing record that continues the trace
+// in the record headers, if any. The span must be ended with End after the
+// record is processed.
+func StartConsume(ctx context.Context, record *kgo.Record) (context.Context, trace.Span) {
+	ctx = otel.GetTextMapPropagator().Extract(ctx, recordCarrier{record: record})
+	return tracer().Start(ctx, "kafka.consume "+record.Topic,
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "kafka"),
+			attribute.String("messaging.destination.name", record.Topic),
+			attribute.Int("messaging.destination.partition.id", int(record.Partition)),
+			attribute.Int64("messaging.kafka.offset", record.Offset),
+		),
+	)
+}
@@ -0,0 +1,124 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/hashicorp-forge/hermes/pkg/search"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentSearchProvider returns p with a span started for each call to its
+// document and draft indexes. The returned indexes implement
+// search.DocumentUpdater if the indexes of p do.
+func InstrumentSearchProvider(p search.Provider) search.Provider {
+	return &searchProvider{Provider: p}
+}
+
+type searchProvider struct {
+	search.Provider
+}
+
+func (p *searchProvider) DocumentIndex() search.DocumentIndex {
+	return newSearchIndex(p.Name(), "documents", p.Provider.DocumentIndex())
+}
+
+func (p *searchProvider) DraftIndex() search.DraftIndex {
+	return newSearchIndex(p.Name(), "drafts", p.Provider.DraftIndex())
+}
+
+// newSearchIndex returns idx with its calls traced. The returned index
+// implements search.DocumentUpdater if idx does.
+func newSearchIndex(
+	provider, index string,
+	idx search.DocumentIndex,
+) search.DocumentIndex {
+	si := &searchIndex{
+		DocumentIndex: idx,
+		attrs: []attribute.KeyValue{
+			attribute.String("search.provider", provider),
+			attribute.String("search.index", index),
+		},
+	}
+	if u, ok := idx.(search.DocumentUpdater); ok {
+		return &searchUpdaterIndex{searchIndex: si, updater: u}
+	}
+	return si
+}
+
+// searchIndex is a traced document or draft index. Document and draft indexes
+// have the same methods.
+type searchIndex struct {
+	search.DocumentIndex
+	attrs []attribute.KeyValue
+}
+
+// start starts the span of a call of operation op.
+func (i *searchIndex) start(
+	ctx context.Context,
+	op string,
+	attrs ...attribute.KeyValue,
+) (context.Context, trace.Span) {
+	return Start(ctx, "search."+op, append(attrs, i.attrs...)...)
+}
+
+func (i *searchIndex) Index(ctx context.Context, doc *search.Document) (err error) {
+	ctx, span := i.start(ctx, "Index", attribute.String("search.doc_id", doc.ObjectID))
+	defer func() { End(span, err) }()
+	return i.DocumentIndex.Index(ctx, doc)
+}
+
+func (i *searchIndex) IndexBatch(ctx context.Context, docs []*search.Document) (err error) {
+	ctx, span := i.start(ctx, "IndexBatch", attribute.Int("search.docs", len(docs)))
+	defer func() { End(span, err) }()
+	return i.DocumentIndex.IndexBatch(ctx, docs)
+}
+
+func (i *searchIndex) Delete(ctx context.Context, docID string) (err error) {
+	ctx, span := i.start(ctx, "Delete", attribute.String("search.doc_id", docID))
+	defer func() { End(span, err) }()
+	return i.DocumentIndex.Delete(ctx, docID)
+}
+
+func (i *searchIndex) DeleteBatch(ctx context.Context, docIDs []string) (err error) {
+	ctx, span := i.start(ctx, "DeleteBatch", attribute.Int("search.docs", len(docIDs)))
+	defer func() { End(span, err) }()
+	return i.DocumentIndex.DeleteBatch(ctx, docIDs)
+}
+
+func (i *searchIndex) Search(ctx context.Context, query *search.SearchQuery) (_ *search.SearchResult, err error) {
+	ctx, span := i.start(ctx, "Search")
+	defer func() { End(span, err) }()
+	return i.DocumentIndex.Search(ctx, query)
+}
+
+func (i *searchIndex) GetObject(ctx context.Context, docID string) (_ *search.Document, err error) {
+	ctx, span := i.start(ctx, "GetObject", attribute.String("search.doc_id", docID))
+	defer func() { End(span, err) }()
+	return i.DocumentIndex.GetObject(ctx, docID)
+}
+
+func (i *searchIndex) GetFacets(ctx context.Context, facetNames []string) (_ *search.Facets, err error) {
+	ctx, span := i.start(ctx, "GetFacets")
+	defer func() { End(span, err) }()
+	return i.DocumentIndex.GetFacets(ctx, facetNames)
+}
+
+func (i *searchIndex) Clear(ctx context.Context) (err error) {
+	ctx, span := i.start(ctx, "Clear")
+	defer func() { End(span, err) }()
+	return i.DocumentIndex.Clear(ctx)
+}
+
+// searchUpdaterIndex is a searchIndex of an index that implements
+// search.DocumentUpdater.
+type searchUpdaterIndex struct {
+	*searchIndex
+	updater search.DocumentUpdater
+}
+
+func (i *searchUpdaterIndex) UpdateFields(ctx context.Context, docID string, fields map[string]any) (err error) {
+	ctx, span := i.start(ctx, "UpdateFields", attribute.String("search.doc_id", docID))
+	defer func() { End(span, err) }()
+	return i.updater.UpdateFields(ctx, docID, fields)
+}
@@ -0,0 +1,44 @@
+// Package tracing traces requests through the Hermes server, indexer, and
+// notifier with OpenTelemetry, and exports the spans with OTLP.
+//
+// Spans are started for API requests, workspace and search provider calls, and
+// Kafka produce and consume calls. The trace context is propagated across
+// processes in W3C Trace Context HTTP and Kafka record headers.
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName is the name of the tracer of Hermes spans.
+const instrumentationName = "github.com/hashicorp-forge/hermes/pkg/tracing"
+
+// tracer returns the tracer of Hermes spans. It uses the global tracer
+// provider when called, so spans are dropped until Setup is called.
+func tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Start starts a span named name that is a child of the span in ctx, if any.
+// The span must be ended with End.
+func Start(
+	ctx context.Context,
+	name string,
+	attrs ...attribute.KeyValue,
+) (context.Context, trace.Span) {
+	return tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records err, if it isn't nil, on span and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,94 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/pkg/search"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// setupTest records spans in memory for the duration of the test.
+func setupTest(t *testing.T) *tracetest.SpanRecorder {
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	prevTP, prevProp := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevTP)
+		otel.SetTextMapPropagator(prevProp)
+	})
+	return sr
+}
+
+type fakeIndex struct {
+	search.DocumentIndex
+}
+
+func (f *fakeIndex) GetObject(ctx context.Context, docID string) (*search.Document, error) {
+	if docID != "doc1" {
+		return nil, errors.New("not found")
+	}
+	return &search.Document{ObjectID: docID}, nil
+}
+
+type fakeProvider struct {
+	search.Provider
+}
+
+func (p *fakeProvider) Name() string                        { return "fake" }
+func (p *fakeProvider) DocumentIndex() search.DocumentIndex { return &fakeIndex{} }
+
+func TestInstrumentHandler(t *testing.T) {
+	sr := setupTest(t)
+
+	var inner string
+	h := InstrumentHandler("/api/v2/drafts", http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			p := InstrumentSearchProvider(&fakeProvider{})
+			_, _ = p.DocumentIndex().GetObject(r.Context(), "doc1")
+			inner = "called"
+		}))
+
+	req := httptest.NewRequest("POST", "/api/v2/drafts", nil)
+	req.Header.Set("traceparent",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	require.Equal(t, "called", inner)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 2)
+	get, server := spans[0], spans[1]
+	assert.Equal(t, "search.GetObject", get.Name())
+	assert.Equal(t, "POST /api/v2/drafts", server.Name())
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736",
+		server.SpanContext().TraceID().String(), "trace is continued")
+	assert.Equal(t, server.SpanContext().SpanID(), get.Parent().SpanID())
+}
+
+func TestInstrumentSearchProvider(t *testing.T) {
+	sr := setupTest(t)
+	p := InstrumentSearchProvider(&fakeProvider{})
+
+	_, err := p.DocumentIndex().GetObject(context.Background(), "doc2")
+	require.Error(t, err)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Contains(t, spans[0].Attributes(),
+		attribute.String("search.provider", "fake"))
+	assert.Contains(t, spans[0].Attributes(),
+		attribute.String("search.index", "documents"))
+}
@@ -0,0 +1,152 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/hashicorp-forge/hermes/pkg/docid"
+	"github.com/hashicorp-forge/hermes/pkg/workspace"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentWorkspaceProvider returns p with a span started for each of its
+// document, content, permission, people, and email calls. name is the name of
+// the provider (e.g., "google" or "local") recorded on the spans. Other calls
+// are passed through untraced.
+//
+// The returned provider doesn't implement the optional interfaces of p; use
+// workspace.Unwrap to assert them.
+func InstrumentWorkspaceProvider(
+	name string,
+	p workspace.WorkspaceProvider,
+) workspace.WorkspaceProvider {
+	return &workspaceProvider{WorkspaceProvider: p, name: name}
+}
+
+type workspaceProvider struct {
+	workspace.WorkspaceProvider
+	name string
+}
+
+// Unwrap returns the traced provider.
+func (p *workspaceProvider) Unwrap() workspace.WorkspaceProvider {
+	return p.WorkspaceProvider
+}
+
+// start starts the span of a call of operation op.
+func (p *workspaceProvider) start(
+	ctx context.Context,
+	op string,
+	attrs ...attribute.KeyValue,
+) (context.Context, trace.Span) {
+	return Start(ctx, "workspace."+op,
+		append(attrs, attribute.String("workspace.provider", p.name))...)
+}
+
+func (p *workspaceProvider) GetDocument(ctx context.Context, providerID string) (_ *workspace.DocumentMetadata, err error) {
+	ctx, span := p.start(ctx, "GetDocument", attribute.String("workspace.provider_id", providerID))
+	defer func() { End(span, err) }()
+	return p.WorkspaceProvider.GetDocument(ctx, providerID)
+}
+
+func (p *workspaceProvider) GetDocumentByUUID(ctx context.Context, uuid docid.UUID) (_ *workspace.DocumentMetadata, err error) {
+	ctx, span := p.start(ctx, "GetDocumentByUUID")
+	defer func() { End(span, err) }()
+	return p.WorkspaceProvider.GetDocumentByUUID(ctx, uuid)
+}
+
+func (p *workspaceProvider) CreateDocument(ctx context.Context, templateID, destFolderID, name string) (_ *workspace.DocumentMetadata, err error) {
+	ctx, span := p.start(ctx, "CreateDocument")
+	defer func() { End(span, err) }()
+	return p.WorkspaceProvider.CreateDocument(ctx, templateID, destFolderID, name)
+}
+
+func (p *workspaceProvider) CreateDocumentWithUUID(ctx context.Context, uuid docid.UUID, templateID, destFolderID, name string) (_ *workspace.DocumentMetadata, err error) {
+	ctx, span := p.start(ctx, "CreateDocumentWithUUID")
+	defer func() { End(span, err) }()
+	return p.WorkspaceProvider.CreateDocumentWithUUID(ctx, uuid, templateID, destFolderID, name)
+}
+
+func (p *workspaceProvider) CopyDocument(ctx context.Context, srcProviderID, destFolderID, name string) (_ *workspace.DocumentMetadata, err error) {
+	ctx, span := p.start(ctx, "CopyDocument", attribute.String("workspace.provider_id", srcProviderID))
+	defer func() { End(span, err) }()
+	return p.WorkspaceProvider.CopyDocument(ctx, srcProviderID, destFolderID, name)
+}
+
+func (p *workspaceProvider) MoveDocument(ctx context.Context, providerID, destFolderID string) (_ *workspace.DocumentMetadata, err error) {
+	ctx, span := p.start(ctx, "MoveDocument", attribute.String("workspace.provider_id", providerID))
+	defer func() { End(span, err) }()
+	return p.WorkspaceProvider.MoveDocument(ctx, providerID, destFolderID)
+}
+
+func (p *workspaceProvider) DeleteDocument(ctx context.Context, providerID string) (err error) {
+	ctx, span := p.start(ctx, "DeleteDocument", attribute.String("workspace.provider_id", providerID))
+	defer func() { End(span, err) }()
+	return p.WorkspaceProvider.DeleteDocument(ctx, providerID)
+}
+
+func (p *workspaceProvider) RenameDocument(ctx context.Context, providerID, newName string) (err error) {
+	ctx, span := p.start(ctx, "RenameDocument", attribute.String("workspace.provider_id", providerID))
+	defer func() { End(span, err) }()
+	return p.WorkspaceProvider.RenameDocument(ctx, providerID, newName)
+}
+
+func (p *workspaceProvider) CreateFolder(ctx context.Context, name, parentID string) (_ *workspace.DocumentMetadata, err error) {
+	ctx, span := p.start(ctx, "CreateFolder")
+	defer func() { End(span, err) }()
+	return p.WorkspaceProvider.CreateFolder(ctx, name, parentID)
+}
+
+func (p *workspaceProvider) GetSubfolder(ctx context.Context, parentID, name string) (_ string, err error) {
+	ctx, span := p.start(ctx, "GetSubfolder")
+	defer func() { End(span, err) }()
+	return p.WorkspaceProvider.GetSubfolder(ctx, parentID, name)
+}
+
+func (p *workspaceProvider) GetContent(ctx context.Context, providerID string) (_ *workspace.DocumentContent, err error) {
+	ctx, span := p.start(ctx, "GetContent", attribute.String("workspace.provider_id", providerID))
+	defer func() { End(span, err) }()
+	return p.WorkspaceProvider.GetContent(ctx, providerID)
+}
+
+func (p *workspaceProvider) UpdateContent(ctx context.Context, providerID string, content string) (_ *workspace.DocumentContent, err error) {
+	ctx, span := p.start(ctx, "UpdateContent", attribute.String("workspace.provider_id", providerID))
+	defer func() { End(span, err) }()
+	return p.WorkspaceProvider.UpdateContent(ctx, providerID, content)
+}
+
+func (p *workspaceProvider) ShareDocument(ctx context.Context, providerID, email, role string) (err error) {
+	ctx, span := p.start(ctx, "ShareDocument", attribute.String("workspace.provider_id", providerID))
+	defer func() { End(span, err) }()
+	return p.WorkspaceProvider.ShareDocument(ctx, providerID, email, role)
+}
+
+func (p *workspaceProvider) ListPermissions(ctx context.Context, providerID string) (_ []*workspace.FilePermission, err error) {
+	ctx, span := p.start(ctx, "ListPermissions", attribute.String("workspace.provider_id", providerID))
+	defer func() { End(span, err) }()
+	return p.WorkspaceProvider.ListPermissions(ctx, providerID)
+}
+
+func (p *workspaceProvider) RemovePermission(ctx context.Context, providerID, permissionID string) (err error) {
+	ctx, span := p.start(ctx, "RemovePermission", attribute.String("workspace.provider_id", providerID))
+	defer func() { End(span, err) }()
+	return p.WorkspaceProvider.RemovePermission(ctx, providerID, permissionID)
+}
+
+func (p *workspaceProvider) SearchPeople(ctx context.Context, query string) (_ []*workspace.UserIdentity, err error) {
+	ctx, span := p.start(ctx, "SearchPeople")
+	defer func() { End(span, err) }()
+	return p.WorkspaceProvider.SearchPeople(ctx, query)
+}
+
+func (p *workspaceProvider) GetPerson(ctx context.Context, email string) (_ *workspace.UserIdentity, err error) {
+	ctx, span := p.start(ctx, "GetPerson")
+	defer func() { End(span, err) }()
+	return p.WorkspaceProvider.GetPerson(ctx, email)
+}
+
+func (p *workspaceProvider) SendEmail(ctx context.Context, to []string, from, subject, body string) (err error) {
+	ctx, span := p.start(ctx, "SendEmail")
+	defer func() { End(span, err) }()
+	return p.WorkspaceProvider.SendEmail(ctx, to, from, subject, body)
+}
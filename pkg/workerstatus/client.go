@@ -0,0 +1,67 @@
+package workerstatus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/apiclient"
+)
+
+// requestTimeout is the timeout of status reports. Reports aren't retried, as
+// the status is reported again at the next interval.
+const requestTimeout = 10 * time.Second
+
+// NewClient returns a Hermes API client that sends requests to the API at
+// baseURL, authenticated with service token token.
+func NewClient(baseURL, token string) *apiclient.Client {
+	httpClient := &http.Client{Timeout: requestTimeout}
+	baseURL = strings.TrimSuffix(baseURL, "/")
+
+	return apiclient.New(apiclient.DoerFunc(
+		func(ctx context.Context, method, path string, body, result any) error {
+			var reqBody io.Reader
+			if body != nil {
+				b, err := json.Marshal(body)
+				if err != nil {
+					return fmt.Errorf("error marshaling request body: %w", err)
+				}
+				reqBody = bytes.NewReader(b)
+			}
+
+			req, err := http.NewRequestWithContext(
+				ctx, method, baseURL+path, reqBody)
+			if err != nil {
+				return fmt.Errorf("error creating request: %w", err)
+			}
+			req.Header.Set("Authorization", "Bearer "+token)
+			req.Header.Set("Accept", "application/json")
+			if body != nil {
+				req.Header.Set("Content-Type", "application/json")
+			}
+
+			resp, err := httpClient.Do(req)
+			if err != nil {
+				return fmt.Errorf("error sending request: %w", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+				msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+				return fmt.Errorf("%s %s returned status %d: %s",
+					method, path, resp.StatusCode, bytes.TrimSpace(msg))
+			}
+			if result == nil {
+				return nil
+			}
+			if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+				return fmt.Errorf("error decoding response body: %w", err)
+			}
+			return nil
+		}))
+}
@@ -0,0 +1,200 @@
+// Package workerstatus reports the status of pipeline workers (indexers and
+// notifiers) to the Hermes API, so site admins can see the health of the
+// pipeline at /api/v2/admin/workers.
+package workerstatus
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/internal/version"
+	"github.com/hashicorp-forge/hermes/pkg/apiclient"
+	"github.com/hashicorp/go-hclog"
+)
+
+// DefaultInterval is how often the status is reported by default.
+const DefaultInterval = 30 * time.Second
+
+// Config is the configuration of a Reporter.
+type Config struct {
+	// Client is the Hermes API client. It must authenticate with an "api"
+	// service token with the "workers" scope.
+	Client *apiclient.Client
+
+	// Name is the unique name of the worker (default: the hostname).
+	Name string
+
+	// Kind is the kind of worker, "indexer" or "notifier".
+	Kind string
+
+	// Interval is how often the status is reported (default:
+	// DefaultInterval).
+	Interval time.Duration
+
+	// Logger logs errors reporting the status.
+	Logger hclog.Logger
+}
+
+// Reporter collects the progress of a worker and reports it to the Hermes API.
+// Its methods are safe for concurrent use, and the Observe methods of a nil
+// Reporter do nothing, so workers can call them whether reporting is enabled
+// or not.
+type Reporter struct {
+	client   *apiclient.Client
+	name     string
+	kind     string
+	hostname string
+	interval time.Duration
+	logger   hclog.Logger
+
+	startedAt time.Time
+
+	mu          sync.Mutex
+	partitions  map[partitionKey]apiclient.WorkerPartitionStatus
+	processed   int64
+	errors      int64
+	lastError   string
+	lastErrorAt *time.Time
+}
+
+// partitionKey identifies a topic partition consumed by a consumer group.
+type partitionKey struct {
+	group     string
+	topic     string
+	partition int32
+}
+
+// New returns a Reporter for the worker of cfg.
+func New(cfg Config) (*Reporter, error) {
+	if cfg.Client == nil {
+		return nil, fmt.Errorf("client is required")
+	}
+	if cfg.Kind == "" {
+		return nil, fmt.Errorf("kind is required")
+	}
+	hostname, _ := os.Hostname()
+	if cfg.Name == "" {
+		cfg.Name = hostname
+	}
+	if cfg.Name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	if cfg.Interval == 0 {
+		cfg.Interval = DefaultInterval
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = hclog.NewNullLogger()
+	}
+
+	return &Reporter{
+		client:     cfg.Client,
+		name:       cfg.Name,
+		kind:       cfg.Kind,
+		hostname:   hostname,
+		interval:   cfg.Interval,
+		logger:     cfg.Logger,
+		startedAt:  time.Now().UTC(),
+		partitions: map[partitionKey]apiclient.WorkerPartitionStatus{},
+	}, nil
+}
+
+// ObservePartition records that the worker processed records of a topic
+// partition up to offset, with lag records remaining.
+func (r *Reporter) ObservePartition(
+	consumerGroup, topic string, partition int32, offset, lag int64,
+) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.partitions[partitionKey{consumerGroup, topic, partition}] =
+		apiclient.WorkerPartitionStatus{
+			ConsumerGroup: consumerGroup,
+			Topic:         topic,
+			Partition:     int(partition),
+			Offset:        offset,
+			Lag:           lag,
+		}
+}
+
+// ObserveRecord records that the worker processed a record, which failed if
+// err isn't nil.
+func (r *Reporter) ObserveRecord(err error) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.processed++
+	if err != nil {
+		now := time.Now().UTC()
+		r.errors++
+		r.lastError = err.Error()
+		r.lastErrorAt = &now
+	}
+}
+
+// Run reports the status right away and then every interval, until ctx is
+// done. Errors are logged, and the status is reported again at the next
+// interval.
+func (r *Reporter) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.Report(ctx); err != nil && ctx.Err() == nil {
+			r.logger.Warn("error reporting worker status", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Report reports the current status of the worker.
+func (r *Reporter) Report(ctx context.Context) error {
+	_, err := r.client.SendWorkerHeartbeat(ctx, r.status())
+	return err
+}
+
+// status returns the current status of the worker.
+func (r *Reporter) status() apiclient.WorkerHeartbeatRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	partitions := make([]apiclient.WorkerPartitionStatus, 0, len(r.partitions))
+	for _, p := range r.partitions {
+		partitions = append(partitions, p)
+	}
+	sort.Slice(partitions, func(i, j int) bool {
+		a, b := partitions[i], partitions[j]
+		if a.ConsumerGroup != b.ConsumerGroup {
+			return a.ConsumerGroup < b.ConsumerGroup
+		}
+		if a.Topic != b.Topic {
+			return a.Topic < b.Topic
+		}
+		return a.Partition < b.Partition
+	})
+
+	startedAt := r.startedAt
+	return apiclient.WorkerHeartbeatRequest{
+		Name:           r.name,
+		Kind:           r.kind,
+		Version:        version.Version,
+		Hostname:       r.hostname,
+		Partitions:     partitions,
+		ProcessedCount: r.processed,
+		ErrorCount:     r.errors,
+		LastError:      r.lastError,
+		LastErrorAt:    r.lastErrorAt,
+		StartedAt:      &startedAt,
+	}
+}
@@ -0,0 +1,97 @@
+package workerstatus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/pkg/apiclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReporter(t *testing.T) {
+	var (
+		got      apiclient.WorkerHeartbeatRequest
+		authz    string
+		reqPath  string
+		response = http.StatusOK
+	)
+	ts := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			authz = r.Header.Get("Authorization")
+			reqPath = r.URL.Path
+			if response != http.StatusOK {
+				http.Error(w, "nope", response)
+				return
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+			json.NewEncoder(w).Encode(apiclient.WorkerHeartbeatResponse{
+				Acknowledged: true,
+			})
+		}))
+	defer ts.Close()
+
+	r, err := New(Config{
+		Client: NewClient(ts.URL+"/", "hst_token"),
+		Name:   "indexer-1",
+		Kind:   "indexer",
+	})
+	require.NoError(t, err)
+
+	t.Run("reports progress", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+
+		r.ObservePartition("hermes-indexer-workers", "hermes.document-revisions", 1, 10, 5)
+		r.ObservePartition("hermes-indexer-workers", "hermes.document-revisions", 0, 7, 0)
+		r.ObservePartition("hermes-indexer-workers", "hermes.document-revisions", 1, 14, 1)
+		r.ObserveRecord(nil)
+		r.ObserveRecord(errors.New("search unavailable"))
+		require.NoError(r.Report(context.Background()))
+
+		assert.Equal("/api/v2/workers/heartbeat", reqPath)
+		assert.Equal("Bearer hst_token", authz)
+		assert.Equal("indexer-1", got.Name)
+		assert.Equal("indexer", got.Kind)
+		assert.Equal([]apiclient.WorkerPartitionStatus{
+			{ConsumerGroup: "hermes-indexer-workers",
+				Topic: "hermes.document-revisions", Partition: 0, Offset: 7},
+			{ConsumerGroup: "hermes-indexer-workers",
+				Topic: "hermes.document-revisions", Partition: 1, Offset: 14, Lag: 1},
+		}, got.Partitions)
+		assert.Equal(int64(2), got.ProcessedCount)
+		assert.Equal(int64(1), got.ErrorCount)
+		assert.Equal("search unavailable", got.LastError)
+		assert.NotNil(got.LastErrorAt)
+		assert.NotNil(got.StartedAt)
+	})
+
+	t.Run("returns error responses", func(t *testing.T) {
+		response = http.StatusForbidden
+		defer func() { response = http.StatusOK }()
+		err := r.Report(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "403")
+	})
+}
+
+func TestNilReporter(t *testing.T) {
+	var r *Reporter
+	r.ObservePartition("group", "topic", 0, 1, 0)
+	r.ObserveRecord(errors.New("ignored"))
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(Config{Kind: "indexer"})
+	assert.Error(t, err)
+	_, err = New(Config{Client: NewClient("http://localhost", "t")})
+	assert.Error(t, err)
+
+	r, err := New(Config{Client: NewClient("http://localhost", "t"), Kind: "notifier"})
+	require.NoError(t, err)
+	assert.Equal(t, DefaultInterval, r.interval)
+	assert.NotEmpty(t, r.name)
+}
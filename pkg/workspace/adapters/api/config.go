@@ -43,6 +43,11 @@ type Config struct {
 	// RetryDelay between retries
 	// Default: 1 second
 	RetryDelay time.Duration `hcl:"retry_delay,optional" json:"retryDelay,omitempty"`
+
+	// EdgeInstance identifies this instance to the remote Hermes instance when
+	// registering documents
+	// Default: the hostname
+	EdgeInstance string `hcl:"edge_instance,optional" json:"edgeInstance,omitempty"`
 }
 
 // DefaultConfig returns a Config with sensible defaults
@@ -3,8 +3,8 @@ package api
 import (
 	"context"
 	"fmt"
-	"net/url"
 
+	"github.com/hashicorp-forge/hermes/pkg/apiclient"
 	"github.com/hashicorp-forge/hermes/pkg/docid"
 	"github.com/hashicorp-forge/hermes/pkg/workspace"
 )
@@ -20,14 +20,20 @@ func (p *Provider) GetContent(ctx context.Context, providerID string) (*workspac
 		return nil, err
 	}
 
-	path := fmt.Sprintf("/api/v2/documents/%s/content", url.PathEscape(providerID))
-
-	var content workspace.DocumentContent
-	if err := p.doRequest(ctx, "GET", path, nil, &content); err != nil {
+	resp, err := p.api.GetDocumentContent(ctx, providerID, nil)
+	if err != nil {
 		return nil, fmt.Errorf("failed to get content: %w", err)
 	}
 
-	return &content, nil
+	content := &workspace.DocumentContent{
+		ProviderID: providerID,
+		Body:       resp.Content,
+	}
+	if resp.Revision != nil {
+		content.LastModified = resp.Revision.ModifiedTime
+	}
+
+	return content, nil
 }
 
 // GetContentByUUID retrieves content using UUID from remote Hermes
@@ -52,18 +58,16 @@ func (p *Provider) UpdateContent(ctx context.Context, providerID string, content
 		return nil, err
 	}
 
-	path := fmt.Sprintf("/api/v2/documents/%s/content", url.PathEscape(providerID))
-
-	requestBody := map[string]string{
-		"content": content,
-	}
-
-	var updatedContent workspace.DocumentContent
-	if err := p.doRequest(ctx, "PUT", path, requestBody, &updatedContent); err != nil {
+	if err := p.api.UpdateDocumentContent(ctx, providerID,
+		apiclient.DocumentContentRequest{Content: content},
+	); err != nil {
 		return nil, fmt.Errorf("failed to update content: %w", err)
 	}
 
-	return &updatedContent, nil
+	return &workspace.DocumentContent{
+		ProviderID: providerID,
+		Body:       content,
+	}, nil
 }
 
 // GetContentBatch retrieves multiple documents' content from remote Hermes (efficient for migration)
@@ -62,7 +62,7 @@
 //   - GET    /api/v2/documents/:id
 //   - GET    /api/v2/documents/uuid/:uuid
 //   - POST   /api/v2/documents
-//   - POST   /api/v2/documents/register
+//   - POST   /api/v2/edge/documents/register
 //   - POST   /api/v2/documents/:id/copy
 //   - PUT    /api/v2/documents/:id/move
 //   - DELETE /api/v2/documents/:id
@@ -91,7 +91,7 @@
 //
 // People:
 //   - GET  /api/v2/people/search
-//   - GET  /api/v2/people/:email
+//   - GET  /api/v2/people?emails=:email
 //   - GET  /api/v2/people/unified/:id
 //   - POST /api/v2/people/resolve
 //
@@ -108,6 +108,14 @@
 // Capabilities (optional, for feature discovery):
 //   - GET /api/v2/capabilities
 //
+// Requests to endpoints described by the server's OpenAPI document
+// (/api/v2/openapi.json) are sent with the generated client in package
+// apiclient, so their request and response types stay in sync with the
+// server. Today that covers getting and renaming documents, getting and
+// updating content, registering edge documents, and looking up people by
+// email. The other endpoints above aren't in the OpenAPI document yet and are
+// called by path; move them onto the generated client as they're added to it.
+//
 // # Error Handling
 //
 // The provider includes:
@@ -2,9 +2,12 @@ package api
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/url"
+	"time"
 
+	"github.com/hashicorp-forge/hermes/pkg/apiclient"
 	"github.com/hashicorp-forge/hermes/pkg/docid"
 	"github.com/hashicorp-forge/hermes/pkg/workspace"
 )
@@ -16,11 +19,14 @@ import (
 
 // GetDocument retrieves file metadata from remote Hermes
 func (p *Provider) GetDocument(ctx context.Context, providerID string) (*workspace.DocumentMetadata, error) {
-	path := fmt.Sprintf("/api/v2/documents/%s", url.PathEscape(providerID))
+	raw, err := p.api.GetDocument(ctx, providerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get document: %w", err)
+	}
 
 	var doc workspace.DocumentMetadata
-	if err := p.doRequest(ctx, "GET", path, nil, &doc); err != nil {
-		return nil, fmt.Errorf("failed to get document: %w", err)
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode document: %w", err)
 	}
 
 	return &doc, nil
@@ -75,15 +81,43 @@ func (p *Provider) CreateDocumentWithUUID(ctx context.Context, uuid docid.UUID,
 	return &doc, nil
 }
 
-// RegisterDocument registers document metadata with remote provider (for tracking)
+// RegisterDocument registers document metadata with remote Hermes (for tracking)
+// via the edge sync API
 func (p *Provider) RegisterDocument(ctx context.Context, doc *workspace.DocumentMetadata) (*workspace.DocumentMetadata, error) {
-	path := "/api/v2/documents/register"
-
-	var registered workspace.DocumentMetadata
-	if err := p.doRequest(ctx, "POST", path, doc, &registered); err != nil {
+	req := apiclient.RegisterDocumentRequest{
+		UUID:         doc.UUID.String(),
+		Title:        doc.Name,
+		DocumentType: doc.ProviderType,
+		Status:       doc.WorkflowStatus,
+		EdgeInstance: p.config.EdgeInstance,
+		ProviderID:   doc.ProviderID,
+		Product:      doc.Project,
+		Tags:         doc.Tags,
+		Parents:      doc.Parents,
+		Metadata:     doc.ExtendedMetadata,
+		ContentHash:  doc.ContentHash,
+	}
+	if doc.Owner != nil {
+		req.Owners = []string{doc.Owner.Email}
+	}
+	if !doc.CreatedTime.IsZero() {
+		req.CreatedAt = doc.CreatedTime.Format(time.RFC3339)
+	}
+	if !doc.ModifiedTime.IsZero() {
+		req.UpdatedAt = doc.ModifiedTime.Format(time.RFC3339)
+	}
+
+	record, err := p.api.RegisterEdgeDocument(ctx, req)
+	if err != nil {
 		return nil, fmt.Errorf("failed to register document: %w", err)
 	}
 
+	registered := *doc
+	registered.Name = record.Title
+	registered.WorkflowStatus = record.Status
+	registered.ContentHash = record.ContentHash
+	registered.CreatedTime = record.CreatedAt
+	registered.ModifiedTime = record.UpdatedAt
 	return &registered, nil
 }
 
@@ -133,13 +167,9 @@ func (p *Provider) DeleteDocument(ctx context.Context, providerID string) error
 
 // RenameDocument renames a document on remote Hermes
 func (p *Provider) RenameDocument(ctx context.Context, providerID, newName string) error {
-	path := fmt.Sprintf("/api/v2/documents/%s", url.PathEscape(providerID))
-
-	requestBody := map[string]string{
-		"name": newName,
-	}
-
-	if err := p.doRequest(ctx, "PATCH", path, requestBody, nil); err != nil {
+	if err := p.api.UpdateDocument(ctx, providerID,
+		apiclient.DocumentPatchRequest{Title: &newName},
+	); err != nil {
 		return fmt.Errorf("failed to rename document: %w", err)
 	}
 
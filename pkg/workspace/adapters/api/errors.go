@@ -0,0 +1,90 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp-forge/hermes/pkg/workspace"
+)
+
+// APIError is returned when the remote Hermes instance responds with a
+// non-2xx status. Code is populated from RFC 7807 problem details responses
+// (application/problem+json) so callers can branch on machine-readable codes
+// such as "draft_not_found" or "document_locked".
+type APIError struct {
+	StatusCode int
+	Code       string
+	Detail     string
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("API error (status %d, code %s): %s",
+			e.StatusCode, e.Code, e.Detail)
+	}
+	return fmt.Sprintf("API returned status %d: %s", e.StatusCode, e.Detail)
+}
+
+// Unwrap maps the HTTP status to the matching workspace sentinel error so
+// callers can use errors.Is(err, workspace.ErrNotFound).
+func (e *APIError) Unwrap() error {
+	switch e.StatusCode {
+	case http.StatusNotFound:
+		return workspace.ErrNotFound
+	case http.StatusConflict:
+		return workspace.ErrAlreadyExists
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return workspace.ErrInvalidInput
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusLocked:
+		return workspace.ErrPermissionDenied
+	case http.StatusNotImplemented:
+		return workspace.ErrNotImplemented
+	}
+	return nil
+}
+
+// ErrorCode returns the machine-readable error code from err if it wraps an
+// APIError, or an empty string otherwise.
+func ErrorCode(err error) string {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Code
+	}
+	return ""
+}
+
+// parseAPIError builds an APIError from a response body. It understands RFC
+// 7807 problem details as well as the legacy {"error": "..."} and plain text
+// error bodies.
+func parseAPIError(statusCode int, body []byte) *APIError {
+	apiErr := &APIError{StatusCode: statusCode}
+
+	var resp struct {
+		Code    string `json:"code"`
+		Detail  string `json:"detail"`
+		Title   string `json:"title"`
+		Error   string `json:"error"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(body, &resp); err == nil {
+		apiErr.Code = resp.Code
+		switch {
+		case resp.Detail != "":
+			apiErr.Detail = resp.Detail
+		case resp.Error != "":
+			apiErr.Detail = resp.Error
+		case resp.Message != "":
+			apiErr.Detail = resp.Message
+		case resp.Title != "":
+			apiErr.Detail = resp.Title
+		}
+	}
+	if apiErr.Detail == "" {
+		apiErr.Detail = string(body)
+	}
+
+	return apiErr
+}
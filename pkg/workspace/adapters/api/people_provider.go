@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/url"
 
+	"github.com/hashicorp-forge/hermes/pkg/apiclient"
 	"github.com/hashicorp-forge/hermes/pkg/workspace"
 )
 
@@ -35,14 +36,15 @@ func (p *Provider) GetPerson(ctx context.Context, email string) (*workspace.User
 		return nil, err
 	}
 
-	path := fmt.Sprintf("/api/v2/people/%s", url.PathEscape(email))
-
-	var person workspace.UserIdentity
-	if err := p.doRequest(ctx, "GET", path, nil, &person); err != nil {
+	people, err := p.api.GetPeople(ctx, &apiclient.GetPeopleParams{Emails: email})
+	if err != nil {
 		return nil, fmt.Errorf("failed to get person: %w", err)
 	}
+	if len(people) == 0 || people[0] == nil {
+		return nil, fmt.Errorf("failed to get person %q: %w", email, workspace.ErrNotFound)
+	}
 
-	return &person, nil
+	return userIdentityFromAPI(people[0]), nil
 }
 
 // GetPersonByUnifiedID retrieves user by unified ID from remote Hermes (cross-provider lookup)
@@ -80,3 +82,23 @@ func (p *Provider) ResolveIdentity(ctx context.Context, email string) (*workspac
 
 	return &identity, nil
 }
+
+// userIdentityFromAPI converts a user identity returned by the generated API
+// client to its workspace representation.
+func userIdentityFromAPI(u *apiclient.UserIdentity) *workspace.UserIdentity {
+	identity := &workspace.UserIdentity{
+		Email:         u.Email,
+		DisplayName:   u.DisplayName,
+		PhotoURL:      u.PhotoURL,
+		UnifiedUserID: u.UnifiedUserID,
+	}
+	for _, alt := range u.AlternateEmails {
+		identity.AlternateEmails = append(identity.AlternateEmails,
+			workspace.AlternateIdentity{
+				Email:          alt.Email,
+				Provider:       alt.Provider,
+				ProviderUserID: alt.ProviderUserID,
+			})
+	}
+	return identity
+}
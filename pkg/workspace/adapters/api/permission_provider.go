@@ -2,12 +2,15 @@ package api
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
 
 	"github.com/hashicorp-forge/hermes/pkg/workspace"
 )
 
+var _ workspace.BatchPermissionProvider = (*Provider)(nil)
+
 // ===================================================================
 // PermissionProvider Implementation
 // ===================================================================
@@ -33,6 +36,45 @@ func (p *Provider) ShareDocument(ctx context.Context, providerID, email, role st
 	return nil
 }
 
+// ShareDocumentBatch grants shares on remote Hermes in one request
+func (p *Provider) ShareDocumentBatch(ctx context.Context, providerID string, shares []workspace.Share) error {
+	if err := p.checkCapability("permissions"); err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("/api/v2/documents/%s/permissions/batch", url.PathEscape(providerID))
+
+	requestBody := map[string]interface{}{
+		"shares": shares,
+	}
+
+	var resp struct {
+		Results []struct {
+			Email string `json:"email"`
+			Role  string `json:"role"`
+			Error string `json:"error"`
+		} `json:"results"`
+	}
+	if err := p.doRequest(ctx, "POST", path, requestBody, &resp); err != nil {
+		return fmt.Errorf("failed to share document: %w", err)
+	}
+
+	var batchErr workspace.ShareBatchError
+	for _, r := range resp.Results {
+		if r.Error != "" {
+			batchErr.Errors = append(batchErr.Errors, &workspace.ShareError{
+				Share: workspace.Share{Email: r.Email, Role: r.Role},
+				Err:   errors.New(r.Error),
+			})
+		}
+	}
+	if len(batchErr.Errors) > 0 {
+		return &batchErr
+	}
+
+	return nil
+}
+
 // ShareDocumentWithDomain grants access to entire domain on remote Hermes
 func (p *Provider) ShareDocumentWithDomain(ctx context.Context, providerID, domain, role string) error {
 	if err := p.checkCapability("permissions"); err != nil {
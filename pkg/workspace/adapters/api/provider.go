@@ -8,9 +8,11 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"os"
 	"strings"
 	"time"
 
+	"github.com/hashicorp-forge/hermes/pkg/apiclient"
 	"github.com/hashicorp-forge/hermes/pkg/workspace"
 )
 
@@ -28,6 +30,9 @@ type Provider struct {
 	config       *Config
 	client       *http.Client
 	capabilities *Capabilities
+
+	// api is the generated API client, which sends requests with doRequest.
+	api *apiclient.Client
 }
 
 // Capabilities discovered from remote Hermes API
@@ -50,6 +55,7 @@ var (
 	_ workspace.PeopleProvider           = (*Provider)(nil)
 	_ workspace.TeamProvider             = (*Provider)(nil)
 	_ workspace.NotificationProvider     = (*Provider)(nil)
+	_ workspace.HealthChecker            = (*Provider)(nil)
 )
 
 // NewProvider creates a new API workspace provider
@@ -68,6 +74,9 @@ func NewProvider(cfg *Config) (*Provider, error) {
 	if cfg.RetryDelay == 0 {
 		cfg.RetryDelay = 1 * time.Second
 	}
+	if cfg.EdgeInstance == "" {
+		cfg.EdgeInstance, _ = os.Hostname()
+	}
 
 	// Validate configuration
 	if err := cfg.Validate(); err != nil {
@@ -81,6 +90,7 @@ func NewProvider(cfg *Config) (*Provider, error) {
 		config: cfg,
 		client: client,
 	}
+	p.api = apiclient.New(apiclient.DoerFunc(p.doRequest))
 
 	// Discover remote capabilities
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -150,6 +160,33 @@ func (p *Provider) discoverCapabilities(ctx context.Context) error {
 	return nil
 }
 
+// Healthy returns an error if the remote Hermes instance isn't serving
+// requests. It isn't retried, so an unreachable instance fails fast.
+func (p *Provider) Healthy(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodGet, p.config.BaseURL+"/health", nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("remote Hermes unreachable: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("remote Hermes health returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// APIClient returns the generated Hermes API client, which sends requests with
+// the provider's authentication and retries.
+func (p *Provider) APIClient() *apiclient.Client {
+	return p.api
+}
+
 // doRequest executes an HTTP request with retry logic and error handling
 func (p *Provider) doRequest(ctx context.Context, method, path string, body interface{}, result interface{}) error {
 	endpoint := fmt.Sprintf("%s%s", p.config.BaseURL, path)
@@ -213,16 +250,8 @@ func (p *Provider) doRequest(ctx context.Context, method, path string, body inte
 				continue
 			}
 
-			// Parse error response
-			var apiErr struct {
-				Error   string `json:"error"`
-				Message string `json:"message"`
-			}
-			if err := json.Unmarshal(respBody, &apiErr); err == nil && apiErr.Error != "" {
-				return fmt.Errorf("API error (status %d): %s", resp.StatusCode, apiErr.Error)
-			}
-
-			return fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(respBody))
+			// Parse error response (problem+json or legacy formats).
+			return parseAPIError(resp.StatusCode, respBody)
 		}
 
 		// Decode response if result is provided
@@ -34,6 +34,8 @@ var (
 	_ workspace.PeopleProvider           = (*Adapter)(nil)
 	_ workspace.TeamProvider             = (*Adapter)(nil)
 	_ workspace.NotificationProvider     = (*Adapter)(nil)
+	_ workspace.PeopleDirectoryProvider  = (*Adapter)(nil)
+	_ workspace.HealthChecker            = (*Adapter)(nil)
 )
 
 // NewAdapter creates a new Google Workspace adapter.
@@ -49,6 +51,15 @@ func (a *Adapter) GetService() *Service {
 	return a.service
 }
 
+// Healthy returns an error if the Drive API is unreachable or rejects the
+// service credentials.
+func (a *Adapter) Healthy(ctx context.Context) error {
+	if _, err := a.service.Drive.About.Get().Fields("user").Context(ctx).Do(); err != nil {
+		return fmt.Errorf("Drive API unavailable: %w", err)
+	}
+	return nil
+}
+
 // ===================================================================
 // DocumentProvider Implementation
 // ===================================================================
@@ -63,7 +74,7 @@ func (a *Adapter) GetDocument(ctx context.Context, providerID string) (*workspac
 	}
 
 	// Get file from Google Drive
-	file, err := a.service.GetFile(fileID)
+	file, err := a.service.WithContext(ctx).GetFile(fileID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get Google Drive file: %w", err)
 	}
@@ -110,18 +121,18 @@ func (a *Adapter) CreateDocument(ctx context.Context, templateID, destFolderID,
 // CreateDocumentWithUUID creates document with explicit UUID (for migration).
 func (a *Adapter) CreateDocumentWithUUID(ctx context.Context, uuid docid.UUID, templateID, destFolderID, name string) (*workspace.DocumentMetadata, error) {
 	// Copy file from template
-	file, err := a.service.CopyFile(templateID, destFolderID, name)
+	file, err := a.service.WithContext(ctx).CopyFile(templateID, destFolderID, name)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create document from template: %w", err)
 	}
 
 	// Update file with UUID in custom properties
-	if err := UpdateFileWithUUID(a.service.Drive, file.Id, uuid); err != nil {
+	if err := UpdateFileWithUUID(ctx, a.service.Drive, file.Id, uuid); err != nil {
 		return nil, fmt.Errorf("failed to set UUID on document: %w", err)
 	}
 
 	// Get updated file
-	file, err = a.service.GetFile(file.Id)
+	file, err = a.service.WithContext(ctx).GetFile(file.Id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve created document: %w", err)
 	}
@@ -139,7 +150,7 @@ func (a *Adapter) RegisterDocument(ctx context.Context, doc *workspace.DocumentM
 	}
 
 	// Update file with UUID in custom properties
-	if err := UpdateFileWithUUID(a.service.Drive, fileID, doc.UUID); err != nil {
+	if err := UpdateFileWithUUID(ctx, a.service.Drive, fileID, doc.UUID); err != nil {
 		return nil, fmt.Errorf("failed to register document UUID: %w", err)
 	}
 
@@ -155,7 +166,7 @@ func (a *Adapter) CopyDocument(ctx context.Context, srcProviderID, destFolderID,
 	}
 
 	// Copy file
-	file, err := a.service.CopyFile(srcFileID, destFolderID, name)
+	file, err := a.service.WithContext(ctx).CopyFile(srcFileID, destFolderID, name)
 	if err != nil {
 		return nil, fmt.Errorf("failed to copy document: %w", err)
 	}
@@ -170,7 +181,7 @@ func (a *Adapter) MoveDocument(ctx context.Context, providerID, destFolderID str
 		return nil, err
 	}
 
-	file, err := a.service.MoveFile(fileID, destFolderID)
+	file, err := a.service.WithContext(ctx).MoveFile(fileID, destFolderID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to move document: %w", err)
 	}
@@ -185,7 +196,7 @@ func (a *Adapter) DeleteDocument(ctx context.Context, providerID string) error {
 		return err
 	}
 
-	return a.service.DeleteFile(fileID)
+	return a.service.WithContext(ctx).DeleteFile(fileID)
 }
 
 // RenameDocument renames a document.
@@ -195,12 +206,12 @@ func (a *Adapter) RenameDocument(ctx context.Context, providerID, newName string
 		return err
 	}
 
-	return a.service.RenameFile(fileID, newName)
+	return a.service.WithContext(ctx).RenameFile(fileID, newName)
 }
 
 // CreateFolder creates a folder/directory.
 func (a *Adapter) CreateFolder(ctx context.Context, name, parentID string) (*workspace.DocumentMetadata, error) {
-	file, err := a.service.CreateFolder(name, parentID)
+	file, err := a.service.WithContext(ctx).CreateFolder(name, parentID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create folder: %w", err)
 	}
@@ -210,7 +221,7 @@ func (a *Adapter) CreateFolder(ctx context.Context, name, parentID string) (*wor
 
 // GetSubfolder finds a subfolder by name.
 func (a *Adapter) GetSubfolder(ctx context.Context, parentID, name string) (string, error) {
-	subfolder, err := a.service.GetSubfolder(parentID, name)
+	subfolder, err := a.service.WithContext(ctx).GetSubfolder(parentID, name)
 	if err != nil {
 		return "", err
 	}
@@ -232,13 +243,13 @@ func (a *Adapter) GetContent(ctx context.Context, providerID string) (*workspace
 	}
 
 	// Get file metadata
-	file, err := a.service.GetFile(fileID)
+	file, err := a.service.WithContext(ctx).GetFile(fileID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get file metadata: %w", err)
 	}
 
 	// Get document content (for Google Docs)
-	doc, err := a.service.GetDoc(fileID)
+	doc, err := a.service.WithContext(ctx).GetDoc(fileID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get document content: %w", err)
 	}
@@ -257,12 +268,18 @@ func (a *Adapter) GetContentByUUID(ctx context.Context, uuid docid.UUID) (*works
 	return a.GetContent(ctx, meta.ProviderID)
 }
 
-// UpdateContent updates document content.
+// UpdateContent replaces the document content with Markdown content.
 func (a *Adapter) UpdateContent(ctx context.Context, providerID string, content string) (*workspace.DocumentContent, error) {
-	// Note: For Google Docs, this would require using the Docs API to update content
-	// This is a complex operation that requires converting markdown to Google Docs format
-	// For now, return not implemented
-	return nil, fmt.Errorf("UpdateContent not yet implemented for Google Workspace")
+	fileID, err := extractGoogleFileID(providerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.service.WithContext(ctx).ReplaceDocContent(fileID, content); err != nil {
+		return nil, fmt.Errorf("failed to update document content: %w", err)
+	}
+
+	return a.GetContent(ctx, providerID)
 }
 
 // GetContentBatch retrieves multiple documents (efficient for migration).
@@ -272,6 +289,9 @@ func (a *Adapter) GetContentBatch(ctx context.Context, providerIDs []string) ([]
 	for _, providerID := range providerIDs {
 		content, err := a.GetContent(ctx, providerID)
 		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
 			// Log error but continue with other documents
 			continue
 		}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/hashicorp-forge/hermes/pkg/workspace"
 	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/people/v1"
 )
 
 // ===================================================================
@@ -19,7 +20,7 @@ func (a *Adapter) ShareDocument(ctx context.Context, providerID, email, role str
 		return err
 	}
 
-	return a.service.ShareFile(fileID, email, role)
+	return a.service.WithContext(ctx).ShareFile(fileID, email, role)
 }
 
 // ShareDocumentWithDomain grants access to entire domain.
@@ -29,7 +30,7 @@ func (a *Adapter) ShareDocumentWithDomain(ctx context.Context, providerID, domai
 		return err
 	}
 
-	return a.service.ShareFileWithDomain(fileID, domain, role)
+	return a.service.WithContext(ctx).ShareFileWithDomain(fileID, domain, role)
 }
 
 // ListPermissions lists all permissions for a document.
@@ -39,7 +40,7 @@ func (a *Adapter) ListPermissions(ctx context.Context, providerID string) ([]*wo
 		return nil, err
 	}
 
-	perms, err := a.service.ListPermissions(fileID)
+	perms, err := a.service.WithContext(ctx).ListPermissions(fileID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list permissions: %w", err)
 	}
@@ -60,7 +61,7 @@ func (a *Adapter) RemovePermission(ctx context.Context, providerID, permissionID
 		return err
 	}
 
-	return a.service.DeletePermission(fileID, permissionID)
+	return a.service.WithContext(ctx).DeletePermission(fileID, permissionID)
 }
 
 // UpdatePermission changes permission role.
@@ -88,12 +89,27 @@ func (a *Adapter) UpdatePermission(ctx context.Context, providerID, permissionID
 
 // SearchPeople searches for users in the directory.
 func (a *Adapter) SearchPeople(ctx context.Context, query string) ([]*workspace.UserIdentity, error) {
-	persons, err := a.service.SearchPeople(query, "emailAddresses,names,photos")
+	persons, err := a.service.WithContext(ctx).SearchPeople(query, "emailAddresses,names,photos")
 	if err != nil {
 		return nil, fmt.Errorf("failed to search people: %w", err)
 	}
 
-	// Convert to RFC-084 UserIdentity
+	return userIdentities(persons), nil
+}
+
+// ListPeople lists all people in the domain directory.
+func (a *Adapter) ListPeople(ctx context.Context) ([]*workspace.UserIdentity, error) {
+	persons, err := a.service.WithContext(ctx).ListDirectoryPeople("emailAddresses,names,photos")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list people: %w", err)
+	}
+
+	return userIdentities(persons), nil
+}
+
+// userIdentities converts People API persons to RFC-084 user identities,
+// skipping people without an email address.
+func userIdentities(persons []*people.Person) []*workspace.UserIdentity {
 	results := make([]*workspace.UserIdentity, 0, len(persons))
 	for _, person := range persons {
 		// Extract email from person
@@ -117,7 +133,7 @@ func (a *Adapter) SearchPeople(ctx context.Context, query string) ([]*workspace.
 		}
 	}
 
-	return results, nil
+	return results
 }
 
 // GetPerson retrieves a user by email.
@@ -271,12 +287,12 @@ func (a *Adapter) GetTeamMembers(ctx context.Context, teamID string) ([]*workspa
 // SendEmail sends an email notification.
 func (a *Adapter) SendEmail(ctx context.Context, to []string, from, subject, body string) error {
 	// Use Gmail API to send email
-	return a.service.SendEmail(to, from, subject, body)
+	return a.service.WithContext(ctx).SendEmail(to, from, subject, body)
 }
 
 // SendEmailWithTemplate sends email using template.
 func (a *Adapter) SendEmailWithTemplate(ctx context.Context, to []string, template string, data map[string]any) error {
 	// For now, just send plain email
 	// Template rendering would be implemented by a higher-level service
-	return a.service.SendEmail(to, "", template, fmt.Sprintf("%v", data))
+	return a.service.WithContext(ctx).SendEmail(to, "", template, fmt.Sprintf("%v", data))
 }
@@ -178,8 +178,7 @@ func (a *CompatAdapter) GetDocumentContent(fileID string) (string, error) {
 }
 
 func (a *CompatAdapter) UpdateDocumentContent(fileID, content string) error {
-	// Google Docs content updates are complex - not yet implemented
-	return fmt.Errorf("UpdateDocumentContent not implemented for Google Workspace")
+	return a.service.ReplaceDocContent(fileID, content)
 }
 
 // SupportsContentEditing implements workspace.ProviderCapabilities.
@@ -1,12 +1,14 @@
 package google
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
 	"time"
 
 	"github.com/hashicorp-forge/hermes/pkg/docid"
+	"github.com/hashicorp-forge/hermes/pkg/mdconv"
 	"github.com/hashicorp-forge/hermes/pkg/workspace"
 	"google.golang.org/api/docs/v1"
 	"google.golang.org/api/drive/v3"
@@ -108,8 +110,8 @@ func ConvertToDocumentMetadata(file *drive.File) (*workspace.DocumentMetadata, e
 // ConvertToDocumentContent converts Google Doc content to RFC-084 DocumentContent.
 //
 // Mapping:
-//   - Body: Extracted plain text from Google Doc
-//   - Format: "richtext" (Google Docs native format)
+//   - Body: Google Doc exported as normalized Markdown (see the mdconv package)
+//   - Format: "markdown"
 //   - BackendRevision: Current revision info from Drive
 func ConvertToDocumentContent(doc *docs.Document, file *drive.File) (*workspace.DocumentContent, error) {
 	if doc == nil {
@@ -119,7 +121,7 @@ func ConvertToDocumentContent(doc *docs.Document, file *drive.File) (*workspace.
 	content := &workspace.DocumentContent{
 		ProviderID: fmt.Sprintf("google:%s", doc.DocumentId),
 		Title:      doc.Title,
-		Format:     "richtext", // Google Docs native format
+		Format:     "markdown",
 	}
 
 	// Extract UUID from document properties or generate
@@ -133,8 +135,8 @@ func ConvertToDocumentContent(doc *docs.Document, file *drive.File) (*workspace.
 	}
 	content.UUID = uuid
 
-	// Extract body text from Google Doc
-	content.Body = ExtractDocText(doc)
+	// Export the Google Doc as Markdown
+	content.Body = mdconv.FromDocument(doc)
 
 	// Calculate content hash
 	hash := sha256.Sum256([]byte(content.Body))
@@ -265,10 +267,16 @@ func ConvertToFilePermission(perm *drive.Permission) *workspace.FilePermission {
 	}
 
 	fp := &workspace.FilePermission{
-		ID:    perm.Id,
-		Email: perm.EmailAddress,
-		Role:  perm.Role,
-		Type:  perm.Type,
+		ID:     perm.Id,
+		Email:  perm.EmailAddress,
+		Role:   perm.Role,
+		Type:   perm.Type,
+		Domain: perm.Domain,
+	}
+	for _, pd := range perm.PermissionDetails {
+		if pd.Inherited {
+			fp.Inherited = true
+		}
 	}
 
 	// Convert user info if available
@@ -320,7 +328,7 @@ func ExtractDocText(doc *docs.Document) string {
 }
 
 // UpdateFileWithUUID updates a Google Drive file with Hermes UUID in custom properties.
-func UpdateFileWithUUID(service *drive.Service, fileID string, uuid docid.UUID) error {
+func UpdateFileWithUUID(ctx context.Context, service *drive.Service, fileID string, uuid docid.UUID) error {
 	file := &drive.File{
 		Properties: map[string]string{
 			"hermesUuid": uuid.String(),
@@ -329,6 +337,7 @@ func UpdateFileWithUUID(service *drive.Service, fileID string, uuid docid.UUID)
 
 	_, err := service.Files.Update(fileID, file).
 		Fields("id,properties").
+		Context(ctx).
 		Do()
 
 	return err
@@ -103,17 +103,25 @@ func TestConvertToDocumentContent(t *testing.T) {
 			Content: []*docs.StructuralElement{
 				{
 					Paragraph: &docs.Paragraph{
+						ParagraphStyle: &docs.ParagraphStyle{NamedStyleType: "HEADING_1"},
 						Elements: []*docs.ParagraphElement{
-							{
-								TextRun: &docs.TextRun{
-									Content: "# RFC-084\n\n",
-								},
-							},
-							{
-								TextRun: &docs.TextRun{
-									Content: "## Summary\n\nThis RFC proposes...\n",
-								},
-							},
+							{TextRun: &docs.TextRun{Content: "RFC-084\n"}},
+						},
+					},
+				},
+				{
+					Paragraph: &docs.Paragraph{
+						ParagraphStyle: &docs.ParagraphStyle{NamedStyleType: "HEADING_2"},
+						Elements: []*docs.ParagraphElement{
+							{TextRun: &docs.TextRun{Content: "Summary\n"}},
+						},
+					},
+				},
+				{
+					Paragraph: &docs.Paragraph{
+						ParagraphStyle: &docs.ParagraphStyle{NamedStyleType: "NORMAL_TEXT"},
+						Elements: []*docs.ParagraphElement{
+							{TextRun: &docs.TextRun{Content: "This RFC proposes...\n"}},
 						},
 					},
 				},
@@ -145,9 +153,8 @@ func TestConvertToDocumentContent(t *testing.T) {
 
 	// Verify content
 	assert.Equal(t, "RFC-084: Provider Interface Refactoring", content.Title)
-	assert.Equal(t, "richtext", content.Format)
-	assert.Contains(t, content.Body, "# RFC-084")
-	assert.Contains(t, content.Body, "## Summary")
+	assert.Equal(t, "markdown", content.Format)
+	assert.Equal(t, "# RFC-084\n\n## Summary\n\nThis RFC proposes...\n", content.Body)
 
 	// Verify content hash
 	assert.True(t, strings.HasPrefix(content.ContentHash, "sha256:"))
@@ -276,6 +283,24 @@ func TestConvertToFilePermission(t *testing.T) {
 	assert.Equal(t, "Test User", fp.User.DisplayName)
 }
 
+func TestConvertToFilePermission_Domain(t *testing.T) {
+	fp := ConvertToFilePermission(&drive.Permission{
+		Id:     "perm456",
+		Role:   "commenter",
+		Type:   "domain",
+		Domain: "example.com",
+		PermissionDetails: []*drive.PermissionPermissionDetails{
+			{Inherited: true},
+		},
+	})
+	require.NotNil(t, fp)
+
+	assert.Equal(t, "domain", fp.Type)
+	assert.Equal(t, "example.com", fp.Domain)
+	assert.True(t, fp.Inherited)
+	assert.Nil(t, fp.User)
+}
+
 func TestExtractDocText(t *testing.T) {
 	doc := &docs.Document{
 		DocumentId: "doc123",
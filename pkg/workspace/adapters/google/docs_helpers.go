@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp-forge/hermes/pkg/mdconv"
 	"google.golang.org/api/docs/v1"
 )
 
@@ -15,7 +16,7 @@ func (s *Service) GetDoc(id string) (*docs.Document, error) {
 	)
 
 	op := func() error {
-		d, err = s.Docs.Documents.Get(id).Do()
+		d, err = s.Docs.Documents.Get(id).Context(s.callContext()).Do()
 		if err != nil {
 			return fmt.Errorf("error getting document: %w", err)
 		}
@@ -23,7 +24,8 @@ func (s *Service) GetDoc(id string) (*docs.Document, error) {
 		return nil
 	}
 
-	boErr := backoff.RetryNotify(op, defaultBackoff(), backoffNotify)
+	boErr := backoff.RetryNotify(op,
+		backoff.WithContext(defaultBackoff(), s.callContext()), backoffNotify)
 	if boErr != nil {
 		return nil, boErr
 	}
@@ -36,7 +38,44 @@ func (s *Service) UpdateDoc(fileID string, requests []*docs.Request) (*docs.Batc
 	req := &docs.BatchUpdateDocumentRequest{
 		Requests: requests,
 	}
-	return s.Docs.Documents.BatchUpdate(fileID, req).Do()
+	return s.Docs.Documents.BatchUpdate(fileID, req).
+		Context(s.callContext()).
+		Do()
+}
+
+// ReplaceDocContent replaces the body of a Google Doc with Markdown content,
+// converted to Google Docs formatting with the mdconv package.
+func (s *Service) ReplaceDocContent(fileID, markdown string) error {
+	d, err := s.GetDoc(fileID)
+	if err != nil {
+		return err
+	}
+
+	var reqs []*docs.Request
+
+	// The body always ends with a newline that can't be deleted.
+	if d.Body != nil && len(d.Body.Content) > 0 {
+		end := d.Body.Content[len(d.Body.Content)-1].EndIndex
+		if end > 2 {
+			reqs = append(reqs, &docs.Request{
+				DeleteContentRange: &docs.DeleteContentRangeRequest{
+					Range: &docs.Range{
+						StartIndex: 1,
+						EndIndex:   end - 1,
+					},
+				},
+			})
+		}
+	}
+	reqs = append(reqs, mdconv.Requests(markdown, 1)...)
+	if len(reqs) == 0 {
+		return nil
+	}
+
+	if _, err := s.UpdateDoc(fileID, reqs); err != nil {
+		return fmt.Errorf("error updating document: %w", err)
+	}
+	return nil
 }
 
 // GetLinkURLs returns all link URLs in a Google Doc Body.
@@ -99,6 +138,7 @@ func (s *Service) ReplaceText(id string, r map[string]string) error {
 	}
 
 	_, err := s.Docs.Documents.BatchUpdate(id, req).
+		Context(s.callContext()).
 		Do()
 	if err != nil {
 		return fmt.Errorf("error executing document batch update: %w", err)
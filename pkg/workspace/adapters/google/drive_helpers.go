@@ -1,7 +1,6 @@
 package google
 
 import (
-	"context"
 	"fmt"
 	"strings"
 
@@ -28,6 +27,7 @@ func (s *Service) CopyFile(
 	resp, err := s.Drive.Files.Copy(fileID, f).
 		Fields("*").
 		SupportsAllDrives(true).
+		Context(s.callContext()).
 		Do()
 	if err != nil {
 		return nil, fmt.Errorf("error copying file: %w", err)
@@ -44,7 +44,7 @@ func (s *Service) CreateFileAsUser(templateID, destFolderID, name, userEmail str
 	}
 
 	// Create JWT config for user impersonation
-	ctx := context.Background()
+	ctx := s.callContext()
 	conf := &jwt.Config{
 		Email:      s.Config.ClientEmail,
 		PrivateKey: []byte(s.Config.PrivateKey),
@@ -71,6 +71,7 @@ func (s *Service) CreateFileAsUser(templateID, destFolderID, name, userEmail str
 	resp, err := impersonatedDrive.Files.Copy(templateID, f).
 		Fields("*").
 		SupportsAllDrives(true).
+		Context(ctx).
 		Do()
 	if err != nil {
 		return nil, fmt.Errorf("error copying file as user: %w", err)
@@ -100,6 +101,7 @@ func (s *Service) CreateFolder(
 	resp, err := s.Drive.Files.Create(f).
 		Fields("id,mimeType,name,parents").
 		SupportsAllDrives(true).
+		Context(s.callContext()).
 		Do()
 	if err != nil {
 		return nil, err
@@ -137,6 +139,7 @@ func (s *Service) CreateShortcut(
 	resp, err := s.Drive.Files.Create(f).
 		Fields("id,mimeType,name,parents,shortcutDetails").
 		SupportsAllDrives(true).
+		Context(s.callContext()).
 		Do()
 	if err != nil {
 		return nil, err
@@ -161,6 +164,7 @@ func (s *Service) GetFile(fileID string) (*drive.File, error) {
 		resp, err = s.Drive.Files.Get(fileID).
 			Fields(fileFields).
 			SupportsAllDrives(true).
+			Context(s.callContext()).
 			Do()
 		if err != nil {
 			return fmt.Errorf("error getting file: %w", err)
@@ -169,7 +173,8 @@ func (s *Service) GetFile(fileID string) (*drive.File, error) {
 		return nil
 	}
 
-	boErr := backoff.RetryNotify(op, defaultBackoff(), backoffNotify)
+	boErr := backoff.RetryNotify(op,
+		backoff.WithContext(defaultBackoff(), s.callContext()), backoffNotify)
 	if boErr != nil {
 		return nil, boErr
 	}
@@ -284,6 +289,7 @@ func (s *Service) KeepRevisionForever(
 		KeepForever: true,
 	}).
 		Fields("keepForever").
+		Context(s.callContext()).
 		Do()
 	if err != nil {
 		return nil, err
@@ -299,6 +305,7 @@ func (s *Service) UpdateKeepRevisionForever(
 		KeepForever: keepForever,
 	}).
 		Fields("keepForever").
+		Context(s.callContext()).
 		Do()
 
 	return err
@@ -320,7 +327,7 @@ func (s *Service) ListFiles(folderID, query string) ([]*drive.File, error) {
 			if nextPageToken != "" {
 				call = call.PageToken(nextPageToken)
 			}
-			resp, err := call.Do()
+			resp, err := call.Context(s.callContext()).Do()
 			if err != nil {
 				return fmt.Errorf("error listing files: %w", err)
 			}
@@ -330,7 +337,8 @@ func (s *Service) ListFiles(folderID, query string) ([]*drive.File, error) {
 			return nil
 		}
 
-		boErr := backoff.RetryNotify(op, defaultBackoff(), backoffNotify)
+		boErr := backoff.RetryNotify(op,
+			backoff.WithContext(defaultBackoff(), s.callContext()), backoffNotify)
 		if boErr != nil {
 			return nil, boErr
 		}
@@ -355,7 +363,7 @@ func (s *Service) ListRevisions(fileID string) ([]*drive.Revision, error) {
 		if nextPageToken != "" {
 			call = call.PageToken(nextPageToken)
 		}
-		resp, err := call.Do()
+		resp, err := call.Context(s.callContext()).Do()
 		if err != nil {
 			return nil, err
 		}
@@ -386,6 +394,7 @@ func (s *Service) MoveFile(fileID, destFolder string) (*drive.File, error) {
 		RemoveParents(strings.Join(f.Parents[:], ",")).
 		Fields("parents").
 		SupportsAllDrives(true).
+		Context(s.callContext()).
 		Do()
 	if err != nil {
 		return nil, fmt.Errorf("error updating file: %w", err)
@@ -399,6 +408,7 @@ func (s *Service) RenameFile(fileID, newName string) error {
 		Name: newName,
 	}).
 		SupportsAllDrives(true).
+		Context(s.callContext()).
 		Do()
 	if err != nil {
 		return fmt.Errorf("error updating file: %w", err)
@@ -417,6 +427,7 @@ func (s *Service) ShareFile(
 			Type:         "user",
 		}).
 		SupportsAllDrives(true).
+		Context(s.callContext()).
 		Do()
 	if err != nil {
 		return fmt.Errorf("error updating file permissions: %w", err)
@@ -435,6 +446,7 @@ func (s *Service) ShareFileWithDomain(
 			Type:   "domain",
 		}).
 		SupportsAllDrives(true).
+		Context(s.callContext()).
 		Do()
 	if err != nil {
 		return fmt.Errorf("error updating file permissions: %w", err)
@@ -455,7 +467,7 @@ func (s *Service) ListPermissions(fileID string) ([]*drive.Permission, error) {
 		if nextPageToken != "" {
 			call = call.PageToken(nextPageToken)
 		}
-		resp, err := call.Do()
+		resp, err := call.Context(s.callContext()).Do()
 		if err != nil {
 			return nil, err
 		}
@@ -474,6 +486,7 @@ func (s *Service) ListPermissions(fileID string) ([]*drive.Permission, error) {
 func (s *Service) DeleteFile(fileID string) error {
 	err := s.Drive.Files.Delete(fileID).
 		SupportsAllDrives(true).
+		Context(s.callContext()).
 		Do()
 	if err != nil {
 		return fmt.Errorf("error deleting file: %w", err)
@@ -486,6 +499,7 @@ func (s *Service) DeletePermission(
 	fileID, permissionID string) error {
 	err := s.Drive.Permissions.Delete(fileID, permissionID).
 		SupportsAllDrives(true).
+		Context(s.callContext()).
 		Do()
 	if err != nil {
 		return fmt.Errorf("error deleting permission: %w", err)
@@ -17,7 +17,9 @@ func (s *Service) SendEmail(to []string, from, subject, body string) error {
 		Raw: base64.URLEncoding.EncodeToString([]byte(email)),
 	}
 
-	_, err := s.Gmail.Users.Messages.Send("me", msg).Do()
+	_, err := s.Gmail.Users.Messages.Send("me", msg).
+		Context(s.callContext()).
+		Do()
 	if err != nil {
 		return fmt.Errorf("error sending email: %w", err)
 	}
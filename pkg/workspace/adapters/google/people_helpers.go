@@ -21,7 +21,7 @@ func (s *Service) SearchPeople(
 	)
 
 	op := func() error {
-		resp, err = call.Do()
+		resp, err = call.Context(s.callContext()).Do()
 		if err != nil {
 			return fmt.Errorf("error searching people directory: %w", err)
 		}
@@ -38,7 +38,54 @@ func (s *Service) SearchPeople(
 			call = call.PageToken(nextPageToken)
 		}
 
-		boErr := backoff.RetryNotify(op, defaultBackoff(), backoffNotify)
+		boErr := backoff.RetryNotify(op,
+			backoff.WithContext(defaultBackoff(), s.callContext()), backoffNotify)
+		if boErr != nil {
+			return nil, boErr
+		}
+
+		ret = append(ret, resp.People...)
+
+		nextPageToken = resp.NextPageToken
+		if nextPageToken == "" {
+			break
+		}
+	}
+
+	return ret, nil
+}
+
+// ListDirectoryPeople lists all people in the domain directory.
+func (s *Service) ListDirectoryPeople(readMask string) ([]*people.Person, error) {
+	var (
+		call          *people.PeopleListDirectoryPeopleCall
+		err           error
+		nextPageToken string
+		ret           []*people.Person
+		resp          *people.ListDirectoryPeopleResponse
+	)
+
+	op := func() error {
+		resp, err = call.Context(s.callContext()).Do()
+		if err != nil {
+			return fmt.Errorf("error listing people directory: %w", err)
+		}
+
+		return nil
+	}
+
+	for {
+		call = s.People.ListDirectoryPeople().
+			ReadMask(readMask).
+			Sources("DIRECTORY_SOURCE_TYPE_DOMAIN_PROFILE").
+			PageSize(1000)
+
+		if nextPageToken != "" {
+			call = call.PageToken(nextPageToken)
+		}
+
+		boErr := backoff.RetryNotify(op,
+			backoff.WithContext(defaultBackoff(), s.callContext()), backoffNotify)
 		if boErr != nil {
 			return nil, boErr
 		}
@@ -100,7 +147,7 @@ func (s *Service) SearchDirectory(opts workspace.PeopleSearchOptions) ([]*people
 	}
 
 	op := func() error {
-		resp, err = call.Do()
+		resp, err = call.Context(s.callContext()).Do()
 		if err != nil {
 			return fmt.Errorf("error searching people directory: %w", err)
 		}
@@ -112,7 +159,8 @@ func (s *Service) SearchDirectory(opts workspace.PeopleSearchOptions) ([]*people
 			call = call.PageToken(nextPageToken)
 		}
 
-		boErr := backoff.RetryNotify(op, defaultBackoff(), backoffNotify)
+		boErr := backoff.RetryNotify(op,
+			backoff.WithContext(defaultBackoff(), s.callContext()), backoffNotify)
 		if boErr != nil {
 			return nil, boErr
 		}
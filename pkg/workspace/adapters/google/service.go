@@ -34,6 +34,25 @@ type Service struct {
 
 	// Config holds the authentication configuration for user impersonation
 	Config *Config
+
+	// ctx is the context of API calls (default: context.Background()).
+	ctx context.Context
+}
+
+// WithContext returns a shallow copy of s whose API calls, including retries,
+// use ctx, so they stop when ctx is canceled or its deadline passes.
+func (s *Service) WithContext(ctx context.Context) *Service {
+	s2 := *s
+	s2.ctx = ctx
+	return &s2
+}
+
+// callContext returns the context of the API calls of s.
+func (s *Service) callContext() context.Context {
+	if s.ctx == nil {
+		return context.Background()
+	}
+	return s.ctx
 }
 
 // Config is the configuration for interacting with Google Workspace using a
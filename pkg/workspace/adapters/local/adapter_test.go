@@ -2,7 +2,9 @@ package local_test
 
 import (
 	"context"
+	"io"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -417,3 +419,53 @@ func TestNewAdapter_ErrorPaths(t *testing.T) {
 		assert.Contains(t, err.Error(), "invalid configuration")
 	})
 }
+
+func TestWorkspaceAdapter_Healthy(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	adapter, err := local.NewAdapter(&local.Config{
+		BasePath:   "/workspace",
+		FileSystem: fs,
+	})
+	require.NoError(t, err)
+
+	provider := local.NewWorkspaceAdapter(adapter).(workspace.HealthChecker)
+	require.NoError(t, provider.Healthy(context.Background()))
+
+	require.NoError(t, fs.RemoveAll("/workspace/docs"))
+	assert.Error(t, provider.Healthy(context.Background()))
+}
+
+func TestWorkspaceAdapter_Attachments(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	adapter, err := local.NewAdapter(&local.Config{
+		BasePath:   "/workspace",
+		FileSystem: fs,
+	})
+	require.NoError(t, err)
+
+	provider := local.NewWorkspaceAdapter(adapter).(workspace.AttachmentProvider)
+	ctx := context.Background()
+
+	require.NoError(t, provider.PutAttachment(
+		ctx, "local:doc1", "plan.pdf", strings.NewReader("v1")))
+	require.NoError(t, provider.PutAttachment(
+		ctx, "local:doc1", "plan.pdf", strings.NewReader("v2")))
+	exists, err := afero.Exists(fs, "/workspace/attachments/doc1/plan.pdf")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	rc, err := provider.GetAttachment(ctx, "local:doc1", "plan.pdf")
+	require.NoError(t, err)
+	b, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	require.NoError(t, rc.Close())
+	assert.Equal(t, "v2", string(b))
+
+	require.NoError(t, provider.DeleteAttachment(ctx, "local:doc1", "plan.pdf"))
+	_, err = provider.GetAttachment(ctx, "local:doc1", "plan.pdf")
+	assert.ErrorIs(t, err, workspace.ErrNotFound)
+
+	err = provider.PutAttachment(
+		ctx, "local:doc1", "../doc2.md", strings.NewReader("x"))
+	assert.ErrorIs(t, err, workspace.ErrInvalidInput)
+}
@@ -3,6 +3,7 @@ package local
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp-forge/hermes/pkg/docid"
 	"github.com/hashicorp-forge/hermes/pkg/workspace"
@@ -24,6 +25,7 @@ var (
 	_ workspace.PeopleProvider           = (*WorkspaceAdapter)(nil)
 	_ workspace.TeamProvider             = (*WorkspaceAdapter)(nil)
 	_ workspace.NotificationProvider     = (*WorkspaceAdapter)(nil)
+	_ workspace.HealthChecker            = (*WorkspaceAdapter)(nil)
 )
 
 // NewWorkspaceAdapter creates a new WorkspaceProvider-compliant adapter.
@@ -39,6 +41,14 @@ func (w *WorkspaceAdapter) GetAdapter() *Adapter {
 	return w.adapter
 }
 
+// Healthy returns an error if the documents directory can't be read.
+func (w *WorkspaceAdapter) Healthy(ctx context.Context) error {
+	if _, err := w.adapter.fs.Stat(w.adapter.docsPath); err != nil {
+		return fmt.Errorf("documents directory unavailable: %w", err)
+	}
+	return nil
+}
+
 // ===================================================================
 // DocumentProvider Implementation
 // ===================================================================
@@ -283,7 +293,8 @@ func (w *WorkspaceAdapter) GetSubfolder(ctx context.Context, parentID, name stri
 		}
 	}
 
-	return "", fmt.Errorf("subfolder %s not found in parent %s", name, parentID)
+	return "", fmt.Errorf("subfolder %s not found in parent %s: %w",
+		name, parentID, workspace.ErrNotFound)
 }
 
 // ===================================================================
@@ -394,25 +405,59 @@ func (w *WorkspaceAdapter) CompareContent(ctx context.Context, providerID1, prov
 // RevisionTrackingProvider Implementation
 // ===================================================================
 
-// GetRevisionHistory lists all revisions for a document in this backend.
-// For local filesystem with Git, this would query Git history.
-// For simple filesystem, this returns empty list (not yet implemented).
+// GetRevisionHistory lists all revisions for a document in this backend,
+// newest first. Revisions are snapshots kept each time content is replaced;
+// the current content is the "latest" revision.
 func (w *WorkspaceAdapter) GetRevisionHistory(ctx context.Context, providerID string, limit int) ([]*workspace.BackendRevision, error) {
-	// TODO: Implement Git integration for revision tracking
-	// For now, return empty list
-	return []*workspace.BackendRevision{}, nil
+	localID := strings.TrimPrefix(providerID, "local:")
+	revs, err := w.adapter.DocumentStorage().ListRevisions(ctx, localID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list revisions: %w", err)
+	}
+
+	if limit > 0 && len(revs) > limit {
+		revs = revs[:limit]
+	}
+
+	result := make([]*workspace.BackendRevision, len(revs))
+	for i, rev := range revs {
+		result[i] = ConvertToBackendRevision(rev)
+	}
+	return result, nil
 }
 
 // GetRevision retrieves a specific revision.
 func (w *WorkspaceAdapter) GetRevision(ctx context.Context, providerID, revisionID string) (*workspace.BackendRevision, error) {
-	// TODO: Implement Git integration for revision tracking
-	return nil, fmt.Errorf("revision tracking not yet implemented for local filesystem")
+	localID := strings.TrimPrefix(providerID, "local:")
+	rev, err := w.adapter.DocumentStorage().GetRevision(ctx, localID, revisionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get revision: %w", err)
+	}
+	return ConvertToBackendRevision(rev), nil
 }
 
 // GetRevisionContent retrieves content at a specific revision.
 func (w *WorkspaceAdapter) GetRevisionContent(ctx context.Context, providerID, revisionID string) (*workspace.DocumentContent, error) {
-	// TODO: Implement Git integration for revision tracking
-	return nil, fmt.Errorf("revision tracking not yet implemented for local filesystem")
+	localID := strings.TrimPrefix(providerID, "local:")
+	storage := w.adapter.DocumentStorage()
+
+	doc, err := storage.GetDocument(ctx, localID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get document: %w", err)
+	}
+	rev, err := storage.GetRevision(ctx, localID, revisionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get revision: %w", err)
+	}
+
+	doc.Content = rev.Content
+	doc.ModifiedTime = rev.ModifiedTime
+	content, err := ConvertToDocumentContent(doc)
+	if err != nil {
+		return nil, err
+	}
+	content.BackendRevision = ConvertToBackendRevision(rev)
+	return content, nil
 }
 
 // KeepRevisionForever marks a revision as permanent (if supported).
@@ -443,7 +488,8 @@ func (w *WorkspaceAdapter) ShareDocument(ctx context.Context, providerID, email,
 
 // ShareDocumentWithDomain grants access to entire domain.
 func (w *WorkspaceAdapter) ShareDocumentWithDomain(ctx context.Context, providerID, domain, role string) error {
-	return fmt.Errorf("domain sharing not supported for local filesystem")
+	return fmt.Errorf("domain sharing not supported for local filesystem: %w",
+		workspace.ErrNotImplemented)
 }
 
 // ListPermissions lists all permissions for a document.
@@ -512,26 +558,86 @@ func (w *WorkspaceAdapter) ResolveIdentity(ctx context.Context, email string) (*
 // ===================================================================
 
 // ListTeams lists teams matching query.
-// For local filesystem, teams are stored in configuration files.
+// For local filesystem, teams are stored in the teams.json file in the base
+// path.
 func (w *WorkspaceAdapter) ListTeams(ctx context.Context, domain, query string, maxResults int64) ([]*workspace.Team, error) {
-	// Local filesystem doesn't have teams by default
-	// This would need to be implemented via configuration
-	return []*workspace.Team{}, nil
+	teams, err := w.adapter.loadTeams()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load teams: %w", err)
+	}
+
+	results := []*workspace.Team{}
+	for _, id := range sortedTeamIDs(teams) {
+		t := teams[id]
+		if !containsIgnoreCase(id, query) && !containsIgnoreCase(t.Name, query) &&
+			!containsIgnoreCase(t.Email, query) {
+			continue
+		}
+		results = append(results, convertToTeam(id, t))
+		if maxResults > 0 && int64(len(results)) >= maxResults {
+			break
+		}
+	}
+
+	return results, nil
 }
 
 // GetTeam retrieves team details.
 func (w *WorkspaceAdapter) GetTeam(ctx context.Context, teamID string) (*workspace.Team, error) {
-	return nil, fmt.Errorf("teams not supported for local filesystem")
+	teams, err := w.adapter.loadTeams()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load teams: %w", err)
+	}
+
+	t, ok := teams[teamID]
+	if !ok {
+		return nil, workspace.NotFoundError("team", teamID)
+	}
+	return convertToTeam(teamID, t), nil
 }
 
 // GetUserTeams lists all teams a user belongs to.
 func (w *WorkspaceAdapter) GetUserTeams(ctx context.Context, userEmail string) ([]*workspace.Team, error) {
-	return []*workspace.Team{}, nil
+	teams, err := w.adapter.loadTeams()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load teams: %w", err)
+	}
+
+	results := []*workspace.Team{}
+	for _, id := range sortedTeamIDs(teams) {
+		if teams[id].hasMember(userEmail) {
+			results = append(results, convertToTeam(id, teams[id]))
+		}
+	}
+
+	return results, nil
 }
 
 // GetTeamMembers lists all members of a team.
+// Members that aren't in the users.json file are returned with only their
+// email address.
 func (w *WorkspaceAdapter) GetTeamMembers(ctx context.Context, teamID string) ([]*workspace.UserIdentity, error) {
-	return []*workspace.UserIdentity{}, nil
+	teams, err := w.adapter.loadTeams()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load teams: %w", err)
+	}
+
+	t, ok := teams[teamID]
+	if !ok {
+		return nil, workspace.NotFoundError("team", teamID)
+	}
+
+	members := make([]*workspace.UserIdentity, 0, len(t.Members))
+	for _, email := range t.Members {
+		user, err := w.adapter.PeopleService().GetUser(ctx, email)
+		if err != nil {
+			members = append(members, &workspace.UserIdentity{Email: email})
+			continue
+		}
+		members = append(members, ConvertToUserIdentity(user))
+	}
+
+	return members, nil
 }
 
 // ===================================================================
@@ -0,0 +1,81 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp-forge/hermes/pkg/workspace"
+	"github.com/spf13/afero"
+)
+
+var _ workspace.AttachmentProvider = (*WorkspaceAdapter)(nil)
+
+// attachmentPath returns the path of the file name attached to the document
+// with provider ID providerID. Files are stored in the "attachments" directory,
+// in a directory per document.
+func (w *WorkspaceAdapter) attachmentPath(providerID, name string) (string, error) {
+	localID := strings.TrimPrefix(providerID, "local:")
+	if localID == "" || strings.ContainsAny(localID, `/\`) || localID == ".." {
+		return "", workspace.InvalidInputError("providerID", "must be a document ID")
+	}
+	if name == "" || name != filepath.Base(name) || name == "." || name == ".." {
+		return "", workspace.InvalidInputError("name", "must be a file name")
+	}
+	return filepath.Join(w.adapter.basePath, "attachments", localID, name), nil
+}
+
+// PutAttachment stores a file attached to a document.
+func (w *WorkspaceAdapter) PutAttachment(ctx context.Context, providerID, name string, r io.Reader) error {
+	path, err := w.attachmentPath(providerID, name)
+	if err != nil {
+		return err
+	}
+	if err := w.adapter.fs.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create attachments directory: %w", err)
+	}
+
+	// Write to a temporary file first so readers never see a partial file.
+	tmp := path + ".tmp"
+	if err := afero.WriteReader(w.adapter.fs, tmp, r); err != nil {
+		_ = w.adapter.fs.Remove(tmp)
+		return fmt.Errorf("failed to write attachment: %w", err)
+	}
+	if err := w.adapter.fs.Rename(tmp, path); err != nil {
+		_ = w.adapter.fs.Remove(tmp)
+		return fmt.Errorf("failed to write attachment: %w", err)
+	}
+	return nil
+}
+
+// GetAttachment opens a file attached to a document.
+func (w *WorkspaceAdapter) GetAttachment(ctx context.Context, providerID, name string) (io.ReadCloser, error) {
+	path, err := w.attachmentPath(providerID, name)
+	if err != nil {
+		return nil, err
+	}
+	f, err := w.adapter.fs.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, workspace.NotFoundError("attachment", name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open attachment: %w", err)
+	}
+	return f, nil
+}
+
+// DeleteAttachment deletes a file attached to a document.
+func (w *WorkspaceAdapter) DeleteAttachment(ctx context.Context, providerID, name string) error {
+	path, err := w.attachmentPath(providerID, name)
+	if err != nil {
+		return err
+	}
+	if err := w.adapter.fs.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete attachment: %w", err)
+	}
+	return nil
+}
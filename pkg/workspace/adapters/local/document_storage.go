@@ -19,6 +19,10 @@ type documentStorage struct {
 
 // GetDocument retrieves a document by ID.
 func (ds *documentStorage) GetDocument(ctx context.Context, id string) (*workspace.Document, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	// Find the document in either docs or drafts
 	docPath, _, _, err := ds.adapter.findDocumentPath(id)
 	if err != nil {
@@ -71,6 +75,10 @@ func (ds *documentStorage) GetDocument(ctx context.Context, id string) (*workspa
 
 // CreateDocument creates a new document.
 func (ds *documentStorage) CreateDocument(ctx context.Context, doc *workspace.DocumentCreate) (*workspace.Document, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	if doc.Name == "" {
 		return nil, workspace.InvalidInputError("Name", "cannot be empty")
 	}
@@ -129,6 +137,10 @@ func (ds *documentStorage) CreateDocument(ctx context.Context, doc *workspace.Do
 
 // UpdateDocument updates an existing document.
 func (ds *documentStorage) UpdateDocument(ctx context.Context, id string, updates *workspace.DocumentUpdate) (*workspace.Document, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	// Find the document
 	docPath, isDraft, isDir, err := ds.adapter.findDocumentPath(id)
 	if err != nil {
@@ -168,6 +180,14 @@ func (ds *documentStorage) UpdateDocument(ctx context.Context, id string, update
 	}
 	if updates.Content != nil {
 		contentToSave = *updates.Content
+
+		// Keep the replaced content so it can be read back by revision.
+		if contentToSave != currentContent {
+			if err := ds.adapter.snapshotRevision(
+				id, currentContent, meta.ModifiedTime); err != nil {
+				return nil, err
+			}
+		}
 	}
 	if updates.Metadata != nil {
 		if meta.Metadata == nil {
@@ -194,6 +214,10 @@ func (ds *documentStorage) UpdateDocument(ctx context.Context, id string, update
 
 // DeleteDocument deletes a document.
 func (ds *documentStorage) DeleteDocument(ctx context.Context, id string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	// Find the document
 	docPath, _, _, err := ds.adapter.findDocumentPath(id)
 	if err != nil {
@@ -214,6 +238,10 @@ func (ds *documentStorage) DeleteDocument(ctx context.Context, id string) error
 
 // ListDocuments lists documents in a folder.
 func (ds *documentStorage) ListDocuments(ctx context.Context, folderID string, opts *workspace.ListOptions) ([]*workspace.Document, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	allMeta, err := ds.adapter.metadataStore.List(ds.adapter.docsPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list metadata: %w", err)
@@ -252,7 +280,7 @@ func (ds *documentStorage) ListDocuments(ctx context.Context, folderID string, o
 		docs = append(docs, doc)
 
 		// Apply page size limit
-		if opts.PageSize > 0 && len(docs) >= opts.PageSize {
+		if opts != nil && opts.PageSize > 0 && len(docs) >= opts.PageSize {
 			break
 		}
 	}
@@ -319,6 +347,10 @@ func (ds *documentStorage) MoveDocument(ctx context.Context, docID, destFolderID
 
 // CreateFolder creates a new folder.
 func (ds *documentStorage) CreateFolder(ctx context.Context, name, parentID string) (*workspace.Folder, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	if name == "" {
 		return nil, workspace.InvalidInputError("name", "cannot be empty")
 	}
@@ -351,6 +383,10 @@ func (ds *documentStorage) CreateFolder(ctx context.Context, name, parentID stri
 
 // GetFolder retrieves folder information.
 func (ds *documentStorage) GetFolder(ctx context.Context, id string) (*workspace.Folder, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	folderPath := ds.adapter.getFolderPath(id)
 	data, err := afero.ReadFile(ds.adapter.fs, folderPath)
 	if err != nil {
@@ -411,16 +447,33 @@ func (ds *documentStorage) GetSubfolder(ctx context.Context, parentID, name stri
 	return nil, nil // Not found, but not an error
 }
 
-// ListRevisions lists document revisions/versions.
+// ListRevisions lists document revisions/versions, newest first. The current
+// content is listed as the "latest" revision, followed by snapshots of
+// content replaced by earlier updates.
 func (ds *documentStorage) ListRevisions(ctx context.Context, docID string) ([]*workspace.Revision, error) {
-	// Filesystem adapter doesn't support revisions by default
-	// This would require additional implementation (e.g., git backend)
-	return nil, workspace.ErrNotImplemented
+	latest, err := ds.GetLatestRevision(ctx, docID)
+	if err != nil {
+		return nil, err
+	}
+	latest.Content = ""
+
+	snapshots, err := ds.adapter.listRevisionSnapshots(docID)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]*workspace.Revision{latest}, snapshots...), nil
 }
 
 // GetRevision retrieves a specific revision.
 func (ds *documentStorage) GetRevision(ctx context.Context, docID, revisionID string) (*workspace.Revision, error) {
-	return nil, workspace.ErrNotImplemented
+	if revisionID == latestRevisionID {
+		return ds.GetLatestRevision(ctx, docID)
+	}
+	if _, _, _, err := ds.adapter.findDocumentPath(docID); err != nil {
+		return nil, err
+	}
+	return ds.adapter.readRevisionSnapshot(docID, revisionID)
 }
 
 // GetLatestRevision retrieves the latest revision.
@@ -432,7 +485,7 @@ func (ds *documentStorage) GetLatestRevision(ctx context.Context, docID string)
 	}
 
 	return &workspace.Revision{
-		ID:           "latest",
+		ID:           latestRevisionID,
 		DocumentID:   docID,
 		ModifiedTime: doc.ModifiedTime,
 		ModifiedBy:   doc.Owner,
@@ -464,6 +464,52 @@ func TestDocumentStorage_Templates(t *testing.T) {
 	})
 }
 
+// TestDocumentStorage_Revisions tests that replaced content is kept as
+// revision snapshots.
+func TestDocumentStorage_Revisions(t *testing.T) {
+	ctx := context.Background()
+	adapter, cleanup := createTestAdapter(t)
+	defer cleanup()
+
+	docStorage := adapter.DocumentStorage()
+	doc, err := docStorage.CreateDocument(ctx, &workspace.DocumentCreate{
+		Name:           "Runbook",
+		ParentFolderID: "docs",
+		Content:        "Version 1",
+		Owner:          "test@hashicorp.com",
+	})
+	require.NoError(t, err)
+
+	for _, content := range []string{"Version 2", "Version 2", "Version 3"} {
+		content := content
+		_, err := docStorage.UpdateDocument(ctx, doc.ID, &workspace.DocumentUpdate{
+			Content: &content,
+		})
+		require.NoError(t, err)
+	}
+
+	revs, err := docStorage.ListRevisions(ctx, doc.ID)
+	require.NoError(t, err)
+	// Unchanged content is not snapshotted.
+	require.Len(t, revs, 3)
+	assert.Equal(t, latestRevisionID, revs[0].ID)
+	for i := 1; i < len(revs); i++ {
+		assert.False(t, revs[i].ModifiedTime.After(revs[i-1].ModifiedTime),
+			"revisions should be ordered newest first")
+	}
+
+	var contents []string
+	for _, rev := range revs {
+		got, err := docStorage.GetRevision(ctx, doc.ID, rev.ID)
+		require.NoError(t, err)
+		contents = append(contents, got.Content)
+	}
+	assert.Equal(t, []string{"Version 3", "Version 2", "Version 1"}, contents)
+
+	_, err = docStorage.GetRevision(ctx, doc.ID, "12345")
+	assert.Error(t, err)
+}
+
 // createTestAdapter creates a test adapter with a temporary storage directory.
 func createTestAdapter(t *testing.T) (*Adapter, func()) {
 	t.Helper()
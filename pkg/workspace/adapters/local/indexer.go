@@ -17,6 +17,9 @@ type DocumentIndexer struct {
 	adapter        *Adapter
 	searchProvider search.Provider
 	logger         hclog.Logger
+
+	// BatchOptions configures the batches documents are indexed in.
+	BatchOptions search.BatchOptions
 }
 
 // NewDocumentIndexer creates a new document indexer.
@@ -65,11 +68,26 @@ func (di *DocumentIndexer) indexDirectory(ctx context.Context, dirPath string, i
 	}
 
 	indexType := "docs"
+	var idx search.BatchIndexer = di.searchProvider.DocumentIndex()
 	if isDraft {
 		indexType = "drafts"
+		idx = di.searchProvider.DraftIndex()
 	}
 
-	indexed := 0
+	// failed is only updated by OnError, whose calls aren't concurrent.
+	failed := 0
+	opts := di.BatchOptions
+	opts.OnError = func(docs []*search.Document, err error) {
+		failed += len(docs)
+		for _, doc := range docs {
+			di.logger.Error("failed to index document",
+				"id", doc.ObjectID,
+				"type", indexType,
+				"error", err,
+			)
+		}
+	}
+	batcher := search.NewBatcher(idx, opts)
 	skipped := 0
 
 	for _, file := range files {
@@ -78,8 +96,9 @@ func (di *DocumentIndexer) indexDirectory(ctx context.Context, dirPath string, i
 			metadataPath := filepath.Join(dirPath, file.Name(), "metadata.json")
 			if _, err := di.adapter.fs.Stat(metadataPath); err == nil {
 				// This is a directory-based document
-				if err := di.indexDocument(ctx, file.Name(), isDraft); err != nil {
-					di.logger.Error("failed to index directory document",
+				searchDoc, err := di.loadSearchDocument(ctx, file.Name())
+				if err != nil {
+					di.logger.Error("failed to load directory document",
 						"path", file.Name(),
 						"type", indexType,
 						"error", err,
@@ -87,7 +106,7 @@ func (di *DocumentIndexer) indexDirectory(ctx context.Context, dirPath string, i
 					skipped++
 					continue
 				}
-				indexed++
+				batcher.Add(ctx, searchDoc)
 			}
 			// Skip other directories
 			continue
@@ -96,8 +115,9 @@ func (di *DocumentIndexer) indexDirectory(ctx context.Context, dirPath string, i
 		// Handle single-file documents (.md files)
 		if filepath.Ext(file.Name()) == ".md" {
 			docID := strings.TrimSuffix(file.Name(), ".md")
-			if err := di.indexDocument(ctx, docID, isDraft); err != nil {
-				di.logger.Error("failed to index single-file document",
+			searchDoc, err := di.loadSearchDocument(ctx, docID)
+			if err != nil {
+				di.logger.Error("failed to load single-file document",
 					"path", file.Name(),
 					"type", indexType,
 					"error", err,
@@ -105,36 +125,32 @@ func (di *DocumentIndexer) indexDirectory(ctx context.Context, dirPath string, i
 				skipped++
 				continue
 			}
-			indexed++
+			batcher.Add(ctx, searchDoc)
 		}
 	}
 
+	// Documents in failed batches were logged by OnError.
+	_ = batcher.Flush(ctx)
+
 	di.logger.Info("directory indexing completed",
 		"path", dirPath,
 		"type", indexType,
-		"indexed", indexed,
+		"indexed", batcher.Indexed(),
 		"skipped", skipped,
+		"failed", failed,
 	)
 
 	return nil
 }
 
-// indexDocument indexes a single document into the appropriate search index.
-func (di *DocumentIndexer) indexDocument(ctx context.Context, docID string, isDraft bool) error {
-	// Get document from filesystem
+// loadSearchDocument gets a document from the filesystem and converts it to
+// a search document.
+func (di *DocumentIndexer) loadSearchDocument(ctx context.Context, docID string) (*search.Document, error) {
 	doc, err := di.adapter.DocumentStorage().GetDocument(ctx, docID)
 	if err != nil {
-		return fmt.Errorf("failed to get document: %w", err)
-	}
-
-	// Convert to search document
-	searchDoc := workspaceDocumentToSearchDocument(doc)
-
-	// Index into appropriate index
-	if isDraft {
-		return di.searchProvider.DraftIndex().Index(ctx, searchDoc)
+		return nil, fmt.Errorf("failed to get document: %w", err)
 	}
-	return di.searchProvider.DocumentIndex().Index(ctx, searchDoc)
+	return workspaceDocumentToSearchDocument(doc), nil
 }
 
 // workspaceDocumentToSearchDocument converts a workspace.Document to search.Document.
@@ -408,7 +408,8 @@ func (p *ProviderAdapter) GetSubfolder(ctx context.Context, parentID, name strin
 		return "", err
 	}
 	if folder == nil {
-		return "", fmt.Errorf("subfolder not found: %s/%s", parentID, name)
+		return "", fmt.Errorf("subfolder not found: %s/%s: %w",
+			parentID, name, workspace.ErrNotFound)
 	}
 	return folder.ID, nil
 }
@@ -901,21 +902,18 @@ func (p *ProviderAdapter) GetContentBatch(ctx context.Context, providerIDs []str
 // ===================================================================
 
 // GetRevisionHistory lists all revisions for a document.
-// TODO: Implement actual revision history retrieval
 func (p *ProviderAdapter) GetRevisionHistory(ctx context.Context, providerID string, limit int) ([]*workspace.BackendRevision, error) {
-	return nil, fmt.Errorf("GetRevisionHistory not yet implemented for local adapter")
+	return (&WorkspaceAdapter{adapter: p.adapter}).GetRevisionHistory(ctx, providerID, limit)
 }
 
 // GetRevision retrieves a specific revision.
-// TODO: Implement actual revision retrieval
 func (p *ProviderAdapter) GetRevision(ctx context.Context, providerID, revisionID string) (*workspace.BackendRevision, error) {
-	return nil, fmt.Errorf("GetRevision not yet implemented for local adapter")
+	return (&WorkspaceAdapter{adapter: p.adapter}).GetRevision(ctx, providerID, revisionID)
 }
 
 // GetRevisionContent retrieves content at a specific revision.
-// TODO: Implement actual revision content retrieval
 func (p *ProviderAdapter) GetRevisionContent(ctx context.Context, providerID, revisionID string) (*workspace.DocumentContent, error) {
-	return nil, fmt.Errorf("GetRevisionContent not yet implemented for local adapter")
+	return (&WorkspaceAdapter{adapter: p.adapter}).GetRevisionContent(ctx, providerID, revisionID)
 }
 
 // GetAllDocumentRevisions returns all revisions across all backends for a UUID.
@@ -0,0 +1,19 @@
+package local
+
+import (
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/pkg/workspace"
+	"github.com/hashicorp-forge/hermes/pkg/workspace/providertest"
+)
+
+func TestWorkspaceAdapter_ProviderContract(t *testing.T) {
+	providertest.Run(t, providertest.Config{
+		New: func(t *testing.T) workspace.WorkspaceProvider {
+			return NewWorkspaceAdapter(TestAdapter(t, "/hermes"))
+		},
+		Skip: map[string]string{
+			providertest.TestPermissions: "local documents don't have permissions",
+		},
+	})
+}
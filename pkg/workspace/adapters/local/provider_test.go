@@ -1029,3 +1029,26 @@ func TestProviderCompliance_SearchDirectory_MaxResults(t *testing.T) {
 	require.NoError(t, err)
 	assert.Len(t, people, 5, "Should return all matches without MaxResults")
 }
+
+// TestProviderAdapter_CanceledContext tests that calls stop once the context
+// is canceled.
+func TestProviderAdapter_CanceledContext(t *testing.T) {
+	adapter, cleanup := setupTestAdapter(t)
+	defer cleanup()
+
+	doc, err := adapter.DocumentStorage().CreateDocument(context.Background(), testDocumentCreate("Test File", ""))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = NewProviderAdapterWithContext(adapter, ctx).GetFile(doc.ID)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	provider := NewProviderAdapter(adapter)
+	_, err = provider.GetContent(ctx, "local:"+doc.ID)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, err = provider.GetFile(doc.ID)
+	assert.NoError(t, err)
+}
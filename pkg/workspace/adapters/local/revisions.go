@@ -0,0 +1,109 @@
+package local
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/workspace"
+	"github.com/spf13/afero"
+)
+
+// Document content revisions are kept as snapshots under
+// <base>/revisions/<document-id>/<unix-nanos>.md. A snapshot holds the content
+// a document had from the snapshot's timestamp until it was next updated.
+
+// latestRevisionID identifies a document's current content in revision lists.
+const latestRevisionID = "latest"
+
+// revisionsPath returns the snapshot directory for a document.
+func (a *Adapter) revisionsPath(id string) string {
+	return filepath.Join(a.basePath, "revisions", id)
+}
+
+// snapshotRevision saves content that was current since modified, before it
+// is replaced.
+func (a *Adapter) snapshotRevision(id, content string, modified time.Time) error {
+	dir := a.revisionsPath(id)
+	if err := a.fs.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create revisions directory: %w", err)
+	}
+
+	path := filepath.Join(dir, revisionID(modified)+".md")
+	if err := afero.WriteFile(a.fs, path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write revision: %w", err)
+	}
+	return nil
+}
+
+// listRevisionSnapshots returns a document's snapshots without content, newest
+// first.
+func (a *Adapter) listRevisionSnapshots(id string) ([]*workspace.Revision, error) {
+	files, err := afero.ReadDir(a.fs, a.revisionsPath(id))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read revisions directory: %w", err)
+	}
+
+	var revs []*workspace.Revision
+	for _, f := range files {
+		revID := strings.TrimSuffix(f.Name(), ".md")
+		modified, err := parseRevisionID(revID)
+		if f.IsDir() || err != nil {
+			continue
+		}
+		revs = append(revs, &workspace.Revision{
+			ID:           revID,
+			DocumentID:   id,
+			ModifiedTime: modified,
+		})
+	}
+	sort.Slice(revs, func(i, j int) bool {
+		return revs[i].ModifiedTime.After(revs[j].ModifiedTime)
+	})
+	return revs, nil
+}
+
+// readRevisionSnapshot reads a snapshot's content.
+func (a *Adapter) readRevisionSnapshot(id, revID string) (*workspace.Revision, error) {
+	modified, err := parseRevisionID(revID)
+	if err != nil {
+		return nil, workspace.NotFoundError("revision", revID)
+	}
+
+	content, err := afero.ReadFile(a.fs,
+		filepath.Join(a.revisionsPath(id), revID+".md"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, workspace.NotFoundError("revision", revID)
+		}
+		return nil, fmt.Errorf("failed to read revision: %w", err)
+	}
+
+	return &workspace.Revision{
+		ID:           revID,
+		DocumentID:   id,
+		ModifiedTime: modified,
+		Content:      string(content),
+	}, nil
+}
+
+// revisionID returns the revision ID for a snapshot taken at t.
+func revisionID(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
+
+// parseRevisionID returns the time a snapshot revision ID was taken at.
+func parseRevisionID(revID string) (time.Time, error) {
+	nanos, err := strconv.ParseInt(revID, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid revision ID %q", revID)
+	}
+	return time.Unix(0, nanos), nil
+}
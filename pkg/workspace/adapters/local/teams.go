@@ -0,0 +1,71 @@
+package local
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp-forge/hermes/pkg/workspace"
+	"github.com/spf13/afero"
+)
+
+// localTeam is a team in the teams.json file.
+type localTeam struct {
+	Name        string   `json:"name"`
+	Email       string   `json:"email,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Members     []string `json:"members"`
+}
+
+// loadTeams loads the teams in the teams.json file in the base path, keyed by
+// team ID. There are no teams if the file doesn't exist.
+func (a *Adapter) loadTeams() (map[string]*localTeam, error) {
+	teamsPath := filepath.Join(a.basePath, "teams.json")
+	data, err := afero.ReadFile(a.fs, teamsPath)
+	if err != nil {
+		if _, statErr := a.fs.Stat(teamsPath); statErr != nil {
+			return map[string]*localTeam{}, nil
+		}
+		return nil, err
+	}
+
+	var teams map[string]*localTeam
+	if err := json.Unmarshal(data, &teams); err != nil {
+		return nil, err
+	}
+	return teams, nil
+}
+
+// sortedTeamIDs returns the IDs of teams in sorted order.
+func sortedTeamIDs(teams map[string]*localTeam) []string {
+	ids := make([]string, 0, len(teams))
+	for id := range teams {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// hasMember returns true if email is a member of the team.
+func (t *localTeam) hasMember(email string) bool {
+	for _, m := range t.Members {
+		if strings.EqualFold(m, email) {
+			return true
+		}
+	}
+	return false
+}
+
+// convertToTeam converts a team in the teams.json file to a workspace.Team.
+func convertToTeam(id string, t *localTeam) *workspace.Team {
+	return &workspace.Team{
+		ID:           id,
+		Email:        t.Email,
+		Name:         t.Name,
+		Description:  t.Description,
+		MemberCount:  len(t.Members),
+		ProviderType: "local",
+		ProviderID:   id,
+	}
+}
@@ -0,0 +1,106 @@
+package local
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/pkg/workspace"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testTeamsJSON = `{
+  "platform": {
+    "name": "Platform",
+    "email": "platform@example.com",
+    "members": ["alice@example.com", "bob@example.com"]
+  },
+  "security": {
+    "name": "Security",
+    "members": ["Bob@example.com"]
+  }
+}`
+
+func createTestWorkspaceAdapterForTeams(t *testing.T) *WorkspaceAdapter {
+	adapter := createTestAdapterForPeople(t)
+	require.NoError(t, afero.WriteFile(
+		adapter.fs, "/workspace/teams.json", []byte(testTeamsJSON), 0644))
+	require.NoError(t, afero.WriteFile(adapter.fs, "/workspace/users.json",
+		[]byte(`{"alice@example.com": {"email": "alice@example.com", "name": "Alice"}}`),
+		0644))
+	return NewWorkspaceAdapter(adapter).(*WorkspaceAdapter)
+}
+
+func TestWorkspaceAdapter_ListTeams(t *testing.T) {
+	w := createTestWorkspaceAdapterForTeams(t)
+	ctx := context.Background()
+
+	teams, err := w.ListTeams(ctx, "", "", 0)
+	require.NoError(t, err)
+	require.Len(t, teams, 2)
+	assert.Equal(t, &workspace.Team{
+		ID:           "platform",
+		Email:        "platform@example.com",
+		Name:         "Platform",
+		MemberCount:  2,
+		ProviderType: "local",
+		ProviderID:   "platform",
+	}, teams[0])
+	assert.Equal(t, "security", teams[1].ID)
+
+	teams, err = w.ListTeams(ctx, "", "secur", 0)
+	require.NoError(t, err)
+	require.Len(t, teams, 1)
+	assert.Equal(t, "security", teams[0].ID)
+
+	teams, err = w.ListTeams(ctx, "", "", 1)
+	require.NoError(t, err)
+	assert.Len(t, teams, 1)
+}
+
+func TestWorkspaceAdapter_ListTeamsNoFile(t *testing.T) {
+	w := NewWorkspaceAdapter(createTestAdapterForPeople(t))
+
+	teams, err := w.ListTeams(context.Background(), "", "", 0)
+	require.NoError(t, err)
+	assert.Empty(t, teams)
+}
+
+func TestWorkspaceAdapter_GetTeam(t *testing.T) {
+	w := createTestWorkspaceAdapterForTeams(t)
+
+	team, err := w.GetTeam(context.Background(), "security")
+	require.NoError(t, err)
+	assert.Equal(t, "Security", team.Name)
+	assert.Equal(t, 1, team.MemberCount)
+
+	_, err = w.GetTeam(context.Background(), "missing")
+	assert.ErrorIs(t, err, workspace.ErrNotFound)
+}
+
+func TestWorkspaceAdapter_GetUserTeams(t *testing.T) {
+	w := createTestWorkspaceAdapterForTeams(t)
+
+	// Membership is case-insensitive.
+	teams, err := w.GetUserTeams(context.Background(), "bob@example.com")
+	require.NoError(t, err)
+	require.Len(t, teams, 2)
+	assert.Equal(t, "platform", teams[0].ID)
+	assert.Equal(t, "security", teams[1].ID)
+
+	teams, err = w.GetUserTeams(context.Background(), "carol@example.com")
+	require.NoError(t, err)
+	assert.Empty(t, teams)
+}
+
+func TestWorkspaceAdapter_GetTeamMembers(t *testing.T) {
+	w := createTestWorkspaceAdapterForTeams(t)
+
+	members, err := w.GetTeamMembers(context.Background(), "platform")
+	require.NoError(t, err)
+	assert.Equal(t, []*workspace.UserIdentity{
+		{Email: "alice@example.com", DisplayName: "Alice"},
+		{Email: "bob@example.com"},
+	}, members)
+}
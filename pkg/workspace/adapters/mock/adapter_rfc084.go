@@ -4,6 +4,7 @@ package mock
 import (
 	"context"
 	"fmt"
+	"math/rand/v2"
 	"sync"
 	"time"
 
@@ -50,8 +51,19 @@ type FakeAdapter struct {
 	// Folders stores subfolder mappings
 	Folders map[string]map[string]string // parentID -> name -> folderID
 
+	// revisionBodies stores the content of each revision by providerID and
+	// revisionID
+	revisionBodies map[string]map[string]string
+
 	// nextID is used for generating unique IDs
 	nextID int
+
+	// faultMu guards the fault injection state, which is kept apart from the
+	// data so injected latency doesn't block other calls
+	faultMu   sync.Mutex
+	faults    map[string]*faultState
+	calls     map[string]int
+	faultRand *rand.Rand
 }
 
 // EmailRecord tracks emails sent through the fake adapter.
@@ -110,6 +122,10 @@ func (f *FakeAdapter) generateID() string {
 
 // GetDocument retrieves document metadata by backend-specific ID.
 func (f *FakeAdapter) GetDocument(ctx context.Context, providerID string) (*workspace.DocumentMetadata, error) {
+	if _, err := f.inject(ctx, "GetDocument"); err != nil {
+		return nil, err
+	}
+
 	f.mu.RLock()
 	defer f.mu.RUnlock()
 
@@ -122,6 +138,10 @@ func (f *FakeAdapter) GetDocument(ctx context.Context, providerID string) (*work
 
 // GetDocumentByUUID retrieves document metadata by UUID.
 func (f *FakeAdapter) GetDocumentByUUID(ctx context.Context, uuid docid.UUID) (*workspace.DocumentMetadata, error) {
+	if _, err := f.inject(ctx, "GetDocumentByUUID"); err != nil {
+		return nil, err
+	}
+
 	f.mu.RLock()
 	defer f.mu.RUnlock()
 
@@ -134,12 +154,21 @@ func (f *FakeAdapter) GetDocumentByUUID(ctx context.Context, uuid docid.UUID) (*
 
 // CreateDocument creates a new document from template.
 func (f *FakeAdapter) CreateDocument(ctx context.Context, templateID, destFolderID, name string) (*workspace.DocumentMetadata, error) {
+	ctx, err := f.inject(ctx, "CreateDocument")
+	if err != nil {
+		return nil, err
+	}
+
 	uuid := docid.NewUUID()
 	return f.CreateDocumentWithUUID(ctx, uuid, templateID, destFolderID, name)
 }
 
 // CreateDocumentWithUUID creates document with explicit UUID (for migration).
 func (f *FakeAdapter) CreateDocumentWithUUID(ctx context.Context, uuid docid.UUID, templateID, destFolderID, name string) (*workspace.DocumentMetadata, error) {
+	if _, err := f.inject(ctx, "CreateDocumentWithUUID"); err != nil {
+		return nil, err
+	}
+
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
@@ -164,6 +193,7 @@ func (f *FakeAdapter) CreateDocumentWithUUID(ctx context.Context, uuid docid.UUI
 		Name:         name,
 		CreatedTime:  now,
 		ModifiedTime: now,
+		Parents:      folderParents(destFolderID),
 		SyncStatus:   "canonical",
 		ExtendedMetadata: map[string]any{
 			"parent_folder": destFolderID,
@@ -194,10 +224,30 @@ func (f *FakeAdapter) CreateDocumentWithUUID(ctx context.Context, uuid docid.UUI
 		LastModified:    now,
 	}
 	f.Contents[providerID] = docContent
+	f.setRevisionBodyUnsafe(providerID, revision.RevisionID, content)
 
 	return doc, nil
 }
 
+// setRevisionBodyUnsafe stores the content of a revision (caller must hold lock)
+func (f *FakeAdapter) setRevisionBodyUnsafe(providerID, revisionID, body string) {
+	if f.revisionBodies == nil {
+		f.revisionBodies = make(map[string]map[string]string)
+	}
+	if f.revisionBodies[providerID] == nil {
+		f.revisionBodies[providerID] = make(map[string]string)
+	}
+	f.revisionBodies[providerID][revisionID] = body
+}
+
+// folderParents returns the parents of a document in folderID.
+func folderParents(folderID string) []string {
+	if folderID == "" {
+		return nil
+	}
+	return []string{folderID}
+}
+
 // generateIDUnsafe generates ID without locking (caller must hold lock)
 func (f *FakeAdapter) generateIDUnsafe() string {
 	id := fmt.Sprintf("fake-%d", f.nextID)
@@ -207,6 +257,10 @@ func (f *FakeAdapter) generateIDUnsafe() string {
 
 // RegisterDocument registers document metadata with provider.
 func (f *FakeAdapter) RegisterDocument(ctx context.Context, doc *workspace.DocumentMetadata) (*workspace.DocumentMetadata, error) {
+	if _, err := f.inject(ctx, "RegisterDocument"); err != nil {
+		return nil, err
+	}
+
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
@@ -219,6 +273,10 @@ func (f *FakeAdapter) RegisterDocument(ctx context.Context, doc *workspace.Docum
 
 // CopyDocument copies a document (preserves UUID if in frontmatter/metadata).
 func (f *FakeAdapter) CopyDocument(ctx context.Context, srcProviderID, destFolderID, name string) (*workspace.DocumentMetadata, error) {
+	if _, err := f.inject(ctx, "CopyDocument"); err != nil {
+		return nil, err
+	}
+
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
@@ -243,6 +301,7 @@ func (f *FakeAdapter) CopyDocument(ctx context.Context, srcProviderID, destFolde
 		CreatedTime:  now,
 		ModifiedTime: now,
 		Owner:        srcDoc.Owner,
+		Parents:      folderParents(destFolderID),
 		Tags:         append([]string{}, srcDoc.Tags...),
 		SyncStatus:   "canonical",
 		ExtendedMetadata: map[string]any{
@@ -276,6 +335,7 @@ func (f *FakeAdapter) CopyDocument(ctx context.Context, srcProviderID, destFolde
 			LastModified:    now,
 		}
 		f.Contents[providerID] = docContent
+		f.setRevisionBodyUnsafe(providerID, revision.RevisionID, srcContent.Body)
 	}
 
 	return doc, nil
@@ -283,6 +343,10 @@ func (f *FakeAdapter) CopyDocument(ctx context.Context, srcProviderID, destFolde
 
 // MoveDocument moves a document to different folder.
 func (f *FakeAdapter) MoveDocument(ctx context.Context, providerID, destFolderID string) (*workspace.DocumentMetadata, error) {
+	if _, err := f.inject(ctx, "MoveDocument"); err != nil {
+		return nil, err
+	}
+
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
@@ -296,6 +360,7 @@ func (f *FakeAdapter) MoveDocument(ctx context.Context, providerID, destFolderID
 		doc.ExtendedMetadata = make(map[string]any)
 	}
 	doc.ExtendedMetadata["parent_folder"] = destFolderID
+	doc.Parents = folderParents(destFolderID)
 	doc.ModifiedTime = time.Now()
 
 	return doc, nil
@@ -303,6 +368,10 @@ func (f *FakeAdapter) MoveDocument(ctx context.Context, providerID, destFolderID
 
 // DeleteDocument deletes a document.
 func (f *FakeAdapter) DeleteDocument(ctx context.Context, providerID string) error {
+	if _, err := f.inject(ctx, "DeleteDocument"); err != nil {
+		return err
+	}
+
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
@@ -317,12 +386,17 @@ func (f *FakeAdapter) DeleteDocument(ctx context.Context, providerID string) err
 	delete(f.Contents, providerID)
 	delete(f.Revisions, providerID)
 	delete(f.Permissions, providerID)
+	delete(f.revisionBodies, providerID)
 
 	return nil
 }
 
 // RenameDocument renames a document.
 func (f *FakeAdapter) RenameDocument(ctx context.Context, providerID, newName string) error {
+	if _, err := f.inject(ctx, "RenameDocument"); err != nil {
+		return err
+	}
+
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
@@ -339,6 +413,10 @@ func (f *FakeAdapter) RenameDocument(ctx context.Context, providerID, newName st
 
 // CreateFolder creates a folder/directory.
 func (f *FakeAdapter) CreateFolder(ctx context.Context, name, parentID string) (*workspace.DocumentMetadata, error) {
+	if _, err := f.inject(ctx, "CreateFolder"); err != nil {
+		return nil, err
+	}
+
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
@@ -378,17 +456,23 @@ func (f *FakeAdapter) CreateFolder(ctx context.Context, name, parentID string) (
 
 // GetSubfolder finds a subfolder by name.
 func (f *FakeAdapter) GetSubfolder(ctx context.Context, parentID, name string) (string, error) {
+	if _, err := f.inject(ctx, "GetSubfolder"); err != nil {
+		return "", err
+	}
+
 	f.mu.RLock()
 	defer f.mu.RUnlock()
 
 	subfolders, ok := f.Folders[parentID]
 	if !ok {
-		return "", fmt.Errorf("parent folder not found: %s", parentID)
+		return "", fmt.Errorf("parent folder not found: %s: %w",
+			parentID, workspace.ErrNotFound)
 	}
 
 	folderID, ok := subfolders[name]
 	if !ok {
-		return "", fmt.Errorf("subfolder %s not found in parent %s", name, parentID)
+		return "", fmt.Errorf("subfolder %s not found in parent %s: %w",
+			name, parentID, workspace.ErrNotFound)
 	}
 
 	return folderID, nil
@@ -400,6 +484,10 @@ func (f *FakeAdapter) GetSubfolder(ctx context.Context, parentID, name string) (
 
 // GetContent retrieves document content with backend-specific revision.
 func (f *FakeAdapter) GetContent(ctx context.Context, providerID string) (*workspace.DocumentContent, error) {
+	if _, err := f.inject(ctx, "GetContent"); err != nil {
+		return nil, err
+	}
+
 	f.mu.RLock()
 	defer f.mu.RUnlock()
 
@@ -412,6 +500,11 @@ func (f *FakeAdapter) GetContent(ctx context.Context, providerID string) (*works
 
 // GetContentByUUID retrieves content using UUID.
 func (f *FakeAdapter) GetContentByUUID(ctx context.Context, uuid docid.UUID) (*workspace.DocumentContent, error) {
+	ctx, err := f.inject(ctx, "GetContentByUUID")
+	if err != nil {
+		return nil, err
+	}
+
 	// First get document metadata to find providerID
 	doc, err := f.GetDocumentByUUID(ctx, uuid)
 	if err != nil {
@@ -423,6 +516,10 @@ func (f *FakeAdapter) GetContentByUUID(ctx context.Context, uuid docid.UUID) (*w
 
 // UpdateContent updates document content.
 func (f *FakeAdapter) UpdateContent(ctx context.Context, providerID string, content string) (*workspace.DocumentContent, error) {
+	if _, err := f.inject(ctx, "UpdateContent"); err != nil {
+		return nil, err
+	}
+
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
@@ -457,6 +554,7 @@ func (f *FakeAdapter) UpdateContent(ctx context.Context, providerID string, cont
 		LastModified:    now,
 	}
 	f.Contents[providerID] = docContent
+	f.setRevisionBodyUnsafe(providerID, nextRevID, content)
 
 	// Update document modified time
 	doc.ModifiedTime = now
@@ -466,6 +564,10 @@ func (f *FakeAdapter) UpdateContent(ctx context.Context, providerID string, cont
 
 // GetContentBatch retrieves multiple documents (efficient for migration).
 func (f *FakeAdapter) GetContentBatch(ctx context.Context, providerIDs []string) ([]*workspace.DocumentContent, error) {
+	if _, err := f.inject(ctx, "GetContentBatch"); err != nil {
+		return nil, err
+	}
+
 	f.mu.RLock()
 	defer f.mu.RUnlock()
 
@@ -481,6 +583,11 @@ func (f *FakeAdapter) GetContentBatch(ctx context.Context, providerIDs []string)
 
 // CompareContent compares content between two revisions.
 func (f *FakeAdapter) CompareContent(ctx context.Context, providerID1, providerID2 string) (*workspace.ContentComparison, error) {
+	ctx, err := f.inject(ctx, "CompareContent")
+	if err != nil {
+		return nil, err
+	}
+
 	content1, err := f.GetContent(ctx, providerID1)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get first content: %w", err)
@@ -513,6 +620,10 @@ func (f *FakeAdapter) CompareContent(ctx context.Context, providerID1, providerI
 
 // GetRevisionHistory lists all revisions for a document in this backend.
 func (f *FakeAdapter) GetRevisionHistory(ctx context.Context, providerID string, limit int) ([]*workspace.BackendRevision, error) {
+	if _, err := f.inject(ctx, "GetRevisionHistory"); err != nil {
+		return nil, err
+	}
+
 	f.mu.RLock()
 	defer f.mu.RUnlock()
 
@@ -537,6 +648,10 @@ func (f *FakeAdapter) GetRevisionHistory(ctx context.Context, providerID string,
 
 // GetRevision retrieves a specific revision.
 func (f *FakeAdapter) GetRevision(ctx context.Context, providerID, revisionID string) (*workspace.BackendRevision, error) {
+	if _, err := f.inject(ctx, "GetRevision"); err != nil {
+		return nil, err
+	}
+
 	f.mu.RLock()
 	defer f.mu.RUnlock()
 
@@ -556,13 +671,42 @@ func (f *FakeAdapter) GetRevision(ctx context.Context, providerID, revisionID st
 
 // GetRevisionContent retrieves content at a specific revision.
 func (f *FakeAdapter) GetRevisionContent(ctx context.Context, providerID, revisionID string) (*workspace.DocumentContent, error) {
-	// For fake adapter, just return current content
-	// In a real implementation, would retrieve historical content
-	return f.GetContent(ctx, providerID)
+	ctx, err := f.inject(ctx, "GetRevisionContent")
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := f.GetContent(ctx, providerID)
+	if err != nil {
+		return nil, err
+	}
+	rev, err := f.GetRevision(ctx, providerID, revisionID)
+	if err != nil {
+		return nil, err
+	}
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	// Revisions added with the test helpers don't keep their content, so
+	// they have the current content.
+	body, ok := f.revisionBodies[providerID][revisionID]
+	if !ok {
+		return content, nil
+	}
+	revContent := *content
+	revContent.Body = body
+	revContent.BackendRevision = rev
+	revContent.LastModified = rev.ModifiedTime
+	return &revContent, nil
 }
 
 // KeepRevisionForever marks a revision as permanent (if supported).
 func (f *FakeAdapter) KeepRevisionForever(ctx context.Context, providerID, revisionID string) error {
+	if _, err := f.inject(ctx, "KeepRevisionForever"); err != nil {
+		return err
+	}
+
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
@@ -583,6 +727,11 @@ func (f *FakeAdapter) KeepRevisionForever(ctx context.Context, providerID, revis
 
 // GetAllDocumentRevisions returns all revisions across all backends for a UUID.
 func (f *FakeAdapter) GetAllDocumentRevisions(ctx context.Context, uuid docid.UUID) ([]*workspace.RevisionInfo, error) {
+	ctx, err := f.inject(ctx, "GetAllDocumentRevisions")
+	if err != nil {
+		return nil, err
+	}
+
 	// Get document to find providerID
 	doc, err := f.GetDocumentByUUID(ctx, uuid)
 	if err != nil {
@@ -618,6 +767,10 @@ func (f *FakeAdapter) GetAllDocumentRevisions(ctx context.Context, uuid docid.UU
 
 // ShareDocument grants access to a user/group.
 func (f *FakeAdapter) ShareDocument(ctx context.Context, providerID, email, role string) error {
+	if _, err := f.inject(ctx, "ShareDocument"); err != nil {
+		return err
+	}
+
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
@@ -652,6 +805,10 @@ func (f *FakeAdapter) ShareDocument(ctx context.Context, providerID, email, role
 
 // ShareDocumentWithDomain grants access to entire domain.
 func (f *FakeAdapter) ShareDocumentWithDomain(ctx context.Context, providerID, domain, role string) error {
+	if _, err := f.inject(ctx, "ShareDocumentWithDomain"); err != nil {
+		return err
+	}
+
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
@@ -674,12 +831,12 @@ func (f *FakeAdapter) ShareDocumentWithDomain(ctx context.Context, providerID, d
 	}
 
 	// Add new domain permission
-	// Note: FilePermission doesn't have Domain field, so we use ID to store domain info
 	perm := &workspace.FilePermission{
-		ID:    domainID,
-		Email: "", // Empty for domain permissions
-		Role:  role,
-		Type:  "domain",
+		ID:     domainID,
+		Email:  "", // Empty for domain permissions
+		Role:   role,
+		Type:   "domain",
+		Domain: domain,
 	}
 	f.Permissions[providerID] = append(f.Permissions[providerID], perm)
 
@@ -688,6 +845,10 @@ func (f *FakeAdapter) ShareDocumentWithDomain(ctx context.Context, providerID, d
 
 // ListPermissions lists all permissions for a document.
 func (f *FakeAdapter) ListPermissions(ctx context.Context, providerID string) ([]*workspace.FilePermission, error) {
+	if _, err := f.inject(ctx, "ListPermissions"); err != nil {
+		return nil, err
+	}
+
 	f.mu.RLock()
 	defer f.mu.RUnlock()
 
@@ -705,6 +866,10 @@ func (f *FakeAdapter) ListPermissions(ctx context.Context, providerID string) ([
 
 // RemovePermission revokes access.
 func (f *FakeAdapter) RemovePermission(ctx context.Context, providerID, permissionID string) error {
+	if _, err := f.inject(ctx, "RemovePermission"); err != nil {
+		return err
+	}
+
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
@@ -726,6 +891,10 @@ func (f *FakeAdapter) RemovePermission(ctx context.Context, providerID, permissi
 
 // UpdatePermission changes permission role.
 func (f *FakeAdapter) UpdatePermission(ctx context.Context, providerID, permissionID, newRole string) error {
+	if _, err := f.inject(ctx, "UpdatePermission"); err != nil {
+		return err
+	}
+
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
@@ -750,6 +919,10 @@ func (f *FakeAdapter) UpdatePermission(ctx context.Context, providerID, permissi
 
 // SearchPeople searches for users in the directory.
 func (f *FakeAdapter) SearchPeople(ctx context.Context, query string) ([]*workspace.UserIdentity, error) {
+	if _, err := f.inject(ctx, "SearchPeople"); err != nil {
+		return nil, err
+	}
+
 	f.mu.RLock()
 	defer f.mu.RUnlock()
 
@@ -766,6 +939,10 @@ func (f *FakeAdapter) SearchPeople(ctx context.Context, query string) ([]*worksp
 
 // GetPerson retrieves a user by email.
 func (f *FakeAdapter) GetPerson(ctx context.Context, email string) (*workspace.UserIdentity, error) {
+	if _, err := f.inject(ctx, "GetPerson"); err != nil {
+		return nil, err
+	}
+
 	f.mu.RLock()
 	defer f.mu.RUnlock()
 
@@ -779,12 +956,22 @@ func (f *FakeAdapter) GetPerson(ctx context.Context, email string) (*workspace.U
 
 // GetPersonByUnifiedID retrieves user by unified ID.
 func (f *FakeAdapter) GetPersonByUnifiedID(ctx context.Context, unifiedID string) (*workspace.UserIdentity, error) {
+	ctx, err := f.inject(ctx, "GetPersonByUnifiedID")
+	if err != nil {
+		return nil, err
+	}
+
 	// For fake adapter, unified ID is the same as email
 	return f.GetPerson(ctx, unifiedID)
 }
 
 // ResolveIdentity resolves alternate identities for a user.
 func (f *FakeAdapter) ResolveIdentity(ctx context.Context, email string) (*workspace.UserIdentity, error) {
+	ctx, err := f.inject(ctx, "ResolveIdentity")
+	if err != nil {
+		return nil, err
+	}
+
 	// For fake adapter, no alternate identities
 	return f.GetPerson(ctx, email)
 }
@@ -795,6 +982,10 @@ func (f *FakeAdapter) ResolveIdentity(ctx context.Context, email string) (*works
 
 // ListTeams lists teams matching query.
 func (f *FakeAdapter) ListTeams(ctx context.Context, domain, query string, maxResults int64) ([]*workspace.Team, error) {
+	if _, err := f.inject(ctx, "ListTeams"); err != nil {
+		return nil, err
+	}
+
 	f.mu.RLock()
 	defer f.mu.RUnlock()
 
@@ -814,6 +1005,10 @@ func (f *FakeAdapter) ListTeams(ctx context.Context, domain, query string, maxRe
 
 // GetTeam retrieves team details.
 func (f *FakeAdapter) GetTeam(ctx context.Context, teamID string) (*workspace.Team, error) {
+	if _, err := f.inject(ctx, "GetTeam"); err != nil {
+		return nil, err
+	}
+
 	f.mu.RLock()
 	defer f.mu.RUnlock()
 
@@ -827,6 +1022,10 @@ func (f *FakeAdapter) GetTeam(ctx context.Context, teamID string) (*workspace.Te
 
 // GetUserTeams lists all teams a user belongs to.
 func (f *FakeAdapter) GetUserTeams(ctx context.Context, userEmail string) ([]*workspace.Team, error) {
+	if _, err := f.inject(ctx, "GetUserTeams"); err != nil {
+		return nil, err
+	}
+
 	f.mu.RLock()
 	defer f.mu.RUnlock()
 
@@ -847,6 +1046,10 @@ func (f *FakeAdapter) GetUserTeams(ctx context.Context, userEmail string) ([]*wo
 
 // GetTeamMembers lists all members of a team.
 func (f *FakeAdapter) GetTeamMembers(ctx context.Context, teamID string) ([]*workspace.UserIdentity, error) {
+	if _, err := f.inject(ctx, "GetTeamMembers"); err != nil {
+		return nil, err
+	}
+
 	f.mu.RLock()
 	defer f.mu.RUnlock()
 
@@ -871,6 +1074,10 @@ func (f *FakeAdapter) GetTeamMembers(ctx context.Context, teamID string) ([]*wor
 
 // SendEmail sends an email notification.
 func (f *FakeAdapter) SendEmail(ctx context.Context, to []string, from, subject, body string) error {
+	if _, err := f.inject(ctx, "SendEmail"); err != nil {
+		return err
+	}
+
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
@@ -888,6 +1095,10 @@ func (f *FakeAdapter) SendEmail(ctx context.Context, to []string, from, subject,
 
 // SendEmailWithTemplate sends email using template.
 func (f *FakeAdapter) SendEmailWithTemplate(ctx context.Context, to []string, template string, data map[string]any) error {
+	if _, err := f.inject(ctx, "SendEmailWithTemplate"); err != nil {
+		return err
+	}
+
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
@@ -0,0 +1,134 @@
+package mock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
+
+// AllMethods injects a fault into every FakeAdapter method without a fault of
+// its own.
+const AllMethods = "*"
+
+// ErrInjected is the default error returned by calls failed by a Fault.
+var ErrInjected = errors.New("injected fault")
+
+// Fault configures the failures and latency injected into the calls of a
+// FakeAdapter method, so tests can exercise retries, circuit breakers,
+// timeouts, and fallbacks deterministically.
+type Fault struct {
+	// Err is the error returned by failed calls, wrapped with the method name
+	// (default: ErrInjected).
+	Err error
+
+	// FailFirst is the number of calls that fail before calls succeed.
+	FailFirst int
+
+	// ErrorRate is the probability, between 0 and 1, that a call after the
+	// first FailFirst calls fails. Failures are drawn from a random number
+	// generator seeded with SetFaultSeed, so they're the same on every run.
+	ErrorRate float64
+
+	// Latency is added to each call before it fails or runs. Calls whose
+	// context is done while they wait return the context's error.
+	Latency time.Duration
+}
+
+// faultState is a fault and the number of calls it was applied to.
+type faultState struct {
+	Fault
+	calls int
+}
+
+// faultInjectedKey is the context key that marks calls a fault was already
+// applied to, so methods that call other methods are only faulted once.
+type faultInjectedKey struct{}
+
+// WithFault injects fault into the calls of method (e.g., "GetDocument"), or
+// of all methods without a fault of their own if method is AllMethods. Faults
+// apply to the methods tests call, not to the methods they call internally.
+func (f *FakeAdapter) WithFault(method string, fault Fault) *FakeAdapter {
+	f.faultMu.Lock()
+	defer f.faultMu.Unlock()
+	if f.faults == nil {
+		f.faults = make(map[string]*faultState)
+	}
+	f.faults[method] = &faultState{Fault: fault}
+	return f
+}
+
+// ClearFaults removes the faults injected with WithFault.
+func (f *FakeAdapter) ClearFaults() {
+	f.faultMu.Lock()
+	defer f.faultMu.Unlock()
+	f.faults = nil
+}
+
+// SetFaultSeed seeds the random number generator used by Fault.ErrorRate
+// (default: 1).
+func (f *FakeAdapter) SetFaultSeed(seed uint64) {
+	f.faultMu.Lock()
+	defer f.faultMu.Unlock()
+	f.faultRand = rand.New(rand.NewPCG(seed, seed))
+}
+
+// Calls returns the number of calls of method, including failed calls.
+func (f *FakeAdapter) Calls(method string) int {
+	f.faultMu.Lock()
+	defer f.faultMu.Unlock()
+	return f.calls[method]
+}
+
+// inject counts a call of method and applies its fault. It returns the context
+// for the call, and the error the call must fail with, if any.
+func (f *FakeAdapter) inject(
+	ctx context.Context, method string,
+) (context.Context, error) {
+	if ctx.Value(faultInjectedKey{}) != nil {
+		return ctx, nil
+	}
+	ctx = context.WithValue(ctx, faultInjectedKey{}, true)
+
+	f.faultMu.Lock()
+	if f.calls == nil {
+		f.calls = make(map[string]int)
+	}
+	f.calls[method]++
+	fault, ok := f.faults[method]
+	if !ok {
+		fault, ok = f.faults[AllMethods]
+	}
+	if !ok {
+		f.faultMu.Unlock()
+		return ctx, nil
+	}
+	fault.calls++
+	fail := fault.calls <= fault.FailFirst
+	if !fail && fault.ErrorRate > 0 {
+		if f.faultRand == nil {
+			f.faultRand = rand.New(rand.NewPCG(1, 1))
+		}
+		fail = f.faultRand.Float64() < fault.ErrorRate
+	}
+	latency, err := fault.Latency, fault.Err
+	f.faultMu.Unlock()
+
+	if latency > 0 {
+		t := time.NewTimer(latency)
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+			return ctx, ctx.Err()
+		}
+	}
+	if fail {
+		if err == nil {
+			err = ErrInjected
+		}
+		return ctx, fmt.Errorf("%s: %w", method, err)
+	}
+	return ctx, nil
+}
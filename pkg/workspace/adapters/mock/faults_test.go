@@ -0,0 +1,109 @@
+package mock
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeAdapter_Faults(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("first calls fail, then calls succeed", func(t *testing.T) {
+		adapter := NewFakeAdapter().
+			WithPerson(&workspace.UserIdentity{Email: "a@example.com"}).
+			WithFault("GetPerson", Fault{FailFirst: 2})
+
+		for range 2 {
+			_, err := adapter.GetPerson(ctx, "a@example.com")
+			assert.ErrorIs(t, err, ErrInjected)
+			assert.ErrorContains(t, err, "GetPerson: injected fault")
+		}
+		person, err := adapter.GetPerson(ctx, "a@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, "a@example.com", person.Email)
+		assert.Equal(t, 3, adapter.Calls("GetPerson"))
+
+		// Other methods aren't faulted.
+		_, err = adapter.SearchPeople(ctx, "a")
+		assert.NoError(t, err)
+	})
+
+	t.Run("custom errors", func(t *testing.T) {
+		errUnavailable := errors.New("503 Service Unavailable")
+		adapter := NewFakeAdapter().
+			WithFault(AllMethods, Fault{FailFirst: 1, Err: errUnavailable})
+
+		err := adapter.SendEmail(ctx, []string{"a@example.com"}, "", "Hi", "")
+		assert.ErrorIs(t, err, errUnavailable)
+		assert.Empty(t, adapter.EmailsSent)
+	})
+
+	t.Run("error rates are deterministic", func(t *testing.T) {
+		failures := func(seed uint64) []bool {
+			adapter := NewFakeAdapter().
+				WithFault("ListTeams", Fault{ErrorRate: 0.5})
+			adapter.SetFaultSeed(seed)
+			var failed []bool
+			for range 20 {
+				_, err := adapter.ListTeams(ctx, "", "", 0)
+				failed = append(failed, err != nil)
+			}
+			return failed
+		}
+
+		first := failures(42)
+		assert.Equal(t, first, failures(42))
+		assert.Contains(t, first, true)
+		assert.Contains(t, first, false)
+	})
+
+	t.Run("latency", func(t *testing.T) {
+		adapter := NewFakeAdapter().
+			WithFault(AllMethods, Fault{Latency: 20 * time.Millisecond})
+
+		start := time.Now()
+		_, err := adapter.ListTeams(ctx, "", "", 0)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+
+		// Calls stop waiting when their context is done.
+		timeoutCtx, cancel := context.WithTimeout(ctx, time.Millisecond)
+		defer cancel()
+		_, err = adapter.ListTeams(timeoutCtx, "", "", 0)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+		// Provider timeouts apply to slow calls.
+		p := workspace.WithTimeouts(adapter, workspace.Timeouts{
+			Default: time.Millisecond,
+		})
+		_, err = p.GetTeam(ctx, "team")
+		assert.ErrorIs(t, err, workspace.ErrTimeout)
+	})
+
+	t.Run("nested calls are faulted once", func(t *testing.T) {
+		adapter := NewFakeAdapter().
+			WithFault("CreateDocumentWithUUID", Fault{FailFirst: 1})
+
+		_, err := adapter.CreateDocument(ctx, "", "folder", "Doc")
+		require.NoError(t, err)
+		assert.Equal(t, 1, adapter.Calls("CreateDocument"))
+		assert.Equal(t, 0, adapter.Calls("CreateDocumentWithUUID"))
+	})
+
+	t.Run("clear faults", func(t *testing.T) {
+		adapter := NewFakeAdapter().
+			WithFault(AllMethods, Fault{ErrorRate: 1})
+		_, err := adapter.ListTeams(ctx, "", "", 0)
+		require.Error(t, err)
+
+		adapter.ClearFaults()
+		_, err = adapter.ListTeams(ctx, "", "", 0)
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,498 @@
+package mock
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/docid"
+	"github.com/hashicorp-forge/hermes/pkg/workspace"
+	"gopkg.in/yaml.v3"
+)
+
+// Fixture is a scenario of workspace data (people, teams, folders, documents
+// with their content and permissions) that seeds a FakeAdapter. Fixtures are
+// read from YAML files so docker compose environments and handler tests can
+// share the same realistic data instead of building it by hand.
+//
+// Example:
+//
+//	people:
+//	  - email: alice@example.com
+//	    display_name: Alice
+//	teams:
+//	  - id: team-eng
+//	    name: Engineering
+//	    members: [alice@example.com]
+//	documents:
+//	  - uuid: 550e8400-e29b-41d4-a716-446655440000
+//	    provider_id: fake:rfc-1
+//	    name: "RFC-001: Widgets"
+//	    owner: alice@example.com
+//	    content:
+//	      body: "# Widgets"
+//	    permissions:
+//	      - email: bob@example.com
+//	        role: writer
+type Fixture struct {
+	People    []FixturePerson   `yaml:"people,omitempty"`
+	Teams     []FixtureTeam     `yaml:"teams,omitempty"`
+	Folders   []FixtureFolder   `yaml:"folders,omitempty"`
+	Documents []FixtureDocument `yaml:"documents,omitempty"`
+}
+
+// FixturePerson is a person in the directory.
+type FixturePerson struct {
+	Email           string                     `yaml:"email"`
+	DisplayName     string                     `yaml:"display_name,omitempty"`
+	PhotoURL        string                     `yaml:"photo_url,omitempty"`
+	UnifiedUserID   string                     `yaml:"unified_user_id,omitempty"`
+	AlternateEmails []FixtureAlternateIdentity `yaml:"alternate_emails,omitempty"`
+}
+
+// FixtureAlternateIdentity is a person's identity in another identity provider.
+type FixtureAlternateIdentity struct {
+	Email          string `yaml:"email"`
+	Provider       string `yaml:"provider"`
+	ProviderUserID string `yaml:"provider_user_id,omitempty"`
+}
+
+// FixtureTeam is a team and the emails of its members.
+type FixtureTeam struct {
+	ID          string   `yaml:"id"`
+	Email       string   `yaml:"email,omitempty"`
+	Name        string   `yaml:"name"`
+	Description string   `yaml:"description,omitempty"`
+	Members     []string `yaml:"members,omitempty"`
+}
+
+// FixtureFolder is a subfolder of a parent folder.
+type FixtureFolder struct {
+	ID     string `yaml:"id"`
+	Parent string `yaml:"parent"`
+	Name   string `yaml:"name"`
+}
+
+// FixtureDocument is a document with its content and permissions. People are
+// referenced by email; owners and contributors that aren't in the fixture's
+// people are created with only their email.
+type FixtureDocument struct {
+	// UUID is generated if it's empty.
+	UUID           string              `yaml:"uuid,omitempty"`
+	ProviderID     string              `yaml:"provider_id"`
+	Name           string              `yaml:"name"`
+	MimeType       string              `yaml:"mime_type,omitempty"`
+	CreatedTime    time.Time           `yaml:"created_time,omitempty"`
+	ModifiedTime   time.Time           `yaml:"modified_time,omitempty"`
+	Owner          string              `yaml:"owner,omitempty"`
+	OwningTeam     string              `yaml:"owning_team,omitempty"`
+	Contributors   []string            `yaml:"contributors,omitempty"`
+	Parents        []string            `yaml:"parents,omitempty"`
+	Project        string              `yaml:"project,omitempty"`
+	Tags           []string            `yaml:"tags,omitempty"`
+	SyncStatus     string              `yaml:"sync_status,omitempty"`
+	WorkflowStatus string              `yaml:"workflow_status,omitempty"`
+	ContentHash    string              `yaml:"content_hash,omitempty"`
+	Metadata       map[string]any      `yaml:"metadata,omitempty"`
+	Content        *FixtureContent     `yaml:"content,omitempty"`
+	Permissions    []FixturePermission `yaml:"permissions,omitempty"`
+}
+
+// FixtureContent is the content of a document.
+type FixtureContent struct {
+	// Title defaults to the document's name.
+	Title string `yaml:"title,omitempty"`
+	Body  string `yaml:"body"`
+	// Format defaults to "markdown".
+	Format string `yaml:"format,omitempty"`
+}
+
+// FixturePermission grants access to a document.
+type FixturePermission struct {
+	ID    string `yaml:"id,omitempty"`
+	Email string `yaml:"email,omitempty"`
+	// Role is "owner", "writer", or "reader".
+	Role string `yaml:"role"`
+	// Type defaults to "user".
+	Type string `yaml:"type,omitempty"`
+}
+
+// ReadFixture reads a YAML fixture. Unknown fields are errors, so typos in
+// fixtures don't silently drop data.
+func ReadFixture(r io.Reader) (*Fixture, error) {
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+
+	var fx Fixture
+	if err := dec.Decode(&fx); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("error decoding fixture: %w", err)
+	}
+	return &fx, nil
+}
+
+// ReadFixtureFile reads a YAML fixture from a file.
+func ReadFixtureFile(path string) (*Fixture, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading fixture: %w", err)
+	}
+	fx, err := ReadFixture(bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return fx, nil
+}
+
+// NewFakeAdapterFromFixture creates a fake adapter seeded with the fixture in
+// the YAML file at path.
+func NewFakeAdapterFromFixture(path string) (*FakeAdapter, error) {
+	fx, err := ReadFixtureFile(path)
+	if err != nil {
+		return nil, err
+	}
+	f := NewFakeAdapter()
+	if err := f.LoadFixture(fx); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return f, nil
+}
+
+// LoadFixture adds the data in fx to the fake adapter, replacing documents,
+// people, teams, and folders with the same IDs. Documents with content get an
+// initial revision. Nothing is added if the fixture is invalid.
+func (f *FakeAdapter) LoadFixture(fx *Fixture) error {
+	if err := fx.validate(); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, p := range fx.People {
+		person := &workspace.UserIdentity{
+			Email:         p.Email,
+			DisplayName:   p.DisplayName,
+			PhotoURL:      p.PhotoURL,
+			UnifiedUserID: p.UnifiedUserID,
+		}
+		for _, alt := range p.AlternateEmails {
+			person.AlternateEmails = append(person.AlternateEmails,
+				workspace.AlternateIdentity{
+					Email:          alt.Email,
+					Provider:       alt.Provider,
+					ProviderUserID: alt.ProviderUserID,
+				})
+		}
+		f.People[p.Email] = person
+	}
+
+	for _, t := range fx.Teams {
+		f.Teams[t.ID] = &workspace.Team{
+			ID:           t.ID,
+			Email:        t.Email,
+			Name:         t.Name,
+			Description:  t.Description,
+			MemberCount:  len(t.Members),
+			ProviderType: "fake",
+			ProviderID:   t.ID,
+		}
+		for _, email := range t.Members {
+			f.addTeamMemberUnsafe(t.ID, email)
+		}
+	}
+
+	for _, folder := range fx.Folders {
+		if f.Folders[folder.Parent] == nil {
+			f.Folders[folder.Parent] = make(map[string]string)
+		}
+		f.Folders[folder.Parent][folder.Name] = folder.ID
+	}
+
+	for _, d := range fx.Documents {
+		f.loadDocumentUnsafe(d)
+	}
+
+	return nil
+}
+
+// loadDocumentUnsafe stores a fixture document (caller must hold lock).
+func (f *FakeAdapter) loadDocumentUnsafe(d FixtureDocument) {
+	uuid := docid.NewUUID()
+	if d.UUID != "" {
+		uuid = docid.MustParseUUID(d.UUID) // Checked by validate.
+	}
+	syncStatus := d.SyncStatus
+	if syncStatus == "" {
+		syncStatus = "canonical"
+	}
+
+	doc := &workspace.DocumentMetadata{
+		UUID:             uuid,
+		ProviderType:     "fake",
+		ProviderID:       d.ProviderID,
+		Name:             d.Name,
+		MimeType:         d.MimeType,
+		CreatedTime:      d.CreatedTime,
+		ModifiedTime:     d.ModifiedTime,
+		OwningTeam:       d.OwningTeam,
+		Parents:          d.Parents,
+		Project:          d.Project,
+		Tags:             d.Tags,
+		SyncStatus:       syncStatus,
+		WorkflowStatus:   d.WorkflowStatus,
+		ContentHash:      d.ContentHash,
+		ExtendedMetadata: d.Metadata,
+	}
+	if d.Owner != "" {
+		doc.Owner = f.personUnsafe(d.Owner)
+	}
+	for _, email := range d.Contributors {
+		doc.Contributors = append(doc.Contributors, *f.personUnsafe(email))
+	}
+
+	// Replace the previous document with the same provider ID, if any.
+	if prev, ok := f.Documents[d.ProviderID]; ok {
+		delete(f.DocumentsByUUID, prev.UUID)
+	}
+	f.Documents[d.ProviderID] = doc
+	f.DocumentsByUUID[uuid] = doc
+	delete(f.Contents, d.ProviderID)
+	delete(f.Revisions, d.ProviderID)
+	delete(f.Permissions, d.ProviderID)
+	delete(f.revisionBodies, d.ProviderID)
+
+	if d.Content != nil {
+		revision := &workspace.BackendRevision{
+			ProviderType: "fake",
+			RevisionID:   "1",
+			ModifiedTime: d.ModifiedTime,
+		}
+		f.Revisions[d.ProviderID] = []*workspace.BackendRevision{revision}
+		f.setRevisionBodyUnsafe(d.ProviderID, revision.RevisionID, d.Content.Body)
+
+		title := d.Content.Title
+		if title == "" {
+			title = d.Name
+		}
+		format := d.Content.Format
+		if format == "" {
+			format = "markdown"
+		}
+		f.Contents[d.ProviderID] = &workspace.DocumentContent{
+			UUID:            uuid,
+			ProviderID:      d.ProviderID,
+			Title:           title,
+			Body:            d.Content.Body,
+			Format:          format,
+			BackendRevision: revision,
+			ContentHash:     fmt.Sprintf("%x", sha256.Sum256([]byte(d.Content.Body))),
+			LastModified:    d.ModifiedTime,
+		}
+	}
+
+	for i, p := range d.Permissions {
+		perm := &workspace.FilePermission{
+			ID:    p.ID,
+			Email: p.Email,
+			Role:  p.Role,
+			Type:  p.Type,
+		}
+		if perm.Type == "" {
+			perm.Type = "user"
+		}
+		if perm.ID == "" {
+			perm.ID = fmt.Sprintf("perm-%s-%d", d.ProviderID, i+1)
+		}
+		if perm.Email != "" {
+			if person, ok := f.People[perm.Email]; ok {
+				perm.User = person
+			}
+		}
+		f.Permissions[d.ProviderID] = append(f.Permissions[d.ProviderID], perm)
+	}
+}
+
+// personUnsafe returns the person with email, or a person with only an email
+// if they aren't in the directory (caller must hold lock).
+func (f *FakeAdapter) personUnsafe(email string) *workspace.UserIdentity {
+	if person, ok := f.People[email]; ok {
+		return person
+	}
+	return &workspace.UserIdentity{Email: email}
+}
+
+// addTeamMemberUnsafe adds a user to a team once (caller must hold lock).
+func (f *FakeAdapter) addTeamMemberUnsafe(teamID, email string) {
+	for _, member := range f.TeamMembers[teamID] {
+		if member == email {
+			return
+		}
+	}
+	f.TeamMembers[teamID] = append(f.TeamMembers[teamID], email)
+	f.UserTeams[email] = append(f.UserTeams[email], teamID)
+}
+
+// validate checks the fields required to load the fixture.
+func (fx *Fixture) validate() error {
+	var errs []error
+	for i, p := range fx.People {
+		if p.Email == "" {
+			errs = append(errs, fmt.Errorf("people[%d]: email is required", i))
+		}
+	}
+	for i, t := range fx.Teams {
+		if t.ID == "" {
+			errs = append(errs, fmt.Errorf("teams[%d]: id is required", i))
+		}
+	}
+	for i, folder := range fx.Folders {
+		if folder.ID == "" || folder.Parent == "" || folder.Name == "" {
+			errs = append(errs, fmt.Errorf(
+				"folders[%d]: id, parent, and name are required", i))
+		}
+	}
+	providerIDs := make(map[string]bool)
+	for i, d := range fx.Documents {
+		if d.ProviderID == "" {
+			errs = append(errs, fmt.Errorf(
+				"documents[%d]: provider_id is required", i))
+		} else if providerIDs[d.ProviderID] {
+			errs = append(errs, fmt.Errorf(
+				"documents[%d]: duplicate provider_id %q", i, d.ProviderID))
+		}
+		providerIDs[d.ProviderID] = true
+		if d.UUID != "" {
+			if _, err := docid.ParseUUID(d.UUID); err != nil {
+				errs = append(errs, fmt.Errorf("documents[%d]: %w", i, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Snapshot returns the fake adapter's documents, content, permissions,
+// people, teams, and folders as a fixture, sorted by ID so snapshots of the
+// same data are identical. Times are in UTC, and content titles are omitted
+// when they're the document's name. Revision history and sent emails aren't
+// included.
+func (f *FakeAdapter) Snapshot() *Fixture {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	fx := &Fixture{}
+
+	for _, p := range f.People {
+		person := FixturePerson{
+			Email:         p.Email,
+			DisplayName:   p.DisplayName,
+			PhotoURL:      p.PhotoURL,
+			UnifiedUserID: p.UnifiedUserID,
+		}
+		for _, alt := range p.AlternateEmails {
+			person.AlternateEmails = append(person.AlternateEmails,
+				FixtureAlternateIdentity{
+					Email:          alt.Email,
+					Provider:       alt.Provider,
+					ProviderUserID: alt.ProviderUserID,
+				})
+		}
+		fx.People = append(fx.People, person)
+	}
+	sort.Slice(fx.People, func(i, j int) bool {
+		return fx.People[i].Email < fx.People[j].Email
+	})
+
+	for _, t := range f.Teams {
+		fx.Teams = append(fx.Teams, FixtureTeam{
+			ID:          t.ID,
+			Email:       t.Email,
+			Name:        t.Name,
+			Description: t.Description,
+			Members:     append([]string(nil), f.TeamMembers[t.ID]...),
+		})
+	}
+	sort.Slice(fx.Teams, func(i, j int) bool {
+		return fx.Teams[i].ID < fx.Teams[j].ID
+	})
+
+	for parent, subfolders := range f.Folders {
+		for name, id := range subfolders {
+			fx.Folders = append(fx.Folders, FixtureFolder{
+				ID:     id,
+				Parent: parent,
+				Name:   name,
+			})
+		}
+	}
+	sort.Slice(fx.Folders, func(i, j int) bool {
+		if fx.Folders[i].Parent != fx.Folders[j].Parent {
+			return fx.Folders[i].Parent < fx.Folders[j].Parent
+		}
+		return fx.Folders[i].Name < fx.Folders[j].Name
+	})
+
+	for providerID, doc := range f.Documents {
+		d := FixtureDocument{
+			UUID:           doc.UUID.String(),
+			ProviderID:     providerID,
+			Name:           doc.Name,
+			MimeType:       doc.MimeType,
+			CreatedTime:    doc.CreatedTime.UTC(),
+			ModifiedTime:   doc.ModifiedTime.UTC(),
+			OwningTeam:     doc.OwningTeam,
+			Parents:        doc.Parents,
+			Project:        doc.Project,
+			Tags:           doc.Tags,
+			SyncStatus:     doc.SyncStatus,
+			WorkflowStatus: doc.WorkflowStatus,
+			ContentHash:    doc.ContentHash,
+			Metadata:       doc.ExtendedMetadata,
+		}
+		if doc.Owner != nil {
+			d.Owner = doc.Owner.Email
+		}
+		for _, c := range doc.Contributors {
+			d.Contributors = append(d.Contributors, c.Email)
+		}
+		if content, ok := f.Contents[providerID]; ok {
+			d.Content = &FixtureContent{
+				Title:  content.Title,
+				Body:   content.Body,
+				Format: content.Format,
+			}
+			if content.Title == doc.Name {
+				d.Content.Title = ""
+			}
+		}
+		for _, p := range f.Permissions[providerID] {
+			d.Permissions = append(d.Permissions, FixturePermission{
+				ID:    p.ID,
+				Email: p.Email,
+				Role:  p.Role,
+				Type:  p.Type,
+			})
+		}
+		fx.Documents = append(fx.Documents, d)
+	}
+	sort.Slice(fx.Documents, func(i, j int) bool {
+		return fx.Documents[i].ProviderID < fx.Documents[j].ProviderID
+	})
+
+	return fx
+}
+
+// Dump writes a snapshot of the fake adapter as a YAML fixture, which can be
+// loaded with ReadFixture and LoadFixture.
+func (f *FakeAdapter) Dump(w io.Writer) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(f.Snapshot()); err != nil {
+		return fmt.Errorf("error encoding fixture: %w", err)
+	}
+	return enc.Close()
+}
@@ -0,0 +1,94 @@
+package mock
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/pkg/docid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeAdapter_Fixtures(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("load", func(t *testing.T) {
+		adapter, err := NewFakeAdapterFromFixture("testdata/fixture.yaml")
+		require.NoError(t, err)
+
+		doc, err := adapter.GetDocumentByUUID(ctx,
+			docid.MustParseUUID("550e8400-e29b-41d4-a716-446655440000"))
+		require.NoError(t, err)
+		assert.Equal(t, "fake:rfc-001", doc.ProviderID)
+		assert.Equal(t, "RFC-001: Widget Service", doc.Name)
+		assert.Equal(t, "canonical", doc.SyncStatus)
+		require.NotNil(t, doc.Owner)
+		assert.Equal(t, "Alice Adams", doc.Owner.DisplayName)
+		assert.Equal(t, "RFC", doc.ExtendedMetadata["doc_type"])
+
+		content, err := adapter.GetContent(ctx, "fake:rfc-001")
+		require.NoError(t, err)
+		assert.Contains(t, content.Body, "A service for managing widgets.")
+		assert.Equal(t, "markdown", content.Format)
+
+		rev, err := adapter.GetRevision(ctx, "fake:rfc-001", "1")
+		require.NoError(t, err)
+		assert.Equal(t, "1", rev.RevisionID)
+
+		perms, err := adapter.ListPermissions(ctx, "fake:rfc-001")
+		require.NoError(t, err)
+		require.Len(t, perms, 3)
+		assert.Equal(t, "writer", perms[1].Role)
+		assert.Equal(t, "user", perms[1].Type)
+		assert.Equal(t, "domain", perms[2].Type)
+
+		teams, err := adapter.GetUserTeams(ctx, "bob@example.com")
+		require.NoError(t, err)
+		require.Len(t, teams, 1)
+		assert.Equal(t, "Engineering", teams[0].Name)
+
+		folderID, err := adapter.GetSubfolder(ctx, "root", "Drafts")
+		require.NoError(t, err)
+		assert.Equal(t, "folder-drafts", folderID)
+	})
+
+	t.Run("dump round-trips", func(t *testing.T) {
+		adapter, err := NewFakeAdapterFromFixture("testdata/fixture.yaml")
+		require.NoError(t, err)
+		_, err = adapter.CreateDocument(ctx, "fake:prd-001", "folder-drafts", "Copy")
+		require.NoError(t, err)
+		require.NoError(t,
+			adapter.ShareDocument(ctx, "fake:prd-001", "alice@example.com", "reader"))
+
+		var dump bytes.Buffer
+		require.NoError(t, adapter.Dump(&dump))
+
+		fx, err := ReadFixture(&dump)
+		require.NoError(t, err)
+		reloaded := NewFakeAdapter()
+		require.NoError(t, reloaded.LoadFixture(fx))
+		assert.Equal(t, adapter.Snapshot(), reloaded.Snapshot())
+	})
+
+	t.Run("invalid fixtures", func(t *testing.T) {
+		_, err := ReadFixture(strings.NewReader("documents:\n  - nmae: typo\n"))
+		assert.ErrorContains(t, err, "field nmae not found")
+
+		fx, err := ReadFixture(strings.NewReader(`
+documents:
+  - provider_id: fake:1
+    uuid: not-a-uuid
+  - provider_id: fake:1
+  - name: No ID
+`))
+		require.NoError(t, err)
+		adapter := NewFakeAdapter()
+		err = adapter.LoadFixture(fx)
+		assert.ErrorContains(t, err, "documents[0]")
+		assert.ErrorContains(t, err, `documents[1]: duplicate provider_id "fake:1"`)
+		assert.ErrorContains(t, err, "documents[2]: provider_id is required")
+		assert.Empty(t, adapter.Documents)
+	})
+}
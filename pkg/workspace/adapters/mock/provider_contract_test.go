@@ -0,0 +1,17 @@
+package mock
+
+import (
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/pkg/workspace"
+	"github.com/hashicorp-forge/hermes/pkg/workspace/providertest"
+)
+
+func TestFakeAdapter_ProviderContract(t *testing.T) {
+	providertest.Run(t, providertest.Config{
+		New: func(t *testing.T) workspace.WorkspaceProvider {
+			return NewFakeAdapter()
+		},
+		FolderID: "contract-folder",
+	})
+}
@@ -1,6 +1,7 @@
 package s3
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha256"
 	"crypto/tls"
@@ -9,6 +10,7 @@ import (
 	"io"
 	"net/http"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -89,7 +91,8 @@ func NewAdapter(cfg *Config, logger hclog.Logger) (*Adapter, error) {
 		"bucket", cfg.Bucket,
 		"prefix", cfg.Prefix,
 		"versioning", cfg.VersioningEnabled,
-		"metadata_store", cfg.MetadataStore)
+		"metadata_store", cfg.MetadataStore,
+		"compression", cfg.Compression)
 
 	return adapter, nil
 }
@@ -221,12 +224,27 @@ func sanitizeFilename(name string) string {
 	return replacer.Replace(name)
 }
 
-// getObject retrieves an object from S3
+// getObject retrieves the content of an object from S3
 func (a *Adapter) getObject(ctx context.Context, key string) ([]byte, *string, error) {
+	content, result, err := a.getObjectVersion(ctx, key, "")
+	if err != nil {
+		return nil, nil, err
+	}
+	return content, result.VersionId, nil
+}
+
+// getObjectVersion retrieves the content of a version of an object from S3,
+// reassembling chunked content and decompressing compressed content. An empty
+// versionID retrieves the current version. The body of the returned output is
+// already read and closed.
+func (a *Adapter) getObjectVersion(ctx context.Context, key, versionID string) ([]byte, *s3.GetObjectOutput, error) {
 	input := &s3.GetObjectInput{
 		Bucket: aws.String(a.cfg.Bucket),
 		Key:    aws.String(key),
 	}
+	if versionID != "" {
+		input.VersionId = aws.String(versionID)
+	}
 
 	result, err := a.client.GetObject(ctx, input)
 	if err != nil {
@@ -240,33 +258,110 @@ func (a *Adapter) getObject(ctx context.Context, key string) ([]byte, *string, e
 		return nil, nil, fmt.Errorf("failed to read object content: %w", err)
 	}
 
-	return content, result.VersionId, nil
+	// Reassemble chunked content
+	prefix, n, err := parseChunks(result.Metadata)
+	if err != nil {
+		return nil, nil, err
+	}
+	if n > 0 {
+		content, err = a.getChunks(ctx, prefix, n)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	content, err = decodeContent(content, result.Metadata[metaContentEncoding])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return content, result, nil
 }
 
-// putObject stores an object in S3
+// putObject stores an object in S3, compressing content and storing it in
+// chunks as configured
 func (a *Adapter) putObject(ctx context.Context, key string, content []byte, metadata map[string]string) (*string, error) {
+	body, encoding, err := a.encodeContent(content)
+	if err != nil {
+		return nil, err
+	}
+
+	objectMetadata := make(map[string]string, len(metadata)+3)
+	for k, v := range metadata {
+		objectMetadata[k] = v
+	}
+	if encoding != "" {
+		objectMetadata[metaContentEncoding] = encoding
+	}
+
+	// Store large content in chunks
+	var prefix string
+	var chunks int
+	if chunkSize := a.cfg.ChunkSizeMB << 20; chunkSize > 0 && len(body) > chunkSize {
+		prefix = chunkPrefix(key, body)
+		chunks, err = a.putChunks(ctx, prefix, body, chunkSize)
+		if err != nil {
+			return nil, err
+		}
+		objectMetadata[metaChunks] = strconv.Itoa(chunks)
+		objectMetadata[metaChunkPrefix] = prefix
+		body = nil
+	}
+
+	// Without versioning, the chunks of the content being replaced aren't
+	// referenced by any revision once it's replaced
+	var oldPrefix string
+	var oldChunks int
+	if !a.versioningEnabled {
+		oldPrefix, oldChunks, err = a.objectChunks(ctx, key)
+		if err != nil {
+			a.logger.Warn("failed to get chunks of replaced content",
+				"key", key, "error", err)
+		}
+	}
+
 	input := &s3.PutObjectInput{
 		Bucket:      aws.String(a.cfg.Bucket),
 		Key:         aws.String(key),
-		Body:        strings.NewReader(string(content)),
+		Body:        bytes.NewReader(body),
 		ContentType: aws.String(a.cfg.DefaultMimeType),
 	}
 
 	// Add metadata if provided
-	if len(metadata) > 0 {
-		input.Metadata = metadata
+	if len(objectMetadata) > 0 {
+		input.Metadata = objectMetadata
 	}
 
 	result, err := a.client.PutObject(ctx, input)
 	if err != nil {
+		if chunks > 0 && prefix != oldPrefix {
+			a.deleteChunks(ctx, prefix, chunks)
+		}
 		return nil, fmt.Errorf("failed to put object to S3: %w", err)
 	}
 
+	if oldChunks > 0 && oldPrefix != prefix {
+		a.deleteChunks(ctx, oldPrefix, oldChunks)
+	}
+
 	return result.VersionId, nil
 }
 
-// deleteObject deletes an object from S3
+// deleteObject deletes an object from S3. Without versioning, the chunks of
+// its content are deleted too; with versioning, they're kept for its
+// revisions
 func (a *Adapter) deleteObject(ctx context.Context, key string) error {
+	var prefix string
+	var chunks int
+	if !a.versioningEnabled {
+		var err error
+		prefix, chunks, err = a.objectChunks(ctx, key)
+		if err != nil {
+			a.logger.Warn("failed to get chunks of deleted content",
+				"key", key, "error", err)
+		}
+	}
+
 	input := &s3.DeleteObjectInput{
 		Bucket: aws.String(a.cfg.Bucket),
 		Key:    aws.String(key),
@@ -277,6 +372,10 @@ func (a *Adapter) deleteObject(ctx context.Context, key string) error {
 		return fmt.Errorf("failed to delete object from S3: %w", err)
 	}
 
+	if chunks > 0 {
+		a.deleteChunks(ctx, prefix, chunks)
+	}
+
 	return nil
 }
 
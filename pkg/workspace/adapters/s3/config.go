@@ -35,6 +35,11 @@ type Config struct {
 	RequestTimeoutSeconds    int `hcl:"request_timeout_seconds"`    // Request timeout (default: 30)
 	ConnectionTimeoutSeconds int `hcl:"connection_timeout_seconds"` // Connection timeout (default: 10)
 
+	// Compression and Chunking
+	Compression            string `hcl:"compression"`              // Content compression: "gzip" or "none" (default: "gzip")
+	CompressionThresholdKB int    `hcl:"compression_threshold_kb"` // Only compress content of at least this size (default: 16)
+	ChunkSizeMB            int    `hcl:"chunk_size_mb"`            // Store (compressed) content larger than this in chunks (default: 64)
+
 	// TLS/SSL Settings
 	UseSSL             bool   `hcl:"use_ssl"`              // Use SSL/TLS (default: true)
 	InsecureSkipVerify bool   `hcl:"insecure_skip_verify"` // Skip SSL certificate verification (for testing only)
@@ -70,6 +75,17 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("invalid metadata_store: %s (must be one of: s3-tags, dynamodb, manifest)", c.MetadataStore)
 	}
 
+	// Validate compression option
+	if c.Compression != "" && c.Compression != "gzip" && c.Compression != "none" {
+		return fmt.Errorf("invalid compression: %s (must be one of: gzip, none)", c.Compression)
+	}
+	if c.CompressionThresholdKB < 0 {
+		return fmt.Errorf("compression_threshold_kb must not be negative")
+	}
+	if c.ChunkSizeMB < 0 {
+		return fmt.Errorf("chunk_size_mb must not be negative")
+	}
+
 	// Validate DynamoDB configuration if using DynamoDB metadata store
 	if c.MetadataStore == "dynamodb" {
 		if c.DynamoDBTable == "" {
@@ -94,6 +110,15 @@ func (c *Config) SetDefaults() {
 	if c.MultipartThresholdMB == 0 {
 		c.MultipartThresholdMB = 100
 	}
+	if c.Compression == "" {
+		c.Compression = "gzip"
+	}
+	if c.CompressionThresholdKB == 0 {
+		c.CompressionThresholdKB = 16
+	}
+	if c.ChunkSizeMB == 0 {
+		c.ChunkSizeMB = 64
+	}
 	if c.RetryMaxAttempts == 0 {
 		c.RetryMaxAttempts = 3
 	}
@@ -0,0 +1,200 @@
+package s3
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// Content encoding
+//
+// Document content of at least compression_threshold_kb is stored
+// gzip-compressed, and (compressed) content larger than chunk_size_mb is
+// stored in chunks next to the document object, which then has no body. Both
+// are recorded in the user metadata of the document object, so content is
+// decoded transparently whatever the configuration was when it was written,
+// and each version of the object points to the chunks of its content.
+
+// User metadata keys of document objects
+const (
+	metaContentEncoding = "hermes-content-encoding" // "gzip" if compressed
+	metaChunks          = "hermes-chunks"           // Number of chunks
+	metaChunkPrefix     = "hermes-chunk-prefix"     // Key prefix of chunks
+)
+
+// chunksInfix separates the key of a document object from the keys of its
+// chunks.
+const chunksInfix = ".chunks/"
+
+// isChunkKey returns true if key is the key of a content chunk.
+func isChunkKey(key string) bool {
+	return strings.Contains(key, chunksInfix)
+}
+
+// encodeContent returns content compressed with gzip if compression is
+// enabled and content is at least the compression threshold, and the content
+// encoding, which is empty if content isn't compressed.
+func (a *Adapter) encodeContent(content []byte) ([]byte, string, error) {
+	if a.cfg.Compression != "gzip" ||
+		len(content) < a.cfg.CompressionThresholdKB<<10 {
+		return content, "", nil
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(content); err != nil {
+		return nil, "", fmt.Errorf("failed to compress content: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, "", fmt.Errorf("failed to compress content: %w", err)
+	}
+
+	// Keep content that doesn't compress as is.
+	if buf.Len() >= len(content) {
+		return content, "", nil
+	}
+	return buf.Bytes(), "gzip", nil
+}
+
+// decodeContent returns data with the content encoding encoding decoded.
+func decodeContent(data []byte, encoding string) ([]byte, error) {
+	switch encoding {
+	case "":
+		return data, nil
+	case "gzip":
+		zr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress content: %w", err)
+		}
+		defer zr.Close()
+		content, err := io.ReadAll(zr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress content: %w", err)
+		}
+		return content, nil
+	default:
+		return nil, fmt.Errorf("unsupported content encoding %q", encoding)
+	}
+}
+
+// splitChunks splits data into chunks of at most size bytes.
+func splitChunks(data []byte, size int) [][]byte {
+	var chunks [][]byte
+	for len(data) > size {
+		chunks = append(chunks, data[:size])
+		data = data[size:]
+	}
+	return append(chunks, data)
+}
+
+// chunkPrefix returns the key prefix of the chunks of data stored for the
+// document object with key key. Prefixes depend on data, so chunks of
+// previous versions of the object aren't overwritten.
+func chunkPrefix(key string, data []byte) string {
+	sum := sha256.Sum256(data)
+	return key + chunksInfix + hex.EncodeToString(sum[:8]) + "/"
+}
+
+// chunkKey returns the key of chunk i with key prefix prefix.
+func chunkKey(prefix string, i int) string {
+	return fmt.Sprintf("%s%05d", prefix, i)
+}
+
+// putChunks stores data in chunks of at most size bytes under prefix, and
+// returns the number of chunks. Chunks that were stored are deleted if storing
+// one fails.
+func (a *Adapter) putChunks(
+	ctx context.Context, prefix string, data []byte, size int,
+) (int, error) {
+	chunks := splitChunks(data, size)
+	for i, chunk := range chunks {
+		if _, err := a.client.PutObject(ctx, &s3.PutObjectInput{
+			Bucket:      aws.String(a.cfg.Bucket),
+			Key:         aws.String(chunkKey(prefix, i)),
+			Body:        bytes.NewReader(chunk),
+			ContentType: aws.String("application/octet-stream"),
+		}); err != nil {
+			a.deleteChunks(ctx, prefix, i)
+			return 0, fmt.Errorf("failed to put chunk %d to S3: %w", i, err)
+		}
+	}
+	return len(chunks), nil
+}
+
+// getChunks returns the data stored in n chunks under prefix.
+func (a *Adapter) getChunks(ctx context.Context, prefix string, n int) ([]byte, error) {
+	var data bytes.Buffer
+	for i := 0; i < n; i++ {
+		result, err := a.client.GetObject(ctx, &s3.GetObjectInput{
+			Bucket: aws.String(a.cfg.Bucket),
+			Key:    aws.String(chunkKey(prefix, i)),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get chunk %d from S3: %w", i, err)
+		}
+		_, err = io.Copy(&data, result.Body)
+		result.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read chunk %d: %w", i, err)
+		}
+	}
+	return data.Bytes(), nil
+}
+
+// deleteChunks deletes n chunks stored under prefix. Errors are logged, as
+// leftover chunks only take up space.
+func (a *Adapter) deleteChunks(ctx context.Context, prefix string, n int) {
+	for i := 0; i < n; i++ {
+		if _, err := a.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+			Bucket: aws.String(a.cfg.Bucket),
+			Key:    aws.String(chunkKey(prefix, i)),
+		}); err != nil {
+			a.logger.Warn("failed to delete content chunk",
+				"key", chunkKey(prefix, i), "error", err)
+		}
+	}
+}
+
+// objectChunks returns the key prefix and number of chunks of the current
+// version of the object with key key. It returns zero chunks if the object
+// doesn't exist or isn't chunked.
+func (a *Adapter) objectChunks(ctx context.Context, key string) (string, int, error) {
+	result, err := a.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(a.cfg.Bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		var notFound *types.NotFound
+		if errors.As(err, &notFound) {
+			return "", 0, nil
+		}
+		return "", 0, fmt.Errorf("failed to get object metadata: %w", err)
+	}
+	return parseChunks(result.Metadata)
+}
+
+// parseChunks returns the key prefix and number of chunks recorded in the user
+// metadata of a document object.
+func parseChunks(metadata map[string]string) (string, int, error) {
+	s := metadata[metaChunks]
+	if s == "" {
+		return "", 0, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 1 || metadata[metaChunkPrefix] == "" {
+		return "", 0, fmt.Errorf("invalid chunk metadata: %s=%q, %s=%q",
+			metaChunks, s, metaChunkPrefix, metadata[metaChunkPrefix])
+	}
+	return metadata[metaChunkPrefix], n, nil
+}
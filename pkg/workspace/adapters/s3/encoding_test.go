@@ -0,0 +1,72 @@
+package s3
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeContent(t *testing.T) {
+	cfg := &Config{}
+	cfg.SetDefaults()
+	a := &Adapter{cfg: cfg}
+
+	// Small content isn't compressed.
+	small := []byte("# Small document")
+	body, encoding, err := a.encodeContent(small)
+	require.NoError(t, err)
+	assert.Empty(t, encoding)
+	assert.Equal(t, small, body)
+
+	// Large content is compressed, and decompressed transparently.
+	large := []byte(strings.Repeat("# Large document\n\nLorem ipsum.\n", 2000))
+	body, encoding, err = a.encodeContent(large)
+	require.NoError(t, err)
+	assert.Equal(t, "gzip", encoding)
+	assert.Less(t, len(body), len(large))
+	decoded, err := decodeContent(body, encoding)
+	require.NoError(t, err)
+	assert.Equal(t, large, decoded)
+
+	// Compression can be disabled.
+	a.cfg.Compression = "none"
+	body, encoding, err = a.encodeContent(large)
+	require.NoError(t, err)
+	assert.Empty(t, encoding)
+	assert.Equal(t, large, body)
+
+	_, err = decodeContent(body, "br")
+	assert.Error(t, err)
+}
+
+func TestSplitChunks(t *testing.T) {
+	data := []byte("0123456789")
+	assert.Equal(t, [][]byte{[]byte("0123"), []byte("4567"), []byte("89")},
+		splitChunks(data, 4))
+	assert.Equal(t, [][]byte{data}, splitChunks(data, 10))
+	assert.Equal(t, data, bytes.Join(splitChunks(data, 3), nil))
+}
+
+func TestParseChunks(t *testing.T) {
+	prefix := chunkPrefix("docs/doc.md", []byte("content"))
+	assert.True(t, isChunkKey(chunkKey(prefix, 0)))
+	assert.False(t, isChunkKey("docs/doc.md"))
+
+	p, n, err := parseChunks(map[string]string{
+		metaChunks:      "3",
+		metaChunkPrefix: prefix,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, prefix, p)
+	assert.Equal(t, 3, n)
+
+	_, n, err = parseChunks(map[string]string{"hermes-uuid": "x"})
+	require.NoError(t, err)
+	assert.Zero(t, n)
+
+	_, _, err = parseChunks(map[string]string{metaChunks: "three"})
+	assert.Error(t, err)
+}
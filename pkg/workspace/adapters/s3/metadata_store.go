@@ -200,6 +200,10 @@ func (s *S3TagsMetadataStore) List(ctx context.Context, prefix string) ([]string
 
 		for _, obj := range page.Contents {
 			key := aws.ToString(obj.Key)
+			// Skip content chunks
+			if isChunkKey(key) {
+				continue
+			}
 			providerID := fmt.Sprintf("s3:%s/%s", s.bucket, key)
 			providerIDs = append(providerIDs, providerID)
 		}
@@ -305,8 +309,8 @@ func (m *ManifestMetadataStore) List(ctx context.Context, prefix string) ([]stri
 
 		for _, obj := range page.Contents {
 			key := aws.ToString(obj.Key)
-			// Skip metadata files
-			if strings.HasSuffix(key, ".metadata.json") {
+			// Skip metadata files and content chunks
+			if strings.HasSuffix(key, ".metadata.json") || isChunkKey(key) {
 				continue
 			}
 			providerID := fmt.Sprintf("s3:%s/%s", m.bucket, key)
@@ -95,25 +95,11 @@ func (a *Adapter) GetRevisionContent(ctx context.Context, providerID, revisionID
 
 	objectKey := a.parseProviderID(providerID)
 
-	// Get object at specific version
-	input := &s3.GetObjectInput{
-		Bucket:    aws.String(a.cfg.Bucket),
-		Key:       aws.String(objectKey),
-		VersionId: aws.String(revisionID),
-	}
-
-	result, err := a.client.GetObject(ctx, input)
+	// Get content at specific version
+	contentBytes, result, err := a.getObjectVersion(ctx, objectKey, revisionID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get revision content: %w", err)
 	}
-	defer result.Body.Close()
-
-	// Read content
-	contentBytes := make([]byte, aws.ToInt64(result.ContentLength))
-	_, err = result.Body.Read(contentBytes)
-	if err != nil && err.Error() != "EOF" {
-		return nil, fmt.Errorf("failed to read revision content: %w", err)
-	}
 
 	content := string(contentBytes)
 	contentHash := computeContentHash(content)
@@ -14,24 +14,30 @@ import (
 // PermissionProvider stub implementation
 // =========================================================================
 
+// errNoPermissions is returned by permission operations, which S3 doesn't
+// support.
+var errNoPermissions = fmt.Errorf(
+	"S3 adapter does not support permissions natively - delegate to API provider: %w",
+	workspace.ErrNotImplemented)
+
 func (a *Adapter) ShareDocument(ctx context.Context, providerID, email, role string) error {
-	return fmt.Errorf("S3 adapter does not support permissions natively - delegate to API provider")
+	return errNoPermissions
 }
 
 func (a *Adapter) ShareDocumentWithDomain(ctx context.Context, providerID, domain, role string) error {
-	return fmt.Errorf("S3 adapter does not support permissions natively - delegate to API provider")
+	return errNoPermissions
 }
 
 func (a *Adapter) ListPermissions(ctx context.Context, providerID string) ([]*workspace.FilePermission, error) {
-	return nil, fmt.Errorf("S3 adapter does not support permissions natively - delegate to API provider")
+	return nil, errNoPermissions
 }
 
 func (a *Adapter) RemovePermission(ctx context.Context, providerID, permissionID string) error {
-	return fmt.Errorf("S3 adapter does not support permissions natively - delegate to API provider")
+	return errNoPermissions
 }
 
 func (a *Adapter) UpdatePermission(ctx context.Context, providerID, permissionID, newRole string) error {
-	return fmt.Errorf("S3 adapter does not support permissions natively - delegate to API provider")
+	return errNoPermissions
 }
 
 // =========================================================================
@@ -1,8 +1,10 @@
 package workspace
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"time"
 )
 
 var (
@@ -20,6 +22,9 @@ var (
 
 	// ErrNotImplemented is returned when a feature is not implemented.
 	ErrNotImplemented = errors.New("not implemented")
+
+	// ErrTimeout is returned when a provider operation times out.
+	ErrTimeout = errors.New("provider operation timed out")
 )
 
 // NotFoundError creates a not found error with context.
@@ -41,3 +46,10 @@ func InvalidInputError(field, reason string) error {
 func PermissionDeniedError(operation, resource string) error {
 	return fmt.Errorf("%w: cannot %s %s", ErrPermissionDenied, operation, resource)
 }
+
+// TimeoutError creates an error for a provider operation that didn't finish
+// within timeout. It matches both ErrTimeout and context.DeadlineExceeded.
+func TimeoutError(operation string, timeout time.Duration) error {
+	return fmt.Errorf("%w: %s didn't finish within %s: %w",
+		ErrTimeout, operation, timeout, context.DeadlineExceeded)
+}
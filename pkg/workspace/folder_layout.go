@@ -0,0 +1,144 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// FolderLayout resolves the folders that documents are stored in, so callers
+// don't need to know how a provider organizes documents. Folder IDs are
+// backend-specific IDs without a provider prefix, as accepted by CreateDocument,
+// CopyDocument, and MoveDocument.
+type FolderLayout interface {
+	// DraftsFolder returns the ID of the folder that drafts are created in.
+	DraftsFolder(ctx context.Context) (string, error)
+
+	// PublishedFolder returns the ID of the folder that published documents
+	// of document type docType and product are moved to.
+	PublishedFolder(ctx context.Context, docType, product string) (string, error)
+
+	// ShortcutsFolder returns the ID of the folder that shortcuts to
+	// published documents of document type docType and product are created
+	// in, or "" if the layout doesn't have shortcuts.
+	ShortcutsFolder(ctx context.Context, docType, product string) (string, error)
+}
+
+// Folders are the root folders of a FolderLayout.
+type Folders struct {
+	// Drafts is the ID of the folder that contains drafts.
+	Drafts string
+
+	// Published is the ID of the folder that contains published documents.
+	Published string
+
+	// Shortcuts is the ID of the folder that contains shortcuts to published
+	// documents, organized in doc type and product subfolders (e.g.,
+	// "Shortcuts/RFC/Terraform"). Shortcuts aren't created if it is empty.
+	Shortcuts string
+}
+
+// NewFolderLayout returns the folder layout of folders f in provider p.
+// Drafts are created in f.Drafts, and published documents are moved to
+// f.Published, which the indexer lists without descending into subfolders.
+// Shortcuts are created in doc type and product subfolders of f.Shortcuts,
+// which are created if they are missing.
+func NewFolderLayout(p DocumentProvider, f Folders) FolderLayout {
+	return &folderLayout{
+		p:          p,
+		f:          f,
+		subfolders: map[[2]string]string{},
+	}
+}
+
+type folderLayout struct {
+	p DocumentProvider
+	f Folders
+
+	// mu guards subfolders, and is held while subfolders are created so
+	// concurrent requests don't create duplicates.
+	mu sync.Mutex
+
+	// subfolders are the IDs of subfolders by {parent ID, name}.
+	subfolders map[[2]string]string
+}
+
+func (l *folderLayout) DraftsFolder(ctx context.Context) (string, error) {
+	if l.f.Drafts == "" {
+		return "", errors.New("drafts folder is not configured")
+	}
+	return l.f.Drafts, nil
+}
+
+func (l *folderLayout) PublishedFolder(
+	ctx context.Context, docType, product string,
+) (string, error) {
+	if l.f.Published == "" {
+		return "", errors.New("published documents folder is not configured")
+	}
+	return l.f.Published, nil
+}
+
+func (l *folderLayout) ShortcutsFolder(
+	ctx context.Context, docType, product string,
+) (string, error) {
+	if l.f.Shortcuts == "" {
+		return "", nil
+	}
+	if docType == "" || product == "" {
+		return "", InvalidInputError("docType/product", "cannot be empty")
+	}
+
+	docTypeFolderID, err := l.subfolder(ctx, l.f.Shortcuts, docType)
+	if err != nil {
+		return "", fmt.Errorf("error getting doc type shortcuts folder: %w", err)
+	}
+	productFolderID, err := l.subfolder(ctx, docTypeFolderID, product)
+	if err != nil {
+		return "", fmt.Errorf("error getting product shortcuts folder: %w", err)
+	}
+	return productFolderID, nil
+}
+
+// subfolder returns the ID of the subfolder named name of folder parentID,
+// creating it if it is missing.
+func (l *folderLayout) subfolder(
+	ctx context.Context, parentID, name string,
+) (string, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	key := [2]string{parentID, name}
+	if id, ok := l.subfolders[key]; ok {
+		return id, nil
+	}
+
+	// Providers report missing subfolders either with an empty ID or with
+	// ErrNotFound.
+	id, err := l.p.GetSubfolder(ctx, parentID, name)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return "", err
+	}
+	if err != nil || id == "" {
+		folder, err := l.p.CreateFolder(ctx, name, parentID)
+		if err != nil {
+			return "", err
+		}
+		id = folder.ProviderID
+	}
+
+	id = stripProviderPrefix(id)
+	l.subfolders[key] = id
+	return id, nil
+}
+
+// stripProviderPrefix returns the backend-specific ID of provider ID id (e.g.,
+// "abc" for "google:abc").
+func stripProviderPrefix(id string) string {
+	if i := strings.Index(id, ":"); i != -1 {
+		return id[i+1:]
+	}
+	return id
+}
@@ -0,0 +1,112 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// folderProvider stores folders in memory. Missing subfolders are reported
+// with an empty ID, like Google Drive, or with ErrNotFound if notFoundErr is
+// set.
+type folderProvider struct {
+	WorkspaceProvider
+	folders     map[[2]string]string
+	notFoundErr bool
+	getErr      error
+	created     int
+}
+
+func (p *folderProvider) GetSubfolder(ctx context.Context, parentID, name string) (string, error) {
+	if p.getErr != nil {
+		return "", p.getErr
+	}
+	id, ok := p.folders[[2]string{parentID, name}]
+	if !ok && p.notFoundErr {
+		return "", NotFoundError("folder", name)
+	}
+	return id, nil
+}
+
+func (p *folderProvider) CreateFolder(ctx context.Context, name, parentID string) (*DocumentMetadata, error) {
+	p.created++
+	id := fmt.Sprintf("folder%d", p.created)
+	p.folders[[2]string{parentID, name}] = id
+	return &DocumentMetadata{ProviderID: "fake:" + id, Name: name}, nil
+}
+
+func TestFolderLayout(t *testing.T) {
+	ctx := context.Background()
+	folders := Folders{
+		Drafts:    "drafts",
+		Published: "docs",
+		Shortcuts: "shortcuts",
+	}
+
+	t.Run("root folders", func(t *testing.T) {
+		l := NewFolderLayout(&folderProvider{}, folders)
+
+		id, err := l.DraftsFolder(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "drafts", id)
+
+		id, err = l.PublishedFolder(ctx, "RFC", "Terraform")
+		require.NoError(t, err)
+		assert.Equal(t, "docs", id)
+
+		_, err = NewFolderLayout(&folderProvider{}, Folders{}).DraftsFolder(ctx)
+		assert.Error(t, err)
+	})
+
+	for _, notFoundErr := range []bool{false, true} {
+		t.Run(fmt.Sprintf("missing shortcuts folders are created (ErrNotFound: %t)",
+			notFoundErr), func(t *testing.T) {
+			p := &folderProvider{
+				folders: map[[2]string]string{
+					{"shortcuts", "RFC"}: "rfc",
+				},
+				notFoundErr: notFoundErr,
+			}
+			l := NewFolderLayout(p, folders)
+
+			id, err := l.ShortcutsFolder(ctx, "RFC", "Terraform")
+			require.NoError(t, err)
+			assert.Equal(t, "folder1", id)
+			assert.Equal(t, "folder1", p.folders[[2]string{"rfc", "Terraform"}])
+
+			id, err = l.ShortcutsFolder(ctx, "PRD", "Terraform")
+			require.NoError(t, err)
+			assert.Equal(t, "folder3", id)
+			assert.Equal(t, 3, p.created)
+
+			// Folders are only looked up once.
+			p.getErr = errors.New("unavailable")
+			id, err = l.ShortcutsFolder(ctx, "RFC", "Terraform")
+			require.NoError(t, err)
+			assert.Equal(t, "folder1", id)
+		})
+	}
+
+	t.Run("errors getting folders aren't treated as missing folders", func(t *testing.T) {
+		p := &folderProvider{getErr: errors.New("unavailable")}
+		l := NewFolderLayout(p, folders)
+
+		_, err := l.ShortcutsFolder(ctx, "RFC", "Terraform")
+		assert.ErrorContains(t, err, "unavailable")
+		assert.Zero(t, p.created)
+	})
+
+	t.Run("no shortcuts folder", func(t *testing.T) {
+		p := &folderProvider{}
+		l := NewFolderLayout(p, Folders{Drafts: "drafts", Published: "docs"})
+
+		id, err := l.ShortcutsFolder(ctx, "RFC", "Terraform")
+		require.NoError(t, err)
+		assert.Empty(t, id)
+		assert.Zero(t, p.created)
+	})
+}
@@ -2,6 +2,7 @@ package workspace
 
 import (
 	"context"
+	"io"
 
 	"github.com/hashicorp-forge/hermes/pkg/docid"
 )
@@ -25,6 +26,9 @@ import (
 // 8. DocumentSyncProvider - Edge→Central synchronization
 // 9. DocumentMergeProvider - UUID merging for drift resolution
 // 10. IdentityJoinProvider - Cross-provider identity linking
+// 11. PeopleDirectoryProvider - Full directory listing for local caching
+// 12. AttachmentProvider - Storage of files attached to documents
+// 13. BatchPermissionProvider - Native batch sharing
 
 // ===================================================================
 // CORE INTERFACE: DocumentProvider
@@ -283,6 +287,59 @@ type IdentityJoinProvider interface {
 	ListLinkedIdentities(ctx context.Context, userEmail string) ([]*AlternateIdentity, error)
 }
 
+// ===================================================================
+// OPTIONAL INTERFACE: PeopleDirectoryProvider
+// ===================================================================
+// PeopleDirectoryProvider lists the whole people directory
+// This interface is OPTIONAL - used by the directory sync job to cache people
+// locally. Providers without it are synced with an empty SearchPeople query.
+type PeopleDirectoryProvider interface {
+	// ListPeople lists all people in the directory, including photos
+	ListPeople(ctx context.Context) ([]*UserIdentity, error)
+}
+
+// ===================================================================
+// OPTIONAL INTERFACE: HealthChecker
+// ===================================================================
+// HealthChecker checks that the provider backend is reachable
+// This interface is OPTIONAL - used by the server readiness check. Providers
+// without it are assumed to be reachable.
+type HealthChecker interface {
+	// Healthy returns an error if the provider backend is unreachable
+	Healthy(ctx context.Context) error
+}
+
+// ===================================================================
+// OPTIONAL INTERFACE: AttachmentProvider
+// ===================================================================
+// AttachmentProvider stores files attached to documents
+// This interface is OPTIONAL - used by the document attachments API. Files
+// can't be attached to documents of providers without it.
+type AttachmentProvider interface {
+	// PutAttachment stores a file attached to a document, replacing the file
+	// with the same name
+	PutAttachment(ctx context.Context, providerID, name string, r io.Reader) error
+
+	// GetAttachment opens a file attached to a document
+	// Returns: ErrNotFound if the file doesn't exist
+	GetAttachment(ctx context.Context, providerID, name string) (io.ReadCloser, error)
+
+	// DeleteAttachment deletes a file attached to a document
+	DeleteAttachment(ctx context.Context, providerID, name string) error
+}
+
+// ===================================================================
+// OPTIONAL INTERFACE: BatchPermissionProvider
+// ===================================================================
+// BatchPermissionProvider shares a document with several users at once
+// This interface is OPTIONAL - used by ShareDocumentBatch. Providers without it
+// are shared with one ShareDocument call per user, with bounded concurrency.
+type BatchPermissionProvider interface {
+	// ShareDocumentBatch grants each share on a document
+	// Returns: *ShareBatchError if some of the shares failed
+	ShareDocumentBatch(ctx context.Context, providerID string, shares []Share) error
+}
+
 // ===================================================================
 // COMPOSITE INTERFACE: WorkspaceProvider
 // ===================================================================
@@ -297,3 +354,16 @@ type WorkspaceProvider interface {
 	TeamProvider
 	NotificationProvider
 }
+
+// Unwrap returns the provider wrapped by p (e.g., to record metrics), or p if
+// it doesn't wrap a provider. Wrappers implement Unwrap() WorkspaceProvider.
+// Use it before asserting optional interfaces or adapter types on a provider.
+func Unwrap(p WorkspaceProvider) WorkspaceProvider {
+	for {
+		w, ok := p.(interface{ Unwrap() WorkspaceProvider })
+		if !ok {
+			return p
+		}
+		p = w.Unwrap()
+	}
+}
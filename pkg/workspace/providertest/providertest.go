@@ -0,0 +1,326 @@
+// Package providertest provides conformance tests for workspace.WorkspaceProvider
+// implementations, so every adapter (local, S3, API, mock, and future ones) is
+// verified against the same RFC-084 semantics.
+//
+// Adapters run the tests from their own test files:
+//
+//	func TestProviderContract(t *testing.T) {
+//		providertest.Run(t, providertest.Config{
+//			New: func(t *testing.T) workspace.WorkspaceProvider {
+//				return NewFakeAdapter()
+//			},
+//		})
+//	}
+package providertest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/pkg/docid"
+	"github.com/hashicorp-forge/hermes/pkg/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test names, for Config.Skip.
+const (
+	TestDocuments    = "Documents"
+	TestFolders      = "Folders"
+	TestUUIDLookups  = "UUIDLookups"
+	TestContent      = "Content"
+	TestBatchContent = "BatchContent"
+	TestRevisions    = "Revisions"
+	TestPermissions  = "Permissions"
+)
+
+// Config configures the conformance tests for a provider.
+type Config struct {
+	// New returns the provider to test. It's called once per test, so tests
+	// don't see each other's documents if it returns a new provider.
+	New func(t *testing.T) workspace.WorkspaceProvider
+
+	// FolderID is the folder documents are created in (default: no folder).
+	FolderID string
+
+	// Skip maps the names of tests that don't apply to the provider to the
+	// reason they're skipped (e.g., TestPermissions for providers that
+	// delegate permissions to another provider).
+	Skip map[string]string
+}
+
+// Run runs the conformance tests against the provider returned by cfg.New.
+func Run(t *testing.T, cfg Config) {
+	tests := []struct {
+		name string
+		fn   func(*testing.T, *suite)
+	}{
+		{TestDocuments, testDocuments},
+		{TestFolders, testFolders},
+		{TestUUIDLookups, testUUIDLookups},
+		{TestContent, testContent},
+		{TestBatchContent, testBatchContent},
+		{TestRevisions, testRevisions},
+		{TestPermissions, testPermissions},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if reason, ok := cfg.Skip[tt.name]; ok {
+				t.Skip(reason)
+			}
+			tt.fn(t, &suite{
+				ctx: context.Background(),
+				p:   cfg.New(t),
+				cfg: cfg,
+			})
+		})
+	}
+}
+
+// suite is the state of a conformance test.
+type suite struct {
+	ctx context.Context
+	p   workspace.WorkspaceProvider
+	cfg Config
+}
+
+// createDocument creates a document with content.
+func (s *suite) createDocument(t *testing.T, name, body string) *workspace.DocumentMetadata {
+	t.Helper()
+
+	doc, err := s.p.CreateDocument(s.ctx, "", s.cfg.FolderID, name)
+	require.NoError(t, err, "CreateDocument")
+	require.NotNil(t, doc)
+	if body != "" {
+		_, err := s.p.UpdateContent(s.ctx, doc.ProviderID, body)
+		require.NoError(t, err, "UpdateContent")
+	}
+	return doc
+}
+
+func testDocuments(t *testing.T, s *suite) {
+	doc, err := s.p.CreateDocument(s.ctx, "", s.cfg.FolderID, "Contract Document")
+	require.NoError(t, err)
+	assert.NotEmpty(t, doc.ProviderID, "created documents have a provider ID")
+	assert.False(t, doc.UUID.IsZero(), "created documents have a UUID")
+	assert.Equal(t, "Contract Document", doc.Name)
+
+	got, err := s.p.GetDocument(s.ctx, doc.ProviderID)
+	require.NoError(t, err)
+	assert.Equal(t, doc.ProviderID, got.ProviderID)
+	assert.Equal(t, doc.UUID, got.UUID)
+	assert.Equal(t, doc.Name, got.Name)
+
+	_, err = s.p.GetDocument(s.ctx, doc.ProviderID+"-missing")
+	assert.Error(t, err, "getting a missing document fails")
+
+	require.NoError(t, s.p.RenameDocument(s.ctx, doc.ProviderID, "Renamed"))
+	got, err = s.p.GetDocument(s.ctx, doc.ProviderID)
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed", got.Name)
+
+	// Copies are new documents with the same content.
+	_, err = s.p.UpdateContent(s.ctx, doc.ProviderID, "Copied content")
+	require.NoError(t, err)
+	copied, err := s.p.CopyDocument(s.ctx, doc.ProviderID, s.cfg.FolderID, "Copy")
+	require.NoError(t, err)
+	assert.NotEqual(t, doc.ProviderID, copied.ProviderID)
+	assert.Equal(t, "Copy", copied.Name)
+	content, err := s.p.GetContent(s.ctx, copied.ProviderID)
+	require.NoError(t, err)
+	assert.Equal(t, "Copied content", content.Body)
+
+	require.NoError(t, s.p.DeleteDocument(s.ctx, doc.ProviderID))
+	_, err = s.p.GetDocument(s.ctx, doc.ProviderID)
+	assert.Error(t, err, "deleted documents can't be retrieved")
+	_, err = s.p.GetDocument(s.ctx, copied.ProviderID)
+	assert.NoError(t, err, "deleting a document doesn't delete its copies")
+}
+
+func testFolders(t *testing.T, s *suite) {
+	folder, err := s.p.CreateFolder(s.ctx, "Contract Folder", s.cfg.FolderID)
+	require.NoError(t, err)
+	assert.NotEmpty(t, folder.ProviderID)
+
+	folderID, err := s.p.GetSubfolder(s.ctx, s.cfg.FolderID, "Contract Folder")
+	require.NoError(t, err)
+	assert.Equal(t, folder.ProviderID, folderID)
+
+	_, err = s.p.GetSubfolder(s.ctx, s.cfg.FolderID, "Missing Folder")
+	assert.Error(t, err, "getting a missing subfolder fails")
+
+	doc := s.createDocument(t, "Moved Document", "")
+	moved, err := s.p.MoveDocument(s.ctx, doc.ProviderID, folder.ProviderID)
+	require.NoError(t, err)
+	assert.Equal(t, doc.ProviderID, moved.ProviderID)
+	assert.Contains(t, moved.Parents, folder.ProviderID)
+
+	got, err := s.p.GetDocument(s.ctx, doc.ProviderID)
+	require.NoError(t, err)
+	assert.Contains(t, got.Parents, folder.ProviderID)
+}
+
+func testUUIDLookups(t *testing.T, s *suite) {
+	uuid := docid.NewUUID()
+	doc, err := s.p.CreateDocumentWithUUID(
+		s.ctx, uuid, "", s.cfg.FolderID, "Migrated Document")
+	require.NoError(t, err)
+	assert.Equal(t, uuid, doc.UUID, "explicit UUIDs are kept")
+
+	got, err := s.p.GetDocumentByUUID(s.ctx, uuid)
+	require.NoError(t, err)
+	assert.Equal(t, doc.ProviderID, got.ProviderID)
+	assert.Equal(t, uuid, got.UUID)
+
+	_, err = s.p.UpdateContent(s.ctx, doc.ProviderID, "Migrated content")
+	require.NoError(t, err)
+	content, err := s.p.GetContentByUUID(s.ctx, uuid)
+	require.NoError(t, err)
+	assert.Equal(t, "Migrated content", content.Body)
+	assert.Equal(t, uuid, content.UUID)
+
+	_, err = s.p.GetDocumentByUUID(s.ctx, docid.NewUUID())
+	assert.Error(t, err, "getting a missing UUID fails")
+
+	require.NoError(t, s.p.DeleteDocument(s.ctx, doc.ProviderID))
+	_, err = s.p.GetDocumentByUUID(s.ctx, uuid)
+	assert.Error(t, err, "deleted documents can't be retrieved by UUID")
+}
+
+func testContent(t *testing.T, s *suite) {
+	doc := s.createDocument(t, "Content Document", "")
+
+	updated, err := s.p.UpdateContent(s.ctx, doc.ProviderID, "# Version 1")
+	require.NoError(t, err)
+	assert.Equal(t, "# Version 1", updated.Body)
+	assert.NotEmpty(t, updated.ContentHash)
+	assert.NotNil(t, updated.BackendRevision,
+		"content is returned with its backend revision")
+
+	got, err := s.p.GetContent(s.ctx, doc.ProviderID)
+	require.NoError(t, err)
+	assert.Equal(t, doc.ProviderID, got.ProviderID)
+	assert.Equal(t, doc.UUID, got.UUID)
+	assert.Equal(t, "# Version 1", got.Body)
+	assert.Equal(t, updated.ContentHash, got.ContentHash)
+
+	updated, err = s.p.UpdateContent(s.ctx, doc.ProviderID, "# Version 2")
+	require.NoError(t, err)
+	assert.NotEqual(t, got.ContentHash, updated.ContentHash,
+		"content hashes change with content")
+
+	other := s.createDocument(t, "Other Document", "Other content")
+	cmp, err := s.p.CompareContent(s.ctx, doc.ProviderID, other.ProviderID)
+	require.NoError(t, err)
+	assert.False(t, cmp.ContentMatch)
+
+	_, err = s.p.UpdateContent(s.ctx, doc.ProviderID+"-missing", "content")
+	assert.Error(t, err, "updating a missing document fails")
+}
+
+func testBatchContent(t *testing.T, s *suite) {
+	first := s.createDocument(t, "First", "First content")
+	second := s.createDocument(t, "Second", "Second content")
+
+	contents, err := s.p.GetContentBatch(s.ctx, []string{
+		first.ProviderID,
+		first.ProviderID + "-missing",
+		second.ProviderID,
+	})
+	require.NoError(t, err, "missing documents don't fail batches")
+
+	bodies := make(map[string]string)
+	for _, c := range contents {
+		bodies[c.ProviderID] = c.Body
+	}
+	assert.Equal(t, map[string]string{
+		first.ProviderID:  "First content",
+		second.ProviderID: "Second content",
+	}, bodies, "batches return the content of existing documents")
+
+	contents, err = s.p.GetContentBatch(s.ctx, nil)
+	require.NoError(t, err)
+	assert.Empty(t, contents)
+}
+
+func testRevisions(t *testing.T, s *suite) {
+	doc := s.createDocument(t, "Revised Document", "Version 1")
+	_, err := s.p.UpdateContent(s.ctx, doc.ProviderID, "Version 2")
+	require.NoError(t, err)
+
+	revs, err := s.p.GetRevisionHistory(s.ctx, doc.ProviderID, 0)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, len(revs), 2, "updates create revisions")
+	assert.False(t, revs[0].ModifiedTime.Before(revs[1].ModifiedTime),
+		"revisions are listed newest first")
+
+	content, err := s.p.GetRevisionContent(s.ctx, doc.ProviderID, revs[0].RevisionID)
+	require.NoError(t, err)
+	assert.Equal(t, "Version 2", content.Body, "the newest revision is current")
+	content, err = s.p.GetRevisionContent(s.ctx, doc.ProviderID, revs[1].RevisionID)
+	require.NoError(t, err)
+	assert.Equal(t, "Version 1", content.Body,
+		"earlier revisions keep their content")
+
+	rev, err := s.p.GetRevision(s.ctx, doc.ProviderID, revs[1].RevisionID)
+	require.NoError(t, err)
+	assert.Equal(t, revs[1].RevisionID, rev.RevisionID)
+
+	_, err = s.p.GetRevision(s.ctx, doc.ProviderID, "missing-revision")
+	assert.Error(t, err, "getting a missing revision fails")
+
+	limited, err := s.p.GetRevisionHistory(s.ctx, doc.ProviderID, 1)
+	require.NoError(t, err)
+	require.Len(t, limited, 1, "revision history respects limits")
+	assert.Equal(t, revs[0].RevisionID, limited[0].RevisionID)
+}
+
+func testPermissions(t *testing.T, s *suite) {
+	doc := s.createDocument(t, "Shared Document", "")
+	const email = "contract-reader@example.com"
+
+	require.NoError(t, s.p.ShareDocument(s.ctx, doc.ProviderID, email, "reader"))
+	perm := findPermission(t, s, doc.ProviderID, email)
+	require.NotNil(t, perm, "shared documents list the new permission")
+	assert.Equal(t, "reader", perm.Role)
+
+	// Sharing again updates the role instead of adding a permission.
+	require.NoError(t, s.p.ShareDocument(s.ctx, doc.ProviderID, email, "writer"))
+	perms, err := s.p.ListPermissions(s.ctx, doc.ProviderID)
+	require.NoError(t, err)
+	count := 0
+	for _, p := range perms {
+		if p.Email == email {
+			count++
+		}
+	}
+	assert.Equal(t, 1, count, "users have one permission per document")
+
+	require.NoError(t, s.p.UpdatePermission(s.ctx, doc.ProviderID, perm.ID, "commenter"))
+	perm = findPermission(t, s, doc.ProviderID, email)
+	require.NotNil(t, perm)
+	assert.Equal(t, "commenter", perm.Role)
+
+	require.NoError(t, s.p.RemovePermission(s.ctx, doc.ProviderID, perm.ID))
+	assert.Nil(t, findPermission(t, s, doc.ProviderID, email),
+		"removed permissions aren't listed")
+
+	assert.Error(t,
+		s.p.RemovePermission(s.ctx, doc.ProviderID, "missing-permission"),
+		"removing a missing permission fails")
+}
+
+// findPermission returns the permission of a document granted to email, or
+// nil if there isn't one.
+func findPermission(t *testing.T, s *suite, providerID, email string) *workspace.FilePermission {
+	t.Helper()
+
+	perms, err := s.p.ListPermissions(s.ctx, providerID)
+	require.NoError(t, err)
+	for _, p := range perms {
+		if p.Email == email {
+			return p
+		}
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+package router
+
+import (
+	"context"
+
+	"github.com/hashicorp-forge/hermes/pkg/docid"
+	"github.com/hashicorp-forge/hermes/pkg/tenant"
+	"github.com/hashicorp-forge/hermes/pkg/workspace"
+)
+
+// TenantRouter is a workspace provider that routes each call to the provider
+// of the tenant in the call's context (see package tenant). Calls for tenants
+// without their own provider, and calls without a tenant, go to the default
+// provider.
+type TenantRouter struct {
+	defaultProvider workspace.WorkspaceProvider
+	providers       map[string]workspace.WorkspaceProvider // tenant name -> provider
+}
+
+var _ workspace.WorkspaceProvider = (*TenantRouter)(nil)
+
+// NewTenantRouter creates a tenant router with the default provider.
+func NewTenantRouter(defaultProvider workspace.WorkspaceProvider) *TenantRouter {
+	return &TenantRouter{
+		defaultProvider: defaultProvider,
+		providers:       make(map[string]workspace.WorkspaceProvider),
+	}
+}
+
+// SetTenantProvider sets the provider of the named tenant. It must not be
+// called once the router is in use.
+func (r *TenantRouter) SetTenantProvider(name string, provider workspace.WorkspaceProvider) {
+	r.providers[name] = provider
+}
+
+// provider returns the provider of the tenant in ctx.
+func (r *TenantRouter) provider(ctx context.Context) workspace.WorkspaceProvider {
+	if t, ok := tenant.FromContext(ctx); ok {
+		if p, ok := r.providers[t.Name]; ok {
+			return p
+		}
+	}
+	return r.defaultProvider
+}
+
+// The methods below implement workspace.WorkspaceProvider by calling the
+// provider of the tenant in ctx.
+
+func (r *TenantRouter) GetDocument(ctx context.Context, providerID string) (*workspace.DocumentMetadata, error) {
+	return r.provider(ctx).GetDocument(ctx, providerID)
+}
+
+func (r *TenantRouter) GetDocumentByUUID(ctx context.Context, uuid docid.UUID) (*workspace.DocumentMetadata, error) {
+	return r.provider(ctx).GetDocumentByUUID(ctx, uuid)
+}
+
+func (r *TenantRouter) CreateDocument(ctx context.Context, templateID, destFolderID, name string) (*workspace.DocumentMetadata, error) {
+	return r.provider(ctx).CreateDocument(ctx, templateID, destFolderID, name)
+}
+
+func (r *TenantRouter) CreateDocumentWithUUID(ctx context.Context, uuid docid.UUID, templateID, destFolderID, name string) (*workspace.DocumentMetadata, error) {
+	return r.provider(ctx).CreateDocumentWithUUID(ctx, uuid, templateID, destFolderID, name)
+}
+
+func (r *TenantRouter) RegisterDocument(ctx context.Context, doc *workspace.DocumentMetadata) (*workspace.DocumentMetadata, error) {
+	return r.provider(ctx).RegisterDocument(ctx, doc)
+}
+
+func (r *TenantRouter) CopyDocument(ctx context.Context, srcProviderID, destFolderID, name string) (*workspace.DocumentMetadata, error) {
+	return r.provider(ctx).CopyDocument(ctx, srcProviderID, destFolderID, name)
+}
+
+func (r *TenantRouter) MoveDocument(ctx context.Context, providerID, destFolderID string) (*workspace.DocumentMetadata, error) {
+	return r.provider(ctx).MoveDocument(ctx, providerID, destFolderID)
+}
+
+func (r *TenantRouter) DeleteDocument(ctx context.Context, providerID string) error {
+	return r.provider(ctx).DeleteDocument(ctx, providerID)
+}
+
+func (r *TenantRouter) RenameDocument(ctx context.Context, providerID, newName string) error {
+	return r.provider(ctx).RenameDocument(ctx, providerID, newName)
+}
+
+func (r *TenantRouter) CreateFolder(ctx context.Context, name, parentID string) (*workspace.DocumentMetadata, error) {
+	return r.provider(ctx).CreateFolder(ctx, name, parentID)
+}
+
+func (r *TenantRouter) GetSubfolder(ctx context.Context, parentID, name string) (string, error) {
+	return r.provider(ctx).GetSubfolder(ctx, parentID, name)
+}
+
+func (r *TenantRouter) GetContent(ctx context.Context, providerID string) (*workspace.DocumentContent, error) {
+	return r.provider(ctx).GetContent(ctx, providerID)
+}
+
+func (r *TenantRouter) GetContentByUUID(ctx context.Context, uuid docid.UUID) (*workspace.DocumentContent, error) {
+	return r.provider(ctx).GetContentByUUID(ctx, uuid)
+}
+
+func (r *TenantRouter) UpdateContent(ctx context.Context, providerID string, content string) (*workspace.DocumentContent, error) {
+	return r.provider(ctx).UpdateContent(ctx, providerID, content)
+}
+
+func (r *TenantRouter) GetContentBatch(ctx context.Context, providerIDs []string) ([]*workspace.DocumentContent, error) {
+	return r.provider(ctx).GetContentBatch(ctx, providerIDs)
+}
+
+func (r *TenantRouter) CompareContent(ctx context.Context, providerID1, providerID2 string) (*workspace.ContentComparison, error) {
+	return r.provider(ctx).CompareContent(ctx, providerID1, providerID2)
+}
+
+func (r *TenantRouter) GetRevisionHistory(ctx context.Context, providerID string, limit int) ([]*workspace.BackendRevision, error) {
+	return r.provider(ctx).GetRevisionHistory(ctx, providerID, limit)
+}
+
+func (r *TenantRouter) GetRevision(ctx context.Context, providerID, revisionID string) (*workspace.BackendRevision, error) {
+	return r.provider(ctx).GetRevision(ctx, providerID, revisionID)
+}
+
+func (r *TenantRouter) GetRevisionContent(ctx context.Context, providerID, revisionID string) (*workspace.DocumentContent, error) {
+	return r.provider(ctx).GetRevisionContent(ctx, providerID, revisionID)
+}
+
+func (r *TenantRouter) KeepRevisionForever(ctx context.Context, providerID, revisionID string) error {
+	return r.provider(ctx).KeepRevisionForever(ctx, providerID, revisionID)
+}
+
+func (r *TenantRouter) GetAllDocumentRevisions(ctx context.Context, uuid docid.UUID) ([]*workspace.RevisionInfo, error) {
+	return r.provider(ctx).GetAllDocumentRevisions(ctx, uuid)
+}
+
+func (r *TenantRouter) ShareDocument(ctx context.Context, providerID, email, role string) error {
+	return r.provider(ctx).ShareDocument(ctx, providerID, email, role)
+}
+
+func (r *TenantRouter) ShareDocumentWithDomain(ctx context.Context, providerID, domain, role string) error {
+	return r.provider(ctx).ShareDocumentWithDomain(ctx, providerID, domain, role)
+}
+
+func (r *TenantRouter) ListPermissions(ctx context.Context, providerID string) ([]*workspace.FilePermission, error) {
+	return r.provider(ctx).ListPermissions(ctx, providerID)
+}
+
+func (r *TenantRouter) RemovePermission(ctx context.Context, providerID, permissionID string) error {
+	return r.provider(ctx).RemovePermission(ctx, providerID, permissionID)
+}
+
+func (r *TenantRouter) UpdatePermission(ctx context.Context, providerID, permissionID, newRole string) error {
+	return r.provider(ctx).UpdatePermission(ctx, providerID, permissionID, newRole)
+}
+
+func (r *TenantRouter) SearchPeople(ctx context.Context, query string) ([]*workspace.UserIdentity, error) {
+	return r.provider(ctx).SearchPeople(ctx, query)
+}
+
+func (r *TenantRouter) GetPerson(ctx context.Context, email string) (*workspace.UserIdentity, error) {
+	return r.provider(ctx).GetPerson(ctx, email)
+}
+
+func (r *TenantRouter) GetPersonByUnifiedID(ctx context.Context, unifiedID string) (*workspace.UserIdentity, error) {
+	return r.provider(ctx).GetPersonByUnifiedID(ctx, unifiedID)
+}
+
+func (r *TenantRouter) ResolveIdentity(ctx context.Context, email string) (*workspace.UserIdentity, error) {
+	return r.provider(ctx).ResolveIdentity(ctx, email)
+}
+
+func (r *TenantRouter) ListTeams(ctx context.Context, domain, query string, maxResults int64) ([]*workspace.Team, error) {
+	return r.provider(ctx).ListTeams(ctx, domain, query, maxResults)
+}
+
+func (r *TenantRouter) GetTeam(ctx context.Context, teamID string) (*workspace.Team, error) {
+	return r.provider(ctx).GetTeam(ctx, teamID)
+}
+
+func (r *TenantRouter) GetUserTeams(ctx context.Context, userEmail string) ([]*workspace.Team, error) {
+	return r.provider(ctx).GetUserTeams(ctx, userEmail)
+}
+
+func (r *TenantRouter) GetTeamMembers(ctx context.Context, teamID string) ([]*workspace.UserIdentity, error) {
+	return r.provider(ctx).GetTeamMembers(ctx, teamID)
+}
+
+func (r *TenantRouter) SendEmail(ctx context.Context, to []string, from, subject, body string) error {
+	return r.provider(ctx).SendEmail(ctx, to, from, subject, body)
+}
+
+func (r *TenantRouter) SendEmailWithTemplate(ctx context.Context, to []string, template string, data map[string]any) error {
+	return r.provider(ctx).SendEmailWithTemplate(ctx, to, template, data)
+}
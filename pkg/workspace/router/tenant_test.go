@@ -0,0 +1,47 @@
+package router
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/pkg/docid"
+	"github.com/hashicorp-forge/hermes/pkg/tenant"
+	"github.com/hashicorp-forge/hermes/pkg/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTenantRouter(t *testing.T) {
+	uuid := docid.NewUUID()
+
+	defaultProvider := newMockProvider("default")
+	defaultProvider.AddDocument(uuid, &workspace.DocumentMetadata{Name: "Default Doc"})
+	acmeProvider := newMockProvider("acme")
+	acmeProvider.AddDocument(uuid, &workspace.DocumentMetadata{Name: "Acme Doc"})
+
+	r := NewTenantRouter(defaultProvider)
+	r.SetTenantProvider("acme", acmeProvider)
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{"no tenant", context.Background(), "Default Doc"},
+		{"default tenant",
+			tenant.NewContext(context.Background(), tenant.Default), "Default Doc"},
+		{"tenant with provider",
+			tenant.NewContext(context.Background(), tenant.Tenant{ID: 1, Name: "acme"}),
+			"Acme Doc"},
+		{"tenant without provider",
+			tenant.NewContext(context.Background(), tenant.Tenant{ID: 2, Name: "other"}),
+			"Default Doc"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := r.GetDocumentByUUID(tt.ctx, uuid)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, doc.Name)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// DefaultShareConcurrency is the default number of ShareDocument calls in
+// flight when a document is shared with several users by a provider without
+// a native batch API.
+const DefaultShareConcurrency = 8
+
+// Share is a role on a document granted to a user or group.
+type Share struct {
+	// Email is the email address of the user or group.
+	Email string `json:"email"`
+
+	// Role is the role granted (e.g., "reader", "commenter", or "writer").
+	Role string `json:"role"`
+}
+
+// ShareError is the error granting a share.
+type ShareError struct {
+	Share Share
+	Err   error
+}
+
+func (e *ShareError) Error() string {
+	return fmt.Sprintf("error sharing with %s as %s: %v",
+		e.Share.Email, e.Share.Role, e.Err)
+}
+
+func (e *ShareError) Unwrap() error {
+	return e.Err
+}
+
+// ShareBatchError is the error granting some of the shares of a batch. The
+// shares without an error were granted.
+type ShareBatchError struct {
+	Errors []*ShareError
+}
+
+func (e *ShareBatchError) Error() string {
+	if len(e.Errors) == 1 {
+		return e.Errors[0].Error()
+	}
+	return fmt.Sprintf("%d shares failed; first: %v", len(e.Errors), e.Errors[0])
+}
+
+func (e *ShareBatchError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+	return errs
+}
+
+// ShareDocumentBatch grants shares on document providerID, skipping duplicate
+// shares. It uses the native batch API of providers that implement
+// BatchPermissionProvider, and otherwise calls ShareDocument for each share,
+// with at most concurrency calls in flight (default:
+// DefaultShareConcurrency). It returns a *ShareBatchError if some of the
+// shares failed.
+func ShareDocumentBatch(
+	ctx context.Context,
+	p WorkspaceProvider,
+	providerID string,
+	shares []Share,
+	concurrency int,
+) error {
+	seen := make(map[Share]bool, len(shares))
+	unique := make([]Share, 0, len(shares))
+	for _, s := range shares {
+		if !seen[s] {
+			seen[s] = true
+			unique = append(unique, s)
+		}
+	}
+	if len(unique) == 0 {
+		return nil
+	}
+
+	if bp, ok := Unwrap(p).(BatchPermissionProvider); ok {
+		return bp.ShareDocumentBatch(ctx, providerID, unique)
+	}
+
+	if concurrency <= 0 {
+		concurrency = DefaultShareConcurrency
+	}
+	sem := make(chan struct{}, concurrency)
+	errs := make([]error, len(unique))
+	var wg sync.WaitGroup
+	for i, s := range unique {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			errs[i] = ctx.Err()
+			continue
+		}
+		wg.Add(1)
+		go func(i int, s Share) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			errs[i] = p.ShareDocument(ctx, providerID, s.Email, s.Role)
+		}(i, s)
+	}
+	wg.Wait()
+
+	var batchErr ShareBatchError
+	for i, err := range errs {
+		if err != nil {
+			batchErr.Errors = append(batchErr.Errors,
+				&ShareError{Share: unique[i], Err: err})
+		}
+	}
+	if len(batchErr.Errors) > 0 {
+		return &batchErr
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sharingProvider records ShareDocument calls, failing for the emails in
+// fail, and tracks the maximum number of calls in flight.
+type sharingProvider struct {
+	WorkspaceProvider
+	fail map[string]bool
+
+	mu          sync.Mutex
+	shared      []Share
+	inFlight    int
+	maxInFlight int
+}
+
+func (p *sharingProvider) ShareDocument(ctx context.Context, providerID, email, role string) error {
+	p.mu.Lock()
+	p.inFlight++
+	if p.inFlight > p.maxInFlight {
+		p.maxInFlight = p.inFlight
+	}
+	p.mu.Unlock()
+
+	time.Sleep(10 * time.Millisecond)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.inFlight--
+	if p.fail[email] {
+		return errors.New("permission denied")
+	}
+	p.shared = append(p.shared, Share{Email: email, Role: role})
+	return nil
+}
+
+// batchSharingProvider implements BatchPermissionProvider.
+type batchSharingProvider struct {
+	sharingProvider
+	batches [][]Share
+}
+
+func (p *batchSharingProvider) ShareDocumentBatch(ctx context.Context, providerID string, shares []Share) error {
+	p.batches = append(p.batches, shares)
+	return nil
+}
+
+// wrappedProvider wraps a provider like the metrics and tracing wrappers.
+type wrappedProvider struct {
+	WorkspaceProvider
+}
+
+func (p *wrappedProvider) Unwrap() WorkspaceProvider {
+	return p.WorkspaceProvider
+}
+
+func TestShareDocumentBatch(t *testing.T) {
+	ctx := context.Background()
+	shares := []Share{
+		{Email: "a@example.com", Role: "writer"},
+		{Email: "b@example.com", Role: "writer"},
+		{Email: "c@example.com", Role: "reader"},
+		{Email: "a@example.com", Role: "writer"},
+		{Email: "d@example.com", Role: "writer"},
+	}
+
+	t.Run("fans out with bounded concurrency", func(t *testing.T) {
+		p := &sharingProvider{}
+		require.NoError(t, ShareDocumentBatch(ctx, p, "google:1", shares, 2))
+		assert.Len(t, p.shared, 4, "duplicate shares are skipped")
+		assert.ElementsMatch(t, []Share{shares[0], shares[1], shares[2], shares[4]},
+			p.shared)
+		assert.LessOrEqual(t, p.maxInFlight, 2)
+	})
+
+	t.Run("reports the failed shares", func(t *testing.T) {
+		p := &sharingProvider{fail: map[string]bool{"b@example.com": true}}
+		err := ShareDocumentBatch(ctx, p, "google:1", shares, 0)
+
+		var batchErr *ShareBatchError
+		require.ErrorAs(t, err, &batchErr)
+		require.Len(t, batchErr.Errors, 1)
+		assert.Equal(t, shares[1], batchErr.Errors[0].Share)
+		assert.ErrorContains(t, err, "b@example.com")
+		assert.Len(t, p.shared, 3)
+	})
+
+	t.Run("uses the native batch API", func(t *testing.T) {
+		p := &batchSharingProvider{}
+		require.NoError(t, ShareDocumentBatch(ctx, &wrappedProvider{p},
+			"google:1", shares, 0))
+		require.Len(t, p.batches, 1)
+		assert.Len(t, p.batches[0], 4)
+		assert.Empty(t, p.shared)
+	})
+
+	t.Run("no shares", func(t *testing.T) {
+		p := &batchSharingProvider{}
+		require.NoError(t, ShareDocumentBatch(ctx, p, "google:1", nil, 0))
+		assert.Empty(t, p.batches)
+	})
+}
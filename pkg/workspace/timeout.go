@@ -0,0 +1,341 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/docid"
+)
+
+// DefaultTimeout is the default time allowed for a provider operation.
+const DefaultTimeout = time.Minute
+
+// Timeouts configures the time allowed for provider operations. Zero
+// durations use Default, and a zero Default uses DefaultTimeout.
+type Timeouts struct {
+	// Default is the time allowed for operations without a timeout below.
+	Default time.Duration
+
+	// Read is the time allowed for getting documents, content, revisions,
+	// permissions, people, and teams.
+	Read time.Duration
+
+	// Write is the time allowed for creating, copying, moving, renaming,
+	// deleting, and sharing documents and folders, and updating content.
+	Write time.Duration
+
+	// Email is the time allowed for sending emails.
+	Email time.Duration
+}
+
+// WithTimeouts returns p with a deadline set on the context of each of its
+// calls, so a hung backend can't block the caller forever. Calls that exceed
+// their timeout return an error matching ErrTimeout and
+// context.DeadlineExceeded, and calls whose context is canceled return the
+// context's error. Both are returned when the deadline passes, even if p
+// ignores its context; the call is then left to finish in the background.
+//
+// The returned provider doesn't implement the optional interfaces of p; use
+// Unwrap to assert them.
+func WithTimeouts(p WorkspaceProvider, t Timeouts) WorkspaceProvider {
+	def := t.Default
+	if def <= 0 {
+		def = DefaultTimeout
+	}
+	orDefault := func(d time.Duration) time.Duration {
+		if d <= 0 {
+			return def
+		}
+		return d
+	}
+	return &timeoutProvider{
+		WorkspaceProvider: p,
+		read:              orDefault(t.Read),
+		write:             orDefault(t.Write),
+		email:             orDefault(t.Email),
+	}
+}
+
+type timeoutProvider struct {
+	WorkspaceProvider
+	read, write, email time.Duration
+}
+
+// Unwrap returns the provider the timeouts are applied to.
+func (p *timeoutProvider) Unwrap() WorkspaceProvider {
+	return p.WorkspaceProvider
+}
+
+// withTimeout calls f with a context that expires after timeout, and returns
+// once f returns or the context is done.
+func withTimeout[T any](
+	ctx context.Context,
+	op string,
+	timeout time.Duration,
+	f func(ctx context.Context) (T, error),
+) (T, error) {
+	var zero T
+	if err := ctx.Err(); err != nil {
+		return zero, fmt.Errorf("%s: %w", op, err)
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type result struct {
+		v   T
+		err error
+	}
+	// Buffered, so f can finish after the deadline without blocking.
+	done := make(chan result, 1)
+	go func() {
+		v, err := f(callCtx)
+		done <- result{v, err}
+	}()
+
+	select {
+	case r := <-done:
+		if r.err != nil && ctx.Err() == nil &&
+			errors.Is(callCtx.Err(), context.DeadlineExceeded) {
+			return zero, TimeoutError(op, timeout)
+		}
+		return r.v, r.err
+	case <-callCtx.Done():
+		if err := ctx.Err(); err != nil {
+			return zero, fmt.Errorf("%s: %w", op, err)
+		}
+		return zero, TimeoutError(op, timeout)
+	}
+}
+
+// callWithTimeout is withTimeout for operations that only return an error.
+func callWithTimeout(
+	ctx context.Context,
+	op string,
+	timeout time.Duration,
+	f func(ctx context.Context) error,
+) error {
+	_, err := withTimeout(ctx, op, timeout,
+		func(ctx context.Context) (struct{}, error) {
+			return struct{}{}, f(ctx)
+		})
+	return err
+}
+
+func (p *timeoutProvider) GetDocument(ctx context.Context, providerID string) (*DocumentMetadata, error) {
+	return withTimeout(ctx, "GetDocument", p.read, func(ctx context.Context) (*DocumentMetadata, error) {
+		return p.WorkspaceProvider.GetDocument(ctx, providerID)
+	})
+}
+
+func (p *timeoutProvider) GetDocumentByUUID(ctx context.Context, uuid docid.UUID) (*DocumentMetadata, error) {
+	return withTimeout(ctx, "GetDocumentByUUID", p.read, func(ctx context.Context) (*DocumentMetadata, error) {
+		return p.WorkspaceProvider.GetDocumentByUUID(ctx, uuid)
+	})
+}
+
+func (p *timeoutProvider) CreateDocument(ctx context.Context, templateID, destFolderID, name string) (*DocumentMetadata, error) {
+	return withTimeout(ctx, "CreateDocument", p.write, func(ctx context.Context) (*DocumentMetadata, error) {
+		return p.WorkspaceProvider.CreateDocument(ctx, templateID, destFolderID, name)
+	})
+}
+
+func (p *timeoutProvider) CreateDocumentWithUUID(ctx context.Context, uuid docid.UUID, templateID, destFolderID, name string) (*DocumentMetadata, error) {
+	return withTimeout(ctx, "CreateDocumentWithUUID", p.write, func(ctx context.Context) (*DocumentMetadata, error) {
+		return p.WorkspaceProvider.CreateDocumentWithUUID(ctx, uuid, templateID, destFolderID, name)
+	})
+}
+
+func (p *timeoutProvider) RegisterDocument(ctx context.Context, doc *DocumentMetadata) (*DocumentMetadata, error) {
+	return withTimeout(ctx, "RegisterDocument", p.write, func(ctx context.Context) (*DocumentMetadata, error) {
+		return p.WorkspaceProvider.RegisterDocument(ctx, doc)
+	})
+}
+
+func (p *timeoutProvider) CopyDocument(ctx context.Context, srcProviderID, destFolderID, name string) (*DocumentMetadata, error) {
+	return withTimeout(ctx, "CopyDocument", p.write, func(ctx context.Context) (*DocumentMetadata, error) {
+		return p.WorkspaceProvider.CopyDocument(ctx, srcProviderID, destFolderID, name)
+	})
+}
+
+func (p *timeoutProvider) MoveDocument(ctx context.Context, providerID, destFolderID string) (*DocumentMetadata, error) {
+	return withTimeout(ctx, "MoveDocument", p.write, func(ctx context.Context) (*DocumentMetadata, error) {
+		return p.WorkspaceProvider.MoveDocument(ctx, providerID, destFolderID)
+	})
+}
+
+func (p *timeoutProvider) DeleteDocument(ctx context.Context, providerID string) error {
+	return callWithTimeout(ctx, "DeleteDocument", p.write, func(ctx context.Context) error {
+		return p.WorkspaceProvider.DeleteDocument(ctx, providerID)
+	})
+}
+
+func (p *timeoutProvider) RenameDocument(ctx context.Context, providerID, newName string) error {
+	return callWithTimeout(ctx, "RenameDocument", p.write, func(ctx context.Context) error {
+		return p.WorkspaceProvider.RenameDocument(ctx, providerID, newName)
+	})
+}
+
+func (p *timeoutProvider) CreateFolder(ctx context.Context, name, parentID string) (*DocumentMetadata, error) {
+	return withTimeout(ctx, "CreateFolder", p.write, func(ctx context.Context) (*DocumentMetadata, error) {
+		return p.WorkspaceProvider.CreateFolder(ctx, name, parentID)
+	})
+}
+
+func (p *timeoutProvider) GetSubfolder(ctx context.Context, parentID, name string) (string, error) {
+	return withTimeout(ctx, "GetSubfolder", p.read, func(ctx context.Context) (string, error) {
+		return p.WorkspaceProvider.GetSubfolder(ctx, parentID, name)
+	})
+}
+
+func (p *timeoutProvider) GetContent(ctx context.Context, providerID string) (*DocumentContent, error) {
+	return withTimeout(ctx, "GetContent", p.read, func(ctx context.Context) (*DocumentContent, error) {
+		return p.WorkspaceProvider.GetContent(ctx, providerID)
+	})
+}
+
+func (p *timeoutProvider) GetContentByUUID(ctx context.Context, uuid docid.UUID) (*DocumentContent, error) {
+	return withTimeout(ctx, "GetContentByUUID", p.read, func(ctx context.Context) (*DocumentContent, error) {
+		return p.WorkspaceProvider.GetContentByUUID(ctx, uuid)
+	})
+}
+
+func (p *timeoutProvider) UpdateContent(ctx context.Context, providerID string, content string) (*DocumentContent, error) {
+	return withTimeout(ctx, "UpdateContent", p.write, func(ctx context.Context) (*DocumentContent, error) {
+		return p.WorkspaceProvider.UpdateContent(ctx, providerID, content)
+	})
+}
+
+func (p *timeoutProvider) GetContentBatch(ctx context.Context, providerIDs []string) ([]*DocumentContent, error) {
+	return withTimeout(ctx, "GetContentBatch", p.read, func(ctx context.Context) ([]*DocumentContent, error) {
+		return p.WorkspaceProvider.GetContentBatch(ctx, providerIDs)
+	})
+}
+
+func (p *timeoutProvider) CompareContent(ctx context.Context, providerID1, providerID2 string) (*ContentComparison, error) {
+	return withTimeout(ctx, "CompareContent", p.read, func(ctx context.Context) (*ContentComparison, error) {
+		return p.WorkspaceProvider.CompareContent(ctx, providerID1, providerID2)
+	})
+}
+
+func (p *timeoutProvider) GetRevisionHistory(ctx context.Context, providerID string, limit int) ([]*BackendRevision, error) {
+	return withTimeout(ctx, "GetRevisionHistory", p.read, func(ctx context.Context) ([]*BackendRevision, error) {
+		return p.WorkspaceProvider.GetRevisionHistory(ctx, providerID, limit)
+	})
+}
+
+func (p *timeoutProvider) GetRevision(ctx context.Context, providerID, revisionID string) (*BackendRevision, error) {
+	return withTimeout(ctx, "GetRevision", p.read, func(ctx context.Context) (*BackendRevision, error) {
+		return p.WorkspaceProvider.GetRevision(ctx, providerID, revisionID)
+	})
+}
+
+func (p *timeoutProvider) GetRevisionContent(ctx context.Context, providerID, revisionID string) (*DocumentContent, error) {
+	return withTimeout(ctx, "GetRevisionContent", p.read, func(ctx context.Context) (*DocumentContent, error) {
+		return p.WorkspaceProvider.GetRevisionContent(ctx, providerID, revisionID)
+	})
+}
+
+func (p *timeoutProvider) KeepRevisionForever(ctx context.Context, providerID, revisionID string) error {
+	return callWithTimeout(ctx, "KeepRevisionForever", p.write, func(ctx context.Context) error {
+		return p.WorkspaceProvider.KeepRevisionForever(ctx, providerID, revisionID)
+	})
+}
+
+func (p *timeoutProvider) GetAllDocumentRevisions(ctx context.Context, uuid docid.UUID) ([]*RevisionInfo, error) {
+	return withTimeout(ctx, "GetAllDocumentRevisions", p.read, func(ctx context.Context) ([]*RevisionInfo, error) {
+		return p.WorkspaceProvider.GetAllDocumentRevisions(ctx, uuid)
+	})
+}
+
+func (p *timeoutProvider) ShareDocument(ctx context.Context, providerID, email, role string) error {
+	return callWithTimeout(ctx, "ShareDocument", p.write, func(ctx context.Context) error {
+		return p.WorkspaceProvider.ShareDocument(ctx, providerID, email, role)
+	})
+}
+
+func (p *timeoutProvider) ShareDocumentWithDomain(ctx context.Context, providerID, domain, role string) error {
+	return callWithTimeout(ctx, "ShareDocumentWithDomain", p.write, func(ctx context.Context) error {
+		return p.WorkspaceProvider.ShareDocumentWithDomain(ctx, providerID, domain, role)
+	})
+}
+
+func (p *timeoutProvider) ListPermissions(ctx context.Context, providerID string) ([]*FilePermission, error) {
+	return withTimeout(ctx, "ListPermissions", p.read, func(ctx context.Context) ([]*FilePermission, error) {
+		return p.WorkspaceProvider.ListPermissions(ctx, providerID)
+	})
+}
+
+func (p *timeoutProvider) RemovePermission(ctx context.Context, providerID, permissionID string) error {
+	return callWithTimeout(ctx, "RemovePermission", p.write, func(ctx context.Context) error {
+		return p.WorkspaceProvider.RemovePermission(ctx, providerID, permissionID)
+	})
+}
+
+func (p *timeoutProvider) UpdatePermission(ctx context.Context, providerID, permissionID, newRole string) error {
+	return callWithTimeout(ctx, "UpdatePermission", p.write, func(ctx context.Context) error {
+		return p.WorkspaceProvider.UpdatePermission(ctx, providerID, permissionID, newRole)
+	})
+}
+
+func (p *timeoutProvider) SearchPeople(ctx context.Context, query string) ([]*UserIdentity, error) {
+	return withTimeout(ctx, "SearchPeople", p.read, func(ctx context.Context) ([]*UserIdentity, error) {
+		return p.WorkspaceProvider.SearchPeople(ctx, query)
+	})
+}
+
+func (p *timeoutProvider) GetPerson(ctx context.Context, email string) (*UserIdentity, error) {
+	return withTimeout(ctx, "GetPerson", p.read, func(ctx context.Context) (*UserIdentity, error) {
+		return p.WorkspaceProvider.GetPerson(ctx, email)
+	})
+}
+
+func (p *timeoutProvider) GetPersonByUnifiedID(ctx context.Context, unifiedID string) (*UserIdentity, error) {
+	return withTimeout(ctx, "GetPersonByUnifiedID", p.read, func(ctx context.Context) (*UserIdentity, error) {
+		return p.WorkspaceProvider.GetPersonByUnifiedID(ctx, unifiedID)
+	})
+}
+
+func (p *timeoutProvider) ResolveIdentity(ctx context.Context, email string) (*UserIdentity, error) {
+	return withTimeout(ctx, "ResolveIdentity", p.read, func(ctx context.Context) (*UserIdentity, error) {
+		return p.WorkspaceProvider.ResolveIdentity(ctx, email)
+	})
+}
+
+func (p *timeoutProvider) ListTeams(ctx context.Context, domain, query string, maxResults int64) ([]*Team, error) {
+	return withTimeout(ctx, "ListTeams", p.read, func(ctx context.Context) ([]*Team, error) {
+		return p.WorkspaceProvider.ListTeams(ctx, domain, query, maxResults)
+	})
+}
+
+func (p *timeoutProvider) GetTeam(ctx context.Context, teamID string) (*Team, error) {
+	return withTimeout(ctx, "GetTeam", p.read, func(ctx context.Context) (*Team, error) {
+		return p.WorkspaceProvider.GetTeam(ctx, teamID)
+	})
+}
+
+func (p *timeoutProvider) GetUserTeams(ctx context.Context, userEmail string) ([]*Team, error) {
+	return withTimeout(ctx, "GetUserTeams", p.read, func(ctx context.Context) ([]*Team, error) {
+		return p.WorkspaceProvider.GetUserTeams(ctx, userEmail)
+	})
+}
+
+func (p *timeoutProvider) GetTeamMembers(ctx context.Context, teamID string) ([]*UserIdentity, error) {
+	return withTimeout(ctx, "GetTeamMembers", p.read, func(ctx context.Context) ([]*UserIdentity, error) {
+		return p.WorkspaceProvider.GetTeamMembers(ctx, teamID)
+	})
+}
+
+func (p *timeoutProvider) SendEmail(ctx context.Context, to []string, from, subject, body string) error {
+	return callWithTimeout(ctx, "SendEmail", p.email, func(ctx context.Context) error {
+		return p.WorkspaceProvider.SendEmail(ctx, to, from, subject, body)
+	})
+}
+
+func (p *timeoutProvider) SendEmailWithTemplate(ctx context.Context, to []string, template string, data map[string]any) error {
+	return callWithTimeout(ctx, "SendEmailWithTemplate", p.email, func(ctx context.Context) error {
+		return p.WorkspaceProvider.SendEmailWithTemplate(ctx, to, template, data)
+	})
+}
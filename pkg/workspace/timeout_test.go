@@ -0,0 +1,80 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// hungProvider blocks in GetDocument until release is closed, ignoring its
+// context, and returns errors from SendEmail once its context is done.
+type hungProvider struct {
+	WorkspaceProvider
+	release chan struct{}
+}
+
+func (p *hungProvider) GetDocument(ctx context.Context, providerID string) (*DocumentMetadata, error) {
+	<-p.release
+	return &DocumentMetadata{ProviderID: providerID}, nil
+}
+
+func (p *hungProvider) SendEmail(ctx context.Context, to []string, from, subject, body string) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (p *hungProvider) RenameDocument(ctx context.Context, providerID, newName string) error {
+	if newName == "" {
+		return errors.New("name is required")
+	}
+	return nil
+}
+
+func TestWithTimeouts(t *testing.T) {
+	hung := &hungProvider{release: make(chan struct{})}
+	defer close(hung.release)
+	p := WithTimeouts(hung, Timeouts{
+		Read:  20 * time.Millisecond,
+		Email: 20 * time.Millisecond,
+	})
+
+	t.Run("calls that ignore their context time out", func(t *testing.T) {
+		start := time.Now()
+		_, err := p.GetDocument(context.Background(), "google:1")
+		assert.ErrorIs(t, err, ErrTimeout)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.ErrorContains(t, err, "GetDocument didn't finish within 20ms")
+		assert.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("calls that honor their context time out", func(t *testing.T) {
+		err := p.SendEmail(context.Background(), nil, "", "", "")
+		assert.ErrorIs(t, err, ErrTimeout)
+	})
+
+	t.Run("canceled calls aren't timeouts", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			time.Sleep(5 * time.Millisecond)
+			cancel()
+		}()
+		err := p.SendEmail(ctx, nil, "", "", "")
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.NotErrorIs(t, err, ErrTimeout)
+	})
+
+	t.Run("results and errors are passed through", func(t *testing.T) {
+		require.NoError(t, p.RenameDocument(context.Background(), "google:1", "New"))
+		assert.EqualError(t,
+			p.RenameDocument(context.Background(), "google:1", ""),
+			"name is required")
+	})
+
+	t.Run("unwrap", func(t *testing.T) {
+		assert.Same(t, hung, Unwrap(p))
+	})
+}
@@ -146,6 +146,13 @@ type FilePermission struct {
 	Role  string `json:"role"` // "owner", "writer", "reader"
 	Type  string `json:"type"` // "user", "group", "domain", "anyone"
 
+	// Domain is the domain of "domain" permissions.
+	Domain string `json:"domain,omitempty"`
+
+	// Inherited is true if the permission is inherited from a parent folder,
+	// so it can't be removed from the document.
+	Inherited bool `json:"inherited,omitempty"`
+
 	// Identity tracking
 	User *UserIdentity `json:"user,omitempty"`
 }
@@ -5,6 +5,13 @@ brokers        = "redpanda:9092"
 topic          = "hermes.notifications"
 consumer_group = "hermes-notifiers"
 
+# Report the notifier's status at /api/v2/admin/workers. The token is an "api"
+# service token with the "workers" scope.
+# status {
+#   url   = "http://hermes:8000"
+#   token = env("HERMES_WORKER_TOKEN")
+# }
+
 backends {
   audit {
     enabled = true
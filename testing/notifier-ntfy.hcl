@@ -11,5 +11,16 @@ backends {
 
     # server_url = "https://ntfy.sh"  # Optional, defaults to ntfy.sh
     topic = "hermes-dev-test-notifications"
+
+    # Publish to a topic per recipient ("<topic_prefix><hash of email>")
+    # instead; the shared topic above only receives notifications without
+    # email recipients.
+    # per_user_topics = true
+    # topic_prefix    = "hermes-"
+    # topic_secret    = "change-me"  # Keeps topics from being guessed
+
+    # Action buttons: "view" opens the document, "approve" opens the approval
+    # link of review notifications. Set to [] to disable.
+    # actions = ["view", "approve"]
   }
 }
@@ -234,8 +234,8 @@ phase1_prerequisites() {
     fi
 
     # Check individual services
-    check_service_health "$CENTRAL_URL/health" "Central Hermes" || return 1
-    check_service_health "$EDGE_URL/health" "Edge Hermes" || return 1
+    check_service_health "$CENTRAL_URL/readyz" "Central Hermes" || return 1
+    check_service_health "$EDGE_URL/readyz" "Edge Hermes" || return 1
     check_service_health "$MEILISEARCH_URL/health" "Meilisearch" || return 1
     check_service_health "$MAILHOG_URL" "Mailhog" || return 1
 
@@ -747,12 +747,12 @@ phase8_validation() {
     start_test "All services still healthy"
     local unhealthy_services=0
 
-    if ! curl -s -f "$CENTRAL_URL/health" > /dev/null 2>&1; then
+    if ! curl -s -f "$CENTRAL_URL/readyz" > /dev/null 2>&1; then
         ((unhealthy_services++))
         log_warning "Central Hermes health check failed"
     fi
 
-    if ! curl -s -f "$EDGE_URL/health" > /dev/null 2>&1; then
+    if ! curl -s -f "$EDGE_URL/readyz" > /dev/null 2>&1; then
         ((unhealthy_services++))
         log_warning "Edge Hermes health check failed"
     fi
@@ -71,13 +71,13 @@ echo ""
 # Test 1: Wait for services to be healthy
 echo "Test 1: Waiting for services to be healthy..."
 wait_for_service "http://localhost:7701/health" "Meilisearch" || exit 1
-wait_for_service "http://localhost:8000/health" "Central Hermes" || exit 1
-wait_for_service "http://localhost:8002/health" "Edge Hermes" || exit 1
+wait_for_service "http://localhost:8000/readyz" "Central Hermes" || exit 1
+wait_for_service "http://localhost:8002/readyz" "Edge Hermes" || exit 1
 echo ""
 
 # Test 2: Central Hermes health check
 echo "Test 2: Central Hermes health check..."
-CENTRAL_HEALTH=$(curl -s http://localhost:8000/health)
+CENTRAL_HEALTH=$(curl -s http://localhost:8000/readyz)
 if echo "$CENTRAL_HEALTH" | grep -qi "ok"; then
     test_result 0 "Central Hermes is healthy"
 else
@@ -87,7 +87,7 @@ echo ""
 
 # Test 3: Edge Hermes health check
 echo "Test 3: Edge Hermes health check..."
-EDGE_HEALTH=$(curl -s http://localhost:8002/health)
+EDGE_HEALTH=$(curl -s http://localhost:8002/readyz)
 if echo "$EDGE_HEALTH" | grep -qi "ok"; then
     test_result 0 "Edge Hermes is healthy"
 else
@@ -97,7 +97,7 @@ echo ""
 
 # Test 4: Network connectivity - Edge can reach Central
 echo "Test 4: Testing network connectivity (edge -> central)..."
-CONNECT_TEST=$(docker compose exec -T hermes-edge wget --tries=1 --timeout=2 -O- http://hermes-central:8000/health 2>/dev/null || echo "failed")
+CONNECT_TEST=$(docker compose exec -T hermes-edge wget --tries=1 --timeout=2 -O- http://hermes-central:8000/readyz 2>/dev/null || echo "failed")
 if echo "$CONNECT_TEST" | grep -qi "ok"; then
     test_result 0 "Edge can reach Central via container network"
 else
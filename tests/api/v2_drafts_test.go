@@ -15,6 +15,8 @@ import (
 	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
 	mockadapter "github.com/hashicorp-forge/hermes/pkg/auth/adapters/mock"
 	"github.com/hashicorp-forge/hermes/pkg/models"
+	"github.com/hashicorp-forge/hermes/pkg/workspace"
+	"github.com/hashicorp-forge/hermes/pkg/workspace/adapters/mock"
 	"github.com/hashicorp-forge/hermes/tests/api/fixtures"
 	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
@@ -121,9 +123,18 @@ func TestV2Drafts_GetSingle(t *testing.T) {
 		WithStatus(models.WIPDocumentStatus).
 		Create(t, suite.DB)
 
-	// Setup mock workspace with the file and document
-	// TODO: Update to use FakeAdapter WithDocument() method
-	// For now, use the suite's workspace provider
+	// Add the draft to the workspace provider so its header can be replaced.
+	providerID := "google:" + draft.GoogleFileID
+	suite.WorkspaceProvider.(*mock.FakeAdapter).
+		WithDocument(&workspace.DocumentMetadata{
+			ProviderID: providerID,
+			Name:       draft.Title,
+		}).
+		WithContent(providerID, &workspace.DocumentContent{
+			ProviderID: providerID,
+			Body:       "# Background",
+			Format:     "markdown",
+		})
 	// mockWorkspace := mock.NewFakeAdapter().WithDocument(...)
 
 	// Create mock auth adapter as owner
@@ -184,9 +195,18 @@ func TestV2Drafts_Patch(t *testing.T) {
 		WithStatus(models.WIPDocumentStatus).
 		Create(t, suite.DB)
 
-	// Setup mock workspace with the file and document
-	// TODO: Update to use FakeAdapter WithDocument() method
-	// For now, use the suite's workspace provider
+	// Add the draft to the workspace provider so its header can be replaced.
+	providerID := "google:" + draft.GoogleFileID
+	suite.WorkspaceProvider.(*mock.FakeAdapter).
+		WithDocument(&workspace.DocumentMetadata{
+			ProviderID: providerID,
+			Name:       draft.Title,
+		}).
+		WithContent(providerID, &workspace.DocumentContent{
+			ProviderID: providerID,
+			Body:       "# Background",
+			Format:     "markdown",
+		})
 
 	// Create mock auth adapter as owner
 	mockAuth := mockadapter.NewAdapterWithEmail(ownerEmail)
@@ -229,6 +249,12 @@ func TestV2Drafts_Patch(t *testing.T) {
 
 	// Note: Title might not update immediately depending on implementation
 	t.Logf("Original title: %s, Updated draft title: %s", draft.Title, updatedDraft.Title)
+
+	// Verify the draft header was replaced.
+	content, err := suite.WorkspaceProvider.GetContent(req.Context(), providerID)
+	require.NoError(t, err)
+	assert.Contains(t, content.Body, "title: Updated Title\n")
+	assert.Contains(t, content.Body, "# Background")
 }
 
 // TestV2Drafts_Unauthorized tests that unauthorized users cannot access drafts.
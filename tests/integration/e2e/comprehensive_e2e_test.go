@@ -102,8 +102,8 @@ func testServiceHealth(t *testing.T, ctx context.Context) {
 		name string
 		url  string
 	}{
-		{"Central Hermes API", centralURL + "/health"},
-		{"Edge Hermes API", edgeURL + "/health"},
+		{"Central Hermes API", centralURL + "/readyz"},
+		{"Edge Hermes API", edgeURL + "/readyz"},
 		{"Meilisearch", meilisearchURL + "/health"},
 		{"Mailhog", mailhogURL},
 	}
@@ -572,8 +572,8 @@ func testEndToEndValidation(t *testing.T, ctx context.Context) {
 	// Verify all services still healthy
 	t.Run("ServicesStillHealthy", func(t *testing.T) {
 		services := map[string]string{
-			"Central": centralURL + "/health",
-			"Edge":    edgeURL + "/health",
+			"Central": centralURL + "/readyz",
+			"Edge":    edgeURL + "/readyz",
 		}
 
 		for name, url := range services {
@@ -773,7 +773,7 @@ func TestPrerequisites(t *testing.T) {
 		ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
 		defer cancel()
 
-		req, err := http.NewRequestWithContext(ctx, "GET", centralURL+"/health", nil)
+		req, err := http.NewRequestWithContext(ctx, "GET", centralURL+"/readyz", nil)
 		require.NoError(t, err)
 
 		resp, err := http.DefaultClient.Do(req)
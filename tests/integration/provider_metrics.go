@@ -0,0 +1,323 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/workspace"
+)
+
+// ProviderMetrics samples workspace provider call latencies and outbound
+// Google API requests during integration tests. It turns performance
+// expectations (e.g. "GetDocument is fast in local mode") into assertions.
+//
+// Usage:
+//
+//	metrics := integration.NewProviderMetrics()
+//	provider := metrics.Instrument(local.NewAdapter(...))
+//	// ... exercise the provider or an API handler using it ...
+//	metrics.AssertP95Below(t, "GetDocument", 50*time.Millisecond)
+//	metrics.AssertNoGoogleCalls(t)
+type ProviderMetrics struct {
+	mu          sync.Mutex
+	samples     map[string][]time.Duration
+	errors      map[string]int
+	googleCalls []string
+}
+
+// NewProviderMetrics creates an empty metrics collector.
+func NewProviderMetrics() *ProviderMetrics {
+	return &ProviderMetrics{
+		samples: make(map[string][]time.Duration),
+		errors:  make(map[string]int),
+	}
+}
+
+// Instrument wraps a workspace provider so calls are recorded by the
+// collector. Operations not explicitly wrapped pass through unrecorded.
+func (m *ProviderMetrics) Instrument(
+	p workspace.WorkspaceProvider) workspace.WorkspaceProvider {
+	return &instrumentedProvider{WorkspaceProvider: p, metrics: m}
+}
+
+// Transport returns an http.RoundTripper that records requests to Google API
+// hosts before delegating to next (or http.DefaultTransport if nil). Install
+// it on clients used by the server under test to detect unexpected Google
+// traffic in local mode.
+func (m *ProviderMetrics) Transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &googleCallRecorder{next: next, metrics: m}
+}
+
+// Record adds a latency sample for an operation.
+func (m *ProviderMetrics) Record(op string, d time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.samples[op] = append(m.samples[op], d)
+	if err != nil {
+		m.errors[op]++
+	}
+}
+
+// Count returns the number of recorded calls for an operation.
+func (m *ProviderMetrics) Count(op string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.samples[op])
+}
+
+// Errors returns the number of recorded calls for an operation that returned
+// an error.
+func (m *ProviderMetrics) Errors(op string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.errors[op]
+}
+
+// Percentile returns the p-th percentile (0-100) latency for an operation
+// using the nearest-rank method. It returns 0 if there are no samples.
+func (m *ProviderMetrics) Percentile(op string, p float64) time.Duration {
+	m.mu.Lock()
+	samples := append([]time.Duration(nil), m.samples[op]...)
+	m.mu.Unlock()
+
+	return percentile(samples, p)
+}
+
+// GoogleCalls returns the Google API URLs requested through Transport.
+func (m *ProviderMetrics) GoogleCalls() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]string(nil), m.googleCalls...)
+}
+
+// Operations returns the names of all operations with recorded samples.
+func (m *ProviderMetrics) Operations() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ops := make([]string, 0, len(m.samples))
+	for op := range m.samples {
+		ops = append(ops, op)
+	}
+	sort.Strings(ops)
+	return ops
+}
+
+// AssertP95Below fails the test if the p95 latency of an operation exceeds
+// max. Operations with no samples pass.
+func (m *ProviderMetrics) AssertP95Below(
+	t *testing.T, op string, max time.Duration) bool {
+	t.Helper()
+
+	if p95 := m.Percentile(op, 95); p95 > max {
+		t.Errorf("provider operation %q p95 latency %v exceeds %v (%d samples)",
+			op, p95, max, m.Count(op))
+		return false
+	}
+	return true
+}
+
+// AssertAllP95Below fails the test if the p95 latency of any recorded
+// operation exceeds max.
+func (m *ProviderMetrics) AssertAllP95Below(
+	t *testing.T, max time.Duration) bool {
+	t.Helper()
+
+	ok := true
+	for _, op := range m.Operations() {
+		if !m.AssertP95Below(t, op, max) {
+			ok = false
+		}
+	}
+	return ok
+}
+
+// AssertNoGoogleCalls fails the test if any Google API requests were made
+// through Transport.
+func (m *ProviderMetrics) AssertNoGoogleCalls(t *testing.T) bool {
+	t.Helper()
+
+	if calls := m.GoogleCalls(); len(calls) > 0 {
+		t.Errorf("unexpected Google API calls in local mode (%d): %v",
+			len(calls), calls)
+		return false
+	}
+	return true
+}
+
+// LogSummary logs the call count, error count, p50, and p95 latency for every
+// recorded operation.
+func (m *ProviderMetrics) LogSummary(t *testing.T) {
+	t.Helper()
+
+	for _, op := range m.Operations() {
+		t.Logf("provider metrics: op=%s calls=%d errors=%d p50=%v p95=%v",
+			op, m.Count(op), m.Errors(op),
+			m.Percentile(op, 50), m.Percentile(op, 95))
+	}
+	if calls := m.GoogleCalls(); len(calls) > 0 {
+		t.Logf("provider metrics: google_calls=%d", len(calls))
+	}
+}
+
+// percentile returns the p-th percentile of samples using the nearest-rank
+// method.
+func percentile(samples []time.Duration, p float64) time.Duration {
+	if len(samples) == 0 {
+		return 0
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+
+	rank := int(p/100*float64(len(samples))+0.999999) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(samples) {
+		rank = len(samples) - 1
+	}
+	return samples[rank]
+}
+
+// googleCallRecorder records requests to Google API hosts.
+type googleCallRecorder struct {
+	next    http.RoundTripper
+	metrics *ProviderMetrics
+}
+
+func (g *googleCallRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Hostname()
+	if strings.HasSuffix(host, "googleapis.com") ||
+		strings.HasSuffix(host, "google.com") {
+		g.metrics.mu.Lock()
+		g.metrics.googleCalls = append(g.metrics.googleCalls, req.URL.String())
+		g.metrics.mu.Unlock()
+	}
+	return g.next.RoundTrip(req)
+}
+
+// instrumentedProvider records latency for the provider operations exercised
+// by the API handlers.
+type instrumentedProvider struct {
+	workspace.WorkspaceProvider
+	metrics *ProviderMetrics
+}
+
+// SupportsContentEditing implements workspace.ProviderCapabilities by
+// delegating to the wrapped provider, so instrumenting a provider doesn't hide
+// its capabilities from the API handlers.
+func (p *instrumentedProvider) SupportsContentEditing() bool {
+	caps, ok := p.WorkspaceProvider.(workspace.ProviderCapabilities)
+	return ok && caps.SupportsContentEditing()
+}
+
+func (p *instrumentedProvider) observe(op string, start time.Time, err error) {
+	p.metrics.Record(op, time.Since(start), err)
+}
+
+func (p *instrumentedProvider) GetDocument(
+	ctx context.Context, providerID string) (*workspace.DocumentMetadata, error) {
+	start := time.Now()
+	doc, err := p.WorkspaceProvider.GetDocument(ctx, providerID)
+	p.observe("GetDocument", start, err)
+	return doc, err
+}
+
+func (p *instrumentedProvider) CreateDocument(
+	ctx context.Context, templateID, destFolderID, name string,
+) (*workspace.DocumentMetadata, error) {
+	start := time.Now()
+	doc, err := p.WorkspaceProvider.CreateDocument(
+		ctx, templateID, destFolderID, name)
+	p.observe("CreateDocument", start, err)
+	return doc, err
+}
+
+func (p *instrumentedProvider) CopyDocument(
+	ctx context.Context, srcProviderID, destFolderID, name string,
+) (*workspace.DocumentMetadata, error) {
+	start := time.Now()
+	doc, err := p.WorkspaceProvider.CopyDocument(
+		ctx, srcProviderID, destFolderID, name)
+	p.observe("CopyDocument", start, err)
+	return doc, err
+}
+
+func (p *instrumentedProvider) MoveDocument(
+	ctx context.Context, providerID, destFolderID string,
+) (*workspace.DocumentMetadata, error) {
+	start := time.Now()
+	doc, err := p.WorkspaceProvider.MoveDocument(ctx, providerID, destFolderID)
+	p.observe("MoveDocument", start, err)
+	return doc, err
+}
+
+func (p *instrumentedProvider) DeleteDocument(
+	ctx context.Context, providerID string) error {
+	start := time.Now()
+	err := p.WorkspaceProvider.DeleteDocument(ctx, providerID)
+	p.observe("DeleteDocument", start, err)
+	return err
+}
+
+func (p *instrumentedProvider) RenameDocument(
+	ctx context.Context, providerID, newName string) error {
+	start := time.Now()
+	err := p.WorkspaceProvider.RenameDocument(ctx, providerID, newName)
+	p.observe("RenameDocument", start, err)
+	return err
+}
+
+func (p *instrumentedProvider) GetContent(
+	ctx context.Context, providerID string) (*workspace.DocumentContent, error) {
+	start := time.Now()
+	c, err := p.WorkspaceProvider.GetContent(ctx, providerID)
+	p.observe("GetContent", start, err)
+	return c, err
+}
+
+func (p *instrumentedProvider) UpdateContent(
+	ctx context.Context, providerID string, content string,
+) (*workspace.DocumentContent, error) {
+	start := time.Now()
+	c, err := p.WorkspaceProvider.UpdateContent(ctx, providerID, content)
+	p.observe("UpdateContent", start, err)
+	return c, err
+}
+
+func (p *instrumentedProvider) ShareDocument(
+	ctx context.Context, providerID, email, role string) error {
+	start := time.Now()
+	err := p.WorkspaceProvider.ShareDocument(ctx, providerID, email, role)
+	p.observe("ShareDocument", start, err)
+	return err
+}
+
+func (p *instrumentedProvider) SearchPeople(
+	ctx context.Context, query string) ([]*workspace.UserIdentity, error) {
+	start := time.Now()
+	people, err := p.WorkspaceProvider.SearchPeople(ctx, query)
+	p.observe("SearchPeople", start, err)
+	return people, err
+}
+
+func (p *instrumentedProvider) GetUserTeams(
+	ctx context.Context, userEmail string) ([]*workspace.Team, error) {
+	start := time.Now()
+	teams, err := p.WorkspaceProvider.GetUserTeams(ctx, userEmail)
+	p.observe("GetUserTeams", start, err)
+	return teams, err
+}
@@ -0,0 +1,89 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp-forge/hermes/pkg/workspace"
+	"github.com/hashicorp-forge/hermes/pkg/workspace/adapters/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPercentile(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(time.Duration(0), percentile(nil, 95))
+
+	var samples []time.Duration
+	for i := 1; i <= 100; i++ {
+		samples = append(samples, time.Duration(i)*time.Millisecond)
+	}
+	assert.Equal(50*time.Millisecond, percentile(samples, 50))
+	assert.Equal(95*time.Millisecond, percentile(samples, 95))
+	assert.Equal(100*time.Millisecond, percentile(samples, 100))
+}
+
+// TestProviderMetrics_LocalMode samples the fake provider and asserts local
+// mode is fast and never reaches Google APIs.
+func TestProviderMetrics_LocalMode(t *testing.T) {
+	ctx := context.Background()
+	metrics := NewProviderMetrics()
+
+	fake := mock.NewFakeAdapter().
+		WithDocument(&workspace.DocumentMetadata{
+			ProviderID: "doc-1",
+			Name:       "Metrics Doc",
+		})
+	provider := metrics.Instrument(fake)
+
+	for i := 0; i < 20; i++ {
+		_, err := provider.GetDocument(ctx, "doc-1")
+		require.NoError(t, err)
+	}
+	_, err := provider.GetDocument(ctx, "missing")
+	assert.Error(t, err)
+
+	// Local HTTP traffic is not counted as Google traffic.
+	srv := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+	client := &http.Client{Transport: metrics.Transport(nil)}
+	resp, err := client.Get(srv.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	metrics.LogSummary(t)
+	assert.Equal(t, 21, metrics.Count("GetDocument"))
+	assert.Equal(t, 1, metrics.Errors("GetDocument"))
+	metrics.AssertAllP95Below(t, 50*time.Millisecond)
+	metrics.AssertNoGoogleCalls(t)
+}
+
+func TestProviderMetrics_RecordsGoogleCalls(t *testing.T) {
+	metrics := NewProviderMetrics()
+	rt := metrics.Transport(roundTripFunc(
+		func(r *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+		}))
+
+	req := httptest.NewRequest("GET",
+		"https://www.googleapis.com/drive/v3/files/abc", nil)
+	_, err := rt.RoundTrip(req)
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		[]string{"https://www.googleapis.com/drive/v3/files/abc"},
+		metrics.GoogleCalls())
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
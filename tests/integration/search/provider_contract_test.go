@@ -0,0 +1,38 @@
+//go:build integration
+// +build integration
+
+package search
+
+import (
+	"testing"
+
+	"github.com/hashicorp-forge/hermes/pkg/search"
+	"github.com/hashicorp-forge/hermes/pkg/search/adapters/meilisearch"
+	"github.com/hashicorp-forge/hermes/pkg/search/providertest"
+	"github.com/hashicorp-forge/hermes/tests/integration"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMeilisearchAdapter_ProviderContract runs the search provider
+// conformance tests against Meilisearch.
+func TestMeilisearchAdapter_ProviderContract(t *testing.T) {
+	providertest.Run(t, providertest.Config{
+		New: func(t *testing.T) search.Provider {
+			host, apiKey := integration.GetMeilisearchConfig()
+			adapter, err := meilisearch.NewAdapter(&meilisearch.Config{
+				Host:              host,
+				APIKey:            apiKey,
+				DocsIndexName:     "integration-test-contract-docs",
+				DraftsIndexName:   "integration-test-contract-drafts",
+				ProjectsIndexName: "integration-test-contract-projects",
+				LinksIndexName:    "integration-test-contract-links",
+			})
+			require.NoError(t, err)
+			return adapter
+		},
+		Skip: map[string]string{
+			providertest.TestFilterGroups: "filter group expressions are passed " +
+				"to Meilisearch verbatim instead of being translated from field:value",
+		},
+	})
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/hashicorp-forge/hermes/pkg/workspace"
 	"github.com/hashicorp-forge/hermes/pkg/workspace/adapters/local"
 	"github.com/hashicorp-forge/hermes/pkg/workspace/adapters/mock"
+	"github.com/hashicorp-forge/hermes/tests/integration"
 	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -101,8 +102,13 @@ func TestLocalWorkspace_DocumentContentAPI(t *testing.T) {
 
 		progress("Created local workspace adapter")
 
-		// Create provider adapter
-		providerAdapter := local.NewProviderAdapter(adapter)
+		// Create provider adapter, instrumented to check local mode stays fast
+		// and never calls Google APIs.
+		metrics := integration.NewProviderMetrics()
+		providerAdapter := metrics.Instrument(local.NewProviderAdapter(adapter))
+		origTransport := http.DefaultTransport
+		http.DefaultTransport = metrics.Transport(origTransport)
+		defer func() { http.DefaultTransport = origTransport }()
 
 		// Create in-memory database for testing
 		db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
@@ -422,6 +428,13 @@ trashed: false
 			progress("✓ Content persists correctly across multiple requests")
 		})
 
+		t.Run("Provider Metrics", func(t *testing.T) {
+			metrics.LogSummary(t)
+			metrics.AssertP95Below(t, "GetContent", 50*time.Millisecond)
+			metrics.AssertP95Below(t, "UpdateContent", 50*time.Millisecond)
+			metrics.AssertNoGoogleCalls(t)
+		})
+
 		progress("All document content API tests completed successfully")
 	})
 }
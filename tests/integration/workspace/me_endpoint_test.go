@@ -21,7 +21,9 @@ import (
 	"github.com/hashicorp-forge/hermes/internal/config"
 	"github.com/hashicorp-forge/hermes/internal/server"
 	pkgauth "github.com/hashicorp-forge/hermes/pkg/auth"
+	"github.com/hashicorp-forge/hermes/pkg/workspace"
 	"github.com/hashicorp-forge/hermes/pkg/workspace/adapters/local"
+	"github.com/hashicorp-forge/hermes/tests/integration"
 	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -88,8 +90,13 @@ func TestLocalWorkspace_MeEndpoint_UsesUsersJSON(t *testing.T) {
 
 		progress("Created local workspace adapter")
 
-		// Create provider adapter
-		providerAdapter := local.NewProviderAdapter(adapter)
+		// Create provider adapter, instrumented to check local mode stays fast
+		// and never calls Google APIs.
+		metrics := integration.NewProviderMetrics()
+		providerAdapter := metrics.Instrument(local.NewProviderAdapter(adapter))
+		origTransport := http.DefaultTransport
+		http.DefaultTransport = metrics.Transport(origTransport)
+		defer func() { http.DefaultTransport = origTransport }()
 
 		// Create mock server config
 		mockServer := createMockServer(providerAdapter)
@@ -231,6 +238,13 @@ func TestLocalWorkspace_MeEndpoint_UsesUsersJSON(t *testing.T) {
 			progress("✓ Correctly handled missing user with fallback")
 		})
 
+		t.Run("Provider Metrics", func(t *testing.T) {
+			metrics.LogSummary(t)
+			metrics.AssertP95Below(t, "SearchPeople", 50*time.Millisecond)
+			metrics.AssertP95Below(t, "GetUserTeams", 50*time.Millisecond)
+			metrics.AssertNoGoogleCalls(t)
+		})
+
 		progress("All /me endpoint tests completed successfully")
 	})
 }
@@ -238,7 +252,7 @@ func TestLocalWorkspace_MeEndpoint_UsesUsersJSON(t *testing.T) {
 // createMockServer creates a minimal server.Server for testing MeHandler.
 // Now that ProviderAdapter implements the full workspace.Provider interface,
 // we can use it directly without a mock.
-func createMockServer(providerAdapter *local.ProviderAdapter) server.Server {
+func createMockServer(providerAdapter workspace.WorkspaceProvider) server.Server {
 	return server.Server{
 		WorkspaceProvider: providerAdapter,
 		Logger:            hclog.NewNullLogger(),
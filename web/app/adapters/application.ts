@@ -3,6 +3,7 @@ import { service } from "@ember/service";
 import ConfigService from "hermes/services/config";
 import FetchService from "hermes/services/fetch";
 import SessionService from "hermes/services/session";
+import csrfHeaders from "hermes/utils/csrf-headers";
 
 export default class ApplicationAdapter extends RESTAdapter {
   @service("config") declare configSvc: ConfigService;
@@ -15,11 +16,12 @@ export default class ApplicationAdapter extends RESTAdapter {
 
   get headers() {
     // For Dex authentication, we don't need to send an access token
-    // (authentication is handled via session cookies)
+    // (authentication is handled via session cookies), but cookie-based
+    // sessions may require a CSRF token for requests that change data.
     const accessToken = this.session.data?.authenticated?.access_token;
     
     if (!accessToken) {
-      return {};
+      return csrfHeaders("POST");
     }
     
     return {
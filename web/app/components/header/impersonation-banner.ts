@@ -0,0 +1,21 @@
+import Component from "@glimmer/component";
+import { service } from "@ember/service";
+import AuthenticatedUserService from "hermes/services/authenticated-user";
+
+interface HeaderImpersonationBannerSignature {
+  Args: {};
+}
+
+/**
+ * Shown while a site admin is impersonating the user so that
+ * they don't forget whose identity they are acting with.
+ */
+export default class HeaderImpersonationBanner extends Component<HeaderImpersonationBannerSignature> {
+  @service declare authenticatedUser: AuthenticatedUserService;
+}
+
+declare module "@glint/environment-ember-loose/registry" {
+  export default interface Registry {
+    "Header::ImpersonationBanner": typeof HeaderImpersonationBanner;
+  }
+}
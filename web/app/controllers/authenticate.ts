@@ -24,7 +24,7 @@ export default class AuthenticateController extends Controller {
   });
 
   protected authenticateOIDC = dropTask(async () => {
-    // For OIDC providers (Okta/Dex), redirect to the backend auth endpoint
+    // For OIDC providers (Okta/Dex/OIDC), redirect to the backend auth endpoint
     // which will handle the OIDC flow
     window.location.href = `/auth/login`;
   });
@@ -33,14 +33,14 @@ export default class AuthenticatedRoute extends Route {
 
     /**
      * Check if the session is authenticated based on the auth provider.
-     * For Dex, check the session cookie by making a request to /api/v2/me.
+     * For Dex and OIDC, check the session cookie by making a request to /api/v2/me.
      * For Google/Okta, use the session service's requireAuthentication.
      */
     const authProvider = this.configSvc.config.auth_provider;
     console.log('[AuthenticatedRoute] 🔐 Auth provider:', authProvider);
     
-    if (authProvider === "dex") {
-      // For Dex, check if user is authenticated by trying to access the ME endpoint
+    if (authProvider === "dex" || authProvider === "oidc") {
+      // For Dex and OIDC, check if user is authenticated by trying to access the ME endpoint
       // Use GET instead of HEAD so we can retrieve the user data
       console.log('[AuthenticatedRoute] 📡 Checking Dex authentication via /api/v2/me');
       try {
@@ -110,9 +110,14 @@ export default class AuthenticatedRoute extends Route {
 
     /**
      * Kick off the task to poll for expired auth.
-     * Note: For Dex, this may not be needed as sessions are managed via cookies.
+     * Note: For Dex and OIDC, this may not be needed as sessions are managed via cookies.
+     * With `hermes dev`, every request is authenticated, so there's nothing to poll for.
      */
-    if (authProvider !== "dex") {
+    if (
+      authProvider !== "dex" &&
+      authProvider !== "oidc" &&
+      authProvider !== "dev"
+    ) {
       void this.session.pollForExpiredAuth.perform();
     }
   }
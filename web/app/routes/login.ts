@@ -10,8 +10,8 @@ export default class LoginRoute extends Route {
   beforeModel() {
     const authProvider = this.configSvc.config.auth_provider;
 
-    // For Dex, redirect to the backend login endpoint
-    if (authProvider === "dex") {
+    // For Dex and OIDC, redirect to the backend login endpoint
+    if (authProvider === "dex" || authProvider === "oidc") {
       // Save the current URL to redirect back after login
       const redirectAfterLogin = this.router.currentURL || "/dashboard";
       window.location.href = `/auth/login?redirect=${encodeURIComponent(redirectAfterLogin)}`;
@@ -65,7 +65,7 @@ export default class SessionService extends EmberSimpleAuthSessionService {
    */
   get isUsingOIDC(): boolean {
     const provider = this.configSvc.config.auth_provider;
-    return provider === "okta" || provider === "dex";
+    return provider === "okta" || provider === "dex" || provider === "oidc";
   }
 
   /**
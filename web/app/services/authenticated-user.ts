@@ -26,6 +26,12 @@ export default class AuthenticatedUserService extends Service {
   @tracked subscriptions: Subscription[] | null = null;
   @tracked _info: PersonModel | null = null;
 
+  /**
+   * The email address of the site admin impersonating the user, if any.
+   * Set by `loadInfo` from the `/me` response and used to show a banner.
+   */
+  @tracked impersonatedBy: string | null = null;
+
   get info(): PersonModel | null {
     // Note: When using Dex authentication without OIDC flow, user info may not be loaded
     // Return null instead of asserting to prevent application crashes
@@ -106,6 +112,7 @@ export default class AuthenticatedUserService extends Service {
       }
 
       this._info = person;
+      this.impersonatedBy = data.impersonation?.admin ?? null;
       console.log('[AuthenticatedUser] ✅ User info loaded successfully:', person.email);
     } catch (e: unknown) {
       console.error("[AuthenticatedUser] ❌ Error getting user information: ", e);
@@ -113,6 +120,17 @@ export default class AuthenticatedUserService extends Service {
     }
   });
 
+  /**
+   * Ends the site admin's impersonation session and reloads the app
+   * as the admin.
+   */
+  endImpersonation = task(async () => {
+    await this.fetchSvc.fetch("/api/v2/admin/impersonation", {
+      method: "DELETE",
+    });
+    window.location.reload();
+  });
+
   /**
    * Loads the user's subscriptions from the API.
    * If the user has no subscriptions, returns an empty array.
@@ -9,7 +9,7 @@ export default class ConfigService extends Service {
     algolia_internal_index_name: config.algolia.internalIndexName,
     algolia_projects_index_name: config.algolia.projectsIndexName,
     api_version: "v2", // Always use v2 API
-    auth_provider: "google" as "google" | "okta" | "dex", // Runtime auth provider selection
+    auth_provider: "google" as "google" | "okta" | "dex" | "oidc" | "dev", // Runtime auth provider selection
     create_docs_as_user: config.createDocsAsUser,
     dex_issuer_url: "",
     dex_client_id: "",
@@ -21,6 +21,8 @@ export default class ConfigService extends Service {
     skip_google_auth: config.skipGoogleAuth, // Deprecated: use auth_provider
     google_analytics_tag_id: undefined,
     jira_url: config.jiraURL,
+    oidc_issuer_url: "",
+    oidc_client_id: "",
     support_link_url: config.supportLinkURL,
     version: config.version,
     short_revision: config.shortRevision,
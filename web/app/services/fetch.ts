@@ -2,6 +2,7 @@ import Service from "@ember/service";
 import { service } from "@ember/service";
 import ConfigService from "hermes/services/config";
 import SessionService from "./session";
+import csrfHeaders from "hermes/utils/csrf-headers";
 
 interface FetchOptions {
   method?: string;
@@ -56,7 +57,11 @@ export default class FetchService extends Service {
             ...options.headers,
             "Hermes-Google-Access-Token": accessToken,
           };
-        } else if (authProvider === "dex" || authProvider === "okta") {
+        } else if (
+          authProvider === "dex" ||
+          authProvider === "okta" ||
+          authProvider === "oidc"
+        ) {
           // OIDC providers use standard Authorization Bearer header
           options.headers = {
             ...options.headers,
@@ -64,6 +69,12 @@ export default class FetchService extends Service {
           };
         }
       }
+
+      // Cookie-based sessions require a CSRF token to change data.
+      options.headers = {
+        ...options.headers,
+        ...csrfHeaders(options.method),
+      };
     }
 
     try {
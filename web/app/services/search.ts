@@ -15,6 +15,7 @@ import SessionService from "./session";
 import { SearchScope } from "hermes/routes/authenticated/results";
 import { FacetName } from "hermes/components/header/toolbar";
 import StoreService from "./_store";
+import csrfHeaders from "hermes/utils/csrf-headers";
 
 // FIXME: drafts endpoint breaks when you increase this number (to 100, e.g.)
 export const HITS_PER_PAGE = 12;
@@ -106,7 +107,11 @@ export default class SearchService extends Service {
 
     if (authProvider === "google") {
       return { "Hermes-Google-Access-Token": accessToken };
-    } else if (authProvider === "dex" || authProvider === "okta") {
+    } else if (
+      authProvider === "dex" ||
+      authProvider === "okta" ||
+      authProvider === "oidc"
+    ) {
       return { Authorization: `Bearer ${accessToken}` };
     }
     return {};
@@ -148,6 +153,7 @@ export default class SearchService extends Service {
       headers: {
         "Content-Type": "application/json",
         ...this.getAuthHeaders(),
+        ...csrfHeaders("POST"),
       },
       body: JSON.stringify(body),
       credentials: "include", // Include cookies for Dex auth
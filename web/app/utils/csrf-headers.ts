@@ -0,0 +1,30 @@
+/**
+ * The cookie set by the server that holds the CSRF token
+ * for the user's session.
+ */
+export const CSRF_COOKIE_NAME = "hermes_csrf";
+
+/**
+ * The header the server expects the CSRF token in.
+ */
+export const CSRF_HEADER_NAME = "X-CSRF-Token";
+
+/**
+ * Returns the CSRF header for requests that change data, or an empty
+ * object if the server hasn't issued a CSRF token (i.e., CSRF protection
+ * is disabled or the session isn't cookie-based).
+ */
+export default function csrfHeaders(
+  method = "GET",
+): Record<string, string> {
+  if (["GET", "HEAD", "OPTIONS"].includes(method.toUpperCase())) {
+    return {};
+  }
+
+  const token = document.cookie
+    .split("; ")
+    .find((cookie) => cookie.startsWith(`${CSRF_COOKIE_NAME}=`))
+    ?.slice(CSRF_COOKIE_NAME.length + 1);
+
+  return token ? { [CSRF_HEADER_NAME]: decodeURIComponent(token) } : {};
+}
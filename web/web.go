@@ -59,7 +59,7 @@ type ConfigResponse struct {
 	AlgoliaDraftsIndexName   string          `json:"algolia_drafts_index_name"`
 	AlgoliaInternalIndexName string          `json:"algolia_internal_index_name"`
 	AlgoliaProjectsIndexName string          `json:"algolia_projects_index_name"`
-	AuthProvider             string          `json:"auth_provider"` // "google", "okta", or "dex"
+	AuthProvider             string          `json:"auth_provider"` // "google", "okta", "dex", "oidc", or "dev"
 	CreateDocsAsUser         bool            `json:"create_docs_as_user"`
 	DexIssuerURL             string          `json:"dex_issuer_url,omitempty"`
 	DexClientID              string          `json:"dex_client_id,omitempty"`
@@ -70,6 +70,8 @@ type ConfigResponse struct {
 	GoogleOAuth2HD           string          `json:"google_oauth2_hd"`
 	GroupApprovals           bool            `json:"group_approvals"`
 	JiraURL                  string          `json:"jira_url"`
+	OIDCIssuerURL            string          `json:"oidc_issuer_url,omitempty"`
+	OIDCClientID             string          `json:"oidc_client_id,omitempty"`
 	ShortLinkBaseURL         string          `json:"short_link_base_url"`
 	SimplifiedMode           bool            `json:"simplified_mode"`  // True when running in zero-config simplified mode
 	SkipGoogleAuth           bool            `json:"skip_google_auth"` // Deprecated: use auth_provider instead
@@ -124,7 +126,13 @@ func ConfigHandler(
 		authProvider := "google" // Default to Google
 		skipGoogleAuth := false  // Legacy compatibility
 
-		if cfg.Dex != nil && !cfg.Dex.Disabled {
+		if cfg.DevUser != "" {
+			authProvider = "dev"
+			skipGoogleAuth = true
+		} else if cfg.OIDC != nil && !cfg.OIDC.Disabled {
+			authProvider = "oidc"
+			skipGoogleAuth = true
+		} else if cfg.Dex != nil && !cfg.Dex.Disabled {
 			authProvider = "dex"
 			skipGoogleAuth = true
 		} else if cfg.Okta != nil && !cfg.Okta.Disabled {
@@ -170,6 +178,14 @@ func ConfigHandler(
 			dexRedirectURL = cfg.Dex.RedirectURL
 		}
 
+		// Prepare OIDC config (only if using OIDC auth)
+		oidcIssuerURL := ""
+		oidcClientID := ""
+		if authProvider == "oidc" {
+			oidcIssuerURL = cfg.OIDC.IssuerURL
+			oidcClientID = cfg.OIDC.ClientID
+		}
+
 		// Determine which workspace provider is configured
 		workspaceProvider := "google" // Default to Google
 		if cfg.LocalWorkspace != nil {
@@ -192,6 +208,8 @@ func ConfigHandler(
 			GoogleOAuth2HD:           googleOAuth2HD,
 			GroupApprovals:           groupApprovals,
 			JiraURL:                  jiraURL,
+			OIDCIssuerURL:            oidcIssuerURL,
+			OIDCClientID:             oidcClientID,
 			ShortLinkBaseURL:         shortLinkBaseURL,
 			SimplifiedMode:           cfg.SimplifiedMode,
 			SkipGoogleAuth:           skipGoogleAuth, // Legacy compatibility